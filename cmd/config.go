@@ -0,0 +1,53 @@
+/*
+Copyright © 2025 Juan David Cabrera Duran juandavid.juandis@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"orders-service/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+// configCmd groups the commands inspecting the configuration
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the service configuration",
+}
+
+// configValidateCmd represents the config validate command
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate a configuration file",
+	Long: `Load the configuration the server would use and report every invalid setting.
+
+The command exits with a non-zero status when the configuration is invalid,
+so CI can lint deployment configs before they are rolled out.
+
+Examples:
+  # Validate the production config
+  orders-service config validate --config configs/config.yaml --env production
+`,
+	SilenceUsage: true,
+	RunE:         runConfigValidate,
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(configFile, env)
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), "configuration is valid")
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright © 2025 Juan David Cabrera Duran juandavid.juandis@gmail.com
+*/
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"orders-service/internal/adapters/http/middlewares/apikey"
+	"orders-service/pkg/loadgen"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var loadgenConfig loadgen.Config
+
+var (
+	loadgenAPIKey  string
+	loadgenTimeout time.Duration
+)
+
+// loadgenCmd represents the loadgen command, hidden as it is a development tool
+var loadgenCmd = &cobra.Command{
+	Use:    "loadgen",
+	Short:  "Fire concurrent requests at a running instance and print latency percentiles",
+	Hidden: true,
+	Long: `Send requests to a running instance and report the throughput, the status codes and the
+latency percentiles, to compare an instance before and after a performance change.
+
+The create scenario creates an order per request, each for another customer counting up from
+--first-customer-id. The get scenario creates --seed orders first and reads them.
+
+Examples:
+  # 5000 order creations, 50 at a time
+  orders-service loadgen --url http://localhost:8080 --api-key $KEY --scenario create --requests 5000 --concurrency 50
+
+  # Reads of 100 orders of 20 items
+  orders-service loadgen --api-key $KEY --scenario get --seed 100 --items 20
+`,
+	SilenceUsage: true,
+	RunE:         runLoadgen,
+}
+
+func init() {
+	rootCmd.AddCommand(loadgenCmd)
+
+	flags := loadgenCmd.Flags()
+	flags.StringVar(&loadgenConfig.BaseURL, "url", "http://localhost:8080", "address of the instance")
+	flags.StringVar(&loadgenAPIKey, "api-key", "", "API key sent with every request")
+	flags.StringVar(&loadgenConfig.Scenario, "scenario", loadgen.ScenarioCreate, "create or get")
+	flags.IntVar(&loadgenConfig.Concurrency, "concurrency", 10, "requests in flight")
+	flags.IntVar(&loadgenConfig.Requests, "requests", 1000, "requests sent in total")
+	flags.IntVar(&loadgenConfig.Items, "items", 3, "items of the orders created")
+	flags.UintVar(&loadgenConfig.FirstCustomerID, "first-customer-id", 1_000_000, "customer of the first order created")
+	flags.IntVar(&loadgenConfig.Seed, "seed", 100, "orders created for the get scenario")
+	flags.DurationVar(&loadgenTimeout, "timeout", 10*time.Second, "timeout of a request")
+}
+
+func runLoadgen(cmd *cobra.Command, args []string) error {
+	if loadgenAPIKey != "" {
+		loadgenConfig.Header = http.Header{}
+		loadgenConfig.Header.Set(apikey.HeaderAPIKey, loadgenAPIKey)
+	}
+
+	// Interrupting stops sending and reports the requests sent so far
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client := &http.Client{
+		Timeout: loadgenTimeout,
+		Transport: &http.Transport{
+			MaxIdleConns:        loadgenConfig.Concurrency,
+			MaxIdleConnsPerHost: loadgenConfig.Concurrency,
+		},
+	}
+	report, err := loadgen.Run(ctx, client, loadgenConfig)
+	if err != nil {
+		return err
+	}
+	return report.Write(cmd.OutOrStdout())
+}
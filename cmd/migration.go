@@ -5,8 +5,6 @@ package cmd
 
 import (
 	"fmt"
-	"orders-service/internal/adapters/persistence/orders_repository"
-
 	"orders-service/internal/config"
 	"orders-service/internal/infrastructure"
 	"orders-service/pkg/logger"
@@ -50,6 +48,11 @@ func runMigration(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if err := cfg.Validate(); err != nil {
+		log.Fatal("Invalid configuration", "error", err)
+		return err
+	}
+
 	log.Info("Configuration loaded",
 		"env", cfg.Environment,
 		"database", cfg.Database.Database)
@@ -84,7 +87,7 @@ func runDatabaseMigrations(connections *infrastructure.DatabaseConnections, log
 	// Get the GORM database instance
 	db := connections.GetGormDB()
 
-	models := getAllModels()
+	models := infrastructure.Models()
 
 	log.Info("Running AutoMigrate", "models_count", len(models))
 
@@ -95,11 +98,3 @@ func runDatabaseMigrations(connections *infrastructure.DatabaseConnections, log
 	log.Info("All migrations completed successfully")
 	return nil
 }
-
-// getAllModels returns all database models that need migration
-func getAllModels() []interface{} {
-	return []interface{}{
-		&order_repository.OrderModel{},
-		&order_repository.OrderItemModel{},
-	}
-}
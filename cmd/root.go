@@ -19,6 +19,8 @@ var rootCmd = &cobra.Command{
 var (
 	configFile string
 	port       string
+	database   string
+	orderStore string
 	env        string
 )
 
@@ -20,10 +20,12 @@ import (
 
 // serverCmd represents the server command
 var serverCmd = &cobra.Command{
-	Use:   "server",
-	Short: "Start the HTTP server",
-	Long:  "Start the user service HTTP server with Echo framework",
-	RunE:  runServer,
+	Use:     "server",
+	Aliases: []string{"serve"},
+	Short:   "Start the HTTP server",
+	Long:    "Start the user service HTTP server with Echo framework",
+	Example: "  orders-service serve --db sqlite::memory:",
+	RunE:    runServer,
 }
 
 func init() {
@@ -31,6 +33,8 @@ func init() {
 
 	// Add server-specific flags
 	serverCmd.Flags().StringVarP(&port, "port", "p", "", "server port")
+	serverCmd.Flags().StringVar(&database, "db", "", "database as driver:dsn, such as sqlite::memory: or sqlite:orders.db")
+	serverCmd.Flags().StringVar(&orderStore, "order-store", "", "where orders are stored: gorm or mongo")
 }
 
 func runServer(cmd *cobra.Command, args []string) error {
@@ -58,6 +62,27 @@ func runServer(cmd *cobra.Command, args []string) error {
 		log.Info("Port overridden by command line flag", "port", port)
 	}
 
+	// Override the database if provided via flag
+	if cmd.Flags().Changed("db") {
+		if err := cfg.Database.SetURL(database); err != nil {
+			log.Fatal("Invalid database flag", "error", err)
+			return err
+		}
+		log.Info("Database overridden by command line flag", "driver", cfg.Database.Driver)
+	}
+
+	// Override the order store if provided via flag
+	if cmd.Flags().Changed("order-store") {
+		cfg.Database.OrderStore = orderStore
+		log.Info("Order store overridden by command line flag", "order_store", orderStore)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		log.Fatal("Invalid configuration", "error", err)
+		return err
+	}
+	applyLogLevels(log, cfg.Dynamic().Settings())
+
 	log.Info("Configuration loaded",
 		"env", cfg.Environment,
 		"port", cfg.Server.Port,
@@ -92,7 +117,22 @@ func runServer(cmd *cobra.Command, args []string) error {
 		}
 	}()
 
-	log.Info("Server started successfully", "port", cfg.Server.Port)
+	log.Info("Server started successfully", "port", cfg.Server.Port, "tls", cfg.Server.TLS.Enabled())
+
+	// Re-read the dynamic settings such as rate limits and the log level, and the TLS certificate, on SIGHUP
+	reloader := config.NewReloader(cfg, configFile, env, log)
+	reloader.OnReload(func(settings config.DynamicSettings) {
+		applyLogLevels(log, settings)
+	})
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	defer signal.Stop(reload)
+	go func() {
+		for range reload {
+			_ = reloader.Reload()
+			_ = server.ReloadCertificates()
+		}
+	}()
 
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
@@ -113,3 +153,13 @@ func runServer(cmd *cobra.Command, args []string) error {
 	log.Info("Server exited")
 	return nil
 }
+
+// applyLogLevels sets the global and per component log levels of log and the loggers derived from it
+func applyLogLevels(log logger.Logger, settings config.DynamicSettings) {
+	if err := logger.SetLevel(log, settings.LogLevel); err != nil {
+		log.Warn("Failed to apply log level", "error", err)
+	}
+	if err := logger.SetComponentLevels(log, settings.ComponentLogLevels); err != nil {
+		log.Warn("Failed to apply component log levels", "error", err)
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright © 2025 Juan David Cabrera Duran juandavid.juandis@gmail.com
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"orders-service/internal/adapters/messaging/kafka"
+	"orders-service/internal/adapters/workers"
+	"orders-service/internal/config"
+	"orders-service/internal/infrastructure"
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	once   bool
+	noLock bool
+)
+
+// workerCmd represents the worker command
+var workerCmd = &cobra.Command{
+	Use:   "worker",
+	Short: "Run the background jobs",
+	Long:  "Run the background jobs such as order expiry without the HTTP API, set workers.embedded to false on the API servers once this runs",
+	RunE:  runWorker,
+}
+
+func init() {
+	rootCmd.AddCommand(workerCmd)
+
+	workerCmd.Flags().BoolVar(&once, "once", false, "run a single pass of every job and exit")
+	workerCmd.Flags().BoolVar(&noLock, "no-lock", false, "run the jobs without taking the lock shared with other replicas, for local development")
+}
+
+func runWorker(cmd *cobra.Command, args []string) error {
+	// Initialize logging
+	log := logger.New(env)
+
+	defer func() {
+		if err := log.Sync(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to sync logging: %v\n", err)
+		}
+	}()
+
+	log.Info("Starting worker...")
+
+	// Load configuration
+	cfg, err := config.Load(configFile, env)
+	if err != nil {
+		log.Error("Failed to load configuration", "error", err)
+		return err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		log.Error("Invalid configuration", "error", err)
+		return err
+	}
+	applyLogLevels(log, cfg.Dynamic().Settings())
+
+	// Initialize database connections
+	connections, err := infrastructure.NewDatabaseConnections(cfg, log)
+	if err != nil {
+		log.Error("Failed to initialize database connections", "error", err)
+		return err
+	}
+
+	defer func() {
+		if err := connections.Close(); err != nil {
+			log.Error("Failed to close database connections", "error", err)
+		}
+	}()
+
+	services := infrastructure.NewServices(cfg, connections, log)
+	jobLock := services.JobLock
+	if noLock {
+		log.Warn("Jobs run without a lock, other replicas may make the same passes")
+		jobLock = nil
+	}
+	runner := workers.NewRunner(workers.NewJobs(cfg, services.Orders, services.OrderJobQueue, jobLock, log), log)
+	if runner.Len() == 0 && !cfg.Kafka.Enabled {
+		log.Warn("No background job is enabled")
+	}
+
+	// Cancel the jobs on interrupt, a pass in progress finishes its current batch
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var runErr error
+	if once {
+		if cfg.Kafka.Enabled {
+			log.Warn("The Kafka consumer does not run with --once")
+		}
+		runErr = runner.RunOnce(ctx)
+		if runErr != nil {
+			log.Error("Worker pass failed", "error", runErr)
+		}
+	} else {
+		var consumers sync.WaitGroup
+		if cfg.Kafka.Enabled {
+			consumer, closeConsumer, err := newKafkaConsumer(cfg, services, log)
+			if err != nil {
+				log.Error("Failed to start Kafka consumer", "error", err)
+				return err
+			}
+			defer closeConsumer()
+
+			// A consumer that cannot make progress stops the worker so it is restarted
+			consumers.Add(1)
+			go func() {
+				defer consumers.Done()
+				if err := consumer.Run(ctx); err != nil {
+					log.Error("Kafka consumer failed", "error", err)
+					runErr = err
+					stop()
+				}
+			}()
+		}
+
+		health := workers.NewHealthServer(net.JoinHostPort(cfg.Server.Host, cfg.Workers.HealthPort), log)
+		go func() {
+			if err := health.Start(); err != nil {
+				log.Error("Worker health server failed", "error", err)
+				stop()
+			}
+		}()
+
+		log.Info("Worker started", "jobs", runner.Len(), "kafka", cfg.Kafka.Enabled, "health_port", cfg.Workers.HealthPort)
+		runner.Run(ctx)
+		consumers.Wait()
+
+		log.Info("Shutting down worker...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Workers.ShutdownTimeout)
+		defer cancel()
+		if err := health.Shutdown(shutdownCtx); err != nil {
+			log.Error("Failed to stop worker health server", "error", err)
+		}
+	}
+
+	// Flush the audit log written by the jobs
+	flushCtx, cancel := context.WithTimeout(context.Background(), cfg.Workers.ShutdownTimeout)
+	defer cancel()
+	if err := services.Close(flushCtx); err != nil {
+		log.Error("Failed to flush audit log", "error", err)
+	}
+
+	log.Info("Worker exited")
+	return runErr
+}
+
+// newKafkaConsumer connects the consumer ingesting orders from cfg.Kafka.Topic
+func newKafkaConsumer(cfg *config.Config, services *infrastructure.Services, log logger.Logger) (*kafka.Consumer, func(), error) {
+	reader, deadLetters, err := kafka.NewClients(cfg.Kafka)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	consumer := kafka.NewConsumer(reader, deadLetters, services.Orders, kafka.ConsumerConfig{
+		DeadLetterTopic: cfg.Kafka.DeadLetterTopic,
+		MaxAttempts:     cfg.Kafka.MaxAttempts,
+		RetryBackoff:    cfg.Kafka.RetryBackoff,
+	}, metrics.Default, log)
+
+	closeClients := func() {
+		if err := reader.Close(); err != nil {
+			log.Error("Failed to close Kafka reader", "error", err)
+		}
+		if err := deadLetters.Close(); err != nil {
+			log.Error("Failed to close Kafka writer", "error", err)
+		}
+	}
+	return consumer, closeClients, nil
+}
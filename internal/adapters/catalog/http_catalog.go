@@ -0,0 +1,77 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"orders-service/internal/application/ports"
+	"orders-service/pkg/httpclient"
+)
+
+// pricesResponse is the body of GET /products/prices
+type pricesResponse struct {
+	Prices []struct {
+		ProductID uint    `json:"product_id"`
+		UnitPrice float64 `json:"unit_price"`
+	} `json:"prices"`
+}
+
+// HTTPProductCatalog reads product prices from the catalog service with
+// GET {base_url}/products/prices?ids=1,2,3
+type HTTPProductCatalog struct {
+	baseURL string
+	client  *httpclient.Client
+}
+
+// NewHTTPProductCatalog creates a catalog client sending its requests through client, which retries
+// them and stops calling the catalog while its circuit breaker is open
+func NewHTTPProductCatalog(baseURL string, client *httpclient.Client) ports.ProductCatalog {
+	return &HTTPProductCatalog{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  client,
+	}
+}
+
+// GetPrices implements ports.ProductCatalog
+func (c *HTTPProductCatalog) GetPrices(ctx context.Context, productIDs []uint) (map[uint]float64, error) {
+	prices := make(map[uint]float64, len(productIDs))
+	if len(productIDs) == 0 {
+		return prices, nil
+	}
+
+	ids := make([]string, len(productIDs))
+	for i, id := range productIDs {
+		ids[i] = strconv.FormatUint(uint64(id), 10)
+	}
+	query := url.Values{"ids": {strings.Join(ids, ",")}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/products/prices?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("build catalog request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request catalog prices: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("catalog prices: unexpected status %d", resp.StatusCode)
+	}
+
+	var body pricesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode catalog prices: %w", err)
+	}
+	for _, price := range body.Prices {
+		prices[price.ProductID] = price.UnitPrice
+	}
+	return prices, nil
+}
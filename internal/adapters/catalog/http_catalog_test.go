@@ -0,0 +1,90 @@
+package catalog
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"orders-service/pkg/httpclient"
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient builds a catalog client with fast retries, timeout bounds every attempt
+func newTestClient(timeout time.Duration) *httpclient.Client {
+	config := httpclient.DefaultConfig()
+	config.Timeout = timeout
+	config.BaseDelay = time.Millisecond
+	config.MaxDelay = time.Millisecond
+	return httpclient.New("catalog", config, metrics.NewRegistry(), logger.New("test"))
+}
+
+func TestHTTPProductCatalog_GetPrices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/products/prices", r.URL.Path)
+		assert.Equal(t, "1,2", r.URL.Query().Get("ids"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"prices":[{"product_id":1,"unit_price":9.99}]}`))
+	}))
+	defer server.Close()
+
+	catalog := NewHTTPProductCatalog(server.URL+"/", newTestClient(time.Second))
+
+	prices, err := catalog.GetPrices(context.Background(), []uint{1, 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[uint]float64{1: 9.99}, prices)
+}
+
+func TestHTTPProductCatalog_GetPrices_Unavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	catalog := NewHTTPProductCatalog(server.URL, newTestClient(time.Second))
+
+	prices, err := catalog.GetPrices(context.Background(), []uint{1})
+
+	assert.Error(t, err)
+	assert.Nil(t, prices)
+}
+
+func TestHTTPProductCatalog_GetPrices_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	catalog := NewHTTPProductCatalog(server.URL, newTestClient(20*time.Millisecond))
+
+	_, err := catalog.GetPrices(context.Background(), []uint{1})
+
+	assert.Error(t, err)
+}
+
+func TestHTTPProductCatalog_GetPrices_RetriesUnavailableCatalog(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"prices":[{"product_id":1,"unit_price":9.99}]}`))
+	}))
+	defer server.Close()
+
+	catalog := NewHTTPProductCatalog(server.URL, newTestClient(time.Second))
+
+	prices, err := catalog.GetPrices(context.Background(), []uint{1})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[uint]float64{1: 9.99}, prices)
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -0,0 +1,88 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+	"time"
+
+	"orders-service/internal/application/ports"
+)
+
+// templateFiles holds the layouts built into the binary, a layout is named after its file without extension
+//
+//go:embed templates/*.html.tmpl
+var templateFiles embed.FS
+
+var templates = template.Must(template.New("documents").Funcs(template.FuncMap{
+	"money": func(amount float64) string { return fmt.Sprintf("%.2f", amount) },
+	"date":  func(t time.Time) string { return t.UTC().Format("2006-01-02") },
+	"label": func(value any) string { return strings.ReplaceAll(fmt.Sprint(value), "_", " ") },
+	"lines": func(text string) []string { return strings.Split(strings.TrimSpace(text), "\n") },
+}).ParseFS(templateFiles, "templates/*.html.tmpl"))
+
+// Branding is the seller printed on the documents, empty fields are left out
+type Branding struct {
+	Name    string
+	Address string
+	Email   string
+	Phone   string
+	Website string
+	LogoURL string
+	Footer  string
+}
+
+// page is the data the layouts are executed with
+type page struct {
+	ports.OrderDocument
+	Company Branding
+	// Number is the order number, or the order ID for orders created before numbers were assigned
+	Number string
+}
+
+// HTMLRenderer renders order documents as HTML pages ready to print. It is the default
+// ports.DocumentRenderer and cannot produce PDF, see RenderHTML for renderers converting its pages.
+type HTMLRenderer struct {
+	template *template.Template
+	branding Branding
+}
+
+// NewHTMLRenderer creates a renderer using the built-in layout named layout, standard or compact
+func NewHTMLRenderer(layout string, branding Branding) (*HTMLRenderer, error) {
+	tmpl := templates.Lookup(layout + ".html.tmpl")
+	if tmpl == nil {
+		return nil, fmt.Errorf("unknown document template %q", layout)
+	}
+	return &HTMLRenderer{template: tmpl, branding: branding}, nil
+}
+
+// Render implements ports.DocumentRenderer
+func (r *HTMLRenderer) Render(_ context.Context, document ports.OrderDocument, format ports.DocumentFormat) (*ports.RenderedDocument, error) {
+	if format != ports.DocumentFormatHTML {
+		return nil, fmt.Errorf("%w: %s", ports.ErrDocumentFormatUnsupported, format)
+	}
+
+	content, err := r.RenderHTML(document)
+	if err != nil {
+		return nil, err
+	}
+	return &ports.RenderedDocument{ContentType: "text/html; charset=utf-8", Content: content}, nil
+}
+
+// RenderHTML returns the page of document
+func (r *HTMLRenderer) RenderHTML(document ports.OrderDocument) ([]byte, error) {
+	number := document.Order.OrderNumber
+	if number == "" {
+		number = strconv.FormatUint(uint64(document.Order.ID), 10)
+	}
+
+	var buf bytes.Buffer
+	if err := r.template.Execute(&buf, page{OrderDocument: document, Company: r.branding, Number: number}); err != nil {
+		return nil, fmt.Errorf("render order document: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,88 @@
+package documents
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDocument() ports.OrderDocument {
+	return ports.OrderDocument{
+		Order: &entities.Order{
+			ID:          7,
+			OrderNumber: "ORD-2025-000007",
+			CustomerID:  3,
+			Status:      entities.OrderStatusPartiallyShipped,
+			Items: []entities.OrderItem{
+				{ProductID: 1, ProductSKU: "MUG-01", ProductName: "Mug <large>", Quantity: 2, UnitPrice: 7.5, TotalPrice: 15},
+			},
+			TotalAmount:    15,
+			ShippingMethod: entities.ShippingMethodExpress,
+			CustomerEmail:  "jane@example.com",
+			CreatedAt:      time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+		},
+		GeneratedAt: time.Date(2025, 3, 2, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestHTMLRenderer_Render(t *testing.T) {
+	for _, layout := range []string{"standard", "compact"} {
+		t.Run(layout, func(t *testing.T) {
+			renderer, err := NewHTMLRenderer(layout, Branding{Name: "Acme", Address: "1 Main St\nSpringfield", Footer: "Thanks!"})
+			require.NoError(t, err)
+
+			document, err := renderer.Render(context.Background(), testDocument(), ports.DocumentFormatHTML)
+
+			require.NoError(t, err)
+			assert.Equal(t, "text/html; charset=utf-8", document.ContentType)
+			page := string(document.Content)
+			assert.Contains(t, page, "ORD-2025-000007")
+			assert.Contains(t, page, "Mug &lt;large&gt;", "order fields are escaped")
+			assert.Contains(t, page, "15.00")
+			assert.Contains(t, page, "partially shipped")
+			assert.Contains(t, page, "express")
+			assert.Contains(t, page, "Springfield")
+			assert.Contains(t, page, "Thanks!")
+		})
+	}
+}
+
+func TestHTMLRenderer_Render_HidePricesAndContact(t *testing.T) {
+	renderer, err := NewHTMLRenderer("standard", Branding{})
+	require.NoError(t, err)
+
+	document := testDocument()
+	document.HidePrices = true
+	rendered, err := renderer.Render(context.Background(), document, ports.DocumentFormatHTML)
+	require.NoError(t, err)
+	assert.NotContains(t, string(rendered.Content), "7.50")
+	assert.NotContains(t, string(rendered.Content), "15.00")
+	assert.NotContains(t, string(rendered.Content), "jane@example.com")
+
+	document.ShowContact = true
+	rendered, err = renderer.Render(context.Background(), document, ports.DocumentFormatHTML)
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered.Content), "jane@example.com")
+}
+
+func TestHTMLRenderer_Render_PDFUnsupported(t *testing.T) {
+	renderer, err := NewHTMLRenderer("standard", Branding{})
+	require.NoError(t, err)
+
+	document, err := renderer.Render(context.Background(), testDocument(), ports.DocumentFormatPDF)
+
+	assert.Nil(t, document)
+	assert.ErrorIs(t, err, ports.ErrDocumentFormatUnsupported)
+}
+
+func TestNewHTMLRenderer_UnknownLayout(t *testing.T) {
+	_, err := NewHTMLRenderer("fancy", Branding{})
+
+	assert.ErrorContains(t, err, `unknown document template "fancy"`)
+}
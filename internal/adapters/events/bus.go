@@ -0,0 +1,101 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"orders-service/internal/application/ports"
+	domainEvents "orders-service/internal/domain/events"
+	"orders-service/pkg/logger"
+)
+
+// Bus delivers published events to subscribers in the same process. With several replicas a
+// subscriber only sees the changes made through its own replica, such deployments need a broker
+// backed publisher instead.
+type Bus struct {
+	mu             sync.Mutex
+	subscriptions  map[*subscription]struct{}
+	maxSubscribers int
+	bufferSize     int
+	logger         logger.Logger
+}
+
+// NewBus creates a bus accepting up to maxSubscribers subscribers, 0 leaves them unlimited.
+// Each subscriber buffers bufferSize events, events for a subscriber that fell behind are dropped.
+func NewBus(maxSubscribers, bufferSize int, log logger.Logger) *Bus {
+	if bufferSize <= 0 {
+		bufferSize = 16
+	}
+
+	return &Bus{
+		subscriptions:  make(map[*subscription]struct{}),
+		maxSubscribers: maxSubscribers,
+		bufferSize:     bufferSize,
+		logger:         log.With("component", "event_bus"),
+	}
+}
+
+// Publish implements ports.EventPublisher, it never blocks on a slow subscriber
+func (b *Bus) Publish(_ context.Context, event domainEvents.OrderEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscriptions {
+		if !sub.filter(event) {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			b.logger.Warn("Dropped event for slow subscriber", "type", event.Type, "order_id", event.OrderID)
+		}
+	}
+	return nil
+}
+
+// Subscribe implements ports.EventSubscriber
+func (b *Bus) Subscribe(filter func(domainEvents.OrderEvent) bool) (ports.EventSubscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.maxSubscribers > 0 && len(b.subscriptions) >= b.maxSubscribers {
+		return nil, ports.ErrTooManySubscribers
+	}
+
+	sub := &subscription{
+		bus:    b,
+		filter: filter,
+		events: make(chan domainEvents.OrderEvent, b.bufferSize),
+	}
+	b.subscriptions[sub] = struct{}{}
+	return sub, nil
+}
+
+// Subscribers returns the number of open subscriptions
+func (b *Bus) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscriptions)
+}
+
+type subscription struct {
+	bus    *Bus
+	filter func(domainEvents.OrderEvent) bool
+	events chan domainEvents.OrderEvent
+	once   sync.Once
+}
+
+func (s *subscription) Events() <-chan domainEvents.OrderEvent {
+	return s.events
+}
+
+func (s *subscription) Close() {
+	s.once.Do(func() {
+		s.bus.mu.Lock()
+		defer s.bus.mu.Unlock()
+
+		delete(s.bus.subscriptions, s)
+		close(s.events)
+	})
+}
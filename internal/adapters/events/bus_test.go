@@ -0,0 +1,78 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"orders-service/internal/application/ports"
+	domainEvents "orders-service/internal/domain/events"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func orderEvent(orderID uint) domainEvents.OrderEvent {
+	return domainEvents.OrderEvent{Type: domainEvents.OrderStatusChanged, OrderID: orderID}
+}
+
+func TestBus_DeliversMatchingEvents(t *testing.T) {
+	// Given
+	bus := NewBus(0, 4, logger.New("test"))
+	subscription, err := bus.Subscribe(func(event domainEvents.OrderEvent) bool { return event.OrderID == 1 })
+	require.NoError(t, err)
+	defer subscription.Close()
+
+	// When
+	require.NoError(t, bus.Publish(context.Background(), orderEvent(2)))
+	require.NoError(t, bus.Publish(context.Background(), orderEvent(1)))
+
+	// Then
+	require.Len(t, subscription.Events(), 1)
+	assert.Equal(t, uint(1), (<-subscription.Events()).OrderID)
+}
+
+func TestBus_DropsEventsForSlowSubscribers(t *testing.T) {
+	bus := NewBus(0, 1, logger.New("test"))
+	subscription, err := bus.Subscribe(func(domainEvents.OrderEvent) bool { return true })
+	require.NoError(t, err)
+	defer subscription.Close()
+
+	require.NoError(t, bus.Publish(context.Background(), orderEvent(1)))
+	require.NoError(t, bus.Publish(context.Background(), orderEvent(2)))
+
+	require.Len(t, subscription.Events(), 1)
+	assert.Equal(t, uint(1), (<-subscription.Events()).OrderID)
+}
+
+func TestBus_LimitsSubscribers(t *testing.T) {
+	// Given
+	bus := NewBus(1, 1, logger.New("test"))
+	first, err := bus.Subscribe(func(domainEvents.OrderEvent) bool { return true })
+	require.NoError(t, err)
+
+	// When
+	_, err = bus.Subscribe(func(domainEvents.OrderEvent) bool { return true })
+
+	// Then
+	assert.ErrorIs(t, err, ports.ErrTooManySubscribers)
+
+	first.Close()
+	second, err := bus.Subscribe(func(domainEvents.OrderEvent) bool { return true })
+	require.NoError(t, err)
+	second.Close()
+}
+
+func TestBus_CloseStopsDelivery(t *testing.T) {
+	bus := NewBus(0, 1, logger.New("test"))
+	subscription, err := bus.Subscribe(func(domainEvents.OrderEvent) bool { return true })
+	require.NoError(t, err)
+
+	subscription.Close()
+	subscription.Close()
+	require.NoError(t, bus.Publish(context.Background(), orderEvent(1)))
+
+	_, open := <-subscription.Events()
+	assert.False(t, open)
+	assert.Zero(t, bus.Subscribers())
+}
@@ -0,0 +1,30 @@
+package events
+
+import (
+	"context"
+	"errors"
+
+	"orders-service/internal/application/ports"
+	domainEvents "orders-service/internal/domain/events"
+)
+
+// FanoutPublisher hands every event to each of its publishers
+type FanoutPublisher struct {
+	publishers []ports.EventPublisher
+}
+
+// NewFanoutPublisher creates a publisher delivering to all publishers in order
+func NewFanoutPublisher(publishers ...ports.EventPublisher) ports.EventPublisher {
+	return &FanoutPublisher{publishers: publishers}
+}
+
+// Publish implements ports.EventPublisher, a failing publisher does not keep the event from the others
+func (p *FanoutPublisher) Publish(ctx context.Context, event domainEvents.OrderEvent) error {
+	var errs []error
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,37 @@
+package events
+
+import (
+	"context"
+
+	appEvents "orders-service/internal/application/events"
+	"orders-service/internal/application/ports"
+	domainEvents "orders-service/internal/domain/events"
+	"orders-service/pkg/logger"
+)
+
+// LogPublisher writes order events to the service log, used until a message broker is configured
+type LogPublisher struct {
+	logger logger.Logger
+}
+
+// NewLogPublisher creates a publisher that logs every event
+func NewLogPublisher(log logger.Logger) ports.EventPublisher {
+	return &LogPublisher{
+		logger: log.With("component", "event_publisher"),
+	}
+}
+
+// Publish implements ports.EventPublisher
+func (p *LogPublisher) Publish(_ context.Context, event domainEvents.OrderEvent) error {
+	p.logger.Info("Order event published",
+		"type", event.Type,
+		"schema", appEvents.Schema(event.Type, appEvents.DefaultSchemaVersion),
+		"order_id", event.OrderID,
+		"order_public_id", event.OrderPublicID,
+		"customer_id", event.CustomerID,
+		"status", event.Status,
+		"items", len(event.Items),
+		"occurred_at", event.OccurredAt,
+	)
+	return nil
+}
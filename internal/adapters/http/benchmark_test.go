@@ -0,0 +1,125 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"orders-service/internal/adapters/http/handlers"
+	"orders-service/internal/adapters/http/middlewares/apikey"
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+// newBenchmarkServer is newLifecycleServer without rate limits, logging errors only so the request
+// logs do not drown the results
+func newBenchmarkServer(b *testing.B) (*Server, ports.OrderRepository) {
+	b.Helper()
+	server, repo := newLifecycleServer(b, 1<<30, handlers.DefaultOrderHandlerConfig())
+	if err := logger.SetLevel(server.logger, "error"); err != nil {
+		b.Fatal(err)
+	}
+	return server, repo
+}
+
+// serveBenchmarkRequest sends a request with the admin key of the lifecycle server
+func serveBenchmarkRequest(server *Server, method, path string, body []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(apikey.HeaderAPIKey, lifecycleAPIKey)
+	rec := httptest.NewRecorder()
+	server.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+// benchmarkOrderRequest returns the body of POST /orders for customerID with lines items
+func benchmarkOrderRequest(b *testing.B, customerID uint, lines int) []byte {
+	b.Helper()
+	request := dto.CreateOrderRequestDTO{CustomerID: customerID}
+	for i := 1; i <= lines; i++ {
+		request.Items = append(request.Items, dto.CreateOrderItemDTO{
+			ProductID:   uint(i),
+			ProductSKU:  fmt.Sprintf("SKU-%03d", i),
+			ProductName: fmt.Sprintf("Product %d", i),
+			Quantity:    2,
+			UnitPrice:   9.99,
+		})
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return body
+}
+
+// BenchmarkServer_CreateOrder measures POST /api/v1/orders through the routes, middleware, use cases and
+// the in-memory repository. Every request is for another customer, the pending order limit of a
+// customer would stop the loop otherwise.
+func BenchmarkServer_CreateOrder(b *testing.B) {
+	for _, lines := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("items=%d", lines), func(b *testing.B) {
+			server, _ := newBenchmarkServer(b)
+			bodies := make([][]byte, 1024)
+			for i := range bodies {
+				bodies[i] = benchmarkOrderRequest(b, uint(i+1), lines)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if i > 0 && i%len(bodies) == 0 {
+					// The customers used so far reached their pending order limit
+					b.StopTimer()
+					server, _ = newBenchmarkServer(b)
+					b.StartTimer()
+				}
+				if rec := serveBenchmarkRequest(server, http.MethodPost, "/api/v1/orders", bodies[i%len(bodies)]); rec.Code != http.StatusCreated {
+					b.Fatalf("status %d: %s", rec.Code, rec.Body.String())
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkServer_GetOrder measures GET /api/v1/orders/:id over 1000 seeded orders
+func BenchmarkServer_GetOrder(b *testing.B) {
+	for _, lines := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("items=%d", lines), func(b *testing.B) {
+			const seeded = 1000
+			server, repo := newBenchmarkServer(b)
+			paths := make([]string, 0, seeded)
+			for customerID := uint(1); customerID <= seeded; customerID++ {
+				order, err := entities.NewOrder(customerID)
+				if err != nil {
+					b.Fatal(err)
+				}
+				for i := 1; i <= lines; i++ {
+					if err := order.AddItem(uint(i), fmt.Sprintf("SKU-%03d", i), "Product", 2, 9.99); err != nil {
+						b.Fatal(err)
+					}
+				}
+				created, err := repo.Create(context.Background(), order)
+				if err != nil {
+					b.Fatal(err)
+				}
+				paths = append(paths, fmt.Sprintf("/api/v1/orders/%d", created.ID))
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if rec := serveBenchmarkRequest(server, http.MethodGet, paths[i%seeded], nil); rec.Code != http.StatusOK {
+					b.Fatalf("status %d: %s", rec.Code, rec.Body.String())
+				}
+			}
+		})
+	}
+}
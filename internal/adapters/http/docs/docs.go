@@ -0,0 +1,12 @@
+// Package docs embeds the hand-maintained OpenAPI document for the HTTP API.
+// Keep openapi.json in sync with the routes registered by the router package;
+// the server tests fail when a registered route is missing from the document.
+package docs
+
+import _ "embed"
+
+//go:embed openapi.json
+var OpenAPISpec []byte
+
+//go:embed swagger.html
+var SwaggerUI []byte
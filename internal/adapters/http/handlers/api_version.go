@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"orders-service/internal/application/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+// APIVersion is the major version of the API a request was routed to. Both versions share the
+// handlers, which read the version to shape pagination and list responses.
+type APIVersion int
+
+const (
+	// APIv1 lists full orders, numbers pages from 0 and answers lists with the DTO as is
+	APIv1 APIVersion = 1
+	// APIv2 lists order summaries unless ?expand=items, numbers pages from 1, wraps lists in a
+	// ListEnvelope and always answers errors as problem details
+	APIv2 APIVersion = 2
+)
+
+// errUnsupportedExpand rejects an expand parameter naming nothing a list can expand
+var errUnsupportedExpand = errors.New("unsupported expand value, supported values: items")
+
+// apiVersionKey holds the APIVersion of a request in the echo context
+const apiVersionKey = "api_version"
+
+// UseAPIVersion marks the requests of a route group with version, requests without it are APIv1
+func UseAPIVersion(version APIVersion) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(apiVersionKey, version)
+			return next(c)
+		}
+	}
+}
+
+func apiVersion(c echo.Context) APIVersion {
+	if version, ok := c.Get(apiVersionKey).(APIVersion); ok {
+		return version
+	}
+	return APIv1
+}
+
+// firstPage is the number of the first page in the version of the request
+func firstPage(c echo.Context) int {
+	if apiVersion(c) == APIv2 {
+		return 1
+	}
+	return 0
+}
+
+// ListEnvelope is the APIv2 shape of every list response
+type ListEnvelope struct {
+	Data  any       `json:"data"`
+	Meta  ListMeta  `json:"meta"`
+	Links ListLinks `json:"links"`
+}
+
+type ListMeta struct {
+	Pagination Pagination `json:"pagination"`
+}
+
+// Pagination describes the page of a list, Page counts from 1
+type Pagination struct {
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// ListLinks are the URLs of the neighbouring pages, null at either end of the list
+type ListLinks struct {
+	Next *string `json:"next"`
+	Prev *string `json:"prev"`
+}
+
+// envelopeTrailer is the part of a ListEnvelope written after the data of a streamed list
+type envelopeTrailer struct {
+	Meta  ListMeta  `json:"meta"`
+	Links ListLinks `json:"links"`
+}
+
+// listPage is the pagination of a list as the use cases return it, Page counts from 0
+type listPage struct {
+	Page       int
+	PageSize   int
+	Total      int64
+	TotalPages int
+}
+
+func orderListPage(list *dto.OrderListResponseDTO) listPage {
+	return listPage{Page: list.Page, PageSize: list.PageSize, Total: list.Total, TotalPages: list.TotalPages}
+}
+
+// newListEnvelope wraps data, the items of page, for an APIv2 response
+func newListEnvelope(c echo.Context, data any, page listPage) ListEnvelope {
+	envelope := ListEnvelope{
+		Data: data,
+		Meta: ListMeta{Pagination: Pagination{
+			Page:       page.Page + 1,
+			PageSize:   page.PageSize,
+			Total:      page.Total,
+			TotalPages: page.TotalPages,
+		}},
+	}
+	if page.Page+1 < page.TotalPages {
+		next := pageLink(c, page.Page+2)
+		envelope.Links.Next = &next
+	}
+	if page.Page > 0 {
+		prev := pageLink(c, page.Page)
+		envelope.Links.Prev = &prev
+	}
+	return envelope
+}
+
+// pageLink is the URL of the request with its page parameter set to page
+func pageLink(c echo.Context, page int) string {
+	u := *c.Request().URL
+	query := u.Query()
+	query.Set("page", strconv.Itoa(page))
+	u.RawQuery = query.Encode()
+	return u.RequestURI()
+}
+
+// writeList answers a list request, with body as is in APIv1 and with data wrapped in a ListEnvelope in APIv2
+func writeList(c echo.Context, body any, data any, page listPage) error {
+	if apiVersion(c) == APIv2 {
+		return writeJSON(c, http.StatusOK, newListEnvelope(c, data, page))
+	}
+	return writeJSON(c, http.StatusOK, body)
+}
+
+// orderListShape decides how the orders of a list are written
+type orderListShape struct {
+	// summaries lists OrderSummaryResponseDTOs instead of full orders
+	summaries bool
+}
+
+// parseOrderListShape reads the expand query parameter. APIv1 always lists full orders, APIv2 lists
+// summaries unless expand=items.
+func parseOrderListShape(c echo.Context) (orderListShape, error) {
+	if apiVersion(c) == APIv1 {
+		return orderListShape{}, nil
+	}
+
+	switch expand := c.QueryParam("expand"); expand {
+	case "":
+		return orderListShape{summaries: true}, nil
+	case "items":
+		return orderListShape{}, nil
+	default:
+		return orderListShape{}, errUnsupportedExpand
+	}
+}
+
+// order shapes a single order of the list
+func (s orderListShape) order(order *dto.OrderResponseDTO) any {
+	if s.summaries {
+		return dto.OrderResponseToSummaryDTO(order)
+	}
+	return order
+}
+
+// orders shapes the orders of a list
+func (s orderListShape) orders(orders []*dto.OrderResponseDTO) any {
+	if !s.summaries {
+		return orders
+	}
+	summaries := make([]*dto.OrderSummaryResponseDTO, 0, len(orders))
+	for _, order := range orders {
+		summaries = append(summaries, dto.OrderResponseToSummaryDTO(order))
+	}
+	return summaries
+}
+
+// writeOrderList answers a request for a list of orders in the version and shape of the request
+func writeOrderList(c echo.Context, list *dto.OrderListResponseDTO, shape orderListShape) error {
+	return writeList(c, list, shape.orders(list.Orders), orderListPage(list))
+}
+
+// invalidExpandResponse answers a request with an unsupported expand parameter
+func invalidExpandResponse(c echo.Context) error {
+	return WriteError(c, http.StatusBadRequest, ErrorResponse{
+		Error:   "INVALID_REQUEST",
+		Message: errUnsupportedExpand.Error(),
+	})
+}
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/domain/entities"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupVersionedRoutes mounts the list routes under both API versions the way the server does
+func setupVersionedRoutes(handler *OrderHandler) *echo.Echo {
+	e := echo.New()
+	routes := func(g *echo.Group) {
+		g.GET("/orders", handler.ListOrders)
+		g.GET("/customers/:customer_id/orders", handler.GetCustomerOrders)
+		g.GET("/orders/status/:status", handler.GetOrdersByStatus)
+		g.GET("/orders/:id", handler.GetOrder)
+	}
+	routes(e.Group("/api/v1"))
+	v2 := e.Group("/api/v2", UseAPIVersion(APIv2), ForceProblemDetails())
+	v2.RouteNotFound("/*", RouteNotFound)
+	routes(v2)
+	return e
+}
+
+func versionTestList(page int) *dto.OrderListResponseDTO {
+	return &dto.OrderListResponseDTO{
+		Orders: []*dto.OrderResponseDTO{{
+			ID:          1,
+			CustomerID:  123,
+			Items:       []dto.OrderItemResponseDTO{{ID: 1, ProductID: 7, Quantity: 2, UnitPrice: 5, TotalPrice: 10}},
+			ItemCount:   1,
+			TotalAmount: 10,
+			Status:      entities.OrderStatusPending,
+		}},
+		Total:      25,
+		Page:       page,
+		PageSize:   10,
+		TotalPages: 3,
+	}
+}
+
+func serveVersioned(e *echo.Echo, target string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+func TestAPIVersions_OrderLists(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    string
+		mockSetup func(m *MockOrderUseCases, list *dto.OrderListResponseDTO)
+	}{
+		{"list orders", "/orders", func(m *MockOrderUseCases, list *dto.OrderListResponseDTO) {
+			m.On("ListOrders", mock.Anything, list.Page, 10).Return(list, nil)
+		}},
+		{"customer orders", "/customers/123/orders", func(m *MockOrderUseCases, list *dto.OrderListResponseDTO) {
+			m.On("GetCustomerOrders", mock.Anything, uint(123), list.Page, 10).Return(list, nil)
+		}},
+		{"orders by status", "/orders/status/pending", func(m *MockOrderUseCases, list *dto.OrderListResponseDTO) {
+			m.On("GetOrdersByStatus", mock.Anything, entities.OrderStatusPending, list.Page, 10).Return(list, nil)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/v1 lists full orders from page 0", func(t *testing.T) {
+			handler, mockUseCases := setupTestOrderHandler()
+			list := versionTestList(1)
+			tt.mockSetup(mockUseCases, list)
+
+			rec := serveVersioned(setupVersionedRoutes(handler), "/api/v1"+tt.target+"?page=1")
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			expected, err := json.Marshal(list)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), rec.Body.String())
+			mockUseCases.AssertExpectations(t)
+		})
+
+		t.Run(tt.name+"/v2 lists summaries from page 1", func(t *testing.T) {
+			handler, mockUseCases := setupTestOrderHandler()
+			list := versionTestList(1)
+			tt.mockSetup(mockUseCases, list)
+
+			rec := serveVersioned(setupVersionedRoutes(handler), "/api/v2"+tt.target+"?page=2")
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			var body map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.ElementsMatch(t, []string{"data", "meta", "links"}, keys(body))
+
+			var data []map[string]any
+			require.NoError(t, json.Unmarshal(body["data"], &data))
+			require.Len(t, data, 1)
+			assert.NotContains(t, data[0], "items")
+			assert.Equal(t, float64(1), data[0]["item_count"])
+
+			assert.JSONEq(t, `{"pagination":{"page":2,"page_size":10,"total":25,"total_pages":3}}`, string(body["meta"]))
+			assert.JSONEq(t, `{"next":"/api/v2`+tt.target+`?page=3","prev":"/api/v2`+tt.target+`?page=1"}`, string(body["links"]))
+			mockUseCases.AssertExpectations(t)
+		})
+
+		t.Run(tt.name+"/v2 expands items", func(t *testing.T) {
+			handler, mockUseCases := setupTestOrderHandler()
+			list := versionTestList(0)
+			tt.mockSetup(mockUseCases, list)
+
+			rec := serveVersioned(setupVersionedRoutes(handler), "/api/v2"+tt.target+"?expand=items")
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			var envelope struct {
+				Data  []dto.OrderResponseDTO `json:"data"`
+				Meta  ListMeta               `json:"meta"`
+				Links ListLinks              `json:"links"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+			require.Len(t, envelope.Data, 1)
+			assert.Len(t, envelope.Data[0].Items, 1)
+			assert.Equal(t, 1, envelope.Meta.Pagination.Page)
+			assert.Nil(t, envelope.Links.Prev)
+			require.NotNil(t, envelope.Links.Next)
+			assert.Equal(t, "/api/v2"+tt.target+"?expand=items&page=2", *envelope.Links.Next)
+		})
+
+		t.Run(tt.name+"/v2 rejects page 0 as problem details", func(t *testing.T) {
+			handler, mockUseCases := setupTestOrderHandler()
+
+			rec := serveVersioned(setupVersionedRoutes(handler), "/api/v2"+tt.target+"?page=0")
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+			var problem ProblemDetails
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, "INVALID_PAGINATION", problem.Extensions.Code)
+			assert.Equal(t, "must be an integer between 1 and 1000001", problem.Extensions.Details["page"])
+			mockUseCases.AssertExpectations(t)
+		})
+
+		t.Run(tt.name+"/v2 rejects unknown expand", func(t *testing.T) {
+			handler, mockUseCases := setupTestOrderHandler()
+
+			rec := serveVersioned(setupVersionedRoutes(handler), "/api/v2"+tt.target+"?expand=customer")
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			var problem ProblemDetails
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, "INVALID_REQUEST", problem.Extensions.Code)
+			mockUseCases.AssertExpectations(t)
+		})
+
+		t.Run(tt.name+"/v1 ignores expand", func(t *testing.T) {
+			handler, mockUseCases := setupTestOrderHandler()
+			list := versionTestList(0)
+			tt.mockSetup(mockUseCases, list)
+
+			rec := serveVersioned(setupVersionedRoutes(handler), "/api/v1"+tt.target+"?expand=customer")
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			mockUseCases.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIVersions_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		expectCode  int
+		expectType  string
+		expectError string
+	}{
+		{"v1 invalid page", "/api/v1/orders?page=-1", http.StatusBadRequest, echo.MIMEApplicationJSON, "INVALID_PAGINATION"},
+		{"v2 invalid page", "/api/v2/orders?page=-1", http.StatusBadRequest, MIMEApplicationProblemJSON, "INVALID_PAGINATION"},
+		{"v1 invalid id", "/api/v1/orders/abc", http.StatusBadRequest, echo.MIMEApplicationJSON, "INVALID_ID"},
+		{"v2 invalid id", "/api/v2/orders/abc", http.StatusBadRequest, MIMEApplicationProblemJSON, "INVALID_ID"},
+		{"v2 unknown route", "/api/v2/unknown", http.StatusNotFound, MIMEApplicationProblemJSON, "ROUTE_NOT_FOUND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := setupTestOrderHandler()
+
+			rec := serveVersioned(setupVersionedRoutes(handler), tt.target)
+
+			assert.Equal(t, tt.expectCode, rec.Code)
+			assert.Contains(t, rec.Header().Get(echo.HeaderContentType), tt.expectType)
+			assert.Contains(t, rec.Body.String(), tt.expectError)
+		})
+	}
+}
+
+func TestAPIVersions_SingleOrderIsUnchanged(t *testing.T) {
+	order := &dto.OrderResponseDTO{ID: 1, CustomerID: 123, Items: []dto.OrderItemResponseDTO{}, Status: entities.OrderStatusPending}
+
+	var bodies []string
+	for _, prefix := range []string{"/api/v1", "/api/v2"} {
+		handler, mockUseCases := setupTestOrderHandler()
+		mockUseCases.On("GetOrder", mock.Anything, uint(1)).Return(order, nil)
+
+		rec := serveVersioned(setupVersionedRoutes(handler), prefix+"/orders/1")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		bodies = append(bodies, rec.Body.String())
+	}
+	assert.JSONEq(t, bodies[0], bodies[1])
+}
+
+func TestOrderHandler_ListOrders_StreamV2(t *testing.T) {
+	handler, mockUseCases := setupTestOrderHandler()
+	list := versionTestList(0)
+	mockUseCases.On("StreamOrders", mock.Anything, (*time.Time)(nil), (*time.Time)(nil), 0, 10).Return(list, nil)
+
+	rec := serveVersioned(setupVersionedRoutes(handler), "/api/v2/orders?stream=true")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"data":[{"id":1,"customer_id":123,"item_count":1,"total_amount":10,"status":"pending","created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}],"meta":{"pagination":{"page":1,"page_size":10,"total":25,"total_pages":3}},"links":{"next":"/api/v2/orders?page=2&stream=true","prev":null}}`, rec.Body.String())
+}
+
+func keys(m map[string]json.RawMessage) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	return names
+}
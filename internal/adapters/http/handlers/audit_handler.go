@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"orders-service/internal/application/usecases"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AuditHandler struct {
+	auditUseCases usecases.AuditUseCases
+	orders        OrderIDResolver
+	logger        logger.Logger
+}
+
+// NewAuditHandler creates the handler, orders resolves order UUIDs in the path to numeric IDs
+func NewAuditHandler(auditUseCases usecases.AuditUseCases, orders OrderIDResolver, log logger.Logger) *AuditHandler {
+	return &AuditHandler{
+		auditUseCases: auditUseCases,
+		orders:        orders,
+		logger:        log.With("component", "audit_handler"),
+	}
+}
+
+// GetOrderAuditLog handles GET /api/v1/orders/:id/audit
+func (h *AuditHandler) GetOrderAuditLog(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orders)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	page, pageSize, err := parsePaginationParams(c)
+	if err != nil {
+		return invalidPaginationResponse(c, h.logger, requestID, err)
+	}
+
+	h.logger.Info("Get order audit log request received",
+		"request_id", requestID,
+		"order_id", orderID,
+		"page", page,
+		"page_size", pageSize)
+
+	response, err := h.auditUseCases.GetOrderAuditLog(c.Request().Context(), orderID, page, pageSize)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to get order audit log")
+	}
+
+	h.logger.Info("Order audit log retrieved successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"count", len(response.Entries))
+
+	return writeList(c, response, response.Entries, listPage{
+		Page:       response.Page,
+		PageSize:   response.PageSize,
+		Total:      response.Total,
+		TotalPages: response.TotalPages,
+	})
+}
+
+func (h *AuditHandler) handleError(c echo.Context, err error, requestID, logMessage string) error {
+	h.logger.Error(logMessage,
+		"request_id", requestID,
+		"error", err)
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return WriteError(c, http.StatusGatewayTimeout, ErrorResponse{
+			Error:   "GATEWAY_TIMEOUT",
+			Message: "The request timed out",
+		})
+	}
+
+	var domainErr *domainErrors.DomainError
+	if errors.As(err, &domainErr) {
+		return WriteError(c, domainErrors.HTTPStatus(domainErr.Code), ErrorResponse{
+			Error:   domainErr.Code,
+			Message: domainErr.Message,
+			Details: domainErr.Details,
+		})
+	}
+	return WriteError(c, http.StatusInternalServerError, ErrorResponse{
+		Error:   "INTERNAL_ERROR",
+		Message: "An internal error occurred",
+	})
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAuditUseCases implements the AuditUseCases interface for testing
+type MockAuditUseCases struct {
+	mock.Mock
+}
+
+func (m *MockAuditUseCases) GetOrderAuditLog(ctx context.Context, orderID uint, page, pageSize int) (*dto.AuditLogResponseDTO, error) {
+	args := m.Called(ctx, orderID, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AuditLogResponseDTO), args.Error(1)
+}
+
+func setupTestAuditHandler() (*AuditHandler, *MockAuditUseCases) {
+	mockUseCases := new(MockAuditUseCases)
+	return NewAuditHandler(mockUseCases, nil, logger.New("test")), mockUseCases
+}
+
+func TestAuditHandler_GetOrderAuditLog_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestAuditHandler()
+
+	expectedResponse := &dto.AuditLogResponseDTO{
+		Entries: []*dto.AuditEntryResponseDTO{
+			{ID: 1, OrderID: 1, Actor: "backoffice", Action: entities.AuditActionOrderCreated, Before: json.RawMessage("null"), After: json.RawMessage(`{"id":1}`)},
+		},
+		Total:    1,
+		Page:     2,
+		PageSize: 5,
+	}
+	mockUseCases.On("GetOrderAuditLog", mock.Anything, uint(1), 2, 5).Return(expectedResponse, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1/audit?page=2&page_size=5", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.GetOrderAuditLog(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.AuditLogResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.Entries, 1)
+	assert.Equal(t, entities.AuditActionOrderCreated, response.Entries[0].Action)
+	assert.JSONEq(t, `{"id":1}`, string(response.Entries[0].After))
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestAuditHandler_GetOrderAuditLog_InvalidID(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestAuditHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/abc/audit", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+
+	// Execute
+	err := handler.GetOrderAuditLog(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUseCases.AssertNotCalled(t, "GetOrderAuditLog", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuditHandler_GetOrderAuditLog_Failure(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestAuditHandler()
+
+	mockUseCases.On("GetOrderAuditLog", mock.Anything, uint(1), 0, 10).Return(nil, domainErrors.ErrFailedToGetAuditLog)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1/audit", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.GetOrderAuditLog(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "FAILED_TO_GET_AUDIT_LOG", response.Error)
+}
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"orders-service/internal/application/auth"
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/application/usecases"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// customerOrderRepository serves a single order of customer 1 and accepts every update
+type customerOrderRepository struct {
+	ports.OrderRepository
+	order *entities.Order
+}
+
+func (r *customerOrderRepository) GetByID(ctx context.Context, id uint) (*entities.Order, error) {
+	if id != r.order.ID {
+		return nil, domainErrors.ErrOrderNotFound
+	}
+	return r.order.Clone(), nil
+}
+
+func (r *customerOrderRepository) GetByIDForUpdate(ctx context.Context, id uint) (*entities.Order, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *customerOrderRepository) Update(ctx context.Context, order *entities.Order) (*entities.Order, error) {
+	return order.Clone(), nil
+}
+
+func (r *customerOrderRepository) ListByFilter(ctx context.Context, filter ports.OrderFilter, limit, offset int) ([]*entities.Order, int64, error) {
+	if filter.CustomerID != 0 && filter.CustomerID != r.order.CustomerID {
+		return []*entities.Order{}, 0, nil
+	}
+	return []*entities.Order{r.order.Clone()}, 1, nil
+}
+
+func (r *customerOrderRepository) Count(ctx context.Context) (int64, error) {
+	return 1, nil
+}
+
+func (r *customerOrderRepository) CountByCustomerID(ctx context.Context, customerID uint) (int64, error) {
+	if customerID != r.order.CustomerID {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+func setupAuthorizationHandler(t *testing.T) *OrderHandler {
+	t.Helper()
+	order, err := entities.NewOrder(1)
+	require.NoError(t, err)
+	order.ID = 10
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 1, 10))
+
+	useCases := usecases.NewOrderUseCases(&customerOrderRepository{order: order}, logger.New("test"))
+	return NewOrderHandler(useCases, logger.New("test"))
+}
+
+func newPrincipalContext(method, path string, principal *auth.Principal) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, path, nil)
+	req = req.WithContext(auth.WithPrincipal(req.Context(), principal))
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("10")
+	return c, rec
+}
+
+func TestOrderHandler_CrossCustomerAccess(t *testing.T) {
+	writer := []auth.Scope{auth.ScopeOrdersRead, auth.ScopeOrdersWrite}
+
+	tests := []struct {
+		name         string
+		principal    *auth.Principal
+		expectStatus int
+	}{
+		{"owner", &auth.Principal{Name: "gateway", Scopes: writer, CustomerID: 1}, http.StatusOK},
+		{"other customer", &auth.Principal{Name: "gateway", Scopes: writer, CustomerID: 2}, http.StatusNotFound},
+		{"unbound key", &auth.Principal{Name: "backoffice", Scopes: writer}, http.StatusOK},
+		{"admin bound to other customer", &auth.Principal{Name: "support", Scopes: []auth.Scope{auth.ScopeOrdersAdmin}, CustomerID: 2}, http.StatusOK},
+	}
+
+	actions := map[string]func(h *OrderHandler, c echo.Context) error{
+		"get":    (*OrderHandler).GetOrder,
+		"cancel": (*OrderHandler).CancelOrder,
+	}
+
+	for _, tt := range tests {
+		for action, handle := range actions {
+			t.Run(tt.name+"/"+action, func(t *testing.T) {
+				handler := setupAuthorizationHandler(t)
+				c, rec := newPrincipalContext(http.MethodGet, "/api/v1/orders/10", tt.principal)
+
+				require.NoError(t, handle(handler, c))
+				assert.Equal(t, tt.expectStatus, rec.Code)
+
+				if tt.expectStatus == http.StatusNotFound {
+					var response ErrorResponse
+					require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+					assert.Equal(t, "ORDER_NOT_FOUND", response.Error)
+				} else {
+					var response dto.OrderResponseDTO
+					require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+					assert.Equal(t, uint(10), response.ID)
+				}
+			})
+		}
+	}
+}
+
+func TestOrderHandler_CrossCustomerMatchesUnknownOrder(t *testing.T) {
+	handler := setupAuthorizationHandler(t)
+	principal := &auth.Principal{Name: "gateway", Scopes: []auth.Scope{auth.ScopeOrdersRead}, CustomerID: 2}
+
+	foreign, foreignRec := newPrincipalContext(http.MethodGet, "/api/v1/orders/10", principal)
+	require.NoError(t, handler.GetOrder(foreign))
+
+	missing, missingRec := newPrincipalContext(http.MethodGet, "/api/v1/orders/11", principal)
+	missing.SetParamValues("11")
+	require.NoError(t, handler.GetOrder(missing))
+
+	// Another customer's order must be indistinguishable from one that does not exist
+	assert.Equal(t, missingRec.Code, foreignRec.Code)
+	assert.JSONEq(t, missingRec.Body.String(), foreignRec.Body.String())
+}
+
+func TestOrderHandler_CrossCustomerLists(t *testing.T) {
+	reader := []auth.Scope{auth.ScopeOrdersRead}
+
+	tests := []struct {
+		name         string
+		principal    *auth.Principal
+		expectOrders int64
+	}{
+		{"owner", &auth.Principal{Name: "gateway", Scopes: reader, CustomerID: 1}, 1},
+		{"other customer", &auth.Principal{Name: "gateway", Scopes: reader, CustomerID: 2}, 0},
+		{"unbound key", &auth.Principal{Name: "backoffice", Scopes: reader}, 1},
+		{"admin bound to other customer", &auth.Principal{Name: "support", Scopes: []auth.Scope{auth.ScopeOrdersAdmin}, CustomerID: 2}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/list", func(t *testing.T) {
+			handler := setupAuthorizationHandler(t)
+			c, rec := newPrincipalContext(http.MethodGet, "/api/v1/orders", tt.principal)
+
+			require.NoError(t, handler.ListOrders(c))
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var response dto.OrderListResponseDTO
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectOrders, response.Total)
+			assert.Len(t, response.Orders, int(tt.expectOrders))
+		})
+
+		t.Run(tt.name+"/count", func(t *testing.T) {
+			handler := setupAuthorizationHandler(t)
+			c, rec := newPrincipalContext(http.MethodGet, "/api/v1/orders/count", tt.principal)
+
+			require.NoError(t, handler.CountOrders(c))
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var response dto.OrderCountResponseDTO
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectOrders, response.Count)
+		})
+	}
+}
+
+func TestOrderHandler_CreateOrderForOtherCustomerIsForbidden(t *testing.T) {
+	handler := setupAuthorizationHandler(t)
+	principal := &auth.Principal{Name: "gateway", Scopes: []auth.Scope{auth.ScopeOrdersWrite}, CustomerID: 2}
+
+	body := `{"customer_id":1,"items":[{"product_id":1,"product_sku":"SKU-001","product_name":"Product 1","quantity":1,"unit_price":10}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req = req.WithContext(auth.WithPrincipal(req.Context(), principal))
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, handler.CreateOrder(echo.New().NewContext(req, rec)))
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "CUSTOMER_NOT_ALLOWED", response.Error)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// UnknownFieldError reports a JSON body key the target DTO does not declare
+type UnknownFieldError struct {
+	Field string
+}
+
+func (e *UnknownFieldError) Error() string {
+	return "unknown field " + strconv.Quote(e.Field)
+}
+
+// Binder binds requests like echo.DefaultBinder. With Strict set, JSON bodies are decoded with
+// unknown fields disallowed so a misspelled key fails with *UnknownFieldError instead of being ignored.
+type Binder struct {
+	echo.DefaultBinder
+	Strict bool
+}
+
+// Bind implements echo.Binder
+func (b *Binder) Bind(i interface{}, c echo.Context) error {
+	req := c.Request()
+	if !b.Strict || !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return b.DefaultBinder.Bind(i, c)
+	}
+
+	if err := b.BindPathParams(c, i); err != nil {
+		return err
+	}
+	if req.Method == http.MethodGet || req.Method == http.MethodDelete || req.Method == http.MethodHead {
+		if err := b.BindQueryParams(c, i); err != nil {
+			return err
+		}
+	}
+	if req.ContentLength == 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	decoder.DisallowUnknownFields()
+	return unknownFieldError(decoder.Decode(i))
+}
+
+// unknownFieldError converts the error of a decoder disallowing unknown fields into *UnknownFieldError
+// when it names an unknown key, other errors are returned unchanged
+func unknownFieldError(err error) error {
+	if err == nil {
+		return nil
+	}
+	// encoding/json has no typed error for unknown fields, only the message names the key
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		if unquoted, unquoteErr := strconv.Unquote(field); unquoteErr == nil {
+			field = unquoted
+		}
+		return &UnknownFieldError{Field: field}
+	}
+	return err
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"orders-service/internal/application/dto"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bindJSON(binder *Binder, body string, target interface{}) error {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+	return binder.Bind(target, c)
+}
+
+func TestBinder_UnknownFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		target func() interface{}
+		valid  string
+		typo   string
+		field  string
+	}{
+		{
+			name:   "create order",
+			target: func() interface{} { return &dto.CreateOrderRequestDTO{} },
+			valid:  `{"customer_id": 123, "items": [{"product_id": 1, "product_name": "Widget", "quantity": 2, "unit_price": 9.99}]}`,
+			typo:   `{"customer_id": 123, "items": [{"product_id": 1, "product_name": "Widget", "quantity": 2, "unitprice": 9.99}]}`,
+			field:  "unitprice",
+		},
+		{
+			name:   "add order item",
+			target: func() interface{} { return &dto.AddOrderItemRequestDTO{} },
+			valid:  `{"product_id": 1, "product_name": "Widget", "quantity": 2, "unit_price": 9.99}`,
+			typo:   `{"product_id": 1, "productname": "Widget", "quantity": 2, "unit_price": 9.99}`,
+			field:  "productname",
+		},
+		{
+			name:   "update item quantity",
+			target: func() interface{} { return &dto.UpdateOrderItemQuantityRequestDTO{} },
+			valid:  `{"quantity": 5}`,
+			typo:   `{"qty": 5}`,
+			field:  "qty",
+		},
+		{
+			name:   "update order status",
+			target: func() interface{} { return &dto.UpdateOrderStatusRequestDTO{} },
+			valid:  `{"status": "shipped"}`,
+			typo:   `{"status": "shipped", "reson": "carrier pickup"}`,
+			field:  "reson",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strict := &Binder{Strict: true}
+			lenient := &Binder{}
+
+			// Known fields bind in both modes
+			require.NoError(t, bindJSON(strict, tt.valid, tt.target()))
+			require.NoError(t, bindJSON(lenient, tt.valid, tt.target()))
+
+			// An unknown field is named in strict mode
+			err := bindJSON(strict, tt.typo, tt.target())
+			var unknownFieldErr *UnknownFieldError
+			require.ErrorAs(t, err, &unknownFieldErr)
+			assert.Equal(t, tt.field, unknownFieldErr.Field)
+
+			// and ignored in lenient mode
+			assert.NoError(t, bindJSON(lenient, tt.typo, tt.target()))
+		})
+	}
+}
+
+func TestBinder_StrictBindsValues(t *testing.T) {
+	// Given
+	var request dto.UpdateOrderItemQuantityRequestDTO
+
+	// When
+	err := bindJSON(&Binder{Strict: true}, `{"quantity": 5}`, &request)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 5, request.Quantity)
+}
+
+func TestBinder_StrictKeepsSyntaxErrors(t *testing.T) {
+	// When
+	err := bindJSON(&Binder{Strict: true}, `{"quantity": `, &dto.UpdateOrderItemQuantityRequestDTO{})
+
+	// Then
+	var unknownFieldErr *UnknownFieldError
+	assert.Error(t, err)
+	assert.NotErrorAs(t, err, &unknownFieldErr)
+}
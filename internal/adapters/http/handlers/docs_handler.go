@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"orders-service/internal/adapters/http/docs"
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DocsHandler struct {
+	logger logger.Logger
+}
+
+func NewDocsHandler(logger logger.Logger) *DocsHandler {
+	return &DocsHandler{
+		logger: logger.With("component", "docs_handler"),
+	}
+}
+
+// OpenAPI handles GET /api/v1/openapi.json
+func (h *DocsHandler) OpenAPI(c echo.Context) error {
+	h.logger.Debug("OpenAPI document requested",
+		"request_id", RequestID(c),
+		"remote_ip", c.RealIP())
+
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, docs.OpenAPISpec)
+}
+
+// SwaggerUI handles GET /api/v1/docs
+func (h *DocsHandler) SwaggerUI(c echo.Context) error {
+	// The UI loads its assets from a CDN, relax the default CSP for this page only
+	c.Response().Header().Set("Content-Security-Policy",
+		"default-src 'self'; script-src 'self' 'unsafe-inline' https://unpkg.com; style-src 'self' 'unsafe-inline' https://unpkg.com; img-src 'self' data:")
+
+	return c.HTMLBlob(http.StatusOK, docs.SwaggerUI)
+}
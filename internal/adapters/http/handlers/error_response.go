@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MIMEApplicationProblemJSON is the media type of RFC 7807 problem details
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// problemDetailsKey marks a request whose errors are always written as problem details
+const problemDetailsKey = "problem_details"
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string                 `json:"error"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// ProblemDetails is the RFC 7807 form of an ErrorResponse
+type ProblemDetails struct {
+	Type       string            `json:"type"`
+	Title      string            `json:"title"`
+	Status     int               `json:"status"`
+	Detail     string            `json:"detail"`
+	Instance   string            `json:"instance"`
+	Extensions ProblemExtensions `json:"extensions"`
+}
+
+// ProblemExtensions carries the service specific members of a problem
+type ProblemExtensions struct {
+	Code        string                 `json:"code"`
+	FieldErrors map[string]interface{} `json:"field_errors,omitempty"`
+	Details     map[string]interface{} `json:"details,omitempty"`
+}
+
+// ForceProblemDetails makes WriteError answer with problem details whatever the client accepts
+func ForceProblemDetails() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(problemDetailsKey, true)
+			return next(c)
+		}
+	}
+}
+
+// WriteError answers with response and status, as application/problem+json when the client asks
+// for it or ForceProblemDetails is in use, otherwise as an ErrorResponse. Validation details become
+// field_errors of the problem, the details of any other error are kept under details.
+func WriteError(c echo.Context, status int, response ErrorResponse) error {
+	if !wantsProblemDetails(c) {
+		return c.JSON(status, response)
+	}
+
+	problem := ProblemDetails{
+		Type:     "urn:orders-service:problem:" + strings.ToLower(response.Error),
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   response.Message,
+		Instance: c.Request().URL.Path,
+		Extensions: ProblemExtensions{
+			Code: response.Error,
+		},
+	}
+	if response.Error == "VALIDATION_ERROR" {
+		problem.Extensions.FieldErrors = response.Details
+	} else {
+		problem.Extensions.Details = response.Details
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+	c.Response().WriteHeader(status)
+	return c.Echo().JSONSerializer.Serialize(c, problem, "")
+}
+
+func wantsProblemDetails(c echo.Context) bool {
+	if force, _ := c.Get(problemDetailsKey).(bool); force {
+		return true
+	}
+
+	for _, accepted := range strings.Split(c.Request().Header.Get(echo.HeaderAccept), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && mediaType == MIMEApplicationProblemJSON {
+			return true
+		}
+	}
+	return false
+}
+
+// RouteNotFound answers requests for paths no route matches, in place of the default echo 404
+func RouteNotFound(c echo.Context) error {
+	return WriteError(c, http.StatusNotFound, ErrorResponse{
+		Error:   "ROUTE_NOT_FOUND",
+		Message: "No route matches " + c.Request().Method + " " + c.Request().URL.Path,
+	})
+}
+
+// MethodNotAllowed answers requests for a path whose routes only serve the allowed methods. OPTIONS
+// requests are answered with the allowed methods alone, the way echo answers them for other routes.
+func MethodNotAllowed(c echo.Context, allowed []string) error {
+	c.Response().Header().Set(echo.HeaderAllow, strings.Join(allowed, ", "))
+	if c.Request().Method == http.MethodOptions {
+		return c.NoContent(http.StatusNoContent)
+	}
+	return WriteError(c, http.StatusMethodNotAllowed, ErrorResponse{
+		Error:   "METHOD_NOT_ALLOWED",
+		Message: c.Request().Method + " is not allowed on " + c.Request().URL.Path,
+		Details: map[string]interface{}{
+			"allowed_methods": allowed,
+		},
+	})
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domainErrors "orders-service/internal/domain/errors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestError(accept string, force bool, response ErrorResponse) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/42", nil)
+	if accept != "" {
+		req.Header.Set(echo.HeaderAccept, accept)
+	}
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	if force {
+		_ = ForceProblemDetails()(func(c echo.Context) error { return nil })(c)
+	}
+
+	_ = WriteError(c, http.StatusBadRequest, response)
+	return rec
+}
+
+func TestWriteError_Negotiation(t *testing.T) {
+	validationError := ErrorResponse{
+		Error:   "VALIDATION_ERROR",
+		Message: "Request validation failed",
+		Details: map[string]interface{}{"customer_id": "This field is required"},
+	}
+
+	tests := []struct {
+		name    string
+		accept  string
+		force   bool
+		problem bool
+	}{
+		{"no accept header", "", false, false},
+		{"plain json", "application/json", false, false},
+		{"problem json", "application/problem+json", false, true},
+		{"problem json among others", "application/json;q=0.5, application/problem+json", false, true},
+		{"forced by config", "application/json", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			rec := writeTestError(tt.accept, tt.force, validationError)
+
+			// Then
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+			if !tt.problem {
+				assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+
+				var response ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, validationError, response)
+				return
+			}
+
+			assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+
+			var problem ProblemDetails
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, ProblemDetails{
+				Type:     "urn:orders-service:problem:validation_error",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "Request validation failed",
+				Instance: "/api/v1/orders/42",
+				Extensions: ProblemExtensions{
+					Code:        "VALIDATION_ERROR",
+					FieldErrors: map[string]interface{}{"customer_id": "This field is required"},
+				},
+			}, problem)
+		})
+	}
+}
+
+func TestOrderHandler_GetOrder_NotFoundProblemDetails(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+	mockUseCases.On("GetOrder", mock.Anything, uint(42)).Return(nil, domainErrors.ErrOrderNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/42", nil)
+	req.Header.Set(echo.HeaderAccept, MIMEApplicationProblemJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("42")
+
+	// Execute
+	err := handler.GetOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+
+	var problem ProblemDetails
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, http.StatusNotFound, problem.Status)
+	assert.Equal(t, "Not Found", problem.Title)
+	assert.Equal(t, "Order not found", problem.Detail)
+	assert.Equal(t, "ORDER_NOT_FOUND", problem.Extensions.Code)
+	assert.Nil(t, problem.Extensions.FieldErrors)
+}
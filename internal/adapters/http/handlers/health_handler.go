@@ -7,7 +7,9 @@ import (
 	"runtime"
 	"time"
 
+	"orders-service/pkg/httpclient"
 	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
 
 	"github.com/labstack/echo/v4"
 )
@@ -16,13 +18,21 @@ type HealthHandler struct {
 	logger      logger.Logger
 	startTime   time.Time
 	connections *infrastructure.DatabaseConnections
+	// dependencies are the HTTP clients whose circuit breakers are reported
+	dependencies []*httpclient.Client
 }
 
 func NewHealthHandler(logger logger.Logger, connections *infrastructure.DatabaseConnections) *HealthHandler {
+	return NewHealthHandlerWithDependencies(logger, connections, nil)
+}
+
+// NewHealthHandlerWithDependencies reports the circuit breakers of dependencies next to the database checks
+func NewHealthHandlerWithDependencies(logger logger.Logger, connections *infrastructure.DatabaseConnections, dependencies []*httpclient.Client) *HealthHandler {
 	return &HealthHandler{
-		logger:      logger.With("component", "health_handler"),
-		startTime:   time.Now(),
-		connections: connections,
+		logger:       logger.With("component", "health_handler"),
+		startTime:    time.Now(),
+		connections:  connections,
+		dependencies: dependencies,
 	}
 }
 
@@ -48,11 +58,14 @@ type MetricsResponse struct {
 		MemorySys   uint64 `json:"memory_sys"`
 		GCCount     uint32 `json:"gc_count"`
 	} `json:"runtime"`
+	Counters map[string]int64 `json:"counters"`
+	// Circuits holds the circuit breakers of every dependency by host
+	Circuits map[string]map[string]httpclient.BreakerSnapshot `json:"circuits,omitempty"`
 }
 
 // Health returns basic service health status
 func (h *HealthHandler) Health(c echo.Context) error {
-	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	requestID := RequestID(c)
 
 	h.logger.Debug("Health check requested",
 		"request_id", requestID,
@@ -73,7 +86,7 @@ func (h *HealthHandler) Health(c echo.Context) error {
 // Ready checks if the service is ready to accept requests
 // This is where you'd add database connectivity checks, etc.
 func (h *HealthHandler) Ready(c echo.Context) error {
-	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	requestID := RequestID(c)
 
 	h.logger.Info("Readiness check requested",
 		"request_id", requestID,
@@ -109,6 +122,17 @@ func (h *HealthHandler) Ready(c echo.Context) error {
 		}
 	}
 
+	// An open circuit degrades the features of its dependency but leaves the service ready, failing
+	// readiness would take every instance out of rotation for an outage they cannot fix
+	for name, check := range h.circuitChecks() {
+		responseChecks[name] = check
+		if check["status"] != "healthy" {
+			h.logger.Warn("Dependency circuit not closed during readiness check",
+				"dependency", name,
+				"request_id", requestID)
+		}
+	}
+
 	status := "ready"
 	httpStatus := http.StatusOK
 	if !allHealthy {
@@ -134,9 +158,29 @@ func (h *HealthHandler) Ready(c echo.Context) error {
 	return c.JSON(httpStatus, response)
 }
 
+// circuitChecks reports every dependency as healthy while all its circuit breakers are closed and as
+// degraded otherwise, with the breakers by host
+func (h *HealthHandler) circuitChecks() map[string]map[string]interface{} {
+	checks := make(map[string]map[string]interface{}, len(h.dependencies))
+	for _, dependency := range h.dependencies {
+		circuits := dependency.Circuits()
+		status := "healthy"
+		for _, circuit := range circuits {
+			if circuit.State != httpclient.BreakerClosed {
+				status = "degraded"
+			}
+		}
+		checks[dependency.Name()] = map[string]interface{}{
+			"status":   status,
+			"circuits": circuits,
+		}
+	}
+	return checks
+}
+
 // Live checks if the service is alive (minimal check)
 func (h *HealthHandler) Live(c echo.Context) error {
-	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	requestID := RequestID(c)
 
 	h.logger.Debug("Liveness check requested",
 		"request_id", requestID,
@@ -155,7 +199,7 @@ func (h *HealthHandler) Live(c echo.Context) error {
 
 // Metrics returns service metrics and runtime information
 func (h *HealthHandler) Metrics(c echo.Context) error {
-	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	requestID := RequestID(c)
 
 	h.logger.Debug("Metrics requested",
 		"request_id", requestID,
@@ -177,6 +221,13 @@ func (h *HealthHandler) Metrics(c echo.Context) error {
 	response.Runtime.MemoryTotal = m.TotalAlloc
 	response.Runtime.MemorySys = m.Sys
 	response.Runtime.GCCount = m.NumGC
+	response.Counters = metrics.Default.Snapshot()
+	for _, dependency := range h.dependencies {
+		if response.Circuits == nil {
+			response.Circuits = make(map[string]map[string]httpclient.BreakerSnapshot, len(h.dependencies))
+		}
+		response.Circuits[dependency.Name()] = dependency.Circuits()
+	}
 
 	h.logger.Info("Metrics collected",
 		"goroutines", response.Runtime.Goroutines,
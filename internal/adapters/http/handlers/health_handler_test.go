@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"orders-service/pkg/httpclient"
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// trippedClient returns a client whose breaker for the returned host is open
+func trippedClient(t *testing.T) (*httpclient.Client, string) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	config := httpclient.DefaultConfig()
+	config.MaxAttempts = 1
+	config.BreakerThreshold = 1
+	config.BreakerCooldown = time.Hour
+	client := httpclient.New("catalog", config, metrics.NewRegistry(), logger.New("test"))
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	return client, strings.TrimPrefix(server.URL, "http://")
+}
+
+func TestHealthHandler_Metrics_ReportsCircuits(t *testing.T) {
+	client, host := trippedClient(t)
+	handler := NewHealthHandlerWithDependencies(logger.New("test"), nil, []*httpclient.Client{client})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/metrics", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler.Metrics(echo.New().NewContext(req, rec)))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response MetricsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	circuit := response.Circuits["catalog"][host]
+	assert.Equal(t, httpclient.BreakerOpen, circuit.State)
+	assert.Equal(t, 1, circuit.Failures)
+	assert.Equal(t, "unexpected status 503", circuit.LastError)
+}
+
+func TestHealthHandler_CircuitChecks(t *testing.T) {
+	tripped, host := trippedClient(t)
+	idle := httpclient.New("inventory", httpclient.DefaultConfig(), metrics.NewRegistry(), logger.New("test"))
+	handler := NewHealthHandlerWithDependencies(logger.New("test"), nil, []*httpclient.Client{tripped, idle})
+
+	checks := handler.circuitChecks()
+
+	assert.Equal(t, "degraded", checks["catalog"]["status"])
+	circuits := checks["catalog"]["circuits"].(map[string]httpclient.BreakerSnapshot)
+	assert.Equal(t, httpclient.BreakerOpen, circuits[host].State)
+	assert.Equal(t, "healthy", checks["inventory"]["status"])
+}
+
+func TestHealthHandler_Metrics_OmitsCircuitsWithoutDependencies(t *testing.T) {
+	handler := NewHealthHandler(logger.New("test"), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/metrics", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler.Metrics(echo.New().NewContext(req, rec)))
+
+	assert.NotContains(t, rec.Body.String(), `"circuits"`)
+}
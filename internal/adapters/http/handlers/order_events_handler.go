@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	appEvents "orders-service/internal/application/events"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/application/usecases"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DefaultEventHeartbeatInterval is how often an idle event stream sends a comment to keep proxies from closing it
+const DefaultEventHeartbeatInterval = 15 * time.Second
+
+// OrderEventsHandler streams order changes as server-sent events
+type OrderEventsHandler struct {
+	eventUseCases usecases.OrderEventUseCases
+	orders        OrderIDResolver
+	heartbeat     time.Duration
+	logger        logger.Logger
+}
+
+// NewOrderEventsHandler creates the handler, a heartbeat of 0 uses DefaultEventHeartbeatInterval.
+// orders resolves order UUIDs in the path to numeric IDs.
+func NewOrderEventsHandler(eventUseCases usecases.OrderEventUseCases, orders OrderIDResolver, heartbeat time.Duration, log logger.Logger) *OrderEventsHandler {
+	if heartbeat <= 0 {
+		heartbeat = DefaultEventHeartbeatInterval
+	}
+
+	return &OrderEventsHandler{
+		eventUseCases: eventUseCases,
+		orders:        orders,
+		heartbeat:     heartbeat,
+		logger:        log.With("component", "order_events_handler"),
+	}
+}
+
+// StreamOrderEvents handles GET /api/v1/orders/:id/events, optionally in the payload version ?schema_version
+func (h *OrderEventsHandler) StreamOrderEvents(c echo.Context) error {
+	requestID := RequestID(c)
+
+	version, err := appEvents.ParseSchemaVersion(c.QueryParam("schema_version"))
+	if err != nil {
+		return invalidSchemaVersionResponse(c)
+	}
+
+	orderID, err := orderIDParam(c, h.orders)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	h.logger.Info("Order event stream requested",
+		"request_id", requestID,
+		"order_id", orderID,
+		"remote_ip", c.RealIP())
+
+	subscription, err := h.eventUseCases.WatchOrder(c.Request().Context(), orderID)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to open order event stream")
+	}
+	return h.stream(c, subscription, version, requestID)
+}
+
+// StreamOrdersEvents handles GET /api/v1/orders/events, optionally filtered by ?status and in the
+// payload version ?schema_version
+func (h *OrderEventsHandler) StreamOrdersEvents(c echo.Context) error {
+	requestID := RequestID(c)
+
+	version, err := appEvents.ParseSchemaVersion(c.QueryParam("schema_version"))
+	if err != nil {
+		return invalidSchemaVersionResponse(c)
+	}
+
+	status := entities.OrderStatus(c.QueryParam("status"))
+	if status != "" {
+		if err := entities.ValidateOrderStatus(status); err != nil {
+			return invalidStatusResponse(c, h.logger, requestID, string(status))
+		}
+	}
+
+	h.logger.Info("Orders event stream requested",
+		"request_id", requestID,
+		"status", status,
+		"remote_ip", c.RealIP())
+
+	subscription, err := h.eventUseCases.WatchOrders(c.Request().Context(), status)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to open orders event stream")
+	}
+	return h.stream(c, subscription, version, requestID)
+}
+
+// stream writes every event of subscription as payloads of version until the client disconnects,
+// with a heartbeat comment while idle
+func (h *OrderEventsHandler) stream(c echo.Context, subscription ports.EventSubscription, version appEvents.SchemaVersion, requestID string) error {
+	defer subscription.Close()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	// Keep reverse proxies such as nginx from buffering the stream
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	flushResponse(c)
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	ctx := c.Request().Context()
+	showContact := showsContact(c)
+	sent := 0
+	for {
+		select {
+		case <-ctx.Done():
+			h.logger.Info("Event stream closed by client", "request_id", requestID, "events", sent)
+			return nil
+
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			flushResponse(c)
+
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return nil
+			}
+
+			if !showContact {
+				event = event.WithoutContact()
+			}
+			data, err := appEvents.Encode(event, version)
+			if err != nil {
+				h.logger.Error("Failed to encode order event", "request_id", requestID, "error", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return nil
+			}
+			flushResponse(c)
+			sent++
+		}
+	}
+}
+
+func (h *OrderEventsHandler) handleError(c echo.Context, err error, requestID, logMessage string) error {
+	h.logger.Error(logMessage,
+		"request_id", requestID,
+		"error", err)
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return WriteError(c, http.StatusGatewayTimeout, ErrorResponse{
+			Error:   "GATEWAY_TIMEOUT",
+			Message: "The request timed out",
+		})
+	}
+
+	var domainErr *domainErrors.DomainError
+	if errors.As(err, &domainErr) {
+		return WriteError(c, domainErrors.HTTPStatus(domainErr.Code), ErrorResponse{
+			Error:   domainErr.Code,
+			Message: domainErr.Message,
+			Details: domainErr.Details,
+		})
+	}
+
+	return WriteError(c, http.StatusInternalServerError, ErrorResponse{
+		Error:   "INTERNAL_ERROR",
+		Message: "An internal error occurred",
+	})
+}
+
+// invalidSchemaVersionResponse rejects a schema_version the service cannot produce
+func invalidSchemaVersionResponse(c echo.Context) error {
+	return WriteError(c, http.StatusBadRequest, ErrorResponse{
+		Error:   "INVALID_SCHEMA_VERSION",
+		Message: fmt.Sprintf("Unknown event schema version %q", c.QueryParam("schema_version")),
+		Details: map[string]interface{}{
+			"schema_version":  c.QueryParam("schema_version"),
+			"default_version": appEvents.DefaultSchemaVersion,
+		},
+	})
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	appEvents "orders-service/internal/application/events"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// channelSubscription hands out events sent to its channel and records Close
+type channelSubscription struct {
+	events chan events.OrderEvent
+	closed chan struct{}
+}
+
+func newChannelSubscription() *channelSubscription {
+	return &channelSubscription{events: make(chan events.OrderEvent, 4), closed: make(chan struct{})}
+}
+
+func (s *channelSubscription) Events() <-chan events.OrderEvent { return s.events }
+func (s *channelSubscription) Close()                           { close(s.closed) }
+
+// stubOrderEventUseCases answers with subscription or err
+type stubOrderEventUseCases struct {
+	subscription *channelSubscription
+	err          error
+	status       entities.OrderStatus
+}
+
+func (s *stubOrderEventUseCases) WatchOrder(context.Context, uint) (ports.EventSubscription, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.subscription, nil
+}
+
+func (s *stubOrderEventUseCases) WatchOrders(_ context.Context, status entities.OrderStatus) (ports.EventSubscription, error) {
+	s.status = status
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.subscription, nil
+}
+
+func setupEventsServer(t *testing.T, useCases *stubOrderEventUseCases, heartbeat time.Duration) *httptest.Server {
+	t.Helper()
+	handler := NewOrderEventsHandler(useCases, nil, heartbeat, logger.New("test"))
+	e := echo.New()
+	e.GET("/api/v1/orders/events", handler.StreamOrdersEvents)
+	e.GET("/api/v1/orders/:id/events", handler.StreamOrderEvents)
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// readEvent reads lines up to the blank line ending the next message
+func readEvent(t *testing.T, reader *bufio.Reader) []string {
+	t.Helper()
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestOrderEventsHandler_StreamOrderEvents(t *testing.T) {
+	// Given
+	subscription := newChannelSubscription()
+	server := setupEventsServer(t, &stubOrderEventUseCases{subscription: subscription}, time.Hour)
+
+	resp, err := http.Get(server.URL + "/api/v1/orders/1/events")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get(echo.HeaderContentType))
+	assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+	// When
+	subscription.events <- events.OrderEvent{Type: events.OrderStatusChanged, OrderID: 1, CustomerID: 7, Status: entities.OrderStatusConfirmed}
+
+	// Then
+	lines := readEvent(t, bufio.NewReader(resp.Body))
+	require.Len(t, lines, 2)
+	assert.Equal(t, "event: order.status_changed", lines[0])
+	var event appEvents.OrderEventV1
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(lines[1], "data: ")), &event))
+	assert.Equal(t, "order.status_changed.v1", event.Schema)
+	assert.Equal(t, 1, event.SchemaVersion)
+	assert.Equal(t, uint(1), event.OrderID)
+	assert.Equal(t, string(entities.OrderStatusConfirmed), event.Status)
+	assert.Empty(t, event.Items)
+
+	// The subscription is released once the client disconnects
+	require.NoError(t, resp.Body.Close())
+	select {
+	case <-subscription.closed:
+	case <-time.After(time.Second):
+		t.Fatal("subscription was not closed after the client disconnected")
+	}
+}
+
+func TestOrderEventsHandler_ContactDetails(t *testing.T) {
+	tests := []struct {
+		query string
+		email string
+	}{
+		{query: "?schema_version=3", email: ""},
+		{query: "?schema_version=3&include=contact", email: "jane@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			subscription := newChannelSubscription()
+			server := setupEventsServer(t, &stubOrderEventUseCases{subscription: subscription}, time.Hour)
+
+			resp, err := http.Get(server.URL + "/api/v1/orders/1/events" + tt.query)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			subscription.events <- events.OrderEvent{Type: events.OrderContactChanged, OrderID: 1, CustomerEmail: "jane@example.com"}
+
+			lines := readEvent(t, bufio.NewReader(resp.Body))
+			require.Len(t, lines, 2)
+			var event appEvents.OrderEventV3
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(lines[1], "data: ")), &event))
+			assert.Equal(t, 3, event.SchemaVersion)
+			assert.Equal(t, tt.email, event.CustomerEmail)
+		})
+	}
+}
+
+func TestOrderEventsHandler_SendsHeartbeats(t *testing.T) {
+	server := setupEventsServer(t, &stubOrderEventUseCases{subscription: newChannelSubscription()}, 10*time.Millisecond)
+
+	resp, err := http.Get(server.URL + "/api/v1/orders/events")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, []string{": heartbeat"}, readEvent(t, bufio.NewReader(resp.Body)))
+}
+
+func TestOrderEventsHandler_StreamOrdersEvents_PassesStatus(t *testing.T) {
+	useCases := &stubOrderEventUseCases{subscription: newChannelSubscription()}
+	handler := NewOrderEventsHandler(useCases, nil, time.Hour, logger.New("test"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/events?status=shipped", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler.StreamOrdersEvents(echo.New().NewContext(req, rec)))
+
+	assert.Equal(t, entities.OrderStatusShipped, useCases.status)
+}
+
+func TestOrderEventsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "invalid order ID", path: "/api/v1/orders/abc/events", wantStatus: http.StatusBadRequest, wantCode: "INVALID_ID"},
+		{name: "unknown schema version", path: "/api/v1/orders/1/events?schema_version=9", wantStatus: http.StatusBadRequest, wantCode: "INVALID_SCHEMA_VERSION"},
+		{name: "unknown status", path: "/api/v1/orders/events?status=lost", wantStatus: http.StatusBadRequest, wantCode: domainErrors.ErrInvalidOrderStatus.Code},
+		{name: "order not found", path: "/api/v1/orders/1/events", err: domainErrors.ErrOrderNotFound, wantStatus: http.StatusNotFound, wantCode: domainErrors.ErrOrderNotFound.Code},
+		{name: "too many streams", path: "/api/v1/orders/events", err: domainErrors.ErrTooManyEventStreams, wantStatus: http.StatusServiceUnavailable, wantCode: domainErrors.ErrTooManyEventStreams.Code},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := setupEventsServer(t, &stubOrderEventUseCases{subscription: newChannelSubscription(), err: tt.err}, time.Hour)
+
+			resp, err := http.Get(server.URL + tt.path)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			var body ErrorResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.wantCode, body.Error)
+		})
+	}
+}
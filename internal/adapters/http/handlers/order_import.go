@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"orders-service/internal/application/dto"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+// maxImportLineBytes caps a single line of an order import, the default body limit of a single create
+const maxImportLineBytes = 1 << 20
+
+// errTrailingData rejects an import line holding more than one JSON value
+var errTrailingData = errors.New("unexpected data after the order request")
+
+// ImportOrders handles POST /api/v1/admin/orders/import. The body is NDJSON with one create order request
+// per line, read while it is imported. Unlike POST /orders a line may set the status, created_at and
+// updated_at the order had before it was imported. The result of every line streams back under results, followed by
+// the totals of the import. ?stop_on_error=true ends the import at the first failed line and ?max_lines
+// caps the lines read.
+func (h *OrderHandler) ImportOrders(c echo.Context) error {
+	requestID := RequestID(c)
+
+	request, err := parseImportParams(c)
+	if err != nil {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: err.Error(),
+		})
+	}
+
+	h.logger.Info("Import orders request received",
+		"request_id", requestID,
+		"stop_on_error", request.StopOnError,
+		"max_lines", request.MaxLines,
+		"remote_ip", c.RealIP())
+
+	reader := newImportLineReader(c.Request().Body, h.strictBinding, h.validator)
+	stream := newJSONArrayStream(c, "results")
+	summary, err := h.orderUseCases.ImportOrders(c.Request().Context(), request, reader.Next, func(result *dto.ImportOrderResultDTO) error {
+		return stream.Add(result)
+	})
+	if err != nil {
+		if !stream.Started() {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				return h.handleBindError(c, err, requestID)
+			}
+			return h.handleError(c, err, requestID, "Failed to import orders")
+		}
+		// Headers are already sent, the truncated body is all the client will get. The reported lines are stored.
+		h.logger.Error("Import aborted after streaming started",
+			"request_id", requestID,
+			"lines", stream.Count(),
+			"error", err)
+		return nil
+	}
+
+	h.logger.Info("Orders imported successfully",
+		"request_id", requestID,
+		"lines", summary.Lines,
+		"created", summary.Created,
+		"failed", summary.Failed,
+		"stop_reason", summary.StopReason)
+
+	return stream.Close(summary)
+}
+
+// parseImportParams reads the stop_on_error and max_lines query parameters of an order import
+func parseImportParams(c echo.Context) (*dto.ImportOrdersRequestDTO, error) {
+	request := &dto.ImportOrdersRequestDTO{}
+
+	if value := c.QueryParam("stop_on_error"); value != "" {
+		stopOnError, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("stop_on_error must be true or false, got %q", value)
+		}
+		request.StopOnError = stopOnError
+	}
+
+	if value := c.QueryParam("max_lines"); value != "" {
+		maxLines, err := strconv.Atoi(value)
+		if err != nil || maxLines < 1 {
+			return nil, fmt.Errorf("max_lines must be a positive integer, got %q", value)
+		}
+		request.MaxLines = maxLines
+	}
+
+	return request, nil
+}
+
+// importLineReader splits an NDJSON body into the order lines of an import, numbered from 1. Blank lines
+// are skipped. Lines that are not a valid create order request are returned with the error a create
+// would be answered with, so the import can report them and go on.
+type importLineReader struct {
+	reader    *bufio.Reader
+	strict    bool
+	validator *validator.Validate
+	line      int
+}
+
+func newImportLineReader(body io.Reader, strict bool, validator *validator.Validate) *importLineReader {
+	return &importLineReader{
+		reader:    bufio.NewReader(body),
+		strict:    strict,
+		validator: validator,
+	}
+}
+
+// Next returns the next order line, or io.EOF at the end of the body
+func (r *importLineReader) Next() (*dto.ImportOrderLineDTO, error) {
+	for {
+		data, tooLong, err := r.readLine()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if errors.Is(err, io.EOF) && len(data) == 0 && !tooLong {
+			return nil, io.EOF
+		}
+		r.line++
+
+		if tooLong {
+			return &dto.ImportOrderLineDTO{Line: r.line, Error: &dto.ImportErrorDTO{
+				Error:   "REQUEST_TOO_LARGE",
+				Message: "Import line is too large",
+				Details: map[string]interface{}{"limit_bytes": maxImportLineBytes},
+			}}, nil
+		}
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			return r.decode(data), nil
+		}
+	}
+}
+
+// readLine reads up to the next newline. The content of a line over maxImportLineBytes is dropped and
+// reported with tooLong, so a runaway line cannot hold the whole body in memory.
+func (r *importLineReader) readLine() (line []byte, tooLong bool, err error) {
+	for {
+		chunk, err := r.reader.ReadSlice('\n')
+		if !tooLong {
+			if len(line)+len(chunk) > maxImportLineBytes {
+				line, tooLong = nil, true
+			} else {
+				line = append(line, chunk...)
+			}
+		}
+		if !errors.Is(err, bufio.ErrBufferFull) {
+			return line, tooLong, err
+		}
+	}
+}
+
+// decode binds and validates a line like the body of POST /orders, which may also carry the status and
+// timestamps of the order
+func (r *importLineReader) decode(data []byte) *dto.ImportOrderLineDTO {
+	line := &dto.ImportOrderLineDTO{Line: r.line}
+
+	var request dto.HistoricalOrderRequestDTO
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if r.strict {
+		decoder.DisallowUnknownFields()
+	}
+	err := unknownFieldError(decoder.Decode(&request))
+	if err == nil && decoder.InputOffset() < int64(len(data)) {
+		err = errTrailingData
+	}
+	if err != nil {
+		_, response := bindErrorResponse(err)
+		line.Error = importErrorDTO(response)
+		return line
+	}
+
+	// The embedded create request is validated on its own so field paths match those of POST /orders,
+	// the status and timestamps are checked by the use cases
+	if err := r.validator.Struct(request.CreateOrderRequestDTO); err != nil {
+		line.Error = &dto.ImportErrorDTO{
+			Error:   "VALIDATION_ERROR",
+			Message: "Request validation failed",
+			Details: validationDetails(err),
+		}
+		return line
+	}
+
+	line.Request = &request
+	return line
+}
+
+// importErrorDTO reports an error response as the error of an import line
+func importErrorDTO(response ErrorResponse) *dto.ImportErrorDTO {
+	return &dto.ImportErrorDTO{
+		Error:   response.Error,
+		Message: response.Message,
+		Details: response.Details,
+	}
+}
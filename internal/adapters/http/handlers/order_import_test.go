@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/domain/entities"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const importOrderLine = `{"customer_id":123,"items":[{"product_id":1,"product_sku":"SKU-001","product_name":"Product 1","quantity":2,"unit_price":10.5}]}`
+
+// importReport is the body answering an order import
+type importReport struct {
+	Results []dto.ImportOrderResultDTO `json:"results"`
+	dto.ImportOrdersSummaryDTO
+}
+
+func importOrders(t *testing.T, handler *OrderHandler, query string, body io.Reader) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/orders/import"+query, body)
+	req.Header.Set(echo.HeaderContentType, "application/x-ndjson")
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler.ImportOrders(echo.New().NewContext(req, rec)))
+	return rec
+}
+
+func TestOrderHandler_ImportOrders(t *testing.T) {
+	// Given
+	handler, mockUseCases := setupTestOrderHandler()
+	mockUseCases.On("ImportOrders", mock.Anything, &dto.ImportOrdersRequestDTO{StopOnError: false, MaxLines: 100}).Return(nil, nil)
+
+	body := strings.Join([]string{
+		importOrderLine,
+		"",
+		`{"customer_id":123,"items":[`,
+		`{"customer_id":123,"coupon":"FREE"}`,
+		`{"customer_id":0}`,
+		importOrderLine + ` {"customer_id":124}`,
+		"  " + importOrderLine + "\r",
+	}, "\n")
+
+	// When
+	rec := importOrders(t, handler, "?max_lines=100", strings.NewReader(body))
+
+	// Then
+	require.Equal(t, http.StatusOK, rec.Code)
+	var report importReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+
+	require.Len(t, report.Results, 6)
+	assert.Equal(t, dto.ImportOrderResultDTO{Line: 1, OrderID: 1}, report.Results[0])
+	assert.Equal(t, 3, report.Results[1].Line)
+	assert.Equal(t, "INVALID_REQUEST", report.Results[1].Error.Error)
+	assert.Equal(t, "UNKNOWN_FIELD", report.Results[2].Error.Error)
+	assert.Equal(t, "coupon", report.Results[2].Error.Details["field"])
+	assert.Equal(t, "VALIDATION_ERROR", report.Results[3].Error.Error)
+	assert.Contains(t, report.Results[3].Error.Details, "customer_id")
+	assert.Equal(t, "INVALID_REQUEST", report.Results[4].Error.Error)
+	assert.Equal(t, dto.ImportOrderResultDTO{Line: 7, OrderID: 7}, report.Results[5])
+
+	assert.Equal(t, dto.ImportOrdersSummaryDTO{Lines: 6, Created: 2, Failed: 4}, report.ImportOrdersSummaryDTO)
+}
+
+func TestOrderHandler_ImportOrders_InvalidParams(t *testing.T) {
+	handler, mockUseCases := setupTestOrderHandler()
+
+	for _, query := range []string{"?stop_on_error=maybe", "?max_lines=0", "?max_lines=ten"} {
+		rec := importOrders(t, handler, query, strings.NewReader(importOrderLine))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+		assert.Contains(t, rec.Body.String(), "INVALID_REQUEST", query)
+	}
+	mockUseCases.AssertNotCalled(t, "ImportOrders", mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_ImportOrders_BodyTooLarge(t *testing.T) {
+	// Given
+	handler, mockUseCases := setupTestOrderHandler()
+	mockUseCases.On("ImportOrders", mock.Anything, &dto.ImportOrdersRequestDTO{StopOnError: true}).Return(nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/orders/import?stop_on_error=true", strings.NewReader(importOrderLine+"\n"+importOrderLine))
+	rec := httptest.NewRecorder()
+	req.Body = http.MaxBytesReader(rec, req.Body, 10)
+
+	// When
+	require.NoError(t, handler.ImportOrders(echo.New().NewContext(req, rec)))
+
+	// Then
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Contains(t, rec.Body.String(), "REQUEST_TOO_LARGE")
+}
+
+func TestImportLineReader_LineTooLarge(t *testing.T) {
+	body := strings.Repeat(" ", maxImportLineBytes+1) + "\n" + importOrderLine
+	reader := newImportLineReader(strings.NewReader(body), true, newValidator())
+
+	line, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 1, line.Line)
+	require.NotNil(t, line.Error)
+	assert.Equal(t, "REQUEST_TOO_LARGE", line.Error.Error)
+
+	line, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 2, line.Line)
+	assert.Nil(t, line.Error)
+	assert.Equal(t, uint(123), line.Request.CustomerID)
+
+	_, err = reader.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestImportLineReader_HistoricalFields(t *testing.T) {
+	body := `{"customer_id":123,"items":[{"product_id":1,"product_sku":"SKU-001","product_name":"Product 1","quantity":2,"unit_price":10.5}],"status":"delivered","created_at":"2023-05-01T09:30:00Z"}` + "\n" +
+		`{"customer_id":0,"status":"delivered"}`
+	reader := newImportLineReader(strings.NewReader(body), true, newValidator())
+
+	line, err := reader.Next()
+	require.NoError(t, err)
+	require.Nil(t, line.Error)
+	assert.Equal(t, entities.OrderStatusDelivered, line.Request.Status)
+	require.NotNil(t, line.Request.CreatedAt)
+	assert.True(t, time.Date(2023, 5, 1, 9, 30, 0, 0, time.UTC).Equal(*line.Request.CreatedAt))
+	assert.Nil(t, line.Request.UpdatedAt)
+
+	// Field paths are those of POST /orders
+	line, err = reader.Next()
+	require.NoError(t, err)
+	require.NotNil(t, line.Error)
+	assert.Equal(t, "VALIDATION_ERROR", line.Error.Error)
+	assert.Contains(t, line.Error.Details, "customer_id")
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"orders-service/internal/application/auth"
+	"orders-service/internal/application/dto"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/sync/errgroup"
+)
+
+// orderInclude names a resource GET /orders/:id can attach to the order with ?include=
+type orderInclude string
+
+const (
+	// includeHistory attaches the first page of the audit log, it requires the admin scope like the
+	// audit endpoint does
+	includeHistory orderInclude = "history"
+	// includeShipments attaches the shipments of the order
+	includeShipments orderInclude = "shipments"
+	// includeContact keeps the contact details of the customer in the order, see showsContact. Every
+	// endpoint returning orders honors it.
+	includeContact orderInclude = "contact"
+)
+
+// supportedIncludes lists the include values the handler can serve, history needs the audit use cases
+func (h *OrderHandler) supportedIncludes() []orderInclude {
+	if h.audit == nil {
+		return []orderInclude{includeShipments, includeContact}
+	}
+	return []orderInclude{includeHistory, includeShipments, includeContact}
+}
+
+// parseIncludes reads the comma separated include query parameter, blanks and repeats are ignored
+func (h *OrderHandler) parseIncludes(c echo.Context) ([]orderInclude, error) {
+	param := c.QueryParam("include")
+	if param == "" {
+		return nil, nil
+	}
+
+	supported := h.supportedIncludes()
+	var includes []orderInclude
+	for _, value := range strings.Split(param, ",") {
+		include := orderInclude(strings.ToLower(strings.TrimSpace(value)))
+		if include == "" || slices.Contains(includes, include) {
+			continue
+		}
+		if !slices.Contains(supported, include) {
+			return nil, fmt.Errorf("unsupported include %q", value)
+		}
+		includes = append(includes, include)
+	}
+	return includes, nil
+}
+
+// invalidIncludeResponse answers a request with an unsupported include value
+func (h *OrderHandler) invalidIncludeResponse(c echo.Context, err error) error {
+	return WriteError(c, http.StatusBadRequest, ErrorResponse{
+		Error:   "INVALID_REQUEST",
+		Message: err.Error(),
+		Details: map[string]interface{}{
+			"supported_includes": h.supportedIncludes(),
+		},
+	})
+}
+
+// canReadIncludes reports whether the principal of the request may read includes on their own
+func canReadIncludes(c echo.Context, includes []orderInclude) bool {
+	if !slices.Contains(includes, includeHistory) {
+		return true
+	}
+	principal, ok := auth.PrincipalFromContext(c.Request().Context())
+	return !ok || principal.IsAdmin()
+}
+
+// forbiddenIncludeResponse answers a request including history without the admin scope
+func forbiddenIncludeResponse(c echo.Context) error {
+	return WriteError(c, http.StatusForbidden, ErrorResponse{
+		Error:   "FORBIDDEN",
+		Message: "API key does not have the required scope",
+		Details: map[string]interface{}{
+			"missing_scope": string(auth.ScopeOrdersAdmin),
+		},
+	})
+}
+
+// writeOrder answers a request for a single order with the resources it asked to include. The includes
+// are loaded concurrently. One that fails is reported under _errors of the response, or fails the whole
+// request with StrictIncludes.
+func (h *OrderHandler) writeOrder(c echo.Context, requestID string, order *dto.OrderResponseDTO, includes []orderInclude) error {
+	if len(includes) == 0 {
+		return writeJSON(c, http.StatusOK, order)
+	}
+
+	detail := &dto.OrderDetailResponseDTO{OrderResponseDTO: order}
+	var mu sync.Mutex
+	group, ctx := errgroup.WithContext(c.Request().Context())
+	for _, include := range includes {
+		group.Go(func() error {
+			err := h.loadInclude(ctx, detail, include)
+			if err == nil || h.strictIncludes {
+				return err
+			}
+
+			h.logger.Warn("Failed to load order include",
+				"request_id", requestID,
+				"order_id", order.ID,
+				"include", include,
+				"error", err)
+			_, response := errorResponse(err)
+			mu.Lock()
+			defer mu.Unlock()
+			if detail.Errors == nil {
+				detail.Errors = make(map[string]dto.IncludeErrorDTO)
+			}
+			detail.Errors[string(include)] = dto.IncludeErrorDTO{Error: response.Error, Message: response.Message}
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return h.handleError(c, err, requestID, "Failed to load order include")
+	}
+
+	return writeJSON(c, http.StatusOK, detail)
+}
+
+// loadInclude sets the field of detail holding include, every include writes its own field
+func (h *OrderHandler) loadInclude(ctx context.Context, detail *dto.OrderDetailResponseDTO, include orderInclude) error {
+	switch include {
+	case includeHistory:
+		history, err := h.audit.GetOrderAuditLog(ctx, detail.ID, 0, dto.MaxPageSize)
+		if err != nil {
+			return err
+		}
+		detail.History = history
+	case includeShipments:
+		shipments, err := h.orderUseCases.ListShipments(ctx, detail.ID)
+		if err != nil {
+			return err
+		}
+		detail.Shipments = shipments.Shipments
+		if detail.Shipments == nil {
+			detail.Shipments = []*dto.ShipmentResponseDTO{}
+		}
+	case includeContact:
+		// Nothing to load, the order already holds the contact details
+	}
+	return nil
+}
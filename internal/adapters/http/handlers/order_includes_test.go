@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/auth"
+	"orders-service/internal/application/dto"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupIncludeHandler(strict bool) (*OrderHandler, *MockOrderUseCases, *MockAuditUseCases) {
+	orders := new(MockOrderUseCases)
+	audit := new(MockAuditUseCases)
+	handler := NewOrderHandlerWithConfig(orders, logger.New("test"), OrderHandlerConfig{
+		StrictIncludes: strict,
+		Audit:          audit,
+	})
+	orders.On("GetOrder", mock.Anything, uint(1)).Return(&dto.OrderResponseDTO{
+		ID:         1,
+		CustomerID: 123,
+		Items:      []dto.OrderItemResponseDTO{},
+		Status:     entities.OrderStatusShipped,
+	}, nil)
+	return handler, orders, audit
+}
+
+func getOrderWithIncludes(t *testing.T, handler *OrderHandler, query string, principal *auth.Principal) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1"+query, nil)
+	if principal != nil {
+		req = req.WithContext(auth.WithPrincipal(req.Context(), principal))
+	}
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	require.NoError(t, handler.GetOrder(c))
+	return rec
+}
+
+func TestOrderHandler_GetOrder_Includes(t *testing.T) {
+	handler, orders, audit := setupIncludeHandler(false)
+	orders.On("ListShipments", mock.Anything, uint(1)).Return(&dto.ShipmentListResponseDTO{
+		OrderID:   1,
+		Shipments: []*dto.ShipmentResponseDTO{{ID: 5, OrderID: 1, Items: []dto.ShipmentItemDTO{}}},
+	}, nil)
+	audit.On("GetOrderAuditLog", mock.Anything, uint(1), 0, dto.MaxPageSize).Return(&dto.AuditLogResponseDTO{
+		Entries:  []*dto.AuditEntryResponseDTO{{ID: 9, OrderID: 1, Action: entities.AuditActionOrderCreated}},
+		Total:    1,
+		PageSize: dto.MaxPageSize,
+	}, nil)
+
+	rec := getOrderWithIncludes(t, handler, "?include=history,%20SHIPMENTS,history", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var response dto.OrderDetailResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, uint(1), response.ID)
+	require.NotNil(t, response.History)
+	assert.Len(t, response.History.Entries, 1)
+	assert.Len(t, response.Shipments, 1)
+	assert.Nil(t, response.Errors)
+	orders.AssertExpectations(t)
+	audit.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrder_WithoutIncludes(t *testing.T) {
+	handler, orders, audit := setupIncludeHandler(false)
+
+	rec := getOrderWithIncludes(t, handler, "", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.NotContains(t, body, "history")
+	assert.NotContains(t, body, "shipments")
+	assert.NotContains(t, body, "_errors")
+	orders.AssertNotCalled(t, "ListShipments", mock.Anything, mock.Anything)
+	audit.AssertNotCalled(t, "GetOrderAuditLog", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_GetOrder_EmptyIncludeIsWritten(t *testing.T) {
+	handler, orders, _ := setupIncludeHandler(false)
+	orders.On("ListShipments", mock.Anything, uint(1)).Return(&dto.ShipmentListResponseDTO{OrderID: 1}, nil)
+
+	rec := getOrderWithIncludes(t, handler, "?include=shipments", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.JSONEq(t, `[]`, string(body["shipments"]))
+}
+
+func TestOrderHandler_GetOrder_InvalidIncludes(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func() *OrderHandler
+		query   string
+	}{
+		{"unknown include", func() *OrderHandler { h, _, _ := setupIncludeHandler(false); return h }, "?include=shipments,notes"},
+		{"history without an audit log", func() *OrderHandler { h, _ := setupTestOrderHandler(); return h }, "?include=history"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := getOrderWithIncludes(t, tt.handler(), tt.query, nil)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			var response ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, "INVALID_REQUEST", response.Error)
+			assert.Contains(t, response.Details, "supported_includes")
+		})
+	}
+}
+
+func TestOrderHandler_GetOrder_HistoryRequiresAdmin(t *testing.T) {
+	handler, orders, audit := setupIncludeHandler(false)
+	audit.On("GetOrderAuditLog", mock.Anything, uint(1), 0, dto.MaxPageSize).Return(&dto.AuditLogResponseDTO{}, nil)
+
+	reader := &auth.Principal{Name: "dashboard", Scopes: []auth.Scope{auth.ScopeOrdersRead}}
+	rec := getOrderWithIncludes(t, handler, "?include=history", reader)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	orders.AssertNotCalled(t, "GetOrder", mock.Anything, mock.Anything)
+
+	admin := &auth.Principal{Name: "support", Scopes: []auth.Scope{auth.ScopeOrdersAdmin}}
+	rec = getOrderWithIncludes(t, handler, "?include=history", admin)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestOrderHandler_GetOrder_IncludeFailureDegrades(t *testing.T) {
+	handler, orders, audit := setupIncludeHandler(false)
+	orders.On("ListShipments", mock.Anything, uint(1)).Return(&dto.ShipmentListResponseDTO{OrderID: 1}, nil)
+	audit.On("GetOrderAuditLog", mock.Anything, uint(1), 0, dto.MaxPageSize).Return(nil, domainErrors.ErrFailedToGetAuditLog)
+
+	rec := getOrderWithIncludes(t, handler, "?include=history,shipments", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response dto.OrderDetailResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Nil(t, response.History)
+	assert.NotNil(t, response.Shipments)
+	assert.Equal(t, map[string]dto.IncludeErrorDTO{
+		"history": {Error: domainErrors.ErrFailedToGetAuditLog.Code, Message: domainErrors.ErrFailedToGetAuditLog.Message},
+	}, response.Errors)
+}
+
+func TestOrderHandler_GetOrder_IncludeFailureIsStrict(t *testing.T) {
+	handler, orders, _ := setupIncludeHandler(true)
+	orders.On("ListShipments", mock.Anything, uint(1)).Return(nil, errors.New("connection refused"))
+
+	rec := getOrderWithIncludes(t, handler, "?include=shipments", nil)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "INTERNAL_ERROR", response.Error)
+}
+
+func TestOrderHandler_GetOrder_IncludesLoadConcurrently(t *testing.T) {
+	handler, orders, audit := setupIncludeHandler(false)
+
+	// Each include waits for the other to start, loading them one after the other would time out
+	historyStarted := make(chan struct{})
+	shipmentsStarted := make(chan struct{})
+	wait := func(started chan struct{}) {
+		select {
+		case <-started:
+		case <-time.After(2 * time.Second):
+		}
+	}
+	audit.On("GetOrderAuditLog", mock.Anything, uint(1), 0, dto.MaxPageSize).Run(func(mock.Arguments) {
+		close(historyStarted)
+		wait(shipmentsStarted)
+	}).Return(&dto.AuditLogResponseDTO{}, nil)
+	orders.On("ListShipments", mock.Anything, uint(1)).Run(func(mock.Arguments) {
+		close(shipmentsStarted)
+		wait(historyStarted)
+	}).Return(&dto.ShipmentListResponseDTO{}, nil)
+
+	start := time.Now()
+	rec := getOrderWithIncludes(t, handler, "?include=history,shipments", nil)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -1,9 +1,16 @@
 package handlers
 
 import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"orders-service/internal/application/dto"
 	"orders-service/internal/application/usecases"
@@ -15,66 +22,90 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// HeaderTotalCount carries the number of orders of a list in the answer to a HEAD request
+const HeaderTotalCount = "X-Total-Count"
+
 type OrderHandler struct {
-	orderUseCases usecases.OrderUseCases
-	validator     *validator.Validate
-	logger        logger.Logger
+	orderUseCases  usecases.OrderUseCases
+	audit          usecases.AuditUseCases
+	validator      *validator.Validate
+	binder         echo.Binder
+	strictBinding  bool
+	strictIncludes bool
+	listCache      ListCacheConfig
+	logger         logger.Logger
 }
 
-func NewOrderHandler(orderUseCases usecases.OrderUseCases, log logger.Logger) *OrderHandler {
-	return &OrderHandler{
-		orderUseCases: orderUseCases,
-		validator:     validator.New(),
-		logger:        log.With("component", "order_handler"),
+// OrderHandlerConfig tunes request handling
+type OrderHandlerConfig struct {
+	// StrictBinding rejects request bodies with fields the DTO does not declare
+	StrictBinding bool
+
+	// StrictIncludes fails GET /orders/:id when a resource asked for with ?include= cannot be loaded,
+	// otherwise the order is answered with the failure reported under _errors
+	StrictIncludes bool
+
+	// Audit serves ?include=history, nil leaves history out of the supported includes
+	Audit usecases.AuditUseCases
+
+	// ListCache answers unchanged order lists with 304 Not Modified
+	ListCache ListCacheConfig
+}
+
+// DefaultOrderHandlerConfig returns the configuration used by NewOrderHandler
+func DefaultOrderHandlerConfig() OrderHandlerConfig {
+	return OrderHandlerConfig{
+		StrictBinding: true,
 	}
 }
 
-// ErrorResponse represents an error response
-type ErrorResponse struct {
-	Error   string                 `json:"error"`
-	Message string                 `json:"message"`
-	Details map[string]interface{} `json:"details,omitempty"`
+func NewOrderHandler(orderUseCases usecases.OrderUseCases, log logger.Logger) *OrderHandler {
+	return NewOrderHandlerWithConfig(orderUseCases, log, DefaultOrderHandlerConfig())
+}
+
+func NewOrderHandlerWithConfig(orderUseCases usecases.OrderUseCases, log logger.Logger, config OrderHandlerConfig) *OrderHandler {
+	return &OrderHandler{
+		orderUseCases:  orderUseCases,
+		audit:          config.Audit,
+		validator:      newValidator(),
+		binder:         &Binder{Strict: config.StrictBinding},
+		strictBinding:  config.StrictBinding,
+		strictIncludes: config.StrictIncludes,
+		listCache:      config.ListCache,
+		logger:         log.With("component", "order_handler"),
+	}
 }
 
 // CreateOrder handles POST /api/v1/orders
 func (h *OrderHandler) CreateOrder(c echo.Context) error {
-	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	requestID := RequestID(c)
 
 	h.logger.Info("Create order request received",
 		"request_id", requestID,
 		"remote_ip", c.RealIP(),
 		"user_agent", c.Request().UserAgent())
 
-	// Parse request body
-	var request dto.CreateOrderRequestDTO
-	if err := c.Bind(&request); err != nil {
-		h.logger.Warn("Failed to bind request body",
-			"request_id", requestID,
-			"error", err)
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
+	dryRun, err := parseDryRunParam(c)
+	if err != nil {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "INVALID_REQUEST",
-			Message: "Invalid request body format",
+			Message: err.Error(),
 		})
 	}
 
-	// Validate request
-	if err := h.validator.Struct(request); err != nil {
-		h.logger.Warn("Request validation failed",
-			"request_id", requestID,
-			"error", err)
+	// Parse request body
+	var request dto.CreateOrderRequestDTO
+	if err := h.binder.Bind(&request, c); err != nil {
+		return h.handleBindError(c, err, requestID)
+	}
 
-		details := make(map[string]interface{})
-		if validationErrors, ok := err.(validator.ValidationErrors); ok {
-			for _, fieldError := range validationErrors {
-				details[fieldError.Field()] = getValidationErrorMessage(fieldError)
-			}
-		}
+	if dryRun {
+		return h.validateOrder(c, requestID, &request)
+	}
 
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "VALIDATION_ERROR",
-			Message: "Request validation failed",
-			Details: details,
-		})
+	// Validate request
+	if err := h.validator.Struct(request); err != nil {
+		return h.handleValidationError(c, err, requestID)
 	}
 
 	// Execute use case
@@ -88,22 +119,130 @@ func (h *OrderHandler) CreateOrder(c echo.Context) error {
 		"order_id", response.ID,
 		"customer_id", response.CustomerID)
 
-	return c.JSON(http.StatusCreated, response)
+	return writeJSON(c, http.StatusCreated, response)
+}
+
+// validateOrder answers POST /api/v1/orders?dry_run=true with the order the request would create,
+// 200 when it is valid and 422 listing every problem otherwise. Nothing is stored.
+func (h *OrderHandler) validateOrder(c echo.Context, requestID string, request *dto.CreateOrderRequestDTO) error {
+	if err := h.validator.Struct(request); err != nil {
+		h.logger.Info("Order dry run rejected by request validation",
+			"request_id", requestID,
+			"error", err)
+		return c.JSON(http.StatusUnprocessableEntity, dto.OrderToValidationResponseDTO(request, nil, requestValidationErrors(err)))
+	}
+
+	response, err := h.orderUseCases.ValidateOrder(c.Request().Context(), request)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to validate order")
+	}
+
+	h.logger.Info("Order dry run completed",
+		"request_id", requestID,
+		"customer_id", response.CustomerID,
+		"valid", response.Valid)
+
+	if !response.Valid {
+		return writeJSON(c, http.StatusUnprocessableEntity, response)
+	}
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// parseDryRunParam reads the optional dry_run query parameter of POST /orders
+func parseDryRunParam(c echo.Context) (bool, error) {
+	value := c.QueryParam("dry_run")
+	if value == "" {
+		return false, nil
+	}
+	dryRun, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("dry_run must be true or false, got %q", value)
+	}
+	return dryRun, nil
+}
+
+// CreateOrderAsync handles POST /api/v1/orders/async. The request is validated like a synchronous create
+// and answered with 202 and the job creating the order in the background, Location points to the job.
+func (h *OrderHandler) CreateOrderAsync(c echo.Context) error {
+	requestID := RequestID(c)
+
+	h.logger.Info("Create order async request received",
+		"request_id", requestID,
+		"remote_ip", c.RealIP(),
+		"user_agent", c.Request().UserAgent())
+
+	// Parse request body
+	var request dto.CreateOrderRequestDTO
+	if err := h.binder.Bind(&request, c); err != nil {
+		return h.handleBindError(c, err, requestID)
+	}
+
+	// Validate request
+	if err := h.validator.Struct(request); err != nil {
+		return h.handleValidationError(c, err, requestID)
+	}
+
+	// Execute use case
+	response, err := h.orderUseCases.SubmitOrderJob(c.Request().Context(), &request)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to submit order job")
+	}
+
+	h.logger.Info("Order job submitted successfully",
+		"request_id", requestID,
+		"job_id", response.JobID,
+		"customer_id", response.CustomerID)
+
+	location := strings.TrimSuffix(c.Request().URL.Path, "/async") + "/jobs/" + response.JobID
+	c.Response().Header().Set(echo.HeaderLocation, location)
+	return writeJSON(c, http.StatusAccepted, response)
+}
+
+// GetOrderJob handles GET /api/v1/orders/jobs/:job_id, reporting whether the order was created
+func (h *OrderHandler) GetOrderJob(c echo.Context) error {
+	requestID := RequestID(c)
+
+	jobID, err := entities.ParsePublicID(c.Param("job_id"))
+	if err != nil {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid job ID format",
+		})
+	}
+
+	response, err := h.orderUseCases.GetOrderJob(c.Request().Context(), jobID)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to get order job")
+	}
+
+	return writeJSON(c, http.StatusOK, response)
 }
 
-// GetOrder handles GET /api/v1/orders/:id
+// GetOrder handles GET /api/v1/orders/:id, where :id is the numeric ID or the public UUID of the order.
+// The include query parameter attaches related resources, such as ?include=history,shipments.
 func (h *OrderHandler) GetOrder(c echo.Context) error {
-	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	requestID := RequestID(c)
+
+	includes, err := h.parseIncludes(c)
+	if err != nil {
+		return h.invalidIncludeResponse(c, err)
+	}
+	if !canReadIncludes(c, includes) {
+		return forbiddenIncludeResponse(c)
+	}
 
 	// Parse order ID from path parameter
 	idParam := c.Param("id")
+	if entities.IsPublicID(idParam) {
+		return h.getOrderByPublicID(c, idParam, requestID, includes)
+	}
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
 		h.logger.Warn("Invalid order ID parameter",
 			"request_id", requestID,
 			"id_param", idParam,
 			"error", err)
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "INVALID_ID",
 			Message: "Invalid order ID format",
 		})
@@ -112,6 +251,7 @@ func (h *OrderHandler) GetOrder(c echo.Context) error {
 	h.logger.Info("Get order request received",
 		"request_id", requestID,
 		"order_id", id,
+		"include", includes,
 		"remote_ip", c.RealIP())
 
 	// Execute use case
@@ -124,26 +264,116 @@ func (h *OrderHandler) GetOrder(c echo.Context) error {
 		"request_id", requestID,
 		"order_id", response.ID)
 
-	return c.JSON(http.StatusOK, response)
+	return h.writeOrder(c, requestID, response, includes)
+}
+
+func (h *OrderHandler) getOrderByPublicID(c echo.Context, publicID, requestID string, includes []orderInclude) error {
+	h.logger.Info("Get order request received",
+		"request_id", requestID,
+		"public_id", publicID,
+		"include", includes,
+		"remote_ip", c.RealIP())
+
+	// Execute use case
+	response, err := h.orderUseCases.GetOrderByPublicID(c.Request().Context(), publicID)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to get order")
+	}
+
+	h.logger.Info("Order retrieved successfully",
+		"request_id", requestID,
+		"order_id", response.ID)
+
+	return h.writeOrder(c, requestID, response, includes)
+}
+
+// GetOrderByExternalReference handles GET /api/v1/orders/by-reference
+func (h *OrderHandler) GetOrderByExternalReference(c echo.Context) error {
+	requestID := RequestID(c)
+
+	customerID, err := strconv.ParseUint(c.QueryParam("customer_id"), 10, 32)
+	if err != nil || customerID == 0 {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid customer ID format",
+		})
+	}
+
+	reference := strings.TrimSpace(c.QueryParam("reference"))
+	if reference == "" || len(reference) > entities.MaxExternalReferenceLength {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: fmt.Sprintf("reference is required and must be at most %d characters", entities.MaxExternalReferenceLength),
+		})
+	}
+
+	h.logger.Info("Get order by external reference request received",
+		"request_id", requestID,
+		"customer_id", customerID,
+		"reference", reference)
+
+	// Execute use case
+	response, err := h.orderUseCases.GetOrderByExternalReference(c.Request().Context(), uint(customerID), reference)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to get order by external reference")
+	}
+
+	h.logger.Info("Order retrieved by external reference successfully",
+		"request_id", requestID,
+		"order_id", response.ID)
+
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// GetOrderByNumber handles GET /api/v1/orders/number/:order_number
+func (h *OrderHandler) GetOrderByNumber(c echo.Context) error {
+	requestID := RequestID(c)
+
+	number := strings.TrimSpace(c.Param("order_number"))
+	if number == "" || len(number) > entities.MaxOrderNumberLength {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: fmt.Sprintf("order number is required and must be at most %d characters", entities.MaxOrderNumberLength),
+		})
+	}
+
+	h.logger.Info("Get order by number request received",
+		"request_id", requestID,
+		"order_number", number)
+
+	// Execute use case
+	response, err := h.orderUseCases.GetOrderByNumber(c.Request().Context(), number)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to get order by number")
+	}
+
+	h.logger.Info("Order retrieved by number successfully",
+		"request_id", requestID,
+		"order_id", response.ID)
+
+	return writeJSON(c, http.StatusOK, response)
 }
 
 // AddItemToOrder handles POST /api/v1/orders/:id/items
 func (h *OrderHandler) AddItemToOrder(c echo.Context) error {
-	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	requestID := RequestID(c)
 
 	// Parse order ID
 	idParam := c.Param("id")
-	orderID, err := strconv.ParseUint(idParam, 10, 32)
-	if err != nil {
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
 		h.logger.Warn("Invalid order ID parameter",
 			"request_id", requestID,
 			"id_param", idParam,
 			"error", err)
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "INVALID_ID",
 			Message: "Invalid order ID format",
 		})
 	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
 
 	h.logger.Info("Add item to order request received",
 		"request_id", requestID,
@@ -152,14 +382,8 @@ func (h *OrderHandler) AddItemToOrder(c echo.Context) error {
 
 	// Parse request body
 	var request dto.AddOrderItemRequestDTO
-	if err := c.Bind(&request); err != nil {
-		h.logger.Warn("Failed to bind request body",
-			"request_id", requestID,
-			"error", err)
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "INVALID_REQUEST",
-			Message: "Invalid request body format",
-		})
+	if err := h.binder.Bind(&request, c); err != nil {
+		return h.handleBindError(c, err, requestID)
 	}
 
 	// Validate request
@@ -168,7 +392,7 @@ func (h *OrderHandler) AddItemToOrder(c echo.Context) error {
 	}
 
 	// Execute use case
-	response, err := h.orderUseCases.AddItemToOrder(c.Request().Context(), uint(orderID), &request)
+	response, err := h.orderUseCases.AddItemToOrder(c.Request().Context(), orderID, &request)
 	if err != nil {
 		return h.handleError(c, err, requestID, "Failed to add item to order")
 	}
@@ -178,30 +402,39 @@ func (h *OrderHandler) AddItemToOrder(c echo.Context) error {
 		"order_id", response.ID,
 		"product_id", request.ProductID)
 
-	return c.JSON(http.StatusOK, response)
+	return writeJSON(c, http.StatusOK, response)
 }
 
-// RemoveItemFromOrder handles DELETE /api/v1/orders/:id/items/:product_id
+// RemoveItemFromOrder handles DELETE /api/v1/orders/:id/items/:product_id, with a quantity
+// query parameter it cancels that quantity of the line of a confirmed order
 func (h *OrderHandler) RemoveItemFromOrder(c echo.Context) error {
-	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	requestID := RequestID(c)
 
 	// Parse order ID and product ID
-	orderID, err := parseUintParam(c, "id")
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "INVALID_ID",
 			Message: "Invalid order ID format",
 		})
 	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
 
 	productID, err := parseUintParam(c, "product_id")
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "INVALID_ID",
 			Message: "Invalid product ID format",
 		})
 	}
 
+	// A quantity cancels part or all of the line of a confirmed order instead
+	if c.QueryParam("quantity") != "" {
+		return h.cancelOrderItem(c, requestID, orderID, productID)
+	}
+
 	h.logger.Info("Remove item from order request received",
 		"request_id", requestID,
 		"order_id", orderID,
@@ -218,141 +451,147 @@ func (h *OrderHandler) RemoveItemFromOrder(c echo.Context) error {
 		"order_id", orderID,
 		"product_id", productID)
 
-	return c.JSON(http.StatusOK, response)
+	return writeJSON(c, http.StatusOK, response)
 }
 
-// UpdateItemQuantity handles PUT /api/v1/orders/:id/items/:product_id
-func (h *OrderHandler) UpdateItemQuantity(c echo.Context) error {
-	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
-
-	// Parse IDs
-	orderID, err := parseUintParam(c, "id")
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "INVALID_ID",
-			Message: "Invalid order ID format",
+// cancelOrderItem handles DELETE /api/v1/orders/:id/items/:product_id?quantity=N
+func (h *OrderHandler) cancelOrderItem(c echo.Context, requestID string, orderID, productID uint) error {
+	quantity, err := strconv.Atoi(c.QueryParam("quantity"))
+	if err != nil || quantity <= 0 {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   domainErrors.ErrInvalidQuantity.Code,
+			Message: "Quantity must be a positive integer",
 		})
 	}
 
-	productID, err := parseUintParam(c, "product_id")
+	h.logger.Info("Cancel order item request received",
+		"request_id", requestID,
+		"order_id", orderID,
+		"product_id", productID,
+		"quantity", quantity)
+
+	// Execute use case
+	response, err := h.orderUseCases.CancelOrderItem(c.Request().Context(), orderID, productID, quantity)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "INVALID_ID",
-			Message: "Invalid product ID format",
-		})
+		return h.handleError(c, err, requestID, "Failed to cancel order item")
 	}
 
-	h.logger.Info("Update item quantity request received",
+	h.logger.Info("Order item cancelled successfully",
 		"request_id", requestID,
 		"order_id", orderID,
-		"product_id", productID)
+		"product_id", productID,
+		"quantity", quantity)
 
-	// Parse request body
-	var request dto.UpdateOrderItemQuantityRequestDTO
-	if err := c.Bind(&request); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "INVALID_REQUEST",
-			Message: "Invalid request body format",
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// ReplaceOrderItems handles PUT /api/v1/orders/:id/items
+func (h *OrderHandler) ReplaceOrderItems(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
 		})
 	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	// Parse request body
+	var request dto.ReplaceOrderItemsRequestDTO
+	if err := h.binder.Bind(&request, c); err != nil {
+		return h.handleBindError(c, err, requestID)
+	}
 
 	// Validate request
 	if err := h.validator.Struct(request); err != nil {
 		return h.handleValidationError(c, err, requestID)
 	}
 
+	h.logger.Info("Replace order items request received",
+		"request_id", requestID,
+		"order_id", orderID,
+		"item_count", len(request.Items))
+
 	// Execute use case
-	response, err := h.orderUseCases.UpdateItemQuantity(c.Request().Context(), orderID, productID, &request)
+	response, err := h.orderUseCases.ReplaceOrderItems(c.Request().Context(), orderID, &request)
 	if err != nil {
-		return h.handleError(c, err, requestID, "Failed to update item quantity")
+		return h.handleError(c, err, requestID, "Failed to replace order items")
 	}
 
-	h.logger.Info("Item quantity updated successfully",
+	h.logger.Info("Order items replaced successfully",
 		"request_id", requestID,
 		"order_id", orderID,
-		"product_id", productID,
-		"quantity", request.Quantity)
+		"item_count", response.ItemCount)
 
-	return c.JSON(http.StatusOK, response)
+	return writeJSON(c, http.StatusOK, response)
 }
 
-// ConfirmOrder handles POST /api/v1/orders/:id/confirm
-func (h *OrderHandler) ConfirmOrder(c echo.Context) error {
-	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+// FulfillOrderItem handles POST /api/v1/orders/:id/items/:product_id/fulfill
+func (h *OrderHandler) FulfillOrderItem(c echo.Context) error {
+	requestID := RequestID(c)
 
-	orderID, err := parseUintParam(c, "id")
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
+	// Parse order ID and product ID
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "INVALID_ID",
 			Message: "Invalid order ID format",
 		})
 	}
-
-	h.logger.Info("Confirm order request received",
-		"request_id", requestID,
-		"order_id", orderID)
-
-	// Execute use case
-	response, err := h.orderUseCases.ConfirmOrder(c.Request().Context(), orderID)
 	if err != nil {
-		return h.handleError(c, err, requestID, "Failed to confirm order")
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
 	}
 
-	h.logger.Info("Order confirmed successfully",
-		"request_id", requestID,
-		"order_id", orderID)
-
-	return c.JSON(http.StatusOK, response)
-}
-
-// CancelOrder handles POST /api/v1/orders/:id/cancel
-func (h *OrderHandler) CancelOrder(c echo.Context) error {
-	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
-
-	orderID, err := parseUintParam(c, "id")
+	productID, err := parseUintParam(c, "product_id")
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "INVALID_ID",
-			Message: "Invalid order ID format",
+			Message: "Invalid product ID format",
 		})
 	}
 
-	h.logger.Info("Cancel order request received",
+	h.logger.Info("Fulfill order item request received",
 		"request_id", requestID,
-		"order_id", orderID)
+		"order_id", orderID,
+		"product_id", productID)
 
 	// Execute use case
-	response, err := h.orderUseCases.CancelOrder(c.Request().Context(), orderID)
+	response, err := h.orderUseCases.FulfillOrderItem(c.Request().Context(), orderID, productID)
 	if err != nil {
-		return h.handleError(c, err, requestID, "Failed to cancel order")
+		return h.handleError(c, err, requestID, "Failed to fulfill order item")
 	}
 
-	h.logger.Info("Order cancelled successfully",
+	h.logger.Info("Order item fulfilled successfully",
 		"request_id", requestID,
-		"order_id", orderID)
+		"order_id", orderID,
+		"product_id", productID)
 
-	return c.JSON(http.StatusOK, response)
+	return writeJSON(c, http.StatusOK, response)
 }
 
-// UpdateOrderStatus handles PUT /api/v1/orders/:id/status
-func (h *OrderHandler) UpdateOrderStatus(c echo.Context) error {
-	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+// AmendOrder handles POST /api/v1/orders/:id/amend
+func (h *OrderHandler) AmendOrder(c echo.Context) error {
+	requestID := RequestID(c)
 
-	orderID, err := parseUintParam(c, "id")
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "INVALID_ID",
 			Message: "Invalid order ID format",
 		})
 	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
 
 	// Parse request body
-	var request dto.UpdateOrderStatusRequestDTO
-	if err := c.Bind(&request); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "INVALID_REQUEST",
-			Message: "Invalid request body format",
-		})
+	var request dto.AmendOrderRequestDTO
+	if err := h.binder.Bind(&request, c); err != nil {
+		return h.handleBindError(c, err, requestID)
 	}
 
 	// Validate request
@@ -360,219 +599,1673 @@ func (h *OrderHandler) UpdateOrderStatus(c echo.Context) error {
 		return h.handleValidationError(c, err, requestID)
 	}
 
-	h.logger.Info("Update order status request received",
+	h.logger.Info("Amend order request received",
 		"request_id", requestID,
 		"order_id", orderID,
-		"new_status", request.Status)
+		"item_count", len(request.Items))
 
 	// Execute use case
-	response, err := h.orderUseCases.TransitionOrderStatus(c.Request().Context(), orderID, &request)
+	response, err := h.orderUseCases.AmendOrder(c.Request().Context(), orderID, &request)
 	if err != nil {
-		return h.handleError(c, err, requestID, "Failed to update order status")
+		return h.handleError(c, err, requestID, "Failed to amend order")
 	}
 
-	h.logger.Info("Order status updated successfully",
+	h.logger.Info("Order amended successfully",
 		"request_id", requestID,
 		"order_id", orderID,
-		"new_status", request.Status)
+		"amendment_count", response.AmendmentCount)
 
-	return c.JSON(http.StatusOK, response)
+	return writeJSON(c, http.StatusOK, response)
 }
 
-// ListOrders handles GET /api/v1/orders
-func (h *OrderHandler) ListOrders(c echo.Context) error {
-	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+// RepriceOrder handles POST /api/v1/orders/:id/reprice
+func (h *OrderHandler) RepriceOrder(c echo.Context) error {
+	requestID := RequestID(c)
 
-	h.logger.Info("List orders request received",
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	h.logger.Info("Reprice order request received",
 		"request_id", requestID,
-		"remote_ip", c.RealIP())
+		"order_id", orderID)
 
-	// Parse query parameters
-	page, pageSize := parsePaginationParams(c)
+	// Execute use case
+	response, err := h.orderUseCases.RepriceOrder(c.Request().Context(), orderID)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to reprice order")
+	}
+
+	h.logger.Info("Order repriced successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"changed_items", len(response.PriceChanges))
+
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// UpdateItemQuantity handles PUT /api/v1/orders/:id/items/:product_id
+func (h *OrderHandler) UpdateItemQuantity(c echo.Context) error {
+	requestID := RequestID(c)
+
+	// Parse IDs
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	productID, err := parseUintParam(c, "product_id")
+	if err != nil {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid product ID format",
+		})
+	}
+
+	h.logger.Info("Update item quantity request received",
+		"request_id", requestID,
+		"order_id", orderID,
+		"product_id", productID)
+
+	// Parse request body
+	var request dto.UpdateOrderItemQuantityRequestDTO
+	if err := h.binder.Bind(&request, c); err != nil {
+		return h.handleBindError(c, err, requestID)
+	}
+
+	// Validate request
+	if err := h.validator.Struct(request); err != nil {
+		return h.handleValidationError(c, err, requestID)
+	}
+
+	// Execute use case
+	response, err := h.orderUseCases.UpdateItemQuantity(c.Request().Context(), orderID, productID, &request)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to update item quantity")
+	}
+
+	h.logger.Info("Item quantity updated successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"product_id", productID,
+		"quantity", request.Quantity)
+
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// ConfirmOrder handles POST /api/v1/orders/:id/confirm
+func (h *OrderHandler) ConfirmOrder(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	// Parse the optional request body
+	var request dto.ConfirmOrderRequestDTO
+	if err := h.binder.Bind(&request, c); err != nil {
+		return h.handleBindError(c, err, requestID)
+	}
+
+	// Validate request
+	if err := h.validator.Struct(request); err != nil {
+		return h.handleValidationError(c, err, requestID)
+	}
+
+	h.logger.Info("Confirm order request received",
+		"request_id", requestID,
+		"order_id", orderID,
+		"shipping_method", request.ShippingMethod)
+
+	// Execute use case
+	response, err := h.orderUseCases.ConfirmOrder(c.Request().Context(), orderID, &request)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to confirm order")
+	}
+
+	h.logger.Info("Order confirmed successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"already_in_state", response.AlreadyInState)
+
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// CancelOrder handles POST /api/v1/orders/:id/cancel
+func (h *OrderHandler) CancelOrder(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	h.logger.Info("Cancel order request received",
+		"request_id", requestID,
+		"order_id", orderID)
+
+	// Execute use case
+	response, err := h.orderUseCases.CancelOrder(c.Request().Context(), orderID)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to cancel order")
+	}
+
+	h.logger.Info("Order cancelled successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"already_in_state", response.AlreadyInState)
+
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// HoldOrder handles POST /api/v1/orders/:id/hold
+func (h *OrderHandler) HoldOrder(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	// Parse request body
+	var request dto.PlaceOrderOnHoldRequestDTO
+	if err := h.binder.Bind(&request, c); err != nil {
+		return h.handleBindError(c, err, requestID)
+	}
+
+	// Validate request
+	if err := h.validator.Struct(request); err != nil {
+		return h.handleValidationError(c, err, requestID)
+	}
+
+	h.logger.Info("Hold order request received",
+		"request_id", requestID,
+		"order_id", orderID)
+
+	// Execute use case
+	response, err := h.orderUseCases.PlaceOrderOnHold(c.Request().Context(), orderID, &request)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to place order on hold")
+	}
+
+	h.logger.Info("Order placed on hold successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"held_from_status", response.HeldFromStatus,
+		"already_in_state", response.AlreadyInState)
+
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// ReleaseOrder handles POST /api/v1/orders/:id/release
+func (h *OrderHandler) ReleaseOrder(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	h.logger.Info("Release order request received",
+		"request_id", requestID,
+		"order_id", orderID)
+
+	// Execute use case
+	response, err := h.orderUseCases.ReleaseOrderHold(c.Request().Context(), orderID)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to release order hold")
+	}
+
+	h.logger.Info("Order released successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"status", response.Status,
+		"already_in_state", response.AlreadyInState)
+
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// UpdateOrderStatus handles PUT /api/v1/orders/:id/status
+func (h *OrderHandler) UpdateOrderStatus(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	// Parse request body
+	var request dto.UpdateOrderStatusRequestDTO
+	if err := h.binder.Bind(&request, c); err != nil {
+		return h.handleBindError(c, err, requestID)
+	}
+
+	// Validate request
+	if err := h.validator.Struct(request); err != nil {
+		return h.handleValidationError(c, err, requestID)
+	}
+
+	requested := request.Status
+	if err := request.Normalize(); err != nil {
+		return invalidStatusResponse(c, h.logger, requestID, string(requested))
+	}
+
+	h.logger.Info("Update order status request received",
+		"request_id", requestID,
+		"order_id", orderID,
+		"new_status", request.Status)
+
+	// Execute use case
+	response, err := h.orderUseCases.TransitionOrderStatus(c.Request().Context(), orderID, &request)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to update order status")
+	}
+
+	h.logger.Info("Order status updated successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"new_status", request.Status,
+		"already_in_state", response.AlreadyInState)
+
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// CreateShipment handles POST /api/v1/orders/:id/shipments
+func (h *OrderHandler) CreateShipment(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	// Parse request body
+	var request dto.CreateShipmentRequestDTO
+	if err := h.binder.Bind(&request, c); err != nil {
+		return h.handleBindError(c, err, requestID)
+	}
+
+	// Validate request
+	if err := h.validator.Struct(request); err != nil {
+		return h.handleValidationError(c, err, requestID)
+	}
+
+	h.logger.Info("Create shipment request received",
+		"request_id", requestID,
+		"order_id", orderID,
+		"item_count", len(request.Items))
+
+	// Execute use case
+	response, err := h.orderUseCases.CreateShipment(c.Request().Context(), orderID, &request)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to create shipment")
+	}
+
+	h.logger.Info("Shipment created successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"shipment_id", response.ID,
+		"order_status", response.OrderStatus)
+
+	return writeJSON(c, http.StatusCreated, response)
+}
+
+// ListShipments handles GET /api/v1/orders/:id/shipments
+func (h *OrderHandler) ListShipments(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	h.logger.Info("List shipments request received",
+		"request_id", requestID,
+		"order_id", orderID)
+
+	// Execute use case
+	response, err := h.orderUseCases.ListShipments(c.Request().Context(), orderID)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to list shipments")
+	}
+
+	h.logger.Info("Shipments retrieved successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"count", len(response.Shipments))
+
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// DeliverShipment handles POST /api/v1/orders/:id/shipments/:shipment_id/deliver
+func (h *OrderHandler) DeliverShipment(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	shipmentID, err := parseUintParam(c, "shipment_id")
+	if err != nil {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid shipment ID format",
+		})
+	}
+
+	h.logger.Info("Deliver shipment request received",
+		"request_id", requestID,
+		"order_id", orderID,
+		"shipment_id", shipmentID)
+
+	// Execute use case
+	response, err := h.orderUseCases.DeliverShipment(c.Request().Context(), orderID, shipmentID)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to deliver shipment")
+	}
+
+	h.logger.Info("Shipment delivered successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"shipment_id", shipmentID,
+		"order_status", response.OrderStatus)
+
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// CreateRefund handles POST /api/v1/orders/:id/refunds
+func (h *OrderHandler) CreateRefund(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	// Parse request body
+	var request dto.CreateRefundRequestDTO
+	if err := h.binder.Bind(&request, c); err != nil {
+		return h.handleBindError(c, err, requestID)
+	}
+
+	// Validate request
+	if err := h.validator.Struct(request); err != nil {
+		return h.handleValidationError(c, err, requestID)
+	}
+
+	h.logger.Info("Create refund request received",
+		"request_id", requestID,
+		"order_id", orderID,
+		"amount", request.Amount)
+
+	// Execute use case
+	response, err := h.orderUseCases.CreateRefund(c.Request().Context(), orderID, &request)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to create refund")
+	}
+
+	h.logger.Info("Refund created successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"refund_id", response.ID,
+		"order_status", response.OrderStatus)
+
+	return writeJSON(c, http.StatusCreated, response)
+}
+
+// ListRefunds handles GET /api/v1/orders/:id/refunds
+func (h *OrderHandler) ListRefunds(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	h.logger.Info("List refunds request received",
+		"request_id", requestID,
+		"order_id", orderID)
+
+	// Execute use case
+	response, err := h.orderUseCases.ListRefunds(c.Request().Context(), orderID)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to list refunds")
+	}
+
+	h.logger.Info("Refunds retrieved successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"count", len(response.Refunds))
+
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// ListOrderSnapshots handles GET /api/v1/orders/:id/snapshots
+func (h *OrderHandler) ListOrderSnapshots(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	h.logger.Info("List order snapshots request received",
+		"request_id", requestID,
+		"order_id", orderID)
+
+	// Execute use case
+	response, err := h.orderUseCases.ListOrderSnapshots(c.Request().Context(), orderID)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to list order snapshots")
+	}
+
+	h.logger.Info("Order snapshots retrieved successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"count", len(response.Snapshots))
+
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// GetOrderSnapshot handles GET /api/v1/orders/:id/snapshots/:version
+func (h *OrderHandler) GetOrderSnapshot(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   domainErrors.ErrInvalidSnapshotVersion.Code,
+			Message: "Invalid snapshot version format",
+		})
+	}
+
+	h.logger.Info("Get order snapshot request received",
+		"request_id", requestID,
+		"order_id", orderID,
+		"version", version)
+
+	// Execute use case
+	response, err := h.orderUseCases.GetOrderSnapshot(c.Request().Context(), orderID, version)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to get order snapshot")
+	}
+
+	h.logger.Info("Order snapshot retrieved successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"version", version)
+
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// GetOrderDocument handles GET /api/v1/orders/:id/document, answering the printable document of an order
+// as a download named after the order number
+func (h *OrderHandler) GetOrderDocument(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	hidePrices, err := parseHidePricesParam(c)
+	if err != nil {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: err.Error(),
+		})
+	}
+
+	request := &dto.OrderDocumentRequestDTO{
+		Format:      strings.ToLower(c.QueryParam("format")),
+		HidePrices:  hidePrices,
+		ShowContact: showsContact(c),
+	}
+
+	h.logger.Info("Get order document request received",
+		"request_id", requestID,
+		"order_id", orderID,
+		"format", request.Format)
+
+	// Execute use case
+	document, err := h.orderUseCases.GetOrderDocument(c.Request().Context(), orderID, request)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to get order document")
+	}
+
+	h.logger.Info("Order document rendered successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"filename", document.Filename)
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", document.Filename))
+	return c.Blob(http.StatusOK, document.ContentType, document.Content)
+}
+
+// parseHidePricesParam reads the optional hide_prices query parameter of GET /orders/:id/document
+func parseHidePricesParam(c echo.Context) (bool, error) {
+	value := c.QueryParam("hide_prices")
+	if value == "" {
+		return false, nil
+	}
+	hidePrices, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("hide_prices must be true or false, got %q", value)
+	}
+	return hidePrices, nil
+}
+
+// ScheduleTransition handles POST /api/v1/orders/:id/scheduled-transitions
+func (h *OrderHandler) ScheduleTransition(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	// Parse request body
+	var request dto.ScheduleTransitionRequestDTO
+	if err := h.binder.Bind(&request, c); err != nil {
+		return h.handleBindError(c, err, requestID)
+	}
+
+	// Validate request
+	if err := h.validator.Struct(request); err != nil {
+		return h.handleValidationError(c, err, requestID)
+	}
+
+	h.logger.Info("Schedule transition request received",
+		"request_id", requestID,
+		"order_id", orderID,
+		"target_status", request.TargetStatus,
+		"execute_at", request.ExecuteAt)
+
+	// Execute use case
+	response, err := h.orderUseCases.ScheduleTransition(c.Request().Context(), orderID, &request)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to schedule transition")
+	}
+
+	h.logger.Info("Transition scheduled successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"scheduled_transition_id", response.ID)
+
+	return writeJSON(c, http.StatusCreated, response)
+}
+
+// ListScheduledTransitions handles GET /api/v1/orders/:id/scheduled-transitions
+func (h *OrderHandler) ListScheduledTransitions(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	h.logger.Info("List scheduled transitions request received",
+		"request_id", requestID,
+		"order_id", orderID)
+
+	// Execute use case
+	response, err := h.orderUseCases.ListScheduledTransitions(c.Request().Context(), orderID)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to list scheduled transitions")
+	}
+
+	h.logger.Info("Scheduled transitions retrieved successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"count", len(response.ScheduledTransitions))
+
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// CancelScheduledTransition handles POST /api/v1/orders/:id/scheduled-transitions/:transition_id/cancel
+func (h *OrderHandler) CancelScheduledTransition(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	transitionID, err := parseUintParam(c, "transition_id")
+	if err != nil {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid scheduled transition ID format",
+		})
+	}
+
+	h.logger.Info("Cancel scheduled transition request received",
+		"request_id", requestID,
+		"order_id", orderID,
+		"scheduled_transition_id", transitionID)
+
+	// Execute use case
+	response, err := h.orderUseCases.CancelScheduledTransition(c.Request().Context(), orderID, transitionID)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to cancel scheduled transition")
+	}
+
+	h.logger.Info("Scheduled transition cancelled successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"scheduled_transition_id", transitionID)
+
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// ListOrders handles GET /api/v1/orders, the optional created_from and created_to query
+// parameters restrict the list to orders created in that range
+func (h *OrderHandler) ListOrders(c echo.Context) error {
+	requestID := RequestID(c)
+
+	h.logger.Info("List orders request received",
+		"request_id", requestID,
+		"remote_ip", c.RealIP())
+
+	// Parse query parameters
+	page, pageSize, err := parsePaginationParams(c)
+	if err != nil {
+		return invalidPaginationResponse(c, h.logger, requestID, err)
+	}
+
+	from, to, err := parseDateRange(c, "created_from", "created_to")
+	if err != nil {
+		h.logger.Warn("Invalid list date range",
+			"request_id", requestID,
+			"error", err)
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_FILTER",
+			Message: err.Error(),
+		})
+	}
+
+	if c.Request().Method == http.MethodHead {
+		return h.headOrders(c, requestID, func(ctx context.Context) (int64, error) {
+			if from != nil || to != nil {
+				return h.orderUseCases.CountOrdersByDateRange(ctx, from, to)
+			}
+			return h.orderUseCases.CountOrders(ctx, 0, "")
+		})
+	}
+
+	stream, err := parseStreamParam(c)
+	if err != nil {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_REQUEST",
+			Message: err.Error(),
+		})
+	}
+
+	shape, err := parseOrderListShape(c)
+	if err != nil {
+		return invalidExpandResponse(c)
+	}
 
 	h.logger.Info("List orders parameters",
+		"request_id", requestID,
+		"page", page,
+		"page_size", pageSize,
+		"created_from", from,
+		"created_to", to,
+		"stream", stream)
+
+	if stream {
+		return h.streamOrders(c, requestID, from, to, page, pageSize, shape)
+	}
+
+	freshness := h.listFreshness(c, requestID, ListRouteOrders, &dto.OrderFilterDTO{From: from, To: to})
+	if freshness.notModified(c) {
+		return freshness.writeNotModified(c)
+	}
+
+	// Execute use case
+	var response *dto.OrderListResponseDTO
+	if from != nil || to != nil {
+		response, err = h.orderUseCases.ListOrdersByDateRange(c.Request().Context(), from, to, page, pageSize)
+	} else {
+		response, err = h.orderUseCases.ListOrders(c.Request().Context(), page, pageSize)
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to list orders")
+	}
+
+	h.logger.Info("Orders listed successfully",
+		"request_id", requestID,
+		"count", len(response.Orders),
+		"page", page)
+
+	freshness.writeHeaders(c)
+	return writeOrderList(c, response, shape)
+}
+
+// streamOrders answers ListOrders with ?stream=true, encoding each order as it is converted instead of
+// building the whole list first. The body is the same document as the buffered response.
+func (h *OrderHandler) streamOrders(c echo.Context, requestID string, from, to *time.Time, page, pageSize int, shape orderListShape) error {
+	field := "orders"
+	if apiVersion(c) == APIv2 {
+		field = "data"
+	}
+
+	stream := newJSONArrayStream(c, field)
+	showContact := showsContact(c)
+	list, err := h.orderUseCases.StreamOrders(c.Request().Context(), from, to, page, pageSize, func(order *dto.OrderResponseDTO) error {
+		if !showContact {
+			order = order.WithoutContact()
+		}
+		return stream.Add(shape.order(order))
+	})
+	if err != nil {
+		if !stream.Started() {
+			return h.handleError(c, err, requestID, "Failed to list orders")
+		}
+		h.logger.Error("Order list aborted after streaming started",
+			"request_id", requestID,
+			"orders", stream.Count(),
+			"error", err)
+		return nil
+	}
+
+	h.logger.Info("Orders streamed successfully",
+		"request_id", requestID,
+		"count", stream.Count(),
+		"page", list.Page)
+
+	if apiVersion(c) == APIv2 {
+		envelope := newListEnvelope(c, nil, orderListPage(list))
+		return stream.Close(envelopeTrailer{Meta: envelope.Meta, Links: envelope.Links})
+	}
+	return stream.Close(newPageMetadata(list))
+}
+
+// pageMetadata is the navigation part of OrderListResponseDTO, written after a streamed list
+type pageMetadata struct {
+	Total       int64 `json:"total"`
+	Page        int   `json:"page"`
+	PageSize    int   `json:"page_size"`
+	TotalPages  int   `json:"total_pages"`
+	HasNext     bool  `json:"has_next"`
+	HasPrevious bool  `json:"has_previous"`
+}
+
+func newPageMetadata(list *dto.OrderListResponseDTO) pageMetadata {
+	return pageMetadata{
+		Total:       list.Total,
+		Page:        list.Page,
+		PageSize:    list.PageSize,
+		TotalPages:  list.TotalPages,
+		HasNext:     list.HasNext,
+		HasPrevious: list.HasPrevious,
+	}
+}
+
+// parseStreamParam reads the optional stream query parameter of list endpoints
+func parseStreamParam(c echo.Context) (bool, error) {
+	value := c.QueryParam("stream")
+	if value == "" {
+		return false, nil
+	}
+	stream, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("stream must be true or false, got %q", value)
+	}
+	return stream, nil
+}
+
+// headOrders answers HEAD on an order list with the number of orders the list would page through in
+// the X-Total-Count header, counting them without loading a page
+func (h *OrderHandler) headOrders(c echo.Context, requestID string, count func(ctx context.Context) (int64, error)) error {
+	total, err := count(c.Request().Context())
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to count orders")
+	}
+
+	c.Response().Header().Set(HeaderTotalCount, strconv.FormatInt(total, 10))
+	return c.NoContent(http.StatusOK)
+}
+
+// CountOrders handles GET /api/v1/orders/count, the optional status and customer_id query parameters
+// narrow the count the way they narrow the order lists
+func (h *OrderHandler) CountOrders(c echo.Context) error {
+	requestID := RequestID(c)
+
+	var status entities.OrderStatus
+	if statusParam := c.QueryParam("status"); statusParam != "" {
+		var err error
+		if status, err = entities.ParseOrderStatus(statusParam); err != nil {
+			return invalidStatusResponse(c, h.logger, requestID, statusParam)
+		}
+	}
+
+	var customerID uint
+	if customerParam := c.QueryParam("customer_id"); customerParam != "" {
+		id, err := strconv.ParseUint(customerParam, 10, 32)
+		if err != nil || id == 0 {
+			return WriteError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "INVALID_ID",
+				Message: "Invalid customer ID format",
+			})
+		}
+		customerID = uint(id)
+	}
+
+	h.logger.Info("Count orders request received",
+		"request_id", requestID,
+		"status", status,
+		"customer_id", customerID)
+
+	count, err := h.orderUseCases.CountOrders(c.Request().Context(), customerID, status)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to count orders")
+	}
+
+	return c.JSON(http.StatusOK, dto.OrderCountResponseDTO{Count: count})
+}
+
+// GetCustomerOrders handles GET /api/v1/customers/:customer_id/orders, the optional status query
+// parameter narrows the result to orders in that status
+func (h *OrderHandler) GetCustomerOrders(c echo.Context) error {
+	requestID := RequestID(c)
+
+	customerID, err := parseUintParam(c, "customer_id")
+	if err != nil {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid customer ID format",
+		})
+	}
+
+	var status entities.OrderStatus
+	if statusParam := c.QueryParam("status"); statusParam != "" {
+		if status, err = entities.ParseOrderStatus(statusParam); err != nil {
+			return invalidStatusResponse(c, h.logger, requestID, statusParam)
+		}
+	}
+
+	if c.Request().Method == http.MethodHead {
+		return h.headOrders(c, requestID, func(ctx context.Context) (int64, error) {
+			return h.orderUseCases.CountOrders(ctx, customerID, status)
+		})
+	}
+
+	// Parse query parameters
+	page, pageSize, err := parsePaginationParams(c)
+	if err != nil {
+		return invalidPaginationResponse(c, h.logger, requestID, err)
+	}
+
+	shape, err := parseOrderListShape(c)
+	if err != nil {
+		return invalidExpandResponse(c)
+	}
+
+	h.logger.Info("Get customer orders request received",
+		"request_id", requestID,
+		"customer_id", customerID,
+		"status", status,
+		"page", page,
+		"page_size", pageSize)
+
+	freshness := h.listFreshness(c, requestID, ListRouteCustomerOrders, &dto.OrderFilterDTO{CustomerID: customerID, Status: status})
+	if freshness.notModified(c) {
+		return freshness.writeNotModified(c)
+	}
+
+	// Execute use case
+	var response *dto.OrderListResponseDTO
+	if status != "" {
+		response, err = h.orderUseCases.GetCustomerOrdersByStatus(c.Request().Context(), customerID, status, page, pageSize)
+	} else {
+		response, err = h.orderUseCases.GetCustomerOrders(c.Request().Context(), customerID, page, pageSize)
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to get customer orders")
+	}
+
+	h.logger.Info("Customer orders retrieved successfully",
+		"request_id", requestID,
+		"customer_id", customerID,
+		"count", len(response.Orders))
+
+	freshness.writeHeaders(c)
+	return writeOrderList(c, response, shape)
+}
+
+// GetOrdersByStatus handles GET /api/v1/orders/status/:status, the optional customer_id query
+// parameter narrows the result to that customer's orders
+func (h *OrderHandler) GetOrdersByStatus(c echo.Context) error {
+	requestID := RequestID(c)
+
+	// Rejected here rather than in the use case so the response can list the valid statuses
+	statusParam := c.Param("status")
+	status, err := entities.ParseOrderStatus(statusParam)
+	if err != nil {
+		return invalidStatusResponse(c, h.logger, requestID, statusParam)
+	}
+
+	var customerID uint
+	if customerParam := c.QueryParam("customer_id"); customerParam != "" {
+		id, err := strconv.ParseUint(customerParam, 10, 32)
+		if err != nil || id == 0 {
+			return WriteError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "INVALID_ID",
+				Message: "Invalid customer ID format",
+			})
+		}
+		customerID = uint(id)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		return h.headOrders(c, requestID, func(ctx context.Context) (int64, error) {
+			return h.orderUseCases.CountOrders(ctx, customerID, status)
+		})
+	}
+
+	// Parse query parameters
+	page, pageSize, err := parsePaginationParams(c)
+	if err != nil {
+		return invalidPaginationResponse(c, h.logger, requestID, err)
+	}
+
+	shape, err := parseOrderListShape(c)
+	if err != nil {
+		return invalidExpandResponse(c)
+	}
+
+	h.logger.Info("Get orders by status request received",
+		"request_id", requestID,
+		"status", status,
+		"customer_id", customerID,
+		"page", page,
+		"page_size", pageSize)
+
+	freshness := h.listFreshness(c, requestID, ListRouteOrdersByStatus, &dto.OrderFilterDTO{CustomerID: customerID, Status: status})
+	if freshness.notModified(c) {
+		return freshness.writeNotModified(c)
+	}
+
+	// Execute use case
+	var response *dto.OrderListResponseDTO
+	if customerID != 0 {
+		response, err = h.orderUseCases.GetCustomerOrdersByStatus(c.Request().Context(), customerID, status, page, pageSize)
+	} else {
+		response, err = h.orderUseCases.GetOrdersByStatus(c.Request().Context(), status, page, pageSize)
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to get orders by status")
+	}
+
+	h.logger.Info("Orders by status retrieved successfully",
+		"request_id", requestID,
+		"status", status,
+		"count", len(response.Orders))
+
+	freshness.writeHeaders(c)
+	return writeOrderList(c, response, shape)
+}
+
+// ListBackorderedOrders handles GET /api/v1/orders/backordered
+func (h *OrderHandler) ListBackorderedOrders(c echo.Context) error {
+	requestID := RequestID(c)
+
+	// Parse query parameters
+	page, pageSize, err := parsePaginationParams(c)
+	if err != nil {
+		return invalidPaginationResponse(c, h.logger, requestID, err)
+	}
+
+	shape, err := parseOrderListShape(c)
+	if err != nil {
+		return invalidExpandResponse(c)
+	}
+
+	h.logger.Info("List backordered orders request received",
+		"request_id", requestID,
+		"page", page,
+		"page_size", pageSize)
+
+	// Execute use case
+	response, err := h.orderUseCases.ListBackorderedOrders(c.Request().Context(), page, pageSize)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to list backordered orders")
+	}
+
+	h.logger.Info("Backordered orders listed successfully",
+		"request_id", requestID,
+		"count", len(response.Orders),
+		"page", page)
+
+	return writeOrderList(c, response, shape)
+}
+
+// GetOrderQueue handles GET /api/v1/orders/queue, listing the orders in ?status, confirmed by default,
+// the most urgent first and the oldest first within a priority
+func (h *OrderHandler) GetOrderQueue(c echo.Context) error {
+	requestID := RequestID(c)
+
+	status := entities.OrderStatusConfirmed
+	if statusParam := c.QueryParam("status"); statusParam != "" {
+		parsed, err := entities.ParseOrderStatus(statusParam)
+		if err != nil {
+			return invalidStatusResponse(c, h.logger, requestID, statusParam)
+		}
+		status = parsed
+	}
+
+	// Parse query parameters
+	page, pageSize, err := parsePaginationParams(c)
+	if err != nil {
+		return invalidPaginationResponse(c, h.logger, requestID, err)
+	}
+
+	shape, err := parseOrderListShape(c)
+	if err != nil {
+		return invalidExpandResponse(c)
+	}
+
+	h.logger.Info("Get order queue request received",
+		"request_id", requestID,
+		"status", status,
+		"page", page,
+		"page_size", pageSize)
+
+	// Execute use case
+	response, err := h.orderUseCases.GetOrderQueue(c.Request().Context(), status, page, pageSize)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to get order queue")
+	}
+
+	h.logger.Info("Order queue retrieved successfully",
+		"request_id", requestID,
+		"status", status,
+		"count", len(response.Orders))
+
+	return writeOrderList(c, response, shape)
+}
+
+// UpdateOrder handles PATCH /api/v1/orders/:id
+func (h *OrderHandler) UpdateOrder(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	// Parse request body
+	var request dto.UpdateOrderRequestDTO
+	if err := h.binder.Bind(&request, c); err != nil {
+		return h.handleBindError(c, err, requestID)
+	}
+
+	// Validate request
+	if err := h.validator.Struct(request); err != nil {
+		return h.handleValidationError(c, err, requestID)
+	}
+
+	h.logger.Info("Update order request received",
+		"request_id", requestID,
+		"order_id", orderID,
+		"priority", request.Priority)
+
+	// Execute use case
+	response, err := h.orderUseCases.UpdateOrder(c.Request().Context(), orderID, &request)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to update order")
+	}
+
+	h.logger.Info("Order updated successfully",
+		"request_id", requestID,
+		"order_id", orderID,
+		"priority", response.Priority)
+
+	return writeJSON(c, http.StatusOK, response)
+}
+
+// DeleteOrder handles DELETE /api/v1/orders/:id
+func (h *OrderHandler) DeleteOrder(c echo.Context) error {
+	requestID := RequestID(c)
+
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid order ID format",
+		})
+	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
+
+	h.logger.Info("Delete order request received",
+		"request_id", requestID,
+		"order_id", orderID)
+
+	// Execute use case
+	err = h.orderUseCases.DeleteOrder(c.Request().Context(), orderID)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to delete order")
+	}
+
+	h.logger.Info("Order deleted successfully",
+		"request_id", requestID,
+		"order_id", orderID)
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListDeletedOrders handles GET /api/v1/admin/orders/deleted
+func (h *OrderHandler) ListDeletedOrders(c echo.Context) error {
+	requestID := RequestID(c)
+
+	page, pageSize, err := parsePaginationParams(c)
+	if err != nil {
+		return invalidPaginationResponse(c, h.logger, requestID, err)
+	}
+
+	h.logger.Info("List deleted orders request received",
 		"request_id", requestID,
 		"page", page,
 		"page_size", pageSize)
 
 	// Execute use case
-	response, err := h.orderUseCases.ListOrders(c.Request().Context(), page, pageSize)
+	response, err := h.orderUseCases.ListDeletedOrders(c.Request().Context(), page, pageSize)
 	if err != nil {
-		return h.handleError(c, err, requestID, "Failed to list orders")
+		return h.handleError(c, err, requestID, "Failed to list deleted orders")
 	}
 
-	h.logger.Info("Orders listed successfully",
+	h.logger.Info("Deleted orders listed successfully",
 		"request_id", requestID,
 		"count", len(response.Orders),
 		"page", page)
 
-	return c.JSON(http.StatusOK, response)
+	return writeList(c, response, response.Orders, listPage{
+		Page:       response.Page,
+		PageSize:   response.PageSize,
+		Total:      response.Total,
+		TotalPages: response.TotalPages,
+	})
 }
 
-// GetCustomerOrders handles GET /api/v1/customers/:customer_id/orders
-func (h *OrderHandler) GetCustomerOrders(c echo.Context) error {
-	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+// RestoreOrder handles POST /api/v1/admin/orders/:id/restore. Public IDs only resolve to orders
+// that are not deleted, so a deleted order is restored by its numeric ID.
+func (h *OrderHandler) RestoreOrder(c echo.Context) error {
+	requestID := RequestID(c)
 
-	customerID, err := parseUintParam(c, "customer_id")
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
+	orderID, err := orderIDParam(c, h.orderUseCases)
+	if errors.Is(err, errInvalidOrderID) {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "INVALID_ID",
-			Message: "Invalid customer ID format",
+			Message: "Invalid order ID format",
 		})
 	}
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to resolve order ID")
+	}
 
-	// Parse query parameters
-	page, pageSize := parsePaginationParams(c)
-
-	h.logger.Info("Get customer orders request received",
+	h.logger.Info("Restore order request received",
 		"request_id", requestID,
-		"customer_id", customerID,
-		"page", page,
-		"page_size", pageSize)
+		"order_id", orderID)
 
 	// Execute use case
-	response, err := h.orderUseCases.GetCustomerOrders(c.Request().Context(), customerID, page, pageSize)
+	response, err := h.orderUseCases.RestoreOrder(c.Request().Context(), orderID)
 	if err != nil {
-		return h.handleError(c, err, requestID, "Failed to get customer orders")
+		return h.handleError(c, err, requestID, "Failed to restore order")
 	}
 
-	h.logger.Info("Customer orders retrieved successfully",
+	h.logger.Info("Order restored successfully",
 		"request_id", requestID,
-		"customer_id", customerID,
-		"count", len(response.Orders))
+		"order_id", orderID)
 
-	return c.JSON(http.StatusOK, response)
+	return writeJSON(c, http.StatusOK, response)
 }
 
-// GetOrdersByStatus handles GET /api/v1/orders/status/:status
-func (h *OrderHandler) GetOrdersByStatus(c echo.Context) error {
-	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+// ExportOrders handles GET /api/v1/orders/export
+func (h *OrderHandler) ExportOrders(c echo.Context) error {
+	requestID := RequestID(c)
 
-	statusParam := c.Param("status")
-	if statusParam == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "INVALID_STATUS",
-			Message: "Status parameter is required",
+	format := c.QueryParam("format")
+	if format != "" && format != "csv" && format != "json" {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_FORMAT",
+			Message: "Unsupported export format, supported formats: csv, json",
+		})
+	}
+
+	filter, err := parseOrderFilter(c)
+	if err != nil {
+		h.logger.Warn("Invalid export filter",
+			"request_id", requestID,
+			"error", err)
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_FILTER",
+			Message: err.Error(),
 		})
 	}
 
-	status := entities.OrderStatus(statusParam)
+	h.logger.Info("Export orders request received",
+		"request_id", requestID,
+		"customer_id", filter.CustomerID,
+		"status", filter.Status,
+		"format", format,
+		"remote_ip", c.RealIP())
 
-	// Parse query parameters
-	page, pageSize := parsePaginationParams(c)
+	if format == "json" {
+		return h.exportOrdersJSON(c, requestID, filter)
+	}
 
-	h.logger.Info("Get orders by status request received",
+	// The response is started lazily so errors raised before the first row can still be reported as JSON
+	var writer *csv.Writer
+	start := func() error {
+		res := c.Response()
+		res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+		res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", exportFilename(filter, "csv")))
+		res.WriteHeader(http.StatusOK)
+
+		writer = csv.NewWriter(res)
+		return writer.Write(exportColumns)
+	}
+
+	rows := 0
+	err = h.orderUseCases.ExportOrders(c.Request().Context(), filter, func(order *dto.OrderResponseDTO) error {
+		if writer == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+
+		for _, record := range orderToExportRecords(order) {
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+			rows++
+		}
+
+		writer.Flush()
+		flushResponse(c)
+		return writer.Error()
+	})
+
+	if err != nil {
+		if writer == nil {
+			return h.handleError(c, err, requestID, "Failed to export orders")
+		}
+		// Headers are already sent, the truncated body is all the client will get
+		h.logger.Error("Export aborted after streaming started",
+			"request_id", requestID,
+			"rows", rows,
+			"error", err)
+		return nil
+	}
+
+	if writer == nil {
+		if err := start(); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+
+	h.logger.Info("Orders exported successfully",
 		"request_id", requestID,
-		"status", status,
-		"page", page,
-		"page_size", pageSize)
+		"rows", rows)
+
+	return writer.Error()
+}
+
+// exportOrdersJSON answers ExportOrders with format=json, a JSON array of orders encoded one by one
+// as the repository reads them, so memory use does not grow with the size of the export
+func (h *OrderHandler) exportOrdersJSON(c echo.Context, requestID string, filter *dto.OrderFilterDTO) error {
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", exportFilename(filter, "json")))
+
+	stream := newJSONArrayStream(c, "")
+	showContact := showsContact(c)
+	err := h.orderUseCases.ExportOrders(c.Request().Context(), filter, func(order *dto.OrderResponseDTO) error {
+		if !showContact {
+			order = order.WithoutContact()
+		}
+		return stream.Add(order)
+	})
+	if err != nil {
+		if !stream.Started() {
+			c.Response().Header().Del(echo.HeaderContentDisposition)
+			return h.handleError(c, err, requestID, "Failed to export orders")
+		}
+		// Headers are already sent, the truncated body is all the client will get
+		h.logger.Error("Export aborted after streaming started",
+			"request_id", requestID,
+			"orders", stream.Count(),
+			"error", err)
+		return nil
+	}
+
+	h.logger.Info("Orders exported successfully",
+		"request_id", requestID,
+		"orders", stream.Count())
+
+	return stream.Close(nil)
+}
+
+// GetOrderStats handles GET /api/v1/orders/stats
+func (h *OrderHandler) GetOrderStats(c echo.Context) error {
+	requestID := RequestID(c)
+
+	filter, err := parseOrderFilter(c)
+	if err != nil {
+		h.logger.Warn("Invalid stats filter",
+			"request_id", requestID,
+			"error", err)
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_FILTER",
+			Message: err.Error(),
+		})
+	}
+
+	h.logger.Info("Get order stats request received",
+		"request_id", requestID,
+		"customer_id", filter.CustomerID,
+		"status", filter.Status)
 
 	// Execute use case
-	response, err := h.orderUseCases.GetOrdersByStatus(c.Request().Context(), status, page, pageSize)
+	response, err := h.orderUseCases.GetOrderStats(c.Request().Context(), filter)
 	if err != nil {
-		return h.handleError(c, err, requestID, "Failed to get orders by status")
+		return h.handleError(c, err, requestID, "Failed to get order stats")
 	}
 
-	h.logger.Info("Orders by status retrieved successfully",
+	h.logger.Info("Order stats retrieved successfully",
 		"request_id", requestID,
-		"status", status,
-		"count", len(response.Orders))
+		"total_orders", response.TotalOrders)
 
-	return c.JSON(http.StatusOK, response)
+	return writeJSON(c, http.StatusOK, response)
 }
 
-// DeleteOrder handles DELETE /api/v1/orders/:id
-func (h *OrderHandler) DeleteOrder(c echo.Context) error {
-	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+// GetCustomerOrderSummary handles GET /api/v1/customers/:customer_id/orders/summary
+func (h *OrderHandler) GetCustomerOrderSummary(c echo.Context) error {
+	requestID := RequestID(c)
 
-	orderID, err := parseUintParam(c, "id")
+	customerID, err := parseUintParam(c, "customer_id")
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "INVALID_ID",
-			Message: "Invalid order ID format",
+			Message: "Invalid customer ID format",
 		})
 	}
 
-	h.logger.Info("Delete order request received",
+	h.logger.Info("Get customer order summary request received",
 		"request_id", requestID,
-		"order_id", orderID)
+		"customer_id", customerID)
 
 	// Execute use case
-	err = h.orderUseCases.DeleteOrder(c.Request().Context(), orderID)
+	response, err := h.orderUseCases.GetCustomerOrderSummary(c.Request().Context(), customerID)
 	if err != nil {
-		return h.handleError(c, err, requestID, "Failed to delete order")
+		return h.handleError(c, err, requestID, "Failed to get customer order summary")
 	}
 
-	h.logger.Info("Order deleted successfully",
+	h.logger.Info("Customer order summary retrieved successfully",
 		"request_id", requestID,
-		"order_id", orderID)
+		"customer_id", customerID,
+		"total_orders", response.TotalOrders)
 
-	return c.JSON(http.StatusNoContent, nil)
+	return writeJSON(c, http.StatusOK, response)
 }
 
 // Helper functions
 
+// handleError answers err by the code of its outermost domain error. The full error,
+// including any wrapped cause, is logged but never sent to the client.
 func (h *OrderHandler) handleError(c echo.Context, err error, requestID, logMessage string) error {
 	h.logger.Error(logMessage,
 		"request_id", requestID,
 		"error", err)
 
+	status, response := errorResponse(err)
+	return WriteError(c, status, response)
+}
+
+// errorResponse maps an error of the use cases to the status and body answering it
+func errorResponse(err error) (int, ErrorResponse) {
+	// Handle database calls that ran out of time
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout, ErrorResponse{
+			Error:   "GATEWAY_TIMEOUT",
+			Message: "The request timed out",
+		}
+	}
+
 	// Handle domain errors
 	var domainErr *domainErrors.DomainError
 	if errors.As(err, &domainErr) {
-		switch domainErr.Code {
-		case domainErrors.ErrOrderNotFound.Code:
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   domainErr.Code,
-				Message: domainErr.Message,
-			})
-		case domainErrors.ErrOrderAlreadyExists.Code:
-			return c.JSON(http.StatusConflict, ErrorResponse{
-				Error:   domainErr.Code,
-				Message: domainErr.Message,
-			})
-		case domainErrors.ErrInvalidCustomerID.Code,
-			domainErrors.ErrInvalidOrderStatus.Code,
-			domainErrors.ErrInvalidStatusTransition.Code,
-			domainErrors.ErrOrderAlreadyConfirmed.Code,
-			domainErrors.ErrOrderCannotBeCancelled.Code,
-			domainErrors.ErrEmptyOrder.Code,
-			domainErrors.ErrOrderItemNotFound.Code:
-			return c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   domainErr.Code,
-				Message: domainErr.Message,
-			})
-		default:
-			return c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   domainErr.Code,
-				Message: domainErr.Message,
-			})
+		return domainErrors.HTTPStatus(domainErr.Code), ErrorResponse{
+			Error:   domainErr.Code,
+			Message: domainErr.Message,
+			Details: domainErr.Details,
+		}
+	}
+
+	// Handle rejected status transitions, listing where the order can go instead.
+	// An expired order is a conflict with the current state rather than a bad request.
+	var transitionErr *entities.TransitionError
+	if errors.As(err, &transitionErr) {
+		code := domainErrors.ErrInvalidStatusTransition.Code
+		if errors.Is(err, entities.ErrOrderExpired) {
+			code = domainErrors.ErrOrderExpired.Code
+		}
+		return domainErrors.HTTPStatus(code), ErrorResponse{
+			Error:   code,
+			Message: transitionErr.Reason,
+			Details: map[string]interface{}{
+				"current_status":      transitionErr.From,
+				"requested_status":    transitionErr.To,
+				"allowed_transitions": transitionErr.Allowed,
+			},
 		}
 	}
 
 	// Handle generic errors
-	return c.JSON(http.StatusInternalServerError, ErrorResponse{
+	return http.StatusInternalServerError, ErrorResponse{
 		Error:   "INTERNAL_ERROR",
 		Message: "An internal error occurred",
-	})
+	}
 }
 
-func (h *OrderHandler) handleValidationError(c echo.Context, err error, requestID string) error {
-	h.logger.Warn("Request validation failed",
+// handleBindError answers a request whose body could not be decoded, pointing at the offending
+// position or field of malformed JSON. Bodies over the configured size limit get 413,
+// keys the DTO does not declare get UNKNOWN_FIELD under strict binding.
+func (h *OrderHandler) handleBindError(c echo.Context, err error, requestID string) error {
+	h.logger.Warn("Failed to bind request body",
 		"request_id", requestID,
 		"error", err)
 
-	details := make(map[string]interface{})
-	if validationErrors, ok := err.(validator.ValidationErrors); ok {
-		for _, fieldError := range validationErrors {
-			details[fieldError.Field()] = getValidationErrorMessage(fieldError)
+	status, response := bindErrorResponse(err)
+	return WriteError(c, status, response)
+}
+
+// bindErrorResponse maps an error decoding a request body to the status and body answering it
+func bindErrorResponse(err error) (int, ErrorResponse) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge, ErrorResponse{
+			Error:   "REQUEST_TOO_LARGE",
+			Message: "Request body is too large",
+			Details: map[string]interface{}{"limit_bytes": maxBytesErr.Limit},
+		}
+	}
+
+	var unknownFieldErr *UnknownFieldError
+	if errors.As(err, &unknownFieldErr) {
+		return http.StatusBadRequest, ErrorResponse{
+			Error:   "UNKNOWN_FIELD",
+			Message: fmt.Sprintf("Request body has an unknown field %q", unknownFieldErr.Field),
+			Details: map[string]interface{}{"field": unknownFieldErr.Field},
+		}
+	}
+
+	response := ErrorResponse{
+		Error:   "INVALID_REQUEST",
+		Message: "Invalid request body format",
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		response.Message = "Request body is not valid JSON"
+		response.Details = map[string]interface{}{
+			"offset": syntaxErr.Offset,
+			"error":  syntaxErr.Error(),
+		}
+	case errors.As(err, &typeErr):
+		response.Message = "Request body has a value of the wrong type"
+		response.Details = map[string]interface{}{
+			"field":    typeErr.Field,
+			"expected": typeErr.Type.String(),
+			"actual":   typeErr.Value,
+			"offset":   typeErr.Offset,
+		}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		response.Message = "Request body is not valid JSON"
+		response.Details = map[string]interface{}{
+			"error": "unexpected end of JSON input",
 		}
 	}
 
-	return c.JSON(http.StatusBadRequest, ErrorResponse{
+	return http.StatusBadRequest, response
+}
+
+func (h *OrderHandler) handleValidationError(c echo.Context, err error, requestID string) error {
+	h.logger.Warn("Request validation failed",
+		"request_id", requestID,
+		"error", err)
+
+	return WriteError(c, http.StatusBadRequest, ErrorResponse{
 		Error:   "VALIDATION_ERROR",
 		Message: "Request validation failed",
-		Details: details,
+		Details: validationDetails(err),
 	})
 }
 
@@ -585,29 +2278,231 @@ func parseUintParam(c echo.Context, paramName string) (uint, error) {
 	return uint(id), nil
 }
 
-func parsePaginationParams(c echo.Context) (int, int) {
+// OrderIDResolver finds the numeric ID of the order with a public ID, usecases.OrderUseCases implements it
+type OrderIDResolver interface {
+	ResolveOrderID(ctx context.Context, publicID string) (uint, error)
+}
+
+// errInvalidOrderID is returned by orderIDParam for a parameter that is neither a numeric ID nor a UUID
+var errInvalidOrderID = errors.New("invalid order ID")
+
+// orderIDParam reads the :id path parameter, which holds the numeric ID or the public UUID of an order.
+// A UUID is resolved to the numeric ID with resolver, whose errors are returned unchanged.
+func orderIDParam(c echo.Context, resolver OrderIDResolver) (uint, error) {
+	param := c.Param("id")
+	if entities.IsPublicID(param) && resolver != nil {
+		return resolver.ResolveOrderID(c.Request().Context(), param)
+	}
+
+	id, err := strconv.ParseUint(param, 10, 32)
+	if err != nil {
+		return 0, errInvalidOrderID
+	}
+	return uint(id), nil
+}
+
+// invalidStatusResponse answers a request naming an unknown order status with the list of valid ones
+func invalidStatusResponse(c echo.Context, log logger.Logger, requestID, status string) error {
+	log.Warn("Unknown order status",
+		"request_id", requestID,
+		"status", status)
+
+	return WriteError(c, http.StatusBadRequest, ErrorResponse{
+		Error:   domainErrors.ErrInvalidOrderStatus.Code,
+		Message: fmt.Sprintf("Unknown order status %q", status),
+		Details: map[string]interface{}{
+			"status":         status,
+			"valid_statuses": entities.OrderStatuses(),
+		},
+	})
+}
+
+// parsePaginationParams reads the page and page_size query parameters, absent parameters fall back
+// to the first page and the default page size. Invalid values are reported per parameter in the error
+// details. Pages count from 1 in APIv2, the returned page always counts from 0.
+func parsePaginationParams(c echo.Context) (int, int, error) {
+	first := firstPage(c)
 	page := 0
-	pageSize := 10
+	pageSize := dto.DefaultPageSize
+	details := make(map[string]interface{})
 
 	if pageParam := c.QueryParam("page"); pageParam != "" {
-		if p, err := strconv.Atoi(pageParam); err == nil && p >= 0 {
-			page = p
+		p, err := strconv.Atoi(pageParam)
+		if err != nil || p < first || p > dto.MaxPage+first {
+			details["page"] = fmt.Sprintf("must be an integer between %d and %d", first, dto.MaxPage+first)
 		}
+		// The use cases number pages from 0 whatever the API version
+		page = p - first
 	}
 
 	if sizeParam := c.QueryParam("page_size"); sizeParam != "" {
-		if ps, err := strconv.Atoi(sizeParam); err == nil && ps > 0 && ps <= 100 {
-			pageSize = ps
+		ps, err := strconv.Atoi(sizeParam)
+		if err != nil || ps < 1 || ps > dto.MaxPageSize {
+			details["page_size"] = fmt.Sprintf("must be an integer between 1 and %d", dto.MaxPageSize)
+		}
+		pageSize = ps
+	}
+
+	if len(details) > 0 {
+		return 0, 0, domainErrors.ErrInvalidPagination.WithDetails(details)
+	}
+
+	return page, pageSize, nil
+}
+
+// invalidPaginationResponse answers a request whose pagination parameters were rejected
+func invalidPaginationResponse(c echo.Context, log logger.Logger, requestID string, err error) error {
+	log.Warn("Invalid pagination parameters",
+		"request_id", requestID,
+		"error", err)
+
+	var domainErr *domainErrors.DomainError
+	errors.As(err, &domainErr)
+	return WriteError(c, http.StatusBadRequest, ErrorResponse{
+		Error:   domainErr.Code,
+		Message: domainErr.Message,
+		Details: domainErr.Details,
+	})
+}
+
+// parseOrderFilter reads the customer_id, status, from and to query parameters.
+// Dates are accepted as YYYY-MM-DD or RFC 3339; a date-only "to" includes the whole day.
+func parseOrderFilter(c echo.Context) (*dto.OrderFilterDTO, error) {
+	filter := &dto.OrderFilterDTO{}
+
+	if customerParam := c.QueryParam("customer_id"); customerParam != "" {
+		customerID, err := strconv.ParseUint(customerParam, 10, 32)
+		if err != nil || customerID == 0 {
+			return nil, errors.New("customer_id must be a positive integer")
+		}
+		filter.CustomerID = uint(customerID)
+	}
+
+	filter.Status = entities.OrderStatus(c.QueryParam("status"))
+
+	from, to, err := parseDateRange(c, "from", "to")
+	if err != nil {
+		return nil, err
+	}
+	filter.From = from
+	filter.To = to
+
+	return filter, nil
+}
+
+// parseDateRange reads an optional creation date range from the named query parameters.
+// A date-only end value covers that whole day, so the returned end is exclusive either way.
+func parseDateRange(c echo.Context, fromName, toName string) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+
+	if fromParam := c.QueryParam(fromName); fromParam != "" {
+		t, _, err := parseFilterTime(fromParam)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s must be a date (YYYY-MM-DD) or RFC 3339 timestamp", fromName)
+		}
+		from = &t
+	}
+
+	if toParam := c.QueryParam(toName); toParam != "" {
+		t, dateOnly, err := parseFilterTime(toParam)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s must be a date (YYYY-MM-DD) or RFC 3339 timestamp", toName)
 		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		to = &t
+	}
+
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, fmt.Errorf("%s must be before %s", fromName, toName)
+	}
+
+	return from, to, nil
+}
+
+func parseFilterTime(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
+
+var exportColumns = []string{
+	"order_id", "customer_id", "status", "total_amount", "created_at", "updated_at",
+	"item_id", "product_id", "product_sku", "product_name", "quantity", "unit_price", "total_price",
+}
+
+// orderToExportRecords returns one CSV record per item, repeating the order columns.
+// Orders without items produce a single record with empty item columns.
+func orderToExportRecords(order *dto.OrderResponseDTO) [][]string {
+	orderColumns := []string{
+		strconv.FormatUint(uint64(order.ID), 10),
+		strconv.FormatUint(uint64(order.CustomerID), 10),
+		string(order.Status),
+		strconv.FormatFloat(order.TotalAmount, 'f', 2, 64),
+		order.CreatedAt.UTC().Format(time.RFC3339),
+		order.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+
+	if len(order.Items) == 0 {
+		return [][]string{append(orderColumns, "", "", "", "", "", "", "")}
+	}
+
+	records := make([][]string, 0, len(order.Items))
+	for _, item := range order.Items {
+		record := make([]string, 0, len(exportColumns))
+		record = append(record, orderColumns...)
+		record = append(record,
+			strconv.FormatUint(uint64(item.ID), 10),
+			strconv.FormatUint(uint64(item.ProductID), 10),
+			csvText(item.ProductSKU),
+			csvText(item.ProductName),
+			strconv.Itoa(item.Quantity),
+			strconv.FormatFloat(item.UnitPrice, 'f', 2, 64),
+			strconv.FormatFloat(item.TotalPrice, 'f', 2, 64),
+		)
+		records = append(records, record)
 	}
+	return records
+}
+
+// csvText neutralizes free text that a spreadsheet would evaluate as a formula by prefixing it
+// with a single quote, e.g. a product named "=HYPERLINK(...)" is exported as "'=HYPERLINK(...)"
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
 
-	return page, pageSize
+// flushResponse pushes buffered bytes to the client when the underlying writer supports it
+func flushResponse(c echo.Context) {
+	_ = http.NewResponseController(c.Response().Writer).Flush()
+}
+
+// exportFilename names the export after its date range, e.g. orders_2025-01-01_2025-01-31.csv
+func exportFilename(filter *dto.OrderFilterDTO, extension string) string {
+	from, to := "start", time.Now().UTC().Format(time.DateOnly)
+	if filter.From != nil {
+		from = filter.From.UTC().Format(time.DateOnly)
+	}
+	if filter.To != nil {
+		// To is exclusive, name the file after the last included instant
+		to = filter.To.Add(-time.Nanosecond).UTC().Format(time.DateOnly)
+	}
+	return fmt.Sprintf("orders_%s_%s.%s", from, to, extension)
 }
 
 func getValidationErrorMessage(fieldError validator.FieldError) string {
 	switch fieldError.Tag() {
 	case "required":
 		return "This field is required"
+	case "required_without_all":
+		return "This field is required when no other field is set"
+	case "email":
+		return "Value must be a valid email address"
 	case "min":
 		return "Minimum value is " + fieldError.Param()
 	case "max":
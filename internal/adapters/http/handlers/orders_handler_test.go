@@ -3,10 +3,17 @@ package handlers
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"orders-service/internal/application/dto"
 	"orders-service/internal/domain/entities"
@@ -32,6 +39,14 @@ func (m *MockOrderUseCases) CreateOrder(ctx context.Context, request *dto.Create
 	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
 }
 
+func (m *MockOrderUseCases) ValidateOrder(ctx context.Context, request *dto.CreateOrderRequestDTO) (*dto.OrderValidationResponseDTO, error) {
+	args := m.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderValidationResponseDTO), args.Error(1)
+}
+
 func (m *MockOrderUseCases) GetOrder(ctx context.Context, id uint) (*dto.OrderResponseDTO, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -40,6 +55,35 @@ func (m *MockOrderUseCases) GetOrder(ctx context.Context, id uint) (*dto.OrderRe
 	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
 }
 
+func (m *MockOrderUseCases) GetOrderByPublicID(ctx context.Context, publicID string) (*dto.OrderResponseDTO, error) {
+	args := m.Called(ctx, publicID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) ResolveOrderID(ctx context.Context, publicID string) (uint, error) {
+	args := m.Called(ctx, publicID)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockOrderUseCases) GetOrderByNumber(ctx context.Context, number string) (*dto.OrderResponseDTO, error) {
+	args := m.Called(ctx, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) GetOrderByExternalReference(ctx context.Context, customerID uint, reference string) (*dto.OrderResponseDTO, error) {
+	args := m.Called(ctx, customerID, reference)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
+}
+
 func (m *MockOrderUseCases) AddItemToOrder(ctx context.Context, orderID uint, request *dto.AddOrderItemRequestDTO) (*dto.OrderResponseDTO, error) {
 	args := m.Called(ctx, orderID, request)
 	if args.Get(0) == nil {
@@ -64,11 +108,59 @@ func (m *MockOrderUseCases) UpdateItemQuantity(ctx context.Context, orderID, pro
 	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
 }
 
-func (m *MockOrderUseCases) ConfirmOrder(ctx context.Context, orderID uint) (*dto.OrderResponseDTO, error) {
+func (m *MockOrderUseCases) CancelOrderItem(ctx context.Context, orderID, productID uint, quantity int) (*dto.OrderResponseDTO, error) {
+	args := m.Called(ctx, orderID, productID, quantity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) ReplaceOrderItems(ctx context.Context, orderID uint, request *dto.ReplaceOrderItemsRequestDTO) (*dto.OrderResponseDTO, error) {
+	args := m.Called(ctx, orderID, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) AmendOrder(ctx context.Context, orderID uint, request *dto.AmendOrderRequestDTO) (*dto.OrderResponseDTO, error) {
+	args := m.Called(ctx, orderID, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) FulfillOrderItem(ctx context.Context, orderID, productID uint) (*dto.OrderResponseDTO, error) {
+	args := m.Called(ctx, orderID, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) UpdateOrder(ctx context.Context, orderID uint, request *dto.UpdateOrderRequestDTO) (*dto.OrderResponseDTO, error) {
+	args := m.Called(ctx, orderID, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) RepriceOrder(ctx context.Context, orderID uint) (*dto.RepriceOrderResponseDTO, error) {
 	args := m.Called(ctx, orderID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
+	return args.Get(0).(*dto.RepriceOrderResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) ConfirmOrder(ctx context.Context, orderID uint, request *dto.ConfirmOrderRequestDTO) (*dto.OrderResponseDTO, error) {
+	args := m.Called(ctx, orderID, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
 }
 
@@ -80,6 +172,22 @@ func (m *MockOrderUseCases) CancelOrder(ctx context.Context, orderID uint) (*dto
 	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
 }
 
+func (m *MockOrderUseCases) PlaceOrderOnHold(ctx context.Context, orderID uint, request *dto.PlaceOrderOnHoldRequestDTO) (*dto.OrderResponseDTO, error) {
+	args := m.Called(ctx, orderID, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) ReleaseOrderHold(ctx context.Context, orderID uint) (*dto.OrderResponseDTO, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
+}
+
 func (m *MockOrderUseCases) TransitionOrderStatus(ctx context.Context, orderID uint, request *dto.UpdateOrderStatusRequestDTO) (*dto.OrderResponseDTO, error) {
 	args := m.Called(ctx, orderID, request)
 	if args.Get(0) == nil {
@@ -88,712 +196,4172 @@ func (m *MockOrderUseCases) TransitionOrderStatus(ctx context.Context, orderID u
 	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
 }
 
-func (m *MockOrderUseCases) GetCustomerOrders(ctx context.Context, customerID uint, page, pageSize int) (*dto.OrderListResponseDTO, error) {
-	args := m.Called(ctx, customerID, page, pageSize)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*dto.OrderListResponseDTO), args.Error(1)
-}
+func (m *MockOrderUseCases) CreateShipment(ctx context.Context, orderID uint, request *dto.CreateShipmentRequestDTO) (*dto.ShipmentResponseDTO, error) {
+	args := m.Called(ctx, orderID, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ShipmentResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) ListShipments(ctx context.Context, orderID uint) (*dto.ShipmentListResponseDTO, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ShipmentListResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) DeliverShipment(ctx context.Context, orderID, shipmentID uint) (*dto.ShipmentResponseDTO, error) {
+	args := m.Called(ctx, orderID, shipmentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ShipmentResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) CreateRefund(ctx context.Context, orderID uint, request *dto.CreateRefundRequestDTO) (*dto.RefundResponseDTO, error) {
+	args := m.Called(ctx, orderID, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.RefundResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) ListRefunds(ctx context.Context, orderID uint) (*dto.RefundListResponseDTO, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.RefundListResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) ListOrderSnapshots(ctx context.Context, orderID uint) (*dto.OrderSnapshotListResponseDTO, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderSnapshotListResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) GetOrderSnapshot(ctx context.Context, orderID uint, version int) (*dto.OrderSnapshotResponseDTO, error) {
+	args := m.Called(ctx, orderID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderSnapshotResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) GetOrderDocument(ctx context.Context, orderID uint, request *dto.OrderDocumentRequestDTO) (*dto.OrderDocumentDTO, error) {
+	args := m.Called(ctx, orderID, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderDocumentDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) ScheduleTransition(ctx context.Context, orderID uint, request *dto.ScheduleTransitionRequestDTO) (*dto.ScheduledTransitionResponseDTO, error) {
+	args := m.Called(ctx, orderID, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ScheduledTransitionResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) ListScheduledTransitions(ctx context.Context, orderID uint) (*dto.ScheduledTransitionListResponseDTO, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ScheduledTransitionListResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) CancelScheduledTransition(ctx context.Context, orderID, transitionID uint) (*dto.ScheduledTransitionResponseDTO, error) {
+	args := m.Called(ctx, orderID, transitionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ScheduledTransitionResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) GetCustomerOrders(ctx context.Context, customerID uint, page, pageSize int) (*dto.OrderListResponseDTO, error) {
+	args := m.Called(ctx, customerID, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderListResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) GetOrdersByStatus(ctx context.Context, status entities.OrderStatus, page, pageSize int) (*dto.OrderListResponseDTO, error) {
+	args := m.Called(ctx, status, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderListResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) ListBackorderedOrders(ctx context.Context, page, pageSize int) (*dto.OrderListResponseDTO, error) {
+	args := m.Called(ctx, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderListResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) GetOrderQueue(ctx context.Context, status entities.OrderStatus, page, pageSize int) (*dto.OrderListResponseDTO, error) {
+	args := m.Called(ctx, status, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderListResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) GetCustomerOrdersByStatus(ctx context.Context, customerID uint, status entities.OrderStatus, page, pageSize int) (*dto.OrderListResponseDTO, error) {
+	args := m.Called(ctx, customerID, status, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderListResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) ListOrdersByDateRange(ctx context.Context, from, to *time.Time, page, pageSize int) (*dto.OrderListResponseDTO, error) {
+	args := m.Called(ctx, from, to, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderListResponseDTO), args.Error(1)
+}
+
+// StreamOrders hands the orders of the mocked list to fn and returns its metadata
+func (m *MockOrderUseCases) StreamOrders(ctx context.Context, from, to *time.Time, page, pageSize int, fn func(order *dto.OrderResponseDTO) error) (*dto.OrderListResponseDTO, error) {
+	args := m.Called(ctx, from, to, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	list := *args.Get(0).(*dto.OrderListResponseDTO)
+	for _, order := range list.Orders {
+		if err := fn(order); err != nil {
+			return nil, err
+		}
+	}
+	list.Orders = nil
+	return &list, args.Error(1)
+}
+
+func (m *MockOrderUseCases) ListOrders(ctx context.Context, page, pageSize int) (*dto.OrderListResponseDTO, error) {
+	args := m.Called(ctx, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderListResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) DeleteOrder(ctx context.Context, orderID uint) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockOrderUseCases) ListDeletedOrders(ctx context.Context, page, pageSize int) (*dto.DeletedOrderListResponseDTO, error) {
+	args := m.Called(ctx, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.DeletedOrderListResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) RestoreOrder(ctx context.Context, orderID uint) (*dto.OrderResponseDTO, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) ExportOrders(ctx context.Context, filter *dto.OrderFilterDTO, fn func(order *dto.OrderResponseDTO) error) error {
+	args := m.Called(ctx, filter)
+	if orders, ok := args.Get(0).([]*dto.OrderResponseDTO); ok {
+		for _, order := range orders {
+			if err := fn(order); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockOrderUseCases) GetOrderStats(ctx context.Context, filter *dto.OrderFilterDTO) (*dto.OrderStatsResponseDTO, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderStatsResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) GetCustomerOrderSummary(ctx context.Context, customerID uint) (*dto.CustomerOrderSummaryDTO, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.CustomerOrderSummaryDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) ExpirePendingOrders(ctx context.Context, now time.Time, batchSize int) (int, error) {
+	args := m.Called(ctx, now, batchSize)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockOrderUseCases) CountOrders(ctx context.Context, customerID uint, status entities.OrderStatus) (int64, error) {
+	args := m.Called(ctx, customerID, status)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOrderUseCases) CountOrdersByDateRange(ctx context.Context, from, to *time.Time) (int64, error) {
+	args := m.Called(ctx, from, to)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOrderUseCases) OrdersLastModified(ctx context.Context, filter *dto.OrderFilterDTO) (time.Time, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockOrderUseCases) ExecuteScheduledTransitions(ctx context.Context, now time.Time, batchSize int) (int, error) {
+	args := m.Called(ctx, now, batchSize)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockOrderUseCases) SubmitOrderJob(ctx context.Context, request *dto.CreateOrderRequestDTO) (*dto.OrderJobResponseDTO, error) {
+	args := m.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderJobResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) GetOrderJob(ctx context.Context, jobID string) (*dto.OrderJobResponseDTO, error) {
+	args := m.Called(ctx, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderJobResponseDTO), args.Error(1)
+}
+
+func (m *MockOrderUseCases) ProcessOrderJob(ctx context.Context, jobID string) error {
+	args := m.Called(ctx, jobID)
+	return args.Error(0)
+}
+
+func (m *MockOrderUseCases) ProcessPendingOrderJobs(ctx context.Context, before time.Time, batchSize int) (int, error) {
+	args := m.Called(ctx, before, batchSize)
+	return args.Int(0), args.Error(1)
+}
+
+// ImportOrders reads every line and reports it, failed when the reader rejected it and otherwise created
+// as the order numbered after its line
+func (m *MockOrderUseCases) ImportOrders(ctx context.Context, request *dto.ImportOrdersRequestDTO, next func() (*dto.ImportOrderLineDTO, error), fn func(result *dto.ImportOrderResultDTO) error) (*dto.ImportOrdersSummaryDTO, error) {
+	args := m.Called(ctx, request)
+	if err := args.Error(1); err != nil {
+		return nil, err
+	}
+
+	summary := &dto.ImportOrdersSummaryDTO{}
+	for {
+		line, err := next()
+		if errors.Is(err, io.EOF) {
+			return summary, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		result := &dto.ImportOrderResultDTO{Line: line.Line, Error: line.Error}
+		summary.Lines++
+		if line.Error != nil {
+			summary.Failed++
+		} else {
+			result.OrderID = uint(line.Line)
+			summary.Created++
+		}
+		if err := fn(result); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (m *MockOrderUseCases) CreateHistoricalOrder(ctx context.Context, request *dto.HistoricalOrderRequestDTO) (*dto.OrderResponseDTO, error) {
+	args := m.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OrderResponseDTO), args.Error(1)
+}
+
+func setupTestOrderHandler() (*OrderHandler, *MockOrderUseCases) {
+	mockUseCases := new(MockOrderUseCases)
+	log := logger.New("test")
+	handler := NewOrderHandler(mockUseCases, log)
+	return handler, mockUseCases
+}
+
+// CreateOrder Tests
+func TestOrderHandler_CreateOrder_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	requestBody := dto.CreateOrderRequestDTO{
+		CustomerID: 123,
+		Items: []dto.CreateOrderItemDTO{
+			{
+				ProductID:   1,
+				ProductSKU:  "SKU-001",
+				ProductName: "Product 1",
+				Quantity:    2,
+				UnitPrice:   10.50,
+			},
+		},
+	}
+
+	expectedResponse := &dto.OrderResponseDTO{
+		ID:          1,
+		CustomerID:  123,
+		Items:       []dto.OrderItemResponseDTO{},
+		ItemCount:   1,
+		TotalItems:  2,
+		TotalAmount: 21.00,
+		Status:      entities.OrderStatusPending,
+	}
+
+	mockUseCases.On("CreateOrder", mock.Anything, &requestBody).Return(expectedResponse, nil)
+
+	// Create request
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.CreateOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var response dto.OrderResponseDTO
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedResponse.ID, response.ID)
+	assert.Equal(t, expectedResponse.CustomerID, response.CustomerID)
+	assert.Equal(t, expectedResponse.Status, response.Status)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_CreateOrder_DryRun(t *testing.T) {
+	requestBody := dto.CreateOrderRequestDTO{
+		CustomerID: 123,
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 10.50},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		response     *dto.OrderValidationResponseDTO
+		expectStatus int
+	}{
+		{"valid cart", &dto.OrderValidationResponseDTO{Valid: true, Errors: []dto.OrderValidationErrorDTO{}, CustomerID: 123, TotalAmount: 21}, http.StatusOK},
+		{"rejected cart", &dto.OrderValidationResponseDTO{
+			Errors:      []dto.OrderValidationErrorDTO{{Error: "ORDER_BELOW_MINIMUM", Message: "Order total is below the minimum order amount", Field: "total_amount"}},
+			CustomerID:  123,
+			TotalAmount: 21,
+		}, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			handler, mockUseCases := setupTestOrderHandler()
+			mockUseCases.On("ValidateOrder", mock.Anything, &requestBody).Return(tt.response, nil)
+
+			jsonBody, _ := json.Marshal(requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/orders?dry_run=true", bytes.NewBuffer(jsonBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			// Execute
+			err := handler.CreateOrder(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectStatus, rec.Code)
+			var response dto.OrderValidationResponseDTO
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, *tt.response, response)
+			mockUseCases.AssertExpectations(t)
+			mockUseCases.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderHandler_CreateOrder_DryRunRequestValidation(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders?dry_run=true", bytes.NewBufferString(`{"items":[{"product_id":1,"product_sku":"SKU-001","product_name":"Product 1","quantity":0,"unit_price":10}]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.CreateOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	var response dto.OrderValidationResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.False(t, response.Valid)
+	assert.Equal(t, []dto.OrderItemResponseDTO{}, response.Items)
+	require.Len(t, response.Errors, 2)
+	assert.Equal(t, "customer_id", response.Errors[0].Field)
+	assert.Equal(t, "items[0].quantity", response.Errors[1].Field)
+	assert.Equal(t, "VALIDATION_ERROR", response.Errors[1].Error)
+	mockUseCases.AssertNotCalled(t, "ValidateOrder", mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_CreateOrder_InvalidDryRun(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders?dry_run=maybe", bytes.NewBufferString(`{"customer_id":123}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.CreateOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INVALID_REQUEST")
+	mockUseCases.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_CreateOrderAsync(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+	requestBody := dto.CreateOrderRequestDTO{
+		CustomerID: 123,
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 10.50},
+		},
+	}
+	job := &dto.OrderJobResponseDTO{
+		JobID:      "0f8fad5b-d9cb-469f-a165-70867728950e",
+		CustomerID: 123,
+		Status:     entities.OrderJobPending,
+		CreatedBy:  "importer",
+	}
+	mockUseCases.On("SubmitOrderJob", mock.Anything, &requestBody).Return(job, nil)
+
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/orders/async", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.CreateOrderAsync(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "/api/v2/orders/jobs/0f8fad5b-d9cb-469f-a165-70867728950e", rec.Header().Get(echo.HeaderLocation))
+	var response dto.OrderJobResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, job.JobID, response.JobID)
+	assert.Equal(t, entities.OrderJobPending, response.Status)
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_CreateOrderAsync_ValidationError(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/async", strings.NewReader(`{"customer_id":0,"items":[]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.CreateOrderAsync(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUseCases.AssertNotCalled(t, "SubmitOrderJob", mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_GetOrderJob(t *testing.T) {
+	tests := []struct {
+		name         string
+		jobID        string
+		mockSetup    func(m *MockOrderUseCases)
+		expectStatus int
+		expectError  string
+	}{
+		{
+			name:  "succeeded job",
+			jobID: "0F8FAD5B-D9CB-469F-A165-70867728950E",
+			mockSetup: func(m *MockOrderUseCases) {
+				m.On("GetOrderJob", mock.Anything, "0f8fad5b-d9cb-469f-a165-70867728950e").Return(&dto.OrderJobResponseDTO{
+					JobID:   "0f8fad5b-d9cb-469f-a165-70867728950e",
+					Status:  entities.OrderJobSucceeded,
+					OrderID: 42,
+				}, nil)
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:  "unknown job",
+			jobID: "0f8fad5b-d9cb-469f-a165-70867728950e",
+			mockSetup: func(m *MockOrderUseCases) {
+				m.On("GetOrderJob", mock.Anything, "0f8fad5b-d9cb-469f-a165-70867728950e").Return(nil, domainErrors.ErrOrderJobNotFound)
+			},
+			expectStatus: http.StatusNotFound,
+			expectError:  "ORDER_JOB_NOT_FOUND",
+		},
+		{
+			name:         "invalid job ID",
+			jobID:        "42",
+			mockSetup:    func(m *MockOrderUseCases) {},
+			expectStatus: http.StatusBadRequest,
+			expectError:  "INVALID_ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			handler, mockUseCases := setupTestOrderHandler()
+			tt.mockSetup(mockUseCases)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/jobs/"+tt.jobID, nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("job_id")
+			c.SetParamValues(tt.jobID)
+
+			// Execute
+			err := handler.GetOrderJob(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectStatus, rec.Code)
+			if tt.expectError != "" {
+				var response ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectError, response.Error)
+			}
+			mockUseCases.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderHandler_CreateOrder_TooManyPendingOrders(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	requestBody := dto.CreateOrderRequestDTO{CustomerID: 123}
+	limitErr := domainErrors.ErrTooManyPendingOrders.WithDetails(map[string]interface{}{
+		"pending_orders": int64(10),
+		"limit":          10,
+	})
+
+	mockUseCases.On("CreateOrder", mock.Anything, &requestBody).Return(nil, limitErr)
+
+	// Create request
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.CreateOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	var response ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "TOO_MANY_PENDING_ORDERS", response.Error)
+	assert.Equal(t, float64(10), response.Details["pending_orders"])
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_CreateOrder_ValidationError(t *testing.T) {
+	// Setup
+	handler, _ := setupTestOrderHandler()
+
+	requestBody := dto.CreateOrderRequestDTO{
+		CustomerID: 0, // Invalid - required
+		Items:      []dto.CreateOrderItemDTO{},
+	}
+
+	// Create request
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.CreateOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "VALIDATION_ERROR", response.Error)
+	assert.Equal(t, map[string]interface{}{
+		"message": "This field is required",
+		"tag":     "required",
+		"value":   float64(0),
+	}, response.Details["customer_id"])
+	assert.NotContains(t, response.Details, "CustomerID")
+}
+
+func TestOrderHandler_CreateOrder_InvalidItems(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("CreateOrder", mock.Anything, mock.Anything).Return(nil,
+		domainErrors.ErrInvalidOrderItems.WithDetails(map[string]interface{}{
+			"items[0].product_sku": "product SKU is required",
+			"items[3].quantity":    "quantity must be positive",
+		}))
+
+	body := `{"customer_id": 123, "items": [{"product_id": 1, "product_sku": " ", "product_name": "Widget", "quantity": 1, "unit_price": 10}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.CreateOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "INVALID_ORDER_ITEMS", response.Error)
+	assert.Equal(t, "product SKU is required", response.Details["items[0].product_sku"])
+	assert.Equal(t, "quantity must be positive", response.Details["items[3].quantity"])
+}
+
+func TestOrderHandler_CreateOrder_ItemValidationError(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	body := `{"customer_id": 123, "items": [
+		{"product_id": 1, "product_sku": "SKU-1", "product_name": "Widget", "quantity": 1, "unit_price": 10},
+		{"product_id": 2, "product_sku": "SKU-2", "product_name": "Gadget", "quantity": 0, "unit_price": -5}
+	]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.CreateOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "VALIDATION_ERROR", response.Error)
+	assert.Len(t, response.Details, 2)
+	assert.Equal(t, map[string]interface{}{
+		"message": "This field is required",
+		"tag":     "required",
+		"value":   float64(0),
+	}, response.Details["items[1].quantity"])
+	assert.Equal(t, map[string]interface{}{
+		"message": "Value must be greater than 0",
+		"tag":     "gt",
+		"value":   float64(-5),
+	}, response.Details["items[1].unit_price"])
+
+	mockUseCases.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_CreateOrder_MalformedJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		details map[string]interface{}
+	}{
+		{
+			name:    "truncated",
+			body:    `{"customer_id": 123, "items": [`,
+			details: map[string]interface{}{"error": "unexpected end of JSON input"},
+		},
+		{
+			name:    "syntax error",
+			body:    `{"customer_id": 123,, "items": []}`,
+			details: map[string]interface{}{"offset": float64(21), "error": "invalid character ',' looking for beginning of object key string"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			handler, mockUseCases := setupTestOrderHandler()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			// Execute
+			err := handler.CreateOrder(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var response ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, "INVALID_REQUEST", response.Error)
+			assert.Equal(t, tt.details, response.Details)
+
+			mockUseCases.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderHandler_CreateOrder_OversizedBody(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	body := `{"customer_id": 123, "items": [{"product_id": 1, "product_name": "` + strings.Repeat("a", 2048) + `"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	req.Body = http.MaxBytesReader(rec, req.Body, 1024)
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.CreateOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "REQUEST_TOO_LARGE", response.Error)
+	assert.Equal(t, float64(1024), response.Details["limit_bytes"])
+
+	mockUseCases.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_CreateOrder_UnknownField(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	body := `{"customer_id": 123, "items": [{"product_id": 1, "product_sku": "SKU-1", "product_name": "Widget", "quantity": 2, "unitprice": 9.99}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.CreateOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "UNKNOWN_FIELD", response.Error)
+	assert.Equal(t, "unitprice", response.Details["field"])
+
+	mockUseCases.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_UpdateOrderStatus_LenientBinding(t *testing.T) {
+	// Setup
+	mockUseCases := new(MockOrderUseCases)
+	handler := NewOrderHandlerWithConfig(mockUseCases, logger.New("test"), OrderHandlerConfig{StrictBinding: false})
+
+	expectedRequest := &dto.UpdateOrderStatusRequestDTO{Status: entities.OrderStatusShipped}
+	mockUseCases.On("TransitionOrderStatus", mock.Anything, uint(1), expectedRequest).
+		Return(&dto.OrderResponseDTO{ID: 1, Status: entities.OrderStatusShipped}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/1/status", strings.NewReader(`{"status": "shipped", "comment": "legacy client"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.UpdateOrderStatus(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+// GetOrder Tests
+func TestOrderHandler_GetOrder_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	expectedResponse := &dto.OrderResponseDTO{
+		ID:          1,
+		CustomerID:  123,
+		Items:       []dto.OrderItemResponseDTO{},
+		TotalAmount: 100.00,
+		Status:      entities.OrderStatusConfirmed,
+	}
+
+	mockUseCases.On("GetOrder", mock.Anything, uint(1)).Return(expectedResponse, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.GetOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.OrderResponseDTO
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedResponse.ID, response.ID)
+	assert.Equal(t, expectedResponse.CustomerID, response.CustomerID)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrder_ByPublicID(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+	publicID := "3f2504e0-4f89-41d3-9a0c-0305e82c3301"
+
+	expectedResponse := &dto.OrderResponseDTO{
+		ID:         1,
+		PublicID:   publicID,
+		CustomerID: 123,
+		Status:     entities.OrderStatusPending,
+	}
+
+	mockUseCases.On("GetOrderByPublicID", mock.Anything, publicID).Return(expectedResponse, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/"+publicID, nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(publicID)
+
+	// Execute
+	err := handler.GetOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.OrderResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, publicID, response.PublicID)
+
+	mockUseCases.AssertNotCalled(t, "GetOrder", mock.Anything, mock.Anything)
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrder_NotFound(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("GetOrder", mock.Anything, uint(999)).Return(nil, domainErrors.ErrOrderNotFound)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/999", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("999")
+
+	// Execute
+	err := handler.GetOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var response ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ORDER_NOT_FOUND", response.Error)
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrder_Deleted(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("GetOrder", mock.Anything, uint(7)).
+		Return(nil, domainErrors.ErrOrderDeleted.WithDetails(map[string]interface{}{"deleted_at": "2024-03-01T12:00:00Z"}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/7", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+
+	// Execute
+	err := handler.GetOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusGone, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "ORDER_DELETED", response.Error)
+	assert.Equal(t, "2024-03-01T12:00:00Z", response.Details["deleted_at"])
+}
+
+func TestOrderHandler_ListDeletedOrders(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	deletedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockUseCases.On("ListDeletedOrders", mock.Anything, 2, 5).Return(&dto.DeletedOrderListResponseDTO{
+		Orders:   []*dto.OrderSummaryResponseDTO{{ID: 4, CustomerID: 1, DeletedAt: &deletedAt}},
+		Total:    11,
+		Page:     2,
+		PageSize: 5,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/orders/deleted?page=2&page_size=5", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.ListDeletedOrders(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"deleted_at":"2024-03-01T12:00:00Z"`)
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_RestoreOrder(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("RestoreOrder", mock.Anything, uint(4)).Return(&dto.OrderResponseDTO{ID: 4}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/orders/4/restore", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("4")
+
+	// Execute
+	err := handler.RestoreOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrder_WrappedCauseIsNotExposed(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	cause := errors.New("pq: relation \"orders\" does not exist")
+	mockUseCases.On("GetOrder", mock.Anything, uint(1)).
+		Return(nil, domainErrors.WrapDomainError(domainErrors.ErrFailedToGetOrderStats, cause))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.GetOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, domainErrors.ErrFailedToGetOrderStats.Code, response.Error)
+	assert.Equal(t, domainErrors.ErrFailedToGetOrderStats.Message, response.Message)
+	assert.NotContains(t, rec.Body.String(), "relation")
+}
+
+func TestOrderHandler_GetOrder_Timeout(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("GetOrder", mock.Anything, uint(1)).Return(nil, fmt.Errorf("get order: %w", context.DeadlineExceeded))
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.GetOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+
+	var response ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "GATEWAY_TIMEOUT", response.Error)
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrder_InvalidID(t *testing.T) {
+	// Setup
+	handler, _ := setupTestOrderHandler()
+
+	// Create request with invalid ID
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/invalid", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("invalid")
+
+	// Execute
+	err := handler.GetOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "INVALID_ID", response.Error)
+}
+
+// AddItemToOrder Tests
+func TestOrderHandler_AddItemToOrder_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	requestBody := dto.AddOrderItemRequestDTO{
+		ProductID:   1,
+		ProductSKU:  "SKU-001",
+		ProductName: "Product 1",
+		Quantity:    2,
+		UnitPrice:   10.50,
+	}
+
+	expectedResponse := &dto.OrderResponseDTO{
+		ID:          1,
+		CustomerID:  123,
+		Items:       []dto.OrderItemResponseDTO{},
+		TotalAmount: 21.00,
+		Status:      entities.OrderStatusPending,
+	}
+
+	mockUseCases.On("AddItemToOrder", mock.Anything, uint(1), &requestBody).Return(expectedResponse, nil)
+
+	// Create request
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/items", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.AddItemToOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_AddItemToOrder_InvalidOnDuplicate(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	body := `{"product_id":1,"product_sku":"SKU-001","product_name":"Product 1","quantity":1,"unit_price":10,"on_duplicate":"replace"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/items", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.AddItemToOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "on_duplicate")
+	mockUseCases.AssertNotCalled(t, "AddItemToOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// RemoveItemFromOrder Tests
+func TestOrderHandler_RemoveItemFromOrder_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	expectedResponse := &dto.OrderResponseDTO{
+		ID:          1,
+		CustomerID:  123,
+		Items:       []dto.OrderItemResponseDTO{},
+		TotalAmount: 0.00,
+		Status:      entities.OrderStatusPending,
+	}
+
+	mockUseCases.On("RemoveItemFromOrder", mock.Anything, uint(1), uint(1)).Return(expectedResponse, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/orders/1/items/1", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id", "product_id")
+	c.SetParamValues("1", "1")
+
+	// Execute
+	err := handler.RemoveItemFromOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_RemoveItemFromOrder_CancelsQuantity(t *testing.T) {
+	handler, mockUseCases := setupTestOrderHandler()
+
+	expectedResponse := &dto.OrderResponseDTO{
+		ID:     1,
+		Status: entities.OrderStatusConfirmed,
+		CancelledItems: []dto.CancelledItemResponseDTO{
+			{ProductID: 1, Quantity: 2, UnitPrice: 10.50},
+		},
+	}
+	mockUseCases.On("CancelOrderItem", mock.Anything, uint(1), uint(1), 2).Return(expectedResponse, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/orders/1/items/1?quantity=2", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id", "product_id")
+	c.SetParamValues("1", "1")
+
+	err := handler.RemoveItemFromOrder(c)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"cancelled_items"`)
+	mockUseCases.AssertExpectations(t)
+	mockUseCases.AssertNotCalled(t, "RemoveItemFromOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_RemoveItemFromOrder_InvalidCancelQuantity(t *testing.T) {
+	for _, quantity := range []string{"0", "-1", "two"} {
+		t.Run(quantity, func(t *testing.T) {
+			handler, mockUseCases := setupTestOrderHandler()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/orders/1/items/1?quantity="+quantity, nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("id", "product_id")
+			c.SetParamValues("1", "1")
+
+			err := handler.RemoveItemFromOrder(c)
+
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), "INVALID_QUANTITY")
+			mockUseCases.AssertNotCalled(t, "CancelOrderItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderHandler_RemoveItemFromOrder_LastItemCancellation(t *testing.T) {
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("CancelOrderItem", mock.Anything, uint(1), uint(1), 3).Return(nil, domainErrors.ErrLastItemCancellation)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/orders/1/items/1?quantity=3", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id", "product_id")
+	c.SetParamValues("1", "1")
+
+	err := handler.RemoveItemFromOrder(c)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "LAST_ITEM_CANCELLATION")
+	mockUseCases.AssertExpectations(t)
+}
+
+// UpdateItemQuantity Tests
+func TestOrderHandler_UpdateItemQuantity_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	requestBody := dto.UpdateOrderItemQuantityRequestDTO{
+		Quantity: 5,
+	}
+
+	expectedResponse := &dto.OrderResponseDTO{
+		ID:          1,
+		CustomerID:  123,
+		Items:       []dto.OrderItemResponseDTO{},
+		TotalAmount: 52.50,
+		Status:      entities.OrderStatusPending,
+	}
+
+	mockUseCases.On("UpdateItemQuantity", mock.Anything, uint(1), uint(1), &requestBody).Return(expectedResponse, nil)
+
+	// Create request
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/1/items/1", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id", "product_id")
+	c.SetParamValues("1", "1")
+
+	// Execute
+	err := handler.UpdateItemQuantity(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_UpdateItemQuantity_WrongType(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/1/items/1", strings.NewReader(`{"quantity": "five"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id", "product_id")
+	c.SetParamValues("1", "1")
+
+	// Execute
+	err := handler.UpdateItemQuantity(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "INVALID_REQUEST", response.Error)
+	assert.Equal(t, "quantity", response.Details["field"])
+	assert.Equal(t, "int", response.Details["expected"])
+	assert.Equal(t, "string", response.Details["actual"])
+	assert.Equal(t, float64(19), response.Details["offset"])
+
+	mockUseCases.AssertNotCalled(t, "UpdateItemQuantity", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ConfirmOrder Tests
+func TestOrderHandler_ConfirmOrder_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	expectedResponse := &dto.OrderResponseDTO{
+		ID:          1,
+		CustomerID:  123,
+		Items:       []dto.OrderItemResponseDTO{},
+		TotalAmount: 100.00,
+		Status:      entities.OrderStatusConfirmed,
+	}
+
+	mockUseCases.On("ConfirmOrder", mock.Anything, uint(1), mock.Anything).Return(expectedResponse, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/confirm", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.ConfirmOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.OrderResponseDTO
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, entities.OrderStatusConfirmed, response.Status)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_ConfirmOrder_ByPublicID(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+	publicID := "3f2504e0-4f89-41d3-9a0c-0305e82c3301"
+
+	mockUseCases.On("ResolveOrderID", mock.Anything, publicID).Return(uint(1), nil)
+	mockUseCases.On("ConfirmOrder", mock.Anything, uint(1), mock.Anything).
+		Return(&dto.OrderResponseDTO{ID: 1, PublicID: publicID, Status: entities.OrderStatusConfirmed}, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/"+publicID+"/confirm", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(publicID)
+
+	// Execute
+	err := handler.ConfirmOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_ConfirmOrder_UnknownPublicID(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+	publicID := "3f2504e0-4f89-41d3-9a0c-0305e82c3301"
+
+	mockUseCases.On("ResolveOrderID", mock.Anything, publicID).Return(uint(0), domainErrors.ErrOrderNotFound)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/"+publicID+"/confirm", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(publicID)
+
+	// Execute
+	err := handler.ConfirmOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockUseCases.AssertNotCalled(t, "ConfirmOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_ConfirmOrder_EmptyOrder(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("ConfirmOrder", mock.Anything, uint(1), mock.Anything).Return(nil, domainErrors.ErrEmptyOrder)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/confirm", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.ConfirmOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "EMPTY_ORDER", response.Error)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_ConfirmOrder_Expired(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	order, _ := entities.NewOrder(123)
+	order.Status = entities.OrderStatusExpired
+	mockUseCases.On("ConfirmOrder", mock.Anything, uint(1), mock.Anything).Return(nil, order.ConfirmOrder())
+
+	// Create request
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/confirm", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.ConfirmOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	var response ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ORDER_EXPIRED", response.Error)
+	assert.Equal(t, "expired", response.Details["current_status"])
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_ConfirmOrder_WithShipping(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	expectedResponse := &dto.OrderResponseDTO{ID: 1, Status: entities.OrderStatusConfirmed, ShippingMethod: entities.ShippingMethodPickup}
+	mockUseCases.On("ConfirmOrder", mock.Anything, uint(1), mock.MatchedBy(func(request *dto.ConfirmOrderRequestDTO) bool {
+		return request.ShippingMethod == entities.ShippingMethodPickup && request.EstimatedDeliveryAt != nil
+	})).Return(expectedResponse, nil)
+
+	body := `{"shipping_method":"pickup","estimated_delivery_at":"2030-01-02T15:04:05Z"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/confirm", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.ConfirmOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"shipping_method":"pickup"`)
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_ConfirmOrder_InvalidShippingMethod(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/confirm", strings.NewReader(`{"shipping_method":"drone"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.ConfirmOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "shipping_method")
+	mockUseCases.AssertNotCalled(t, "ConfirmOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_ConfirmOrder_BelowMinimum(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	belowMinimum := domainErrors.ErrOrderBelowMinimum.WithDetails(map[string]interface{}{"minimum_amount": 5.0, "total_amount": 3.5, "shortfall": 1.5})
+	mockUseCases.On("ConfirmOrder", mock.Anything, uint(1), mock.MatchedBy(func(request *dto.ConfirmOrderRequestDTO) bool {
+		return request.OverrideMinimum
+	})).Return(nil, belowMinimum)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/confirm", strings.NewReader(`{"override_minimum":true}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.ConfirmOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "ORDER_BELOW_MINIMUM")
+	assert.Contains(t, rec.Body.String(), `"shortfall":1.5`)
+	mockUseCases.AssertExpectations(t)
+}
+
+// CancelOrder Tests
+func TestOrderHandler_CancelOrder_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	expectedResponse := &dto.OrderResponseDTO{
+		ID:          1,
+		CustomerID:  123,
+		Items:       []dto.OrderItemResponseDTO{},
+		TotalAmount: 100.00,
+		Status:      entities.OrderStatusCancelled,
+	}
+
+	mockUseCases.On("CancelOrder", mock.Anything, uint(1)).Return(expectedResponse, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/cancel", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.CancelOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.OrderResponseDTO
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, entities.OrderStatusCancelled, response.Status)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_RepeatedStatusChange_AlreadyInState(t *testing.T) {
+	response := &dto.OrderResponseDTO{ID: 1, Status: entities.OrderStatusCancelled, AlreadyInState: true}
+	tests := []struct {
+		name   string
+		method string
+		call   func(handler *OrderHandler, c echo.Context) error
+		body   string
+	}{
+		{"confirm", "ConfirmOrder", (*OrderHandler).ConfirmOrder, ""},
+		{"cancel", "CancelOrder", (*OrderHandler).CancelOrder, ""},
+		{"hold", "PlaceOrderOnHold", (*OrderHandler).HoldOrder, `{"reason":"fraud review"}`},
+		{"release", "ReleaseOrderHold", (*OrderHandler).ReleaseOrder, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			handler, mockUseCases := setupTestOrderHandler()
+			switch tt.method {
+			case "ConfirmOrder", "PlaceOrderOnHold":
+				mockUseCases.On(tt.method, mock.Anything, uint(1), mock.Anything).Return(response, nil)
+			default:
+				mockUseCases.On(tt.method, mock.Anything, uint(1)).Return(response, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/"+tt.name, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			// Execute
+			err := tt.call(handler, c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"already_in_state":true`)
+			mockUseCases.AssertExpectations(t)
+		})
+	}
+}
+
+// UpdateOrderStatus Tests
+func TestOrderHandler_UpdateOrderStatus_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	requestBody := dto.UpdateOrderStatusRequestDTO{
+		Status: entities.OrderStatusProcessing,
+	}
+
+	expectedResponse := &dto.OrderResponseDTO{
+		ID:          1,
+		CustomerID:  123,
+		Items:       []dto.OrderItemResponseDTO{},
+		TotalAmount: 100.00,
+		Status:      entities.OrderStatusProcessing,
+	}
+
+	mockUseCases.On("TransitionOrderStatus", mock.Anything, uint(1), &requestBody).Return(expectedResponse, nil)
+
+	// Create request
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/1/status", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.UpdateOrderStatus(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.OrderResponseDTO
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, entities.OrderStatusProcessing, response.Status)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_UpdateOrderStatus_NormalizesCase(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	expected := &dto.UpdateOrderStatusRequestDTO{Status: entities.OrderStatusShipped}
+	mockUseCases.On("TransitionOrderStatus", mock.Anything, uint(1), expected).Return(&dto.OrderResponseDTO{ID: 1, Status: entities.OrderStatusShipped}, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/1/status", bytes.NewBufferString(`{"status":" Shipped "}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.UpdateOrderStatus(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_UpdateOrderStatus_UnknownStatus(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	// Create request
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/1/status", bytes.NewBufferString(`{"status":"shiped"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.UpdateOrderStatus(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "INVALID_ORDER_STATUS", response.Error)
+	assert.Equal(t, "shiped", response.Details["status"])
+	assert.Contains(t, response.Details["valid_statuses"], "shipped")
+
+	mockUseCases.AssertNotCalled(t, "TransitionOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_UpdateOrderStatus_InvalidTransition(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	requestBody := dto.UpdateOrderStatusRequestDTO{
+		Status: entities.OrderStatusShipped,
+	}
+
+	order, _ := entities.NewOrder(123)
+	transitionErr := order.TransitionTo(entities.OrderStatusShipped)
+
+	mockUseCases.On("TransitionOrderStatus", mock.Anything, uint(1), &requestBody).Return(nil, transitionErr)
+
+	// Create request
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/1/status", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.UpdateOrderStatus(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "INVALID_STATUS_TRANSITION", response.Error)
+	assert.Equal(t, "only processing or partially shipped orders can be shipped", response.Message)
+	assert.Equal(t, "pending", response.Details["current_status"])
+	assert.Equal(t, "shipped", response.Details["requested_status"])
+	assert.Equal(t, []interface{}{"on_hold", "cancelled"}, response.Details["allowed_transitions"])
+
+	mockUseCases.AssertExpectations(t)
+}
+
+// Shipments Tests
+func TestOrderHandler_CreateShipment_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	expectedResponse := &dto.ShipmentResponseDTO{
+		ID:          7,
+		OrderID:     1,
+		Items:       []dto.ShipmentItemDTO{{ProductID: 1, Quantity: 2}},
+		Carrier:     "DHL",
+		OrderStatus: entities.OrderStatusPartiallyShipped,
+	}
+
+	mockUseCases.On("CreateShipment", mock.Anything, uint(1), mock.MatchedBy(func(request *dto.CreateShipmentRequestDTO) bool {
+		return len(request.Items) == 1 && request.Items[0].Quantity == 2 && request.Carrier == "DHL"
+	})).Return(expectedResponse, nil)
+
+	// Create request
+	body := `{"items":[{"product_id":1,"quantity":2}],"carrier":"DHL"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/shipments", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.CreateShipment(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var response dto.ShipmentResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, uint(7), response.ID)
+	assert.Equal(t, entities.OrderStatusPartiallyShipped, response.OrderStatus)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_CreateShipment_NoItems(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	// Create request
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/shipments", strings.NewReader(`{"items":[]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.CreateShipment(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUseCases.AssertNotCalled(t, "CreateShipment", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_CreateShipment_ExceedsUnshippedQuantity(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("CreateShipment", mock.Anything, uint(1), mock.Anything).
+		Return(nil, domainErrors.ErrInvalidShipment.WithDetails(map[string]interface{}{"reason": "quantity 3 exceeds the 2 unshipped units"}))
+
+	// Create request
+	body := `{"items":[{"product_id":1,"quantity":3}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/shipments", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.CreateShipment(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "INVALID_SHIPMENT", response.Error)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_ListShipments_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("ListShipments", mock.Anything, uint(1)).Return(&dto.ShipmentListResponseDTO{
+		OrderID:     1,
+		OrderStatus: entities.OrderStatusShipped,
+		Shipments: []*dto.ShipmentResponseDTO{
+			{ID: 1, OrderID: 1, Items: []dto.ShipmentItemDTO{{ProductID: 1, Quantity: 1}}},
+			{ID: 2, OrderID: 1, Items: []dto.ShipmentItemDTO{{ProductID: 1, Quantity: 1}}},
+		},
+	}, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1/shipments", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.ListShipments(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.ShipmentListResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Len(t, response.Shipments, 2)
+	assert.Equal(t, entities.OrderStatusShipped, response.OrderStatus)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_DeliverShipment_NotFound(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("DeliverShipment", mock.Anything, uint(1), uint(9)).Return(nil, domainErrors.ErrShipmentNotFound)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/shipments/9/deliver", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id", "shipment_id")
+	c.SetParamValues("1", "9")
+
+	// Execute
+	err := handler.DeliverShipment(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "SHIPMENT_NOT_FOUND", response.Error)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_CreateRefund_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	refundedAmount := 10.0
+	expectedResponse := &dto.RefundResponseDTO{
+		ID:             3,
+		OrderID:        1,
+		Amount:         10,
+		Reason:         "Damaged mug",
+		OrderStatus:    entities.OrderStatusDelivered,
+		RefundedAmount: &refundedAmount,
+	}
+
+	mockUseCases.On("CreateRefund", mock.Anything, uint(1), mock.MatchedBy(func(request *dto.CreateRefundRequestDTO) bool {
+		return request.Amount == 10 && request.Reason == "Damaged mug"
+	})).Return(expectedResponse, nil)
+
+	// Create request
+	body := `{"amount":10,"reason":"Damaged mug"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/refunds", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.CreateRefund(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var response dto.RefundResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, uint(3), response.ID)
+	assert.Equal(t, entities.OrderStatusDelivered, response.OrderStatus)
+	require.NotNil(t, response.RefundedAmount)
+	assert.Equal(t, 10.0, *response.RefundedAmount)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_CreateRefund_InvalidAmount(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	// Create request
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/refunds", strings.NewReader(`{"amount":-5}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.CreateRefund(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUseCases.AssertNotCalled(t, "CreateRefund", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_CreateRefund_ExceedsTotal(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("CreateRefund", mock.Anything, uint(1), mock.Anything).
+		Return(nil, domainErrors.ErrRefundExceedsTotal.WithDetails(map[string]interface{}{"remaining_amount": 5.0}))
+
+	// Create request
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/refunds", strings.NewReader(`{"amount":30}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.CreateRefund(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "REFUND_EXCEEDS_TOTAL", response.Error)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_ListRefunds_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("ListRefunds", mock.Anything, uint(1)).Return(&dto.RefundListResponseDTO{
+		OrderID:        1,
+		OrderStatus:    entities.OrderStatusRefunded,
+		TotalAmount:    25,
+		RefundedAmount: 25,
+		Refunds: []*dto.RefundResponseDTO{
+			{ID: 1, OrderID: 1, Amount: 10},
+			{ID: 2, OrderID: 1, Amount: 15},
+		},
+	}, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1/refunds", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.ListRefunds(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.RefundListResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Len(t, response.Refunds, 2)
+	assert.Equal(t, 25.0, response.RefundedAmount)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrderSnapshot_LeavesContactOut(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("GetOrderSnapshot", mock.Anything, uint(1), 2).Return(&dto.OrderSnapshotResponseDTO{
+		OrderID:       1,
+		Version:       2,
+		Reason:        entities.SnapshotReasonAmended,
+		SchemaVersion: 4,
+		Payload:       json.RawMessage(`{"order_id":1,"total_amount":25,"customer_email":"jane@example.com"}`),
+	}, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1/snapshots/2", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id", "version")
+	c.SetParamValues("1", "2")
+
+	// Execute
+	err := handler.GetOrderSnapshot(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.OrderSnapshotResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Version)
+	assert.JSONEq(t, `{"order_id":1,"total_amount":25}`, string(response.Payload))
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrderSnapshot_InvalidVersion(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1/snapshots/latest", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id", "version")
+	c.SetParamValues("1", "latest")
+
+	// Execute
+	err := handler.GetOrderSnapshot(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "INVALID_SNAPSHOT_VERSION", response.Error)
+	mockUseCases.AssertNotCalled(t, "GetOrderSnapshot", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_GetOrderDocument(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("GetOrderDocument", mock.Anything, uint(1), &dto.OrderDocumentRequestDTO{Format: "html", HidePrices: true}).
+		Return(&dto.OrderDocumentDTO{
+			ContentType: "text/html; charset=utf-8",
+			Filename:    "order-ORD-2025-000001.html",
+			Content:     []byte("<html></html>"),
+		}, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1/document?format=HTML&hide_prices=true", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.GetOrderDocument(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `inline; filename="order-ORD-2025-000001.html"`, rec.Header().Get(echo.HeaderContentDisposition))
+	assert.Equal(t, "<html></html>", rec.Body.String())
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrderDocument_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		err      error
+		expected int
+		code     string
+	}{
+		{"invalid hide_prices", "?hide_prices=maybe", nil, http.StatusBadRequest, "INVALID_REQUEST"},
+		{"unknown format", "?format=docx", domainErrors.ErrInvalidDocumentFormat, http.StatusBadRequest, "INVALID_DOCUMENT_FORMAT"},
+		{"pdf unavailable", "?format=pdf", domainErrors.ErrDocumentFormatUnavailable, http.StatusNotImplemented, "DOCUMENT_FORMAT_UNAVAILABLE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			handler, mockUseCases := setupTestOrderHandler()
+			if tt.err != nil {
+				mockUseCases.On("GetOrderDocument", mock.Anything, uint(1), mock.Anything).Return(nil, tt.err)
+			}
+
+			// Create request
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1/document"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			// Execute
+			err := handler.GetOrderDocument(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rec.Code)
+			var response ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.code, response.Error)
+		})
+	}
+}
+
+func TestOrderHandler_ListOrderSnapshots_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("ListOrderSnapshots", mock.Anything, uint(1)).Return(&dto.OrderSnapshotListResponseDTO{
+		OrderID: 1,
+		Snapshots: []*dto.OrderSnapshotResponseDTO{
+			{OrderID: 1, Version: 1, Reason: entities.SnapshotReasonConfirmed, SchemaVersion: 4},
+			{OrderID: 1, Version: 2, Reason: entities.SnapshotReasonAmended, SchemaVersion: 4},
+		},
+	}, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1/snapshots", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.ListOrderSnapshots(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.OrderSnapshotListResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.Snapshots, 2)
+	assert.Equal(t, entities.SnapshotReasonAmended, response.Snapshots[1].Reason)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+// ScheduleTransition Tests
+
+func TestOrderHandler_ScheduleTransition_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+	executeAt := time.Date(2030, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	expectedResponse := &dto.ScheduledTransitionResponseDTO{
+		ID:           3,
+		OrderID:      1,
+		TargetStatus: entities.OrderStatusCancelled,
+		ExecuteAt:    executeAt,
+		Status:       entities.ScheduledTransitionPending,
+	}
+
+	mockUseCases.On("ScheduleTransition", mock.Anything, uint(1), mock.MatchedBy(func(request *dto.ScheduleTransitionRequestDTO) bool {
+		return request.TargetStatus == entities.OrderStatusCancelled && request.ExecuteAt.Equal(executeAt)
+	})).Return(expectedResponse, nil)
+
+	// Create request
+	body := `{"target_status":"cancelled","execute_at":"2030-01-02T12:00:00Z"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/scheduled-transitions", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.ScheduleTransition(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var response dto.ScheduledTransitionResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, uint(3), response.ID)
+	assert.Equal(t, entities.ScheduledTransitionPending, response.Status)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_ScheduleTransition_MissingExecuteAt(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	// Create request
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/scheduled-transitions", strings.NewReader(`{"target_status":"cancelled"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.ScheduleTransition(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUseCases.AssertNotCalled(t, "ScheduleTransition", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_CancelScheduledTransition_NotPending(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+	mockUseCases.On("CancelScheduledTransition", mock.Anything, uint(1), uint(3)).Return(nil, domainErrors.ErrScheduledTransitionNotPending)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/scheduled-transitions/3/cancel", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id", "transition_id")
+	c.SetParamValues("1", "3")
+
+	// Execute
+	err := handler.CancelScheduledTransition(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "SCHEDULED_TRANSITION_NOT_PENDING", response.Error)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+// ListOrders Tests
+func TestOrderHandler_ListOrders_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	expectedOrders := []*dto.OrderResponseDTO{
+		{
+			ID:          1,
+			CustomerID:  123,
+			Items:       []dto.OrderItemResponseDTO{},
+			TotalAmount: 100.00,
+			Status:      entities.OrderStatusPending,
+		},
+		{
+			ID:          2,
+			CustomerID:  456,
+			Items:       []dto.OrderItemResponseDTO{},
+			TotalAmount: 200.00,
+			Status:      entities.OrderStatusConfirmed,
+		},
+	}
+
+	expectedResponse := &dto.OrderListResponseDTO{
+		Orders:   expectedOrders,
+		Total:    50,
+		Page:     0,
+		PageSize: 10,
+	}
+
+	mockUseCases.On("ListOrders", mock.Anything, 0, 10).Return(expectedResponse, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.ListOrders(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.OrderListResponseDTO
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Len(t, response.Orders, 2)
+	assert.Equal(t, int64(50), response.Total)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_ListOrders_WithPagination(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	expectedResponse := &dto.OrderListResponseDTO{
+		Orders:   []*dto.OrderResponseDTO{},
+		Total:    0,
+		Page:     2,
+		PageSize: 5,
+	}
+
+	mockUseCases.On("ListOrders", mock.Anything, 2, 5).Return(expectedResponse, nil)
+
+	// Create request with pagination parameters
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders?page=2&page_size=5", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.ListOrders(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_ListOrders_InvalidPagination(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		invalid []string
+	}{
+		{"negative page", "page=-5", []string{"page"}},
+		{"page size too large", "page_size=9999", []string{"page_size"}},
+		{"non-numeric page size", "page_size=abc", []string{"page_size"}},
+		{"zero page size", "page_size=0", []string{"page_size"}},
+		{"both", "page=x&page_size=-1", []string{"page", "page_size"}},
+		{"page whose offset overflows", "page=9223372036854775807&page_size=100", []string{"page"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			handler, mockUseCases := setupTestOrderHandler()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/orders?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			// Execute
+			err := handler.ListOrders(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var response ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, "INVALID_PAGINATION", response.Error)
+			assert.Len(t, response.Details, len(tt.invalid))
+			for _, field := range tt.invalid {
+				assert.Contains(t, response.Details, field)
+			}
+
+			mockUseCases.AssertNotCalled(t, "ListOrders", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderHandler_ListOrders_DateRange(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		query      string
+		expectFrom *time.Time
+		expectTo   *time.Time
+	}{
+		{"date only, end covers the whole day", "created_from=2024-03-01&created_to=2024-03-30", &from, &to},
+		{"rfc3339", "created_from=2024-03-01T00:00:00Z&created_to=2024-03-31T00:00:00Z", &from, &to},
+		{"open end", "created_from=2024-03-01", &from, nil},
+		{"open start", "created_to=2024-03-30", nil, &to},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockUseCases := setupTestOrderHandler()
+			mockUseCases.On("ListOrdersByDateRange", mock.Anything, tt.expectFrom, tt.expectTo, 0, 10).
+				Return(&dto.OrderListResponseDTO{Orders: []*dto.OrderResponseDTO{}}, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/orders?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			err := handler.ListOrders(c)
+
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			mockUseCases.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderHandler_ListOrders_InvalidDateRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"from after to", "created_from=2024-03-10&created_to=2024-03-01"},
+		{"malformed from", "created_from=yesterday"},
+		{"malformed to", "created_to=2024-13-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockUseCases := setupTestOrderHandler()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/orders?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			err := handler.ListOrders(c)
+
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), "INVALID_FILTER")
+			mockUseCases.AssertExpectations(t)
+		})
+	}
+}
+
+// FuzzParsePaginationParams checks that any page and page_size parse to a page whose offset fits in an int
+// or fail with INVALID_PAGINATION, under both API versions
+func FuzzParsePaginationParams(f *testing.F) {
+	f.Add("0", "10", false)
+	f.Add("1", "100", true)
+	f.Add("-1", "0", false)
+	f.Add("9223372036854775807", "100", false)
+	f.Add("-9223372036854775808", "1", true)
+	f.Add("1e3", "+5", false)
+	f.Add("", "\x00", true)
+
+	f.Fuzz(func(t *testing.T, page, pageSize string, v2 bool) {
+		query := url.Values{}
+		query.Set("page", page)
+		query.Set("page_size", pageSize)
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/orders?"+query.Encode(), nil), httptest.NewRecorder())
+		if v2 {
+			c.Set(apiVersionKey, APIv2)
+		}
+
+		parsedPage, parsedSize, err := parsePaginationParams(c)
+		if err != nil {
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidPagination)
+			return
+		}
+		assert.GreaterOrEqual(t, parsedPage, 0)
+		assert.LessOrEqual(t, parsedPage, dto.MaxPage)
+		assert.GreaterOrEqual(t, parsedSize, 1)
+		assert.LessOrEqual(t, parsedSize, dto.MaxPageSize)
+	})
+}
+
+// FuzzParseOrderFilter checks that any filter query parses to a filter with an ordered date range or fails
+func FuzzParseOrderFilter(f *testing.F) {
+	f.Add("123", "pending", "2024-01-01", "2024-01-31")
+	f.Add("0", "", "2024-01-31", "2024-01-01")
+	f.Add("4294967296", "bogus", "2024-02-30", "2024-01-01T00:00:00Z")
+	f.Add("", "", "2024-01-01T00:00:00+14:00", "2024-01-01")
+
+	f.Fuzz(func(t *testing.T, customerID, status, from, to string) {
+		query := url.Values{}
+		query.Set("customer_id", customerID)
+		query.Set("status", status)
+		query.Set("from", from)
+		query.Set("to", to)
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/orders?"+query.Encode(), nil), httptest.NewRecorder())
+
+		filter, err := parseOrderFilter(c)
+		if err != nil {
+			assert.Nil(t, filter)
+			return
+		}
+		if filter.From != nil && filter.To != nil {
+			assert.True(t, filter.From.Before(*filter.To))
+		}
+	})
+}
+
+func TestOrderHandler_ListOrders_DefaultPagination(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("ListOrders", mock.Anything, 0, 10).Return(&dto.OrderListResponseDTO{Orders: []*dto.OrderResponseDTO{}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.ListOrders(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_ListOrders_Stream(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	buffered := &dto.OrderListResponseDTO{
+		Orders: []*dto.OrderResponseDTO{
+			{ID: 1, CustomerID: 123, Items: []dto.OrderItemResponseDTO{}, TotalAmount: 100, Status: entities.OrderStatusPending},
+			{ID: 2, CustomerID: 456, Items: []dto.OrderItemResponseDTO{}, TotalAmount: 200, Status: entities.OrderStatusConfirmed},
+		},
+		Total:      12,
+		Page:       1,
+		PageSize:   2,
+		TotalPages: 6,
+		HasNext:    true,
+	}
+	mockUseCases.On("StreamOrders", mock.Anything, (*time.Time)(nil), (*time.Time)(nil), 1, 2).Return(buffered, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders?stream=true&page=1&page_size=2", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.ListOrders(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, echo.MIMEApplicationJSON, rec.Header().Get(echo.HeaderContentType))
+
+	// The streamed body is the same document as the buffered one
+	expected, err := json.Marshal(buffered)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), rec.Body.String())
+	mockUseCases.AssertNotCalled(t, "ListOrders", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_ListOrders_StreamEmptyPage(t *testing.T) {
+	handler, mockUseCases := setupTestOrderHandler()
+	mockUseCases.On("StreamOrders", mock.Anything, (*time.Time)(nil), (*time.Time)(nil), 0, 10).Return(&dto.OrderListResponseDTO{PageSize: 10}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders?stream=1", nil)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, handler.ListOrders(echo.New().NewContext(req, rec)))
+	assert.JSONEq(t, `{"orders":[],"total":0,"page":0,"page_size":10,"total_pages":0,"has_next":false,"has_previous":false}`, rec.Body.String())
+}
+
+func TestOrderHandler_ListOrders_StreamErrors(t *testing.T) {
+	handler, mockUseCases := setupTestOrderHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders?stream=maybe", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler.ListOrders(echo.New().NewContext(req, rec)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// An error before the first order is still answered as an error response
+	mockUseCases.On("StreamOrders", mock.Anything, mock.Anything, mock.Anything, 0, 10).Return(nil, domainErrors.ErrFailedToListOrders)
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/orders?stream=true", nil)
+	rec = httptest.NewRecorder()
+	require.NoError(t, handler.ListOrders(echo.New().NewContext(req, rec)))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "FAILED_TO_LIST_ORDERS", response.Error)
+}
+
+// GetCustomerOrders Tests
+func TestOrderHandler_HeadOrderLists(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		target    string
+		params    map[string]string
+		handle    func(h *OrderHandler, c echo.Context) error
+		mockSetup func(m *MockOrderUseCases)
+	}{
+		{"all orders", "/api/v1/orders", nil, (*OrderHandler).ListOrders, func(m *MockOrderUseCases) {
+			m.On("CountOrders", mock.Anything, uint(0), entities.OrderStatus("")).Return(int64(42), nil)
+		}},
+		{"orders in a date range", "/api/v1/orders?created_from=2024-03-01", nil, (*OrderHandler).ListOrders, func(m *MockOrderUseCases) {
+			m.On("CountOrdersByDateRange", mock.Anything, &from, (*time.Time)(nil)).Return(int64(42), nil)
+		}},
+		{"customer orders", "/api/v1/customers/123/orders?status=pending", map[string]string{"customer_id": "123"}, (*OrderHandler).GetCustomerOrders, func(m *MockOrderUseCases) {
+			m.On("CountOrders", mock.Anything, uint(123), entities.OrderStatusPending).Return(int64(42), nil)
+		}},
+		{"orders by status", "/api/v1/orders/status/pending?customer_id=123", map[string]string{"status": "pending"}, (*OrderHandler).GetOrdersByStatus, func(m *MockOrderUseCases) {
+			m.On("CountOrders", mock.Anything, uint(123), entities.OrderStatusPending).Return(int64(42), nil)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockUseCases := setupTestOrderHandler()
+			tt.mockSetup(mockUseCases)
+
+			req := httptest.NewRequest(http.MethodHead, tt.target, nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			for name, value := range tt.params {
+				c.SetParamNames(name)
+				c.SetParamValues(value)
+			}
+
+			require.NoError(t, tt.handle(handler, c))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "42", rec.Header().Get(HeaderTotalCount))
+			assert.Empty(t, rec.Body.String())
+			mockUseCases.AssertExpectations(t)
+			mockUseCases.AssertNotCalled(t, "ListOrders", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderHandler_HeadOrderLists_InvalidFilter(t *testing.T) {
+	handler, mockUseCases := setupTestOrderHandler()
+
+	req := httptest.NewRequest(http.MethodHead, "/api/v1/orders?created_from=yesterday", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler.ListOrders(echo.New().NewContext(req, rec)))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, rec.Header().Get(HeaderTotalCount))
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_CountOrders(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(m *MockOrderUseCases)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"all orders", "", func(m *MockOrderUseCases) {
+			m.On("CountOrders", mock.Anything, uint(0), entities.OrderStatus("")).Return(int64(5), nil)
+		}, http.StatusOK, `{"count":5}`},
+		{"filtered", "?status=PENDING&customer_id=123", func(m *MockOrderUseCases) {
+			m.On("CountOrders", mock.Anything, uint(123), entities.OrderStatusPending).Return(int64(2), nil)
+		}, http.StatusOK, `{"count":2}`},
+		{"invalid status", "?status=lost", func(m *MockOrderUseCases) {}, http.StatusBadRequest, ""},
+		{"invalid customer", "?customer_id=0", func(m *MockOrderUseCases) {}, http.StatusBadRequest, ""},
+		{"repository failure", "", func(m *MockOrderUseCases) {
+			m.On("CountOrders", mock.Anything, uint(0), entities.OrderStatus("")).Return(int64(0), domainErrors.ErrFailedToListOrders)
+		}, http.StatusInternalServerError, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockUseCases := setupTestOrderHandler()
+			tt.mockSetup(mockUseCases)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/count"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			require.NoError(t, handler.CountOrders(echo.New().NewContext(req, rec)))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			mockUseCases.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderHandler_GetCustomerOrders_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	expectedOrders := []*dto.OrderResponseDTO{
+		{
+			ID:          1,
+			CustomerID:  123,
+			Items:       []dto.OrderItemResponseDTO{},
+			TotalAmount: 100.00,
+			Status:      entities.OrderStatusPending,
+		},
+	}
+
+	expectedResponse := &dto.OrderListResponseDTO{
+		Orders:   expectedOrders,
+		Total:    1,
+		Page:     0,
+		PageSize: 10,
+	}
+
+	mockUseCases.On("GetCustomerOrders", mock.Anything, uint(123), 0, 10).Return(expectedResponse, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/customers/123/orders", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("customer_id")
+	c.SetParamValues("123")
+
+	// Execute
+	err := handler.GetCustomerOrders(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.OrderListResponseDTO
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Len(t, response.Orders, 1)
+	assert.Equal(t, uint(123), response.Orders[0].CustomerID)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+// GetOrdersByStatus Tests
+func TestOrderHandler_GetCustomerOrders_WithStatus(t *testing.T) {
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("GetCustomerOrdersByStatus", mock.Anything, uint(123), entities.OrderStatusPending, 0, 10).
+		Return(&dto.OrderListResponseDTO{Orders: []*dto.OrderResponseDTO{}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/customers/123/orders?status=pending", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("customer_id")
+	c.SetParamValues("123")
+
+	err := handler.GetCustomerOrders(c)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCases.AssertExpectations(t)
+	mockUseCases.AssertNotCalled(t, "GetCustomerOrders", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_GetCustomerOrders_InvalidStatus(t *testing.T) {
+	handler, mockUseCases := setupTestOrderHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/customers/123/orders?status=bogus", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("customer_id")
+	c.SetParamValues("123")
+
+	err := handler.GetCustomerOrders(c)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INVALID_ORDER_STATUS")
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrdersByStatus_WithCustomer(t *testing.T) {
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("GetCustomerOrdersByStatus", mock.Anything, uint(123), entities.OrderStatusPending, 1, 5).
+		Return(&dto.OrderListResponseDTO{Orders: []*dto.OrderResponseDTO{}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/status/pending?customer_id=123&page=1&page_size=5", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("status")
+	c.SetParamValues("pending")
+
+	err := handler.GetOrdersByStatus(c)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrdersByStatus_InvalidCustomer(t *testing.T) {
+	handler, mockUseCases := setupTestOrderHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/status/pending?customer_id=abc", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("status")
+	c.SetParamValues("pending")
+
+	err := handler.GetOrdersByStatus(c)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INVALID_ID")
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrdersByStatus_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	expectedOrders := []*dto.OrderResponseDTO{
+		{
+			ID:          1,
+			CustomerID:  123,
+			Items:       []dto.OrderItemResponseDTO{},
+			TotalAmount: 100.00,
+			Status:      entities.OrderStatusPending,
+		},
+	}
+
+	expectedResponse := &dto.OrderListResponseDTO{
+		Orders:   expectedOrders,
+		Total:    1,
+		Page:     0,
+		PageSize: 10,
+	}
+
+	mockUseCases.On("GetOrdersByStatus", mock.Anything, entities.OrderStatusPending, 0, 10).Return(expectedResponse, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/status/pending", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("status")
+	c.SetParamValues("pending")
+
+	// Execute
+	err := handler.GetOrdersByStatus(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.OrderListResponseDTO
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Len(t, response.Orders, 1)
+	assert.Equal(t, entities.OrderStatusPending, response.Orders[0].Status)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrdersByStatus_CaseInsensitive(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("GetOrdersByStatus", mock.Anything, entities.OrderStatusPending, 0, 10).
+		Return(&dto.OrderListResponseDTO{Orders: []*dto.OrderResponseDTO{}}, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/status/Pending", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("status")
+	c.SetParamValues("Pending")
+
+	// Execute
+	err := handler.GetOrdersByStatus(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrdersByStatus_UnknownStatus(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/status/shiped", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("status")
+	c.SetParamValues("shiped")
+
+	// Execute
+	err := handler.GetOrdersByStatus(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "INVALID_ORDER_STATUS", response.Error)
+	assert.Len(t, response.Details["valid_statuses"], len(entities.OrderStatuses()))
+
+	mockUseCases.AssertNotCalled(t, "GetOrdersByStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_GetOrdersByStatus_BogusStatusIsRejectedBeforeTheUseCase(t *testing.T) {
+	for _, prefix := range []string{"/api/v1", "/api/v2"} {
+		t.Run(prefix, func(t *testing.T) {
+			// Given
+			handler, mockUseCases := setupTestOrderHandler()
+
+			// When
+			rec := serveVersioned(setupVersionedRoutes(handler), prefix+"/orders/status/bogus")
+
+			// Then
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"INVALID_ORDER_STATUS"`)
+			assert.Contains(t, rec.Body.String(), `"valid_statuses":["pending",`)
+			mockUseCases.AssertNotCalled(t, "GetOrdersByStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			mockUseCases.AssertNotCalled(t, "GetCustomerOrdersByStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// GetOrderByExternalReference Tests
+func TestOrderHandler_GetOrderByExternalReference_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	expectedResponse := &dto.OrderResponseDTO{
+		ID:                7,
+		CustomerID:        123,
+		ExternalReference: "PO-1001",
+		Status:            entities.OrderStatusPending,
+	}
+
+	mockUseCases.On("GetOrderByExternalReference", mock.Anything, uint(123), "PO-1001").Return(expectedResponse, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/by-reference?customer_id=123&reference=PO-1001", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.GetOrderByExternalReference(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.OrderResponseDTO
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "PO-1001", response.ExternalReference)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrderByExternalReference_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		code  string
+	}{
+		{name: "missing customer", query: "reference=PO-1001", code: "INVALID_ID"},
+		{name: "invalid customer", query: "customer_id=abc&reference=PO-1001", code: "INVALID_ID"},
+		{name: "missing reference", query: "customer_id=123", code: "INVALID_REQUEST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			handler, mockUseCases := setupTestOrderHandler()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/by-reference?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			// Execute
+			err := handler.GetOrderByExternalReference(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var response ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.code, response.Error)
+			mockUseCases.AssertNotCalled(t, "GetOrderByExternalReference", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderHandler_GetOrderByNumber_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	expectedResponse := &dto.OrderResponseDTO{
+		ID:          7,
+		CustomerID:  123,
+		OrderNumber: "ORD-2025-000123",
+		Status:      entities.OrderStatusPending,
+	}
+
+	mockUseCases.On("GetOrderByNumber", mock.Anything, "ORD-2025-000123").Return(expectedResponse, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/number/ORD-2025-000123", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("order_number")
+	c.SetParamValues("ORD-2025-000123")
+
+	// Execute
+	err := handler.GetOrderByNumber(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.OrderResponseDTO
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), response.ID)
+	assert.Equal(t, "ORD-2025-000123", response.OrderNumber)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrderByNumber_InvalidNumber(t *testing.T) {
+	for _, number := range []string{" ", strings.Repeat("9", entities.MaxOrderNumberLength+1)} {
+		// Setup
+		handler, mockUseCases := setupTestOrderHandler()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/number/x", nil)
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(req, rec)
+		c.SetParamNames("order_number")
+		c.SetParamValues(number)
+
+		// Execute
+		err := handler.GetOrderByNumber(c)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "INVALID_REQUEST", response.Error)
+		mockUseCases.AssertNotCalled(t, "GetOrderByNumber", mock.Anything, mock.Anything)
+	}
+}
+
+func TestOrderHandler_CreateOrder_DuplicateExternalReference(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	requestBody := dto.CreateOrderRequestDTO{CustomerID: 123, ExternalReference: "PO-1001"}
+	mockUseCases.On("CreateOrder", mock.Anything, &requestBody).Return(nil, domainErrors.ErrDuplicateExternalReference)
+
+	// Create request
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.CreateOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "DUPLICATE_EXTERNAL_REFERENCE")
+}
+
+// ReplaceOrderItems Tests
+func TestOrderHandler_ReplaceOrderItems_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	request := &dto.ReplaceOrderItemsRequestDTO{
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 10.0},
+			{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 5.0},
+		},
+	}
+	expectedResponse := &dto.OrderResponseDTO{
+		ID:          1,
+		ItemCount:   2,
+		TotalAmount: 25.0,
+		Status:      entities.OrderStatusPending,
+	}
+
+	mockUseCases.On("ReplaceOrderItems", mock.Anything, uint(1), request).Return(expectedResponse, nil)
+
+	// Create request
+	jsonBody, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/1/items", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.ReplaceOrderItems(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.OrderResponseDTO
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, 2, response.ItemCount)
+	assert.Equal(t, 25.0, response.TotalAmount)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_ReplaceOrderItems_InvalidItem(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	// Create request
+	body := `{"items":[{"product_id":1,"product_sku":"SKU-001","product_name":"Product 1","quantity":0,"unit_price":10}]}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/1/items", bytes.NewBufferString(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.ReplaceOrderItems(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUseCases.AssertNotCalled(t, "ReplaceOrderItems", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// AmendOrder Tests
+func TestOrderHandler_AmendOrder_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	request := &dto.AmendOrderRequestDTO{
+		Reason: "customer called",
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 3, UnitPrice: 10.0},
+		},
+	}
+	expectedResponse := &dto.OrderResponseDTO{
+		ID:             1,
+		ItemCount:      1,
+		TotalAmount:    30.0,
+		Status:         entities.OrderStatusConfirmed,
+		AmendmentCount: 1,
+	}
+
+	mockUseCases.On("AmendOrder", mock.Anything, uint(1), request).Return(expectedResponse, nil)
+
+	// Create request
+	jsonBody, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/amend", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.AmendOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.OrderResponseDTO
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.AmendmentCount)
+	assert.Equal(t, 30.0, response.TotalAmount)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestOrderHandler_AmendOrder_MissingReason(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	// Create request
+	body := `{"items":[{"product_id":1,"product_sku":"SKU-001","product_name":"Product 1","quantity":3,"unit_price":10}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/amend", bytes.NewBufferString(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.AmendOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUseCases.AssertNotCalled(t, "AmendOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_AddItemToOrder_RequiresAmendment(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	mockUseCases.On("AddItemToOrder", mock.Anything, uint(1), mock.Anything).Return(nil, domainErrors.ErrOrderRequiresAmendment)
+
+	// Create request
+	body := `{"product_id":2,"product_sku":"SKU-002","product_name":"Product 2","quantity":1,"unit_price":5}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/items", bytes.NewBufferString(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// Execute
+	err := handler.AddItemToOrder(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "ORDER_REQUIRES_AMENDMENT", response.Error)
+}
+
+// Backorder Tests
+func TestOrderHandler_FulfillOrderItem_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	expectedResponse := &dto.OrderResponseDTO{
+		ID:     1,
+		Status: entities.OrderStatusConfirmed,
+		Items: []dto.OrderItemResponseDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, ReservedQuantity: 2},
+		},
+	}
+
+	mockUseCases.On("FulfillOrderItem", mock.Anything, uint(1), uint(1)).Return(expectedResponse, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/items/1/fulfill", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id", "product_id")
+	c.SetParamValues("1", "1")
+
+	// Execute
+	err := handler.FulfillOrderItem(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.OrderResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Items[0].ReservedQuantity)
+	assert.False(t, response.Items[0].Backordered)
 
-func (m *MockOrderUseCases) GetOrdersByStatus(ctx context.Context, status entities.OrderStatus, page, pageSize int) (*dto.OrderListResponseDTO, error) {
-	args := m.Called(ctx, status, page, pageSize)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*dto.OrderListResponseDTO), args.Error(1)
+	mockUseCases.AssertExpectations(t)
 }
 
-func (m *MockOrderUseCases) ListOrders(ctx context.Context, page, pageSize int) (*dto.OrderListResponseDTO, error) {
-	args := m.Called(ctx, page, pageSize)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*dto.OrderListResponseDTO), args.Error(1)
-}
+func TestOrderHandler_FulfillOrderItem_NotBackordered(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
 
-func (m *MockOrderUseCases) DeleteOrder(ctx context.Context, orderID uint) error {
-	args := m.Called(ctx, orderID)
-	return args.Error(0)
-}
+	mockUseCases.On("FulfillOrderItem", mock.Anything, uint(1), uint(2)).Return(nil, domainErrors.ErrItemNotBackordered)
 
-func setupTestOrderHandler() (*OrderHandler, *MockOrderUseCases) {
-	mockUseCases := new(MockOrderUseCases)
-	log := logger.New("test")
-	handler := NewOrderHandler(mockUseCases, log)
-	return handler, mockUseCases
+	// Create request
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/items/2/fulfill", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id", "product_id")
+	c.SetParamValues("1", "2")
+
+	// Execute
+	err := handler.FulfillOrderItem(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "ITEM_NOT_BACKORDERED")
 }
 
-// CreateOrder Tests
-func TestOrderHandler_CreateOrder_Success(t *testing.T) {
+func TestOrderHandler_ListBackorderedOrders_Success(t *testing.T) {
 	// Setup
 	handler, mockUseCases := setupTestOrderHandler()
 
-	requestBody := dto.CreateOrderRequestDTO{
-		CustomerID: 123,
-		Items: []dto.CreateOrderItemDTO{
+	expectedResponse := &dto.OrderListResponseDTO{
+		Orders: []*dto.OrderResponseDTO{
 			{
-				ProductID:   1,
-				ProductSKU:  "SKU-001",
-				ProductName: "Product 1",
-				Quantity:    2,
-				UnitPrice:   10.50,
+				ID:     1,
+				Status: entities.OrderStatusConfirmed,
+				Items: []dto.OrderItemResponseDTO{
+					{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, ReservedQuantity: 1, Backordered: true},
+				},
 			},
 		},
+		Total:    1,
+		Page:     0,
+		PageSize: 10,
 	}
 
+	mockUseCases.On("ListBackorderedOrders", mock.Anything, 0, 10).Return(expectedResponse, nil)
+
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/backordered", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	// Execute
+	err := handler.ListBackorderedOrders(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.OrderListResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.Orders, 1)
+	assert.True(t, response.Orders[0].Items[0].Backordered)
+	assert.Equal(t, 1, response.Orders[0].Items[0].ReservedQuantity)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+// HoldOrder Tests
+func TestOrderHandler_HoldOrder_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
+
+	request := &dto.PlaceOrderOnHoldRequestDTO{Reason: "fraud review"}
 	expectedResponse := &dto.OrderResponseDTO{
-		ID:          1,
-		CustomerID:  123,
-		Items:       []dto.OrderItemResponseDTO{},
-		ItemCount:   1,
-		TotalItems:  2,
-		TotalAmount: 21.00,
-		Status:      entities.OrderStatusPending,
+		ID:             1,
+		Status:         entities.OrderStatusOnHold,
+		HeldFromStatus: entities.OrderStatusConfirmed,
+		HoldReason:     "fraud review",
 	}
 
-	mockUseCases.On("CreateOrder", mock.Anything, &requestBody).Return(expectedResponse, nil)
+	mockUseCases.On("PlaceOrderOnHold", mock.Anything, uint(1), request).Return(expectedResponse, nil)
 
 	// Create request
-	jsonBody, _ := json.Marshal(requestBody)
-	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", bytes.NewBuffer(jsonBody))
+	jsonBody, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/hold", bytes.NewBuffer(jsonBody))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
-
 	rec := httptest.NewRecorder()
 	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
 
 	// Execute
-	err := handler.CreateOrder(c)
+	err := handler.HoldOrder(c)
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, http.StatusOK, rec.Code)
 
 	var response dto.OrderResponseDTO
 	err = json.Unmarshal(rec.Body.Bytes(), &response)
 	require.NoError(t, err)
-
-	assert.Equal(t, expectedResponse.ID, response.ID)
-	assert.Equal(t, expectedResponse.CustomerID, response.CustomerID)
-	assert.Equal(t, expectedResponse.Status, response.Status)
+	assert.Equal(t, entities.OrderStatusOnHold, response.Status)
+	assert.Equal(t, entities.OrderStatusConfirmed, response.HeldFromStatus)
 
 	mockUseCases.AssertExpectations(t)
 }
 
-func TestOrderHandler_CreateOrder_ValidationError(t *testing.T) {
+func TestOrderHandler_HoldOrder_MissingReason(t *testing.T) {
 	// Setup
-	handler, _ := setupTestOrderHandler()
-
-	requestBody := dto.CreateOrderRequestDTO{
-		CustomerID: 0, // Invalid - required
-		Items:      []dto.CreateOrderItemDTO{},
-	}
+	handler, mockUseCases := setupTestOrderHandler()
 
 	// Create request
-	jsonBody, _ := json.Marshal(requestBody)
-	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", bytes.NewBuffer(jsonBody))
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/hold", bytes.NewBufferString(`{}`))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
-
 	rec := httptest.NewRecorder()
 	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
 
 	// Execute
-	err := handler.CreateOrder(c)
+	err := handler.HoldOrder(c)
 
 	// Assert
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 
 	var response ErrorResponse
-	err = json.Unmarshal(rec.Body.Bytes(), &response)
-	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Contains(t, response.Details, "reason")
 
-	assert.Equal(t, "VALIDATION_ERROR", response.Error)
-	assert.NotNil(t, response.Details)
+	mockUseCases.AssertNotCalled(t, "PlaceOrderOnHold", mock.Anything, mock.Anything, mock.Anything)
 }
 
-// GetOrder Tests
-func TestOrderHandler_GetOrder_Success(t *testing.T) {
+// ReleaseOrder Tests
+func TestOrderHandler_ReleaseOrder_Success(t *testing.T) {
 	// Setup
 	handler, mockUseCases := setupTestOrderHandler()
 
 	expectedResponse := &dto.OrderResponseDTO{
-		ID:          1,
-		CustomerID:  123,
-		Items:       []dto.OrderItemResponseDTO{},
-		TotalAmount: 100.00,
-		Status:      entities.OrderStatusConfirmed,
+		ID:     1,
+		Status: entities.OrderStatusConfirmed,
 	}
 
-	mockUseCases.On("GetOrder", mock.Anything, uint(1)).Return(expectedResponse, nil)
+	mockUseCases.On("ReleaseOrderHold", mock.Anything, uint(1)).Return(expectedResponse, nil)
 
 	// Create request
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1", nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/release", nil)
 	rec := httptest.NewRecorder()
 	c := echo.New().NewContext(req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("1")
 
 	// Execute
-	err := handler.GetOrder(c)
+	err := handler.ReleaseOrder(c)
 
 	// Assert
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rec.Code)
 
-	var response dto.OrderResponseDTO
-	err = json.Unmarshal(rec.Body.Bytes(), &response)
-	require.NoError(t, err)
-
-	assert.Equal(t, expectedResponse.ID, response.ID)
-	assert.Equal(t, expectedResponse.CustomerID, response.CustomerID)
-
 	mockUseCases.AssertExpectations(t)
 }
 
-func TestOrderHandler_GetOrder_NotFound(t *testing.T) {
+// DeleteOrder Tests
+func TestOrderHandler_DeleteOrder_Success(t *testing.T) {
 	// Setup
 	handler, mockUseCases := setupTestOrderHandler()
 
-	mockUseCases.On("GetOrder", mock.Anything, uint(999)).Return(nil, domainErrors.ErrOrderNotFound)
+	mockUseCases.On("DeleteOrder", mock.Anything, uint(1)).Return(nil)
 
 	// Create request
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/999", nil)
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/orders/1", nil)
 	rec := httptest.NewRecorder()
 	c := echo.New().NewContext(req, rec)
 	c.SetParamNames("id")
-	c.SetParamValues("999")
+	c.SetParamValues("1")
 
 	// Execute
-	err := handler.GetOrder(c)
+	err := handler.DeleteOrder(c)
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, http.StatusNotFound, rec.Code)
-
-	var response ErrorResponse
-	err = json.Unmarshal(rec.Body.Bytes(), &response)
-	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Body.Bytes())
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentType))
 
-	assert.Equal(t, "ORDER_NOT_FOUND", response.Error)
 	mockUseCases.AssertExpectations(t)
 }
 
-func TestOrderHandler_GetOrder_InvalidID(t *testing.T) {
+func TestOrderHandler_DeleteOrder_NotDeletable(t *testing.T) {
 	// Setup
-	handler, _ := setupTestOrderHandler()
+	handler, mockUseCases := setupTestOrderHandler()
 
-	// Create request with invalid ID
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/invalid", nil)
+	mockUseCases.On("DeleteOrder", mock.Anything, uint(1)).Return(domainErrors.ErrOrderNotDeletable.WithDetails(map[string]interface{}{
+		"current_status": entities.OrderStatusShipped,
+	}))
+
+	// Create request
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/orders/1", nil)
 	rec := httptest.NewRecorder()
 	c := echo.New().NewContext(req, rec)
 	c.SetParamNames("id")
-	c.SetParamValues("invalid")
+	c.SetParamValues("1")
 
 	// Execute
-	err := handler.GetOrder(c)
+	err := handler.DeleteOrder(c)
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, http.StatusConflict, rec.Code)
 
 	var response ErrorResponse
 	err = json.Unmarshal(rec.Body.Bytes(), &response)
 	require.NoError(t, err)
 
-	assert.Equal(t, "INVALID_ID", response.Error)
+	assert.Equal(t, "ORDER_NOT_DELETABLE", response.Error)
+	assert.Equal(t, "shipped", response.Details["current_status"])
+
+	mockUseCases.AssertExpectations(t)
 }
 
-// AddItemToOrder Tests
-func TestOrderHandler_AddItemToOrder_Success(t *testing.T) {
+func TestOrderHandler_DeleteOrder_NotFound(t *testing.T) {
 	// Setup
 	handler, mockUseCases := setupTestOrderHandler()
 
-	requestBody := dto.AddOrderItemRequestDTO{
-		ProductID:   1,
-		ProductSKU:  "SKU-001",
-		ProductName: "Product 1",
-		Quantity:    2,
-		UnitPrice:   10.50,
-	}
-
-	expectedResponse := &dto.OrderResponseDTO{
-		ID:          1,
-		CustomerID:  123,
-		Items:       []dto.OrderItemResponseDTO{},
-		TotalAmount: 21.00,
-		Status:      entities.OrderStatusPending,
-	}
-
-	mockUseCases.On("AddItemToOrder", mock.Anything, uint(1), &requestBody).Return(expectedResponse, nil)
+	mockUseCases.On("DeleteOrder", mock.Anything, uint(999)).Return(domainErrors.ErrOrderNotFound)
 
 	// Create request
-	jsonBody, _ := json.Marshal(requestBody)
-	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/items", bytes.NewBuffer(jsonBody))
-	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
-
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/orders/999", nil)
 	rec := httptest.NewRecorder()
 	c := echo.New().NewContext(req, rec)
 	c.SetParamNames("id")
-	c.SetParamValues("1")
+	c.SetParamValues("999")
 
 	// Execute
-	err := handler.AddItemToOrder(c)
+	err := handler.DeleteOrder(c)
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var response ErrorResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ORDER_NOT_FOUND", response.Error)
 
 	mockUseCases.AssertExpectations(t)
 }
 
-// RemoveItemFromOrder Tests
-func TestOrderHandler_RemoveItemFromOrder_Success(t *testing.T) {
+// ExportOrders Tests
+func TestOrderHandler_ExportOrders_Success(t *testing.T) {
 	// Setup
 	handler, mockUseCases := setupTestOrderHandler()
 
-	expectedResponse := &dto.OrderResponseDTO{
-		ID:          1,
-		CustomerID:  123,
-		Items:       []dto.OrderItemResponseDTO{},
-		TotalAmount: 0.00,
-		Status:      entities.OrderStatusPending,
+	createdAt := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	orders := []*dto.OrderResponseDTO{
+		{
+			ID:          1,
+			CustomerID:  123,
+			Status:      entities.OrderStatusConfirmed,
+			TotalAmount: 41.00,
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+			Items: []dto.OrderItemResponseDTO{
+				{ID: 10, ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 10.50, TotalPrice: 21.00},
+				{ID: 11, ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product, 2", Quantity: 1, UnitPrice: 20.00, TotalPrice: 20.00},
+			},
+		},
+		{
+			ID:         2,
+			CustomerID: 124,
+			Status:     entities.OrderStatusPending,
+			CreatedAt:  createdAt,
+			UpdatedAt:  createdAt,
+			Items:      []dto.OrderItemResponseDTO{},
+		},
 	}
 
-	mockUseCases.On("RemoveItemFromOrder", mock.Anything, uint(1), uint(1)).Return(expectedResponse, nil)
+	mockUseCases.On("ExportOrders", mock.Anything, mock.MatchedBy(func(filter *dto.OrderFilterDTO) bool {
+		return filter.Status == entities.OrderStatusConfirmed &&
+			filter.From.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) &&
+			filter.To.Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	})).Return(orders, nil)
 
 	// Create request
-	req := httptest.NewRequest(http.MethodDelete, "/api/v1/orders/1/items/1", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/export?format=csv&status=confirmed&from=2025-01-01&to=2025-01-31", nil)
 	rec := httptest.NewRecorder()
 	c := echo.New().NewContext(req, rec)
-	c.SetParamNames("id", "product_id")
-	c.SetParamValues("1", "1")
 
 	// Execute
-	err := handler.RemoveItemFromOrder(c)
+	err := handler.ExportOrders(c)
 
 	// Assert
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `attachment; filename="orders_2025-01-01_2025-01-31.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, "order_id", records[0][0])
+	assert.Equal(t, []string{"1", "123", "confirmed", "41.00", "2025-01-15T10:00:00Z", "2025-01-15T10:00:00Z", "10", "1", "SKU-001", "Product 1", "2", "10.50", "21.00"}, records[1])
+	assert.Equal(t, "Product, 2", records[2][9])
+	assert.Equal(t, "2", records[3][0])
+	assert.Equal(t, "", records[3][6])
 
 	mockUseCases.AssertExpectations(t)
 }
 
-// UpdateItemQuantity Tests
-func TestOrderHandler_UpdateItemQuantity_Success(t *testing.T) {
-	// Setup
+func TestOrderHandler_ExportOrders_EscapesFormulas(t *testing.T) {
+	// Given
 	handler, mockUseCases := setupTestOrderHandler()
 
-	requestBody := dto.UpdateOrderItemQuantityRequestDTO{
-		Quantity: 5,
+	orders := []*dto.OrderResponseDTO{
+		{ID: 1, CustomerID: 123, Status: entities.OrderStatusConfirmed, Items: []dto.OrderItemResponseDTO{
+			{ID: 10, ProductID: 1, ProductSKU: "=1+1", ProductName: `=HYPERLINK("http://evil.example","x")`, Quantity: 1, UnitPrice: 1, TotalPrice: 1},
+			{ID: 11, ProductID: 2, ProductSKU: "+SKU", ProductName: "-2+3", Quantity: 1, UnitPrice: 1, TotalPrice: 1},
+			{ID: 12, ProductID: 3, ProductSKU: "@SUM(A1)", ProductName: "\t=cmd", Quantity: 1, UnitPrice: 1, TotalPrice: 1},
+			{ID: 13, ProductID: 4, ProductSKU: "SKU-004", ProductName: "Product = 4", Quantity: 1, UnitPrice: 1, TotalPrice: 1},
+		}},
 	}
+	mockUseCases.On("ExportOrders", mock.Anything, mock.Anything).Return(orders, nil)
 
-	expectedResponse := &dto.OrderResponseDTO{
-		ID:          1,
-		CustomerID:  123,
-		Items:       []dto.OrderItemResponseDTO{},
-		TotalAmount: 52.50,
-		Status:      entities.OrderStatusPending,
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/export?format=csv", nil)
+	rec := httptest.NewRecorder()
+
+	// When
+	err := handler.ExportOrders(echo.New().NewContext(req, rec))
+
+	// Then
+	require.NoError(t, err)
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 5)
+
+	tests := []struct {
+		sku  string
+		name string
+	}{
+		{"'=1+1", `'=HYPERLINK("http://evil.example","x")`},
+		{"'+SKU", "'-2+3"},
+		{"'@SUM(A1)", "'\t=cmd"},
+		{"SKU-004", "Product = 4"},
+	}
+	for i, tt := range tests {
+		assert.Equal(t, tt.sku, records[i+1][8])
+		assert.Equal(t, tt.name, records[i+1][9])
 	}
+}
 
-	mockUseCases.On("UpdateItemQuantity", mock.Anything, uint(1), uint(1), &requestBody).Return(expectedResponse, nil)
+func TestOrderHandler_ExportOrders_JSON(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
 
-	// Create request
-	jsonBody, _ := json.Marshal(requestBody)
-	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/1/items/1", bytes.NewBuffer(jsonBody))
-	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	orders := []*dto.OrderResponseDTO{
+		{ID: 1, CustomerID: 123, Status: entities.OrderStatusConfirmed, Items: []dto.OrderItemResponseDTO{
+			{ID: 10, ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 10.50, TotalPrice: 21.00},
+		}},
+		{ID: 2, CustomerID: 124, Status: entities.OrderStatusPending, Items: []dto.OrderItemResponseDTO{}},
+	}
+	mockUseCases.On("ExportOrders", mock.Anything, mock.Anything).Return(orders, nil)
 
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/export?format=json&from=2025-01-01&to=2025-01-31", nil)
 	rec := httptest.NewRecorder()
-	c := echo.New().NewContext(req, rec)
-	c.SetParamNames("id", "product_id")
-	c.SetParamValues("1", "1")
+	c := echo.New().NewContext(req, rec)
 
 	// Execute
-	err := handler.UpdateItemQuantity(c)
+	err := handler.ExportOrders(c)
 
 	// Assert
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, echo.MIMEApplicationJSON, rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `attachment; filename="orders_2025-01-01_2025-01-31.json"`, rec.Header().Get(echo.HeaderContentDisposition))
 
-	mockUseCases.AssertExpectations(t)
+	var exported []*dto.OrderResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &exported))
+	assert.Equal(t, orders, exported)
 }
 
-// ConfirmOrder Tests
-func TestOrderHandler_ConfirmOrder_Success(t *testing.T) {
-	// Setup
+func TestOrderHandler_ExportOrders_JSONEmptyAndTooLarge(t *testing.T) {
 	handler, mockUseCases := setupTestOrderHandler()
+	mockUseCases.On("ExportOrders", mock.Anything, mock.MatchedBy(func(filter *dto.OrderFilterDTO) bool { return filter.CustomerID == 1 })).Return(nil, nil)
+	mockUseCases.On("ExportOrders", mock.Anything, mock.MatchedBy(func(filter *dto.OrderFilterDTO) bool { return filter.CustomerID == 2 })).Return(nil, domainErrors.ErrExportTooLarge)
 
-	expectedResponse := &dto.OrderResponseDTO{
-		ID:          1,
-		CustomerID:  123,
-		Items:       []dto.OrderItemResponseDTO{},
-		TotalAmount: 100.00,
-		Status:      entities.OrderStatusConfirmed,
-	}
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/export?format=json&customer_id=1", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler.ExportOrders(echo.New().NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "[]", rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/orders/export?format=json&customer_id=2", nil)
+	rec = httptest.NewRecorder()
+	require.NoError(t, handler.ExportOrders(echo.New().NewContext(req, rec)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentDisposition))
+}
+
+func TestOrderHandler_ExportOrders_Empty(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestOrderHandler()
 
-	mockUseCases.On("ConfirmOrder", mock.Anything, uint(1)).Return(expectedResponse, nil)
+	mockUseCases.On("ExportOrders", mock.Anything, mock.Anything).Return(nil, nil)
 
 	// Create request
-	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/confirm", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/export", nil)
 	rec := httptest.NewRecorder()
 	c := echo.New().NewContext(req, rec)
-	c.SetParamNames("id")
-	c.SetParamValues("1")
 
 	// Execute
-	err := handler.ConfirmOrder(c)
+	err := handler.ExportOrders(c)
 
 	// Assert
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rec.Code)
 
-	var response dto.OrderResponseDTO
-	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	records, err := csv.NewReader(rec.Body).ReadAll()
 	require.NoError(t, err)
-
-	assert.Equal(t, entities.OrderStatusConfirmed, response.Status)
-
-	mockUseCases.AssertExpectations(t)
+	assert.Len(t, records, 1)
 }
 
-func TestOrderHandler_ConfirmOrder_EmptyOrder(t *testing.T) {
+func TestOrderHandler_ExportOrders_TooLarge(t *testing.T) {
 	// Setup
 	handler, mockUseCases := setupTestOrderHandler()
 
-	mockUseCases.On("ConfirmOrder", mock.Anything, uint(1)).Return(nil, domainErrors.ErrEmptyOrder)
+	mockUseCases.On("ExportOrders", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrExportTooLarge)
 
 	// Create request
-	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/confirm", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/export", nil)
 	rec := httptest.NewRecorder()
 	c := echo.New().NewContext(req, rec)
-	c.SetParamNames("id")
-	c.SetParamValues("1")
 
 	// Execute
-	err := handler.ConfirmOrder(c)
+	err := handler.ExportOrders(c)
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
 
 	var response ErrorResponse
 	err = json.Unmarshal(rec.Body.Bytes(), &response)
 	require.NoError(t, err)
+	assert.Equal(t, "EXPORT_TOO_LARGE", response.Error)
+}
 
-	assert.Equal(t, "EMPTY_ORDER", response.Error)
+func TestOrderHandler_ExportOrders_InvalidParameters(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		expectedError string
+	}{
+		{"unsupported format", "format=xlsx", "INVALID_FORMAT"},
+		{"invalid customer", "customer_id=abc", "INVALID_FILTER"},
+		{"invalid date", "from=01/02/2025", "INVALID_FILTER"},
+		{"inverted range", "from=2025-02-01&to=2025-01-01", "INVALID_FILTER"},
+	}
 
-	mockUseCases.AssertExpectations(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockUseCases := setupTestOrderHandler()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/export?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			err := handler.ExportOrders(c)
+
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var response ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedError, response.Error)
+			mockUseCases.AssertNotCalled(t, "ExportOrders", mock.Anything, mock.Anything)
+		})
+	}
 }
 
-// CancelOrder Tests
-func TestOrderHandler_CancelOrder_Success(t *testing.T) {
+// GetOrderStats Tests
+func TestOrderHandler_GetOrderStats_Success(t *testing.T) {
 	// Setup
 	handler, mockUseCases := setupTestOrderHandler()
 
-	expectedResponse := &dto.OrderResponseDTO{
-		ID:          1,
-		CustomerID:  123,
-		Items:       []dto.OrderItemResponseDTO{},
-		TotalAmount: 100.00,
-		Status:      entities.OrderStatusCancelled,
+	expectedResponse := &dto.OrderStatsResponseDTO{
+		TotalOrders:       2,
+		TotalRevenue:      50,
+		AverageOrderValue: 25,
+		ByStatus:          []dto.StatusStatsDTO{{Status: entities.OrderStatusPending, Orders: 2, Revenue: 50}},
+		Daily:             []dto.DailyStatsDTO{{Date: "2025-01-01", Orders: 2, Revenue: 50}},
 	}
 
-	mockUseCases.On("CancelOrder", mock.Anything, uint(1)).Return(expectedResponse, nil)
+	mockUseCases.On("GetOrderStats", mock.Anything, mock.MatchedBy(func(filter *dto.OrderFilterDTO) bool {
+		return filter.From.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) &&
+			filter.To.Equal(time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC))
+	})).Return(expectedResponse, nil)
 
 	// Create request
-	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/cancel", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/stats?from=2025-01-01&to=2025-01-01", nil)
 	rec := httptest.NewRecorder()
 	c := echo.New().NewContext(req, rec)
-	c.SetParamNames("id")
-	c.SetParamValues("1")
 
 	// Execute
-	err := handler.CancelOrder(c)
+	err := handler.GetOrderStats(c)
 
 	// Assert
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rec.Code)
 
-	var response dto.OrderResponseDTO
+	var response dto.OrderStatsResponseDTO
 	err = json.Unmarshal(rec.Body.Bytes(), &response)
 	require.NoError(t, err)
-
-	assert.Equal(t, entities.OrderStatusCancelled, response.Status)
+	assert.Equal(t, int64(2), response.TotalOrders)
+	assert.Equal(t, 25.0, response.AverageOrderValue)
+	assert.Len(t, response.Daily, 1)
 
 	mockUseCases.AssertExpectations(t)
 }
 
-// UpdateOrderStatus Tests
-func TestOrderHandler_UpdateOrderStatus_Success(t *testing.T) {
+func TestOrderHandler_GetCustomerOrderSummary_Success(t *testing.T) {
 	// Setup
 	handler, mockUseCases := setupTestOrderHandler()
 
-	requestBody := dto.UpdateOrderStatusRequestDTO{
-		Status: entities.OrderStatusProcessing,
-	}
-
-	expectedResponse := &dto.OrderResponseDTO{
-		ID:          1,
-		CustomerID:  123,
-		Items:       []dto.OrderItemResponseDTO{},
-		TotalAmount: 100.00,
-		Status:      entities.OrderStatusProcessing,
-	}
-
-	mockUseCases.On("TransitionOrderStatus", mock.Anything, uint(1), &requestBody).Return(expectedResponse, nil)
+	mockUseCases.On("GetCustomerOrderSummary", mock.Anything, uint(123)).Return(&dto.CustomerOrderSummaryDTO{
+		CustomerID:     123,
+		OrdersByStatus: map[entities.OrderStatus]int64{entities.OrderStatusPending: 0},
+	}, nil)
 
 	// Create request
-	jsonBody, _ := json.Marshal(requestBody)
-	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/1/status", bytes.NewBuffer(jsonBody))
-	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
-
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/customers/123/orders/summary", nil)
 	rec := httptest.NewRecorder()
 	c := echo.New().NewContext(req, rec)
-	c.SetParamNames("id")
-	c.SetParamValues("1")
+	c.SetParamNames("customer_id")
+	c.SetParamValues("123")
 
 	// Execute
-	err := handler.UpdateOrderStatus(c)
+	err := handler.GetCustomerOrderSummary(c)
 
 	// Assert
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rec.Code)
-
-	var response dto.OrderResponseDTO
-	err = json.Unmarshal(rec.Body.Bytes(), &response)
-	require.NoError(t, err)
-
-	assert.Equal(t, entities.OrderStatusProcessing, response.Status)
-
+	assert.JSONEq(t, `{"customer_id":123,"total_orders":0,"total_spend":0,"average_order_value":0,"orders_by_status":{"pending":0},"first_order_at":null,"last_order_at":null}`, rec.Body.String())
 	mockUseCases.AssertExpectations(t)
 }
 
-// ListOrders Tests
-func TestOrderHandler_ListOrders_Success(t *testing.T) {
+func TestOrderHandler_GetCustomerOrderSummary_InvalidID(t *testing.T) {
 	// Setup
 	handler, mockUseCases := setupTestOrderHandler()
 
-	expectedOrders := []*dto.OrderResponseDTO{
-		{
-			ID:          1,
-			CustomerID:  123,
-			Items:       []dto.OrderItemResponseDTO{},
-			TotalAmount: 100.00,
-			Status:      entities.OrderStatusPending,
-		},
-		{
-			ID:          2,
-			CustomerID:  456,
-			Items:       []dto.OrderItemResponseDTO{},
-			TotalAmount: 200.00,
-			Status:      entities.OrderStatusConfirmed,
-		},
-	}
-
-	expectedResponse := &dto.OrderListResponseDTO{
-		Orders:   expectedOrders,
-		Total:    50,
-		Page:     0,
-		PageSize: 10,
-	}
-
-	mockUseCases.On("ListOrders", mock.Anything, 0, 10).Return(expectedResponse, nil)
-
 	// Create request
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/customers/abc/orders/summary", nil)
 	rec := httptest.NewRecorder()
 	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("customer_id")
+	c.SetParamValues("abc")
 
 	// Execute
-	err := handler.ListOrders(c)
+	err := handler.GetCustomerOrderSummary(c)
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, http.StatusOK, rec.Code)
-
-	var response dto.OrderListResponseDTO
-	err = json.Unmarshal(rec.Body.Bytes(), &response)
-	require.NoError(t, err)
-
-	assert.Len(t, response.Orders, 2)
-	assert.Equal(t, int64(50), response.Total)
-
-	mockUseCases.AssertExpectations(t)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUseCases.AssertNotCalled(t, "GetCustomerOrderSummary", mock.Anything, mock.Anything)
 }
 
-func TestOrderHandler_ListOrders_WithPagination(t *testing.T) {
+func TestOrderHandler_GetOrderStats_InvalidRange(t *testing.T) {
 	// Setup
 	handler, mockUseCases := setupTestOrderHandler()
 
-	expectedResponse := &dto.OrderListResponseDTO{
-		Orders:   []*dto.OrderResponseDTO{},
-		Total:    0,
-		Page:     2,
-		PageSize: 5,
-	}
-
-	mockUseCases.On("ListOrders", mock.Anything, 2, 5).Return(expectedResponse, nil)
-
-	// Create request with pagination parameters
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders?page=2&page_size=5", nil)
+	// Create request
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/stats?from=2025-02-01&to=2025-01-01", nil)
 	rec := httptest.NewRecorder()
 	c := echo.New().NewContext(req, rec)
 
 	// Execute
-	err := handler.ListOrders(c)
+	err := handler.GetOrderStats(c)
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, http.StatusOK, rec.Code)
-
-	mockUseCases.AssertExpectations(t)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUseCases.AssertNotCalled(t, "GetOrderStats", mock.Anything, mock.Anything)
 }
 
-// GetCustomerOrders Tests
-func TestOrderHandler_GetCustomerOrders_Success(t *testing.T) {
+func TestOrderHandler_GetOrderStats_RangeTooLong(t *testing.T) {
 	// Setup
 	handler, mockUseCases := setupTestOrderHandler()
 
-	expectedOrders := []*dto.OrderResponseDTO{
-		{
-			ID:          1,
-			CustomerID:  123,
-			Items:       []dto.OrderItemResponseDTO{},
-			TotalAmount: 100.00,
-			Status:      entities.OrderStatusPending,
-		},
-	}
-
-	expectedResponse := &dto.OrderListResponseDTO{
-		Orders:   expectedOrders,
-		Total:    1,
-		Page:     0,
-		PageSize: 10,
-	}
-
-	mockUseCases.On("GetCustomerOrders", mock.Anything, uint(123), 0, 10).Return(expectedResponse, nil)
+	mockUseCases.On("GetOrderStats", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrInvalidDateRange)
 
 	// Create request
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/customers/123/orders", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/stats?from=2020-01-01", nil)
 	rec := httptest.NewRecorder()
 	c := echo.New().NewContext(req, rec)
-	c.SetParamNames("customer_id")
-	c.SetParamValues("123")
 
 	// Execute
-	err := handler.GetCustomerOrders(c)
+	err := handler.GetOrderStats(c)
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
 
-	var response dto.OrderListResponseDTO
+	var response ErrorResponse
 	err = json.Unmarshal(rec.Body.Bytes(), &response)
 	require.NoError(t, err)
-
-	assert.Len(t, response.Orders, 1)
-	assert.Equal(t, uint(123), response.Orders[0].CustomerID)
-
-	mockUseCases.AssertExpectations(t)
+	assert.Equal(t, "INVALID_DATE_RANGE", response.Error)
 }
 
-// GetOrdersByStatus Tests
-func TestOrderHandler_GetOrdersByStatus_Success(t *testing.T) {
+// RepriceOrder Tests
+func TestOrderHandler_RepriceOrder_Success(t *testing.T) {
 	// Setup
 	handler, mockUseCases := setupTestOrderHandler()
 
-	expectedOrders := []*dto.OrderResponseDTO{
-		{
+	expectedResponse := &dto.RepriceOrderResponseDTO{
+		OrderResponseDTO: &dto.OrderResponseDTO{
 			ID:          1,
 			CustomerID:  123,
 			Items:       []dto.OrderItemResponseDTO{},
-			TotalAmount: 100.00,
+			TotalAmount: 25.00,
 			Status:      entities.OrderStatusPending,
 		},
+		PriceChanges: []dto.PriceChangeDTO{{ItemID: 10, ProductID: 1, OldUnitPrice: 10.00, NewUnitPrice: 12.50}},
 	}
-
-	expectedResponse := &dto.OrderListResponseDTO{
-		Orders:   expectedOrders,
-		Total:    1,
-		Page:     0,
-		PageSize: 10,
-	}
-
-	mockUseCases.On("GetOrdersByStatus", mock.Anything, entities.OrderStatusPending, 0, 10).Return(expectedResponse, nil)
+	mockUseCases.On("RepriceOrder", mock.Anything, uint(1)).Return(expectedResponse, nil)
 
 	// Create request
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/status/pending", nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/reprice", nil)
 	rec := httptest.NewRecorder()
 	c := echo.New().NewContext(req, rec)
-	c.SetParamNames("status")
-	c.SetParamValues("pending")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
 
 	// Execute
-	err := handler.GetOrdersByStatus(c)
+	err := handler.RepriceOrder(c)
 
 	// Assert
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rec.Code)
 
-	var response dto.OrderListResponseDTO
-	err = json.Unmarshal(rec.Body.Bytes(), &response)
-	require.NoError(t, err)
-
-	assert.Len(t, response.Orders, 1)
-	assert.Equal(t, entities.OrderStatusPending, response.Orders[0].Status)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, float64(1), response["id"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"item_id": float64(10), "product_id": float64(1), "old_unit_price": 10.0, "new_unit_price": 12.5,
+	}}, response["price_changes"])
 
 	mockUseCases.AssertExpectations(t)
 }
 
-// DeleteOrder Tests
-func TestOrderHandler_DeleteOrder_Success(t *testing.T) {
+func TestOrderHandler_RepriceOrder_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"not pending", domainErrors.ErrOrderNotRepriceable, http.StatusConflict, "ORDER_NOT_REPRICEABLE"},
+		{"catalog unavailable", domainErrors.ErrCatalogUnavailable, http.StatusServiceUnavailable, "CATALOG_UNAVAILABLE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			handler, mockUseCases := setupTestOrderHandler()
+			mockUseCases.On("RepriceOrder", mock.Anything, uint(1)).Return(nil, tt.err)
+
+			// Create request
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/reprice", nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			// Execute
+			err := handler.RepriceOrder(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.expectedCode)
+		})
+	}
+}
+
+func TestOrderHandler_UpdateOrder_Success(t *testing.T) {
 	// Setup
 	handler, mockUseCases := setupTestOrderHandler()
 
-	mockUseCases.On("DeleteOrder", mock.Anything, uint(1)).Return(nil)
+	request := &dto.UpdateOrderRequestDTO{Priority: entities.OrderPriorityUrgent}
+	expectedResponse := &dto.OrderResponseDTO{
+		ID:       1,
+		Status:   entities.OrderStatusConfirmed,
+		Priority: entities.OrderPriorityUrgent,
+	}
+
+	mockUseCases.On("UpdateOrder", mock.Anything, uint(1), request).Return(expectedResponse, nil)
 
 	// Create request
-	req := httptest.NewRequest(http.MethodDelete, "/api/v1/orders/1", nil)
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/orders/1", bytes.NewBufferString(`{"priority":"urgent"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := echo.New().NewContext(req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("1")
 
 	// Execute
-	err := handler.DeleteOrder(c)
+	err := handler.UpdateOrder(c)
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.OrderResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, entities.OrderPriorityUrgent, response.Priority)
 
 	mockUseCases.AssertExpectations(t)
 }
 
-func TestOrderHandler_DeleteOrder_NotFound(t *testing.T) {
+func TestOrderHandler_UpdateOrder_Rejected(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		useCaseErr error
+		wantStatus int
+	}{
+		{name: "unknown priority", body: `{"priority":"asap"}`, wantStatus: http.StatusBadRequest},
+		{name: "nothing to change", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid email", body: `{"customer_email":"jane"}`, wantStatus: http.StatusBadRequest},
+		{name: "contact of confirmed order", body: `{"customer_name":"Jane"}`, useCaseErr: domainErrors.ErrContactChangeNotAllowed, wantStatus: http.StatusConflict},
+		{name: "order in fulfillment", body: `{"priority":"high"}`, useCaseErr: domainErrors.ErrPriorityChangeNotAllowed, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			handler, mockUseCases := setupTestOrderHandler()
+			if tt.useCaseErr != nil {
+				mockUseCases.On("UpdateOrder", mock.Anything, uint(1), mock.Anything).Return(nil, tt.useCaseErr)
+			}
+
+			// Create request
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/orders/1", bytes.NewBufferString(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			// Execute
+			err := handler.UpdateOrder(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.useCaseErr == nil {
+				mockUseCases.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestOrderHandler_GetOrderQueue(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		status entities.OrderStatus
+	}{
+		{name: "confirmed by default", query: "", status: entities.OrderStatusConfirmed},
+		{name: "requested status", query: "?status=Processing", status: entities.OrderStatusProcessing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			handler, mockUseCases := setupTestOrderHandler()
+
+			expectedResponse := &dto.OrderListResponseDTO{
+				Orders: []*dto.OrderResponseDTO{
+					{ID: 2, Status: tt.status, Priority: entities.OrderPriorityUrgent},
+					{ID: 1, Status: tt.status, Priority: entities.OrderPriorityNormal},
+				},
+				Total:    2,
+				PageSize: 10,
+			}
+			mockUseCases.On("GetOrderQueue", mock.Anything, tt.status, 0, 10).Return(expectedResponse, nil)
+
+			// Create request
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/queue"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			// Execute
+			err := handler.GetOrderQueue(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var response dto.OrderListResponseDTO
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response.Orders, 2)
+			assert.Equal(t, entities.OrderPriorityUrgent, response.Orders[0].Priority)
+
+			mockUseCases.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderHandler_GetOrderQueue_InvalidStatus(t *testing.T) {
 	// Setup
 	handler, mockUseCases := setupTestOrderHandler()
 
-	mockUseCases.On("DeleteOrder", mock.Anything, uint(999)).Return(domainErrors.ErrOrderNotFound)
-
 	// Create request
-	req := httptest.NewRequest(http.MethodDelete, "/api/v1/orders/999", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/queue?status=unknown", nil)
 	rec := httptest.NewRecorder()
 	c := echo.New().NewContext(req, rec)
-	c.SetParamNames("id")
-	c.SetParamValues("999")
 
 	// Execute
-	err := handler.DeleteOrder(c)
+	err := handler.GetOrderQueue(c)
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, http.StatusNotFound, rec.Code)
-
-	var response ErrorResponse
-	err = json.Unmarshal(rec.Body.Bytes(), &response)
-	require.NoError(t, err)
-
-	assert.Equal(t, "ORDER_NOT_FOUND", response.Error)
-
-	mockUseCases.AssertExpectations(t)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockUseCases.AssertNotCalled(t, "GetOrderQueue", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
@@ -0,0 +1,14 @@
+package handlers
+
+import (
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequestID returns the ID of the request, taken from the X-Request-ID header of the client or generated
+// by the request ID middleware, which stores it in the request context. It is empty when the middleware
+// is not installed.
+func RequestID(c echo.Context) string {
+	return logger.RequestIDFromContext(c.Request().Context())
+}
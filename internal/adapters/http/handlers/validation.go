@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+
+	"orders-service/internal/application/dto"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes why a request field was rejected
+type FieldError struct {
+	Message string      `json:"message"`
+	Tag     string      `json:"tag"`
+	Value   interface{} `json:"value"`
+}
+
+// newValidator returns a validator that names fields after their JSON keys, so errors
+// match the API contract rather than the Go structs
+func newValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	return validate
+}
+
+// validationDetails keys every rejected field by its JSON path, e.g. "items[1].quantity"
+func validationDetails(err error) map[string]interface{} {
+	details := make(map[string]interface{})
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return details
+	}
+
+	for _, fieldError := range validationErrors {
+		// The namespace starts with the name of the validated struct, which is not part of the body
+		_, path, _ := strings.Cut(fieldError.Namespace(), ".")
+		if path == "" {
+			path = fieldError.Field()
+		}
+		details[path] = FieldError{
+			Message: getValidationErrorMessage(fieldError),
+			Tag:     fieldError.Tag(),
+			Value:   fieldError.Value(),
+		}
+	}
+	return details
+}
+
+// requestValidationErrors lists the fields rejected by request validation in the shape of a dry run response
+func requestValidationErrors(err error) []dto.OrderValidationErrorDTO {
+	details := validationDetails(err)
+	paths := make([]string, 0, len(details))
+	for path := range details {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	errs := make([]dto.OrderValidationErrorDTO, 0, len(paths))
+	for _, path := range paths {
+		fieldError, _ := details[path].(FieldError)
+		errs = append(errs, dto.OrderValidationErrorDTO{
+			Error:   "VALIDATION_ERROR",
+			Message: fieldError.Message,
+			Field:   path,
+		})
+	}
+	return errs
+}
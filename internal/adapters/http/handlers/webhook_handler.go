@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"orders-service/internal/application/usecases"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler struct {
+	webhookUseCases usecases.WebhookUseCases
+	logger          logger.Logger
+}
+
+// NewWebhookHandler creates the handler of the webhook dead letters
+func NewWebhookHandler(webhookUseCases usecases.WebhookUseCases, log logger.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUseCases: webhookUseCases,
+		logger:          log.With("component", "webhook_handler"),
+	}
+}
+
+// ListDeadLetters handles GET /api/v1/webhooks/:id/dead-letters
+func (h *WebhookHandler) ListDeadLetters(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	webhookID := c.Param("id")
+
+	from, to, err := parseDateRange(c, "from", "to")
+	if err != nil {
+		return h.invalidFilterResponse(c, requestID, err)
+	}
+
+	page, pageSize, err := parsePaginationParams(c)
+	if err != nil {
+		return invalidPaginationResponse(c, h.logger, requestID, err)
+	}
+
+	h.logger.Info("List webhook dead letters request received",
+		"request_id", requestID,
+		"webhook_id", webhookID,
+		"from", from,
+		"to", to,
+		"page", page,
+		"page_size", pageSize)
+
+	response, err := h.webhookUseCases.ListDeadLetters(c.Request().Context(), webhookID, from, to, page, pageSize)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to list webhook dead letters")
+	}
+
+	h.logger.Info("Webhook dead letters retrieved successfully",
+		"request_id", requestID,
+		"count", len(response.DeadLetters))
+
+	return writeList(c, response, response.DeadLetters, listPage{
+		Page:       response.Page,
+		PageSize:   response.PageSize,
+		Total:      response.Total,
+		TotalPages: response.TotalPages,
+	})
+}
+
+// ReplayDeadLetter handles POST /api/v1/webhooks/:id/dead-letters/:dead_letter_id/replay
+func (h *WebhookHandler) ReplayDeadLetter(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	webhookID := c.Param("id")
+
+	deadLetterID, err := parseUintParam(c, "dead_letter_id")
+	if err != nil {
+		return WriteError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "INVALID_ID",
+			Message: "Invalid dead letter ID format",
+		})
+	}
+
+	h.logger.Info("Replay webhook dead letter request received",
+		"request_id", requestID,
+		"webhook_id", webhookID,
+		"dead_letter_id", deadLetterID)
+
+	response, err := h.webhookUseCases.ReplayDeadLetter(c.Request().Context(), webhookID, deadLetterID)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to replay webhook dead letter")
+	}
+
+	h.logger.Info("Webhook dead letter replayed successfully",
+		"request_id", requestID,
+		"webhook_id", webhookID,
+		"dead_letter_id", deadLetterID)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// ReplayDeadLetters handles POST /api/v1/webhooks/:id/dead-letters/replay, replaying the oldest pending
+// dead letters between the from and to query parameters up to the configured batch size
+func (h *WebhookHandler) ReplayDeadLetters(c echo.Context) error {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	webhookID := c.Param("id")
+
+	from, to, err := parseDateRange(c, "from", "to")
+	if err != nil {
+		return h.invalidFilterResponse(c, requestID, err)
+	}
+
+	h.logger.Info("Replay webhook dead letters request received",
+		"request_id", requestID,
+		"webhook_id", webhookID,
+		"from", from,
+		"to", to)
+
+	response, err := h.webhookUseCases.ReplayDeadLetters(c.Request().Context(), webhookID, from, to)
+	if err != nil {
+		return h.handleError(c, err, requestID, "Failed to replay webhook dead letters")
+	}
+
+	h.logger.Info("Webhook dead letters replayed",
+		"request_id", requestID,
+		"replayed", response.Replayed,
+		"failed", response.Failed,
+		"remaining", response.Remaining)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *WebhookHandler) invalidFilterResponse(c echo.Context, requestID string, err error) error {
+	h.logger.Warn("Invalid webhook dead letter date range",
+		"request_id", requestID,
+		"error", err)
+	return WriteError(c, http.StatusBadRequest, ErrorResponse{
+		Error:   "INVALID_FILTER",
+		Message: err.Error(),
+	})
+}
+
+func (h *WebhookHandler) handleError(c echo.Context, err error, requestID, logMessage string) error {
+	h.logger.Error(logMessage,
+		"request_id", requestID,
+		"error", err)
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return WriteError(c, http.StatusGatewayTimeout, ErrorResponse{
+			Error:   "GATEWAY_TIMEOUT",
+			Message: "The request timed out",
+		})
+	}
+
+	var domainErr *domainErrors.DomainError
+	if errors.As(err, &domainErr) {
+		return WriteError(c, domainErrors.HTTPStatus(domainErr.Code), ErrorResponse{
+			Error:   domainErr.Code,
+			Message: domainErr.Message,
+			Details: domainErr.Details,
+		})
+	}
+	return WriteError(c, http.StatusInternalServerError, ErrorResponse{
+		Error:   "INTERNAL_ERROR",
+		Message: "An internal error occurred",
+	})
+}
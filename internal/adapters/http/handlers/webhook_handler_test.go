@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/dto"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockWebhookUseCases implements the WebhookUseCases interface for testing
+type MockWebhookUseCases struct {
+	mock.Mock
+}
+
+func (m *MockWebhookUseCases) ListDeadLetters(ctx context.Context, webhookID string, from, to *time.Time, page, pageSize int) (*dto.WebhookDeadLetterListResponseDTO, error) {
+	args := m.Called(ctx, webhookID, from, to, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.WebhookDeadLetterListResponseDTO), args.Error(1)
+}
+
+func (m *MockWebhookUseCases) ReplayDeadLetter(ctx context.Context, webhookID string, id uint) (*dto.WebhookDeadLetterResponseDTO, error) {
+	args := m.Called(ctx, webhookID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.WebhookDeadLetterResponseDTO), args.Error(1)
+}
+
+func (m *MockWebhookUseCases) ReplayDeadLetters(ctx context.Context, webhookID string, from, to *time.Time) (*dto.ReplayWebhookDeadLettersResponseDTO, error) {
+	args := m.Called(ctx, webhookID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ReplayWebhookDeadLettersResponseDTO), args.Error(1)
+}
+
+func setupTestWebhookHandler() (*WebhookHandler, *MockWebhookUseCases) {
+	mockUseCases := new(MockWebhookUseCases)
+	return NewWebhookHandler(mockUseCases, logger.New("test")), mockUseCases
+}
+
+func TestWebhookHandler_ListDeadLetters_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestWebhookHandler()
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	expectedResponse := &dto.WebhookDeadLetterListResponseDTO{
+		DeadLetters: []*dto.WebhookDeadLetterResponseDTO{
+			{ID: 3, WebhookID: "partner", EventType: "order.created", Payload: json.RawMessage(`{"order_id":7}`), LastError: "unexpected status 503", Attempts: 5},
+		},
+		Total:    1,
+		PageSize: 10,
+	}
+	mockUseCases.On("ListDeadLetters", mock.Anything, "partner", &from, &to, 0, 10).Return(expectedResponse, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/partner/dead-letters?from=2024-03-01&to=2024-03-01", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("partner")
+
+	// Execute
+	err := handler.ListDeadLetters(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response dto.WebhookDeadLetterListResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.DeadLetters, 1)
+	assert.Equal(t, "unexpected status 503", response.DeadLetters[0].LastError)
+	assert.JSONEq(t, `{"order_id":7}`, string(response.DeadLetters[0].Payload))
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestWebhookHandler_ListDeadLetters_V2Envelope(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestWebhookHandler()
+	expectedResponse := &dto.WebhookDeadLetterListResponseDTO{
+		DeadLetters: []*dto.WebhookDeadLetterResponseDTO{{ID: 3, WebhookID: "partner", EventType: "order.created", Payload: json.RawMessage(`{}`)}},
+		Total:       21,
+		Page:        1,
+		PageSize:    10,
+		TotalPages:  3,
+	}
+	mockUseCases.On("ListDeadLetters", mock.Anything, "partner", (*time.Time)(nil), (*time.Time)(nil), 1, 10).Return(expectedResponse, nil)
+
+	e := echo.New()
+	e.Group("/api/v2", UseAPIVersion(APIv2)).GET("/webhooks/:id/dead-letters", handler.ListDeadLetters)
+	rec := httptest.NewRecorder()
+
+	// Execute
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v2/webhooks/partner/dead-letters?page=2", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var envelope struct {
+		Data  []*dto.WebhookDeadLetterResponseDTO `json:"data"`
+		Meta  ListMeta                            `json:"meta"`
+		Links ListLinks                           `json:"links"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+	require.Len(t, envelope.Data, 1)
+	assert.Equal(t, Pagination{Page: 2, PageSize: 10, Total: 21, TotalPages: 3}, envelope.Meta.Pagination)
+	require.NotNil(t, envelope.Links.Next)
+	assert.Equal(t, "/api/v2/webhooks/partner/dead-letters?page=3", *envelope.Links.Next)
+
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestWebhookHandler_ListDeadLetters_InvalidFilter(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestWebhookHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/partner/dead-letters?from=yesterday", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("partner")
+
+	// Execute
+	err := handler.ListDeadLetters(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INVALID_FILTER")
+	mockUseCases.AssertNotCalled(t, "ListDeadLetters", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWebhookHandler_ReplayDeadLetter_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestWebhookHandler()
+
+	replayedAt := time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)
+	mockUseCases.On("ReplayDeadLetter", mock.Anything, "partner", uint(9)).
+		Return(&dto.WebhookDeadLetterResponseDTO{ID: 9, WebhookID: "partner", Payload: json.RawMessage(`{}`), ReplayedAt: &replayedAt}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/partner/dead-letters/9/replay", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id", "dead_letter_id")
+	c.SetParamValues("partner", "9")
+
+	// Execute
+	err := handler.ReplayDeadLetter(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"replayed_at":"2024-03-02T09:00:00Z"`)
+	mockUseCases.AssertExpectations(t)
+}
+
+func TestWebhookHandler_ReplayDeadLetter_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		param          string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"invalid ID", "abc", nil, http.StatusBadRequest, "INVALID_ID"},
+		{"unknown webhook", "9", domainErrors.ErrWebhookNotFound, http.StatusNotFound, "WEBHOOK_NOT_FOUND"},
+		{"not found", "9", domainErrors.ErrWebhookDeadLetterNotFound, http.StatusNotFound, "WEBHOOK_DEAD_LETTER_NOT_FOUND"},
+		{"already replayed", "9", domainErrors.ErrWebhookDeadLetterReplayed, http.StatusConflict, "WEBHOOK_DEAD_LETTER_REPLAYED"},
+		{"delivery failed", "9", domainErrors.WrapDomainError(domainErrors.ErrWebhookDeliveryFailed, errors.New("unexpected status 503")), http.StatusServiceUnavailable, "WEBHOOK_DELIVERY_FAILED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			handler, mockUseCases := setupTestWebhookHandler()
+			if tt.err != nil {
+				mockUseCases.On("ReplayDeadLetter", mock.Anything, "partner", uint(9)).Return(nil, tt.err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/partner/dead-letters/"+tt.param+"/replay", nil)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("id", "dead_letter_id")
+			c.SetParamValues("partner", tt.param)
+
+			// Execute
+			err := handler.ReplayDeadLetter(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.expectedCode)
+			mockUseCases.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookHandler_ReplayDeadLetters_Success(t *testing.T) {
+	// Setup
+	handler, mockUseCases := setupTestWebhookHandler()
+
+	from := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	response := &dto.ReplayWebhookDeadLettersResponseDTO{Replayed: 2, Failed: 1, Remaining: 4}
+	mockUseCases.On("ReplayDeadLetters", mock.Anything, "partner", &from, (*time.Time)(nil)).Return(response, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/partner/dead-letters/replay?from=2024-03-01T12:00:00Z", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("partner")
+
+	// Execute
+	err := handler.ReplayDeadLetters(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"replayed":2,"failed":1,"remaining":4}`, rec.Body.String())
+	mockUseCases.AssertExpectations(t)
+}
@@ -0,0 +1,564 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"orders-service/internal/adapters/events"
+	"orders-service/internal/adapters/http/handlers"
+	"orders-service/internal/adapters/http/middlewares/apikey"
+	"orders-service/internal/adapters/persistence/audit_repository"
+	"orders-service/internal/adapters/persistence/order_jobs_repository"
+	"orders-service/internal/adapters/persistence/orders_repository"
+	"orders-service/internal/adapters/persistence/refunds_repository"
+	"orders-service/internal/adapters/persistence/scheduled_transitions_repository"
+	"orders-service/internal/adapters/persistence/shipments_repository"
+	"orders-service/internal/adapters/persistence/snapshots_repository"
+	"orders-service/internal/adapters/persistence/transaction"
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/application/usecases"
+	"orders-service/internal/config"
+	"orders-service/internal/domain/entities"
+	"orders-service/internal/infrastructure"
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lifecycleAPIKey = "lifecycle-admin-key"
+
+// setupLifecycleServer wires the real routes, middleware and use cases over an in-memory SQLite database
+func setupLifecycleServer(t *testing.T) *Server {
+	t.Helper()
+	return setupLifecycleServerWithConfig(t, handlers.DefaultOrderHandlerConfig())
+}
+
+// setupLifecycleServerWithConfig is setupLifecycleServer with the given order handler config
+func setupLifecycleServerWithConfig(t *testing.T, handlerConfig handlers.OrderHandlerConfig) *Server {
+	t.Helper()
+	server, _ := newLifecycleServer(t, 1000, handlerConfig)
+	return server
+}
+
+// newLifecycleServer wires the server over the GORM repositories of a new in-memory SQLite database,
+// the order repository is returned for seeding. rateLimit requests a second are let through.
+func newLifecycleServer(tb testing.TB, rateLimit int, handlerConfig handlers.OrderHandlerConfig) (*Server, ports.OrderRepository) {
+	tb.Helper()
+	log := logger.New("test")
+	cfg := &config.Config{
+		Security: config.SecurityConfig{
+			RateLimitRPS:   rateLimit,
+			RateLimitBurst: rateLimit,
+			APIKeys: []config.APIKeyConfig{
+				{Name: "lifecycle", Hash: apikey.HashKey(lifecycleAPIKey), Scopes: []string{"orders:admin"}},
+			},
+		},
+	}
+	server := &Server{
+		echo:   echo.New(),
+		config: cfg,
+		logger: log,
+	}
+
+	connections, err := infrastructure.NewDatabaseConnections(&config.Config{
+		Database: config.DatabaseConfig{Driver: config.DriverSQLite, DSN: ":memory:", LogLevel: "silent"},
+	}, log)
+	require.NoError(tb, err)
+	tb.Cleanup(func() { _ = connections.Close() })
+
+	db := connections.GetGormDB()
+	orderRepo := order_repository.NewGormOrderRepository(db)
+	unitOfWork := transaction.NewGormUnitOfWork(db, ports.Repositories{
+		Orders:               orderRepo,
+		Audit:                audit_repository.NewGormAuditRepository(db),
+		Shipments:            shipment_repository.NewGormShipmentRepository(db),
+		Refunds:              refund_repository.NewGormRefundRepository(db),
+		Snapshots:            snapshot_repository.NewGormSnapshotRepository(db),
+		ScheduledTransitions: scheduled_transition_repository.NewGormScheduledTransitionRepository(db),
+		OrderJobs:            order_job_repository.NewGormOrderJobRepository(db),
+	})
+	bus := events.NewBus(10, 16, log)
+	orderUseCases := usecases.NewOrderUseCasesWithConfig(orderRepo, unitOfWork, bus, nil, log, usecases.DefaultOrderUseCasesConfig())
+	server.registerRoutes(
+		handlers.NewHealthHandler(log, nil),
+		handlers.NewOrderHandlerWithConfig(orderUseCases, log, handlerConfig),
+		handlers.NewOrderEventsHandler(usecases.NewOrderEventUseCases(orderRepo, bus, log), orderUseCases, time.Hour, log),
+		handlers.NewAuditHandler(nil, orderUseCases, log),
+		handlers.NewWebhookHandler(nil, log),
+		handlers.NewDocsHandler(log),
+	)
+	return server, orderRepo
+}
+
+func doLifecycleRequest(t *testing.T, server *Server, method, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	var payload bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&payload).Encode(body))
+	}
+	req := httptest.NewRequest(method, path, &payload)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(apikey.HeaderAPIKey, lifecycleAPIKey)
+	rec := httptest.NewRecorder()
+	server.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeOrder(t *testing.T, rec *httptest.ResponseRecorder) dto.OrderResponseDTO {
+	t.Helper()
+	var order dto.OrderResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &order), rec.Body.String())
+	return order
+}
+
+func TestServer_OrderLifecycle(t *testing.T) {
+	server := setupLifecycleServer(t)
+
+	rec := doLifecycleRequest(t, server, http.MethodPost, "/api/v1/orders", dto.CreateOrderRequestDTO{
+		CustomerID: 7,
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 10},
+		},
+	})
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	created := decodeOrder(t, rec)
+	require.NotZero(t, created.ID)
+	assert.Equal(t, entities.OrderStatusPending, created.Status)
+	orderPath := fmt.Sprintf("/api/v1/orders/%d", created.ID)
+
+	rec = doLifecycleRequest(t, server, http.MethodPost, orderPath+"/items", dto.AddOrderItemRequestDTO{
+		ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 5,
+	})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.InDelta(t, 25.0, decodeOrder(t, rec).TotalAmount, 0.001)
+
+	rec = doLifecycleRequest(t, server, http.MethodPost, orderPath+"/confirm", nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, entities.OrderStatusConfirmed, decodeOrder(t, rec).Status)
+
+	for _, status := range []entities.OrderStatus{entities.OrderStatusProcessing, entities.OrderStatusShipped, entities.OrderStatusDelivered} {
+		rec = doLifecycleRequest(t, server, http.MethodPut, orderPath+"/status", dto.UpdateOrderStatusRequestDTO{Status: status})
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, status, decodeOrder(t, rec).Status)
+	}
+
+	rec = doLifecycleRequest(t, server, http.MethodGet, orderPath, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	order := decodeOrder(t, rec)
+	assert.Equal(t, entities.OrderStatusDelivered, order.Status)
+	assert.Len(t, order.Items, 2)
+}
+
+func TestServer_OrderByPublicID(t *testing.T) {
+	server := setupLifecycleServer(t)
+
+	rec := doLifecycleRequest(t, server, http.MethodPost, "/api/v1/orders", dto.CreateOrderRequestDTO{
+		CustomerID: 7,
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 10},
+		},
+	})
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	created := decodeOrder(t, rec)
+	require.True(t, entities.IsPublicID(created.PublicID), created.PublicID)
+	orderPath := "/api/v1/orders/" + created.PublicID
+
+	rec = doLifecycleRequest(t, server, http.MethodGet, orderPath, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, created.ID, decodeOrder(t, rec).ID)
+
+	rec = doLifecycleRequest(t, server, http.MethodPost, orderPath+"/confirm", nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, entities.OrderStatusConfirmed, decodeOrder(t, rec).Status)
+
+	rec = doLifecycleRequest(t, server, http.MethodGet, "/api/v1/orders/"+entities.NewPublicID(), nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+}
+
+func TestServer_CountsMatchListTotals(t *testing.T) {
+	server := setupLifecycleServer(t)
+
+	for _, customerID := range []uint{7, 7, 8} {
+		rec := doLifecycleRequest(t, server, http.MethodPost, "/api/v1/orders", dto.CreateOrderRequestDTO{
+			CustomerID: customerID,
+			Items: []dto.CreateOrderItemDTO{
+				{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 1, UnitPrice: 10},
+			},
+		})
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	rec := doLifecycleRequest(t, server, http.MethodPost, "/api/v1/orders/1/confirm", nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	tests := []struct {
+		list  string
+		count string
+		total int64
+	}{
+		{"/api/v1/orders", "/api/v1/orders/count", 3},
+		{"/api/v1/customers/7/orders", "/api/v1/orders/count?customer_id=7", 2},
+		{"/api/v1/customers/7/orders?status=pending", "/api/v1/orders/count?customer_id=7&status=pending", 1},
+		{"/api/v1/orders/status/pending", "/api/v1/orders/count?status=pending", 2},
+		{"/api/v1/orders/status/pending?customer_id=8", "/api/v1/orders/count?status=pending&customer_id=8", 1},
+	}
+
+	for _, tt := range tests {
+		rec = doLifecycleRequest(t, server, http.MethodGet, tt.list, nil)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var list dto.OrderListResponseDTO
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+		assert.Equal(t, tt.total, list.Total, tt.list)
+
+		rec = doLifecycleRequest(t, server, http.MethodHead, tt.list, nil)
+		assert.Equal(t, http.StatusOK, rec.Code, tt.list)
+		assert.Equal(t, fmt.Sprint(tt.total), rec.Header().Get(handlers.HeaderTotalCount), tt.list)
+		assert.Empty(t, rec.Body.String(), tt.list)
+
+		rec = doLifecycleRequest(t, server, http.MethodGet, tt.count, nil)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.JSONEq(t, fmt.Sprintf(`{"count":%d}`, tt.total), rec.Body.String(), tt.count)
+	}
+}
+
+func TestServer_DryRunCreatesNothing(t *testing.T) {
+	server := setupLifecycleServer(t)
+
+	rec := doLifecycleRequest(t, server, http.MethodPost, "/api/v1/orders?dry_run=true", dto.CreateOrderRequestDTO{
+		CustomerID: 7,
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 3, UnitPrice: 10},
+		},
+	})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var preview dto.OrderValidationResponseDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &preview))
+	assert.True(t, preview.Valid)
+	assert.Equal(t, 30.0, preview.TotalAmount)
+	assert.NotContains(t, rec.Body.String(), `"id"`)
+
+	rec = doLifecycleRequest(t, server, http.MethodGet, "/api/v1/orders/count", nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"count":0}`, rec.Body.String())
+}
+
+func TestServer_DoubleSubmittedStatusTransition(t *testing.T) {
+	server := setupLifecycleServer(t)
+	rec := doLifecycleRequest(t, server, http.MethodPost, "/api/v1/orders", dto.CreateOrderRequestDTO{
+		CustomerID: 7,
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 1, UnitPrice: 10},
+		},
+	})
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	cancel := dto.UpdateOrderStatusRequestDTO{Status: entities.OrderStatusCancelled}
+	rec = doLifecycleRequest(t, server, http.MethodPut, "/api/v1/orders/1/status", cancel)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	first := decodeOrder(t, rec)
+	assert.False(t, first.AlreadyInState)
+	assert.NotContains(t, rec.Body.String(), "already_in_state")
+
+	rec = doLifecycleRequest(t, server, http.MethodPut, "/api/v1/orders/1/status", cancel)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	second := decodeOrder(t, rec)
+	assert.True(t, second.AlreadyInState)
+	assert.Equal(t, entities.OrderStatusCancelled, second.Status)
+	assert.Equal(t, first.UpdatedAt, second.UpdatedAt)
+
+	rec = doLifecycleRequest(t, server, http.MethodPut, "/api/v1/orders/1/status", dto.UpdateOrderStatusRequestDTO{Status: entities.OrderStatusConfirmed})
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "INVALID_STATUS_TRANSITION")
+}
+
+func TestServer_DoubleSubmittedOrderActions(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  []string
+		action string
+		body   any
+		want   entities.OrderStatus
+	}{
+		{name: "confirm", action: "/confirm", want: entities.OrderStatusConfirmed},
+		{name: "cancel", action: "/cancel", want: entities.OrderStatusCancelled},
+		{name: "hold", action: "/hold", body: dto.PlaceOrderOnHoldRequestDTO{Reason: "fraud review"}, want: entities.OrderStatusOnHold},
+		{name: "release", setup: []string{"/hold"}, action: "/release", want: entities.OrderStatusPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := setupLifecycleServer(t)
+			rec := doLifecycleRequest(t, server, http.MethodPost, "/api/v1/orders", dto.CreateOrderRequestDTO{
+				CustomerID: 7,
+				Items: []dto.CreateOrderItemDTO{
+					{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 1, UnitPrice: 10},
+				},
+			})
+			require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+			for _, action := range tt.setup {
+				rec = doLifecycleRequest(t, server, http.MethodPost, "/api/v1/orders/1"+action, dto.PlaceOrderOnHoldRequestDTO{Reason: "fraud review"})
+				require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			}
+
+			rec = doLifecycleRequest(t, server, http.MethodPost, "/api/v1/orders/1"+tt.action, tt.body)
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			first := decodeOrder(t, rec)
+			assert.NotContains(t, rec.Body.String(), "already_in_state")
+
+			rec = doLifecycleRequest(t, server, http.MethodPost, "/api/v1/orders/1"+tt.action, tt.body)
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			second := decodeOrder(t, rec)
+			assert.True(t, second.AlreadyInState)
+			assert.Equal(t, tt.want, first.Status)
+			assert.Equal(t, tt.want, second.Status)
+			assert.Equal(t, first.UpdatedAt, second.UpdatedAt)
+		})
+	}
+}
+
+func TestServer_OrderEventStream(t *testing.T) {
+	server := setupLifecycleServer(t)
+	rec := doLifecycleRequest(t, server, http.MethodPost, "/api/v1/orders", dto.CreateOrderRequestDTO{
+		CustomerID: 7,
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 10},
+		},
+	})
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	orderPath := fmt.Sprintf("/api/v1/orders/%d", decodeOrder(t, rec).ID)
+
+	// Open the stream over a real connection, the recorder cannot be read while the handler runs
+	httpServer := httptest.NewServer(server.echo)
+	defer httpServer.Close()
+	req, err := http.NewRequest(http.MethodGet, httpServer.URL+orderPath+"/events", nil)
+	require.NoError(t, err)
+	req.Header.Set(apikey.HeaderAPIKey, lifecycleAPIKey)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	rec = doLifecycleRequest(t, server, http.MethodPost, orderPath+"/confirm", nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "event: order.status_changed\n", line)
+	line, err = reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Contains(t, line, `"status":"confirmed"`)
+}
+
+func TestServer_ImportOrders(t *testing.T) {
+	server := setupLifecycleServer(t)
+
+	line := `{"customer_id":7,"external_reference":"%s","items":[{"product_id":1,"product_sku":"SKU-001","product_name":"Product 1","quantity":2,"unit_price":10}]}`
+	body := fmt.Sprintf(line+"\n"+line+"\n\n"+line+"\n", "LEGACY-1", "LEGACY-1", "LEGACY-2")
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/orders/import", bytes.NewBufferString(body))
+	req.Header.Set(echo.HeaderContentType, "application/x-ndjson")
+	req.Header.Set(apikey.HeaderAPIKey, lifecycleAPIKey)
+	rec := httptest.NewRecorder()
+	server.echo.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var report struct {
+		Results []dto.ImportOrderResultDTO `json:"results"`
+		dto.ImportOrdersSummaryDTO
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report), rec.Body.String())
+	assert.Equal(t, dto.ImportOrdersSummaryDTO{Lines: 3, Created: 2, Failed: 1}, report.ImportOrdersSummaryDTO)
+	require.Len(t, report.Results, 3)
+	assert.Equal(t, []int{1, 2, 4}, []int{report.Results[0].Line, report.Results[1].Line, report.Results[2].Line})
+	require.NotNil(t, report.Results[1].Error)
+	assert.Equal(t, "DUPLICATE_EXTERNAL_REFERENCE", report.Results[1].Error.Error)
+
+	rec = doLifecycleRequest(t, server, http.MethodGet, fmt.Sprintf("/api/v1/orders/%d", report.Results[2].OrderID), nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	order := decodeOrder(t, rec)
+	assert.Equal(t, "LEGACY-2", order.ExternalReference)
+	assert.Equal(t, report.Results[2].OrderNumber, order.OrderNumber)
+}
+
+func TestServer_HistoricalStatusOnlyThroughImport(t *testing.T) {
+	createdAt := time.Date(2023, 5, 1, 9, 30, 0, 0, time.UTC)
+	body := map[string]any{
+		"customer_id": 7,
+		"items":       []map[string]any{{"product_id": 1, "product_sku": "SKU-001", "product_name": "Product 1", "quantity": 2, "unit_price": 10}},
+		"status":      "delivered",
+		"created_at":  createdAt,
+		"updated_at":  createdAt,
+	}
+
+	// Strict binding rejects the fields outright
+	rec := doLifecycleRequest(t, setupLifecycleServer(t), http.MethodPost, "/api/v1/orders", body)
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "UNKNOWN_FIELD")
+
+	// Lenient binding drops them, the order starts pending now
+	server := setupLifecycleServerWithConfig(t, handlers.OrderHandlerConfig{StrictBinding: false})
+	before := time.Now()
+	rec = doLifecycleRequest(t, server, http.MethodPost, "/api/v1/orders", body)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	created := decodeOrder(t, rec)
+	assert.Equal(t, entities.OrderStatusPending, created.Status)
+	assert.False(t, created.CreatedAt.Before(before))
+	assert.NotNil(t, created.ExpiresAt)
+
+	// The import keeps them
+	line, err := json.Marshal(body)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/orders/import", bytes.NewReader(line))
+	req.Header.Set(echo.HeaderContentType, "application/x-ndjson")
+	req.Header.Set(apikey.HeaderAPIKey, lifecycleAPIKey)
+	rec = httptest.NewRecorder()
+	server.echo.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var report struct {
+		Results []dto.ImportOrderResultDTO `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report), rec.Body.String())
+	require.Len(t, report.Results, 1)
+	require.Nil(t, report.Results[0].Error)
+
+	rec = doLifecycleRequest(t, server, http.MethodGet, fmt.Sprintf("/api/v1/orders/%d", report.Results[0].OrderID), nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	imported := decodeOrder(t, rec)
+	assert.Equal(t, entities.OrderStatusDelivered, imported.Status)
+	assert.True(t, createdAt.Equal(imported.CreatedAt))
+	assert.True(t, createdAt.Equal(imported.UpdatedAt))
+	assert.Nil(t, imported.ExpiresAt)
+}
+
+func TestServer_AmendConfirmedOrder(t *testing.T) {
+	server := setupLifecycleServer(t)
+
+	rec := doLifecycleRequest(t, server, http.MethodPost, "/api/v1/orders", dto.CreateOrderRequestDTO{
+		CustomerID: 7,
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 10},
+		},
+	})
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	orderPath := fmt.Sprintf("/api/v1/orders/%d", decodeOrder(t, rec).ID)
+	rec = doLifecycleRequest(t, server, http.MethodPost, orderPath+"/confirm", nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// Item changes of the confirmed order are refused
+	rec = doLifecycleRequest(t, server, http.MethodPost, orderPath+"/items", dto.AddOrderItemRequestDTO{
+		ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 5,
+	})
+	require.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "ORDER_REQUIRES_AMENDMENT")
+
+	// The amendment changes them and is recorded
+	rec = doLifecycleRequest(t, server, http.MethodPost, orderPath+"/amend", dto.AmendOrderRequestDTO{
+		Reason: "customer called",
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 10},
+			{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 5},
+		},
+	})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = doLifecycleRequest(t, server, http.MethodGet, orderPath, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	order := decodeOrder(t, rec)
+	assert.Equal(t, entities.OrderStatusConfirmed, order.Status)
+	assert.InDelta(t, 25.0, order.TotalAmount, 0.001)
+	assert.Equal(t, 1, order.AmendmentCount)
+	require.Len(t, order.Amendments, 1)
+	assert.Equal(t, "customer called", order.Amendments[0].Reason)
+	require.Len(t, order.Amendments[0].Changes, 1)
+	assert.Equal(t, uint(2), order.Amendments[0].Changes[0].ProductID)
+	assert.Equal(t, 1, order.Amendments[0].Changes[0].QuantityAfter)
+}
+
+// TestServer_ConcurrentReadersAndWriter reads an order and streams its events while another client keeps
+// adding items, run it with go test -race to check no order is shared between the goroutines
+func TestServer_ConcurrentReadersAndWriter(t *testing.T) {
+	server := setupLifecycleServer(t)
+	rec := doLifecycleRequest(t, server, http.MethodPost, "/api/v1/orders", dto.CreateOrderRequestDTO{
+		CustomerID: 7,
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 1, UnitPrice: 10},
+		},
+	})
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	orderPath := fmt.Sprintf("/api/v1/orders/%d", decodeOrder(t, rec).ID)
+
+	httpServer := httptest.NewServer(server.echo)
+	defer httpServer.Close()
+	req, err := http.NewRequest(http.MethodGet, httpServer.URL+orderPath+"/events", nil)
+	require.NoError(t, err)
+	req.Header.Set(apikey.HeaderAPIKey, lifecycleAPIKey)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	streamed := make(chan int)
+	go func() {
+		events := 0
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if strings.HasPrefix(scanner.Text(), "event: ") {
+				events++
+			}
+		}
+		streamed <- events
+	}()
+
+	const added = 20
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				// require must not stop the test from another goroutine, so decode without decodeOrder
+				var order dto.OrderResponseDTO
+				rec := doLifecycleRequest(t, server, http.MethodGet, orderPath, nil)
+				if !assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &order), rec.Body.String()) {
+					return
+				}
+				total := 0.0
+				for _, item := range order.Items {
+					total += item.TotalPrice
+				}
+				assert.InDelta(t, total, order.TotalAmount, 0.001, "an order is read in one state")
+			}
+		}()
+	}
+
+	for i := 0; i < added; i++ {
+		rec := doLifecycleRequest(t, server, http.MethodPost, orderPath+"/items", dto.AddOrderItemRequestDTO{
+			ProductID: uint(i + 2), ProductSKU: fmt.Sprintf("SKU-%03d", i+2), ProductName: "Product", Quantity: 1, UnitPrice: 5,
+		})
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	}
+	close(done)
+	wg.Wait()
+
+	order := decodeOrder(t, doLifecycleRequest(t, server, http.MethodGet, orderPath, nil))
+	assert.Len(t, order.Items, added+1)
+	assert.InDelta(t, 10.0+5*added, order.TotalAmount, 0.001)
+
+	resp.Body.Close()
+	assert.Positive(t, <-streamed)
+}
@@ -0,0 +1,154 @@
+package apikey
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"orders-service/internal/adapters/http/handlers"
+	"orders-service/internal/application/auth"
+	"orders-service/internal/config"
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderAPIKey is the header carrying the client API key
+const HeaderAPIKey = "X-API-Key"
+
+// HeaderCustomerID is the header the gateway sets to the customer a customer scoped key acts for
+const HeaderCustomerID = "X-Customer-ID"
+
+// HashKey returns the hex encoded SHA-256 of an API key, the format stored in config
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Authenticate validates the X-API-Key header against the configured key hashes
+// and attaches the matching principal to the request context. Customer scoped keys
+// are bound to the customer in the X-Customer-ID header. Requests without a valid
+// key are rejected. It is Identify followed by RequireAuthenticated.
+func Authenticate(keys []config.APIKeyConfig, logger logger.Logger) echo.MiddlewareFunc {
+	identify := Identify(keys, logger)
+	requireAuthenticated := RequireAuthenticated()
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return identify(requireAuthenticated(next))
+	}
+}
+
+// Identify validates the X-API-Key header like Authenticate but lets requests without
+// a valid key through without a principal, so middleware such as the rate limiter can
+// run between identifying the client and RequireAuthenticated rejecting it.
+func Identify(keys []config.APIKeyConfig, logger logger.Logger) echo.MiddlewareFunc {
+	principals := make(map[string]*auth.Principal, len(keys))
+	customerScoped := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		scopes := make([]auth.Scope, 0, len(key.Scopes))
+		for _, scope := range key.Scopes {
+			scopes = append(scopes, auth.Scope(scope))
+		}
+		hash := strings.ToLower(key.Hash)
+		principals[hash] = &auth.Principal{
+			Name:   key.Name,
+			Scopes: scopes,
+			KeyID:  hash,
+		}
+		customerScoped[hash] = key.CustomerScoped
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			requestID := handlers.RequestID(c)
+
+			key := c.Request().Header.Get(HeaderAPIKey)
+			if key == "" {
+				logger.Warn("Missing API key",
+					"request_id", requestID,
+					"remote_ip", c.RealIP())
+				return next(c)
+			}
+
+			hash := HashKey(key)
+			principal, ok := principals[hash]
+			if !ok {
+				logger.Warn("Invalid API key",
+					"request_id", requestID,
+					"remote_ip", c.RealIP())
+				return next(c)
+			}
+
+			if customerScoped[hash] {
+				customerID, err := strconv.ParseUint(c.Request().Header.Get(HeaderCustomerID), 10, 0)
+				if err != nil || customerID == 0 {
+					logger.Warn("Missing customer ID for customer scoped API key",
+						"request_id", requestID,
+						"principal", principal.Name)
+					c.Set(rejectionKey, "A valid X-Customer-ID header is required for this API key")
+					return next(c)
+				}
+
+				scoped := *principal
+				scoped.CustomerID = uint(customerID)
+				principal = &scoped
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(auth.WithPrincipal(req.Context(), principal)))
+
+			return next(c)
+		}
+	}
+}
+
+// RequireAuthenticated rejects requests Identify attached no principal to
+func RequireAuthenticated() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, ok := auth.PrincipalFromContext(c.Request().Context()); !ok {
+				return unauthenticated(c)
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireScope rejects requests whose principal lacks scope
+func RequireScope(scope auth.Scope) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, ok := auth.PrincipalFromContext(c.Request().Context())
+			if !ok {
+				return unauthenticated(c)
+			}
+
+			if !principal.HasScope(scope) {
+				return handlers.WriteError(c, http.StatusForbidden, handlers.ErrorResponse{
+					Error:   "FORBIDDEN",
+					Message: "API key does not have the required scope",
+					Details: map[string]interface{}{
+						"missing_scope": string(scope),
+					},
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// rejectionKey stores why Identify did not attach a principal when the default message does not say it
+const rejectionKey = "apikey.rejection"
+
+func unauthenticated(c echo.Context) error {
+	message, ok := c.Get(rejectionKey).(string)
+	if !ok {
+		message = "A valid API key is required"
+	}
+	return handlers.WriteError(c, http.StatusUnauthorized, handlers.ErrorResponse{
+		Error:   "UNAUTHENTICATED",
+		Message: message,
+	})
+}
@@ -0,0 +1,219 @@
+package apikey
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"orders-service/internal/adapters/http/handlers"
+	"orders-service/internal/application/auth"
+	"orders-service/internal/config"
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestEcho() *echo.Echo {
+	keys := []config.APIKeyConfig{
+		{Name: "reader", Hash: HashKey("read-key"), Scopes: []string{"orders:read"}},
+		{Name: "writer", Hash: HashKey("write-key"), Scopes: []string{"orders:read", "orders:write"}},
+		{Name: "admin", Hash: HashKey("admin-key"), Scopes: []string{"orders:admin"}},
+	}
+
+	ok := func(c echo.Context) error {
+		principal, _ := auth.PrincipalFromContext(c.Request().Context())
+		return c.String(http.StatusOK, principal.Name)
+	}
+
+	e := echo.New()
+	orders := e.Group("/orders", Authenticate(keys, logger.New("test")))
+	orders.GET("/:id", ok, RequireScope(auth.ScopeOrdersRead))
+	orders.POST("", ok, RequireScope(auth.ScopeOrdersWrite))
+	orders.DELETE("/:id", ok, RequireScope(auth.ScopeOrdersAdmin))
+	orders.PUT("/:id/status", ok, RequireScope(auth.ScopeOrdersAdmin))
+	return e
+}
+
+func doRequest(e *echo.Echo, method, path, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if key != "" {
+		req.Header.Set(HeaderAPIKey, key)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAuthenticate_MissingKey(t *testing.T) {
+	e := setupTestEcho()
+
+	rec := doRequest(e, http.MethodGet, "/orders/1", "")
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	var response handlers.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "UNAUTHENTICATED", response.Error)
+}
+
+func TestAuthenticate_UnknownKey(t *testing.T) {
+	e := setupTestEcho()
+
+	rec := doRequest(e, http.MethodGet, "/orders/1", "not-a-key")
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuthenticate_AttachesPrincipal(t *testing.T) {
+	e := setupTestEcho()
+
+	rec := doRequest(e, http.MethodGet, "/orders/1", "read-key")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "reader", rec.Body.String())
+}
+
+func TestAuthenticate_CustomerScopedKey(t *testing.T) {
+	keys := []config.APIKeyConfig{
+		{Name: "gateway", Hash: HashKey("gateway-key"), Scopes: []string{"orders:read"}, CustomerScoped: true},
+		{Name: "reader", Hash: HashKey("read-key"), Scopes: []string{"orders:read"}},
+	}
+
+	var seen []uint
+	e := echo.New()
+	e.GET("/orders/:id", func(c echo.Context) error {
+		principal, _ := auth.PrincipalFromContext(c.Request().Context())
+		seen = append(seen, principal.CustomerID)
+		return c.NoContent(http.StatusOK)
+	}, Authenticate(keys, logger.New("test")))
+
+	tests := []struct {
+		name         string
+		key          string
+		customerID   string
+		expectStatus int
+	}{
+		{"bound to header customer", "gateway-key", "42", http.StatusOK},
+		{"missing customer header", "gateway-key", "", http.StatusUnauthorized},
+		{"invalid customer header", "gateway-key", "abc", http.StatusUnauthorized},
+		{"zero customer header", "gateway-key", "0", http.StatusUnauthorized},
+		{"unscoped key ignores header", "read-key", "42", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+			req.Header.Set(HeaderAPIKey, tt.key)
+			if tt.customerID != "" {
+				req.Header.Set(HeaderCustomerID, tt.customerID)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+		})
+	}
+
+	// The shared principal of the scoped key is never modified
+	assert.Equal(t, []uint{42, 0}, seen)
+}
+
+func TestRequireScope_Boundaries(t *testing.T) {
+	tests := []struct {
+		name         string
+		key          string
+		method       string
+		path         string
+		expectStatus int
+		missingScope string
+	}{
+		{"read key can read", "read-key", http.MethodGet, "/orders/1", http.StatusOK, ""},
+		{"read key cannot write", "read-key", http.MethodPost, "/orders", http.StatusForbidden, "orders:write"},
+		{"read key cannot delete", "read-key", http.MethodDelete, "/orders/1", http.StatusForbidden, "orders:admin"},
+		{"write key can read", "write-key", http.MethodGet, "/orders/1", http.StatusOK, ""},
+		{"write key can write", "write-key", http.MethodPost, "/orders", http.StatusOK, ""},
+		{"write key cannot delete", "write-key", http.MethodDelete, "/orders/1", http.StatusForbidden, "orders:admin"},
+		{"write key cannot transition status", "write-key", http.MethodPut, "/orders/1/status", http.StatusForbidden, "orders:admin"},
+		{"admin key can read", "admin-key", http.MethodGet, "/orders/1", http.StatusOK, ""},
+		{"admin key can write", "admin-key", http.MethodPost, "/orders", http.StatusOK, ""},
+		{"admin key can delete", "admin-key", http.MethodDelete, "/orders/1", http.StatusOK, ""},
+		{"admin key can transition status", "admin-key", http.MethodPut, "/orders/1/status", http.StatusOK, ""},
+	}
+
+	e := setupTestEcho()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doRequest(e, tt.method, tt.path, tt.key)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+
+			if tt.expectStatus == http.StatusForbidden {
+				var response handlers.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, "FORBIDDEN", response.Error)
+				assert.Equal(t, tt.missingScope, response.Details["missing_scope"])
+			}
+		})
+	}
+}
+
+func TestHashKey(t *testing.T) {
+	assert.Equal(t,
+		"df76ff796f70d2c9cb055ea6280553caa27eda26b70e01082c160de75a05a4a9",
+		HashKey("dev-admin-key"))
+}
+
+func TestIdentify_LeavesRejectionToRequireAuthenticated(t *testing.T) {
+	keys := []config.APIKeyConfig{
+		{Name: "reader", Hash: HashKey("read-key"), Scopes: []string{"orders:read"}},
+		{Name: "gateway", Hash: HashKey("gateway-key"), Scopes: []string{"orders:read"}, CustomerScoped: true},
+	}
+
+	tests := []struct {
+		name          string
+		key           string
+		expectKeyID   string
+		expectMessage string
+	}{
+		{"valid key", "read-key", HashKey("read-key"), ""},
+		{"missing key", "", "", "A valid API key is required"},
+		{"unknown key", "not-a-key", "", "A valid API key is required"},
+		{"customer scoped key without customer", "gateway-key", "", "A valid X-Customer-ID header is required for this API key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			var identified string
+			e := echo.New()
+			e.GET("/orders/:id", func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			}, Identify(keys, logger.New("test")), func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					if principal, ok := auth.PrincipalFromContext(c.Request().Context()); ok {
+						identified = principal.KeyID
+					}
+					return next(c)
+				}
+			}, RequireAuthenticated())
+
+			// When
+			rec := doRequest(e, http.MethodGet, "/orders/1", tt.key)
+
+			// Then
+			assert.Equal(t, tt.expectKeyID, identified)
+			if tt.expectMessage == "" {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				return
+			}
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			var response handlers.ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, "UNAUTHENTICATED", response.Error)
+			assert.Equal(t, tt.expectMessage, response.Message)
+		})
+	}
+}
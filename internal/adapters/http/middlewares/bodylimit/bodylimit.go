@@ -0,0 +1,34 @@
+package bodylimit
+
+import (
+	"net/http"
+
+	"orders-service/internal/adapters/http/handlers"
+
+	"github.com/labstack/echo/v4"
+)
+
+// BodyLimit rejects request bodies larger than limit bytes with 413 REQUEST_TOO_LARGE.
+// A declared Content-Length over the limit is refused before the handler runs, other bodies
+// are cut off at the limit and fail to bind with an *http.MaxBytesError. A limit of 0 disables the check.
+func BodyLimit(limit int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if limit <= 0 || req.Body == nil || req.Body == http.NoBody {
+				return next(c)
+			}
+
+			if req.ContentLength > limit {
+				return handlers.WriteError(c, http.StatusRequestEntityTooLarge, handlers.ErrorResponse{
+					Error:   "REQUEST_TOO_LARGE",
+					Message: "Request body is too large",
+					Details: map[string]interface{}{"limit_bytes": limit},
+				})
+			}
+
+			req.Body = http.MaxBytesReader(c.Response(), req.Body, limit)
+			return next(c)
+		}
+	}
+}
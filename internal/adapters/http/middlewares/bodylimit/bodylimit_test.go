@@ -0,0 +1,92 @@
+package bodylimit
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"orders-service/internal/adapters/http/handlers"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readingHandler reads the whole body and reports whether it hit the limit
+func readingHandler(c echo.Context) error {
+	if _, err := io.ReadAll(c.Request().Body); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return c.NoContent(http.StatusRequestEntityTooLarge)
+		}
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+func serve(limit int64, req *http.Request) *httptest.ResponseRecorder {
+	e := echo.New()
+	e.Use(BodyLimit(limit))
+	e.POST("/orders", readingHandler)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestBodyLimit_DeclaredLengthOverLimit(t *testing.T) {
+	// Given
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(strings.Repeat("a", 11)))
+
+	// When
+	rec := serve(10, req)
+
+	// Then
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	var response handlers.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "REQUEST_TOO_LARGE", response.Error)
+	assert.Equal(t, float64(10), response.Details["limit_bytes"])
+}
+
+func TestBodyLimit_UndeclaredLengthOverLimit(t *testing.T) {
+	// Given a chunked body whose size is only known once it is read
+	req := httptest.NewRequest(http.MethodPost, "/orders", io.NopCloser(strings.NewReader(strings.Repeat("a", 11))))
+	req.ContentLength = -1
+
+	// When
+	rec := serve(10, req)
+
+	// Then
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestBodyLimit_WithinLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int64
+		body  string
+	}{
+		{"at the limit", 10, strings.Repeat("a", 10)},
+		{"empty body", 10, ""},
+		{"disabled", 0, strings.Repeat("a", 100)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(tt.body))
+
+			// When
+			rec := serve(tt.limit, req)
+
+			// Then
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package compress
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"mime"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"orders-service/internal/config"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Gzip compresses responses for clients accepting gzip. Only responses whose content type is listed
+// in cfg.ContentTypes and whose body reaches cfg.MinSize bytes are compressed, smaller bodies are not
+// worth the overhead. A handler flushing the response, such as a streamed export, has its output
+// compressed from the first flush on regardless of the size so the stream is not held back.
+func Gzip(cfg config.CompressionConfig) echo.MiddlewareFunc {
+	pool := &sync.Pool{New: func() any {
+		gz, _ := gzip.NewWriterLevel(nil, cfg.Level)
+		return gz
+	}}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			res := c.Response()
+			res.Header().Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+			if c.Request().Method == http.MethodHead || !acceptsGzip(c.Request().Header.Get(echo.HeaderAcceptEncoding)) {
+				return next(c)
+			}
+
+			w := &gzipWriter{ResponseWriter: res.Writer, cfg: cfg, pool: pool}
+			res.Writer = w
+			defer func() {
+				w.close()
+				res.Writer = w.ResponseWriter
+			}()
+			return next(c)
+		}
+	}
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") && strings.TrimSpace(coding) != "*" {
+			continue
+		}
+		if q, ok := strings.CutPrefix(strings.ReplaceAll(params, " ", ""), "q="); ok {
+			if weight, err := strconv.ParseFloat(q, 64); err == nil && weight == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// gzipWriter buffers the start of a response until it knows whether to compress it
+type gzipWriter struct {
+	http.ResponseWriter
+	cfg  config.CompressionConfig
+	pool *sync.Pool
+
+	status      int
+	decided     bool
+	compressing bool
+	buf         bytes.Buffer
+	gz          *gzip.Writer
+}
+
+func (w *gzipWriter) WriteHeader(status int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	w.status = status
+}
+
+func (w *gzipWriter) Write(p []byte) (int, error) {
+	if w.decided {
+		if w.compressing {
+			return w.gz.Write(p)
+		}
+		return w.ResponseWriter.Write(p)
+	}
+
+	if !w.compressible() {
+		if err := w.decide(false); err != nil {
+			return 0, err
+		}
+		return w.ResponseWriter.Write(p)
+	}
+
+	w.buf.Write(p)
+	if w.buf.Len() >= w.cfg.MinSize {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush sends the buffered output, starting compression if the response qualifies
+func (w *gzipWriter) Flush() {
+	if !w.decided {
+		if err := w.decide(w.compressible()); err != nil {
+			return
+		}
+	}
+	if w.compressing {
+		_ = w.gz.Flush()
+	}
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack lets protocols such as websockets take over the connection
+func (w *gzipWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return hijacker.Hijack()
+	}
+	return nil, nil, errors.New("response writer does not support hijacking")
+}
+
+// Unwrap exposes the wrapped writer to http.ResponseController
+func (w *gzipWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// compressible reports whether the headers set so far allow compressing the response
+func (w *gzipWriter) compressible() bool {
+	header := w.Header()
+	if header.Get(echo.HeaderContentEncoding) != "" {
+		return false
+	}
+	if w.status == http.StatusNoContent || w.status == http.StatusNotModified || (w.status >= 100 && w.status < 200) {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(header.Get(echo.HeaderContentType))
+	return err == nil && slices.Contains(w.cfg.ContentTypes, mediaType)
+}
+
+// decide writes the headers and the buffered body, compressed or not
+func (w *gzipWriter) decide(compress bool) error {
+	w.decided = true
+	w.compressing = compress
+
+	if compress {
+		header := w.Header()
+		header.Set(echo.HeaderContentEncoding, "gzip")
+		header.Del(echo.HeaderContentLength)
+		w.gz = w.pool.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+	}
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	if w.buf.Len() == 0 {
+		return nil
+	}
+
+	var err error
+	if compress {
+		_, err = w.gz.Write(w.buf.Bytes())
+	} else {
+		_, err = w.ResponseWriter.Write(w.buf.Bytes())
+	}
+	w.buf.Reset()
+	return err
+}
+
+// close finishes the response once the handler returned, a body below the size threshold is sent as is
+func (w *gzipWriter) close() {
+	if !w.decided {
+		if w.status == 0 && w.buf.Len() == 0 {
+			// Nothing was written, leave the response to the error handler
+			return
+		}
+		_ = w.decide(false)
+	}
+	if w.compressing {
+		_ = w.gz.Close()
+		w.gz.Reset(nil)
+		w.pool.Put(w.gz)
+	}
+}
@@ -0,0 +1,127 @@
+package compress
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"orders-service/internal/config"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = config.CompressionConfig{
+	Enabled:      true,
+	MinSize:      100,
+	Level:        gzip.DefaultCompression,
+	ContentTypes: []string{"application/json", "text/csv"},
+}
+
+func serve(handler echo.HandlerFunc, acceptEncoding string) *httptest.ResponseRecorder {
+	e := echo.New()
+	e.Use(Gzip(testConfig))
+	e.GET("/", handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if acceptEncoding != "" {
+		req.Header.Set(echo.HeaderAcceptEncoding, acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func gunzip(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	reader, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func jsonBody(size int) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSONBlob(http.StatusOK, []byte(`{"data":"`+strings.Repeat("a", size)+`"}`))
+	}
+}
+
+func TestGzip_CompressesLargeAllowedBodies(t *testing.T) {
+	rec := serve(jsonBody(500), "br, gzip")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Equal(t, echo.HeaderAcceptEncoding, rec.Header().Get(echo.HeaderVary))
+	assert.Less(t, rec.Body.Len(), 500)
+	assert.Equal(t, `{"data":"`+strings.Repeat("a", 500)+`"}`, gunzip(t, rec))
+}
+
+func TestGzip_LeavesBodiesAsIs(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        echo.HandlerFunc
+		acceptEncoding string
+	}{
+		{"below threshold", jsonBody(10), "gzip"},
+		{"client without gzip", jsonBody(500), ""},
+		{"gzip refused", jsonBody(500), "gzip;q=0, identity"},
+		{"content type not allowed", func(c echo.Context) error {
+			return c.Blob(http.StatusOK, "image/png", make([]byte, 500))
+		}, "gzip"},
+		{"no content", func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }, "gzip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(tt.handler, tt.acceptEncoding)
+
+			assert.Empty(t, rec.Header().Get(echo.HeaderContentEncoding))
+			_, err := gzip.NewReader(rec.Body)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGzip_KeepsStatusOfSmallBodies(t *testing.T) {
+	rec := serve(func(c echo.Context) error {
+		return c.JSON(http.StatusCreated, map[string]int{"id": 1})
+	}, "gzip")
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.JSONEq(t, `{"id":1}`, rec.Body.String())
+}
+
+func TestGzip_FlushedStreamsAreCompressedRightAway(t *testing.T) {
+	rec := serve(func(c echo.Context) error {
+		res := c.Response()
+		res.Header().Set(echo.HeaderContentType, "text/csv")
+		res.WriteHeader(http.StatusOK)
+		_, _ = res.Write([]byte("id\n"))
+		res.Flush()
+		assert.True(t, recorder(c).Flushed)
+		_, _ = res.Write([]byte("1\n"))
+		return nil
+	}, "gzip")
+
+	assert.Equal(t, "gzip", rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Equal(t, "id\n1\n", gunzip(t, rec))
+}
+
+// recorder returns the recorder under the compressing writer
+func recorder(c echo.Context) *httptest.ResponseRecorder {
+	return c.Response().Writer.(*gzipWriter).ResponseWriter.(*httptest.ResponseRecorder)
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	assert.True(t, acceptsGzip("gzip"))
+	assert.True(t, acceptsGzip("deflate, GZIP;q=0.5"))
+	assert.True(t, acceptsGzip("*"))
+	assert.False(t, acceptsGzip(""))
+	assert.False(t, acceptsGzip("br, deflate"))
+	assert.False(t, acceptsGzip("gzip;q=0"))
+}
@@ -0,0 +1,91 @@
+package cors
+
+import (
+	"strings"
+
+	"orders-service/internal/config"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// CORS answers preflight requests and adds the CORS headers to the responses of allowed origins.
+// It must run as a global middleware so preflight requests, which match no route, reach it before
+// authentication. Requests skip reports true for, such as health checks, get no CORS headers.
+// A preflight from an origin that is not allowed is answered without CORS headers, so the browser blocks it.
+func CORS(cfg config.CORSConfig, skip func(c echo.Context) bool) echo.MiddlewareFunc {
+	corsConfig := middleware.CORSConfig{
+		Skipper:          skip,
+		AllowMethods:     cfg.AllowMethods,
+		AllowHeaders:     cfg.AllowHeaders,
+		ExposeHeaders:    cfg.ExposeHeaders,
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           int(cfg.MaxAge.Seconds()),
+	}
+
+	if len(cfg.AllowOrigins) == 1 && cfg.AllowOrigins[0] == "*" && !cfg.AllowCredentials {
+		corsConfig.AllowOrigins = []string{"*"}
+	} else {
+		matcher := newOriginMatcher(cfg.AllowOrigins)
+		corsConfig.AllowOriginFunc = func(origin string) (bool, error) {
+			return matcher.matches(origin), nil
+		}
+	}
+	return middleware.CORSWithConfig(corsConfig)
+}
+
+// originMatcher decides whether an origin is allowed by the configured origins
+type originMatcher struct {
+	any   bool
+	exact map[string]bool
+	// wildcards holds the scheme and the parent domain of each *. origin, such as https:// and .example.com
+	wildcards []wildcardOrigin
+}
+
+type wildcardOrigin struct {
+	scheme string
+	suffix string
+}
+
+func newOriginMatcher(origins []string) *originMatcher {
+	m := &originMatcher{exact: make(map[string]bool, len(origins))}
+	for _, origin := range origins {
+		origin = strings.ToLower(strings.TrimSuffix(origin, "/"))
+		if origin == "*" {
+			m.any = true
+			continue
+		}
+		if scheme, host, ok := strings.Cut(origin, "://*."); ok {
+			m.wildcards = append(m.wildcards, wildcardOrigin{scheme: scheme + "://", suffix: "." + host})
+			continue
+		}
+		m.exact[origin] = true
+	}
+	return m
+}
+
+// matches reports whether origin is allowed, a wildcard only matches subdomains and not the parent domain itself
+func (m *originMatcher) matches(origin string) bool {
+	if origin == "" {
+		return false
+	}
+	if m.any {
+		return true
+	}
+
+	origin = strings.ToLower(origin)
+	if m.exact[origin] {
+		return true
+	}
+	for _, wildcard := range m.wildcards {
+		host, ok := strings.CutPrefix(origin, wildcard.scheme)
+		if !ok {
+			continue
+		}
+		subdomain, ok := strings.CutSuffix(host, wildcard.suffix)
+		if ok && subdomain != "" && !strings.ContainsAny(subdomain, "/:@") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"orders-service/internal/config"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOriginMatcher(t *testing.T) {
+	matcher := newOriginMatcher([]string{"https://app.example.com", "https://*.example.org", "http://localhost:3000/"})
+
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{"https://app.example.com", true},
+		{"https://APP.example.com", true},
+		{"http://app.example.com", false},
+		{"https://evil.com", false},
+		{"https://dashboard.example.org", true},
+		{"https://a.b.example.org", true},
+		{"https://example.org", false},
+		{"http://dashboard.example.org", false},
+		{"https://dashboard.example.org.evil.com", false},
+		{"https://evilexample.org", false},
+		{"https://dashboard.example.org:8443", false},
+		{"http://localhost:3000", true},
+		{"", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.allowed, matcher.matches(tt.origin), tt.origin)
+	}
+}
+
+// serve sends req through the CORS middleware in front of an authenticated orders route
+func serve(cfg config.CORSConfig, req *http.Request) *httptest.ResponseRecorder {
+	e := echo.New()
+	e.Use(CORS(cfg, func(c echo.Context) bool {
+		return !strings.HasPrefix(c.Request().URL.Path, "/api/v1/orders")
+	}))
+	requireKey := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Request().Header.Get("X-API-Key") == "" {
+				return c.NoContent(http.StatusUnauthorized)
+			}
+			return next(c)
+		}
+	}
+	e.POST("/api/v1/orders", func(c echo.Context) error { return c.NoContent(http.StatusCreated) }, requireKey)
+	e.GET("/api/v1/health", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func preflight(path, origin string) *http.Request {
+	req := httptest.NewRequest(http.MethodOptions, path, nil)
+	req.Header.Set(echo.HeaderOrigin, origin)
+	req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodPost)
+	req.Header.Set(echo.HeaderAccessControlRequestHeaders, "Content-Type, X-API-Key")
+	return req
+}
+
+var dashboardCORS = config.CORSConfig{
+	AllowOrigins:     []string{"https://*.example.com"},
+	AllowMethods:     []string{http.MethodGet, http.MethodPost},
+	AllowHeaders:     []string{"Content-Type", "X-API-Key"},
+	MaxAge:           10 * time.Minute,
+	AllowCredentials: true,
+}
+
+func TestCORS_PreflightFromAllowedOrigin(t *testing.T) {
+	rec := serve(dashboardCORS, preflight("/api/v1/orders", "https://dashboard.example.com"))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://dashboard.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Equal(t, "GET,POST", rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+	assert.Equal(t, "Content-Type,X-API-Key", rec.Header().Get(echo.HeaderAccessControlAllowHeaders))
+	assert.Equal(t, "600", rec.Header().Get(echo.HeaderAccessControlMaxAge))
+	assert.Equal(t, "true", rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+}
+
+func TestCORS_PreflightFromDisallowedOrigin(t *testing.T) {
+	rec := serve(dashboardCORS, preflight("/api/v1/orders", "https://evil.com"))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	for _, header := range []string{
+		echo.HeaderAccessControlAllowOrigin,
+		echo.HeaderAccessControlAllowMethods,
+		echo.HeaderAccessControlAllowHeaders,
+		echo.HeaderAccessControlAllowCredentials,
+		echo.HeaderAccessControlMaxAge,
+	} {
+		assert.Empty(t, rec.Header().Get(header), header)
+	}
+}
+
+func TestCORS_ActualRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil)
+	req.Header.Set(echo.HeaderOrigin, "https://dashboard.example.com")
+	req.Header.Set("X-API-Key", "key")
+
+	rec := serve(dashboardCORS, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "https://dashboard.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+}
+
+func TestCORS_SkippedRoutesSendNoHeaders(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/health", nil)
+	req.Header.Set(echo.HeaderOrigin, "https://dashboard.example.com")
+
+	rec := serve(dashboardCORS, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+}
+
+func TestCORS_AnyOrigin(t *testing.T) {
+	rec := serve(config.CORSConfig{AllowOrigins: []string{"*"}}, preflight("/api/v1/orders", "https://anything.test"))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "*", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+}
@@ -0,0 +1,24 @@
+package logging
+
+import (
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequestContext copies the X-Request-ID assigned to the response into the request context together
+// with log tagged with it, so loggers below the HTTP layer, such as those of the use cases and the
+// GORM logger, tag their entries with it. See logger.FromContext.
+func RequestContext(log logger.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if requestID := c.Response().Header().Get(echo.HeaderXRequestID); requestID != "" {
+				req := c.Request()
+				ctx := logger.WithRequestID(req.Context(), requestID)
+				ctx = logger.WithContext(ctx, log.With("request_id", requestID))
+				c.SetRequest(req.WithContext(ctx))
+			}
+			return next(c)
+		}
+	}
+}
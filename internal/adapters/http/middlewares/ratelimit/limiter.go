@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter decides whether a request identified by key may proceed.
+// The in-memory implementation below is process local; a Redis backed
+// implementation can satisfy the same interface to share limits across replicas.
+type Limiter interface {
+	// Allow consumes one token for key. When no token is available it returns
+	// false together with the time until the next token becomes available.
+	Allow(ctx context.Context, key string) (bool, time.Duration, error)
+}
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// MemoryLimiter is an in-memory token bucket limiter keyed by client
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	limits    func() (rps, burst int)
+	buckets   map[string]*bucket
+	now       func() time.Time
+	idleTTL   time.Duration
+	lastSweep time.Time
+}
+
+// NewMemoryLimiter creates a token bucket limiter refilling rps tokens per second
+// up to burst tokens. A burst lower than rps is raised to rps.
+func NewMemoryLimiter(rps, burst int) *MemoryLimiter {
+	return NewDynamicMemoryLimiter(func() (int, int) { return rps, burst })
+}
+
+// NewDynamicMemoryLimiter creates a token bucket limiter reading its rate and burst from limits on
+// every request, so changed limits apply to the next request of every client
+func NewDynamicMemoryLimiter(limits func() (rps, burst int)) *MemoryLimiter {
+	return &MemoryLimiter{
+		limits:  limits,
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+		idleTTL: 10 * time.Minute,
+	}
+}
+
+// Allow implements Limiter
+func (l *MemoryLimiter) Allow(_ context.Context, key string) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	rps, maxBurst := l.limits()
+	rate, burst := float64(rps), float64(max(rps, maxBurst))
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, lastSeen: now}
+		l.buckets[key] = b
+	}
+
+	// Refill tokens for the time elapsed since the last request, a lowered burst applies right away
+	elapsed := now.Sub(b.lastSeen).Seconds()
+	b.tokens = math.Min(burst, b.tokens+max(elapsed, 0)*rate)
+	b.lastSeen = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0, nil
+	}
+
+	if rate <= 0 {
+		return false, time.Second, nil
+	}
+
+	wait := time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	return false, wait, nil
+}
+
+// sweep drops buckets that have been idle long enough to be full again
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.idleTTL {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) >= l.idleTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
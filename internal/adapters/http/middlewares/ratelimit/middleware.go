@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"orders-service/internal/adapters/http/handlers"
+	"orders-service/internal/application/auth"
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RateLimit limits requests per client, using the reads limiter for safe methods
+// (GET, HEAD, OPTIONS) and the writes limiter for everything else. It runs after
+// apikey.Identify so clients are told apart by their authenticated key.
+func RateLimit(reads, writes Limiter, logger logger.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			limiter := writes
+			class := "writes"
+			switch c.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				limiter = reads
+				class = "reads"
+			}
+
+			key := ClientKey(c)
+			allowed, retryAfter, err := limiter.Allow(c.Request().Context(), class+":"+key)
+			if err != nil {
+				// Fail open so a limiter outage does not take the API down with it
+				logger.Warn("Rate limiter unavailable, allowing request",
+					"request_id", handlers.RequestID(c),
+					"error", err)
+				return next(c)
+			}
+
+			if !allowed {
+				seconds := int(math.Ceil(retryAfter.Seconds()))
+				if seconds < 1 {
+					seconds = 1
+				}
+
+				logger.Warn("Rate limit exceeded",
+					"request_id", handlers.RequestID(c),
+					"client", key,
+					"class", class,
+					"retry_after", seconds)
+
+				c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(seconds))
+				return handlers.WriteError(c, http.StatusTooManyRequests, handlers.ErrorResponse{
+					Error:   "RATE_LIMITED",
+					Message: "Too many requests, please retry later",
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// ClientKey identifies the client by the API key it authenticated with, falling back to the
+// client IP. The raw X-API-Key header is not trusted, unknown keys would each get a fresh bucket.
+func ClientKey(c echo.Context) string {
+	if principal, ok := auth.PrincipalFromContext(c.Request().Context()); ok && principal.KeyID != "" {
+		return "key:" + principal.KeyID
+	}
+	return "ip:" + c.RealIP()
+}
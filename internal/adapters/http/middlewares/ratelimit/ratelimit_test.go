@@ -0,0 +1,224 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"orders-service/internal/adapters/http/handlers"
+	"orders-service/internal/adapters/http/middlewares/apikey"
+	"orders-service/internal/application/auth"
+	"orders-service/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time { return f.now }
+
+func (f *fakeClock) Advance(d time.Duration) { f.now = f.now.Add(d) }
+
+func newTestLimiter(rps, burst int) (*MemoryLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := NewMemoryLimiter(rps, burst)
+	limiter.now = clock.Now
+	return limiter, clock
+}
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(context.Context, string) (bool, time.Duration, error) {
+	return false, 0, errors.New("redis unavailable")
+}
+
+func TestMemoryLimiter_BurstExhaustion(t *testing.T) {
+	limiter, _ := newTestLimiter(1, 3)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		allowed, _, err := limiter.Allow(ctx, "client")
+		require.NoError(t, err)
+		assert.True(t, allowed, "request %d should be allowed within burst", i)
+	}
+
+	allowed, retryAfter, err := limiter.Allow(ctx, "client")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, time.Second, retryAfter)
+}
+
+func TestMemoryLimiter_Recovery(t *testing.T) {
+	limiter, clock := newTestLimiter(2, 2)
+	ctx := context.Background()
+
+	limiter.Allow(ctx, "client")
+	limiter.Allow(ctx, "client")
+
+	allowed, retryAfter, _ := limiter.Allow(ctx, "client")
+	assert.False(t, allowed)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	// Half a second refills one token at 2 rps
+	clock.Advance(500 * time.Millisecond)
+	allowed, _, _ = limiter.Allow(ctx, "client")
+	assert.True(t, allowed)
+
+	allowed, _, _ = limiter.Allow(ctx, "client")
+	assert.False(t, allowed)
+
+	// A long pause never refills beyond the burst
+	clock.Advance(time.Minute)
+	for i := 0; i < 2; i++ {
+		allowed, _, _ = limiter.Allow(ctx, "client")
+		assert.True(t, allowed)
+	}
+	allowed, _, _ = limiter.Allow(ctx, "client")
+	assert.False(t, allowed)
+}
+
+func TestDynamicMemoryLimiter_AppliesChangedLimits(t *testing.T) {
+	rps, burst := 1, 1
+	limiter := NewDynamicMemoryLimiter(func() (int, int) { return rps, burst })
+	limiter.now = (&fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}).Now
+	ctx := context.Background()
+
+	allowed, _, err := limiter.Allow(ctx, "client")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	allowed, _, _ = limiter.Allow(ctx, "client")
+	assert.False(t, allowed, "burst of 1 is exhausted")
+
+	// A raised limit refills existing buckets at the new rate, a lowered one caps them right away
+	rps, burst = 10, 10
+	allowed, retryAfter, _ := limiter.Allow(ctx, "client")
+	assert.False(t, allowed)
+	assert.Equal(t, 100*time.Millisecond, retryAfter)
+
+	allowed, _, _ = limiter.Allow(ctx, "other")
+	assert.True(t, allowed)
+	rps, burst = 1, 1
+	allowed, _, _ = limiter.Allow(ctx, "other")
+	assert.True(t, allowed)
+	allowed, _, _ = limiter.Allow(ctx, "other")
+	assert.False(t, allowed, "tokens above the lowered burst are dropped")
+}
+
+func TestMemoryLimiter_KeysAreIndependent(t *testing.T) {
+	limiter, _ := newTestLimiter(1, 1)
+	ctx := context.Background()
+
+	allowed, _, _ := limiter.Allow(ctx, "a")
+	assert.True(t, allowed)
+	allowed, _, _ = limiter.Allow(ctx, "a")
+	assert.False(t, allowed)
+
+	allowed, _, _ = limiter.Allow(ctx, "b")
+	assert.True(t, allowed)
+}
+
+func TestMemoryLimiter_SweepsIdleBuckets(t *testing.T) {
+	limiter, clock := newTestLimiter(1, 1)
+	ctx := context.Background()
+
+	limiter.Allow(ctx, "a")
+	clock.Advance(limiter.idleTTL)
+	limiter.Allow(ctx, "b")
+
+	_, exists := limiter.buckets["a"]
+	assert.False(t, exists)
+	assert.Len(t, limiter.buckets, 1)
+}
+
+// performRequest sends a request authenticated with keyID, or an anonymous one when keyID is empty
+func performRequest(mw echo.MiddlewareFunc, method, keyID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/v1/orders", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	if keyID != "" {
+		req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{Name: keyID, KeyID: keyID}))
+	}
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	handler := mw(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	_ = handler(c)
+	return rec
+}
+
+func TestRateLimit_ReturnsTooManyRequests(t *testing.T) {
+	reads, _ := newTestLimiter(10, 10)
+	writes, _ := newTestLimiter(1, 1)
+	mw := RateLimit(reads, writes, logger.New("test"))
+
+	rec := performRequest(mw, http.MethodPost, "partner")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = performRequest(mw, http.MethodPost, "partner")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get(echo.HeaderRetryAfter))
+
+	var response handlers.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "RATE_LIMITED", response.Error)
+
+	// Reads use their own budget
+	rec = performRequest(mw, http.MethodGet, "partner")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Other clients are unaffected
+	rec = performRequest(mw, http.MethodPost, "other")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRateLimit_FallsBackToClientIP(t *testing.T) {
+	reads, _ := newTestLimiter(1, 1)
+	writes, _ := newTestLimiter(1, 1)
+	mw := RateLimit(reads, writes, logger.New("test"))
+
+	rec := performRequest(mw, http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = performRequest(mw, http.MethodGet, "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
+
+func TestRateLimit_UnauthenticatedKeysShareTheClientIPBucket(t *testing.T) {
+	// Given
+	reads, _ := newTestLimiter(1, 1)
+	writes, _ := newTestLimiter(1, 1)
+	mw := RateLimit(reads, writes, logger.New("test"))
+
+	send := func(apiKey string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set(apikey.HeaderAPIKey, apiKey)
+		rec := httptest.NewRecorder()
+		_ = mw(func(c echo.Context) error { return c.NoContent(http.StatusOK) })(echo.New().NewContext(req, rec))
+		return rec.Code
+	}
+
+	// When
+	first := send("guess-1")
+	second := send("guess-2")
+
+	// Then
+	assert.Equal(t, http.StatusOK, first)
+	assert.Equal(t, http.StatusTooManyRequests, second, "a new unverified key must not get a fresh bucket")
+}
+
+func TestRateLimit_FailsOpenOnLimiterError(t *testing.T) {
+	mw := RateLimit(failingLimiter{}, failingLimiter{}, logger.New("test"))
+
+	rec := performRequest(mw, http.MethodPost, "partner")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
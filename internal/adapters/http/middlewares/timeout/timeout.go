@@ -0,0 +1,59 @@
+package timeout
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"orders-service/internal/adapters/http/handlers"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// Timeout bounds the request context by timeout so database calls made for the request are cancelled
+// once it runs out. Handlers answer timed out requests themselves, an unhandled context.DeadlineExceeded
+// is turned into 504 GATEWAY_TIMEOUT. Requests matching skipper keep the context of the connection.
+func Timeout(timeout time.Duration, skipper middleware.Skipper) echo.MiddlewareFunc {
+	if skipper == nil {
+		skipper = middleware.DefaultSkipper
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if timeout <= 0 || skipper(c) {
+				return next(c)
+			}
+
+			ctx, cancel := context.WithTimeout(c.Request().Context(), timeout)
+			defer cancel()
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			err := next(c)
+			if err != nil && errors.Is(err, context.DeadlineExceeded) && !c.Response().Committed {
+				return handlers.WriteError(c, http.StatusGatewayTimeout, handlers.ErrorResponse{
+					Error:   "GATEWAY_TIMEOUT",
+					Message: "The request timed out",
+				})
+			}
+			return err
+		}
+	}
+}
+
+// NoDeadlines lifts the read and write deadlines the server set on the connection for the requests
+// matching match, so streaming routes are not cut off by the read and write timeouts of the server
+func NoDeadlines(match func(c echo.Context) bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if match(c) {
+				controller := http.NewResponseController(c.Response())
+				// Connections that cannot change their deadlines have none to lift
+				_ = controller.SetReadDeadline(time.Time{})
+				_ = controller.SetWriteDeadline(time.Time{})
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package timeout
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"orders-service/internal/adapters/http/handlers"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowHandler waits for the request context like a database call would
+func slowHandler(c echo.Context) error {
+	select {
+	case <-c.Request().Context().Done():
+		return c.Request().Context().Err()
+	case <-time.After(time.Second):
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func serve(e *echo.Echo) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	return rec
+}
+
+func TestTimeout_DeadlineExceeded(t *testing.T) {
+	// Given
+	e := echo.New()
+	e.Use(Timeout(20*time.Millisecond, nil))
+	e.GET("/slow", slowHandler)
+
+	// When
+	start := time.Now()
+	rec := serve(e)
+
+	// Then
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+
+	var response handlers.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "GATEWAY_TIMEOUT", response.Error)
+}
+
+func TestTimeout_SetsDeadline(t *testing.T) {
+	// Given
+	e := echo.New()
+	e.Use(Timeout(time.Minute, nil))
+	var deadline time.Time
+	var ok bool
+	e.GET("/slow", func(c echo.Context) error {
+		deadline, ok = c.Request().Context().Deadline()
+		return c.NoContent(http.StatusOK)
+	})
+
+	// When
+	rec := serve(e)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+}
+
+func TestTimeout_Skipped(t *testing.T) {
+	// Given
+	e := echo.New()
+	e.Use(Timeout(time.Millisecond, func(echo.Context) bool { return true }))
+	var hasDeadline bool
+	e.GET("/slow", func(c echo.Context) error {
+		_, hasDeadline = c.Request().Context().Deadline()
+		return c.NoContent(http.StatusOK)
+	})
+
+	// When
+	rec := serve(e)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, hasDeadline)
+}
+
+func TestTimeout_CommittedResponseIsKept(t *testing.T) {
+	// Given
+	e := echo.New()
+	e.Use(Timeout(time.Minute, nil))
+	e.GET("/slow", func(c echo.Context) error {
+		c.Response().WriteHeader(http.StatusOK)
+		return context.DeadlineExceeded
+	})
+
+	// When
+	rec := serve(e)
+
+	// Then
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// postSlowBody posts a body to url whose second half follows after pause
+func postSlowBody(url string, pause time.Duration) (*http.Response, error) {
+	body, writer := io.Pipe()
+	go func() {
+		_, _ = writer.Write([]byte(`{"first":"half",`))
+		time.Sleep(pause)
+		_, _ = writer.Write([]byte(`"second":"half"}`))
+		_ = writer.Close()
+	}()
+	return http.Post(url, echo.MIMEApplicationJSON, body)
+}
+
+// readBody answers with the length of the request body, or 400 when it cannot be read
+func readBody(c echo.Context) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	return c.String(http.StatusOK, strconv.Itoa(len(body)))
+}
+
+func TestNoDeadlines(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		expectRead bool
+	}{
+		{"matching request outlives the read timeout", "/stream", true},
+		{"other request is cut off", "/upload", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			e := echo.New()
+			e.Use(NoDeadlines(func(c echo.Context) bool { return c.Path() == "/stream" }))
+			e.POST("/stream", readBody)
+			e.POST("/upload", readBody)
+
+			server := httptest.NewUnstartedServer(e)
+			server.Config.ReadTimeout = 100 * time.Millisecond
+			server.Start()
+			defer server.Close()
+
+			// When
+			resp, err := postSlowBody(server.URL+tt.path, 300*time.Millisecond)
+
+			// Then
+			if tt.expectRead {
+				require.NoError(t, err)
+				defer resp.Body.Close()
+				body, _ := io.ReadAll(resp.Body)
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, "32", string(body))
+				return
+			}
+			if err == nil {
+				defer resp.Body.Close()
+				assert.NotEqual(t, http.StatusOK, resp.StatusCode)
+			}
+		})
+	}
+}
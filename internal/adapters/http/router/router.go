@@ -0,0 +1,326 @@
+// Package router mounts the HTTP handlers of the service on an echo router. Every API version serves
+// the same routes, the options choose the prefix, the versions, the middleware and whether the admin
+// routes are mounted.
+package router
+
+import (
+	"slices"
+	"strings"
+
+	"orders-service/internal/adapters/http/handlers"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// Version is an API version mounted under the prefix
+type Version struct {
+	// Name is the path segment of the version, like v1
+	Name string
+	// Middleware runs for every route of the version before the middleware of the route
+	Middleware []echo.MiddlewareFunc
+	// NotFound answers the requests for paths no route of the version serves
+	NotFound echo.HandlerFunc
+}
+
+// DefaultVersions returns the versions mounted when no WithVersions option is given. APIv2 shares the
+// handlers of APIv1, which shape pagination, lists and errors by the version.
+func DefaultVersions() []Version {
+	return []Version{
+		{Name: "v1", NotFound: handlers.RouteNotFound},
+		{
+			Name:       "v2",
+			Middleware: []echo.MiddlewareFunc{handlers.UseAPIVersion(handlers.APIv2), handlers.ForceProblemDetails()},
+			NotFound:   handlers.RouteNotFound,
+		},
+	}
+}
+
+// Middleware are the chains the API routes run through, health checks, metrics and docs run through
+// none of them. An empty chain adds nothing.
+type Middleware struct {
+	// API runs first for every API route, rate limiting and authentication
+	API []echo.MiddlewareFunc
+	// Read, Write and Admin authorize the routes reading orders, changing them and administering them
+	Read  []echo.MiddlewareFunc
+	Write []echo.MiddlewareFunc
+	Admin []echo.MiddlewareFunc
+	// Orders runs for the routes under /orders after API, the body limit of the order requests
+	Orders []echo.MiddlewareFunc
+	// Import runs for the order import after Admin, the body limit of the imports
+	Import []echo.MiddlewareFunc
+}
+
+// Option customizes a call to RegisterRoutes
+type Option func(o *options)
+
+type options struct {
+	prefix     string
+	versions   []Version
+	middleware Middleware
+	admin      bool
+
+	health   *handlers.HealthHandler
+	events   *handlers.OrderEventsHandler
+	audit    *handlers.AuditHandler
+	webhooks *handlers.WebhookHandler
+	docs     *handlers.DocsHandler
+}
+
+// WithPrefix mounts the versions under prefix instead of /api
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = "/" + strings.Trim(prefix, "/")
+	}
+}
+
+// WithVersions mounts versions instead of DefaultVersions
+func WithVersions(versions ...Version) Option {
+	return func(o *options) {
+		o.versions = versions
+	}
+}
+
+// WithMiddleware runs the API routes through the chains of middleware
+func WithMiddleware(middleware Middleware) Option {
+	return func(o *options) {
+		o.middleware = middleware
+	}
+}
+
+// WithAdminRoutes sets whether the /admin routes are mounted, they are by default
+func WithAdminRoutes(enabled bool) Option {
+	return func(o *options) {
+		o.admin = enabled
+	}
+}
+
+// WithHealthHandler mounts the health and metrics endpoints under the first version
+func WithHealthHandler(h *handlers.HealthHandler) Option {
+	return func(o *options) {
+		o.health = h
+	}
+}
+
+// WithEventsHandler mounts the order change streams
+func WithEventsHandler(h *handlers.OrderEventsHandler) Option {
+	return func(o *options) {
+		o.events = h
+	}
+}
+
+// WithAuditHandler mounts the audit log of orders
+func WithAuditHandler(h *handlers.AuditHandler) Option {
+	return func(o *options) {
+		o.audit = h
+	}
+}
+
+// WithWebhookHandler mounts the dead letters of the webhooks with the admin routes
+func WithWebhookHandler(h *handlers.WebhookHandler) Option {
+	return func(o *options) {
+		o.webhooks = h
+	}
+}
+
+// WithDocsHandler mounts the OpenAPI document and its UI under the first version
+func WithDocsHandler(h *handlers.DocsHandler) Option {
+	return func(o *options) {
+		o.docs = h
+	}
+}
+
+// RegisterRoutes mounts the order routes of h on e in every version, and the routes of the handlers
+// given as options. Paths are routed with or without a trailing slash, and a path routed for other
+// methods is answered 405 with the allowed methods.
+func RegisterRoutes(e *echo.Echo, h *handlers.OrderHandler, opts ...Option) {
+	o := &options{prefix: "/api", versions: DefaultVersions(), admin: true}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	// Some gateways append a trailing slash
+	e.Pre(middleware.RemoveTrailingSlash())
+
+	// Paths outside the versions are answered in the shape of the other errors
+	e.RouteNotFound("/*", handlers.RouteNotFound)
+
+	for i, version := range o.versions {
+		g := e.Group(o.prefix+"/"+version.Name, version.Middleware...)
+		handleUnmatched(e, g, version.NotFound)
+
+		// Health checks, metrics and docs are public and served by the first version only
+		if i == 0 {
+			registerPublicRoutes(g, o)
+		}
+		registerAPIRoutes(e, g, h, o, version.NotFound)
+	}
+}
+
+// registerPublicRoutes mounts the health, metrics and docs endpoints that were configured
+func registerPublicRoutes(g *echo.Group, o *options) {
+	if o.health != nil {
+		// Health endpoints
+		g.GET("/health", o.health.Health)
+		g.GET("/health/ready", o.health.Ready)
+		g.GET("/health/live", o.health.Live)
+
+		// Metrics endpoint
+		g.GET("/metrics", o.health.Metrics)
+	}
+
+	if o.docs != nil {
+		// API documentation
+		g.GET("/openapi.json", o.docs.OpenAPI)
+		g.GET("/docs", o.docs.SwaggerUI)
+	}
+}
+
+// registerAPIRoutes mounts the order, query, admin and webhook routes on the group of a version
+func registerAPIRoutes(e *echo.Echo, g *echo.Group, h *handlers.OrderHandler, o *options, notFound echo.HandlerFunc) {
+	mw := o.middleware
+	canRead, canWrite, isAdmin := mw.Read, mw.Write, mw.Admin
+
+	// Order routes
+	orders := g.Group("/orders", chain(mw.API, mw.Orders)...)
+	{
+		// CRUD operations
+		orders.POST("", h.CreateOrder, canWrite...)                            // Create order
+		orders.POST("/async", h.CreateOrderAsync, canWrite...)                 // Create order in the background
+		orders.GET("/jobs/:job_id", h.GetOrderJob, canRead...)                 // Poll a background order creation
+		orders.GET("", h.ListOrders, canRead...)                               // List all orders
+		orders.HEAD("", h.ListOrders, canRead...)                              // Count all orders
+		orders.GET("/count", h.CountOrders, canRead...)                        // Count orders by status and customer
+		orders.GET("/export", h.ExportOrders, isAdmin...)                      // Export orders as CSV
+		orders.GET("/stats", h.GetOrderStats, canRead...)                      // Aggregate statistics
+		orders.GET("/backordered", h.ListBackorderedOrders, canRead...)        // List orders waiting for stock
+		orders.GET("/queue", h.GetOrderQueue, canRead...)                      // List orders by priority for fulfillment
+		orders.GET("/by-reference", h.GetOrderByExternalReference, canRead...) // Get order by external reference
+		if o.events != nil {
+			orders.GET("/events", o.events.StreamOrdersEvents, canRead...) // Stream order changes
+		}
+		orders.GET("/number/:order_number", h.GetOrderByNumber, canRead...) // Get order by order number
+		orders.GET("/:id", h.GetOrder, canRead...)                          // Get order by ID
+		orders.PATCH("/:id", h.UpdateOrder, canWrite...)                    // Change the priority of an order
+		orders.DELETE("/:id", h.DeleteOrder, isAdmin...)                    // Delete order
+
+		// Order items management
+		orders.POST("/:id/items", h.AddItemToOrder, canWrite...)                    // Add item to order
+		orders.PUT("/:id/items", h.ReplaceOrderItems, canWrite...)                  // Replace all order items
+		orders.DELETE("/:id/items/:product_id", h.RemoveItemFromOrder, canWrite...) // Remove item from order, or cancel ?quantity of a confirmed one
+		orders.PUT("/:id/items/:product_id", h.UpdateItemQuantity, canWrite...)     // Update item quantity
+		orders.POST("/:id/reprice", h.RepriceOrder, canWrite...)                    // Refresh item prices from the catalog
+		orders.POST("/:id/amend", h.AmendOrder, canWrite...)                        // Change the items of a confirmed order
+
+		// Backordered items
+		orders.POST("/:id/items/:product_id/fulfill", h.FulfillOrderItem, isAdmin...) // Mark a backordered item in stock
+
+		// Order actions
+		orders.POST("/:id/confirm", h.ConfirmOrder, canWrite...)   // Confirm order
+		orders.POST("/:id/cancel", h.CancelOrder, canWrite...)     // Cancel order
+		orders.PUT("/:id/status", h.UpdateOrderStatus, isAdmin...) // Update order status
+		orders.POST("/:id/hold", h.HoldOrder, isAdmin...)          // Place order on hold
+		orders.POST("/:id/release", h.ReleaseOrder, isAdmin...)    // Release order hold
+
+		// Shipments
+		orders.POST("/:id/shipments", h.CreateShipment, isAdmin...)                       // Ship part or all of an order
+		orders.GET("/:id/shipments", h.ListShipments, canRead...)                         // List order shipments
+		orders.POST("/:id/shipments/:shipment_id/deliver", h.DeliverShipment, isAdmin...) // Mark a shipment delivered
+
+		// Refunds
+		orders.POST("/:id/refunds", h.CreateRefund, isAdmin...) // Pay part or all of an order back
+		orders.GET("/:id/refunds", h.ListRefunds, canRead...)   // List order refunds
+
+		// Snapshots taken at confirmation and amendments
+		orders.GET("/:id/snapshots", h.ListOrderSnapshots, canRead...)        // List order snapshots
+		orders.GET("/:id/snapshots/:version", h.GetOrderSnapshot, canRead...) // Get an order snapshot
+
+		// Printable documents
+		orders.GET("/:id/document", h.GetOrderDocument, canRead...) // Render an order as HTML or PDF
+
+		// Scheduled status transitions
+		orders.POST("/:id/scheduled-transitions", h.ScheduleTransition, isAdmin...)                              // Schedule a status change
+		orders.GET("/:id/scheduled-transitions", h.ListScheduledTransitions, isAdmin...)                         // List scheduled status changes
+		orders.POST("/:id/scheduled-transitions/:transition_id/cancel", h.CancelScheduledTransition, isAdmin...) // Cancel a scheduled status change
+
+		// Order change stream
+		if o.events != nil {
+			orders.GET("/:id/events", o.events.StreamOrderEvents, canRead...) // Stream changes of an order
+		}
+
+		// Audit log
+		if o.audit != nil {
+			orders.GET("/:id/audit", o.audit.GetOrderAuditLog, isAdmin...) // Get order audit log
+		}
+	}
+	handleUnmatched(e, orders, notFound)
+
+	// Query routes
+	read := chain(mw.API, canRead)
+	g.GET("/customers/:customer_id/orders", h.GetCustomerOrders, read...)               // Get orders by customer
+	g.HEAD("/customers/:customer_id/orders", h.GetCustomerOrders, read...)              // Count orders by customer
+	g.GET("/customers/:customer_id/orders/summary", h.GetCustomerOrderSummary, read...) // Get customer order summary
+	g.GET("/orders/status/:status", h.GetOrdersByStatus, read...)                       // Get orders by status
+	g.HEAD("/orders/status/:status", h.GetOrdersByStatus, read...)                      // Count orders by status
+
+	// Admin routes
+	if o.admin {
+		admin := g.Group("/admin", chain(mw.API, isAdmin)...)
+		{
+			admin.GET("/orders/deleted", h.ListDeletedOrders)          // List soft deleted orders
+			admin.POST("/orders/:id/restore", h.RestoreOrder)          // Restore a soft deleted order
+			admin.POST("/orders/import", h.ImportOrders, mw.Import...) // Import orders from NDJSON
+		}
+		handleUnmatched(e, admin, notFound)
+
+		// Webhook dead letters
+		if o.webhooks != nil {
+			webhooks := g.Group("/webhooks", chain(mw.API, isAdmin)...)
+			{
+				webhooks.GET("/:id/dead-letters", o.webhooks.ListDeadLetters)                          // List failed deliveries of a webhook
+				webhooks.POST("/:id/dead-letters/replay", o.webhooks.ReplayDeadLetters)                // Replay pending failed deliveries
+				webhooks.POST("/:id/dead-letters/:dead_letter_id/replay", o.webhooks.ReplayDeadLetter) // Replay a failed delivery
+			}
+			handleUnmatched(e, webhooks, notFound)
+		}
+	}
+}
+
+// chain joins chains of middleware in order
+func chain(chains ...[]echo.MiddlewareFunc) []echo.MiddlewareFunc {
+	return slices.Concat(chains...)
+}
+
+// Route is a method and path mounted by RegisterRoutes
+type Route struct {
+	Method string
+	Path   string
+}
+
+// Routes returns the routes RegisterRoutes mounts with opts, sorted by path and method. The routes of
+// every handler are included, whether or not opts give it, and the catch-all routes are left out.
+func Routes(opts ...Option) []Route {
+	e := echo.New()
+	allHandlers := []Option{
+		WithHealthHandler(&handlers.HealthHandler{}),
+		WithEventsHandler(&handlers.OrderEventsHandler{}),
+		WithAuditHandler(&handlers.AuditHandler{}),
+		WithWebhookHandler(&handlers.WebhookHandler{}),
+		WithDocsHandler(&handlers.DocsHandler{}),
+	}
+	RegisterRoutes(e, &handlers.OrderHandler{}, append(allHandlers, opts...)...)
+
+	routes := make([]Route, 0, len(e.Routes()))
+	for _, route := range e.Routes() {
+		if route.Method != echo.RouteNotFound {
+			routes = append(routes, Route{Method: route.Method, Path: route.Path})
+		}
+	}
+	slices.SortFunc(routes, func(a, b Route) int {
+		if c := strings.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Method, b.Method)
+	})
+	return routes
+}
@@ -0,0 +1,187 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"orders-service/internal/adapters/http/handlers"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// publicRoutes are served by the first version only
+var publicRoutes = []Route{
+	{"GET", "/health"},
+	{"GET", "/health/live"},
+	{"GET", "/health/ready"},
+	{"GET", "/metrics"},
+	{"GET", "/openapi.json"},
+	{"GET", "/docs"},
+}
+
+// apiRoutes are served by every version
+var apiRoutes = []Route{
+	{"GET", "/customers/:customer_id/orders"},
+	{"HEAD", "/customers/:customer_id/orders"},
+	{"GET", "/customers/:customer_id/orders/summary"},
+	{"GET", "/orders"},
+	{"HEAD", "/orders"},
+	{"POST", "/orders"},
+	{"DELETE", "/orders/:id"},
+	{"GET", "/orders/:id"},
+	{"PATCH", "/orders/:id"},
+	{"POST", "/orders/:id/amend"},
+	{"GET", "/orders/:id/audit"},
+	{"POST", "/orders/:id/cancel"},
+	{"POST", "/orders/:id/confirm"},
+	{"GET", "/orders/:id/document"},
+	{"GET", "/orders/:id/events"},
+	{"POST", "/orders/:id/hold"},
+	{"POST", "/orders/:id/items"},
+	{"PUT", "/orders/:id/items"},
+	{"DELETE", "/orders/:id/items/:product_id"},
+	{"PUT", "/orders/:id/items/:product_id"},
+	{"POST", "/orders/:id/items/:product_id/fulfill"},
+	{"GET", "/orders/:id/refunds"},
+	{"POST", "/orders/:id/refunds"},
+	{"POST", "/orders/:id/release"},
+	{"POST", "/orders/:id/reprice"},
+	{"GET", "/orders/:id/scheduled-transitions"},
+	{"POST", "/orders/:id/scheduled-transitions"},
+	{"POST", "/orders/:id/scheduled-transitions/:transition_id/cancel"},
+	{"GET", "/orders/:id/shipments"},
+	{"POST", "/orders/:id/shipments"},
+	{"POST", "/orders/:id/shipments/:shipment_id/deliver"},
+	{"GET", "/orders/:id/snapshots"},
+	{"GET", "/orders/:id/snapshots/:version"},
+	{"PUT", "/orders/:id/status"},
+	{"POST", "/orders/async"},
+	{"GET", "/orders/backordered"},
+	{"GET", "/orders/by-reference"},
+	{"GET", "/orders/count"},
+	{"GET", "/orders/events"},
+	{"GET", "/orders/export"},
+	{"GET", "/orders/jobs/:job_id"},
+	{"GET", "/orders/number/:order_number"},
+	{"GET", "/orders/queue"},
+	{"GET", "/orders/stats"},
+	{"GET", "/orders/status/:status"},
+	{"HEAD", "/orders/status/:status"},
+}
+
+// adminRoutes are served by every version unless the admin routes are disabled
+var adminRoutes = []Route{
+	{"GET", "/admin/orders/deleted"},
+	{"POST", "/admin/orders/:id/restore"},
+	{"POST", "/admin/orders/import"},
+	{"GET", "/webhooks/:id/dead-letters"},
+	{"POST", "/webhooks/:id/dead-letters/replay"},
+	{"POST", "/webhooks/:id/dead-letters/:dead_letter_id/replay"},
+}
+
+// under prefixes the paths of routes
+func under(prefix string, routes ...[]Route) []Route {
+	var prefixed []Route
+	for _, table := range routes {
+		for _, route := range table {
+			prefixed = append(prefixed, Route{Method: route.Method, Path: prefix + route.Path})
+		}
+	}
+	return prefixed
+}
+
+func TestRoutes_DefaultTable(t *testing.T) {
+	expected := under("/api/v1", publicRoutes, apiRoutes, adminRoutes)
+	expected = append(expected, under("/api/v2", apiRoutes, adminRoutes)...)
+
+	assert.ElementsMatch(t, expected, Routes())
+}
+
+func TestRoutes_WithoutAdminRoutes(t *testing.T) {
+	expected := under("/api/v1", publicRoutes, apiRoutes)
+	expected = append(expected, under("/api/v2", apiRoutes)...)
+
+	assert.ElementsMatch(t, expected, Routes(WithAdminRoutes(false)))
+}
+
+func TestRoutes_WithPrefixAndVersions(t *testing.T) {
+	routes := Routes(WithPrefix("internal/"), WithVersions(Version{Name: "v3", NotFound: echo.NotFoundHandler}))
+
+	assert.ElementsMatch(t, under("/internal/v3", publicRoutes, apiRoutes, adminRoutes), routes)
+}
+
+func TestRoutes_AreSorted(t *testing.T) {
+	routes := Routes()
+
+	for i := 1; i < len(routes); i++ {
+		previous, current := routes[i-1], routes[i]
+		assert.True(t, previous.Path < current.Path || previous.Path == current.Path && previous.Method < current.Method,
+			"%v is listed before %v", previous, current)
+	}
+}
+
+func TestRegisterRoutes_MiddlewareChains(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		expected []string
+	}{
+		{"order route", http.MethodGet, "/api/v1/orders/1", []string{"api", "orders", "read"}},
+		{"order change", http.MethodPost, "/api/v2/orders/1/confirm", []string{"api", "orders", "write"}},
+		{"admin order route", http.MethodDelete, "/api/v1/orders/1", []string{"api", "orders", "admin"}},
+		{"query route", http.MethodGet, "/api/v1/customers/1/orders", []string{"api", "read"}},
+		{"admin route", http.MethodGet, "/api/v2/admin/orders/deleted", []string{"api", "admin"}},
+		{"webhook route", http.MethodPost, "/api/v1/webhooks/partner/dead-letters/replay", []string{"api", "admin"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			var trace []string
+			record := func(name string, answer bool) []echo.MiddlewareFunc {
+				return []echo.MiddlewareFunc{func(next echo.HandlerFunc) echo.HandlerFunc {
+					return func(c echo.Context) error {
+						trace = append(trace, name)
+						if answer {
+							return c.NoContent(http.StatusNoContent)
+						}
+						return next(c)
+					}
+				}}
+			}
+			e := echo.New()
+			RegisterRoutes(e, &handlers.OrderHandler{}, WithMiddleware(Middleware{
+				API:    record("api", false),
+				Orders: record("orders", false),
+				Read:   record("read", true),
+				Write:  record("write", true),
+				Admin:  record("admin", true),
+			}), WithWebhookHandler(&handlers.WebhookHandler{}))
+
+			// When
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, nil))
+
+			// Then
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			assert.Equal(t, tt.expected, trace)
+		})
+	}
+}
+
+func TestRegisterRoutes_TrailingSlashAndUnknownMethod(t *testing.T) {
+	// Given
+	e := echo.New()
+	RegisterRoutes(e, &handlers.OrderHandler{})
+
+	// When
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/", nil))
+
+	// Then
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "OPTIONS, DELETE, GET, PATCH", rec.Header().Get(echo.HeaderAllow))
+}
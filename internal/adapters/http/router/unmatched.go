@@ -0,0 +1,56 @@
+package router
+
+import (
+	"net/http"
+	"slices"
+
+	"orders-service/internal/adapters/http/handlers"
+
+	"github.com/labstack/echo/v4"
+)
+
+// handleUnmatched replaces the catch-all routes echo registers for a group with middleware, so the
+// requests no route of the group serves still run through the middleware of the group. A request for
+// a path that is routed for other methods is answered 405 with the allowed methods, any other one is
+// answered by notFound.
+func handleUnmatched(e *echo.Echo, g *echo.Group, notFound echo.HandlerFunc) {
+	handler := func(c echo.Context) error {
+		if allowed := allowedMethods(e, c.Request().URL.Path); len(allowed) > 0 {
+			return handlers.MethodNotAllowed(c, allowed)
+		}
+		return notFound(c)
+	}
+	g.RouteNotFound("", handler)
+	g.RouteNotFound("/*", handler)
+}
+
+// allowedMethods returns the methods e routes path to a handler for, OPTIONS first and the others
+// sorted. Each method is looked up with the router, so a static route hides the methods of a parameter
+// route the way it does when serving. It returns nil when no method is routed.
+func allowedMethods(e *echo.Echo, path string) []string {
+	routed := make(map[string]bool)
+	var methods []string
+	for _, route := range e.Routes() {
+		if route.Method == echo.RouteNotFound {
+			continue
+		}
+		routed[route.Method+" "+route.Path] = true
+		if !slices.Contains(methods, route.Method) {
+			methods = append(methods, route.Method)
+		}
+	}
+	slices.Sort(methods)
+
+	var allowed []string
+	for _, method := range methods {
+		c := e.NewContext(nil, nil)
+		e.Router().Find(method, path, c)
+		if routed[method+" "+c.Path()] {
+			allowed = append(allowed, method)
+		}
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+	return append([]string{http.MethodOptions}, allowed...)
+}
@@ -2,16 +2,29 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"strings"
 
 	"orders-service/internal/adapters/http/handlers"
+	"orders-service/internal/adapters/http/middlewares/apikey"
+	"orders-service/internal/adapters/http/middlewares/bodylimit"
+	"orders-service/internal/adapters/http/middlewares/compress"
+	"orders-service/internal/adapters/http/middlewares/cors"
 	"orders-service/internal/adapters/http/middlewares/logging"
-	"orders-service/internal/adapters/persistence/orders_repository"
-	"orders-service/internal/application/usecases"
+	"orders-service/internal/adapters/http/middlewares/ratelimit"
+	"orders-service/internal/adapters/http/middlewares/timeout"
+	"orders-service/internal/adapters/http/router"
+	"orders-service/internal/adapters/workers"
+	"orders-service/internal/application/auth"
 	"orders-service/internal/config"
 	"orders-service/internal/infrastructure"
 	"orders-service/pkg/logger"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
@@ -21,6 +34,18 @@ type Server struct {
 	config      *config.Config
 	logger      logger.Logger
 	connections *infrastructure.DatabaseConnections
+
+	services *infrastructure.Services
+
+	// workers is nil when the background jobs run in the worker command
+	workers     *workers.Runner
+	stopWorkers context.CancelFunc
+
+	// tlsConfig is nil when the API is served over plain HTTP
+	tlsConfig    *tls.Config
+	certificates *CertificateReloader
+	// health serves the health endpoints over plain HTTP next to the TLS listener, nil when not configured
+	health *echo.Echo
 }
 
 func NewServer(cfg *config.Config, log logger.Logger, connections *infrastructure.DatabaseConnections) (*Server, error) {
@@ -29,6 +54,8 @@ func NewServer(cfg *config.Config, log logger.Logger, connections *infrastructur
 	// Configure Echo
 	e.HideBanner = true
 	e.HidePort = true
+	configureHTTPServer(e.Server, cfg.Server)
+	configureHTTPServer(e.TLSServer, cfg.Server)
 
 	server := &Server{
 		echo:        e,
@@ -37,6 +64,13 @@ func NewServer(cfg *config.Config, log logger.Logger, connections *infrastructur
 		connections: connections,
 	}
 
+	// Refuse to start with a missing or expired certificate rather than failing every handshake
+	if cfg.Server.TLS.Enabled() {
+		if err := server.setupTLS(); err != nil {
+			return nil, err
+		}
+	}
+
 	// Setup middleware
 	server.setupMiddleware()
 
@@ -46,9 +80,15 @@ func NewServer(cfg *config.Config, log logger.Logger, connections *infrastructur
 	return server, nil
 }
 
+// newRequestID generates the X-Request-ID of a request that arrived without one
+func newRequestID() string {
+	return uuid.NewString()
+}
+
 func (s *Server) setupMiddleware() {
-	// Request ID middleware
-	s.echo.Use(middleware.RequestID())
+	// Request ID middleware, keeps the X-Request-ID of the gateway or generates a UUID when it sent none
+	s.echo.Use(middleware.RequestIDWithConfig(middleware.RequestIDConfig{Generator: newRequestID}))
+	s.echo.Use(logging.RequestContext(s.logger))
 
 	// Replace Echo's logger with our custom Zap logger
 	s.echo.Use(logging.ZapLogger(s.logger.With("component", "http")))
@@ -56,6 +96,11 @@ func (s *Server) setupMiddleware() {
 	// Recovery middleware
 	s.echo.Use(middleware.Recover())
 
+	// RFC 7807 error bodies for every client, when the gateway requires them
+	if s.config.Server.ProblemDetails {
+		s.echo.Use(handlers.ForceProblemDetails())
+	}
+
 	// Security headers
 	s.echo.Use(middleware.SecureWithConfig(middleware.SecureConfig{
 		XSSProtection:         "1; mode=block",
@@ -65,68 +110,159 @@ func (s *Server) setupMiddleware() {
 		ContentSecurityPolicy: "default-src 'self'",
 	}))
 
-	// CORS middleware
-	s.echo.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: s.config.Server.CORS.AllowOrigins,
-		AllowMethods: s.config.Server.CORS.AllowMethods,
-		AllowHeaders: s.config.Server.CORS.AllowHeaders,
-	}))
+	// CORS for browser clients of the API routes, registered globally so preflight requests are answered before authentication
+	s.echo.Use(cors.CORS(s.config.Server.CORS, skipsCORS))
 
-	// Request timeout middleware
-	s.echo.Use(middleware.TimeoutWithConfig(middleware.TimeoutConfig{
-		Timeout: s.config.Server.ReadTimeout,
-	}))
+	// Gzip for large JSON and CSV bodies, event streams are never compressed
+	if s.config.Server.Compression.Enabled {
+		s.echo.Use(compress.Gzip(s.config.Server.Compression))
+	}
+
+	// Request timeout middleware, streaming routes are exempt so large exports and imports are not cut off
+	// by it nor by the read and write timeouts of the connection
+	s.echo.Use(timeout.NoDeadlines(isStreamingRoute))
+	s.echo.Use(timeout.Timeout(s.config.Server.RequestTimeout, isStreamingRoute))
 }
 
-func (s *Server) setupRoutes() {
-	// Health check handler
-	healthHandler := handlers.NewHealthHandler(s.logger, s.connections)
+// configureHTTPServer bounds the connections of server by the timeouts and header limit of cfg, so slow
+// clients cannot hold connections open
+func configureHTTPServer(server *http.Server, cfg config.ServerConfig) {
+	server.ReadTimeout = cfg.ReadTimeout
+	server.ReadHeaderTimeout = cfg.ReadHeaderTimeout
+	server.WriteTimeout = cfg.WriteTimeout
+	server.IdleTimeout = cfg.IdleTimeout
+	server.MaxHeaderBytes = cfg.MaxHeaderBytes
+}
+
+// skipsCORS reports whether the request is outside the API routes, health checks and metrics never send CORS headers
+func skipsCORS(c echo.Context) bool {
+	path := c.Request().URL.Path
+	if !strings.HasPrefix(path, "/api/v1/") && !strings.HasPrefix(path, "/api/v2/") {
+		return true
+	}
+	return path == "/api/v1/metrics" || path == "/api/v1/health" || strings.HasPrefix(path, "/api/v1/health/")
+}
 
-	// Initialize repository
-	orderRepo := order_repository.NewGormOrderRepository(s.connections.GetGormDB())
+// isStreamingRoute reports whether the matched route streams its response
+func isStreamingRoute(c echo.Context) bool {
+	switch strings.Replace(c.Path(), "/api/v2/", "/api/v1/", 1) {
+	case "/api/v1/orders/export", "/api/v1/orders/events", "/api/v1/orders/:id/events", "/api/v1/admin/orders/import":
+		return true
+	}
+	return false
+}
 
+func (s *Server) setupRoutes() {
 	// Initialize use cases
-	orderUseCases := usecases.NewOrderUseCases(orderRepo, s.logger)
+	s.services = infrastructure.NewServices(s.config, s.connections, s.logger)
+
+	// Health check handler
+	healthHandler := handlers.NewHealthHandlerWithDependencies(s.logger, s.connections, s.services.Dependencies)
+
+	// Background jobs run here unless a separate worker command took them over
+	if s.config.Workers.Embedded {
+		s.workers = workers.NewRunner(workers.NewJobs(s.config, s.services.Orders, s.services.OrderJobQueue, s.services.JobLock, s.logger), s.logger)
+	}
 
 	// Initialize handlers
-	orderHandler := handlers.NewOrderHandler(orderUseCases, s.logger)
+	orderHandler := handlers.NewOrderHandlerWithConfig(s.services.Orders, s.logger, handlers.OrderHandlerConfig{
+		StrictBinding:  s.config.Server.StrictJSON,
+		StrictIncludes: s.config.Server.StrictIncludes,
+		Audit:          s.services.Audit,
+		ListCache: handlers.ListCacheConfig{
+			Enabled:       s.config.Server.ListCache.Enabled,
+			DefaultMaxAge: s.config.Server.ListCache.DefaultMaxAge,
+			MaxAge:        s.config.Server.ListCache.MaxAge,
+		},
+	})
+	eventsHandler := handlers.NewOrderEventsHandler(s.services.OrderEvents, s.services.Orders, s.config.Server.Events.HeartbeatInterval, s.logger)
+	auditHandler := handlers.NewAuditHandler(s.services.Audit, s.services.Orders, s.logger)
+	docsHandler := handlers.NewDocsHandler(s.logger)
+
+	webhookHandler := handlers.NewWebhookHandler(s.services.Webhooks, s.logger)
+	s.registerRoutes(healthHandler, orderHandler, eventsHandler, auditHandler, webhookHandler, docsHandler)
+
+	if s.tlsConfig != nil && s.config.Server.TLS.HealthPort != "" {
+		s.health = newHealthEcho(healthHandler)
+		configureHTTPServer(s.health.Server, s.config.Server)
+	}
 
-	// API v1 routes
-	v1 := s.echo.Group("/api/v1")
+	s.logRegisteredRoutes()
+}
 
-	// Health endpoints
-	v1.GET("/health", healthHandler.Health)
-	v1.GET("/health/ready", healthHandler.Ready)
-	v1.GET("/health/live", healthHandler.Live)
+// setupTLS loads the certificate and the client CA of the TLS listener
+func (s *Server) setupTLS() error {
+	tlsCfg := s.config.Server.TLS
+	certificates, err := NewCertificateReloader(tlsCfg.CertFile, tlsCfg.KeyFile)
+	if err != nil {
+		return err
+	}
+	tlsConfig, err := newTLSConfig(tlsCfg, certificates)
+	if err != nil {
+		return err
+	}
 
-	// Metrics endpoint
-	v1.GET("/metrics", healthHandler.Metrics)
+	s.certificates = certificates
+	s.tlsConfig = tlsConfig
+	s.logger.Info("TLS enabled",
+		"cert_file", tlsCfg.CertFile,
+		"expires_at", certificates.NotAfter(),
+		"min_version", tlsCfg.MinVersion,
+		"client_auth", tlsCfg.ClientCAFile != "")
+	return nil
+}
 
-	// Order routes
-	orders := v1.Group("/orders")
-	{
-		// CRUD operations
-		orders.POST("", orderHandler.CreateOrder)       // Create order
-		orders.GET("", orderHandler.ListOrders)         // List all orders
-		orders.GET("/:id", orderHandler.GetOrder)       // Get order by ID
-		orders.DELETE("/:id", orderHandler.DeleteOrder) // Delete order
+// newHealthEcho serves the health endpoints alone, at the same paths as on the API listener
+func newHealthEcho(healthHandler *handlers.HealthHandler) *echo.Echo {
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+	e.Pre(middleware.RemoveTrailingSlash())
+	e.Use(middleware.Recover())
+	e.RouteNotFound("/*", handlers.RouteNotFound)
 
-		// Order items management
-		orders.POST("/:id/items", orderHandler.AddItemToOrder)                    // Add item to order
-		orders.DELETE("/:id/items/:product_id", orderHandler.RemoveItemFromOrder) // Remove item from order
-		orders.PUT("/:id/items/:product_id", orderHandler.UpdateItemQuantity)     // Update item quantity
+	v1 := e.Group("/api/v1")
+	v1.GET("/health", healthHandler.Health)
+	v1.GET("/health/ready", healthHandler.Ready)
+	v1.GET("/health/live", healthHandler.Live)
+	return e
+}
 
-		// Order actions
-		orders.POST("/:id/confirm", orderHandler.ConfirmOrder)    // Confirm order
-		orders.POST("/:id/cancel", orderHandler.CancelOrder)      // Cancel order
-		orders.PUT("/:id/status", orderHandler.UpdateOrderStatus) // Update order status
-	}
+// registerRoutes mounts every handler on the echo router
+func (s *Server) registerRoutes(healthHandler *handlers.HealthHandler, orderHandler *handlers.OrderHandler, eventsHandler *handlers.OrderEventsHandler, auditHandler *handlers.AuditHandler, webhookHandler *handlers.WebhookHandler, docsHandler *handlers.DocsHandler) {
+	// Rate limiting and authentication apply to the API routes only, health and metrics stay open.
+	// The limiter runs between identifying and rejecting clients, so valid keys are limited per key
+	// and requests with a missing or unknown key per client IP.
+	router.RegisterRoutes(s.echo, orderHandler,
+		router.WithMiddleware(router.Middleware{
+			API: []echo.MiddlewareFunc{
+				apikey.Identify(s.config.Security.APIKeys, s.logger.With("component", "auth")),
+				s.rateLimitMiddleware(),
+				apikey.RequireAuthenticated(),
+			},
+			Read:   []echo.MiddlewareFunc{apikey.RequireScope(auth.ScopeOrdersRead)},
+			Write:  []echo.MiddlewareFunc{apikey.RequireScope(auth.ScopeOrdersWrite)},
+			Admin:  []echo.MiddlewareFunc{apikey.RequireScope(auth.ScopeOrdersAdmin)},
+			Orders: []echo.MiddlewareFunc{bodylimit.BodyLimit(s.config.Server.BodyLimit.For("orders"))},
+			Import: []echo.MiddlewareFunc{bodylimit.BodyLimit(s.config.Server.BodyLimit.For("import"))},
+		}),
+		router.WithHealthHandler(healthHandler),
+		router.WithEventsHandler(eventsHandler),
+		router.WithAuditHandler(auditHandler),
+		router.WithWebhookHandler(webhookHandler),
+		router.WithDocsHandler(docsHandler),
+	)
+}
 
-	// Query routes
-	v1.GET("/customers/:customer_id/orders", orderHandler.GetCustomerOrders) // Get orders by customer
-	v1.GET("/orders/status/:status", orderHandler.GetOrdersByStatus)         // Get orders by status
+// rateLimitMiddleware limits clients with the current rate limits, which a configuration reload may change
+func (s *Server) rateLimitMiddleware() echo.MiddlewareFunc {
+	dynamic := s.config.Dynamic()
 
-	s.logRegisteredRoutes()
+	return ratelimit.RateLimit(
+		ratelimit.NewDynamicMemoryLimiter(dynamic.RateLimitReads),
+		ratelimit.NewDynamicMemoryLimiter(dynamic.RateLimitWrites),
+		s.logger.With("component", "rate_limiter"),
+	)
 }
 
 func (s *Server) logRegisteredRoutes() {
@@ -143,10 +279,78 @@ func (s *Server) Start() error {
 	address := fmt.Sprintf("%s:%s", s.config.Server.Host, s.config.Server.Port)
 	s.logger.Info("Starting HTTP server", "address", address)
 
-	return s.echo.Start(address)
+	s.startWorkers()
+
+	if s.tlsConfig == nil {
+		return s.echo.Start(address)
+	}
+
+	if s.health != nil {
+		s.startHealthServer()
+	}
+
+	// StartServer serves HTTP/2 and HTTP/1.1 as negotiated by the TLS config
+	s.echo.TLSServer.Addr = address
+	s.echo.TLSServer.TLSConfig = s.tlsConfig
+	return s.echo.StartServer(s.echo.TLSServer)
+}
+
+// startHealthServer serves the health endpoints over plain HTTP for probes that cannot speak TLS
+func (s *Server) startHealthServer() {
+	address := net.JoinHostPort(s.config.Server.Host, s.config.Server.TLS.HealthPort)
+	s.logger.Info("Starting plain HTTP health server", "address", address)
+
+	go func() {
+		if err := s.health.Start(address); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("Health server failed", "error", err)
+		}
+	}()
+}
+
+// ReloadCertificates reads the TLS certificate files again, new handshakes use the reloaded certificate.
+// It does nothing when TLS is disabled and keeps the current certificate when the files are invalid.
+func (s *Server) ReloadCertificates() error {
+	if s.certificates == nil {
+		return nil
+	}
+	if err := s.certificates.Reload(); err != nil {
+		s.logger.Error("TLS certificate reload failed, keeping the current certificate", "error", err)
+		return err
+	}
+	s.logger.Info("TLS certificate reloaded", "expires_at", s.certificates.NotAfter())
+	return nil
+}
+
+// startWorkers launches the background workers, they stop when the server shuts down
+func (s *Server) startWorkers() {
+	if s.workers == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopWorkers = cancel
+	go s.workers.Run(ctx)
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.Info("Shutting down HTTP server...")
-	return s.echo.Shutdown(ctx)
+	if s.stopWorkers != nil {
+		s.stopWorkers()
+	}
+
+	err := s.echo.Shutdown(ctx)
+	if s.health != nil {
+		if healthErr := s.health.Shutdown(ctx); healthErr != nil {
+			s.logger.Error("Failed to shut down health server", "error", healthErr)
+		}
+	}
+
+	// Flush the audit log once no request can record new entries
+	if s.services != nil {
+		if closeErr := s.services.Close(ctx); closeErr != nil {
+			s.logger.Error("Failed to flush audit log", "error", closeErr)
+		}
+	}
+
+	return err
 }
@@ -0,0 +1,413 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"orders-service/internal/adapters/http/docs"
+	"orders-service/internal/adapters/http/handlers"
+	"orders-service/internal/adapters/http/middlewares/apikey"
+	"orders-service/internal/adapters/http/router"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/config"
+	"orders-service/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type openAPIDocument struct {
+	OpenAPI string                                `json:"openapi"`
+	Paths   map[string]map[string]json.RawMessage `json:"paths"`
+}
+
+var pathParamPattern = regexp.MustCompile(`:([a-zA-Z_]+)`)
+
+func setupTestServer() *Server {
+	log := logger.New("test")
+	server := &Server{
+		echo:   echo.New(),
+		config: &config.Config{},
+		logger: log,
+	}
+	server.registerRoutes(
+		handlers.NewHealthHandler(log, nil),
+		handlers.NewOrderHandler(nil, log),
+		handlers.NewOrderEventsHandler(nil, nil, 0, log),
+		handlers.NewAuditHandler(nil, nil, log),
+		handlers.NewWebhookHandler(nil, log),
+		handlers.NewDocsHandler(log),
+	)
+	return server
+}
+
+func loadOpenAPIDocument(t *testing.T) openAPIDocument {
+	var document openAPIDocument
+	require.NoError(t, json.Unmarshal(docs.OpenAPISpec, &document))
+	return document
+}
+
+func TestOpenAPISpec_IsValidDocument(t *testing.T) {
+	document := loadOpenAPIDocument(t)
+
+	assert.True(t, strings.HasPrefix(document.OpenAPI, "3."))
+	assert.NotEmpty(t, document.Paths)
+}
+
+func TestOpenAPISpec_CoversRegisteredRoutes(t *testing.T) {
+	document := loadOpenAPIDocument(t)
+
+	for _, route := range router.Routes() {
+		path := pathParamPattern.ReplaceAllString(route.Path, "{$1}")
+		operations, ok := document.Paths[path]
+		if !assert.True(t, ok, "path %s is missing from openapi.json", path) {
+			continue
+		}
+
+		_, ok = operations[strings.ToLower(route.Method)]
+		assert.True(t, ok, "operation %s %s is missing from openapi.json", route.Method, path)
+	}
+}
+
+func TestOpenAPISpec_DocumentsOnlyRegisteredRoutes(t *testing.T) {
+	document := loadOpenAPIDocument(t)
+
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		path := pathParamPattern.ReplaceAllString(route.Path, "{$1}")
+		registered[route.Method+" "+path] = true
+	}
+
+	for path, operations := range document.Paths {
+		for method := range operations {
+			key := strings.ToUpper(method) + " " + path
+			assert.True(t, registered[key], "openapi.json documents %s which is not registered", key)
+		}
+	}
+}
+
+func TestDocsRoutes_ServeSpecAndUI(t *testing.T) {
+	server := setupTestServer()
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil)
+	rec := httptest.NewRecorder()
+	server.echo.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, docs.OpenAPISpec, rec.Body.Bytes())
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/docs", nil)
+	rec = httptest.NewRecorder()
+	server.echo.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "swagger-ui")
+}
+
+func TestServer_CORSPreflightOnCreateOrder(t *testing.T) {
+	log := logger.New("test")
+	server := &Server{
+		echo: echo.New(),
+		config: &config.Config{Server: config.ServerConfig{CORS: config.CORSConfig{
+			AllowOrigins: []string{"https://*.example.com"},
+			AllowMethods: []string{http.MethodGet, http.MethodPost},
+			AllowHeaders: []string{"Content-Type", "X-API-Key"},
+		}}},
+		logger: log,
+	}
+	server.setupMiddleware()
+	server.registerRoutes(
+		handlers.NewHealthHandler(log, nil),
+		handlers.NewOrderHandler(nil, log),
+		handlers.NewOrderEventsHandler(nil, nil, 0, log),
+		handlers.NewAuditHandler(nil, nil, log),
+		handlers.NewWebhookHandler(nil, log),
+		handlers.NewDocsHandler(log),
+	)
+
+	send := func(method, path, origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set(echo.HeaderOrigin, origin)
+		req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodPost)
+		rec := httptest.NewRecorder()
+		server.echo.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// The preflight carries no API key and is answered before authentication
+	rec := send(http.MethodOptions, "/api/v1/orders", "https://dashboard.example.com")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://dashboard.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Equal(t, "GET,POST", rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+
+	rec = send(http.MethodOptions, "/api/v1/orders", "https://evil.com")
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+
+	for _, path := range []string{"/api/v1/metrics", "/api/v1/health/live"} {
+		rec = send(http.MethodGet, path, "https://dashboard.example.com")
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin), path)
+	}
+}
+
+func TestServer_RoutesBothAPIVersions(t *testing.T) {
+	routes := router.Routes()
+
+	registered := make(map[string]bool)
+	for _, route := range routes {
+		registered[route.Method+" "+route.Path] = true
+	}
+
+	for _, route := range routes {
+		path, ok := strings.CutPrefix(route.Path, "/api/v1/")
+		if !ok {
+			continue
+		}
+		public := strings.HasPrefix(path, "health") || path == "metrics" || path == "openapi.json" || path == "docs"
+		assert.Equal(t, !public, registered[route.Method+" /api/v2/"+path], "%s %s", route.Method, route.Path)
+	}
+}
+
+func TestServer_APIv2ErrorsAreProblemDetails(t *testing.T) {
+	server := setupTestServer()
+
+	for _, target := range []string{"/api/v2/orders", "/api/v2/unknown"} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		rec := httptest.NewRecorder()
+		server.echo.ServeHTTP(rec, req)
+
+		assert.Equal(t, handlers.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType), target)
+	}
+
+	// Version 1 keeps answering plain error responses
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+	rec := httptest.NewRecorder()
+	server.echo.ServeHTTP(rec, req)
+	assert.Equal(t, echo.MIMEApplicationJSON, rec.Header().Get(echo.HeaderContentType))
+}
+
+// routeTarget fills the parameters of a route path with 1
+func routeTarget(path string) string {
+	return pathParamPattern.ReplaceAllString(path, "1")
+}
+
+func TestServer_RoutesResolveWithAndWithoutTrailingSlash(t *testing.T) {
+	server := setupTestServer()
+
+	// Record the route each request resolves to instead of calling its handler
+	var matched string
+	server.echo.Use(func(echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			matched = c.Path()
+			return c.NoContent(http.StatusNoContent)
+		}
+	})
+
+	for _, route := range server.echo.Routes() {
+		if route.Method == echo.RouteNotFound {
+			continue
+		}
+		for _, target := range []string{routeTarget(route.Path), routeTarget(route.Path) + "/"} {
+			matched = ""
+			server.echo.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(route.Method, target, nil))
+			assert.Equal(t, route.Path, matched, "%s %s", route.Method, target)
+		}
+	}
+}
+
+func TestServer_UnregisteredMethodIsNotAllowed(t *testing.T) {
+	server := setupLifecycleServer(t)
+
+	methods := make(map[string][]string)
+	for _, route := range server.echo.Routes() {
+		if route.Method != echo.RouteNotFound {
+			methods[route.Path] = append(methods[route.Path], route.Method)
+		}
+	}
+
+	// A static path also allows the methods of the parameter route next to it, /orders/stats is
+	// routed to PATCH /orders/:id, so only the registered methods are required in the Allow header
+	for path, registered := range methods {
+		rejected := false
+		for _, method := range []string{http.MethodPut, http.MethodPost, http.MethodPatch, http.MethodDelete, http.MethodGet} {
+			if slices.Contains(registered, method) {
+				continue
+			}
+			rec := doLifecycleRequest(t, server, method, routeTarget(path), nil)
+			if rec.Code != http.StatusMethodNotAllowed {
+				continue
+			}
+
+			allowed := strings.Split(rec.Header().Get(echo.HeaderAllow), ", ")
+			assert.Subset(t, allowed, registered, "%s %s", method, path)
+			assert.NotContains(t, allowed, method, "%s %s", method, path)
+			rejected = true
+			break
+		}
+		assert.True(t, rejected, "every method is routed for %s", path)
+	}
+}
+
+func TestServer_MethodNotAllowedResponses(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		expectCode  int
+		expectAllow string
+		expectType  string
+		expectError string
+	}{
+		{"v1 list with trailing slash", http.MethodPut, "/api/v1/orders/", http.StatusMethodNotAllowed, "OPTIONS, GET, HEAD, POST", echo.MIMEApplicationJSON, "METHOD_NOT_ALLOWED"},
+		{"v2 order", http.MethodPost, "/api/v2/orders/1", http.StatusMethodNotAllowed, "OPTIONS, DELETE, GET, PATCH", handlers.MIMEApplicationProblemJSON, "METHOD_NOT_ALLOWED"},
+		{"v2 orders by status", http.MethodDelete, "/api/v2/orders/status/pending", http.StatusMethodNotAllowed, "OPTIONS, GET, HEAD", handlers.MIMEApplicationProblemJSON, "METHOD_NOT_ALLOWED"},
+		{"health", http.MethodPost, "/api/v1/health", http.StatusMethodNotAllowed, "OPTIONS, GET", echo.MIMEApplicationJSON, "METHOD_NOT_ALLOWED"},
+		{"options", http.MethodOptions, "/api/v1/orders/1/items", http.StatusNoContent, "OPTIONS, POST, PUT", "", ""},
+		{"v1 unknown path", http.MethodGet, "/api/v1/orders/1/unknown", http.StatusNotFound, "", echo.MIMEApplicationJSON, "ROUTE_NOT_FOUND"},
+		{"v2 unknown path", http.MethodGet, "/api/v2/orders/1/unknown", http.StatusNotFound, "", handlers.MIMEApplicationProblemJSON, "ROUTE_NOT_FOUND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := setupLifecycleServer(t)
+
+			rec := doLifecycleRequest(t, server, tt.method, tt.target, nil)
+
+			assert.Equal(t, tt.expectCode, rec.Code)
+			assert.Equal(t, tt.expectAllow, rec.Header().Get(echo.HeaderAllow))
+			assert.Contains(t, rec.Header().Get(echo.HeaderContentType), tt.expectType)
+			assert.Contains(t, rec.Body.String(), tt.expectError)
+		})
+	}
+}
+
+// startConfiguredServer serves the lifecycle routes over a real connection bounded by cfg
+func startConfiguredServer(t *testing.T, cfg config.ServerConfig) (*httptest.Server, ports.OrderRepository) {
+	t.Helper()
+	server, orderRepo := newLifecycleServer(t, 1000, handlers.DefaultOrderHandlerConfig())
+	httpServer := httptest.NewUnstartedServer(server.echo)
+	configureHTTPServer(httpServer.Config, cfg)
+	httpServer.Start()
+	t.Cleanup(httpServer.Close)
+	return httpServer, orderRepo
+}
+
+func TestServer_SlowRequestBodyIsCutOffAtReadTimeout(t *testing.T) {
+	// Given
+	httpServer, orderRepo := startConfiguredServer(t, config.ServerConfig{ReadTimeout: 100 * time.Millisecond})
+
+	body, writer := io.Pipe()
+	go func() {
+		_, _ = writer.Write([]byte(`{"customer_id":1,`))
+		time.Sleep(time.Second)
+		_, _ = writer.Write([]byte(`"items":[{"product_id":1,"product_sku":"SKU-1","product_name":"Mug","quantity":1,"unit_price":5}]}`))
+		_ = writer.Close()
+	}()
+	req, err := http.NewRequest(http.MethodPost, httpServer.URL+"/api/v1/orders", body)
+	require.NoError(t, err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(apikey.HeaderAPIKey, lifecycleAPIKey)
+
+	// When
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+
+	// Then
+	if err == nil {
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+	assert.Less(t, time.Since(start), time.Second, "the request waited for the slow body")
+	total, countErr := orderRepo.Count(context.Background())
+	require.NoError(t, countErr)
+	assert.Zero(t, total)
+}
+
+func TestServer_OversizedHeadersAreRejected(t *testing.T) {
+	// Given
+	httpServer, _ := startConfiguredServer(t, config.ServerConfig{MaxHeaderBytes: 4 << 10})
+
+	req, err := http.NewRequest(http.MethodGet, httpServer.URL+"/api/v1/health/live", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Padding", strings.Repeat("a", 16<<10))
+
+	// When
+	resp, err := http.DefaultClient.Do(req)
+
+	// Then
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusRequestHeaderFieldsTooLarge, resp.StatusCode)
+}
+
+func TestServer_RequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		provided string
+	}{
+		{"provided by the gateway", "gateway-7f3a"},
+		{"generated", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			server := &Server{echo: echo.New(), config: &config.Config{}, logger: logger.New("test")}
+			server.setupMiddleware()
+			server.echo.GET("/request-id", func(c echo.Context) error {
+				return c.String(http.StatusOK, handlers.RequestID(c))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/request-id", nil)
+			if tt.provided != "" {
+				req.Header.Set(echo.HeaderXRequestID, tt.provided)
+			}
+			rec := httptest.NewRecorder()
+
+			// When
+			server.echo.ServeHTTP(rec, req)
+
+			// Then
+			require.Equal(t, http.StatusOK, rec.Code)
+			requestID := rec.Header().Get(echo.HeaderXRequestID)
+			assert.Equal(t, requestID, rec.Body.String(), "the context and the response carry the same ID")
+			if tt.provided != "" {
+				assert.Equal(t, tt.provided, requestID)
+			} else {
+				_, err := uuid.Parse(requestID)
+				assert.NoError(t, err, "%q is not a UUID", requestID)
+			}
+		})
+	}
+}
+
+func TestServer_RateLimitsAuthenticatedKeysAndClientIPsSeparately(t *testing.T) {
+	// Given
+	server, _ := newLifecycleServer(t, 1, handlers.DefaultOrderHandlerConfig())
+	send := func(apiKey string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set(apikey.HeaderAPIKey, apiKey)
+		rec := httptest.NewRecorder()
+		server.echo.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// When
+	firstGuess := send("guess-1")
+	secondGuess := send("guess-2")
+	authenticated := send(lifecycleAPIKey)
+
+	// Then
+	assert.Equal(t, http.StatusUnauthorized, firstGuess)
+	assert.Equal(t, http.StatusTooManyRequests, secondGuess, "unknown keys share the client IP bucket")
+	assert.Equal(t, http.StatusOK, authenticated, "a valid key has its own bucket")
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"orders-service/internal/config"
+)
+
+// tlsVersions maps the server.tls.min_version setting to its TLS version
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// CertificateReloader serves the server certificate to TLS handshakes and replaces it on Reload, so
+// a renewed certificate is picked up without dropping open connections. It is safe for concurrent use.
+type CertificateReloader struct {
+	certFile string
+	keyFile  string
+	current  atomic.Pointer[tls.Certificate]
+	now      func() time.Time
+}
+
+// NewCertificateReloader loads the certificate, it fails when the files are missing, do not match or
+// the certificate is not valid right now
+func NewCertificateReloader(certFile, keyFile string) (*CertificateReloader, error) {
+	r := &CertificateReloader{certFile: certFile, keyFile: keyFile, now: time.Now}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reads the certificate files again, the current certificate stays in use when they are invalid
+func (r *CertificateReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate %s with key %s: %w", r.certFile, r.keyFile, err)
+	}
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("failed to parse TLS certificate %s: %w", r.certFile, err)
+	}
+	now := r.now()
+	if now.After(leaf.NotAfter) {
+		return fmt.Errorf("TLS certificate %s expired at %s", r.certFile, leaf.NotAfter.UTC().Format(time.RFC3339))
+	}
+	if now.Before(leaf.NotBefore) {
+		return fmt.Errorf("TLS certificate %s is not valid before %s", r.certFile, leaf.NotBefore.UTC().Format(time.RFC3339))
+	}
+
+	cert.Leaf = leaf
+	r.current.Store(&cert)
+	return nil
+}
+
+// NotAfter returns when the current certificate expires
+func (r *CertificateReloader) NotAfter() time.Time {
+	return r.current.Load().Leaf.NotAfter
+}
+
+// GetCertificate is the tls.Config hook returning the current certificate
+func (r *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.current.Load(), nil
+}
+
+// newTLSConfig builds the TLS settings of the API server, HTTP/2 is negotiated through ALPN
+func newTLSConfig(cfg config.TLSConfig, certificates *CertificateReloader) (*tls.Config, error) {
+	minVersion, ok := tlsVersions[cfg.MinVersion]
+	if !ok {
+		return nil, fmt.Errorf("unsupported TLS version %q", cfg.MinVersion)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:     minVersion,
+		GetCertificate: certificates.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+
+	if cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLS client CA %s: %w", cfg.ClientCAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("TLS client CA %s contains no PEM certificate", cfg.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"orders-service/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCertificate writes a self-signed certificate for localhost valid between notBefore and notAfter,
+// it returns the paths of the certificate and key files
+func writeCertificate(t *testing.T, dir, name string, notBefore, notAfter time.Time) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func TestNewCertificateReloader_RejectsUnusableCertificates(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	_, err := NewCertificateReloader(filepath.Join(dir, "missing.crt"), filepath.Join(dir, "missing.key"))
+	assert.ErrorContains(t, err, "failed to load TLS certificate")
+
+	certFile, keyFile := writeCertificate(t, dir, "expired", now.Add(-48*time.Hour), now.Add(-time.Hour))
+	_, err = NewCertificateReloader(certFile, keyFile)
+	assert.ErrorContains(t, err, "expired at")
+
+	certFile, keyFile = writeCertificate(t, dir, "future", now.Add(time.Hour), now.Add(48*time.Hour))
+	_, err = NewCertificateReloader(certFile, keyFile)
+	assert.ErrorContains(t, err, "is not valid before")
+}
+
+func TestCertificateReloader_Reload(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	certFile, keyFile := writeCertificate(t, dir, "server", now.Add(-time.Hour), now.Add(24*time.Hour))
+
+	reloader, err := NewCertificateReloader(certFile, keyFile)
+	require.NoError(t, err)
+	first, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+
+	// A renewed certificate replaces the current one
+	writeCertificate(t, dir, "server", now.Add(-time.Hour), now.Add(48*time.Hour))
+	require.NoError(t, reloader.Reload())
+	renewed, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Leaf.SerialNumber, renewed.Leaf.SerialNumber)
+
+	// A broken file keeps the renewed certificate in use
+	require.NoError(t, os.WriteFile(certFile, []byte("not a certificate"), 0o600))
+	assert.Error(t, reloader.Reload())
+	current, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, renewed.Leaf.SerialNumber, current.Leaf.SerialNumber)
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	certFile, keyFile := writeCertificate(t, dir, "server", now.Add(-time.Hour), now.Add(24*time.Hour))
+	caFile, _ := writeCertificate(t, dir, "client-ca", now.Add(-time.Hour), now.Add(24*time.Hour))
+
+	reloader, err := NewCertificateReloader(certFile, keyFile)
+	require.NoError(t, err)
+
+	tlsConfig, err := newTLSConfig(config.TLSConfig{MinVersion: "1.3", ClientCAFile: caFile}, reloader)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), tlsConfig.MinVersion)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, tlsConfig.ClientAuth)
+	assert.Contains(t, tlsConfig.NextProtos, "h2")
+
+	_, err = newTLSConfig(config.TLSConfig{MinVersion: "1.0"}, reloader)
+	assert.Error(t, err)
+
+	_, err = newTLSConfig(config.TLSConfig{MinVersion: "1.2", ClientCAFile: keyFile}, reloader)
+	assert.ErrorContains(t, err, "contains no PEM certificate")
+}
+
+func TestNewTLSConfig_ServesHTTP2(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	certFile, keyFile := writeCertificate(t, dir, "server", now.Add(-time.Hour), now.Add(24*time.Hour))
+
+	reloader, err := NewCertificateReloader(certFile, keyFile)
+	require.NoError(t, err)
+	tlsConfig, err := newTLSConfig(config.TLSConfig{MinVersion: "1.2"}, reloader)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	server.TLS = tlsConfig
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	pemBytes, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+	require.True(t, roots.AppendCertsFromPEM(pemBytes))
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{RootCAs: roots, ServerName: "localhost"},
+		ForceAttemptHTTP2: true,
+	}}
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, 2, resp.ProtoMajor)
+}
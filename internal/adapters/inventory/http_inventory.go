@@ -0,0 +1,86 @@
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	"orders-service/pkg/httpclient"
+)
+
+// reservationItem is a line of the body of POST /reservations
+type reservationItem struct {
+	ProductID uint `json:"product_id"`
+	Quantity  int  `json:"quantity"`
+}
+
+// reservationRequest is the body of POST /reservations
+type reservationRequest struct {
+	OrderID uint              `json:"order_id"`
+	Items   []reservationItem `json:"items"`
+}
+
+// reservationResponse is the answer to POST /reservations, the units reserved for every requested
+// line in the order of the request
+type reservationResponse struct {
+	Reserved []int `json:"reserved"`
+}
+
+// HTTPInventory reserves stock with the inventory service with POST {base_url}/reservations
+type HTTPInventory struct {
+	baseURL string
+	client  *httpclient.Client
+}
+
+// NewHTTPInventory creates an inventory client sending its requests through client, which stops calling
+// the inventory while its circuit breaker is open. Reservations are not idempotent and never retried.
+func NewHTTPInventory(baseURL string, client *httpclient.Client) ports.Inventory {
+	return &HTTPInventory{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  client,
+	}
+}
+
+// Reserve implements ports.Inventory
+func (i *HTTPInventory) Reserve(ctx context.Context, order *entities.Order) ([]int, error) {
+	body := reservationRequest{OrderID: order.ID, Items: make([]reservationItem, len(order.Items))}
+	for n, item := range order.Items {
+		body.Items[n] = reservationItem{ProductID: item.ProductID, Quantity: item.Quantity}
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("encode reservation: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, i.baseURL+"/reservations", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("build inventory request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := i.client.Do(req)
+	if errors.Is(err, httpclient.ErrCircuitOpen) {
+		return nil, fmt.Errorf("request reservation: %w: %w", ports.ErrInventoryCircuitOpen, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("request reservation: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("reservation: unexpected status %d", resp.StatusCode)
+	}
+
+	var reservation reservationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&reservation); err != nil {
+		return nil, fmt.Errorf("decode reservation: %w", err)
+	}
+	return reservation.Reserved, nil
+}
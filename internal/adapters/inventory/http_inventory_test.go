@@ -0,0 +1,102 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	"orders-service/pkg/httpclient"
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient builds an inventory client with fast retries, as the server would configure them
+func newTestClient() *httpclient.Client {
+	config := httpclient.DefaultConfig()
+	config.Timeout = time.Second
+	config.BaseDelay = time.Millisecond
+	config.MaxDelay = time.Millisecond
+	return httpclient.New("inventory", config, metrics.NewRegistry(), logger.New("test"))
+}
+
+func testOrder() *entities.Order {
+	return &entities.Order{
+		ID: 7,
+		Items: []entities.OrderItem{
+			{ProductID: 1, Quantity: 2},
+			{ProductID: 2, Quantity: 3},
+		},
+	}
+}
+
+func TestHTTPInventory_Reserve(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/reservations", r.URL.Path)
+		var body reservationRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, reservationRequest{OrderID: 7, Items: []reservationItem{
+			{ProductID: 1, Quantity: 2},
+			{ProductID: 2, Quantity: 3},
+		}}, body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"reserved":[2,1]}`))
+	}))
+	defer server.Close()
+
+	inventory := NewHTTPInventory(server.URL+"/", newTestClient())
+
+	reserved, err := inventory.Reserve(context.Background(), testOrder())
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 1}, reserved)
+}
+
+func TestHTTPInventory_Reserve_IsNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	inventory := NewHTTPInventory(server.URL, newTestClient())
+
+	reserved, err := inventory.Reserve(context.Background(), testOrder())
+
+	assert.Error(t, err)
+	assert.Nil(t, reserved)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestHTTPInventory_Reserve_CircuitOpen(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := httpclient.DefaultConfig()
+	config.BreakerThreshold = 1
+	config.BreakerCooldown = time.Minute
+	inventory := NewHTTPInventory(server.URL, httpclient.New("inventory", config, metrics.NewRegistry(), logger.New("test")))
+
+	_, err := inventory.Reserve(context.Background(), testOrder())
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ports.ErrInventoryCircuitOpen, "the failing reservation reached the inventory")
+
+	_, err = inventory.Reserve(context.Background(), testOrder())
+	assert.ErrorIs(t, err, ports.ErrInventoryCircuitOpen)
+	assert.ErrorIs(t, err, httpclient.ErrCircuitOpen)
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,55 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrQueueFull is returned by Enqueue when the buffer of the queue is full
+var ErrQueueFull = errors.New("job queue is full")
+
+// ChannelQueue implements ports.JobQueue with a buffered channel. Jobs reach the consumers of this
+// process only and a job still queued when the process stops is lost from the queue, the stored job
+// stays pending so a broker backed queue is only needed to spread the work over several replicas.
+type ChannelQueue struct {
+	jobs chan string
+}
+
+// NewChannelQueue creates a queue buffering up to size job IDs
+func NewChannelQueue(size int) *ChannelQueue {
+	if size <= 0 {
+		size = 1024
+	}
+	return &ChannelQueue{jobs: make(chan string, size)}
+}
+
+// Enqueue implements ports.JobQueue
+func (q *ChannelQueue) Enqueue(ctx context.Context, jobID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case q.jobs <- jobID:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Consume implements ports.JobQueue, it returns ctx.Err() once ctx is cancelled
+func (q *ChannelQueue) Consume(ctx context.Context, handle func(ctx context.Context, jobID string)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case jobID := <-q.jobs:
+			handle(ctx, jobID)
+		}
+	}
+}
+
+// Len returns the number of queued jobs
+func (q *ChannelQueue) Len() int {
+	return len(q.jobs)
+}
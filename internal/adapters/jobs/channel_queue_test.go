@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelQueue_DeliversInOrder(t *testing.T) {
+	queue := NewChannelQueue(4)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, queue.Enqueue(ctx, "a"))
+	require.NoError(t, queue.Enqueue(ctx, "b"))
+	assert.Equal(t, 2, queue.Len())
+
+	var got []string
+	done := make(chan error, 1)
+	go func() {
+		done <- queue.Consume(ctx, func(_ context.Context, jobID string) {
+			got = append(got, jobID)
+			if len(got) == 2 {
+				cancel()
+			}
+		})
+	}()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(2 * time.Second):
+		t.Fatal("consumer did not stop")
+	}
+	assert.Equal(t, []string{"a", "b"}, got)
+}
+
+func TestChannelQueue_FullQueueDoesNotBlock(t *testing.T) {
+	queue := NewChannelQueue(1)
+
+	require.NoError(t, queue.Enqueue(context.Background(), "a"))
+	assert.ErrorIs(t, queue.Enqueue(context.Background(), "b"), ErrQueueFull)
+	assert.Equal(t, 1, queue.Len())
+}
+
+func TestChannelQueue_CancelledEnqueue(t *testing.T) {
+	queue := NewChannelQueue(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, queue.Enqueue(ctx, "a"), context.Canceled)
+	assert.Zero(t, queue.Len())
+}
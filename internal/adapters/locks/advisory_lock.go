@@ -0,0 +1,109 @@
+package locks
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"orders-service/internal/application/ports"
+)
+
+// AdvisoryLock implements ports.DistributedLock with Postgres session advisory locks. Each held lock
+// keeps a connection of db out of the pool until it is released. Postgres releases the lock when that
+// session ends, so a crashed replica frees its locks with its connections and the ttl is not needed.
+type AdvisoryLock struct {
+	db   *sql.DB
+	mu   sync.Mutex
+	held map[string]*sql.Conn
+}
+
+func NewAdvisoryLock(db *sql.DB) *AdvisoryLock {
+	return &AdvisoryLock{
+		db:   db,
+		held: make(map[string]*sql.Conn),
+	}
+}
+
+// Acquire implements ports.DistributedLock, the ttl is ignored
+func (l *AdvisoryLock) Acquire(ctx context.Context, key string, _ time.Duration) error {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("get connection: %w", err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", advisoryLockID(key)).Scan(&acquired); err != nil {
+		discard(conn)
+		return fmt.Errorf("acquire advisory lock: %w", err)
+	}
+	if !acquired {
+		_ = conn.Close()
+		return ports.ErrLockHeld
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.held[key] = conn
+	return nil
+}
+
+// Renew implements ports.DistributedLock by checking the session holding the lock is still alive
+func (l *AdvisoryLock) Renew(ctx context.Context, key string, _ time.Duration) error {
+	l.mu.Lock()
+	conn, ok := l.held[key]
+	l.mu.Unlock()
+	if !ok {
+		return ports.ErrLockLost
+	}
+
+	if err := conn.PingContext(ctx); err != nil {
+		l.forget(key, conn)
+		discard(conn)
+		return fmt.Errorf("%w: %v", ports.ErrLockLost, err)
+	}
+	return nil
+}
+
+// Release implements ports.DistributedLock
+func (l *AdvisoryLock) Release(ctx context.Context, key string) error {
+	l.mu.Lock()
+	conn, ok := l.held[key]
+	delete(l.held, key)
+	l.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", advisoryLockID(key)); err != nil {
+		// The session still holds the lock, closing it is the only way to release it
+		discard(conn)
+		return fmt.Errorf("release advisory lock: %w", err)
+	}
+	return conn.Close()
+}
+
+// forget drops key from the held locks unless it was acquired again on another connection
+func (l *AdvisoryLock) forget(key string, conn *sql.Conn) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.held[key] == conn {
+		delete(l.held, key)
+	}
+}
+
+// advisoryLockID maps key to the bigint naming advisory locks
+func advisoryLockID(key string) int64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(key))
+	return int64(hash.Sum64())
+}
+
+// discard closes the session of conn instead of returning it to the pool, releasing its advisory locks
+func discard(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	_ = conn.Close()
+}
@@ -0,0 +1,69 @@
+package locks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"orders-service/internal/application/ports"
+)
+
+// MemoryLock implements ports.DistributedLock within a single process, for deployments running one
+// replica. Locks expire once their ttl passes without a renewal.
+type MemoryLock struct {
+	mu      sync.Mutex
+	expires map[string]time.Time
+	now     func() time.Time
+}
+
+func NewMemoryLock() *MemoryLock {
+	return &MemoryLock{
+		expires: make(map[string]time.Time),
+		now:     time.Now,
+	}
+}
+
+// Acquire implements ports.DistributedLock
+func (l *MemoryLock) Acquire(ctx context.Context, key string, ttl time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if expires, ok := l.expires[key]; ok && now.Before(expires) {
+		return ports.ErrLockHeld
+	}
+	l.expires[key] = now.Add(ttl)
+	return nil
+}
+
+// Renew implements ports.DistributedLock
+func (l *MemoryLock) Renew(ctx context.Context, key string, ttl time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	expires, ok := l.expires[key]
+	if !ok || !now.Before(expires) {
+		delete(l.expires, key)
+		return ports.ErrLockLost
+	}
+	l.expires[key] = now.Add(ttl)
+	return nil
+}
+
+// Release implements ports.DistributedLock
+func (l *MemoryLock) Release(_ context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.expires, key)
+	return nil
+}
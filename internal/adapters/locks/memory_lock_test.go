@@ -0,0 +1,54 @@
+package locks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/ports"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLock_AcquireIsExclusiveUntilReleased(t *testing.T) {
+	// Given
+	lock := NewMemoryLock()
+	ctx := context.Background()
+	require.NoError(t, lock.Acquire(ctx, "expiration", time.Minute))
+
+	// When
+	held := lock.Acquire(ctx, "expiration", time.Minute)
+	other := lock.Acquire(ctx, "order_jobs", time.Minute)
+	require.NoError(t, lock.Release(ctx, "expiration"))
+	released := lock.Acquire(ctx, "expiration", time.Minute)
+
+	// Then
+	assert.ErrorIs(t, held, ports.ErrLockHeld)
+	assert.NoError(t, other)
+	assert.NoError(t, released)
+}
+
+func TestMemoryLock_ExpiresWithoutRenewal(t *testing.T) {
+	// Given
+	lock := NewMemoryLock()
+	ctx := context.Background()
+	now := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	lock.now = func() time.Time { return now }
+	require.NoError(t, lock.Acquire(ctx, "expiration", time.Minute))
+
+	// When
+	now = now.Add(50 * time.Second)
+	renewErr := lock.Renew(ctx, "expiration", time.Minute)
+	now = now.Add(50 * time.Second)
+	stillHeld := lock.Acquire(ctx, "expiration", time.Minute)
+	now = now.Add(time.Minute)
+	lostErr := lock.Renew(ctx, "expiration", time.Minute)
+	expired := lock.Acquire(ctx, "expiration", time.Minute)
+
+	// Then
+	assert.NoError(t, renewErr)
+	assert.ErrorIs(t, stillHeld, ports.ErrLockHeld, "the renewal pushed the expiry past 100s")
+	assert.ErrorIs(t, lostErr, ports.ErrLockLost)
+	assert.NoError(t, expired)
+}
@@ -0,0 +1,109 @@
+package kafka
+
+import (
+	"context"
+
+	"orders-service/internal/config"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// brokerReader is a Reader on a kafka-go consumer group reader
+type brokerReader struct {
+	reader *kafkago.Reader
+}
+
+// brokerWriter is a Writer on a kafka-go writer
+type brokerWriter struct {
+	writer *kafkago.Writer
+}
+
+// NewClients connects the reader of cfg.Topic and the dead letter writer to cfg.Brokers. Both connect
+// lazily, an unreachable broker surfaces on the first fetch or write.
+func NewClients(cfg config.KafkaConfig) (Reader, Writer, error) {
+	reader := kafkago.NewReader(kafkago.ReaderConfig{
+		Brokers: cfg.Brokers,
+		GroupID: cfg.GroupID,
+		Topic:   cfg.Topic,
+		// Offsets are committed synchronously by CommitMessages, never in the background
+		CommitInterval: 0,
+	})
+
+	// Messages with the same key go to the same partition, so the dead letters of an upstream order stay in order
+	writer := &kafkago.Writer{
+		Addr:         kafkago.TCP(cfg.Brokers...),
+		Balancer:     &kafkago.Hash{},
+		RequiredAcks: kafkago.RequireAll,
+	}
+
+	return &brokerReader{reader: reader}, &brokerWriter{writer: writer}, nil
+}
+
+// FetchMessage implements Reader
+func (r *brokerReader) FetchMessage(ctx context.Context) (Message, error) {
+	message, err := r.reader.FetchMessage(ctx)
+	if err != nil {
+		return Message{}, err
+	}
+	return fromBrokerMessage(message), nil
+}
+
+// CommitMessages implements Reader
+func (r *brokerReader) CommitMessages(ctx context.Context, messages ...Message) error {
+	return r.reader.CommitMessages(ctx, toBrokerMessages(messages)...)
+}
+
+// Close implements Reader, leaving the consumer group
+func (r *brokerReader) Close() error {
+	return r.reader.Close()
+}
+
+// WriteMessages implements Writer, returning once every message is acknowledged by all in-sync replicas
+func (w *brokerWriter) WriteMessages(ctx context.Context, messages ...Message) error {
+	brokerMessages := toBrokerMessages(messages)
+	// Partitions are picked by the balancer, a message read from another topic carries its old one
+	for i := range brokerMessages {
+		brokerMessages[i].Partition = 0
+		brokerMessages[i].Offset = 0
+	}
+	return w.writer.WriteMessages(ctx, brokerMessages...)
+}
+
+// Close implements Writer, flushing pending messages
+func (w *brokerWriter) Close() error {
+	return w.writer.Close()
+}
+
+func fromBrokerMessage(message kafkago.Message) Message {
+	headers := make([]Header, 0, len(message.Headers))
+	for _, header := range message.Headers {
+		headers = append(headers, Header{Key: header.Key, Value: header.Value})
+	}
+	return Message{
+		Topic:     message.Topic,
+		Partition: message.Partition,
+		Offset:    message.Offset,
+		Key:       message.Key,
+		Value:     message.Value,
+		Headers:   headers,
+	}
+}
+
+func toBrokerMessages(messages []Message) []kafkago.Message {
+	brokerMessages := make([]kafkago.Message, 0, len(messages))
+	for _, message := range messages {
+		headers := make([]kafkago.Header, 0, len(message.Headers))
+		for _, header := range message.Headers {
+			headers = append(headers, kafkago.Header{Key: header.Key, Value: header.Value})
+		}
+		brokerMessages = append(brokerMessages, kafkago.Message{
+			Topic:     message.Topic,
+			Partition: message.Partition,
+			Offset:    message.Offset,
+			Key:       message.Key,
+			Value:     message.Value,
+			Headers:   headers,
+		})
+	}
+	return brokerMessages
+}
@@ -0,0 +1,115 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"orders-service/internal/config"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// kafkaTestBrokersEnv lists the comma separated brokers the round trip test runs against, it is skipped without them
+const kafkaTestBrokersEnv = "ORDERS_TEST_KAFKA_BROKERS"
+
+func TestNewClients_ConfiguresConsumerGroup(t *testing.T) {
+	reader, writer, err := NewClients(config.KafkaConfig{
+		Brokers: []string{"kafka-1:9092", "kafka-2:9092"},
+		GroupID: "orders-service",
+		Topic:   "marketplace.orders",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = reader.Close()
+		_ = writer.Close()
+	})
+
+	readerConfig := reader.(*brokerReader).reader.Config()
+	assert.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, readerConfig.Brokers)
+	assert.Equal(t, "orders-service", readerConfig.GroupID)
+	assert.Equal(t, "marketplace.orders", readerConfig.Topic)
+	assert.Zero(t, readerConfig.CommitInterval, "offsets are committed synchronously")
+
+	brokerWriter := writer.(*brokerWriter).writer
+	assert.Equal(t, "kafka-1:9092,kafka-2:9092", brokerWriter.Addr.String())
+	assert.Empty(t, brokerWriter.Topic, "every message names its topic")
+	assert.Equal(t, kafkago.RequireAll, brokerWriter.RequiredAcks)
+}
+
+func TestBrokerMessages_RoundTrip(t *testing.T) {
+	message := Message{
+		Topic:     "marketplace.orders",
+		Partition: 3,
+		Offset:    42,
+		Key:       []byte("upstream-1"),
+		Value:     []byte(`{"customer_id":1}`),
+		Headers:   []Header{{Key: HeaderOriginalTopic, Value: []byte("marketplace.orders")}},
+	}
+
+	brokerMessages := toBrokerMessages([]Message{message})
+	require.Len(t, brokerMessages, 1)
+	assert.Equal(t, 3, brokerMessages[0].Partition, "commits need the partition and offset")
+	assert.Equal(t, int64(42), brokerMessages[0].Offset)
+	assert.Equal(t, message, fromBrokerMessage(brokerMessages[0]))
+}
+
+func TestBrokerWriter_UnreachableBroker(t *testing.T) {
+	// A port nothing listens on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	_, writer, err := NewClients(config.KafkaConfig{Brokers: []string{address}, GroupID: "orders-service", Topic: "marketplace.orders"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = writer.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	err = writer.WriteMessages(ctx, Message{Topic: "marketplace.orders.dlq", Partition: 3, Offset: 42, Value: []byte("{}")})
+
+	assert.Error(t, err)
+}
+
+func TestClients_RoundTrip(t *testing.T) {
+	brokers := os.Getenv(kafkaTestBrokersEnv)
+	if brokers == "" {
+		t.Skipf("%s is not set", kafkaTestBrokersEnv)
+	}
+	topic := fmt.Sprintf("orders-test-%d", time.Now().UnixNano())
+	cfg := config.KafkaConfig{Brokers: strings.Split(brokers, ","), GroupID: topic, Topic: topic}
+
+	conn, err := kafkago.Dial("tcp", cfg.Brokers[0])
+	require.NoError(t, err)
+	require.NoError(t, conn.CreateTopics(kafkago.TopicConfig{Topic: topic, NumPartitions: 1, ReplicationFactor: 1}))
+	t.Cleanup(func() {
+		_ = conn.DeleteTopics(topic)
+		_ = conn.Close()
+	})
+
+	reader, writer, err := NewClients(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = reader.Close()
+		_ = writer.Close()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	sent := Message{Topic: topic, Key: []byte("upstream-1"), Value: []byte(`{"customer_id":1}`), Headers: []Header{{Key: "source", Value: []byte("test")}}}
+	require.NoError(t, writer.WriteMessages(ctx, sent))
+
+	received, err := reader.FetchMessage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sent.Key, received.Key)
+	assert.Equal(t, sent.Value, received.Value)
+	assert.Equal(t, sent.Headers, received.Headers)
+	require.NoError(t, reader.CommitMessages(ctx, received))
+}
@@ -0,0 +1,230 @@
+package kafka
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/usecases"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Metric names reported by Consumer
+const (
+	ConsumedMetric     = "kafka_orders_consumed_total"
+	FailedMetric       = "kafka_orders_failed_total"
+	DeadLetteredMetric = "kafka_orders_dead_lettered_total"
+)
+
+// Headers added to dead-lettered messages
+const (
+	HeaderError             = "x-error"
+	HeaderAttempts          = "x-attempts"
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+)
+
+// ConsumerConfig controls retries and dead-lettering
+type ConsumerConfig struct {
+	DeadLetterTopic string
+	// MaxAttempts includes the first try, values below 1 are treated as 1
+	MaxAttempts  int
+	RetryBackoff time.Duration
+}
+
+// Consumer creates orders from CreateOrderRequestDTO messages. The message key is the idempotency key:
+// it becomes the external reference of the order, so a redelivered message is recognised as a duplicate
+// instead of creating a second order. An offset is committed only once its message was ingested or
+// dead-lettered.
+type Consumer struct {
+	reader      Reader
+	deadLetters Writer
+	useCases    usecases.OrderUseCases
+	config      ConsumerConfig
+	validator   *validator.Validate
+
+	consumed     *metrics.Counter
+	failed       *metrics.Counter
+	deadLettered *metrics.Counter
+	logger       logger.Logger
+}
+
+func NewConsumer(reader Reader, deadLetters Writer, useCases usecases.OrderUseCases, cfg ConsumerConfig, registry *metrics.Registry, log logger.Logger) *Consumer {
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+
+	return &Consumer{
+		reader:       reader,
+		deadLetters:  deadLetters,
+		useCases:     useCases,
+		config:       cfg,
+		validator:    validator.New(),
+		consumed:     registry.Counter(ConsumedMetric),
+		failed:       registry.Counter(FailedMetric),
+		deadLettered: registry.Counter(DeadLetteredMetric),
+		logger:       log.With("component", "kafka_consumer"),
+	}
+}
+
+// Run consumes messages until ctx is cancelled. It returns an error when a message can neither be
+// ingested nor dead-lettered, or the offset cannot be committed, the message is then delivered again.
+func (c *Consumer) Run(ctx context.Context) error {
+	c.logger.Info("Kafka consumer started", "max_attempts", c.config.MaxAttempts, "dead_letter_topic", c.config.DeadLetterTopic)
+
+	for {
+		message, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				c.logger.Info("Kafka consumer stopped")
+				return nil
+			}
+			return fmt.Errorf("fetch message: %w", err)
+		}
+
+		if err := c.handle(ctx, message); err != nil {
+			if ctx.Err() != nil {
+				c.logger.Info("Kafka consumer stopped")
+				return nil
+			}
+			return err
+		}
+
+		if err := c.reader.CommitMessages(ctx, message); err != nil {
+			if ctx.Err() != nil {
+				c.logger.Info("Kafka consumer stopped")
+				return nil
+			}
+			return fmt.Errorf("commit offset %d of partition %d: %w", message.Offset, message.Partition, err)
+		}
+	}
+}
+
+// handle ingests message, retrying transient failures, and dead-letters it when it cannot be ingested
+func (c *Consumer) handle(ctx context.Context, message Message) error {
+	request, err := c.decode(message)
+	if err != nil {
+		c.failed.Inc()
+		return c.deadLetter(ctx, message, 1, err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.createOrder(ctx, request)
+		if err == nil {
+			c.consumed.Inc()
+			return nil
+		}
+		c.failed.Inc()
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !isTransientError(err) || attempt >= c.config.MaxAttempts {
+			return c.deadLetter(ctx, message, attempt, err)
+		}
+
+		c.logger.Warn("Failed to ingest order, retrying",
+			"partition", message.Partition,
+			"offset", message.Offset,
+			"attempt", attempt,
+			"error", err)
+
+		timer := time.NewTimer(c.config.RetryBackoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// decode parses and validates the request, deriving its external reference from the message key
+// unless the request carries one
+func (c *Consumer) decode(message Message) (*dto.CreateOrderRequestDTO, error) {
+	var request dto.CreateOrderRequestDTO
+	if err := json.Unmarshal(message.Value, &request); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	if request.ExternalReference == "" && len(message.Key) > 0 {
+		request.ExternalReference = externalReference(message.Key)
+	}
+
+	if err := c.validator.Struct(request); err != nil {
+		return nil, fmt.Errorf("invalid order: %w", err)
+	}
+	return &request, nil
+}
+
+func (c *Consumer) createOrder(ctx context.Context, request *dto.CreateOrderRequestDTO) error {
+	order, err := c.useCases.CreateOrder(ctx, request)
+	if errors.Is(err, domainErrors.ErrDuplicateExternalReference) {
+		c.logger.Info("Order already ingested", "customer_id", request.CustomerID, "external_reference", request.ExternalReference)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info("Order ingested", "order_id", order.ID, "customer_id", order.CustomerID, "external_reference", order.ExternalReference)
+	return nil
+}
+
+func (c *Consumer) deadLetter(ctx context.Context, message Message, attempts int, cause error) error {
+	c.logger.Error("Dead-lettering message",
+		"partition", message.Partition,
+		"offset", message.Offset,
+		"attempts", attempts,
+		"error", cause)
+
+	deadLetter := Message{
+		Topic: c.config.DeadLetterTopic,
+		Key:   message.Key,
+		Value: message.Value,
+		Headers: append(append([]Header(nil), message.Headers...),
+			Header{Key: HeaderError, Value: []byte(cause.Error())},
+			Header{Key: HeaderAttempts, Value: []byte(strconv.Itoa(attempts))},
+			Header{Key: HeaderOriginalTopic, Value: []byte(message.Topic)},
+			Header{Key: HeaderOriginalPartition, Value: []byte(strconv.Itoa(message.Partition))},
+			Header{Key: HeaderOriginalOffset, Value: []byte(strconv.FormatInt(message.Offset, 10))},
+		),
+	}
+	if err := c.deadLetters.WriteMessages(ctx, deadLetter); err != nil {
+		return fmt.Errorf("dead-letter offset %d of partition %d: %w", message.Offset, message.Partition, err)
+	}
+
+	c.deadLettered.Inc()
+	return nil
+}
+
+// externalReference uses the message key as is, keys longer than an external reference are hashed
+func externalReference(key []byte) string {
+	if len(key) <= entities.MaxExternalReferenceLength {
+		return string(key)
+	}
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:])
+}
+
+// isTransientError reports whether err may succeed on a later attempt. Domain errors the client
+// caused, such as invalid items, fail the same way every time.
+func isTransientError(err error) bool {
+	var domainErr *domainErrors.DomainError
+	if errors.As(err, &domainErr) {
+		return domainErrors.HTTPStatus(domainErr.Code) >= 500
+	}
+	return true
+}
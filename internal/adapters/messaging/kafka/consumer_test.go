@@ -0,0 +1,348 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/usecases"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeReader hands out the queued messages and blocks once they are exhausted
+type fakeReader struct {
+	mu        sync.Mutex
+	messages  []Message
+	committed []int64
+	commitErr error
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (Message, error) {
+	r.mu.Lock()
+	if len(r.messages) > 0 {
+		message := r.messages[0]
+		r.messages = r.messages[1:]
+		r.mu.Unlock()
+		return message, nil
+	}
+	r.mu.Unlock()
+
+	<-ctx.Done()
+	return Message{}, ctx.Err()
+}
+
+func (r *fakeReader) CommitMessages(_ context.Context, messages ...Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.commitErr != nil {
+		return r.commitErr
+	}
+	for _, message := range messages {
+		r.committed = append(r.committed, message.Offset)
+	}
+	return nil
+}
+
+func (r *fakeReader) Close() error { return nil }
+
+func (r *fakeReader) commits() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int64(nil), r.committed...)
+}
+
+type fakeWriter struct {
+	mu       sync.Mutex
+	messages []Message
+	err      error
+}
+
+func (w *fakeWriter) WriteMessages(_ context.Context, messages ...Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+	w.messages = append(w.messages, messages...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error { return nil }
+
+func (w *fakeWriter) written() []Message {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]Message(nil), w.messages...)
+}
+
+// stubOrderUseCases answers CreateOrder with the queued errors, succeeding once they are used up
+type stubOrderUseCases struct {
+	usecases.OrderUseCases
+	mu       sync.Mutex
+	errs     []error
+	requests []dto.CreateOrderRequestDTO
+}
+
+func (s *stubOrderUseCases) CreateOrder(_ context.Context, request *dto.CreateOrderRequestDTO) (*dto.OrderResponseDTO, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, *request)
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &dto.OrderResponseDTO{ID: uint(len(s.requests)), CustomerID: request.CustomerID, ExternalReference: request.ExternalReference}, nil
+}
+
+func (s *stubOrderUseCases) calls() []dto.CreateOrderRequestDTO {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]dto.CreateOrderRequestDTO(nil), s.requests...)
+}
+
+func orderMessage(t *testing.T, offset int64, key string) Message {
+	t.Helper()
+	value, err := json.Marshal(dto.CreateOrderRequestDTO{
+		CustomerID: 7,
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 10},
+		},
+	})
+	require.NoError(t, err)
+	return Message{Topic: "orders", Partition: 3, Offset: offset, Key: []byte(key), Value: value}
+}
+
+type consumerFixture struct {
+	reader   *fakeReader
+	writer   *fakeWriter
+	useCases *stubOrderUseCases
+	registry *metrics.Registry
+	consumer *Consumer
+}
+
+func newConsumerFixture(messages ...Message) *consumerFixture {
+	f := &consumerFixture{
+		reader:   &fakeReader{messages: messages},
+		writer:   &fakeWriter{},
+		useCases: &stubOrderUseCases{},
+		registry: metrics.NewRegistry(),
+	}
+	f.consumer = NewConsumer(f.reader, f.writer, f.useCases, ConsumerConfig{
+		DeadLetterTopic: "orders.dlq",
+		MaxAttempts:     3,
+	}, f.registry, logger.New("test"))
+	return f
+}
+
+// consume runs the consumer until it committed commits offsets, then stops it
+func (f *consumerFixture) consume(t *testing.T, commits int) error {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- f.consumer.Run(ctx) }()
+
+	require.Eventually(t, func() bool { return len(f.reader.commits()) >= commits }, time.Second, time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(time.Second):
+		t.Fatal("consumer did not stop after cancellation")
+		return nil
+	}
+}
+
+func (f *consumerFixture) counter(name string) int64 {
+	return f.registry.Counter(name).Value()
+}
+
+func TestConsumer_Run_CreatesOrderAndCommits(t *testing.T) {
+	// Given
+	f := newConsumerFixture(orderMessage(t, 10, "mp-1001"), orderMessage(t, 11, "mp-1002"))
+
+	// When
+	err := f.consume(t, 2)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []int64{10, 11}, f.reader.commits())
+	calls := f.useCases.calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, "mp-1001", calls[0].ExternalReference)
+	assert.Equal(t, "mp-1002", calls[1].ExternalReference)
+	assert.Equal(t, int64(2), f.counter(ConsumedMetric))
+	assert.Empty(t, f.writer.written())
+}
+
+func TestConsumer_Run_KeepsReferenceOfTheMessage(t *testing.T) {
+	message := orderMessage(t, 1, "mp-1001")
+	message.Value = []byte(`{"customer_id":7,"external_reference":"PO-77","items":[{"product_id":1,"product_sku":"SKU-001","product_name":"Product 1","quantity":1,"unit_price":5}]}`)
+	f := newConsumerFixture(message)
+
+	require.NoError(t, f.consume(t, 1))
+
+	assert.Equal(t, "PO-77", f.useCases.calls()[0].ExternalReference)
+}
+
+func TestConsumer_Run_HashesLongKeys(t *testing.T) {
+	f := newConsumerFixture(orderMessage(t, 1, strings.Repeat("k", 150)))
+
+	require.NoError(t, f.consume(t, 1))
+
+	reference := f.useCases.calls()[0].ExternalReference
+	assert.Len(t, reference, 64)
+	assert.Equal(t, externalReference([]byte(strings.Repeat("k", 150))), reference)
+}
+
+func TestConsumer_Run_RedeliveryIsNotDuplicated(t *testing.T) {
+	// Given the order of the message was created before its offset was committed
+	f := newConsumerFixture(orderMessage(t, 5, "mp-1001"))
+	f.useCases.errs = []error{domainErrors.ErrDuplicateExternalReference}
+
+	// When
+	err := f.consume(t, 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []int64{5}, f.reader.commits())
+	assert.Len(t, f.useCases.calls(), 1)
+	assert.Empty(t, f.writer.written())
+}
+
+func TestConsumer_Run_RetriesTransientErrors(t *testing.T) {
+	f := newConsumerFixture(orderMessage(t, 5, "mp-1001"))
+	f.useCases.errs = []error{domainErrors.ErrFailedToCreateOrder, errors.New("connection reset")}
+
+	require.NoError(t, f.consume(t, 1))
+
+	assert.Len(t, f.useCases.calls(), 3)
+	assert.Equal(t, int64(2), f.counter(FailedMetric))
+	assert.Equal(t, int64(1), f.counter(ConsumedMetric))
+	assert.Empty(t, f.writer.written())
+}
+
+func TestConsumer_Run_DeadLettersAfterMaxAttempts(t *testing.T) {
+	// Given
+	message := orderMessage(t, 42, "mp-1001")
+	f := newConsumerFixture(message)
+	f.useCases.errs = []error{domainErrors.ErrFailedToCreateOrder, domainErrors.ErrFailedToCreateOrder, domainErrors.ErrFailedToCreateOrder}
+
+	// When
+	err := f.consume(t, 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Len(t, f.useCases.calls(), 3)
+	assert.Equal(t, []int64{42}, f.reader.commits())
+
+	written := f.writer.written()
+	require.Len(t, written, 1)
+	assert.Equal(t, "orders.dlq", written[0].Topic)
+	assert.Equal(t, message.Key, written[0].Key)
+	assert.Equal(t, message.Value, written[0].Value)
+	headers := map[string]string{}
+	for _, header := range written[0].Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	assert.Equal(t, "3", headers[HeaderAttempts])
+	assert.Equal(t, "orders", headers[HeaderOriginalTopic])
+	assert.Equal(t, "3", headers[HeaderOriginalPartition])
+	assert.Equal(t, "42", headers[HeaderOriginalOffset])
+	assert.NotEmpty(t, headers[HeaderError])
+
+	assert.Equal(t, int64(3), f.counter(FailedMetric))
+	assert.Equal(t, int64(1), f.counter(DeadLetteredMetric))
+	assert.Zero(t, f.counter(ConsumedMetric))
+}
+
+func TestConsumer_Run_DeadLettersInvalidMessagesRightAway(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		errs  []error
+	}{
+		{name: "malformed JSON", value: `{"customer_id":`},
+		{name: "failed validation", value: `{"items":[]}`},
+		{name: "rejected by the domain", errs: []error{domainErrors.ErrOrderItemLimitExceeded}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := orderMessage(t, 1, "mp-1001")
+			if tt.value != "" {
+				message.Value = []byte(tt.value)
+			}
+			f := newConsumerFixture(message, orderMessage(t, 2, "mp-1002"))
+			f.useCases.errs = tt.errs
+
+			require.NoError(t, f.consume(t, 2))
+
+			assert.Equal(t, []int64{1, 2}, f.reader.commits())
+			require.Len(t, f.writer.written(), 1)
+			assert.Equal(t, int64(1), f.counter(DeadLetteredMetric))
+			assert.Equal(t, int64(1), f.counter(ConsumedMetric))
+		})
+	}
+}
+
+func TestConsumer_Run_DoesNotCommitWhenDeadLetteringFails(t *testing.T) {
+	f := newConsumerFixture(orderMessage(t, 1, "mp-1001"))
+	f.useCases.errs = []error{domainErrors.ErrOrderItemLimitExceeded}
+	f.writer.err = errors.New("broker unavailable")
+
+	err := f.consumer.Run(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broker unavailable")
+	assert.Empty(t, f.reader.commits())
+}
+
+func TestConsumer_Run_ReturnsCommitErrors(t *testing.T) {
+	f := newConsumerFixture(orderMessage(t, 1, "mp-1001"))
+	f.reader.commitErr = errors.New("rebalance in progress")
+
+	err := f.consumer.Run(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rebalance in progress")
+}
+
+func TestConsumer_Run_StopsRetryingOnCancel(t *testing.T) {
+	// Given a message that keeps failing with a long backoff
+	f := newConsumerFixture(orderMessage(t, 1, "mp-1001"))
+	f.consumer.config.RetryBackoff = time.Hour
+	f.useCases.errs = []error{domainErrors.ErrFailedToCreateOrder}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// When
+	done := make(chan error, 1)
+	go func() { done <- f.consumer.Run(ctx) }()
+	require.Eventually(t, func() bool { return len(f.useCases.calls()) == 1 }, time.Second, time.Millisecond)
+	cancel()
+
+	// Then the message is left uncommitted for redelivery
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("consumer did not stop after cancellation")
+	}
+	assert.Empty(t, f.reader.commits())
+	assert.Empty(t, f.writer.written())
+}
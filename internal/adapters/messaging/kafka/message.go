@@ -0,0 +1,33 @@
+package kafka
+
+import "context"
+
+// Header is a key value pair attached to a message
+type Header struct {
+	Key   string
+	Value []byte
+}
+
+// Message is a record read from or written to a topic
+type Message struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   []Header
+}
+
+// Reader fetches messages of a consumer group. Offsets only move forward when messages are committed,
+// so a message fetched but not committed is delivered again after a restart or rebalance.
+type Reader interface {
+	FetchMessage(ctx context.Context) (Message, error)
+	CommitMessages(ctx context.Context, messages ...Message) error
+	Close() error
+}
+
+// Writer publishes messages, each to the topic set on it
+type Writer interface {
+	WriteMessages(ctx context.Context, messages ...Message) error
+	Close() error
+}
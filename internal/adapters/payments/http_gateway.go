@@ -0,0 +1,122 @@
+package payments
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"orders-service/internal/application/ports"
+	"orders-service/pkg/httpclient"
+)
+
+// authorizeRequest is the body of POST /authorizations
+type authorizeRequest struct {
+	OrderID  uint    `json:"order_id"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// authorizeResponse is the answer to POST /authorizations
+type authorizeResponse struct {
+	ID string `json:"id"`
+}
+
+// captureRequest is the body of POST /authorizations/{id}/capture
+type captureRequest struct {
+	Amount float64 `json:"amount"`
+}
+
+// HTTPPaymentGateway authorizes and settles payments with the payment service:
+//
+//	POST {base_url}/authorizations
+//	POST {base_url}/authorizations/{id}/capture
+//	POST {base_url}/authorizations/{id}/void
+//
+// A 402 Payment Required answer is a declined payment.
+type HTTPPaymentGateway struct {
+	baseURL string
+	client  *httpclient.Client
+}
+
+// NewHTTPPaymentGateway creates a payment gateway client sending its requests through client. Captures
+// and voids carry an Idempotency-Key, so client retries them, authorizations are never retried.
+func NewHTTPPaymentGateway(baseURL string, client *httpclient.Client) ports.PaymentGateway {
+	return &HTTPPaymentGateway{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  client,
+	}
+}
+
+// Authorize implements ports.PaymentGateway
+func (g *HTTPPaymentGateway) Authorize(ctx context.Context, orderID uint, amount float64, currency string) (string, error) {
+	var authorization authorizeResponse
+	err := g.post(ctx, "/authorizations", "", authorizeRequest{OrderID: orderID, Amount: amount, Currency: currency}, &authorization)
+	if err != nil {
+		return "", fmt.Errorf("authorize payment: %w", err)
+	}
+	return authorization.ID, nil
+}
+
+// Capture implements ports.PaymentGateway
+func (g *HTTPPaymentGateway) Capture(ctx context.Context, authorizationID string, amount float64) error {
+	path := "/authorizations/" + url.PathEscape(authorizationID) + "/capture"
+	if err := g.post(ctx, path, "capture-"+authorizationID, captureRequest{Amount: amount}, nil); err != nil {
+		return fmt.Errorf("capture payment: %w", err)
+	}
+	return nil
+}
+
+// Void implements ports.PaymentGateway
+func (g *HTTPPaymentGateway) Void(ctx context.Context, authorizationID string) error {
+	path := "/authorizations/" + url.PathEscape(authorizationID) + "/void"
+	if err := g.post(ctx, path, "void-"+authorizationID, nil, nil); err != nil {
+		return fmt.Errorf("void payment: %w", err)
+	}
+	return nil
+}
+
+// post sends body as JSON to path and decodes the answer into out unless it is nil. A non-empty
+// idempotencyKey lets the client retry the request.
+func (g *HTTPPaymentGateway) post(ctx context.Context, path, idempotencyKey string, body, out any) error {
+	payload := []byte("{}")
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusPaymentRequired {
+		return ports.ErrPaymentDeclined
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/pkg/httpclient"
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient builds a payment gateway client with fast retries
+func newTestClient() *httpclient.Client {
+	config := httpclient.DefaultConfig()
+	config.Timeout = time.Second
+	config.BaseDelay = time.Millisecond
+	config.MaxDelay = time.Millisecond
+	return httpclient.New("payments", config, metrics.NewRegistry(), logger.New("test"))
+}
+
+func TestHTTPPaymentGateway_Authorize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/authorizations", r.URL.Path)
+		assert.Empty(t, r.Header.Get("Idempotency-Key"))
+		var body authorizeRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, authorizeRequest{OrderID: 7, Amount: 19.98, Currency: "EUR"}, body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"auth-1"}`))
+	}))
+	defer server.Close()
+
+	gateway := NewHTTPPaymentGateway(server.URL+"/", newTestClient())
+
+	authorizationID, err := gateway.Authorize(context.Background(), 7, 19.98, "EUR")
+
+	require.NoError(t, err)
+	assert.Equal(t, "auth-1", authorizationID)
+}
+
+func TestHTTPPaymentGateway_Authorize_Declined(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+	}))
+	defer server.Close()
+
+	gateway := NewHTTPPaymentGateway(server.URL, newTestClient())
+
+	_, err := gateway.Authorize(context.Background(), 7, 19.98, "EUR")
+
+	assert.ErrorIs(t, err, ports.ErrPaymentDeclined)
+}
+
+func TestHTTPPaymentGateway_Authorize_IsNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	gateway := NewHTTPPaymentGateway(server.URL, newTestClient())
+
+	_, err := gateway.Authorize(context.Background(), 7, 19.98, "EUR")
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ports.ErrPaymentDeclined)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestHTTPPaymentGateway_Capture_RetriesUnavailableGateway(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/authorizations/auth-1/capture", r.URL.Path)
+		assert.Equal(t, "capture-auth-1", r.Header.Get("Idempotency-Key"))
+		var body captureRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, 19.98, body.Amount)
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	gateway := NewHTTPPaymentGateway(server.URL, newTestClient())
+
+	err := gateway.Capture(context.Background(), "auth-1", 19.98)
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestHTTPPaymentGateway_Void(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/authorizations/auth-1/void", r.URL.Path)
+		assert.Equal(t, "void-auth-1", r.Header.Get("Idempotency-Key"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	gateway := NewHTTPPaymentGateway(server.URL, newTestClient())
+
+	assert.NoError(t, gateway.Void(context.Background(), "auth-1"))
+}
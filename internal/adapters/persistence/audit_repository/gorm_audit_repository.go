@@ -0,0 +1,118 @@
+package audit_repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"orders-service/internal/adapters/persistence/transaction"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+
+	"gorm.io/gorm"
+)
+
+// AuditEntryModel represents the database model for audit log entries
+type AuditEntryModel struct {
+	ID        uint      `gorm:"primarykey"`
+	OrderID   uint      `gorm:"not null;index:idx_audit_log_order_created,priority:1"`
+	Actor     string    `gorm:"size:255;not null"`
+	Action    string    `gorm:"size:64;not null"`
+	Before    *string   `gorm:"type:jsonb"`
+	After     *string   `gorm:"type:jsonb"`
+	CreatedAt time.Time `gorm:"not null;index:idx_audit_log_order_created,priority:2"`
+}
+
+// TableName specifies the table name for GORM
+func (AuditEntryModel) TableName() string {
+	return "audit_log"
+}
+
+// GormAuditRepository implements the AuditRepository interface using GORM
+type GormAuditRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAuditRepository creates a new GORM audit repository
+func NewGormAuditRepository(db *gorm.DB) ports.AuditRepository {
+	return &GormAuditRepository{db: db}
+}
+
+// Create implements ports.AuditRepository
+func (r *GormAuditRepository) Create(ctx context.Context, entry *entities.AuditEntry) error {
+	model := toModel(entry)
+	if err := transaction.Conn(ctx, r.db).Create(model).Error; err != nil {
+		return err
+	}
+
+	entry.ID = model.ID
+	return nil
+}
+
+// ListByOrderID implements ports.AuditRepository
+func (r *GormAuditRepository) ListByOrderID(ctx context.Context, orderID uint, limit, offset int) ([]*entities.AuditEntry, error) {
+	var models []AuditEntryModel
+
+	err := transaction.Conn(ctx, r.db).
+		Where("order_id = ?", orderID).
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*entities.AuditEntry, 0, len(models))
+	for i := range models {
+		entries = append(entries, toEntity(&models[i]))
+	}
+	return entries, nil
+}
+
+// CountByOrderID implements ports.AuditRepository
+func (r *GormAuditRepository) CountByOrderID(ctx context.Context, orderID uint) (int64, error) {
+	var count int64
+	err := transaction.Conn(ctx, r.db).Model(&AuditEntryModel{}).Where("order_id = ?", orderID).Count(&count).Error
+	return count, err
+}
+
+func toModel(entry *entities.AuditEntry) *AuditEntryModel {
+	return &AuditEntryModel{
+		ID:        entry.ID,
+		OrderID:   entry.OrderID,
+		Actor:     entry.Actor,
+		Action:    string(entry.Action),
+		Before:    rawToString(entry.Before),
+		After:     rawToString(entry.After),
+		CreatedAt: entry.CreatedAt,
+	}
+}
+
+func toEntity(model *AuditEntryModel) *entities.AuditEntry {
+	return &entities.AuditEntry{
+		ID:        model.ID,
+		OrderID:   model.OrderID,
+		Actor:     model.Actor,
+		Action:    entities.AuditAction(model.Action),
+		Before:    stringToRaw(model.Before),
+		After:     stringToRaw(model.After),
+		CreatedAt: model.CreatedAt,
+	}
+}
+
+// rawToString stores an empty snapshot as NULL
+func rawToString(raw json.RawMessage) *string {
+	if len(raw) == 0 {
+		return nil
+	}
+	value := string(raw)
+	return &value
+}
+
+func stringToRaw(value *string) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+	return json.RawMessage(*value)
+}
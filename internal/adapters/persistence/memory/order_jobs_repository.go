@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+)
+
+// OrderJobRepository implements ports.OrderJobRepository in memory, for tests and demos
+type OrderJobRepository struct {
+	mu   sync.RWMutex
+	jobs map[string]*entities.OrderJob
+}
+
+// NewOrderJobRepository creates an empty in-memory order job repository
+func NewOrderJobRepository() ports.OrderJobRepository {
+	return &OrderJobRepository{jobs: make(map[string]*entities.OrderJob)}
+}
+
+// Create implements ports.OrderJobRepository
+func (r *OrderJobRepository) Create(ctx context.Context, job *entities.OrderJob) (*entities.OrderJob, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.jobs[job.ID] = job.Clone()
+	return job.Clone(), nil
+}
+
+// Update implements ports.OrderJobRepository. Only the outcome changes, like in the GORM repository.
+func (r *OrderJobRepository) Update(ctx context.Context, job *entities.OrderJob) (*entities.OrderJob, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.jobs[job.ID]
+	if !ok {
+		return nil, domainErrors.ErrOrderJobNotFound
+	}
+	if !current.IsPending() {
+		return nil, domainErrors.ErrOrderJobNotPending
+	}
+
+	update := job.Clone()
+	stored := current.Clone()
+	stored.Status = update.Status
+	stored.OrderID = update.OrderID
+	stored.OrderPublicID = update.OrderPublicID
+	stored.ErrorCode = update.ErrorCode
+	stored.ErrorMessage = update.ErrorMessage
+	stored.CompletedAt = update.CompletedAt
+
+	r.jobs[stored.ID] = stored
+	return stored.Clone(), nil
+}
+
+// GetByID implements ports.OrderJobRepository
+func (r *OrderJobRepository) GetByID(ctx context.Context, id string) (*entities.OrderJob, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, domainErrors.ErrOrderJobNotFound
+	}
+	return job.Clone(), nil
+}
+
+// FindPending implements ports.OrderJobRepository
+func (r *OrderJobRepository) FindPending(ctx context.Context, before time.Time, limit int) ([]*entities.OrderJob, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pending := make([]*entities.OrderJob, 0)
+	for _, job := range r.jobs {
+		if job.IsPending() && !job.CreatedAt.After(before) {
+			pending = append(pending, job.Clone())
+		}
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		if !pending[i].CreatedAt.Equal(pending[j].CreatedAt) {
+			return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+		}
+		return pending[i].ID < pending[j].ID
+	})
+	if limit > 0 && len(pending) > limit {
+		pending = pending[:limit]
+	}
+	return pending, nil
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"orders-service/internal/adapters/persistence/repositorytest"
+	"orders-service/internal/application/ports"
+)
+
+func TestOrderJobRepository_Conformance(t *testing.T) {
+	repositorytest.RunOrderJobRepositoryTests(t, func(t *testing.T) ports.OrderJobRepository {
+		return NewOrderJobRepository()
+	})
+}
@@ -0,0 +1,575 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+)
+
+// OrderRepository implements ports.OrderRepository in memory, for tests and demos.
+// It follows the ordering, pagination and soft delete semantics of the GORM repository.
+type OrderRepository struct {
+	mu         sync.RWMutex
+	orders     map[uint]*entities.Order
+	nextID     uint
+	nextItemID uint
+	sequences  map[string]uint64
+
+	locksMu       sync.Mutex
+	customerLocks map[uint]*sync.Mutex
+}
+
+// NewOrderRepository creates an empty in-memory order repository
+func NewOrderRepository() ports.OrderRepository {
+	return &OrderRepository{
+		orders:        make(map[uint]*entities.Order),
+		sequences:     make(map[string]uint64),
+		customerLocks: make(map[uint]*sync.Mutex),
+	}
+}
+
+// Create implements ports.OrderRepository
+func (r *OrderRepository) Create(ctx context.Context, order *entities.Order) (*entities.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if order.ExternalReference != "" && r.referenceTaken(order.CustomerID, order.ExternalReference) {
+		return nil, domainErrors.ErrDuplicateExternalReference
+	}
+	if order.OrderNumber != "" && r.numberTaken(order.OrderNumber) {
+		return nil, domainErrors.ErrDuplicateOrderNumber
+	}
+	if order.PublicID != "" && r.publicIDTaken(order.PublicID) {
+		return nil, domainErrors.ErrOrderAlreadyExists
+	}
+
+	stored := order.Clone()
+	r.nextID++
+	stored.ID = r.nextID
+	now := time.Now()
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = now
+	}
+	if stored.UpdatedAt.IsZero() {
+		stored.UpdatedAt = now
+	}
+	r.assignItemIDs(stored)
+
+	r.orders[stored.ID] = stored
+	return stored.Clone(), nil
+}
+
+// GetByID implements ports.OrderRepository
+func (r *OrderRepository) GetByID(ctx context.Context, id uint) (*entities.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	order, ok := r.live(id)
+	if !ok {
+		return nil, domainErrors.ErrOrderNotFound
+	}
+	return order.Clone(), nil
+}
+
+// GetByIDIncludingDeleted implements ports.OrderRepository
+func (r *OrderRepository) GetByIDIncludingDeleted(ctx context.Context, id uint) (*entities.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	order, ok := r.orders[id]
+	if !ok {
+		return nil, domainErrors.ErrOrderNotFound
+	}
+	return order.Clone(), nil
+}
+
+// GetByIDForUpdate implements ports.OrderRepository. There are no row locks in memory,
+// callers needing mutual exclusion use WithCustomerLock.
+func (r *OrderRepository) GetByIDForUpdate(ctx context.Context, id uint) (*entities.Order, error) {
+	return r.GetByID(ctx, id)
+}
+
+// GetByPublicID implements ports.OrderRepository
+func (r *OrderRepository) GetByPublicID(ctx context.Context, publicID string) (*entities.Order, error) {
+	orders := r.filter(func(order *entities.Order) bool {
+		return order.PublicID == publicID
+	})
+	if len(orders) == 0 {
+		return nil, domainErrors.ErrOrderNotFound
+	}
+	return orders[0], nil
+}
+
+// GetByExternalReference implements ports.OrderRepository
+func (r *OrderRepository) GetByExternalReference(ctx context.Context, customerID uint, reference string) (*entities.Order, error) {
+	orders := r.filter(func(order *entities.Order) bool {
+		return order.CustomerID == customerID && order.ExternalReference == reference
+	})
+	if len(orders) == 0 {
+		return nil, domainErrors.ErrOrderNotFound
+	}
+	return orders[0], nil
+}
+
+// GetByOrderNumber implements ports.OrderRepository
+func (r *OrderRepository) GetByOrderNumber(ctx context.Context, number string) (*entities.Order, error) {
+	orders := r.filter(func(order *entities.Order) bool {
+		return order.OrderNumber == number
+	})
+	if len(orders) == 0 {
+		return nil, domainErrors.ErrOrderNotFound
+	}
+	return orders[0], nil
+}
+
+// NextOrderNumberSequence implements ports.OrderRepository
+func (r *OrderRepository) NextOrderNumberSequence(ctx context.Context, scope string) (uint64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sequences[scope]++
+	return r.sequences[scope], nil
+}
+
+// Update implements ports.OrderRepository. Like the GORM repository it keeps the stored
+// identifiers, tags and creation time.
+func (r *OrderRepository) Update(ctx context.Context, order *entities.Order) (*entities.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.live(order.ID)
+	if !ok {
+		return nil, domainErrors.ErrOrderNotFound
+	}
+
+	stored := order.Clone()
+	stored.ExternalReference = current.ExternalReference
+	stored.Tags = slices.Clone(current.Tags)
+	stored.OrderNumber = current.OrderNumber
+	stored.PublicID = current.PublicID
+	stored.CreatedAt = current.CreatedAt
+	stored.UpdatedAt = time.Now()
+	r.assignItemIDs(stored)
+
+	r.orders[stored.ID] = stored
+	return stored.Clone(), nil
+}
+
+// Delete implements ports.OrderRepository
+func (r *OrderRepository) Delete(ctx context.Context, id uint) error {
+	if err := ctx.Err(); err != nil {
+		return domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, ok := r.live(id)
+	if !ok {
+		return domainErrors.ErrOrderNotFound
+	}
+	deletedAt := time.Now()
+	order.DeletedAt = &deletedAt
+	return nil
+}
+
+// Restore implements ports.OrderRepository
+func (r *OrderRepository) Restore(ctx context.Context, id uint) (*entities.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, ok := r.orders[id]
+	if !ok || order.DeletedAt == nil {
+		return nil, domainErrors.ErrOrderNotFound
+	}
+	order.DeletedAt = nil
+	order.UpdatedAt = time.Now()
+	return order.Clone(), nil
+}
+
+// ListDeleted implements ports.OrderRepository
+func (r *OrderRepository) ListDeleted(ctx context.Context, limit, offset int) ([]*entities.Order, error) {
+	r.mu.RLock()
+	orders := make([]*entities.Order, 0)
+	for _, order := range r.orders {
+		if order.DeletedAt != nil {
+			orders = append(orders, order.Clone())
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(orders, func(i, j int) bool {
+		if orders[i].DeletedAt.Equal(*orders[j].DeletedAt) {
+			return orders[i].ID > orders[j].ID
+		}
+		return orders[i].DeletedAt.After(*orders[j].DeletedAt)
+	})
+	return page(orders, limit, offset), nil
+}
+
+// CountDeleted implements ports.OrderRepository
+func (r *OrderRepository) CountDeleted(ctx context.Context) (int64, error) {
+	orders, err := r.ListDeleted(ctx, -1, 0)
+	return int64(len(orders)), err
+}
+
+// List implements ports.OrderRepository
+func (r *OrderRepository) List(ctx context.Context, limit, offset int) ([]*entities.Order, error) {
+	return page(newestFirst(r.filter(nil)), limit, offset), nil
+}
+
+// GetByCustomerID implements ports.OrderRepository
+func (r *OrderRepository) GetByCustomerID(ctx context.Context, customerID uint, limit, offset int) ([]*entities.Order, error) {
+	orders := r.filter(func(order *entities.Order) bool { return order.CustomerID == customerID })
+	return page(newestFirst(orders), limit, offset), nil
+}
+
+// GetByStatus implements ports.OrderRepository
+func (r *OrderRepository) GetByStatus(ctx context.Context, status entities.OrderStatus, limit, offset int) ([]*entities.Order, error) {
+	orders := r.filter(func(order *entities.Order) bool { return order.Status == status })
+	return page(newestFirst(orders), limit, offset), nil
+}
+
+// ListByFilterOrderedByPriority implements ports.OrderRepository
+func (r *OrderRepository) ListByFilterOrderedByPriority(ctx context.Context, filter ports.OrderFilter, limit, offset int) ([]*entities.Order, error) {
+	orders := r.filter(matches(filter))
+	sort.Slice(orders, func(i, j int) bool {
+		if rankI, rankJ := orders[i].Priority.Rank(), orders[j].Priority.Rank(); rankI != rankJ {
+			return rankI > rankJ
+		}
+		if orders[i].CreatedAt.Equal(orders[j].CreatedAt) {
+			return orders[i].ID < orders[j].ID
+		}
+		return orders[i].CreatedAt.Before(orders[j].CreatedAt)
+	})
+	return page(orders, limit, offset), nil
+}
+
+// ListRecentByItemSet implements ports.OrderRepository
+func (r *OrderRepository) ListRecentByItemSet(ctx context.Context, customerID uint, itemSetHash string, since time.Time) ([]*entities.Order, error) {
+	orders := r.filter(func(order *entities.Order) bool {
+		return order.CustomerID == customerID && !order.CreatedAt.Before(since) && order.ItemSetHash() == itemSetHash
+	})
+	return newestFirst(orders), nil
+}
+
+// GetByCustomerIDAndStatus implements ports.OrderRepository
+func (r *OrderRepository) GetByCustomerIDAndStatus(ctx context.Context, customerID uint, status entities.OrderStatus, limit, offset int) ([]*entities.Order, error) {
+	orders := r.filter(func(order *entities.Order) bool {
+		return order.CustomerID == customerID && order.Status == status
+	})
+	return page(newestFirst(orders), limit, offset), nil
+}
+
+// ListByDateRange implements ports.OrderRepository
+func (r *OrderRepository) ListByDateRange(ctx context.Context, from, to time.Time, limit, offset int) ([]*entities.Order, error) {
+	orders := r.filter(matches(ports.OrderFilter{CreatedFrom: from, CreatedBefore: to}))
+	return page(newestFirst(orders), limit, offset), nil
+}
+
+// FindExpiredPending implements ports.OrderRepository
+func (r *OrderRepository) FindExpiredPending(ctx context.Context, before time.Time, limit int) ([]*entities.Order, error) {
+	orders := r.filter(func(order *entities.Order) bool {
+		return order.Status == entities.OrderStatusPending && order.ExpiresAt != nil && !order.ExpiresAt.After(before)
+	})
+	sort.SliceStable(orders, func(i, j int) bool { return orders[i].ExpiresAt.Before(*orders[j].ExpiresAt) })
+	return page(orders, limit, 0), nil
+}
+
+// Count implements ports.OrderRepository
+func (r *OrderRepository) Count(ctx context.Context) (int64, error) {
+	return int64(len(r.filter(nil))), nil
+}
+
+// CountByCustomerID implements ports.OrderRepository
+func (r *OrderRepository) CountByCustomerID(ctx context.Context, customerID uint) (int64, error) {
+	return int64(len(r.filter(func(order *entities.Order) bool { return order.CustomerID == customerID }))), nil
+}
+
+// CountByStatus implements ports.OrderRepository
+func (r *OrderRepository) CountByStatus(ctx context.Context, status entities.OrderStatus) (int64, error) {
+	return int64(len(r.filter(func(order *entities.Order) bool { return order.Status == status }))), nil
+}
+
+// CountByFilter implements ports.OrderRepository
+func (r *OrderRepository) CountByFilter(ctx context.Context, filter ports.OrderFilter) (int64, error) {
+	return int64(len(r.filter(matches(filter)))), nil
+}
+
+// CountByCustomerIDAndStatus implements ports.OrderRepository
+func (r *OrderRepository) CountByCustomerIDAndStatus(ctx context.Context, customerID uint, status entities.OrderStatus) (int64, error) {
+	return int64(len(r.filter(func(order *entities.Order) bool {
+		return order.CustomerID == customerID && order.Status == status
+	}))), nil
+}
+
+// heldCustomerLocksKey carries the customers locked by the calling goroutine, so nested calls do not deadlock
+type heldCustomerLocksKey struct{}
+
+// WithCustomerLock implements ports.OrderRepository. Writes are applied as they happen,
+// fn returning an error does not roll them back.
+func (r *OrderRepository) WithCustomerLock(ctx context.Context, customerID uint, fn func(ctx context.Context) error) error {
+	held, _ := ctx.Value(heldCustomerLocksKey{}).(map[uint]bool)
+	if held[customerID] {
+		return fn(ctx)
+	}
+
+	r.locksMu.Lock()
+	lock, ok := r.customerLocks[customerID]
+	if !ok {
+		lock = &sync.Mutex{}
+		r.customerLocks[customerID] = lock
+	}
+	r.locksMu.Unlock()
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	locked := make(map[uint]bool, len(held)+1)
+	for id := range held {
+		locked[id] = true
+	}
+	locked[customerID] = true
+	return fn(context.WithValue(ctx, heldCustomerLocksKey{}, locked))
+}
+
+// ListByFilter implements ports.OrderRepository, the page and the total come from one locked read
+func (r *OrderRepository) ListByFilter(ctx context.Context, filter ports.OrderFilter, limit, offset int) ([]*entities.Order, int64, error) {
+	orders := r.filter(matches(filter))
+	return page(newestFirst(orders), limit, offset), int64(len(orders)), nil
+}
+
+// StreamByFilter implements ports.OrderRepository, visiting orders by ascending ID like GORM's batches
+func (r *OrderRepository) StreamByFilter(ctx context.Context, filter ports.OrderFilter, batchSize int, fn func(order *entities.Order) error) error {
+	orders := r.filter(matches(filter))
+	sort.Slice(orders, func(i, j int) bool { return orders[i].ID < orders[j].ID })
+
+	for _, order := range orders {
+		if err := ctx.Err(); err != nil {
+			return domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+		}
+		if err := fn(order); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CountItemsByFilter implements ports.OrderRepository
+func (r *OrderRepository) CountItemsByFilter(ctx context.Context, filter ports.OrderFilter) (int64, error) {
+	var count int64
+	for _, order := range r.filter(matches(filter)) {
+		if len(order.Items) == 0 {
+			count++
+		} else {
+			count += int64(len(order.Items))
+		}
+	}
+	return count, nil
+}
+
+// MaxUpdatedAt implements ports.OrderRepository
+func (r *OrderRepository) MaxUpdatedAt(ctx context.Context, filter ports.OrderFilter) (time.Time, error) {
+	if err := ctx.Err(); err != nil {
+		return time.Time{}, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	filter.Status = ""
+	filter.Backordered = false
+	keep := matches(filter)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest time.Time
+	for _, order := range r.orders {
+		if !keep(order) {
+			continue
+		}
+		if order.UpdatedAt.After(latest) {
+			latest = order.UpdatedAt
+		}
+		if order.DeletedAt != nil && order.DeletedAt.After(latest) {
+			latest = *order.DeletedAt
+		}
+	}
+	return latest, nil
+}
+
+// AggregateByStatus implements ports.OrderRepository
+func (r *OrderRepository) AggregateByStatus(ctx context.Context, filter ports.OrderFilter) ([]ports.StatusAggregate, error) {
+	byStatus := make(map[entities.OrderStatus]*ports.StatusAggregate)
+	for _, order := range r.filter(matches(filter)) {
+		aggregate, ok := byStatus[order.Status]
+		if !ok {
+			aggregate = &ports.StatusAggregate{Status: order.Status, FirstCreatedAt: order.CreatedAt, LastCreatedAt: order.CreatedAt}
+			byStatus[order.Status] = aggregate
+		}
+		aggregate.Orders++
+		aggregate.Revenue += order.TotalAmount
+		if order.CreatedAt.Before(aggregate.FirstCreatedAt) {
+			aggregate.FirstCreatedAt = order.CreatedAt
+		}
+		if order.CreatedAt.After(aggregate.LastCreatedAt) {
+			aggregate.LastCreatedAt = order.CreatedAt
+		}
+	}
+
+	aggregates := make([]ports.StatusAggregate, 0, len(byStatus))
+	for _, aggregate := range byStatus {
+		aggregates = append(aggregates, *aggregate)
+	}
+	sort.Slice(aggregates, func(i, j int) bool { return aggregates[i].Status < aggregates[j].Status })
+	return aggregates, nil
+}
+
+// AggregateByDay implements ports.OrderRepository, days are UTC calendar days
+func (r *OrderRepository) AggregateByDay(ctx context.Context, filter ports.OrderFilter) ([]ports.DailyAggregate, error) {
+	byDay := make(map[time.Time]*ports.DailyAggregate)
+	for _, order := range r.filter(matches(filter)) {
+		created := order.CreatedAt.UTC()
+		day := time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, time.UTC)
+		aggregate, ok := byDay[day]
+		if !ok {
+			aggregate = &ports.DailyAggregate{Day: day}
+			byDay[day] = aggregate
+		}
+		aggregate.Orders++
+		aggregate.Revenue += order.TotalAmount
+	}
+
+	aggregates := make([]ports.DailyAggregate, 0, len(byDay))
+	for _, aggregate := range byDay {
+		aggregates = append(aggregates, *aggregate)
+	}
+	sort.Slice(aggregates, func(i, j int) bool { return aggregates[i].Day.Before(aggregates[j].Day) })
+	return aggregates, nil
+}
+
+// numberTaken reports whether any order, deleted ones included, has number. The caller holds r.mu.
+func (r *OrderRepository) numberTaken(number string) bool {
+	for _, order := range r.orders {
+		if order.OrderNumber == number {
+			return true
+		}
+	}
+	return false
+}
+
+// publicIDTaken reports whether any order, deleted ones included, has publicID. The caller holds r.mu.
+func (r *OrderRepository) publicIDTaken(publicID string) bool {
+	for _, order := range r.orders {
+		if order.PublicID == publicID {
+			return true
+		}
+	}
+	return false
+}
+
+// live returns the stored order unless it is missing or soft deleted. The caller holds r.mu.
+func (r *OrderRepository) live(id uint) (*entities.Order, bool) {
+	order, ok := r.orders[id]
+	if !ok || order.DeletedAt != nil {
+		return nil, false
+	}
+	return order, true
+}
+
+// referenceTaken reports whether a live order of the customer uses reference. The caller holds r.mu.
+func (r *OrderRepository) referenceTaken(customerID uint, reference string) bool {
+	for _, order := range r.orders {
+		if order.DeletedAt == nil && order.CustomerID == customerID && order.ExternalReference == reference {
+			return true
+		}
+	}
+	return false
+}
+
+// assignItemIDs gives new items an ID. The caller holds r.mu.
+func (r *OrderRepository) assignItemIDs(order *entities.Order) {
+	for i := range order.Items {
+		if order.Items[i].ID == 0 {
+			r.nextItemID++
+			order.Items[i].ID = r.nextItemID
+		}
+	}
+}
+
+// filter returns copies of the live orders accepted by keep, every live order when keep is nil
+func (r *OrderRepository) filter(keep func(order *entities.Order) bool) []*entities.Order {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orders := make([]*entities.Order, 0, len(r.orders))
+	for _, order := range r.orders {
+		if order.DeletedAt != nil || (keep != nil && !keep(order)) {
+			continue
+		}
+		orders = append(orders, order.Clone())
+	}
+	return orders
+}
+
+// matches returns a predicate applying the non-zero fields of filter
+func matches(filter ports.OrderFilter) func(order *entities.Order) bool {
+	return func(order *entities.Order) bool {
+		return (filter.CustomerID == 0 || order.CustomerID == filter.CustomerID) &&
+			(filter.Status == "" || order.Status == filter.Status) &&
+			(filter.CreatedFrom.IsZero() || !order.CreatedAt.Before(filter.CreatedFrom)) &&
+			(filter.CreatedBefore.IsZero() || order.CreatedAt.Before(filter.CreatedBefore)) &&
+			(!filter.Backordered || order.HasBackorderedItems())
+	}
+}
+
+// newestFirst sorts orders by creation time, newest first, breaking ties by ID
+func newestFirst(orders []*entities.Order) []*entities.Order {
+	sort.Slice(orders, func(i, j int) bool {
+		if orders[i].CreatedAt.Equal(orders[j].CreatedAt) {
+			return orders[i].ID > orders[j].ID
+		}
+		return orders[i].CreatedAt.After(orders[j].CreatedAt)
+	})
+	return orders
+}
+
+// page applies limit and offset, a negative limit returns every remaining order
+func page(orders []*entities.Order, limit, offset int) []*entities.Order {
+	if offset >= len(orders) {
+		return []*entities.Order{}
+	}
+	orders = orders[offset:]
+	if limit >= 0 && limit < len(orders) {
+		orders = orders[:limit]
+	}
+	return orders
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"orders-service/internal/adapters/persistence/repositorytest"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+)
+
+func TestOrderRepository_Conformance(t *testing.T) {
+	repositorytest.RunOrderRepositoryTests(t, func(t *testing.T) ports.OrderRepository {
+		return NewOrderRepository()
+	})
+}
+
+// newBenchmarkOrder returns an unsaved order of customerID with lines items
+func newBenchmarkOrder(b *testing.B, customerID uint, lines int) *entities.Order {
+	b.Helper()
+	order, err := entities.NewOrder(customerID)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 1; i <= lines; i++ {
+		if err := order.AddItem(uint(i), fmt.Sprintf("SKU-%03d", i), "Product", 2, 9.99); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return order
+}
+
+func BenchmarkOrderRepository_Create(b *testing.B) {
+	for _, lines := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("items=%d", lines), func(b *testing.B) {
+			repo := NewOrderRepository()
+			order := newBenchmarkOrder(b, 123, lines)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				clone := order.Clone()
+				clone.PublicID = entities.NewPublicID()
+				if _, err := repo.Create(context.Background(), clone); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkOrderRepository_GetByID(b *testing.B) {
+	for _, lines := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("items=%d", lines), func(b *testing.B) {
+			const seeded = 1000
+			repo := NewOrderRepository()
+			ids := make([]uint, 0, seeded)
+			for customerID := uint(1); customerID <= seeded; customerID++ {
+				created, err := repo.Create(context.Background(), newBenchmarkOrder(b, customerID, lines))
+				if err != nil {
+					b.Fatal(err)
+				}
+				ids = append(ids, created.ID)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.GetByID(context.Background(), ids[i%seeded]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
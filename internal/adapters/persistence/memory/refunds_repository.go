@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+)
+
+// RefundRepository implements ports.RefundRepository in memory, for tests and demos
+type RefundRepository struct {
+	mu      sync.RWMutex
+	refunds map[uint]*entities.Refund
+	nextID  uint
+}
+
+// NewRefundRepository creates an empty in-memory refund repository
+func NewRefundRepository() ports.RefundRepository {
+	return &RefundRepository{refunds: make(map[uint]*entities.Refund)}
+}
+
+// Create implements ports.RefundRepository
+func (r *RefundRepository) Create(ctx context.Context, refund *entities.Refund) (*entities.Refund, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := refund.Clone()
+	r.nextID++
+	stored.ID = r.nextID
+
+	r.refunds[stored.ID] = stored
+	return stored.Clone(), nil
+}
+
+// ListByOrderID implements ports.RefundRepository
+func (r *RefundRepository) ListByOrderID(ctx context.Context, orderID uint) ([]*entities.Refund, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// IDs grow with every create, so they order refunds oldest first
+	refunds := make([]*entities.Refund, 0)
+	for id := uint(1); id <= r.nextID; id++ {
+		if refund, ok := r.refunds[id]; ok && refund.OrderID == orderID {
+			refunds = append(refunds, refund.Clone())
+		}
+	}
+	return refunds, nil
+}
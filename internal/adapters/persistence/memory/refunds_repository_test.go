@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"orders-service/internal/adapters/persistence/repositorytest"
+	"orders-service/internal/application/ports"
+)
+
+func TestRefundRepository_Conformance(t *testing.T) {
+	repositorytest.RunRefundRepositoryTests(t, func(t *testing.T) ports.RefundRepository {
+		return NewRefundRepository()
+	})
+}
@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+)
+
+// ScheduledTransitionRepository implements ports.ScheduledTransitionRepository in memory, for tests and demos
+type ScheduledTransitionRepository struct {
+	mu          sync.RWMutex
+	transitions map[uint]*entities.ScheduledTransition
+	nextID      uint
+}
+
+// NewScheduledTransitionRepository creates an empty in-memory scheduled transition repository
+func NewScheduledTransitionRepository() ports.ScheduledTransitionRepository {
+	return &ScheduledTransitionRepository{transitions: make(map[uint]*entities.ScheduledTransition)}
+}
+
+// Create implements ports.ScheduledTransitionRepository
+func (r *ScheduledTransitionRepository) Create(ctx context.Context, transition *entities.ScheduledTransition) (*entities.ScheduledTransition, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := transition.Clone()
+	r.nextID++
+	stored.ID = r.nextID
+
+	r.transitions[stored.ID] = stored
+	return stored.Clone(), nil
+}
+
+// Update implements ports.ScheduledTransitionRepository. Only the outcome changes, like in the GORM repository.
+func (r *ScheduledTransitionRepository) Update(ctx context.Context, transition *entities.ScheduledTransition) (*entities.ScheduledTransition, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.transitions[transition.ID]
+	if !ok || current.OrderID != transition.OrderID {
+		return nil, domainErrors.ErrScheduledTransitionNotFound
+	}
+	if !current.IsPending() {
+		return nil, domainErrors.ErrScheduledTransitionNotPending
+	}
+
+	update := transition.Clone()
+	stored := current.Clone()
+	stored.Status = update.Status
+	stored.FailureCode = update.FailureCode
+	stored.FailureMessage = update.FailureMessage
+	stored.CompletedAt = update.CompletedAt
+
+	r.transitions[stored.ID] = stored
+	return stored.Clone(), nil
+}
+
+// ListByOrderID implements ports.ScheduledTransitionRepository
+func (r *ScheduledTransitionRepository) ListByOrderID(ctx context.Context, orderID uint) ([]*entities.ScheduledTransition, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// IDs grow with every create, so they order transitions oldest first
+	transitions := make([]*entities.ScheduledTransition, 0)
+	for id := uint(1); id <= r.nextID; id++ {
+		if transition, ok := r.transitions[id]; ok && transition.OrderID == orderID {
+			transitions = append(transitions, transition.Clone())
+		}
+	}
+	return transitions, nil
+}
+
+// FindDue implements ports.ScheduledTransitionRepository
+func (r *ScheduledTransitionRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.ScheduledTransition, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	due := make([]*entities.ScheduledTransition, 0)
+	for _, transition := range r.transitions {
+		if transition.IsDue(now) {
+			due = append(due, transition.Clone())
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].ExecuteAt.Equal(due[j].ExecuteAt) {
+			return due[i].ExecuteAt.Before(due[j].ExecuteAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
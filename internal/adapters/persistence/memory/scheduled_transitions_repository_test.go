@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"orders-service/internal/adapters/persistence/repositorytest"
+	"orders-service/internal/application/ports"
+)
+
+func TestScheduledTransitionRepository_Conformance(t *testing.T) {
+	repositorytest.RunScheduledTransitionRepositoryTests(t, func(t *testing.T) ports.ScheduledTransitionRepository {
+		return NewScheduledTransitionRepository()
+	})
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+)
+
+// ShipmentRepository implements ports.ShipmentRepository in memory, for tests and demos
+type ShipmentRepository struct {
+	mu        sync.RWMutex
+	shipments map[uint]*entities.Shipment
+	nextID    uint
+}
+
+// NewShipmentRepository creates an empty in-memory shipment repository
+func NewShipmentRepository() ports.ShipmentRepository {
+	return &ShipmentRepository{shipments: make(map[uint]*entities.Shipment)}
+}
+
+// Create implements ports.ShipmentRepository
+func (r *ShipmentRepository) Create(ctx context.Context, shipment *entities.Shipment) (*entities.Shipment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := shipment.Clone()
+	r.nextID++
+	stored.ID = r.nextID
+
+	r.shipments[stored.ID] = stored
+	return stored.Clone(), nil
+}
+
+// Update implements ports.ShipmentRepository. Only the delivery time changes, like in the GORM repository.
+func (r *ShipmentRepository) Update(ctx context.Context, shipment *entities.Shipment) (*entities.Shipment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.shipments[shipment.ID]
+	if !ok || current.OrderID != shipment.OrderID {
+		return nil, domainErrors.ErrShipmentNotFound
+	}
+
+	stored := current.Clone()
+	stored.DeliveredAt = shipment.Clone().DeliveredAt
+
+	r.shipments[stored.ID] = stored
+	return stored.Clone(), nil
+}
+
+// ListByOrderID implements ports.ShipmentRepository
+func (r *ShipmentRepository) ListByOrderID(ctx context.Context, orderID uint) ([]*entities.Shipment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// IDs grow with every create, so they order shipments oldest first
+	shipments := make([]*entities.Shipment, 0)
+	for id := uint(1); id <= r.nextID; id++ {
+		if shipment, ok := r.shipments[id]; ok && shipment.OrderID == orderID {
+			shipments = append(shipments, shipment.Clone())
+		}
+	}
+	return shipments, nil
+}
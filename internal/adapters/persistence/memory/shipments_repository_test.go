@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"orders-service/internal/adapters/persistence/repositorytest"
+	"orders-service/internal/application/ports"
+)
+
+func TestShipmentRepository_Conformance(t *testing.T) {
+	repositorytest.RunShipmentRepositoryTests(t, func(t *testing.T) ports.ShipmentRepository {
+		return NewShipmentRepository()
+	})
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+)
+
+// SnapshotRepository implements ports.SnapshotRepository in memory, for tests and demos
+type SnapshotRepository struct {
+	mu        sync.RWMutex
+	snapshots map[uint]*entities.OrderSnapshot
+	nextID    uint
+}
+
+// NewSnapshotRepository creates an empty in-memory snapshot repository
+func NewSnapshotRepository() ports.SnapshotRepository {
+	return &SnapshotRepository{snapshots: make(map[uint]*entities.OrderSnapshot)}
+}
+
+// Create implements ports.SnapshotRepository. A version the order already has is rejected, like the
+// unique index of the GORM repository does.
+func (r *SnapshotRepository) Create(ctx context.Context, snapshot *entities.OrderSnapshot) (*entities.OrderSnapshot, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.snapshots {
+		if existing.OrderID == snapshot.OrderID && existing.Version == snapshot.Version {
+			return nil, fmt.Errorf("snapshot version %d of order %d already exists", snapshot.Version, snapshot.OrderID)
+		}
+	}
+
+	stored := snapshot.Clone()
+	r.nextID++
+	stored.ID = r.nextID
+
+	r.snapshots[stored.ID] = stored
+	return stored.Clone(), nil
+}
+
+// ListByOrderID implements ports.SnapshotRepository
+func (r *SnapshotRepository) ListByOrderID(ctx context.Context, orderID uint) ([]*entities.OrderSnapshot, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Versions grow with every create of an order, so do IDs
+	snapshots := make([]*entities.OrderSnapshot, 0)
+	for id := uint(1); id <= r.nextID; id++ {
+		if snapshot, ok := r.snapshots[id]; ok && snapshot.OrderID == orderID {
+			snapshots = append(snapshots, snapshot.Clone())
+		}
+	}
+	return snapshots, nil
+}
+
+// GetByVersion implements ports.SnapshotRepository
+func (r *SnapshotRepository) GetByVersion(ctx context.Context, orderID uint, version int) (*entities.OrderSnapshot, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, snapshot := range r.snapshots {
+		if snapshot.OrderID == orderID && snapshot.Version == version {
+			return snapshot.Clone(), nil
+		}
+	}
+	return nil, domainErrors.ErrSnapshotNotFound
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"orders-service/internal/adapters/persistence/repositorytest"
+	"orders-service/internal/application/ports"
+)
+
+func TestSnapshotRepository_Conformance(t *testing.T) {
+	repositorytest.RunSnapshotRepositoryTests(t, func(t *testing.T) ports.SnapshotRepository {
+		return NewSnapshotRepository()
+	})
+}
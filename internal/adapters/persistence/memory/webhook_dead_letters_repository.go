@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+)
+
+// WebhookDeadLetterRepository implements ports.WebhookDeadLetterRepository in memory, for tests and demos
+type WebhookDeadLetterRepository struct {
+	mu          sync.RWMutex
+	deadLetters map[uint]*entities.WebhookDeadLetter
+	nextID      uint
+}
+
+// NewWebhookDeadLetterRepository creates an empty in-memory webhook dead letter repository
+func NewWebhookDeadLetterRepository() ports.WebhookDeadLetterRepository {
+	return &WebhookDeadLetterRepository{deadLetters: make(map[uint]*entities.WebhookDeadLetter)}
+}
+
+// Create implements ports.WebhookDeadLetterRepository
+func (r *WebhookDeadLetterRepository) Create(ctx context.Context, deadLetter *entities.WebhookDeadLetter) (*entities.WebhookDeadLetter, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := deadLetter.Clone()
+	r.nextID++
+	stored.ID = r.nextID
+
+	r.deadLetters[stored.ID] = stored
+	return stored.Clone(), nil
+}
+
+// GetByID implements ports.WebhookDeadLetterRepository
+func (r *WebhookDeadLetterRepository) GetByID(ctx context.Context, webhookID string, id uint) (*entities.WebhookDeadLetter, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deadLetter, ok := r.deadLetters[id]
+	if !ok || deadLetter.WebhookID != webhookID {
+		return nil, domainErrors.ErrWebhookDeadLetterNotFound
+	}
+	return deadLetter.Clone(), nil
+}
+
+// List implements ports.WebhookDeadLetterRepository
+func (r *WebhookDeadLetterRepository) List(ctx context.Context, webhookID string, filter ports.WebhookDeadLetterFilter, limit, offset int) ([]*entities.WebhookDeadLetter, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matching []*entities.WebhookDeadLetter
+	for _, deadLetter := range r.deadLetters {
+		if deadLetter.WebhookID != webhookID {
+			continue
+		}
+		if filter.Pending && deadLetter.IsReplayed() {
+			continue
+		}
+		if filter.From != nil && deadLetter.CreatedAt.Before(*filter.From) {
+			continue
+		}
+		if filter.To != nil && !deadLetter.CreatedAt.Before(*filter.To) {
+			continue
+		}
+		matching = append(matching, deadLetter)
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		if !matching[i].CreatedAt.Equal(matching[j].CreatedAt) {
+			return matching[i].CreatedAt.Before(matching[j].CreatedAt)
+		}
+		return matching[i].ID < matching[j].ID
+	})
+
+	total := int64(len(matching))
+	if offset >= len(matching) {
+		return []*entities.WebhookDeadLetter{}, total, nil
+	}
+	matching = matching[offset:]
+	if limit < len(matching) {
+		matching = matching[:limit]
+	}
+
+	result := make([]*entities.WebhookDeadLetter, 0, len(matching))
+	for _, deadLetter := range matching {
+		result = append(result, deadLetter.Clone())
+	}
+	return result, total, nil
+}
+
+// ClaimReplay implements ports.WebhookDeadLetterRepository
+func (r *WebhookDeadLetterRepository) ClaimReplay(ctx context.Context, webhookID string, id uint, replayedAt time.Time) (*entities.WebhookDeadLetter, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deadLetter, ok := r.deadLetters[id]
+	if !ok || deadLetter.WebhookID != webhookID {
+		return nil, domainErrors.ErrWebhookDeadLetterNotFound
+	}
+	if deadLetter.IsReplayed() {
+		return nil, domainErrors.ErrWebhookDeadLetterReplayed
+	}
+
+	deadLetter.MarkReplayed(replayedAt)
+	return deadLetter.Clone(), nil
+}
+
+// ReleaseReplay implements ports.WebhookDeadLetterRepository
+func (r *WebhookDeadLetterRepository) ReleaseReplay(ctx context.Context, deadLetter *entities.WebhookDeadLetter) (*entities.WebhookDeadLetter, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.deadLetters[deadLetter.ID]
+	if !ok || current.WebhookID != deadLetter.WebhookID {
+		return nil, domainErrors.ErrWebhookDeadLetterNotFound
+	}
+
+	stored := current.Clone()
+	stored.Attempts = deadLetter.Attempts
+	stored.LastError = deadLetter.LastError
+	stored.ReplayedAt = nil
+
+	r.deadLetters[stored.ID] = stored
+	return stored.Clone(), nil
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"orders-service/internal/adapters/persistence/repositorytest"
+	"orders-service/internal/application/ports"
+)
+
+func TestWebhookDeadLetterRepository_Conformance(t *testing.T) {
+	repositorytest.RunWebhookDeadLetterRepositoryTests(t, func(t *testing.T) ports.WebhookDeadLetterRepository {
+		return NewWebhookDeadLetterRepository()
+	})
+}
@@ -0,0 +1,63 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"orders-service/internal/config"
+	"orders-service/pkg/logger"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// Client is the connection to the MongoDB database holding the orders
+type Client struct {
+	client   *mongo.Client
+	database *mongo.Database
+	logger   logger.Logger
+}
+
+// NewClient connects to the database of cfg.Mongo and pings it, an unreachable server fails the boot
+func NewClient(cfg *config.Config, log logger.Logger) (*Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Mongo.ConnectTimeout)
+	defer cancel()
+
+	opts := options.Client().
+		ApplyURI(cfg.Mongo.URI).
+		SetConnectTimeout(cfg.Mongo.ConnectTimeout).
+		SetServerSelectionTimeout(cfg.Mongo.ConnectTimeout)
+	client, err := mongo.Connect(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to mongo: %w", err)
+	}
+	if err := client.Ping(ctx, readpref.Primary()); err != nil {
+		_ = client.Disconnect(context.Background())
+		return nil, fmt.Errorf("failed to ping mongo: %w", err)
+	}
+
+	log = log.With("component", "mongo")
+	log.Info("MongoDB connection established", "database", cfg.Mongo.Database)
+
+	return &Client{
+		client:   client,
+		database: client.Database(cfg.Mongo.Database),
+		logger:   log,
+	}, nil
+}
+
+// Database returns the database holding the orders
+func (c *Client) Database() *mongo.Database {
+	return c.database
+}
+
+// HealthCheck pings the primary
+func (c *Client) HealthCheck(ctx context.Context) error {
+	return c.client.Ping(ctx, readpref.Primary())
+}
+
+// Close disconnects from the server
+func (c *Client) Close() error {
+	return c.client.Disconnect(context.Background())
+}
@@ -0,0 +1,160 @@
+package order_job_repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"orders-service/internal/adapters/persistence/transaction"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+
+	"gorm.io/gorm"
+)
+
+// OrderJobModel represents the database model for orders accepted for asynchronous creation
+type OrderJobModel struct {
+	ID            string    `gorm:"primarykey;size:36"`
+	CustomerID    uint      `gorm:"not null;index"`
+	Request       []byte    `gorm:"not null"`
+	CreatedBy     string    `gorm:"size:255;not null"`
+	Status        string    `gorm:"size:20;not null;index:idx_order_jobs_pending,priority:1"`
+	OrderID       *uint     `gorm:"index"`
+	OrderPublicID string    `gorm:"size:36"`
+	ErrorCode     string    `gorm:"size:100"`
+	ErrorMessage  string    `gorm:"size:500"`
+	CreatedAt     time.Time `gorm:"not null;index:idx_order_jobs_pending,priority:2"`
+	CompletedAt   *time.Time
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (OrderJobModel) TableName() string {
+	return "order_jobs"
+}
+
+// GormOrderJobRepository implements the OrderJobRepository interface using GORM
+type GormOrderJobRepository struct {
+	db *gorm.DB
+}
+
+// NewGormOrderJobRepository creates a new GORM order job repository
+func NewGormOrderJobRepository(db *gorm.DB) ports.OrderJobRepository {
+	return &GormOrderJobRepository{db: db}
+}
+
+// Create implements ports.OrderJobRepository
+func (r *GormOrderJobRepository) Create(ctx context.Context, job *entities.OrderJob) (*entities.OrderJob, error) {
+	model := toModel(job)
+	if err := transaction.Conn(ctx, r.db).Create(model).Error; err != nil {
+		return nil, err
+	}
+	return toEntity(model), nil
+}
+
+// Update implements ports.OrderJobRepository. The request is fixed once submitted, so only the outcome
+// is written and only while the stored job is pending.
+func (r *GormOrderJobRepository) Update(ctx context.Context, job *entities.OrderJob) (*entities.OrderJob, error) {
+	db := transaction.Conn(ctx, r.db)
+
+	model := toModel(job)
+	result := db.Model(&OrderJobModel{}).
+		Where("id = ? AND status = ?", job.ID, string(entities.OrderJobPending)).
+		Updates(map[string]interface{}{
+			"status":          model.Status,
+			"order_id":        model.OrderID,
+			"order_public_id": model.OrderPublicID,
+			"error_code":      model.ErrorCode,
+			"error_message":   model.ErrorMessage,
+			"completed_at":    model.CompletedAt,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return job.Clone(), nil
+	}
+
+	// Nothing pending matched, tell an unknown job from one that already completed
+	if _, err := r.GetByID(ctx, job.ID); err != nil {
+		return nil, err
+	}
+	return nil, domainErrors.ErrOrderJobNotPending
+}
+
+// GetByID implements ports.OrderJobRepository
+func (r *GormOrderJobRepository) GetByID(ctx context.Context, id string) (*entities.OrderJob, error) {
+	var model OrderJobModel
+
+	err := transaction.Conn(ctx, r.db).Where("id = ?", id).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrOrderJobNotFound
+		}
+		return nil, err
+	}
+	return toEntity(&model), nil
+}
+
+// FindPending implements ports.OrderJobRepository
+func (r *GormOrderJobRepository) FindPending(ctx context.Context, before time.Time, limit int) ([]*entities.OrderJob, error) {
+	var models []OrderJobModel
+
+	err := transaction.Conn(ctx, r.db).
+		Where("status = ? AND created_at <= ?", string(entities.OrderJobPending), before).
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*entities.OrderJob, 0, len(models))
+	for i := range models {
+		jobs = append(jobs, toEntity(&models[i]))
+	}
+	return jobs, nil
+}
+
+func toModel(job *entities.OrderJob) *OrderJobModel {
+	model := &OrderJobModel{
+		ID:            job.ID,
+		CustomerID:    job.CustomerID,
+		Request:       job.Request,
+		CreatedBy:     job.CreatedBy,
+		Status:        string(job.Status),
+		OrderPublicID: job.OrderPublicID,
+		ErrorCode:     job.ErrorCode,
+		ErrorMessage:  job.ErrorMessage,
+		CreatedAt:     job.CreatedAt,
+		CompletedAt:   job.CompletedAt,
+	}
+	if job.OrderID != 0 {
+		orderID := job.OrderID
+		model.OrderID = &orderID
+	}
+	return model
+}
+
+func toEntity(model *OrderJobModel) *entities.OrderJob {
+	job := &entities.OrderJob{
+		ID:            model.ID,
+		CustomerID:    model.CustomerID,
+		Request:       model.Request,
+		CreatedBy:     model.CreatedBy,
+		Status:        entities.OrderJobStatus(model.Status),
+		OrderPublicID: model.OrderPublicID,
+		ErrorCode:     model.ErrorCode,
+		ErrorMessage:  model.ErrorMessage,
+		CreatedAt:     model.CreatedAt.UTC(),
+	}
+	if model.OrderID != nil {
+		job.OrderID = *model.OrderID
+	}
+	if model.CompletedAt != nil {
+		completedAt := model.CompletedAt.UTC()
+		job.CompletedAt = &completedAt
+	}
+	return job
+}
@@ -0,0 +1,14 @@
+package order_job_repository
+
+import (
+	"testing"
+
+	"orders-service/internal/adapters/persistence/repositorytest"
+	"orders-service/internal/application/ports"
+)
+
+func TestGormOrderJobRepository_Conformance(t *testing.T) {
+	repositorytest.RunOrderJobRepositoryTests(t, func(t *testing.T) ports.OrderJobRepository {
+		return NewGormOrderJobRepository(repositorytest.OpenSQLite(t, &OrderJobModel{}))
+	})
+}
@@ -0,0 +1,158 @@
+package order_repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"orders-service/internal/adapters/persistence/transaction"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	"orders-service/pkg/logger"
+)
+
+// orderCacheKeyPrefix namespaces cached orders, bump the version when the cached JSON shape changes
+const orderCacheKeyPrefix = "orders:v1:"
+
+// CachedOrderRepository is a cache-aside decorator for an OrderRepository.
+// GetByID is served from the cache, every write invalidates the cached order once it is committed:
+// evicting it earlier would let a concurrent read cache the row as it was before the commit.
+// Cache failures are logged and the call falls through to the wrapped repository.
+type CachedOrderRepository struct {
+	ports.OrderRepository
+	cache  ports.Cache
+	ttl    time.Duration
+	logger logger.Logger
+}
+
+// NewCachedOrderRepository wraps repo with a read cache that keeps orders for ttl
+func NewCachedOrderRepository(repo ports.OrderRepository, cache ports.Cache, ttl time.Duration, log logger.Logger) ports.OrderRepository {
+	return &CachedOrderRepository{
+		OrderRepository: repo,
+		cache:           cache,
+		ttl:             ttl,
+		logger:          log.With("component", "order_cache"),
+	}
+}
+
+// GetByID implements ports.OrderRepository. Reads inside a transaction bypass the cache
+// so they see the transaction's own writes.
+func (r *CachedOrderRepository) GetByID(ctx context.Context, id uint) (*entities.Order, error) {
+	if inTransaction(ctx) {
+		return r.OrderRepository.GetByID(ctx, id)
+	}
+
+	key := orderCacheKey(id)
+	if order, ok := r.read(ctx, key); ok {
+		return order, nil
+	}
+
+	order, err := r.OrderRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.write(ctx, key, order)
+	return order, nil
+}
+
+// Create implements ports.OrderRepository
+func (r *CachedOrderRepository) Create(ctx context.Context, order *entities.Order) (*entities.Order, error) {
+	created, err := r.OrderRepository.Create(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidate(ctx, created.ID)
+	return created, nil
+}
+
+// Update implements ports.OrderRepository
+func (r *CachedOrderRepository) Update(ctx context.Context, order *entities.Order) (*entities.Order, error) {
+	updated, err := r.OrderRepository.Update(ctx, order)
+	// The row may have changed even when reloading it failed
+	r.invalidate(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Delete implements ports.OrderRepository
+func (r *CachedOrderRepository) Delete(ctx context.Context, id uint) error {
+	if err := r.OrderRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, id)
+	return nil
+}
+
+// Restore implements ports.OrderRepository
+func (r *CachedOrderRepository) Restore(ctx context.Context, id uint) (*entities.Order, error) {
+	restored, err := r.OrderRepository.Restore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.invalidate(ctx, id)
+	return restored, nil
+}
+
+func (r *CachedOrderRepository) read(ctx context.Context, key string) (*entities.Order, bool) {
+	data, err := r.cache.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, ports.ErrCacheMiss) {
+			r.logger.Warn("Order cache read failed, falling back to the database", "key", key, "error", err)
+		}
+		return nil, false
+	}
+
+	var order entities.Order
+	if err := json.Unmarshal(data, &order); err != nil {
+		r.logger.Warn("Discarding undecodable cached order", "key", key, "error", err)
+		r.delete(ctx, key)
+		return nil, false
+	}
+
+	return &order, true
+}
+
+func (r *CachedOrderRepository) write(ctx context.Context, key string, order *entities.Order) {
+	data, err := json.Marshal(order)
+	if err != nil {
+		r.logger.Warn("Failed to encode order for the cache", "key", key, "error", err)
+		return
+	}
+
+	if err := r.cache.Set(ctx, key, data, r.ttl); err != nil {
+		r.logger.Warn("Order cache write failed", "key", key, "error", err)
+	}
+}
+
+// invalidate evicts the cached order once the unit of work of ctx commits, right away outside one
+func (r *CachedOrderRepository) invalidate(ctx context.Context, id uint) {
+	// The request may be over by the time the unit of work commits
+	ctx = context.WithoutCancel(ctx)
+	ports.AfterCommit(ctx, func() {
+		r.delete(ctx, orderCacheKey(id))
+	})
+}
+
+func (r *CachedOrderRepository) delete(ctx context.Context, key string) {
+	if err := r.cache.Delete(ctx, key); err != nil {
+		r.logger.Warn("Order cache invalidation failed, entry expires with its TTL", "key", key, "error", err)
+	}
+}
+
+func orderCacheKey(id uint) string {
+	return orderCacheKeyPrefix + strconv.FormatUint(uint64(id), 10)
+}
+
+// inTransaction reports whether ctx carries a transaction opened by WithCustomerLock or a unit of work
+func inTransaction(ctx context.Context) bool {
+	return transaction.Active(ctx)
+}
@@ -0,0 +1,290 @@
+package order_repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	redisConn "orders-service/internal/adapters/persistence/redis"
+	"orders-service/internal/adapters/persistence/transaction"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/config"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newRedis starts an in-process Redis and a cache client connected to it
+func newRedis(t *testing.T) (*miniredis.Miniredis, *redisConn.Client) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redisConn.NewClient(&config.Config{Cache: config.CacheConfig{
+		Addr:        server.Addr(),
+		DialTimeout: 100 * time.Millisecond,
+		IOTimeout:   100 * time.Millisecond,
+	}}, logger.New("test"))
+	t.Cleanup(func() { _ = client.Close() })
+	return server, client
+}
+
+// countingRepository serves orders from a map and counts GetByID calls
+type countingRepository struct {
+	ports.OrderRepository
+	orders map[uint]*entities.Order
+	reads  int
+}
+
+func (r *countingRepository) GetByID(_ context.Context, id uint) (*entities.Order, error) {
+	r.reads++
+	order, ok := r.orders[id]
+	if !ok {
+		return nil, domainErrors.ErrOrderNotFound
+	}
+	copied := *order
+	return &copied, nil
+}
+
+func (r *countingRepository) Create(_ context.Context, order *entities.Order) (*entities.Order, error) {
+	order.ID = uint(len(r.orders) + 1)
+	r.orders[order.ID] = order
+	return order, nil
+}
+
+func (r *countingRepository) Update(_ context.Context, order *entities.Order) (*entities.Order, error) {
+	r.orders[order.ID] = order
+	return order, nil
+}
+
+func (r *countingRepository) Delete(_ context.Context, id uint) error {
+	delete(r.orders, id)
+	return nil
+}
+
+func setupCachedRepository(cache ports.Cache) (ports.OrderRepository, *countingRepository) {
+	order, _ := entities.NewOrder(123)
+	order.ID = 1
+	_ = order.AddItem(1, "SKU-001", "Product 1", 2, 10.0)
+
+	repo := &countingRepository{orders: map[uint]*entities.Order{1: order}}
+	return NewCachedOrderRepository(repo, cache, time.Minute, logger.New("test")), repo
+}
+
+func TestCachedOrderRepository_GetByID_MissThenHit(t *testing.T) {
+	// Given
+	server, redis := newRedis(t)
+	cached, repo := setupCachedRepository(redis)
+	ctx := context.Background()
+
+	// When
+	first, err := cached.GetByID(ctx, 1)
+	require.NoError(t, err)
+	second, err := cached.GetByID(ctx, 1)
+	require.NoError(t, err)
+
+	// Then
+	assert.Equal(t, 1, repo.reads)
+	assert.Equal(t, []string{"orders:v1:1"}, server.Keys())
+	assert.Equal(t, time.Minute, server.TTL("orders:v1:1"))
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, first.TotalAmount, second.TotalAmount)
+	assert.Equal(t, first.Items, second.Items)
+	assert.Equal(t, entities.OrderStatusPending, second.Status)
+}
+
+func TestCachedOrderRepository_GetByID_ExpiredEntryIsReadAgain(t *testing.T) {
+	// Given
+	server, redis := newRedis(t)
+	cached, repo := setupCachedRepository(redis)
+	ctx := context.Background()
+	_, err := cached.GetByID(ctx, 1)
+	require.NoError(t, err)
+
+	// When
+	server.FastForward(2 * time.Minute)
+	_, err = cached.GetByID(ctx, 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 2, repo.reads)
+}
+
+func TestCachedOrderRepository_GetByID_NotFoundIsNotCached(t *testing.T) {
+	// Given
+	server, redis := newRedis(t)
+	cached, repo := setupCachedRepository(redis)
+	ctx := context.Background()
+
+	// When
+	_, err := cached.GetByID(ctx, 99)
+	_, _ = cached.GetByID(ctx, 99)
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+	assert.Equal(t, 2, repo.reads)
+	assert.Empty(t, server.Keys())
+}
+
+func TestCachedOrderRepository_WritesInvalidate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("update", func(t *testing.T) {
+		// Given
+		_, redis := newRedis(t)
+		cached, repo := setupCachedRepository(redis)
+		order, err := cached.GetByID(ctx, 1)
+		require.NoError(t, err)
+
+		// When
+		require.NoError(t, order.ConfirmOrder())
+		_, err = cached.Update(ctx, order)
+		require.NoError(t, err)
+		reloaded, err := cached.GetByID(ctx, 1)
+		require.NoError(t, err)
+
+		// Then
+		assert.Equal(t, entities.OrderStatusConfirmed, reloaded.Status)
+		assert.Equal(t, 2, repo.reads)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		// Given
+		server, redis := newRedis(t)
+		cached, _ := setupCachedRepository(redis)
+		_, err := cached.GetByID(ctx, 1)
+		require.NoError(t, err)
+
+		// When
+		require.NoError(t, cached.Delete(ctx, 1))
+		_, err = cached.GetByID(ctx, 1)
+
+		// Then
+		assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+		assert.False(t, server.Exists("orders:v1:1"))
+	})
+
+	t.Run("create", func(t *testing.T) {
+		// Given
+		server, redis := newRedis(t)
+		cached, _ := setupCachedRepository(redis)
+		require.NoError(t, server.Set("orders:v1:2", `{"id":2,"status":"cancelled"}`))
+		order, _ := entities.NewOrder(456)
+
+		// When
+		created, err := cached.Create(ctx, order)
+		require.NoError(t, err)
+		reloaded, err := cached.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+
+		// Then
+		assert.Equal(t, entities.OrderStatusPending, reloaded.Status)
+	})
+}
+
+func TestCachedOrderRepository_TransactionBypassesCache(t *testing.T) {
+	// Given
+	server, redis := newRedis(t)
+	cached, repo := setupCachedRepository(redis)
+	ctx := transaction.WithTx(context.Background(), &gorm.DB{})
+
+	// When
+	_, err := cached.GetByID(ctx, 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.reads)
+	assert.Empty(t, server.Keys())
+}
+
+func TestCachedOrderRepository_RedisDown(t *testing.T) {
+	// Given a cached order and then Redis going away
+	server, redis := newRedis(t)
+	cached, repo := setupCachedRepository(redis)
+	ctx := context.Background()
+	_, err := cached.GetByID(ctx, 1)
+	require.NoError(t, err)
+	server.Close()
+
+	// When
+	order, err := cached.GetByID(ctx, 1)
+	require.NoError(t, err)
+	require.NoError(t, order.ConfirmOrder())
+	_, updateErr := cached.Update(ctx, order)
+	deleteErr := cached.Delete(ctx, 1)
+
+	// Then reads fall back to the repository and writes still succeed
+	assert.Equal(t, uint(1), order.ID)
+	assert.Equal(t, 2, repo.reads)
+	assert.NoError(t, updateErr)
+	assert.NoError(t, deleteErr)
+}
+
+func TestCachedOrderRepository_UndecodableEntryIsDiscarded(t *testing.T) {
+	// Given
+	server, redis := newRedis(t)
+	cached, repo := setupCachedRepository(redis)
+	ctx := context.Background()
+	require.NoError(t, server.Set("orders:v1:1", "not json"))
+
+	// When
+	order, err := cached.GetByID(ctx, 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), order.ID)
+	assert.Equal(t, 1, repo.reads)
+	cachedJSON, err := server.Get("orders:v1:1")
+	require.NoError(t, err)
+	assert.NotEqual(t, "not json", cachedJSON)
+}
+
+func TestCachedOrderRepository_ReadDuringUnitOfWorkDoesNotKeepStaleEntry(t *testing.T) {
+	// Given a cached order and a unit of work writing through the cached repository
+	db := openSQLite(t)
+	_, redis := newRedis(t)
+	cached := NewCachedOrderRepository(NewGormOrderRepository(db), redis, time.Minute, logger.New("test"))
+	unitOfWork := transaction.NewGormUnitOfWork(db, ports.Repositories{Orders: cached})
+	ctx := context.Background()
+
+	order, _ := entities.NewOrder(123)
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 2, 10.0))
+	created, err := cached.Create(ctx, order)
+	require.NoError(t, err)
+	_, err = cached.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+
+	// When another request reads the order while the update is not yet committed
+	err = unitOfWork.Do(ctx, func(txCtx context.Context, repos ports.Repositories) error {
+		current, err := repos.Orders.GetByID(txCtx, created.ID)
+		if err != nil {
+			return err
+		}
+		if err := current.ConfirmOrder(); err != nil {
+			return err
+		}
+		if _, err := repos.Orders.Update(txCtx, current); err != nil {
+			return err
+		}
+
+		read := make(chan error, 1)
+		go func() {
+			stale, err := cached.GetByID(ctx, created.ID)
+			if err == nil && stale.Status != entities.OrderStatusPending {
+				err = fmt.Errorf("read the uncommitted status %s", stale.Status)
+			}
+			read <- err
+		}()
+		return <-read
+	})
+	require.NoError(t, err)
+
+	// Then the entry cached by that read is evicted once the update commits
+	reloaded, err := cached.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusConfirmed, reloaded.Status)
+}
@@ -0,0 +1,160 @@
+package order_repository
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+type constraintKind int
+
+const (
+	uniqueConstraint constraintKind = iota + 1
+	foreignKeyConstraint
+)
+
+// constraintViolation is a unique or foreign key violation reported by the database.
+// constraint names the violated index or constraint when the driver reports it.
+type constraintViolation struct {
+	kind       constraintKind
+	constraint string
+}
+
+// violates reports whether the violation concerns the index or constraint called name
+func (v constraintViolation) violates(name string) bool {
+	// MySQL 8 qualifies index names with their table
+	return v.constraint == name || strings.HasSuffix(v.constraint, "."+name)
+}
+
+// constraintMatcher recognises constraint violations in the errors of one database driver
+type constraintMatcher func(err error) (constraintViolation, bool)
+
+var constraintMatchers = []constraintMatcher{
+	matchPostgresConstraint,
+	matchMySQLConstraint,
+	matchSQLiteConstraint,
+	matchGormConstraint,
+}
+
+// matchConstraintViolation returns the constraint violation err reports, if any
+func matchConstraintViolation(err error) (constraintViolation, bool) {
+	for _, match := range constraintMatchers {
+		if violation, ok := match(err); ok {
+			return violation, true
+		}
+	}
+	return constraintViolation{}, false
+}
+
+// Postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+func matchPostgresConstraint(err error) (constraintViolation, bool) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return constraintViolation{}, false
+	}
+
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		return constraintViolation{kind: uniqueConstraint, constraint: pgErr.ConstraintName}, true
+	case pgForeignKeyViolation:
+		return constraintViolation{kind: foreignKeyConstraint, constraint: pgErr.ConstraintName}, true
+	default:
+		return constraintViolation{}, false
+	}
+}
+
+// MySQL error numbers, see https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+const (
+	mysqlDuplicateEntry       = 1062 // ER_DUP_ENTRY
+	mysqlRowIsReferenced      = 1451 // ER_ROW_IS_REFERENCED_2
+	mysqlNoReferencedRow      = 1452 // ER_NO_REFERENCED_ROW_2
+	mysqlDuplicateKeyIndexTag = "for key '"
+)
+
+func matchMySQLConstraint(err error) (constraintViolation, bool) {
+	number, message, ok := mysqlError(err)
+	if !ok {
+		return constraintViolation{}, false
+	}
+
+	switch number {
+	case mysqlDuplicateEntry:
+		// Duplicate entry '42-abc' for key 'orders.idx_orders_customer_external_reference'
+		constraint := ""
+		if _, key, found := strings.Cut(message, mysqlDuplicateKeyIndexTag); found {
+			constraint = strings.TrimSuffix(key, "'")
+		}
+		return constraintViolation{kind: uniqueConstraint, constraint: constraint}, true
+	case mysqlRowIsReferenced, mysqlNoReferencedRow:
+		return constraintViolation{kind: foreignKeyConstraint}, true
+	default:
+		return constraintViolation{}, false
+	}
+}
+
+// mysqlError reads the Number and Message fields of the MySQL driver's *mysql.MySQLError.
+// The driver is not a dependency of this service, so the error is recognised by its shape.
+func mysqlError(err error) (uint16, string, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		value := reflect.ValueOf(err)
+		if value.Kind() == reflect.Pointer {
+			value = value.Elem()
+		}
+		if value.Kind() != reflect.Struct || value.Type().Name() != "MySQLError" {
+			continue
+		}
+
+		number, message := value.FieldByName("Number"), value.FieldByName("Message")
+		if number.IsValid() && number.CanUint() && message.IsValid() && message.Kind() == reflect.String {
+			return uint16(number.Uint()), message.String(), true
+		}
+	}
+	return 0, "", false
+}
+
+// SQLite reports constraint violations by message only, listing the columns of a violated unique index
+const (
+	sqliteUniqueViolation     = "UNIQUE constraint failed: "
+	sqliteForeignKeyViolation = "FOREIGN KEY constraint failed"
+)
+
+// sqliteUniqueIndexes names the unique indexes by the column list SQLite reports for them
+var sqliteUniqueIndexes = map[string]string{
+	"orders.customer_id, orders.external_reference": externalReferenceIndex,
+	"orders.order_number":                           orderNumberIndex,
+	"orders.public_id":                              publicIDIndex,
+}
+
+func matchSQLiteConstraint(err error) (constraintViolation, bool) {
+	message := err.Error()
+
+	if _, columns, found := strings.Cut(message, sqliteUniqueViolation); found {
+		// Drivers may append the result code, as in "... orders.external_reference (2067)"
+		columns, _, _ = strings.Cut(columns, " (")
+		return constraintViolation{kind: uniqueConstraint, constraint: sqliteUniqueIndexes[columns]}, true
+	}
+	if strings.Contains(message, sqliteForeignKeyViolation) {
+		return constraintViolation{kind: foreignKeyConstraint}, true
+	}
+	return constraintViolation{}, false
+}
+
+// matchGormConstraint covers dialects whose errors GORM translates, these do not name the constraint
+func matchGormConstraint(err error) (constraintViolation, bool) {
+	switch {
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return constraintViolation{kind: uniqueConstraint}, true
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return constraintViolation{kind: foreignKeyConstraint}, true
+	default:
+		return constraintViolation{}, false
+	}
+}
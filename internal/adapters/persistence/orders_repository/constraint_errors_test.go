@@ -0,0 +1,171 @@
+package order_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	domainErrors "orders-service/internal/domain/errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// MySQLError has the shape of the MySQL driver's error, which is not a dependency of this service
+type MySQLError struct {
+	Number   uint16
+	SQLState [5]byte
+	Message  string
+}
+
+func (e *MySQLError) Error() string {
+	return fmt.Sprintf("Error %d: %s", e.Number, e.Message)
+}
+
+func TestMatchConstraintViolation(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		matched    bool
+		kind       constraintKind
+		constraint string
+	}{
+		{
+			name:       "postgres unique violation",
+			err:        &pgconn.PgError{Code: "23505", ConstraintName: externalReferenceIndex},
+			matched:    true,
+			kind:       uniqueConstraint,
+			constraint: externalReferenceIndex,
+		},
+		{
+			name:       "postgres foreign key violation",
+			err:        fmt.Errorf("insert: %w", &pgconn.PgError{Code: "23503", ConstraintName: "fk_orders_items"}),
+			matched:    true,
+			kind:       foreignKeyConstraint,
+			constraint: "fk_orders_items",
+		},
+		{
+			name:    "postgres other error",
+			err:     &pgconn.PgError{Code: "40001"},
+			matched: false,
+		},
+		{
+			name:       "mysql duplicate entry",
+			err:        &MySQLError{Number: 1062, Message: "Duplicate entry '42-abc' for key 'orders.idx_orders_customer_external_reference'"},
+			matched:    true,
+			kind:       uniqueConstraint,
+			constraint: "orders.idx_orders_customer_external_reference",
+		},
+		{
+			name:    "mysql missing parent row",
+			err:     fmt.Errorf("insert: %w", &MySQLError{Number: 1452, Message: "Cannot add or update a child row"}),
+			matched: true,
+			kind:    foreignKeyConstraint,
+		},
+		{
+			name:    "mysql referenced row",
+			err:     &MySQLError{Number: 1451, Message: "Cannot delete or update a parent row"},
+			matched: true,
+			kind:    foreignKeyConstraint,
+		},
+		{
+			name:    "mysql other error",
+			err:     &MySQLError{Number: 1213, Message: "Deadlock found"},
+			matched: false,
+		},
+		{
+			name:       "sqlite unique external reference",
+			err:        errors.New("constraint failed: UNIQUE constraint failed: orders.customer_id, orders.external_reference (2067)"),
+			matched:    true,
+			kind:       uniqueConstraint,
+			constraint: externalReferenceIndex,
+		},
+		{
+			name:    "sqlite unique primary key",
+			err:     errors.New("UNIQUE constraint failed: orders.id"),
+			matched: true,
+			kind:    uniqueConstraint,
+		},
+		{
+			name:    "sqlite foreign key",
+			err:     fmt.Errorf("insert: %w", errors.New("FOREIGN KEY constraint failed")),
+			matched: true,
+			kind:    foreignKeyConstraint,
+		},
+		{
+			name:    "gorm duplicated key",
+			err:     gorm.ErrDuplicatedKey,
+			matched: true,
+			kind:    uniqueConstraint,
+		},
+		{
+			name:    "gorm foreign key violated",
+			err:     gorm.ErrForeignKeyViolated,
+			matched: true,
+			kind:    foreignKeyConstraint,
+		},
+		{
+			name:    "message mentioning a duplicate key",
+			err:     errors.New("duplicate key value violates unique constraint"),
+			matched: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violation, ok := matchConstraintViolation(tt.err)
+
+			assert.Equal(t, tt.matched, ok)
+			assert.Equal(t, tt.kind, violation.kind)
+			assert.Equal(t, tt.constraint, violation.constraint)
+		})
+	}
+}
+
+func TestConstraintViolation_Violates(t *testing.T) {
+	assert.True(t, constraintViolation{constraint: externalReferenceIndex}.violates(externalReferenceIndex))
+	assert.True(t, constraintViolation{constraint: "orders." + externalReferenceIndex}.violates(externalReferenceIndex))
+	assert.False(t, constraintViolation{constraint: "orders_pkey"}.violates(externalReferenceIndex))
+	assert.False(t, constraintViolation{}.violates(externalReferenceIndex))
+}
+
+func TestGormOrderRepository_HandleError(t *testing.T) {
+	repo := &GormOrderRepository{}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"not found", gorm.ErrRecordNotFound, domainErrors.ErrOrderNotFound},
+		{"cancelled", fmt.Errorf("query: %w", context.Canceled), domainErrors.ErrRequestCancelled},
+		{"deadline", context.DeadlineExceeded, domainErrors.ErrRequestCancelled},
+		{"duplicate external reference", &pgconn.PgError{Code: "23505", ConstraintName: externalReferenceIndex}, domainErrors.ErrDuplicateExternalReference},
+		{"duplicate order number", &pgconn.PgError{Code: "23505", ConstraintName: orderNumberIndex}, domainErrors.ErrDuplicateOrderNumber},
+		{"sqlite duplicate order number", errors.New("UNIQUE constraint failed: orders.order_number"), domainErrors.ErrDuplicateOrderNumber},
+		{"duplicate key", &pgconn.PgError{Code: "23505", ConstraintName: "orders_pkey"}, domainErrors.ErrOrderAlreadyExists},
+		{"mysql duplicate external reference", &MySQLError{Number: 1062, Message: "Duplicate entry '1-a' for key 'orders.idx_orders_customer_external_reference'"}, domainErrors.ErrDuplicateExternalReference},
+		{"sqlite duplicate external reference", errors.New("UNIQUE constraint failed: orders.customer_id, orders.external_reference"), domainErrors.ErrDuplicateExternalReference},
+		{"foreign key", &pgconn.PgError{Code: "23503"}, domainErrors.NewOrderValidationError("customer_id", "invalid customer ID")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := repo.handleError(tt.err)
+
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+
+	t.Run("timeouts stay visible", func(t *testing.T) {
+		assert.ErrorIs(t, repo.handleError(context.DeadlineExceeded), context.DeadlineExceeded)
+	})
+
+	t.Run("other errors pass through", func(t *testing.T) {
+		err := errors.New("connection reset")
+		assert.Equal(t, err, repo.handleError(err))
+		assert.NoError(t, repo.handleError(nil))
+	})
+}
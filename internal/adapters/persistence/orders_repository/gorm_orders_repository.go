@@ -2,41 +2,238 @@ package order_repository
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
-	"strings"
+	"fmt"
+	"log/slog"
 	"time"
 
+	"orders-service/internal/adapters/persistence/transaction"
 	"orders-service/internal/application/ports"
 	"orders-service/internal/domain/entities"
 	domainErrors "orders-service/internal/domain/errors"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // OrderModel represents the database model for orders
 type OrderModel struct {
-	ID          uint             `gorm:"primarykey"`
-	CustomerID  uint             `gorm:"not null;index"`
-	Items       []OrderItemModel `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
-	TotalAmount float64          `gorm:"type:decimal(10,2);not null;default:0"`
-	Status      string           `gorm:"not null;default:'pending';index"`
-	CreatedAt   time.Time        `gorm:"autoCreateTime;index"`
-	UpdatedAt   time.Time        `gorm:"autoUpdateTime"`
-	DeletedAt   gorm.DeletedAt   `gorm:"index"` // For soft deletes
+	ID uint `gorm:"primarykey"`
+	// PublicID is NULL for orders created before public IDs were introduced
+	PublicID   *string `gorm:"size:36;uniqueIndex:idx_orders_public_id"`
+	CustomerID uint    `gorm:"not null;index;uniqueIndex:idx_orders_customer_external_reference,priority:1;index:idx_orders_customer_item_set,priority:1"`
+	// ExternalReference is NULL when unset so the unique index only applies to orders that have one
+	ExternalReference *string `gorm:"size:100;uniqueIndex:idx_orders_customer_external_reference,priority:2"`
+	// OrderNumber is NULL for orders created before numbering was introduced
+	OrderNumber *string `gorm:"size:64;uniqueIndex:idx_orders_order_number"`
+	// Tags are stored as a JSON array, NULL when the order has none
+	Tags  orderTags        `gorm:"type:jsonb"`
+	Items []OrderItemModel `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
+	// CancelledItems are stored as a JSON array, NULL until an item is cancelled
+	CancelledItems cancelledItems `gorm:"type:jsonb"`
+	AmendmentCount int            `gorm:"not null;default:0"`
+	// Amendments are stored as a JSON array, NULL until the order is amended
+	Amendments             orderAmendments `gorm:"type:jsonb"`
+	TotalAmount            float64         `gorm:"type:decimal(10,2);not null;default:0"`
+	TotalWeightGrams       *int
+	RefundedAmount         float64    `gorm:"type:decimal(10,2);not null;default:0"`
+	Status                 string     `gorm:"not null;default:'pending';index;index:idx_orders_created_at_status,priority:2"`
+	HeldFromStatus         string     `gorm:"size:32"`
+	HoldReason             string     `gorm:"size:500"`
+	ExpiresAt              *time.Time `gorm:"index"`
+	ShippingMethod         string     `gorm:"size:32"`
+	EstimatedDeliveryAt    *time.Time
+	Carrier                string         `gorm:"size:100"`
+	TrackingNumber         string         `gorm:"size:100"`
+	Priority               string         `gorm:"size:16;not null;default:'normal';index"`
+	CustomerEmail          personalData   `gorm:"size:254"`
+	CustomerName           personalData   `gorm:"size:200"`
+	PaymentAuthorizationID string         `gorm:"size:100"`
+	PaymentStatus          string         `gorm:"size:16"`
+	ReservationSkipped     bool           `gorm:"not null;default:false"`
+	ItemSetHash            string         `gorm:"size:64;index:idx_orders_customer_item_set,priority:2"` // entities.ItemSetHash of the items, updated on every write
+	CreatedAt              time.Time      `gorm:"autoCreateTime;index;index:idx_orders_created_at_status,priority:1"`
+	UpdatedAt              time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt              gorm.DeletedAt `gorm:"index"` // For soft deletes
 }
 
 // OrderItemModel represents the database model for order items
 type OrderItemModel struct {
-	ID          uint      `gorm:"primarykey"`
-	OrderID     uint      `gorm:"not null;index"`
-	ProductID   uint      `gorm:"not null;index"`
-	ProductSKU  string    `gorm:"not null;index"`
-	ProductName string    `gorm:"not null"`
-	Quantity    int       `gorm:"not null"`
-	UnitPrice   float64   `gorm:"type:decimal(10,2);not null"`
-	TotalPrice  float64   `gorm:"type:decimal(10,2);not null"`
-	CreatedAt   time.Time `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	ID          uint    `gorm:"primarykey"`
+	OrderID     uint    `gorm:"not null;index"`
+	ProductID   uint    `gorm:"not null;index"`
+	ProductSKU  string  `gorm:"not null;index"`
+	ProductName string  `gorm:"not null"`
+	Quantity    int     `gorm:"not null"`
+	UnitPrice   float64 `gorm:"type:decimal(10,2);not null"`
+	TotalPrice  float64 `gorm:"type:decimal(10,2);not null"`
+	// Attributes are stored as a JSON object, NULL when the item has none
+	Attributes       itemAttributes `gorm:"type:jsonb"`
+	UnitWeightGrams  *int
+	ReservedQuantity int       `gorm:"not null;default:0"`
+	Backordered      bool      `gorm:"not null;default:false;index"`
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime"`
+}
+
+// itemAttributes maps the custom attributes of an order item to a JSON column
+type itemAttributes map[string]string
+
+// Value implements driver.Valuer
+func (a itemAttributes) Value() (driver.Value, error) {
+	if len(a) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(map[string]string(a))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (a *itemAttributes) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into item attributes", value)
+	}
+	return json.Unmarshal(data, (*map[string]string)(a))
+}
+
+// orderTags maps the tags of an order to a JSON column
+type orderTags []string
+
+// Value implements driver.Valuer
+func (t orderTags) Value() (driver.Value, error) {
+	if len(t) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal([]string(t))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (t *orderTags) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into order tags", value)
+	}
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+// cancelledItems maps the item cancellations of an order to a JSON column
+type cancelledItems []entities.CancelledItem
+
+// Value implements driver.Valuer
+func (c cancelledItems) Value() (driver.Value, error) {
+	if len(c) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal([]entities.CancelledItem(c))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (c *cancelledItems) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into cancelled items", value)
+	}
+	return json.Unmarshal(data, (*[]entities.CancelledItem)(c))
+}
+
+// orderAmendments maps the amendments of an order to a JSON column
+type orderAmendments []entities.OrderAmendment
+
+// Value implements driver.Valuer
+func (a orderAmendments) Value() (driver.Value, error) {
+	if len(a) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal([]entities.OrderAmendment(a))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (a *orderAmendments) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into order amendments", value)
+	}
+	return json.Unmarshal(data, (*[]entities.OrderAmendment)(a))
+}
+
+// personalData is a column holding personal data of the customer. SQL logs show it redacted, see
+// persistence.GormZapLogger.ParamsFilter.
+type personalData string
+
+// Value implements driver.Valuer
+func (p personalData) Value() (driver.Value, error) {
+	return string(p), nil
+}
+
+// Scan implements sql.Scanner
+func (p *personalData) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*p = ""
+	case []byte:
+		*p = personalData(v)
+	case string:
+		*p = personalData(v)
+	default:
+		return fmt.Errorf("cannot scan %T into personal data", value)
+	}
+	return nil
+}
+
+// LogValue implements slog.LogValuer, hiding the value from logs
+func (p personalData) LogValue() slog.Value {
+	return slog.StringValue("[REDACTED]")
 }
 
 // TableName specifies the table name for GORM
@@ -51,39 +248,218 @@ func (OrderItemModel) TableName() string {
 
 // GormOrderRepository implements the OrderRepository interface using GORM
 type GormOrderRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	config GormOrderRepositoryConfig
+}
+
+// DefaultItemBatchSize is the number of order items written per INSERT when none is configured
+const DefaultItemBatchSize = 100
+
+// GormOrderRepositoryConfig tunes the GORM order repository
+type GormOrderRepositoryConfig struct {
+	// ItemBatchSize caps the order items written per INSERT statement
+	ItemBatchSize int
 }
 
 // NewGormOrderRepository creates a new GORM order repository
 func NewGormOrderRepository(db *gorm.DB) ports.OrderRepository {
-	return &GormOrderRepository{db: db}
+	return NewGormOrderRepositoryWithConfig(db, GormOrderRepositoryConfig{})
+}
+
+// NewGormOrderRepositoryWithConfig creates a new GORM order repository, zero config values fall back to the defaults
+func NewGormOrderRepositoryWithConfig(db *gorm.DB, config GormOrderRepositoryConfig) ports.OrderRepository {
+	if config.ItemBatchSize <= 0 {
+		config.ItemBatchSize = DefaultItemBatchSize
+	}
+	return &GormOrderRepository{db: db, config: config}
+}
+
+// customerLockNamespace keeps the per-customer advisory locks apart from other advisory lock users
+const customerLockNamespace int64 = 0x6f726473
+
+// conn returns the transaction carried by ctx, or the connection pool when there is none
+func (r *GormOrderRepository) conn(ctx context.Context) *gorm.DB {
+	return transaction.Conn(ctx, r.db)
 }
 
 // Create implements ports.OrderRepository
 func (r *GormOrderRepository) Create(ctx context.Context, order *entities.Order) (*entities.Order, error) {
 	gormModel := r.toModel(order)
+	stampCreated(gormModel, time.Now())
 
 	// Create order with items in a transaction
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Create(gormModel).Error; err != nil {
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Items").Create(gormModel).Error; err != nil {
 			return err
 		}
-		return nil
+		return r.insertItems(tx, gormModel)
 	})
 
 	if err != nil {
 		return nil, r.handleError(err)
 	}
 
-	// Reload to get all generated IDs
-	return r.GetByID(ctx, gormModel.ID)
+	return r.storedEntity(ctx, gormModel)
+}
+
+// stampCreated writes the timestamps of the order rather than leaving them to autoCreateTime, so an
+// imported order keeps the times it had. Its items share them, only timestamps left zero are set to now.
+func stampCreated(model *OrderModel, now time.Time) {
+	if model.CreatedAt.IsZero() {
+		model.CreatedAt = now
+	}
+	if model.UpdatedAt.IsZero() {
+		model.UpdatedAt = model.CreatedAt
+	}
+	for i := range model.Items {
+		model.Items[i].CreatedAt = model.CreatedAt
+		model.Items[i].UpdatedAt = model.UpdatedAt
+	}
+}
+
+// insertItems writes the items of model in batches of the configured size
+func (r *GormOrderRepository) insertItems(tx *gorm.DB, model *OrderModel) error {
+	if len(model.Items) == 0 {
+		return nil
+	}
+	for i := range model.Items {
+		model.Items[i].OrderID = model.ID
+	}
+	return tx.CreateInBatches(&model.Items, r.config.ItemBatchSize).Error
+}
+
+// publicIDIndex is the unique index guarding public IDs
+const publicIDIndex = "idx_orders_public_id"
+
+// GetByPublicID implements ports.OrderRepository
+func (r *GormOrderRepository) GetByPublicID(ctx context.Context, publicID string) (*entities.Order, error) {
+	var model OrderModel
+
+	err := r.conn(ctx).
+		Preload("Items").
+		Where("public_id = ?", publicID).
+		First(&model).Error
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	return r.toEntity(&model), nil
+}
+
+// externalReferenceIndex is the unique index guarding external references per customer
+const externalReferenceIndex = "idx_orders_customer_external_reference"
+
+// GetByExternalReference implements ports.OrderRepository
+func (r *GormOrderRepository) GetByExternalReference(ctx context.Context, customerID uint, reference string) (*entities.Order, error) {
+	var model OrderModel
+
+	err := r.conn(ctx).
+		Preload("Items").
+		Where("customer_id = ? AND external_reference = ?", customerID, reference).
+		First(&model).Error
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	return r.toEntity(&model), nil
+}
+
+// orderNumberIndex is the unique index guarding order numbers
+const orderNumberIndex = "idx_orders_order_number"
+
+// GetByOrderNumber implements ports.OrderRepository
+func (r *GormOrderRepository) GetByOrderNumber(ctx context.Context, number string) (*entities.Order, error) {
+	var model OrderModel
+
+	err := r.conn(ctx).
+		Preload("Items").
+		Where("order_number = ?", number).
+		First(&model).Error
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	return r.toEntity(&model), nil
+}
+
+// OrderNumberSequenceModel holds the last value handed out by an order number sequence
+type OrderNumberSequenceModel struct {
+	Scope string `gorm:"primarykey;size:64"`
+	Value uint64 `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (OrderNumberSequenceModel) TableName() string {
+	return "order_number_sequences"
+}
+
+// NextOrderNumberSequence implements ports.OrderRepository. The row of the scope is created or
+// incremented in a single upsert, so concurrent callers never receive the same value.
+func (r *GormOrderRepository) NextOrderNumberSequence(ctx context.Context, scope string) (uint64, error) {
+	model := OrderNumberSequenceModel{Scope: scope, Value: 1}
+
+	err := r.conn(ctx).
+		Clauses(
+			clause.OnConflict{
+				Columns:   []clause.Column{{Name: "scope"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{"value": gorm.Expr("order_number_sequences.value + 1")}),
+			},
+			clause.Returning{Columns: []clause.Column{{Name: "value"}}},
+		).
+		Create(&model).Error
+	if err != nil {
+		return 0, r.handleError(err)
+	}
+
+	return model.Value, nil
 }
 
 // GetByID implements ports.OrderRepository
 func (r *GormOrderRepository) GetByID(ctx context.Context, id uint) (*entities.Order, error) {
 	var model OrderModel
 
-	err := r.db.WithContext(ctx).
+	err := r.snapshot(ctx, func(tx *gorm.DB) error {
+		return tx.
+			Preload("Items").
+			Where("id = ?", id).
+			First(&model).Error
+	})
+
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	return r.toEntity(&model), nil
+}
+
+// GetByIDIncludingDeleted implements ports.OrderRepository
+func (r *GormOrderRepository) GetByIDIncludingDeleted(ctx context.Context, id uint) (*entities.Order, error) {
+	var model OrderModel
+
+	err := r.conn(ctx).
+		Unscoped().
+		Preload("Items").
+		Where("id = ?", id).
+		First(&model).Error
+
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	return r.toEntity(&model), nil
+}
+
+// GetByIDForUpdate implements ports.OrderRepository with SELECT ... FOR UPDATE on the order row.
+// Dialects without row locks, such as SQLite, read the order unlocked.
+func (r *GormOrderRepository) GetByIDForUpdate(ctx context.Context, id uint) (*entities.Order, error) {
+	var model OrderModel
+
+	query := r.conn(ctx)
+	if supportsRowLocks(r.db) {
+		query = query.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate})
+	}
+
+	err := query.
 		Preload("Items").
 		Where("id = ?", id).
 		First(&model).Error
@@ -95,22 +471,51 @@ func (r *GormOrderRepository) GetByID(ctx context.Context, id uint) (*entities.O
 	return r.toEntity(&model), nil
 }
 
+// supportsRowLocks reports whether the dialect of db understands SELECT ... FOR UPDATE
+func supportsRowLocks(db *gorm.DB) bool {
+	return db.Dialector == nil || db.Dialector.Name() != "sqlite"
+}
+
 // Update implements ports.OrderRepository
 func (r *GormOrderRepository) Update(ctx context.Context, order *entities.Order) (*entities.Order, error) {
 	gormModel := r.toModel(order)
 
 	// Update order and items in a transaction
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
 		// Update order fields
-		if err := tx.Model(&OrderModel{}).
+		gormModel.UpdatedAt = time.Now()
+		result := tx.Model(&OrderModel{}).
 			Where("id = ?", gormModel.ID).
 			Updates(map[string]interface{}{
-				"customer_id":  gormModel.CustomerID,
-				"total_amount": gormModel.TotalAmount,
-				"status":       gormModel.Status,
-				"updated_at":   time.Now(),
-			}).Error; err != nil {
-			return err
+				"customer_id":              gormModel.CustomerID,
+				"total_amount":             gormModel.TotalAmount,
+				"cancelled_items":          gormModel.CancelledItems,
+				"amendment_count":          gormModel.AmendmentCount,
+				"amendments":               gormModel.Amendments,
+				"total_weight_grams":       gormModel.TotalWeightGrams,
+				"refunded_amount":          gormModel.RefundedAmount,
+				"status":                   gormModel.Status,
+				"held_from_status":         gormModel.HeldFromStatus,
+				"hold_reason":              gormModel.HoldReason,
+				"shipping_method":          gormModel.ShippingMethod,
+				"estimated_delivery_at":    gormModel.EstimatedDeliveryAt,
+				"carrier":                  gormModel.Carrier,
+				"tracking_number":          gormModel.TrackingNumber,
+				"priority":                 gormModel.Priority,
+				"customer_email":           gormModel.CustomerEmail,
+				"customer_name":            gormModel.CustomerName,
+				"payment_authorization_id": gormModel.PaymentAuthorizationID,
+				"payment_status":           gormModel.PaymentStatus,
+				"reservation_skipped":      gormModel.ReservationSkipped,
+				"item_set_hash":            gormModel.ItemSetHash,
+				"expires_at":               gormModel.ExpiresAt,
+				"updated_at":               gormModel.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domainErrors.ErrOrderNotFound
 		}
 
 		// Delete existing items
@@ -119,28 +524,31 @@ func (r *GormOrderRepository) Update(ctx context.Context, order *entities.Order)
 		}
 
 		// Insert updated items
-		if len(gormModel.Items) > 0 {
-			for i := range gormModel.Items {
-				gormModel.Items[i].OrderID = gormModel.ID
-			}
-			if err := tx.Create(&gormModel.Items).Error; err != nil {
-				return err
-			}
-		}
-
-		return nil
+		return r.insertItems(tx, gormModel)
 	})
 
 	if err != nil {
 		return nil, r.handleError(err)
 	}
 
-	return r.GetByID(ctx, order.ID)
+	return r.storedEntity(ctx, gormModel)
+}
+
+// storedEntity converts a model that was just written back to an entity. GORM fills in the
+// generated IDs of inserted rows, so the order is only reloaded when the driver did not report them.
+func (r *GormOrderRepository) storedEntity(ctx context.Context, model *OrderModel) (*entities.Order, error) {
+	for _, item := range model.Items {
+		if item.ID == 0 {
+			return r.GetByID(ctx, model.ID)
+		}
+	}
+
+	return r.toEntity(model), nil
 }
 
 // Delete implements ports.OrderRepository
 func (r *GormOrderRepository) Delete(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Delete(&OrderModel{}, id)
+	result := r.conn(ctx).Delete(&OrderModel{}, id)
 	if result.Error != nil {
 		return r.handleError(result.Error)
 	}
@@ -152,11 +560,63 @@ func (r *GormOrderRepository) Delete(ctx context.Context, id uint) error {
 	return nil
 }
 
+// Restore implements ports.OrderRepository
+func (r *GormOrderRepository) Restore(ctx context.Context, id uint) (*entities.Order, error) {
+	result := r.conn(ctx).
+		Unscoped().
+		Model(&OrderModel{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return nil, r.handleError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, domainErrors.ErrOrderNotFound
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+// ListDeleted implements ports.OrderRepository
+func (r *GormOrderRepository) ListDeleted(ctx context.Context, limit, offset int) ([]*entities.Order, error) {
+	var models []OrderModel
+
+	err := r.conn(ctx).
+		Unscoped().
+		Preload("Items").
+		Where("deleted_at IS NOT NULL").
+		Limit(limit).
+		Offset(offset).
+		Order("deleted_at DESC, id DESC").
+		Find(&models).Error
+
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	return r.toEntities(models), nil
+}
+
+// CountDeleted implements ports.OrderRepository
+func (r *GormOrderRepository) CountDeleted(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.conn(ctx).
+		Unscoped().
+		Model(&OrderModel{}).
+		Where("deleted_at IS NOT NULL").
+		Count(&count).Error
+	if err != nil {
+		return 0, r.handleError(err)
+	}
+	return count, nil
+}
+
 // List implements ports.OrderRepository
 func (r *GormOrderRepository) List(ctx context.Context, limit, offset int) ([]*entities.Order, error) {
 	var models []OrderModel
 
-	err := r.db.WithContext(ctx).
+	err := r.conn(ctx).
 		Preload("Items").
 		Limit(limit).
 		Offset(offset).
@@ -174,7 +634,7 @@ func (r *GormOrderRepository) List(ctx context.Context, limit, offset int) ([]*e
 func (r *GormOrderRepository) GetByCustomerID(ctx context.Context, customerID uint, limit, offset int) ([]*entities.Order, error) {
 	var models []OrderModel
 
-	err := r.db.WithContext(ctx).
+	err := r.conn(ctx).
 		Preload("Items").
 		Where("customer_id = ?", customerID).
 		Limit(limit).
@@ -193,7 +653,7 @@ func (r *GormOrderRepository) GetByCustomerID(ctx context.Context, customerID ui
 func (r *GormOrderRepository) GetByStatus(ctx context.Context, status entities.OrderStatus, limit, offset int) ([]*entities.Order, error) {
 	var models []OrderModel
 
-	err := r.db.WithContext(ctx).
+	err := r.conn(ctx).
 		Preload("Items").
 		Where("status = ?", string(status)).
 		Limit(limit).
@@ -208,10 +668,104 @@ func (r *GormOrderRepository) GetByStatus(ctx context.Context, status entities.O
 	return r.toEntities(models), nil
 }
 
+// priorityRank ranks the priority column like entities.OrderPriority.Rank
+const priorityRank = "CASE priority WHEN 'urgent' THEN 3 WHEN 'high' THEN 2 WHEN 'low' THEN 0 ELSE 1 END"
+
+// ListByFilterOrderedByPriority implements ports.OrderRepository
+func (r *GormOrderRepository) ListByFilterOrderedByPriority(ctx context.Context, filter ports.OrderFilter, limit, offset int) ([]*entities.Order, error) {
+	var models []OrderModel
+
+	err := r.applyFilter(r.conn(ctx), filter).
+		Preload("Items").
+		Limit(limit).
+		Offset(offset).
+		Order(priorityRank + " DESC, created_at ASC, id ASC").
+		Find(&models).Error
+
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	return r.toEntities(models), nil
+}
+
+// ListRecentByItemSet implements ports.OrderRepository
+func (r *GormOrderRepository) ListRecentByItemSet(ctx context.Context, customerID uint, itemSetHash string, since time.Time) ([]*entities.Order, error) {
+	var models []OrderModel
+
+	err := r.conn(ctx).
+		Preload("Items").
+		Where("customer_id = ? AND item_set_hash = ? AND created_at >= ?", customerID, itemSetHash, since).
+		Order("created_at DESC, id DESC").
+		Find(&models).Error
+
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	return r.toEntities(models), nil
+}
+
+// GetByCustomerIDAndStatus implements ports.OrderRepository
+func (r *GormOrderRepository) GetByCustomerIDAndStatus(ctx context.Context, customerID uint, status entities.OrderStatus, limit, offset int) ([]*entities.Order, error) {
+	var models []OrderModel
+
+	err := r.conn(ctx).
+		Preload("Items").
+		Where("customer_id = ? AND status = ?", customerID, string(status)).
+		Limit(limit).
+		Offset(offset).
+		Order("created_at DESC").
+		Find(&models).Error
+
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	return r.toEntities(models), nil
+}
+
+// ListByDateRange implements ports.OrderRepository
+func (r *GormOrderRepository) ListByDateRange(ctx context.Context, from, to time.Time, limit, offset int) ([]*entities.Order, error) {
+	var models []OrderModel
+
+	query := r.applyFilter(r.conn(ctx), ports.OrderFilter{CreatedFrom: from, CreatedBefore: to})
+	err := query.
+		Preload("Items").
+		Limit(limit).
+		Offset(offset).
+		Order("created_at DESC").
+		Find(&models).Error
+
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	return r.toEntities(models), nil
+}
+
+// FindExpiredPending implements ports.OrderRepository
+func (r *GormOrderRepository) FindExpiredPending(ctx context.Context, before time.Time, limit int) ([]*entities.Order, error) {
+	var models []OrderModel
+
+	err := r.conn(ctx).
+		Preload("Items").
+		Where("status = ? AND expires_at IS NOT NULL AND expires_at <= ?", string(entities.OrderStatusPending), before).
+		Limit(limit).
+		Order("expires_at ASC").
+		Find(&models).Error
+
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	return r.toEntities(models), nil
+}
+
 // Count implements ports.OrderRepository
 func (r *GormOrderRepository) Count(ctx context.Context) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&OrderModel{}).Count(&count).Error
+	err := r.conn(ctx).Model(&OrderModel{}).Count(&count).Error
 	if err != nil {
 		return 0, r.handleError(err)
 	}
@@ -221,7 +775,7 @@ func (r *GormOrderRepository) Count(ctx context.Context) (int64, error) {
 // CountByCustomerID implements ports.OrderRepository
 func (r *GormOrderRepository) CountByCustomerID(ctx context.Context, customerID uint) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := r.conn(ctx).
 		Model(&OrderModel{}).
 		Where("customer_id = ?", customerID).
 		Count(&count).Error
@@ -234,7 +788,7 @@ func (r *GormOrderRepository) CountByCustomerID(ctx context.Context, customerID
 // CountByStatus implements ports.OrderRepository
 func (r *GormOrderRepository) CountByStatus(ctx context.Context, status entities.OrderStatus) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := r.conn(ctx).
 		Model(&OrderModel{}).
 		Where("status = ?", string(status)).
 		Count(&count).Error
@@ -244,16 +798,330 @@ func (r *GormOrderRepository) CountByStatus(ctx context.Context, status entities
 	return count, nil
 }
 
+// CountByFilter implements ports.OrderRepository
+func (r *GormOrderRepository) CountByFilter(ctx context.Context, filter ports.OrderFilter) (int64, error) {
+	var count int64
+	query := r.applyFilter(r.conn(ctx).Model(&OrderModel{}), filter)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, r.handleError(err)
+	}
+	return count, nil
+}
+
+// CountByCustomerIDAndStatus implements ports.OrderRepository
+func (r *GormOrderRepository) CountByCustomerIDAndStatus(ctx context.Context, customerID uint, status entities.OrderStatus) (int64, error) {
+	var count int64
+	err := r.conn(ctx).
+		Model(&OrderModel{}).
+		Where("customer_id = ? AND status = ?", customerID, string(status)).
+		Count(&count).Error
+	if err != nil {
+		return 0, r.handleError(err)
+	}
+	return count, nil
+}
+
+// WithCustomerLock implements ports.OrderRepository. On Postgres the lock is a transaction scoped
+// advisory lock, so concurrent callers for the same customer run fn one after another. SQLite
+// allows a single writer at a time, there fn runs in a plain transaction.
+func (r *GormOrderRepository) WithCustomerLock(ctx context.Context, customerID uint, fn func(ctx context.Context) error) error {
+	ctx, hooks := ports.WithCommitHooks(ctx)
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if isPostgres(r.db) {
+			key := customerLockNamespace<<32 | int64(customerID)
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", key).Error; err != nil {
+				return r.handleError(err)
+			}
+		}
+		return fn(transaction.WithTx(ctx, tx))
+	})
+	if err == nil {
+		hooks.Run()
+	}
+	return err
+}
+
+// isPostgres reports whether db talks to Postgres
+func isPostgres(db *gorm.DB) bool {
+	return db.Dialector != nil && db.Dialector.Name() == "postgres"
+}
+
+// snapshotTxOptions asks for a read only transaction whose statements share one snapshot. Postgres runs it
+// REPEATABLE READ, SQLite transactions see a single snapshot already and its driver ignores the options.
+var snapshotTxOptions = &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+
+// snapshot runs read in the transaction of ctx, or in a read only transaction of its own so the statements
+// of read, such as an order and the preload of its items, see the same state
+func (r *GormOrderRepository) snapshot(ctx context.Context, read func(tx *gorm.DB) error) error {
+	if transaction.Active(ctx) {
+		return read(r.conn(ctx))
+	}
+	return r.db.WithContext(ctx).Transaction(read, snapshotTxOptions)
+}
+
+// ListByFilter implements ports.OrderRepository. The count and the page run in one transaction,
+// read only REPEATABLE READ on Postgres, or in the caller's transaction when ctx carries one.
+func (r *GormOrderRepository) ListByFilter(ctx context.Context, filter ports.OrderFilter, limit, offset int) ([]*entities.Order, int64, error) {
+	var models []OrderModel
+	var total int64
+
+	list := func(tx *gorm.DB) error {
+		if err := r.applyFilter(tx.Model(&OrderModel{}), filter).Count(&total).Error; err != nil {
+			return err
+		}
+		return r.applyFilter(tx, filter).
+			Preload("Items").
+			Limit(limit).
+			Offset(offset).
+			Order("created_at DESC").
+			Find(&models).Error
+	}
+
+	if err := r.snapshot(ctx, list); err != nil {
+		return nil, 0, r.handleError(err)
+	}
+
+	return r.toEntities(models), total, nil
+}
+
+// StreamByFilter implements ports.OrderRepository
+func (r *GormOrderRepository) StreamByFilter(ctx context.Context, filter ports.OrderFilter, batchSize int, fn func(order *entities.Order) error) error {
+	var models []OrderModel
+	// fnErr keeps the callback's error apart from database errors, it is returned unchanged
+	var fnErr error
+
+	query := r.applyFilter(r.conn(ctx).Model(&OrderModel{}), filter)
+	result := query.
+		Preload("Items").
+		FindInBatches(&models, batchSize, func(tx *gorm.DB, batch int) error {
+			for i := range models {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				if fnErr = fn(r.toEntity(&models[i])); fnErr != nil {
+					return fnErr
+				}
+			}
+			return nil
+		})
+
+	if fnErr != nil {
+		return fnErr
+	}
+	if result.Error != nil {
+		return r.handleError(result.Error)
+	}
+	return nil
+}
+
+// CountItemsByFilter implements ports.OrderRepository
+func (r *GormOrderRepository) CountItemsByFilter(ctx context.Context, filter ports.OrderFilter) (int64, error) {
+	var count int64
+
+	query := r.conn(ctx).
+		Model(&OrderModel{}).
+		Joins("LEFT JOIN order_items ON order_items.order_id = orders.id")
+
+	err := r.applyFilter(query, filter).Count(&count).Error
+	if err != nil {
+		return 0, r.handleError(err)
+	}
+	return count, nil
+}
+
+// MaxUpdatedAt implements ports.OrderRepository
+func (r *GormOrderRepository) MaxUpdatedAt(ctx context.Context, filter ports.OrderFilter) (time.Time, error) {
+	var row struct {
+		UpdatedAt aggregateTime
+		DeletedAt aggregateTime
+	}
+
+	// Soft deleted orders are read too, a deletion changes the lists they were in
+	filter.Status = ""
+	filter.Backordered = false
+	query := r.conn(ctx).
+		Unscoped().
+		Model(&OrderModel{}).
+		Select("MAX(orders.updated_at) AS updated_at, MAX(orders.deleted_at) AS deleted_at")
+
+	if err := r.applyFilter(query, filter).Find(&row).Error; err != nil {
+		return time.Time{}, r.handleError(err)
+	}
+
+	latest := time.Time(row.UpdatedAt)
+	if deletedAt := time.Time(row.DeletedAt); deletedAt.After(latest) {
+		latest = deletedAt
+	}
+	return latest, nil
+}
+
+// AggregateByStatus implements ports.OrderRepository
+func (r *GormOrderRepository) AggregateByStatus(ctx context.Context, filter ports.OrderFilter) ([]ports.StatusAggregate, error) {
+	var rows []struct {
+		Status         string
+		Orders         int64
+		Revenue        float64
+		FirstCreatedAt aggregateTime
+		LastCreatedAt  aggregateTime
+	}
+
+	query := r.conn(ctx).
+		Model(&OrderModel{}).
+		Select("orders.status AS status, COUNT(*) AS orders, COALESCE(SUM(orders.total_amount), 0) AS revenue, " +
+			"MIN(orders.created_at) AS first_created_at, MAX(orders.created_at) AS last_created_at").
+		Group("orders.status").
+		Order("orders.status")
+
+	if err := r.applyFilter(query, filter).Scan(&rows).Error; err != nil {
+		return nil, r.handleError(err)
+	}
+
+	aggregates := make([]ports.StatusAggregate, 0, len(rows))
+	for _, row := range rows {
+		aggregates = append(aggregates, ports.StatusAggregate{
+			Status:         entities.OrderStatus(row.Status),
+			Orders:         row.Orders,
+			Revenue:        row.Revenue,
+			FirstCreatedAt: time.Time(row.FirstCreatedAt),
+			LastCreatedAt:  time.Time(row.LastCreatedAt),
+		})
+	}
+	return aggregates, nil
+}
+
+// AggregateByDay implements ports.OrderRepository
+func (r *GormOrderRepository) AggregateByDay(ctx context.Context, filter ports.OrderFilter) ([]ports.DailyAggregate, error) {
+	var rows []struct {
+		Day     aggregateTime
+		Orders  int64
+		Revenue float64
+	}
+
+	query := r.conn(ctx).
+		Model(&OrderModel{}).
+		Select("DATE(orders.created_at) AS day, COUNT(*) AS orders, COALESCE(SUM(orders.total_amount), 0) AS revenue").
+		Group("DATE(orders.created_at)").
+		Order("day")
+
+	if err := r.applyFilter(query, filter).Scan(&rows).Error; err != nil {
+		return nil, r.handleError(err)
+	}
+
+	aggregates := make([]ports.DailyAggregate, 0, len(rows))
+	for _, row := range rows {
+		aggregates = append(aggregates, ports.DailyAggregate{
+			Day:     time.Time(row.Day),
+			Orders:  row.Orders,
+			Revenue: row.Revenue,
+		})
+	}
+	return aggregates, nil
+}
+
+// aggregateTime scans the time an aggregate such as MAX(created_at) or DATE(created_at) returns. Postgres
+// returns a time, SQLite loses the column type in aggregates and returns the text it stores. NULL scans
+// as the zero time.
+type aggregateTime time.Time
+
+// aggregateTimeLayouts are the layouts SQLite stores times in, and the layout of DATE
+var aggregateTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// Scan implements sql.Scanner
+func (t *aggregateTime) Scan(value any) error {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		*t = aggregateTime{}
+		return nil
+	case time.Time:
+		*t = aggregateTime(v)
+		return nil
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	default:
+		return fmt.Errorf("cannot scan %T into a time", value)
+	}
+
+	for _, layout := range aggregateTimeLayouts {
+		if parsed, err := time.Parse(layout, text); err == nil {
+			*t = aggregateTime(parsed)
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse %q as a time", text)
+}
+
+// applyFilter adds the WHERE clauses for the non-zero fields of filter
+func (r *GormOrderRepository) applyFilter(query *gorm.DB, filter ports.OrderFilter) *gorm.DB {
+	if filter.CustomerID != 0 {
+		query = query.Where("orders.customer_id = ?", filter.CustomerID)
+	}
+	if filter.Status != "" {
+		query = query.Where("orders.status = ?", string(filter.Status))
+	}
+	if !filter.CreatedFrom.IsZero() {
+		query = query.Where("orders.created_at >= ?", filter.CreatedFrom)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query = query.Where("orders.created_at < ?", filter.CreatedBefore)
+	}
+	if filter.Backordered {
+		query = query.Where("EXISTS (SELECT 1 FROM order_items backordered WHERE backordered.order_id = orders.id AND backordered.backordered = ?)", true)
+	}
+	return query
+}
+
 // Helper functions for conversion between domain entities and GORM models
 
 func (r *GormOrderRepository) toModel(order *entities.Order) *OrderModel {
 	model := &OrderModel{
-		ID:          order.ID,
-		CustomerID:  order.CustomerID,
-		TotalAmount: order.TotalAmount,
-		Status:      string(order.Status),
-		CreatedAt:   order.CreatedAt,
-		UpdatedAt:   order.UpdatedAt,
+		ID:                     order.ID,
+		CustomerID:             order.CustomerID,
+		Tags:                   orderTags(order.Tags),
+		CancelledItems:         cancelledItems(order.CancelledItems),
+		AmendmentCount:         order.AmendmentCount,
+		Amendments:             orderAmendments(order.Amendments),
+		TotalAmount:            order.TotalAmount,
+		TotalWeightGrams:       order.TotalWeightGrams,
+		RefundedAmount:         order.RefundedAmount,
+		Status:                 string(order.Status),
+		HeldFromStatus:         string(order.HeldFromStatus),
+		HoldReason:             order.HoldReason,
+		ExpiresAt:              order.ExpiresAt,
+		ShippingMethod:         string(order.ShippingMethod),
+		EstimatedDeliveryAt:    order.EstimatedDeliveryAt,
+		Carrier:                order.Carrier,
+		TrackingNumber:         order.TrackingNumber,
+		Priority:               string(order.Priority),
+		CustomerEmail:          personalData(order.CustomerEmail),
+		CustomerName:           personalData(order.CustomerName),
+		PaymentAuthorizationID: order.PaymentAuthorizationID,
+		PaymentStatus:          string(order.PaymentStatus),
+		ReservationSkipped:     order.ReservationSkipped,
+		ItemSetHash:            order.ItemSetHash(),
+		CreatedAt:              order.CreatedAt,
+		UpdatedAt:              order.UpdatedAt,
+	}
+
+	if order.ExternalReference != "" {
+		reference := order.ExternalReference
+		model.ExternalReference = &reference
+	}
+	if order.OrderNumber != "" {
+		number := order.OrderNumber
+		model.OrderNumber = &number
+	}
+	if order.PublicID != "" {
+		publicID := order.PublicID
+		model.PublicID = &publicID
 	}
 
 	// Convert items
@@ -261,14 +1129,18 @@ func (r *GormOrderRepository) toModel(order *entities.Order) *OrderModel {
 		model.Items = make([]OrderItemModel, 0, len(order.Items))
 		for _, item := range order.Items {
 			model.Items = append(model.Items, OrderItemModel{
-				ID:          item.ID,
-				OrderID:     order.ID,
-				ProductID:   item.ProductID,
-				ProductSKU:  item.ProductSKU,
-				ProductName: item.ProductName,
-				Quantity:    item.Quantity,
-				UnitPrice:   item.UnitPrice,
-				TotalPrice:  item.TotalPrice,
+				ID:               item.ID,
+				OrderID:          order.ID,
+				ProductID:        item.ProductID,
+				ProductSKU:       item.ProductSKU,
+				ProductName:      item.ProductName,
+				Quantity:         item.Quantity,
+				UnitPrice:        item.UnitPrice,
+				TotalPrice:       item.TotalPrice,
+				Attributes:       itemAttributes(item.Attributes),
+				UnitWeightGrams:  item.UnitWeightGrams,
+				ReservedQuantity: item.ReservedQuantity,
+				Backordered:      item.Backordered,
 			})
 		}
 	}
@@ -278,12 +1150,45 @@ func (r *GormOrderRepository) toModel(order *entities.Order) *OrderModel {
 
 func (r *GormOrderRepository) toEntity(model *OrderModel) *entities.Order {
 	order := &entities.Order{
-		ID:          model.ID,
-		CustomerID:  model.CustomerID,
-		TotalAmount: model.TotalAmount,
-		Status:      entities.OrderStatus(model.Status),
-		CreatedAt:   model.CreatedAt,
-		UpdatedAt:   model.UpdatedAt,
+		ID:                     model.ID,
+		CustomerID:             model.CustomerID,
+		Tags:                   model.Tags,
+		CancelledItems:         model.CancelledItems,
+		AmendmentCount:         model.AmendmentCount,
+		Amendments:             model.Amendments,
+		TotalAmount:            model.TotalAmount,
+		TotalWeightGrams:       model.TotalWeightGrams,
+		RefundedAmount:         model.RefundedAmount,
+		Status:                 entities.OrderStatus(model.Status),
+		HeldFromStatus:         entities.OrderStatus(model.HeldFromStatus),
+		HoldReason:             model.HoldReason,
+		ExpiresAt:              model.ExpiresAt,
+		ShippingMethod:         entities.ShippingMethod(model.ShippingMethod),
+		EstimatedDeliveryAt:    model.EstimatedDeliveryAt,
+		Carrier:                model.Carrier,
+		TrackingNumber:         model.TrackingNumber,
+		Priority:               entities.OrderPriority(model.Priority),
+		CustomerEmail:          string(model.CustomerEmail),
+		CustomerName:           string(model.CustomerName),
+		PaymentAuthorizationID: model.PaymentAuthorizationID,
+		PaymentStatus:          entities.PaymentStatus(model.PaymentStatus),
+		ReservationSkipped:     model.ReservationSkipped,
+		CreatedAt:              model.CreatedAt,
+		UpdatedAt:              model.UpdatedAt,
+	}
+
+	if model.ExternalReference != nil {
+		order.ExternalReference = *model.ExternalReference
+	}
+	if model.OrderNumber != nil {
+		order.OrderNumber = *model.OrderNumber
+	}
+	if model.PublicID != nil {
+		order.PublicID = *model.PublicID
+	}
+	if model.DeletedAt.Valid {
+		deletedAt := model.DeletedAt.Time
+		order.DeletedAt = &deletedAt
 	}
 
 	// Convert items
@@ -291,13 +1196,17 @@ func (r *GormOrderRepository) toEntity(model *OrderModel) *entities.Order {
 		order.Items = make([]entities.OrderItem, 0, len(model.Items))
 		for _, item := range model.Items {
 			order.Items = append(order.Items, entities.OrderItem{
-				ID:          item.ID,
-				ProductID:   item.ProductID,
-				ProductSKU:  item.ProductSKU,
-				ProductName: item.ProductName,
-				Quantity:    item.Quantity,
-				UnitPrice:   item.UnitPrice,
-				TotalPrice:  item.TotalPrice,
+				ID:               item.ID,
+				ProductID:        item.ProductID,
+				ProductSKU:       item.ProductSKU,
+				ProductName:      item.ProductName,
+				Quantity:         item.Quantity,
+				UnitPrice:        item.UnitPrice,
+				TotalPrice:       item.TotalPrice,
+				Attributes:       item.Attributes,
+				UnitWeightGrams:  item.UnitWeightGrams,
+				ReservedQuantity: item.ReservedQuantity,
+				Backordered:      item.Backordered,
 			})
 		}
 	} else {
@@ -321,21 +1230,26 @@ func (r *GormOrderRepository) handleError(err error) error {
 		return nil
 	}
 
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return domainErrors.ErrOrderNotFound
+	// The caller is gone or out of time, the failure says nothing about the data
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
 	}
 
-	// Handle foreign key constraint violations
-	if strings.Contains(err.Error(), "foreign key constraint") ||
-		strings.Contains(err.Error(), "FOREIGN KEY constraint") {
-		return domainErrors.NewOrderValidationError("customer_id", "invalid customer ID")
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domainErrors.ErrOrderNotFound
 	}
 
-	// Handle unique constraint violations
-	if errors.Is(err, gorm.ErrDuplicatedKey) ||
-		(err.Error() != "" && (strings.Contains(err.Error(), "duplicate key") ||
-			strings.Contains(err.Error(), "UNIQUE constraint"))) {
-		return domainErrors.ErrOrderAlreadyExists
+	if violation, ok := matchConstraintViolation(err); ok {
+		switch {
+		case violation.kind == foreignKeyConstraint:
+			return domainErrors.WrapDomainError(domainErrors.NewOrderValidationError("customer_id", "invalid customer ID"), err)
+		case violation.violates(externalReferenceIndex):
+			return domainErrors.WrapDomainError(domainErrors.ErrDuplicateExternalReference, err)
+		case violation.violates(orderNumberIndex):
+			return domainErrors.WrapDomainError(domainErrors.ErrDuplicateOrderNumber, err)
+		default:
+			return domainErrors.WrapDomainError(domainErrors.ErrOrderAlreadyExists, err)
+		}
 	}
 
 	// Return wrapped error for other cases
@@ -0,0 +1,613 @@
+package order_repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"orders-service/internal/adapters/persistence/repositorytest"
+	"orders-service/internal/adapters/persistence/transaction"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+// openDryRun opens a Postgres database that records the SQL of every query without connecting
+func openDryRun(t *testing.T) (*gorm.DB, *[]string) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+	return recordQueries(t, db)
+}
+
+func TestGormOrderRepository_Conformance(t *testing.T) {
+	repositorytest.RunOrderRepositoryTests(t, func(t *testing.T) ports.OrderRepository {
+		return NewGormOrderRepository(openSQLite(t))
+	})
+}
+
+func TestGormOrderRepository_GetByIDForUpdate_LocksOrderRow(t *testing.T) {
+	db, statements := openDryRun(t)
+	repo := NewGormOrderRepository(db)
+
+	_, _ = repo.GetByIDForUpdate(context.Background(), 1)
+
+	require.NotEmpty(t, *statements)
+	assert.Contains(t, (*statements)[0], `FROM "orders"`)
+	assert.Contains(t, (*statements)[0], "FOR UPDATE")
+}
+
+func TestGormOrderRepository_GetByID_DoesNotLock(t *testing.T) {
+	db, statements := openDryRun(t)
+	repo := NewGormOrderRepository(db)
+
+	// Outside a transaction GetByID opens one, which the dry run cannot, so the read joins one
+	_, _ = repo.GetByID(transaction.WithTx(context.Background(), db), 1)
+
+	require.NotEmpty(t, *statements)
+	assert.NotContains(t, (*statements)[0], "FOR UPDATE")
+}
+
+func TestGormOrderRepository_ListByDateRange_OpenEnd(t *testing.T) {
+	db, statements := openDryRun(t)
+	repo := NewGormOrderRepository(db)
+
+	_, _ = repo.ListByDateRange(context.Background(), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Time{}, 10, 0)
+
+	require.NotEmpty(t, *statements)
+	assert.Contains(t, (*statements)[0], "orders.created_at >=")
+	assert.NotContains(t, (*statements)[0], "orders.created_at <")
+}
+
+func TestGormOrderRepository_MaxUpdatedAt_ReadsDeletedOrdersWithoutStatus(t *testing.T) {
+	db, statements := openDryRun(t)
+	repo := NewGormOrderRepository(db)
+
+	_, _ = repo.MaxUpdatedAt(context.Background(), ports.OrderFilter{CustomerID: 1, Status: entities.OrderStatusPending})
+
+	require.NotEmpty(t, *statements)
+	assert.Contains(t, (*statements)[0], "MAX(orders.updated_at)")
+	assert.Contains(t, (*statements)[0], "orders.customer_id =")
+	assert.NotContains(t, (*statements)[0], "orders.status")
+	assert.NotContains(t, (*statements)[0], "deleted_at\" IS NULL")
+}
+
+func TestOrderModel_CreatedAtStatusIndex(t *testing.T) {
+	db, _ := openDryRun(t)
+	require.NoError(t, db.Statement.Parse(&OrderModel{}))
+
+	index := db.Statement.Schema.LookIndex("idx_orders_created_at_status")
+	require.NotNil(t, index)
+	require.Len(t, index.Fields, 2)
+	assert.Equal(t, "created_at", index.Fields[0].DBName)
+	assert.Equal(t, "status", index.Fields[1].DBName)
+}
+
+func TestOrderModel_OrderNumberIndex(t *testing.T) {
+	db, _ := openDryRun(t)
+	require.NoError(t, db.Statement.Parse(&OrderModel{}))
+
+	index := db.Statement.Schema.LookIndex(orderNumberIndex)
+	require.NotNil(t, index)
+	assert.Equal(t, "UNIQUE", index.Class)
+	require.Len(t, index.Fields, 1)
+	assert.Equal(t, "order_number", index.Fields[0].DBName)
+}
+
+func TestOrderModel_PublicIDIndex(t *testing.T) {
+	db, _ := openDryRun(t)
+	require.NoError(t, db.Statement.Parse(&OrderModel{}))
+
+	index := db.Statement.Schema.LookIndex(publicIDIndex)
+	require.NotNil(t, index)
+	assert.Equal(t, "UNIQUE", index.Class)
+	require.Len(t, index.Fields, 1)
+	assert.Equal(t, "public_id", index.Fields[0].DBName)
+}
+
+func TestGormOrderRepository_NextOrderNumberSequence_Upserts(t *testing.T) {
+	sqlDB := sql.OpenDB(connector{&returningDriver{}})
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: gormLogger.Discard})
+	require.NoError(t, err)
+	var statement string
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:record_insert", func(tx *gorm.DB) {
+		statement = tx.Statement.SQL.String()
+	}))
+	repo := NewGormOrderRepository(db)
+
+	value, err := repo.NextOrderNumberSequence(context.Background(), "ORD-2025")
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), value)
+	assert.Contains(t, statement, `INSERT INTO "order_number_sequences"`)
+	assert.Contains(t, statement, `ON CONFLICT ("scope") DO UPDATE`)
+	assert.Contains(t, statement, `RETURNING "value"`)
+}
+
+// returningDriver answers INSERT ... RETURNING with generated IDs and every other query with no rows,
+// standing in for Postgres where only the statements issued matter
+type returningDriver struct {
+	mu     sync.Mutex
+	nextID int64
+	txs    []driver.TxOptions
+}
+
+func (d *returningDriver) Open(string) (driver.Conn, error) {
+	return &returningConn{driver: d}, nil
+}
+
+type returningConn struct {
+	driver *returningDriver
+}
+
+func (c *returningConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *returningConn) Close() error                        { return nil }
+func (c *returningConn) Begin() (driver.Tx, error)           { return c, nil }
+func (c *returningConn) Commit() error                       { return nil }
+func (c *returningConn) Rollback() error                     { return nil }
+
+func (c *returningConn) BeginTx(_ context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	c.driver.txs = append(c.driver.txs, opts)
+	return c, nil
+}
+
+func (c *returningConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return execResult{}, nil
+}
+
+// execResult reports one affected row and, like Postgres, no last insert ID
+type execResult struct{}
+
+func (execResult) LastInsertId() (int64, error) { return 0, nil }
+func (execResult) RowsAffected() (int64, error) { return 1, nil }
+
+func (c *returningConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if !strings.Contains(query, "RETURNING") {
+		return &idRows{}, nil
+	}
+
+	// One generated value per inserted tuple, named after the returned column
+	column := "id"
+	if _, returning, ok := strings.Cut(query, `RETURNING "`); ok {
+		column, _, _ = strings.Cut(returning, `"`)
+	}
+	rows := &idRows{columns: []string{column}}
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	for i := 0; i < strings.Count(query, "),(")+1; i++ {
+		c.driver.nextID++
+		rows.ids = append(rows.ids, c.driver.nextID)
+	}
+	return rows, nil
+}
+
+type idRows struct {
+	columns []string
+	ids     []int64
+}
+
+func (r *idRows) Columns() []string { return r.columns }
+func (r *idRows) Close() error      { return nil }
+
+func (r *idRows) Next(dest []driver.Value) error {
+	if len(r.ids) == 0 {
+		return io.EOF
+	}
+	dest[0], r.ids = r.ids[0], r.ids[1:]
+	return nil
+}
+
+// openCounting opens a Postgres database backed by returningDriver that counts the statements GORM issues
+func openCounting(t testing.TB, config postgres.Config) (*gorm.DB, map[string]int) {
+	t.Helper()
+	sqlDB := sql.OpenDB(connector{&returningDriver{}})
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	config.Conn = sqlDB
+	db, err := gorm.Open(postgres.New(config), &gorm.Config{Logger: gormLogger.Discard})
+	require.NoError(t, err)
+	return db, countStatements(t, db)
+}
+
+// countStatements counts the inserts, selects, updates and deletes GORM issues through db
+func countStatements(t testing.TB, db *gorm.DB) map[string]int {
+	t.Helper()
+	counts := map[string]int{}
+	count := func(kind string) func(*gorm.DB) {
+		return func(*gorm.DB) { counts[kind]++ }
+	}
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:count_create", count("insert")))
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:count_query", count("select")))
+	require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:count_update", count("update")))
+	require.NoError(t, db.Callback().Delete().After("gorm:delete").Register("test:count_delete", count("delete")))
+	return counts
+}
+
+type connector struct {
+	driver *returningDriver
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) { return c.driver.Open("") }
+func (c connector) Driver() driver.Driver                        { return c.driver }
+
+func TestGormOrderRepository_ListByFilter_ReadsOneSnapshot(t *testing.T) {
+	drv := &returningDriver{}
+	sqlDB := sql.OpenDB(connector{drv})
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: gormLogger.Discard})
+	require.NoError(t, err)
+	db, statements := recordQueries(t, db)
+	repo := NewGormOrderRepository(db)
+
+	_, total, err := repo.ListByFilter(context.Background(), ports.OrderFilter{Status: entities.OrderStatusPending}, 10, 0)
+
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	require.Len(t, drv.txs, 1)
+	assert.Equal(t, driver.IsolationLevel(sql.LevelRepeatableRead), drv.txs[0].Isolation)
+	assert.True(t, drv.txs[0].ReadOnly)
+	require.Len(t, *statements, 2)
+	assert.Contains(t, (*statements)[0], "count(*)")
+	assert.Contains(t, (*statements)[1], "LIMIT")
+	for _, statement := range *statements {
+		assert.Contains(t, statement, "orders.status =")
+	}
+}
+
+func TestGormOrderRepository_ListByFilter_JoinsCallerTransaction(t *testing.T) {
+	drv := &returningDriver{}
+	sqlDB := sql.OpenDB(connector{drv})
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: gormLogger.Discard})
+	require.NoError(t, err)
+	repo := NewGormOrderRepository(db)
+
+	err = transaction.NewGormUnitOfWork(db, ports.Repositories{Orders: repo}).Do(context.Background(), func(ctx context.Context, repos ports.Repositories) error {
+		_, _, err := repos.Orders.ListByFilter(ctx, ports.OrderFilter{}, 10, 0)
+		return err
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, drv.txs, 1, "no second transaction is opened")
+}
+
+// recordQueries registers a callback keeping the SQL of every query run through db
+func recordQueries(t *testing.T, db *gorm.DB) (*gorm.DB, *[]string) {
+	t.Helper()
+	var statements []string
+	err := db.Callback().Query().After("gorm:query").Register("test:record_sql", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	})
+	require.NoError(t, err)
+	return db, &statements
+}
+
+func newTestOrder(t *testing.T) *entities.Order {
+	t.Helper()
+	order, err := entities.NewOrder(123)
+	require.NoError(t, err)
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 2, 10))
+	require.NoError(t, order.AddItem(2, "SKU-002", "Product 2", 1, 5))
+	return order
+}
+
+func TestGormOrderRepository_Create_DoesNotReload(t *testing.T) {
+	db, counts := openCounting(t, postgres.Config{})
+	repo := NewGormOrderRepository(db)
+
+	created, err := repo.Create(context.Background(), newTestOrder(t))
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, counts["select"])
+	assert.Equal(t, 2, counts["insert"], "the order and its items")
+	assert.NotZero(t, created.ID)
+	require.Len(t, created.Items, 2)
+	assert.NotZero(t, created.Items[0].ID)
+	assert.NotEqual(t, created.Items[0].ID, created.Items[1].ID)
+	assert.Equal(t, 25.0, created.TotalAmount)
+}
+
+func TestGormOrderRepository_Create_KeepsHistoricalTimestamps(t *testing.T) {
+	db, _ := openCounting(t, postgres.Config{})
+	var written []time.Time
+	require.NoError(t, db.Callback().Create().Before("gorm:create").Register("test:record_times", func(tx *gorm.DB) {
+		switch model := tx.Statement.Dest.(type) {
+		case *OrderModel:
+			written = append(written, model.CreatedAt, model.UpdatedAt)
+		case []OrderItemModel:
+			for _, item := range model {
+				written = append(written, item.CreatedAt, item.UpdatedAt)
+			}
+		}
+	}))
+	repo := NewGormOrderRepository(db)
+
+	createdAt := time.Date(2023, 5, 1, 9, 30, 0, 0, time.UTC)
+	order := newTestOrder(t)
+	require.NoError(t, order.RestoreHistory(entities.OrderStatusDelivered, createdAt, createdAt, createdAt.AddDate(1, 0, 0)))
+
+	created, err := repo.Create(context.Background(), order)
+
+	require.NoError(t, err)
+	assert.Equal(t, createdAt, created.CreatedAt)
+	assert.Equal(t, createdAt, created.UpdatedAt)
+	require.Len(t, written, 6, "the order and its two items")
+	for _, at := range written {
+		assert.Equal(t, createdAt, at)
+	}
+}
+
+// newLargeOrder builds a pending order with one line per product
+func newLargeOrder(t testing.TB, lines int) *entities.Order {
+	t.Helper()
+	order, err := entities.NewOrder(123)
+	require.NoError(t, err)
+	for i := 1; i <= lines; i++ {
+		require.NoError(t, order.AddItem(uint(i), fmt.Sprintf("SKU-%03d", i), "Product", 1, 1))
+	}
+	return order
+}
+
+func TestGormOrderRepository_Create_InsertsItemsInBatches(t *testing.T) {
+	db := openSQLite(t)
+	counts := countStatements(t, db)
+	repo := NewGormOrderRepositoryWithConfig(db, GormOrderRepositoryConfig{ItemBatchSize: 50})
+
+	created, err := repo.Create(context.Background(), newLargeOrder(t, 200))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1+4, counts["insert"], "the order and four batches of 50 items")
+	assert.Equal(t, 0, counts["select"])
+	require.Len(t, created.Items, 200)
+	for _, item := range created.Items {
+		assert.NotZero(t, item.ID)
+	}
+
+	reloaded, err := repo.GetByID(context.Background(), created.ID)
+	require.NoError(t, err)
+	assert.Len(t, reloaded.Items, 200)
+}
+
+func TestGormOrderRepository_Update_InsertsItemsInBatches(t *testing.T) {
+	db := openSQLite(t)
+	repo := NewGormOrderRepositoryWithConfig(db, GormOrderRepositoryConfig{ItemBatchSize: 50})
+	created, err := repo.Create(context.Background(), newLargeOrder(t, 1))
+	require.NoError(t, err)
+	counts := countStatements(t, db)
+
+	order := newLargeOrder(t, 120)
+	order.ID = created.ID
+	_, err = repo.Update(context.Background(), order)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, counts["insert"], "120 items in batches of 50")
+	reloaded, err := repo.GetByID(context.Background(), created.ID)
+	require.NoError(t, err)
+	assert.Len(t, reloaded.Items, 120)
+}
+
+func BenchmarkGormOrderRepository_Create(b *testing.B) {
+	for _, lines := range []int{1, 50, 500} {
+		b.Run(fmt.Sprintf("items=%d", lines), func(b *testing.B) {
+			repo := NewGormOrderRepository(openSQLite(b))
+			order := newLargeOrder(b, lines)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// Every order stored needs a public ID of its own
+				clone := order.Clone()
+				clone.PublicID = entities.NewPublicID()
+				if _, err := repo.Create(context.Background(), clone); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGormOrderRepository_GetByID(b *testing.B) {
+	for _, lines := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("items=%d", lines), func(b *testing.B) {
+			const seeded = 100
+			repo := NewGormOrderRepository(openSQLite(b))
+			order := newLargeOrder(b, lines)
+			ids := make([]uint, 0, seeded)
+			for i := 0; i < seeded; i++ {
+				clone := order.Clone()
+				clone.PublicID = entities.NewPublicID()
+				created, err := repo.Create(context.Background(), clone)
+				if err != nil {
+					b.Fatal(err)
+				}
+				ids = append(ids, created.ID)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.GetByID(context.Background(), ids[i%seeded]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestGormOrderRepository_Update_DoesNotReload(t *testing.T) {
+	db, counts := openCounting(t, postgres.Config{})
+	repo := NewGormOrderRepository(db)
+
+	order := newTestOrder(t)
+	order.ID = 7
+	require.NoError(t, order.AddItem(3, "SKU-003", "Product 3", 1, 1))
+
+	updated, err := repo.Update(context.Background(), order)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, counts["select"])
+	assert.Equal(t, 1, counts["update"])
+	assert.Equal(t, 1, counts["delete"])
+	assert.Equal(t, 1, counts["insert"], "the replaced items in one batch")
+	assert.Equal(t, uint(7), updated.ID)
+	require.Len(t, updated.Items, 3)
+	for _, item := range updated.Items {
+		assert.NotZero(t, item.ID)
+	}
+	assert.False(t, updated.UpdatedAt.IsZero())
+}
+
+func TestGormOrderRepository_Update_ReloadsWithoutReportedIDs(t *testing.T) {
+	db, counts := openCounting(t, postgres.Config{WithoutReturning: true})
+	repo := NewGormOrderRepository(db)
+
+	order := newTestOrder(t)
+	order.ID = 7
+
+	// The stand-in database has no rows to reload, the attempt is what matters
+	_, _ = repo.Update(context.Background(), order)
+
+	assert.Equal(t, 1, counts["select"])
+}
+
+func TestGormOrderRepository_WithCustomerLock_TakesAdvisoryLockOnPostgres(t *testing.T) {
+	db, _ := openCounting(t, postgres.Config{})
+	var statements []string
+	require.NoError(t, db.Callback().Raw().After("gorm:raw").Register("test:record_raw", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}))
+	repo := NewGormOrderRepository(db)
+
+	err := repo.WithCustomerLock(context.Background(), 42, func(ctx context.Context) error { return nil })
+
+	require.NoError(t, err)
+	require.Len(t, statements, 1)
+	assert.Contains(t, statements[0], "pg_advisory_xact_lock")
+}
+
+// openStreaming opens a SQLite database holding total orders and records the size of every batch of orders
+// loaded from it
+func openStreaming(t *testing.T, total int) (*GormOrderRepository, *[]int) {
+	t.Helper()
+	db := openSQLite(t)
+
+	orders := make([]OrderModel, total)
+	for i := range orders {
+		orders[i] = OrderModel{CustomerID: uint(i%7 + 1), Status: string(entities.OrderStatusPending)}
+	}
+	require.NoError(t, db.CreateInBatches(orders, 500).Error)
+
+	var batches []int
+	err := db.Callback().Query().After("gorm:query").Register("test:record_batches", func(tx *gorm.DB) {
+		if tx.Statement.Table == "orders" {
+			batches = append(batches, int(tx.RowsAffected))
+		}
+	})
+	require.NoError(t, err)
+	return NewGormOrderRepository(db).(*GormOrderRepository), &batches
+}
+
+func TestGormOrderRepository_StreamByFilter_LoadsBoundedBatches(t *testing.T) {
+	repo, batches := openStreaming(t, 3000)
+
+	var visited int64
+	err := repo.StreamByFilter(context.Background(), ports.OrderFilter{}, 500, func(order *entities.Order) error {
+		visited++
+		assert.Equal(t, uint(visited), order.ID)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), visited)
+	// Six full batches, then an empty one ends the iteration
+	assert.Equal(t, []int{500, 500, 500, 500, 500, 500, 0}, *batches)
+}
+
+func TestGormOrderRepository_StreamByFilter_StopsOnCallbackError(t *testing.T) {
+	repo, batches := openStreaming(t, 3000)
+	stop := errors.New("client went away")
+
+	visited := 0
+	err := repo.StreamByFilter(context.Background(), ports.OrderFilter{}, 500, func(order *entities.Order) error {
+		visited++
+		if visited == 750 {
+			return stop
+		}
+		return nil
+	})
+
+	assert.Same(t, stop, err)
+	assert.Equal(t, 750, visited)
+	assert.Len(t, *batches, 2)
+}
+
+func TestGormOrderRepository_StreamByFilter_StopsWhenCancelled(t *testing.T) {
+	repo, _ := openStreaming(t, 3000)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	visited := 0
+	err := repo.StreamByFilter(ctx, ports.OrderFilter{}, 500, func(order *entities.Order) error {
+		visited++
+		if visited == 10 {
+			cancel()
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, domainErrors.ErrRequestCancelled)
+	assert.Equal(t, 10, visited)
+}
+
+func TestItemAttributes_ValueAndScan(t *testing.T) {
+	// Given
+	attributes := itemAttributes{"size": "M", "color": "red"}
+
+	// When
+	value, err := attributes.Value()
+	require.NoError(t, err)
+	var fromString, fromBytes itemAttributes
+	require.NoError(t, fromString.Scan(value))
+	require.NoError(t, fromBytes.Scan([]byte(value.(string))))
+
+	// Then
+	assert.Equal(t, attributes, fromString)
+	assert.Equal(t, attributes, fromBytes)
+}
+
+func TestItemAttributes_EmptyIsNull(t *testing.T) {
+	// When
+	value, err := itemAttributes(nil).Value()
+	require.NoError(t, err)
+	attributes := itemAttributes{"size": "M"}
+	require.NoError(t, attributes.Scan(nil))
+
+	// Then
+	assert.Nil(t, value)
+	assert.Nil(t, attributes)
+	assert.Error(t, attributes.Scan(42))
+}
+
+// openSQLite opens a SQLite database with the order tables
+func openSQLite(t testing.TB) *gorm.DB {
+	t.Helper()
+	return repositorytest.OpenSQLite(t, &OrderModel{}, &OrderItemModel{}, &OrderNumberSequenceModel{})
+}
@@ -0,0 +1,1035 @@
+package order_repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collections of the MongoDB order repository
+const (
+	ordersCollection        = "orders"
+	countersCollection      = "counters"
+	customerLocksCollection = "customer_locks"
+)
+
+// Counters handing out the numeric IDs, order number sequences use orderNumberCounterPrefix + scope
+const (
+	orderIDCounter           = "orders"
+	orderItemIDCounter       = "order_items"
+	orderNumberCounterPrefix = "order_number:"
+)
+
+// customerLockPoll is how often a caller waiting for a customer lock checks whether it was released
+const customerLockPoll = 20 * time.Millisecond
+
+// orderDocument is an order as stored in the orders collection, with its items embedded. The
+// identifiers are left out when empty so the partial unique indexes skip orders without one.
+type orderDocument struct {
+	ID                     uint                    `bson:"_id"`
+	PublicID               string                  `bson:"public_id,omitempty"`
+	CustomerID             uint                    `bson:"customer_id"`
+	ExternalReference      string                  `bson:"external_reference,omitempty"`
+	OrderNumber            string                  `bson:"order_number,omitempty"`
+	Tags                   []string                `bson:"tags,omitempty"`
+	Items                  []orderItemDocument     `bson:"items"`
+	CancelledItems         []cancelledItemDocument `bson:"cancelled_items"`
+	AmendmentCount         int                     `bson:"amendment_count"`
+	Amendments             []amendmentDocument     `bson:"amendments"`
+	TotalAmount            float64                 `bson:"total_amount"`
+	TotalWeightGrams       *int                    `bson:"total_weight_grams"`
+	RefundedAmount         float64                 `bson:"refunded_amount"`
+	Status                 string                  `bson:"status"`
+	HeldFromStatus         string                  `bson:"held_from_status"`
+	HoldReason             string                  `bson:"hold_reason"`
+	ExpiresAt              *time.Time              `bson:"expires_at"`
+	ShippingMethod         string                  `bson:"shipping_method"`
+	EstimatedDeliveryAt    *time.Time              `bson:"estimated_delivery_at"`
+	Carrier                string                  `bson:"carrier"`
+	TrackingNumber         string                  `bson:"tracking_number"`
+	Priority               string                  `bson:"priority"`
+	PriorityRank           int                     `bson:"priority_rank"` // entities.OrderPriority.Rank, orders the fulfillment queue
+	CustomerEmail          string                  `bson:"customer_email"`
+	CustomerName           string                  `bson:"customer_name"`
+	PaymentAuthorizationID string                  `bson:"payment_authorization_id"`
+	PaymentStatus          string                  `bson:"payment_status"`
+	ReservationSkipped     bool                    `bson:"reservation_skipped"`
+	ItemSetHash            string                  `bson:"item_set_hash"` // entities.ItemSetHash of the items, updated on every write
+	CreatedAt              time.Time               `bson:"created_at"`
+	UpdatedAt              time.Time               `bson:"updated_at"`
+	DeletedAt              *time.Time              `bson:"deleted_at"` // null until the order is soft deleted
+}
+
+// orderItemDocument is a line of an orderDocument
+type orderItemDocument struct {
+	ID               uint              `bson:"id"`
+	ProductID        uint              `bson:"product_id"`
+	ProductSKU       string            `bson:"product_sku"`
+	ProductName      string            `bson:"product_name"`
+	Quantity         int               `bson:"quantity"`
+	UnitPrice        float64           `bson:"unit_price"`
+	TotalPrice       float64           `bson:"total_price"`
+	Attributes       map[string]string `bson:"attributes,omitempty"`
+	UnitWeightGrams  *int              `bson:"unit_weight_grams"`
+	ReservedQuantity int               `bson:"reserved_quantity"`
+	Backordered      bool              `bson:"backordered"`
+}
+
+// cancelledItemDocument is an entities.CancelledItem of an orderDocument
+type cancelledItemDocument struct {
+	ProductID   uint      `bson:"product_id"`
+	ProductSKU  string    `bson:"product_sku"`
+	ProductName string    `bson:"product_name"`
+	Quantity    int       `bson:"quantity"`
+	UnitPrice   float64   `bson:"unit_price"`
+	CancelledAt time.Time `bson:"cancelled_at"`
+}
+
+// amendmentDocument is an entities.OrderAmendment of an orderDocument
+type amendmentDocument struct {
+	Number    int                  `bson:"number"`
+	Reason    string               `bson:"reason"`
+	Changes   []itemChangeDocument `bson:"changes"`
+	AmendedAt time.Time            `bson:"amended_at"`
+}
+
+// itemChangeDocument is an entities.ItemChange of an amendmentDocument
+type itemChangeDocument struct {
+	ProductID       uint              `bson:"product_id"`
+	ProductSKU      string            `bson:"product_sku"`
+	ProductName     string            `bson:"product_name"`
+	Attributes      map[string]string `bson:"attributes,omitempty"`
+	QuantityBefore  int               `bson:"quantity_before"`
+	QuantityAfter   int               `bson:"quantity_after"`
+	UnitPriceBefore float64           `bson:"unit_price_before"`
+	UnitPriceAfter  float64           `bson:"unit_price_after"`
+}
+
+// counterDocument holds the last value handed out by a counter of the counters collection
+type counterDocument struct {
+	Name  string `bson:"_id"`
+	Value uint64 `bson:"value"`
+}
+
+// customerLockDocument is a customer lock held by the caller that wrote token until it expires
+type customerLockDocument struct {
+	CustomerID uint      `bson:"_id"`
+	Token      string    `bson:"token"`
+	ExpiresAt  time.Time `bson:"expires_at"`
+}
+
+// DefaultCustomerLockLease is how long a customer lock is held when no lease is configured
+const DefaultCustomerLockLease = 30 * time.Second
+
+// MongoOrderRepositoryConfig tunes the MongoDB order repository
+type MongoOrderRepositoryConfig struct {
+	// CustomerLockLease is how long WithCustomerLock holds a lock before another caller may take it over
+	CustomerLockLease time.Duration
+}
+
+// MongoOrderRepository implements the OrderRepository interface on MongoDB. An order is a single
+// document with its items embedded, numeric IDs come from the counters collection and deleted
+// orders are kept with deleted_at set.
+//
+// MongoDB writes do not join the SQL transactions of a unit of work, every write is applied as it
+// happens. There are no row locks either: GetByIDForUpdate reads the order unlocked, callers needing
+// mutual exclusion use WithCustomerLock.
+type MongoOrderRepository struct {
+	orders   *mongo.Collection
+	counters *mongo.Collection
+	locks    *mongo.Collection
+	config   MongoOrderRepositoryConfig
+}
+
+// NewMongoOrderRepository creates a MongoDB order repository on db, see EnsureMongoIndexes
+func NewMongoOrderRepository(db *mongo.Database) ports.OrderRepository {
+	return NewMongoOrderRepositoryWithConfig(db, MongoOrderRepositoryConfig{})
+}
+
+// NewMongoOrderRepositoryWithConfig creates a MongoDB order repository, zero config values fall back to the defaults
+func NewMongoOrderRepositoryWithConfig(db *mongo.Database, config MongoOrderRepositoryConfig) ports.OrderRepository {
+	if config.CustomerLockLease <= 0 {
+		config.CustomerLockLease = DefaultCustomerLockLease
+	}
+	return &MongoOrderRepository{
+		orders:   db.Collection(ordersCollection),
+		counters: db.Collection(countersCollection),
+		locks:    db.Collection(customerLocksCollection),
+		config:   config,
+	}
+}
+
+// EnsureMongoIndexes creates the indexes of the MongoDB order repository, among them the unique
+// indexes guarding public IDs, order numbers and external references. Existing indexes are kept.
+func EnsureMongoIndexes(ctx context.Context, db *mongo.Database) error {
+	hasString := func(field string) bson.M {
+		return bson.M{field: bson.M{"$type": "string"}}
+	}
+	orderIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "public_id", Value: 1}},
+			Options: options.Index().SetName(publicIDIndex).SetUnique(true).SetPartialFilterExpression(hasString("public_id")),
+		},
+		{
+			Keys:    bson.D{{Key: "order_number", Value: 1}},
+			Options: options.Index().SetName(orderNumberIndex).SetUnique(true).SetPartialFilterExpression(hasString("order_number")),
+		},
+		{
+			Keys:    bson.D{{Key: "customer_id", Value: 1}, {Key: "external_reference", Value: 1}},
+			Options: options.Index().SetName(externalReferenceIndex).SetUnique(true).SetPartialFilterExpression(hasString("external_reference")),
+		},
+		{Keys: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "customer_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "priority_rank", Value: -1}, {Key: "created_at", Value: 1}}},
+		{Keys: bson.D{{Key: "customer_id", Value: 1}, {Key: "item_set_hash", Value: 1}}},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}},
+		{Keys: bson.D{{Key: "deleted_at", Value: -1}}},
+		{Keys: bson.D{{Key: "items.backordered", Value: 1}}},
+	}
+	if _, err := db.Collection(ordersCollection).Indexes().CreateMany(ctx, orderIndexes); err != nil {
+		return err
+	}
+
+	// Locks left behind by a caller that crashed are removed once expired
+	_, err := db.Collection(customerLocksCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	return err
+}
+
+// Create implements ports.OrderRepository
+func (r *MongoOrderRepository) Create(ctx context.Context, order *entities.Order) (*entities.Order, error) {
+	doc := toOrderDocument(order)
+	now := mongoTime(time.Now())
+	if doc.CreatedAt.IsZero() {
+		doc.CreatedAt = now
+	}
+	if doc.UpdatedAt.IsZero() {
+		doc.UpdatedAt = doc.CreatedAt
+	}
+
+	id, err := r.nextValues(ctx, orderIDCounter, 1)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	doc.ID = uint(id)
+	if err := r.assignItemIDs(ctx, doc.Items); err != nil {
+		return nil, r.handleError(err)
+	}
+
+	if _, err := r.orders.InsertOne(ctx, doc); err != nil {
+		return nil, r.handleError(err)
+	}
+	return fromOrderDocument(doc), nil
+}
+
+// GetByID implements ports.OrderRepository
+func (r *MongoOrderRepository) GetByID(ctx context.Context, id uint) (*entities.Order, error) {
+	return r.findOne(ctx, bson.M{"_id": id, "deleted_at": nil})
+}
+
+// GetByIDIncludingDeleted implements ports.OrderRepository
+func (r *MongoOrderRepository) GetByIDIncludingDeleted(ctx context.Context, id uint) (*entities.Order, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+// GetByIDForUpdate implements ports.OrderRepository. MongoDB has no row locks outside transactions,
+// the order is read unlocked.
+func (r *MongoOrderRepository) GetByIDForUpdate(ctx context.Context, id uint) (*entities.Order, error) {
+	return r.GetByID(ctx, id)
+}
+
+// GetByPublicID implements ports.OrderRepository
+func (r *MongoOrderRepository) GetByPublicID(ctx context.Context, publicID string) (*entities.Order, error) {
+	return r.findOne(ctx, bson.M{"public_id": publicID, "deleted_at": nil})
+}
+
+// GetByExternalReference implements ports.OrderRepository
+func (r *MongoOrderRepository) GetByExternalReference(ctx context.Context, customerID uint, reference string) (*entities.Order, error) {
+	return r.findOne(ctx, bson.M{"customer_id": customerID, "external_reference": reference, "deleted_at": nil})
+}
+
+// GetByOrderNumber implements ports.OrderRepository
+func (r *MongoOrderRepository) GetByOrderNumber(ctx context.Context, number string) (*entities.Order, error) {
+	return r.findOne(ctx, bson.M{"order_number": number, "deleted_at": nil})
+}
+
+// NextOrderNumberSequence implements ports.OrderRepository with a counter per scope, incremented atomically
+func (r *MongoOrderRepository) NextOrderNumberSequence(ctx context.Context, scope string) (uint64, error) {
+	value, err := r.nextValues(ctx, orderNumberCounterPrefix+scope, 1)
+	if err != nil {
+		return 0, r.handleError(err)
+	}
+	return value, nil
+}
+
+// Update implements ports.OrderRepository. Like the GORM repository it keeps the stored
+// identifiers, tags and creation time.
+func (r *MongoOrderRepository) Update(ctx context.Context, order *entities.Order) (*entities.Order, error) {
+	doc := toOrderDocument(order)
+	doc.UpdatedAt = mongoTime(time.Now())
+	if err := r.assignItemIDs(ctx, doc.Items); err != nil {
+		return nil, r.handleError(err)
+	}
+
+	set := bson.M{
+		"customer_id":              doc.CustomerID,
+		"items":                    doc.Items,
+		"cancelled_items":          doc.CancelledItems,
+		"amendment_count":          doc.AmendmentCount,
+		"amendments":               doc.Amendments,
+		"total_amount":             doc.TotalAmount,
+		"total_weight_grams":       doc.TotalWeightGrams,
+		"refunded_amount":          doc.RefundedAmount,
+		"status":                   doc.Status,
+		"held_from_status":         doc.HeldFromStatus,
+		"hold_reason":              doc.HoldReason,
+		"expires_at":               doc.ExpiresAt,
+		"shipping_method":          doc.ShippingMethod,
+		"estimated_delivery_at":    doc.EstimatedDeliveryAt,
+		"carrier":                  doc.Carrier,
+		"tracking_number":          doc.TrackingNumber,
+		"priority":                 doc.Priority,
+		"priority_rank":            doc.PriorityRank,
+		"customer_email":           doc.CustomerEmail,
+		"customer_name":            doc.CustomerName,
+		"payment_authorization_id": doc.PaymentAuthorizationID,
+		"payment_status":           doc.PaymentStatus,
+		"reservation_skipped":      doc.ReservationSkipped,
+		"item_set_hash":            doc.ItemSetHash,
+		"updated_at":               doc.UpdatedAt,
+	}
+
+	var stored orderDocument
+	err := r.orders.FindOneAndUpdate(ctx,
+		bson.M{"_id": doc.ID, "deleted_at": nil},
+		bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&stored)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	return fromOrderDocument(&stored), nil
+}
+
+// Delete implements ports.OrderRepository
+func (r *MongoOrderRepository) Delete(ctx context.Context, id uint) error {
+	result, err := r.orders.UpdateOne(ctx,
+		bson.M{"_id": id, "deleted_at": nil},
+		bson.M{"$set": bson.M{"deleted_at": mongoTime(time.Now())}},
+	)
+	if err != nil {
+		return r.handleError(err)
+	}
+	if result.MatchedCount == 0 {
+		return domainErrors.ErrOrderNotFound
+	}
+	return nil
+}
+
+// Restore implements ports.OrderRepository
+func (r *MongoOrderRepository) Restore(ctx context.Context, id uint) (*entities.Order, error) {
+	var stored orderDocument
+	err := r.orders.FindOneAndUpdate(ctx,
+		bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}},
+		bson.M{"$set": bson.M{"deleted_at": nil, "updated_at": mongoTime(time.Now())}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&stored)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	return fromOrderDocument(&stored), nil
+}
+
+// ListDeleted implements ports.OrderRepository
+func (r *MongoOrderRepository) ListDeleted(ctx context.Context, limit, offset int) ([]*entities.Order, error) {
+	mostRecentlyDeleted := bson.D{{Key: "deleted_at", Value: -1}, {Key: "_id", Value: -1}}
+	return r.find(ctx, bson.M{"deleted_at": bson.M{"$ne": nil}}, mostRecentlyDeleted, limit, offset)
+}
+
+// CountDeleted implements ports.OrderRepository
+func (r *MongoOrderRepository) CountDeleted(ctx context.Context) (int64, error) {
+	return r.count(ctx, bson.M{"deleted_at": bson.M{"$ne": nil}})
+}
+
+// newestFirst sorts orders by creation time, newest first, breaking ties by ID
+var newestFirst = bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}
+
+// List implements ports.OrderRepository
+func (r *MongoOrderRepository) List(ctx context.Context, limit, offset int) ([]*entities.Order, error) {
+	return r.find(ctx, mongoFilter(ports.OrderFilter{}), newestFirst, limit, offset)
+}
+
+// GetByCustomerID implements ports.OrderRepository
+func (r *MongoOrderRepository) GetByCustomerID(ctx context.Context, customerID uint, limit, offset int) ([]*entities.Order, error) {
+	return r.find(ctx, mongoFilter(ports.OrderFilter{CustomerID: customerID}), newestFirst, limit, offset)
+}
+
+// GetByStatus implements ports.OrderRepository
+func (r *MongoOrderRepository) GetByStatus(ctx context.Context, status entities.OrderStatus, limit, offset int) ([]*entities.Order, error) {
+	return r.find(ctx, mongoFilter(ports.OrderFilter{Status: status}), newestFirst, limit, offset)
+}
+
+// ListByFilterOrderedByPriority implements ports.OrderRepository
+func (r *MongoOrderRepository) ListByFilterOrderedByPriority(ctx context.Context, filter ports.OrderFilter, limit, offset int) ([]*entities.Order, error) {
+	mostUrgentFirst := bson.D{{Key: "priority_rank", Value: -1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}
+	return r.find(ctx, mongoFilter(filter), mostUrgentFirst, limit, offset)
+}
+
+// ListRecentByItemSet implements ports.OrderRepository
+func (r *MongoOrderRepository) ListRecentByItemSet(ctx context.Context, customerID uint, itemSetHash string, since time.Time) ([]*entities.Order, error) {
+	query := mongoFilter(ports.OrderFilter{CustomerID: customerID, CreatedFrom: since})
+	query["item_set_hash"] = itemSetHash
+	return r.find(ctx, query, newestFirst, -1, 0)
+}
+
+// GetByCustomerIDAndStatus implements ports.OrderRepository
+func (r *MongoOrderRepository) GetByCustomerIDAndStatus(ctx context.Context, customerID uint, status entities.OrderStatus, limit, offset int) ([]*entities.Order, error) {
+	return r.find(ctx, mongoFilter(ports.OrderFilter{CustomerID: customerID, Status: status}), newestFirst, limit, offset)
+}
+
+// ListByDateRange implements ports.OrderRepository
+func (r *MongoOrderRepository) ListByDateRange(ctx context.Context, from, to time.Time, limit, offset int) ([]*entities.Order, error) {
+	return r.find(ctx, mongoFilter(ports.OrderFilter{CreatedFrom: from, CreatedBefore: to}), newestFirst, limit, offset)
+}
+
+// FindExpiredPending implements ports.OrderRepository
+func (r *MongoOrderRepository) FindExpiredPending(ctx context.Context, before time.Time, limit int) ([]*entities.Order, error) {
+	query := mongoFilter(ports.OrderFilter{Status: entities.OrderStatusPending})
+	query["expires_at"] = bson.M{"$lte": before}
+	soonestFirst := bson.D{{Key: "expires_at", Value: 1}, {Key: "_id", Value: 1}}
+	return r.find(ctx, query, soonestFirst, limit, 0)
+}
+
+// Count implements ports.OrderRepository
+func (r *MongoOrderRepository) Count(ctx context.Context) (int64, error) {
+	return r.count(ctx, mongoFilter(ports.OrderFilter{}))
+}
+
+// CountByCustomerID implements ports.OrderRepository
+func (r *MongoOrderRepository) CountByCustomerID(ctx context.Context, customerID uint) (int64, error) {
+	return r.count(ctx, mongoFilter(ports.OrderFilter{CustomerID: customerID}))
+}
+
+// CountByStatus implements ports.OrderRepository
+func (r *MongoOrderRepository) CountByStatus(ctx context.Context, status entities.OrderStatus) (int64, error) {
+	return r.count(ctx, mongoFilter(ports.OrderFilter{Status: status}))
+}
+
+// CountByFilter implements ports.OrderRepository
+func (r *MongoOrderRepository) CountByFilter(ctx context.Context, filter ports.OrderFilter) (int64, error) {
+	return r.count(ctx, mongoFilter(filter))
+}
+
+// CountByCustomerIDAndStatus implements ports.OrderRepository
+func (r *MongoOrderRepository) CountByCustomerIDAndStatus(ctx context.Context, customerID uint, status entities.OrderStatus) (int64, error) {
+	return r.count(ctx, mongoFilter(ports.OrderFilter{CustomerID: customerID, Status: status}))
+}
+
+// mongoCustomerLocksKey carries the customers locked by the caller, so nested calls do not wait for themselves
+type mongoCustomerLocksKey struct{}
+
+// WithCustomerLock implements ports.OrderRepository with a lease in the customer_locks collection,
+// concurrent callers for the same customer run fn one after another. A caller holding the lock past
+// the configured lease loses it to the next one. Writes are applied as they happen, fn returning an
+// error does not roll them back.
+func (r *MongoOrderRepository) WithCustomerLock(ctx context.Context, customerID uint, fn func(ctx context.Context) error) error {
+	held, _ := ctx.Value(mongoCustomerLocksKey{}).(map[uint]bool)
+	if held[customerID] {
+		return fn(ctx)
+	}
+
+	token := primitive.NewObjectID().Hex()
+	if err := r.lockCustomer(ctx, customerID, token); err != nil {
+		return err
+	}
+
+	locked := make(map[uint]bool, len(held)+1)
+	for id := range held {
+		locked[id] = true
+	}
+	locked[customerID] = true
+
+	ctx, hooks := ports.WithCommitHooks(ctx)
+	err := fn(context.WithValue(ctx, mongoCustomerLocksKey{}, locked))
+	// A lock that failed to be released expires with its lease
+	_, _ = r.locks.DeleteOne(context.WithoutCancel(ctx), bson.M{"_id": customerID, "token": token})
+	if err == nil {
+		hooks.Run()
+	}
+	return err
+}
+
+// lockCustomer waits until it takes the lock of customerID, a missing or expired lock is taken over by
+// a single upsert and a lock held by another caller fails it with a duplicate key error
+func (r *MongoOrderRepository) lockCustomer(ctx context.Context, customerID uint, token string) error {
+	for {
+		now := mongoTime(time.Now())
+		_, err := r.locks.UpdateOne(ctx,
+			bson.M{"_id": customerID, "expires_at": bson.M{"$lte": now}},
+			bson.M{"$set": customerLockDocument{CustomerID: customerID, Token: token, ExpiresAt: now.Add(r.config.CustomerLockLease)}},
+			options.Update().SetUpsert(true),
+		)
+		if err == nil {
+			return nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return r.handleError(err)
+		}
+
+		timer := time.NewTimer(customerLockPoll)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return r.handleError(ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// ListByFilter implements ports.OrderRepository. The page and the total come from one aggregation
+// whose $facet stages share the matched orders, so they cannot drift apart.
+func (r *MongoOrderRepository) ListByFilter(ctx context.Context, filter ports.OrderFilter, limit, offset int) ([]*entities.Order, int64, error) {
+	pageStages := bson.A{bson.M{"$sort": newestFirst}, bson.M{"$skip": offset}}
+	if limit >= 0 {
+		// $limit must be positive, a zero limit keeps a page of one that is dropped below
+		pageStages = append(pageStages, bson.M{"$limit": max(limit, 1)})
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: mongoFilter(filter)}},
+		{{Key: "$facet", Value: bson.M{
+			"total": bson.A{bson.M{"$count": "orders"}},
+			"page":  pageStages,
+		}}},
+	}
+
+	var results []struct {
+		Total []struct {
+			Orders int64 `bson:"orders"`
+		} `bson:"total"`
+		Page []orderDocument `bson:"page"`
+	}
+	if err := r.aggregate(ctx, pipeline, &results); err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	var docs []orderDocument
+	if len(results) > 0 {
+		if len(results[0].Total) > 0 {
+			total = results[0].Total[0].Orders
+		}
+		docs = results[0].Page
+	}
+	if limit == 0 {
+		docs = nil
+	}
+	return fromOrderDocuments(docs), total, nil
+}
+
+// StreamByFilter implements ports.OrderRepository, visiting orders by ascending ID through one cursor
+func (r *MongoOrderRepository) StreamByFilter(ctx context.Context, filter ports.OrderFilter, batchSize int, fn func(order *entities.Order) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	if batchSize > 0 {
+		opts.SetBatchSize(int32(min(batchSize, 1<<30)))
+	}
+	cursor, err := r.orders.Find(ctx, mongoFilter(filter), opts)
+	if err != nil {
+		return r.handleError(err)
+	}
+	defer cursor.Close(context.WithoutCancel(ctx))
+
+	for cursor.Next(ctx) {
+		if err := ctx.Err(); err != nil {
+			return r.handleError(err)
+		}
+		var doc orderDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return r.handleError(err)
+		}
+		if err := fn(fromOrderDocument(&doc)); err != nil {
+			return err
+		}
+	}
+	return r.handleError(cursor.Err())
+}
+
+// CountItemsByFilter implements ports.OrderRepository
+func (r *MongoOrderRepository) CountItemsByFilter(ctx context.Context, filter ports.OrderFilter) (int64, error) {
+	lines := bson.M{"$max": bson.A{1, bson.M{"$size": bson.M{"$ifNull": bson.A{"$items", bson.A{}}}}}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: mongoFilter(filter)}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "lines": bson.M{"$sum": lines}}}},
+	}
+
+	var rows []struct {
+		Lines int64 `bson:"lines"`
+	}
+	if err := r.aggregate(ctx, pipeline, &rows); err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return rows[0].Lines, nil
+}
+
+// MaxUpdatedAt implements ports.OrderRepository
+func (r *MongoOrderRepository) MaxUpdatedAt(ctx context.Context, filter ports.OrderFilter) (time.Time, error) {
+	// Soft deleted orders are read too, a deletion changes the lists they were in
+	filter.Status = ""
+	filter.Backordered = false
+	query := mongoFilter(filter)
+	delete(query, "deleted_at")
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: query}},
+		{{Key: "$group", Value: bson.M{
+			"_id":        nil,
+			"updated_at": bson.M{"$max": "$updated_at"},
+			"deleted_at": bson.M{"$max": "$deleted_at"},
+		}}},
+	}
+
+	var rows []struct {
+		UpdatedAt *time.Time `bson:"updated_at"`
+		DeletedAt *time.Time `bson:"deleted_at"`
+	}
+	if err := r.aggregate(ctx, pipeline, &rows); err != nil {
+		return time.Time{}, err
+	}
+
+	var latest time.Time
+	if len(rows) > 0 {
+		if rows[0].UpdatedAt != nil {
+			latest = *rows[0].UpdatedAt
+		}
+		if rows[0].DeletedAt != nil && rows[0].DeletedAt.After(latest) {
+			latest = *rows[0].DeletedAt
+		}
+	}
+	return latest, nil
+}
+
+// AggregateByStatus implements ports.OrderRepository
+func (r *MongoOrderRepository) AggregateByStatus(ctx context.Context, filter ports.OrderFilter) ([]ports.StatusAggregate, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: mongoFilter(filter)}},
+		{{Key: "$group", Value: bson.M{
+			"_id":              "$status",
+			"orders":           bson.M{"$sum": 1},
+			"revenue":          bson.M{"$sum": "$total_amount"},
+			"first_created_at": bson.M{"$min": "$created_at"},
+			"last_created_at":  bson.M{"$max": "$created_at"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+
+	var rows []struct {
+		Status         string    `bson:"_id"`
+		Orders         int64     `bson:"orders"`
+		Revenue        float64   `bson:"revenue"`
+		FirstCreatedAt time.Time `bson:"first_created_at"`
+		LastCreatedAt  time.Time `bson:"last_created_at"`
+	}
+	if err := r.aggregate(ctx, pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	aggregates := make([]ports.StatusAggregate, 0, len(rows))
+	for _, row := range rows {
+		aggregates = append(aggregates, ports.StatusAggregate{
+			Status:         entities.OrderStatus(row.Status),
+			Orders:         row.Orders,
+			Revenue:        row.Revenue,
+			FirstCreatedAt: row.FirstCreatedAt,
+			LastCreatedAt:  row.LastCreatedAt,
+		})
+	}
+	return aggregates, nil
+}
+
+// AggregateByDay implements ports.OrderRepository, days are UTC calendar days
+func (r *MongoOrderRepository) AggregateByDay(ctx context.Context, filter ports.OrderFilter) ([]ports.DailyAggregate, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: mongoFilter(filter)}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     bson.M{"$dateTrunc": bson.M{"date": "$created_at", "unit": "day"}},
+			"orders":  bson.M{"$sum": 1},
+			"revenue": bson.M{"$sum": "$total_amount"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+
+	var rows []struct {
+		Day     time.Time `bson:"_id"`
+		Orders  int64     `bson:"orders"`
+		Revenue float64   `bson:"revenue"`
+	}
+	if err := r.aggregate(ctx, pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	aggregates := make([]ports.DailyAggregate, 0, len(rows))
+	for _, row := range rows {
+		aggregates = append(aggregates, ports.DailyAggregate{
+			Day:     row.Day.UTC(),
+			Orders:  row.Orders,
+			Revenue: row.Revenue,
+		})
+	}
+	return aggregates, nil
+}
+
+// nextValues advances the counter called name by n and returns its new value, the last of the n
+// values handed out. The counter starts at 0 and is created on first use.
+func (r *MongoOrderRepository) nextValues(ctx context.Context, name string, n int) (uint64, error) {
+	var counter counterDocument
+	// Two callers creating the same counter race on its insert, the loser retries the increment
+	for attempt := 0; ; attempt++ {
+		err := r.counters.FindOneAndUpdate(ctx,
+			bson.M{"_id": name},
+			bson.M{"$inc": bson.M{"value": n}},
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		).Decode(&counter)
+		if err == nil {
+			return counter.Value, nil
+		}
+		if attempt > 0 || !mongo.IsDuplicateKeyError(err) {
+			return 0, err
+		}
+	}
+}
+
+// assignItemIDs gives the items without an ID one from the order items counter
+func (r *MongoOrderRepository) assignItemIDs(ctx context.Context, items []orderItemDocument) error {
+	missing := 0
+	for _, item := range items {
+		if item.ID == 0 {
+			missing++
+		}
+	}
+	if missing == 0 {
+		return nil
+	}
+
+	last, err := r.nextValues(ctx, orderItemIDCounter, missing)
+	if err != nil {
+		return err
+	}
+	next := uint(last) - uint(missing) + 1
+	for i := range items {
+		if items[i].ID == 0 {
+			items[i].ID = next
+			next++
+		}
+	}
+	return nil
+}
+
+// findOne returns the order matching query
+func (r *MongoOrderRepository) findOne(ctx context.Context, query bson.M) (*entities.Order, error) {
+	var doc orderDocument
+	if err := r.orders.FindOne(ctx, query).Decode(&doc); err != nil {
+		return nil, r.handleError(err)
+	}
+	return fromOrderDocument(&doc), nil
+}
+
+// find returns a page of the orders matching query in sort order, a negative limit returns every
+// remaining order
+func (r *MongoOrderRepository) find(ctx context.Context, query bson.M, sort bson.D, limit, offset int) ([]*entities.Order, error) {
+	if limit == 0 {
+		return []*entities.Order{}, nil
+	}
+	opts := options.Find().SetSort(sort).SetSkip(int64(max(offset, 0)))
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.orders.Find(ctx, query, opts)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	var docs []orderDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, r.handleError(err)
+	}
+	return fromOrderDocuments(docs), nil
+}
+
+// count returns the number of orders matching query
+func (r *MongoOrderRepository) count(ctx context.Context, query bson.M) (int64, error) {
+	count, err := r.orders.CountDocuments(ctx, query)
+	if err != nil {
+		return 0, r.handleError(err)
+	}
+	return count, nil
+}
+
+// aggregate runs pipeline on the orders collection and decodes every result into results
+func (r *MongoOrderRepository) aggregate(ctx context.Context, pipeline mongo.Pipeline, results any) error {
+	cursor, err := r.orders.Aggregate(ctx, pipeline)
+	if err != nil {
+		return r.handleError(err)
+	}
+	if err := cursor.All(ctx, results); err != nil {
+		return r.handleError(err)
+	}
+	return nil
+}
+
+// mongoFilter returns the query matching the live orders with the non-zero fields of filter
+func mongoFilter(filter ports.OrderFilter) bson.M {
+	query := bson.M{"deleted_at": nil}
+	if filter.CustomerID != 0 {
+		query["customer_id"] = filter.CustomerID
+	}
+	if filter.Status != "" {
+		query["status"] = string(filter.Status)
+	}
+	created := bson.M{}
+	if !filter.CreatedFrom.IsZero() {
+		created["$gte"] = filter.CreatedFrom
+	}
+	if !filter.CreatedBefore.IsZero() {
+		created["$lt"] = filter.CreatedBefore
+	}
+	if len(created) > 0 {
+		query["created_at"] = created
+	}
+	if filter.Backordered {
+		query["items.backordered"] = true
+	}
+	return query
+}
+
+// mongoTime returns t as MongoDB stores it, in UTC with millisecond precision, so an order returned
+// after a write equals the order read back
+func mongoTime(t time.Time) time.Time {
+	return t.Truncate(time.Millisecond).UTC()
+}
+
+// mongoTimePtr is mongoTime for an optional time
+func mongoTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	stored := mongoTime(*t)
+	return &stored
+}
+
+func toOrderDocument(order *entities.Order) *orderDocument {
+	doc := &orderDocument{
+		ID:                     order.ID,
+		PublicID:               order.PublicID,
+		CustomerID:             order.CustomerID,
+		ExternalReference:      order.ExternalReference,
+		OrderNumber:            order.OrderNumber,
+		Tags:                   order.Tags,
+		Items:                  make([]orderItemDocument, 0, len(order.Items)),
+		AmendmentCount:         order.AmendmentCount,
+		TotalAmount:            order.TotalAmount,
+		TotalWeightGrams:       order.TotalWeightGrams,
+		RefundedAmount:         order.RefundedAmount,
+		Status:                 string(order.Status),
+		HeldFromStatus:         string(order.HeldFromStatus),
+		HoldReason:             order.HoldReason,
+		ExpiresAt:              mongoTimePtr(order.ExpiresAt),
+		ShippingMethod:         string(order.ShippingMethod),
+		EstimatedDeliveryAt:    mongoTimePtr(order.EstimatedDeliveryAt),
+		Carrier:                order.Carrier,
+		TrackingNumber:         order.TrackingNumber,
+		Priority:               string(order.Priority),
+		PriorityRank:           order.Priority.Rank(),
+		CustomerEmail:          order.CustomerEmail,
+		CustomerName:           order.CustomerName,
+		PaymentAuthorizationID: order.PaymentAuthorizationID,
+		PaymentStatus:          string(order.PaymentStatus),
+		ReservationSkipped:     order.ReservationSkipped,
+		ItemSetHash:            order.ItemSetHash(),
+		CreatedAt:              mongoTime(order.CreatedAt),
+		UpdatedAt:              mongoTime(order.UpdatedAt),
+		DeletedAt:              mongoTimePtr(order.DeletedAt),
+	}
+
+	for _, item := range order.Items {
+		doc.Items = append(doc.Items, orderItemDocument{
+			ID:               item.ID,
+			ProductID:        item.ProductID,
+			ProductSKU:       item.ProductSKU,
+			ProductName:      item.ProductName,
+			Quantity:         item.Quantity,
+			UnitPrice:        item.UnitPrice,
+			TotalPrice:       item.TotalPrice,
+			Attributes:       item.Attributes,
+			UnitWeightGrams:  item.UnitWeightGrams,
+			ReservedQuantity: item.ReservedQuantity,
+			Backordered:      item.Backordered,
+		})
+	}
+	for _, cancelled := range order.CancelledItems {
+		doc.CancelledItems = append(doc.CancelledItems, cancelledItemDocument{
+			ProductID:   cancelled.ProductID,
+			ProductSKU:  cancelled.ProductSKU,
+			ProductName: cancelled.ProductName,
+			Quantity:    cancelled.Quantity,
+			UnitPrice:   cancelled.UnitPrice,
+			CancelledAt: mongoTime(cancelled.CancelledAt),
+		})
+	}
+	for _, amendment := range order.Amendments {
+		changes := make([]itemChangeDocument, 0, len(amendment.Changes))
+		for _, change := range amendment.Changes {
+			changes = append(changes, itemChangeDocument{
+				ProductID:       change.ProductID,
+				ProductSKU:      change.ProductSKU,
+				ProductName:     change.ProductName,
+				Attributes:      change.Attributes,
+				QuantityBefore:  change.QuantityBefore,
+				QuantityAfter:   change.QuantityAfter,
+				UnitPriceBefore: change.UnitPriceBefore,
+				UnitPriceAfter:  change.UnitPriceAfter,
+			})
+		}
+		doc.Amendments = append(doc.Amendments, amendmentDocument{
+			Number:    amendment.Number,
+			Reason:    amendment.Reason,
+			Changes:   changes,
+			AmendedAt: mongoTime(amendment.AmendedAt),
+		})
+	}
+	return doc
+}
+
+// fromOrderDocument converts a stored document to an order sharing nothing with it
+func fromOrderDocument(doc *orderDocument) *entities.Order {
+	order := &entities.Order{
+		ID:                     doc.ID,
+		PublicID:               doc.PublicID,
+		CustomerID:             doc.CustomerID,
+		ExternalReference:      doc.ExternalReference,
+		OrderNumber:            doc.OrderNumber,
+		Tags:                   doc.Tags,
+		Items:                  make([]entities.OrderItem, 0, len(doc.Items)),
+		AmendmentCount:         doc.AmendmentCount,
+		TotalAmount:            doc.TotalAmount,
+		TotalWeightGrams:       doc.TotalWeightGrams,
+		RefundedAmount:         doc.RefundedAmount,
+		Status:                 entities.OrderStatus(doc.Status),
+		HeldFromStatus:         entities.OrderStatus(doc.HeldFromStatus),
+		HoldReason:             doc.HoldReason,
+		ExpiresAt:              doc.ExpiresAt,
+		ShippingMethod:         entities.ShippingMethod(doc.ShippingMethod),
+		EstimatedDeliveryAt:    doc.EstimatedDeliveryAt,
+		Carrier:                doc.Carrier,
+		TrackingNumber:         doc.TrackingNumber,
+		Priority:               entities.OrderPriority(doc.Priority),
+		CustomerEmail:          doc.CustomerEmail,
+		CustomerName:           doc.CustomerName,
+		PaymentAuthorizationID: doc.PaymentAuthorizationID,
+		PaymentStatus:          entities.PaymentStatus(doc.PaymentStatus),
+		ReservationSkipped:     doc.ReservationSkipped,
+		CreatedAt:              doc.CreatedAt,
+		UpdatedAt:              doc.UpdatedAt,
+		DeletedAt:              doc.DeletedAt,
+	}
+
+	for _, item := range doc.Items {
+		order.Items = append(order.Items, entities.OrderItem{
+			ID:               item.ID,
+			ProductID:        item.ProductID,
+			ProductSKU:       item.ProductSKU,
+			ProductName:      item.ProductName,
+			Quantity:         item.Quantity,
+			UnitPrice:        item.UnitPrice,
+			TotalPrice:       item.TotalPrice,
+			Attributes:       item.Attributes,
+			UnitWeightGrams:  item.UnitWeightGrams,
+			ReservedQuantity: item.ReservedQuantity,
+			Backordered:      item.Backordered,
+		})
+	}
+	for _, cancelled := range doc.CancelledItems {
+		order.CancelledItems = append(order.CancelledItems, entities.CancelledItem{
+			ProductID:   cancelled.ProductID,
+			ProductSKU:  cancelled.ProductSKU,
+			ProductName: cancelled.ProductName,
+			Quantity:    cancelled.Quantity,
+			UnitPrice:   cancelled.UnitPrice,
+			CancelledAt: cancelled.CancelledAt,
+		})
+	}
+	for _, amendment := range doc.Amendments {
+		changes := make([]entities.ItemChange, 0, len(amendment.Changes))
+		for _, change := range amendment.Changes {
+			changes = append(changes, entities.ItemChange{
+				ProductID:       change.ProductID,
+				ProductSKU:      change.ProductSKU,
+				ProductName:     change.ProductName,
+				Attributes:      change.Attributes,
+				QuantityBefore:  change.QuantityBefore,
+				QuantityAfter:   change.QuantityAfter,
+				UnitPriceBefore: change.UnitPriceBefore,
+				UnitPriceAfter:  change.UnitPriceAfter,
+			})
+		}
+		order.Amendments = append(order.Amendments, entities.OrderAmendment{
+			Number:    amendment.Number,
+			Reason:    amendment.Reason,
+			Changes:   changes,
+			AmendedAt: amendment.AmendedAt,
+		})
+	}
+	return order.Clone()
+}
+
+func fromOrderDocuments(docs []orderDocument) []*entities.Order {
+	orders := make([]*entities.Order, 0, len(docs))
+	for i := range docs {
+		orders = append(orders, fromOrderDocument(&docs[i]))
+	}
+	return orders
+}
+
+// handleError converts MongoDB errors to domain errors
+func (r *MongoOrderRepository) handleError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// The caller is gone or out of time, the failure says nothing about the data
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domainErrors.ErrOrderNotFound
+	}
+
+	// Duplicate key errors name the violated index in their message
+	if mongo.IsDuplicateKeyError(err) {
+		switch {
+		case strings.Contains(err.Error(), externalReferenceIndex):
+			return domainErrors.WrapDomainError(domainErrors.ErrDuplicateExternalReference, err)
+		case strings.Contains(err.Error(), orderNumberIndex):
+			return domainErrors.WrapDomainError(domainErrors.ErrDuplicateOrderNumber, err)
+		default:
+			return domainErrors.WrapDomainError(domainErrors.ErrOrderAlreadyExists, err)
+		}
+	}
+
+	return err
+}
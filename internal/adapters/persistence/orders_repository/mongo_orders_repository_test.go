@@ -0,0 +1,228 @@
+package order_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"orders-service/internal/adapters/persistence/repositorytest"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoTestURIEnv names the MongoDB server the MongoDB repository tests run against, they are skipped without one
+const mongoTestURIEnv = "ORDERS_TEST_MONGO_URI"
+
+// openMongo returns an indexed database of its own on the server of mongoTestURIEnv, dropped when the test ends
+func openMongo(t *testing.T) *mongo.Database {
+	t.Helper()
+	uri := os.Getenv(mongoTestURIEnv)
+	if uri == "" {
+		t.Skipf("%s is not set", mongoTestURIEnv)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Disconnect(context.Background()) })
+
+	db := client.Database(fmt.Sprintf("orders_test_%d", time.Now().UnixNano()))
+	t.Cleanup(func() { _ = db.Drop(context.Background()) })
+	require.NoError(t, EnsureMongoIndexes(ctx, db))
+	return db
+}
+
+func TestMongoOrderRepository_Conformance(t *testing.T) {
+	repositorytest.RunOrderRepositoryTests(t, func(t *testing.T) ports.OrderRepository {
+		return NewMongoOrderRepository(openMongo(t))
+	})
+}
+
+func TestMongoOrderRepository_Create_NumbersOrdersAndItemsFromCounters(t *testing.T) {
+	db := openMongo(t)
+	repo := NewMongoOrderRepository(db)
+	ctx := context.Background()
+
+	first, err := repo.Create(ctx, newTestOrder(t))
+	require.NoError(t, err)
+	second, err := repo.Create(ctx, newTestOrder(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, first.ID+1, second.ID)
+	assert.Equal(t, []uint{1, 2}, []uint{first.Items[0].ID, first.Items[1].ID})
+	assert.Equal(t, []uint{3, 4}, []uint{second.Items[0].ID, second.Items[1].ID})
+
+	var counter counterDocument
+	require.NoError(t, db.Collection(countersCollection).FindOne(ctx, bson.M{"_id": orderIDCounter}).Decode(&counter))
+	assert.Equal(t, uint64(second.ID), counter.Value)
+}
+
+func TestMongoOrderRepository_Delete_KeepsDocument(t *testing.T) {
+	db := openMongo(t)
+	repo := NewMongoOrderRepository(db)
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newTestOrder(t))
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Delete(ctx, created.ID))
+
+	var doc orderDocument
+	require.NoError(t, db.Collection(ordersCollection).FindOne(ctx, bson.M{"_id": created.ID}).Decode(&doc))
+	assert.NotNil(t, doc.DeletedAt)
+}
+
+func TestMongoOrderRepository_WithCustomerLock_TakesOverExpiredLease(t *testing.T) {
+	db := openMongo(t)
+	repo := NewMongoOrderRepositoryWithConfig(db, MongoOrderRepositoryConfig{CustomerLockLease: time.Minute})
+	ctx := context.Background()
+	// A caller that crashed while holding the lock
+	_, err := db.Collection(customerLocksCollection).InsertOne(ctx, customerLockDocument{
+		CustomerID: 7,
+		Token:      "crashed",
+		ExpiresAt:  time.Now().Add(-time.Second),
+	})
+	require.NoError(t, err)
+
+	ran := false
+	err = repo.WithCustomerLock(ctx, 7, func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.True(t, ran)
+	count, err := db.Collection(customerLocksCollection).CountDocuments(ctx, bson.M{"_id": 7})
+	require.NoError(t, err)
+	assert.Zero(t, count, "the lock is released")
+}
+
+func TestMongoFilter(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter ports.OrderFilter
+		want   bson.M
+	}{
+		{
+			name:   "no filter reads live orders",
+			filter: ports.OrderFilter{},
+			want:   bson.M{"deleted_at": nil},
+		},
+		{
+			name:   "open end",
+			filter: ports.OrderFilter{CustomerID: 9, CreatedFrom: from},
+			want:   bson.M{"deleted_at": nil, "customer_id": uint(9), "created_at": bson.M{"$gte": from}},
+		},
+		{
+			name: "every field",
+			filter: ports.OrderFilter{
+				CustomerID:    9,
+				Status:        entities.OrderStatusConfirmed,
+				CreatedFrom:   from,
+				CreatedBefore: before,
+				Backordered:   true,
+			},
+			want: bson.M{
+				"deleted_at":        nil,
+				"customer_id":       uint(9),
+				"status":            "confirmed",
+				"created_at":        bson.M{"$gte": from, "$lt": before},
+				"items.backordered": true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mongoFilter(tt.filter))
+		})
+	}
+}
+
+func TestOrderDocument_RoundTrip(t *testing.T) {
+	// Given
+	order := newTestOrder(t)
+	order.ID = 42
+	order.PublicID = "3f1c9a52-7d2e-4b8e-9a61-0c5d2e7f8a14"
+	order.OrderNumber = "ORD-2024-000042"
+	order.Priority = entities.OrderPriorityUrgent
+	order.Items[0].ID = 1
+	order.Items[0].Attributes = map[string]string{"size": "M"}
+	order.Items[1].ID = 2
+	order.Items[1].Backordered = true
+	order.CreatedAt = time.Date(2024, 3, 1, 10, 30, 0, 123456789, time.FixedZone("CET", 3600))
+	order.UpdatedAt = order.CreatedAt
+
+	// When
+	doc := toOrderDocument(order)
+	raw, err := bson.Marshal(doc)
+	require.NoError(t, err)
+	var stored orderDocument
+	require.NoError(t, bson.Unmarshal(raw, &stored))
+	read := fromOrderDocument(&stored)
+
+	// Then
+	assert.Equal(t, entities.OrderPriorityUrgent.Rank(), doc.PriorityRank)
+	assert.Equal(t, order.ItemSetHash(), doc.ItemSetHash)
+	assert.Equal(t, time.Date(2024, 3, 1, 9, 30, 0, 123000000, time.UTC), read.CreatedAt, "stored in UTC with millisecond precision")
+	assert.Equal(t, order.PublicID, read.PublicID)
+	assert.Equal(t, order.OrderNumber, read.OrderNumber)
+	assert.Equal(t, order.TotalAmount, read.TotalAmount)
+	require.Len(t, read.Items, 2)
+	assert.Equal(t, order.Items[0].Attributes, read.Items[0].Attributes)
+	assert.True(t, read.Items[1].Backordered)
+	assert.Nil(t, read.DeletedAt)
+}
+
+func TestOrderDocument_OmitsEmptyIdentifiers(t *testing.T) {
+	order := newTestOrder(t)
+	order.PublicID = ""
+	raw, err := bson.Marshal(toOrderDocument(order))
+	require.NoError(t, err)
+
+	// The partial unique indexes only cover documents holding the field
+	for _, field := range []string{"public_id", "order_number", "external_reference"} {
+		_, err := bson.Raw(raw).LookupErr(field)
+		assert.Error(t, err, field)
+	}
+}
+
+func TestMongoOrderRepository_HandleError(t *testing.T) {
+	repo := &MongoOrderRepository{}
+	duplicate := func(index string) error {
+		return mongo.WriteException{WriteErrors: mongo.WriteErrors{{
+			Code:    11000,
+			Message: "E11000 duplicate key error collection: orders index: " + index + " dup key",
+		}}}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"not found", mongo.ErrNoDocuments, domainErrors.ErrOrderNotFound},
+		{"cancelled", context.Canceled, domainErrors.ErrRequestCancelled},
+		{"external reference", duplicate(externalReferenceIndex), domainErrors.ErrDuplicateExternalReference},
+		{"order number", duplicate(orderNumberIndex), domainErrors.ErrDuplicateOrderNumber},
+		{"public ID", duplicate(publicIDIndex), domainErrors.ErrOrderAlreadyExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, errors.Is(repo.handleError(tt.err), tt.want), "got %v", repo.handleError(tt.err))
+		})
+	}
+	assert.NoError(t, repo.handleError(nil))
+}
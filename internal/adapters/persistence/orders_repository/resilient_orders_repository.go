@@ -0,0 +1,359 @@
+package order_repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"syscall"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Metric names reported by ResilientOrderRepository
+const (
+	RetriesMetric          = "db_retries_total"
+	RetriesExhaustedMetric = "db_retries_exhausted_total"
+)
+
+// RetryPolicy controls how transient database errors are retried
+type RetryPolicy struct {
+	// MaxAttempts includes the first call, values below 2 disable retries
+	MaxAttempts int
+	// BaseDelay is the backoff before the second attempt, it doubles for every further attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff, 0 leaves it uncapped
+	MaxDelay time.Duration
+}
+
+// ResilientOrderRepository retries calls that failed with a transient database error,
+// such as a refused connection during a failover, a serialization failure or a deadlock.
+// Only reads and idempotent writes are retried. Create, Delete, Restore, WithCustomerLock and
+// StreamByFilter may have partially applied and are passed through unchanged.
+// Calls inside a transaction are never retried, the transaction is aborted after an error.
+type ResilientOrderRepository struct {
+	ports.OrderRepository
+	policy    RetryPolicy
+	retries   *metrics.Counter
+	exhausted *metrics.Counter
+	logger    logger.Logger
+}
+
+// NewResilientOrderRepository wraps repo with retries following policy, counting them in registry
+func NewResilientOrderRepository(repo ports.OrderRepository, policy RetryPolicy, registry *metrics.Registry, log logger.Logger) ports.OrderRepository {
+	return &ResilientOrderRepository{
+		OrderRepository: repo,
+		policy:          policy,
+		retries:         registry.Counter(RetriesMetric),
+		exhausted:       registry.Counter(RetriesExhaustedMetric),
+		logger:          log.With("component", "order_repository_retry"),
+	}
+}
+
+// GetByID implements ports.OrderRepository
+func (r *ResilientOrderRepository) GetByID(ctx context.Context, id uint) (*entities.Order, error) {
+	return retry(ctx, r, "GetByID", func() (*entities.Order, error) {
+		return r.OrderRepository.GetByID(ctx, id)
+	})
+}
+
+// GetByIDIncludingDeleted implements ports.OrderRepository
+func (r *ResilientOrderRepository) GetByIDIncludingDeleted(ctx context.Context, id uint) (*entities.Order, error) {
+	return retry(ctx, r, "GetByIDIncludingDeleted", func() (*entities.Order, error) {
+		return r.OrderRepository.GetByIDIncludingDeleted(ctx, id)
+	})
+}
+
+// GetByIDForUpdate implements ports.OrderRepository. Inside a transaction, where the lock
+// is meant to be held, failures are not retried.
+func (r *ResilientOrderRepository) GetByIDForUpdate(ctx context.Context, id uint) (*entities.Order, error) {
+	return retry(ctx, r, "GetByIDForUpdate", func() (*entities.Order, error) {
+		return r.OrderRepository.GetByIDForUpdate(ctx, id)
+	})
+}
+
+// GetByExternalReference implements ports.OrderRepository
+func (r *ResilientOrderRepository) GetByExternalReference(ctx context.Context, customerID uint, reference string) (*entities.Order, error) {
+	return retry(ctx, r, "GetByExternalReference", func() (*entities.Order, error) {
+		return r.OrderRepository.GetByExternalReference(ctx, customerID, reference)
+	})
+}
+
+// GetByPublicID implements ports.OrderRepository
+func (r *ResilientOrderRepository) GetByPublicID(ctx context.Context, publicID string) (*entities.Order, error) {
+	return retry(ctx, r, "GetByPublicID", func() (*entities.Order, error) {
+		return r.OrderRepository.GetByPublicID(ctx, publicID)
+	})
+}
+
+// GetByOrderNumber implements ports.OrderRepository
+func (r *ResilientOrderRepository) GetByOrderNumber(ctx context.Context, number string) (*entities.Order, error) {
+	return retry(ctx, r, "GetByOrderNumber", func() (*entities.Order, error) {
+		return r.OrderRepository.GetByOrderNumber(ctx, number)
+	})
+}
+
+// NextOrderNumberSequence implements ports.OrderRepository. Repeating it after an ambiguous
+// failure may skip a value, which only leaves a gap in the numbers.
+func (r *ResilientOrderRepository) NextOrderNumberSequence(ctx context.Context, scope string) (uint64, error) {
+	return retry(ctx, r, "NextOrderNumberSequence", func() (uint64, error) {
+		return r.OrderRepository.NextOrderNumberSequence(ctx, scope)
+	})
+}
+
+// Update implements ports.OrderRepository. An update writes the complete order state,
+// so repeating it after an ambiguous failure leaves the same result.
+func (r *ResilientOrderRepository) Update(ctx context.Context, order *entities.Order) (*entities.Order, error) {
+	return retry(ctx, r, "Update", func() (*entities.Order, error) {
+		return r.OrderRepository.Update(ctx, order)
+	})
+}
+
+// List implements ports.OrderRepository
+func (r *ResilientOrderRepository) List(ctx context.Context, limit, offset int) ([]*entities.Order, error) {
+	return retry(ctx, r, "List", func() ([]*entities.Order, error) {
+		return r.OrderRepository.List(ctx, limit, offset)
+	})
+}
+
+// ListDeleted implements ports.OrderRepository
+func (r *ResilientOrderRepository) ListDeleted(ctx context.Context, limit, offset int) ([]*entities.Order, error) {
+	return retry(ctx, r, "ListDeleted", func() ([]*entities.Order, error) {
+		return r.OrderRepository.ListDeleted(ctx, limit, offset)
+	})
+}
+
+// GetByCustomerID implements ports.OrderRepository
+func (r *ResilientOrderRepository) GetByCustomerID(ctx context.Context, customerID uint, limit, offset int) ([]*entities.Order, error) {
+	return retry(ctx, r, "GetByCustomerID", func() ([]*entities.Order, error) {
+		return r.OrderRepository.GetByCustomerID(ctx, customerID, limit, offset)
+	})
+}
+
+// GetByStatus implements ports.OrderRepository
+func (r *ResilientOrderRepository) GetByStatus(ctx context.Context, status entities.OrderStatus, limit, offset int) ([]*entities.Order, error) {
+	return retry(ctx, r, "GetByStatus", func() ([]*entities.Order, error) {
+		return r.OrderRepository.GetByStatus(ctx, status, limit, offset)
+	})
+}
+
+// ListByFilterOrderedByPriority implements ports.OrderRepository
+func (r *ResilientOrderRepository) ListByFilterOrderedByPriority(ctx context.Context, filter ports.OrderFilter, limit, offset int) ([]*entities.Order, error) {
+	return retry(ctx, r, "ListByFilterOrderedByPriority", func() ([]*entities.Order, error) {
+		return r.OrderRepository.ListByFilterOrderedByPriority(ctx, filter, limit, offset)
+	})
+}
+
+// ListRecentByItemSet implements ports.OrderRepository
+func (r *ResilientOrderRepository) ListRecentByItemSet(ctx context.Context, customerID uint, itemSetHash string, since time.Time) ([]*entities.Order, error) {
+	return retry(ctx, r, "ListRecentByItemSet", func() ([]*entities.Order, error) {
+		return r.OrderRepository.ListRecentByItemSet(ctx, customerID, itemSetHash, since)
+	})
+}
+
+// GetByCustomerIDAndStatus implements ports.OrderRepository
+func (r *ResilientOrderRepository) GetByCustomerIDAndStatus(ctx context.Context, customerID uint, status entities.OrderStatus, limit, offset int) ([]*entities.Order, error) {
+	return retry(ctx, r, "GetByCustomerIDAndStatus", func() ([]*entities.Order, error) {
+		return r.OrderRepository.GetByCustomerIDAndStatus(ctx, customerID, status, limit, offset)
+	})
+}
+
+// ListByDateRange implements ports.OrderRepository
+func (r *ResilientOrderRepository) ListByDateRange(ctx context.Context, from, to time.Time, limit, offset int) ([]*entities.Order, error) {
+	return retry(ctx, r, "ListByDateRange", func() ([]*entities.Order, error) {
+		return r.OrderRepository.ListByDateRange(ctx, from, to, limit, offset)
+	})
+}
+
+// ListByFilter implements ports.OrderRepository
+func (r *ResilientOrderRepository) ListByFilter(ctx context.Context, filter ports.OrderFilter, limit, offset int) ([]*entities.Order, int64, error) {
+	var total int64
+	orders, err := retry(ctx, r, "ListByFilter", func() ([]*entities.Order, error) {
+		orders, count, err := r.OrderRepository.ListByFilter(ctx, filter, limit, offset)
+		total = count
+		return orders, err
+	})
+	return orders, total, err
+}
+
+// FindExpiredPending implements ports.OrderRepository
+func (r *ResilientOrderRepository) FindExpiredPending(ctx context.Context, before time.Time, limit int) ([]*entities.Order, error) {
+	return retry(ctx, r, "FindExpiredPending", func() ([]*entities.Order, error) {
+		return r.OrderRepository.FindExpiredPending(ctx, before, limit)
+	})
+}
+
+// Count implements ports.OrderRepository
+func (r *ResilientOrderRepository) Count(ctx context.Context) (int64, error) {
+	return retry(ctx, r, "Count", func() (int64, error) {
+		return r.OrderRepository.Count(ctx)
+	})
+}
+
+// CountDeleted implements ports.OrderRepository
+func (r *ResilientOrderRepository) CountDeleted(ctx context.Context) (int64, error) {
+	return retry(ctx, r, "CountDeleted", func() (int64, error) {
+		return r.OrderRepository.CountDeleted(ctx)
+	})
+}
+
+// CountByCustomerID implements ports.OrderRepository
+func (r *ResilientOrderRepository) CountByCustomerID(ctx context.Context, customerID uint) (int64, error) {
+	return retry(ctx, r, "CountByCustomerID", func() (int64, error) {
+		return r.OrderRepository.CountByCustomerID(ctx, customerID)
+	})
+}
+
+// CountByStatus implements ports.OrderRepository
+func (r *ResilientOrderRepository) CountByStatus(ctx context.Context, status entities.OrderStatus) (int64, error) {
+	return retry(ctx, r, "CountByStatus", func() (int64, error) {
+		return r.OrderRepository.CountByStatus(ctx, status)
+	})
+}
+
+// CountByFilter implements ports.OrderRepository
+func (r *ResilientOrderRepository) CountByFilter(ctx context.Context, filter ports.OrderFilter) (int64, error) {
+	return retry(ctx, r, "CountByFilter", func() (int64, error) {
+		return r.OrderRepository.CountByFilter(ctx, filter)
+	})
+}
+
+// CountByCustomerIDAndStatus implements ports.OrderRepository
+func (r *ResilientOrderRepository) CountByCustomerIDAndStatus(ctx context.Context, customerID uint, status entities.OrderStatus) (int64, error) {
+	return retry(ctx, r, "CountByCustomerIDAndStatus", func() (int64, error) {
+		return r.OrderRepository.CountByCustomerIDAndStatus(ctx, customerID, status)
+	})
+}
+
+// CountItemsByFilter implements ports.OrderRepository
+func (r *ResilientOrderRepository) CountItemsByFilter(ctx context.Context, filter ports.OrderFilter) (int64, error) {
+	return retry(ctx, r, "CountItemsByFilter", func() (int64, error) {
+		return r.OrderRepository.CountItemsByFilter(ctx, filter)
+	})
+}
+
+// MaxUpdatedAt implements ports.OrderRepository
+func (r *ResilientOrderRepository) MaxUpdatedAt(ctx context.Context, filter ports.OrderFilter) (time.Time, error) {
+	return retry(ctx, r, "MaxUpdatedAt", func() (time.Time, error) {
+		return r.OrderRepository.MaxUpdatedAt(ctx, filter)
+	})
+}
+
+// AggregateByStatus implements ports.OrderRepository
+func (r *ResilientOrderRepository) AggregateByStatus(ctx context.Context, filter ports.OrderFilter) ([]ports.StatusAggregate, error) {
+	return retry(ctx, r, "AggregateByStatus", func() ([]ports.StatusAggregate, error) {
+		return r.OrderRepository.AggregateByStatus(ctx, filter)
+	})
+}
+
+// AggregateByDay implements ports.OrderRepository
+func (r *ResilientOrderRepository) AggregateByDay(ctx context.Context, filter ports.OrderFilter) ([]ports.DailyAggregate, error) {
+	return retry(ctx, r, "AggregateByDay", func() ([]ports.DailyAggregate, error) {
+		return r.OrderRepository.AggregateByDay(ctx, filter)
+	})
+}
+
+// retry calls fn until it succeeds, fails with a permanent error or the policy is exhausted.
+// A backoff that would end after the context deadline is not started, the last error is returned instead.
+func retry[T any](ctx context.Context, r *ResilientOrderRepository, operation string, fn func() (T, error)) (T, error) {
+	result, err := fn()
+	if err == nil || inTransaction(ctx) {
+		return result, err
+	}
+
+	for attempt := 1; attempt < r.policy.MaxAttempts && isTransientError(err); attempt++ {
+		delay := r.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			break
+		}
+
+		r.logger.Warn("Transient database error, retrying",
+			"operation", operation,
+			"attempt", attempt,
+			"delay", delay,
+			"error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, err
+		case <-timer.C:
+		}
+
+		r.retries.Inc()
+		result, err = fn()
+		if err == nil {
+			return result, nil
+		}
+	}
+
+	if isTransientError(err) {
+		r.exhausted.Inc()
+		r.logger.Error("Database operation failed after retries",
+			"operation", operation,
+			"max_attempts", r.policy.MaxAttempts,
+			"error", err)
+	}
+
+	return result, err
+}
+
+// backoff returns the jittered delay before the retry following attempt, between half and all of the exponential delay
+func (r *ResilientOrderRepository) backoff(attempt int) time.Duration {
+	delay := r.policy.BaseDelay
+	for i := 1; i < attempt && delay < math.MaxInt64/2; i++ {
+		delay *= 2
+	}
+	if r.policy.MaxDelay > 0 && delay > r.policy.MaxDelay {
+		delay = r.policy.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// Postgres error codes worth retrying, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+var transientErrorCodes = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"57P01": true, // admin_shutdown
+	"57P02": true, // crash_shutdown
+	"57P03": true, // cannot_connect_now
+}
+
+// isTransientError reports whether err is likely to go away when the call is repeated
+func isTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Class 08 is connection_exception
+		return transientErrorCodes[pgErr.Code] || strings.HasPrefix(pgErr.Code, "08")
+	}
+
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+
+	return pgconn.SafeToRetry(err) ||
+		mongo.IsNetworkError(err) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
@@ -0,0 +1,242 @@
+package order_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"orders-service/internal/adapters/persistence/transaction"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+)
+
+var errSerialization = &pgconn.PgError{Code: "40001", Message: "could not serialize access"}
+
+// flakyRepository fails the first failures calls of every method with err
+type flakyRepository struct {
+	ports.OrderRepository
+	failures int
+	err      error
+	calls    map[string]int
+}
+
+func newFlakyRepository(failures int, err error) *flakyRepository {
+	return &flakyRepository{failures: failures, err: err, calls: make(map[string]int)}
+}
+
+func (r *flakyRepository) call(method string) error {
+	r.calls[method]++
+	if r.calls[method] <= r.failures {
+		return r.err
+	}
+	return nil
+}
+
+func (r *flakyRepository) GetByID(_ context.Context, id uint) (*entities.Order, error) {
+	if err := r.call("GetByID"); err != nil {
+		return nil, err
+	}
+	order, _ := entities.NewOrder(123)
+	order.ID = id
+	return order, nil
+}
+
+func (r *flakyRepository) Count(_ context.Context) (int64, error) {
+	if err := r.call("Count"); err != nil {
+		return 0, err
+	}
+	return 42, nil
+}
+
+func (r *flakyRepository) Create(_ context.Context, order *entities.Order) (*entities.Order, error) {
+	if err := r.call("Create"); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (r *flakyRepository) Update(_ context.Context, order *entities.Order) (*entities.Order, error) {
+	if err := r.call("Update"); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (r *flakyRepository) Delete(_ context.Context, _ uint) error {
+	return r.call("Delete")
+}
+
+func setupResilientRepository(flaky *flakyRepository, policy RetryPolicy) (ports.OrderRepository, *metrics.Registry) {
+	registry := metrics.NewRegistry()
+	return NewResilientOrderRepository(flaky, policy, registry, logger.New("test")), registry
+}
+
+var testPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+func TestResilientOrderRepository_RetriesTransientReads(t *testing.T) {
+	// Given
+	flaky := newFlakyRepository(2, errSerialization)
+	repo, registry := setupResilientRepository(flaky, testPolicy)
+
+	// When
+	order, err := repo.GetByID(context.Background(), 7)
+	count, countErr := repo.Count(context.Background())
+
+	// Then
+	require.NoError(t, err)
+	require.NoError(t, countErr)
+	assert.Equal(t, uint(7), order.ID)
+	assert.Equal(t, int64(42), count)
+	assert.Equal(t, 3, flaky.calls["GetByID"])
+	assert.Equal(t, 3, flaky.calls["Count"])
+	assert.Equal(t, int64(4), registry.Counter(RetriesMetric).Value())
+	assert.Equal(t, int64(0), registry.Counter(RetriesExhaustedMetric).Value())
+}
+
+func TestResilientOrderRepository_GivesUpAfterMaxAttempts(t *testing.T) {
+	// Given
+	flaky := newFlakyRepository(5, errSerialization)
+	repo, registry := setupResilientRepository(flaky, testPolicy)
+
+	// When
+	_, err := repo.GetByID(context.Background(), 7)
+
+	// Then
+	assert.ErrorIs(t, err, errSerialization)
+	assert.Equal(t, 3, flaky.calls["GetByID"])
+	assert.Equal(t, int64(2), registry.Counter(RetriesMetric).Value())
+	assert.Equal(t, int64(1), registry.Counter(RetriesExhaustedMetric).Value())
+}
+
+func TestResilientOrderRepository_PermanentErrorsAreNotRetried(t *testing.T) {
+	// Given
+	flaky := newFlakyRepository(1, domainErrors.ErrOrderNotFound)
+	repo, registry := setupResilientRepository(flaky, testPolicy)
+
+	// When
+	_, err := repo.GetByID(context.Background(), 7)
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+	assert.Equal(t, 1, flaky.calls["GetByID"])
+	assert.Equal(t, int64(0), registry.Counter(RetriesMetric).Value())
+}
+
+func TestResilientOrderRepository_Writes(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("create and delete are not retried", func(t *testing.T) {
+		// Given
+		flaky := newFlakyRepository(1, errSerialization)
+		repo, _ := setupResilientRepository(flaky, testPolicy)
+		order, _ := entities.NewOrder(123)
+
+		// When
+		_, createErr := repo.Create(ctx, order)
+		deleteErr := repo.Delete(ctx, 7)
+
+		// Then
+		assert.ErrorIs(t, createErr, errSerialization)
+		assert.ErrorIs(t, deleteErr, errSerialization)
+		assert.Equal(t, 1, flaky.calls["Create"])
+		assert.Equal(t, 1, flaky.calls["Delete"])
+	})
+
+	t.Run("update is idempotent and retried", func(t *testing.T) {
+		// Given
+		flaky := newFlakyRepository(1, errSerialization)
+		repo, _ := setupResilientRepository(flaky, testPolicy)
+		order, _ := entities.NewOrder(123)
+
+		// When
+		_, err := repo.Update(ctx, order)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, 2, flaky.calls["Update"])
+	})
+}
+
+func TestResilientOrderRepository_TransactionIsNotRetried(t *testing.T) {
+	// Given
+	flaky := newFlakyRepository(1, errSerialization)
+	repo, _ := setupResilientRepository(flaky, testPolicy)
+	ctx := transaction.WithTx(context.Background(), &gorm.DB{})
+
+	// When
+	_, err := repo.GetByID(ctx, 7)
+
+	// Then
+	assert.ErrorIs(t, err, errSerialization)
+	assert.Equal(t, 1, flaky.calls["GetByID"])
+}
+
+func TestResilientOrderRepository_RespectsContextDeadline(t *testing.T) {
+	// Given a backoff longer than the time left on the request
+	flaky := newFlakyRepository(1, errSerialization)
+	repo, registry := setupResilientRepository(flaky, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// When
+	start := time.Now()
+	_, err := repo.GetByID(ctx, 7)
+
+	// Then
+	assert.ErrorIs(t, err, errSerialization)
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+	assert.Equal(t, 1, flaky.calls["GetByID"])
+	assert.Equal(t, int64(0), registry.Counter(RetriesMetric).Value())
+}
+
+func TestResilientOrderRepository_Backoff(t *testing.T) {
+	repo := &ResilientOrderRepository{policy: RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}}
+
+	for attempt, max := range map[int]time.Duration{1: 100, 2: 200, 3: 300, 10: 300, 80: 300} {
+		max *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			delay := repo.backoff(attempt)
+			assert.GreaterOrEqual(t, delay, max/2, "attempt %d", attempt)
+			assert.LessOrEqual(t, delay, max, "attempt %d", attempt)
+		}
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		transient bool
+	}{
+		{"serialization failure", errSerialization, true},
+		{"deadlock", fmt.Errorf("query: %w", &pgconn.PgError{Code: "40P01"}), true},
+		{"connection exception", &pgconn.PgError{Code: "08006"}, true},
+		{"admin shutdown", &pgconn.PgError{Code: "57P01"}, true},
+		{"connection refused", &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, true},
+		{"connection reset", fmt.Errorf("read: %w", syscall.ECONNRESET), true},
+		{"mongo network error", mongo.CommandError{Labels: []string{"NetworkError"}}, true},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false},
+		{"not found", domainErrors.ErrOrderNotFound, false},
+		{"deadline exceeded", context.DeadlineExceeded, false},
+		{"other", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.transient, isTransientError(tt.err))
+		})
+	}
+}
@@ -10,7 +10,9 @@ import (
 	"orders-service/pkg/logger"
 
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
 )
 
 type GormDB struct {
@@ -19,17 +21,8 @@ type GormDB struct {
 }
 
 func NewGormConnection(cfg *config.Config, log logger.Logger) (*GormDB, error) {
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Database.Host, cfg.Database.Port, cfg.Database.Username, cfg.Database.Password, cfg.Database.Database, cfg.Database.SSLMode)
-
 	// Configure GORM with your zap logger
-	gormLogLevel := StringToGormLogLevel(cfg.LogLevel)
-
-	customLogger := NewGormZapLoggerWithConfig(log, GormLoggerConfig{
-		LogLevel:                  gormLogLevel,
-		IgnoreRecordNotFoundError: true,
-		SlowThreshold:             200 * time.Millisecond,
-	})
+	customLogger := newGormLogger(cfg, log)
 
 	gormConfig := &gorm.Config{
 		Logger: customLogger,
@@ -38,9 +31,9 @@ func NewGormConnection(cfg *config.Config, log logger.Logger) (*GormDB, error) {
 		},
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), gormConfig)
+	db, err := gorm.Open(dialector(cfg.Database), gormConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to postgres with GORM: %w", err)
+		return nil, fmt.Errorf("failed to connect to %s with GORM: %w", cfg.Database.Driver, err)
 	}
 
 	// Get underlying sql.DB to configure connection pool
@@ -49,24 +42,33 @@ func NewGormConnection(cfg *config.Config, log logger.Logger) (*GormDB, error) {
 		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
 
-	// Configure connection pool
-	sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)
-	sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
-	sqlDB.SetConnMaxLifetime(cfg.Database.MaxLifetime)
+	// Configure connection pool. SQLite allows a single writer and an in-memory database lives as long
+	// as its connection, so it keeps exactly one connection open.
+	if cfg.Database.Driver == config.DriverSQLite {
+		sqlDB.SetMaxOpenConns(1)
+	} else {
+		configurePool(sqlDB, cfg.Database)
+	}
 
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := sqlDB.PingContext(ctx); err != nil {
-		return nil, fmt.Errorf("failed to ping postgres: %w", err)
+		return nil, fmt.Errorf("failed to ping %s: %w", cfg.Database.Driver, err)
 	}
 
-	log.Info("GORM PostgreSQL connection established",
-		"host", cfg.Database.Host,
-		"port", cfg.Database.Port,
-		"database", cfg.Database.Database,
-		"max_open_conns", cfg.Database.MaxOpenConns)
+	if cfg.Database.Driver == config.DriverSQLite {
+		log.Info("GORM SQLite connection established", "dsn", cfg.Database.DSN)
+	} else {
+		log.Info("GORM PostgreSQL connection established",
+			"host", cfg.Database.Host,
+			"port", cfg.Database.Port,
+			"database", cfg.Database.Database,
+			"max_open_conns", cfg.Database.MaxOpenConns,
+			"max_idle_conns", cfg.Database.MaxIdleConns,
+			"slow_query_threshold", cfg.Database.SlowQueryThreshold)
+	}
 
 	return &GormDB{
 		db:     db,
@@ -74,12 +76,61 @@ func NewGormConnection(cfg *config.Config, log logger.Logger) (*GormDB, error) {
 	}, nil
 }
 
+// dialector opens the database selected by cfg.Driver
+func dialector(cfg config.DatabaseConfig) gorm.Dialector {
+	if cfg.Driver == config.DriverSQLite {
+		return sqlite.Open(cfg.DSN)
+	}
+
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.Database, cfg.SSLMode)
+	return postgres.Open(dsn)
+}
+
+// connectionPool is the part of *sql.DB that the pool settings are applied to
+type connectionPool interface {
+	SetMaxOpenConns(n int)
+	SetMaxIdleConns(n int)
+	SetConnMaxLifetime(d time.Duration)
+	SetConnMaxIdleTime(d time.Duration)
+}
+
+// configurePool applies the pool settings from cfg, zero values keep the database/sql defaults
+func configurePool(pool connectionPool, cfg config.DatabaseConfig) {
+	if cfg.MaxOpenConns > 0 {
+		pool.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		pool.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		pool.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		pool.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	}
+}
+
+// newGormLogger builds the GORM logger from the database section, falling back to the service log level
+func newGormLogger(cfg *config.Config, log logger.Logger) gormLogger.Interface {
+	level := cfg.Database.LogLevel
+	if level == "" {
+		level = cfg.LogLevel
+	}
+
+	return NewGormZapLoggerWithConfig(log, GormLoggerConfig{
+		LogLevel:                  StringToGormLogLevel(level),
+		IgnoreRecordNotFoundError: true,
+		SlowThreshold:             cfg.Database.SlowQueryThreshold,
+	})
+}
+
 func (g *GormDB) DB() *gorm.DB {
 	return g.db
 }
 
 func (g *GormDB) Close() error {
-	g.logger.Info("Closing GORM PostgreSQL connection")
+	g.logger.Info("Closing GORM database connection")
 	sqlDB, err := g.db.DB()
 	if err != nil {
 		return err
@@ -99,8 +150,8 @@ func (g *GormDB) HealthCheck(ctx context.Context) error {
 	}
 
 	if err := sqlDB.PingContext(ctx); err != nil {
-		g.logger.Error("GORM PostgreSQL health check failed", "error", err)
-		return fmt.Errorf("gorm postgres health check failed: %w", err)
+		g.logger.Error("GORM database health check failed", "error", err)
+		return fmt.Errorf("gorm database health check failed: %w", err)
 	}
 
 	return nil
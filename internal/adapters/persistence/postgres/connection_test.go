@@ -0,0 +1,178 @@
+package persistence
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"orders-service/internal/config"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+// recordingPool captures the settings applied by configurePool
+type recordingPool struct {
+	maxOpen     int
+	maxIdle     int
+	maxLifetime time.Duration
+	maxIdleTime time.Duration
+}
+
+func (p *recordingPool) SetMaxOpenConns(n int)              { p.maxOpen = n }
+func (p *recordingPool) SetMaxIdleConns(n int)              { p.maxIdle = n }
+func (p *recordingPool) SetConnMaxLifetime(d time.Duration) { p.maxLifetime = d }
+func (p *recordingPool) SetConnMaxIdleTime(d time.Duration) { p.maxIdleTime = d }
+
+// recordingLogger keeps the fields of every entry written through it
+type recordingLogger struct {
+	logger.Logger
+	fields  []interface{}
+	entries *[][]interface{}
+}
+
+func (l *recordingLogger) record(args []interface{}) {
+	*l.entries = append(*l.entries, append(append([]interface{}{}, l.fields...), args...))
+}
+
+func (l *recordingLogger) Debug(msg string, args ...interface{}) { l.record(args) }
+func (l *recordingLogger) Info(msg string, args ...interface{})  { l.record(args) }
+func (l *recordingLogger) Warn(msg string, args ...interface{})  { l.record(args) }
+func (l *recordingLogger) Error(msg string, args ...interface{}) { l.record(args) }
+
+func (l *recordingLogger) With(fields ...interface{}) logger.Logger {
+	return &recordingLogger{fields: append(append([]interface{}{}, l.fields...), fields...), entries: l.entries}
+}
+
+func loadFixture(t *testing.T) *config.Config {
+	t.Helper()
+	cfg, err := config.Load("testdata/config.yaml", "test")
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestConfigurePool_AppliesFixtureSettings(t *testing.T) {
+	cfg := loadFixture(t)
+	pool := &recordingPool{}
+
+	configurePool(pool, cfg.Database)
+
+	assert.Equal(t, 40, pool.maxOpen)
+	assert.Equal(t, 10, pool.maxIdle)
+	assert.Equal(t, 30*time.Minute, pool.maxLifetime)
+	assert.Equal(t, 2*time.Minute, pool.maxIdleTime)
+}
+
+func TestConfigurePool_AppliesToSQLDB(t *testing.T) {
+	cfg := loadFixture(t)
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               gormLogger.Discard,
+	})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	configurePool(sqlDB, cfg.Database)
+
+	assert.Equal(t, 40, sqlDB.Stats().MaxOpenConnections)
+}
+
+func TestConfigurePool_ZeroKeepsDefaults(t *testing.T) {
+	pool := &recordingPool{maxOpen: -1, maxIdle: -1, maxLifetime: -1, maxIdleTime: -1}
+
+	configurePool(pool, config.DatabaseConfig{})
+
+	assert.Equal(t, &recordingPool{maxOpen: -1, maxIdle: -1, maxLifetime: -1, maxIdleTime: -1}, pool)
+}
+
+func TestNewGormConnection_SQLiteInMemory(t *testing.T) {
+	cfg := loadFixture(t)
+	require.NoError(t, cfg.Database.SetURL("sqlite::memory:"))
+
+	conn, err := NewGormConnection(cfg, logger.New("test"))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// The single connection keeps the in-memory database alive across statements
+	require.NoError(t, conn.DB().Exec("CREATE TABLE probes (id INTEGER)").Error)
+	require.NoError(t, conn.DB().Exec("INSERT INTO probes (id) VALUES (1)").Error)
+	var count int64
+	require.NoError(t, conn.DB().Table("probes").Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, "sqlite", conn.DB().Dialector.Name())
+	assert.NoError(t, conn.HealthCheck(context.Background()))
+}
+
+func TestNewGormLogger_UsesDatabaseSection(t *testing.T) {
+	cfg := loadFixture(t)
+
+	gormLog, ok := newGormLogger(cfg, logger.New("test")).(*GormZapLogger)
+	require.True(t, ok)
+
+	assert.Equal(t, gormLogger.Error, gormLog.logLevel)
+	assert.Equal(t, 150*time.Millisecond, gormLog.slowThreshold)
+}
+
+func TestNewGormLogger_FallsBackToServiceLogLevel(t *testing.T) {
+	cfg := &config.Config{LogLevel: "info"}
+
+	gormLog, ok := newGormLogger(cfg, logger.New("test")).(*GormZapLogger)
+	require.True(t, ok)
+
+	assert.Equal(t, gormLogger.Info, gormLog.logLevel)
+}
+
+func TestGormZapLogger_SlowQueryCarriesRequestID(t *testing.T) {
+	var entries [][]interface{}
+	gormLog := NewGormZapLoggerWithConfig(&recordingLogger{entries: &entries}, GormLoggerConfig{
+		LogLevel:      gormLogger.Warn,
+		SlowThreshold: 10 * time.Millisecond,
+	})
+	ctx := logger.WithRequestID(context.Background(), "req-123")
+
+	gormLog.Trace(ctx, time.Now().Add(-time.Second), func() (string, int64) { return "SELECT 1", 1 }, nil)
+	gormLog.Trace(ctx, time.Now(), func() (string, int64) { return "SELECT 1", 1 }, nil)
+
+	require.Len(t, entries, 1)
+	assert.Contains(t, entries[0], "req-123")
+	assert.Contains(t, entries[0], "threshold")
+}
+
+func TestGormZapLogger_UsesRequestLoggerFromContext(t *testing.T) {
+	var entries [][]interface{}
+	gormLog := NewGormZapLoggerWithConfig(&recordingLogger{entries: &entries}, GormLoggerConfig{
+		LogLevel:      gormLogger.Warn,
+		SlowThreshold: 10 * time.Millisecond,
+	})
+	requestLog := &recordingLogger{fields: []interface{}{"request_id", "req-456"}, entries: &entries}
+	ctx := logger.WithContext(context.Background(), requestLog)
+
+	gormLog.Trace(ctx, time.Now().Add(-time.Second), func() (string, int64) { return "SELECT 1", 1 }, nil)
+
+	require.Len(t, entries, 1)
+	assert.Equal(t, []interface{}{"request_id", "req-456"}, entries[0][:2])
+	assert.Contains(t, entries[0], "SELECT 1")
+}
+
+// secretParam stands for a column value that must not appear in logs
+type secretParam string
+
+func (secretParam) LogValue() slog.Value { return slog.StringValue("[REDACTED]") }
+
+func TestGormZapLogger_ParamsFilterRedactsLogValuers(t *testing.T) {
+	gormLog := NewGormZapLogger(logger.New("test")).(*GormZapLogger)
+	params := []interface{}{"pending", secretParam("jane@example.com")}
+
+	sql, filtered := gormLog.ParamsFilter(context.Background(), "UPDATE orders SET status = ?, customer_email = ?", params...)
+
+	assert.Equal(t, "UPDATE orders SET status = ?, customer_email = ?", sql)
+	assert.Equal(t, []interface{}{"pending", "[REDACTED]"}, filtered)
+	assert.Equal(t, secretParam("jane@example.com"), params[1], "the parameters of the query are not changed")
+}
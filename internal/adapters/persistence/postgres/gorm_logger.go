@@ -4,6 +4,7 @@ package persistence
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"time"
 
 	"orders-service/pkg/logger"
@@ -56,24 +57,37 @@ func (l *GormZapLogger) LogMode(level gormLogger.LogLevel) gormLogger.Interface
 // Info implements gorm.io/gorm/logger.Interface
 func (l *GormZapLogger) Info(ctx context.Context, msg string, data ...interface{}) {
 	if l.logLevel >= gormLogger.Info {
-		l.logger.Info(msg, data...)
+		l.loggerFor(ctx).Info(msg, data...)
 	}
 }
 
 // Warn implements gorm.io/gorm/logger.Interface
 func (l *GormZapLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
 	if l.logLevel >= gormLogger.Warn {
-		l.logger.Warn(msg, data...)
+		l.loggerFor(ctx).Warn(msg, data...)
 	}
 }
 
 // Error implements gorm.io/gorm/logger.Interface
 func (l *GormZapLogger) Error(ctx context.Context, msg string, data ...interface{}) {
 	if l.logLevel >= gormLogger.Error {
-		l.logger.Error(msg, data...)
+		l.loggerFor(ctx).Error(msg, data...)
 	}
 }
 
+// loggerFor returns the request logger carried by ctx, or the GORM logger tagged with the request ID
+// carried by ctx, if any
+func (l *GormZapLogger) loggerFor(ctx context.Context) logger.Logger {
+	log := logger.FromContext(ctx, l.logger)
+	if log != l.logger {
+		return log
+	}
+	if requestID := logger.RequestIDFromContext(ctx); requestID != "" {
+		return l.logger.With("request_id", requestID)
+	}
+	return l.logger
+}
+
 // Trace implements gorm.io/gorm/logger.Interface
 // This is where SQL queries are logged
 func (l *GormZapLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
@@ -92,12 +106,25 @@ func (l *GormZapLogger) Trace(ctx context.Context, begin time.Time, fc func() (s
 
 	switch {
 	case err != nil && l.logLevel >= gormLogger.Error && (!errors.Is(err, gormLogger.ErrRecordNotFound) || !l.ignoreRecordNotFoundError):
-		l.logger.Error("database query failed", append(fields, "error", err)...)
+		l.loggerFor(ctx).Error("database query failed", append(fields, "error", err)...)
 	case elapsed > l.slowThreshold && l.slowThreshold != 0 && l.logLevel >= gormLogger.Warn:
-		l.logger.Warn("slow query detected", append(fields, "threshold", l.slowThreshold)...)
+		l.loggerFor(ctx).Warn("slow query detected", append(fields, "threshold", l.slowThreshold)...)
 	case l.logLevel == gormLogger.Info:
-		l.logger.Debug("database query executed", fields...)
+		l.loggerFor(ctx).Debug("database query executed", fields...)
+	}
+}
+
+// ParamsFilter implements gorm.ParamsFilter, replacing the query parameters that implement slog.LogValuer,
+// such as personal data of customers, with their log value before the SQL is logged
+func (l *GormZapLogger) ParamsFilter(_ context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	filtered := make([]interface{}, len(params))
+	for i, param := range params {
+		if valuer, ok := param.(slog.LogValuer); ok {
+			param = valuer.LogValue().Any()
+		}
+		filtered[i] = param
 	}
+	return sql, filtered
 }
 
 func StringToGormLogLevel(level string) gormLogger.LogLevel {
@@ -0,0 +1,109 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/config"
+	"orders-service/pkg/logger"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Client is the Redis cache, a go-redis client behind ports.Cache
+type Client struct {
+	rdb    *goredis.Client
+	logger logger.Logger
+}
+
+// NewClient creates a Redis client. An unreachable server is logged and not treated as fatal,
+// connections are retried on every command so the cache recovers once Redis is back.
+func NewClient(cfg *config.Config, log logger.Logger) *Client {
+	poolSize := cfg.Cache.PoolSize
+	if poolSize <= 0 {
+		poolSize = 10
+	}
+
+	client := &Client{
+		rdb: goredis.NewClient(&goredis.Options{
+			Addr:         cfg.Cache.Addr,
+			Password:     cfg.Cache.Password,
+			DB:           cfg.Cache.DB,
+			PoolSize:     poolSize,
+			DialTimeout:  cfg.Cache.DialTimeout,
+			ReadTimeout:  ioTimeout(cfg.Cache.IOTimeout),
+			WriteTimeout: ioTimeout(cfg.Cache.IOTimeout),
+			// A failed cache command falls back to the database, retrying it would only delay the request
+			MaxRetries: -1,
+		}),
+		logger: log.With("component", "redis"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.HealthCheck(ctx); err != nil {
+		client.logger.Warn("Redis is unreachable, reads will fall back to the database", "addr", cfg.Cache.Addr, "error", err)
+	} else {
+		client.logger.Info("Redis connection established", "addr", cfg.Cache.Addr, "db", cfg.Cache.DB, "pool_size", poolSize)
+	}
+
+	return client
+}
+
+// ioTimeout maps an unset timeout to no timeout, go-redis would otherwise apply its default of 3s
+func ioTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return -1
+	}
+	return timeout
+}
+
+// Get implements ports.Cache
+func (c *Client) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.rdb.Get(ctx, key).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, ports.ErrCacheMiss
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis: get %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// Set implements ports.Cache, a ttl of 0 keeps the value until it is deleted
+func (c *Client) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.rdb.Set(ctx, key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("redis: set %s: %w", key, err)
+	}
+	return nil
+}
+
+// Delete implements ports.Cache
+func (c *Client) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if err := c.rdb.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("redis: del: %w", err)
+	}
+	return nil
+}
+
+// HealthCheck pings the server
+func (c *Client) HealthCheck(ctx context.Context) error {
+	if err := c.rdb.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("redis health check failed: %w", err)
+	}
+	return nil
+}
+
+// Close closes the pooled connections
+func (c *Client) Close() error {
+	c.logger.Info("Closing Redis connections")
+	return c.rdb.Close()
+}
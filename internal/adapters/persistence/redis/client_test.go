@@ -0,0 +1,110 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/config"
+	"orders-service/pkg/logger"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, addr string) *Client {
+	t.Helper()
+	cfg := &config.Config{Cache: config.CacheConfig{
+		Addr:        addr,
+		PoolSize:    2,
+		DialTimeout: 200 * time.Millisecond,
+		IOTimeout:   200 * time.Millisecond,
+	}}
+	client := NewClient(cfg, logger.New("test"))
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestClient_SetGetDelete(t *testing.T) {
+	// Given
+	server := miniredis.RunT(t)
+	client := newTestClient(t, server.Addr())
+	ctx := context.Background()
+
+	// When
+	require.NoError(t, client.Set(ctx, "orders:v1:1", []byte(`{"id":1}`), time.Minute))
+	value, err := client.Get(ctx, "orders:v1:1")
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":1}`, string(value))
+	assert.Equal(t, time.Minute, server.TTL("orders:v1:1"))
+
+	require.NoError(t, client.Delete(ctx, "orders:v1:1", "orders:v1:2"))
+	_, err = client.Get(ctx, "orders:v1:1")
+	assert.ErrorIs(t, err, ports.ErrCacheMiss)
+}
+
+func TestClient_SetWithoutTTLKeepsTheValue(t *testing.T) {
+	// Given
+	server := miniredis.RunT(t)
+	client := newTestClient(t, server.Addr())
+
+	// When
+	require.NoError(t, client.Set(context.Background(), "orders:v1:1", []byte("{}"), 0))
+
+	// Then
+	assert.True(t, server.Exists("orders:v1:1"))
+	assert.Zero(t, server.TTL("orders:v1:1"))
+}
+
+func TestClient_ExpiredValueIsAMiss(t *testing.T) {
+	// Given
+	server := miniredis.RunT(t)
+	client := newTestClient(t, server.Addr())
+	ctx := context.Background()
+	require.NoError(t, client.Set(ctx, "orders:v1:1", []byte("{}"), time.Second))
+
+	// When
+	server.FastForward(2 * time.Second)
+	_, err := client.Get(ctx, "orders:v1:1")
+
+	// Then
+	assert.ErrorIs(t, err, ports.ErrCacheMiss)
+}
+
+func TestClient_RecoversOnceRedisIsBack(t *testing.T) {
+	// Given
+	server := miniredis.RunT(t)
+	client := newTestClient(t, server.Addr())
+	ctx := context.Background()
+	require.NoError(t, client.HealthCheck(ctx))
+
+	// When
+	server.Close()
+	_, downErr := client.Get(ctx, "orders:v1:1")
+	require.NoError(t, server.Restart())
+
+	// Then
+	assert.Error(t, downErr)
+	assert.NotErrorIs(t, downErr, ports.ErrCacheMiss)
+	require.NoError(t, client.Set(ctx, "orders:v1:1", []byte("{}"), time.Minute))
+	assert.NoError(t, client.HealthCheck(ctx))
+}
+
+func TestClient_Unreachable(t *testing.T) {
+	// Given
+	server := miniredis.RunT(t)
+	addr := server.Addr()
+	server.Close()
+
+	// When
+	client := newTestClient(t, addr)
+	_, err := client.Get(context.Background(), "orders:v1:1")
+
+	// Then
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ports.ErrCacheMiss)
+}
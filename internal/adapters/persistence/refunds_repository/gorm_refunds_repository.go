@@ -0,0 +1,87 @@
+package refund_repository
+
+import (
+	"context"
+	"time"
+
+	"orders-service/internal/adapters/persistence/transaction"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+
+	"gorm.io/gorm"
+)
+
+// RefundModel represents the database model for refunds
+type RefundModel struct {
+	ID        uint      `gorm:"primarykey"`
+	OrderID   uint      `gorm:"not null;index"`
+	Amount    float64   `gorm:"type:decimal(10,2);not null"`
+	Reason    string    `gorm:"size:500"`
+	Reference string    `gorm:"size:100"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (RefundModel) TableName() string {
+	return "order_refunds"
+}
+
+// GormRefundRepository implements the RefundRepository interface using GORM
+type GormRefundRepository struct {
+	db *gorm.DB
+}
+
+// NewGormRefundRepository creates a new GORM refund repository
+func NewGormRefundRepository(db *gorm.DB) ports.RefundRepository {
+	return &GormRefundRepository{db: db}
+}
+
+// Create implements ports.RefundRepository
+func (r *GormRefundRepository) Create(ctx context.Context, refund *entities.Refund) (*entities.Refund, error) {
+	model := toModel(refund)
+	if err := transaction.Conn(ctx, r.db).Create(model).Error; err != nil {
+		return nil, err
+	}
+	return toEntity(model), nil
+}
+
+// ListByOrderID implements ports.RefundRepository
+func (r *GormRefundRepository) ListByOrderID(ctx context.Context, orderID uint) ([]*entities.Refund, error) {
+	var models []RefundModel
+
+	err := transaction.Conn(ctx, r.db).
+		Where("order_id = ?", orderID).
+		Order("created_at ASC, id ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	refunds := make([]*entities.Refund, 0, len(models))
+	for i := range models {
+		refunds = append(refunds, toEntity(&models[i]))
+	}
+	return refunds, nil
+}
+
+func toModel(refund *entities.Refund) *RefundModel {
+	return &RefundModel{
+		ID:        refund.ID,
+		OrderID:   refund.OrderID,
+		Amount:    refund.Amount,
+		Reason:    refund.Reason,
+		Reference: refund.Reference,
+		CreatedAt: refund.CreatedAt,
+	}
+}
+
+func toEntity(model *RefundModel) *entities.Refund {
+	return &entities.Refund{
+		ID:        model.ID,
+		OrderID:   model.OrderID,
+		Amount:    model.Amount,
+		Reason:    model.Reason,
+		Reference: model.Reference,
+		CreatedAt: model.CreatedAt.UTC(),
+	}
+}
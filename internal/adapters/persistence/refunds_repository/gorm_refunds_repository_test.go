@@ -0,0 +1,14 @@
+package refund_repository
+
+import (
+	"testing"
+
+	"orders-service/internal/adapters/persistence/repositorytest"
+	"orders-service/internal/application/ports"
+)
+
+func TestGormRefundRepository_Conformance(t *testing.T) {
+	repositorytest.RunRefundRepositoryTests(t, func(t *testing.T) ports.RefundRepository {
+		return NewGormRefundRepository(repositorytest.OpenSQLite(t, &RefundModel{}))
+	})
+}
@@ -0,0 +1,144 @@
+package repositorytest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunOrderJobRepositoryTests runs the conformance suite against the repositories built by newRepository.
+// Every subtest gets a fresh, empty repository.
+func RunOrderJobRepositoryTests(t *testing.T, newRepository func(t *testing.T) ports.OrderJobRepository) {
+	tests := map[string]func(t *testing.T, repo ports.OrderJobRepository){
+		"CreateAndGet":           testOrderJobsCreateAndGet,
+		"FindPendingOldestFirst": testOrderJobsFindPendingOldestFirst,
+		"UpdateRecordsOutcome":   testOrderJobsUpdateRecordsOutcome,
+		"UpdateCompletedJob":     testOrderJobsUpdateCompletedJob,
+		"UnknownJob":             testOrderJobsUnknownJob,
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test(t, newRepository(t))
+		})
+	}
+}
+
+// newOrderJob builds a pending job of customerID created minutes after baseTime
+func newOrderJob(customerID uint, minutes int) *entities.OrderJob {
+	request := json.RawMessage(`{"customer_id":1,"items":[]}`)
+	return entities.NewOrderJob(customerID, request, "importer", baseTime.Add(time.Duration(minutes)*time.Minute))
+}
+
+func testOrderJobsCreateAndGet(t *testing.T, repo ports.OrderJobRepository) {
+	ctx := context.Background()
+	job := newOrderJob(1, 0)
+	created, err := repo.Create(ctx, job)
+	require.NoError(t, err)
+	assert.Equal(t, job.ID, created.ID)
+
+	found, err := repo.GetByID(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), found.CustomerID)
+	assert.JSONEq(t, `{"customer_id":1,"items":[]}`, string(found.Request))
+	assert.Equal(t, "importer", found.CreatedBy)
+	assert.Equal(t, entities.OrderJobPending, found.Status)
+	assert.True(t, baseTime.Equal(found.CreatedAt))
+	assert.Zero(t, found.OrderID)
+	assert.Nil(t, found.CompletedAt)
+}
+
+func testOrderJobsFindPendingOldestFirst(t *testing.T, repo ports.OrderJobRepository) {
+	ctx := context.Background()
+	late, err := repo.Create(ctx, newOrderJob(1, 20))
+	require.NoError(t, err)
+	early, err := repo.Create(ctx, newOrderJob(2, 10))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, newOrderJob(3, 90))
+	require.NoError(t, err)
+	failed, err := repo.Create(ctx, newOrderJob(4, 5))
+	require.NoError(t, err)
+	require.NoError(t, failed.MarkFailed(baseTime, "INVALID_ORDER_ITEMS", "order must have at least one item"))
+	_, err = repo.Update(ctx, failed)
+	require.NoError(t, err)
+
+	pending, err := repo.FindPending(ctx, baseTime.Add(30*time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, early.ID, pending[0].ID)
+	assert.Equal(t, late.ID, pending[1].ID)
+
+	limited, err := repo.FindPending(ctx, baseTime.Add(30*time.Minute), 1)
+	require.NoError(t, err)
+	require.Len(t, limited, 1)
+	assert.Equal(t, early.ID, limited[0].ID)
+
+	// A job created at the cut-off is included
+	exact, err := repo.FindPending(ctx, baseTime.Add(10*time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, exact, 1)
+	assert.Equal(t, early.ID, exact[0].ID)
+}
+
+func testOrderJobsUpdateRecordsOutcome(t *testing.T, repo ports.OrderJobRepository) {
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newOrderJob(1, 0))
+	require.NoError(t, err)
+
+	require.NoError(t, created.MarkSucceeded(baseTime.Add(time.Minute), 42, "0f8fad5b-d9cb-469f-a165-70867728950e"))
+	_, err = repo.Update(ctx, created)
+	require.NoError(t, err)
+
+	found, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderJobSucceeded, found.Status)
+	assert.Equal(t, uint(42), found.OrderID)
+	assert.Equal(t, "0f8fad5b-d9cb-469f-a165-70867728950e", found.OrderPublicID)
+	require.NotNil(t, found.CompletedAt)
+	assert.True(t, baseTime.Add(time.Minute).Equal(*found.CompletedAt))
+
+	pending, err := repo.FindPending(ctx, baseTime.Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func testOrderJobsUpdateCompletedJob(t *testing.T, repo ports.OrderJobRepository) {
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newOrderJob(1, 0))
+	require.NoError(t, err)
+
+	succeeded := created.Clone()
+	require.NoError(t, succeeded.MarkSucceeded(baseTime, 42, ""))
+	_, err = repo.Update(ctx, succeeded)
+	require.NoError(t, err)
+
+	// A second worker that read the job before it succeeded must not overwrite it
+	require.NoError(t, created.MarkFailed(baseTime, "TOO_MANY_PENDING_ORDERS", "too many pending orders"))
+	_, err = repo.Update(ctx, created)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderJobNotPending)
+
+	found, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderJobSucceeded, found.Status)
+	assert.Empty(t, found.ErrorCode)
+}
+
+func testOrderJobsUnknownJob(t *testing.T, repo ports.OrderJobRepository) {
+	ctx := context.Background()
+
+	_, err := repo.GetByID(ctx, "0f8fad5b-d9cb-469f-a165-70867728950e")
+	assert.ErrorIs(t, err, domainErrors.ErrOrderJobNotFound)
+
+	job := newOrderJob(1, 0)
+	require.NoError(t, job.MarkSucceeded(baseTime, 42, ""))
+	_, err = repo.Update(ctx, job)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderJobNotFound)
+}
@@ -0,0 +1,864 @@
+// Package repositorytest holds the conformance suites every ports.OrderRepository and
+// ports.ShipmentRepository implementation must pass
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// baseTime anchors the creation times of the fixtures so ordering does not depend on the clock
+var baseTime = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// RunOrderRepositoryTests runs the conformance suite against the repositories built by newRepository.
+// Every subtest gets a fresh, empty repository.
+func RunOrderRepositoryTests(t *testing.T, newRepository func(t *testing.T) ports.OrderRepository) {
+	tests := map[string]func(t *testing.T, repo ports.OrderRepository){
+		"CreateAssignsIDs":              testCreateAssignsIDs,
+		"GetByIDUnknownOrder":           testGetByIDUnknownOrder,
+		"UpdateReplacesItems":           testUpdateReplacesItems,
+		"ItemAttributesRoundTrip":       testItemAttributesRoundTrip,
+		"WeightRoundTrip":               testWeightRoundTrip,
+		"ShippingRoundTrip":             testShippingRoundTrip,
+		"TagsAreKeptOnUpdate":           testTagsAreKeptOnUpdate,
+		"ContactRoundTrip":              testContactRoundTrip,
+		"PaymentRoundTrip":              testPaymentRoundTrip,
+		"SkippedReservationRoundTrip":   testSkippedReservationRoundTrip,
+		"CancelledItemsRoundTrip":       testCancelledItemsRoundTrip,
+		"AmendmentsRoundTrip":           testAmendmentsRoundTrip,
+		"UpdateUnknownOrder":            testUpdateUnknownOrder,
+		"DeleteIsSoft":                  testDeleteIsSoft,
+		"GetByIDIncludingDeleted":       testGetByIDIncludingDeleted,
+		"ListAndRestoreDeleted":         testListAndRestoreDeleted,
+		"ListNewestFirst":               testListNewestFirst,
+		"FiltersByCustomerAndStatus":    testFiltersByCustomerAndStatus,
+		"ListByDateRange":               testListByDateRange,
+		"ListByFilterPagesWithTotal":    testListByFilterPagesWithTotal,
+		"ListByFilterIsConsistent":      testListByFilterIsConsistent,
+		"ListByFilterBackordered":       testListByFilterBackordered,
+		"ListByPriority":                testListByPriority,
+		"ListRecentByItemSet":           testListRecentByItemSet,
+		"ExternalReferenceIsUnique":     testExternalReferenceIsUnique,
+		"OrderNumberIsUnique":           testOrderNumberIsUnique,
+		"GetByPublicID":                 testGetByPublicID,
+		"OrderNumberSequencePerScope":   testOrderNumberSequencePerScope,
+		"FindExpiredPending":            testFindExpiredPending,
+		"StreamAndAggregateByFilter":    testStreamAndAggregateByFilter,
+		"StreamStopsEarly":              testStreamStopsEarly,
+		"MaxUpdatedAt":                  testMaxUpdatedAt,
+		"WithCustomerLockRunsFn":        testWithCustomerLockRunsFn,
+		"GetByIDForUpdateReadsTheOrder": testGetByIDForUpdateReadsTheOrder,
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test(t, newRepository(t))
+		})
+	}
+}
+
+// newOrder builds a pending order of customerID created minutes after baseTime with one item per price
+func newOrder(t *testing.T, customerID uint, minutes int, prices ...float64) *entities.Order {
+	t.Helper()
+	order, err := entities.NewOrder(customerID)
+	require.NoError(t, err)
+	order.CreatedAt = baseTime.Add(time.Duration(minutes) * time.Minute)
+	order.UpdatedAt = order.CreatedAt
+	for i, price := range prices {
+		require.NoError(t, order.AddItem(uint(i+1), "SKU", "Product", 1, price))
+	}
+	return order
+}
+
+func create(t *testing.T, repo ports.OrderRepository, order *entities.Order) *entities.Order {
+	t.Helper()
+	created, err := repo.Create(context.Background(), order)
+	require.NoError(t, err)
+	return created
+}
+
+func ids(orders []*entities.Order) []uint {
+	result := make([]uint, 0, len(orders))
+	for _, order := range orders {
+		result = append(result, order.ID)
+	}
+	return result
+}
+
+func testCreateAssignsIDs(t *testing.T, repo ports.OrderRepository) {
+	created := create(t, repo, newOrder(t, 1, 0, 10, 5))
+
+	assert.NotZero(t, created.ID)
+	require.Len(t, created.Items, 2)
+	assert.NotZero(t, created.Items[0].ID)
+	assert.NotEqual(t, created.Items[0].ID, created.Items[1].ID)
+
+	loaded, err := repo.GetByID(context.Background(), created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), loaded.CustomerID)
+	assert.Equal(t, entities.OrderStatusPending, loaded.Status)
+	assert.InDelta(t, 15.0, loaded.TotalAmount, 0.001)
+	assert.Len(t, loaded.Items, 2)
+}
+
+func testGetByIDUnknownOrder(t *testing.T, repo ports.OrderRepository) {
+	_, err := repo.GetByID(context.Background(), 9999)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+}
+
+func testUpdateReplacesItems(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	created := create(t, repo, newOrder(t, 1, 0, 10))
+
+	require.NoError(t, created.RemoveItem(1))
+	require.NoError(t, created.AddItem(7, "SKU-7", "Product 7", 3, 2))
+	require.NoError(t, created.ConfirmOrder())
+
+	updated, err := repo.Update(ctx, created)
+	require.NoError(t, err)
+	require.Len(t, updated.Items, 1)
+	assert.NotZero(t, updated.Items[0].ID)
+
+	loaded, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusConfirmed, loaded.Status)
+	require.Len(t, loaded.Items, 1)
+	assert.Equal(t, uint(7), loaded.Items[0].ProductID)
+	assert.Equal(t, 3, loaded.Items[0].Quantity)
+	assert.InDelta(t, 6.0, loaded.TotalAmount, 0.001)
+}
+
+func testItemAttributesRoundTrip(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	order := newOrder(t, 1, 0, 10)
+	require.NoError(t, order.AddItem(1, "SKU", "Product", 1, 10, entities.WithAttributes(map[string]string{"size": "M"})))
+	created := create(t, repo, order)
+
+	loaded, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	require.Len(t, loaded.Items, 2)
+	assert.Nil(t, loaded.Items[0].Attributes)
+	assert.Equal(t, map[string]string{"size": "M"}, loaded.Items[1].Attributes)
+
+	loaded.Items[1].Attributes["size"] = "L"
+	reloaded, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "M", reloaded.Items[1].Attributes["size"], "loaded orders must not share attributes with the store")
+}
+
+func testWeightRoundTrip(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	grams := 250
+	order, err := entities.NewOrder(1)
+	require.NoError(t, err)
+	require.NoError(t, order.AddItem(1, "SKU", "Product", 2, 10, entities.WithUnitWeightGrams(&grams)))
+	created := create(t, repo, order)
+
+	loaded, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	require.NotNil(t, loaded.TotalWeightGrams)
+	assert.Equal(t, 500, *loaded.TotalWeightGrams)
+	require.NotNil(t, loaded.Items[0].UnitWeightGrams)
+	assert.Equal(t, 250, *loaded.Items[0].UnitWeightGrams)
+
+	// A line without weight clears the total
+	require.NoError(t, loaded.AddItem(2, "SKU-2", "Product 2", 1, 5))
+	_, err = repo.Update(ctx, loaded)
+	require.NoError(t, err)
+
+	reloaded, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Nil(t, reloaded.TotalWeightGrams)
+	assert.Nil(t, reloaded.Items[1].UnitWeightGrams)
+}
+
+func testShippingRoundTrip(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	created := create(t, repo, newOrder(t, 1, 0, 10))
+	estimate := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+
+	require.NoError(t, created.ConfirmOrder(entities.WithShipping(entities.ShippingMethodExpress, &estimate)))
+	require.NoError(t, created.TransitionToProcessing())
+	require.NoError(t, created.TransitionToShipped(entities.WithTracking("DHL", "JD0123")))
+	_, err := repo.Update(ctx, created)
+	require.NoError(t, err)
+
+	loaded, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.ShippingMethodExpress, loaded.ShippingMethod)
+	require.NotNil(t, loaded.EstimatedDeliveryAt)
+	assert.True(t, estimate.Equal(*loaded.EstimatedDeliveryAt))
+	assert.Equal(t, "DHL", loaded.Carrier)
+	assert.Equal(t, "JD0123", loaded.TrackingNumber)
+}
+
+func testTagsAreKeptOnUpdate(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	order := newOrder(t, 1, 0, 10)
+	require.NoError(t, order.SetTags([]string{"sample", "vip"}))
+	created := create(t, repo, order)
+	assert.Equal(t, []string{"sample", "vip"}, created.Tags)
+
+	// Tags are set on creation only
+	created.Tags = nil
+	_, err := repo.Update(ctx, created)
+	require.NoError(t, err)
+
+	loaded, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sample", "vip"}, loaded.Tags)
+}
+
+func testContactRoundTrip(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	order := newOrder(t, 1, 0, 10)
+	require.NoError(t, order.SetContact("jane@example.com", "Jane Doe"))
+	created := create(t, repo, order)
+
+	require.NoError(t, created.SetContact("jane.doe@example.com", ""))
+	_, err := repo.Update(ctx, created)
+	require.NoError(t, err)
+
+	loaded, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "jane.doe@example.com", loaded.CustomerEmail)
+	assert.Empty(t, loaded.CustomerName)
+}
+
+func testPaymentRoundTrip(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	created := create(t, repo, newOrder(t, 1, 0, 10))
+
+	require.NoError(t, created.ConfirmOrder())
+	require.NoError(t, created.RecordPaymentAuthorization("auth-1"))
+	_, err := repo.Update(ctx, created)
+	require.NoError(t, err)
+
+	loaded, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "auth-1", loaded.PaymentAuthorizationID)
+	assert.Equal(t, entities.PaymentStatusAuthorized, loaded.PaymentStatus)
+
+	require.NoError(t, loaded.RecordPaymentCapture())
+	_, err = repo.Update(ctx, loaded)
+	require.NoError(t, err)
+
+	loaded, err = repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.PaymentStatusCaptured, loaded.PaymentStatus)
+}
+
+func testSkippedReservationRoundTrip(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	created := create(t, repo, newOrder(t, 1, 0, 10))
+
+	require.NoError(t, created.ConfirmOrder())
+	require.NoError(t, created.SkipReservation())
+	_, err := repo.Update(ctx, created)
+	require.NoError(t, err)
+
+	loaded, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.True(t, loaded.ReservationSkipped)
+	assert.True(t, loaded.HasBackorderedItems())
+}
+
+func testCancelledItemsRoundTrip(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	created := create(t, repo, newOrder(t, 1, 0, 10, 20))
+
+	require.NoError(t, created.ConfirmOrder())
+	_, err := created.CancelItem(1, 1)
+	require.NoError(t, err)
+	_, err = repo.Update(ctx, created)
+	require.NoError(t, err)
+
+	loaded, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	require.Len(t, loaded.Items, 1)
+	require.Len(t, loaded.CancelledItems, 1)
+	assert.Equal(t, uint(1), loaded.CancelledItems[0].ProductID)
+	assert.Equal(t, 1, loaded.CancelledItems[0].Quantity)
+	assert.Equal(t, 10.0, loaded.CancelledItems[0].UnitPrice)
+	assert.Equal(t, 20.0, loaded.TotalAmount)
+}
+
+func testAmendmentsRoundTrip(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	created := create(t, repo, newOrder(t, 1, 0, 10, 20))
+
+	require.NoError(t, created.ConfirmOrder())
+	_, err := created.Amend("customer called", []entities.OrderItemInput{
+		{ProductID: 1, ProductSKU: "SKU-1", ProductName: "Product 1", Quantity: 3, UnitPrice: 10},
+	})
+	require.NoError(t, err)
+	_, err = repo.Update(ctx, created)
+	require.NoError(t, err)
+
+	loaded, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, loaded.AmendmentCount)
+	require.Len(t, loaded.Amendments, 1)
+	assert.Equal(t, "customer called", loaded.Amendments[0].Reason)
+	require.Len(t, loaded.Amendments[0].Changes, 2)
+	assert.Equal(t, 3, loaded.Amendments[0].Changes[0].QuantityAfter)
+	assert.Zero(t, loaded.Amendments[0].Changes[1].QuantityAfter)
+	require.Len(t, loaded.Items, 1)
+	assert.Equal(t, 30.0, loaded.TotalAmount)
+}
+
+func testUpdateUnknownOrder(t *testing.T, repo ports.OrderRepository) {
+	order := newOrder(t, 1, 0, 10)
+	order.ID = 9999
+
+	_, err := repo.Update(context.Background(), order)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+}
+
+func testDeleteIsSoft(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	kept := create(t, repo, newOrder(t, 1, 0, 10))
+	deleted := create(t, repo, newOrder(t, 1, 1, 10))
+
+	require.NoError(t, repo.Delete(ctx, deleted.ID))
+
+	_, err := repo.GetByID(ctx, deleted.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, deleted.ID), domainErrors.ErrOrderNotFound)
+
+	orders, err := repo.List(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{kept.ID}, ids(orders))
+
+	count, err := repo.CountByCustomerID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func testGetByIDIncludingDeleted(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	kept := create(t, repo, newOrder(t, 1, 0, 10))
+	deleted := create(t, repo, newOrder(t, 1, 1, 10))
+	require.NoError(t, repo.Delete(ctx, deleted.ID))
+
+	loaded, err := repo.GetByIDIncludingDeleted(ctx, kept.ID)
+	require.NoError(t, err)
+	assert.Nil(t, loaded.DeletedAt)
+
+	loaded, err = repo.GetByIDIncludingDeleted(ctx, deleted.ID)
+	require.NoError(t, err)
+	require.NotNil(t, loaded.DeletedAt)
+	assert.Len(t, loaded.Items, 1)
+
+	_, err = repo.GetByIDIncludingDeleted(ctx, 9999)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+}
+
+func testListAndRestoreDeleted(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	kept := create(t, repo, newOrder(t, 1, 0, 10))
+	first := create(t, repo, newOrder(t, 1, 1, 10))
+	second := create(t, repo, newOrder(t, 2, 2, 10))
+	require.NoError(t, repo.Delete(ctx, first.ID))
+	require.NoError(t, repo.Delete(ctx, second.ID))
+
+	deleted, err := repo.ListDeleted(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{second.ID, first.ID}, ids(deleted))
+	for _, order := range deleted {
+		assert.NotNil(t, order.DeletedAt)
+	}
+	count, err := repo.CountDeleted(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	restored, err := repo.Restore(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	assert.Len(t, restored.Items, 1)
+
+	_, err = repo.Restore(ctx, first.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+	_, err = repo.Restore(ctx, kept.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+	_, err = repo.Restore(ctx, 9999)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+
+	orders, err := repo.List(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{first.ID, kept.ID}, ids(orders))
+	count, err = repo.CountDeleted(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func testListNewestFirst(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	first := create(t, repo, newOrder(t, 1, 0, 10))
+	second := create(t, repo, newOrder(t, 2, 1, 10))
+	third := create(t, repo, newOrder(t, 3, 2, 10))
+
+	page, err := repo.List(ctx, 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{third.ID, second.ID}, ids(page))
+
+	page, err = repo.List(ctx, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{first.ID}, ids(page))
+
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func testFiltersByCustomerAndStatus(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	pending := create(t, repo, newOrder(t, 1, 0, 10))
+	confirmed := create(t, repo, newOrder(t, 1, 1, 10))
+	require.NoError(t, confirmed.ConfirmOrder())
+	_, err := repo.Update(ctx, confirmed)
+	require.NoError(t, err)
+	other := create(t, repo, newOrder(t, 2, 2, 10))
+
+	byCustomer, err := repo.GetByCustomerID(ctx, 1, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{confirmed.ID, pending.ID}, ids(byCustomer))
+
+	byStatus, err := repo.GetByStatus(ctx, entities.OrderStatusPending, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{other.ID, pending.ID}, ids(byStatus))
+
+	count, err := repo.CountByStatus(ctx, entities.OrderStatusPending)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	byCustomerAndStatus, err := repo.GetByCustomerIDAndStatus(ctx, 1, entities.OrderStatusPending, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{pending.ID}, ids(byCustomerAndStatus))
+
+	byCustomerAndStatus, err = repo.GetByCustomerIDAndStatus(ctx, 2, entities.OrderStatusConfirmed, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, byCustomerAndStatus)
+
+	count, err = repo.CountByCustomerIDAndStatus(ctx, 1, entities.OrderStatusPending)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func testListByDateRange(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	early := create(t, repo, newOrder(t, 1, 0, 10))
+	middle := create(t, repo, newOrder(t, 2, 60, 10))
+	late := create(t, repo, newOrder(t, 3, 120, 10))
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		expected []uint
+	}{
+		{"closed range excludes the end", baseTime, baseTime.Add(2 * time.Hour), []uint{middle.ID, early.ID}},
+		{"open start", time.Time{}, baseTime.Add(time.Hour), []uint{early.ID}},
+		{"open end", baseTime.Add(time.Hour), time.Time{}, []uint{late.ID, middle.ID}},
+		{"unbounded", time.Time{}, time.Time{}, []uint{late.ID, middle.ID, early.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders, err := repo.ListByDateRange(ctx, tt.from, tt.to, 10, 0)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ids(orders))
+
+			count, err := repo.CountByFilter(ctx, ports.OrderFilter{CreatedFrom: tt.from, CreatedBefore: tt.to})
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(tt.expected)), count)
+		})
+	}
+
+	page, err := repo.ListByDateRange(ctx, time.Time{}, time.Time{}, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{middle.ID}, ids(page))
+
+	count, err := repo.CountByFilter(ctx, ports.OrderFilter{CustomerID: 2, CreatedFrom: baseTime})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func testListByFilterPagesWithTotal(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	first := create(t, repo, newOrder(t, 1, 0, 10))
+	second := create(t, repo, newOrder(t, 1, 1, 10))
+	create(t, repo, newOrder(t, 2, 2, 10))
+	third := create(t, repo, newOrder(t, 1, 3, 10))
+
+	orders, total, err := repo.ListByFilter(ctx, ports.OrderFilter{CustomerID: 1}, 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{third.ID, second.ID}, ids(orders))
+	assert.Equal(t, int64(3), total)
+
+	orders, total, err = repo.ListByFilter(ctx, ports.OrderFilter{CustomerID: 1}, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{first.ID}, ids(orders))
+	assert.Equal(t, int64(3), total)
+
+	orders, total, err = repo.ListByFilter(ctx, ports.OrderFilter{CustomerID: 1}, 2, 10)
+	require.NoError(t, err)
+	assert.Empty(t, orders)
+	assert.Equal(t, int64(3), total, "the total survives a page past the end")
+}
+
+// testListByFilterBackordered keeps the orders with a backordered line and round trips the reservations
+func testListByFilterBackordered(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	reserved := create(t, repo, newOrder(t, 1, 0, 10, 20))
+	backordered := create(t, repo, newOrder(t, 1, 1, 10, 20))
+	create(t, repo, newOrder(t, 1, 2, 10))
+
+	for _, order := range []*entities.Order{reserved, backordered} {
+		require.NoError(t, order.ConfirmOrder())
+	}
+	require.NoError(t, reserved.ReserveAllItems())
+	require.NoError(t, backordered.ReserveItems([]int{1, 0}))
+	for _, order := range []*entities.Order{reserved, backordered} {
+		_, err := repo.Update(ctx, order)
+		require.NoError(t, err)
+	}
+
+	orders, total, err := repo.ListByFilter(ctx, ports.OrderFilter{Backordered: true}, 10, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, orders, 1)
+	assert.Equal(t, backordered.ID, orders[0].ID)
+	require.Len(t, orders[0].Items, 2)
+	assert.Equal(t, 1, orders[0].Items[0].ReservedQuantity)
+	assert.False(t, orders[0].Items[0].Backordered)
+	assert.Zero(t, orders[0].Items[1].ReservedQuantity)
+	assert.True(t, orders[0].Items[1].Backordered)
+
+	count, err := repo.CountItemsByFilter(ctx, ports.OrderFilter{Backordered: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func testListByPriority(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	newWithPriority := func(minutes int, priority entities.OrderPriority) *entities.Order {
+		order := newOrder(t, 1, minutes, 10)
+		require.NoError(t, order.SetPriority(priority))
+		return create(t, repo, order)
+	}
+	oldNormal := newWithPriority(0, entities.OrderPriorityNormal)
+	low := newWithPriority(1, entities.OrderPriorityLow)
+	urgent := newWithPriority(2, entities.OrderPriorityUrgent)
+	newNormal := newWithPriority(3, entities.OrderPriorityNormal)
+	high := newWithPriority(4, entities.OrderPriorityHigh)
+	confirmed := newWithPriority(5, entities.OrderPriorityUrgent)
+	require.NoError(t, confirmed.ConfirmOrder())
+	_, err := repo.Update(ctx, confirmed)
+	require.NoError(t, err)
+
+	// Raising the priority of a stored order moves it up the queue
+	require.NoError(t, low.SetPriority(entities.OrderPriorityHigh))
+	_, err = repo.Update(ctx, low)
+	require.NoError(t, err)
+
+	otherCustomer := newOrder(t, 2, 6, 10)
+	require.NoError(t, otherCustomer.SetPriority(entities.OrderPriorityUrgent))
+	otherCustomer = create(t, repo, otherCustomer)
+	pending := ports.OrderFilter{CustomerID: 1, Status: entities.OrderStatusPending}
+
+	orders, err := repo.ListByFilterOrderedByPriority(ctx, pending, 10, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, []uint{urgent.ID, low.ID, high.ID, oldNormal.ID, newNormal.ID}, ids(orders))
+	assert.Equal(t, entities.OrderPriorityHigh, orders[1].Priority)
+
+	page, err := repo.ListByFilterOrderedByPriority(ctx, pending, 2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{oldNormal.ID, newNormal.ID}, ids(page))
+
+	everyCustomer, err := repo.ListByFilterOrderedByPriority(ctx, ports.OrderFilter{Status: entities.OrderStatusPending}, 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{urgent.ID, otherCustomer.ID}, ids(everyCustomer))
+}
+
+func testListRecentByItemSet(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	old := create(t, repo, newOrder(t, 1, 0, 10, 20))
+	recent := create(t, repo, newOrder(t, 1, 5, 10, 20))
+	newest := create(t, repo, newOrder(t, 1, 6, 10, 20))
+	create(t, repo, newOrder(t, 1, 7, 10))     // other items
+	create(t, repo, newOrder(t, 2, 7, 10, 20)) // other customer
+	hash := old.ItemSetHash()
+
+	orders, err := repo.ListRecentByItemSet(ctx, 1, hash, baseTime.Add(5*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []uint{newest.ID, recent.ID}, ids(orders))
+
+	// The hash follows item changes
+	require.NoError(t, newest.UpdateItemQuantity(1, 3))
+	_, err = repo.Update(ctx, newest)
+	require.NoError(t, err)
+
+	orders, err = repo.ListRecentByItemSet(ctx, 1, hash, baseTime)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{recent.ID, old.ID}, ids(orders))
+}
+
+// testListByFilterIsConsistent lists while orders are being inserted, the page and the total
+// must describe the same set of orders
+func testListByFilterIsConsistent(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			if _, err := repo.Create(ctx, newOrder(t, 1, i, 10)); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+
+	for listing := true; listing; {
+		select {
+		case <-done:
+			listing = false
+		default:
+		}
+
+		orders, total, err := repo.ListByFilter(ctx, ports.OrderFilter{CustomerID: 1}, 1000, 0)
+		require.NoError(t, err)
+		require.Equal(t, total, int64(len(orders)))
+	}
+}
+
+func testExternalReferenceIsUnique(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	order := newOrder(t, 1, 0, 10)
+	require.NoError(t, order.SetExternalReference("PO-1"))
+	created := create(t, repo, order)
+
+	duplicate := newOrder(t, 1, 1, 10)
+	require.NoError(t, duplicate.SetExternalReference("PO-1"))
+	_, err := repo.Create(ctx, duplicate)
+	assert.ErrorIs(t, err, domainErrors.ErrDuplicateExternalReference)
+
+	otherCustomer := newOrder(t, 2, 1, 10)
+	require.NoError(t, otherCustomer.SetExternalReference("PO-1"))
+	create(t, repo, otherCustomer)
+
+	found, err := repo.GetByExternalReference(ctx, 1, "PO-1")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, found.ID)
+
+	_, err = repo.GetByExternalReference(ctx, 3, "PO-1")
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+}
+
+func testOrderNumberIsUnique(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	order := newOrder(t, 1, 0, 10)
+	order.OrderNumber = "ORD-2025-000001"
+	created := create(t, repo, order)
+	assert.Equal(t, "ORD-2025-000001", created.OrderNumber)
+
+	duplicate := newOrder(t, 2, 1, 10)
+	duplicate.OrderNumber = "ORD-2025-000001"
+	_, err := repo.Create(ctx, duplicate)
+	assert.ErrorIs(t, err, domainErrors.ErrDuplicateOrderNumber)
+
+	// Orders created before numbering existed have no number and never collide
+	create(t, repo, newOrder(t, 1, 2, 10))
+	create(t, repo, newOrder(t, 1, 3, 10))
+
+	found, err := repo.GetByOrderNumber(ctx, "ORD-2025-000001")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, found.ID)
+
+	_, err = repo.GetByOrderNumber(ctx, "ORD-2025-000002")
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+}
+
+func testGetByPublicID(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	order := newOrder(t, 1, 1, 10)
+	created := create(t, repo, order)
+	assert.Equal(t, order.PublicID, created.PublicID)
+
+	found, err := repo.GetByPublicID(ctx, order.PublicID)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, found.ID)
+	require.Len(t, found.Items, 1)
+
+	// Updates keep the public ID
+	found.Status = entities.OrderStatusConfirmed
+	updated, err := repo.Update(ctx, found)
+	require.NoError(t, err)
+	assert.Equal(t, order.PublicID, updated.PublicID)
+
+	_, err = repo.GetByPublicID(ctx, entities.NewPublicID())
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+
+	require.NoError(t, repo.Delete(ctx, created.ID))
+	_, err = repo.GetByPublicID(ctx, order.PublicID)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+}
+
+func testOrderNumberSequencePerScope(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	for _, want := range []uint64{1, 2, 3} {
+		value, err := repo.NextOrderNumberSequence(ctx, "ORD-2025")
+		require.NoError(t, err)
+		assert.Equal(t, want, value)
+	}
+
+	value, err := repo.NextOrderNumberSequence(ctx, "ORD-2026")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), value)
+}
+
+func testFindExpiredPending(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+
+	later := newOrder(t, 1, 0, 10)
+	later.SetExpiry(2 * time.Hour)
+	later = create(t, repo, later)
+
+	sooner := newOrder(t, 1, 0, 10)
+	sooner.SetExpiry(time.Hour)
+	sooner = create(t, repo, sooner)
+
+	never := newOrder(t, 1, 0, 10)
+	create(t, repo, never)
+
+	expired, err := repo.FindExpiredPending(ctx, baseTime.Add(3*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{sooner.ID, later.ID}, ids(expired))
+
+	expired, err = repo.FindExpiredPending(ctx, baseTime.Add(90*time.Minute), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{sooner.ID}, ids(expired))
+}
+
+func testStreamAndAggregateByFilter(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+	first := create(t, repo, newOrder(t, 1, 0, 10, 5))
+	second := create(t, repo, newOrder(t, 1, 24*60, 20))
+	create(t, repo, newOrder(t, 2, 0, 40))
+
+	filter := ports.OrderFilter{CustomerID: 1}
+
+	var streamed []uint
+	err := repo.StreamByFilter(ctx, filter, 1, func(order *entities.Order) error {
+		streamed = append(streamed, order.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uint{first.ID, second.ID}, streamed)
+
+	items, err := repo.CountItemsByFilter(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), items)
+
+	byStatus, err := repo.AggregateByStatus(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, byStatus, 1)
+	assert.Equal(t, entities.OrderStatusPending, byStatus[0].Status)
+	assert.Equal(t, int64(2), byStatus[0].Orders)
+	assert.InDelta(t, 35.0, byStatus[0].Revenue, 0.001)
+	assert.True(t, byStatus[0].FirstCreatedAt.Equal(first.CreatedAt), "first created at %s", byStatus[0].FirstCreatedAt)
+	assert.True(t, byStatus[0].LastCreatedAt.Equal(second.CreatedAt), "last created at %s", byStatus[0].LastCreatedAt)
+
+	byDay, err := repo.AggregateByDay(ctx, ports.OrderFilter{CreatedFrom: baseTime, CreatedBefore: baseTime.Add(time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, byDay, 1)
+	assert.Equal(t, int64(2), byDay[0].Orders)
+	assert.InDelta(t, 55.0, byDay[0].Revenue, 0.001)
+}
+
+func testMaxUpdatedAt(t *testing.T, repo ports.OrderRepository) {
+	ctx := context.Background()
+
+	latest, err := repo.MaxUpdatedAt(ctx, ports.OrderFilter{})
+	require.NoError(t, err)
+	assert.True(t, latest.IsZero(), "no orders, got %s", latest)
+
+	create(t, repo, newOrder(t, 1, 0, 10))
+	newest := create(t, repo, newOrder(t, 1, 10, 10))
+	create(t, repo, newOrder(t, 2, 20, 10))
+
+	latest, err = repo.MaxUpdatedAt(ctx, ports.OrderFilter{CustomerID: 1})
+	require.NoError(t, err)
+	assert.True(t, latest.Equal(newest.UpdatedAt), "latest update %s", latest)
+
+	// Orders leave a status list by changing, the status is not applied
+	latest, err = repo.MaxUpdatedAt(ctx, ports.OrderFilter{CustomerID: 1, Status: entities.OrderStatusConfirmed})
+	require.NoError(t, err)
+	assert.True(t, latest.Equal(newest.UpdatedAt), "latest update %s", latest)
+
+	require.NoError(t, repo.Delete(ctx, newest.ID))
+	latest, err = repo.MaxUpdatedAt(ctx, ports.OrderFilter{CustomerID: 1})
+	require.NoError(t, err)
+	assert.True(t, latest.After(newest.UpdatedAt), "a deletion moves the latest update, got %s", latest)
+}
+
+func testStreamStopsEarly(t *testing.T, repo ports.OrderRepository) {
+	for i := 0; i < 5; i++ {
+		create(t, repo, newOrder(t, 1, i, 10))
+	}
+
+	stop := errors.New("stop")
+	visited := 0
+	err := repo.StreamByFilter(context.Background(), ports.OrderFilter{}, 2, func(order *entities.Order) error {
+		visited++
+		if visited == 3 {
+			return stop
+		}
+		return nil
+	})
+	assert.Same(t, stop, err)
+	assert.Equal(t, 3, visited)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	visited = 0
+	err = repo.StreamByFilter(ctx, ports.OrderFilter{}, 2, func(order *entities.Order) error {
+		visited++
+		cancel()
+		return nil
+	})
+	assert.ErrorIs(t, err, domainErrors.ErrRequestCancelled)
+	assert.Equal(t, 1, visited)
+}
+
+func testWithCustomerLockRunsFn(t *testing.T, repo ports.OrderRepository) {
+	err := repo.WithCustomerLock(context.Background(), 1, func(ctx context.Context) error {
+		_, err := repo.Create(ctx, newOrder(t, 1, 0, 10))
+		return err
+	})
+	require.NoError(t, err)
+
+	count, err := repo.CountByCustomerID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func testGetByIDForUpdateReadsTheOrder(t *testing.T, repo ports.OrderRepository) {
+	created := create(t, repo, newOrder(t, 1, 0, 10))
+
+	locked, err := repo.GetByIDForUpdate(context.Background(), created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, locked.ID)
+
+	_, err = repo.GetByIDForUpdate(context.Background(), 9999)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+}
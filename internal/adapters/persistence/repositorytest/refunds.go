@@ -0,0 +1,69 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunRefundRepositoryTests runs the conformance suite against the repositories built by newRepository.
+// Every subtest gets a fresh, empty repository.
+func RunRefundRepositoryTests(t *testing.T, newRepository func(t *testing.T) ports.RefundRepository) {
+	tests := map[string]func(t *testing.T, repo ports.RefundRepository){
+		"CreateAndListOldestFirst": testRefundsCreateAndListOldestFirst,
+		"ListWithoutRefunds":       testRefundsListWithoutRefunds,
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test(t, newRepository(t))
+		})
+	}
+}
+
+func newRefund(orderID uint, minutes int, amount float64) *entities.Refund {
+	return &entities.Refund{
+		OrderID:   orderID,
+		Amount:    amount,
+		Reason:    "Damaged in transit",
+		Reference: "RF-0001",
+		CreatedAt: baseTime.Add(time.Duration(minutes) * time.Minute),
+	}
+}
+
+func testRefundsCreateAndListOldestFirst(t *testing.T, repo ports.RefundRepository) {
+	ctx := context.Background()
+	first, err := repo.Create(ctx, newRefund(1, 0, 10.5))
+	require.NoError(t, err)
+	second, err := repo.Create(ctx, newRefund(1, 5, 4.25))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, newRefund(2, 1, 1))
+	require.NoError(t, err)
+
+	assert.NotZero(t, first.ID)
+	assert.NotEqual(t, first.ID, second.ID)
+
+	refunds, err := repo.ListByOrderID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, refunds, 2)
+	assert.Equal(t, first.ID, refunds[0].ID)
+	assert.Equal(t, second.ID, refunds[1].ID)
+	assert.Equal(t, 10.5, refunds[0].Amount)
+	assert.Equal(t, "Damaged in transit", refunds[0].Reason)
+	assert.Equal(t, "RF-0001", refunds[0].Reference)
+	assert.True(t, baseTime.Equal(refunds[0].CreatedAt))
+}
+
+func testRefundsListWithoutRefunds(t *testing.T, repo ports.RefundRepository) {
+	refunds, err := repo.ListByOrderID(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.NotNil(t, refunds)
+	assert.Empty(t, refunds)
+}
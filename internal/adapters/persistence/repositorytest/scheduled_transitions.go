@@ -0,0 +1,161 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunScheduledTransitionRepositoryTests runs the conformance suite against the repositories built by newRepository.
+// Every subtest gets a fresh, empty repository.
+func RunScheduledTransitionRepositoryTests(t *testing.T, newRepository func(t *testing.T) ports.ScheduledTransitionRepository) {
+	tests := map[string]func(t *testing.T, repo ports.ScheduledTransitionRepository){
+		"CreateAndListOldestFirst":  testScheduledTransitionsCreateAndListOldestFirst,
+		"FindDueEarliestFirst":      testScheduledTransitionsFindDueEarliestFirst,
+		"UpdateRecordsOutcome":      testScheduledTransitionsUpdateRecordsOutcome,
+		"UpdateCompletedTransition": testScheduledTransitionsUpdateCompletedTransition,
+		"UpdateUnknownTransition":   testScheduledTransitionsUpdateUnknownTransition,
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test(t, newRepository(t))
+		})
+	}
+}
+
+func newScheduledTransition(orderID uint, minutes int, target entities.OrderStatus) *entities.ScheduledTransition {
+	return &entities.ScheduledTransition{
+		OrderID:      orderID,
+		TargetStatus: target,
+		ExecuteAt:    baseTime.Add(time.Duration(minutes) * time.Minute),
+		CreatedBy:    "support",
+		Status:       entities.ScheduledTransitionPending,
+		CreatedAt:    baseTime,
+	}
+}
+
+func testScheduledTransitionsCreateAndListOldestFirst(t *testing.T, repo ports.ScheduledTransitionRepository) {
+	ctx := context.Background()
+	first, err := repo.Create(ctx, newScheduledTransition(1, 60, entities.OrderStatusCancelled))
+	require.NoError(t, err)
+	second, err := repo.Create(ctx, newScheduledTransition(1, 30, entities.OrderStatusConfirmed))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, newScheduledTransition(2, 10, entities.OrderStatusCancelled))
+	require.NoError(t, err)
+
+	assert.NotZero(t, first.ID)
+	assert.NotEqual(t, first.ID, second.ID)
+
+	transitions, err := repo.ListByOrderID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, transitions, 2)
+	assert.Equal(t, first.ID, transitions[0].ID)
+	assert.Equal(t, second.ID, transitions[1].ID)
+	assert.Equal(t, entities.OrderStatusCancelled, transitions[0].TargetStatus)
+	assert.True(t, baseTime.Add(time.Hour).Equal(transitions[0].ExecuteAt))
+	assert.Equal(t, "support", transitions[0].CreatedBy)
+	assert.Equal(t, entities.ScheduledTransitionPending, transitions[0].Status)
+	assert.Nil(t, transitions[0].CompletedAt)
+
+	none, err := repo.ListByOrderID(ctx, 99)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func testScheduledTransitionsFindDueEarliestFirst(t *testing.T, repo ports.ScheduledTransitionRepository) {
+	ctx := context.Background()
+	late, err := repo.Create(ctx, newScheduledTransition(1, 20, entities.OrderStatusCancelled))
+	require.NoError(t, err)
+	early, err := repo.Create(ctx, newScheduledTransition(2, 10, entities.OrderStatusCancelled))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, newScheduledTransition(3, 90, entities.OrderStatusCancelled))
+	require.NoError(t, err)
+	cancelled, err := repo.Create(ctx, newScheduledTransition(4, 5, entities.OrderStatusCancelled))
+	require.NoError(t, err)
+	require.NoError(t, cancelled.Cancel(baseTime))
+	_, err = repo.Update(ctx, cancelled)
+	require.NoError(t, err)
+
+	due, err := repo.FindDue(ctx, baseTime.Add(30*time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	assert.Equal(t, early.ID, due[0].ID)
+	assert.Equal(t, late.ID, due[1].ID)
+
+	limited, err := repo.FindDue(ctx, baseTime.Add(30*time.Minute), 1)
+	require.NoError(t, err)
+	require.Len(t, limited, 1)
+	assert.Equal(t, early.ID, limited[0].ID)
+
+	// A transition is due at its execution time
+	exact, err := repo.FindDue(ctx, baseTime.Add(10*time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, exact, 1)
+	assert.Equal(t, early.ID, exact[0].ID)
+}
+
+func testScheduledTransitionsUpdateRecordsOutcome(t *testing.T, repo ports.ScheduledTransitionRepository) {
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newScheduledTransition(1, 0, entities.OrderStatusCancelled))
+	require.NoError(t, err)
+
+	require.NoError(t, created.MarkFailed(baseTime.Add(time.Minute), "INVALID_STATUS_TRANSITION", "order cannot be cancelled in current status"))
+	_, err = repo.Update(ctx, created)
+	require.NoError(t, err)
+
+	transitions, err := repo.ListByOrderID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, transitions, 1)
+	assert.Equal(t, entities.ScheduledTransitionFailed, transitions[0].Status)
+	assert.Equal(t, "INVALID_STATUS_TRANSITION", transitions[0].FailureCode)
+	assert.Equal(t, "order cannot be cancelled in current status", transitions[0].FailureMessage)
+	require.NotNil(t, transitions[0].CompletedAt)
+	assert.True(t, baseTime.Add(time.Minute).Equal(*transitions[0].CompletedAt))
+
+	due, err := repo.FindDue(ctx, baseTime.Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+}
+
+func testScheduledTransitionsUpdateCompletedTransition(t *testing.T, repo ports.ScheduledTransitionRepository) {
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newScheduledTransition(1, 0, entities.OrderStatusCancelled))
+	require.NoError(t, err)
+
+	cancelled := created.Clone()
+	require.NoError(t, cancelled.Cancel(baseTime))
+	_, err = repo.Update(ctx, cancelled)
+	require.NoError(t, err)
+
+	// An execution that read the transition before the cancellation must not overwrite it
+	require.NoError(t, created.MarkExecuted(baseTime))
+	_, err = repo.Update(ctx, created)
+	assert.ErrorIs(t, err, domainErrors.ErrScheduledTransitionNotPending)
+
+	transitions, err := repo.ListByOrderID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, transitions, 1)
+	assert.Equal(t, entities.ScheduledTransitionCancelled, transitions[0].Status)
+}
+
+func testScheduledTransitionsUpdateUnknownTransition(t *testing.T, repo ports.ScheduledTransitionRepository) {
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newScheduledTransition(1, 0, entities.OrderStatusCancelled))
+	require.NoError(t, err)
+
+	// A transition of another order is unknown too
+	created.OrderID = 2
+	_, err = repo.Update(ctx, created)
+	assert.ErrorIs(t, err, domainErrors.ErrScheduledTransitionNotFound)
+
+	_, err = repo.Update(ctx, &entities.ScheduledTransition{ID: 999, OrderID: 1})
+	assert.ErrorIs(t, err, domainErrors.ErrScheduledTransitionNotFound)
+}
@@ -0,0 +1,98 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunShipmentRepositoryTests runs the conformance suite against the repositories built by newRepository.
+// Every subtest gets a fresh, empty repository.
+func RunShipmentRepositoryTests(t *testing.T, newRepository func(t *testing.T) ports.ShipmentRepository) {
+	tests := map[string]func(t *testing.T, repo ports.ShipmentRepository){
+		"CreateAndListOldestFirst": testShipmentsCreateAndListOldestFirst,
+		"UpdateRecordsDelivery":    testShipmentsUpdateRecordsDelivery,
+		"UpdateUnknownShipment":    testShipmentsUpdateUnknownShipment,
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test(t, newRepository(t))
+		})
+	}
+}
+
+func newShipment(orderID uint, minutes int, items ...entities.ShipmentItem) *entities.Shipment {
+	return &entities.Shipment{
+		OrderID:        orderID,
+		Items:          items,
+		Carrier:        "DHL",
+		TrackingNumber: "JD0123456789",
+		ShippedAt:      baseTime.Add(time.Duration(minutes) * time.Minute),
+	}
+}
+
+func testShipmentsCreateAndListOldestFirst(t *testing.T, repo ports.ShipmentRepository) {
+	ctx := context.Background()
+	first, err := repo.Create(ctx, newShipment(1, 0, entities.ShipmentItem{ProductID: 1, Quantity: 2}, entities.ShipmentItem{ProductID: 2, Quantity: 1}))
+	require.NoError(t, err)
+	second, err := repo.Create(ctx, newShipment(1, 5, entities.ShipmentItem{ProductID: 1, Quantity: 1}))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, newShipment(2, 1, entities.ShipmentItem{ProductID: 1, Quantity: 1}))
+	require.NoError(t, err)
+
+	assert.NotZero(t, first.ID)
+	assert.NotEqual(t, first.ID, second.ID)
+
+	shipments, err := repo.ListByOrderID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, shipments, 2)
+	assert.Equal(t, first.ID, shipments[0].ID)
+	assert.Equal(t, second.ID, shipments[1].ID)
+	assert.Equal(t, []entities.ShipmentItem{{ProductID: 1, Quantity: 2}, {ProductID: 2, Quantity: 1}}, shipments[0].Items)
+	assert.Equal(t, "DHL", shipments[0].Carrier)
+	assert.Equal(t, "JD0123456789", shipments[0].TrackingNumber)
+	assert.True(t, baseTime.Equal(shipments[0].ShippedAt))
+	assert.Nil(t, shipments[0].DeliveredAt)
+
+	none, err := repo.ListByOrderID(ctx, 99)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func testShipmentsUpdateRecordsDelivery(t *testing.T, repo ports.ShipmentRepository) {
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newShipment(1, 0, entities.ShipmentItem{ProductID: 1, Quantity: 1}))
+	require.NoError(t, err)
+
+	require.NoError(t, created.MarkDelivered(baseTime.Add(time.Hour)))
+	_, err = repo.Update(ctx, created)
+	require.NoError(t, err)
+
+	shipments, err := repo.ListByOrderID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, shipments, 1)
+	require.NotNil(t, shipments[0].DeliveredAt)
+	assert.True(t, baseTime.Add(time.Hour).Equal(*shipments[0].DeliveredAt))
+}
+
+func testShipmentsUpdateUnknownShipment(t *testing.T, repo ports.ShipmentRepository) {
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newShipment(1, 0, entities.ShipmentItem{ProductID: 1, Quantity: 1}))
+	require.NoError(t, err)
+
+	// A shipment of another order is unknown too
+	created.OrderID = 2
+	_, err = repo.Update(ctx, created)
+	assert.ErrorIs(t, err, domainErrors.ErrShipmentNotFound)
+
+	_, err = repo.Update(ctx, &entities.Shipment{ID: 999, OrderID: 1})
+	assert.ErrorIs(t, err, domainErrors.ErrShipmentNotFound)
+}
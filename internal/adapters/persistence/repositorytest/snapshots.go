@@ -0,0 +1,110 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunSnapshotRepositoryTests runs the conformance suite against the repositories built by newRepository.
+// Every subtest gets a fresh, empty repository.
+func RunSnapshotRepositoryTests(t *testing.T, newRepository func(t *testing.T) ports.SnapshotRepository) {
+	tests := map[string]func(t *testing.T, repo ports.SnapshotRepository){
+		"CreateAndListByVersion":     testSnapshotsCreateAndListByVersion,
+		"GetByVersion":               testSnapshotsGetByVersion,
+		"GetUnknownVersion":          testSnapshotsGetUnknownVersion,
+		"DuplicateVersionIsRejected": testSnapshotsDuplicateVersionIsRejected,
+		"ListWithoutSnapshots":       testSnapshotsListWithoutSnapshots,
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test(t, newRepository(t))
+		})
+	}
+}
+
+func newSnapshot(orderID uint, version int, reason entities.SnapshotReason) *entities.OrderSnapshot {
+	return &entities.OrderSnapshot{
+		OrderID:       orderID,
+		Version:       version,
+		Reason:        reason,
+		SchemaVersion: 4,
+		TakenAt:       baseTime.Add(time.Duration(version) * time.Minute),
+		Payload:       []byte(`{"order_id": 1, "total_amount": 25}`),
+	}
+}
+
+func testSnapshotsCreateAndListByVersion(t *testing.T, repo ports.SnapshotRepository) {
+	ctx := context.Background()
+	first, err := repo.Create(ctx, newSnapshot(1, 1, entities.SnapshotReasonConfirmed))
+	require.NoError(t, err)
+	second, err := repo.Create(ctx, newSnapshot(1, 2, entities.SnapshotReasonAmended))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, newSnapshot(2, 1, entities.SnapshotReasonConfirmed))
+	require.NoError(t, err)
+
+	assert.NotZero(t, first.ID)
+	assert.NotEqual(t, first.ID, second.ID)
+
+	snapshots, err := repo.ListByOrderID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, 1, snapshots[0].Version)
+	assert.Equal(t, entities.SnapshotReasonConfirmed, snapshots[0].Reason)
+	assert.Equal(t, 2, snapshots[1].Version)
+	assert.Equal(t, entities.SnapshotReasonAmended, snapshots[1].Reason)
+	assert.Equal(t, 4, snapshots[0].SchemaVersion)
+	assert.True(t, baseTime.Add(time.Minute).Equal(snapshots[0].TakenAt))
+}
+
+func testSnapshotsGetByVersion(t *testing.T, repo ports.SnapshotRepository) {
+	ctx := context.Background()
+	_, err := repo.Create(ctx, newSnapshot(1, 1, entities.SnapshotReasonConfirmed))
+	require.NoError(t, err)
+
+	snapshot, err := repo.GetByVersion(ctx, 1, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), snapshot.OrderID)
+	assert.JSONEq(t, `{"order_id": 1, "total_amount": 25}`, string(snapshot.Payload))
+}
+
+func testSnapshotsGetUnknownVersion(t *testing.T, repo ports.SnapshotRepository) {
+	ctx := context.Background()
+	_, err := repo.Create(ctx, newSnapshot(1, 1, entities.SnapshotReasonConfirmed))
+	require.NoError(t, err)
+
+	snapshot, err := repo.GetByVersion(ctx, 1, 2)
+
+	assert.Nil(t, snapshot)
+	assert.ErrorIs(t, err, domainErrors.ErrSnapshotNotFound)
+}
+
+func testSnapshotsDuplicateVersionIsRejected(t *testing.T, repo ports.SnapshotRepository) {
+	ctx := context.Background()
+	_, err := repo.Create(ctx, newSnapshot(1, 1, entities.SnapshotReasonConfirmed))
+	require.NoError(t, err)
+
+	_, err = repo.Create(ctx, newSnapshot(1, 1, entities.SnapshotReasonAmended))
+
+	assert.Error(t, err)
+	snapshots, err := repo.ListByOrderID(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+}
+
+func testSnapshotsListWithoutSnapshots(t *testing.T, repo ports.SnapshotRepository) {
+	snapshots, err := repo.ListByOrderID(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.NotNil(t, snapshots)
+	assert.Empty(t, snapshots)
+}
@@ -0,0 +1,27 @@
+package repositorytest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+// OpenSQLite opens a SQLite database in a file of its own with the tables of models, closed when the test ends.
+// The journal is written ahead so a reader on one connection sees the last commit while another connection
+// holds a write transaction open, which the concurrency cases of the suites rely on.
+func OpenSQLite(t testing.TB, models ...any) *gorm.DB {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "orders.db") + "?_journal_mode=WAL&_busy_timeout=5000"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: gormLogger.Default.LogMode(gormLogger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(models...))
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	return db
+}
@@ -0,0 +1,219 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunWebhookDeadLetterRepositoryTests runs the conformance suite against the repositories built by newRepository.
+// Every subtest gets a fresh, empty repository.
+func RunWebhookDeadLetterRepositoryTests(t *testing.T, newRepository func(t *testing.T) ports.WebhookDeadLetterRepository) {
+	tests := map[string]func(t *testing.T, repo ports.WebhookDeadLetterRepository){
+		"CreateAndGet":              testWebhookDeadLettersCreateAndGet,
+		"GetOtherWebhook":           testWebhookDeadLettersGetOtherWebhook,
+		"ListOldestFirstAndFilters": testWebhookDeadLettersListOldestFirstAndFilters,
+		"ListPages":                 testWebhookDeadLettersListPages,
+		"ClaimReplayOnce":           testWebhookDeadLettersClaimReplayOnce,
+		"ClaimReplayConcurrently":   testWebhookDeadLettersClaimReplayConcurrently,
+		"ClaimReplayUnknown":        testWebhookDeadLettersClaimReplayUnknown,
+		"ReleaseReplay":             testWebhookDeadLettersReleaseReplay,
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test(t, newRepository(t))
+		})
+	}
+}
+
+func newWebhookDeadLetter(webhookID string, minutes int) *entities.WebhookDeadLetter {
+	return entities.NewWebhookDeadLetter(webhookID, "order.created", []byte(`{"type":"order.created"}`), 5,
+		errors.New("unexpected status 503"), baseTime.Add(time.Duration(minutes)*time.Minute))
+}
+
+func testWebhookDeadLettersCreateAndGet(t *testing.T, repo ports.WebhookDeadLetterRepository) {
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newWebhookDeadLetter("partner", 0))
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+
+	found, err := repo.GetByID(ctx, "partner", created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "partner", found.WebhookID)
+	assert.Equal(t, "order.created", found.EventType)
+	assert.JSONEq(t, `{"type":"order.created"}`, string(found.Payload))
+	assert.Equal(t, "unexpected status 503", found.LastError)
+	assert.Equal(t, 5, found.Attempts)
+	assert.True(t, baseTime.Equal(found.CreatedAt))
+	assert.Nil(t, found.ReplayedAt)
+}
+
+func testWebhookDeadLettersGetOtherWebhook(t *testing.T, repo ports.WebhookDeadLetterRepository) {
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newWebhookDeadLetter("partner", 0))
+	require.NoError(t, err)
+
+	_, err = repo.GetByID(ctx, "other", created.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrWebhookDeadLetterNotFound)
+
+	_, err = repo.GetByID(ctx, "partner", 999)
+	assert.ErrorIs(t, err, domainErrors.ErrWebhookDeadLetterNotFound)
+}
+
+func testWebhookDeadLettersListOldestFirstAndFilters(t *testing.T, repo ports.WebhookDeadLetterRepository) {
+	ctx := context.Background()
+	late, err := repo.Create(ctx, newWebhookDeadLetter("partner", 60))
+	require.NoError(t, err)
+	early, err := repo.Create(ctx, newWebhookDeadLetter("partner", 10))
+	require.NoError(t, err)
+	replayed, err := repo.Create(ctx, newWebhookDeadLetter("partner", 30))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, newWebhookDeadLetter("other", 20))
+	require.NoError(t, err)
+	_, err = repo.ClaimReplay(ctx, "partner", replayed.ID, baseTime.Add(2*time.Hour))
+	require.NoError(t, err)
+
+	all, total, err := repo.List(ctx, "partner", ports.WebhookDeadLetterFilter{}, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, all, 3)
+	assert.Equal(t, []uint{early.ID, replayed.ID, late.ID}, []uint{all[0].ID, all[1].ID, all[2].ID})
+
+	pending, total, err := repo.List(ctx, "partner", ports.WebhookDeadLetterFilter{Pending: true}, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, pending, 2)
+	assert.Equal(t, early.ID, pending[0].ID)
+	assert.Equal(t, late.ID, pending[1].ID)
+
+	// From is inclusive and To exclusive
+	from, to := baseTime.Add(30*time.Minute), baseTime.Add(60*time.Minute)
+	ranged, total, err := repo.List(ctx, "partner", ports.WebhookDeadLetterFilter{From: &from, To: &to}, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, ranged, 1)
+	assert.Equal(t, replayed.ID, ranged[0].ID)
+	require.NotNil(t, ranged[0].ReplayedAt)
+	assert.True(t, baseTime.Add(2*time.Hour).Equal(*ranged[0].ReplayedAt))
+
+	none, total, err := repo.List(ctx, "unknown", ports.WebhookDeadLetterFilter{}, 10, 0)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	assert.Empty(t, none)
+}
+
+func testWebhookDeadLettersListPages(t *testing.T, repo ports.WebhookDeadLetterRepository) {
+	ctx := context.Background()
+	var ids []uint
+	for i := 0; i < 5; i++ {
+		created, err := repo.Create(ctx, newWebhookDeadLetter("partner", i))
+		require.NoError(t, err)
+		ids = append(ids, created.ID)
+	}
+
+	page, total, err := repo.List(ctx, "partner", ports.WebhookDeadLetterFilter{}, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	require.Len(t, page, 2)
+	assert.Equal(t, ids[2], page[0].ID)
+	assert.Equal(t, ids[3], page[1].ID)
+
+	beyond, total, err := repo.List(ctx, "partner", ports.WebhookDeadLetterFilter{}, 2, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.Empty(t, beyond)
+}
+
+func testWebhookDeadLettersClaimReplayOnce(t *testing.T, repo ports.WebhookDeadLetterRepository) {
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newWebhookDeadLetter("partner", 0))
+	require.NoError(t, err)
+
+	claimed, err := repo.ClaimReplay(ctx, "partner", created.ID, baseTime.Add(time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, claimed.ReplayedAt)
+	assert.True(t, baseTime.Add(time.Hour).Equal(*claimed.ReplayedAt))
+	assert.Equal(t, 5, claimed.Attempts)
+
+	_, err = repo.ClaimReplay(ctx, "partner", created.ID, baseTime.Add(2*time.Hour))
+	assert.ErrorIs(t, err, domainErrors.ErrWebhookDeadLetterReplayed)
+
+	found, err := repo.GetByID(ctx, "partner", created.ID)
+	require.NoError(t, err)
+	require.NotNil(t, found.ReplayedAt)
+	assert.True(t, baseTime.Add(time.Hour).Equal(*found.ReplayedAt))
+}
+
+func testWebhookDeadLettersClaimReplayConcurrently(t *testing.T, repo ports.WebhookDeadLetterRepository) {
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newWebhookDeadLetter("partner", 0))
+	require.NoError(t, err)
+
+	const replays = 8
+	errs := make(chan error, replays)
+	var wg sync.WaitGroup
+	for i := 0; i < replays; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.ClaimReplay(ctx, "partner", created.ID, baseTime.Add(time.Hour))
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	claimed := 0
+	for err := range errs {
+		if err == nil {
+			claimed++
+			continue
+		}
+		assert.ErrorIs(t, err, domainErrors.ErrWebhookDeadLetterReplayed)
+	}
+	assert.Equal(t, 1, claimed)
+}
+
+func testWebhookDeadLettersClaimReplayUnknown(t *testing.T, repo ports.WebhookDeadLetterRepository) {
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newWebhookDeadLetter("partner", 0))
+	require.NoError(t, err)
+
+	_, err = repo.ClaimReplay(ctx, "other", created.ID, baseTime)
+	assert.ErrorIs(t, err, domainErrors.ErrWebhookDeadLetterNotFound)
+
+	_, err = repo.ClaimReplay(ctx, "partner", 999, baseTime)
+	assert.ErrorIs(t, err, domainErrors.ErrWebhookDeadLetterNotFound)
+}
+
+func testWebhookDeadLettersReleaseReplay(t *testing.T, repo ports.WebhookDeadLetterRepository) {
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newWebhookDeadLetter("partner", 0))
+	require.NoError(t, err)
+	claimed, err := repo.ClaimReplay(ctx, "partner", created.ID, baseTime.Add(time.Hour))
+	require.NoError(t, err)
+
+	claimed.RecordFailure(3, errors.New("connection refused"))
+	released, err := repo.ReleaseReplay(ctx, claimed)
+	require.NoError(t, err)
+	assert.Nil(t, released.ReplayedAt)
+	assert.Equal(t, 8, released.Attempts)
+	assert.Equal(t, "connection refused", released.LastError)
+
+	// The released dead letter is pending again and can be claimed by the next replay
+	_, err = repo.ClaimReplay(ctx, "partner", created.ID, baseTime.Add(2*time.Hour))
+	require.NoError(t, err)
+
+	_, err = repo.ReleaseReplay(ctx, &entities.WebhookDeadLetter{ID: 999, WebhookID: "partner"})
+	assert.ErrorIs(t, err, domainErrors.ErrWebhookDeadLetterNotFound)
+}
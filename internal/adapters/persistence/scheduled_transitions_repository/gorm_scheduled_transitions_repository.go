@@ -0,0 +1,156 @@
+package scheduled_transition_repository
+
+import (
+	"context"
+	"time"
+
+	"orders-service/internal/adapters/persistence/transaction"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+
+	"gorm.io/gorm"
+)
+
+// ScheduledTransitionModel represents the database model for scheduled status transitions
+type ScheduledTransitionModel struct {
+	ID             uint      `gorm:"primarykey"`
+	OrderID        uint      `gorm:"not null;index"`
+	TargetStatus   string    `gorm:"size:50;not null"`
+	ExecuteAt      time.Time `gorm:"not null;index:idx_scheduled_transitions_due,priority:2"`
+	CreatedBy      string    `gorm:"size:255;not null"`
+	Status         string    `gorm:"size:20;not null;index:idx_scheduled_transitions_due,priority:1"`
+	FailureCode    string    `gorm:"size:100"`
+	FailureMessage string    `gorm:"size:500"`
+	CompletedAt    *time.Time
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (ScheduledTransitionModel) TableName() string {
+	return "scheduled_transitions"
+}
+
+// GormScheduledTransitionRepository implements the ScheduledTransitionRepository interface using GORM
+type GormScheduledTransitionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormScheduledTransitionRepository creates a new GORM scheduled transition repository
+func NewGormScheduledTransitionRepository(db *gorm.DB) ports.ScheduledTransitionRepository {
+	return &GormScheduledTransitionRepository{db: db}
+}
+
+// Create implements ports.ScheduledTransitionRepository
+func (r *GormScheduledTransitionRepository) Create(ctx context.Context, transition *entities.ScheduledTransition) (*entities.ScheduledTransition, error) {
+	model := toModel(transition)
+	if err := transaction.Conn(ctx, r.db).Create(model).Error; err != nil {
+		return nil, err
+	}
+	return toEntity(model), nil
+}
+
+// Update implements ports.ScheduledTransitionRepository. The target and execution time are fixed once
+// scheduled, so only the outcome is written and only while the stored transition is pending.
+func (r *GormScheduledTransitionRepository) Update(ctx context.Context, transition *entities.ScheduledTransition) (*entities.ScheduledTransition, error) {
+	db := transaction.Conn(ctx, r.db)
+
+	result := db.Model(&ScheduledTransitionModel{}).
+		Where("id = ? AND order_id = ? AND status = ?", transition.ID, transition.OrderID, string(entities.ScheduledTransitionPending)).
+		Updates(map[string]interface{}{
+			"status":          string(transition.Status),
+			"failure_code":    transition.FailureCode,
+			"failure_message": transition.FailureMessage,
+			"completed_at":    transition.CompletedAt,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return transition.Clone(), nil
+	}
+
+	// Nothing pending matched, tell an unknown transition from one that already completed
+	var count int64
+	if err := db.Model(&ScheduledTransitionModel{}).
+		Where("id = ? AND order_id = ?", transition.ID, transition.OrderID).
+		Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, domainErrors.ErrScheduledTransitionNotFound
+	}
+	return nil, domainErrors.ErrScheduledTransitionNotPending
+}
+
+// ListByOrderID implements ports.ScheduledTransitionRepository
+func (r *GormScheduledTransitionRepository) ListByOrderID(ctx context.Context, orderID uint) ([]*entities.ScheduledTransition, error) {
+	var models []ScheduledTransitionModel
+
+	err := transaction.Conn(ctx, r.db).
+		Where("order_id = ?", orderID).
+		Order("id ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return toEntities(models), nil
+}
+
+// FindDue implements ports.ScheduledTransitionRepository
+func (r *GormScheduledTransitionRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.ScheduledTransition, error) {
+	var models []ScheduledTransitionModel
+
+	err := transaction.Conn(ctx, r.db).
+		Where("status = ? AND execute_at <= ?", string(entities.ScheduledTransitionPending), now).
+		Order("execute_at ASC, id ASC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return toEntities(models), nil
+}
+
+func toModel(transition *entities.ScheduledTransition) *ScheduledTransitionModel {
+	return &ScheduledTransitionModel{
+		ID:             transition.ID,
+		OrderID:        transition.OrderID,
+		TargetStatus:   string(transition.TargetStatus),
+		ExecuteAt:      transition.ExecuteAt,
+		CreatedBy:      transition.CreatedBy,
+		Status:         string(transition.Status),
+		FailureCode:    transition.FailureCode,
+		FailureMessage: transition.FailureMessage,
+		CompletedAt:    transition.CompletedAt,
+		CreatedAt:      transition.CreatedAt,
+	}
+}
+
+func toEntity(model *ScheduledTransitionModel) *entities.ScheduledTransition {
+	transition := &entities.ScheduledTransition{
+		ID:             model.ID,
+		OrderID:        model.OrderID,
+		TargetStatus:   entities.OrderStatus(model.TargetStatus),
+		ExecuteAt:      model.ExecuteAt.UTC(),
+		CreatedBy:      model.CreatedBy,
+		Status:         entities.ScheduledTransitionStatus(model.Status),
+		FailureCode:    model.FailureCode,
+		FailureMessage: model.FailureMessage,
+		CreatedAt:      model.CreatedAt.UTC(),
+	}
+	if model.CompletedAt != nil {
+		completedAt := model.CompletedAt.UTC()
+		transition.CompletedAt = &completedAt
+	}
+	return transition
+}
+
+func toEntities(models []ScheduledTransitionModel) []*entities.ScheduledTransition {
+	transitions := make([]*entities.ScheduledTransition, 0, len(models))
+	for i := range models {
+		transitions = append(transitions, toEntity(&models[i]))
+	}
+	return transitions
+}
@@ -0,0 +1,14 @@
+package scheduled_transition_repository
+
+import (
+	"testing"
+
+	"orders-service/internal/adapters/persistence/repositorytest"
+	"orders-service/internal/application/ports"
+)
+
+func TestGormScheduledTransitionRepository_Conformance(t *testing.T) {
+	repositorytest.RunScheduledTransitionRepositoryTests(t, func(t *testing.T) ports.ScheduledTransitionRepository {
+		return NewGormScheduledTransitionRepository(repositorytest.OpenSQLite(t, &ScheduledTransitionModel{}))
+	})
+}
@@ -0,0 +1,140 @@
+package shipment_repository
+
+import (
+	"context"
+	"time"
+
+	"orders-service/internal/adapters/persistence/transaction"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+
+	"gorm.io/gorm"
+)
+
+// ShipmentModel represents the database model for shipments
+type ShipmentModel struct {
+	ID             uint                `gorm:"primarykey"`
+	OrderID        uint                `gorm:"not null;index"`
+	Items          []ShipmentItemModel `gorm:"foreignKey:ShipmentID;constraint:OnDelete:CASCADE"`
+	Carrier        string              `gorm:"size:100"`
+	TrackingNumber string              `gorm:"size:100"`
+	ShippedAt      time.Time           `gorm:"not null"`
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (ShipmentModel) TableName() string {
+	return "shipments"
+}
+
+// ShipmentItemModel represents the database model for the items of a shipment
+type ShipmentItemModel struct {
+	ID         uint `gorm:"primarykey"`
+	ShipmentID uint `gorm:"not null;index"`
+	ProductID  uint `gorm:"not null"`
+	Quantity   int  `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (ShipmentItemModel) TableName() string {
+	return "shipment_items"
+}
+
+// GormShipmentRepository implements the ShipmentRepository interface using GORM
+type GormShipmentRepository struct {
+	db *gorm.DB
+}
+
+// NewGormShipmentRepository creates a new GORM shipment repository
+func NewGormShipmentRepository(db *gorm.DB) ports.ShipmentRepository {
+	return &GormShipmentRepository{db: db}
+}
+
+// Create implements ports.ShipmentRepository
+func (r *GormShipmentRepository) Create(ctx context.Context, shipment *entities.Shipment) (*entities.Shipment, error) {
+	model := toModel(shipment)
+	if err := transaction.Conn(ctx, r.db).Create(model).Error; err != nil {
+		return nil, err
+	}
+	return toEntity(model), nil
+}
+
+// Update implements ports.ShipmentRepository. Items and tracking are fixed once shipped,
+// so only the delivery time is written.
+func (r *GormShipmentRepository) Update(ctx context.Context, shipment *entities.Shipment) (*entities.Shipment, error) {
+	result := transaction.Conn(ctx, r.db).
+		Model(&ShipmentModel{}).
+		Where("id = ? AND order_id = ?", shipment.ID, shipment.OrderID).
+		Update("delivered_at", shipment.DeliveredAt)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, domainErrors.ErrShipmentNotFound
+	}
+	return shipment.Clone(), nil
+}
+
+// ListByOrderID implements ports.ShipmentRepository
+func (r *GormShipmentRepository) ListByOrderID(ctx context.Context, orderID uint) ([]*entities.Shipment, error) {
+	var models []ShipmentModel
+
+	err := transaction.Conn(ctx, r.db).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Where("order_id = ?", orderID).
+		Order("shipped_at ASC, id ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	shipments := make([]*entities.Shipment, 0, len(models))
+	for i := range models {
+		shipments = append(shipments, toEntity(&models[i]))
+	}
+	return shipments, nil
+}
+
+func toModel(shipment *entities.Shipment) *ShipmentModel {
+	model := &ShipmentModel{
+		ID:             shipment.ID,
+		OrderID:        shipment.OrderID,
+		Items:          make([]ShipmentItemModel, 0, len(shipment.Items)),
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		ShippedAt:      shipment.ShippedAt,
+		DeliveredAt:    shipment.DeliveredAt,
+	}
+	for _, item := range shipment.Items {
+		model.Items = append(model.Items, ShipmentItemModel{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+	return model
+}
+
+func toEntity(model *ShipmentModel) *entities.Shipment {
+	shipment := &entities.Shipment{
+		ID:             model.ID,
+		OrderID:        model.OrderID,
+		Items:          make([]entities.ShipmentItem, 0, len(model.Items)),
+		Carrier:        model.Carrier,
+		TrackingNumber: model.TrackingNumber,
+		ShippedAt:      model.ShippedAt.UTC(),
+	}
+	if model.DeliveredAt != nil {
+		deliveredAt := model.DeliveredAt.UTC()
+		shipment.DeliveredAt = &deliveredAt
+	}
+	for _, item := range model.Items {
+		shipment.Items = append(shipment.Items, entities.ShipmentItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+	return shipment
+}
@@ -0,0 +1,14 @@
+package shipment_repository
+
+import (
+	"testing"
+
+	"orders-service/internal/adapters/persistence/repositorytest"
+	"orders-service/internal/application/ports"
+)
+
+func TestGormShipmentRepository_Conformance(t *testing.T) {
+	repositorytest.RunShipmentRepositoryTests(t, func(t *testing.T) ports.ShipmentRepository {
+		return NewGormShipmentRepository(repositorytest.OpenSQLite(t, &ShipmentModel{}, &ShipmentItemModel{}))
+	})
+}
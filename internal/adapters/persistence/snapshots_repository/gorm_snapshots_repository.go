@@ -0,0 +1,108 @@
+package snapshot_repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"orders-service/internal/adapters/persistence/transaction"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+
+	"gorm.io/gorm"
+)
+
+// SnapshotModel represents the database model for order snapshots
+type SnapshotModel struct {
+	ID            uint      `gorm:"primarykey"`
+	OrderID       uint      `gorm:"not null;uniqueIndex:idx_order_snapshots_order_version,priority:1"`
+	Version       int       `gorm:"not null;uniqueIndex:idx_order_snapshots_order_version,priority:2"`
+	Reason        string    `gorm:"size:32;not null"`
+	SchemaVersion int       `gorm:"not null"`
+	TakenAt       time.Time `gorm:"not null"`
+	Payload       string    `gorm:"type:jsonb;not null"`
+}
+
+// TableName specifies the table name for GORM
+func (SnapshotModel) TableName() string {
+	return "order_snapshots"
+}
+
+// GormSnapshotRepository implements the SnapshotRepository interface using GORM
+type GormSnapshotRepository struct {
+	db *gorm.DB
+}
+
+// NewGormSnapshotRepository creates a new GORM snapshot repository
+func NewGormSnapshotRepository(db *gorm.DB) ports.SnapshotRepository {
+	return &GormSnapshotRepository{db: db}
+}
+
+// Create implements ports.SnapshotRepository
+func (r *GormSnapshotRepository) Create(ctx context.Context, snapshot *entities.OrderSnapshot) (*entities.OrderSnapshot, error) {
+	model := toModel(snapshot)
+	if err := transaction.Conn(ctx, r.db).Create(model).Error; err != nil {
+		return nil, err
+	}
+	return toEntity(model), nil
+}
+
+// ListByOrderID implements ports.SnapshotRepository
+func (r *GormSnapshotRepository) ListByOrderID(ctx context.Context, orderID uint) ([]*entities.OrderSnapshot, error) {
+	var models []SnapshotModel
+
+	err := transaction.Conn(ctx, r.db).
+		Where("order_id = ?", orderID).
+		Order("version ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*entities.OrderSnapshot, 0, len(models))
+	for i := range models {
+		snapshots = append(snapshots, toEntity(&models[i]))
+	}
+	return snapshots, nil
+}
+
+// GetByVersion implements ports.SnapshotRepository
+func (r *GormSnapshotRepository) GetByVersion(ctx context.Context, orderID uint, version int) (*entities.OrderSnapshot, error) {
+	var model SnapshotModel
+
+	err := transaction.Conn(ctx, r.db).
+		Where("order_id = ? AND version = ?", orderID, version).
+		First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainErrors.ErrSnapshotNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toEntity(&model), nil
+}
+
+func toModel(snapshot *entities.OrderSnapshot) *SnapshotModel {
+	return &SnapshotModel{
+		ID:            snapshot.ID,
+		OrderID:       snapshot.OrderID,
+		Version:       snapshot.Version,
+		Reason:        string(snapshot.Reason),
+		SchemaVersion: snapshot.SchemaVersion,
+		TakenAt:       snapshot.TakenAt,
+		Payload:       string(snapshot.Payload),
+	}
+}
+
+func toEntity(model *SnapshotModel) *entities.OrderSnapshot {
+	return &entities.OrderSnapshot{
+		ID:            model.ID,
+		OrderID:       model.OrderID,
+		Version:       model.Version,
+		Reason:        entities.SnapshotReason(model.Reason),
+		SchemaVersion: model.SchemaVersion,
+		TakenAt:       model.TakenAt.UTC(),
+		Payload:       []byte(model.Payload),
+	}
+}
@@ -0,0 +1,14 @@
+package snapshot_repository
+
+import (
+	"testing"
+
+	"orders-service/internal/adapters/persistence/repositorytest"
+	"orders-service/internal/application/ports"
+)
+
+func TestGormSnapshotRepository_Conformance(t *testing.T) {
+	repositorytest.RunSnapshotRepositoryTests(t, func(t *testing.T) ports.SnapshotRepository {
+		return NewGormSnapshotRepository(repositorytest.OpenSQLite(t, &SnapshotModel{}))
+	})
+}
@@ -0,0 +1,58 @@
+package transaction
+
+import (
+	"context"
+
+	"orders-service/internal/application/ports"
+
+	"gorm.io/gorm"
+)
+
+// contextKey carries the open transaction shared by the GORM repositories
+type contextKey struct{}
+
+// WithTx returns a copy of ctx carrying tx
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, contextKey{}, tx)
+}
+
+// Active reports whether ctx carries a transaction
+func Active(ctx context.Context) bool {
+	return ctx.Value(contextKey{}) != nil
+}
+
+// Conn returns the transaction carried by ctx, or db when there is none, bound to ctx
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(contextKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// GormUnitOfWork implements ports.UnitOfWork with a database transaction shared through the context
+type GormUnitOfWork struct {
+	db    *gorm.DB
+	repos ports.Repositories
+}
+
+// NewGormUnitOfWork creates a unit of work running repos in transactions opened on db.
+// The repositories must read their connection with Conn to take part.
+func NewGormUnitOfWork(db *gorm.DB, repos ports.Repositories) ports.UnitOfWork {
+	return &GormUnitOfWork{db: db, repos: repos}
+}
+
+// Do implements ports.UnitOfWork
+func (u *GormUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context, repos ports.Repositories) error) error {
+	if Active(ctx) {
+		return fn(ctx, u.repos)
+	}
+
+	ctx, hooks := ports.WithCommitHooks(ctx)
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(WithTx(ctx, tx), u.repos)
+	})
+	if err == nil {
+		hooks.Run()
+	}
+	return err
+}
@@ -0,0 +1,79 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"orders-service/internal/application/ports"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// openDryRun opens a database that builds statements without connecting
+func openDryRun(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func TestConn_PrefersTransactionFromContext(t *testing.T) {
+	db := openDryRun(t)
+	tx := openDryRun(t)
+
+	assert.False(t, Active(context.Background()))
+	assert.Same(t, db.ConnPool, Conn(context.Background(), db).Statement.ConnPool)
+
+	ctx := WithTx(context.Background(), tx)
+	assert.True(t, Active(ctx))
+	assert.Same(t, tx.ConnPool, Conn(ctx, db).Statement.ConnPool)
+}
+
+func TestGormUnitOfWork_JoinsOpenTransaction(t *testing.T) {
+	ctx := WithTx(context.Background(), openDryRun(t))
+	repos := ports.Repositories{}
+
+	// A nil db proves no new transaction is opened
+	unitOfWork := NewGormUnitOfWork(nil, repos)
+
+	var joined bool
+	err := unitOfWork.Do(ctx, func(innerCtx context.Context, got ports.Repositories) error {
+		joined = innerCtx == ctx
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, joined)
+}
+
+func TestGormUnitOfWork_RunsHooksAfterCommit(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("CREATE TABLE probes (id INTEGER)").Error)
+	unitOfWork := NewGormUnitOfWork(db, ports.Repositories{})
+
+	// The hook sees the committed row, reading it outside the transaction
+	var seen int64
+	err = unitOfWork.Do(context.Background(), func(ctx context.Context, repos ports.Repositories) error {
+		ports.AfterCommit(ctx, func() { db.Table("probes").Count(&seen) })
+		return Conn(ctx, db).Exec("INSERT INTO probes (id) VALUES (1)").Error
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), seen)
+
+	ran := false
+	err = unitOfWork.Do(context.Background(), func(ctx context.Context, repos ports.Repositories) error {
+		ports.AfterCommit(ctx, func() { ran = true })
+		return errors.New("boom")
+	})
+	assert.Error(t, err)
+	assert.False(t, ran, "the hooks of a rolled back unit of work never run")
+}
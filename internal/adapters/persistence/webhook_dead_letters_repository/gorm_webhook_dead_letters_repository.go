@@ -0,0 +1,177 @@
+package webhook_dead_letter_repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"orders-service/internal/adapters/persistence/transaction"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+
+	"gorm.io/gorm"
+)
+
+// WebhookDeadLetterModel represents the database model for webhook deliveries that failed after their retries
+type WebhookDeadLetterModel struct {
+	ID         uint      `gorm:"primarykey"`
+	WebhookID  string    `gorm:"size:100;not null;index:idx_webhook_dead_letters_webhook,priority:1"`
+	EventType  string    `gorm:"size:100;not null"`
+	Payload    []byte    `gorm:"not null"`
+	LastError  string    `gorm:"size:500"`
+	Attempts   int       `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"not null;index:idx_webhook_dead_letters_webhook,priority:2"`
+	ReplayedAt *time.Time
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (WebhookDeadLetterModel) TableName() string {
+	return "webhook_dead_letters"
+}
+
+// GormWebhookDeadLetterRepository implements the WebhookDeadLetterRepository interface using GORM
+type GormWebhookDeadLetterRepository struct {
+	db *gorm.DB
+}
+
+// NewGormWebhookDeadLetterRepository creates a new GORM webhook dead letter repository
+func NewGormWebhookDeadLetterRepository(db *gorm.DB) ports.WebhookDeadLetterRepository {
+	return &GormWebhookDeadLetterRepository{db: db}
+}
+
+// Create implements ports.WebhookDeadLetterRepository
+func (r *GormWebhookDeadLetterRepository) Create(ctx context.Context, deadLetter *entities.WebhookDeadLetter) (*entities.WebhookDeadLetter, error) {
+	model := toModel(deadLetter)
+	if err := transaction.Conn(ctx, r.db).Create(model).Error; err != nil {
+		return nil, err
+	}
+	return toEntity(model), nil
+}
+
+// GetByID implements ports.WebhookDeadLetterRepository
+func (r *GormWebhookDeadLetterRepository) GetByID(ctx context.Context, webhookID string, id uint) (*entities.WebhookDeadLetter, error) {
+	var model WebhookDeadLetterModel
+
+	err := transaction.Conn(ctx, r.db).
+		Where("id = ? AND webhook_id = ?", id, webhookID).
+		First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainErrors.ErrWebhookDeadLetterNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toEntity(&model), nil
+}
+
+// List implements ports.WebhookDeadLetterRepository
+func (r *GormWebhookDeadLetterRepository) List(ctx context.Context, webhookID string, filter ports.WebhookDeadLetterFilter, limit, offset int) ([]*entities.WebhookDeadLetter, int64, error) {
+	query := transaction.Conn(ctx, r.db).Model(&WebhookDeadLetterModel{}).Where("webhook_id = ?", webhookID)
+	if filter.Pending {
+		query = query.Where("replayed_at IS NULL")
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var models []WebhookDeadLetterModel
+	err := query.
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return toEntities(models), total, nil
+}
+
+// ClaimReplay implements ports.WebhookDeadLetterRepository. The dead letter is claimed by a single
+// conditional update, so of two replays racing for it only the one whose update matched delivers it.
+func (r *GormWebhookDeadLetterRepository) ClaimReplay(ctx context.Context, webhookID string, id uint, replayedAt time.Time) (*entities.WebhookDeadLetter, error) {
+	db := transaction.Conn(ctx, r.db)
+
+	result := db.Model(&WebhookDeadLetterModel{}).
+		Where("id = ? AND webhook_id = ? AND replayed_at IS NULL", id, webhookID).
+		Update("replayed_at", replayedAt.UTC())
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		// Nothing pending matched, tell an unknown dead letter from one replayed already
+		if _, err := r.GetByID(ctx, webhookID, id); err != nil {
+			return nil, err
+		}
+		return nil, domainErrors.ErrWebhookDeadLetterReplayed
+	}
+	return r.GetByID(ctx, webhookID, id)
+}
+
+// ReleaseReplay implements ports.WebhookDeadLetterRepository
+func (r *GormWebhookDeadLetterRepository) ReleaseReplay(ctx context.Context, deadLetter *entities.WebhookDeadLetter) (*entities.WebhookDeadLetter, error) {
+	result := transaction.Conn(ctx, r.db).Model(&WebhookDeadLetterModel{}).
+		Where("id = ? AND webhook_id = ?", deadLetter.ID, deadLetter.WebhookID).
+		Updates(map[string]interface{}{
+			"attempts":    deadLetter.Attempts,
+			"last_error":  deadLetter.LastError,
+			"replayed_at": nil,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, domainErrors.ErrWebhookDeadLetterNotFound
+	}
+
+	released := deadLetter.Clone()
+	released.ReplayedAt = nil
+	return released, nil
+}
+
+func toModel(deadLetter *entities.WebhookDeadLetter) *WebhookDeadLetterModel {
+	return &WebhookDeadLetterModel{
+		ID:         deadLetter.ID,
+		WebhookID:  deadLetter.WebhookID,
+		EventType:  deadLetter.EventType,
+		Payload:    deadLetter.Payload,
+		LastError:  deadLetter.LastError,
+		Attempts:   deadLetter.Attempts,
+		CreatedAt:  deadLetter.CreatedAt,
+		ReplayedAt: deadLetter.ReplayedAt,
+	}
+}
+
+func toEntity(model *WebhookDeadLetterModel) *entities.WebhookDeadLetter {
+	deadLetter := &entities.WebhookDeadLetter{
+		ID:        model.ID,
+		WebhookID: model.WebhookID,
+		EventType: model.EventType,
+		Payload:   model.Payload,
+		LastError: model.LastError,
+		Attempts:  model.Attempts,
+		CreatedAt: model.CreatedAt.UTC(),
+	}
+	if model.ReplayedAt != nil {
+		replayedAt := model.ReplayedAt.UTC()
+		deadLetter.ReplayedAt = &replayedAt
+	}
+	return deadLetter
+}
+
+func toEntities(models []WebhookDeadLetterModel) []*entities.WebhookDeadLetter {
+	deadLetters := make([]*entities.WebhookDeadLetter, 0, len(models))
+	for i := range models {
+		deadLetters = append(deadLetters, toEntity(&models[i]))
+	}
+	return deadLetters
+}
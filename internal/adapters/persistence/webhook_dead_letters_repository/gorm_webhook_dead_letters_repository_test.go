@@ -0,0 +1,14 @@
+package webhook_dead_letter_repository
+
+import (
+	"testing"
+
+	"orders-service/internal/adapters/persistence/repositorytest"
+	"orders-service/internal/application/ports"
+)
+
+func TestGormWebhookDeadLetterRepository_Conformance(t *testing.T) {
+	repositorytest.RunWebhookDeadLetterRepositoryTests(t, func(t *testing.T) ports.WebhookDeadLetterRepository {
+		return NewGormWebhookDeadLetterRepository(repositorytest.OpenSQLite(t, &WebhookDeadLetterModel{}))
+	})
+}
@@ -0,0 +1,176 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	appEvents "orders-service/internal/application/events"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainEvents "orders-service/internal/domain/events"
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+)
+
+// Metric names reported by Publisher
+const (
+	DeliveredMetric    = "webhook_deliveries_total"
+	DeadLetteredMetric = "webhook_dead_letters_total"
+)
+
+// deadLetterTimeout bounds storing a single dead letter so a slow database cannot stall an endpoint
+const deadLetterTimeout = 5 * time.Second
+
+// errQueueFull is stored as the error of deliveries an endpoint fell too far behind to queue
+var errQueueFull = errors.New("webhook delivery queue full")
+
+// delivery is an event payload waiting to be posted to an endpoint
+type delivery struct {
+	eventType string
+	payload   []byte
+}
+
+// queue holds the deliveries of one endpoint
+type queue struct {
+	endpoint   Endpoint
+	deliveries chan delivery
+}
+
+// Publisher implements ports.EventPublisher, posting every event to the endpoints subscribed to its type.
+// Events are encoded with the default schema version of internal/application/events. Each endpoint has its
+// own queue and goroutine, so an unreachable partner does not hold back the others. A delivery failing after
+// its retries, or dropped because the queue of its endpoint is full, is stored as a dead letter.
+type Publisher struct {
+	sender      ports.WebhookSender
+	deadLetters ports.WebhookDeadLetterRepository
+	queues      []*queue
+	now         func() time.Time
+
+	delivered    *metrics.Counter
+	deadLettered *metrics.Counter
+	logger       logger.Logger
+
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewPublisher starts a delivery goroutine per endpoint. Call Close to flush queued deliveries on shutdown.
+func NewPublisher(sender ports.WebhookSender, deadLetters ports.WebhookDeadLetterRepository, endpoints []Endpoint, bufferSize int, registry *metrics.Registry, log logger.Logger) *Publisher {
+	if bufferSize <= 0 {
+		bufferSize = 1000
+	}
+
+	publisher := &Publisher{
+		sender:       sender,
+		deadLetters:  deadLetters,
+		now:          time.Now,
+		delivered:    registry.Counter(DeliveredMetric),
+		deadLettered: registry.Counter(DeadLetteredMetric),
+		logger:       log.With("component", "webhook_publisher"),
+	}
+	for _, endpoint := range endpoints {
+		q := &queue{endpoint: endpoint, deliveries: make(chan delivery, bufferSize)}
+		publisher.queues = append(publisher.queues, q)
+
+		publisher.wg.Add(1)
+		go publisher.run(q)
+	}
+
+	return publisher
+}
+
+// Publish implements ports.EventPublisher. It only queues the deliveries, their outcome is never reported
+// to the caller.
+func (p *Publisher) Publish(ctx context.Context, event domainEvents.OrderEvent) error {
+	eventType := string(event.Type)
+
+	var payload []byte
+	for _, q := range p.queues {
+		if !q.endpoint.Subscribes(eventType) {
+			continue
+		}
+		if payload == nil {
+			var err error
+			if payload, err = appEvents.Encode(event, appEvents.DefaultSchemaVersion); err != nil {
+				return err
+			}
+		}
+		p.enqueue(q, delivery{eventType: eventType, payload: payload})
+	}
+	return nil
+}
+
+func (p *Publisher) enqueue(q *queue, d delivery) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		p.logger.Warn("Webhook publisher closed, dropping delivery", "webhook_id", q.endpoint.ID, "event_type", d.eventType)
+		return
+	}
+
+	select {
+	case q.deliveries <- d:
+	default:
+		p.logger.Error("Webhook queue full, dead-lettering delivery", "webhook_id", q.endpoint.ID, "event_type", d.eventType)
+		p.deadLetter(q.endpoint.ID, d, 0, errQueueFull)
+	}
+}
+
+// Close stops accepting events and waits until the queued deliveries are made or ctx is done
+func (p *Publisher) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		for _, q := range p.queues {
+			close(q.deliveries)
+		}
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Publisher) run(q *queue) {
+	defer p.wg.Done()
+
+	for d := range q.deliveries {
+		attempts, err := p.sender.Send(context.Background(), q.endpoint.ID, d.eventType, d.payload)
+		if err != nil {
+			p.logger.Error("Webhook delivery failed, dead-lettering it",
+				"webhook_id", q.endpoint.ID,
+				"event_type", d.eventType,
+				"attempts", attempts,
+				"error", err)
+			p.deadLetter(q.endpoint.ID, d, attempts, err)
+			continue
+		}
+		p.delivered.Inc()
+	}
+}
+
+func (p *Publisher) deadLetter(webhookID string, d delivery, attempts int, cause error) {
+	ctx, cancel := context.WithTimeout(context.Background(), deadLetterTimeout)
+	defer cancel()
+
+	deadLetter := entities.NewWebhookDeadLetter(webhookID, d.eventType, d.payload, attempts, cause, p.now())
+	if _, err := p.deadLetters.Create(ctx, deadLetter); err != nil {
+		p.logger.Error("Failed to store webhook dead letter", "webhook_id", webhookID, "event_type", d.eventType, "error", err)
+		return
+	}
+	p.deadLettered.Inc()
+}
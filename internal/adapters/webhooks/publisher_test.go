@@ -0,0 +1,104 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"orders-service/internal/adapters/persistence/memory"
+	appEvents "orders-service/internal/application/events"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainEvents "orders-service/internal/domain/events"
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSender records the deliveries and fails those of the webhooks listed in failing
+type fakeSender struct {
+	mu      sync.Mutex
+	sent    map[string][]string
+	failing map[string]bool
+}
+
+func (s *fakeSender) HasWebhook(webhookID string) bool {
+	return true
+}
+
+func (s *fakeSender) Send(ctx context.Context, webhookID, eventType string, payload []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failing[webhookID] {
+		return 3, errors.New("unexpected status 503")
+	}
+	if s.sent == nil {
+		s.sent = make(map[string][]string)
+	}
+	s.sent[webhookID] = append(s.sent[webhookID], eventType)
+	return 1, nil
+}
+
+func newTestEvent(t *testing.T, eventType domainEvents.OrderEventType) domainEvents.OrderEvent {
+	order, err := entities.NewOrder(42)
+	require.NoError(t, err)
+	order.ID = 7
+	return domainEvents.NewOrderEvent(eventType, order, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+}
+
+func TestPublisher_DeliversSubscribedEvents(t *testing.T) {
+	sender := &fakeSender{}
+	registry := metrics.NewRegistry()
+	publisher := NewPublisher(sender, memory.NewWebhookDeadLetterRepository(), []Endpoint{
+		{ID: "all"},
+		{ID: "status", Events: []string{string(domainEvents.OrderStatusChanged)}},
+	}, 10, registry, logger.New("test"))
+
+	require.NoError(t, publisher.Publish(context.Background(), newTestEvent(t, domainEvents.OrderCreated)))
+	require.NoError(t, publisher.Publish(context.Background(), newTestEvent(t, domainEvents.OrderStatusChanged)))
+	require.NoError(t, publisher.Close(context.Background()))
+
+	assert.Equal(t, []string{"order.created", "order.status_changed"}, sender.sent["all"])
+	assert.Equal(t, []string{"order.status_changed"}, sender.sent["status"])
+	assert.Equal(t, int64(3), registry.Counter(DeliveredMetric).Value())
+}
+
+func TestPublisher_DeadLettersFailedDeliveries(t *testing.T) {
+	sender := &fakeSender{failing: map[string]bool{"down": true}}
+	deadLetters := memory.NewWebhookDeadLetterRepository()
+	registry := metrics.NewRegistry()
+	publisher := NewPublisher(sender, deadLetters, []Endpoint{{ID: "up"}, {ID: "down"}}, 10, registry, logger.New("test"))
+	event := newTestEvent(t, domainEvents.OrderCreated)
+
+	require.NoError(t, publisher.Publish(context.Background(), event))
+	require.NoError(t, publisher.Close(context.Background()))
+
+	assert.Equal(t, []string{"order.created"}, sender.sent["up"])
+
+	stored, total, err := deadLetters.List(context.Background(), "down", ports.WebhookDeadLetterFilter{}, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	assert.Equal(t, "order.created", stored[0].EventType)
+	assert.Equal(t, 3, stored[0].Attempts)
+	assert.Equal(t, "unexpected status 503", stored[0].LastError)
+
+	payload, err := appEvents.Encode(event, appEvents.DefaultSchemaVersion)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(payload), string(stored[0].Payload))
+	assert.Equal(t, int64(1), registry.Counter(DeadLetteredMetric).Value())
+}
+
+func TestPublisher_DropsEventsAfterClose(t *testing.T) {
+	sender := &fakeSender{}
+	publisher := NewPublisher(sender, memory.NewWebhookDeadLetterRepository(), []Endpoint{{ID: "partner"}}, 10, metrics.NewRegistry(), logger.New("test"))
+	require.NoError(t, publisher.Close(context.Background()))
+
+	require.NoError(t, publisher.Publish(context.Background(), newTestEvent(t, domainEvents.OrderCreated)))
+
+	assert.Empty(t, sender.sent)
+}
@@ -0,0 +1,164 @@
+// Package webhooks delivers order events to the endpoints of partners. Every delivery is signed, retried
+// with backoff, and kept as a dead letter once its retries are exhausted.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"orders-service/internal/application/ports"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/httpclient"
+)
+
+// Headers sent with every delivery
+const (
+	HeaderWebhookID = "X-Webhook-ID"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Endpoint is a partner endpoint deliveries are posted to
+type Endpoint struct {
+	ID     string
+	URL    string
+	Secret string
+	// Events lists the event types delivered to the endpoint, all of them when empty
+	Events []string
+}
+
+// Subscribes reports whether events of eventType are delivered to the endpoint
+func (e Endpoint) Subscribes(eventType string) bool {
+	if len(e.Events) == 0 {
+		return true
+	}
+	for _, subscribed := range e.Events {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// SenderConfig controls the attempts of a delivery
+type SenderConfig struct {
+	// MaxAttempts includes the first try, values below 1 are treated as 1
+	MaxAttempts int
+	// RetryBackoff is the wait before the second attempt, it doubles for every further one
+	RetryBackoff time.Duration
+}
+
+// HTTPSender implements ports.WebhookSender by posting payloads as JSON. Network errors, 408, 429 and
+// 5xx responses are retried, other responses outside 2xx fail the delivery right away, and so does an
+// open circuit breaker of the endpoint host.
+type HTTPSender struct {
+	endpoints map[string]Endpoint
+	client    *httpclient.Client
+	config    SenderConfig
+	now       func() time.Time
+}
+
+// NewHTTPSender creates a sender for the given endpoints posting through client. The sender retries
+// deliveries itself so every attempt is signed anew, client should not retry.
+func NewHTTPSender(endpoints []Endpoint, client *httpclient.Client, cfg SenderConfig) ports.WebhookSender {
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+
+	byID := make(map[string]Endpoint, len(endpoints))
+	for _, endpoint := range endpoints {
+		byID[endpoint.ID] = endpoint
+	}
+
+	return &HTTPSender{
+		endpoints: byID,
+		client:    client,
+		config:    cfg,
+		now:       time.Now,
+	}
+}
+
+// HasWebhook implements ports.WebhookSender
+func (s *HTTPSender) HasWebhook(webhookID string) bool {
+	_, ok := s.endpoints[webhookID]
+	return ok
+}
+
+// Send implements ports.WebhookSender. Every attempt is signed anew, so partners checking the age of
+// the timestamp accept retries and replays.
+func (s *HTTPSender) Send(ctx context.Context, webhookID, eventType string, payload []byte) (int, error) {
+	endpoint, ok := s.endpoints[webhookID]
+	if !ok {
+		return 0, domainErrors.ErrWebhookNotFound
+	}
+
+	backoff := s.config.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		retry, err := s.post(ctx, endpoint, eventType, payload)
+		if err == nil {
+			return attempt, nil
+		}
+		if !retry || attempt >= s.config.MaxAttempts || ctx.Err() != nil {
+			return attempt, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// post makes a single attempt, reporting whether a failed one may succeed when retried
+func (s *HTTPSender) post(ctx context.Context, endpoint Endpoint, eventType string, payload []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(payload))
+	if err != nil {
+		return false, fmt.Errorf("build webhook request: %w", err)
+	}
+
+	timestamp := s.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookID, endpoint.ID)
+	req.Header.Set(HeaderEvent, eventType)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(endpoint.Secret, timestamp, payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return !errors.Is(err, httpclient.ErrCircuitOpen), fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 ||
+		resp.StatusCode == http.StatusRequestTimeout ||
+		resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("post webhook: unexpected status %d", resp.StatusCode)
+}
+
+// Sign returns the X-Webhook-Signature of a delivery: the hex HMAC-SHA256 of "<timestamp>.<payload>"
+// keyed with the endpoint secret, prefixed with "sha256="
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
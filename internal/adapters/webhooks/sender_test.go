@@ -0,0 +1,148 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/httpclient"
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSender(url string, maxAttempts int) *HTTPSender {
+	return newTestSenderWithClient(url, maxAttempts, httpclient.Config{Timeout: time.Second, MaxAttempts: 1})
+}
+
+func newTestSenderWithClient(url string, maxAttempts int, config httpclient.Config) *HTTPSender {
+	client := httpclient.New("webhooks", config, metrics.NewRegistry(), logger.New("test"))
+	sender := NewHTTPSender([]Endpoint{{ID: "partner", URL: url, Secret: "s3cret"}}, client, SenderConfig{
+		MaxAttempts:  maxAttempts,
+		RetryBackoff: time.Millisecond,
+	})
+	return sender.(*HTTPSender)
+}
+
+func TestHTTPSender_Send_SignsPayload(t *testing.T) {
+	payload := []byte(`{"type":"order.created"}`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		timestamp, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "partner", r.Header.Get(HeaderWebhookID))
+		assert.Equal(t, "order.created", r.Header.Get(HeaderEvent))
+		assert.Equal(t, int64(1735732800), timestamp)
+		assert.Equal(t, Sign("s3cret", timestamp, body), r.Header.Get(HeaderSignature))
+		assert.Equal(t, payload, body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := newTestSender(server.URL, 3)
+	sender.now = func() time.Time { return time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC) }
+
+	attempts, err := sender.Send(context.Background(), "partner", "order.created", payload)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '1735732800.{}' | openssl dgst -sha256 -hmac s3cret
+	assert.Equal(t, "sha256=53d6c12b3f2993bea96ebff2408baa35f4b88468abab3a6203888d8ec9ee2528", Sign("s3cret", 1735732800, []byte(`{}`)))
+}
+
+func TestHTTPSender_Send_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	attempts, err := newTestSender(server.URL, 5).Send(context.Background(), "partner", "order.created", []byte(`{}`))
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestHTTPSender_Send_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	attempts, err := newTestSender(server.URL, 3).Send(context.Background(), "partner", "order.created", []byte(`{}`))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status 429")
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestHTTPSender_Send_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	attempts, err := newTestSender(server.URL, 5).Send(context.Background(), "partner", "order.created", []byte(`{}`))
+
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestHTTPSender_Send_StopsAtOpenCircuit(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+	sender := newTestSenderWithClient(server.URL, 5, httpclient.Config{
+		Timeout:          time.Second,
+		MaxAttempts:      1,
+		BreakerThreshold: 2,
+		BreakerCooldown:  time.Minute,
+	})
+
+	attempts, err := sender.Send(context.Background(), "partner", "order.created", []byte(`{}`))
+
+	// The breaker opens after two failed attempts, the third is rejected without reaching the endpoint
+	assert.ErrorIs(t, err, httpclient.ErrCircuitOpen)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestHTTPSender_Send_UnknownWebhook(t *testing.T) {
+	sender := newTestSender("http://127.0.0.1:0", 1)
+
+	assert.False(t, sender.HasWebhook("other"))
+	assert.True(t, sender.HasWebhook("partner"))
+
+	attempts, err := sender.Send(context.Background(), "other", "order.created", []byte(`{}`))
+
+	assert.ErrorIs(t, err, domainErrors.ErrWebhookNotFound)
+	assert.Zero(t, attempts)
+}
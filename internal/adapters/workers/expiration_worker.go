@@ -0,0 +1,86 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"orders-service/internal/application/usecases"
+	"orders-service/pkg/logger"
+)
+
+// ExpirationWorker periodically expires pending orders that passed their expiry time
+type ExpirationWorker struct {
+	useCases  usecases.OrderUseCases
+	interval  time.Duration
+	batchSize int
+	logger    logger.Logger
+	now       func() time.Time
+	// lock keeps other replicas from making a pass at the same time, nil runs every pass
+	lock *JobLock
+}
+
+// NewExpirationWorker creates a worker that runs every interval, expiring batchSize orders at a time
+func NewExpirationWorker(useCases usecases.OrderUseCases, interval time.Duration, batchSize int, log logger.Logger) *ExpirationWorker {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	return &ExpirationWorker{
+		useCases:  useCases,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    log.With("component", "expiration_worker"),
+		now:       time.Now,
+	}
+}
+
+func (w *ExpirationWorker) Name() string {
+	return "expiration"
+}
+
+// Run expires orders right away and then on every tick until ctx is cancelled
+func (w *ExpirationWorker) Run(ctx context.Context) {
+	w.logger.Info("Expiration worker started", "interval", w.interval, "batch_size", w.batchSize)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.RunOnce(ctx); err != nil {
+			w.logger.Error("Expiration run failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Expiration worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce expires batches until a batch comes back short, returning the number of expired orders
+func (w *ExpirationWorker) RunOnce(ctx context.Context) (int, error) {
+	return w.lock.Do(ctx, w.Name(), w.expire)
+}
+
+func (w *ExpirationWorker) expire(ctx context.Context) (int, error) {
+	now := w.now()
+	total := 0
+
+	for ctx.Err() == nil {
+		expired, err := w.useCases.ExpirePendingOrders(ctx, now, w.batchSize)
+		total += expired
+		if err != nil {
+			return total, err
+		}
+		if expired < w.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		w.logger.Info("Expired pending orders", "expired", total)
+	}
+	return total, nil
+}
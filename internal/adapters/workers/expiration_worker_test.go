@@ -0,0 +1,68 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/usecases"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubOrderUseCases returns the queued batch sizes from ExpirePendingOrders
+type stubOrderUseCases struct {
+	usecases.OrderUseCases
+	batches []int
+	calls   []time.Time
+}
+
+func (s *stubOrderUseCases) ExpirePendingOrders(_ context.Context, now time.Time, _ int) (int, error) {
+	s.calls = append(s.calls, now)
+	if len(s.batches) == 0 {
+		return 0, nil
+	}
+	expired := s.batches[0]
+	s.batches = s.batches[1:]
+	return expired, nil
+}
+
+func TestExpirationWorker_RunOnce_DrainsFullBatches(t *testing.T) {
+	// Given
+	useCases := &stubOrderUseCases{batches: []int{2, 2, 1}}
+	worker := NewExpirationWorker(useCases, time.Minute, 2, logger.New("test"))
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	worker.now = func() time.Time { return now }
+
+	// When
+	expired, err := worker.RunOnce(context.Background())
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 5, expired)
+	assert.Equal(t, []time.Time{now, now, now}, useCases.calls)
+}
+
+func TestExpirationWorker_Run_StopsOnCancel(t *testing.T) {
+	// Given
+	useCases := &stubOrderUseCases{}
+	worker := NewExpirationWorker(useCases, time.Hour, 10, logger.New("test"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// When
+	done := make(chan struct{})
+	go func() {
+		worker.Run(ctx)
+		close(done)
+	}()
+
+	// Then
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop after cancellation")
+	}
+}
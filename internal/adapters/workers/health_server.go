@@ -0,0 +1,72 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"orders-service/pkg/logger"
+)
+
+// HealthServer answers liveness probes of the worker command, which runs without the API server
+type HealthServer struct {
+	server    *http.Server
+	logger    logger.Logger
+	startTime time.Time
+}
+
+type healthResponse struct {
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+	Service   string    `json:"service"`
+	Uptime    string    `json:"uptime"`
+}
+
+func NewHealthServer(address string, log logger.Logger) *HealthServer {
+	h := &HealthServer{
+		logger:    log.With("component", "worker_health"),
+		startTime: time.Now(),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health/live", h.live)
+	h.server = &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return h
+}
+
+// Handler returns the HTTP handler serving the health routes
+func (h *HealthServer) Handler() http.Handler {
+	return h.server.Handler
+}
+
+// Start serves until Shutdown is called
+func (h *HealthServer) Start() error {
+	h.logger.Info("Starting worker health server", "address", h.server.Addr)
+	if err := h.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (h *HealthServer) Shutdown(ctx context.Context) error {
+	return h.server.Shutdown(ctx)
+}
+
+func (h *HealthServer) live(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(healthResponse{
+		Status:    "alive",
+		Timestamp: time.Now(),
+		Service:   "orders-service-worker",
+		Uptime:    time.Since(h.startTime).String(),
+	}); err != nil {
+		h.logger.Error("Failed to write liveness response", "error", err)
+	}
+}
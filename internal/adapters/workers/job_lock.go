@@ -0,0 +1,118 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+)
+
+// Metric names reported by JobLock
+const (
+	LockAcquiredMetric  = "worker_lock_acquired_total"
+	LockContendedMetric = "worker_lock_contended_total"
+	LockLostMetric      = "worker_lock_lost_total"
+)
+
+// JobLock lets one replica at a time make a pass of a job. A replica finding the lock of a job held
+// skips the pass, the holder renews the lock every third of its ttl while the pass runs and the pass
+// is cancelled once a renewal fails, so a lost lock does not let two replicas work on the same rows.
+// A nil JobLock runs every pass.
+type JobLock struct {
+	lock      ports.DistributedLock
+	ttl       time.Duration
+	acquired  *metrics.Counter
+	contended *metrics.Counter
+	lost      *metrics.Counter
+	logger    logger.Logger
+}
+
+// NewJobLock creates a JobLock taking the locks of lock for ttl at a time
+func NewJobLock(lock ports.DistributedLock, ttl time.Duration, registry *metrics.Registry, log logger.Logger) *JobLock {
+	if ttl <= 0 {
+		ttl = 30 * time.Second
+	}
+
+	return &JobLock{
+		lock:      lock,
+		ttl:       ttl,
+		acquired:  registry.Counter(LockAcquiredMetric),
+		contended: registry.Counter(LockContendedMetric),
+		lost:      registry.Counter(LockLostMetric),
+		logger:    log.With("component", "job_lock"),
+	}
+}
+
+// Do runs pass holding the lock of job and returns what it returned, or 0 handled records when another
+// replica holds the lock
+func (l *JobLock) Do(ctx context.Context, job string, pass func(ctx context.Context) (int, error)) (int, error) {
+	if l == nil {
+		return pass(ctx)
+	}
+
+	key := "orders-service/workers/" + job
+	if err := l.lock.Acquire(ctx, key, l.ttl); err != nil {
+		if errors.Is(err, ports.ErrLockHeld) {
+			l.contended.Inc()
+			l.logger.Debug("Job pass skipped, another replica holds the lock", "job", job)
+			return 0, nil
+		}
+		return 0, fmt.Errorf("acquire lock: %w", err)
+	}
+	l.acquired.Inc()
+
+	passCtx, cancel := context.WithCancelCause(ctx)
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		l.renew(passCtx, key, job, cancel)
+	}()
+
+	handled, err := pass(passCtx)
+	lost := context.Cause(passCtx)
+	cancel(nil)
+	<-renewed
+
+	// The pass may have been cut short by shutdown, the lock is released all the same
+	releaseCtx, cancelRelease := context.WithTimeout(context.WithoutCancel(ctx), l.ttl)
+	defer cancelRelease()
+	if releaseErr := l.lock.Release(releaseCtx, key); releaseErr != nil {
+		l.logger.Warn("Failed to release job lock", "job", job, "error", releaseErr)
+	}
+
+	if errors.Is(lost, ports.ErrLockLost) && err == nil {
+		err = lost
+	}
+	return handled, err
+}
+
+// renew extends the lock on key until ctx is done, cancelling ctx with the failure once a renewal fails
+func (l *JobLock) renew(ctx context.Context, key, job string, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := l.lock.Renew(ctx, key, l.ttl); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			l.lost.Inc()
+			l.logger.Error("Job lock lost, cancelling the pass", "job", job, "error", err)
+			if !errors.Is(err, ports.ErrLockLost) {
+				err = fmt.Errorf("%w: %v", ports.ErrLockLost, err)
+			}
+			cancel(err)
+			return
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"orders-service/internal/adapters/locks"
+	"orders-service/internal/application/ports"
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyLock counts renewals and fails them once failAfter renewals passed
+type flakyLock struct {
+	ports.DistributedLock
+	mu        sync.Mutex
+	renewals  int
+	failAfter int
+}
+
+func (l *flakyLock) Renew(ctx context.Context, key string, ttl time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.renewals++
+	if l.renewals > l.failAfter {
+		return errors.New("connection reset")
+	}
+	return l.DistributedLock.Renew(ctx, key, ttl)
+}
+
+func (l *flakyLock) Renewals() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.renewals
+}
+
+func TestJobLock_Do_SkipsPassWhileAnotherReplicaHoldsTheLock(t *testing.T) {
+	// Given
+	registry := metrics.NewRegistry()
+	lock := locks.NewMemoryLock()
+	jobLock := NewJobLock(lock, time.Minute, registry, logger.New("test"))
+	require.NoError(t, lock.Acquire(context.Background(), "orders-service/workers/expiration", time.Minute))
+	passes := 0
+
+	// When
+	handled, err := jobLock.Do(context.Background(), "expiration", func(context.Context) (int, error) {
+		passes++
+		return 3, nil
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Zero(t, handled)
+	assert.Zero(t, passes)
+	assert.Equal(t, int64(1), registry.Counter(LockContendedMetric).Value())
+	assert.Zero(t, registry.Counter(LockAcquiredMetric).Value())
+}
+
+func TestJobLock_Do_RunsPassAndReleasesTheLock(t *testing.T) {
+	// Given
+	registry := metrics.NewRegistry()
+	lock := locks.NewMemoryLock()
+	jobLock := NewJobLock(lock, time.Minute, registry, logger.New("test"))
+
+	// When
+	handled, err := jobLock.Do(context.Background(), "expiration", func(context.Context) (int, error) {
+		return 3, nil
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 3, handled)
+	assert.Equal(t, int64(1), registry.Counter(LockAcquiredMetric).Value())
+	assert.NoError(t, lock.Acquire(context.Background(), "orders-service/workers/expiration", time.Minute), "the lock was released")
+}
+
+func TestJobLock_Do_RenewsTheLockDuringLongPasses(t *testing.T) {
+	// Given
+	lock := &flakyLock{DistributedLock: locks.NewMemoryLock(), failAfter: 100}
+	jobLock := NewJobLock(lock, 30*time.Millisecond, metrics.NewRegistry(), logger.New("test"))
+
+	// When
+	_, err := jobLock.Do(context.Background(), "expiration", func(ctx context.Context) (int, error) {
+		time.Sleep(100 * time.Millisecond)
+		return 1, ctx.Err()
+	})
+
+	// Then
+	require.NoError(t, err, "renewals kept the lock past its ttl")
+	assert.GreaterOrEqual(t, lock.Renewals(), 3)
+}
+
+func TestJobLock_Do_CancelsPassOnceTheLockIsLost(t *testing.T) {
+	// Given
+	registry := metrics.NewRegistry()
+	lock := &flakyLock{DistributedLock: locks.NewMemoryLock(), failAfter: 1}
+	jobLock := NewJobLock(lock, 30*time.Millisecond, registry, logger.New("test"))
+
+	// When
+	handled, err := jobLock.Do(context.Background(), "expiration", func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 2, nil
+	})
+
+	// Then
+	assert.Equal(t, 2, handled)
+	assert.ErrorIs(t, err, ports.ErrLockLost)
+	assert.Equal(t, int64(1), registry.Counter(LockLostMetric).Value())
+}
+
+func TestJobLock_Do_WithoutLockRunsThePass(t *testing.T) {
+	var jobLock *JobLock
+
+	handled, err := jobLock.Do(context.Background(), "expiration", func(context.Context) (int, error) {
+		return 4, nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, handled)
+}
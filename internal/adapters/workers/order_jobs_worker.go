@@ -0,0 +1,117 @@
+package workers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/application/usecases"
+	"orders-service/pkg/logger"
+)
+
+// OrderJobWorker creates the orders submitted for asynchronous creation. It runs the jobs delivered by
+// the queue as they arrive and sweeps the stored jobs for the ones the queue never delivered, such as
+// jobs queued when the process stopped, jobs the full queue refused or jobs submitted to another process.
+type OrderJobWorker struct {
+	useCases  usecases.OrderUseCases
+	queue     ports.JobQueue
+	interval  time.Duration
+	batchSize int
+	logger    logger.Logger
+	now       func() time.Time
+	// lock keeps other replicas from sweeping at the same time, every replica consumes its own queue
+	lock *JobLock
+}
+
+// NewOrderJobWorker creates a worker consuming queue and sweeping every interval, batchSize jobs at a
+// time. A nil queue leaves the jobs to the sweep.
+func NewOrderJobWorker(useCases usecases.OrderUseCases, queue ports.JobQueue, interval time.Duration, batchSize int, log logger.Logger) *OrderJobWorker {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	return &OrderJobWorker{
+		useCases:  useCases,
+		queue:     queue,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    log.With("component", "order_job_worker"),
+		now:       time.Now,
+	}
+}
+
+func (w *OrderJobWorker) Name() string {
+	return "order_jobs"
+}
+
+// Run consumes the queue and sweeps the stored jobs right away and then on every tick until ctx is
+// cancelled, it returns once the job in progress finished
+func (w *OrderJobWorker) Run(ctx context.Context) {
+	w.logger.Info("Order job worker started", "interval", w.interval, "batch_size", w.batchSize)
+
+	var wg sync.WaitGroup
+	if w.queue != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.consume(ctx)
+		}()
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.RunOnce(ctx); err != nil {
+			w.logger.Error("Order job sweep failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			w.logger.Info("Order job worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// consume runs the queued jobs one at a time until ctx is cancelled
+func (w *OrderJobWorker) consume(ctx context.Context) {
+	err := w.queue.Consume(ctx, func(ctx context.Context, jobID string) {
+		if err := w.useCases.ProcessOrderJob(ctx, jobID); err != nil {
+			w.logger.Warn("Order job left pending", "job_id", jobID, "error", err)
+		}
+	})
+	if err != nil && ctx.Err() == nil {
+		w.logger.Error("Order job queue stopped", "error", err)
+	}
+}
+
+// RunOnce runs the jobs pending for at least an interval in batches until a batch comes back short,
+// returning the number of jobs that succeeded or failed. Younger jobs are left to the queue.
+func (w *OrderJobWorker) RunOnce(ctx context.Context) (int, error) {
+	return w.lock.Do(ctx, w.Name(), w.sweep)
+}
+
+func (w *OrderJobWorker) sweep(ctx context.Context) (int, error) {
+	before := w.now().Add(-w.interval)
+	total := 0
+
+	for ctx.Err() == nil {
+		completed, err := w.useCases.ProcessPendingOrderJobs(ctx, before, w.batchSize)
+		total += completed
+		if err != nil {
+			return total, err
+		}
+		if completed < w.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		w.logger.Info("Processed pending order jobs", "completed", total)
+	}
+	return total, nil
+}
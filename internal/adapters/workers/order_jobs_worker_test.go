@@ -0,0 +1,96 @@
+package workers
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"orders-service/internal/adapters/jobs"
+	"orders-service/internal/application/usecases"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubOrderJobUseCases records the processed jobs and returns the queued batch sizes from ProcessPendingOrderJobs
+type stubOrderJobUseCases struct {
+	usecases.OrderUseCases
+
+	mu        sync.Mutex
+	batches   []int
+	before    []time.Time
+	processed []string
+}
+
+func (s *stubOrderJobUseCases) ProcessPendingOrderJobs(_ context.Context, before time.Time, _ int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.before = append(s.before, before)
+	if len(s.batches) == 0 {
+		return 0, nil
+	}
+	completed := s.batches[0]
+	s.batches = s.batches[1:]
+	return completed, nil
+}
+
+func (s *stubOrderJobUseCases) ProcessOrderJob(_ context.Context, jobID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.processed = append(s.processed, jobID)
+	return nil
+}
+
+func (s *stubOrderJobUseCases) processedJobs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.processed...)
+}
+
+func TestOrderJobWorker_RunOnce_SweepsJobsOlderThanAnInterval(t *testing.T) {
+	// Given
+	useCases := &stubOrderJobUseCases{batches: []int{2, 1}}
+	worker := NewOrderJobWorker(useCases, nil, 30*time.Second, 2, logger.New("test"))
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	worker.now = func() time.Time { return now }
+
+	// When
+	completed, err := worker.RunOnce(context.Background())
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 3, completed)
+	cutoff := now.Add(-30 * time.Second)
+	assert.Equal(t, []time.Time{cutoff, cutoff}, useCases.before)
+}
+
+func TestOrderJobWorker_Run_ConsumesQueue(t *testing.T) {
+	// Given
+	useCases := &stubOrderJobUseCases{}
+	queue := jobs.NewChannelQueue(4)
+	worker := NewOrderJobWorker(useCases, queue, time.Hour, 10, logger.New("test"))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		worker.Run(ctx)
+		close(done)
+	}()
+
+	// When
+	require.NoError(t, queue.Enqueue(ctx, "job-1"))
+	require.NoError(t, queue.Enqueue(ctx, "job-2"))
+
+	// Then
+	assert.Eventually(t, func() bool { return len(useCases.processedJobs()) == 2 }, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, []string{"job-1", "job-2"}, useCases.processedJobs())
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("worker did not stop")
+	}
+}
@@ -0,0 +1,97 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/application/usecases"
+	"orders-service/internal/config"
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+)
+
+// Job is a background component driven by the Runner
+type Job interface {
+	Name() string
+	// Run works until ctx is cancelled
+	Run(ctx context.Context)
+	// RunOnce makes a single pass and reports how many records it handled
+	RunOnce(ctx context.Context) (int, error)
+}
+
+// NewJobs builds the jobs enabled in cfg, the order job worker consumes jobQueue. The passes of the
+// jobs take the locks of lock so one replica at a time makes them, a nil lock runs every pass.
+func NewJobs(cfg *config.Config, orderUseCases usecases.OrderUseCases, jobQueue ports.JobQueue, lock ports.DistributedLock, log logger.Logger) []Job {
+	var jobLock *JobLock
+	if lock != nil {
+		jobLock = NewJobLock(lock, cfg.Workers.Lock.TTL, metrics.Default, log)
+	}
+
+	var jobs []Job
+	if cfg.Orders.PendingTTL > 0 && cfg.Workers.Expiration.Interval > 0 {
+		worker := NewExpirationWorker(orderUseCases, cfg.Workers.Expiration.Interval, cfg.Workers.Expiration.BatchSize, log)
+		worker.lock = jobLock
+		jobs = append(jobs, worker)
+	}
+	if cfg.Workers.ScheduledTransitions.Interval > 0 {
+		worker := NewScheduledTransitionWorker(orderUseCases, cfg.Workers.ScheduledTransitions.Interval, cfg.Workers.ScheduledTransitions.BatchSize, log)
+		worker.lock = jobLock
+		jobs = append(jobs, worker)
+	}
+	if cfg.Workers.OrderJobs.Interval > 0 {
+		worker := NewOrderJobWorker(orderUseCases, jobQueue, cfg.Workers.OrderJobs.Interval, cfg.Workers.OrderJobs.BatchSize, log)
+		worker.lock = jobLock
+		jobs = append(jobs, worker)
+	}
+	return jobs
+}
+
+// Runner runs a set of jobs side by side
+type Runner struct {
+	jobs   []Job
+	logger logger.Logger
+}
+
+func NewRunner(jobs []Job, log logger.Logger) *Runner {
+	return &Runner{
+		jobs:   jobs,
+		logger: log.With("component", "worker_runner"),
+	}
+}
+
+// Run starts every job and returns once ctx is cancelled and all of them stopped
+func (r *Runner) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, job := range r.jobs {
+		wg.Add(1)
+		go func(job Job) {
+			defer wg.Done()
+			job.Run(ctx)
+		}(job)
+	}
+
+	<-ctx.Done()
+	wg.Wait()
+}
+
+// RunOnce makes a single pass of every job, a failing job does not stop the others
+func (r *Runner) RunOnce(ctx context.Context) error {
+	var errs []error
+	for _, job := range r.jobs {
+		handled, err := job.RunOnce(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", job.Name(), err))
+			continue
+		}
+		r.logger.Info("Job pass finished", "job", job.Name(), "handled", handled)
+	}
+	return errors.Join(errs...)
+}
+
+// Len returns the number of jobs
+func (r *Runner) Len() int {
+	return len(r.jobs)
+}
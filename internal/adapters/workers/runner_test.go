@@ -0,0 +1,159 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"orders-service/internal/adapters/locks"
+	"orders-service/internal/config"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeJob counts its passes and fails RunOnce with err
+type fakeJob struct {
+	name   string
+	err    error
+	passes atomic.Int32
+	runs   atomic.Int32
+}
+
+func (j *fakeJob) Name() string { return j.name }
+
+func (j *fakeJob) Run(ctx context.Context) {
+	j.runs.Add(1)
+	<-ctx.Done()
+}
+
+func (j *fakeJob) RunOnce(context.Context) (int, error) {
+	j.passes.Add(1)
+	return 1, j.err
+}
+
+func TestRunner_RunOnce_RunsEveryJobAndJoinsErrors(t *testing.T) {
+	// Given
+	failing := &fakeJob{name: "failing", err: errors.New("boom")}
+	healthy := &fakeJob{name: "healthy"}
+	runner := NewRunner([]Job{failing, healthy}, logger.New("test"))
+
+	// When
+	err := runner.RunOnce(context.Background())
+
+	// Then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failing: boom")
+	assert.Equal(t, int32(1), failing.passes.Load())
+	assert.Equal(t, int32(1), healthy.passes.Load())
+	assert.Zero(t, healthy.runs.Load())
+}
+
+func TestRunner_Run_StopsEveryJobOnCancel(t *testing.T) {
+	// Given
+	first, second := &fakeJob{name: "first"}, &fakeJob{name: "second"}
+	runner := NewRunner([]Job{first, second}, logger.New("test"))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// When
+	done := make(chan struct{})
+	go func() {
+		runner.Run(ctx)
+		close(done)
+	}()
+	require.Eventually(t, func() bool { return first.runs.Load() == 1 && second.runs.Load() == 1 }, time.Second, time.Millisecond)
+	cancel()
+
+	// Then
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("runner did not stop after cancellation")
+	}
+}
+
+func TestRunner_Run_WaitsForCancelWithoutJobs(t *testing.T) {
+	runner := NewRunner(nil, logger.New("test"))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	runner.Run(ctx)
+
+	assert.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+}
+
+func TestNewJobs_EnablesExpirationFromConfig(t *testing.T) {
+	cfg := &config.Config{
+		Orders:  config.OrdersConfig{PendingTTL: time.Hour},
+		Workers: config.WorkersConfig{Expiration: config.ExpirationWorkerConfig{Interval: time.Minute, BatchSize: 10}},
+	}
+	jobs := NewJobs(cfg, &stubOrderUseCases{}, nil, nil, logger.New("test"))
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "expiration", jobs[0].Name())
+
+	cfg.Orders.PendingTTL = 0
+	assert.Empty(t, NewJobs(cfg, &stubOrderUseCases{}, nil, nil, logger.New("test")))
+}
+
+func TestNewJobs_EnablesScheduledTransitionsFromConfig(t *testing.T) {
+	cfg := &config.Config{
+		Workers: config.WorkersConfig{ScheduledTransitions: config.ScheduledTransitionWorkerConfig{Interval: time.Minute, BatchSize: 10}},
+	}
+	jobs := NewJobs(cfg, &stubOrderUseCases{}, nil, nil, logger.New("test"))
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "scheduled_transitions", jobs[0].Name())
+
+	cfg.Workers.ScheduledTransitions.Interval = 0
+	assert.Empty(t, NewJobs(cfg, &stubOrderUseCases{}, nil, nil, logger.New("test")))
+}
+
+func TestNewJobs_EnablesOrderJobsFromConfig(t *testing.T) {
+	cfg := &config.Config{
+		Workers: config.WorkersConfig{OrderJobs: config.OrderJobWorkerConfig{Interval: time.Minute, BatchSize: 10}},
+	}
+	jobs := NewJobs(cfg, &stubOrderUseCases{}, nil, nil, logger.New("test"))
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "order_jobs", jobs[0].Name())
+
+	cfg.Workers.OrderJobs.Interval = 0
+	assert.Empty(t, NewJobs(cfg, &stubOrderUseCases{}, nil, nil, logger.New("test")))
+}
+
+func TestNewJobs_PassesTakeTheLock(t *testing.T) {
+	// Given
+	cfg := &config.Config{
+		Orders:  config.OrdersConfig{PendingTTL: time.Hour},
+		Workers: config.WorkersConfig{Expiration: config.ExpirationWorkerConfig{Interval: time.Minute, BatchSize: 10}},
+	}
+	lock := locks.NewMemoryLock()
+	require.NoError(t, lock.Acquire(context.Background(), "orders-service/workers/expiration", time.Minute))
+	useCases := &stubOrderUseCases{batches: []int{1}}
+	jobs := NewJobs(cfg, useCases, nil, lock, logger.New("test"))
+	require.Len(t, jobs, 1)
+
+	// When
+	expired, err := jobs[0].RunOnce(context.Background())
+
+	// Then
+	require.NoError(t, err)
+	assert.Zero(t, expired)
+	assert.Empty(t, useCases.calls, "another replica holds the lock")
+}
+
+func TestHealthServer_Live(t *testing.T) {
+	health := NewHealthServer("127.0.0.1:0", logger.New("test"))
+	rec := httptest.NewRecorder()
+
+	health.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body healthResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "alive", body.Status)
+}
@@ -0,0 +1,88 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"orders-service/internal/application/usecases"
+	"orders-service/pkg/logger"
+)
+
+// ScheduledTransitionWorker periodically executes the order status transitions that became due
+type ScheduledTransitionWorker struct {
+	useCases  usecases.OrderUseCases
+	interval  time.Duration
+	batchSize int
+	logger    logger.Logger
+	now       func() time.Time
+	// lock keeps other replicas from making a pass at the same time, nil runs every pass
+	lock *JobLock
+}
+
+// NewScheduledTransitionWorker creates a worker that runs every interval, executing batchSize transitions at a time
+func NewScheduledTransitionWorker(useCases usecases.OrderUseCases, interval time.Duration, batchSize int, log logger.Logger) *ScheduledTransitionWorker {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	return &ScheduledTransitionWorker{
+		useCases:  useCases,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    log.With("component", "scheduled_transition_worker"),
+		now:       time.Now,
+	}
+}
+
+func (w *ScheduledTransitionWorker) Name() string {
+	return "scheduled_transitions"
+}
+
+// Run executes due transitions right away and then on every tick until ctx is cancelled
+func (w *ScheduledTransitionWorker) Run(ctx context.Context) {
+	w.logger.Info("Scheduled transition worker started", "interval", w.interval, "batch_size", w.batchSize)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.RunOnce(ctx); err != nil {
+			w.logger.Error("Scheduled transition run failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Scheduled transition worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce executes batches until a batch comes back short, returning the number of transitions
+// executed or failed. Transitions left pending by a transient error shorten the batch, so they are
+// retried on the next tick rather than right away.
+func (w *ScheduledTransitionWorker) RunOnce(ctx context.Context) (int, error) {
+	return w.lock.Do(ctx, w.Name(), w.execute)
+}
+
+func (w *ScheduledTransitionWorker) execute(ctx context.Context) (int, error) {
+	now := w.now()
+	total := 0
+
+	for ctx.Err() == nil {
+		completed, err := w.useCases.ExecuteScheduledTransitions(ctx, now, w.batchSize)
+		total += completed
+		if err != nil {
+			return total, err
+		}
+		if completed < w.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		w.logger.Info("Executed scheduled transitions", "completed", total)
+	}
+	return total, nil
+}
@@ -0,0 +1,46 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/usecases"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubScheduledTransitionUseCases returns the queued batch sizes from ExecuteScheduledTransitions
+type stubScheduledTransitionUseCases struct {
+	usecases.OrderUseCases
+	batches []int
+	calls   []time.Time
+}
+
+func (s *stubScheduledTransitionUseCases) ExecuteScheduledTransitions(_ context.Context, now time.Time, _ int) (int, error) {
+	s.calls = append(s.calls, now)
+	if len(s.batches) == 0 {
+		return 0, nil
+	}
+	completed := s.batches[0]
+	s.batches = s.batches[1:]
+	return completed, nil
+}
+
+func TestScheduledTransitionWorker_RunOnce_DrainsFullBatches(t *testing.T) {
+	// Given
+	useCases := &stubScheduledTransitionUseCases{batches: []int{2, 2, 1, 2}}
+	worker := NewScheduledTransitionWorker(useCases, time.Minute, 2, logger.New("test"))
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	worker.now = func() time.Time { return now }
+
+	// When
+	completed, err := worker.RunOnce(context.Background())
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 5, completed)
+	assert.Equal(t, []time.Time{now, now, now}, useCases.calls)
+}
@@ -0,0 +1,155 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"orders-service/internal/application/auth"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	"orders-service/pkg/logger"
+)
+
+// SystemActor is recorded for changes made without an authenticated caller, such as background workers
+const SystemActor = "system"
+
+// writeTimeout bounds a single audit write so a slow database cannot stall the writer
+const writeTimeout = 5 * time.Second
+
+// record is a mutation waiting to be written
+type record struct {
+	actor      string
+	action     entities.AuditAction
+	orderID    uint
+	before     *entities.Order
+	after      *entities.Order
+	occurredAt time.Time
+}
+
+// AsyncRecorder implements ports.AuditRecorder. Records are queued on a buffered channel and
+// written by a single goroutine, so requests never wait for the audit log. When the buffer is
+// full the record is dropped and logged.
+type AsyncRecorder struct {
+	repo      ports.AuditRepository
+	redactors []Redactor
+	logger    logger.Logger
+
+	mu      sync.RWMutex
+	closed  bool
+	records chan record
+	done    chan struct{}
+}
+
+// NewAsyncRecorder starts the writer goroutine. Call Close to flush queued records on shutdown.
+func NewAsyncRecorder(repo ports.AuditRepository, bufferSize int, log logger.Logger, redactors ...Redactor) *AsyncRecorder {
+	if bufferSize <= 0 {
+		bufferSize = 1000
+	}
+
+	recorder := &AsyncRecorder{
+		repo:      repo,
+		redactors: redactors,
+		logger:    log.With("component", "audit_recorder"),
+		records:   make(chan record, bufferSize),
+		done:      make(chan struct{}),
+	}
+
+	go recorder.run()
+
+	return recorder
+}
+
+// Record implements ports.AuditRecorder. The orders are copied before being queued, so the caller
+// may keep changing them while the writer goroutine snapshots the copies.
+func (r *AsyncRecorder) Record(ctx context.Context, action entities.AuditAction, orderID uint, before, after *entities.Order) {
+	rec := record{
+		actor:      actorFromContext(ctx),
+		action:     action,
+		orderID:    orderID,
+		before:     cloneOrder(before),
+		after:      cloneOrder(after),
+		occurredAt: time.Now(),
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		r.logger.Warn("Audit recorder closed, dropping entry", "action", action, "order_id", orderID)
+		return
+	}
+
+	select {
+	case r.records <- rec:
+	default:
+		r.logger.Error("Audit buffer full, dropping entry", "action", action, "order_id", orderID, "actor", rec.actor)
+	}
+}
+
+// Close stops accepting records and waits until the queued ones are written or ctx is done
+func (r *AsyncRecorder) Close(ctx context.Context) error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.records)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *AsyncRecorder) run() {
+	defer close(r.done)
+
+	for rec := range r.records {
+		r.write(rec)
+	}
+}
+
+func (r *AsyncRecorder) write(rec record) {
+	entry := &entities.AuditEntry{
+		OrderID:   rec.orderID,
+		Actor:     rec.actor,
+		Action:    rec.action,
+		CreatedAt: rec.occurredAt,
+	}
+
+	var err error
+	if entry.Before, err = Snapshot(rec.before, r.redactors...); err != nil {
+		r.logger.Error("Failed to snapshot order for audit", "order_id", rec.orderID, "error", err)
+		return
+	}
+	if entry.After, err = Snapshot(rec.after, r.redactors...); err != nil {
+		r.logger.Error("Failed to snapshot order for audit", "order_id", rec.orderID, "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+	defer cancel()
+
+	if err := r.repo.Create(ctx, entry); err != nil {
+		r.logger.Error("Failed to write audit entry", "action", rec.action, "order_id", rec.orderID, "error", err)
+	}
+}
+
+// cloneOrder returns a copy of order owned by the writer goroutine, nil for a missing state
+func cloneOrder(order *entities.Order) *entities.Order {
+	if order == nil {
+		return nil
+	}
+	return order.Clone()
+}
+
+// actorFromContext names the authenticated caller, or the system for unauthenticated changes
+func actorFromContext(ctx context.Context) string {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok && principal.Name != "" {
+		return principal.Name
+	}
+	return SystemActor
+}
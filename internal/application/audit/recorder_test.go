@@ -0,0 +1,166 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/auth"
+	"orders-service/internal/domain/entities"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAuditRepository keeps created entries in memory
+type memoryAuditRepository struct {
+	mu      sync.Mutex
+	entries []*entities.AuditEntry
+	block   chan struct{}
+	err     error
+}
+
+func (r *memoryAuditRepository) Create(_ context.Context, entry *entities.AuditEntry) error {
+	if r.block != nil {
+		<-r.block
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *memoryAuditRepository) ListByOrderID(context.Context, uint, int, int) ([]*entities.AuditEntry, error) {
+	return nil, nil
+}
+
+func (r *memoryAuditRepository) CountByOrderID(context.Context, uint) (int64, error) {
+	return 0, nil
+}
+
+func newOrder(t *testing.T, status entities.OrderStatus) *entities.Order {
+	order, err := entities.NewOrder(123)
+	require.NoError(t, err)
+	order.ID = 1
+	order.Status = status
+	return order
+}
+
+func TestAsyncRecorder_WritesEntries(t *testing.T) {
+	// Given
+	repo := &memoryAuditRepository{}
+	recorder := NewAsyncRecorder(repo, 10, logger.New("test"))
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "backoffice"})
+	before := newOrder(t, entities.OrderStatusPending)
+	after := newOrder(t, entities.OrderStatusConfirmed)
+
+	// When
+	recorder.Record(ctx, entities.AuditActionStatusChanged, 1, before, after)
+	recorder.Record(context.Background(), entities.AuditActionOrderDeleted, 1, after, nil)
+	require.NoError(t, recorder.Close(context.Background()))
+
+	// Then
+	require.Len(t, repo.entries, 2)
+
+	changed := repo.entries[0]
+	assert.Equal(t, "backoffice", changed.Actor)
+	assert.Equal(t, entities.AuditActionStatusChanged, changed.Action)
+	assert.Equal(t, uint(1), changed.OrderID)
+	assert.False(t, changed.CreatedAt.IsZero())
+
+	var snapshot map[string]interface{}
+	require.NoError(t, json.Unmarshal(changed.Before, &snapshot))
+	assert.Equal(t, "pending", snapshot["status"])
+	require.NoError(t, json.Unmarshal(changed.After, &snapshot))
+	assert.Equal(t, "confirmed", snapshot["status"])
+
+	deleted := repo.entries[1]
+	assert.Equal(t, SystemActor, deleted.Actor)
+	assert.Nil(t, deleted.After)
+}
+
+func TestAsyncRecorder_RecordsStateAtRecordTime(t *testing.T) {
+	// Given
+	repo := &memoryAuditRepository{}
+	recorder := NewAsyncRecorder(repo, 10, logger.New("test"))
+	order := newOrder(t, entities.OrderStatusPending)
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 1, 10.0))
+
+	// When the caller keeps changing the order while the writer snapshots it, which go test -race
+	// reports as a data race unless the recorder took its own copy
+	recorder.Record(context.Background(), entities.AuditActionItemAdded, 1, nil, order)
+	for i := 2; i < 50; i++ {
+		require.NoError(t, order.AddItem(uint(i), "SKU", "Product", 1, 1.0))
+	}
+	require.NoError(t, recorder.Close(context.Background()))
+
+	// Then
+	require.Len(t, repo.entries, 1)
+	var snapshot struct {
+		Items []json.RawMessage `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(repo.entries[0].After, &snapshot))
+	assert.Len(t, snapshot.Items, 1)
+}
+
+func TestAsyncRecorder_DropsWhenBufferFull(t *testing.T) {
+	// Given a writer stuck on its first entry and a buffer of one
+	repo := &memoryAuditRepository{block: make(chan struct{})}
+	recorder := NewAsyncRecorder(repo, 1, logger.New("test"))
+	order := newOrder(t, entities.OrderStatusPending)
+
+	// When
+	recorder.Record(context.Background(), entities.AuditActionOrderCreated, 1, nil, order)
+	require.Eventually(t, func() bool { return len(recorder.records) == 0 }, time.Second, time.Millisecond)
+	recorder.Record(context.Background(), entities.AuditActionItemAdded, 1, order, order)
+	recorder.Record(context.Background(), entities.AuditActionItemAdded, 1, order, order)
+	close(repo.block)
+	require.NoError(t, recorder.Close(context.Background()))
+
+	// Then
+	assert.Len(t, repo.entries, 2)
+}
+
+func TestAsyncRecorder_WriteFailureDoesNotStopWriter(t *testing.T) {
+	// Given
+	repo := &memoryAuditRepository{err: errors.New("database down")}
+	recorder := NewAsyncRecorder(repo, 10, logger.New("test"))
+
+	// When
+	recorder.Record(context.Background(), entities.AuditActionOrderCreated, 1, nil, newOrder(t, entities.OrderStatusPending))
+	require.NoError(t, recorder.Close(context.Background()))
+	recorder.Record(context.Background(), entities.AuditActionOrderCreated, 2, nil, newOrder(t, entities.OrderStatusPending))
+
+	// Then
+	assert.Empty(t, repo.entries)
+}
+
+func TestSnapshot_AppliesRedactors(t *testing.T) {
+	// Given
+	order := newOrder(t, entities.OrderStatusPending)
+	order.ExternalReference = "PO-1234"
+	redactReference := func(snapshot map[string]interface{}) {
+		delete(snapshot, "external_reference")
+	}
+
+	// When
+	plain, err := Snapshot(order)
+	require.NoError(t, err)
+	redacted, err := Snapshot(order, redactReference)
+	require.NoError(t, err)
+	empty, err := Snapshot(nil)
+	require.NoError(t, err)
+
+	// Then
+	assert.Contains(t, string(plain), "PO-1234")
+	assert.NotContains(t, string(redacted), "PO-1234")
+	assert.Contains(t, string(redacted), `"status":"pending"`)
+	assert.Nil(t, empty)
+}
@@ -0,0 +1,39 @@
+package audit
+
+import (
+	"encoding/json"
+
+	"orders-service/internal/domain/entities"
+)
+
+// Redactor removes or masks fields of an order snapshot before it is stored.
+// The snapshot is the order's JSON representation decoded into a map.
+type Redactor func(snapshot map[string]interface{})
+
+// Snapshot serializes the order for the audit log, applying the redactors in order.
+// A nil order has no snapshot.
+func Snapshot(order *entities.Order, redactors ...Redactor) (json.RawMessage, error) {
+	if order == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(redactors) == 0 {
+		return data, nil
+	}
+
+	var snapshot map[string]interface{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+
+	for _, redact := range redactors {
+		redact(snapshot)
+	}
+
+	return json.Marshal(snapshot)
+}
@@ -0,0 +1,58 @@
+package auth
+
+import "context"
+
+// Scope is a permission granted to an authenticated caller
+type Scope string
+
+const (
+	ScopeOrdersRead  Scope = "orders:read"
+	ScopeOrdersWrite Scope = "orders:write"
+	ScopeOrdersAdmin Scope = "orders:admin"
+)
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	Name   string
+	Scopes []Scope
+
+	// CustomerID is the customer the principal acts for, 0 when it is not bound to a customer
+	CustomerID uint
+
+	// KeyID identifies the API key the principal authenticated with, the hex encoded hash of the key
+	KeyID string
+}
+
+// HasScope reports whether the principal was granted scope. The admin scope grants every scope.
+func (p *Principal) HasScope(scope Scope) bool {
+	for _, s := range p.Scopes {
+		if s == scope || s == ScopeOrdersAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin reports whether the principal holds the admin scope
+func (p *Principal) IsAdmin() bool {
+	return p.HasScope(ScopeOrdersAdmin)
+}
+
+// CanAccessCustomer reports whether the principal may access the orders of customerID.
+// Admins and principals not bound to a customer access every customer.
+func (p *Principal) CanAccessCustomer(customerID uint) bool {
+	return p.CustomerID == 0 || p.CustomerID == customerID || p.IsAdmin()
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the principal
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal stored in ctx, if any
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
@@ -1,32 +1,77 @@
 package dto
 
 import (
+	"encoding/json"
+	"orders-service/internal/application/ports"
 	"orders-service/internal/domain/entities"
 	"time"
 )
 
 // CreateOrderRequestDTO for order creation
 type CreateOrderRequestDTO struct {
-	CustomerID uint                 `json:"customer_id" validate:"required,min=1"`
-	Items      []CreateOrderItemDTO `json:"items" validate:"omitempty,dive"`
+	CustomerID        uint                 `json:"customer_id" validate:"required,min=1"`
+	ExternalReference string               `json:"external_reference,omitempty" validate:"omitempty,max=100"`
+	Items             []CreateOrderItemDTO `json:"items" validate:"omitempty,dive"`
+	// Tags label the order, orders tagged sample are exempt from the minimum order amount
+	Tags []string `json:"tags,omitempty" validate:"omitempty,max=10,dive,max=32"`
+	// Priority decides how soon fulfillment picks the order up, normal when empty
+	Priority entities.OrderPriority `json:"priority,omitempty" validate:"omitempty,oneof=low normal high urgent"`
+	// CustomerEmail and CustomerName are kept on the order as given, later changes of the customer's
+	// account do not reach it
+	CustomerEmail string `json:"customer_email,omitempty" validate:"omitempty,email,max=254"`
+	CustomerName  string `json:"customer_name,omitempty" validate:"omitempty,max=200"`
+	// AllowDuplicate creates the order even when the customer just created one with the same items
+	AllowDuplicate bool `json:"allow_duplicate,omitempty"`
 }
 
 // CreateOrderItemDTO for adding items when creating an order
 type CreateOrderItemDTO struct {
-	ProductID   uint    `json:"product_id" validate:"required,min=1"`
-	ProductSKU  string  `json:"product_sku" validate:"required,min=1,max=100"`
-	ProductName string  `json:"product_name" validate:"required,min=1,max=255"`
-	Quantity    int     `json:"quantity" validate:"required,min=1"`
-	UnitPrice   float64 `json:"unit_price" validate:"required,gt=0"`
+	ProductID   uint              `json:"product_id" validate:"required,min=1"`
+	ProductSKU  string            `json:"product_sku" validate:"required,min=1,max=100"`
+	ProductName string            `json:"product_name" validate:"required,min=1,max=255"`
+	Quantity    int               `json:"quantity" validate:"required,min=1"`
+	UnitPrice   float64           `json:"unit_price" validate:"required,gt=0"`
+	Attributes  map[string]string `json:"attributes,omitempty" validate:"omitempty,max=20,dive,max=255"`
+	// UnitWeightGrams is optional, the order has no total weight unless every item has one
+	UnitWeightGrams *int `json:"unit_weight_grams,omitempty" validate:"omitempty,gt=0"`
 }
 
-// AddOrderItemRequestDTO for adding a single item to an existing order
+// AddOrderItemRequestDTO for adding a single item to an existing order.
+// OnDuplicate decides what happens when the product is already in the order, merge when empty.
 type AddOrderItemRequestDTO struct {
-	ProductID   uint    `json:"product_id" validate:"required,min=1"`
-	ProductSKU  string  `json:"product_sku" validate:"required,min=1,max=100"`
-	ProductName string  `json:"product_name" validate:"required,min=1,max=255"`
-	Quantity    int     `json:"quantity" validate:"required,min=1"`
-	UnitPrice   float64 `json:"unit_price" validate:"required,gt=0"`
+	ProductID       uint                         `json:"product_id" validate:"required,min=1"`
+	ProductSKU      string                       `json:"product_sku" validate:"required,min=1,max=100"`
+	ProductName     string                       `json:"product_name" validate:"required,min=1,max=255"`
+	Quantity        int                          `json:"quantity" validate:"required,min=1"`
+	UnitPrice       float64                      `json:"unit_price" validate:"required,gt=0"`
+	OnDuplicate     entities.DuplicateItemPolicy `json:"on_duplicate,omitempty" validate:"omitempty,oneof=merge reject"`
+	Attributes      map[string]string            `json:"attributes,omitempty" validate:"omitempty,max=20,dive,max=255"`
+	UnitWeightGrams *int                         `json:"unit_weight_grams,omitempty" validate:"omitempty,gt=0"`
+}
+
+// ReplaceOrderItemsRequestDTO for setting the complete item list of an order
+type ReplaceOrderItemsRequestDTO struct {
+	Items []CreateOrderItemDTO `json:"items" validate:"required,dive"`
+}
+
+// AmendOrderRequestDTO for changing the items of a confirmed order, Items is the complete new item list
+type AmendOrderRequestDTO struct {
+	Reason string               `json:"reason" validate:"required,max=500"`
+	Items  []CreateOrderItemDTO `json:"items" validate:"required,min=1,dive"`
+}
+
+// UpdateOrderRequestDTO for changing the details of an order, at least one of them. The priority can be
+// changed while the order is pending or confirmed, the contact details only while it is pending. Contact
+// fields left out are kept, an empty value clears them.
+type UpdateOrderRequestDTO struct {
+	Priority      entities.OrderPriority `json:"priority,omitempty" validate:"required_without_all=CustomerEmail CustomerName,omitempty,oneof=low normal high urgent"`
+	CustomerEmail *string                `json:"customer_email,omitempty" validate:"omitempty,email,max=254"`
+	CustomerName  *string                `json:"customer_name,omitempty" validate:"omitempty,max=200"`
+}
+
+// ChangesContact reports whether the request sets a contact detail
+func (dto *UpdateOrderRequestDTO) ChangesContact() bool {
+	return dto.CustomerEmail != nil || dto.CustomerName != nil
 }
 
 // UpdateOrderItemQuantityRequestDTO for updating item quantity
@@ -34,52 +79,208 @@ type UpdateOrderItemQuantityRequestDTO struct {
 	Quantity int `json:"quantity" validate:"required,min=1"`
 }
 
-// UpdateOrderStatusRequestDTO for updating order status
+// UpdateOrderStatusRequestDTO for updating order status.
+// Tracking is only recorded when the order moves to shipped.
 type UpdateOrderStatusRequestDTO struct {
-	Status entities.OrderStatus `json:"status" validate:"required,oneof=pending confirmed processing shipped delivered cancelled refunded"`
+	Status   entities.OrderStatus `json:"status" validate:"required"`
+	Tracking *ShipmentTrackingDTO `json:"tracking,omitempty"`
+}
+
+// ShipmentTrackingDTO identifies the parcel of a shipped order
+type ShipmentTrackingDTO struct {
+	Carrier        string `json:"carrier" validate:"max=100"`
+	TrackingNumber string `json:"tracking_number" validate:"max=100"`
+}
+
+// ConfirmOrderRequestDTO for confirming an order, every field is optional.
+// The shipping method defaults to standard.
+type ConfirmOrderRequestDTO struct {
+	ShippingMethod      entities.ShippingMethod `json:"shipping_method,omitempty" validate:"omitempty,oneof=standard express pickup"`
+	EstimatedDeliveryAt *time.Time              `json:"estimated_delivery_at,omitempty"`
+	// OverrideMinimum confirms an order below the minimum order amount, admins only
+	OverrideMinimum bool `json:"override_minimum,omitempty"`
+}
+
+// CreateShipmentRequestDTO for shipping part or all of an order.
+// ShippedAt defaults to the time of the request.
+type CreateShipmentRequestDTO struct {
+	Items          []ShipmentItemDTO `json:"items" validate:"required,min=1,dive"`
+	Carrier        string            `json:"carrier,omitempty" validate:"max=100"`
+	TrackingNumber string            `json:"tracking_number,omitempty" validate:"max=100"`
+	ShippedAt      *time.Time        `json:"shipped_at,omitempty"`
+}
+
+// ShipmentItemDTO is the quantity of a product in a shipment
+type ShipmentItemDTO struct {
+	ProductID uint `json:"product_id" validate:"required,min=1"`
+	Quantity  int  `json:"quantity" validate:"required,min=1"`
+}
+
+// ToEntities converts the items of the request
+func (r *CreateShipmentRequestDTO) ToEntities() []entities.ShipmentItem {
+	items := make([]entities.ShipmentItem, 0, len(r.Items))
+	for _, item := range r.Items {
+		items = append(items, entities.ShipmentItem{ProductID: item.ProductID, Quantity: item.Quantity})
+	}
+	return items
+}
+
+// CreateRefundRequestDTO for paying part or all of an order back
+type CreateRefundRequestDTO struct {
+	Amount    float64 `json:"amount" validate:"required,gt=0"`
+	Reason    string  `json:"reason,omitempty" validate:"max=500"`
+	Reference string  `json:"reference,omitempty" validate:"max=100"`
+}
+
+// OrderDocumentRequestDTO for a printable document of an order
+type OrderDocumentRequestDTO struct {
+	// Format is html or pdf, empty means html
+	Format string
+	// HidePrices leaves the prices out of gift orders not delivered yet, other orders always show them
+	HidePrices bool
+	// ShowContact prints the contact details of the customer, set for callers that may see them
+	ShowContact bool
+}
+
+// ScheduleTransitionRequestDTO for moving an order to another status at a later time
+type ScheduleTransitionRequestDTO struct {
+	TargetStatus entities.OrderStatus `json:"target_status" validate:"required"`
+	ExecuteAt    time.Time            `json:"execute_at" validate:"required"`
+}
+
+// PlaceOrderOnHoldRequestDTO for placing an order on hold
+type PlaceOrderOnHoldRequestDTO struct {
+	Reason string `json:"reason" validate:"required,min=1,max=500"`
+}
+
+// OrderFilterDTO for filtering order listings and exports
+type OrderFilterDTO struct {
+	CustomerID uint
+	Status     entities.OrderStatus
+	From       *time.Time // inclusive
+	To         *time.Time // exclusive
 }
 
 // OrderItemResponseDTO for order item responses
 type OrderItemResponseDTO struct {
-	ID          uint    `json:"id"`
-	ProductID   uint    `json:"product_id"`
-	ProductSKU  string  `json:"product_sku"`
-	ProductName string  `json:"product_name"`
-	Quantity    int     `json:"quantity"`
-	UnitPrice   float64 `json:"unit_price"`
-	TotalPrice  float64 `json:"total_price"`
+	// ID is left out of the items of an order that was not stored, such as a dry run preview
+	ID              uint              `json:"id,omitempty"`
+	ProductID       uint              `json:"product_id"`
+	ProductSKU      string            `json:"product_sku"`
+	ProductName     string            `json:"product_name"`
+	Quantity        int               `json:"quantity"`
+	UnitPrice       float64           `json:"unit_price"`
+	TotalPrice      float64           `json:"total_price"`
+	Attributes      map[string]string `json:"attributes,omitempty"`
+	UnitWeightGrams *int              `json:"unit_weight_grams,omitempty"`
+	// ReservedQuantity and Backordered are set once the order was confirmed, a backordered line waits for stock
+	ReservedQuantity int  `json:"reserved_quantity,omitempty"`
+	Backordered      bool `json:"backordered,omitempty"`
+}
+
+// CancelledItemResponseDTO for a quantity dropped from a confirmed order
+type CancelledItemResponseDTO struct {
+	ProductID   uint      `json:"product_id"`
+	ProductSKU  string    `json:"product_sku"`
+	ProductName string    `json:"product_name"`
+	Quantity    int       `json:"quantity"`
+	UnitPrice   float64   `json:"unit_price"`
+	CancelledAt time.Time `json:"cancelled_at"`
+}
+
+// OrderAmendmentResponseDTO describes an amendment of a confirmed order
+type OrderAmendmentResponseDTO struct {
+	Number    int                     `json:"number"`
+	Reason    string                  `json:"reason"`
+	Changes   []ItemChangeResponseDTO `json:"changes"`
+	AmendedAt time.Time               `json:"amended_at"`
+}
+
+// ItemChangeResponseDTO describes how an amendment changed a line, a quantity of 0 marks an added or removed line
+type ItemChangeResponseDTO struct {
+	ProductID       uint              `json:"product_id"`
+	ProductSKU      string            `json:"product_sku"`
+	ProductName     string            `json:"product_name"`
+	Attributes      map[string]string `json:"attributes,omitempty"`
+	QuantityBefore  int               `json:"quantity_before"`
+	QuantityAfter   int               `json:"quantity_after"`
+	UnitPriceBefore float64           `json:"unit_price_before"`
+	UnitPriceAfter  float64           `json:"unit_price_after"`
 }
 
 // OrderResponseDTO for order responses
 type OrderResponseDTO struct {
-	ID          uint                   `json:"id"`
-	CustomerID  uint                   `json:"customer_id"`
-	Items       []OrderItemResponseDTO `json:"items"`
-	ItemCount   int                    `json:"item_count"`
-	TotalItems  int                    `json:"total_items"`
-	TotalAmount float64                `json:"total_amount"`
-	Status      entities.OrderStatus   `json:"status"`
-	CreatedAt   time.Time              `json:"created_at"`
-	UpdatedAt   time.Time              `json:"updated_at"`
+	ID                  uint                        `json:"id"`
+	PublicID            string                      `json:"public_id,omitempty"`
+	CustomerID          uint                        `json:"customer_id"`
+	ExternalReference   string                      `json:"external_reference,omitempty"`
+	OrderNumber         string                      `json:"order_number,omitempty"`
+	Tags                []string                    `json:"tags,omitempty"`
+	Priority            entities.OrderPriority      `json:"priority"`
+	CustomerEmail       string                      `json:"customer_email,omitempty"`
+	CustomerName        string                      `json:"customer_name,omitempty"`
+	Items               []OrderItemResponseDTO      `json:"items"`
+	CancelledItems      []CancelledItemResponseDTO  `json:"cancelled_items,omitempty"`
+	AmendmentCount      int                         `json:"amendment_count,omitempty"`
+	Amendments          []OrderAmendmentResponseDTO `json:"amendments,omitempty"`
+	ItemCount           int                         `json:"item_count"`
+	TotalItems          int                         `json:"total_items"`
+	TotalAmount         float64                     `json:"total_amount"`
+	TotalWeightGrams    *int                        `json:"total_weight_grams,omitempty"`
+	RefundedAmount      float64                     `json:"refunded_amount"`
+	PaymentStatus       entities.PaymentStatus      `json:"payment_status,omitempty"`
+	ReservationSkipped  bool                        `json:"reservation_skipped,omitempty"`
+	Status              entities.OrderStatus        `json:"status"`
+	AllowedTransitions  []entities.OrderStatus      `json:"allowed_transitions"`
+	HeldFromStatus      entities.OrderStatus        `json:"held_from_status,omitempty"`
+	HoldReason          string                      `json:"hold_reason,omitempty"`
+	ExpiresAt           *time.Time                  `json:"expires_at,omitempty"`
+	ShippingMethod      entities.ShippingMethod     `json:"shipping_method,omitempty"`
+	EstimatedDeliveryAt *time.Time                  `json:"estimated_delivery_at,omitempty"`
+	Carrier             string                      `json:"carrier,omitempty"`
+	TrackingNumber      string                      `json:"tracking_number,omitempty"`
+	CreatedAt           time.Time                   `json:"created_at"`
+	UpdatedAt           time.Time                   `json:"updated_at"`
+	// AlreadyInState is set when a status transition found the order in the requested status already
+	AlreadyInState bool `json:"already_in_state,omitempty"`
 }
 
 // OrderSummaryResponseDTO for lightweight order list responses
 type OrderSummaryResponseDTO struct {
 	ID          uint                 `json:"id"`
+	PublicID    string               `json:"public_id,omitempty"`
 	CustomerID  uint                 `json:"customer_id"`
+	OrderNumber string               `json:"order_number,omitempty"`
 	ItemCount   int                  `json:"item_count"`
 	TotalAmount float64              `json:"total_amount"`
 	Status      entities.OrderStatus `json:"status"`
 	CreatedAt   time.Time            `json:"created_at"`
 	UpdatedAt   time.Time            `json:"updated_at"`
+	DeletedAt   *time.Time           `json:"deleted_at,omitempty"`
 }
 
+// Pagination limits of the list endpoints, pages are numbered from 0. The orders.max_page_size
+// setting may lower MaxPageSize at runtime. MaxPage keeps the offset of any page far from overflowing.
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+	MaxPage         = 1_000_000
+)
+
 // OrderListResponseDTO for paginated order lists
 type OrderListResponseDTO struct {
-	Orders   []*OrderResponseDTO `json:"orders"`
-	Total    int64               `json:"total"`
-	Page     int                 `json:"page"`
-	PageSize int                 `json:"page_size"`
+	Orders      []*OrderResponseDTO `json:"orders"`
+	Total       int64               `json:"total"`
+	Page        int                 `json:"page"`
+	PageSize    int                 `json:"page_size"`
+	TotalPages  int                 `json:"total_pages"`
+	HasNext     bool                `json:"has_next"`
+	HasPrevious bool                `json:"has_previous"`
+}
+
+// OrderCountResponseDTO for the number of orders matching a filter
+type OrderCountResponseDTO struct {
+	Count int64 `json:"count"`
 }
 
 // OrderSummaryListResponseDTO for lightweight paginated order lists
@@ -90,13 +291,90 @@ type OrderSummaryListResponseDTO struct {
 	PageSize int                        `json:"page_size"`
 }
 
+// DeletedOrderListResponseDTO for paginated lists of soft deleted orders
+type DeletedOrderListResponseDTO struct {
+	Orders      []*OrderSummaryResponseDTO `json:"orders"`
+	Total       int64                      `json:"total"`
+	Page        int                        `json:"page"`
+	PageSize    int                        `json:"page_size"`
+	TotalPages  int                        `json:"total_pages"`
+	HasNext     bool                       `json:"has_next"`
+	HasPrevious bool                       `json:"has_previous"`
+}
+
+// OrderStatsResponseDTO for aggregate order statistics over a date range
+type OrderStatsResponseDTO struct {
+	From              time.Time        `json:"from"`
+	To                time.Time        `json:"to"`
+	TotalOrders       int64            `json:"total_orders"`
+	TotalRevenue      float64          `json:"total_revenue"`
+	AverageOrderValue float64          `json:"average_order_value"`
+	ByStatus          []StatusStatsDTO `json:"by_status"`
+	Daily             []DailyStatsDTO  `json:"daily"`
+}
+
+// CustomerOrderSummaryDTO for the lifetime aggregates of a customer's orders.
+// TotalSpend and AverageOrderValue leave out cancelled and refunded orders.
+type CustomerOrderSummaryDTO struct {
+	CustomerID        uint                           `json:"customer_id"`
+	TotalOrders       int64                          `json:"total_orders"`
+	TotalSpend        float64                        `json:"total_spend"`
+	AverageOrderValue float64                        `json:"average_order_value"`
+	OrdersByStatus    map[entities.OrderStatus]int64 `json:"orders_by_status"`
+	FirstOrderAt      *time.Time                     `json:"first_order_at"`
+	LastOrderAt       *time.Time                     `json:"last_order_at"`
+}
+
+// StatusStatsDTO for order statistics of a single status
+type StatusStatsDTO struct {
+	Status  entities.OrderStatus `json:"status"`
+	Orders  int64                `json:"orders"`
+	Revenue float64              `json:"revenue"`
+}
+
+// DailyStatsDTO for order statistics of a single day
+type DailyStatsDTO struct {
+	Date    string  `json:"date"`
+	Orders  int64   `json:"orders"`
+	Revenue float64 `json:"revenue"`
+}
+
 // Conversion methods - Request DTOs to Domain Entities
 
 func (dto *CreateOrderRequestDTO) ToEntity() (*entities.Order, error) {
+	return dto.ToEntityWithLimits(entities.OrderLimits{})
+}
+
+// Validate checks every item and returns entities.ItemErrors listing all rejected fields
+func (dto *CreateOrderRequestDTO) Validate() error {
+	return entities.ValidateItems(toItemInputs(dto.Items))
+}
+
+// ToEntityWithLimits builds the order, rejecting items that exceed limits
+func (dto *CreateOrderRequestDTO) ToEntityWithLimits(limits entities.OrderLimits) (*entities.Order, error) {
 	order, err := entities.NewOrder(dto.CustomerID)
 	if err != nil {
 		return nil, err
 	}
+	order.Limits = limits
+
+	if err := order.SetExternalReference(dto.ExternalReference); err != nil {
+		return nil, err
+	}
+	if err := order.SetTags(dto.Tags); err != nil {
+		return nil, err
+	}
+	if err := order.SetPriority(dto.Priority); err != nil {
+		return nil, err
+	}
+	if err := order.SetContact(dto.CustomerEmail, dto.CustomerName); err != nil {
+		return nil, err
+	}
+
+	// Validate every item before adding any, so all problems are reported together
+	if err := dto.Validate(); err != nil {
+		return nil, err
+	}
 
 	// Add items if provided
 	for _, item := range dto.Items {
@@ -106,6 +384,8 @@ func (dto *CreateOrderRequestDTO) ToEntity() (*entities.Order, error) {
 			item.ProductName,
 			item.Quantity,
 			item.UnitPrice,
+			entities.WithAttributes(item.Attributes),
+			entities.WithUnitWeightGrams(item.UnitWeightGrams),
 		)
 		if err != nil {
 			return nil, err
@@ -115,6 +395,41 @@ func (dto *CreateOrderRequestDTO) ToEntity() (*entities.Order, error) {
 	return order, nil
 }
 
+func (dto *ReplaceOrderItemsRequestDTO) ToItemInputs() []entities.OrderItemInput {
+	return toItemInputs(dto.Items)
+}
+
+func toItemInputs(items []CreateOrderItemDTO) []entities.OrderItemInput {
+	inputs := make([]entities.OrderItemInput, 0, len(items))
+	for _, item := range items {
+		inputs = append(inputs, entities.OrderItemInput{
+			ProductID:       item.ProductID,
+			ProductSKU:      item.ProductSKU,
+			ProductName:     item.ProductName,
+			Quantity:        item.Quantity,
+			UnitPrice:       item.UnitPrice,
+			Attributes:      item.Attributes,
+			UnitWeightGrams: item.UnitWeightGrams,
+		})
+	}
+	return inputs
+}
+
+// ToItemInputs converts the requested items into entity inputs
+func (dto *AmendOrderRequestDTO) ToItemInputs() []entities.OrderItemInput {
+	return toItemInputs(dto.Items)
+}
+
+// Normalize replaces the requested status by its canonical form, see entities.ParseOrderStatus
+func (dto *UpdateOrderStatusRequestDTO) Normalize() error {
+	status, err := entities.ParseOrderStatus(string(dto.Status))
+	if err != nil {
+		return err
+	}
+	dto.Status = status
+	return nil
+}
+
 func (dto *AddOrderItemRequestDTO) ToOrderItem() (*entities.OrderItem, error) {
 	return entities.NewOrderItem(
 		dto.ProductID,
@@ -125,27 +440,93 @@ func (dto *AddOrderItemRequestDTO) ToOrderItem() (*entities.OrderItem, error) {
 	)
 }
 
+func (dto *OrderFilterDTO) ToFilter() ports.OrderFilter {
+	filter := ports.OrderFilter{
+		CustomerID: dto.CustomerID,
+		Status:     dto.Status,
+	}
+	if dto.From != nil {
+		filter.CreatedFrom = *dto.From
+	}
+	if dto.To != nil {
+		filter.CreatedBefore = *dto.To
+	}
+	return filter
+}
+
 // Conversion methods - Domain Entities to Response DTOs
 
 func OrderToResponseDTO(order *entities.Order) *OrderResponseDTO {
 	return &OrderResponseDTO{
+		ID:                  order.ID,
+		PublicID:            order.PublicID,
+		CustomerID:          order.CustomerID,
+		ExternalReference:   order.ExternalReference,
+		OrderNumber:         order.OrderNumber,
+		Tags:                order.Tags,
+		Priority:            order.Priority,
+		CustomerEmail:       order.CustomerEmail,
+		CustomerName:        order.CustomerName,
+		Items:               OrderItemsToResponseDTOs(order.Items),
+		CancelledItems:      CancelledItemsToResponseDTOs(order.CancelledItems),
+		AmendmentCount:      order.AmendmentCount,
+		Amendments:          AmendmentsToResponseDTOs(order.Amendments),
+		ItemCount:           order.GetItemCount(),
+		TotalItems:          order.GetTotalQuantity(),
+		TotalAmount:         order.TotalAmount,
+		TotalWeightGrams:    order.TotalWeightGrams,
+		RefundedAmount:      order.RefundedAmount,
+		PaymentStatus:       order.PaymentStatus,
+		ReservationSkipped:  order.ReservationSkipped,
+		Status:              order.Status,
+		AllowedTransitions:  order.AllowedTransitions(),
+		HeldFromStatus:      order.HeldFromStatus,
+		HoldReason:          order.HoldReason,
+		ExpiresAt:           order.ExpiresAt,
+		ShippingMethod:      order.ShippingMethod,
+		EstimatedDeliveryAt: order.EstimatedDeliveryAt,
+		Carrier:             order.Carrier,
+		TrackingNumber:      order.TrackingNumber,
+		CreatedAt:           order.CreatedAt,
+		UpdatedAt:           order.UpdatedAt,
+	}
+}
+
+// WithoutContact returns the order without the contact details of the customer, for callers not allowed
+// to see them. The order itself is not changed, a copy is returned when it has contact details.
+func (r *OrderResponseDTO) WithoutContact() *OrderResponseDTO {
+	if r == nil || (r.CustomerEmail == "" && r.CustomerName == "") {
+		return r
+	}
+	redacted := *r
+	redacted.CustomerEmail = ""
+	redacted.CustomerName = ""
+	return &redacted
+}
+
+func OrderToSummaryResponseDTO(order *entities.Order) *OrderSummaryResponseDTO {
+	return &OrderSummaryResponseDTO{
 		ID:          order.ID,
+		PublicID:    order.PublicID,
 		CustomerID:  order.CustomerID,
-		Items:       OrderItemsToResponseDTOs(order.Items),
+		OrderNumber: order.OrderNumber,
 		ItemCount:   order.GetItemCount(),
-		TotalItems:  order.GetTotalQuantity(),
 		TotalAmount: order.TotalAmount,
 		Status:      order.Status,
 		CreatedAt:   order.CreatedAt,
 		UpdatedAt:   order.UpdatedAt,
+		DeletedAt:   order.DeletedAt,
 	}
 }
 
-func OrderToSummaryResponseDTO(order *entities.Order) *OrderSummaryResponseDTO {
+// OrderResponseToSummaryDTO reduces a full order response to its summary
+func OrderResponseToSummaryDTO(order *OrderResponseDTO) *OrderSummaryResponseDTO {
 	return &OrderSummaryResponseDTO{
 		ID:          order.ID,
+		PublicID:    order.PublicID,
 		CustomerID:  order.CustomerID,
-		ItemCount:   order.GetItemCount(),
+		OrderNumber: order.OrderNumber,
+		ItemCount:   order.ItemCount,
 		TotalAmount: order.TotalAmount,
 		Status:      order.Status,
 		CreatedAt:   order.CreatedAt,
@@ -155,13 +536,17 @@ func OrderToSummaryResponseDTO(order *entities.Order) *OrderSummaryResponseDTO {
 
 func OrderItemToResponseDTO(item entities.OrderItem) OrderItemResponseDTO {
 	return OrderItemResponseDTO{
-		ID:          item.ID,
-		ProductID:   item.ProductID,
-		ProductSKU:  item.ProductSKU,
-		ProductName: item.ProductName,
-		Quantity:    item.Quantity,
-		UnitPrice:   item.UnitPrice,
-		TotalPrice:  item.TotalPrice,
+		ID:               item.ID,
+		ProductID:        item.ProductID,
+		ProductSKU:       item.ProductSKU,
+		ProductName:      item.ProductName,
+		Quantity:         item.Quantity,
+		UnitPrice:        item.UnitPrice,
+		TotalPrice:       item.TotalPrice,
+		Attributes:       item.Attributes,
+		UnitWeightGrams:  item.UnitWeightGrams,
+		ReservedQuantity: item.ReservedQuantity,
+		Backordered:      item.Backordered,
 	}
 }
 
@@ -173,6 +558,25 @@ func OrderItemsToResponseDTOs(items []entities.OrderItem) []OrderItemResponseDTO
 	return dtos
 }
 
+// CancelledItemsToResponseDTOs converts the item cancellations of an order, nil when there are none
+func CancelledItemsToResponseDTOs(items []entities.CancelledItem) []CancelledItemResponseDTO {
+	if len(items) == 0 {
+		return nil
+	}
+	dtos := make([]CancelledItemResponseDTO, 0, len(items))
+	for _, item := range items {
+		dtos = append(dtos, CancelledItemResponseDTO{
+			ProductID:   item.ProductID,
+			ProductSKU:  item.ProductSKU,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			CancelledAt: item.CancelledAt,
+		})
+	}
+	return dtos
+}
+
 func OrdersToResponseDTOs(orders []*entities.Order) []*OrderResponseDTO {
 	dtos := make([]*OrderResponseDTO, 0, len(orders))
 	for _, order := range orders {
@@ -181,6 +585,42 @@ func OrdersToResponseDTOs(orders []*entities.Order) []*OrderResponseDTO {
 	return dtos
 }
 
+// NewOrderListResponseDTO builds a page of orders with its navigation metadata
+func NewOrderListResponseDTO(orders []*entities.Order, total int64, page, pageSize int) *OrderListResponseDTO {
+	totalPages := TotalPages(total, pageSize)
+	return &OrderListResponseDTO{
+		Orders:      OrdersToResponseDTOs(orders),
+		Total:       total,
+		Page:        page,
+		PageSize:    pageSize,
+		TotalPages:  totalPages,
+		HasNext:     page+1 < totalPages,
+		HasPrevious: page > 0,
+	}
+}
+
+// NewDeletedOrderListResponseDTO builds a page of deleted orders with its navigation metadata
+func NewDeletedOrderListResponseDTO(orders []*entities.Order, total int64, page, pageSize int) *DeletedOrderListResponseDTO {
+	totalPages := TotalPages(total, pageSize)
+	return &DeletedOrderListResponseDTO{
+		Orders:      OrdersToSummaryResponseDTOs(orders),
+		Total:       total,
+		Page:        page,
+		PageSize:    pageSize,
+		TotalPages:  totalPages,
+		HasNext:     page+1 < totalPages,
+		HasPrevious: page > 0,
+	}
+}
+
+// TotalPages returns the number of pages of pageSize needed to list total items
+func TotalPages(total int64, pageSize int) int {
+	if total <= 0 || pageSize <= 0 {
+		return 0
+	}
+	return int((total + int64(pageSize) - 1) / int64(pageSize))
+}
+
 func OrdersToSummaryResponseDTOs(orders []*entities.Order) []*OrderSummaryResponseDTO {
 	dtos := make([]*OrderSummaryResponseDTO, 0, len(orders))
 	for _, order := range orders {
@@ -188,3 +628,496 @@ func OrdersToSummaryResponseDTOs(orders []*entities.Order) []*OrderSummaryRespon
 	}
 	return dtos
 }
+
+// AuditEntryResponseDTO for a single audit log entry
+type AuditEntryResponseDTO struct {
+	ID        uint                 `json:"id"`
+	OrderID   uint                 `json:"order_id"`
+	Actor     string               `json:"actor"`
+	Action    entities.AuditAction `json:"action"`
+	Before    json.RawMessage      `json:"before"`
+	After     json.RawMessage      `json:"after"`
+	CreatedAt time.Time            `json:"created_at"`
+}
+
+// AuditLogResponseDTO for the paginated audit log of an order
+type AuditLogResponseDTO struct {
+	Entries     []*AuditEntryResponseDTO `json:"entries"`
+	Total       int64                    `json:"total"`
+	Page        int                      `json:"page"`
+	PageSize    int                      `json:"page_size"`
+	TotalPages  int                      `json:"total_pages"`
+	HasNext     bool                     `json:"has_next"`
+	HasPrevious bool                     `json:"has_previous"`
+}
+
+// ShipmentResponseDTO for a shipment of an order. OrderPublicID and OrderStatus describe the order
+// after the shipment was created or delivered, OrderStatus being the status it moved to.
+type ShipmentResponseDTO struct {
+	ID             uint                 `json:"id"`
+	OrderID        uint                 `json:"order_id"`
+	OrderPublicID  string               `json:"order_public_id,omitempty"`
+	Items          []ShipmentItemDTO    `json:"items"`
+	Carrier        string               `json:"carrier,omitempty"`
+	TrackingNumber string               `json:"tracking_number,omitempty"`
+	ShippedAt      time.Time            `json:"shipped_at"`
+	DeliveredAt    *time.Time           `json:"delivered_at,omitempty"`
+	OrderStatus    entities.OrderStatus `json:"order_status,omitempty"`
+}
+
+// ShipmentListResponseDTO for the shipments of an order, oldest first
+type ShipmentListResponseDTO struct {
+	OrderID       uint                   `json:"order_id"`
+	OrderPublicID string                 `json:"order_public_id,omitempty"`
+	OrderStatus   entities.OrderStatus   `json:"order_status"`
+	Shipments     []*ShipmentResponseDTO `json:"shipments"`
+}
+
+// OrderDetailResponseDTO is an order with the related resources a client asked to include. Resources
+// that were not asked for are left out, a nil slice is omitted while an empty one is written.
+type OrderDetailResponseDTO struct {
+	*OrderResponseDTO
+
+	// History is the first page of the audit log of the order
+	History   *AuditLogResponseDTO   `json:"history,omitempty"`
+	Shipments []*ShipmentResponseDTO `json:"shipments,omitzero"`
+
+	// Errors names the included resources that could not be loaded
+	Errors map[string]IncludeErrorDTO `json:"_errors,omitempty"`
+}
+
+// IncludeErrorDTO tells why an included resource is missing from an OrderDetailResponseDTO
+type IncludeErrorDTO struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// ShipmentToResponseDTO converts a shipment entity
+func ShipmentToResponseDTO(shipment *entities.Shipment) *ShipmentResponseDTO {
+	response := &ShipmentResponseDTO{
+		ID:             shipment.ID,
+		OrderID:        shipment.OrderID,
+		Items:          make([]ShipmentItemDTO, 0, len(shipment.Items)),
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		ShippedAt:      shipment.ShippedAt,
+		DeliveredAt:    shipment.DeliveredAt,
+	}
+	for _, item := range shipment.Items {
+		response.Items = append(response.Items, ShipmentItemDTO{ProductID: item.ProductID, Quantity: item.Quantity})
+	}
+	return response
+}
+
+// RefundResponseDTO for a refund of an order. OrderStatus and RefundedAmount describe the order after
+// the refund and are only set on the response of the refund.
+type RefundResponseDTO struct {
+	ID             uint                 `json:"id"`
+	OrderID        uint                 `json:"order_id"`
+	OrderPublicID  string               `json:"order_public_id,omitempty"`
+	Amount         float64              `json:"amount"`
+	Reason         string               `json:"reason,omitempty"`
+	Reference      string               `json:"reference,omitempty"`
+	CreatedAt      time.Time            `json:"created_at"`
+	OrderStatus    entities.OrderStatus `json:"order_status,omitempty"`
+	RefundedAmount *float64             `json:"refunded_amount,omitempty"`
+}
+
+// RefundListResponseDTO for the refunds of an order, oldest first
+type RefundListResponseDTO struct {
+	OrderID        uint                 `json:"order_id"`
+	OrderPublicID  string               `json:"order_public_id,omitempty"`
+	OrderStatus    entities.OrderStatus `json:"order_status"`
+	TotalAmount    float64              `json:"total_amount"`
+	RefundedAmount float64              `json:"refunded_amount"`
+	Refunds        []*RefundResponseDTO `json:"refunds"`
+}
+
+// RefundToResponseDTO converts a refund entity
+func RefundToResponseDTO(refund *entities.Refund) *RefundResponseDTO {
+	return &RefundResponseDTO{
+		ID:        refund.ID,
+		OrderID:   refund.OrderID,
+		Amount:    refund.Amount,
+		Reason:    refund.Reason,
+		Reference: refund.Reference,
+		CreatedAt: refund.CreatedAt,
+	}
+}
+
+// OrderSnapshotResponseDTO for a snapshot of an order. Payload is the order as it was when the snapshot
+// was taken, encoded in the event schema version SchemaVersion. Lists leave it out.
+type OrderSnapshotResponseDTO struct {
+	OrderID       uint                    `json:"order_id"`
+	OrderPublicID string                  `json:"order_public_id,omitempty"`
+	Version       int                     `json:"version"`
+	Reason        entities.SnapshotReason `json:"reason"`
+	SchemaVersion int                     `json:"schema_version"`
+	TakenAt       time.Time               `json:"taken_at"`
+	Payload       json.RawMessage         `json:"payload,omitempty"`
+}
+
+// OrderSnapshotListResponseDTO for the snapshots of an order, oldest version first
+type OrderSnapshotListResponseDTO struct {
+	OrderID       uint                        `json:"order_id"`
+	OrderPublicID string                      `json:"order_public_id,omitempty"`
+	Snapshots     []*OrderSnapshotResponseDTO `json:"snapshots"`
+}
+
+// OrderDocumentDTO is a rendered order document, Filename is suggested for downloading it
+type OrderDocumentDTO struct {
+	ContentType string
+	Filename    string
+	Content     []byte
+}
+
+// OrderSnapshotToResponseDTO converts a snapshot entity, with its payload when withPayload is set
+func OrderSnapshotToResponseDTO(snapshot *entities.OrderSnapshot, order *entities.Order, withPayload bool) *OrderSnapshotResponseDTO {
+	response := &OrderSnapshotResponseDTO{
+		OrderID:       snapshot.OrderID,
+		OrderPublicID: order.PublicID,
+		Version:       snapshot.Version,
+		Reason:        snapshot.Reason,
+		SchemaVersion: snapshot.SchemaVersion,
+		TakenAt:       snapshot.TakenAt,
+	}
+	if withPayload {
+		response.Payload = json.RawMessage(snapshot.Payload)
+	}
+	return response
+}
+
+// WithoutContact returns the snapshot without the contact details of the customer in its payload, for
+// callers not allowed to see them. The snapshot itself is not changed, a copy is returned when its
+// payload has contact details.
+func (r *OrderSnapshotResponseDTO) WithoutContact() *OrderSnapshotResponseDTO {
+	if r == nil || len(r.Payload) == 0 {
+		return r
+	}
+
+	// Values are kept as written, only the contact fields are taken out. A payload that cannot be read
+	// is left out rather than risk showing them.
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(r.Payload, &payload); err != nil {
+		redacted := *r
+		redacted.Payload = nil
+		return &redacted
+	}
+	_, hasEmail := payload["customer_email"]
+	_, hasName := payload["customer_name"]
+	if !hasEmail && !hasName {
+		return r
+	}
+	delete(payload, "customer_email")
+	delete(payload, "customer_name")
+
+	redacted := *r
+	redacted.Payload, _ = json.Marshal(payload)
+	return &redacted
+}
+
+// ScheduledTransitionResponseDTO for a status change scheduled for an order
+type ScheduledTransitionResponseDTO struct {
+	ID             uint                               `json:"id"`
+	OrderID        uint                               `json:"order_id"`
+	TargetStatus   entities.OrderStatus               `json:"target_status"`
+	ExecuteAt      time.Time                          `json:"execute_at"`
+	CreatedBy      string                             `json:"created_by"`
+	Status         entities.ScheduledTransitionStatus `json:"status"`
+	FailureCode    string                             `json:"failure_code,omitempty"`
+	FailureMessage string                             `json:"failure_message,omitempty"`
+	CreatedAt      time.Time                          `json:"created_at"`
+	CompletedAt    *time.Time                         `json:"completed_at,omitempty"`
+}
+
+// ScheduledTransitionListResponseDTO for the scheduled transitions of an order, oldest first
+type ScheduledTransitionListResponseDTO struct {
+	OrderID              uint                              `json:"order_id"`
+	OrderPublicID        string                            `json:"order_public_id,omitempty"`
+	ScheduledTransitions []*ScheduledTransitionResponseDTO `json:"scheduled_transitions"`
+}
+
+// ScheduledTransitionToResponseDTO converts a scheduled transition entity
+func ScheduledTransitionToResponseDTO(transition *entities.ScheduledTransition) *ScheduledTransitionResponseDTO {
+	return &ScheduledTransitionResponseDTO{
+		ID:             transition.ID,
+		OrderID:        transition.OrderID,
+		TargetStatus:   transition.TargetStatus,
+		ExecuteAt:      transition.ExecuteAt,
+		CreatedBy:      transition.CreatedBy,
+		Status:         transition.Status,
+		FailureCode:    transition.FailureCode,
+		FailureMessage: transition.FailureMessage,
+		CreatedAt:      transition.CreatedAt,
+		CompletedAt:    transition.CompletedAt,
+	}
+}
+
+// OrderJobResponseDTO for an order accepted for asynchronous creation. OrderID is set once the job
+// succeeded, ErrorCode and ErrorMessage once it failed.
+type OrderJobResponseDTO struct {
+	JobID         string                  `json:"job_id"`
+	CustomerID    uint                    `json:"customer_id"`
+	Status        entities.OrderJobStatus `json:"status"`
+	OrderID       uint                    `json:"order_id,omitempty"`
+	OrderPublicID string                  `json:"order_public_id,omitempty"`
+	ErrorCode     string                  `json:"error_code,omitempty"`
+	ErrorMessage  string                  `json:"error_message,omitempty"`
+	CreatedBy     string                  `json:"created_by"`
+	CreatedAt     time.Time               `json:"created_at"`
+	CompletedAt   *time.Time              `json:"completed_at,omitempty"`
+}
+
+// OrderJobToResponseDTO converts an order job entity
+func OrderJobToResponseDTO(job *entities.OrderJob) *OrderJobResponseDTO {
+	return &OrderJobResponseDTO{
+		JobID:         job.ID,
+		CustomerID:    job.CustomerID,
+		Status:        job.Status,
+		OrderID:       job.OrderID,
+		OrderPublicID: job.OrderPublicID,
+		ErrorCode:     job.ErrorCode,
+		ErrorMessage:  job.ErrorMessage,
+		CreatedBy:     job.CreatedBy,
+		CreatedAt:     job.CreatedAt,
+		CompletedAt:   job.CompletedAt,
+	}
+}
+
+// ImportOrdersRequestDTO tunes an order import
+type ImportOrdersRequestDTO struct {
+	// StopOnError ends the import at the first line that fails, otherwise failed lines are reported and skipped
+	StopOnError bool
+
+	// MaxLines caps the order lines read, 0 or values above the configured cap fall back to the cap
+	MaxLines int
+}
+
+// HistoricalOrderRequestDTO is a create order request carrying the status and timestamps the order had
+// before it was imported. Only the admin import accepts it, POST /orders always creates pending orders.
+type HistoricalOrderRequestDTO struct {
+	CreateOrderRequestDTO
+	// Status defaults to pending
+	Status entities.OrderStatus `json:"status,omitempty"`
+	// CreatedAt defaults to now and UpdatedAt to CreatedAt
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// ImportOrderLineDTO is an order line read from an import. Error is set instead of Request when the
+// line was rejected before reaching the use cases, such as malformed JSON.
+type ImportOrderLineDTO struct {
+	Line    int
+	Request *HistoricalOrderRequestDTO
+	Error   *ImportErrorDTO
+}
+
+// ImportErrorDTO describes why an import line failed, in the shape of an error response
+type ImportErrorDTO struct {
+	Error   string                 `json:"error"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// ImportOrderResultDTO reports the order created for an import line, or Error when the line failed
+type ImportOrderResultDTO struct {
+	Line        int             `json:"line"`
+	OrderID     uint            `json:"order_id,omitempty"`
+	PublicID    string          `json:"public_id,omitempty"`
+	OrderNumber string          `json:"order_number,omitempty"`
+	Error       *ImportErrorDTO `json:"error,omitempty"`
+}
+
+// Reasons an import stopped before the end of its body
+const (
+	ImportStoppedOnError  = "error"
+	ImportStoppedMaxLines = "max_lines"
+)
+
+// ImportOrdersSummaryDTO totals an import. Lines counts the reported lines, the lines after a stop are
+// not imported.
+type ImportOrdersSummaryDTO struct {
+	Lines      int    `json:"lines"`
+	Created    int    `json:"created"`
+	Failed     int    `json:"failed"`
+	Stopped    bool   `json:"stopped"`
+	StopReason string `json:"stop_reason,omitempty"`
+}
+
+// PriceChangeDTO for an order line whose unit price changed when the order was repriced
+type PriceChangeDTO struct {
+	ItemID       uint    `json:"item_id"`
+	ProductID    uint    `json:"product_id"`
+	OldUnitPrice float64 `json:"old_unit_price"`
+	NewUnitPrice float64 `json:"new_unit_price"`
+}
+
+// RepriceOrderResponseDTO for a repriced order and the lines whose price changed
+type RepriceOrderResponseDTO struct {
+	*OrderResponseDTO
+	PriceChanges []PriceChangeDTO `json:"price_changes"`
+}
+
+// RepriceOrderToResponseDTO converts a repriced order and its price changes
+func RepriceOrderToResponseDTO(order *entities.Order, changes []entities.PriceChange) *RepriceOrderResponseDTO {
+	response := &RepriceOrderResponseDTO{
+		OrderResponseDTO: OrderToResponseDTO(order),
+		PriceChanges:     make([]PriceChangeDTO, 0, len(changes)),
+	}
+	for _, change := range changes {
+		response.PriceChanges = append(response.PriceChanges, PriceChangeDTO{
+			ItemID:       change.ItemID,
+			ProductID:    change.ProductID,
+			OldUnitPrice: change.OldPrice,
+			NewUnitPrice: change.NewPrice,
+		})
+	}
+	return response
+}
+
+// OrderValidationErrorDTO is one problem found validating an order, Field names the rejected field when known
+type OrderValidationErrorDTO struct {
+	Error   string                 `json:"error"`
+	Message string                 `json:"message"`
+	Field   string                 `json:"field,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// OrderValidationResponseDTO previews the order a create request would produce without storing it.
+// Valid is false when creating the order would be rejected, Errors then lists every problem found.
+type OrderValidationResponseDTO struct {
+	Valid             bool                      `json:"valid"`
+	Errors            []OrderValidationErrorDTO `json:"errors"`
+	CustomerID        uint                      `json:"customer_id"`
+	ExternalReference string                    `json:"external_reference,omitempty"`
+	Tags              []string                  `json:"tags,omitempty"`
+	Items             []OrderItemResponseDTO    `json:"items"`
+	ItemCount         int                       `json:"item_count"`
+	TotalItems        int                       `json:"total_items"`
+	TotalAmount       float64                   `json:"total_amount"`
+	TotalWeightGrams  *int                      `json:"total_weight_grams,omitempty"`
+	Status            entities.OrderStatus      `json:"status,omitempty"`
+}
+
+// OrderToValidationResponseDTO previews an order built from a create request, a nil order previews
+// nothing but the customer of the request
+func OrderToValidationResponseDTO(request *CreateOrderRequestDTO, order *entities.Order, errs []OrderValidationErrorDTO) *OrderValidationResponseDTO {
+	if errs == nil {
+		errs = []OrderValidationErrorDTO{}
+	}
+	response := &OrderValidationResponseDTO{
+		Valid:      len(errs) == 0,
+		Errors:     errs,
+		CustomerID: request.CustomerID,
+		Items:      []OrderItemResponseDTO{},
+	}
+	if order == nil {
+		return response
+	}
+
+	response.ExternalReference = order.ExternalReference
+	response.Tags = order.Tags
+	response.Items = OrderItemsToResponseDTOs(order.Items)
+	response.ItemCount = order.GetItemCount()
+	response.TotalItems = order.GetTotalQuantity()
+	response.TotalAmount = order.TotalAmount
+	response.TotalWeightGrams = order.TotalWeightGrams
+	response.Status = order.Status
+	return response
+}
+
+// AuditEntryToResponseDTO converts an audit entry, empty snapshots are rendered as null
+func AuditEntryToResponseDTO(entry *entities.AuditEntry) *AuditEntryResponseDTO {
+	response := &AuditEntryResponseDTO{
+		ID:        entry.ID,
+		OrderID:   entry.OrderID,
+		Actor:     entry.Actor,
+		Action:    entry.Action,
+		Before:    entry.Before,
+		After:     entry.After,
+		CreatedAt: entry.CreatedAt,
+	}
+	if len(response.Before) == 0 {
+		response.Before = json.RawMessage("null")
+	}
+	if len(response.After) == 0 {
+		response.After = json.RawMessage("null")
+	}
+	return response
+}
+
+// WebhookDeadLetterResponseDTO for a webhook delivery that failed after its retries. Payload is the body
+// that was posted, ReplayedAt is set once a replay delivered it.
+type WebhookDeadLetterResponseDTO struct {
+	ID         uint            `json:"id"`
+	WebhookID  string          `json:"webhook_id"`
+	EventType  string          `json:"event_type"`
+	Payload    json.RawMessage `json:"payload"`
+	LastError  string          `json:"last_error"`
+	Attempts   int             `json:"attempts"`
+	CreatedAt  time.Time       `json:"created_at"`
+	ReplayedAt *time.Time      `json:"replayed_at,omitempty"`
+}
+
+// WebhookDeadLetterListResponseDTO for the paginated dead letters of a webhook
+type WebhookDeadLetterListResponseDTO struct {
+	DeadLetters []*WebhookDeadLetterResponseDTO `json:"dead_letters"`
+	Total       int64                           `json:"total"`
+	Page        int                             `json:"page"`
+	PageSize    int                             `json:"page_size"`
+	TotalPages  int                             `json:"total_pages"`
+	HasNext     bool                            `json:"has_next"`
+	HasPrevious bool                            `json:"has_previous"`
+}
+
+// ReplayWebhookDeadLettersResponseDTO totals a bulk replay. Remaining counts the pending dead letters of
+// the range left for a further replay, beyond the batch size or because their replay failed.
+type ReplayWebhookDeadLettersResponseDTO struct {
+	Replayed  int   `json:"replayed"`
+	Failed    int   `json:"failed"`
+	Remaining int64 `json:"remaining"`
+}
+
+// WebhookDeadLetterToResponseDTO converts a webhook dead letter entity
+func WebhookDeadLetterToResponseDTO(deadLetter *entities.WebhookDeadLetter) *WebhookDeadLetterResponseDTO {
+	return &WebhookDeadLetterResponseDTO{
+		ID:         deadLetter.ID,
+		WebhookID:  deadLetter.WebhookID,
+		EventType:  deadLetter.EventType,
+		Payload:    deadLetter.Payload,
+		LastError:  deadLetter.LastError,
+		Attempts:   deadLetter.Attempts,
+		CreatedAt:  deadLetter.CreatedAt,
+		ReplayedAt: deadLetter.ReplayedAt,
+	}
+}
+
+// AmendmentsToResponseDTOs converts the amendments of an order, nil when there are none
+func AmendmentsToResponseDTOs(amendments []entities.OrderAmendment) []OrderAmendmentResponseDTO {
+	if len(amendments) == 0 {
+		return nil
+	}
+	dtos := make([]OrderAmendmentResponseDTO, 0, len(amendments))
+	for _, amendment := range amendments {
+		changes := make([]ItemChangeResponseDTO, 0, len(amendment.Changes))
+		for _, change := range amendment.Changes {
+			changes = append(changes, ItemChangeResponseDTO{
+				ProductID:       change.ProductID,
+				ProductSKU:      change.ProductSKU,
+				ProductName:     change.ProductName,
+				Attributes:      change.Attributes,
+				QuantityBefore:  change.QuantityBefore,
+				QuantityAfter:   change.QuantityAfter,
+				UnitPriceBefore: change.UnitPriceBefore,
+				UnitPriceAfter:  change.UnitPriceAfter,
+			})
+		}
+		dtos = append(dtos, OrderAmendmentResponseDTO{
+			Number:    amendment.Number,
+			Reason:    amendment.Reason,
+			Changes:   changes,
+			AmendedAt: amendment.AmendedAt,
+		})
+	}
+	return dtos
+}
@@ -2,6 +2,9 @@ package dto
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -230,6 +233,11 @@ func TestOrderToResponseDTO(t *testing.T) {
 	assert.Equal(t, 3, dto.TotalItems) // 2 + 1
 	assert.Equal(t, 35.0, dto.TotalAmount)
 	assert.Equal(t, entities.OrderStatusConfirmed, dto.Status)
+	assert.Equal(t, []entities.OrderStatus{
+		entities.OrderStatusProcessing,
+		entities.OrderStatusOnHold,
+		entities.OrderStatusCancelled,
+	}, dto.AllowedTransitions)
 	assert.Equal(t, now, dto.CreatedAt)
 	assert.Equal(t, now, dto.UpdatedAt)
 
@@ -296,6 +304,47 @@ func TestOrderItemToResponseDTO(t *testing.T) {
 	assert.Equal(t, item.TotalPrice, dto.TotalPrice)
 }
 
+func TestCreateOrderRequestDTO_ToEntity_Attributes(t *testing.T) {
+	// Given
+	dto := CreateOrderRequestDTO{
+		CustomerID: 123,
+		Items: []CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "T-Shirt", Quantity: 1, UnitPrice: 10, Attributes: map[string]string{"size": "M"}},
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "T-Shirt", Quantity: 2, UnitPrice: 10, Attributes: map[string]string{"size": "L"}},
+		},
+	}
+
+	// When
+	order, err := dto.ToEntity()
+	require.NoError(t, err)
+	response := OrderToResponseDTO(order)
+
+	// Then
+	require.Len(t, response.Items, 2)
+	assert.Equal(t, map[string]string{"size": "M"}, response.Items[0].Attributes)
+	assert.Equal(t, map[string]string{"size": "L"}, response.Items[1].Attributes)
+}
+
+func TestOrderToResponseDTO_Weight(t *testing.T) {
+	// Given
+	weight := 300
+	order, err := entities.NewOrder(123)
+	require.NoError(t, err)
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 2, 10, entities.WithUnitWeightGrams(&weight)))
+
+	// When
+	withWeight, err := json.Marshal(OrderToResponseDTO(order))
+	require.NoError(t, err)
+	require.NoError(t, order.AddItem(2, "SKU-002", "Product 2", 1, 10))
+	withoutWeight, err := json.Marshal(OrderToResponseDTO(order))
+	require.NoError(t, err)
+
+	// Then
+	assert.Contains(t, string(withWeight), `"total_weight_grams":600`)
+	assert.Contains(t, string(withWeight), `"unit_weight_grams":300`)
+	assert.NotContains(t, string(withoutWeight), "total_weight_grams")
+}
+
 func TestOrdersToResponseDTOs(t *testing.T) {
 	// Given
 	now := time.Now()
@@ -525,6 +574,39 @@ func TestOrderListResponseDTO_Structure(t *testing.T) {
 	assert.Equal(t, 2, decoded.PageSize)
 }
 
+func TestNewOrderListResponseDTO_PageMetadata(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int64
+		page        int
+		pageSize    int
+		totalPages  int
+		hasNext     bool
+		hasPrevious bool
+	}{
+		{"empty", 0, 0, 10, 0, false, false},
+		{"single partial page", 7, 0, 10, 1, false, false},
+		{"exact pages", 20, 0, 10, 2, true, false},
+		{"middle page", 45, 2, 10, 5, true, true},
+		{"last page", 45, 4, 10, 5, false, true},
+		{"beyond the last page", 45, 9, 10, 5, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			response := NewOrderListResponseDTO(nil, tt.total, tt.page, tt.pageSize)
+
+			// Then
+			assert.Empty(t, response.Orders)
+			assert.Equal(t, tt.total, response.Total)
+			assert.Equal(t, tt.totalPages, response.TotalPages)
+			assert.Equal(t, tt.hasNext, response.HasNext)
+			assert.Equal(t, tt.hasPrevious, response.HasPrevious)
+		})
+	}
+}
+
 func TestOrderSummaryListResponseDTO_Structure(t *testing.T) {
 	// Given
 	now := time.Now()
@@ -623,3 +705,140 @@ func TestCreateOrderRequestDTO_EmptyItems(t *testing.T) {
 	assert.Equal(t, 0.0, entity.TotalAmount)
 	assert.True(t, entity.IsEmpty())
 }
+
+func TestCreateOrderRequestDTO_ValidateReportsEveryItem(t *testing.T) {
+	// Given
+	dto := CreateOrderRequestDTO{
+		CustomerID: 123,
+		Items: []CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 0, UnitPrice: 10.0},
+			{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 5.0},
+			{ProductID: 3, ProductSKU: "SKU-003", ProductName: "Product 3", Quantity: 2, UnitPrice: -1},
+		},
+	}
+
+	// When
+	entity, err := dto.ToEntity()
+
+	// Then no partial order is built and both failures are reported
+	assert.Nil(t, entity)
+
+	var itemErrors entities.ItemErrors
+	require.ErrorAs(t, err, &itemErrors)
+	require.Len(t, itemErrors, 2)
+	assert.Equal(t, entities.ItemError{Index: 0, Field: "quantity", Reason: "quantity must be positive"}, itemErrors[0])
+	assert.Equal(t, entities.ItemError{Index: 2, Field: "unit_price", Reason: "unit price must be positive"}, itemErrors[1])
+	assert.ErrorAs(t, dto.Validate(), &itemErrors)
+}
+
+func TestOrderSnapshotResponseDTO_WithoutContact(t *testing.T) {
+	snapshot := &OrderSnapshotResponseDTO{
+		OrderID: 1,
+		Version: 1,
+		Payload: json.RawMessage(`{"order_id":1,"total_amount":25.10,"customer_email":"jane@example.com","customer_name":"Jane Doe"}`),
+	}
+
+	redacted := snapshot.WithoutContact()
+
+	assert.JSONEq(t, `{"order_id":1,"total_amount":25.10}`, string(redacted.Payload))
+	assert.Contains(t, string(redacted.Payload), "25.10", "numbers are kept as written")
+	assert.Contains(t, string(snapshot.Payload), "jane@example.com", "the snapshot itself is not changed")
+
+	withoutContact := &OrderSnapshotResponseDTO{Payload: json.RawMessage(`{"order_id":1}`)}
+	assert.Same(t, withoutContact, withoutContact.WithoutContact())
+}
+
+func BenchmarkOrderToResponseDTO(b *testing.B) {
+	for _, lines := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("items=%d", lines), func(b *testing.B) {
+			order, err := entities.NewOrder(123)
+			if err != nil {
+				b.Fatal(err)
+			}
+			order.ID = 1
+			for i := 1; i <= lines; i++ {
+				if err := order.AddItem(uint(i), fmt.Sprintf("SKU-%03d", i), "Product", 2, 9.99); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				OrderToResponseDTO(order)
+			}
+		})
+	}
+}
+
+func BenchmarkCreateOrderRequestDTO_ToEntity(b *testing.B) {
+	for _, lines := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("items=%d", lines), func(b *testing.B) {
+			request := CreateOrderRequestDTO{CustomerID: 123}
+			for i := 1; i <= lines; i++ {
+				request.Items = append(request.Items, CreateOrderItemDTO{
+					ProductID: uint(i), ProductSKU: fmt.Sprintf("SKU-%03d", i), ProductName: "Product", Quantity: 2, UnitPrice: 9.99,
+				})
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := request.ToEntity(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// FuzzCreateOrderRequestDTO_ToEntity decodes arbitrary bodies into a create request and builds the order,
+// which must never panic and must reject bad requests with an error the use cases can map to a response
+func FuzzCreateOrderRequestDTO_ToEntity(f *testing.F) {
+	f.Add([]byte(`{"customer_id":1,"items":[{"product_id":1,"product_sku":"SKU-001","product_name":"Mug","quantity":2,"unit_price":9.99}]}`))
+	f.Add([]byte(`{"customer_id":1,"items":[{"product_id":1,"product_sku":"SKU-001","product_name":"Mug","quantity":-0,"unit_price":-0.0}]}`))
+	f.Add([]byte(`{"customer_id":1,"items":[{"product_id":1,"product_sku":"SKU-001","product_name":"Mug","quantity":9223372036854775807,"unit_price":1e308}]}`))
+	f.Add([]byte(`{"customer_id":1,"tags":["GIFT","gift"," "],"priority":"urgent","customer_email":"jane@example.com"}`))
+	f.Add([]byte(`{"customer_id":1,"external_reference":"` + strings.Repeat("é", 100) + `","tags":["` + strings.Repeat("İ", 32) + `"]}`))
+	f.Add([]byte(`{"customer_id":0,"items":[{"attributes":{"":""}}]}`))
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		var request CreateOrderRequestDTO
+		if err := json.Unmarshal(body, &request); err != nil {
+			return
+		}
+
+		order, err := request.ToEntity()
+		if err != nil {
+			assert.Nil(t, order)
+			assert.True(t, isTypedOrderError(err), "untyped error %v", err)
+			return
+		}
+		assert.Equal(t, request.CustomerID, order.CustomerID)
+		assert.Len(t, order.Items, len(request.Items))
+		assert.LessOrEqual(t, order.TotalAmount, entities.MaxExactAmount)
+	})
+}
+
+// isTypedOrderError reports whether err is one of the errors building an order is documented to return
+func isTypedOrderError(err error) bool {
+	var itemErrors entities.ItemErrors
+	if errors.As(err, &itemErrors) {
+		return true
+	}
+	for _, target := range []error{
+		entities.ErrCustomerIDRequired,
+		entities.ErrInvalidExternalReference,
+		entities.ErrInvalidTags,
+		entities.ErrInvalidUnitPrice,
+		entities.ErrTotalLimitExceeded,
+		entities.ErrInvalidPriority,
+		entities.ErrInvalidCustomerEmail,
+		entities.ErrInvalidCustomerName,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+// Package events defines the versioned payloads order events are serialized to for consumers outside
+// the service. The JSON form of each version is a contract: a change consumers would notice goes into
+// a new version next to the existing ones, a published version is never edited.
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	domainEvents "orders-service/internal/domain/events"
+)
+
+// SchemaVersion identifies a version of the event payloads
+type SchemaVersion int
+
+const (
+	// SchemaV1 is the first version of the event payloads, see OrderEventV1
+	SchemaV1 SchemaVersion = 1
+	// SchemaV2 adds the priority of the order, see OrderEventV2
+	SchemaV2 SchemaVersion = 2
+	// SchemaV3 adds the contact details of the customer, see OrderEventV3
+	SchemaV3 SchemaVersion = 3
+	// SchemaV4 adds the refunded amount and the refund of refund events, see OrderEventV4
+	SchemaV4 SchemaVersion = 4
+
+	// DefaultSchemaVersion is used for consumers not asking for a version. It stays at the oldest
+	// supported version so adding a version does not change what existing consumers receive.
+	DefaultSchemaVersion = SchemaV1
+)
+
+// ErrUnknownSchemaVersion is returned for a schema version the service cannot produce
+var ErrUnknownSchemaVersion = errors.New("unknown event schema version")
+
+// encoders builds the payload of each supported version from a domain event
+var encoders = map[SchemaVersion]func(event domainEvents.OrderEvent) any{
+	SchemaV1: func(event domainEvents.OrderEvent) any { return NewOrderEventV1(event) },
+	SchemaV2: func(event domainEvents.OrderEvent) any { return NewOrderEventV2(event) },
+	SchemaV3: func(event domainEvents.OrderEvent) any { return NewOrderEventV3(event) },
+	SchemaV4: func(event domainEvents.OrderEvent) any { return NewOrderEventV4(event) },
+}
+
+// ParseSchemaVersion parses a requested schema version, an empty value selects DefaultSchemaVersion
+func ParseSchemaVersion(value string) (SchemaVersion, error) {
+	if value == "" {
+		return DefaultSchemaVersion, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownSchemaVersion, value)
+	}
+	version := SchemaVersion(number)
+	if _, ok := encoders[version]; !ok {
+		return 0, fmt.Errorf("%w: %d", ErrUnknownSchemaVersion, version)
+	}
+	return version, nil
+}
+
+// Schema names the schema of an event type in a version, such as order.created.v1
+func Schema(eventType domainEvents.OrderEventType, version SchemaVersion) string {
+	return fmt.Sprintf("%s.v%d", eventType, version)
+}
+
+// Encode serializes event as the payload of the given schema version
+func Encode(event domainEvents.OrderEvent, version SchemaVersion) ([]byte, error) {
+	encode, ok := encoders[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownSchemaVersion, version)
+	}
+	return json.Marshal(encode(event))
+}
@@ -0,0 +1,82 @@
+package events
+
+import (
+	"maps"
+	"time"
+
+	domainEvents "orders-service/internal/domain/events"
+)
+
+// OrderEventV1 is the version 1 payload of every order event type. Fields only hold plain types so
+// a change of the domain types cannot alter the serialized form.
+type OrderEventV1 struct {
+	// Schema names the event type and version, such as order.created.v1
+	Schema        string    `json:"schema"`
+	SchemaVersion int       `json:"schema_version"`
+	Type          string    `json:"type"`
+	OrderPublicID string    `json:"order_public_id"`
+	OrderID       uint      `json:"order_id"`
+	CustomerID    uint      `json:"customer_id"`
+	Status        string    `json:"status"`
+	OccurredAt    time.Time `json:"occurred_at"`
+
+	// Items and TotalAmount are the order lines when the event occurred
+	Items       []OrderItemV1 `json:"items"`
+	TotalAmount float64       `json:"total_amount"`
+
+	// Carrier and TrackingNumber are set once the order shipped with tracking details
+	Carrier        string `json:"carrier,omitempty"`
+	TrackingNumber string `json:"tracking_number,omitempty"`
+
+	// ShipmentID is set on shipment events
+	ShipmentID uint `json:"shipment_id,omitempty"`
+
+	// ProductID and Quantity are set on item cancelled events
+	ProductID uint `json:"product_id,omitempty"`
+	Quantity  int  `json:"quantity,omitempty"`
+}
+
+// OrderItemV1 is an order line in version 1 payloads
+type OrderItemV1 struct {
+	ProductID   uint              `json:"product_id"`
+	ProductSKU  string            `json:"product_sku"`
+	ProductName string            `json:"product_name"`
+	Quantity    int               `json:"quantity"`
+	UnitPrice   float64           `json:"unit_price"`
+	TotalPrice  float64           `json:"total_price"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+}
+
+// NewOrderEventV1 converts a domain event to its version 1 payload
+func NewOrderEventV1(event domainEvents.OrderEvent) OrderEventV1 {
+	items := make([]OrderItemV1, 0, len(event.Items))
+	for _, item := range event.Items {
+		items = append(items, OrderItemV1{
+			ProductID:   item.ProductID,
+			ProductSKU:  item.ProductSKU,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			TotalPrice:  item.TotalPrice,
+			Attributes:  maps.Clone(item.Attributes),
+		})
+	}
+
+	return OrderEventV1{
+		Schema:         Schema(event.Type, SchemaV1),
+		SchemaVersion:  int(SchemaV1),
+		Type:           string(event.Type),
+		OrderPublicID:  event.OrderPublicID,
+		OrderID:        event.OrderID,
+		CustomerID:     event.CustomerID,
+		Status:         string(event.Status),
+		OccurredAt:     event.OccurredAt.UTC(),
+		Items:          items,
+		TotalAmount:    event.TotalAmount,
+		Carrier:        event.Carrier,
+		TrackingNumber: event.TrackingNumber,
+		ShipmentID:     event.ShipmentID,
+		ProductID:      event.ProductID,
+		Quantity:       event.Quantity,
+	}
+}
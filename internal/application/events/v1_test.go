@@ -0,0 +1,122 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"orders-service/internal/domain/entities"
+	domainEvents "orders-service/internal/domain/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of the event payloads")
+
+func sampleOrder() *entities.Order {
+	return &entities.Order{
+		ID:             42,
+		PublicID:       "5f0c7a8e-2b1d-4c3e-9f6a-1d2e3f4a5b6c",
+		CustomerID:     7,
+		Status:         entities.OrderStatusConfirmed,
+		TotalAmount:    41.5,
+		Carrier:        "ups",
+		TrackingNumber: "1Z999",
+		Items: []entities.OrderItem{
+			{ID: 1, ProductID: 10, ProductSKU: "SKU-10", ProductName: "Mug", Quantity: 2, UnitPrice: 10.5, TotalPrice: 21,
+				Attributes: map[string]string{"color": "blue"}},
+			{ID: 2, ProductID: 11, ProductSKU: "SKU-11", ProductName: "Plate", Quantity: 1, UnitPrice: 20.5, TotalPrice: 20.5},
+		},
+	}
+}
+
+// assertGolden compares payload with testdata/name, rewriting the file when run with -update
+func assertGolden(t *testing.T, name string, payload []byte) {
+	t.Helper()
+
+	var indented bytes.Buffer
+	require.NoError(t, json.Indent(&indented, payload, "", "  "))
+	indented.WriteByte('\n')
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.WriteFile(path, indented.Bytes(), 0o644))
+	}
+
+	golden, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), indented.String(), "payload of %s changed, a breaking change needs a new schema version", name)
+}
+
+func TestEncode_V1Golden(t *testing.T) {
+	occurredAt := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	order := sampleOrder()
+
+	shipment := &entities.Shipment{ID: 3, Carrier: "dhl", TrackingNumber: "JD0001"}
+	cancelled := &entities.CancelledItem{ProductID: 10, Quantity: 1}
+
+	tests := []struct {
+		golden string
+		event  domainEvents.OrderEvent
+	}{
+		{"order.created.v1.json", domainEvents.NewOrderEvent(domainEvents.OrderCreated, order, occurredAt)},
+		{"order.shipment_created.v1.json", domainEvents.NewShipmentEvent(domainEvents.OrderShipmentCreated, order, shipment, occurredAt)},
+		{"order.item_cancelled.v1.json", domainEvents.NewItemCancelledEvent(order, cancelled, occurredAt)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			payload, err := Encode(tt.event, SchemaV1)
+
+			require.NoError(t, err)
+			assertGolden(t, tt.golden, payload)
+		})
+	}
+}
+
+func TestNewOrderEventV1_SnapshotsItems(t *testing.T) {
+	order := sampleOrder()
+	event := domainEvents.NewOrderEvent(domainEvents.OrderItemsChanged, order, time.Now())
+
+	order.Items[0].Quantity = 5
+	order.Items[0].Attributes["color"] = "red"
+
+	payload := NewOrderEventV1(event)
+	assert.Equal(t, "order.items_changed.v1", payload.Schema)
+	assert.Equal(t, 2, payload.Items[0].Quantity)
+	assert.Equal(t, "blue", payload.Items[0].Attributes["color"])
+}
+
+func TestNewOrderEventV1_EmptyItemsEncodeAsArray(t *testing.T) {
+	payload, err := Encode(domainEvents.OrderEvent{Type: domainEvents.OrderDeleted}, SchemaV1)
+
+	require.NoError(t, err)
+	assert.Contains(t, string(payload), `"items":[]`)
+}
+
+func TestParseSchemaVersion(t *testing.T) {
+	version, err := ParseSchemaVersion("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultSchemaVersion, version)
+
+	version, err = ParseSchemaVersion("1")
+	require.NoError(t, err)
+	assert.Equal(t, SchemaV1, version)
+
+	version, err = ParseSchemaVersion("2")
+	require.NoError(t, err)
+	assert.Equal(t, SchemaV2, version)
+
+	for _, value := range []string{"0", "99", "v1"} {
+		_, err := ParseSchemaVersion(value)
+		assert.ErrorIs(t, err, ErrUnknownSchemaVersion, value)
+	}
+
+	_, err = Encode(domainEvents.OrderEvent{}, SchemaVersion(99))
+	assert.ErrorIs(t, err, ErrUnknownSchemaVersion)
+}
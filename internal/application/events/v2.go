@@ -0,0 +1,27 @@
+package events
+
+import (
+	domainEvents "orders-service/internal/domain/events"
+)
+
+// OrderEventV2 is the version 2 payload of every order event type, version 1 with the priority of the order
+type OrderEventV2 struct {
+	OrderEventV1
+
+	// Priority lets pickers route urgent orders first
+	Priority string `json:"priority"`
+}
+
+// NewOrderEventV2 converts a domain event to its version 2 payload. Events of orders stored before
+// priorities existed carry the normal priority.
+func NewOrderEventV2(event domainEvents.OrderEvent) OrderEventV2 {
+	payload := NewOrderEventV1(event)
+	payload.Schema = Schema(event.Type, SchemaV2)
+	payload.SchemaVersion = int(SchemaV2)
+
+	priority := string(event.Priority)
+	if priority == "" {
+		priority = "normal"
+	}
+	return OrderEventV2{OrderEventV1: payload, Priority: priority}
+}
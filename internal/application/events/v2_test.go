@@ -0,0 +1,33 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"orders-service/internal/domain/entities"
+	domainEvents "orders-service/internal/domain/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode_V2Golden(t *testing.T) {
+	occurredAt := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	order := sampleOrder()
+	order.Priority = entities.OrderPriorityUrgent
+
+	payload, err := Encode(domainEvents.NewOrderEvent(domainEvents.OrderCreated, order, occurredAt), SchemaV2)
+
+	require.NoError(t, err)
+	assertGolden(t, "order.created.v2.json", payload)
+}
+
+func TestNewOrderEventV2_DefaultsToNormalPriority(t *testing.T) {
+	event := domainEvents.NewOrderEvent(domainEvents.OrderStatusChanged, sampleOrder(), time.Now())
+
+	payload := NewOrderEventV2(event)
+
+	assert.Equal(t, "order.status_changed.v2", payload.Schema)
+	assert.Equal(t, 2, payload.SchemaVersion)
+	assert.Equal(t, "normal", payload.Priority)
+}
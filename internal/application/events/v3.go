@@ -0,0 +1,27 @@
+package events
+
+import (
+	domainEvents "orders-service/internal/domain/events"
+)
+
+// OrderEventV3 is the version 3 payload of every order event type, version 2 with the contact details the
+// customer gave for the order. They are omitted when the order has none or the consumer may not see them.
+type OrderEventV3 struct {
+	OrderEventV2
+
+	CustomerEmail string `json:"customer_email,omitempty"`
+	CustomerName  string `json:"customer_name,omitempty"`
+}
+
+// NewOrderEventV3 converts a domain event to its version 3 payload
+func NewOrderEventV3(event domainEvents.OrderEvent) OrderEventV3 {
+	payload := NewOrderEventV2(event)
+	payload.Schema = Schema(event.Type, SchemaV3)
+	payload.SchemaVersion = int(SchemaV3)
+
+	return OrderEventV3{
+		OrderEventV2:  payload,
+		CustomerEmail: event.CustomerEmail,
+		CustomerName:  event.CustomerName,
+	}
+}
@@ -0,0 +1,49 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	domainEvents "orders-service/internal/domain/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode_V3Golden(t *testing.T) {
+	occurredAt := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	order := sampleOrder()
+	order.CustomerEmail = "jane@example.com"
+	order.CustomerName = "Jane Doe"
+
+	payload, err := Encode(domainEvents.NewOrderEvent(domainEvents.OrderCreated, order, occurredAt), SchemaV3)
+
+	require.NoError(t, err)
+	assertGolden(t, "order.created.v3.json", payload)
+}
+
+func TestEncode_EarlierVersionsLeaveContactOut(t *testing.T) {
+	order := sampleOrder()
+	order.CustomerEmail = "jane@example.com"
+	event := domainEvents.NewOrderEvent(domainEvents.OrderCreated, order, time.Now())
+
+	for _, version := range []SchemaVersion{SchemaV1, SchemaV2} {
+		payload, err := Encode(event, version)
+
+		require.NoError(t, err)
+		assert.NotContains(t, string(payload), "jane@example.com")
+	}
+}
+
+func TestNewOrderEventV3_WithoutContact(t *testing.T) {
+	order := sampleOrder()
+	order.CustomerEmail = "jane@example.com"
+	order.CustomerName = "Jane Doe"
+	event := domainEvents.NewOrderEvent(domainEvents.OrderCreated, order, time.Now())
+
+	payload := NewOrderEventV3(event.WithoutContact())
+
+	assert.Equal(t, "order.created.v3", payload.Schema)
+	assert.Empty(t, payload.CustomerEmail)
+	assert.Empty(t, payload.CustomerName)
+}
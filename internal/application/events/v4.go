@@ -0,0 +1,29 @@
+package events
+
+import (
+	domainEvents "orders-service/internal/domain/events"
+)
+
+// OrderEventV4 is the version 4 payload of every order event type, version 3 with the amount refunded
+// to the customer and, on refund events, the refund the event is about
+type OrderEventV4 struct {
+	OrderEventV3
+
+	RefundedAmount float64 `json:"refunded_amount"`
+	RefundID       uint    `json:"refund_id,omitempty"`
+	RefundAmount   float64 `json:"refund_amount,omitempty"`
+}
+
+// NewOrderEventV4 converts a domain event to its version 4 payload
+func NewOrderEventV4(event domainEvents.OrderEvent) OrderEventV4 {
+	payload := NewOrderEventV3(event)
+	payload.Schema = Schema(event.Type, SchemaV4)
+	payload.SchemaVersion = int(SchemaV4)
+
+	return OrderEventV4{
+		OrderEventV3:   payload,
+		RefundedAmount: event.RefundedAmount,
+		RefundID:       event.RefundID,
+		RefundAmount:   event.RefundAmount,
+	}
+}
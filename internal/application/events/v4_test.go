@@ -0,0 +1,39 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"orders-service/internal/domain/entities"
+	domainEvents "orders-service/internal/domain/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode_V4Golden(t *testing.T) {
+	occurredAt := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	order := sampleOrder()
+	order.Status = entities.OrderStatusDelivered
+	order.RefundedAmount = 10.5
+	refund := &entities.Refund{ID: 4, OrderID: order.ID, Amount: 10.5}
+
+	payload, err := Encode(domainEvents.NewRefundEvent(order, refund, occurredAt), SchemaV4)
+
+	require.NoError(t, err)
+	assertGolden(t, "order.refunded.v4.json", payload)
+}
+
+func TestEncode_EarlierVersionsLeaveRefundOut(t *testing.T) {
+	order := sampleOrder()
+	order.RefundedAmount = 10.5
+	event := domainEvents.NewRefundEvent(order, &entities.Refund{ID: 4, Amount: 10.5}, time.Now())
+
+	for _, version := range []SchemaVersion{SchemaV1, SchemaV2, SchemaV3} {
+		payload, err := Encode(event, version)
+
+		require.NoError(t, err)
+		assert.NotContains(t, string(payload), "refund_id")
+		assert.NotContains(t, string(payload), "refunded_amount")
+	}
+}
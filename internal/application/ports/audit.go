@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+
+	"orders-service/internal/domain/entities"
+)
+
+// AuditRepository persists audit log entries
+type AuditRepository interface {
+	// Create stores a new audit entry
+	Create(ctx context.Context, entry *entities.AuditEntry) error
+
+	// ListByOrderID retrieves the audit entries of an order, oldest first
+	ListByOrderID(ctx context.Context, orderID uint, limit, offset int) ([]*entities.AuditEntry, error)
+
+	// CountByOrderID returns the number of audit entries of an order
+	CountByOrderID(ctx context.Context, orderID uint) (int64, error)
+}
+
+// AuditRecorder records successful order mutations. Recording must not block or fail the mutation.
+type AuditRecorder interface {
+	// Record logs that the caller in ctx applied action to the order. before is nil for
+	// created orders and after is nil for deleted ones.
+	Record(ctx context.Context, action entities.AuditAction, orderID uint, before, after *entities.Order)
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrCacheMiss is returned by Cache.Get when the key is not cached
+var ErrCacheMiss = errors.New("cache miss")
+
+// Cache is a key value store for serialized data with per key expiry
+type Cache interface {
+	// Get returns the value stored at key or ErrCacheMiss
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set stores value at key for ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the keys, missing keys are ignored
+	Delete(ctx context.Context, keys ...string) error
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "context"
+
+// ProductCatalog provides the current prices of products
+type ProductCatalog interface {
+	// GetPrices returns the current unit price of each product found, products unknown to the
+	// catalog are left out. An error means the catalog could not be reached.
+	GetPrices(ctx context.Context, productIDs []uint) (map[uint]float64, error)
+}
@@ -0,0 +1,43 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"orders-service/internal/domain/entities"
+)
+
+// DocumentFormat is a file format of printable order documents
+type DocumentFormat string
+
+const (
+	DocumentFormatHTML DocumentFormat = "html"
+	DocumentFormatPDF  DocumentFormat = "pdf"
+)
+
+// ErrDocumentFormatUnsupported is returned by a DocumentRenderer asked for a format it cannot produce
+var ErrDocumentFormatUnsupported = errors.New("document format unsupported")
+
+// OrderDocument is what a printable order document shows
+type OrderDocument struct {
+	Order *entities.Order
+	// HidePrices leaves the prices and totals out, such as on the slip packed with a gift
+	HidePrices bool
+	// ShowContact prints the contact details of the customer, they are personal data
+	ShowContact bool
+	GeneratedAt time.Time
+}
+
+// RenderedDocument is a document ready to be downloaded
+type RenderedDocument struct {
+	ContentType string
+	Content     []byte
+}
+
+// DocumentRenderer renders printable order documents with the layout and branding of the deployment. A
+// renderer producing PDF, such as one driving wkhtmltopdf or a headless browser, can wrap one producing HTML.
+type DocumentRenderer interface {
+	// Render returns document in format, ErrDocumentFormatUnsupported when the renderer cannot produce it
+	Render(ctx context.Context, document OrderDocument, format DocumentFormat) (*RenderedDocument, error)
+}
@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"orders-service/internal/domain/events"
+)
+
+// EventPublisher delivers order events to interested consumers
+type EventPublisher interface {
+	// Publish sends a single event
+	Publish(ctx context.Context, event events.OrderEvent) error
+}
+
+// ErrTooManySubscribers is returned by EventSubscriber.Subscribe once the subscriber limit is reached
+var ErrTooManySubscribers = errors.New("too many event subscribers")
+
+// EventSubscriber delivers published events to listeners of the same process
+type EventSubscriber interface {
+	// Subscribe registers a listener for the events matching filter
+	Subscribe(filter func(events.OrderEvent) bool) (EventSubscription, error)
+}
+
+// EventSubscription is a registered listener, it must be closed once the listener is gone
+type EventSubscription interface {
+	// Events delivers the matching events, it is closed by Close
+	Events() <-chan events.OrderEvent
+	Close()
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"orders-service/internal/domain/entities"
+)
+
+// ErrInventoryCircuitOpen is returned by an Inventory that stopped calling the inventory after repeated
+// failures, without asking it for the reservation
+var ErrInventoryCircuitOpen = errors.New("inventory circuit breaker is open")
+
+// Inventory reserves stock for the items of confirmed orders
+type Inventory interface {
+	// Reserve reserves stock for the items of order and returns how many units of each item it reserved,
+	// in the order of order.Items. A count below the quantity leaves the rest of the line backordered.
+	// An error means the inventory could not be reached, ErrInventoryCircuitOpen that it was not called.
+	Reserve(ctx context.Context, order *entities.Order) ([]int, error)
+}
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrLockHeld is returned by DistributedLock.Acquire when another holder has the lock
+	ErrLockHeld = errors.New("lock held by another holder")
+
+	// ErrLockLost is returned by DistributedLock.Renew when the lock is no longer held
+	ErrLockLost = errors.New("lock lost")
+)
+
+// DistributedLock lets one holder among the replicas of the service work on a key at a time, such as
+// the pass of a background job
+type DistributedLock interface {
+	// Acquire takes the lock on key for ttl without waiting, ErrLockHeld when another holder has it
+	Acquire(ctx context.Context, key string, ttl time.Duration) error
+
+	// Renew extends a held lock on key by ttl, ErrLockLost when it expired or was taken over
+	Renew(ctx context.Context, key string, ttl time.Duration) error
+
+	// Release gives up the lock on key, releasing a lock that is not held does nothing
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"orders-service/internal/domain/entities"
+)
+
+// OrderJobRepository persists the orders accepted for asynchronous creation, so jobs survive a restart
+type OrderJobRepository interface {
+	// Create stores a new job under its ID
+	Create(ctx context.Context, job *entities.OrderJob) (*entities.OrderJob, error)
+
+	// Update stores the outcome of a pending job. A job that completed in the meantime is left unchanged
+	// and ErrOrderJobNotPending is returned, so two workers running the same job cannot both succeed.
+	Update(ctx context.Context, job *entities.OrderJob) (*entities.OrderJob, error)
+
+	// GetByID retrieves a job by its ID
+	GetByID(ctx context.Context, id string) (*entities.OrderJob, error)
+
+	// FindPending retrieves up to limit pending jobs created at or before before, oldest first
+	FindPending(ctx context.Context, before time.Time, limit int) ([]*entities.OrderJob, error)
+}
+
+// JobQueue hands the IDs of stored order jobs to the workers running them. Delivery is best effort,
+// a job that is never delivered stays pending in the OrderJobRepository and is run from there.
+type JobQueue interface {
+	// Enqueue queues the job with the given ID, failing instead of blocking when the queue is full
+	Enqueue(ctx context.Context, jobID string) error
+
+	// Consume calls handle with every queued job ID, one at a time, until ctx is cancelled
+	Consume(ctx context.Context, handle func(ctx context.Context, jobID string)) error
+}
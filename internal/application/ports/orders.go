@@ -3,8 +3,38 @@ package ports
 import (
 	"context"
 	"orders-service/internal/domain/entities"
+	"time"
 )
 
+// OrderFilter narrows order queries. Zero values are ignored.
+type OrderFilter struct {
+	CustomerID    uint
+	Status        entities.OrderStatus
+	CreatedFrom   time.Time // inclusive
+	CreatedBefore time.Time // exclusive
+
+	// Backordered keeps the orders with at least one backordered line
+	Backordered bool
+}
+
+// StatusAggregate is the number and value of orders in a status
+type StatusAggregate struct {
+	Status  entities.OrderStatus
+	Orders  int64
+	Revenue float64
+
+	// FirstCreatedAt and LastCreatedAt bound the creation times of the orders in the status
+	FirstCreatedAt time.Time
+	LastCreatedAt  time.Time
+}
+
+// DailyAggregate is the number and value of orders created on a day
+type DailyAggregate struct {
+	Day     time.Time
+	Orders  int64
+	Revenue float64
+}
+
 // OrderRepository defines the interface for order persistence operations
 type OrderRepository interface {
 	// Create creates a new order in the repository
@@ -13,12 +43,42 @@ type OrderRepository interface {
 	// GetByID retrieves an order by its ID
 	GetByID(ctx context.Context, id uint) (*entities.Order, error)
 
+	// GetByIDIncludingDeleted retrieves an order by its ID even when it was soft deleted,
+	// in which case the returned order has DeletedAt set
+	GetByIDIncludingDeleted(ctx context.Context, id uint) (*entities.Order, error)
+
+	// GetByIDForUpdate retrieves an order by its ID and locks it against concurrent writers until
+	// the transaction in ctx ends. It must run inside a UnitOfWork for the lock to outlive the read.
+	GetByIDForUpdate(ctx context.Context, id uint) (*entities.Order, error)
+
+	// GetByPublicID retrieves an order by the UUID it was given on creation
+	GetByPublicID(ctx context.Context, publicID string) (*entities.Order, error)
+
+	// GetByExternalReference retrieves the order a customer created with the given external reference
+	GetByExternalReference(ctx context.Context, customerID uint, reference string) (*entities.Order, error)
+
+	// GetByOrderNumber retrieves an order by the human friendly number it was given on creation
+	GetByOrderNumber(ctx context.Context, number string) (*entities.Order, error)
+
+	// NextOrderNumberSequence returns the next value of the order number sequence named scope, starting at 1.
+	// Values are never handed out twice, but a value taken by a failed create leaves a gap.
+	NextOrderNumberSequence(ctx context.Context, scope string) (uint64, error)
+
 	// Update updates an existing order
 	Update(ctx context.Context, order *entities.Order) (*entities.Order, error)
 
 	// Delete soft deletes an order by ID
 	Delete(ctx context.Context, id uint) error
 
+	// Restore clears the soft delete of an order, it fails with ErrOrderNotFound unless the order is deleted
+	Restore(ctx context.Context, id uint) (*entities.Order, error)
+
+	// ListDeleted retrieves a paginated list of soft deleted orders, most recently deleted first
+	ListDeleted(ctx context.Context, limit, offset int) ([]*entities.Order, error)
+
+	// CountDeleted returns the number of soft deleted orders
+	CountDeleted(ctx context.Context) (int64, error)
+
 	// List retrieves a paginated list of all orders
 	List(ctx context.Context, limit, offset int) ([]*entities.Order, error)
 
@@ -28,6 +88,23 @@ type OrderRepository interface {
 	// GetByStatus retrieves orders by status
 	GetByStatus(ctx context.Context, status entities.OrderStatus, limit, offset int) ([]*entities.Order, error)
 
+	// ListByFilterOrderedByPriority retrieves the orders matching filter, the most urgent first and the oldest
+	// first within a priority, the order in which fulfillment works through them
+	ListByFilterOrderedByPriority(ctx context.Context, filter OrderFilter, limit, offset int) ([]*entities.Order, error)
+
+	// GetByCustomerIDAndStatus retrieves the orders of a customer in a specific status
+	GetByCustomerIDAndStatus(ctx context.Context, customerID uint, status entities.OrderStatus, limit, offset int) ([]*entities.Order, error)
+
+	// ListRecentByItemSet retrieves the orders a customer created at or after since whose items have the
+	// given entities.ItemSetHash, newest first
+	ListRecentByItemSet(ctx context.Context, customerID uint, itemSetHash string, since time.Time) ([]*entities.Order, error)
+
+	// ListByDateRange retrieves orders created in [from, to), a zero from or to leaves that end open
+	ListByDateRange(ctx context.Context, from, to time.Time, limit, offset int) ([]*entities.Order, error)
+
+	// FindExpiredPending retrieves up to limit pending orders whose expiry time is at or before the given time
+	FindExpiredPending(ctx context.Context, before time.Time, limit int) ([]*entities.Order, error)
+
 	// Count returns the total number of orders
 	Count(ctx context.Context) (int64, error)
 
@@ -36,4 +113,38 @@ type OrderRepository interface {
 
 	// CountByStatus returns the total number of orders with a specific status
 	CountByStatus(ctx context.Context, status entities.OrderStatus) (int64, error)
+
+	// CountByFilter returns the number of orders matching filter
+	CountByFilter(ctx context.Context, filter OrderFilter) (int64, error)
+
+	// CountByCustomerIDAndStatus returns the number of orders of a customer in a specific status
+	CountByCustomerIDAndStatus(ctx context.Context, customerID uint, status entities.OrderStatus) (int64, error)
+
+	// WithCustomerLock runs fn in a transaction holding an exclusive lock for the customer.
+	// Repository calls made with the context passed to fn join that transaction.
+	WithCustomerLock(ctx context.Context, customerID uint, fn func(ctx context.Context) error) error
+
+	// ListByFilter retrieves a page of the orders matching filter, newest first, together with the
+	// total number of matches. Both are read from the same snapshot so they cannot drift apart.
+	ListByFilter(ctx context.Context, filter OrderFilter, limit, offset int) ([]*entities.Order, int64, error)
+
+	// StreamByFilter calls fn for every order matching filter in ascending ID order, loading batchSize
+	// orders at a time. Iteration stops at the first error returned by fn, which is returned unchanged,
+	// and with ErrRequestCancelled once ctx is done.
+	StreamByFilter(ctx context.Context, filter OrderFilter, batchSize int, fn func(order *entities.Order) error) error
+
+	// CountItemsByFilter returns the number of order lines matching filter,
+	// counting an order without items as a single line
+	CountItemsByFilter(ctx context.Context, filter OrderFilter) (int64, error)
+
+	// MaxUpdatedAt returns the time of the latest change to the orders matching filter, zero when there is
+	// none. Status and Backordered are ignored because orders move in and out of them, and soft deleted
+	// orders count with their deletion time, so any change to a list of the filter moves the result forward.
+	MaxUpdatedAt(ctx context.Context, filter OrderFilter) (time.Time, error)
+
+	// AggregateByStatus returns order counts and revenue grouped by status
+	AggregateByStatus(ctx context.Context, filter OrderFilter) ([]StatusAggregate, error)
+
+	// AggregateByDay returns order counts and revenue grouped by creation day, for days with orders only
+	AggregateByDay(ctx context.Context, filter OrderFilter) ([]DailyAggregate, error)
 }
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrPaymentDeclined is returned by a PaymentGateway refusing an operation, such as an authorization
+// above the available funds. Other errors mean the gateway could not be reached.
+var ErrPaymentDeclined = errors.New("payment declined")
+
+// PaymentGateway authorizes the amount of orders being confirmed and settles the authorization once the
+// order ships or is cancelled. Capture and Void must succeed for an authorization already captured or
+// voided respectively, so a settlement whose outcome the service failed to store can be repeated.
+type PaymentGateway interface {
+	// Authorize holds amount on the customer's payment method for the order and returns the ID of the authorization
+	Authorize(ctx context.Context, orderID uint, amount float64, currency string) (string, error)
+	// Capture charges amount, at most the authorized amount, of an authorization
+	Capture(ctx context.Context, authorizationID string, amount float64) error
+	// Void releases an authorization without charging it
+	Void(ctx context.Context, authorizationID string) error
+}
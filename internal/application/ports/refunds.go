@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"orders-service/internal/domain/entities"
+)
+
+// RefundRepository persists the refunds of orders
+type RefundRepository interface {
+	// Create stores a new refund and returns it with its ID
+	Create(ctx context.Context, refund *entities.Refund) (*entities.Refund, error)
+
+	// ListByOrderID retrieves the refunds of an order, oldest first
+	ListByOrderID(ctx context.Context, orderID uint) ([]*entities.Refund, error)
+}
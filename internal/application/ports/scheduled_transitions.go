@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"orders-service/internal/domain/entities"
+)
+
+// ScheduledTransitionRepository persists the status changes scheduled for orders
+type ScheduledTransitionRepository interface {
+	// Create stores a new scheduled transition and returns it with its ID
+	Create(ctx context.Context, transition *entities.ScheduledTransition) (*entities.ScheduledTransition, error)
+
+	// Update stores the outcome of a pending scheduled transition. A transition that completed in the
+	// meantime is left unchanged and ErrScheduledTransitionNotPending is returned, so a cancellation
+	// and an execution racing each other cannot both succeed.
+	Update(ctx context.Context, transition *entities.ScheduledTransition) (*entities.ScheduledTransition, error)
+
+	// ListByOrderID retrieves the scheduled transitions of an order, oldest first
+	ListByOrderID(ctx context.Context, orderID uint) ([]*entities.ScheduledTransition, error)
+
+	// FindDue retrieves up to limit pending transitions to execute at or before now, earliest first
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.ScheduledTransition, error)
+}
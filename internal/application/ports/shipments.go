@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"orders-service/internal/domain/entities"
+)
+
+// ShipmentRepository persists the shipments of orders
+type ShipmentRepository interface {
+	// Create stores a new shipment and returns it with its ID
+	Create(ctx context.Context, shipment *entities.Shipment) (*entities.Shipment, error)
+
+	// Update stores the delivery of an existing shipment
+	Update(ctx context.Context, shipment *entities.Shipment) (*entities.Shipment, error)
+
+	// ListByOrderID retrieves the shipments of an order, oldest first
+	ListByOrderID(ctx context.Context, orderID uint) ([]*entities.Shipment, error)
+}
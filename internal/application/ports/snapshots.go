@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"orders-service/internal/domain/entities"
+)
+
+// SnapshotRepository persists the snapshots of orders. Snapshots are never updated or deleted.
+type SnapshotRepository interface {
+	// Create stores a new snapshot and returns it with its ID. The version must not be taken by
+	// another snapshot of the order.
+	Create(ctx context.Context, snapshot *entities.OrderSnapshot) (*entities.OrderSnapshot, error)
+
+	// ListByOrderID retrieves the snapshots of an order, oldest version first
+	ListByOrderID(ctx context.Context, orderID uint) ([]*entities.OrderSnapshot, error)
+
+	// GetByVersion retrieves a snapshot of an order, ErrSnapshotNotFound when the order has no such version
+	GetByVersion(ctx context.Context, orderID uint, version int) (*entities.OrderSnapshot, error)
+}
@@ -0,0 +1,75 @@
+package ports
+
+import (
+	"context"
+	"sync"
+)
+
+// Repositories groups the stores that take part in a unit of work
+type Repositories struct {
+	Orders               OrderRepository
+	Audit                AuditRepository
+	Shipments            ShipmentRepository
+	Refunds              RefundRepository
+	Snapshots            SnapshotRepository
+	ScheduledTransitions ScheduledTransitionRepository
+	OrderJobs            OrderJobRepository
+}
+
+// UnitOfWork runs several repository calls as one atomic change
+type UnitOfWork interface {
+	// Do runs fn in a single transaction, committed when fn returns nil and rolled back otherwise.
+	// Repositories take part in the transaction only when called with the ctx passed to fn.
+	// Calling Do with a ctx that already carries a transaction joins it.
+	// Hooks registered with AfterCommit on the ctx passed to fn run once the transaction committed.
+	Do(ctx context.Context, fn func(ctx context.Context, repos Repositories) error) error
+}
+
+// commitHooksKey carries the CommitHooks of the unit of work running in a context
+type commitHooksKey struct{}
+
+// CommitHooks collects the functions registered with AfterCommit while a unit of work runs
+type CommitHooks struct {
+	mu    sync.Mutex
+	hooks []func()
+}
+
+// WithCommitHooks returns a copy of ctx collecting the AfterCommit hooks of a unit of work starting in it.
+// A ctx already collecting them belongs to an outer unit of work, it is returned with nil hooks and the
+// hooks run when the outer unit of work commits.
+func WithCommitHooks(ctx context.Context) (context.Context, *CommitHooks) {
+	if _, ok := ctx.Value(commitHooksKey{}).(*CommitHooks); ok {
+		return ctx, nil
+	}
+	hooks := &CommitHooks{}
+	return context.WithValue(ctx, commitHooksKey{}, hooks), hooks
+}
+
+// Run calls the collected hooks in the order they were registered, once the unit of work committed.
+// It does nothing on nil hooks.
+func (h *CommitHooks) Run() {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	hooks := h.hooks
+	h.hooks = nil
+	h.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+// AfterCommit runs fn once the unit of work running in ctx commits, fn never runs when it rolls back.
+// Outside a unit of work fn runs right away.
+func AfterCommit(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(commitHooksKey{}).(*CommitHooks)
+	if !ok {
+		fn()
+		return
+	}
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.hooks = append(hooks.hooks, fn)
+}
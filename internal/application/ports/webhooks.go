@@ -0,0 +1,47 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"orders-service/internal/domain/entities"
+)
+
+// WebhookSender delivers payloads to the webhook endpoints of partners
+type WebhookSender interface {
+	// HasWebhook reports whether webhookID names a configured endpoint
+	HasWebhook(webhookID string) bool
+
+	// Send signs payload and posts it to the endpoint of webhookID, retrying failed attempts with backoff
+	// until the retry budget is spent. It returns the number of attempts made, the error is the one of the
+	// last attempt. Each call starts with a fresh budget.
+	Send(ctx context.Context, webhookID, eventType string, payload []byte) (int, error)
+}
+
+// WebhookDeadLetterFilter narrows the dead letters of a webhook. From is inclusive and To exclusive,
+// both compare to the time the delivery was given up. Pending leaves out the replayed dead letters.
+type WebhookDeadLetterFilter struct {
+	From    *time.Time
+	To      *time.Time
+	Pending bool
+}
+
+// WebhookDeadLetterRepository persists the webhook deliveries that failed after their retries
+type WebhookDeadLetterRepository interface {
+	// Create stores a new dead letter and returns it with its ID
+	Create(ctx context.Context, deadLetter *entities.WebhookDeadLetter) (*entities.WebhookDeadLetter, error)
+
+	// GetByID retrieves a dead letter of a webhook, ErrWebhookDeadLetterNotFound when the webhook has none with id
+	GetByID(ctx context.Context, webhookID string, id uint) (*entities.WebhookDeadLetter, error)
+
+	// List retrieves the dead letters of a webhook matching filter, oldest first, with the total that match
+	List(ctx context.Context, webhookID string, filter WebhookDeadLetterFilter, limit, offset int) ([]*entities.WebhookDeadLetter, int64, error)
+
+	// ClaimReplay marks a pending dead letter replayed at the given instant and returns it. A dead letter
+	// replayed or claimed in the meantime is left unchanged and ErrWebhookDeadLetterReplayed is returned,
+	// so concurrent replays deliver a dead letter once.
+	ClaimReplay(ctx context.Context, webhookID string, id uint, replayedAt time.Time) (*entities.WebhookDeadLetter, error)
+
+	// ReleaseReplay stores the outcome of a claimed replay that failed, making the dead letter pending again
+	ReleaseReplay(ctx context.Context, deadLetter *entities.WebhookDeadLetter) (*entities.WebhookDeadLetter, error)
+}
@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+)
+
+// AuditUseCases defines the interface for reading the order audit log
+type AuditUseCases interface {
+	GetOrderAuditLog(ctx context.Context, orderID uint, page, pageSize int) (*dto.AuditLogResponseDTO, error)
+}
+
+// auditUseCasesImpl implements AuditUseCases interface
+type auditUseCasesImpl struct {
+	auditRepo ports.AuditRepository
+	logger    logger.Logger
+}
+
+// NewAuditUseCases creates a new instance of audit use cases
+func NewAuditUseCases(auditRepo ports.AuditRepository, log logger.Logger) AuditUseCases {
+	return &auditUseCasesImpl{
+		auditRepo: auditRepo,
+		logger:    log.With("component", "audit_usecases"),
+	}
+}
+
+// GetOrderAuditLog retrieves the audit entries of an order, oldest first.
+// Deleted orders keep their audit log, so the order itself is not looked up.
+func (uc *auditUseCasesImpl) GetOrderAuditLog(ctx context.Context, orderID uint, page, pageSize int) (*dto.AuditLogResponseDTO, error) {
+	uc.log(ctx).Info("GetOrderAuditLog use case called", "order_id", orderID, "page", page, "page_size", pageSize)
+
+	page, pageSize, err := validatePagination(page, pageSize, dto.MaxPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.auditRepo.ListByOrderID(ctx, orderID, pageSize, page*pageSize)
+	if err != nil {
+		uc.log(ctx).Error("Failed to list audit entries", "order_id", orderID, "error", err)
+		return nil, repositoryError(err, domainErrors.ErrFailedToGetAuditLog)
+	}
+
+	total, err := uc.auditRepo.CountByOrderID(ctx, orderID)
+	if err != nil {
+		uc.log(ctx).Error("Failed to count audit entries", "order_id", orderID, "error", err)
+		return nil, repositoryError(err, domainErrors.ErrFailedToGetAuditLog)
+	}
+
+	totalPages := dto.TotalPages(total, pageSize)
+	response := &dto.AuditLogResponseDTO{
+		Entries:     make([]*dto.AuditEntryResponseDTO, 0, len(entries)),
+		Total:       total,
+		Page:        page,
+		PageSize:    pageSize,
+		TotalPages:  totalPages,
+		HasNext:     page+1 < totalPages,
+		HasPrevious: page > 0,
+	}
+	for _, entry := range entries {
+		response.Entries = append(response.Entries, dto.AuditEntryToResponseDTO(entry))
+	}
+
+	uc.log(ctx).Info("GetOrderAuditLog success", "order_id", orderID, "count", len(entries))
+	return response, nil
+}
+
+// log returns the request logger carried by ctx, falling back to the logger of the use cases
+func (uc *auditUseCasesImpl) log(ctx context.Context) logger.Logger {
+	return logger.FromContext(ctx, uc.logger)
+}
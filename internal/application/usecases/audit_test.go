@@ -0,0 +1,141 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAuditRepository implements the AuditRepository interface for testing
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Create(ctx context.Context, entry *entities.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) ListByOrderID(ctx context.Context, orderID uint, limit, offset int) ([]*entities.AuditEntry, error) {
+	args := m.Called(ctx, orderID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.AuditEntry), args.Error(1)
+}
+
+func (m *MockAuditRepository) CountByOrderID(ctx context.Context, orderID uint) (int64, error) {
+	args := m.Called(ctx, orderID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// recordingAuditor keeps every recorded mutation in memory
+type recordingAuditor struct {
+	actions []entities.AuditAction
+	before  []*entities.Order
+	after   []*entities.Order
+}
+
+func (a *recordingAuditor) Record(_ context.Context, action entities.AuditAction, _ uint, before, after *entities.Order) {
+	a.actions = append(a.actions, action)
+	a.before = append(a.before, before)
+	a.after = append(a.after, after)
+}
+
+func TestAuditUseCases_GetOrderAuditLog_Success(t *testing.T) {
+	// Given
+	mockRepo := new(MockAuditRepository)
+	useCases := NewAuditUseCases(mockRepo, logger.New("test"))
+	ctx := context.Background()
+
+	entries := []*entities.AuditEntry{
+		{ID: 1, OrderID: 7, Actor: "backoffice", Action: entities.AuditActionOrderCreated, After: json.RawMessage(`{"id":7}`)},
+	}
+	mockRepo.On("ListByOrderID", ctx, uint(7), 20, 20).Return(entries, nil)
+	mockRepo.On("CountByOrderID", ctx, uint(7)).Return(int64(21), nil)
+
+	// When
+	response, err := useCases.GetOrderAuditLog(ctx, 7, 1, 20)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, int64(21), response.Total)
+	assert.Equal(t, 1, response.Page)
+	require.Len(t, response.Entries, 1)
+	assert.Equal(t, "backoffice", response.Entries[0].Actor)
+	assert.JSONEq(t, "null", string(response.Entries[0].Before))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuditUseCases_GetOrderAuditLog_RepositoryError(t *testing.T) {
+	// Given
+	mockRepo := new(MockAuditRepository)
+	useCases := NewAuditUseCases(mockRepo, logger.New("test"))
+	ctx := context.Background()
+
+	mockRepo.On("ListByOrderID", ctx, uint(7), 10, 0).Return(nil, assert.AnError)
+
+	// When
+	response, err := useCases.GetOrderAuditLog(ctx, 7, 0, 10)
+
+	// Then
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToGetAuditLog)
+}
+
+func TestOrderUseCases_RecordsAuditEntries(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	auditor := &recordingAuditor{}
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, auditor, logger.New("test"), OrderUseCasesConfig{})
+	ctx := context.Background()
+
+	order, _ := entities.NewOrder(123)
+	order.ID = 1
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 1, 10.0))
+
+	// The unit of work hands the repository a context of its own
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(order), nil)
+	mockRepo.On("Delete", mock.Anything, uint(1)).Return(nil)
+
+	// When
+	_, err := useCases.ConfirmOrder(ctx, 1, nil)
+	require.NoError(t, err)
+	err = useCases.DeleteOrder(ctx, 1)
+	require.NoError(t, err)
+
+	// Then
+	assert.Equal(t, []entities.AuditAction{entities.AuditActionStatusChanged, entities.AuditActionOrderDeleted}, auditor.actions)
+	assert.Equal(t, entities.OrderStatusPending, auditor.before[0].Status)
+	assert.Equal(t, entities.OrderStatusConfirmed, auditor.after[0].Status)
+	assert.NotSame(t, auditor.before[0], auditor.after[0])
+	assert.Nil(t, auditor.after[1])
+}
+
+func TestOrderUseCases_FailedMutationIsNotAudited(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	auditor := &recordingAuditor{}
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, auditor, logger.New("test"), OrderUseCasesConfig{})
+	ctx := context.Background()
+
+	order, _ := entities.NewOrder(123)
+	order.ID = 1
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+
+	// When
+	_, err := useCases.ConfirmOrder(ctx, 1, nil)
+
+	// Then
+	assert.Error(t, err)
+	assert.Empty(t, auditor.actions)
+}
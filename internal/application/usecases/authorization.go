@@ -0,0 +1,77 @@
+package usecases
+
+import (
+	"context"
+
+	"orders-service/internal/application/audit"
+	"orders-service/internal/application/auth"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+)
+
+// authorizeCustomer rejects access to the orders of customerID by a principal bound to another customer.
+// The rejection is ErrOrderNotFound, a distinct error would let callers enumerate order IDs.
+func (uc *orderUseCasesImpl) authorizeCustomer(ctx context.Context, customerID uint) error {
+	return authorizeCustomer(ctx, uc.log(ctx), customerID)
+}
+
+func authorizeCustomer(ctx context.Context, log logger.Logger, customerID uint) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.CanAccessCustomer(customerID) {
+		return nil
+	}
+
+	log.Warn("Denied access to another customer's order",
+		"principal", principal.Name,
+		"principal_customer_id", principal.CustomerID,
+		"customer_id", customerID)
+	return domainErrors.ErrOrderNotFound
+}
+
+// boundCustomer returns the customer a principal bound to a customer is limited to, zero for admins and
+// principals that may see every customer
+func boundCustomer(ctx context.Context) uint {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.IsAdmin() {
+		return 0
+	}
+	return principal.CustomerID
+}
+
+// customerScope narrows a query for the orders of customerID, zero for every customer, to what the principal
+// may see. An unscoped query of a bound principal is limited to its customer, a query for another customer
+// is not visible at all and answered as if that customer had no orders.
+func (uc *orderUseCasesImpl) customerScope(ctx context.Context, customerID uint) (uint, bool) {
+	if customerID == 0 {
+		return boundCustomer(ctx), true
+	}
+	return customerID, uc.authorizeCustomer(ctx, customerID) == nil
+}
+
+// authorizeCreate rejects orders a principal bound to a customer creates for another customer
+func (uc *orderUseCasesImpl) authorizeCreate(ctx context.Context, customerID uint) error {
+	bound := boundCustomer(ctx)
+	if bound == 0 || bound == customerID {
+		return nil
+	}
+
+	uc.log(ctx).Warn("Denied creating an order for another customer",
+		"principal", principalName(ctx),
+		"principal_customer_id", bound,
+		"customer_id", customerID)
+	return domainErrors.ErrCustomerNotAllowed
+}
+
+// isAdmin reports whether the request runs as a principal holding the admin scope
+func isAdmin(ctx context.Context) bool {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	return ok && principal.IsAdmin()
+}
+
+// principalName names the caller, or the system actor of the audit log when there is no authenticated caller
+func principalName(ctx context.Context) string {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok && principal.Name != "" {
+		return principal.Name
+	}
+	return audit.SystemActor
+}
@@ -0,0 +1,315 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/auth"
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func customerContext(customerID uint, scopes ...auth.Scope) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Name: "gateway", Scopes: scopes, CustomerID: customerID})
+}
+
+func TestOrderUseCases_GetOrder_OtherCustomerIsNotFound(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := customerContext(2, auth.ScopeOrdersRead)
+
+	order, _ := entities.NewOrder(1)
+	order.ID = 10
+	mockRepo.On("GetByID", ctx, uint(10)).Return(order, nil)
+
+	// When
+	result, err := useCases.GetOrder(ctx, 10)
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+}
+
+func TestOrderUseCases_GetOrder_AdminSeesEveryCustomer(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := customerContext(2, auth.ScopeOrdersAdmin)
+
+	order, _ := entities.NewOrder(1)
+	order.ID = 10
+	mockRepo.On("GetByID", ctx, uint(10)).Return(order, nil)
+
+	// When
+	result, err := useCases.GetOrder(ctx, 10)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, uint(10), result.ID)
+}
+
+func TestOrderUseCases_GetOrder_DeletedOrderIsGoneForAdmins(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := customerContext(0, auth.ScopeOrdersAdmin)
+
+	deletedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	order, _ := entities.NewOrder(1)
+	order.ID = 10
+	order.DeletedAt = &deletedAt
+	mockRepo.On("GetByID", ctx, uint(10)).Return(nil, domainErrors.ErrOrderNotFound)
+	mockRepo.On("GetByIDIncludingDeleted", ctx, uint(10)).Return(order, nil)
+
+	// When
+	result, err := useCases.GetOrder(ctx, 10)
+
+	// Then
+	assert.Nil(t, result)
+	require.ErrorIs(t, err, domainErrors.ErrOrderDeleted)
+	var domainErr *domainErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "2024-03-01T12:00:00Z", domainErr.Details["deleted_at"])
+}
+
+func TestOrderUseCases_GetOrder_UnknownOrderIsNotFoundForAdmins(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := customerContext(0, auth.ScopeOrdersAdmin)
+
+	mockRepo.On("GetByID", ctx, uint(10)).Return(nil, domainErrors.ErrOrderNotFound)
+	mockRepo.On("GetByIDIncludingDeleted", ctx, uint(10)).Return(nil, domainErrors.ErrOrderNotFound)
+
+	// When
+	_, err := useCases.GetOrder(ctx, 10)
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+}
+
+func TestOrderUseCases_GetOrder_DeletedOrderIsNotFoundForCustomers(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := customerContext(1, auth.ScopeOrdersRead)
+
+	mockRepo.On("GetByID", ctx, uint(10)).Return(nil, domainErrors.ErrOrderNotFound)
+
+	// When
+	_, err := useCases.GetOrder(ctx, 10)
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+	mockRepo.AssertNotCalled(t, "GetByIDIncludingDeleted", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_AddItemToOrder_OtherCustomerIsNotUpdated(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := customerContext(2, auth.ScopeOrdersWrite)
+
+	order, _ := entities.NewOrder(1)
+	order.ID = 10
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(10)).Return(order, nil)
+
+	// When
+	result, err := useCases.AddItemToOrder(ctx, 10, &dto.AddOrderItemRequestDTO{
+		ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 1, UnitPrice: 10,
+	})
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_GetOrderByExternalReference_OtherCustomerIsNotFound(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := customerContext(2, auth.ScopeOrdersRead)
+
+	// When
+	result, err := useCases.GetOrderByExternalReference(ctx, 1, "PO-1")
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+	mockRepo.AssertNotCalled(t, "GetByExternalReference", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_GetCustomerOrders_OtherCustomerIsEmpty(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := customerContext(2, auth.ScopeOrdersRead)
+
+	// When
+	result, err := useCases.GetCustomerOrders(ctx, 1, 0, 10)
+
+	// Then
+	require.NoError(t, err)
+	assert.Empty(t, result.Orders)
+	assert.Equal(t, int64(0), result.Total)
+	mockRepo.AssertNotCalled(t, "ListByFilter", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_CountOrders_OtherCustomerIsZero(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := customerContext(2, auth.ScopeOrdersRead)
+
+	// When
+	count, err := useCases.CountOrders(ctx, 1, "")
+
+	// Then
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	mockRepo.AssertNotCalled(t, "CountByCustomerID", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_Lists_BoundCustomerSeesOwnOrders(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter ports.OrderFilter
+		list   func(useCases OrderUseCases, ctx context.Context) (*dto.OrderListResponseDTO, error)
+	}{
+		{"every order", ports.OrderFilter{CustomerID: 2}, func(useCases OrderUseCases, ctx context.Context) (*dto.OrderListResponseDTO, error) {
+			return useCases.ListOrders(ctx, 0, 10)
+		}},
+		{"by status", ports.OrderFilter{CustomerID: 2, Status: entities.OrderStatusPending}, func(useCases OrderUseCases, ctx context.Context) (*dto.OrderListResponseDTO, error) {
+			return useCases.GetOrdersByStatus(ctx, entities.OrderStatusPending, 0, 10)
+		}},
+		{"by date range", ports.OrderFilter{CustomerID: 2}, func(useCases OrderUseCases, ctx context.Context) (*dto.OrderListResponseDTO, error) {
+			return useCases.ListOrdersByDateRange(ctx, nil, nil, 0, 10)
+		}},
+		{"streamed", ports.OrderFilter{CustomerID: 2}, func(useCases OrderUseCases, ctx context.Context) (*dto.OrderListResponseDTO, error) {
+			return useCases.StreamOrders(ctx, nil, nil, 0, 10, func(*dto.OrderResponseDTO) error { return nil })
+		}},
+		{"backordered", ports.OrderFilter{CustomerID: 2, Backordered: true}, func(useCases OrderUseCases, ctx context.Context) (*dto.OrderListResponseDTO, error) {
+			return useCases.ListBackorderedOrders(ctx, 0, 10)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo := setupTestOrderUseCases()
+			ctx := customerContext(2, auth.ScopeOrdersRead)
+			mockRepo.On("ListByFilter", ctx, tt.filter, 10, 0).Return([]*entities.Order{}, int64(0), nil)
+
+			// When
+			_, err := tt.list(useCases, ctx)
+
+			// Then
+			require.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderUseCases_ListOrders_AdminSeesEveryCustomer(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := customerContext(2, auth.ScopeOrdersAdmin)
+	mockRepo.On("ListByFilter", ctx, ports.OrderFilter{}, 10, 0).Return([]*entities.Order{}, int64(0), nil)
+
+	// When
+	_, err := useCases.ListOrders(ctx, 0, 10)
+
+	// Then
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_GetOrderQueue_BoundCustomerSeesOwnOrders(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := customerContext(2, auth.ScopeOrdersRead)
+	filter := ports.OrderFilter{CustomerID: 2, Status: entities.OrderStatusConfirmed}
+	mockRepo.On("ListByFilterOrderedByPriority", ctx, filter, 10, 0).Return([]*entities.Order{}, nil)
+	mockRepo.On("CountByFilter", ctx, filter).Return(int64(0), nil)
+
+	// When
+	_, err := useCases.GetOrderQueue(ctx, entities.OrderStatusConfirmed, 0, 10)
+
+	// Then
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_Counts_BoundCustomerCountsOwnOrders(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := customerContext(2, auth.ScopeOrdersRead)
+	mockRepo.On("CountByCustomerID", ctx, uint(2)).Return(int64(3), nil)
+	mockRepo.On("CountByFilter", ctx, ports.OrderFilter{CustomerID: 2}).Return(int64(4), nil)
+
+	// When
+	count, err := useCases.CountOrders(ctx, 0, "")
+	require.NoError(t, err)
+	byDateRange, err := useCases.CountOrdersByDateRange(ctx, nil, nil)
+	require.NoError(t, err)
+
+	// Then
+	assert.Equal(t, int64(3), count)
+	assert.Equal(t, int64(4), byDateRange)
+	mockRepo.AssertNotCalled(t, "Count", mock.Anything)
+}
+
+func TestOrderUseCases_GetOrderStats_BoundCustomer(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+
+	t.Run("own orders", func(t *testing.T) {
+		// Given
+		useCases, mockRepo := setupTestOrderUseCases()
+		ctx := customerContext(2, auth.ScopeOrdersRead)
+		filter := ports.OrderFilter{CustomerID: 2, CreatedFrom: from, CreatedBefore: to}
+		mockRepo.On("AggregateByStatus", ctx, filter).Return([]ports.StatusAggregate{}, nil)
+		mockRepo.On("AggregateByDay", ctx, filter).Return([]ports.DailyAggregate{}, nil)
+
+		// When
+		_, err := useCases.GetOrderStats(ctx, &dto.OrderFilterDTO{From: &from, To: &to})
+
+		// Then
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("other customer", func(t *testing.T) {
+		// Given
+		useCases, mockRepo := setupTestOrderUseCases()
+		ctx := customerContext(2, auth.ScopeOrdersRead)
+
+		// When
+		stats, err := useCases.GetOrderStats(ctx, &dto.OrderFilterDTO{CustomerID: 1, From: &from, To: &to})
+
+		// Then
+		require.NoError(t, err)
+		assert.Zero(t, stats.TotalOrders)
+		assert.Len(t, stats.Daily, 2)
+		mockRepo.AssertNotCalled(t, "AggregateByStatus", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderUseCases_CreateOrder_OtherCustomerIsRejected(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := customerContext(2, auth.ScopeOrdersWrite)
+	request := &dto.CreateOrderRequestDTO{CustomerID: 1, Items: []dto.CreateOrderItemDTO{
+		{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 1, UnitPrice: 10},
+	}}
+
+	// When
+	result, err := useCases.CreateOrder(ctx, request)
+	_, jobErr := useCases.SubmitOrderJob(ctx, request)
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrCustomerNotAllowed)
+	assert.ErrorIs(t, jobErr, domainErrors.ErrCustomerNotAllowed)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
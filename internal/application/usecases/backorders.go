@@ -0,0 +1,133 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
+)
+
+// InventoryOpenCircuitPolicy decides how orders are confirmed while the circuit breaker of the inventory
+// is open
+type InventoryOpenCircuitPolicy string
+
+const (
+	// InventoryOpenCircuitFailFast fails the confirmation with ErrDependencyUnavailable
+	InventoryOpenCircuitFailFast InventoryOpenCircuitPolicy = "fail_fast"
+	// InventoryOpenCircuitSkip confirms the order without a reservation, see entities.Order.SkipReservation
+	InventoryOpenCircuitSkip InventoryOpenCircuitPolicy = "skip"
+)
+
+// reserveItems reserves stock for the items of an order being confirmed and records the outcome on its
+// lines, every line is reserved in full when no inventory is configured. The inventory is asked while the
+// order is locked, a failure is reported as ErrInventoryUnavailable and fails the confirmation. An open
+// circuit breaker is handled by the InventoryOpenCircuit policy.
+func (uc *orderUseCasesImpl) reserveItems(ctx context.Context, order *entities.Order) error {
+	if uc.config.Inventory == nil {
+		return order.ReserveAllItems()
+	}
+
+	reserved, err := uc.config.Inventory.Reserve(ctx, order)
+	if err != nil {
+		if ctx.Err() != nil {
+			return domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+		}
+		if errors.Is(err, ports.ErrInventoryCircuitOpen) {
+			return uc.skipReservation(ctx, order, err)
+		}
+		uc.log(ctx).Error("Failed to reserve order items", "order_id", order.ID, "error", err)
+		return domainErrors.WrapDomainError(domainErrors.ErrInventoryUnavailable, err)
+	}
+	if err := order.ReserveItems(reserved); err != nil {
+		uc.log(ctx).Error("Inventory returned an invalid reservation", "order_id", order.ID, "error", err)
+		return domainErrors.WrapDomainError(domainErrors.ErrInventoryUnavailable, err)
+	}
+
+	if order.HasBackorderedItems() {
+		uc.log(ctx).Warn("Order has backordered items", "order_id", order.ID)
+	}
+	return nil
+}
+
+// skipReservation applies the InventoryOpenCircuit policy to an order the inventory was not asked about
+func (uc *orderUseCasesImpl) skipReservation(ctx context.Context, order *entities.Order, cause error) error {
+	if uc.config.InventoryOpenCircuit != InventoryOpenCircuitSkip {
+		uc.log(ctx).Error("Inventory circuit breaker is open", "order_id", order.ID, "error", cause)
+		return domainErrors.WrapDomainError(domainErrors.ErrDependencyUnavailable, cause).
+			WithDetails(map[string]interface{}{"dependency": "inventory"})
+	}
+
+	if err := order.SkipReservation(); err != nil {
+		return domainErrors.WrapDomainError(domainErrors.ErrInventoryUnavailable, err)
+	}
+	uc.log(ctx).Warn("Order items left unreserved, inventory circuit breaker is open", "order_id", order.ID)
+	return nil
+}
+
+// FulfillOrderItem marks the backordered line of productID fully reserved once its stock arrived
+func (uc *orderUseCasesImpl) FulfillOrderItem(ctx context.Context, orderID, productID uint) (*dto.OrderResponseDTO, error) {
+	uc.log(ctx).Info("FulfillOrderItem use case called", "order_id", orderID, "product_id", productID)
+
+	// Fulfill the line of the locked order and store it
+	var fulfilled *entities.OrderItem
+	before, updatedOrder, err := uc.modifyOrder(ctx, orderID, func(order *entities.Order) error {
+		var err error
+		fulfilled, err = order.FulfillItem(productID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to fulfill order item", "order_id", orderID, "product_id", productID, "error", err)
+			return fulfillmentError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.audit(ctx, entities.AuditActionItemFulfilled, orderID, before, updatedOrder)
+	uc.publish(ctx, events.NewItemFulfilledEvent(updatedOrder, fulfilled, time.Now()))
+
+	uc.log(ctx).Info("FulfillOrderItem success", "order_id", orderID, "product_id", productID)
+	return dto.OrderToResponseDTO(updatedOrder), nil
+}
+
+// ListBackorderedOrders retrieves a paginated list of the orders with at least one backordered line
+func (uc *orderUseCasesImpl) ListBackorderedOrders(ctx context.Context, page, pageSize int) (*dto.OrderListResponseDTO, error) {
+	uc.log(ctx).Info("ListBackorderedOrders use case called", "page", page, "page_size", pageSize)
+
+	// Validate pagination
+	page, pageSize, err := validatePagination(page, pageSize, uc.maxPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the page and the total number of matches in one consistent read
+	filter := ports.OrderFilter{CustomerID: boundCustomer(ctx), Backordered: true}
+	orders, total, err := uc.orderRepo.ListByFilter(ctx, filter, pageSize, page*pageSize)
+	if err != nil {
+		uc.log(ctx).Error("Failed to list backordered orders", "error", err)
+		return nil, repositoryError(err, domainErrors.ErrFailedToListOrders)
+	}
+
+	uc.log(ctx).Info("ListBackorderedOrders success", "count", len(orders))
+	return dto.NewOrderListResponseDTO(orders, total, page, pageSize), nil
+}
+
+// fulfillmentError converts a fulfillment the order rejected into the matching domain error.
+// Other errors are returned unchanged.
+func fulfillmentError(err error) error {
+	switch {
+	case errors.Is(err, entities.ErrItemNotInOrder):
+		return domainErrors.ErrOrderItemNotFound
+	case errors.Is(err, entities.ErrItemNotBackordered):
+		return domainErrors.ErrItemNotBackordered
+	case errors.Is(err, entities.ErrFulfillmentNotAllowed):
+		return domainErrors.ErrFulfillmentNotAllowed.WithDetails(map[string]interface{}{"reason": err.Error()})
+	default:
+		return err
+	}
+}
@@ -0,0 +1,248 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubInventory answers every reservation with reserved or err and records the orders it was asked about
+type stubInventory struct {
+	reserved []int
+	err      error
+	orders   []*entities.Order
+}
+
+func (i *stubInventory) Reserve(_ context.Context, order *entities.Order) ([]int, error) {
+	i.orders = append(i.orders, order)
+	return i.reserved, i.err
+}
+
+// pendingTestOrder returns pending order 1 of 2 units of product 1 at 10 and 1 unit of product 2 at 5
+func pendingTestOrder(t *testing.T) *entities.Order {
+	t.Helper()
+	order, _ := entities.NewOrder(123)
+	order.ID = 1
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 2, 10.0))
+	require.NoError(t, order.AddItem(2, "SKU-002", "Product 2", 1, 5.0))
+	return order
+}
+
+func TestOrderUseCases_ConfirmOrder_ReservesItems(t *testing.T) {
+	tests := []struct {
+		name        string
+		inventory   ports.Inventory
+		reserved    []int
+		backordered []bool
+	}{
+		{name: "no inventory", reserved: []int{2, 1}, backordered: []bool{false, false}},
+		{name: "partial stock", inventory: &stubInventory{reserved: []int{1, 1}}, reserved: []int{1, 1}, backordered: []bool{true, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			mockRepo := new(MockOrderRepository)
+			config := DefaultOrderUseCasesConfig()
+			config.Inventory = tt.inventory
+			useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config)
+			ctx := context.Background()
+
+			existingOrder := pendingTestOrder(t)
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+			mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(existingOrder), nil)
+
+			// When
+			result, err := useCases.ConfirmOrder(ctx, 1, nil)
+
+			// Then
+			require.NoError(t, err)
+			require.Len(t, result.Items, 2)
+			for i, item := range result.Items {
+				assert.Equal(t, tt.reserved[i], item.ReservedQuantity)
+				assert.Equal(t, tt.backordered[i], item.Backordered)
+			}
+		})
+	}
+}
+
+func TestOrderUseCases_ConfirmOrder_InventoryUnavailable(t *testing.T) {
+	tests := []struct {
+		name      string
+		inventory *stubInventory
+	}{
+		{name: "inventory error", inventory: &stubInventory{err: assert.AnError}},
+		{name: "invalid reservation", inventory: &stubInventory{reserved: []int{3, 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			mockRepo := new(MockOrderRepository)
+			config := DefaultOrderUseCasesConfig()
+			config.Inventory = tt.inventory
+			useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config)
+			ctx := context.Background()
+
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(pendingTestOrder(t), nil)
+
+			// When
+			result, err := useCases.ConfirmOrder(ctx, 1, nil)
+
+			// Then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, domainErrors.ErrInventoryUnavailable)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCases_ConfirmOrder_InventoryCircuitOpen(t *testing.T) {
+	circuitOpen := &stubInventory{err: fmt.Errorf("request reservation: %w", ports.ErrInventoryCircuitOpen)}
+
+	t.Run("fail fast", func(t *testing.T) {
+		// Given
+		mockRepo := new(MockOrderRepository)
+		config := DefaultOrderUseCasesConfig()
+		config.Inventory = circuitOpen
+		useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config)
+
+		mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(pendingTestOrder(t), nil)
+
+		// When
+		result, err := useCases.ConfirmOrder(context.Background(), 1, nil)
+
+		// Then
+		assert.Nil(t, result)
+		var domainErr *domainErrors.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, domainErrors.ErrDependencyUnavailable.Code, domainErr.Code)
+		assert.Equal(t, "inventory", domainErr.Details["dependency"])
+		assert.Equal(t, http.StatusServiceUnavailable, domainErrors.HTTPStatus(domainErr.Code))
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		// Given
+		mockRepo := new(MockOrderRepository)
+		config := DefaultOrderUseCasesConfig()
+		config.Inventory = circuitOpen
+		config.InventoryOpenCircuit = InventoryOpenCircuitSkip
+		useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config)
+
+		existingOrder := pendingTestOrder(t)
+		mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(existingOrder), nil)
+
+		// When
+		result, err := useCases.ConfirmOrder(context.Background(), 1, nil)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, entities.OrderStatusConfirmed, result.Status)
+		assert.True(t, result.ReservationSkipped)
+		for _, item := range result.Items {
+			assert.Zero(t, item.ReservedQuantity)
+			assert.True(t, item.Backordered)
+		}
+	})
+}
+
+func TestOrderUseCases_FulfillOrderItem_Success(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	publisher := &recordingPublisher{}
+	auditor := &recordingAuditor{}
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, publisher, auditor, logger.New("test"), DefaultOrderUseCasesConfig())
+	ctx := context.Background()
+
+	existingOrder := pendingTestOrder(t)
+	require.NoError(t, existingOrder.ConfirmOrder())
+	require.NoError(t, existingOrder.ReserveItems([]int{0, 1}))
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return !order.HasBackorderedItems()
+	})).Return(storeInto(existingOrder), nil)
+
+	// When
+	result, err := useCases.FulfillOrderItem(ctx, 1, 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Items[0].ReservedQuantity)
+	assert.False(t, result.Items[0].Backordered)
+
+	assert.Equal(t, []entities.AuditAction{entities.AuditActionItemFulfilled}, auditor.actions)
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, events.OrderItemFulfilled, publisher.events[0].Type)
+	assert.Equal(t, uint(1), publisher.events[0].ProductID)
+	assert.Equal(t, 2, publisher.events[0].Quantity)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_FulfillOrderItem_Rejected(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    entities.OrderStatus
+		productID uint
+		expected  error
+	}{
+		{"pending order", entities.OrderStatusPending, 1, domainErrors.ErrFulfillmentNotAllowed},
+		{"unknown product", entities.OrderStatusConfirmed, 9, domainErrors.ErrOrderItemNotFound},
+		{"line not backordered", entities.OrderStatusConfirmed, 2, domainErrors.ErrItemNotBackordered},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo := setupTestOrderUseCases()
+			ctx := context.Background()
+
+			existingOrder := pendingTestOrder(t)
+			require.NoError(t, existingOrder.ReserveItems([]int{0, 1}))
+			existingOrder.Status = tt.status
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+			// When
+			result, err := useCases.FulfillOrderItem(ctx, 1, tt.productID)
+
+			// Then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.expected)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCases_ListBackorderedOrders(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	backordered := pendingTestOrder(t)
+	require.NoError(t, backordered.ConfirmOrder())
+	require.NoError(t, backordered.ReserveItems([]int{0, 1}))
+	mockRepo.On("ListByFilter", ctx, ports.OrderFilter{Backordered: true}, 10, 10).
+		Return([]*entities.Order{backordered}, int64(11), nil)
+
+	// When
+	result, err := useCases.ListBackorderedOrders(ctx, 1, 10)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, result.Orders, 1)
+	assert.True(t, result.Orders[0].Items[0].Backordered)
+	assert.Equal(t, int64(11), result.Total)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,109 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupDuplicateCheckUseCases builds use cases rejecting duplicate orders within two minutes and no pending order limit
+func setupDuplicateCheckUseCases() (OrderUseCases, *MockOrderRepository) {
+	mockRepo := new(MockOrderRepository)
+	config := DefaultOrderUseCasesConfig()
+	config.MaxPendingOrdersPerCustomer = 0
+	config.DuplicateOrderWindow = 2 * time.Minute
+	return NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config), mockRepo
+}
+
+func duplicateCheckRequest() *dto.CreateOrderRequestDTO {
+	return &dto.CreateOrderRequestDTO{
+		CustomerID: 123,
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 10},
+			{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 5},
+		},
+	}
+}
+
+func TestOrderUseCases_CreateOrder_PossibleDuplicate(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupDuplicateCheckUseCases()
+	ctx := context.Background()
+
+	existing := pendingTestOrder(t)
+	existing.ID = 7
+	mockRepo.On("NextOrderNumberSequence", mock.Anything, mock.Anything).Return(uint64(1), nil)
+	mockRepo.On("WithCustomerLock", mock.Anything, uint(123)).Return(nil)
+	mockRepo.On("ListRecentByItemSet", mock.Anything, uint(123), existing.ItemSetHash(), mock.MatchedBy(func(since time.Time) bool {
+		return time.Since(since) >= 2*time.Minute && time.Since(since) < 3*time.Minute
+	})).Return([]*entities.Order{existing}, nil)
+
+	// When
+	result, err := useCases.CreateOrder(ctx, duplicateCheckRequest())
+
+	// Then
+	assert.Nil(t, result)
+	require.ErrorIs(t, err, domainErrors.ErrPossibleDuplicateOrder)
+
+	var domainErr *domainErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, uint(7), domainErr.Details["existing_order_id"])
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_CreateOrder_DuplicateCheckPasses(t *testing.T) {
+	cancelled := pendingTestOrder(t)
+	require.NoError(t, cancelled.CancelOrder())
+
+	tests := []struct {
+		name   string
+		modify func(request *dto.CreateOrderRequestDTO)
+		recent []*entities.Order
+		check  bool
+	}{
+		{name: "no recent order", recent: []*entities.Order{}, check: true},
+		{name: "recent order cancelled", recent: []*entities.Order{cancelled}, check: true},
+		{name: "duplicate allowed", modify: func(request *dto.CreateOrderRequestDTO) { request.AllowDuplicate = true }},
+		{name: "no items", modify: func(request *dto.CreateOrderRequestDTO) { request.Items = nil }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo := setupDuplicateCheckUseCases()
+			ctx := context.Background()
+			request := duplicateCheckRequest()
+			if tt.modify != nil {
+				tt.modify(request)
+			}
+
+			mockRepo.On("NextOrderNumberSequence", mock.Anything, mock.Anything).Return(uint64(1), nil)
+			if tt.check {
+				mockRepo.On("WithCustomerLock", mock.Anything, uint(123)).Return(nil)
+				mockRepo.On("ListRecentByItemSet", mock.Anything, uint(123), mock.Anything, mock.Anything).Return(tt.recent, nil)
+			}
+			created := pendingTestOrder(t)
+			created.ID = 8
+			mockRepo.On("Create", mock.Anything, mock.Anything).Return(created, nil)
+
+			// When
+			result, err := useCases.CreateOrder(ctx, request)
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, uint(8), result.ID)
+			if !tt.check {
+				mockRepo.AssertNotCalled(t, "ListRecentByItemSet", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
+)
+
+// AmendOrder replaces the items of a confirmed order, the only way they change after confirmation.
+// The amendment is recorded on the order with its reason and the lines it changed. The units it adds
+// are reserved as on confirmation, an inventory failure fails the amendment.
+func (uc *orderUseCasesImpl) AmendOrder(ctx context.Context, orderID uint, request *dto.AmendOrderRequestDTO) (*dto.OrderResponseDTO, error) {
+	uc.log(ctx).Info("AmendOrder use case called", "order_id", orderID, "item_count", len(request.Items))
+
+	// Amend the locked order and store it along with its snapshot
+	var amendment *entities.OrderAmendment
+	before, updatedOrder, err := uc.modifyOrderWithSnapshot(ctx, orderID, entities.SnapshotReasonAmended, events.OrderAmended, func(order *entities.Order) error {
+		order.Limits = uc.config.OrderLimits
+		var err error
+		amendment, err = order.Amend(request.Reason, request.ToItemInputs())
+		if err != nil {
+			uc.log(ctx).Error("Failed to amend order", "order_id", orderID, "error", err)
+			err = amendmentError(orderLimitError(err))
+			var domainErr *domainErrors.DomainError
+			if !errors.As(err, &domainErr) {
+				err = domainErrors.NewOrderItemValidationError("items", err.Error())
+			}
+			return err
+		}
+		return uc.reserveAddedItems(ctx, order, amendment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.audit(ctx, entities.AuditActionOrderAmended, orderID, before, updatedOrder)
+	uc.publish(ctx, events.NewOrderEvent(events.OrderAmended, updatedOrder, time.Now()))
+
+	uc.log(ctx).Info("AmendOrder success",
+		"order_id", orderID,
+		"amendment", amendment.Number,
+		"changed_lines", len(amendment.Changes))
+	return dto.OrderToResponseDTO(updatedOrder), nil
+}
+
+// reserveAddedItems reserves the units an amendment added to the order, see reserveItems
+func (uc *orderUseCasesImpl) reserveAddedItems(ctx context.Context, order *entities.Order, amendment *entities.OrderAmendment) error {
+	added := order.AddedItems(amendment)
+	if len(added.Items) == 0 {
+		return nil
+	}
+	if err := uc.reserveItems(ctx, added); err != nil {
+		return err
+	}
+	if err := order.ReserveAddedItems(added); err != nil {
+		uc.log(ctx).Error("Inventory returned an invalid reservation", "order_id", order.ID, "error", err)
+		return domainErrors.WrapDomainError(domainErrors.ErrInventoryUnavailable, err)
+	}
+	return nil
+}
+
+// amendmentError converts an item change a confirmed order rejected into the matching domain error.
+// Other errors are returned unchanged.
+func amendmentError(err error) error {
+	switch {
+	case errors.Is(err, entities.ErrAmendmentRequired):
+		return domainErrors.ErrOrderRequiresAmendment
+	case errors.Is(err, entities.ErrAmendmentNotAllowed):
+		return domainErrors.ErrAmendmentNotAllowed
+	case errors.Is(err, entities.ErrInvalidAmendment):
+		return domainErrors.ErrInvalidAmendment.WithDetails(map[string]interface{}{"reason": err.Error()})
+	default:
+		return err
+	}
+}
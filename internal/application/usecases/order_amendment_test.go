@@ -0,0 +1,277 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// confirmedTestOrder returns confirmed order 1 of 2 reserved units of product 1 at 10
+func confirmedTestOrder(t *testing.T) *entities.Order {
+	t.Helper()
+	order, _ := entities.NewOrder(123)
+	order.ID = 1
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 2, 10.0))
+	require.NoError(t, order.ConfirmOrder())
+	require.NoError(t, order.ReserveAllItems())
+	return order
+}
+
+func TestOrderUseCases_AmendOrder_Success(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	publisher := &recordingPublisher{}
+	auditor := &recordingAuditor{}
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, publisher, auditor, logger.New("test"), DefaultOrderUseCasesConfig())
+	ctx := context.Background()
+
+	existingOrder := confirmedTestOrder(t)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.AmendmentCount == 1 && order.Items[0].Quantity == 3
+	})).Return(storeInto(existingOrder), nil)
+
+	// When
+	result, err := useCases.AmendOrder(ctx, 1, &dto.AmendOrderRequestDTO{
+		Reason: "customer called",
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 3, UnitPrice: 10.0},
+		},
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusConfirmed, result.Status)
+	assert.Equal(t, 30.0, result.TotalAmount)
+	assert.Equal(t, 1, result.AmendmentCount)
+	require.Len(t, result.Amendments, 1)
+	assert.Equal(t, "customer called", result.Amendments[0].Reason)
+	require.Len(t, result.Amendments[0].Changes, 1)
+	assert.Equal(t, 2, result.Amendments[0].Changes[0].QuantityBefore)
+	assert.Equal(t, 3, result.Amendments[0].Changes[0].QuantityAfter)
+
+	assert.Equal(t, []entities.AuditAction{entities.AuditActionOrderAmended}, auditor.actions)
+	assert.Equal(t, 2, auditor.before[0].Items[0].Quantity)
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, events.OrderAmended, publisher.events[0].Type)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_AmendOrder_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   entities.OrderStatus
+		request  *dto.AmendOrderRequestDTO
+		expected error
+	}{
+		{
+			name:   "pending order",
+			status: entities.OrderStatusPending,
+			request: &dto.AmendOrderRequestDTO{Reason: "change", Items: []dto.CreateOrderItemDTO{
+				{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 3, UnitPrice: 10.0},
+			}},
+			expected: domainErrors.ErrAmendmentNotAllowed,
+		},
+		{
+			name:   "unchanged items",
+			status: entities.OrderStatusConfirmed,
+			request: &dto.AmendOrderRequestDTO{Reason: "change", Items: []dto.CreateOrderItemDTO{
+				{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 10.0},
+			}},
+			expected: domainErrors.ErrInvalidAmendment,
+		},
+		{
+			name:   "blank reason",
+			status: entities.OrderStatusConfirmed,
+			request: &dto.AmendOrderRequestDTO{Reason: "  ", Items: []dto.CreateOrderItemDTO{
+				{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 1, UnitPrice: 10.0},
+			}},
+			expected: domainErrors.ErrInvalidAmendment,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo := setupTestOrderUseCases()
+			ctx := context.Background()
+
+			existingOrder := confirmedTestOrder(t)
+			existingOrder.Status = tt.status
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+			// When
+			result, err := useCases.AmendOrder(ctx, 1, tt.request)
+
+			// Then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.expected)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCases_ConfirmedOrderItemChangesRequireAmendment(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(ctx context.Context, useCases OrderUseCases) (*dto.OrderResponseDTO, error)
+	}{
+		{
+			name: "add item",
+			change: func(ctx context.Context, useCases OrderUseCases) (*dto.OrderResponseDTO, error) {
+				return useCases.AddItemToOrder(ctx, 1, &dto.AddOrderItemRequestDTO{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 5})
+			},
+		},
+		{
+			name: "remove item",
+			change: func(ctx context.Context, useCases OrderUseCases) (*dto.OrderResponseDTO, error) {
+				return useCases.RemoveItemFromOrder(ctx, 1, 1)
+			},
+		},
+		{
+			name: "update quantity",
+			change: func(ctx context.Context, useCases OrderUseCases) (*dto.OrderResponseDTO, error) {
+				return useCases.UpdateItemQuantity(ctx, 1, 1, &dto.UpdateOrderItemQuantityRequestDTO{Quantity: 5})
+			},
+		},
+		{
+			name: "replace items",
+			change: func(ctx context.Context, useCases OrderUseCases) (*dto.OrderResponseDTO, error) {
+				return useCases.ReplaceOrderItems(ctx, 1, &dto.ReplaceOrderItemsRequestDTO{Items: []dto.CreateOrderItemDTO{
+					{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 5},
+				}})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo := setupTestOrderUseCases()
+			ctx := context.Background()
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(confirmedTestOrder(t), nil)
+
+			// When
+			result, err := tt.change(ctx, useCases)
+
+			// Then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, domainErrors.ErrOrderRequiresAmendment)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCases_AmendOrder_ReservesAddedUnits(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	inventory := &stubInventory{reserved: []int{1, 1}}
+	config := DefaultOrderUseCasesConfig()
+	config.Inventory = inventory
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config)
+	ctx := context.Background()
+
+	existingOrder := confirmedTestOrder(t)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(existingOrder), nil)
+
+	// When
+	result, err := useCases.AmendOrder(ctx, 1, &dto.AmendOrderRequestDTO{
+		Reason: "customer called",
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 3, UnitPrice: 10.0},
+			{ProductID: 3, ProductSKU: "SKU-003", ProductName: "Product 3", Quantity: 2, UnitPrice: 5.0},
+		},
+	})
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, inventory.orders, 1)
+	requested := inventory.orders[0]
+	assert.Equal(t, uint(1), requested.ID)
+	require.Len(t, requested.Items, 2, "only the added units are reserved")
+	assert.Equal(t, uint(1), requested.Items[0].ProductID)
+	assert.Equal(t, 1, requested.Items[0].Quantity)
+	assert.Equal(t, uint(3), requested.Items[1].ProductID)
+	assert.Equal(t, 2, requested.Items[1].Quantity)
+
+	require.Len(t, result.Items, 2)
+	assert.Equal(t, 3, result.Items[0].ReservedQuantity)
+	assert.False(t, result.Items[0].Backordered)
+	assert.Equal(t, 1, result.Items[1].ReservedQuantity)
+	assert.True(t, result.Items[1].Backordered)
+}
+
+func TestOrderUseCases_AmendOrder_RemovedUnitsAreNotReserved(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	inventory := &stubInventory{err: assert.AnError}
+	config := DefaultOrderUseCasesConfig()
+	config.Inventory = inventory
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config)
+
+	existingOrder := confirmedTestOrder(t)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(existingOrder), nil)
+
+	// When
+	result, err := useCases.AmendOrder(context.Background(), 1, &dto.AmendOrderRequestDTO{
+		Reason: "customer called",
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 1, UnitPrice: 10.0},
+		},
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Empty(t, inventory.orders)
+	assert.Equal(t, 1, result.Items[0].ReservedQuantity)
+}
+
+func TestOrderUseCases_AmendOrder_InventoryUnavailable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected *domainErrors.DomainError
+	}{
+		{name: "inventory error", err: assert.AnError, expected: domainErrors.ErrInventoryUnavailable},
+		{name: "circuit open", err: ports.ErrInventoryCircuitOpen, expected: domainErrors.ErrDependencyUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			mockRepo := new(MockOrderRepository)
+			config := DefaultOrderUseCasesConfig()
+			config.Inventory = &stubInventory{err: tt.err}
+			useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config)
+
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(confirmedTestOrder(t), nil)
+
+			// When
+			result, err := useCases.AmendOrder(context.Background(), 1, &dto.AmendOrderRequestDTO{
+				Reason: "customer called",
+				Items: []dto.CreateOrderItemDTO{
+					{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 3, UnitPrice: 10.0},
+				},
+			})
+
+			// Then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Equal(t, http.StatusServiceUnavailable, domainErrors.HTTPStatus(tt.expected.Code))
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
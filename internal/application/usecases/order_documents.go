@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+)
+
+// errNoDocumentRenderer is returned when the use cases were built without a document renderer
+var errNoDocumentRenderer = errors.New("no document renderer configured")
+
+// GetOrderDocument renders a printable document of an order. Prices are left out of gift orders not
+// delivered yet when the request asks to hide them, so the document can travel with the gift.
+func (uc *orderUseCasesImpl) GetOrderDocument(ctx context.Context, orderID uint, request *dto.OrderDocumentRequestDTO) (*dto.OrderDocumentDTO, error) {
+	uc.log(ctx).Info("GetOrderDocument use case called", "order_id", orderID, "format", request.Format)
+
+	format := ports.DocumentFormat(request.Format)
+	if format == "" {
+		format = ports.DocumentFormatHTML
+	}
+	if format != ports.DocumentFormatHTML && format != ports.DocumentFormatPDF {
+		return nil, domainErrors.ErrInvalidDocumentFormat
+	}
+	if uc.config.DocumentRenderer == nil {
+		uc.log(ctx).Error("Order documents are unavailable", "error", errNoDocumentRenderer)
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrDocumentFormatUnavailable, errNoDocumentRenderer)
+	}
+
+	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		uc.log(ctx).Error("Failed to get order", "order_id", orderID, "error", err)
+		return nil, err
+	}
+	if err := uc.authorizeCustomer(ctx, order.CustomerID); err != nil {
+		return nil, err
+	}
+
+	hidePrices := request.HidePrices && order.HasTag(entities.TagGift) && order.Status != entities.OrderStatusDelivered
+	document, err := uc.config.DocumentRenderer.Render(ctx, ports.OrderDocument{
+		Order:       order,
+		HidePrices:  hidePrices,
+		ShowContact: request.ShowContact,
+		GeneratedAt: time.Now().UTC(),
+	}, format)
+	if errors.Is(err, ports.ErrDocumentFormatUnsupported) {
+		uc.log(ctx).Warn("Document format is unavailable", "order_id", orderID, "format", format, "error", err)
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrDocumentFormatUnavailable, err)
+	}
+	if err != nil {
+		uc.log(ctx).Error("Failed to render order document", "order_id", orderID, "format", format, "error", err)
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrFailedToRenderDocument, err)
+	}
+
+	number := order.OrderNumber
+	if number == "" {
+		number = fmt.Sprintf("%d", order.ID)
+	}
+
+	uc.log(ctx).Info("GetOrderDocument success", "order_id", orderID, "format", format, "hide_prices", hidePrices, "bytes", len(document.Content))
+	return &dto.OrderDocumentDTO{
+		ContentType: document.ContentType,
+		Filename:    fmt.Sprintf("order-%s.%s", number, format),
+		Content:     document.Content,
+	}, nil
+}
@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDocumentRenderer records the documents it renders, it renders HTML only unless err is set
+type fakeDocumentRenderer struct {
+	documents []ports.OrderDocument
+	err       error
+}
+
+func (r *fakeDocumentRenderer) Render(_ context.Context, document ports.OrderDocument, format ports.DocumentFormat) (*ports.RenderedDocument, error) {
+	r.documents = append(r.documents, document)
+	if r.err != nil {
+		return nil, r.err
+	}
+	if format != ports.DocumentFormatHTML {
+		return nil, ports.ErrDocumentFormatUnsupported
+	}
+	return &ports.RenderedDocument{ContentType: "text/html; charset=utf-8", Content: []byte("<html></html>")}, nil
+}
+
+func setupDocumentUseCases() (OrderUseCases, *MockOrderRepository, *fakeDocumentRenderer) {
+	mockRepo := new(MockOrderRepository)
+	renderer := &fakeDocumentRenderer{}
+	config := DefaultOrderUseCasesConfig()
+	config.DocumentRenderer = renderer
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config)
+	return useCases, mockRepo, renderer
+}
+
+func TestOrderUseCases_GetOrderDocument(t *testing.T) {
+	// Given
+	useCases, mockRepo, renderer := setupDocumentUseCases()
+	ctx := context.Background()
+
+	order := confirmedTestOrder(t)
+	order.OrderNumber = "ORD-2025-000001"
+	mockRepo.On("GetByID", ctx, uint(1)).Return(order, nil)
+
+	// When
+	result, err := useCases.GetOrderDocument(ctx, 1, &dto.OrderDocumentRequestDTO{ShowContact: true})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "text/html; charset=utf-8", result.ContentType)
+	assert.Equal(t, "order-ORD-2025-000001.html", result.Filename)
+	assert.Equal(t, "<html></html>", string(result.Content))
+	require.Len(t, renderer.documents, 1)
+	assert.Same(t, order, renderer.documents[0].Order)
+	assert.True(t, renderer.documents[0].ShowContact)
+	assert.False(t, renderer.documents[0].GeneratedAt.IsZero())
+}
+
+func TestOrderUseCases_GetOrderDocument_HidePrices(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []string
+		status   entities.OrderStatus
+		expected bool
+	}{
+		{"gift order on its way", []string{entities.TagGift}, entities.OrderStatusShipped, true},
+		{"delivered gift order", []string{entities.TagGift}, entities.OrderStatusDelivered, false},
+		{"order that is no gift", nil, entities.OrderStatusShipped, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo, renderer := setupDocumentUseCases()
+			ctx := context.Background()
+
+			order := confirmedTestOrder(t)
+			order.Tags = tt.tags
+			order.Status = tt.status
+			mockRepo.On("GetByID", ctx, uint(1)).Return(order, nil)
+
+			// When
+			_, err := useCases.GetOrderDocument(ctx, 1, &dto.OrderDocumentRequestDTO{HidePrices: true})
+
+			// Then
+			require.NoError(t, err)
+			require.Len(t, renderer.documents, 1)
+			assert.Equal(t, tt.expected, renderer.documents[0].HidePrices)
+		})
+	}
+}
+
+func TestOrderUseCases_GetOrderDocument_Rejected(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		renderErr   error
+		noRenderer  bool
+		expected    error
+		rendersPage bool
+	}{
+		{"unknown format", "docx", nil, false, domainErrors.ErrInvalidDocumentFormat, false},
+		{"format the renderer cannot produce", "pdf", nil, false, domainErrors.ErrDocumentFormatUnavailable, true},
+		{"no renderer configured", "html", nil, true, domainErrors.ErrDocumentFormatUnavailable, false},
+		{"renderer failing", "html", errors.New("template broken"), false, domainErrors.ErrFailedToRenderDocument, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			mockRepo := new(MockOrderRepository)
+			renderer := &fakeDocumentRenderer{err: tt.renderErr}
+			config := DefaultOrderUseCasesConfig()
+			if !tt.noRenderer {
+				config.DocumentRenderer = renderer
+			}
+			useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config)
+			ctx := context.Background()
+			mockRepo.On("GetByID", ctx, uint(1)).Return(confirmedTestOrder(t), nil)
+
+			// When
+			result, err := useCases.GetOrderDocument(ctx, 1, &dto.OrderDocumentRequestDTO{Format: tt.format})
+
+			// Then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Equal(t, tt.rendersPage, len(renderer.documents) == 1)
+		})
+	}
+}
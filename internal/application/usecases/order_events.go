@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"orders-service/internal/application/auth"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
+	"orders-service/pkg/logger"
+)
+
+// OrderEventUseCases defines the interface for following order changes as they happen
+type OrderEventUseCases interface {
+	// WatchOrder subscribes to the changes of a single order
+	WatchOrder(ctx context.Context, orderID uint) (ports.EventSubscription, error)
+	// WatchOrders subscribes to the changes of every order the caller may read, an empty status matches all statuses
+	WatchOrders(ctx context.Context, status entities.OrderStatus) (ports.EventSubscription, error)
+}
+
+// orderEventUseCasesImpl implements OrderEventUseCases interface
+type orderEventUseCasesImpl struct {
+	orderRepo  ports.OrderRepository
+	subscriber ports.EventSubscriber
+	logger     logger.Logger
+}
+
+// NewOrderEventUseCases creates the event use cases, subscriber must receive the events the order use cases publish
+func NewOrderEventUseCases(orderRepo ports.OrderRepository, subscriber ports.EventSubscriber, log logger.Logger) OrderEventUseCases {
+	return &orderEventUseCasesImpl{
+		orderRepo:  orderRepo,
+		subscriber: subscriber,
+		logger:     log.With("component", "order_event_usecases"),
+	}
+}
+
+// WatchOrder checks the order exists and is visible to the caller before subscribing to it
+func (uc *orderEventUseCasesImpl) WatchOrder(ctx context.Context, orderID uint) (ports.EventSubscription, error) {
+	uc.log(ctx).Info("WatchOrder use case called", "order_id", orderID)
+
+	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		uc.log(ctx).Error("Failed to get order", "order_id", orderID, "error", err)
+		return nil, err
+	}
+	if err := authorizeCustomer(ctx, uc.log(ctx), order.CustomerID); err != nil {
+		return nil, err
+	}
+
+	return uc.subscribe(func(event events.OrderEvent) bool {
+		return event.OrderID == orderID
+	})
+}
+
+// WatchOrders subscribes to every order with status, a principal bound to a customer only sees that customer's orders
+func (uc *orderEventUseCasesImpl) WatchOrders(ctx context.Context, status entities.OrderStatus) (ports.EventSubscription, error) {
+	uc.log(ctx).Info("WatchOrders use case called", "status", status)
+
+	if status != "" {
+		if err := entities.ValidateOrderStatus(status); err != nil {
+			return nil, invalidStatusError(status)
+		}
+	}
+
+	var customerID uint
+	if principal, ok := auth.PrincipalFromContext(ctx); ok && !principal.IsAdmin() {
+		customerID = principal.CustomerID
+	}
+
+	return uc.subscribe(func(event events.OrderEvent) bool {
+		return (status == "" || event.Status == status) &&
+			(customerID == 0 || event.CustomerID == customerID)
+	})
+}
+
+// log returns the request logger carried by ctx, falling back to the logger of the use cases
+func (uc *orderEventUseCasesImpl) log(ctx context.Context) logger.Logger {
+	return logger.FromContext(ctx, uc.logger)
+}
+
+func (uc *orderEventUseCasesImpl) subscribe(filter func(events.OrderEvent) bool) (ports.EventSubscription, error) {
+	subscription, err := uc.subscriber.Subscribe(filter)
+	if errors.Is(err, ports.ErrTooManySubscribers) {
+		uc.logger.Warn("Event stream limit reached")
+		return nil, domainErrors.ErrTooManyEventStreams
+	}
+	return subscription, err
+}
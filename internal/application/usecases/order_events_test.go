@@ -0,0 +1,121 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"orders-service/internal/application/auth"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSubscriber keeps the filter of the last subscription
+type recordingSubscriber struct {
+	filter func(events.OrderEvent) bool
+	err    error
+}
+
+func (s *recordingSubscriber) Subscribe(filter func(events.OrderEvent) bool) (ports.EventSubscription, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.filter = filter
+	return &closedSubscription{}, nil
+}
+
+type closedSubscription struct{}
+
+func (closedSubscription) Events() <-chan events.OrderEvent { return nil }
+func (closedSubscription) Close()                           {}
+
+func TestOrderEventUseCases_WatchOrder_FiltersOnOrder(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	subscriber := &recordingSubscriber{}
+	useCases := NewOrderEventUseCases(mockRepo, subscriber, logger.New("test"))
+	ctx := context.Background()
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.Order{ID: 1, CustomerID: 123}, nil)
+
+	// When
+	subscription, err := useCases.WatchOrder(ctx, 1)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, subscription)
+	assert.True(t, subscriber.filter(events.OrderEvent{OrderID: 1}))
+	assert.False(t, subscriber.filter(events.OrderEvent{OrderID: 2}))
+}
+
+func TestOrderEventUseCases_WatchOrder_NotFound(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	subscriber := &recordingSubscriber{}
+	useCases := NewOrderEventUseCases(mockRepo, subscriber, logger.New("test"))
+	ctx := context.Background()
+	mockRepo.On("GetByID", ctx, uint(1)).Return(nil, domainErrors.ErrOrderNotFound)
+
+	_, err := useCases.WatchOrder(ctx, 1)
+
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+	assert.Nil(t, subscriber.filter)
+}
+
+func TestOrderEventUseCases_WatchOrder_HidesOtherCustomersOrders(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	subscriber := &recordingSubscriber{}
+	useCases := NewOrderEventUseCases(mockRepo, subscriber, logger.New("test"))
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "storefront", Scopes: []auth.Scope{auth.ScopeOrdersRead}, CustomerID: 7})
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&entities.Order{ID: 1, CustomerID: 123}, nil)
+
+	_, err := useCases.WatchOrder(ctx, 1)
+
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+	assert.Nil(t, subscriber.filter)
+}
+
+func TestOrderEventUseCases_WatchOrders_FiltersOnStatusAndCustomer(t *testing.T) {
+	// Given a principal bound to customer 7
+	subscriber := &recordingSubscriber{}
+	useCases := NewOrderEventUseCases(new(MockOrderRepository), subscriber, logger.New("test"))
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "storefront", Scopes: []auth.Scope{auth.ScopeOrdersRead}, CustomerID: 7})
+
+	// When
+	_, err := useCases.WatchOrders(ctx, entities.OrderStatusShipped)
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, subscriber.filter(events.OrderEvent{CustomerID: 7, Status: entities.OrderStatusShipped}))
+	assert.False(t, subscriber.filter(events.OrderEvent{CustomerID: 8, Status: entities.OrderStatusShipped}))
+	assert.False(t, subscriber.filter(events.OrderEvent{CustomerID: 7, Status: entities.OrderStatusPending}))
+}
+
+func TestOrderEventUseCases_WatchOrders_AllStatuses(t *testing.T) {
+	subscriber := &recordingSubscriber{}
+	useCases := NewOrderEventUseCases(new(MockOrderRepository), subscriber, logger.New("test"))
+
+	_, err := useCases.WatchOrders(context.Background(), "")
+
+	require.NoError(t, err)
+	assert.True(t, subscriber.filter(events.OrderEvent{CustomerID: 8, Status: entities.OrderStatusPending}))
+}
+
+func TestOrderEventUseCases_WatchOrders_InvalidStatus(t *testing.T) {
+	useCases := NewOrderEventUseCases(new(MockOrderRepository), &recordingSubscriber{}, logger.New("test"))
+
+	_, err := useCases.WatchOrders(context.Background(), "lost")
+
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidOrderStatus)
+}
+
+func TestOrderEventUseCases_WatchOrders_TooManyStreams(t *testing.T) {
+	useCases := NewOrderEventUseCases(new(MockOrderRepository), &recordingSubscriber{err: ports.ErrTooManySubscribers}, logger.New("test"))
+
+	_, err := useCases.WatchOrders(context.Background(), "")
+
+	assert.ErrorIs(t, err, domainErrors.ErrTooManyEventStreams)
+}
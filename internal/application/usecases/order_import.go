@@ -0,0 +1,237 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+)
+
+// ImportOrders creates an order for every line returned by next until it returns io.EOF, such as the
+// orders of a migration. Lines are imported in chunks of ImportBatchSize, each in one transaction, and fn
+// is called with the result of every line of a chunk once it committed. Lines failing validation or a
+// business rule are reported with their error and skipped, or end the import with StopOnError. Errors of
+// next, fn or the repository end the import and are returned, the chunks reported before stay stored.
+//
+// Imported orders are audited but publish no events, they load history rather than take new orders.
+func (uc *orderUseCasesImpl) ImportOrders(ctx context.Context, request *dto.ImportOrdersRequestDTO, next func() (*dto.ImportOrderLineDTO, error), fn func(result *dto.ImportOrderResultDTO) error) (*dto.ImportOrdersSummaryDTO, error) {
+	maxLines := uc.config.ImportMaxLines
+	if request.MaxLines > 0 && (maxLines <= 0 || request.MaxLines < maxLines) {
+		maxLines = request.MaxLines
+	}
+	batchSize := uc.config.ImportBatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultOrderUseCasesConfig().ImportBatchSize
+	}
+
+	uc.log(ctx).Info("ImportOrders use case called", "max_lines", maxLines, "stop_on_error", request.StopOnError)
+
+	summary := &dto.ImportOrdersSummaryDTO{}
+	chunk := make([]*dto.ImportOrderLineDTO, 0, batchSize)
+	flush := func() error {
+		err := uc.importChunk(ctx, chunk, request.StopOnError, summary, fn)
+		chunk = chunk[:0]
+		return err
+	}
+
+	read := 0
+	for !summary.Stopped {
+		line, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			uc.log(ctx).Warn("Order import aborted while reading", "lines", read, "error", err)
+			return nil, err
+		}
+
+		if maxLines > 0 && read == maxLines {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			if !summary.Stopped {
+				uc.log(ctx).Warn("Order import exceeds the line limit", "max_lines", maxLines)
+				summary.Stopped, summary.StopReason = true, dto.ImportStoppedMaxLines
+			}
+			break
+		}
+
+		read++
+		chunk = append(chunk, line)
+		if len(chunk) == batchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+
+		if every := uc.config.ImportProgressEvery; every > 0 && read%every == 0 {
+			uc.log(ctx).Info("Order import progress", "lines", read, "created", summary.Created, "failed", summary.Failed)
+		}
+	}
+	if len(chunk) > 0 {
+		if err := flush(); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.log(ctx).Info("ImportOrders success",
+		"lines", summary.Lines,
+		"created", summary.Created,
+		"failed", summary.Failed,
+		"stop_reason", summary.StopReason)
+	return summary, nil
+}
+
+// importChunk imports the lines of chunk in one transaction and reports them to fn once it committed.
+// A failed line ends the chunk and the import when stopOnError is set.
+func (uc *orderUseCasesImpl) importChunk(ctx context.Context, chunk []*dto.ImportOrderLineDTO, stopOnError bool, summary *dto.ImportOrdersSummaryDTO, fn func(result *dto.ImportOrderResultDTO) error) error {
+	var results []*dto.ImportOrderResultDTO
+	var created []*entities.Order
+	stopped := false
+
+	err := uc.unitOfWork.Do(ctx, func(ctx context.Context, _ ports.Repositories) error {
+		results, created, stopped = make([]*dto.ImportOrderResultDTO, 0, len(chunk)), nil, false
+		for _, line := range chunk {
+			result, order, err := uc.importOrder(ctx, line)
+			if err != nil {
+				return err
+			}
+
+			results = append(results, result)
+			if order != nil {
+				created = append(created, order)
+			}
+			if result.Error != nil && stopOnError {
+				stopped = true
+				return nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		uc.log(ctx).Error("Failed to import order chunk", "first_line", chunk[0].Line, "error", err)
+		return repositoryError(err, domainErrors.ErrFailedToImportOrders)
+	}
+
+	for _, order := range created {
+		uc.audit(ctx, entities.AuditActionOrderCreated, order.ID, nil, order)
+	}
+	for _, result := range results {
+		summary.Lines++
+		if result.Error != nil {
+			summary.Failed++
+		} else {
+			summary.Created++
+		}
+		if err := fn(result); err != nil {
+			return err
+		}
+	}
+
+	if stopped {
+		summary.Stopped, summary.StopReason = true, dto.ImportStoppedOnError
+	}
+	return nil
+}
+
+// importOrder creates the order of an import line with the checks of CreateOrder. A rejected line is
+// returned as a failed result, only errors worth retrying such as an unavailable database are returned.
+func (uc *orderUseCasesImpl) importOrder(ctx context.Context, line *dto.ImportOrderLineDTO) (*dto.ImportOrderResultDTO, *entities.Order, error) {
+	result := &dto.ImportOrderResultDTO{Line: line.Line}
+	if line.Error != nil {
+		result.Error = line.Error
+		return result, nil, nil
+	}
+
+	createdOrder, err := uc.createHistoricalOrder(ctx, line.Request)
+	if err != nil {
+		if _, _, retry := domainErrorFailure(err); retry {
+			return nil, nil, err
+		}
+		result.Error = importError(err)
+		return result, nil, nil
+	}
+
+	result.OrderID = createdOrder.ID
+	result.PublicID = createdOrder.PublicID
+	result.OrderNumber = createdOrder.OrderNumber
+	return result, createdOrder, nil
+}
+
+// CreateHistoricalOrder creates an order with the status and timestamps it had before it was imported,
+// bypassing the rule that new orders start pending. Items get the checks of CreateOrder. The order is
+// audited but publishes no event, it loads history rather than taking a new order.
+func (uc *orderUseCasesImpl) CreateHistoricalOrder(ctx context.Context, request *dto.HistoricalOrderRequestDTO) (*dto.OrderResponseDTO, error) {
+	uc.log(ctx).Info("CreateHistoricalOrder use case called", "customer_id", request.CustomerID, "status", request.Status)
+
+	createdOrder, err := uc.createHistoricalOrder(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.audit(ctx, entities.AuditActionOrderCreated, createdOrder.ID, nil, createdOrder)
+
+	uc.log(ctx).Info("CreateHistoricalOrder success", "order_id", createdOrder.ID, "status", createdOrder.Status)
+	return dto.OrderToResponseDTO(createdOrder), nil
+}
+
+// createHistoricalOrder builds and stores the order of a historical request, leaving the audit to the caller
+func (uc *orderUseCasesImpl) createHistoricalOrder(ctx context.Context, request *dto.HistoricalOrderRequestDTO) (*entities.Order, error) {
+	order, err := request.ToEntityWithLimits(uc.config.OrderLimits)
+	if err != nil {
+		return nil, orderItemsError(orderLimitError(priorityError(contactError(orderDetailsError(err)))))
+	}
+
+	status := request.Status
+	if status == "" {
+		status = entities.OrderStatusPending
+	}
+	var createdAt, updatedAt time.Time
+	if request.CreatedAt != nil {
+		createdAt = *request.CreatedAt
+	}
+	if request.UpdatedAt != nil {
+		updatedAt = *request.UpdatedAt
+	}
+	if err := order.RestoreHistory(status, createdAt, updatedAt, time.Now()); err != nil {
+		return nil, orderHistoryError(err)
+	}
+	if order.Status == entities.OrderStatusPending {
+		// A pending order expires relative to the time it was created
+		order.SetExpiry(uc.config.PendingOrderTTL)
+	}
+
+	// Imported orders are checked against each other by their external references, not their items
+	return uc.createOrder(ctx, order, true)
+}
+
+// orderHistoryError converts a status or timestamps rejected by RestoreHistory into their domain error
+func orderHistoryError(err error) error {
+	switch {
+	case errors.Is(err, entities.ErrUnknownOrderStatus):
+		return domainErrors.WrapDomainError(domainErrors.ErrInvalidOrderStatus, err)
+	case errors.Is(err, entities.ErrInvalidOrderHistory):
+		return domainErrors.WrapDomainError(domainErrors.ErrInvalidOrderHistory, err).WithDetails(map[string]interface{}{"reason": err.Error()})
+	default:
+		return err
+	}
+}
+
+// importError describes why an import line was rejected, errors other than domain errors are
+// reported as an order validation error
+func importError(err error) *dto.ImportErrorDTO {
+	var domainErr *domainErrors.DomainError
+	if !errors.As(err, &domainErr) {
+		domainErr = domainErrors.NewOrderValidationError("", err.Error())
+	}
+	return &dto.ImportErrorDTO{
+		Error:   domainErr.Code,
+		Message: domainErr.Message,
+		Details: domainErr.Details,
+	}
+}
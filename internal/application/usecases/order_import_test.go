@@ -0,0 +1,256 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupImportUseCases(batchSize int) (OrderUseCases, *MockOrderRepository, *recordingAuditor) {
+	mockRepo := new(MockOrderRepository)
+	auditor := &recordingAuditor{}
+	config := DefaultOrderUseCasesConfig()
+	config.ImportBatchSize = batchSize
+	useCases := NewOrderUseCasesWithConfig(mockRepo, NewInMemoryUnitOfWork(ports.Repositories{Orders: mockRepo}), nil, auditor, logger.New("test"), config)
+	return useCases, mockRepo, auditor
+}
+
+// importLines returns the lines numbered from 1, a nil request stands for a line the reader rejected
+func importLines(requests ...*dto.CreateOrderRequestDTO) func() (*dto.ImportOrderLineDTO, error) {
+	next := 0
+	return func() (*dto.ImportOrderLineDTO, error) {
+		if next == len(requests) {
+			return nil, io.EOF
+		}
+		next++
+		line := &dto.ImportOrderLineDTO{Line: next}
+		if request := requests[next-1]; request != nil {
+			line.Request = &dto.HistoricalOrderRequestDTO{CreateOrderRequestDTO: *request}
+		} else {
+			line.Error = &dto.ImportErrorDTO{Error: "INVALID_REQUEST", Message: "Request body is not valid JSON"}
+		}
+		return line, nil
+	}
+}
+
+// collectResults returns a callback recording the reported results
+func collectResults(results *[]*dto.ImportOrderResultDTO) func(result *dto.ImportOrderResultDTO) error {
+	return func(result *dto.ImportOrderResultDTO) error {
+		*results = append(*results, result)
+		return nil
+	}
+}
+
+func emptyCartRequest() *dto.CreateOrderRequestDTO {
+	request := cartRequest()
+	request.Items[0].Quantity = 0
+	return request
+}
+
+func TestOrderUseCases_ImportOrders(t *testing.T) {
+	// Given
+	useCases, mockRepo, auditor := setupImportUseCases(2)
+	expectOrderCreate(mockRepo, 42)
+
+	// When
+	var results []*dto.ImportOrderResultDTO
+	summary, err := useCases.ImportOrders(context.Background(), &dto.ImportOrdersRequestDTO{},
+		importLines(cartRequest(), nil, emptyCartRequest(), cartRequest(), cartRequest()), collectResults(&results))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, &dto.ImportOrdersSummaryDTO{Lines: 5, Created: 3, Failed: 2}, summary)
+
+	require.Len(t, results, 5)
+	for i, result := range results {
+		assert.Equal(t, i+1, result.Line)
+	}
+	assert.Equal(t, uint(42), results[0].OrderID)
+	assert.Equal(t, "INVALID_REQUEST", results[1].Error.Error)
+	assert.Equal(t, domainErrors.ErrInvalidOrderItems.Code, results[2].Error.Error)
+	assert.Contains(t, results[2].Error.Details, "items[0].quantity")
+	assert.Nil(t, results[4].Error)
+
+	mockRepo.AssertNumberOfCalls(t, "Create", 3)
+	assert.Equal(t, []entities.AuditAction{entities.AuditActionOrderCreated, entities.AuditActionOrderCreated, entities.AuditActionOrderCreated}, auditor.actions)
+}
+
+func TestOrderUseCases_ImportOrders_StopOnError(t *testing.T) {
+	// Given
+	useCases, mockRepo, _ := setupImportUseCases(10)
+	expectOrderCreate(mockRepo, 42)
+
+	// When
+	var results []*dto.ImportOrderResultDTO
+	summary, err := useCases.ImportOrders(context.Background(), &dto.ImportOrdersRequestDTO{StopOnError: true},
+		importLines(cartRequest(), emptyCartRequest(), cartRequest()), collectResults(&results))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, &dto.ImportOrdersSummaryDTO{Lines: 2, Created: 1, Failed: 1, Stopped: true, StopReason: dto.ImportStoppedOnError}, summary)
+	assert.Len(t, results, 2)
+	mockRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestOrderUseCases_ImportOrders_MaxLines(t *testing.T) {
+	// Given
+	useCases, mockRepo, _ := setupImportUseCases(10)
+	expectOrderCreate(mockRepo, 42)
+
+	// When
+	var results []*dto.ImportOrderResultDTO
+	summary, err := useCases.ImportOrders(context.Background(), &dto.ImportOrdersRequestDTO{MaxLines: 2},
+		importLines(cartRequest(), cartRequest(), cartRequest()), collectResults(&results))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, &dto.ImportOrdersSummaryDTO{Lines: 2, Created: 2, Stopped: true, StopReason: dto.ImportStoppedMaxLines}, summary)
+	assert.Len(t, results, 2)
+
+	// A body of exactly max_lines lines is read to its end
+	summary, err = useCases.ImportOrders(context.Background(), &dto.ImportOrdersRequestDTO{MaxLines: 2},
+		importLines(cartRequest(), cartRequest()), collectResults(&results))
+	require.NoError(t, err)
+	assert.False(t, summary.Stopped)
+}
+
+func TestOrderUseCases_ImportOrders_RepositoryFailureAborts(t *testing.T) {
+	// Given
+	useCases, mockRepo, auditor := setupImportUseCases(10)
+	mockRepo.On("WithCustomerLock", mock.Anything, uint(123)).Return(nil)
+	mockRepo.On("CountByCustomerIDAndStatus", mock.Anything, uint(123), entities.OrderStatusPending).Return(int64(0), nil)
+	mockRepo.On("NextOrderNumberSequence", mock.Anything, mock.Anything).Return(uint64(0), errors.New("connection refused"))
+
+	// When
+	var results []*dto.ImportOrderResultDTO
+	summary, err := useCases.ImportOrders(context.Background(), &dto.ImportOrdersRequestDTO{},
+		importLines(cartRequest(), cartRequest()), collectResults(&results))
+
+	// Then
+	assert.Nil(t, summary)
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToImportOrders)
+	assert.Empty(t, results)
+	assert.Empty(t, auditor.actions)
+}
+
+func TestOrderUseCases_ImportOrders_ReadFailureAborts(t *testing.T) {
+	useCases, _, _ := setupImportUseCases(10)
+	readErr := errors.New("connection reset")
+
+	summary, err := useCases.ImportOrders(context.Background(), &dto.ImportOrdersRequestDTO{},
+		func() (*dto.ImportOrderLineDTO, error) { return nil, readErr },
+		func(*dto.ImportOrderResultDTO) error { return nil })
+
+	assert.Nil(t, summary)
+	assert.ErrorIs(t, err, readErr)
+}
+
+func TestOrderUseCases_CreateHistoricalOrder(t *testing.T) {
+	// Given
+	useCases, mockRepo, auditor := setupImportUseCases(10)
+	createdAt := time.Date(2023, 5, 1, 9, 30, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(72 * time.Hour)
+	expectOrderCreate(mockRepo, 42)
+
+	// When
+	response, err := useCases.CreateHistoricalOrder(context.Background(), &dto.HistoricalOrderRequestDTO{
+		CreateOrderRequestDTO: *cartRequest(),
+		Status:                entities.OrderStatusRefunded,
+		CreatedAt:             &createdAt,
+		UpdatedAt:             &updatedAt,
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, uint(42), response.ID)
+	mockRepo.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.Status == entities.OrderStatusRefunded &&
+			order.CreatedAt.Equal(createdAt) &&
+			order.UpdatedAt.Equal(updatedAt) &&
+			order.RefundedAmount == 25.0 &&
+			order.ExpiresAt == nil
+	}))
+	assert.Equal(t, []entities.AuditAction{entities.AuditActionOrderCreated}, auditor.actions)
+}
+
+func TestOrderUseCases_CreateHistoricalOrder_DefaultsToPending(t *testing.T) {
+	useCases, mockRepo, _ := setupImportUseCases(10)
+	expectOrderCreate(mockRepo, 42)
+
+	_, err := useCases.CreateHistoricalOrder(context.Background(), &dto.HistoricalOrderRequestDTO{CreateOrderRequestDTO: *cartRequest()})
+
+	require.NoError(t, err)
+	mockRepo.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.Status == entities.OrderStatusPending && order.ExpiresAt != nil
+	}))
+}
+
+func TestOrderUseCases_CreateHistoricalOrder_Rejects(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		request *dto.HistoricalOrderRequestDTO
+		want    *domainErrors.DomainError
+	}{
+		{"unknown status", &dto.HistoricalOrderRequestDTO{CreateOrderRequestDTO: *cartRequest(), Status: "archived"}, domainErrors.ErrInvalidOrderStatus},
+		{"on hold", &dto.HistoricalOrderRequestDTO{CreateOrderRequestDTO: *cartRequest(), Status: entities.OrderStatusOnHold}, domainErrors.ErrInvalidOrderHistory},
+		{"created in the future", &dto.HistoricalOrderRequestDTO{CreateOrderRequestDTO: *cartRequest(), CreatedAt: &future}, domainErrors.ErrInvalidOrderHistory},
+		{"invalid items", &dto.HistoricalOrderRequestDTO{CreateOrderRequestDTO: *emptyCartRequest(), Status: entities.OrderStatusDelivered}, domainErrors.ErrInvalidOrderItems},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCases, mockRepo, auditor := setupImportUseCases(10)
+
+			response, err := useCases.CreateHistoricalOrder(context.Background(), tt.request)
+
+			assert.Nil(t, response)
+			assert.ErrorIs(t, err, tt.want)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			assert.Empty(t, auditor.actions)
+		})
+	}
+}
+
+func TestOrderUseCases_ImportOrders_HistoricalStatus(t *testing.T) {
+	// Given
+	useCases, mockRepo, _ := setupImportUseCases(10)
+	expectOrderCreate(mockRepo, 42)
+	createdAt := time.Date(2023, 5, 1, 9, 30, 0, 0, time.UTC)
+	next := 0
+	lines := []*dto.HistoricalOrderRequestDTO{
+		{CreateOrderRequestDTO: *cartRequest(), Status: entities.OrderStatusShipped, CreatedAt: &createdAt},
+		{CreateOrderRequestDTO: *cartRequest(), Status: entities.OrderStatusPartiallyShipped},
+	}
+
+	// When
+	var results []*dto.ImportOrderResultDTO
+	summary, err := useCases.ImportOrders(context.Background(), &dto.ImportOrdersRequestDTO{}, func() (*dto.ImportOrderLineDTO, error) {
+		if next == len(lines) {
+			return nil, io.EOF
+		}
+		next++
+		return &dto.ImportOrderLineDTO{Line: next, Request: lines[next-1]}, nil
+	}, collectResults(&results))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, &dto.ImportOrdersSummaryDTO{Lines: 2, Created: 1, Failed: 1}, summary)
+	assert.Equal(t, domainErrors.ErrInvalidOrderHistory.Code, results[1].Error.Error)
+	assert.Contains(t, results[1].Error.Details, "reason")
+	mockRepo.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.Status == entities.OrderStatusShipped && order.CreatedAt.Equal(createdAt)
+	}))
+}
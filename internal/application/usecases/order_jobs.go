@@ -0,0 +1,216 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"orders-service/internal/application/auth"
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+)
+
+// errNoOrderJobRepository is returned when the unit of work was built without an order job repository
+var errNoOrderJobRepository = errors.New("no order job repository configured")
+
+// SubmitOrderJob stores a create order request as a pending job and queues it, the order is created in
+// the background by ProcessOrderJob. A job the queue does not take stays pending and is picked up by
+// ProcessPendingOrderJobs instead.
+func (uc *orderUseCasesImpl) SubmitOrderJob(ctx context.Context, request *dto.CreateOrderRequestDTO) (*dto.OrderJobResponseDTO, error) {
+	uc.log(ctx).Info("SubmitOrderJob use case called", "customer_id", request.CustomerID)
+
+	if err := uc.authorizeCreate(ctx, request.CustomerID); err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		uc.log(ctx).Error("Failed to encode order job request", "error", err)
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrFailedToSubmitOrderJob, err)
+	}
+
+	var created *entities.OrderJob
+	err = uc.inOrderJobsUnitOfWork(ctx, func(ctx context.Context, jobs ports.OrderJobRepository) error {
+		var err error
+		created, err = jobs.Create(ctx, entities.NewOrderJob(request.CustomerID, payload, principalName(ctx), time.Now()))
+		if err != nil {
+			uc.log(ctx).Error("Failed to store order job", "customer_id", request.CustomerID, "error", err)
+			return repositoryError(err, domainErrors.ErrFailedToSubmitOrderJob)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if uc.config.JobQueue != nil {
+		if err := uc.config.JobQueue.Enqueue(ctx, created.ID); err != nil {
+			uc.log(ctx).Warn("Failed to queue order job, it waits for the pending job sweep", "job_id", created.ID, "error", err)
+		}
+	}
+
+	uc.log(ctx).Info("SubmitOrderJob success", "job_id", created.ID, "customer_id", request.CustomerID)
+	return dto.OrderJobToResponseDTO(created), nil
+}
+
+// GetOrderJob retrieves an order job, jobs of other customers are reported as not found
+func (uc *orderUseCasesImpl) GetOrderJob(ctx context.Context, jobID string) (*dto.OrderJobResponseDTO, error) {
+	uc.log(ctx).Info("GetOrderJob use case called", "job_id", jobID)
+
+	job, err := uc.getOrderJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.authorizeCustomer(ctx, job.CustomerID); err != nil {
+		return nil, domainErrors.ErrOrderJobNotFound
+	}
+
+	uc.log(ctx).Info("GetOrderJob success", "job_id", jobID, "status", job.Status)
+	return dto.OrderJobToResponseDTO(job), nil
+}
+
+// ProcessOrderJob creates the order of a pending job, a job that already completed is skipped. It
+// returns an error only when the job stays pending, see ProcessPendingOrderJobs.
+func (uc *orderUseCasesImpl) ProcessOrderJob(ctx context.Context, jobID string) error {
+	uc.log(ctx).Debug("ProcessOrderJob use case called", "job_id", jobID)
+
+	job, err := uc.getOrderJob(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if !job.IsPending() {
+		uc.log(ctx).Debug("Order job already completed", "job_id", jobID, "status", job.Status)
+		return nil
+	}
+	return uc.runOrderJob(ctx, job, time.Now())
+}
+
+// ProcessPendingOrderJobs runs up to batchSize jobs still pending that were created at or before before,
+// such as jobs queued when the process stopped or jobs the queue did not take. It returns the number of
+// jobs that succeeded or failed, jobs hitting a transient error stay pending for the next run.
+func (uc *orderUseCasesImpl) ProcessPendingOrderJobs(ctx context.Context, before time.Time, batchSize int) (int, error) {
+	uc.log(ctx).Debug("ProcessPendingOrderJobs use case called", "before", before, "batch_size", batchSize)
+
+	var pending []*entities.OrderJob
+	err := uc.inOrderJobsUnitOfWork(ctx, func(ctx context.Context, jobs ports.OrderJobRepository) error {
+		var err error
+		pending, err = jobs.FindPending(ctx, before, batchSize)
+		return err
+	})
+	if err != nil {
+		uc.log(ctx).Error("Failed to find pending order jobs", "error", err)
+		return 0, repositoryError(err, domainErrors.ErrFailedToProcessOrderJobs)
+	}
+
+	completed := 0
+	for _, job := range pending {
+		if err := uc.runOrderJob(ctx, job, time.Now()); err != nil {
+			uc.log(ctx).Warn("Order job left pending", "job_id", job.ID, "error", err)
+			continue
+		}
+		completed++
+	}
+
+	if completed > 0 {
+		uc.log(ctx).Info("ProcessPendingOrderJobs success", "completed", completed)
+	}
+	return completed, nil
+}
+
+// getOrderJob reads a job in its own unit of work
+func (uc *orderUseCasesImpl) getOrderJob(ctx context.Context, jobID string) (*entities.OrderJob, error) {
+	var job *entities.OrderJob
+	err := uc.inOrderJobsUnitOfWork(ctx, func(ctx context.Context, jobs ports.OrderJobRepository) error {
+		var err error
+		job, err = jobs.GetByID(ctx, jobID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrOrderJobNotFound) {
+			return nil, err
+		}
+		uc.log(ctx).Error("Failed to get order job", "job_id", jobID, "error", err)
+		return nil, repositoryError(err, domainErrors.ErrFailedToGetOrderJob)
+	}
+	return job, nil
+}
+
+// runOrderJob creates the order of job through CreateOrder, so every rule of a synchronous create
+// applies, and records the outcome. The order and the success of the job commit together. A rejected
+// request rolls back before the failure is recorded, and a transient error such as an unavailable
+// database leaves the job pending and is returned. A job another worker completed first is left as is.
+func (uc *orderUseCasesImpl) runOrderJob(ctx context.Context, job *entities.OrderJob, now time.Time) error {
+	var request dto.CreateOrderRequestDTO
+	if err := json.Unmarshal(job.Request, &request); err != nil {
+		uc.log(ctx).Error("Stored order job request cannot be decoded", "job_id", job.ID, "error", err)
+		return uc.failOrderJob(ctx, job, now, domainErrors.ErrFailedToCreateOrder.Code, "stored request could not be decoded")
+	}
+
+	// The order is created for whoever submitted the job, who is the actor of its audit entries
+	ctx = auth.WithPrincipal(ctx, &auth.Principal{Name: job.CreatedBy})
+
+	err := uc.inOrderJobsUnitOfWork(ctx, func(ctx context.Context, jobs ports.OrderJobRepository) error {
+		created, err := uc.CreateOrder(ctx, &request)
+		if err != nil {
+			return err
+		}
+
+		succeeded := job.Clone()
+		if err := succeeded.MarkSucceeded(now, created.ID, created.PublicID); err != nil {
+			return err
+		}
+		if _, err := jobs.Update(ctx, succeeded); err != nil {
+			uc.log(ctx).Error("Failed to store order job outcome", "job_id", job.ID, "error", err)
+			return err
+		}
+
+		uc.log(ctx).Info("Order job succeeded", "job_id", job.ID, "order_id", created.ID)
+		return nil
+	})
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, domainErrors.ErrOrderJobNotPending):
+		uc.log(ctx).Info("Order job completed by another worker", "job_id", job.ID)
+		return nil
+	}
+
+	code, message, retry := domainErrorFailure(err)
+	if retry {
+		return err
+	}
+	uc.log(ctx).Warn("Order job failed", "job_id", job.ID, "code", code, "error", err)
+	return uc.failOrderJob(ctx, job, now, code, message)
+}
+
+// failOrderJob records that job failed with the given error code and message
+func (uc *orderUseCasesImpl) failOrderJob(ctx context.Context, job *entities.OrderJob, now time.Time, code, message string) error {
+	failed := job.Clone()
+	if err := failed.MarkFailed(now, code, message); err != nil {
+		return err
+	}
+
+	err := uc.inOrderJobsUnitOfWork(ctx, func(ctx context.Context, jobs ports.OrderJobRepository) error {
+		_, err := jobs.Update(ctx, failed)
+		return err
+	})
+	if err != nil && !errors.Is(err, domainErrors.ErrOrderJobNotPending) {
+		uc.log(ctx).Error("Failed to store order job outcome", "job_id", job.ID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// inOrderJobsUnitOfWork runs fn in a unit of work with its order job repository
+func (uc *orderUseCasesImpl) inOrderJobsUnitOfWork(ctx context.Context, fn func(ctx context.Context, jobs ports.OrderJobRepository) error) error {
+	return uc.unitOfWork.Do(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		if repos.OrderJobs == nil {
+			uc.log(ctx).Error("Order jobs are unavailable", "error", errNoOrderJobRepository)
+			return domainErrors.WrapDomainError(domainErrors.ErrFailedToGetOrderJob, errNoOrderJobRepository)
+		}
+		return fn(ctx, repos.OrderJobs)
+	})
+}
@@ -0,0 +1,258 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/auth"
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOrderJobRepository keeps order jobs in memory, oldest first
+type fakeOrderJobRepository struct {
+	jobs []*entities.OrderJob
+}
+
+func (r *fakeOrderJobRepository) Create(_ context.Context, job *entities.OrderJob) (*entities.OrderJob, error) {
+	r.jobs = append(r.jobs, job.Clone())
+	return job.Clone(), nil
+}
+
+func (r *fakeOrderJobRepository) Update(_ context.Context, job *entities.OrderJob) (*entities.OrderJob, error) {
+	for i, stored := range r.jobs {
+		if stored.ID == job.ID {
+			if !stored.IsPending() {
+				return nil, domainErrors.ErrOrderJobNotPending
+			}
+			r.jobs[i] = job.Clone()
+			return job.Clone(), nil
+		}
+	}
+	return nil, domainErrors.ErrOrderJobNotFound
+}
+
+func (r *fakeOrderJobRepository) GetByID(_ context.Context, id string) (*entities.OrderJob, error) {
+	for _, job := range r.jobs {
+		if job.ID == id {
+			return job.Clone(), nil
+		}
+	}
+	return nil, domainErrors.ErrOrderJobNotFound
+}
+
+func (r *fakeOrderJobRepository) FindPending(_ context.Context, before time.Time, limit int) ([]*entities.OrderJob, error) {
+	pending := make([]*entities.OrderJob, 0, len(r.jobs))
+	for _, job := range r.jobs {
+		if job.IsPending() && !job.CreatedAt.After(before) && len(pending) < limit {
+			pending = append(pending, job.Clone())
+		}
+	}
+	return pending, nil
+}
+
+// fakeJobQueue records the queued job IDs, or fails every enqueue with err
+type fakeJobQueue struct {
+	queued []string
+	err    error
+}
+
+func (q *fakeJobQueue) Enqueue(_ context.Context, jobID string) error {
+	if q.err != nil {
+		return q.err
+	}
+	q.queued = append(q.queued, jobID)
+	return nil
+}
+
+func (q *fakeJobQueue) Consume(ctx context.Context, _ func(ctx context.Context, jobID string)) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func setupOrderJobUseCases(queue ports.JobQueue) (OrderUseCases, *MockOrderRepository, *fakeOrderJobRepository, *recordingAuditor) {
+	mockRepo := new(MockOrderRepository)
+	jobRepo := &fakeOrderJobRepository{}
+	auditor := &recordingAuditor{}
+	unitOfWork := NewInMemoryUnitOfWork(ports.Repositories{Orders: mockRepo, OrderJobs: jobRepo})
+	config := DefaultOrderUseCasesConfig()
+	config.JobQueue = queue
+	useCases := NewOrderUseCasesWithConfig(mockRepo, unitOfWork, nil, auditor, logger.New("test"), config)
+	return useCases, mockRepo, jobRepo, auditor
+}
+
+// expectOrderCreate lets the mock repository store the order of cartRequest as order id
+func expectOrderCreate(mockRepo *MockOrderRepository, id uint) {
+	mockRepo.On("WithCustomerLock", mock.Anything, uint(123)).Return(nil)
+	mockRepo.On("CountByCustomerIDAndStatus", mock.Anything, uint(123), entities.OrderStatusPending).Return(int64(0), nil)
+	mockRepo.On("NextOrderNumberSequence", mock.Anything, mock.Anything).Return(uint64(1), nil)
+	created, _ := cartRequest().ToEntity()
+	created.ID = id
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(created, nil)
+}
+
+func TestOrderUseCases_SubmitOrderJob(t *testing.T) {
+	// Given
+	queue := &fakeJobQueue{}
+	useCases, mockRepo, jobRepo, _ := setupOrderJobUseCases(queue)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "importer"})
+
+	// When
+	job, err := useCases.SubmitOrderJob(ctx, cartRequest())
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, entities.IsPublicID(job.JobID))
+	assert.Equal(t, entities.OrderJobPending, job.Status)
+	assert.Equal(t, uint(123), job.CustomerID)
+	assert.Equal(t, "importer", job.CreatedBy)
+	assert.Equal(t, []string{job.JobID}, queue.queued)
+
+	require.Len(t, jobRepo.jobs, 1)
+	var stored dto.CreateOrderRequestDTO
+	require.NoError(t, json.Unmarshal(jobRepo.jobs[0].Request, &stored))
+	assert.Equal(t, *cartRequest(), stored)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_SubmitOrderJob_FullQueueKeepsJob(t *testing.T) {
+	// Given
+	useCases, _, jobRepo, _ := setupOrderJobUseCases(&fakeJobQueue{err: errors.New("job queue is full")})
+
+	// When
+	job, err := useCases.SubmitOrderJob(context.Background(), cartRequest())
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, jobRepo.jobs, 1)
+	assert.Equal(t, job.JobID, jobRepo.jobs[0].ID)
+	assert.True(t, jobRepo.jobs[0].IsPending())
+}
+
+func TestOrderUseCases_SubmitOrderJob_WithoutRepository(t *testing.T) {
+	// Given
+	useCases := NewOrderUseCases(new(MockOrderRepository), logger.New("test"))
+
+	// When
+	job, err := useCases.SubmitOrderJob(context.Background(), cartRequest())
+
+	// Then
+	assert.Nil(t, job)
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToGetOrderJob)
+}
+
+func TestOrderUseCases_ProcessOrderJob_Succeeds(t *testing.T) {
+	// Given
+	useCases, mockRepo, jobRepo, auditor := setupOrderJobUseCases(nil)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "importer"})
+	submitted, err := useCases.SubmitOrderJob(ctx, cartRequest())
+	require.NoError(t, err)
+	expectOrderCreate(mockRepo, 42)
+
+	// When
+	err = useCases.ProcessOrderJob(context.Background(), submitted.JobID)
+
+	// Then
+	require.NoError(t, err)
+	job, err := useCases.GetOrderJob(context.Background(), submitted.JobID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderJobSucceeded, job.Status)
+	assert.Equal(t, uint(42), job.OrderID)
+	assert.NotEmpty(t, job.OrderPublicID)
+	assert.NotNil(t, job.CompletedAt)
+	assert.Equal(t, []entities.AuditAction{entities.AuditActionOrderCreated}, auditor.actions)
+
+	// A job runs once
+	require.NoError(t, useCases.ProcessOrderJob(context.Background(), submitted.JobID))
+	mockRepo.AssertNumberOfCalls(t, "Create", 1)
+	assert.Equal(t, entities.OrderJobSucceeded, jobRepo.jobs[0].Status)
+}
+
+func TestOrderUseCases_ProcessOrderJob_RejectedRequestFails(t *testing.T) {
+	// Given
+	useCases, mockRepo, _, _ := setupOrderJobUseCases(nil)
+	request := cartRequest()
+	request.Items[0].Quantity = 0
+	submitted, err := useCases.SubmitOrderJob(context.Background(), request)
+	require.NoError(t, err)
+
+	// When
+	err = useCases.ProcessOrderJob(context.Background(), submitted.JobID)
+
+	// Then
+	require.NoError(t, err)
+	job, err := useCases.GetOrderJob(context.Background(), submitted.JobID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderJobFailed, job.Status)
+	assert.Equal(t, domainErrors.ErrInvalidOrderItems.Code, job.ErrorCode)
+	assert.NotEmpty(t, job.ErrorMessage)
+	assert.Zero(t, job.OrderID)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_ProcessOrderJob_TransientErrorStaysPending(t *testing.T) {
+	// Given
+	useCases, mockRepo, jobRepo, _ := setupOrderJobUseCases(nil)
+	submitted, err := useCases.SubmitOrderJob(context.Background(), cartRequest())
+	require.NoError(t, err)
+	mockRepo.On("NextOrderNumberSequence", mock.Anything, mock.Anything).Return(uint64(0), errors.New("connection refused"))
+
+	// When
+	err = useCases.ProcessOrderJob(context.Background(), submitted.JobID)
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToCreateOrder)
+	assert.True(t, jobRepo.jobs[0].IsPending())
+}
+
+func TestOrderUseCases_ProcessPendingOrderJobs(t *testing.T) {
+	// Given
+	useCases, mockRepo, jobRepo, _ := setupOrderJobUseCases(nil)
+	_, err := useCases.SubmitOrderJob(context.Background(), cartRequest())
+	require.NoError(t, err)
+	rejected := cartRequest()
+	rejected.Items[0].Quantity = 0
+	_, err = useCases.SubmitOrderJob(context.Background(), rejected)
+	require.NoError(t, err)
+	expectOrderCreate(mockRepo, 42)
+
+	// When
+	none, err := useCases.ProcessPendingOrderJobs(context.Background(), time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	completed, err := useCases.ProcessPendingOrderJobs(context.Background(), time.Now(), 10)
+
+	// Then
+	require.NoError(t, err)
+	assert.Zero(t, none)
+	assert.Equal(t, 2, completed)
+	assert.Equal(t, entities.OrderJobSucceeded, jobRepo.jobs[0].Status)
+	assert.Equal(t, entities.OrderJobFailed, jobRepo.jobs[1].Status)
+}
+
+func TestOrderUseCases_GetOrderJob_OtherCustomerIsNotFound(t *testing.T) {
+	// Given
+	useCases, _, _, _ := setupOrderJobUseCases(nil)
+	submitted, err := useCases.SubmitOrderJob(context.Background(), cartRequest())
+	require.NoError(t, err)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "storefront", CustomerID: 456})
+
+	// When
+	job, err := useCases.GetOrderJob(ctx, submitted.JobID)
+
+	// Then
+	assert.Nil(t, job)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderJobNotFound)
+
+	_, err = useCases.GetOrderJob(context.Background(), entities.NewPublicID())
+	assert.ErrorIs(t, err, domainErrors.ErrOrderJobNotFound)
+}
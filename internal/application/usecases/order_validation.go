@@ -0,0 +1,131 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+)
+
+// ValidateOrder runs the checks of CreateOrder on a request without storing anything, previewing the
+// order it would create. Item rules, order limits and the minimum order amount are checked, and the
+// unit prices are compared to the catalog when one is configured. The pending order limit of the
+// customer needs the repository and is left to the create. Rejections are reported in the response,
+// the error is kept for failures such as an unavailable catalog.
+func (uc *orderUseCasesImpl) ValidateOrder(ctx context.Context, request *dto.CreateOrderRequestDTO) (*dto.OrderValidationResponseDTO, error) {
+	uc.log(ctx).Info("ValidateOrder use case called", "customer_id", request.CustomerID)
+
+	var errs []dto.OrderValidationErrorDTO
+	order, err := request.ToEntityWithLimits(uc.config.OrderLimits)
+	if err != nil {
+		errs = append(errs, orderValidationErrors(orderItemsError(orderLimitError(priorityError(contactError(orderDetailsError(err))))))...)
+
+		// An order over a limit is still previewed, so the totals can be shown next to the error
+		order, err = request.ToEntity()
+		if err != nil {
+			order = nil
+		}
+	}
+	if order == nil {
+		uc.log(ctx).Info("ValidateOrder success, order rejected", "customer_id", request.CustomerID, "errors", len(errs))
+		return dto.OrderToValidationResponseDTO(request, nil, errs), nil
+	}
+	order.Limits = uc.config.OrderLimits
+
+	if err := order.CheckMinimumAmount(); err != nil {
+		errs = append(errs, orderValidationErrors(minimumAmountError(err))...)
+	}
+
+	if uc.config.ProductCatalog != nil && len(order.Items) > 0 {
+		priceErrs, err := uc.catalogPriceErrors(ctx, order)
+		if err != nil {
+			return nil, err
+		}
+		errs = append(errs, priceErrs...)
+	}
+
+	uc.log(ctx).Info("ValidateOrder success", "customer_id", request.CustomerID, "valid", len(errs) == 0, "errors", len(errs))
+	return dto.OrderToValidationResponseDTO(request, order, errs), nil
+}
+
+// catalogPriceErrors reports every line of the order whose product has no catalog price or whose unit
+// price differs from it
+func (uc *orderUseCasesImpl) catalogPriceErrors(ctx context.Context, order *entities.Order) ([]dto.OrderValidationErrorDTO, error) {
+	prices, err := uc.catalogPrices(ctx, order.ProductIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []dto.OrderValidationErrorDTO
+	for i, item := range order.Items {
+		if price, ok := prices[item.ProductID]; !ok || price <= 0 {
+			errs = append(errs, dto.OrderValidationErrorDTO{
+				Error:   domainErrors.ErrProductNotInCatalog.Code,
+				Message: domainErrors.ErrProductNotInCatalog.Message,
+				Field:   fmt.Sprintf("items[%d].product_id", i),
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return errs, nil
+	}
+
+	// Repricing a copy finds the changed prices with the rounding of the order itself, without the
+	// limits already reported
+	preview := order.Clone()
+	preview.Limits = entities.OrderLimits{}
+	changes, err := preview.Reprice(prices)
+	if err != nil {
+		return nil, err
+	}
+	changed := make(map[uint]entities.PriceChange, len(changes))
+	for _, change := range changes {
+		changed[change.ProductID] = change
+	}
+	for i, item := range order.Items {
+		change, ok := changed[item.ProductID]
+		if !ok {
+			continue
+		}
+		errs = append(errs, dto.OrderValidationErrorDTO{
+			Error:   domainErrors.ErrPriceMismatch.Code,
+			Message: domainErrors.ErrPriceMismatch.Message,
+			Field:   fmt.Sprintf("items[%d].unit_price", i),
+			Details: map[string]interface{}{
+				"unit_price":    change.OldPrice,
+				"catalog_price": change.NewPrice,
+			},
+		})
+	}
+	return errs, nil
+}
+
+// orderValidationErrors lists a rejection of ValidateOrder, one entry per field for invalid items
+func orderValidationErrors(err error) []dto.OrderValidationErrorDTO {
+	var itemErrors entities.ItemErrors
+	if errors.As(err, &itemErrors) {
+		errs := make([]dto.OrderValidationErrorDTO, 0, len(itemErrors))
+		for _, itemErr := range itemErrors {
+			errs = append(errs, dto.OrderValidationErrorDTO{
+				Error:   domainErrors.ErrInvalidOrderItems.Code,
+				Message: itemErr.Reason,
+				Field:   fmt.Sprintf("items[%d].%s", itemErr.Index, itemErr.Field),
+			})
+		}
+		return errs
+	}
+
+	var domainErr *domainErrors.DomainError
+	if !errors.As(err, &domainErr) {
+		domainErr = domainErrors.NewOrderValidationError("", err.Error())
+	}
+	return []dto.OrderValidationErrorDTO{{
+		Error:   domainErr.Code,
+		Message: domainErr.Message,
+		Field:   domainErr.Field,
+		Details: domainErr.Details,
+	}}
+}
@@ -0,0 +1,179 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupValidateUseCases(catalog *fakeProductCatalog, limits entities.OrderLimits) (OrderUseCases, *MockOrderRepository) {
+	mockRepo := new(MockOrderRepository)
+	config := DefaultOrderUseCasesConfig()
+	config.OrderLimits = limits
+	if catalog != nil {
+		config.ProductCatalog = catalog
+	}
+	return NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config), mockRepo
+}
+
+// cartRequest asks for 2 units of product 1 at 10 and 1 unit of product 2 at 5
+func cartRequest() *dto.CreateOrderRequestDTO {
+	return &dto.CreateOrderRequestDTO{
+		CustomerID: 123,
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 10.0},
+			{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 5.0},
+		},
+	}
+}
+
+func errorCodes(errs []dto.OrderValidationErrorDTO) []string {
+	codes := make([]string, 0, len(errs))
+	for _, err := range errs {
+		codes = append(codes, err.Error)
+	}
+	return codes
+}
+
+func TestOrderUseCases_ValidateOrder_ValidCart(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupValidateUseCases(&fakeProductCatalog{prices: map[uint]float64{1: 10.0, 2: 5.0}}, entities.DefaultOrderLimits())
+
+	// When
+	result, err := useCases.ValidateOrder(context.Background(), cartRequest())
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, uint(123), result.CustomerID)
+	assert.Len(t, result.Items, 2)
+	assert.Equal(t, 2, result.ItemCount)
+	assert.Equal(t, 3, result.TotalItems)
+	assert.Equal(t, 25.0, result.TotalAmount)
+	assert.Equal(t, entities.OrderStatusPending, result.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_ValidateOrder_Rejections(t *testing.T) {
+	tests := []struct {
+		name        string
+		catalog     *fakeProductCatalog
+		limits      entities.OrderLimits
+		request     func() *dto.CreateOrderRequestDTO
+		expectCodes []string
+		expectTotal float64
+	}{
+		{
+			name:   "over the total limit is still previewed",
+			limits: entities.OrderLimits{MaxTotalAmount: 20},
+			request: func() *dto.CreateOrderRequestDTO {
+				return cartRequest()
+			},
+			expectCodes: []string{domainErrors.ErrOrderTotalLimitExceeded.Code},
+			expectTotal: 25,
+		},
+		{
+			name:   "below the minimum order amount",
+			limits: entities.OrderLimits{MinOrderAmount: 50},
+			request: func() *dto.CreateOrderRequestDTO {
+				return cartRequest()
+			},
+			expectCodes: []string{domainErrors.ErrOrderBelowMinimum.Code},
+			expectTotal: 25,
+		},
+		{
+			name:    "product missing from the catalog",
+			catalog: &fakeProductCatalog{prices: map[uint]float64{1: 12.5}},
+			request: func() *dto.CreateOrderRequestDTO {
+				return cartRequest()
+			},
+			expectCodes: []string{domainErrors.ErrProductNotInCatalog.Code},
+			expectTotal: 25,
+		},
+		{
+			name:    "changed catalog price",
+			catalog: &fakeProductCatalog{prices: map[uint]float64{1: 12.5, 2: 5.0}},
+			request: func() *dto.CreateOrderRequestDTO {
+				return cartRequest()
+			},
+			expectCodes: []string{domainErrors.ErrPriceMismatch.Code},
+			expectTotal: 25,
+		},
+		{
+			name: "every invalid item",
+			request: func() *dto.CreateOrderRequestDTO {
+				request := cartRequest()
+				request.Items[0].Quantity = 0
+				request.Items[1].UnitPrice = -1
+				return request
+			},
+			expectCodes: []string{domainErrors.ErrInvalidOrderItems.Code, domainErrors.ErrInvalidOrderItems.Code},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo := setupValidateUseCases(tt.catalog, tt.limits)
+
+			// When
+			result, err := useCases.ValidateOrder(context.Background(), tt.request())
+
+			// Then
+			require.NoError(t, err)
+			assert.False(t, result.Valid)
+			assert.Equal(t, tt.expectCodes, errorCodes(result.Errors))
+			assert.Equal(t, tt.expectTotal, result.TotalAmount)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderUseCases_ValidateOrder_ReportsPriceMismatch(t *testing.T) {
+	// Given
+	useCases, _ := setupValidateUseCases(&fakeProductCatalog{prices: map[uint]float64{1: 12.5, 2: 5.0}}, entities.DefaultOrderLimits())
+
+	// When
+	result, err := useCases.ValidateOrder(context.Background(), cartRequest())
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "items[0].unit_price", result.Errors[0].Field)
+	assert.Equal(t, map[string]interface{}{"unit_price": 10.0, "catalog_price": 12.5}, result.Errors[0].Details)
+}
+
+func TestOrderUseCases_ValidateOrder_SampleIsExemptFromMinimum(t *testing.T) {
+	// Given
+	useCases, _ := setupValidateUseCases(nil, entities.OrderLimits{MinOrderAmount: 50})
+	request := cartRequest()
+	request.Tags = []string{entities.TagSample}
+
+	// When
+	result, err := useCases.ValidateOrder(context.Background(), request)
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+}
+
+func TestOrderUseCases_ValidateOrder_CatalogUnavailable(t *testing.T) {
+	// Given
+	useCases, _ := setupValidateUseCases(&fakeProductCatalog{err: errors.New("connection refused")}, entities.DefaultOrderLimits())
+
+	// When
+	result, err := useCases.ValidateOrder(context.Background(), cartRequest())
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrCatalogUnavailable)
+}
@@ -3,409 +3,1636 @@ package usecases
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"orders-service/internal/application/auth"
 	"orders-service/internal/application/dto"
 	"orders-service/internal/application/ports"
 	"orders-service/internal/domain/entities"
 	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
 	"orders-service/pkg/logger"
 )
 
 // OrderUseCases defines the interface for order business operations
 type OrderUseCases interface {
 	CreateOrder(ctx context.Context, request *dto.CreateOrderRequestDTO) (*dto.OrderResponseDTO, error)
+	ValidateOrder(ctx context.Context, request *dto.CreateOrderRequestDTO) (*dto.OrderValidationResponseDTO, error)
 	GetOrder(ctx context.Context, id uint) (*dto.OrderResponseDTO, error)
+	GetOrderByPublicID(ctx context.Context, publicID string) (*dto.OrderResponseDTO, error)
+	ResolveOrderID(ctx context.Context, publicID string) (uint, error)
+	GetOrderByExternalReference(ctx context.Context, customerID uint, reference string) (*dto.OrderResponseDTO, error)
+	GetOrderByNumber(ctx context.Context, number string) (*dto.OrderResponseDTO, error)
 	AddItemToOrder(ctx context.Context, orderID uint, request *dto.AddOrderItemRequestDTO) (*dto.OrderResponseDTO, error)
 	RemoveItemFromOrder(ctx context.Context, orderID, productID uint) (*dto.OrderResponseDTO, error)
+	CancelOrderItem(ctx context.Context, orderID, productID uint, quantity int) (*dto.OrderResponseDTO, error)
 	UpdateItemQuantity(ctx context.Context, orderID, productID uint, request *dto.UpdateOrderItemQuantityRequestDTO) (*dto.OrderResponseDTO, error)
-	ConfirmOrder(ctx context.Context, orderID uint) (*dto.OrderResponseDTO, error)
+	ReplaceOrderItems(ctx context.Context, orderID uint, request *dto.ReplaceOrderItemsRequestDTO) (*dto.OrderResponseDTO, error)
+	AmendOrder(ctx context.Context, orderID uint, request *dto.AmendOrderRequestDTO) (*dto.OrderResponseDTO, error)
+	FulfillOrderItem(ctx context.Context, orderID, productID uint) (*dto.OrderResponseDTO, error)
+	RepriceOrder(ctx context.Context, orderID uint) (*dto.RepriceOrderResponseDTO, error)
+	UpdateOrder(ctx context.Context, orderID uint, request *dto.UpdateOrderRequestDTO) (*dto.OrderResponseDTO, error)
+	ConfirmOrder(ctx context.Context, orderID uint, request *dto.ConfirmOrderRequestDTO) (*dto.OrderResponseDTO, error)
 	CancelOrder(ctx context.Context, orderID uint) (*dto.OrderResponseDTO, error)
+	PlaceOrderOnHold(ctx context.Context, orderID uint, request *dto.PlaceOrderOnHoldRequestDTO) (*dto.OrderResponseDTO, error)
+	ReleaseOrderHold(ctx context.Context, orderID uint) (*dto.OrderResponseDTO, error)
 	TransitionOrderStatus(ctx context.Context, orderID uint, request *dto.UpdateOrderStatusRequestDTO) (*dto.OrderResponseDTO, error)
+	CreateShipment(ctx context.Context, orderID uint, request *dto.CreateShipmentRequestDTO) (*dto.ShipmentResponseDTO, error)
+	ListShipments(ctx context.Context, orderID uint) (*dto.ShipmentListResponseDTO, error)
+	DeliverShipment(ctx context.Context, orderID, shipmentID uint) (*dto.ShipmentResponseDTO, error)
+	CreateRefund(ctx context.Context, orderID uint, request *dto.CreateRefundRequestDTO) (*dto.RefundResponseDTO, error)
+	ListRefunds(ctx context.Context, orderID uint) (*dto.RefundListResponseDTO, error)
+	ListOrderSnapshots(ctx context.Context, orderID uint) (*dto.OrderSnapshotListResponseDTO, error)
+	GetOrderSnapshot(ctx context.Context, orderID uint, version int) (*dto.OrderSnapshotResponseDTO, error)
+	GetOrderDocument(ctx context.Context, orderID uint, request *dto.OrderDocumentRequestDTO) (*dto.OrderDocumentDTO, error)
+	ScheduleTransition(ctx context.Context, orderID uint, request *dto.ScheduleTransitionRequestDTO) (*dto.ScheduledTransitionResponseDTO, error)
+	ListScheduledTransitions(ctx context.Context, orderID uint) (*dto.ScheduledTransitionListResponseDTO, error)
+	CancelScheduledTransition(ctx context.Context, orderID, transitionID uint) (*dto.ScheduledTransitionResponseDTO, error)
 	GetCustomerOrders(ctx context.Context, customerID uint, page, pageSize int) (*dto.OrderListResponseDTO, error)
 	GetOrdersByStatus(ctx context.Context, status entities.OrderStatus, page, pageSize int) (*dto.OrderListResponseDTO, error)
+	ListBackorderedOrders(ctx context.Context, page, pageSize int) (*dto.OrderListResponseDTO, error)
+	GetOrderQueue(ctx context.Context, status entities.OrderStatus, page, pageSize int) (*dto.OrderListResponseDTO, error)
+	GetCustomerOrdersByStatus(ctx context.Context, customerID uint, status entities.OrderStatus, page, pageSize int) (*dto.OrderListResponseDTO, error)
 	ListOrders(ctx context.Context, page, pageSize int) (*dto.OrderListResponseDTO, error)
+	ListOrdersByDateRange(ctx context.Context, from, to *time.Time, page, pageSize int) (*dto.OrderListResponseDTO, error)
+	StreamOrders(ctx context.Context, from, to *time.Time, page, pageSize int, fn func(order *dto.OrderResponseDTO) error) (*dto.OrderListResponseDTO, error)
+	CountOrders(ctx context.Context, customerID uint, status entities.OrderStatus) (int64, error)
+	CountOrdersByDateRange(ctx context.Context, from, to *time.Time) (int64, error)
+	OrdersLastModified(ctx context.Context, filter *dto.OrderFilterDTO) (time.Time, error)
 	DeleteOrder(ctx context.Context, orderID uint) error
+	ListDeletedOrders(ctx context.Context, page, pageSize int) (*dto.DeletedOrderListResponseDTO, error)
+	RestoreOrder(ctx context.Context, orderID uint) (*dto.OrderResponseDTO, error)
+	ExportOrders(ctx context.Context, filter *dto.OrderFilterDTO, fn func(order *dto.OrderResponseDTO) error) error
+	GetOrderStats(ctx context.Context, filter *dto.OrderFilterDTO) (*dto.OrderStatsResponseDTO, error)
+	GetCustomerOrderSummary(ctx context.Context, customerID uint) (*dto.CustomerOrderSummaryDTO, error)
+	ExpirePendingOrders(ctx context.Context, now time.Time, batchSize int) (int, error)
+	ExecuteScheduledTransitions(ctx context.Context, now time.Time, batchSize int) (int, error)
+	SubmitOrderJob(ctx context.Context, request *dto.CreateOrderRequestDTO) (*dto.OrderJobResponseDTO, error)
+	GetOrderJob(ctx context.Context, jobID string) (*dto.OrderJobResponseDTO, error)
+	ProcessOrderJob(ctx context.Context, jobID string) error
+	ProcessPendingOrderJobs(ctx context.Context, before time.Time, batchSize int) (int, error)
+	ImportOrders(ctx context.Context, request *dto.ImportOrdersRequestDTO, next func() (*dto.ImportOrderLineDTO, error), fn func(result *dto.ImportOrderResultDTO) error) (*dto.ImportOrdersSummaryDTO, error)
+	// CreateHistoricalOrder is internal to the admin import, POST /orders must keep using CreateOrder
+	CreateHistoricalOrder(ctx context.Context, request *dto.HistoricalOrderRequestDTO) (*dto.OrderResponseDTO, error)
+}
+
+// OrderUseCasesConfig holds the tunable limits of the order use cases
+type OrderUseCasesConfig struct {
+	ExportMaxRows   int
+	ExportBatchSize int
+
+	// ImportMaxLines caps the order lines of an import, ImportBatchSize is how many lines share a
+	// transaction and ImportProgressEvery how many lines pass between progress log entries
+	ImportMaxLines      int
+	ImportBatchSize     int
+	ImportProgressEvery int
+
+	// MaxPendingOrdersPerCustomer caps open pending orders per customer, 0 disables the limit
+	MaxPendingOrdersPerCustomer int
+
+	// OrderLimits caps the items, quantities and total of every order
+	OrderLimits entities.OrderLimits
+
+	// PendingOrderTTL is how long a new order may stay pending before it expires, 0 disables expiry
+	PendingOrderTTL time.Duration
+
+	// DuplicateOrderWindow is how long after creating an order a customer must set allow_duplicate to
+	// create another order with the same items, 0 disables the check
+	DuplicateOrderWindow time.Duration
+
+	// RepositoryTimeout bounds every repository call, 0 leaves calls bounded by the caller's context only
+	RepositoryTimeout time.Duration
+
+	// OrderNumberFormat shapes the numbers given to new orders
+	OrderNumberFormat entities.OrderNumberFormat
+
+	// ProductCatalog provides the current prices used to reprice pending orders, nil makes repricing unavailable
+	ProductCatalog ports.ProductCatalog
+
+	// Inventory reserves stock for orders being confirmed, nil reserves every line in full.
+	// InventoryOpenCircuit decides how orders are confirmed while the inventory is not called.
+	Inventory            ports.Inventory
+	InventoryOpenCircuit InventoryOpenCircuitPolicy
+
+	// PaymentGateway authorizes the total of orders being confirmed, captures it once they ship and voids it
+	// when they are cancelled, nil confirms orders without a payment. Currency is the ISO 4217 code of the
+	// order amounts handed to it.
+	PaymentGateway ports.PaymentGateway
+	Currency       string
+
+	// DocumentRenderer renders the printable documents of orders, nil makes documents unavailable
+	DocumentRenderer ports.DocumentRenderer
+
+	// JobQueue hands submitted order jobs to the workers, nil leaves them to ProcessPendingOrderJobs
+	JobQueue ports.JobQueue
+
+	// MaxPageSize returns the largest page size of the list use cases, read on every call so it can change
+	// at runtime. Nil or values above dto.MaxPageSize fall back to dto.MaxPageSize.
+	MaxPageSize func() int
+}
+
+// DefaultOrderUseCasesConfig returns the limits used by NewOrderUseCases
+func DefaultOrderUseCasesConfig() OrderUseCasesConfig {
+	return OrderUseCasesConfig{
+		ExportMaxRows:               100000,
+		ExportBatchSize:             500,
+		ImportMaxLines:              5000000,
+		ImportBatchSize:             500,
+		ImportProgressEvery:         10000,
+		MaxPendingOrdersPerCustomer: 10,
+		OrderLimits:                 entities.DefaultOrderLimits(),
+		PendingOrderTTL:             72 * time.Hour,
+		OrderNumberFormat:           entities.DefaultOrderNumberFormat(),
+		InventoryOpenCircuit:        InventoryOpenCircuitFailFast,
+		Currency:                    "USD",
+	}
 }
 
 // orderUseCasesImpl implements OrderUseCases interface
 type orderUseCasesImpl struct {
-	orderRepo ports.OrderRepository
-	logger    logger.Logger
+	orderRepo  ports.OrderRepository
+	unitOfWork ports.UnitOfWork
+	events     ports.EventPublisher
+	auditor    ports.AuditRecorder
+	logger     logger.Logger
+	config     OrderUseCasesConfig
 }
 
-// NewOrderUseCases creates a new instance of order use cases
+// NewOrderUseCases creates a new instance of order use cases without events or an audit log
 func NewOrderUseCases(orderRepo ports.OrderRepository, log logger.Logger) OrderUseCases {
+	return NewOrderUseCasesWithConfig(orderRepo, nil, nil, nil, log, DefaultOrderUseCasesConfig())
+}
+
+// NewOrderUseCasesWithConfig creates a new instance of order use cases with custom limits.
+// A nil unit of work runs multi-step writes without a transaction and without shipments, refunds or
+// snapshots, a nil publisher disables events and a nil auditor disables the audit log.
+func NewOrderUseCasesWithConfig(orderRepo ports.OrderRepository, unitOfWork ports.UnitOfWork, publisher ports.EventPublisher, auditor ports.AuditRecorder, log logger.Logger, config OrderUseCasesConfig) OrderUseCases {
+	if unitOfWork == nil {
+		unitOfWork = NewInMemoryUnitOfWork(ports.Repositories{Orders: orderRepo})
+	}
+
 	return &orderUseCasesImpl{
-		orderRepo: orderRepo,
-		logger:    log.With("component", "order_usecases"),
+		orderRepo:  withRepositoryTimeout(orderRepo, config.RepositoryTimeout),
+		unitOfWork: unitOfWork,
+		events:     publisher,
+		auditor:    auditor,
+		logger:     log.With("component", "order_usecases"),
+		config:     config,
 	}
 }
 
 // CreateOrder creates a new order
 func (uc *orderUseCasesImpl) CreateOrder(ctx context.Context, request *dto.CreateOrderRequestDTO) (*dto.OrderResponseDTO, error) {
-	uc.logger.Info("CreateOrder use case called", "customer_id", request.CustomerID)
+	uc.log(ctx).Info("CreateOrder use case called", "customer_id", request.CustomerID)
+
+	if err := uc.authorizeCreate(ctx, request.CustomerID); err != nil {
+		return nil, err
+	}
 
 	// Convert DTO to domain entity
-	domainEntity, err := request.ToEntity()
+	domainEntity, err := request.ToEntityWithLimits(uc.config.OrderLimits)
 	if err != nil {
-		uc.logger.Error("Failed to convert DTO to entity", "error", err)
-		return nil, err
+		uc.log(ctx).Error("Failed to convert DTO to entity", "error", err)
+		return nil, orderItemsError(orderLimitError(priorityError(contactError(orderDetailsError(err)))))
 	}
+	domainEntity.SetExpiry(uc.config.PendingOrderTTL)
 
 	// Create order in repository
-	createdOrder, err := uc.orderRepo.Create(ctx, domainEntity)
+	createdOrder, err := uc.createOrder(ctx, domainEntity, request.AllowDuplicate)
 	if err != nil {
-		uc.logger.Error("Failed to create order", "error", err)
-		return nil, domainErrors.ErrFailedToCreateOrder
+		return nil, err
 	}
 
-	uc.logger.Info("CreateOrder success", "order_id", createdOrder.ID, "customer_id", request.CustomerID)
+	uc.audit(ctx, entities.AuditActionOrderCreated, createdOrder.ID, nil, createdOrder)
+	uc.publish(ctx, events.NewOrderEvent(events.OrderCreated, createdOrder, time.Now()))
+
+	uc.log(ctx).Info("CreateOrder success", "order_id", createdOrder.ID, "customer_id", request.CustomerID)
 	return dto.OrderToResponseDTO(createdOrder), nil
 }
 
+// maxOrderNumberAttempts is how many order numbers a create tries before giving up on a taken number
+const maxOrderNumberAttempts = 5
+
+// createOrder numbers and persists the order. A number another order took in the meantime, possible
+// with random suffixes or numbers from an earlier format, is replaced by a fresh one and the create retried.
+// Unless allowDuplicate, an order with the items of one the customer just created is rejected, see storeOrder.
+func (uc *orderUseCasesImpl) createOrder(ctx context.Context, order *entities.Order, allowDuplicate bool) (*entities.Order, error) {
+	for attempt := 1; ; attempt++ {
+		number, err := uc.nextOrderNumber(ctx, time.Now())
+		if err != nil {
+			return nil, uc.createOrderError(ctx, err)
+		}
+		order.OrderNumber = number
+
+		createdOrder, err := uc.storeOrder(ctx, order, allowDuplicate)
+		if errors.Is(err, domainErrors.ErrDuplicateOrderNumber) && attempt < maxOrderNumberAttempts {
+			uc.log(ctx).Warn("Order number already taken, retrying with a new one", "order_number", number, "attempt", attempt)
+			continue
+		}
+		if err != nil {
+			return nil, uc.createOrderError(ctx, err)
+		}
+		return createdOrder, nil
+	}
+}
+
+// nextOrderNumber builds the number of an order created at the given time
+func (uc *orderUseCasesImpl) nextOrderNumber(ctx context.Context, at time.Time) (string, error) {
+	format := uc.config.OrderNumberFormat
+	if !format.UsesSequence() {
+		return format.Random(at)
+	}
+
+	value, err := uc.orderRepo.NextOrderNumberSequence(ctx, format.SequenceScope(at))
+	if err != nil {
+		uc.log(ctx).Error("Failed to get next order number", "error", err)
+		return "", err
+	}
+	return format.Format(at, value), nil
+}
+
+// storeOrder persists the order in a unit of work, enforcing the pending order limit of the customer and,
+// unless allowDuplicate, rejecting an order with the same items as one the customer created within the
+// duplicate window. The checks and insert run under a customer lock so concurrent creates cannot both pass them.
+func (uc *orderUseCasesImpl) storeOrder(ctx context.Context, order *entities.Order, allowDuplicate bool) (*entities.Order, error) {
+	limit := uc.config.MaxPendingOrdersPerCustomer
+	principal, ok := auth.PrincipalFromContext(ctx)
+	checkLimit := limit > 0 && !(ok && principal.IsAdmin())
+	// Orders created empty get their items later, they cannot be told apart yet
+	checkDuplicate := !allowDuplicate && uc.config.DuplicateOrderWindow > 0 && len(order.Items) > 0
+
+	var createdOrder *entities.Order
+	err := uc.inUnitOfWork(ctx, func(ctx context.Context, orders ports.OrderRepository) error {
+		if !checkLimit && !checkDuplicate {
+			var err error
+			createdOrder, err = orders.Create(ctx, order)
+			return err
+		}
+
+		return orders.WithCustomerLock(ctx, order.CustomerID, func(ctx context.Context) error {
+			if checkDuplicate {
+				if err := uc.checkDuplicateOrder(ctx, orders, order); err != nil {
+					return err
+				}
+			}
+
+			if checkLimit {
+				pending, err := orders.CountByCustomerIDAndStatus(ctx, order.CustomerID, entities.OrderStatusPending)
+				if err != nil {
+					return err
+				}
+
+				if pending >= int64(limit) {
+					uc.log(ctx).Warn("Pending order limit reached", "customer_id", order.CustomerID, "pending_orders", pending, "limit", limit)
+					return domainErrors.ErrTooManyPendingOrders.WithDetails(map[string]interface{}{
+						"pending_orders": pending,
+						"limit":          limit,
+					})
+				}
+			}
+
+			var err error
+			createdOrder, err = orders.Create(ctx, order)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdOrder, nil
+}
+
+// checkDuplicateOrder fails with ErrPossibleDuplicateOrder, naming the existing order, when the customer
+// created an order with the same items within the duplicate window. Cancelled and expired orders do not count.
+func (uc *orderUseCasesImpl) checkDuplicateOrder(ctx context.Context, orders ports.OrderRepository, order *entities.Order) error {
+	since := time.Now().Add(-uc.config.DuplicateOrderWindow)
+	recent, err := orders.ListRecentByItemSet(ctx, order.CustomerID, order.ItemSetHash(), since)
+	if err != nil {
+		return err
+	}
+
+	for _, existing := range recent {
+		if existing.Status == entities.OrderStatusCancelled || existing.Status == entities.OrderStatusExpired {
+			continue
+		}
+		uc.log(ctx).Warn("Possible duplicate order rejected", "customer_id", order.CustomerID, "existing_order_id", existing.ID)
+		return domainErrors.ErrPossibleDuplicateOrder.WithDetails(map[string]interface{}{
+			"existing_order_id":        existing.ID,
+			"existing_order_public_id": existing.PublicID,
+		})
+	}
+	return nil
+}
+
+// createOrderError passes on errors the client can act on and hides the rest behind ErrFailedToCreateOrder
+func (uc *orderUseCasesImpl) createOrderError(ctx context.Context, err error) error {
+	if errors.Is(err, domainErrors.ErrTooManyPendingOrders) ||
+		errors.Is(err, domainErrors.ErrPossibleDuplicateOrder) ||
+		errors.Is(err, domainErrors.ErrDuplicateExternalReference) ||
+		errors.Is(err, domainErrors.ErrDuplicateOrderNumber) {
+		return err
+	}
+
+	uc.log(ctx).Error("Failed to create order", "error", err)
+	return repositoryError(err, domainErrors.ErrFailedToCreateOrder)
+}
+
+// invalidStatusError rejects an unknown order status with the same code and details as the HTTP
+// handlers, which check the status before calling the use cases
+func invalidStatusError(status entities.OrderStatus) error {
+	return domainErrors.ErrInvalidOrderStatus.WithDetails(map[string]interface{}{
+		"status":         status,
+		"valid_statuses": entities.OrderStatuses(),
+	})
+}
+
+// orderDetailsError converts a rejected customer ID, external reference or tags of a new order into the
+// matching domain error. Other errors are returned unchanged.
+func orderDetailsError(err error) error {
+	switch {
+	case errors.Is(err, entities.ErrCustomerIDRequired):
+		return domainErrors.ErrInvalidCustomerID
+	case errors.Is(err, entities.ErrInvalidExternalReference):
+		return domainErrors.ErrInvalidExternalReference
+	case errors.Is(err, entities.ErrInvalidTags):
+		return domainErrors.ErrInvalidOrderTags.WithDetails(map[string]interface{}{"reason": err.Error()})
+	default:
+		return err
+	}
+}
+
+// orderLimitError converts an exceeded order limit, a rejected unit price or a rejected duplicate item into
+// its domain error, other errors are returned unchanged
+func orderLimitError(err error) error {
+	var priceErr *entities.UnitPriceError
+	if errors.As(err, &priceErr) {
+		// NaN and Inf have no JSON encoding, the price is reported as written
+		return domainErrors.WrapDomainError(domainErrors.ErrInvalidUnitPrice, err).WithDetails(map[string]interface{}{
+			"unit_price": strconv.FormatFloat(priceErr.Price, 'g', -1, 64),
+			"reason":     err.Error(),
+		})
+	}
+
+	switch {
+	case errors.Is(err, entities.ErrItemLimitExceeded):
+		return domainErrors.ErrOrderItemLimitExceeded.WithDetails(map[string]interface{}{"reason": err.Error()})
+	case errors.Is(err, entities.ErrQuantityLimitExceeded):
+		return domainErrors.ErrQuantityLimitExceeded.WithDetails(map[string]interface{}{"reason": err.Error()})
+	case errors.Is(err, entities.ErrTotalLimitExceeded):
+		return domainErrors.ErrOrderTotalLimitExceeded.WithDetails(map[string]interface{}{"reason": err.Error()})
+	case errors.Is(err, entities.ErrWeightLimitExceeded):
+		return domainErrors.ErrWeightLimitExceeded.WithDetails(map[string]interface{}{"reason": err.Error()})
+	case errors.Is(err, entities.ErrDuplicateItem):
+		return domainErrors.ErrDuplicateOrderItem.WithDetails(map[string]interface{}{"reason": err.Error()})
+	default:
+		return err
+	}
+}
+
+// minimumAmountError converts a confirmation rejected for a total below the minimum order amount into
+// ErrOrderBelowMinimum detailing the shortfall. Other errors are returned unchanged.
+func minimumAmountError(err error) error {
+	var belowMinimum *entities.BelowMinimumError
+	if !errors.As(err, &belowMinimum) {
+		return err
+	}
+	return domainErrors.ErrOrderBelowMinimum.WithDetails(map[string]interface{}{
+		"minimum_amount": belowMinimum.Minimum,
+		"total_amount":   belowMinimum.Total,
+		"shortfall":      belowMinimum.Shortfall(),
+	})
+}
+
+// itemCancellationError converts a rejected item cancellation into the matching domain error.
+// Other errors are returned unchanged.
+func itemCancellationError(err error) error {
+	switch {
+	case errors.Is(err, entities.ErrItemNotInOrder):
+		return domainErrors.ErrOrderItemNotFound
+	case errors.Is(err, entities.ErrInvalidCancelQuantity):
+		return domainErrors.ErrInvalidQuantity.WithDetails(map[string]interface{}{"reason": err.Error()})
+	case errors.Is(err, entities.ErrLastItemCancellation):
+		return domainErrors.ErrLastItemCancellation
+	case errors.Is(err, entities.ErrItemCancellationNotAllowed):
+		return domainErrors.ErrItemCancellationNotAllowed.WithDetails(map[string]interface{}{"reason": err.Error()})
+	default:
+		return err
+	}
+}
+
+// shippingError converts a transition rejected for its shipping details into the matching domain error.
+// Other errors are returned unchanged.
+func shippingError(err error) error {
+	switch {
+	case errors.Is(err, entities.ErrInvalidShippingMethod):
+		return domainErrors.ErrInvalidShippingMethod.WithDetails(map[string]interface{}{"reason": err.Error()})
+	case errors.Is(err, entities.ErrEstimatedDeliveryInPast):
+		return domainErrors.ErrInvalidEstimatedDelivery.WithDetails(map[string]interface{}{"reason": err.Error()})
+	case errors.Is(err, entities.ErrTrackingNumberTooLong):
+		return domainErrors.ErrInvalidTrackingNumber.WithDetails(map[string]interface{}{"reason": err.Error()})
+	default:
+		return err
+	}
+}
+
+// orderItemsError converts rejected items into ErrInvalidOrderItems, detailing every field by its
+// path in the request such as items[3].quantity. Other errors are returned unchanged.
+func orderItemsError(err error) error {
+	var itemErrors entities.ItemErrors
+	if !errors.As(err, &itemErrors) {
+		return err
+	}
+
+	details := make(map[string]interface{}, len(itemErrors))
+	for _, itemErr := range itemErrors {
+		details[fmt.Sprintf("items[%d].%s", itemErr.Index, itemErr.Field)] = itemErr.Reason
+	}
+	return domainErrors.WrapDomainError(domainErrors.ErrInvalidOrderItems, err).WithDetails(details)
+}
+
 // GetOrder retrieves an order by ID
 func (uc *orderUseCasesImpl) GetOrder(ctx context.Context, id uint) (*dto.OrderResponseDTO, error) {
-	uc.logger.Info("GetOrder use case called", "order_id", id)
+	uc.log(ctx).Info("GetOrder use case called", "order_id", id)
 
 	order, err := uc.orderRepo.GetByID(ctx, id)
+	if errors.Is(err, domainErrors.ErrOrderNotFound) && isAdmin(ctx) {
+		err = uc.deletedOrderError(ctx, id, err)
+	}
 	if err != nil {
-		uc.logger.Error("Failed to get order", "order_id", id, "error", err)
+		uc.log(ctx).Error("Failed to get order", "order_id", id, "error", err)
+		return nil, err
+	}
+	if err := uc.authorizeCustomer(ctx, order.CustomerID); err != nil {
 		return nil, err
 	}
 
-	uc.logger.Info("GetOrder success", "order_id", id)
+	uc.log(ctx).Info("GetOrder success", "order_id", id)
 	return dto.OrderToResponseDTO(order), nil
 }
 
-// AddItemToOrder adds an item to an existing order
-func (uc *orderUseCasesImpl) AddItemToOrder(ctx context.Context, orderID uint, request *dto.AddOrderItemRequestDTO) (*dto.OrderResponseDTO, error) {
-	uc.logger.Info("AddItemToOrder use case called", "order_id", orderID, "product_id", request.ProductID)
+// deletedOrderError tells an order that was soft deleted apart from one that never existed,
+// returning ErrOrderDeleted with the deletion time for the former and notFound otherwise
+func (uc *orderUseCasesImpl) deletedOrderError(ctx context.Context, id uint, notFound error) error {
+	order, err := uc.orderRepo.GetByIDIncludingDeleted(ctx, id)
+	if err != nil || order.DeletedAt == nil {
+		return notFound
+	}
+
+	return domainErrors.ErrOrderDeleted.WithDetails(map[string]interface{}{
+		"order_id":   id,
+		"deleted_at": order.DeletedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// GetOrderByPublicID retrieves an order by the UUID it was given on creation
+func (uc *orderUseCasesImpl) GetOrderByPublicID(ctx context.Context, publicID string) (*dto.OrderResponseDTO, error) {
+	uc.log(ctx).Info("GetOrderByPublicID use case called", "public_id", publicID)
 
-	// Get existing order
-	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	order, err := uc.getByPublicID(ctx, publicID)
 	if err != nil {
-		uc.logger.Error("Failed to get order", "order_id", orderID, "error", err)
 		return nil, err
 	}
 
-	// Add item to order
-	err = order.AddItem(
-		request.ProductID,
-		request.ProductSKU,
-		request.ProductName,
-		request.Quantity,
-		request.UnitPrice,
-	)
+	uc.log(ctx).Info("GetOrderByPublicID success", "order_id", order.ID, "public_id", publicID)
+	return dto.OrderToResponseDTO(order), nil
+}
+
+// ResolveOrderID returns the numeric ID of the order with the given UUID, so operations taking
+// a numeric ID can be addressed by either. Orders of other customers are not resolved.
+func (uc *orderUseCasesImpl) ResolveOrderID(ctx context.Context, publicID string) (uint, error) {
+	order, err := uc.getByPublicID(ctx, publicID)
+	if err != nil {
+		return 0, err
+	}
+	return order.ID, nil
+}
+
+// getByPublicID loads the order with publicID once the caller may read it, a malformed ID finds no order
+func (uc *orderUseCasesImpl) getByPublicID(ctx context.Context, publicID string) (*entities.Order, error) {
+	id, err := entities.ParsePublicID(publicID)
+	if err != nil {
+		return nil, domainErrors.ErrOrderNotFound
+	}
+
+	order, err := uc.orderRepo.GetByPublicID(ctx, id)
 	if err != nil {
-		uc.logger.Error("Failed to add item to order", "order_id", orderID, "error", err)
+		uc.log(ctx).Error("Failed to get order by public ID", "public_id", publicID, "error", err)
+		return nil, err
+	}
+	if err := uc.authorizeCustomer(ctx, order.CustomerID); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// GetOrderByExternalReference retrieves an order by the reference its customer attached to it
+func (uc *orderUseCasesImpl) GetOrderByExternalReference(ctx context.Context, customerID uint, reference string) (*dto.OrderResponseDTO, error) {
+	uc.log(ctx).Info("GetOrderByExternalReference use case called", "customer_id", customerID, "reference", reference)
+
+	if customerID == 0 {
+		return nil, domainErrors.ErrInvalidCustomerID
+	}
+	if err := uc.authorizeCustomer(ctx, customerID); err != nil {
 		return nil, err
 	}
 
-	// Update order in repository
-	updatedOrder, err := uc.orderRepo.Update(ctx, order)
+	order, err := uc.orderRepo.GetByExternalReference(ctx, customerID, strings.TrimSpace(reference))
 	if err != nil {
-		uc.logger.Error("Failed to update order", "order_id", orderID, "error", err)
-		return nil, domainErrors.ErrFailedToUpdateOrder
+		uc.log(ctx).Error("Failed to get order by external reference", "customer_id", customerID, "reference", reference, "error", err)
+		return nil, err
 	}
 
-	uc.logger.Info("AddItemToOrder success", "order_id", orderID, "product_id", request.ProductID)
+	uc.log(ctx).Info("GetOrderByExternalReference success", "order_id", order.ID, "customer_id", customerID)
+	return dto.OrderToResponseDTO(order), nil
+}
+
+// GetOrderByNumber retrieves an order by the number it was given on creation
+func (uc *orderUseCasesImpl) GetOrderByNumber(ctx context.Context, number string) (*dto.OrderResponseDTO, error) {
+	uc.log(ctx).Info("GetOrderByNumber use case called", "order_number", number)
+
+	order, err := uc.orderRepo.GetByOrderNumber(ctx, strings.TrimSpace(number))
+	if err != nil {
+		uc.log(ctx).Error("Failed to get order by number", "order_number", number, "error", err)
+		return nil, err
+	}
+	if err := uc.authorizeCustomer(ctx, order.CustomerID); err != nil {
+		return nil, err
+	}
+
+	uc.log(ctx).Info("GetOrderByNumber success", "order_id", order.ID, "order_number", number)
+	return dto.OrderToResponseDTO(order), nil
+}
+
+// AddItemToOrder adds an item to an existing order
+func (uc *orderUseCasesImpl) AddItemToOrder(ctx context.Context, orderID uint, request *dto.AddOrderItemRequestDTO) (*dto.OrderResponseDTO, error) {
+	uc.log(ctx).Info("AddItemToOrder use case called", "order_id", orderID, "product_id", request.ProductID)
+
+	// Add the item to the locked order and store it
+	before, updatedOrder, err := uc.modifyOrder(ctx, orderID, func(order *entities.Order) error {
+		order.Limits = uc.config.OrderLimits
+		err := order.AddItem(
+			request.ProductID,
+			request.ProductSKU,
+			request.ProductName,
+			request.Quantity,
+			request.UnitPrice,
+			entities.OnDuplicate(request.OnDuplicate),
+			entities.WithAttributes(request.Attributes),
+			entities.WithUnitWeightGrams(request.UnitWeightGrams),
+		)
+		if err != nil {
+			uc.log(ctx).Error("Failed to add item to order", "order_id", orderID, "error", err)
+			return amendmentError(orderLimitError(err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.audit(ctx, entities.AuditActionItemAdded, orderID, before, updatedOrder)
+	uc.publish(ctx, events.NewOrderEvent(events.OrderItemsChanged, updatedOrder, time.Now()))
+
+	uc.log(ctx).Info("AddItemToOrder success", "order_id", orderID, "product_id", request.ProductID)
 	return dto.OrderToResponseDTO(updatedOrder), nil
 }
 
 // RemoveItemFromOrder removes an item from an order
 func (uc *orderUseCasesImpl) RemoveItemFromOrder(ctx context.Context, orderID, productID uint) (*dto.OrderResponseDTO, error) {
-	uc.logger.Info("RemoveItemFromOrder use case called", "order_id", orderID, "product_id", productID)
-
-	// Get existing order
-	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	uc.log(ctx).Info("RemoveItemFromOrder use case called", "order_id", orderID, "product_id", productID)
+
+	// Remove the item from the locked order and store it
+	before, updatedOrder, err := uc.modifyOrder(ctx, orderID, func(order *entities.Order) error {
+		if err := order.RemoveItem(productID); err != nil {
+			uc.log(ctx).Error("Failed to remove item from order", "order_id", orderID, "product_id", productID, "error", err)
+			return amendmentError(err)
+		}
+		return nil
+	})
 	if err != nil {
-		uc.logger.Error("Failed to get order", "order_id", orderID, "error", err)
 		return nil, err
 	}
 
-	// Remove item from order
-	err = order.RemoveItem(productID)
+	uc.audit(ctx, entities.AuditActionItemRemoved, orderID, before, updatedOrder)
+	uc.publish(ctx, events.NewOrderEvent(events.OrderItemsChanged, updatedOrder, time.Now()))
+
+	uc.log(ctx).Info("RemoveItemFromOrder success", "order_id", orderID, "product_id", productID)
+	return dto.OrderToResponseDTO(updatedOrder), nil
+}
+
+// CancelOrderItem drops quantity units of a line from a confirmed order before it is processed
+func (uc *orderUseCasesImpl) CancelOrderItem(ctx context.Context, orderID, productID uint, quantity int) (*dto.OrderResponseDTO, error) {
+	uc.log(ctx).Info("CancelOrderItem use case called", "order_id", orderID, "product_id", productID, "quantity", quantity)
+
+	// Cancel the quantity on the locked order and store it
+	var cancelled *entities.CancelledItem
+	before, updatedOrder, err := uc.modifyOrder(ctx, orderID, func(order *entities.Order) error {
+		var err error
+		cancelled, err = order.CancelItem(productID, quantity)
+		if err != nil {
+			uc.log(ctx).Error("Failed to cancel order item", "order_id", orderID, "product_id", productID, "error", err)
+			return itemCancellationError(err)
+		}
+		return nil
+	})
 	if err != nil {
-		uc.logger.Error("Failed to remove item from order", "order_id", orderID, "product_id", productID, "error", err)
 		return nil, err
 	}
 
-	// Update order in repository
-	updatedOrder, err := uc.orderRepo.Update(ctx, order)
+	uc.audit(ctx, entities.AuditActionItemCancelled, orderID, before, updatedOrder)
+	uc.publish(ctx, events.NewItemCancelledEvent(updatedOrder, cancelled, time.Now()))
+
+	uc.log(ctx).Info("CancelOrderItem success", "order_id", orderID, "product_id", productID, "quantity", quantity)
+	return dto.OrderToResponseDTO(updatedOrder), nil
+}
+
+// ReplaceOrderItems sets the complete item list of an order in a single update
+func (uc *orderUseCasesImpl) ReplaceOrderItems(ctx context.Context, orderID uint, request *dto.ReplaceOrderItemsRequestDTO) (*dto.OrderResponseDTO, error) {
+	uc.log(ctx).Info("ReplaceOrderItems use case called", "order_id", orderID, "item_count", len(request.Items))
+
+	// Replace the items of the locked order and store it
+	before, updatedOrder, err := uc.modifyOrder(ctx, orderID, func(order *entities.Order) error {
+		order.Limits = uc.config.OrderLimits
+		if err := order.ReplaceItems(request.ToItemInputs()); err != nil {
+			uc.log(ctx).Error("Failed to replace order items", "order_id", orderID, "error", err)
+			err = amendmentError(orderLimitError(err))
+			var domainErr *domainErrors.DomainError
+			if !errors.As(err, &domainErr) {
+				err = domainErrors.NewOrderItemValidationError("items", err.Error())
+			}
+			return err
+		}
+		return nil
+	})
 	if err != nil {
-		uc.logger.Error("Failed to update order", "order_id", orderID, "error", err)
-		return nil, domainErrors.ErrFailedToUpdateOrder
+		return nil, err
 	}
 
-	uc.logger.Info("RemoveItemFromOrder success", "order_id", orderID, "product_id", productID)
+	uc.audit(ctx, entities.AuditActionItemsReplaced, orderID, before, updatedOrder)
+	uc.publish(ctx, events.NewOrderEvent(events.OrderItemsChanged, updatedOrder, time.Now()))
+
+	uc.log(ctx).Info("ReplaceOrderItems success", "order_id", orderID, "item_count", len(updatedOrder.Items))
 	return dto.OrderToResponseDTO(updatedOrder), nil
 }
 
 // UpdateItemQuantity updates the quantity of an item in an order
 func (uc *orderUseCasesImpl) UpdateItemQuantity(ctx context.Context, orderID, productID uint, request *dto.UpdateOrderItemQuantityRequestDTO) (*dto.OrderResponseDTO, error) {
-	uc.logger.Info("UpdateItemQuantity use case called", "order_id", orderID, "product_id", productID, "quantity", request.Quantity)
-
-	// Get existing order
-	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	uc.log(ctx).Info("UpdateItemQuantity use case called", "order_id", orderID, "product_id", productID, "quantity", request.Quantity)
+
+	// Update the item quantity of the locked order and store it
+	before, updatedOrder, err := uc.modifyOrder(ctx, orderID, func(order *entities.Order) error {
+		order.Limits = uc.config.OrderLimits
+		if err := order.UpdateItemQuantity(productID, request.Quantity); err != nil {
+			uc.log(ctx).Error("Failed to update item quantity", "order_id", orderID, "product_id", productID, "error", err)
+			return amendmentError(orderLimitError(err))
+		}
+		return nil
+	})
 	if err != nil {
-		uc.logger.Error("Failed to get order", "order_id", orderID, "error", err)
 		return nil, err
 	}
 
-	// Update item quantity
-	err = order.UpdateItemQuantity(productID, request.Quantity)
+	uc.audit(ctx, entities.AuditActionItemQuantityUpdated, orderID, before, updatedOrder)
+	uc.publish(ctx, events.NewOrderEvent(events.OrderItemsChanged, updatedOrder, time.Now()))
+
+	uc.log(ctx).Info("UpdateItemQuantity success", "order_id", orderID, "product_id", productID)
+	return dto.OrderToResponseDTO(updatedOrder), nil
+}
+
+// ConfirmOrder confirms a pending order and reserves its items, a nil request confirms with the default shipping.
+// Confirming a confirmed order returns it unchanged with AlreadyInState set.
+func (uc *orderUseCasesImpl) ConfirmOrder(ctx context.Context, orderID uint, request *dto.ConfirmOrderRequestDTO) (*dto.OrderResponseDTO, error) {
+	uc.log(ctx).Info("ConfirmOrder use case called", "order_id", orderID)
+
+	var opts []entities.TransitionOption
+	if request != nil {
+		opts = append(opts, entities.WithShipping(request.ShippingMethod, request.EstimatedDeliveryAt))
+		if request.OverrideMinimum {
+			if !isAdmin(ctx) {
+				uc.log(ctx).Warn("Denied minimum order amount override", "order_id", orderID, "principal", principalName(ctx))
+				return nil, domainErrors.ErrMinimumOverrideForbidden
+			}
+			opts = append(opts, entities.WithMinimumOverride())
+		}
+	}
+
+	// Confirm the locked order and store it along with its snapshot
+	var current *entities.Order
+	before, updatedOrder, err := uc.modifyOrderWithSnapshot(ctx, orderID, entities.SnapshotReasonConfirmed, events.OrderStatusChanged, func(order *entities.Order) error {
+		order.Limits = uc.config.OrderLimits
+		if err := order.ConfirmOrder(opts...); err != nil {
+			if errors.Is(err, entities.ErrAlreadyInStatus) {
+				current = order
+				return err
+			}
+			uc.log(ctx).Error("Failed to confirm order", "order_id", orderID, "error", err)
+			return minimumAmountError(shippingError(err))
+		}
+		return uc.reserveItems(ctx, order)
+	})
+	if errors.Is(err, entities.ErrAlreadyInStatus) {
+		uc.log(ctx).Info("ConfirmOrder order already confirmed", "order_id", orderID)
+		return alreadyInStateResponse(current), nil
+	}
 	if err != nil {
-		uc.logger.Error("Failed to update item quantity", "order_id", orderID, "product_id", productID, "error", err)
 		return nil, err
 	}
 
-	// Update order in repository
-	updatedOrder, err := uc.orderRepo.Update(ctx, order)
+	uc.audit(ctx, entities.AuditActionStatusChanged, orderID, before, updatedOrder)
+	uc.publish(ctx, events.NewOrderEvent(events.OrderStatusChanged, updatedOrder, time.Now()))
+
+	uc.log(ctx).Info("ConfirmOrder success", "order_id", orderID)
+	return dto.OrderToResponseDTO(updatedOrder), nil
+}
+
+// CancelOrder cancels an order, a cancelled order is returned unchanged with AlreadyInState set
+func (uc *orderUseCasesImpl) CancelOrder(ctx context.Context, orderID uint) (*dto.OrderResponseDTO, error) {
+	uc.log(ctx).Info("CancelOrder use case called", "order_id", orderID)
+
+	// Cancel the locked order and store it
+	var current *entities.Order
+	before, updatedOrder, err := uc.modifyOrder(ctx, orderID, func(order *entities.Order) error {
+		if err := order.CancelOrder(); err != nil {
+			if errors.Is(err, entities.ErrAlreadyInStatus) {
+				current = order
+				return err
+			}
+			uc.log(ctx).Error("Failed to cancel order", "order_id", orderID, "error", err)
+			return err
+		}
+		return nil
+	})
+	if errors.Is(err, entities.ErrAlreadyInStatus) {
+		uc.log(ctx).Info("CancelOrder order already cancelled", "order_id", orderID, "status", current.Status)
+		return alreadyInStateResponse(current), nil
+	}
 	if err != nil {
-		uc.logger.Error("Failed to update order", "order_id", orderID, "error", err)
-		return nil, domainErrors.ErrFailedToUpdateOrder
+		return nil, err
 	}
 
-	uc.logger.Info("UpdateItemQuantity success", "order_id", orderID, "product_id", productID)
+	uc.audit(ctx, entities.AuditActionStatusChanged, orderID, before, updatedOrder)
+	uc.publish(ctx, events.NewOrderEvent(events.OrderStatusChanged, updatedOrder, time.Now()))
+
+	uc.log(ctx).Info("CancelOrder success", "order_id", orderID)
 	return dto.OrderToResponseDTO(updatedOrder), nil
 }
 
-// ConfirmOrder confirms a pending order
-func (uc *orderUseCasesImpl) ConfirmOrder(ctx context.Context, orderID uint) (*dto.OrderResponseDTO, error) {
-	uc.logger.Info("ConfirmOrder use case called", "order_id", orderID)
-
-	// Get existing order
-	order, err := uc.orderRepo.GetByID(ctx, orderID)
+// PlaceOrderOnHold freezes an order for review, an order on hold is returned unchanged with AlreadyInState set
+func (uc *orderUseCasesImpl) PlaceOrderOnHold(ctx context.Context, orderID uint, request *dto.PlaceOrderOnHoldRequestDTO) (*dto.OrderResponseDTO, error) {
+	uc.log(ctx).Info("PlaceOrderOnHold use case called", "order_id", orderID)
+
+	// Place the locked order on hold and store it
+	var current *entities.Order
+	before, updatedOrder, err := uc.modifyOrder(ctx, orderID, func(order *entities.Order) error {
+		if err := order.PlaceOnHold(request.Reason); err != nil {
+			if errors.Is(err, entities.ErrAlreadyInStatus) {
+				current = order
+				return err
+			}
+			uc.log(ctx).Error("Failed to place order on hold", "order_id", orderID, "error", err)
+			return err
+		}
+		return nil
+	})
+	if errors.Is(err, entities.ErrAlreadyInStatus) {
+		uc.log(ctx).Info("PlaceOrderOnHold order already on hold", "order_id", orderID, "status", current.Status)
+		return alreadyInStateResponse(current), nil
+	}
 	if err != nil {
-		uc.logger.Error("Failed to get order", "order_id", orderID, "error", err)
 		return nil, err
 	}
 
-	// Confirm order
-	err = order.ConfirmOrder()
+	uc.audit(ctx, entities.AuditActionStatusChanged, orderID, before, updatedOrder)
+	uc.publish(ctx, events.NewOrderEvent(events.OrderStatusChanged, updatedOrder, time.Now()))
+
+	uc.log(ctx).Info("PlaceOrderOnHold success", "order_id", orderID, "held_from_status", updatedOrder.HeldFromStatus)
+	return dto.OrderToResponseDTO(updatedOrder), nil
+}
+
+// ReleaseOrderHold returns an on-hold order to its previous status. A pending, confirmed or processing order,
+// as a repeated release finds it, is returned unchanged with AlreadyInState set.
+func (uc *orderUseCasesImpl) ReleaseOrderHold(ctx context.Context, orderID uint) (*dto.OrderResponseDTO, error) {
+	uc.log(ctx).Info("ReleaseOrderHold use case called", "order_id", orderID)
+
+	// Release the hold of the locked order and store it
+	var current *entities.Order
+	before, updatedOrder, err := uc.modifyOrder(ctx, orderID, func(order *entities.Order) error {
+		if err := order.ReleaseHold(); err != nil {
+			if errors.Is(err, entities.ErrAlreadyInStatus) {
+				current = order
+				return err
+			}
+			uc.log(ctx).Error("Failed to release order hold", "order_id", orderID, "error", err)
+			return err
+		}
+		return nil
+	})
+	if errors.Is(err, entities.ErrAlreadyInStatus) {
+		uc.log(ctx).Info("ReleaseOrderHold order not on hold", "order_id", orderID, "status", current.Status)
+		return alreadyInStateResponse(current), nil
+	}
 	if err != nil {
-		uc.logger.Error("Failed to confirm order", "order_id", orderID, "error", err)
 		return nil, err
 	}
 
-	// Update order in repository
-	updatedOrder, err := uc.orderRepo.Update(ctx, order)
+	uc.audit(ctx, entities.AuditActionStatusChanged, orderID, before, updatedOrder)
+	uc.publish(ctx, events.NewOrderEvent(events.OrderStatusChanged, updatedOrder, time.Now()))
+
+	uc.log(ctx).Info("ReleaseOrderHold success", "order_id", orderID, "status", updatedOrder.Status)
+	return dto.OrderToResponseDTO(updatedOrder), nil
+}
+
+// alreadyInStateResponse answers a status change the order had made already, as a repeated request finds it.
+// Nothing is stored, audited or published for it.
+func alreadyInStateResponse(order *entities.Order) *dto.OrderResponseDTO {
+	response := dto.OrderToResponseDTO(order)
+	response.AlreadyInState = true
+	return response
+}
+
+// TransitionOrderStatus transitions an order to a new status. Requesting the status the order already
+// has, as a double submitted request does, returns the order unchanged with AlreadyInState set.
+func (uc *orderUseCasesImpl) TransitionOrderStatus(ctx context.Context, orderID uint, request *dto.UpdateOrderStatusRequestDTO) (*dto.OrderResponseDTO, error) {
+	uc.log(ctx).Info("TransitionOrderStatus use case called", "order_id", orderID, "new_status", request.Status)
+
+	// A refunded order records the refund of what is left of its total
+	if request.Status == entities.OrderStatusRefunded {
+		return uc.refundOrder(ctx, orderID)
+	}
+
+	// Transition the locked order through the order state machine and store it, a confirmation along
+	// with its snapshot
+	modify := uc.modifyOrder
+	if request.Status == entities.OrderStatusConfirmed {
+		modify = func(ctx context.Context, orderID uint, change func(order *entities.Order) error) (*entities.Order, *entities.Order, error) {
+			return uc.modifyOrderWithSnapshot(ctx, orderID, entities.SnapshotReasonConfirmed, events.OrderStatusChanged, change)
+		}
+	}
+	var current *entities.Order
+	before, updatedOrder, err := modify(ctx, orderID, func(order *entities.Order) error {
+		if err := entities.ValidateOrderStatus(request.Status); err != nil {
+			uc.log(ctx).Error("Invalid order status", "status", request.Status, "error", err)
+			return invalidStatusError(request.Status)
+		}
+
+		var opts []entities.TransitionOption
+		if request.Tracking != nil {
+			opts = append(opts, entities.WithTracking(request.Tracking.Carrier, request.Tracking.TrackingNumber))
+		}
+
+		order.Limits = uc.config.OrderLimits
+		confirming := order.Status == entities.OrderStatusPending && request.Status == entities.OrderStatusConfirmed
+		if err := order.TransitionTo(request.Status, opts...); err != nil {
+			if errors.Is(err, entities.ErrAlreadyInStatus) {
+				current = order
+				return err
+			}
+			uc.log(ctx).Error("Failed to transition order status", "order_id", orderID, "error", err)
+			return minimumAmountError(shippingError(err))
+		}
+		if confirming {
+			return uc.reserveItems(ctx, order)
+		}
+		return nil
+	})
+	if errors.Is(err, entities.ErrAlreadyInStatus) {
+		uc.log(ctx).Info("TransitionOrderStatus order already in requested status", "order_id", orderID, "status", request.Status)
+		return alreadyInStateResponse(current), nil
+	}
 	if err != nil {
-		uc.logger.Error("Failed to update order", "order_id", orderID, "error", err)
-		return nil, domainErrors.ErrFailedToUpdateOrder
+		return nil, err
 	}
 
-	uc.logger.Info("ConfirmOrder success", "order_id", orderID)
+	uc.audit(ctx, entities.AuditActionStatusChanged, orderID, before, updatedOrder)
+	uc.publish(ctx, events.NewOrderEvent(events.OrderStatusChanged, updatedOrder, time.Now()))
+
+	uc.log(ctx).Info("TransitionOrderStatus success", "order_id", orderID, "new_status", request.Status)
 	return dto.OrderToResponseDTO(updatedOrder), nil
 }
 
-// CancelOrder cancels an order
-func (uc *orderUseCasesImpl) CancelOrder(ctx context.Context, orderID uint) (*dto.OrderResponseDTO, error) {
-	uc.logger.Info("CancelOrder use case called", "order_id", orderID)
+// GetCustomerOrders retrieves all orders for a specific customer
+func (uc *orderUseCasesImpl) GetCustomerOrders(ctx context.Context, customerID uint, page, pageSize int) (*dto.OrderListResponseDTO, error) {
+	uc.log(ctx).Info("GetCustomerOrders use case called", "customer_id", customerID, "page", page, "page_size", pageSize)
 
-	// Get existing order
-	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	// Validate pagination
+	page, pageSize, err := validatePagination(page, pageSize, uc.maxPageSize())
 	if err != nil {
-		uc.logger.Error("Failed to get order", "order_id", orderID, "error", err)
 		return nil, err
 	}
 
-	// Cancel order
-	err = order.CancelOrder()
+	// Other customers' orders do not exist for a customer bound principal
+	if err := uc.authorizeCustomer(ctx, customerID); err != nil {
+		return dto.NewOrderListResponseDTO(nil, 0, page, pageSize), nil
+	}
+
+	// Get the page and the total number of matches in one consistent read
+	orders, total, err := uc.orderRepo.ListByFilter(ctx, ports.OrderFilter{CustomerID: customerID}, pageSize, page*pageSize)
+	if err != nil {
+		uc.log(ctx).Error("Failed to get customer orders", "customer_id", customerID, "error", err)
+		return nil, repositoryError(err, domainErrors.ErrFailedToListOrders)
+	}
+
+	uc.log(ctx).Info("GetCustomerOrders success", "customer_id", customerID, "count", len(orders))
+	return dto.NewOrderListResponseDTO(orders, total, page, pageSize), nil
+}
+
+// GetOrdersByStatus retrieves orders by status
+func (uc *orderUseCasesImpl) GetOrdersByStatus(ctx context.Context, status entities.OrderStatus, page, pageSize int) (*dto.OrderListResponseDTO, error) {
+	uc.log(ctx).Info("GetOrdersByStatus use case called", "status", status, "page", page, "page_size", pageSize)
+
+	// Validate status
+	if err := entities.ValidateOrderStatus(status); err != nil {
+		uc.log(ctx).Error("Invalid order status", "status", status, "error", err)
+		return nil, invalidStatusError(status)
+	}
+
+	// Validate pagination
+	page, pageSize, err := validatePagination(page, pageSize, uc.maxPageSize())
 	if err != nil {
-		uc.logger.Error("Failed to cancel order", "order_id", orderID, "error", err)
 		return nil, err
 	}
 
-	// Update order in repository
-	updatedOrder, err := uc.orderRepo.Update(ctx, order)
+	// Get the page and the total number of matches in one consistent read
+	filter := ports.OrderFilter{CustomerID: boundCustomer(ctx), Status: status}
+	orders, total, err := uc.orderRepo.ListByFilter(ctx, filter, pageSize, page*pageSize)
 	if err != nil {
-		uc.logger.Error("Failed to update order", "order_id", orderID, "error", err)
-		return nil, domainErrors.ErrFailedToUpdateOrder
+		uc.log(ctx).Error("Failed to get orders by status", "status", status, "error", err)
+		return nil, repositoryError(err, domainErrors.ErrFailedToListOrders)
 	}
 
-	uc.logger.Info("CancelOrder success", "order_id", orderID)
-	return dto.OrderToResponseDTO(updatedOrder), nil
+	uc.log(ctx).Info("GetOrdersByStatus success", "status", status, "count", len(orders))
+	return dto.NewOrderListResponseDTO(orders, total, page, pageSize), nil
 }
 
-// TransitionOrderStatus transitions an order to a new status
-func (uc *orderUseCasesImpl) TransitionOrderStatus(ctx context.Context, orderID uint, request *dto.UpdateOrderStatusRequestDTO) (*dto.OrderResponseDTO, error) {
-	uc.logger.Info("TransitionOrderStatus use case called", "order_id", orderID, "new_status", request.Status)
+// GetCustomerOrdersByStatus retrieves the orders of a customer in a specific status
+func (uc *orderUseCasesImpl) GetCustomerOrdersByStatus(ctx context.Context, customerID uint, status entities.OrderStatus, page, pageSize int) (*dto.OrderListResponseDTO, error) {
+	uc.log(ctx).Info("GetCustomerOrdersByStatus use case called", "customer_id", customerID, "status", status, "page", page, "page_size", pageSize)
+
+	// Validate status
+	if err := entities.ValidateOrderStatus(status); err != nil {
+		uc.log(ctx).Error("Invalid order status", "status", status, "error", err)
+		return nil, invalidStatusError(status)
+	}
 
-	// Get existing order
-	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	// Validate pagination
+	page, pageSize, err := validatePagination(page, pageSize, uc.maxPageSize())
 	if err != nil {
-		uc.logger.Error("Failed to get order", "order_id", orderID, "error", err)
 		return nil, err
 	}
 
-	// Validate status
-	if err := entities.ValidateOrderStatus(request.Status); err != nil {
-		uc.logger.Error("Invalid order status", "status", request.Status, "error", err)
-		return nil, domainErrors.ErrInvalidOrderStatus
-	}
-
-	// Transition based on target status
-	switch request.Status {
-	case entities.OrderStatusConfirmed:
-		err = order.ConfirmOrder()
-	case entities.OrderStatusProcessing:
-		err = order.TransitionToProcessing()
-	case entities.OrderStatusShipped:
-		err = order.TransitionToShipped()
-	case entities.OrderStatusDelivered:
-		err = order.TransitionToDelivered()
-	case entities.OrderStatusCancelled:
-		err = order.CancelOrder()
-	case entities.OrderStatusRefunded:
-		err = order.TransitionToRefunded()
-	default:
-		err = errors.New("unsupported status transition")
+	// Other customers' orders do not exist for a customer bound principal
+	if err := uc.authorizeCustomer(ctx, customerID); err != nil {
+		return dto.NewOrderListResponseDTO(nil, 0, page, pageSize), nil
 	}
 
+	// Get the page and the total number of matches in one consistent read
+	orders, total, err := uc.orderRepo.ListByFilter(ctx, ports.OrderFilter{CustomerID: customerID, Status: status}, pageSize, page*pageSize)
+	if err != nil {
+		uc.log(ctx).Error("Failed to get customer orders by status", "customer_id", customerID, "status", status, "error", err)
+		return nil, repositoryError(err, domainErrors.ErrFailedToListOrders)
+	}
+
+	uc.log(ctx).Info("GetCustomerOrdersByStatus success", "customer_id", customerID, "status", status, "count", len(orders))
+	return dto.NewOrderListResponseDTO(orders, total, page, pageSize), nil
+}
+
+// ListOrders retrieves a paginated list of all orders
+func (uc *orderUseCasesImpl) ListOrders(ctx context.Context, page, pageSize int) (*dto.OrderListResponseDTO, error) {
+	uc.log(ctx).Info("ListOrders use case called", "page", page, "page_size", pageSize)
+
+	// Validate pagination
+	page, pageSize, err := validatePagination(page, pageSize, uc.maxPageSize())
 	if err != nil {
-		uc.logger.Error("Failed to transition order status", "order_id", orderID, "error", err)
 		return nil, err
 	}
 
-	// Update order in repository
-	updatedOrder, err := uc.orderRepo.Update(ctx, order)
+	// Get the page and the total number of matches in one consistent read
+	filter := ports.OrderFilter{CustomerID: boundCustomer(ctx)}
+	orders, total, err := uc.orderRepo.ListByFilter(ctx, filter, pageSize, page*pageSize)
 	if err != nil {
-		uc.logger.Error("Failed to update order", "order_id", orderID, "error", err)
-		return nil, domainErrors.ErrFailedToUpdateOrder
+		uc.log(ctx).Error("Failed to list orders", "error", err)
+		return nil, repositoryError(err, domainErrors.ErrFailedToListOrders)
 	}
 
-	uc.logger.Info("TransitionOrderStatus success", "order_id", orderID, "new_status", request.Status)
-	return dto.OrderToResponseDTO(updatedOrder), nil
+	uc.log(ctx).Info("ListOrders success", "count", len(orders))
+	return dto.NewOrderListResponseDTO(orders, total, page, pageSize), nil
 }
 
-// GetCustomerOrders retrieves all orders for a specific customer
-func (uc *orderUseCasesImpl) GetCustomerOrders(ctx context.Context, customerID uint, page, pageSize int) (*dto.OrderListResponseDTO, error) {
-	uc.logger.Info("GetCustomerOrders use case called", "customer_id", customerID, "page", page, "page_size", pageSize)
+// ListOrdersByDateRange retrieves a paginated list of the orders created in [from, to),
+// a nil from or to leaves that end of the range open
+func (uc *orderUseCasesImpl) ListOrdersByDateRange(ctx context.Context, from, to *time.Time, page, pageSize int) (*dto.OrderListResponseDTO, error) {
+	uc.log(ctx).Info("ListOrdersByDateRange use case called", "from", from, "to", to, "page", page, "page_size", pageSize)
 
-	// Validate and normalize pagination
-	page, pageSize = normalizePagination(page, pageSize)
+	filter, err := uc.dateRangeFilter(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	filter.CustomerID = boundCustomer(ctx)
 
-	// Get orders from repository
-	orders, err := uc.orderRepo.GetByCustomerID(ctx, customerID, pageSize, page)
+	// Validate pagination
+	page, pageSize, err = validatePagination(page, pageSize, uc.maxPageSize())
 	if err != nil {
-		uc.logger.Error("Failed to get customer orders", "customer_id", customerID, "error", err)
-		return nil, domainErrors.ErrFailedToListOrders
+		return nil, err
 	}
 
-	// Get total count
-	total, err := uc.orderRepo.CountByCustomerID(ctx, customerID)
+	// Get the page and the total number of matches in one consistent read
+	orders, total, err := uc.orderRepo.ListByFilter(ctx, filter, pageSize, page*pageSize)
 	if err != nil {
-		uc.logger.Error("Failed to count customer orders", "customer_id", customerID, "error", err)
-		total = int64(len(orders))
+		uc.log(ctx).Error("Failed to list orders by date range", "error", err)
+		return nil, repositoryError(err, domainErrors.ErrFailedToListOrders)
 	}
 
-	uc.logger.Info("GetCustomerOrders success", "customer_id", customerID, "count", len(orders))
-	return &dto.OrderListResponseDTO{
-		Orders:   dto.OrdersToResponseDTOs(orders),
-		Total:    total,
-		Page:     page,
-		PageSize: pageSize,
-	}, nil
+	uc.log(ctx).Info("ListOrdersByDateRange success", "count", len(orders))
+	return dto.NewOrderListResponseDTO(orders, total, page, pageSize), nil
 }
 
-// GetOrdersByStatus retrieves orders by status
-func (uc *orderUseCasesImpl) GetOrdersByStatus(ctx context.Context, status entities.OrderStatus, page, pageSize int) (*dto.OrderListResponseDTO, error) {
-	uc.logger.Info("GetOrdersByStatus use case called", "status", status, "page", page, "page_size", pageSize)
+// StreamOrders is ListOrdersByDateRange handing each order of the page to fn as soon as it is converted
+// instead of collecting them, so the caller can encode them one by one. The returned list carries the
+// pagination metadata only. Nil from and to list every order.
+func (uc *orderUseCasesImpl) StreamOrders(ctx context.Context, from, to *time.Time, page, pageSize int, fn func(order *dto.OrderResponseDTO) error) (*dto.OrderListResponseDTO, error) {
+	uc.log(ctx).Info("StreamOrders use case called", "from", from, "to", to, "page", page, "page_size", pageSize)
 
-	// Validate status
-	if err := entities.ValidateOrderStatus(status); err != nil {
-		uc.logger.Error("Invalid order status", "status", status, "error", err)
-		return nil, domainErrors.ErrInvalidOrderStatus
+	filter, err := uc.dateRangeFilter(ctx, from, to)
+	if err != nil {
+		return nil, err
 	}
+	filter.CustomerID = boundCustomer(ctx)
 
-	// Validate and normalize pagination
-	page, pageSize = normalizePagination(page, pageSize)
+	page, pageSize, err = validatePagination(page, pageSize, uc.maxPageSize())
+	if err != nil {
+		return nil, err
+	}
 
-	// Get orders from repository
-	orders, err := uc.orderRepo.GetByStatus(ctx, status, pageSize, page)
+	orders, total, err := uc.orderRepo.ListByFilter(ctx, filter, pageSize, page*pageSize)
 	if err != nil {
-		uc.logger.Error("Failed to get orders by status", "status", status, "error", err)
-		return nil, domainErrors.ErrFailedToListOrders
+		uc.log(ctx).Error("Failed to list orders", "error", err)
+		return nil, repositoryError(err, domainErrors.ErrFailedToListOrders)
+	}
+
+	for i, order := range orders {
+		if err := fn(dto.OrderToResponseDTO(order)); err != nil {
+			return nil, err
+		}
+		// Let the encoded order be collected before the page is done
+		orders[i] = nil
+	}
+
+	uc.log(ctx).Info("StreamOrders success", "count", len(orders))
+	response := dto.NewOrderListResponseDTO(nil, total, page, pageSize)
+	response.Orders = nil
+	return response, nil
+}
+
+// CountOrders returns the number of orders matching the filters of the order lists without loading
+// any of them. A zero customerID or an empty status leaves that filter out.
+func (uc *orderUseCasesImpl) CountOrders(ctx context.Context, customerID uint, status entities.OrderStatus) (int64, error) {
+	uc.log(ctx).Info("CountOrders use case called", "customer_id", customerID, "status", status)
+
+	if status != "" {
+		if err := entities.ValidateOrderStatus(status); err != nil {
+			uc.log(ctx).Error("Invalid order status", "status", status, "error", err)
+			return 0, invalidStatusError(status)
+		}
+	}
+
+	// Other customers' orders do not exist for a customer bound principal
+	customerID, visible := uc.customerScope(ctx, customerID)
+	if !visible {
+		return 0, nil
 	}
 
-	// Get total count
-	total, err := uc.orderRepo.CountByStatus(ctx, status)
+	var count int64
+	var err error
+	switch {
+	case customerID != 0 && status != "":
+		count, err = uc.orderRepo.CountByCustomerIDAndStatus(ctx, customerID, status)
+	case customerID != 0:
+		count, err = uc.orderRepo.CountByCustomerID(ctx, customerID)
+	case status != "":
+		count, err = uc.orderRepo.CountByStatus(ctx, status)
+	default:
+		count, err = uc.orderRepo.Count(ctx)
+	}
 	if err != nil {
-		uc.logger.Error("Failed to count orders by status", "status", status, "error", err)
-		total = int64(len(orders))
+		uc.log(ctx).Error("Failed to count orders", "customer_id", customerID, "status", status, "error", err)
+		return 0, repositoryError(err, domainErrors.ErrFailedToListOrders)
 	}
 
-	uc.logger.Info("GetOrdersByStatus success", "status", status, "count", len(orders))
-	return &dto.OrderListResponseDTO{
-		Orders:   dto.OrdersToResponseDTOs(orders),
-		Total:    total,
-		Page:     page,
-		PageSize: pageSize,
-	}, nil
+	uc.log(ctx).Info("CountOrders success", "count", count)
+	return count, nil
 }
 
-// ListOrders retrieves a paginated list of all orders
-func (uc *orderUseCasesImpl) ListOrders(ctx context.Context, page, pageSize int) (*dto.OrderListResponseDTO, error) {
-	uc.logger.Info("ListOrders use case called", "page", page, "page_size", pageSize)
+// CountOrdersByDateRange returns the number of orders created in [from, to), a nil from or to leaves
+// that end of the range open
+func (uc *orderUseCasesImpl) CountOrdersByDateRange(ctx context.Context, from, to *time.Time) (int64, error) {
+	uc.log(ctx).Info("CountOrdersByDateRange use case called", "from", from, "to", to)
 
-	// Validate and normalize pagination
-	page, pageSize = normalizePagination(page, pageSize)
+	filter, err := uc.dateRangeFilter(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+	filter.CustomerID = boundCustomer(ctx)
 
-	// Get orders from repository
-	orders, err := uc.orderRepo.List(ctx, pageSize, page)
+	count, err := uc.orderRepo.CountByFilter(ctx, filter)
 	if err != nil {
-		uc.logger.Error("Failed to list orders", "error", err)
-		return nil, domainErrors.ErrFailedToListOrders
+		uc.log(ctx).Error("Failed to count orders by date range", "error", err)
+		return 0, repositoryError(err, domainErrors.ErrFailedToListOrders)
 	}
 
-	// Get total count
-	total, err := uc.orderRepo.Count(ctx)
+	uc.log(ctx).Info("CountOrdersByDateRange success", "count", count)
+	return count, nil
+}
+
+// OrdersLastModified returns when the orders listed with filter last changed, zero when none matches, so
+// lists can be answered 304 Not Modified without loading them. A status in filter does not narrow the
+// result, see ports.OrderRepository.MaxUpdatedAt.
+func (uc *orderUseCasesImpl) OrdersLastModified(ctx context.Context, filter *dto.OrderFilterDTO) (time.Time, error) {
+	uc.log(ctx).Info("OrdersLastModified use case called", "customer_id", filter.CustomerID, "status", filter.Status)
+
+	repoFilter, err := uc.dateRangeFilter(ctx, filter.From, filter.To)
 	if err != nil {
-		uc.logger.Error("Failed to count orders", "error", err)
-		total = int64(len(orders))
+		return time.Time{}, err
 	}
+	repoFilter.Status = filter.Status
 
-	uc.logger.Info("ListOrders success", "count", len(orders))
-	return &dto.OrderListResponseDTO{
-		Orders:   dto.OrdersToResponseDTOs(orders),
-		Total:    total,
-		Page:     page,
-		PageSize: pageSize,
-	}, nil
+	// Other customers' orders do not exist for a customer bound principal
+	var visible bool
+	if repoFilter.CustomerID, visible = uc.customerScope(ctx, filter.CustomerID); !visible {
+		return time.Time{}, nil
+	}
+
+	lastModified, err := uc.orderRepo.MaxUpdatedAt(ctx, repoFilter)
+	if err != nil {
+		uc.log(ctx).Error("Failed to read the last order update", "customer_id", filter.CustomerID, "error", err)
+		return time.Time{}, repositoryError(err, domainErrors.ErrFailedToListOrders)
+	}
+
+	uc.log(ctx).Info("OrdersLastModified success", "last_modified", lastModified)
+	return lastModified, nil
+}
+
+// dateRangeFilter builds the filter of orders created in [from, to), a nil from or to leaves that end open
+func (uc *orderUseCasesImpl) dateRangeFilter(ctx context.Context, from, to *time.Time) (ports.OrderFilter, error) {
+	var filter ports.OrderFilter
+	if from != nil {
+		filter.CreatedFrom = from.UTC()
+	}
+	if to != nil {
+		filter.CreatedBefore = to.UTC()
+	}
+
+	if from != nil && to != nil && !filter.CreatedFrom.Before(filter.CreatedBefore) {
+		uc.log(ctx).Error("Invalid order date range", "from", filter.CreatedFrom, "to", filter.CreatedBefore)
+		return ports.OrderFilter{}, domainErrors.ErrInvalidDateRange
+	}
+	return filter, nil
 }
 
 // DeleteOrder soft deletes an order
 func (uc *orderUseCasesImpl) DeleteOrder(ctx context.Context, orderID uint) error {
-	uc.logger.Info("DeleteOrder use case called", "order_id", orderID)
-
-	// Check if order exists
-	_, err := uc.orderRepo.GetByID(ctx, orderID)
+	uc.log(ctx).Info("DeleteOrder use case called", "order_id", orderID)
+
+	// Check and delete the locked order in one unit of work
+	var order *entities.Order
+	err := uc.inUnitOfWork(ctx, func(ctx context.Context, orders ports.OrderRepository) error {
+		var err error
+		order, err = orders.GetByIDForUpdate(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to get order", "order_id", orderID, "error", err)
+			return err
+		}
+		if err := uc.authorizeCustomer(ctx, order.CustomerID); err != nil {
+			return err
+		}
+
+		if !order.CanBeDeleted() {
+			uc.log(ctx).Warn("Refusing to delete order in fulfillment", "order_id", orderID, "status", order.Status)
+			return domainErrors.ErrOrderNotDeletable.WithDetails(map[string]interface{}{
+				"current_status": order.Status,
+			})
+		}
+
+		if err := orders.Delete(ctx, orderID); err != nil {
+			uc.log(ctx).Error("Failed to delete order", "order_id", orderID, "error", err)
+			return repositoryError(err, domainErrors.ErrFailedToDeleteOrder)
+		}
+		return nil
+	})
 	if err != nil {
-		uc.logger.Error("Failed to get order", "order_id", orderID, "error", err)
 		return err
 	}
 
-	// Delete order
-	err = uc.orderRepo.Delete(ctx, orderID)
+	uc.audit(ctx, entities.AuditActionOrderDeleted, orderID, order, nil)
+	uc.publish(ctx, events.NewOrderEvent(events.OrderDeleted, order, time.Now()))
+
+	uc.log(ctx).Info("DeleteOrder success", "order_id", orderID)
+	return nil
+}
+
+// ListDeletedOrders retrieves a paginated list of soft deleted orders, most recently deleted first
+func (uc *orderUseCasesImpl) ListDeletedOrders(ctx context.Context, page, pageSize int) (*dto.DeletedOrderListResponseDTO, error) {
+	uc.log(ctx).Info("ListDeletedOrders use case called", "page", page, "page_size", pageSize)
+
+	page, pageSize, err := validatePagination(page, pageSize, uc.maxPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := uc.orderRepo.ListDeleted(ctx, pageSize, page*pageSize)
+	if err != nil {
+		uc.log(ctx).Error("Failed to list deleted orders", "error", err)
+		return nil, repositoryError(err, domainErrors.ErrFailedToListOrders)
+	}
+
+	total, err := uc.orderRepo.CountDeleted(ctx)
+	if err != nil {
+		uc.log(ctx).Error("Failed to count deleted orders", "error", err)
+		total = int64(len(orders))
+	}
+
+	uc.log(ctx).Info("ListDeletedOrders success", "count", len(orders))
+	return dto.NewDeletedOrderListResponseDTO(orders, total, page, pageSize), nil
+}
+
+// RestoreOrder undoes the soft delete of an order. Orders that are not deleted are reported as not found.
+func (uc *orderUseCasesImpl) RestoreOrder(ctx context.Context, orderID uint) (*dto.OrderResponseDTO, error) {
+	uc.log(ctx).Info("RestoreOrder use case called", "order_id", orderID)
+
+	var restored *entities.Order
+	err := uc.inUnitOfWork(ctx, func(ctx context.Context, orders ports.OrderRepository) error {
+		order, err := orders.GetByIDIncludingDeleted(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to get order", "order_id", orderID, "error", err)
+			return err
+		}
+		if err := uc.authorizeCustomer(ctx, order.CustomerID); err != nil {
+			return err
+		}
+		if order.DeletedAt == nil {
+			uc.log(ctx).Warn("Refusing to restore order that is not deleted", "order_id", orderID)
+			return domainErrors.ErrOrderNotFound
+		}
+
+		restored, err = orders.Restore(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to restore order", "order_id", orderID, "error", err)
+			return repositoryError(err, domainErrors.ErrFailedToUpdateOrder)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.audit(ctx, entities.AuditActionOrderRestored, orderID, nil, restored)
+
+	uc.log(ctx).Info("RestoreOrder success", "order_id", orderID)
+	return dto.OrderToResponseDTO(restored), nil
+}
+
+// ExportOrders streams every order matching filter to fn, refusing exports larger than the configured row limit
+func (uc *orderUseCasesImpl) ExportOrders(ctx context.Context, filter *dto.OrderFilterDTO, fn func(order *dto.OrderResponseDTO) error) error {
+	uc.log(ctx).Info("ExportOrders use case called", "customer_id", filter.CustomerID, "status", filter.Status)
+
+	if filter.Status != "" {
+		if err := entities.ValidateOrderStatus(filter.Status); err != nil {
+			uc.log(ctx).Error("Invalid order status", "status", filter.Status, "error", err)
+			return invalidStatusError(filter.Status)
+		}
+	}
+
+	// Other customers' orders do not exist for a customer bound principal
+	repoFilter := filter.ToFilter()
+	var visible bool
+	if repoFilter.CustomerID, visible = uc.customerScope(ctx, filter.CustomerID); !visible {
+		return nil
+	}
+
+	rows, err := uc.orderRepo.CountItemsByFilter(ctx, repoFilter)
+	if err != nil {
+		uc.log(ctx).Error("Failed to count export rows", "error", err)
+		return repositoryError(err, domainErrors.ErrFailedToExportOrders)
+	}
+
+	if uc.config.ExportMaxRows > 0 && rows > int64(uc.config.ExportMaxRows) {
+		uc.log(ctx).Warn("Export exceeds row limit", "rows", rows, "max_rows", uc.config.ExportMaxRows)
+		return domainErrors.ErrExportTooLarge
+	}
+
+	exported := 0
+	err = uc.orderRepo.StreamByFilter(ctx, repoFilter, uc.config.ExportBatchSize, func(order *entities.Order) error {
+		exported++
+		return fn(dto.OrderToResponseDTO(order))
+	})
 	if err != nil {
-		uc.logger.Error("Failed to delete order", "order_id", orderID, "error", err)
-		return domainErrors.ErrFailedToDeleteOrder
+		uc.log(ctx).Error("Failed to export orders", "exported", exported, "error", err)
+		return err
 	}
 
-	uc.logger.Info("DeleteOrder success", "order_id", orderID)
+	uc.log(ctx).Info("ExportOrders success", "orders", exported, "rows", rows)
 	return nil
 }
 
-// Helper function to normalize pagination parameters
-func normalizePagination(page, pageSize int) (int, int) {
-	if page < 0 {
-		page = 0
+// GetOrderStats aggregates order counts and revenue by status and by day, defaulting to the last 30 days
+func (uc *orderUseCasesImpl) GetOrderStats(ctx context.Context, filter *dto.OrderFilterDTO) (*dto.OrderStatsResponseDTO, error) {
+	uc.log(ctx).Info("GetOrderStats use case called", "customer_id", filter.CustomerID, "status", filter.Status)
+
+	if filter.Status != "" {
+		if err := entities.ValidateOrderStatus(filter.Status); err != nil {
+			uc.log(ctx).Error("Invalid order status", "status", filter.Status, "error", err)
+			return nil, invalidStatusError(filter.Status)
+		}
+	}
+
+	// Default to the last 30 days, whole days in UTC
+	to := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	if filter.To != nil {
+		to = filter.To.UTC()
+	}
+	from := to.AddDate(0, 0, -30)
+	if filter.From != nil {
+		from = filter.From.UTC()
+	}
+
+	if !from.Before(to) || to.Sub(from) > maxStatsRange {
+		uc.log(ctx).Error("Invalid stats date range", "from", from, "to", to)
+		return nil, domainErrors.ErrInvalidDateRange
+	}
+
+	repoFilter := filter.ToFilter()
+	repoFilter.CreatedFrom = from
+	repoFilter.CreatedBefore = to
+
+	// Other customers' orders do not exist for a customer bound principal, their stats are all zero
+	var byStatus []ports.StatusAggregate
+	var byDay []ports.DailyAggregate
+	var visible bool
+	if repoFilter.CustomerID, visible = uc.customerScope(ctx, filter.CustomerID); visible {
+		var err error
+		byStatus, err = uc.orderRepo.AggregateByStatus(ctx, repoFilter)
+		if err != nil {
+			uc.log(ctx).Error("Failed to aggregate orders by status", "error", err)
+			return nil, repositoryError(err, domainErrors.ErrFailedToGetOrderStats)
+		}
+
+		byDay, err = uc.orderRepo.AggregateByDay(ctx, repoFilter)
+		if err != nil {
+			uc.log(ctx).Error("Failed to aggregate orders by day", "error", err)
+			return nil, repositoryError(err, domainErrors.ErrFailedToGetOrderStats)
+		}
+	}
+
+	response := &dto.OrderStatsResponseDTO{
+		From:     from,
+		To:       to,
+		ByStatus: make([]dto.StatusStatsDTO, 0, len(byStatus)),
+		Daily:    dailySeries(from, to, byDay),
+	}
+
+	for _, aggregate := range byStatus {
+		response.TotalOrders += aggregate.Orders
+		response.TotalRevenue += aggregate.Revenue
+		response.ByStatus = append(response.ByStatus, dto.StatusStatsDTO{
+			Status:  aggregate.Status,
+			Orders:  aggregate.Orders,
+			Revenue: aggregate.Revenue,
+		})
+	}
+
+	if response.TotalOrders > 0 {
+		response.AverageOrderValue = response.TotalRevenue / float64(response.TotalOrders)
+	}
+
+	uc.log(ctx).Info("GetOrderStats success", "from", from, "to", to, "total_orders", response.TotalOrders)
+	return response, nil
+}
+
+// maxStatsRange bounds the stats time series to roughly a year of days
+const maxStatsRange = 366 * 24 * time.Hour
+
+// GetCustomerOrderSummary aggregates every order of a customer. A customer without orders gets
+// an all-zero summary rather than ErrOrderNotFound, customers are not stored by this service.
+func (uc *orderUseCasesImpl) GetCustomerOrderSummary(ctx context.Context, customerID uint) (*dto.CustomerOrderSummaryDTO, error) {
+	uc.log(ctx).Info("GetCustomerOrderSummary use case called", "customer_id", customerID)
+
+	if customerID == 0 {
+		return nil, domainErrors.ErrInvalidCustomerID
+	}
+	if err := uc.authorizeCustomer(ctx, customerID); err != nil {
+		return nil, err
+	}
+
+	byStatus, err := uc.orderRepo.AggregateByStatus(ctx, ports.OrderFilter{CustomerID: customerID})
+	if err != nil {
+		uc.log(ctx).Error("Failed to aggregate customer orders", "customer_id", customerID, "error", err)
+		return nil, repositoryError(err, domainErrors.ErrFailedToGetOrderStats)
+	}
+
+	summary := &dto.CustomerOrderSummaryDTO{
+		CustomerID:     customerID,
+		OrdersByStatus: make(map[entities.OrderStatus]int64),
+	}
+	for _, status := range entities.OrderStatuses() {
+		summary.OrdersByStatus[status] = 0
+	}
+
+	var spendingOrders int64
+	for _, aggregate := range byStatus {
+		summary.TotalOrders += aggregate.Orders
+		summary.OrdersByStatus[aggregate.Status] += aggregate.Orders
+		if aggregate.Status.CountsTowardsSpend() {
+			summary.TotalSpend += aggregate.Revenue
+			spendingOrders += aggregate.Orders
+		}
+
+		if summary.FirstOrderAt == nil || aggregate.FirstCreatedAt.Before(*summary.FirstOrderAt) {
+			first := aggregate.FirstCreatedAt
+			summary.FirstOrderAt = &first
+		}
+		if summary.LastOrderAt == nil || aggregate.LastCreatedAt.After(*summary.LastOrderAt) {
+			last := aggregate.LastCreatedAt
+			summary.LastOrderAt = &last
+		}
+	}
+
+	if spendingOrders > 0 {
+		summary.AverageOrderValue = summary.TotalSpend / float64(spendingOrders)
+	}
+
+	uc.log(ctx).Info("GetCustomerOrderSummary success", "customer_id", customerID, "total_orders", summary.TotalOrders)
+	return summary, nil
+}
+
+// dailySeries returns one entry per day in [from, to), filling days without orders with zeros
+func dailySeries(from, to time.Time, aggregates []ports.DailyAggregate) []dto.DailyStatsDTO {
+	byDate := make(map[string]ports.DailyAggregate, len(aggregates))
+	for _, aggregate := range aggregates {
+		byDate[aggregate.Day.Format(time.DateOnly)] = aggregate
+	}
+
+	series := make([]dto.DailyStatsDTO, 0)
+	for day := from.Truncate(24 * time.Hour); day.Before(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(time.DateOnly)
+		aggregate := byDate[date]
+		series = append(series, dto.DailyStatsDTO{
+			Date:    date,
+			Orders:  aggregate.Orders,
+			Revenue: aggregate.Revenue,
+		})
+	}
+	return series
+}
+
+// ExpirePendingOrders expires up to batchSize pending orders whose expiry time passed at now
+// and returns how many were expired. Orders that fail to expire are logged and skipped.
+func (uc *orderUseCasesImpl) ExpirePendingOrders(ctx context.Context, now time.Time, batchSize int) (int, error) {
+	uc.log(ctx).Debug("ExpirePendingOrders use case called", "before", now, "batch_size", batchSize)
+
+	orders, err := uc.orderRepo.FindExpiredPending(ctx, now, batchSize)
+	if err != nil {
+		uc.log(ctx).Error("Failed to find expired pending orders", "error", err)
+		return 0, repositoryError(err, domainErrors.ErrFailedToExpireOrders)
+	}
+
+	expired := 0
+	for _, order := range orders {
+		expiring := order.Clone()
+		if err := expiring.Expire(now); err != nil {
+			uc.log(ctx).Warn("Failed to expire order", "order_id", order.ID, "error", err)
+			continue
+		}
+
+		updatedOrder, err := uc.orderRepo.Update(ctx, expiring)
+		if err != nil {
+			uc.log(ctx).Error("Failed to update expired order", "order_id", order.ID, "error", err)
+			continue
+		}
+
+		uc.audit(ctx, entities.AuditActionStatusChanged, order.ID, order, updatedOrder)
+		uc.publish(ctx, events.NewOrderEvent(events.OrderExpired, updatedOrder, now))
+		expired++
+	}
+
+	if expired > 0 {
+		uc.log(ctx).Info("ExpirePendingOrders success", "expired", expired)
 	}
+	return expired, nil
+}
 
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
+// modifyOrder reads the order locked for update, applies change and stores it in one unit of work,
+// so no concurrent writer can update the order between the read and the write. Orders of other
+// customers are not found for a customer bound principal. change is applied to a copy of the order,
+// so the order as read stays untouched when change or the update fails. It returns the order as read
+// and as stored. Errors of change are returned unchanged. A status change made by change settles the
+// payment of the order, see settlePayment.
+func (uc *orderUseCasesImpl) modifyOrder(ctx context.Context, orderID uint, change func(order *entities.Order) error) (before, after *entities.Order, err error) {
+	return uc.modifyOrderAndThen(ctx, orderID, change, nil)
+}
+
+// modifyOrderAndThen is modifyOrder running then, when not nil, with the stored order in the same unit
+// of work. An error of then undoes the change.
+func (uc *orderUseCasesImpl) modifyOrderAndThen(
+	ctx context.Context,
+	orderID uint,
+	change func(order *entities.Order) error,
+	then func(ctx context.Context, repos ports.Repositories, order *entities.Order) error,
+) (before, after *entities.Order, err error) {
+	var authorizationID string
+	err = uc.unitOfWork.Do(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		orders := withRepositoryTimeout(repos.Orders, uc.config.RepositoryTimeout)
+		order, err := orders.GetByIDForUpdate(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to get order", "order_id", orderID, "error", err)
+			return err
+		}
+		if err := uc.authorizeCustomer(ctx, order.CustomerID); err != nil {
+			return err
+		}
+		working := order.Clone()
+		if err := change(working); err != nil {
+			return err
+		}
+		authorizationID, err = uc.settlePayment(ctx, order.Status, working)
+		if err != nil {
+			return err
+		}
+
+		after, err = orders.Update(ctx, working)
+		if err != nil {
+			uc.log(ctx).Error("Failed to update order", "order_id", orderID, "error", err)
+			return repositoryError(err, domainErrors.ErrFailedToUpdateOrder)
+		}
+		before = order
+		if then != nil {
+			return then(ctx, repos, after)
+		}
+		return nil
+	})
+	if err != nil {
+		uc.voidAbandonedAuthorization(ctx, orderID, authorizationID)
+		return nil, nil, err
+	}
+	return before, after, nil
+}
+
+// log returns the request logger carried by ctx, tagged with the request ID, falling back to the
+// logger of the use cases for calls outside a request such as those of the workers
+func (uc *orderUseCasesImpl) log(ctx context.Context) logger.Logger {
+	return logger.FromContext(ctx, uc.logger)
+}
+
+// audit records a successful mutation if an audit recorder is configured
+func (uc *orderUseCasesImpl) audit(ctx context.Context, action entities.AuditAction, orderID uint, before, after *entities.Order) {
+	if uc.auditor == nil {
+		return
+	}
+	uc.auditor.Record(ctx, action, orderID, before, after)
+}
+
+// publish sends an event if a publisher is configured. Publishing failures are logged, not returned,
+// since the order change they describe is already stored.
+func (uc *orderUseCasesImpl) publish(ctx context.Context, event events.OrderEvent) {
+	if uc.events == nil {
+		return
+	}
+
+	if err := uc.events.Publish(ctx, event); err != nil {
+		uc.log(ctx).Error("Failed to publish order event", "type", event.Type, "order_id", event.OrderID, "error", err)
+	}
+}
+
+// maxPageSize returns the largest page size currently allowed
+func (uc *orderUseCasesImpl) maxPageSize() int {
+	if uc.config.MaxPageSize == nil {
+		return dto.MaxPageSize
+	}
+	if limit := uc.config.MaxPageSize(); limit > 0 && limit < dto.MaxPageSize {
+		return limit
+	}
+	return dto.MaxPageSize
+}
+
+// validatePagination applies the default page size when pageSize is 0 and rejects
+// negative pages and page sizes outside 1..maxPageSize
+func validatePagination(page, pageSize, maxPageSize int) (int, int, error) {
+	if pageSize == 0 {
+		pageSize = dto.DefaultPageSize
+	}
+
+	details := make(map[string]interface{})
+	if page < 0 || page > dto.MaxPage {
+		details["page"] = fmt.Sprintf("must be between 0 and %d", dto.MaxPage)
+	}
+	if pageSize < 1 || pageSize > maxPageSize {
+		details["page_size"] = fmt.Sprintf("must be between 1 and %d", maxPageSize)
+	}
+	if len(details) > 0 {
+		return 0, 0, domainErrors.ErrInvalidPagination.WithDetails(details)
 	}
 
-	return page, pageSize
+	return page, pageSize, nil
 }
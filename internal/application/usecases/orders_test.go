@@ -2,12 +2,19 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"math"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"orders-service/internal/application/auth"
 	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
 	"orders-service/internal/domain/entities"
 	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
 	"orders-service/pkg/logger"
 
 	"github.com/stretchr/testify/assert"
@@ -36,19 +43,100 @@ func (m *MockOrderRepository) GetByID(ctx context.Context, id uint) (*entities.O
 	return args.Get(0).(*entities.Order), args.Error(1)
 }
 
+func (m *MockOrderRepository) GetByIDIncludingDeleted(ctx context.Context, id uint) (*entities.Order, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Order), args.Error(1)
+}
+
+// GetByIDForUpdate returns a copy of the configured order, as a repository reads a fresh one every time
+func (m *MockOrderRepository) GetByIDForUpdate(ctx context.Context, id uint) (*entities.Order, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Order).Clone(), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetByExternalReference(ctx context.Context, customerID uint, reference string) (*entities.Order, error) {
+	args := m.Called(ctx, customerID, reference)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetByPublicID(ctx context.Context, publicID string) (*entities.Order, error) {
+	args := m.Called(ctx, publicID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetByOrderNumber(ctx context.Context, number string) (*entities.Order, error) {
+	args := m.Called(ctx, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) NextOrderNumberSequence(ctx context.Context, scope string) (uint64, error) {
+	args := m.Called(ctx, scope)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+// Update returns the configured order, or the result of a configured func(*entities.Order) *entities.Order
+// such as storeInto
 func (m *MockOrderRepository) Update(ctx context.Context, order *entities.Order) (*entities.Order, error) {
 	args := m.Called(ctx, order)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
+	if store, ok := args.Get(0).(func(*entities.Order) *entities.Order); ok {
+		return store(order), args.Error(1)
+	}
 	return args.Get(0).(*entities.Order), args.Error(1)
 }
 
+// storeInto returns an Update result that writes the updated order over stored and returns it, so
+// later reads configured with stored see the update
+func storeInto(stored *entities.Order) func(*entities.Order) *entities.Order {
+	return func(order *entities.Order) *entities.Order {
+		*stored = *order.Clone()
+		return order.Clone()
+	}
+}
+
 func (m *MockOrderRepository) Delete(ctx context.Context, id uint) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *MockOrderRepository) Restore(ctx context.Context, id uint) (*entities.Order, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) ListDeleted(ctx context.Context, limit, offset int) ([]*entities.Order, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) CountDeleted(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockOrderRepository) List(ctx context.Context, limit, offset int) ([]*entities.Order, error) {
 	args := m.Called(ctx, limit, offset)
 	if args.Get(0) == nil {
@@ -73,6 +161,51 @@ func (m *MockOrderRepository) GetByStatus(ctx context.Context, status entities.O
 	return args.Get(0).([]*entities.Order), args.Error(1)
 }
 
+func (m *MockOrderRepository) ListRecentByItemSet(ctx context.Context, customerID uint, itemSetHash string, since time.Time) ([]*entities.Order, error) {
+	args := m.Called(ctx, customerID, itemSetHash, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) ListByFilterOrderedByPriority(ctx context.Context, filter ports.OrderFilter, limit, offset int) ([]*entities.Order, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetByCustomerIDAndStatus(ctx context.Context, customerID uint, status entities.OrderStatus, limit, offset int) ([]*entities.Order, error) {
+	args := m.Called(ctx, customerID, status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) ListByDateRange(ctx context.Context, from, to time.Time, limit, offset int) ([]*entities.Order, error) {
+	args := m.Called(ctx, from, to, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) CountByFilter(ctx context.Context, filter ports.OrderFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOrderRepository) ListByFilter(ctx context.Context, filter ports.OrderFilter, limit, offset int) ([]*entities.Order, int64, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*entities.Order), args.Get(1).(int64), args.Error(2)
+}
+
 func (m *MockOrderRepository) Count(ctx context.Context) (int64, error) {
 	args := m.Called(ctx)
 	return args.Get(0).(int64), args.Error(1)
@@ -88,6 +221,66 @@ func (m *MockOrderRepository) CountByStatus(ctx context.Context, status entities
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockOrderRepository) CountByCustomerIDAndStatus(ctx context.Context, customerID uint, status entities.OrderStatus) (int64, error) {
+	args := m.Called(ctx, customerID, status)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOrderRepository) FindExpiredPending(ctx context.Context, before time.Time, limit int) ([]*entities.Order, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Order), args.Error(1)
+}
+
+// WithCustomerLock runs fn directly unless the expectation returns an error
+func (m *MockOrderRepository) WithCustomerLock(ctx context.Context, customerID uint, fn func(ctx context.Context) error) error {
+	args := m.Called(ctx, customerID)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(ctx)
+}
+
+func (m *MockOrderRepository) StreamByFilter(ctx context.Context, filter ports.OrderFilter, batchSize int, fn func(order *entities.Order) error) error {
+	args := m.Called(ctx, filter, batchSize)
+	if orders, ok := args.Get(0).([]*entities.Order); ok {
+		for _, order := range orders {
+			if err := fn(order); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockOrderRepository) CountItemsByFilter(ctx context.Context, filter ports.OrderFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOrderRepository) MaxUpdatedAt(ctx context.Context, filter ports.OrderFilter) (time.Time, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockOrderRepository) AggregateByStatus(ctx context.Context, filter ports.OrderFilter) ([]ports.StatusAggregate, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ports.StatusAggregate), args.Error(1)
+}
+
+func (m *MockOrderRepository) AggregateByDay(ctx context.Context, filter ports.OrderFilter) ([]ports.DailyAggregate, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ports.DailyAggregate), args.Error(1)
+}
+
 func setupTestOrderUseCases() (OrderUseCases, *MockOrderRepository) {
 	mockRepo := new(MockOrderRepository)
 	log := logger.New("test")
@@ -134,7 +327,10 @@ func TestOrderUseCases_CreateOrder_Success(t *testing.T) {
 		UpdatedAt:   time.Now(),
 	}
 
-	mockRepo.On("Create", ctx, mock.MatchedBy(func(order *entities.Order) bool {
+	mockRepo.On("WithCustomerLock", mock.Anything, uint(123)).Return(nil)
+	mockRepo.On("CountByCustomerIDAndStatus", mock.Anything, uint(123), entities.OrderStatusPending).Return(int64(0), nil)
+	mockRepo.On("NextOrderNumberSequence", mock.Anything, mock.Anything).Return(uint64(1), nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
 		return order.CustomerID == 123 &&
 			order.Status == entities.OrderStatusPending &&
 			len(order.Items) == 1
@@ -169,537 +365,2559 @@ func TestOrderUseCases_CreateOrder_InvalidCustomerID(t *testing.T) {
 	result, err := useCases.CreateOrder(ctx, request)
 
 	// Then
-	assert.Error(t, err)
 	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "customer ID is required")
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidCustomerID)
 }
 
-func TestOrderUseCases_CreateOrder_RepositoryError(t *testing.T) {
+func TestOrderUseCases_CreateOrder_InvalidDetails(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  dto.CreateOrderRequestDTO
+		expected error
+	}{
+		{
+			name:     "external reference over 100 characters",
+			request:  dto.CreateOrderRequestDTO{CustomerID: 1, ExternalReference: strings.Repeat("é", 101)},
+			expected: domainErrors.ErrInvalidExternalReference,
+		},
+		{
+			name:     "more than 10 tags",
+			request:  dto.CreateOrderRequestDTO{CustomerID: 1, Tags: strings.Split("a b c d e f g h i j k", " ")},
+			expected: domainErrors.ErrInvalidOrderTags,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo := setupTestOrderUseCases()
+
+			// When
+			result, err := useCases.CreateOrder(context.Background(), &tt.request)
+
+			// Then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.expected)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCases_CreateOrder_InvalidItems(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestOrderUseCases()
 	ctx := context.Background()
 
 	request := &dto.CreateOrderRequestDTO{
 		CustomerID: 123,
-		Items:      []dto.CreateOrderItemDTO{},
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 1, UnitPrice: 0},
+			{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 5},
+			{ProductID: 3, ProductSKU: " ", ProductName: "Product 3", Quantity: 0, UnitPrice: 5},
+		},
 	}
 
-	mockRepo.On("Create", ctx, mock.Anything).Return(nil, assert.AnError)
-
 	// When
 	result, err := useCases.CreateOrder(ctx, request)
 
 	// Then
-	assert.Error(t, err)
 	assert.Nil(t, result)
-	assert.Equal(t, domainErrors.ErrFailedToCreateOrder, err)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidOrderItems)
 
-	mockRepo.AssertExpectations(t)
+	var domainErr *domainErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, map[string]interface{}{
+		"items[0].unit_price":  "unit price must be positive",
+		"items[2].product_sku": "product SKU is required",
+		"items[2].quantity":    "quantity must be positive",
+	}, domainErr.Details)
+
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 }
 
-// GetOrder Tests
-func TestOrderUseCases_GetOrder_Success(t *testing.T) {
+func TestOrderUseCases_CreateOrder_DuplicateItems(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestOrderUseCases()
 	ctx := context.Background()
 
-	expectedOrder := &entities.Order{
-		ID:          1,
-		CustomerID:  123,
-		Items:       []entities.OrderItem{},
-		TotalAmount: 100.00,
-		Status:      entities.OrderStatusConfirmed,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+	request := &dto.CreateOrderRequestDTO{
+		CustomerID: 123,
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 1, UnitPrice: 5},
+			{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 5},
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 5},
+		},
 	}
 
-	mockRepo.On("GetByID", ctx, uint(1)).Return(expectedOrder, nil)
-
 	// When
-	result, err := useCases.GetOrder(ctx, 1)
+	_, err := useCases.CreateOrder(ctx, request)
 
-	// Then
-	require.NoError(t, err)
-	require.NotNil(t, result)
-	assert.Equal(t, uint(1), result.ID)
-	assert.Equal(t, uint(123), result.CustomerID)
-	assert.Equal(t, entities.OrderStatusConfirmed, result.Status)
+	// Then the repeated product is rejected instead of merged
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidOrderItems)
 
-	mockRepo.AssertExpectations(t)
+	var domainErr *domainErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, map[string]interface{}{
+		"items[2].product_id": "duplicate product ID 1, already listed at item 0",
+	}, domainErr.Details)
+
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 }
 
-func TestOrderUseCases_GetOrder_NotFound(t *testing.T) {
+func TestOrderUseCases_CreateOrder_RepositoryError(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestOrderUseCases()
 	ctx := context.Background()
 
-	mockRepo.On("GetByID", ctx, uint(999)).Return(nil, domainErrors.ErrOrderNotFound)
+	request := &dto.CreateOrderRequestDTO{
+		CustomerID: 123,
+		Items:      []dto.CreateOrderItemDTO{},
+	}
+
+	mockRepo.On("WithCustomerLock", mock.Anything, uint(123)).Return(nil)
+	mockRepo.On("CountByCustomerIDAndStatus", mock.Anything, uint(123), entities.OrderStatusPending).Return(int64(0), nil)
+	mockRepo.On("NextOrderNumberSequence", mock.Anything, mock.Anything).Return(uint64(1), nil)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil, assert.AnError)
 
 	// When
-	result, err := useCases.GetOrder(ctx, 999)
+	result, err := useCases.CreateOrder(ctx, request)
 
 	// Then
 	assert.Error(t, err)
 	assert.Nil(t, result)
-	assert.Equal(t, domainErrors.ErrOrderNotFound, err)
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToCreateOrder)
+	assert.ErrorIs(t, err, assert.AnError, "the repository failure is kept as the cause")
 
 	mockRepo.AssertExpectations(t)
 }
 
-// AddItemToOrder Tests
-func TestOrderUseCases_AddItemToOrder_Success(t *testing.T) {
+func TestOrderUseCases_CreateOrder_DuplicateExternalReference(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestOrderUseCases()
 	ctx := context.Background()
 
-	existingOrder := &entities.Order{
-		ID:          1,
-		CustomerID:  123,
-		Items:       []entities.OrderItem{},
-		TotalAmount: 0.00,
-		Status:      entities.OrderStatusPending,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-	}
-
-	request := &dto.AddOrderItemRequestDTO{
-		ProductID:   1,
-		ProductSKU:  "SKU-001",
-		ProductName: "Product 1",
-		Quantity:    2,
-		UnitPrice:   10.50,
+	request := &dto.CreateOrderRequestDTO{
+		CustomerID:        123,
+		ExternalReference: "PO-1001",
 	}
 
-	mockRepo.On("GetByID", ctx, uint(1)).Return(existingOrder, nil)
-	mockRepo.On("Update", ctx, mock.MatchedBy(func(order *entities.Order) bool {
-		return order.ID == 1 && len(order.Items) == 1
-	})).Return(existingOrder, nil)
+	mockRepo.On("WithCustomerLock", mock.Anything, uint(123)).Return(nil)
+	mockRepo.On("CountByCustomerIDAndStatus", mock.Anything, uint(123), entities.OrderStatusPending).Return(int64(0), nil)
+	mockRepo.On("NextOrderNumberSequence", mock.Anything, mock.Anything).Return(uint64(1), nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.ExternalReference == "PO-1001"
+	})).Return(nil, domainErrors.ErrDuplicateExternalReference)
 
 	// When
-	result, err := useCases.AddItemToOrder(ctx, 1, request)
+	result, err := useCases.CreateOrder(ctx, request)
 
 	// Then
-	require.NoError(t, err)
-	require.NotNil(t, result)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrDuplicateExternalReference)
 
 	mockRepo.AssertExpectations(t)
 }
 
-func TestOrderUseCases_AddItemToOrder_OrderNotFound(t *testing.T) {
+func TestOrderUseCases_CreateOrder_TooManyPendingOrders(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestOrderUseCases()
 	ctx := context.Background()
 
-	request := &dto.AddOrderItemRequestDTO{
-		ProductID:   1,
-		ProductSKU:  "SKU-001",
-		ProductName: "Product 1",
-		Quantity:    1,
-		UnitPrice:   10.00,
-	}
+	request := &dto.CreateOrderRequestDTO{CustomerID: 123}
 
-	mockRepo.On("GetByID", ctx, uint(999)).Return(nil, domainErrors.ErrOrderNotFound)
+	mockRepo.On("NextOrderNumberSequence", mock.Anything, mock.Anything).Return(uint64(1), nil)
+	mockRepo.On("WithCustomerLock", mock.Anything, uint(123)).Return(nil)
+	mockRepo.On("CountByCustomerIDAndStatus", mock.Anything, uint(123), entities.OrderStatusPending).Return(int64(10), nil)
 
 	// When
-	result, err := useCases.AddItemToOrder(ctx, 999, request)
+	result, err := useCases.CreateOrder(ctx, request)
 
 	// Then
-	assert.Error(t, err)
 	assert.Nil(t, result)
-	assert.Equal(t, domainErrors.ErrOrderNotFound, err)
+	require.ErrorIs(t, err, domainErrors.ErrTooManyPendingOrders)
 
-	mockRepo.AssertExpectations(t)
+	var domainErr *domainErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, int64(10), domainErr.Details["pending_orders"])
+	assert.Equal(t, 10, domainErr.Details["limit"])
+
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 }
 
-// RemoveItemFromOrder Tests
-func TestOrderUseCases_RemoveItemFromOrder_Success(t *testing.T) {
-	// Given
-	useCases, mockRepo := setupTestOrderUseCases()
-	ctx := context.Background()
+func TestOrderUseCases_CreateOrder_PendingLimitSkipped(t *testing.T) {
+	request := &dto.CreateOrderRequestDTO{CustomerID: 123}
+	createdOrder := &entities.Order{ID: 1, CustomerID: 123, Status: entities.OrderStatusPending}
+
+	t.Run("admin caller", func(t *testing.T) {
+		// Given
+		useCases, mockRepo := setupTestOrderUseCases()
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{
+			Name:   "ops",
+			Scopes: []auth.Scope{auth.ScopeOrdersAdmin},
+		})
+
+		mockRepo.On("NextOrderNumberSequence", mock.Anything, mock.Anything).Return(uint64(1), nil)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(createdOrder, nil)
+
+		// When
+		result, err := useCases.CreateOrder(ctx, request)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), result.ID)
+		mockRepo.AssertNotCalled(t, "WithCustomerLock", mock.Anything, mock.Anything)
+	})
+
+	t.Run("limit disabled", func(t *testing.T) {
+		// Given
+		mockRepo := new(MockOrderRepository)
+		config := DefaultOrderUseCasesConfig()
+		config.MaxPendingOrdersPerCustomer = 0
+		useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config)
+		ctx := context.Background()
+
+		mockRepo.On("NextOrderNumberSequence", mock.Anything, mock.Anything).Return(uint64(1), nil)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(createdOrder, nil)
+
+		// When
+		result, err := useCases.CreateOrder(ctx, request)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), result.ID)
+		mockRepo.AssertNotCalled(t, "CountByCustomerIDAndStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
 
-	existingOrder, _ := entities.NewOrder(123)
-	existingOrder.ID = 1
-	existingOrder.AddItem(1, "SKU-001", "Product 1", 2, 10.50)
+// lockingOrderRepository serializes WithCustomerLock like the database lock and keeps created orders in memory
+type lockingOrderRepository struct {
+	MockOrderRepository
+	mu       sync.Mutex
+	pending  int64
+	sequence uint64
+}
 
-	mockRepo.On("GetByID", ctx, uint(1)).Return(existingOrder, nil)
-	mockRepo.On("Update", ctx, mock.MatchedBy(func(order *entities.Order) bool {
-		return order.ID == 1 && len(order.Items) == 0
-	})).Return(existingOrder, nil)
+func (r *lockingOrderRepository) WithCustomerLock(ctx context.Context, customerID uint, fn func(ctx context.Context) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return fn(ctx)
+}
+
+func (r *lockingOrderRepository) CountByCustomerIDAndStatus(ctx context.Context, customerID uint, status entities.OrderStatus) (int64, error) {
+	return r.pending, nil
+}
+
+func (r *lockingOrderRepository) NextOrderNumberSequence(ctx context.Context, scope string) (uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sequence++
+	return r.sequence, nil
+}
+
+func (r *lockingOrderRepository) Create(ctx context.Context, order *entities.Order) (*entities.Order, error) {
+	// Widen the window between count and insert so an unlocked implementation would let both through
+	time.Sleep(10 * time.Millisecond)
+	r.pending++
+	created := *order
+	created.ID = uint(r.pending)
+	return &created, nil
+}
+
+func TestOrderUseCases_CreateOrder_ConcurrentCreatesAtLimit(t *testing.T) {
+	// Given
+	repo := &lockingOrderRepository{pending: 9}
+	useCases := NewOrderUseCases(repo, logger.New("test"))
 
 	// When
-	result, err := useCases.RemoveItemFromOrder(ctx, 1, 1)
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = useCases.CreateOrder(context.Background(), &dto.CreateOrderRequestDTO{CustomerID: 123})
+		}(i)
+	}
+	wg.Wait()
 
 	// Then
-	require.NoError(t, err)
-	require.NotNil(t, result)
-
-	mockRepo.AssertExpectations(t)
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+		} else {
+			assert.ErrorIs(t, err, domainErrors.ErrTooManyPendingOrders)
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, int64(10), repo.pending)
 }
 
-// UpdateItemQuantity Tests
-func TestOrderUseCases_UpdateItemQuantity_Success(t *testing.T) {
+// GetOrderByExternalReference Tests
+func TestOrderUseCases_GetOrderByExternalReference(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestOrderUseCases()
 	ctx := context.Background()
 
-	existingOrder, _ := entities.NewOrder(123)
-	existingOrder.ID = 1
-	existingOrder.AddItem(1, "SKU-001", "Product 1", 2, 10.50)
+	order := &entities.Order{ID: 7, CustomerID: 123, ExternalReference: "PO-1001", Status: entities.OrderStatusPending}
+	mockRepo.On("GetByExternalReference", ctx, uint(123), "PO-1001").Return(order, nil)
+	mockRepo.On("GetByExternalReference", ctx, uint(123), "PO-404").Return(nil, domainErrors.ErrOrderNotFound)
 
-	request := &dto.UpdateOrderItemQuantityRequestDTO{
-		Quantity: 5,
-	}
+	// When
+	result, err := useCases.GetOrderByExternalReference(ctx, 123, " PO-1001 ")
 
-	mockRepo.On("GetByID", ctx, uint(1)).Return(existingOrder, nil)
-	mockRepo.On("Update", ctx, mock.Anything).Return(existingOrder, nil)
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), result.ID)
+	assert.Equal(t, "PO-1001", result.ExternalReference)
 
 	// When
-	result, err := useCases.UpdateItemQuantity(ctx, 1, 1, request)
+	result, err = useCases.GetOrderByExternalReference(ctx, 123, "PO-404")
 
 	// Then
-	require.NoError(t, err)
-	require.NotNil(t, result)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
 
 	mockRepo.AssertExpectations(t)
 }
 
-// ConfirmOrder Tests
-func TestOrderUseCases_ConfirmOrder_Success(t *testing.T) {
+func TestOrderUseCases_GetOrderByNumber(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestOrderUseCases()
 	ctx := context.Background()
 
-	existingOrder, _ := entities.NewOrder(123)
-	existingOrder.ID = 1
-	existingOrder.AddItem(1, "SKU-001", "Product 1", 2, 10.50)
-
-	mockRepo.On("GetByID", ctx, uint(1)).Return(existingOrder, nil)
-	mockRepo.On("Update", ctx, mock.MatchedBy(func(order *entities.Order) bool {
-		return order.Status == entities.OrderStatusConfirmed
-	})).Return(existingOrder, nil)
+	order := &entities.Order{ID: 7, CustomerID: 123, OrderNumber: "ORD-2025-000123", Status: entities.OrderStatusPending}
+	mockRepo.On("GetByOrderNumber", ctx, "ORD-2025-000123").Return(order, nil)
+	mockRepo.On("GetByOrderNumber", ctx, "ORD-2025-999999").Return(nil, domainErrors.ErrOrderNotFound)
 
 	// When
-	result, err := useCases.ConfirmOrder(ctx, 1)
+	result, err := useCases.GetOrderByNumber(ctx, " ORD-2025-000123 ")
 
 	// Then
 	require.NoError(t, err)
-	require.NotNil(t, result)
-	assert.Equal(t, entities.OrderStatusConfirmed, result.Status)
+	assert.Equal(t, uint(7), result.ID)
+	assert.Equal(t, "ORD-2025-000123", result.OrderNumber)
+
+	// When
+	result, err = useCases.GetOrderByNumber(ctx, "ORD-2025-999999")
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
 
 	mockRepo.AssertExpectations(t)
 }
 
-func TestOrderUseCases_ConfirmOrder_EmptyOrder(t *testing.T) {
+func TestOrderUseCases_GetOrderByPublicID(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestOrderUseCases()
 	ctx := context.Background()
+	publicID := "3f2504e0-4f89-41d3-9a0c-0305e82c3301"
 
-	existingOrder, _ := entities.NewOrder(123)
-	existingOrder.ID = 1
-	// No items added
+	order := &entities.Order{ID: 7, PublicID: publicID, CustomerID: 123, Status: entities.OrderStatusPending}
+	mockRepo.On("GetByPublicID", ctx, publicID).Return(order, nil)
+
+	// When
+	result, err := useCases.GetOrderByPublicID(ctx, " 3F2504E0-4F89-41D3-9A0C-0305E82C3301")
 
-	mockRepo.On("GetByID", ctx, uint(1)).Return(existingOrder, nil)
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), result.ID)
+	assert.Equal(t, publicID, result.PublicID)
 
 	// When
-	result, err := useCases.ConfirmOrder(ctx, 1)
+	id, err := useCases.ResolveOrderID(ctx, publicID)
 
 	// Then
-	assert.Error(t, err)
-	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "cannot confirm empty order")
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), id)
+
+	// When
+	_, err = useCases.ResolveOrderID(ctx, "not-a-uuid")
 
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
 	mockRepo.AssertExpectations(t)
 }
 
-// CancelOrder Tests
-func TestOrderUseCases_CancelOrder_Success(t *testing.T) {
+func TestOrderUseCases_ResolveOrderID_OtherCustomer(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestOrderUseCases()
-	ctx := context.Background()
+	ctx := customerContext(5, auth.ScopeOrdersRead)
+	publicID := "3f2504e0-4f89-41d3-9a0c-0305e82c3301"
 
-	existingOrder, _ := entities.NewOrder(123)
-	existingOrder.ID = 1
-	existingOrder.Status = entities.OrderStatusPending
+	mockRepo.On("GetByPublicID", ctx, publicID).Return(&entities.Order{ID: 7, PublicID: publicID, CustomerID: 123}, nil)
 
-	mockRepo.On("GetByID", ctx, uint(1)).Return(existingOrder, nil)
-	mockRepo.On("Update", ctx, mock.MatchedBy(func(order *entities.Order) bool {
-		return order.Status == entities.OrderStatusCancelled
-	})).Return(existingOrder, nil)
+	// When
+	id, err := useCases.ResolveOrderID(ctx, publicID)
+
+	// Then
+	assert.Zero(t, id)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+}
+
+func TestOrderUseCases_CreateOrder_AssignsOrderNumber(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	config := DefaultOrderUseCasesConfig()
+	config.MaxPendingOrdersPerCustomer = 0
+	config.OrderNumberFormat = entities.OrderNumberFormat{Prefix: "SO", Suffix: entities.OrderNumberSuffixSequence, Digits: 4}
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config)
+	ctx := context.Background()
+
+	mockRepo.On("NextOrderNumberSequence", mock.Anything, "SO").Return(uint64(1), nil).Once()
+	mockRepo.On("NextOrderNumberSequence", mock.Anything, "SO").Return(uint64(2), nil).Once()
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.OrderNumber == "SO-0001"
+	})).Return(nil, domainErrors.ErrDuplicateOrderNumber).Once()
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.OrderNumber == "SO-0002"
+	})).Return(&entities.Order{ID: 1, CustomerID: 123, OrderNumber: "SO-0002", Status: entities.OrderStatusPending}, nil).Once()
 
 	// When
-	result, err := useCases.CancelOrder(ctx, 1)
+	result, err := useCases.CreateOrder(ctx, &dto.CreateOrderRequestDTO{CustomerID: 123})
 
 	// Then
 	require.NoError(t, err)
-	require.NotNil(t, result)
-	assert.Equal(t, entities.OrderStatusCancelled, result.Status)
-
+	assert.Equal(t, "SO-0002", result.OrderNumber)
 	mockRepo.AssertExpectations(t)
 }
 
-func TestOrderUseCases_CancelOrder_CannotCancel(t *testing.T) {
+func TestOrderUseCases_CreateOrder_OrderNumbersExhausted(t *testing.T) {
 	// Given
-	useCases, mockRepo := setupTestOrderUseCases()
+	mockRepo := new(MockOrderRepository)
+	config := DefaultOrderUseCasesConfig()
+	config.MaxPendingOrdersPerCustomer = 0
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config)
 	ctx := context.Background()
 
-	existingOrder, _ := entities.NewOrder(123)
-	existingOrder.ID = 1
-	existingOrder.Status = entities.OrderStatusDelivered // Cannot cancel delivered orders
-
-	mockRepo.On("GetByID", ctx, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("NextOrderNumberSequence", mock.Anything, mock.Anything).Return(uint64(1), nil)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDuplicateOrderNumber)
 
 	// When
-	result, err := useCases.CancelOrder(ctx, 1)
+	result, err := useCases.CreateOrder(ctx, &dto.CreateOrderRequestDTO{CustomerID: 123})
 
 	// Then
-	assert.Error(t, err)
 	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "order cannot be cancelled")
-
-	mockRepo.AssertExpectations(t)
+	assert.ErrorIs(t, err, domainErrors.ErrDuplicateOrderNumber)
+	mockRepo.AssertNumberOfCalls(t, "Create", maxOrderNumberAttempts)
 }
 
-// TransitionOrderStatus Tests
-func TestOrderUseCases_TransitionOrderStatus_ToProcessing(t *testing.T) {
+// GetOrder Tests
+func TestOrderUseCases_GetOrder_Success(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestOrderUseCases()
 	ctx := context.Background()
 
-	existingOrder, _ := entities.NewOrder(123)
-	existingOrder.ID = 1
-	existingOrder.Status = entities.OrderStatusConfirmed
-
-	request := &dto.UpdateOrderStatusRequestDTO{
-		Status: entities.OrderStatusProcessing,
+	expectedOrder := &entities.Order{
+		ID:          1,
+		CustomerID:  123,
+		Items:       []entities.OrderItem{},
+		TotalAmount: 100.00,
+		Status:      entities.OrderStatusConfirmed,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
 	}
 
-	mockRepo.On("GetByID", ctx, uint(1)).Return(existingOrder, nil)
-	mockRepo.On("Update", ctx, mock.MatchedBy(func(order *entities.Order) bool {
-		return order.Status == entities.OrderStatusProcessing
-	})).Return(existingOrder, nil)
+	mockRepo.On("GetByID", ctx, uint(1)).Return(expectedOrder, nil)
 
 	// When
-	result, err := useCases.TransitionOrderStatus(ctx, 1, request)
+	result, err := useCases.GetOrder(ctx, 1)
 
 	// Then
 	require.NoError(t, err)
 	require.NotNil(t, result)
-	assert.Equal(t, entities.OrderStatusProcessing, result.Status)
+	assert.Equal(t, uint(1), result.ID)
+	assert.Equal(t, uint(123), result.CustomerID)
+	assert.Equal(t, entities.OrderStatusConfirmed, result.Status)
 
 	mockRepo.AssertExpectations(t)
 }
 
-func TestOrderUseCases_TransitionOrderStatus_InvalidStatus(t *testing.T) {
+func TestOrderUseCases_GetOrder_NotFound(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestOrderUseCases()
 	ctx := context.Background()
 
-	existingOrder, _ := entities.NewOrder(123)
-	existingOrder.ID = 1
-
-	request := &dto.UpdateOrderStatusRequestDTO{
-		Status: "invalid_status",
-	}
-
-	mockRepo.On("GetByID", ctx, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("GetByID", ctx, uint(999)).Return(nil, domainErrors.ErrOrderNotFound)
 
 	// When
-	result, err := useCases.TransitionOrderStatus(ctx, 1, request)
+	result, err := useCases.GetOrder(ctx, 999)
 
 	// Then
 	assert.Error(t, err)
 	assert.Nil(t, result)
-	assert.Equal(t, domainErrors.ErrInvalidOrderStatus, err)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
 
 	mockRepo.AssertExpectations(t)
 }
 
-// GetCustomerOrders Tests
-func TestOrderUseCases_GetCustomerOrders_Success(t *testing.T) {
+// AddItemToOrder Tests
+func TestOrderUseCases_AddItemToOrder_Success(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestOrderUseCases()
 	ctx := context.Background()
 
-	expectedOrders := []*entities.Order{
-		{
-			ID:          1,
-			CustomerID:  123,
-			Items:       []entities.OrderItem{},
-			TotalAmount: 100.00,
-			Status:      entities.OrderStatusPending,
-		},
-		{
-			ID:          2,
-			CustomerID:  123,
-			Items:       []entities.OrderItem{},
-			TotalAmount: 200.00,
-			Status:      entities.OrderStatusConfirmed,
-		},
+	existingOrder := &entities.Order{
+		ID:          1,
+		CustomerID:  123,
+		Items:       []entities.OrderItem{},
+		TotalAmount: 0.00,
+		Status:      entities.OrderStatusPending,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
 	}
 
-	mockRepo.On("GetByCustomerID", ctx, uint(123), 10, 0).Return(expectedOrders, nil)
-	mockRepo.On("CountByCustomerID", ctx, uint(123)).Return(int64(2), nil)
+	request := &dto.AddOrderItemRequestDTO{
+		ProductID:   1,
+		ProductSKU:  "SKU-001",
+		ProductName: "Product 1",
+		Quantity:    2,
+		UnitPrice:   10.50,
+	}
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.ID == 1 && len(order.Items) == 1
+	})).Return(existingOrder, nil)
 
 	// When
-	result, err := useCases.GetCustomerOrders(ctx, 123, 0, 10)
+	result, err := useCases.AddItemToOrder(ctx, 1, request)
 
 	// Then
 	require.NoError(t, err)
 	require.NotNil(t, result)
-	assert.Len(t, result.Orders, 2)
-	assert.Equal(t, int64(2), result.Total)
-	assert.Equal(t, 0, result.Page)
-	assert.Equal(t, 10, result.PageSize)
 
 	mockRepo.AssertExpectations(t)
 }
 
-// GetOrdersByStatus Tests
-func TestOrderUseCases_GetOrdersByStatus_Success(t *testing.T) {
-	// Given
-	useCases, mockRepo := setupTestOrderUseCases()
-	ctx := context.Background()
+func TestOrderUseCases_AddItemToOrder_OnDuplicate(t *testing.T) {
+	newExistingOrder := func() *entities.Order {
+		order, _ := entities.NewOrder(123)
+		order.ID = 1
+		require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 2, 10))
+		return order
+	}
+	request := func(policy entities.DuplicateItemPolicy) *dto.AddOrderItemRequestDTO {
+		return &dto.AddOrderItemRequestDTO{
+			ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 3, UnitPrice: 10, OnDuplicate: policy,
+		}
+	}
 
-	expectedOrders := []*entities.Order{
-		{
-			ID:          1,
-			CustomerID:  123,
-			Items:       []entities.OrderItem{},
-			TotalAmount: 100.00,
-			Status:      entities.OrderStatusPending,
-		},
+	for _, policy := range []entities.DuplicateItemPolicy{"", entities.DuplicateItemMerge} {
+		t.Run("merge "+string(policy), func(t *testing.T) {
+			// Given
+			useCases, mockRepo := setupTestOrderUseCases()
+			ctx := context.Background()
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(newExistingOrder(), nil)
+			mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+				return len(order.Items) == 1 && order.Items[0].Quantity == 5
+			})).Return(newExistingOrder(), nil)
+
+			// When
+			_, err := useCases.AddItemToOrder(ctx, 1, request(policy))
+
+			// Then
+			require.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
 	}
 
-	mockRepo.On("GetByStatus", ctx, entities.OrderStatusPending, 10, 0).Return(expectedOrders, nil)
-	mockRepo.On("CountByStatus", ctx, entities.OrderStatusPending).Return(int64(1), nil)
+	t.Run("reject", func(t *testing.T) {
+		// Given
+		useCases, mockRepo := setupTestOrderUseCases()
+		ctx := context.Background()
+		mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(newExistingOrder(), nil)
 
-	// When
-	result, err := useCases.GetOrdersByStatus(ctx, entities.OrderStatusPending, 0, 10)
+		// When
+		result, err := useCases.AddItemToOrder(ctx, 1, request(entities.DuplicateItemReject))
 
-	// Then
-	require.NoError(t, err)
-	require.NotNil(t, result)
-	assert.Len(t, result.Orders, 1)
-	assert.Equal(t, int64(1), result.Total)
+		// Then
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateOrderItem)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
 
-	mockRepo.AssertExpectations(t)
+func TestOrderUseCases_AddItemToOrder_InvalidUnitPrice(t *testing.T) {
+	tests := []struct {
+		name      string
+		quantity  int
+		unitPrice float64
+		expected  string
+	}{
+		{"fraction of a cent", 1, 9.999, "9.999"},
+		{"above the limit", 1, 1000000.01, "1.00000001e+06"},
+		{"line total past exact amounts", 1000, 1e13, "1e+13"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo := setupTestOrderUseCases()
+			ctx := context.Background()
+
+			order, _ := entities.NewOrder(123)
+			order.ID = 1
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+
+			// When
+			result, err := useCases.AddItemToOrder(ctx, 1, &dto.AddOrderItemRequestDTO{
+				ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: tt.quantity, UnitPrice: tt.unitPrice,
+			})
+
+			// Then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidUnitPrice)
+			var domainErr *domainErrors.DomainError
+			require.ErrorAs(t, err, &domainErr)
+			assert.Equal(t, tt.expected, domainErr.Details["unit_price"])
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
 }
 
-// ListOrders Tests
-func TestOrderUseCases_ListOrders_Success(t *testing.T) {
+func TestOrderUseCases_AddItemToOrder_MergeOverflowingQuantity(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestOrderUseCases()
 	ctx := context.Background()
 
-	expectedOrders := []*entities.Order{
-		{
-			ID:          1,
-			CustomerID:  123,
-			Items:       []entities.OrderItem{},
-			TotalAmount: 100.00,
-			Status:      entities.OrderStatusPending,
-		},
-		{
-			ID:          2,
-			CustomerID:  456,
-			Items:       []entities.OrderItem{},
-			TotalAmount: 200.00,
-			Status:      entities.OrderStatusConfirmed,
-		},
-	}
-
-	mockRepo.On("List", ctx, 10, 0).Return(expectedOrders, nil)
-	mockRepo.On("Count", ctx).Return(int64(50), nil)
+	order, _ := entities.NewOrder(123)
+	order.ID = 1
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", entities.MaxLineQuantity, 10))
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
 
 	// When
-	result, err := useCases.ListOrders(ctx, 0, 10)
+	result, err := useCases.AddItemToOrder(ctx, 1, &dto.AddOrderItemRequestDTO{
+		ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 1, UnitPrice: 10,
+	})
 
 	// Then
-	require.NoError(t, err)
-	require.NotNil(t, result)
-	assert.Len(t, result.Orders, 2)
-	assert.Equal(t, int64(50), result.Total)
-	assert.Equal(t, 0, result.Page)
-	assert.Equal(t, 10, result.PageSize)
-
-	mockRepo.AssertExpectations(t)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrQuantityLimitExceeded)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 }
 
-func TestOrderUseCases_ListOrders_InvalidPagination(t *testing.T) {
+func TestOrderUseCases_AddItemToOrder_OrderNotFound(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestOrderUseCases()
 	ctx := context.Background()
 
-	mockRepo.On("List", ctx, 10, 0).Return([]*entities.Order{}, nil)
-	mockRepo.On("Count", ctx).Return(int64(0), nil)
+	request := &dto.AddOrderItemRequestDTO{
+		ProductID:   1,
+		ProductSKU:  "SKU-001",
+		ProductName: "Product 1",
+		Quantity:    1,
+		UnitPrice:   10.00,
+	}
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(999)).Return(nil, domainErrors.ErrOrderNotFound)
 
-	// When - Pass invalid pagination parameters
-	result, err := useCases.ListOrders(ctx, -1, 150)
+	// When
+	result, err := useCases.AddItemToOrder(ctx, 999, request)
 
 	// Then
-	require.NoError(t, err)
-	require.NotNil(t, result)
-	assert.Equal(t, 0, result.Page)      // Should default to 0
-	assert.Equal(t, 10, result.PageSize) // Should default to 10
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
 
 	mockRepo.AssertExpectations(t)
 }
 
-// DeleteOrder Tests
-func TestOrderUseCases_DeleteOrder_Success(t *testing.T) {
+// loadRecordingRepository keeps the order the use cases read for update
+type loadRecordingRepository struct {
+	*MockOrderRepository
+	loaded *entities.Order
+}
+
+func (r *loadRecordingRepository) GetByIDForUpdate(ctx context.Context, id uint) (*entities.Order, error) {
+	order, err := r.MockOrderRepository.GetByIDForUpdate(ctx, id)
+	r.loaded = order
+	return order, err
+}
+
+func TestOrderUseCases_AddItemToOrder_FailedUpdateLeavesLoadedOrder(t *testing.T) {
 	// Given
-	useCases, mockRepo := setupTestOrderUseCases()
+	mockRepo := new(MockOrderRepository)
+	repo := &loadRecordingRepository{MockOrderRepository: mockRepo}
+	auditor := &recordingAuditor{}
+	useCases := NewOrderUseCasesWithConfig(repo, nil, nil, auditor, logger.New("test"), DefaultOrderUseCasesConfig())
 	ctx := context.Background()
 
 	existingOrder, _ := entities.NewOrder(123)
 	existingOrder.ID = 1
+	require.NoError(t, existingOrder.AddItem(1, "SKU-001", "Product 1", 2, 10.0))
 
-	mockRepo.On("GetByID", ctx, uint(1)).Return(existingOrder, nil)
-	mockRepo.On("Delete", ctx, uint(1)).Return(nil)
+	var updated *entities.Order
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		updated = order
+		return true
+	})).Return(nil, assert.AnError)
 
 	// When
-	err := useCases.DeleteOrder(ctx, 1)
+	result, err := useCases.AddItemToOrder(ctx, 1, &dto.AddOrderItemRequestDTO{
+		ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 5.0,
+	})
 
 	// Then
-	require.NoError(t, err)
-
-	mockRepo.AssertExpectations(t)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToUpdateOrder)
+	require.NotNil(t, updated)
+	assert.Len(t, updated.Items, 2, "the change is applied to the copy sent to the repository")
+	require.NotNil(t, repo.loaded)
+	assert.NotSame(t, repo.loaded, updated)
+	assert.Equal(t, existingOrder, repo.loaded, "the order as read is left untouched")
+	assert.Empty(t, auditor.actions)
 }
 
-func TestOrderUseCases_DeleteOrder_NotFound(t *testing.T) {
+// RemoveItemFromOrder Tests
+func TestOrderUseCases_RemoveItemFromOrder_Success(t *testing.T) {
 	// Given
 	useCases, mockRepo := setupTestOrderUseCases()
 	ctx := context.Background()
 
-	mockRepo.On("GetByID", ctx, uint(999)).Return(nil, domainErrors.ErrOrderNotFound)
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+	existingOrder.AddItem(1, "SKU-001", "Product 1", 2, 10.50)
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.ID == 1 && len(order.Items) == 0
+	})).Return(existingOrder, nil)
 
 	// When
-	err := useCases.DeleteOrder(ctx, 999)
+	result, err := useCases.RemoveItemFromOrder(ctx, 1, 1)
 
 	// Then
-	assert.Error(t, err)
-	assert.Equal(t, domainErrors.ErrOrderNotFound, err)
+	require.NoError(t, err)
+	require.NotNil(t, result)
 
 	mockRepo.AssertExpectations(t)
 }
 
-func TestOrderUseCases_DeleteOrder_RepositoryError(t *testing.T) {
+// CancelOrderItem Tests
+func TestOrderUseCases_CancelOrderItem_Success(t *testing.T) {
 	// Given
-	useCases, mockRepo := setupTestOrderUseCases()
+	mockRepo := new(MockOrderRepository)
+	publisher := &recordingPublisher{}
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, publisher, nil, logger.New("test"), DefaultOrderUseCasesConfig())
 	ctx := context.Background()
 
 	existingOrder, _ := entities.NewOrder(123)
 	existingOrder.ID = 1
+	existingOrder.AddItem(1, "SKU-001", "Product 1", 3, 10.0)
+	existingOrder.Status = entities.OrderStatusConfirmed
 
-	mockRepo.On("GetByID", ctx, uint(1)).Return(existingOrder, nil)
-	mockRepo.On("Delete", ctx, uint(1)).Return(assert.AnError)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.Items[0].Quantity == 1 && len(order.CancelledItems) == 1
+	})).Return(storeInto(existingOrder), nil)
 
 	// When
-	err := useCases.DeleteOrder(ctx, 1)
+	result, err := useCases.CancelOrderItem(ctx, 1, 1, 2)
 
 	// Then
-	assert.Error(t, err)
-	assert.Equal(t, domainErrors.ErrFailedToDeleteOrder, err)
-
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, result.TotalAmount)
+	require.Len(t, result.CancelledItems, 1)
+	assert.Equal(t, 2, result.CancelledItems[0].Quantity)
+
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, events.OrderItemCancelled, publisher.events[0].Type)
+	assert.Equal(t, uint(1), publisher.events[0].ProductID)
+	assert.Equal(t, 2, publisher.events[0].Quantity)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestOrderUseCases_CancelOrderItem_Rejected(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    entities.OrderStatus
+		productID uint
+		quantity  int
+		expected  error
+	}{
+		{"pending order", entities.OrderStatusPending, 1, 1, domainErrors.ErrItemCancellationNotAllowed},
+		{"unknown product", entities.OrderStatusConfirmed, 9, 1, domainErrors.ErrOrderItemNotFound},
+		{"more than ordered", entities.OrderStatusConfirmed, 1, 4, domainErrors.ErrInvalidQuantity},
+		{"last remaining quantity", entities.OrderStatusConfirmed, 1, 3, domainErrors.ErrLastItemCancellation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo := setupTestOrderUseCases()
+			ctx := context.Background()
+
+			existingOrder, _ := entities.NewOrder(123)
+			existingOrder.ID = 1
+			existingOrder.AddItem(1, "SKU-001", "Product 1", 3, 10.0)
+			existingOrder.Status = tt.status
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+			// When
+			result, err := useCases.CancelOrderItem(ctx, 1, tt.productID, tt.quantity)
+
+			// Then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.expected)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// UpdateItemQuantity Tests
+func TestOrderUseCases_UpdateItemQuantity_Success(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+	existingOrder.AddItem(1, "SKU-001", "Product 1", 2, 10.50)
+
+	request := &dto.UpdateOrderItemQuantityRequestDTO{
+		Quantity: 5,
+	}
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(existingOrder, nil)
+
+	// When
+	result, err := useCases.UpdateItemQuantity(ctx, 1, 1, request)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	mockRepo.AssertExpectations(t)
+}
+
+// ConfirmOrder Tests
+func TestOrderUseCases_ConfirmOrder_Success(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+	existingOrder.AddItem(1, "SKU-001", "Product 1", 2, 10.50)
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.Status == entities.OrderStatusConfirmed
+	})).Return(storeInto(existingOrder), nil)
+
+	// When
+	result, err := useCases.ConfirmOrder(ctx, 1, nil)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, entities.OrderStatusConfirmed, result.Status)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_ConfirmOrder_Shipping(t *testing.T) {
+	t.Run("records the requested shipping", func(t *testing.T) {
+		// Given
+		useCases, mockRepo := setupTestOrderUseCases()
+		ctx := context.Background()
+
+		existingOrder, _ := entities.NewOrder(123)
+		existingOrder.ID = 1
+		existingOrder.AddItem(1, "SKU-001", "Product 1", 1, 10)
+		estimate := time.Now().Add(72 * time.Hour)
+
+		mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+			return order.ShippingMethod == entities.ShippingMethodExpress && order.EstimatedDeliveryAt != nil
+		})).Return(storeInto(existingOrder), nil)
+
+		// When
+		result, err := useCases.ConfirmOrder(ctx, 1, &dto.ConfirmOrderRequestDTO{
+			ShippingMethod:      entities.ShippingMethodExpress,
+			EstimatedDeliveryAt: &estimate,
+		})
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, entities.ShippingMethodExpress, result.ShippingMethod)
+		require.NotNil(t, result.EstimatedDeliveryAt)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an estimate in the past", func(t *testing.T) {
+		// Given
+		useCases, mockRepo := setupTestOrderUseCases()
+		ctx := context.Background()
+
+		existingOrder, _ := entities.NewOrder(123)
+		existingOrder.ID = 1
+		existingOrder.AddItem(1, "SKU-001", "Product 1", 1, 10)
+		estimate := time.Now().Add(-time.Hour)
+
+		mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+		// When
+		result, err := useCases.ConfirmOrder(ctx, 1, &dto.ConfirmOrderRequestDTO{EstimatedDeliveryAt: &estimate})
+
+		// Then
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidEstimatedDelivery)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderUseCases_ConfirmOrder_EmptyOrder(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+	// No items added
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+	// When
+	result, err := useCases.ConfirmOrder(ctx, 1, nil)
+
+	// Then
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "cannot confirm empty order")
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_ConfirmOrder_MinimumAmount(t *testing.T) {
+	admin := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "ops", Scopes: []auth.Scope{auth.ScopeOrdersAdmin}})
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		unitPrice float64
+		request   *dto.ConfirmOrderRequestDTO
+		expected  error
+	}{
+		{name: "exactly at the minimum", ctx: context.Background(), unitPrice: 5.0},
+		{name: "below the minimum", ctx: context.Background(), unitPrice: 3.5, expected: domainErrors.ErrOrderBelowMinimum},
+		{name: "override by an admin", ctx: admin, unitPrice: 3.5, request: &dto.ConfirmOrderRequestDTO{OverrideMinimum: true}},
+		{name: "override without the admin scope", ctx: customerContext(123, auth.ScopeOrdersWrite), unitPrice: 3.5,
+			request: &dto.ConfirmOrderRequestDTO{OverrideMinimum: true}, expected: domainErrors.ErrMinimumOverrideForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			mockRepo := new(MockOrderRepository)
+			config := DefaultOrderUseCasesConfig()
+			config.OrderLimits.MinOrderAmount = 5.0
+			useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config)
+
+			existingOrder, _ := entities.NewOrder(123)
+			existingOrder.ID = 1
+			existingOrder.AddItem(1, "SKU-001", "Product 1", 1, tt.unitPrice)
+
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil).Maybe()
+			mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(existingOrder), nil).Maybe()
+
+			// When
+			result, err := useCases.ConfirmOrder(tt.ctx, 1, tt.request)
+
+			// Then
+			if tt.expected == nil {
+				require.NoError(t, err)
+				assert.Equal(t, entities.OrderStatusConfirmed, result.Status)
+				return
+			}
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.expected)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCases_ConfirmOrder_BelowMinimumDetailsShortfall(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	config := DefaultOrderUseCasesConfig()
+	config.OrderLimits.MinOrderAmount = 5.0
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config)
+	ctx := context.Background()
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+	existingOrder.AddItem(1, "SKU-001", "Product 1", 1, 3.5)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+	// When
+	_, err := useCases.ConfirmOrder(ctx, 1, nil)
+
+	// Then
+	var domainErr *domainErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "ORDER_BELOW_MINIMUM", domainErr.Code)
+	assert.Equal(t, 1.5, domainErr.Details["shortfall"])
+	assert.Equal(t, 5.0, domainErr.Details["minimum_amount"])
+}
+
+// CancelOrder Tests
+func TestOrderUseCases_CancelOrder_Success(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+	existingOrder.Status = entities.OrderStatusPending
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.Status == entities.OrderStatusCancelled
+	})).Return(storeInto(existingOrder), nil)
+
+	// When
+	result, err := useCases.CancelOrder(ctx, 1)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, entities.OrderStatusCancelled, result.Status)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_CancelOrder_CannotCancel(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+	existingOrder.Status = entities.OrderStatusDelivered // Cannot cancel delivered orders
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+	// When
+	result, err := useCases.CancelOrder(ctx, 1)
+
+	// Then
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "order cannot be cancelled")
+
+	mockRepo.AssertExpectations(t)
+}
+
+// TransitionOrderStatus Tests
+func TestOrderUseCases_ReplaceOrderItems_Success(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+	existingOrder.AddItem(1, "SKU-001", "Product 1", 5, 10.0)
+
+	request := &dto.ReplaceOrderItemsRequestDTO{
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 2, UnitPrice: 7.5},
+			{ProductID: 3, ProductSKU: "SKU-003", ProductName: "Product 3", Quantity: 1, UnitPrice: 5.0},
+		},
+	}
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return len(order.Items) == 2 && order.TotalAmount == 20.0
+	})).Return(storeInto(existingOrder), nil).Once()
+
+	// When
+	result, err := useCases.ReplaceOrderItems(ctx, 1, request)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.ItemCount)
+	assert.Equal(t, 20.0, result.TotalAmount)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_OrderLimits(t *testing.T) {
+	newUseCases := func() (OrderUseCases, *MockOrderRepository) {
+		mockRepo := new(MockOrderRepository)
+		config := DefaultOrderUseCasesConfig()
+		config.MaxPendingOrdersPerCustomer = 0
+		config.OrderLimits = entities.OrderLimits{MaxItems: 2, MaxQuantityPerItem: 5, MaxTotalAmount: 100, MaxUnitWeightGrams: 1000}
+		return NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config), mockRepo
+	}
+
+	item := func(productID uint, quantity int, unitPrice float64) dto.CreateOrderItemDTO {
+		return dto.CreateOrderItemDTO{ProductID: productID, ProductSKU: "SKU", ProductName: "Product", Quantity: quantity, UnitPrice: unitPrice}
+	}
+
+	t.Run("create with too many items", func(t *testing.T) {
+		// Given
+		useCases, mockRepo := newUseCases()
+		request := &dto.CreateOrderRequestDTO{
+			CustomerID: 123,
+			Items:      []dto.CreateOrderItemDTO{item(1, 1, 1), item(2, 1, 1), item(3, 1, 1)},
+		}
+
+		// When
+		result, err := useCases.CreateOrder(context.Background(), request)
+
+		// Then
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domainErrors.ErrOrderItemLimitExceeded)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("add item over the total", func(t *testing.T) {
+		// Given
+		useCases, mockRepo := newUseCases()
+		ctx := context.Background()
+
+		existingOrder, _ := entities.NewOrder(123)
+		existingOrder.ID = 1
+		existingOrder.AddItem(1, "SKU-001", "Product 1", 1, 90.0)
+		mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+		// When
+		result, err := useCases.AddItemToOrder(ctx, 1, &dto.AddOrderItemRequestDTO{
+			ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 20.0,
+		})
+
+		// Then
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domainErrors.ErrOrderTotalLimitExceeded)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("add item over the weight cap", func(t *testing.T) {
+		// Given
+		useCases, mockRepo := newUseCases()
+		ctx := context.Background()
+
+		existingOrder, _ := entities.NewOrder(123)
+		existingOrder.ID = 1
+		mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+		weight := 1001
+
+		// When
+		result, err := useCases.AddItemToOrder(ctx, 1, &dto.AddOrderItemRequestDTO{
+			ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 20.0, UnitWeightGrams: &weight,
+		})
+
+		// Then
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domainErrors.ErrWeightLimitExceeded)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("update quantity over the cap", func(t *testing.T) {
+		// Given
+		useCases, mockRepo := newUseCases()
+		ctx := context.Background()
+
+		existingOrder, _ := entities.NewOrder(123)
+		existingOrder.ID = 1
+		existingOrder.AddItem(1, "SKU-001", "Product 1", 1, 1.0)
+		mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+		// When
+		result, err := useCases.UpdateItemQuantity(ctx, 1, 1, &dto.UpdateOrderItemQuantityRequestDTO{Quantity: 6})
+
+		// Then
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domainErrors.ErrQuantityLimitExceeded)
+		assert.Equal(t, 1, existingOrder.Items[0].Quantity)
+	})
+}
+
+func TestOrderUseCases_ReplaceOrderItems_Duplicate(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+
+	request := &dto.ReplaceOrderItemsRequestDTO{
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 2, UnitPrice: 7.5},
+			{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 7.5},
+		},
+	}
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+	// When
+	result, err := useCases.ReplaceOrderItems(ctx, 1, request)
+
+	// Then
+	assert.Nil(t, result)
+	var domainErr *domainErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "ORDER_ITEM_VALIDATION_ERROR", domainErr.Code)
+	assert.Contains(t, domainErr.Message, "duplicate product ID 2")
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_PlaceOrderOnHold_AndRelease(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+	existingOrder.Status = entities.OrderStatusConfirmed
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(existingOrder), nil)
+
+	// When
+	held, err := useCases.PlaceOrderOnHold(ctx, 1, &dto.PlaceOrderOnHoldRequestDTO{Reason: "fraud review"})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusOnHold, held.Status)
+	assert.Equal(t, entities.OrderStatusConfirmed, held.HeldFromStatus)
+	assert.Equal(t, "fraud review", held.HoldReason)
+
+	// When
+	released, err := useCases.ReleaseOrderHold(ctx, 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusConfirmed, released.Status)
+	assert.Empty(t, released.HoldReason)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_ReleaseOrderHold_NotOnHold(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+	existingOrder.Status = entities.OrderStatusCancelled // never held, a release cannot have left it here
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+	// When
+	result, err := useCases.ReleaseOrderHold(ctx, 1)
+
+	// Then
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, entities.ErrAlreadyInStatus)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_RepeatedStatusChange_AlreadyInState(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   entities.OrderStatus
+		change func(useCases OrderUseCases) (*dto.OrderResponseDTO, error)
+		want   entities.OrderStatus
+	}{
+		{
+			name: "confirm",
+			from: entities.OrderStatusPending,
+			change: func(useCases OrderUseCases) (*dto.OrderResponseDTO, error) {
+				return useCases.ConfirmOrder(context.Background(), 1, nil)
+			},
+			want: entities.OrderStatusConfirmed,
+		},
+		{
+			name: "cancel",
+			from: entities.OrderStatusConfirmed,
+			change: func(useCases OrderUseCases) (*dto.OrderResponseDTO, error) {
+				return useCases.CancelOrder(context.Background(), 1)
+			},
+			want: entities.OrderStatusCancelled,
+		},
+		{
+			name: "hold",
+			from: entities.OrderStatusConfirmed,
+			change: func(useCases OrderUseCases) (*dto.OrderResponseDTO, error) {
+				return useCases.PlaceOrderOnHold(context.Background(), 1, &dto.PlaceOrderOnHoldRequestDTO{Reason: "fraud review"})
+			},
+			want: entities.OrderStatusOnHold,
+		},
+		{
+			name: "release",
+			from: entities.OrderStatusOnHold,
+			change: func(useCases OrderUseCases) (*dto.OrderResponseDTO, error) {
+				return useCases.ReleaseOrderHold(context.Background(), 1)
+			},
+			want: entities.OrderStatusProcessing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo := setupTestOrderUseCases()
+
+			existingOrder, _ := entities.NewOrder(123)
+			existingOrder.ID = 1
+			require.NoError(t, existingOrder.AddItem(1, "SKU-001", "Product 1", 2, 10.50))
+			existingOrder.Status = tt.from
+			if tt.from == entities.OrderStatusOnHold {
+				existingOrder.HeldFromStatus = entities.OrderStatusProcessing
+				existingOrder.HoldReason = "fraud review"
+			}
+
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+			mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(existingOrder), nil).Once()
+
+			// When
+			first, err := tt.change(useCases)
+			require.NoError(t, err)
+			second, err := tt.change(useCases)
+
+			// Then
+			require.NoError(t, err)
+			assert.False(t, first.AlreadyInState)
+			assert.True(t, second.AlreadyInState)
+			assert.Equal(t, tt.want, first.Status)
+			assert.Equal(t, tt.want, second.Status)
+			mockRepo.AssertNumberOfCalls(t, "Update", 1)
+		})
+	}
+}
+
+func TestOrderUseCases_TransitionOrderStatus_ToProcessing(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+	existingOrder.Status = entities.OrderStatusConfirmed
+
+	request := &dto.UpdateOrderStatusRequestDTO{
+		Status: entities.OrderStatusProcessing,
+	}
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.Status == entities.OrderStatusProcessing
+	})).Return(storeInto(existingOrder), nil)
+
+	// When
+	result, err := useCases.TransitionOrderStatus(ctx, 1, request)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, entities.OrderStatusProcessing, result.Status)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_TransitionOrderStatus_ShippedWithTracking(t *testing.T) {
+	t.Run("records tracking", func(t *testing.T) {
+		// Given
+		useCases, mockRepo := setupTestOrderUseCases()
+		ctx := context.Background()
+
+		existingOrder, _ := entities.NewOrder(123)
+		existingOrder.ID = 1
+		existingOrder.Status = entities.OrderStatusProcessing
+
+		mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+			return order.Status == entities.OrderStatusShipped && order.TrackingNumber == "1Z999"
+		})).Return(storeInto(existingOrder), nil)
+
+		// When
+		result, err := useCases.TransitionOrderStatus(ctx, 1, &dto.UpdateOrderStatusRequestDTO{
+			Status:   entities.OrderStatusShipped,
+			Tracking: &dto.ShipmentTrackingDTO{Carrier: "UPS", TrackingNumber: "1Z999"},
+		})
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, "UPS", result.Carrier)
+		assert.Equal(t, "1Z999", result.TrackingNumber)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a long tracking number", func(t *testing.T) {
+		// Given
+		useCases, mockRepo := setupTestOrderUseCases()
+		ctx := context.Background()
+
+		existingOrder, _ := entities.NewOrder(123)
+		existingOrder.ID = 1
+		existingOrder.Status = entities.OrderStatusProcessing
+
+		mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+		// When
+		result, err := useCases.TransitionOrderStatus(ctx, 1, &dto.UpdateOrderStatusRequestDTO{
+			Status:   entities.OrderStatusShipped,
+			Tracking: &dto.ShipmentTrackingDTO{TrackingNumber: strings.Repeat("1", 101)},
+		})
+
+		// Then
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidTrackingNumber)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderUseCases_TransitionOrderStatus_ReturnFlow(t *testing.T) {
+	// Given
+	useCases, mockRepo, _, _, _ := setupRefundUseCases()
+	ctx := context.Background()
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+	existingOrder.Status = entities.OrderStatusDelivered
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(existingOrder), nil)
+
+	for _, status := range []entities.OrderStatus{
+		entities.OrderStatusReturnRequested,
+		entities.OrderStatusReturned,
+		entities.OrderStatusRefunded,
+	} {
+		// When
+		result, err := useCases.TransitionOrderStatus(ctx, 1, &dto.UpdateOrderStatusRequestDTO{Status: status})
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, status, result.Status)
+	}
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_TransitionOrderStatus_AlreadyInState(t *testing.T) {
+	for _, status := range []entities.OrderStatus{
+		entities.OrderStatusPending,
+		entities.OrderStatusConfirmed,
+		entities.OrderStatusProcessing,
+		entities.OrderStatusShipped,
+		entities.OrderStatusDelivered,
+		entities.OrderStatusCancelled,
+		entities.OrderStatusReturned,
+		entities.OrderStatusRefunded,
+		entities.OrderStatusExpired,
+	} {
+		t.Run(string(status), func(t *testing.T) {
+			// Given
+			useCases, mockRepo, _, _, _ := setupRefundUseCases()
+			ctx := context.Background()
+
+			existingOrder, _ := entities.NewOrder(123)
+			existingOrder.ID = 1
+			existingOrder.Status = status
+
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+			// When
+			result, err := useCases.TransitionOrderStatus(ctx, 1, &dto.UpdateOrderStatusRequestDTO{Status: status})
+
+			// Then
+			require.NoError(t, err)
+			assert.True(t, result.AlreadyInState)
+			assert.Equal(t, status, result.Status)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCases_TransitionOrderStatus_InvalidTransitionIsNotAlreadyInState(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    entities.OrderStatus
+		request *dto.UpdateOrderStatusRequestDTO
+	}{
+		{"terminal order", entities.OrderStatusCancelled, &dto.UpdateOrderStatusRequestDTO{Status: entities.OrderStatusShipped}},
+		{"backwards", entities.OrderStatusShipped, &dto.UpdateOrderStatusRequestDTO{Status: entities.OrderStatusProcessing}},
+		{"other tracking", entities.OrderStatusShipped, &dto.UpdateOrderStatusRequestDTO{
+			Status:   entities.OrderStatusShipped,
+			Tracking: &dto.ShipmentTrackingDTO{Carrier: "UPS", TrackingNumber: "1Z000"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo := setupTestOrderUseCases()
+			ctx := context.Background()
+
+			existingOrder, _ := entities.NewOrder(123)
+			existingOrder.ID = 1
+			existingOrder.Status = tt.from
+			existingOrder.Carrier = "UPS"
+			existingOrder.TrackingNumber = "1Z999"
+
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+			// When
+			result, err := useCases.TransitionOrderStatus(ctx, 1, tt.request)
+
+			// Then
+			assert.Nil(t, result)
+			var transitionErr *entities.TransitionError
+			require.ErrorAs(t, err, &transitionErr)
+			assert.NotErrorIs(t, err, entities.ErrAlreadyInStatus)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCases_TransitionOrderStatus_InvalidStatus(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+
+	request := &dto.UpdateOrderStatusRequestDTO{
+		Status: "invalid_status",
+	}
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+	// When
+	result, err := useCases.TransitionOrderStatus(ctx, 1, request)
+
+	// Then
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidOrderStatus)
+
+	mockRepo.AssertExpectations(t)
+}
+
+// GetCustomerOrders Tests
+func TestOrderUseCases_GetCustomerOrders_Success(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	expectedOrders := []*entities.Order{
+		{
+			ID:          1,
+			CustomerID:  123,
+			Items:       []entities.OrderItem{},
+			TotalAmount: 100.00,
+			Status:      entities.OrderStatusPending,
+		},
+		{
+			ID:          2,
+			CustomerID:  123,
+			Items:       []entities.OrderItem{},
+			TotalAmount: 200.00,
+			Status:      entities.OrderStatusConfirmed,
+		},
+	}
+
+	mockRepo.On("ListByFilter", ctx, ports.OrderFilter{CustomerID: 123}, 10, 0).Return(expectedOrders, int64(2), nil)
+
+	// When
+	result, err := useCases.GetCustomerOrders(ctx, 123, 0, 10)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Len(t, result.Orders, 2)
+	assert.Equal(t, int64(2), result.Total)
+	assert.Equal(t, 0, result.Page)
+	assert.Equal(t, 10, result.PageSize)
+
+	mockRepo.AssertExpectations(t)
+}
+
+// GetOrdersByStatus Tests
+func TestOrderUseCases_GetOrdersByStatus_Success(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	expectedOrders := []*entities.Order{
+		{
+			ID:          1,
+			CustomerID:  123,
+			Items:       []entities.OrderItem{},
+			TotalAmount: 100.00,
+			Status:      entities.OrderStatusPending,
+		},
+	}
+
+	mockRepo.On("ListByFilter", ctx, ports.OrderFilter{Status: entities.OrderStatusPending}, 10, 0).Return(expectedOrders, int64(1), nil)
+
+	// When
+	result, err := useCases.GetOrdersByStatus(ctx, entities.OrderStatusPending, 0, 10)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Len(t, result.Orders, 1)
+	assert.Equal(t, int64(1), result.Total)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_GetOrdersByStatus_UnknownStatus(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+
+	// When
+	result, err := useCases.GetOrdersByStatus(context.Background(), entities.OrderStatus("bogus"), 0, 10)
+
+	// Then
+	assert.Nil(t, result)
+	var domainErr *domainErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domainErrors.ErrInvalidOrderStatus.Code, domainErr.Code)
+	assert.Equal(t, entities.OrderStatus("bogus"), domainErr.Details["status"])
+	assert.Equal(t, entities.OrderStatuses(), domainErr.Details["valid_statuses"])
+	mockRepo.AssertNotCalled(t, "ListByFilter", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// GetCustomerOrdersByStatus Tests
+func TestOrderUseCases_GetCustomerOrdersByStatus_Success(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	expectedOrders := []*entities.Order{
+		{
+			ID:          1,
+			CustomerID:  123,
+			Items:       []entities.OrderItem{},
+			TotalAmount: 100.00,
+			Status:      entities.OrderStatusPending,
+		},
+	}
+
+	mockRepo.On("ListByFilter", ctx, ports.OrderFilter{CustomerID: 123, Status: entities.OrderStatusPending}, 10, 10).Return(expectedOrders, int64(11), nil)
+
+	// When
+	result, err := useCases.GetCustomerOrdersByStatus(ctx, 123, entities.OrderStatusPending, 1, 10)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Len(t, result.Orders, 1)
+	assert.Equal(t, int64(11), result.Total)
+	assert.Equal(t, 1, result.Page)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_GetCustomerOrdersByStatus_InvalidStatus(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+
+	// When
+	result, err := useCases.GetCustomerOrdersByStatus(context.Background(), 123, entities.OrderStatus("bogus"), 0, 10)
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidOrderStatus)
+	mockRepo.AssertExpectations(t)
+}
+
+// ListOrdersByDateRange Tests
+func TestOrderUseCases_ListOrdersByDateRange_OpenEnd(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	orders := []*entities.Order{{ID: 1, CustomerID: 123, Items: []entities.OrderItem{}, Status: entities.OrderStatusPending}}
+	mockRepo.On("ListByFilter", ctx, ports.OrderFilter{CreatedFrom: from}, 10, 0).Return(orders, int64(1), nil)
+
+	// When
+	result, err := useCases.ListOrdersByDateRange(ctx, &from, nil, 0, 10)
+
+	// Then
+	require.NoError(t, err)
+	assert.Len(t, result.Orders, 1)
+	assert.Equal(t, int64(1), result.Total)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_ListOrdersByDateRange_FromAfterTo(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	from := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// When
+	result, err := useCases.ListOrdersByDateRange(context.Background(), &from, &to, 0, 10)
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidDateRange)
+	mockRepo.AssertExpectations(t)
+}
+
+// CountOrders Tests
+func TestOrderUseCases_CountOrders(t *testing.T) {
+	tests := []struct {
+		name       string
+		customerID uint
+		status     entities.OrderStatus
+		mockSetup  func(m *MockOrderRepository)
+	}{
+		{"all orders", 0, "", func(m *MockOrderRepository) {
+			m.On("Count", mock.Anything).Return(int64(7), nil)
+		}},
+		{"by customer", 123, "", func(m *MockOrderRepository) {
+			m.On("CountByCustomerID", mock.Anything, uint(123)).Return(int64(7), nil)
+		}},
+		{"by status", 0, entities.OrderStatusPending, func(m *MockOrderRepository) {
+			m.On("CountByStatus", mock.Anything, entities.OrderStatusPending).Return(int64(7), nil)
+		}},
+		{"by customer and status", 123, entities.OrderStatusPending, func(m *MockOrderRepository) {
+			m.On("CountByCustomerIDAndStatus", mock.Anything, uint(123), entities.OrderStatusPending).Return(int64(7), nil)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo := setupTestOrderUseCases()
+			tt.mockSetup(mockRepo)
+
+			// When
+			count, err := useCases.CountOrders(context.Background(), tt.customerID, tt.status)
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, int64(7), count)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderUseCases_CountOrders_Errors(t *testing.T) {
+	useCases, mockRepo := setupTestOrderUseCases()
+
+	_, err := useCases.CountOrders(context.Background(), 0, "lost")
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidOrderStatus)
+
+	mockRepo.On("Count", mock.Anything).Return(int64(0), errors.New("connection refused"))
+	_, err = useCases.CountOrders(context.Background(), 0, "")
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToListOrders)
+}
+
+func TestOrderUseCases_CountOrdersByDateRange(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("CountByFilter", mock.Anything, ports.OrderFilter{CreatedFrom: from}).Return(int64(3), nil)
+
+	// When
+	count, err := useCases.CountOrdersByDateRange(context.Background(), &from, nil)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	to := from.Add(-time.Hour)
+	_, err = useCases.CountOrdersByDateRange(context.Background(), &from, &to)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidDateRange)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_OrdersLastModified(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+	updatedAt := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	mockRepo.On("MaxUpdatedAt", ctx, ports.OrderFilter{CustomerID: 7, Status: entities.OrderStatusPending}).Return(updatedAt, nil)
+
+	// When
+	lastModified, err := useCases.OrdersLastModified(ctx, &dto.OrderFilterDTO{CustomerID: 7, Status: entities.OrderStatusPending})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, updatedAt, lastModified)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_OrdersLastModified_OtherCustomer(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := customerContext(8, auth.ScopeOrdersRead)
+
+	// When
+	lastModified, err := useCases.OrdersLastModified(ctx, &dto.OrderFilterDTO{CustomerID: 7})
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, lastModified.IsZero())
+	mockRepo.AssertNotCalled(t, "MaxUpdatedAt", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_StreamOrders(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	orders := []*entities.Order{
+		{ID: 1, CustomerID: 123, Items: []entities.OrderItem{}, Status: entities.OrderStatusPending},
+		{ID: 2, CustomerID: 456, Items: []entities.OrderItem{}, Status: entities.OrderStatusConfirmed},
+	}
+	mockRepo.On("ListByFilter", ctx, ports.OrderFilter{}, 2, 2).Return(orders, int64(5), nil)
+
+	// When
+	var streamed []uint
+	result, err := useCases.StreamOrders(ctx, nil, nil, 1, 2, func(order *dto.OrderResponseDTO) error {
+		streamed = append(streamed, order.ID)
+		return nil
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []uint{1, 2}, streamed)
+	assert.Nil(t, result.Orders)
+	assert.Equal(t, int64(5), result.Total)
+	assert.Equal(t, 3, result.TotalPages)
+	assert.True(t, result.HasNext)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_StreamOrders_StopsAtCallbackError(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	orders := []*entities.Order{{ID: 1, Items: []entities.OrderItem{}}, {ID: 2, Items: []entities.OrderItem{}}}
+	mockRepo.On("ListByFilter", ctx, ports.OrderFilter{}, 10, 0).Return(orders, int64(2), nil)
+	writeErr := errors.New("client went away")
+
+	// When
+	calls := 0
+	result, err := useCases.StreamOrders(ctx, nil, nil, 0, 10, func(*dto.OrderResponseDTO) error {
+		calls++
+		return writeErr
+	})
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, writeErr)
+	assert.Equal(t, 1, calls)
+}
+
+// ListOrders Tests
+func TestOrderUseCases_ListOrders_Success(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	expectedOrders := []*entities.Order{
+		{
+			ID:          1,
+			CustomerID:  123,
+			Items:       []entities.OrderItem{},
+			TotalAmount: 100.00,
+			Status:      entities.OrderStatusPending,
+		},
+		{
+			ID:          2,
+			CustomerID:  456,
+			Items:       []entities.OrderItem{},
+			TotalAmount: 200.00,
+			Status:      entities.OrderStatusConfirmed,
+		},
+	}
+
+	mockRepo.On("ListByFilter", ctx, ports.OrderFilter{}, 10, 0).Return(expectedOrders, int64(50), nil)
+
+	// When
+	result, err := useCases.ListOrders(ctx, 0, 10)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Len(t, result.Orders, 2)
+	assert.Equal(t, int64(50), result.Total)
+	assert.Equal(t, 0, result.Page)
+	assert.Equal(t, 10, result.PageSize)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_ListOrders_InvalidPagination(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	// When - Pass invalid pagination parameters
+	result, err := useCases.ListOrders(ctx, -1, 150)
+
+	// Then
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidPagination)
+
+	var domainErr *domainErrors.DomainError
+	require.True(t, errors.As(err, &domainErr))
+	assert.Contains(t, domainErr.Details, "page")
+	assert.Contains(t, domainErr.Details, "page_size")
+
+	mockRepo.AssertNotCalled(t, "ListByFilter", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_ListOrders_PageWhoseOffsetOverflows(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+
+	// When
+	result, err := useCases.ListOrders(context.Background(), math.MaxInt, 100)
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidPagination)
+	mockRepo.AssertNotCalled(t, "ListByFilter", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_ListOrders_DefaultPageSize(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	mockRepo.On("ListByFilter", ctx, ports.OrderFilter{}, 10, 0).Return([]*entities.Order{}, int64(0), nil)
+
+	// When
+	result, err := useCases.ListOrders(ctx, 0, 0)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 10, result.PageSize)
+	assert.Equal(t, 0, result.TotalPages)
+	assert.False(t, result.HasNext)
+	assert.False(t, result.HasPrevious)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_ListOrders_MaxPageSizeIsReadOnEveryCall(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	maxPageSize := 50
+	config := DefaultOrderUseCasesConfig()
+	config.MaxPageSize = func() int { return maxPageSize }
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config)
+	ctx := context.Background()
+
+	mockRepo.On("ListByFilter", ctx, ports.OrderFilter{}, 50, 0).Return([]*entities.Order{}, int64(0), nil)
+
+	_, err := useCases.ListOrders(ctx, 0, 50)
+	require.NoError(t, err)
+
+	maxPageSize = 20
+	_, err = useCases.ListOrders(ctx, 0, 50)
+
+	var domainErr *domainErrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domainErrors.ErrInvalidPagination.Code, domainErr.Code)
+	assert.Equal(t, "must be between 1 and 20", domainErr.Details["page_size"])
+	mockRepo.AssertNumberOfCalls(t, "ListByFilter", 1)
+}
+
+func TestOrderUseCases_ListOrders_PageMetadata(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	mockRepo.On("ListByFilter", ctx, ports.OrderFilter{}, 10, 20).Return([]*entities.Order{}, int64(45), nil)
+
+	// When - Request the third page
+	result, err := useCases.ListOrders(ctx, 2, 10)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.TotalPages)
+	assert.True(t, result.HasNext)
+	assert.True(t, result.HasPrevious)
+
+	mockRepo.AssertExpectations(t)
+}
+
+// DeleteOrder Tests
+func TestOrderUseCases_DeleteOrder_Success(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Delete", mock.Anything, uint(1)).Return(nil)
+
+	// When
+	err := useCases.DeleteOrder(ctx, 1)
+
+	// Then
+	require.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_ListDeletedOrders_Success(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	deletedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	deletedOrders := []*entities.Order{
+		{ID: 3, CustomerID: 123, Items: []entities.OrderItem{}, Status: entities.OrderStatusCancelled, DeletedAt: &deletedAt},
+	}
+	mockRepo.On("ListDeleted", ctx, 1, 1).Return(deletedOrders, nil)
+	mockRepo.On("CountDeleted", ctx).Return(int64(3), nil)
+
+	// When
+	result, err := useCases.ListDeletedOrders(ctx, 1, 1)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, result.Orders, 1)
+	assert.Equal(t, uint(3), result.Orders[0].ID)
+	assert.Equal(t, &deletedAt, result.Orders[0].DeletedAt)
+	assert.Equal(t, int64(3), result.Total)
+	assert.Equal(t, 3, result.TotalPages)
+	assert.True(t, result.HasNext)
+	assert.True(t, result.HasPrevious)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_ListDeletedOrders_InvalidPagination(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+
+	// When
+	_, err := useCases.ListDeletedOrders(context.Background(), -1, 10)
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidPagination)
+	mockRepo.AssertNotCalled(t, "ListDeleted", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_RestoreOrder_Success(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	deletedAt := time.Now()
+	deletedOrder, _ := entities.NewOrder(123)
+	deletedOrder.ID = 1
+	deletedOrder.DeletedAt = &deletedAt
+	restoredOrder := deletedOrder.Clone()
+	restoredOrder.DeletedAt = nil
+
+	mockRepo.On("GetByIDIncludingDeleted", mock.Anything, uint(1)).Return(deletedOrder, nil)
+	mockRepo.On("Restore", mock.Anything, uint(1)).Return(restoredOrder, nil)
+
+	// When
+	result, err := useCases.RestoreOrder(ctx, 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_RestoreOrder_NotDeleted(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	liveOrder, _ := entities.NewOrder(123)
+	liveOrder.ID = 1
+	mockRepo.On("GetByIDIncludingDeleted", mock.Anything, uint(1)).Return(liveOrder, nil)
+
+	// When
+	_, err := useCases.RestoreOrder(ctx, 1)
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+	mockRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_DeleteOrder_InFulfillment(t *testing.T) {
+	for _, status := range []entities.OrderStatus{entities.OrderStatusProcessing, entities.OrderStatusShipped} {
+		t.Run(string(status), func(t *testing.T) {
+			// Given
+			useCases, mockRepo := setupTestOrderUseCases()
+			ctx := context.Background()
+
+			existingOrder, _ := entities.NewOrder(123)
+			existingOrder.ID = 1
+			existingOrder.Status = status
+
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+			// When
+			err := useCases.DeleteOrder(ctx, 1)
+
+			// Then
+			assert.ErrorIs(t, err, domainErrors.ErrOrderNotDeletable)
+
+			var domainErr *domainErrors.DomainError
+			require.True(t, errors.As(err, &domainErr))
+			assert.Equal(t, status, domainErr.Details["current_status"])
+
+			mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderUseCases_DeleteOrder_NotFound(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(999)).Return(nil, domainErrors.ErrOrderNotFound)
+
+	// When
+	err := useCases.DeleteOrder(ctx, 999)
+
+	// Then
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_DeleteOrder_RepositoryError(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Delete", mock.Anything, uint(1)).Return(assert.AnError)
+
+	// When
+	err := useCases.DeleteOrder(ctx, 1)
+
+	// Then
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToDeleteOrder)
+
+	mockRepo.AssertExpectations(t)
+}
+
+// ExportOrders Tests
+func TestOrderUseCases_ExportOrders_Success(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), OrderUseCasesConfig{
+		ExportMaxRows:   10,
+		ExportBatchSize: 2,
+	})
+	ctx := context.Background()
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	filter := &dto.OrderFilterDTO{CustomerID: 123, From: &from, To: &to}
+	expectedFilter := ports.OrderFilter{CustomerID: 123, CreatedFrom: from, CreatedBefore: to}
+
+	orders := []*entities.Order{
+		{ID: 1, CustomerID: 123, Status: entities.OrderStatusPending},
+		{ID: 2, CustomerID: 123, Status: entities.OrderStatusConfirmed},
+	}
+
+	mockRepo.On("CountItemsByFilter", ctx, expectedFilter).Return(int64(2), nil)
+	mockRepo.On("StreamByFilter", ctx, expectedFilter, 2).Return(orders, nil)
+
+	// When
+	var exported []uint
+	err := useCases.ExportOrders(ctx, filter, func(order *dto.OrderResponseDTO) error {
+		exported = append(exported, order.ID)
+		return nil
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []uint{1, 2}, exported)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_ExportOrders_TooLarge(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), OrderUseCasesConfig{
+		ExportMaxRows:   10,
+		ExportBatchSize: 2,
+	})
+	ctx := context.Background()
+
+	mockRepo.On("CountItemsByFilter", ctx, ports.OrderFilter{}).Return(int64(11), nil)
+
+	// When
+	err := useCases.ExportOrders(ctx, &dto.OrderFilterDTO{}, func(order *dto.OrderResponseDTO) error {
+		t.Fatal("no order should be exported")
+		return nil
+	})
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrExportTooLarge)
+	mockRepo.AssertNotCalled(t, "StreamByFilter", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_ExportOrders_InvalidStatus(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	// When
+	err := useCases.ExportOrders(ctx, &dto.OrderFilterDTO{Status: "unknown"}, func(order *dto.OrderResponseDTO) error {
+		return nil
+	})
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidOrderStatus)
+	mockRepo.AssertNotCalled(t, "CountItemsByFilter", mock.Anything, mock.Anything)
+}
+
+// GetOrderStats Tests
+func TestOrderUseCases_GetOrderStats_Success(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC)
+	expectedFilter := ports.OrderFilter{CreatedFrom: from, CreatedBefore: to}
+
+	mockRepo.On("AggregateByStatus", ctx, expectedFilter).Return([]ports.StatusAggregate{
+		{Status: entities.OrderStatusPending, Orders: 1, Revenue: 10},
+		{Status: entities.OrderStatusDelivered, Orders: 3, Revenue: 90},
+	}, nil)
+	mockRepo.On("AggregateByDay", ctx, expectedFilter).Return([]ports.DailyAggregate{
+		{Day: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Orders: 1, Revenue: 10},
+		{Day: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), Orders: 3, Revenue: 90},
+	}, nil)
+
+	// When
+	stats, err := useCases.GetOrderStats(ctx, &dto.OrderFilterDTO{From: &from, To: &to})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), stats.TotalOrders)
+	assert.Equal(t, 100.0, stats.TotalRevenue)
+	assert.Equal(t, 25.0, stats.AverageOrderValue)
+	assert.Len(t, stats.ByStatus, 2)
+	assert.Equal(t, []dto.DailyStatsDTO{
+		{Date: "2025-01-01", Orders: 1, Revenue: 10},
+		{Date: "2025-01-02", Orders: 0, Revenue: 0},
+		{Date: "2025-01-03", Orders: 3, Revenue: 90},
+	}, stats.Daily)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_GetCustomerOrderSummary_Success(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	first := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	last := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+	mockRepo.On("AggregateByStatus", ctx, ports.OrderFilter{CustomerID: 123}).Return([]ports.StatusAggregate{
+		{Status: entities.OrderStatusCancelled, Orders: 1, Revenue: 500, FirstCreatedAt: first, LastCreatedAt: first},
+		{Status: entities.OrderStatusDelivered, Orders: 3, Revenue: 90, FirstCreatedAt: first.Add(time.Hour), LastCreatedAt: last.Add(-time.Hour)},
+		{Status: entities.OrderStatusPending, Orders: 1, Revenue: 30, FirstCreatedAt: last, LastCreatedAt: last},
+		{Status: entities.OrderStatusRefunded, Orders: 1, Revenue: 70, FirstCreatedAt: first.Add(2 * time.Hour), LastCreatedAt: first.Add(2 * time.Hour)},
+	}, nil)
+
+	// When
+	summary, err := useCases.GetCustomerOrderSummary(ctx, 123)
+
+	// Then cancelled and refunded orders are counted but not spent
+	require.NoError(t, err)
+	assert.Equal(t, uint(123), summary.CustomerID)
+	assert.Equal(t, int64(6), summary.TotalOrders)
+	assert.Equal(t, 120.0, summary.TotalSpend)
+	assert.Equal(t, 30.0, summary.AverageOrderValue)
+	assert.Equal(t, int64(3), summary.OrdersByStatus[entities.OrderStatusDelivered])
+	assert.Equal(t, int64(1), summary.OrdersByStatus[entities.OrderStatusRefunded])
+	assert.Equal(t, int64(0), summary.OrdersByStatus[entities.OrderStatusShipped])
+	require.NotNil(t, summary.FirstOrderAt)
+	require.NotNil(t, summary.LastOrderAt)
+	assert.Equal(t, first, *summary.FirstOrderAt)
+	assert.Equal(t, last, *summary.LastOrderAt)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_GetCustomerOrderSummary_NoOrders(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+	mockRepo.On("AggregateByStatus", ctx, ports.OrderFilter{CustomerID: 123}).Return([]ports.StatusAggregate{}, nil)
+
+	// When
+	summary, err := useCases.GetCustomerOrderSummary(ctx, 123)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), summary.TotalOrders)
+	assert.Equal(t, 0.0, summary.TotalSpend)
+	assert.Equal(t, 0.0, summary.AverageOrderValue)
+	assert.Nil(t, summary.FirstOrderAt)
+	assert.Nil(t, summary.LastOrderAt)
+	assert.Len(t, summary.OrdersByStatus, len(entities.OrderStatuses()))
+	for status, orders := range summary.OrdersByStatus {
+		assert.Zero(t, orders, status)
+	}
+}
+
+func TestOrderUseCases_GetCustomerOrderSummary_Errors(t *testing.T) {
+	t.Run("invalid customer", func(t *testing.T) {
+		useCases, mockRepo := setupTestOrderUseCases()
+
+		_, err := useCases.GetCustomerOrderSummary(context.Background(), 0)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidCustomerID)
+		mockRepo.AssertNotCalled(t, "AggregateByStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("other customer", func(t *testing.T) {
+		useCases, mockRepo := setupTestOrderUseCases()
+		ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "storefront", Scopes: []auth.Scope{auth.ScopeOrdersRead}, CustomerID: 7})
+
+		_, err := useCases.GetCustomerOrderSummary(ctx, 123)
+
+		assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+		mockRepo.AssertNotCalled(t, "AggregateByStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("repository failure", func(t *testing.T) {
+		useCases, mockRepo := setupTestOrderUseCases()
+		ctx := context.Background()
+		mockRepo.On("AggregateByStatus", ctx, ports.OrderFilter{CustomerID: 123}).Return(nil, assert.AnError)
+
+		_, err := useCases.GetCustomerOrderSummary(ctx, 123)
+
+		assert.ErrorIs(t, err, domainErrors.ErrFailedToGetOrderStats)
+	})
+}
+
+func TestOrderUseCases_GetOrderStats_EmptyRange(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	mockRepo.On("AggregateByStatus", ctx, mock.Anything).Return([]ports.StatusAggregate{}, nil)
+	mockRepo.On("AggregateByDay", ctx, mock.Anything).Return([]ports.DailyAggregate{}, nil)
+
+	// When
+	stats, err := useCases.GetOrderStats(ctx, &dto.OrderFilterDTO{})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), stats.TotalOrders)
+	assert.Equal(t, 0.0, stats.TotalRevenue)
+	assert.Equal(t, 0.0, stats.AverageOrderValue)
+	assert.Empty(t, stats.ByStatus)
+	assert.Len(t, stats.Daily, 30)
+	assert.Equal(t, 30*24*time.Hour, stats.To.Sub(stats.From))
+	for _, day := range stats.Daily {
+		assert.Equal(t, int64(0), day.Orders)
+	}
+}
+
+func TestOrderUseCases_GetOrderStats_InvalidRange(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	longAgo := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, filter := range []*dto.OrderFilterDTO{
+		{From: &from, To: &to},
+		{From: &longAgo, To: &to},
+	} {
+		// When
+		stats, err := useCases.GetOrderStats(ctx, filter)
+
+		// Then
+		assert.Nil(t, stats)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidDateRange)
+	}
+	mockRepo.AssertNotCalled(t, "AggregateByStatus", mock.Anything, mock.Anything)
+}
+
+// recordingPublisher keeps every published event in memory
+type recordingPublisher struct {
+	events []events.OrderEvent
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event events.OrderEvent) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+func TestOrderUseCases_PublishesEventsAfterWrites(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	publisher := &recordingPublisher{}
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, publisher, nil, logger.New("test"), DefaultOrderUseCasesConfig())
+	ctx := context.Background()
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+	existingOrder.AddItem(1, "SKU-001", "Product 1", 2, 10.50)
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(existingOrder), nil)
+
+	// When
+	_, err := useCases.AddItemToOrder(ctx, 1, &dto.AddOrderItemRequestDTO{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 5})
+	require.NoError(t, err)
+	_, err = useCases.ConfirmOrder(ctx, 1, nil)
+	require.NoError(t, err)
+
+	// Then
+	require.Len(t, publisher.events, 2)
+	assert.Equal(t, events.OrderItemsChanged, publisher.events[0].Type)
+	assert.Equal(t, events.OrderStatusChanged, publisher.events[1].Type)
+	assert.Equal(t, entities.OrderStatusConfirmed, publisher.events[1].Status)
+	assert.Equal(t, uint(123), publisher.events[1].CustomerID)
+}
+
+func TestOrderUseCases_DoesNotPublishFailedWrites(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	publisher := &recordingPublisher{}
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, publisher, nil, logger.New("test"), DefaultOrderUseCasesConfig())
+	ctx := context.Background()
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+	existingOrder.AddItem(1, "SKU-001", "Product 1", 2, 10.50)
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	// When
+	_, err := useCases.ConfirmOrder(ctx, 1, nil)
+
+	// Then
+	require.Error(t, err)
+	assert.Empty(t, publisher.events)
+}
+
+// ExpirePendingOrders Tests
+func TestOrderUseCases_CreateOrder_SetsExpiry(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), OrderUseCasesConfig{
+		PendingOrderTTL: 72 * time.Hour,
+	})
+	ctx := context.Background()
+
+	mockRepo.On("NextOrderNumberSequence", mock.Anything, mock.Anything).Return(uint64(1), nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.ExpiresAt != nil && order.ExpiresAt.Equal(order.CreatedAt.Add(72*time.Hour))
+	})).Return(&entities.Order{ID: 1, CustomerID: 123, Status: entities.OrderStatusPending}, nil)
+
+	// When
+	_, err := useCases.CreateOrder(ctx, &dto.CreateOrderRequestDTO{CustomerID: 123})
+
+	// Then
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_ExpirePendingOrders_Success(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	publisher := &recordingPublisher{}
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, publisher, nil, logger.New("test"), DefaultOrderUseCasesConfig())
+	ctx := context.Background()
+
+	now := time.Now()
+	expiresAt := now.Add(-time.Minute)
+	orders := []*entities.Order{
+		{ID: 1, CustomerID: 123, Status: entities.OrderStatusPending, ExpiresAt: &expiresAt},
+		{ID: 2, CustomerID: 456, Status: entities.OrderStatusPending, ExpiresAt: &expiresAt},
+	}
+
+	mockRepo.On("FindExpiredPending", ctx, now, 10).Return(orders, nil)
+	for _, order := range orders {
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(expired *entities.Order) bool {
+			return expired.ID == order.ID && expired.Status == entities.OrderStatusExpired
+		})).Return(storeInto(order), nil)
+	}
+
+	// When
+	expired, err := useCases.ExpirePendingOrders(ctx, now, 10)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 2, expired)
+	require.Len(t, publisher.events, 2)
+	assert.Equal(t, events.OrderExpired, publisher.events[0].Type)
+	assert.Equal(t, uint(1), publisher.events[0].OrderID)
+	assert.Equal(t, entities.OrderStatusExpired, publisher.events[0].Status)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_ExpirePendingOrders_SkipsFailedUpdates(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	publisher := &recordingPublisher{}
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, publisher, nil, logger.New("test"), DefaultOrderUseCasesConfig())
+	ctx := context.Background()
+
+	now := time.Now()
+	expiresAt := now.Add(-time.Minute)
+	orders := []*entities.Order{
+		{ID: 1, CustomerID: 123, Status: entities.OrderStatusPending, ExpiresAt: &expiresAt},
+	}
+
+	mockRepo.On("FindExpiredPending", ctx, now, 10).Return(orders, nil)
+	mockRepo.On("Update", ctx, mock.Anything).Return(nil, assert.AnError)
+
+	// When
+	expired, err := useCases.ExpirePendingOrders(ctx, now, 10)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 0, expired)
+	assert.Empty(t, publisher.events)
+	assert.Equal(t, entities.OrderStatusPending, orders[0].Status, "the loaded order is left as read")
+	assert.Equal(t, &expiresAt, orders[0].ExpiresAt)
+}
+
+func TestOrderUseCases_ExpirePendingOrders_RepositoryError(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+	now := time.Now()
+
+	mockRepo.On("FindExpiredPending", ctx, now, 10).Return(nil, assert.AnError)
+
+	// When
+	expired, err := useCases.ExpirePendingOrders(ctx, now, 10)
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToExpireOrders)
+	assert.Equal(t, 0, expired)
+}
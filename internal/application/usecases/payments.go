@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+)
+
+// settlePayment keeps the payment of an order in step with a status change, from being the status
+// before the change: confirming a pending order authorizes its total, shipping it captures the
+// authorization and cancelling it voids the authorization. Nothing happens without a payment gateway or,
+// for capture and void, without an outstanding authorization.
+//
+// The gateway is called while the order is locked, before it is stored, so a gateway failure fails the
+// status change and leaves the order as it was. The ID of a new authorization is returned for the caller
+// to void should the order then fail to be stored. A capture or void whose order fails to be stored is
+// repeated by the next attempt of the status change, which the gateway accepts.
+func (uc *orderUseCasesImpl) settlePayment(ctx context.Context, from entities.OrderStatus, order *entities.Order) (string, error) {
+	gateway := uc.config.PaymentGateway
+	if gateway == nil || from == order.Status {
+		return "", nil
+	}
+
+	switch {
+	case from == entities.OrderStatusPending && order.Status == entities.OrderStatusConfirmed:
+		authorizationID, err := gateway.Authorize(ctx, order.ID, order.TotalAmount, uc.config.Currency)
+		if err != nil {
+			return "", uc.paymentError(ctx, order.ID, "authorize", err)
+		}
+		if err := order.RecordPaymentAuthorization(authorizationID); err != nil {
+			uc.log(ctx).Error("Payment gateway returned an invalid authorization", "order_id", order.ID, "error", err)
+			return "", domainErrors.WrapDomainError(domainErrors.ErrPaymentUnavailable, err)
+		}
+		uc.log(ctx).Info("Payment authorized", "order_id", order.ID, "authorization_id", authorizationID)
+		return authorizationID, nil
+
+	case !order.HasOutstandingAuthorization():
+		return "", nil
+
+	case order.Status == entities.OrderStatusCancelled:
+		if err := gateway.Void(ctx, order.PaymentAuthorizationID); err != nil {
+			return "", uc.paymentError(ctx, order.ID, "void", err)
+		}
+		uc.log(ctx).Info("Payment voided", "order_id", order.ID, "authorization_id", order.PaymentAuthorizationID)
+		return "", order.RecordPaymentVoid()
+
+	case order.Status == entities.OrderStatusPartiallyShipped || order.Status == entities.OrderStatusShipped ||
+		order.Status == entities.OrderStatusDelivered:
+		if err := gateway.Capture(ctx, order.PaymentAuthorizationID, order.TotalAmount); err != nil {
+			return "", uc.paymentError(ctx, order.ID, "capture", err)
+		}
+		uc.log(ctx).Info("Payment captured", "order_id", order.ID, "authorization_id", order.PaymentAuthorizationID)
+		return "", order.RecordPaymentCapture()
+	}
+	return "", nil
+}
+
+// voidAbandonedAuthorization compensates an authorization taken for an order whose confirmation then
+// failed to be stored. The void outlives a cancelled request. A failure is only logged: the order was
+// never confirmed and the authorization lapses at the gateway on its own.
+func (uc *orderUseCasesImpl) voidAbandonedAuthorization(ctx context.Context, orderID uint, authorizationID string) {
+	if authorizationID == "" {
+		return
+	}
+
+	if err := uc.config.PaymentGateway.Void(context.WithoutCancel(ctx), authorizationID); err != nil {
+		uc.log(ctx).Error("Failed to void the authorization of an order not confirmed", "order_id", orderID, "authorization_id", authorizationID, "error", err)
+		return
+	}
+	uc.log(ctx).Warn("Voided the authorization of an order not confirmed", "order_id", orderID, "authorization_id", authorizationID)
+}
+
+// paymentError converts a failed gateway call into the matching domain error
+func (uc *orderUseCasesImpl) paymentError(ctx context.Context, orderID uint, operation string, err error) error {
+	if ctx.Err() != nil {
+		return domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+	}
+	if errors.Is(err, ports.ErrPaymentDeclined) {
+		uc.log(ctx).Warn("Payment declined", "order_id", orderID, "operation", operation, "error", err)
+		return domainErrors.WrapDomainError(domainErrors.ErrPaymentDeclined, err)
+	}
+	uc.log(ctx).Error("Payment gateway failed", "order_id", orderID, "operation", operation, "error", err)
+	return domainErrors.WrapDomainError(domainErrors.ErrPaymentUnavailable, err)
+}
@@ -0,0 +1,271 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePaymentGateway records the calls it receives and fails the operations given an error
+type fakePaymentGateway struct {
+	authorizeErr, captureErr, voidErr error
+
+	authorized []float64
+	currencies []string
+	captured   []string
+	voided     []string
+}
+
+func (g *fakePaymentGateway) Authorize(_ context.Context, orderID uint, amount float64, currency string) (string, error) {
+	if g.authorizeErr != nil {
+		return "", g.authorizeErr
+	}
+	g.authorized = append(g.authorized, amount)
+	g.currencies = append(g.currencies, currency)
+	return fmt.Sprintf("auth-%d", orderID), nil
+}
+
+func (g *fakePaymentGateway) Capture(_ context.Context, authorizationID string, _ float64) error {
+	if g.captureErr != nil {
+		return g.captureErr
+	}
+	g.captured = append(g.captured, authorizationID)
+	return nil
+}
+
+func (g *fakePaymentGateway) Void(_ context.Context, authorizationID string) error {
+	g.voided = append(g.voided, authorizationID)
+	return g.voidErr
+}
+
+// setupPaymentUseCases builds use cases taking payments through gateway
+func setupPaymentUseCases(gateway ports.PaymentGateway) (OrderUseCases, *MockOrderRepository) {
+	mockRepo := new(MockOrderRepository)
+	config := DefaultOrderUseCasesConfig()
+	config.PaymentGateway = gateway
+	return NewOrderUseCasesWithConfig(mockRepo, nil, nil, nil, logger.New("test"), config), mockRepo
+}
+
+// authorizedTestOrder returns confirmed order 1 holding authorization auth-1
+func authorizedTestOrder(t *testing.T) *entities.Order {
+	t.Helper()
+	order := confirmedTestOrder(t)
+	require.NoError(t, order.RecordPaymentAuthorization("auth-1"))
+	return order
+}
+
+func TestOrderUseCases_ConfirmOrder_AuthorizesPayment(t *testing.T) {
+	// Given
+	gateway := &fakePaymentGateway{}
+	useCases, mockRepo := setupPaymentUseCases(gateway)
+	ctx := context.Background()
+
+	existingOrder := pendingTestOrder(t)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.PaymentAuthorizationID == "auth-1" && order.PaymentStatus == entities.PaymentStatusAuthorized
+	})).Return(storeInto(existingOrder), nil)
+
+	// When
+	result, err := useCases.ConfirmOrder(ctx, 1, nil)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusConfirmed, result.Status)
+	assert.Equal(t, entities.PaymentStatusAuthorized, result.PaymentStatus)
+	assert.Equal(t, []float64{25}, gateway.authorized)
+	assert.Equal(t, []string{"USD"}, gateway.currencies)
+	assert.Empty(t, gateway.voided)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_ConfirmOrder_AuthorizationFails(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected *domainErrors.DomainError
+	}{
+		{name: "declined", err: fmt.Errorf("insufficient funds: %w", ports.ErrPaymentDeclined), expected: domainErrors.ErrPaymentDeclined},
+		{name: "gateway unreachable", err: assert.AnError, expected: domainErrors.ErrPaymentUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			gateway := &fakePaymentGateway{authorizeErr: tt.err}
+			useCases, mockRepo := setupPaymentUseCases(gateway)
+			ctx := context.Background()
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(pendingTestOrder(t), nil)
+
+			// When
+			result, err := useCases.ConfirmOrder(ctx, 1, nil)
+
+			// Then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Empty(t, gateway.voided, "nothing was authorized")
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCases_ConfirmOrder_VoidsAuthorizationWhenNotStored(t *testing.T) {
+	tests := []struct {
+		name    string
+		voidErr error
+	}{
+		{name: "void succeeds"},
+		{name: "void fails", voidErr: assert.AnError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			gateway := &fakePaymentGateway{voidErr: tt.voidErr}
+			useCases, mockRepo := setupPaymentUseCases(gateway)
+			ctx := context.Background()
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(pendingTestOrder(t), nil)
+			mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+			// When
+			result, err := useCases.ConfirmOrder(ctx, 1, nil)
+
+			// Then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, domainErrors.ErrFailedToUpdateOrder, "the storage failure is reported, not the void")
+			assert.Len(t, gateway.authorized, 1)
+			assert.Equal(t, []string{"auth-1"}, gateway.voided)
+		})
+	}
+}
+
+func TestOrderUseCases_ConfirmOrder_WithoutPaymentGateway(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	existingOrder := pendingTestOrder(t)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(existingOrder), nil)
+
+	// When
+	result, err := useCases.ConfirmOrder(ctx, 1, nil)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusConfirmed, result.Status)
+	assert.Empty(t, result.PaymentStatus)
+}
+
+func TestOrderUseCases_CancelOrder_VoidsAuthorization(t *testing.T) {
+	// Given
+	gateway := &fakePaymentGateway{}
+	useCases, mockRepo := setupPaymentUseCases(gateway)
+	ctx := context.Background()
+
+	existingOrder := authorizedTestOrder(t)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.Status == entities.OrderStatusCancelled && order.PaymentStatus == entities.PaymentStatusVoided
+	})).Return(storeInto(existingOrder), nil)
+
+	// When
+	result, err := useCases.CancelOrder(ctx, 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.PaymentStatusVoided, result.PaymentStatus)
+	assert.Equal(t, []string{"auth-1"}, gateway.voided)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_CancelOrder_VoidFails(t *testing.T) {
+	// Given
+	gateway := &fakePaymentGateway{voidErr: assert.AnError}
+	useCases, mockRepo := setupPaymentUseCases(gateway)
+	ctx := context.Background()
+
+	existingOrder := authorizedTestOrder(t)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+	// When
+	result, err := useCases.CancelOrder(ctx, 1)
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrPaymentUnavailable)
+	assert.Equal(t, entities.OrderStatusConfirmed, existingOrder.Status, "the order keeps its authorization")
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_CancelOrder_WithoutAuthorization(t *testing.T) {
+	// Given
+	gateway := &fakePaymentGateway{}
+	useCases, mockRepo := setupPaymentUseCases(gateway)
+	ctx := context.Background()
+
+	existingOrder := pendingTestOrder(t)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(existingOrder), nil)
+
+	// When
+	result, err := useCases.CancelOrder(ctx, 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusCancelled, result.Status)
+	assert.Empty(t, gateway.voided)
+}
+
+func TestOrderUseCases_TransitionOrderStatus_CapturesPaymentOnShipping(t *testing.T) {
+	// Given
+	gateway := &fakePaymentGateway{}
+	useCases, mockRepo := setupPaymentUseCases(gateway)
+	ctx := context.Background()
+
+	existingOrder := authorizedTestOrder(t)
+	existingOrder.Status = entities.OrderStatusProcessing
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.Status == entities.OrderStatusShipped && order.PaymentStatus == entities.PaymentStatusCaptured
+	})).Return(storeInto(existingOrder), nil)
+
+	// When
+	result, err := useCases.TransitionOrderStatus(ctx, 1, &dto.UpdateOrderStatusRequestDTO{Status: entities.OrderStatusShipped})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.PaymentStatusCaptured, result.PaymentStatus)
+	assert.Equal(t, []string{"auth-1"}, gateway.captured)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_TransitionOrderStatus_CaptureFails(t *testing.T) {
+	// Given
+	gateway := &fakePaymentGateway{captureErr: fmt.Errorf("authorization expired: %w", ports.ErrPaymentDeclined)}
+	useCases, mockRepo := setupPaymentUseCases(gateway)
+	ctx := context.Background()
+
+	existingOrder := authorizedTestOrder(t)
+	existingOrder.Status = entities.OrderStatusProcessing
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+	// When
+	result, err := useCases.TransitionOrderStatus(ctx, 1, &dto.UpdateOrderStatusRequestDTO{Status: entities.OrderStatusShipped})
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrPaymentDeclined)
+	assert.Equal(t, entities.PaymentStatusAuthorized, existingOrder.PaymentStatus)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
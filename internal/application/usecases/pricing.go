@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
+)
+
+// Errors kept internal to repricing
+var (
+	errNoProductCatalog = errors.New("no product catalog configured")
+	errPricesUnchanged  = errors.New("catalog prices match the order")
+)
+
+// RepriceOrder refreshes the unit prices of a pending order to the current catalog prices and
+// recalculates its totals. The prices are read before the order is locked, an unavailable catalog
+// leaves the order untouched. An order whose prices all match is not written.
+func (uc *orderUseCasesImpl) RepriceOrder(ctx context.Context, orderID uint) (*dto.RepriceOrderResponseDTO, error) {
+	uc.log(ctx).Info("RepriceOrder use case called", "order_id", orderID)
+
+	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		uc.log(ctx).Error("Failed to get order", "order_id", orderID, "error", err)
+		return nil, err
+	}
+	if err := uc.authorizeCustomer(ctx, order.CustomerID); err != nil {
+		return nil, err
+	}
+	if !order.IsPending() {
+		return nil, domainErrors.ErrOrderNotRepriceable
+	}
+
+	prices, err := uc.catalogPrices(ctx, order.ProductIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	var current *entities.Order
+	var changes []entities.PriceChange
+	before, updatedOrder, err := uc.modifyOrder(ctx, orderID, func(order *entities.Order) error {
+		order.Limits = uc.config.OrderLimits
+		current = order
+
+		var err error
+		changes, err = order.Reprice(prices)
+		if err != nil {
+			uc.log(ctx).Error("Failed to reprice order", "order_id", orderID, "error", err)
+			return repriceError(err)
+		}
+		if len(changes) == 0 {
+			return errPricesUnchanged
+		}
+		return nil
+	})
+	if errors.Is(err, errPricesUnchanged) {
+		uc.log(ctx).Info("RepriceOrder success, prices unchanged", "order_id", orderID)
+		return dto.RepriceOrderToResponseDTO(current, changes), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	uc.audit(ctx, entities.AuditActionItemsRepriced, orderID, before, updatedOrder)
+	uc.publish(ctx, events.NewOrderEvent(events.OrderItemsChanged, updatedOrder, time.Now()))
+
+	uc.log(ctx).Info("RepriceOrder success", "order_id", orderID, "changed_items", len(changes))
+	return dto.RepriceOrderToResponseDTO(updatedOrder, changes), nil
+}
+
+// catalogPrices reads the current prices of the products from the catalog, any failure is reported as ErrCatalogUnavailable
+func (uc *orderUseCasesImpl) catalogPrices(ctx context.Context, productIDs []uint) (map[uint]float64, error) {
+	if uc.config.ProductCatalog == nil {
+		uc.log(ctx).Error("Product catalog is unavailable", "error", errNoProductCatalog)
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrCatalogUnavailable, errNoProductCatalog)
+	}
+
+	prices, err := uc.config.ProductCatalog.GetPrices(ctx, productIDs)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, domainErrors.WrapDomainError(domainErrors.ErrRequestCancelled, err)
+		}
+		uc.log(ctx).Error("Failed to get catalog prices", "error", err)
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrCatalogUnavailable, err)
+	}
+	return prices, nil
+}
+
+// repriceError converts a rejected repricing into the matching domain error, other errors go through orderLimitError
+func repriceError(err error) error {
+	switch {
+	case errors.Is(err, entities.ErrRepriceNotAllowed):
+		return domainErrors.ErrOrderNotRepriceable
+	case errors.Is(err, entities.ErrProductNotPriced):
+		return domainErrors.ErrProductNotInCatalog.WithDetails(map[string]interface{}{"reason": err.Error()})
+	default:
+		return orderLimitError(err)
+	}
+}
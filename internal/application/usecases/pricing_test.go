@@ -0,0 +1,153 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProductCatalog answers with fixed prices or err
+type fakeProductCatalog struct {
+	prices map[uint]float64
+	err    error
+}
+
+func (c *fakeProductCatalog) GetPrices(_ context.Context, productIDs []uint) (map[uint]float64, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	prices := make(map[uint]float64, len(productIDs))
+	for _, id := range productIDs {
+		if price, ok := c.prices[id]; ok {
+			prices[id] = price
+		}
+	}
+	return prices, nil
+}
+
+func setupRepriceUseCases(catalog *fakeProductCatalog) (OrderUseCases, *MockOrderRepository, *recordingPublisher) {
+	mockRepo := new(MockOrderRepository)
+	publisher := &recordingPublisher{}
+	config := DefaultOrderUseCasesConfig()
+	if catalog != nil {
+		config.ProductCatalog = catalog
+	}
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, publisher, nil, logger.New("test"), config)
+	return useCases, mockRepo, publisher
+}
+
+// pendingPricedOrder returns a pending order of 2 units of product 1 at 10 and 1 unit of product 2 at 5
+func pendingPricedOrder() *entities.Order {
+	order := processingOrder()
+	order.Status = entities.OrderStatusPending
+	return order
+}
+
+func TestOrderUseCases_RepriceOrder_UpdatesChangedLines(t *testing.T) {
+	// Given
+	useCases, mockRepo, publisher := setupRepriceUseCases(&fakeProductCatalog{prices: map[uint]float64{1: 12.5, 2: 5.0}})
+	ctx := context.Background()
+
+	order := pendingPricedOrder()
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(pendingPricedOrder(), nil)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.TotalAmount == 30.0
+	})).Return(storeInto(order), nil)
+
+	// When
+	result, err := useCases.RepriceOrder(ctx, 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 30.0, result.TotalAmount)
+	require.Len(t, result.PriceChanges, 1)
+	assert.Equal(t, uint(1), result.PriceChanges[0].ProductID)
+	assert.Equal(t, 10.0, result.PriceChanges[0].OldUnitPrice)
+	assert.Equal(t, 12.5, result.PriceChanges[0].NewUnitPrice)
+
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, events.OrderItemsChanged, publisher.events[0].Type)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_RepriceOrder_UnchangedPricesAreNotWritten(t *testing.T) {
+	// Given
+	useCases, mockRepo, publisher := setupRepriceUseCases(&fakeProductCatalog{prices: map[uint]float64{1: 10.0, 2: 5.0}})
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(pendingPricedOrder(), nil)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(pendingPricedOrder(), nil)
+
+	// When
+	result, err := useCases.RepriceOrder(ctx, 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Empty(t, result.PriceChanges)
+	assert.Equal(t, 25.0, result.TotalAmount)
+	assert.Empty(t, publisher.events)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_RepriceOrder_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		catalog  *fakeProductCatalog
+		status   entities.OrderStatus
+		expected error
+	}{
+		{"confirmed order", &fakeProductCatalog{prices: map[uint]float64{1: 12.5, 2: 5.0}}, entities.OrderStatusConfirmed, domainErrors.ErrOrderNotRepriceable},
+		{"catalog unreachable", &fakeProductCatalog{err: errors.New("connection refused")}, entities.OrderStatusPending, domainErrors.ErrCatalogUnavailable},
+		{"no catalog configured", nil, entities.OrderStatusPending, domainErrors.ErrCatalogUnavailable},
+		{"product missing from catalog", &fakeProductCatalog{prices: map[uint]float64{1: 12.5}}, entities.OrderStatusPending, domainErrors.ErrProductNotInCatalog},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo, publisher := setupRepriceUseCases(tt.catalog)
+			ctx := context.Background()
+
+			order := pendingPricedOrder()
+			order.Status = tt.status
+			mockRepo.On("GetByID", mock.Anything, uint(1)).Return(order, nil)
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil).Maybe()
+
+			// When
+			result, err := useCases.RepriceOrder(ctx, 1)
+
+			// Then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Equal(t, 10.0, order.Items[0].UnitPrice)
+			assert.Empty(t, publisher.events)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCases_RepriceOrder_OtherCustomer(t *testing.T) {
+	// Given
+	useCases, mockRepo, _ := setupRepriceUseCases(&fakeProductCatalog{prices: map[uint]float64{1: 12.5, 2: 5.0}})
+	ctx := customerContext(456)
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(pendingPricedOrder(), nil)
+
+	// When
+	result, err := useCases.RepriceOrder(ctx, 1)
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+	mockRepo.AssertNotCalled(t, "GetByIDForUpdate", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
+)
+
+// UpdateOrder changes the details of an order: its priority while pending or confirmed, the contact
+// details of the customer while pending
+func (uc *orderUseCasesImpl) UpdateOrder(ctx context.Context, orderID uint, request *dto.UpdateOrderRequestDTO) (*dto.OrderResponseDTO, error) {
+	// The contact details are personal data, only whether they change is logged
+	uc.log(ctx).Info("UpdateOrder use case called", "order_id", orderID, "priority", request.Priority, "changes_contact", request.ChangesContact())
+
+	// Apply the changes to the locked order and store it
+	before, updatedOrder, err := uc.modifyOrder(ctx, orderID, func(order *entities.Order) error {
+		if request.Priority != "" {
+			if err := order.SetPriority(request.Priority); err != nil {
+				uc.log(ctx).Error("Failed to change order priority", "order_id", orderID, "error", err)
+				return priorityError(err)
+			}
+		}
+		if request.ChangesContact() {
+			email, name := order.CustomerEmail, order.CustomerName
+			if request.CustomerEmail != nil {
+				email = *request.CustomerEmail
+			}
+			if request.CustomerName != nil {
+				name = *request.CustomerName
+			}
+			if err := order.SetContact(email, name); err != nil {
+				uc.log(ctx).Error("Failed to change order contact", "order_id", orderID, "error", err)
+				return contactError(err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if before.Priority != updatedOrder.Priority {
+		uc.audit(ctx, entities.AuditActionPriorityChanged, orderID, before, updatedOrder)
+		uc.publish(ctx, events.NewOrderEvent(events.OrderPriorityChanged, updatedOrder, time.Now()))
+	}
+	if before.CustomerEmail != updatedOrder.CustomerEmail || before.CustomerName != updatedOrder.CustomerName {
+		uc.audit(ctx, entities.AuditActionContactChanged, orderID, before, updatedOrder)
+		uc.publish(ctx, events.NewOrderEvent(events.OrderContactChanged, updatedOrder, time.Now()))
+	}
+
+	uc.log(ctx).Info("UpdateOrder success", "order_id", orderID, "priority", updatedOrder.Priority)
+	return dto.OrderToResponseDTO(updatedOrder), nil
+}
+
+// GetOrderQueue retrieves the orders in a status in the order fulfillment works through them,
+// the most urgent first and the oldest first within a priority
+func (uc *orderUseCasesImpl) GetOrderQueue(ctx context.Context, status entities.OrderStatus, page, pageSize int) (*dto.OrderListResponseDTO, error) {
+	uc.log(ctx).Info("GetOrderQueue use case called", "status", status, "page", page, "page_size", pageSize)
+
+	// Validate status
+	if err := entities.ValidateOrderStatus(status); err != nil {
+		uc.log(ctx).Error("Invalid order status", "status", status, "error", err)
+		return nil, invalidStatusError(status)
+	}
+
+	// Validate pagination
+	page, pageSize, err := validatePagination(page, pageSize, uc.maxPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	filter := ports.OrderFilter{CustomerID: boundCustomer(ctx), Status: status}
+	orders, err := uc.orderRepo.ListByFilterOrderedByPriority(ctx, filter, pageSize, page*pageSize)
+	if err != nil {
+		uc.log(ctx).Error("Failed to get order queue", "status", status, "error", err)
+		return nil, repositoryError(err, domainErrors.ErrFailedToListOrders)
+	}
+
+	total, err := uc.orderRepo.CountByFilter(ctx, filter)
+	if err != nil {
+		uc.log(ctx).Error("Failed to count order queue", "status", status, "error", err)
+		return nil, repositoryError(err, domainErrors.ErrFailedToListOrders)
+	}
+
+	uc.log(ctx).Info("GetOrderQueue success", "status", status, "count", len(orders))
+	return dto.NewOrderListResponseDTO(orders, total, page, pageSize), nil
+}
+
+// priorityError converts a rejected priority into the matching domain error. Other errors are returned unchanged.
+func priorityError(err error) error {
+	switch {
+	case errors.Is(err, entities.ErrInvalidPriority):
+		return domainErrors.ErrInvalidOrderPriority.WithDetails(map[string]interface{}{"reason": err.Error()})
+	case errors.Is(err, entities.ErrPriorityChangeNotAllowed):
+		return domainErrors.ErrPriorityChangeNotAllowed
+	default:
+		return err
+	}
+}
+
+// contactError converts rejected contact details into the matching domain error. Other errors are returned unchanged.
+func contactError(err error) error {
+	switch {
+	case errors.Is(err, entities.ErrInvalidCustomerEmail):
+		return domainErrors.ErrInvalidCustomerEmail
+	case errors.Is(err, entities.ErrInvalidCustomerName):
+		return domainErrors.ErrInvalidCustomerName
+	case errors.Is(err, entities.ErrContactChangeNotAllowed):
+		return domainErrors.ErrContactChangeNotAllowed
+	default:
+		return err
+	}
+}
@@ -0,0 +1,215 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderUseCases_UpdateOrder_ChangesPriority(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	publisher := &recordingPublisher{}
+	auditor := &recordingAuditor{}
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, publisher, auditor, logger.New("test"), DefaultOrderUseCasesConfig())
+	ctx := context.Background()
+
+	existingOrder := confirmedTestOrder(t)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.Priority == entities.OrderPriorityUrgent
+	})).Return(storeInto(existingOrder), nil)
+
+	// When
+	result, err := useCases.UpdateOrder(ctx, 1, &dto.UpdateOrderRequestDTO{Priority: entities.OrderPriorityUrgent})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderPriorityUrgent, result.Priority)
+
+	assert.Equal(t, []entities.AuditAction{entities.AuditActionPriorityChanged}, auditor.actions)
+	assert.Equal(t, entities.OrderPriorityNormal, auditor.before[0].Priority)
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, events.OrderPriorityChanged, publisher.events[0].Type)
+	assert.Equal(t, entities.OrderPriorityUrgent, publisher.events[0].Priority)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_UpdateOrder_SamePriorityIsNotRecorded(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	publisher := &recordingPublisher{}
+	auditor := &recordingAuditor{}
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, publisher, auditor, logger.New("test"), DefaultOrderUseCasesConfig())
+	ctx := context.Background()
+
+	existingOrder := confirmedTestOrder(t)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(existingOrder), nil)
+
+	// When
+	result, err := useCases.UpdateOrder(ctx, 1, &dto.UpdateOrderRequestDTO{Priority: entities.OrderPriorityNormal})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderPriorityNormal, result.Priority)
+	assert.Empty(t, auditor.actions)
+	assert.Empty(t, publisher.events)
+}
+
+func TestOrderUseCases_UpdateOrder_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   entities.OrderStatus
+		priority entities.OrderPriority
+		expected error
+	}{
+		{"processing order", entities.OrderStatusProcessing, entities.OrderPriorityHigh, domainErrors.ErrPriorityChangeNotAllowed},
+		{"unknown priority", entities.OrderStatusConfirmed, "asap", domainErrors.ErrInvalidOrderPriority},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo := setupTestOrderUseCases()
+			ctx := context.Background()
+
+			existingOrder := confirmedTestOrder(t)
+			existingOrder.Status = tt.status
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+
+			// When
+			result, err := useCases.UpdateOrder(ctx, 1, &dto.UpdateOrderRequestDTO{Priority: tt.priority})
+
+			// Then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.expected)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCases_UpdateOrder_ChangesContact(t *testing.T) {
+	// Given
+	mockRepo := new(MockOrderRepository)
+	publisher := &recordingPublisher{}
+	auditor := &recordingAuditor{}
+	useCases := NewOrderUseCasesWithConfig(mockRepo, nil, publisher, auditor, logger.New("test"), DefaultOrderUseCasesConfig())
+	ctx := context.Background()
+
+	existingOrder := pendingTestOrder(t)
+	require.NoError(t, existingOrder.SetContact("jane@example.com", "Jane Doe"))
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(existingOrder), nil)
+	email := "jane.doe@example.com"
+
+	// When
+	result, err := useCases.UpdateOrder(ctx, 1, &dto.UpdateOrderRequestDTO{CustomerEmail: &email})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "jane.doe@example.com", result.CustomerEmail)
+	assert.Equal(t, "Jane Doe", result.CustomerName, "fields left out are kept")
+
+	assert.Equal(t, []entities.AuditAction{entities.AuditActionContactChanged}, auditor.actions)
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, events.OrderContactChanged, publisher.events[0].Type)
+	assert.Equal(t, "jane.doe@example.com", publisher.events[0].CustomerEmail)
+}
+
+func TestOrderUseCases_UpdateOrder_ContactRejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		order    func(t *testing.T) *entities.Order
+		email    string
+		expected error
+	}{
+		{"confirmed order", confirmedTestOrder, "jane@example.com", domainErrors.ErrContactChangeNotAllowed},
+		{"invalid email", pendingTestOrder, "jane", domainErrors.ErrInvalidCustomerEmail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo := setupTestOrderUseCases()
+			ctx := context.Background()
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(tt.order(t), nil)
+
+			// When
+			result, err := useCases.UpdateOrder(ctx, 1, &dto.UpdateOrderRequestDTO{CustomerEmail: &tt.email})
+
+			// Then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.expected)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCases_CreateOrder_InvalidEmail(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+
+	// When
+	result, err := useCases.CreateOrder(context.Background(), &dto.CreateOrderRequestDTO{CustomerID: 1, CustomerEmail: "jane"})
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidCustomerEmail)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_CreateOrder_UnknownPriority(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+
+	// When
+	result, err := useCases.CreateOrder(context.Background(), &dto.CreateOrderRequestDTO{CustomerID: 1, Priority: "asap"})
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidOrderPriority)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_GetOrderQueue(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+	ctx := context.Background()
+
+	urgent := confirmedTestOrder(t)
+	require.NoError(t, urgent.SetPriority(entities.OrderPriorityUrgent))
+	filter := ports.OrderFilter{Status: entities.OrderStatusConfirmed}
+	mockRepo.On("ListByFilterOrderedByPriority", ctx, filter, 10, 10).Return([]*entities.Order{urgent}, nil)
+	mockRepo.On("CountByFilter", ctx, filter).Return(int64(11), nil)
+
+	// When
+	result, err := useCases.GetOrderQueue(ctx, entities.OrderStatusConfirmed, 1, 10)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, result.Orders, 1)
+	assert.Equal(t, entities.OrderPriorityUrgent, result.Orders[0].Priority)
+	assert.Equal(t, int64(11), result.Total)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_GetOrderQueue_InvalidStatus(t *testing.T) {
+	useCases, mockRepo := setupTestOrderUseCases()
+
+	result, err := useCases.GetOrderQueue(context.Background(), "unknown", 0, 10)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidOrderStatus)
+	mockRepo.AssertNotCalled(t, "ListByFilterOrderedByPriority", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
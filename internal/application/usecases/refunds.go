@@ -0,0 +1,201 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
+)
+
+// errNoRefundRepository is returned when the unit of work was built without a refund repository
+var errNoRefundRepository = errors.New("no refund repository configured")
+
+// CreateRefund pays part or all of an order back. Refunding what is left of the order total moves the
+// order to refunded, a partial refund keeps its status.
+func (uc *orderUseCasesImpl) CreateRefund(ctx context.Context, orderID uint, request *dto.CreateRefundRequestDTO) (*dto.RefundResponseDTO, error) {
+	uc.log(ctx).Info("CreateRefund use case called", "order_id", orderID, "amount", request.Amount)
+
+	// Add the refund to the locked order and store both
+	before, updatedOrder, refund, err := uc.modifyRefunds(ctx, orderID, func(order *entities.Order) (*entities.Refund, error) {
+		refund, err := order.Refund(request.Amount, request.Reason, request.Reference, time.Now())
+		if err != nil {
+			uc.log(ctx).Error("Failed to refund order", "order_id", orderID, "error", err)
+			return nil, refundError(err, order)
+		}
+		return refund, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.audit(ctx, entities.AuditActionRefunded, orderID, before, updatedOrder)
+	uc.publish(ctx, events.NewRefundEvent(updatedOrder, refund, time.Now()))
+	uc.publishStatusChange(ctx, before, updatedOrder)
+
+	uc.log(ctx).Info("CreateRefund success", "order_id", orderID, "refund_id", refund.ID, "status", updatedOrder.Status)
+	return refundResponse(refund, updatedOrder), nil
+}
+
+// ListRefunds retrieves the refunds of an order, oldest first
+func (uc *orderUseCasesImpl) ListRefunds(ctx context.Context, orderID uint) (*dto.RefundListResponseDTO, error) {
+	uc.log(ctx).Info("ListRefunds use case called", "order_id", orderID)
+
+	var response *dto.RefundListResponseDTO
+	err := uc.inRefundsUnitOfWork(ctx, func(ctx context.Context, orders ports.OrderRepository, repo ports.RefundRepository) error {
+		order, err := orders.GetByID(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to get order", "order_id", orderID, "error", err)
+			return err
+		}
+		if err := uc.authorizeCustomer(ctx, order.CustomerID); err != nil {
+			return err
+		}
+
+		refunds, err := repo.ListByOrderID(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to list refunds", "order_id", orderID, "error", err)
+			return repositoryError(err, domainErrors.ErrFailedToGetRefunds)
+		}
+
+		response = &dto.RefundListResponseDTO{
+			OrderID:        order.ID,
+			OrderPublicID:  order.PublicID,
+			OrderStatus:    order.Status,
+			TotalAmount:    order.TotalAmount,
+			RefundedAmount: order.RefundedAmount,
+			Refunds:        make([]*dto.RefundResponseDTO, 0, len(refunds)),
+		}
+		for _, refund := range refunds {
+			response.Refunds = append(response.Refunds, dto.RefundToResponseDTO(refund))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log(ctx).Info("ListRefunds success", "order_id", orderID, "count", len(response.Refunds))
+	return response, nil
+}
+
+// refundOrder moves the order to refunded on request of a status change, recording the refund of what
+// earlier refunds left so the refunds of the order keep adding up to its refunded amount
+func (uc *orderUseCasesImpl) refundOrder(ctx context.Context, orderID uint) (*dto.OrderResponseDTO, error) {
+	var current *entities.Order
+	before, updatedOrder, refund, err := uc.modifyRefunds(ctx, orderID, func(order *entities.Order) (*entities.Refund, error) {
+		refund, err := order.RefundRemaining("", time.Now())
+		if err != nil {
+			if errors.Is(err, entities.ErrAlreadyInStatus) {
+				current = order
+				return nil, err
+			}
+			uc.log(ctx).Error("Failed to transition order status", "order_id", orderID, "error", err)
+			return nil, err
+		}
+		return refund, nil
+	})
+	if errors.Is(err, entities.ErrAlreadyInStatus) {
+		uc.log(ctx).Info("TransitionOrderStatus order already in requested status", "order_id", orderID, "status", entities.OrderStatusRefunded)
+		response := dto.OrderToResponseDTO(current)
+		response.AlreadyInState = true
+		return response, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	uc.audit(ctx, entities.AuditActionStatusChanged, orderID, before, updatedOrder)
+	if refund != nil {
+		uc.publish(ctx, events.NewRefundEvent(updatedOrder, refund, time.Now()))
+	}
+	uc.publish(ctx, events.NewOrderEvent(events.OrderStatusChanged, updatedOrder, time.Now()))
+
+	uc.log(ctx).Info("TransitionOrderStatus success", "order_id", orderID, "new_status", updatedOrder.Status)
+	return dto.OrderToResponseDTO(updatedOrder), nil
+}
+
+// modifyRefunds runs change on the locked order and stores the refund it returns along with the order.
+// No refund is stored when change returns none. It returns the order before and after the change along
+// with the stored refund.
+func (uc *orderUseCasesImpl) modifyRefunds(
+	ctx context.Context,
+	orderID uint,
+	change func(order *entities.Order) (*entities.Refund, error),
+) (before, after *entities.Order, refund *entities.Refund, err error) {
+	err = uc.inRefundsUnitOfWork(ctx, func(ctx context.Context, orders ports.OrderRepository, repo ports.RefundRepository) error {
+		order, err := orders.GetByIDForUpdate(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to get order", "order_id", orderID, "error", err)
+			return err
+		}
+		if err := uc.authorizeCustomer(ctx, order.CustomerID); err != nil {
+			return err
+		}
+		working := order.Clone()
+
+		refund, err = change(working)
+		if err != nil {
+			return err
+		}
+
+		if refund != nil {
+			refund, err = repo.Create(ctx, refund)
+			if err != nil {
+				uc.log(ctx).Error("Failed to store refund", "order_id", orderID, "error", err)
+				return repositoryError(err, domainErrors.ErrFailedToUpdateOrder)
+			}
+		}
+
+		after, err = orders.Update(ctx, working)
+		if err != nil {
+			uc.log(ctx).Error("Failed to update order", "order_id", orderID, "error", err)
+			return repositoryError(err, domainErrors.ErrFailedToUpdateOrder)
+		}
+		before = order
+		return nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return before, after, refund, nil
+}
+
+// inRefundsUnitOfWork runs fn in a unit of work with its order and refund repositories
+func (uc *orderUseCasesImpl) inRefundsUnitOfWork(ctx context.Context, fn func(ctx context.Context, orders ports.OrderRepository, refunds ports.RefundRepository) error) error {
+	return uc.unitOfWork.Do(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		if repos.Refunds == nil {
+			uc.log(ctx).Error("Refunds are unavailable", "error", errNoRefundRepository)
+			return domainErrors.WrapDomainError(domainErrors.ErrFailedToGetRefunds, errNoRefundRepository)
+		}
+		return fn(ctx, withRepositoryTimeout(repos.Orders, uc.config.RepositoryTimeout), repos.Refunds)
+	})
+}
+
+// refundResponse describes a refund along with the order it left behind
+func refundResponse(refund *entities.Refund, order *entities.Order) *dto.RefundResponseDTO {
+	response := dto.RefundToResponseDTO(refund)
+	response.OrderPublicID = order.PublicID
+	response.OrderStatus = order.Status
+	refundedAmount := order.RefundedAmount
+	response.RefundedAmount = &refundedAmount
+	return response
+}
+
+// refundError converts a rejected refund of order into the matching domain error, other errors are returned unchanged
+func refundError(err error, order *entities.Order) error {
+	switch {
+	case errors.Is(err, entities.ErrRefundNotAllowed):
+		return domainErrors.ErrRefundNotAllowed.WithDetails(map[string]interface{}{"status": order.Status})
+	case errors.Is(err, entities.ErrInvalidRefund):
+		return domainErrors.ErrInvalidRefund.WithDetails(map[string]interface{}{"reason": err.Error()})
+	case errors.Is(err, entities.ErrRefundExceedsTotal):
+		return domainErrors.ErrRefundExceedsTotal.WithDetails(map[string]interface{}{"remaining_amount": order.RemainingRefundableAmount()})
+	default:
+		return err
+	}
+}
@@ -0,0 +1,220 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRefundRepository keeps refunds in memory, oldest first
+type fakeRefundRepository struct {
+	refunds []*entities.Refund
+}
+
+func (r *fakeRefundRepository) Create(_ context.Context, refund *entities.Refund) (*entities.Refund, error) {
+	stored := refund.Clone()
+	stored.ID = uint(len(r.refunds) + 1)
+	r.refunds = append(r.refunds, stored)
+	return stored.Clone(), nil
+}
+
+func (r *fakeRefundRepository) ListByOrderID(_ context.Context, orderID uint) ([]*entities.Refund, error) {
+	refunds := make([]*entities.Refund, 0, len(r.refunds))
+	for _, refund := range r.refunds {
+		if refund.OrderID == orderID {
+			refunds = append(refunds, refund.Clone())
+		}
+	}
+	return refunds, nil
+}
+
+func setupRefundUseCases() (OrderUseCases, *MockOrderRepository, *fakeRefundRepository, *recordingPublisher, *recordingAuditor) {
+	mockRepo := new(MockOrderRepository)
+	refundRepo := &fakeRefundRepository{}
+	publisher := &recordingPublisher{}
+	auditor := &recordingAuditor{}
+	unitOfWork := NewInMemoryUnitOfWork(ports.Repositories{Orders: mockRepo, Refunds: refundRepo})
+	useCases := NewOrderUseCasesWithConfig(mockRepo, unitOfWork, publisher, auditor, logger.New("test"), DefaultOrderUseCasesConfig())
+	return useCases, mockRepo, refundRepo, publisher, auditor
+}
+
+// deliveredTestOrder returns a delivered order with a total of 25
+func deliveredTestOrder(t *testing.T) *entities.Order {
+	t.Helper()
+	order := pendingTestOrder(t)
+	order.Status = entities.OrderStatusDelivered
+	return order
+}
+
+func TestOrderUseCases_CreateRefund_PartialRefundKeepsStatus(t *testing.T) {
+	// Given
+	useCases, mockRepo, refundRepo, publisher, auditor := setupRefundUseCases()
+	ctx := context.Background()
+
+	order := deliveredTestOrder(t)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.RefundedAmount == 10 && order.Status == entities.OrderStatusDelivered
+	})).Return(storeInto(order), nil)
+
+	// When
+	result, err := useCases.CreateRefund(ctx, 1, &dto.CreateRefundRequestDTO{Amount: 10, Reason: "Damaged mug", Reference: "RF-1"})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+	assert.Equal(t, 10.0, result.Amount)
+	assert.Equal(t, "Damaged mug", result.Reason)
+	assert.Equal(t, entities.OrderStatusDelivered, result.OrderStatus)
+	require.NotNil(t, result.RefundedAmount)
+	assert.Equal(t, 10.0, *result.RefundedAmount)
+	require.Len(t, refundRepo.refunds, 1)
+
+	assert.Equal(t, []entities.AuditAction{entities.AuditActionRefunded}, auditor.actions)
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, events.OrderRefunded, publisher.events[0].Type)
+	assert.Equal(t, uint(1), publisher.events[0].RefundID)
+	assert.Equal(t, 10.0, publisher.events[0].RefundAmount)
+	assert.Equal(t, 10.0, publisher.events[0].RefundedAmount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_CreateRefund_FullRefundMovesOrderToRefunded(t *testing.T) {
+	// Given
+	useCases, mockRepo, refundRepo, publisher, _ := setupRefundUseCases()
+	ctx := context.Background()
+
+	order := deliveredTestOrder(t)
+	order.RefundedAmount = 10
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(order), nil)
+
+	// When
+	result, err := useCases.CreateRefund(ctx, 1, &dto.CreateRefundRequestDTO{Amount: 15})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusRefunded, result.OrderStatus)
+	assert.Equal(t, 25.0, *result.RefundedAmount)
+	require.Len(t, refundRepo.refunds, 1)
+
+	require.Len(t, publisher.events, 2)
+	assert.Equal(t, events.OrderRefunded, publisher.events[0].Type)
+	assert.Equal(t, events.OrderStatusChanged, publisher.events[1].Type)
+}
+
+func TestOrderUseCases_CreateRefund_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   entities.OrderStatus
+		amount   float64
+		expected error
+		details  map[string]interface{}
+	}{
+		{"shipped order", entities.OrderStatusShipped, 5, domainErrors.ErrRefundNotAllowed, map[string]interface{}{"status": entities.OrderStatusShipped}},
+		{"above the remaining amount", entities.OrderStatusDelivered, 20, domainErrors.ErrRefundExceedsTotal, map[string]interface{}{"remaining_amount": 15.0}},
+		{"fraction of a cent", entities.OrderStatusDelivered, 0.001, domainErrors.ErrInvalidRefund, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo, refundRepo, publisher, _ := setupRefundUseCases()
+			ctx := context.Background()
+
+			order := deliveredTestOrder(t)
+			order.Status = tt.status
+			order.RefundedAmount = 10
+			mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+
+			// When
+			result, err := useCases.CreateRefund(ctx, 1, &dto.CreateRefundRequestDTO{Amount: tt.amount})
+
+			// Then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.expected)
+			if tt.details != nil {
+				var domainErr *domainErrors.DomainError
+				require.ErrorAs(t, err, &domainErr)
+				assert.Equal(t, tt.details, domainErr.Details)
+			}
+			assert.Empty(t, refundRepo.refunds)
+			assert.Empty(t, publisher.events)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderUseCases_CreateRefund_WithoutRefundRepository(t *testing.T) {
+	// Given
+	useCases, mockRepo := setupTestOrderUseCases()
+
+	// When
+	result, err := useCases.CreateRefund(context.Background(), 1, &dto.CreateRefundRequestDTO{Amount: 5})
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToGetRefunds)
+	mockRepo.AssertNotCalled(t, "GetByIDForUpdate", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_ListRefunds(t *testing.T) {
+	// Given
+	useCases, mockRepo, refundRepo, _, _ := setupRefundUseCases()
+	ctx := context.Background()
+
+	order := deliveredTestOrder(t)
+	order.RefundedAmount = 12
+	refundRepo.refunds = []*entities.Refund{
+		{ID: 1, OrderID: 1, Amount: 5},
+		{ID: 2, OrderID: 2, Amount: 3},
+		{ID: 3, OrderID: 1, Amount: 7},
+	}
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(order, nil)
+
+	// When
+	result, err := useCases.ListRefunds(ctx, 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 25.0, result.TotalAmount)
+	assert.Equal(t, 12.0, result.RefundedAmount)
+	require.Len(t, result.Refunds, 2)
+	assert.Equal(t, uint(1), result.Refunds[0].ID)
+	assert.Equal(t, uint(3), result.Refunds[1].ID)
+}
+
+func TestOrderUseCases_TransitionOrderStatus_RefundedRecordsRemainder(t *testing.T) {
+	// Given
+	useCases, mockRepo, refundRepo, publisher, _ := setupRefundUseCases()
+	ctx := context.Background()
+
+	order := deliveredTestOrder(t)
+	order.RefundedAmount = 10
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(order), nil)
+
+	// When
+	result, err := useCases.TransitionOrderStatus(ctx, 1, &dto.UpdateOrderStatusRequestDTO{Status: entities.OrderStatusRefunded})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusRefunded, result.Status)
+	assert.Equal(t, 25.0, result.RefundedAmount)
+	require.Len(t, refundRepo.refunds, 1)
+	assert.Equal(t, 15.0, refundRepo.refunds[0].Amount)
+
+	require.Len(t, publisher.events, 2)
+	assert.Equal(t, events.OrderRefunded, publisher.events[0].Type)
+	assert.Equal(t, events.OrderStatusChanged, publisher.events[1].Type)
+}
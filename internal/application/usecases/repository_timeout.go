@@ -0,0 +1,247 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+)
+
+// timeoutOrderRepository bounds every repository call by timeout, or by the caller's deadline when that is sooner.
+// Errors of calls that ran out of time wrap context.DeadlineExceeded even when the driver reports them differently.
+// StreamByFilter is passed through, an export runs as long as its request allows.
+type timeoutOrderRepository struct {
+	ports.OrderRepository
+	timeout time.Duration
+}
+
+// withRepositoryTimeout wraps repo so its calls are bounded by timeout, 0 returns repo unchanged
+func withRepositoryTimeout(repo ports.OrderRepository, timeout time.Duration) ports.OrderRepository {
+	if timeout <= 0 {
+		return repo
+	}
+	return &timeoutOrderRepository{OrderRepository: repo, timeout: timeout}
+}
+
+func (r *timeoutOrderRepository) Create(ctx context.Context, order *entities.Order) (*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (*entities.Order, error) {
+		return r.OrderRepository.Create(ctx, order)
+	})
+}
+
+func (r *timeoutOrderRepository) GetByID(ctx context.Context, id uint) (*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (*entities.Order, error) {
+		return r.OrderRepository.GetByID(ctx, id)
+	})
+}
+
+func (r *timeoutOrderRepository) GetByIDIncludingDeleted(ctx context.Context, id uint) (*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (*entities.Order, error) {
+		return r.OrderRepository.GetByIDIncludingDeleted(ctx, id)
+	})
+}
+
+func (r *timeoutOrderRepository) GetByIDForUpdate(ctx context.Context, id uint) (*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (*entities.Order, error) {
+		return r.OrderRepository.GetByIDForUpdate(ctx, id)
+	})
+}
+
+func (r *timeoutOrderRepository) GetByExternalReference(ctx context.Context, customerID uint, reference string) (*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (*entities.Order, error) {
+		return r.OrderRepository.GetByExternalReference(ctx, customerID, reference)
+	})
+}
+
+func (r *timeoutOrderRepository) GetByPublicID(ctx context.Context, publicID string) (*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (*entities.Order, error) {
+		return r.OrderRepository.GetByPublicID(ctx, publicID)
+	})
+}
+
+func (r *timeoutOrderRepository) GetByOrderNumber(ctx context.Context, number string) (*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (*entities.Order, error) {
+		return r.OrderRepository.GetByOrderNumber(ctx, number)
+	})
+}
+
+func (r *timeoutOrderRepository) NextOrderNumberSequence(ctx context.Context, scope string) (uint64, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (uint64, error) {
+		return r.OrderRepository.NextOrderNumberSequence(ctx, scope)
+	})
+}
+
+func (r *timeoutOrderRepository) Update(ctx context.Context, order *entities.Order) (*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (*entities.Order, error) {
+		return r.OrderRepository.Update(ctx, order)
+	})
+}
+
+func (r *timeoutOrderRepository) Delete(ctx context.Context, id uint) error {
+	_, err := callWithTimeout(ctx, r.timeout, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, r.OrderRepository.Delete(ctx, id)
+	})
+	return err
+}
+
+func (r *timeoutOrderRepository) Restore(ctx context.Context, id uint) (*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (*entities.Order, error) {
+		return r.OrderRepository.Restore(ctx, id)
+	})
+}
+
+func (r *timeoutOrderRepository) ListDeleted(ctx context.Context, limit, offset int) ([]*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) ([]*entities.Order, error) {
+		return r.OrderRepository.ListDeleted(ctx, limit, offset)
+	})
+}
+
+func (r *timeoutOrderRepository) List(ctx context.Context, limit, offset int) ([]*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) ([]*entities.Order, error) {
+		return r.OrderRepository.List(ctx, limit, offset)
+	})
+}
+
+func (r *timeoutOrderRepository) GetByCustomerID(ctx context.Context, customerID uint, limit, offset int) ([]*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) ([]*entities.Order, error) {
+		return r.OrderRepository.GetByCustomerID(ctx, customerID, limit, offset)
+	})
+}
+
+func (r *timeoutOrderRepository) GetByStatus(ctx context.Context, status entities.OrderStatus, limit, offset int) ([]*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) ([]*entities.Order, error) {
+		return r.OrderRepository.GetByStatus(ctx, status, limit, offset)
+	})
+}
+
+func (r *timeoutOrderRepository) ListByFilterOrderedByPriority(ctx context.Context, filter ports.OrderFilter, limit, offset int) ([]*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) ([]*entities.Order, error) {
+		return r.OrderRepository.ListByFilterOrderedByPriority(ctx, filter, limit, offset)
+	})
+}
+
+func (r *timeoutOrderRepository) ListRecentByItemSet(ctx context.Context, customerID uint, itemSetHash string, since time.Time) ([]*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) ([]*entities.Order, error) {
+		return r.OrderRepository.ListRecentByItemSet(ctx, customerID, itemSetHash, since)
+	})
+}
+
+func (r *timeoutOrderRepository) GetByCustomerIDAndStatus(ctx context.Context, customerID uint, status entities.OrderStatus, limit, offset int) ([]*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) ([]*entities.Order, error) {
+		return r.OrderRepository.GetByCustomerIDAndStatus(ctx, customerID, status, limit, offset)
+	})
+}
+
+func (r *timeoutOrderRepository) ListByDateRange(ctx context.Context, from, to time.Time, limit, offset int) ([]*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) ([]*entities.Order, error) {
+		return r.OrderRepository.ListByDateRange(ctx, from, to, limit, offset)
+	})
+}
+
+func (r *timeoutOrderRepository) ListByFilter(ctx context.Context, filter ports.OrderFilter, limit, offset int) ([]*entities.Order, int64, error) {
+	var total int64
+	orders, err := callWithTimeout(ctx, r.timeout, func(ctx context.Context) ([]*entities.Order, error) {
+		orders, count, err := r.OrderRepository.ListByFilter(ctx, filter, limit, offset)
+		total = count
+		return orders, err
+	})
+	return orders, total, err
+}
+
+func (r *timeoutOrderRepository) FindExpiredPending(ctx context.Context, before time.Time, limit int) ([]*entities.Order, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) ([]*entities.Order, error) {
+		return r.OrderRepository.FindExpiredPending(ctx, before, limit)
+	})
+}
+
+func (r *timeoutOrderRepository) Count(ctx context.Context) (int64, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (int64, error) {
+		return r.OrderRepository.Count(ctx)
+	})
+}
+
+func (r *timeoutOrderRepository) CountDeleted(ctx context.Context) (int64, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (int64, error) {
+		return r.OrderRepository.CountDeleted(ctx)
+	})
+}
+
+func (r *timeoutOrderRepository) CountByCustomerID(ctx context.Context, customerID uint) (int64, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (int64, error) {
+		return r.OrderRepository.CountByCustomerID(ctx, customerID)
+	})
+}
+
+func (r *timeoutOrderRepository) CountByStatus(ctx context.Context, status entities.OrderStatus) (int64, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (int64, error) {
+		return r.OrderRepository.CountByStatus(ctx, status)
+	})
+}
+
+func (r *timeoutOrderRepository) CountByFilter(ctx context.Context, filter ports.OrderFilter) (int64, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (int64, error) {
+		return r.OrderRepository.CountByFilter(ctx, filter)
+	})
+}
+
+func (r *timeoutOrderRepository) CountByCustomerIDAndStatus(ctx context.Context, customerID uint, status entities.OrderStatus) (int64, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (int64, error) {
+		return r.OrderRepository.CountByCustomerIDAndStatus(ctx, customerID, status)
+	})
+}
+
+// WithCustomerLock bounds the whole transaction, calls made by fn are bounded again individually
+func (r *timeoutOrderRepository) WithCustomerLock(ctx context.Context, customerID uint, fn func(ctx context.Context) error) error {
+	_, err := callWithTimeout(ctx, r.timeout, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, r.OrderRepository.WithCustomerLock(ctx, customerID, fn)
+	})
+	return err
+}
+
+func (r *timeoutOrderRepository) CountItemsByFilter(ctx context.Context, filter ports.OrderFilter) (int64, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (int64, error) {
+		return r.OrderRepository.CountItemsByFilter(ctx, filter)
+	})
+}
+
+func (r *timeoutOrderRepository) MaxUpdatedAt(ctx context.Context, filter ports.OrderFilter) (time.Time, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) (time.Time, error) {
+		return r.OrderRepository.MaxUpdatedAt(ctx, filter)
+	})
+}
+
+func (r *timeoutOrderRepository) AggregateByStatus(ctx context.Context, filter ports.OrderFilter) ([]ports.StatusAggregate, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) ([]ports.StatusAggregate, error) {
+		return r.OrderRepository.AggregateByStatus(ctx, filter)
+	})
+}
+
+func (r *timeoutOrderRepository) AggregateByDay(ctx context.Context, filter ports.OrderFilter) ([]ports.DailyAggregate, error) {
+	return callWithTimeout(ctx, r.timeout, func(ctx context.Context) ([]ports.DailyAggregate, error) {
+		return r.OrderRepository.AggregateByDay(ctx, filter)
+	})
+}
+
+func callWithTimeout[T any](ctx context.Context, timeout time.Duration, fn func(ctx context.Context) (T, error)) (T, error) {
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := fn(callCtx)
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("%w: %w", context.DeadlineExceeded, err)
+	}
+	return result, err
+}
+
+// repositoryError wraps err in fallback. Timeouts and cancelled requests are returned as is
+// so they are not reported as failures of the repository.
+func repositoryError(err error, fallback *domainErrors.DomainError) error {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return err
+	}
+	return domainErrors.WrapDomainError(fallback, err)
+}
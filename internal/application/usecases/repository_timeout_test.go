@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowRepository sleeps for delay before answering. Calls honour ctx unless ignoreContext is set,
+// in which case they fail the way a driver without context support would.
+type slowRepository struct {
+	ports.OrderRepository
+	delay         time.Duration
+	ignoreContext bool
+}
+
+func (r *slowRepository) wait(ctx context.Context) error {
+	if r.ignoreContext {
+		time.Sleep(r.delay)
+		return errors.New("connection closed")
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(r.delay):
+		return nil
+	}
+}
+
+func (r *slowRepository) GetByID(ctx context.Context, id uint) (*entities.Order, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+	order, _ := entities.NewOrder(123)
+	order.ID = id
+	return order, nil
+}
+
+func (r *slowRepository) ListByFilter(ctx context.Context, _ ports.OrderFilter, _, _ int) ([]*entities.Order, int64, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, 0, err
+	}
+	return []*entities.Order{}, 0, nil
+}
+
+func setupSlowOrderUseCases(repo ports.OrderRepository, timeout time.Duration) OrderUseCases {
+	config := DefaultOrderUseCasesConfig()
+	config.RepositoryTimeout = timeout
+	return NewOrderUseCasesWithConfig(repo, nil, nil, nil, logger.New("test"), config)
+}
+
+func TestOrderUseCases_RepositoryTimeout(t *testing.T) {
+	t.Run("get order", func(t *testing.T) {
+		// Given
+		useCases := setupSlowOrderUseCases(&slowRepository{delay: time.Second}, 20*time.Millisecond)
+
+		// When
+		start := time.Now()
+		_, err := useCases.GetOrder(context.Background(), 1)
+
+		// Then
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+
+	t.Run("list keeps the timeout instead of a generic failure", func(t *testing.T) {
+		// Given
+		useCases := setupSlowOrderUseCases(&slowRepository{delay: time.Second}, 20*time.Millisecond)
+
+		// When
+		_, err := useCases.ListOrders(context.Background(), 1, 10)
+
+		// Then
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NotErrorIs(t, err, domainErrors.ErrFailedToListOrders)
+	})
+
+	t.Run("driver error after the deadline", func(t *testing.T) {
+		// Given
+		useCases := setupSlowOrderUseCases(&slowRepository{delay: 50 * time.Millisecond, ignoreContext: true}, 20*time.Millisecond)
+
+		// When
+		_, err := useCases.GetOrder(context.Background(), 1)
+
+		// Then
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("request deadline shorter than the repository timeout", func(t *testing.T) {
+		// Given
+		useCases := setupSlowOrderUseCases(&slowRepository{delay: time.Second}, time.Minute)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		// When
+		_, err := useCases.GetOrder(ctx, 1)
+
+		// Then
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("fast call", func(t *testing.T) {
+		// Given
+		useCases := setupSlowOrderUseCases(&slowRepository{delay: time.Millisecond}, time.Second)
+
+		// When
+		order, err := useCases.GetOrder(context.Background(), 1)
+
+		// Then
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), order.ID)
+	})
+}
+
+func TestOrderUseCases_RepositoryFailureIsNotATimeout(t *testing.T) {
+	// Given
+	useCases := setupSlowOrderUseCases(&slowRepository{delay: time.Millisecond, ignoreContext: true}, time.Second)
+
+	// When
+	_, err := useCases.ListOrders(context.Background(), 1, 10)
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToListOrders)
+}
+
+func TestOrderUseCases_CancelledRequestIsNotAFailure(t *testing.T) {
+	// Given
+	useCases := setupSlowOrderUseCases(&slowRepository{delay: time.Second}, time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// When
+	_, err := useCases.ListOrders(ctx, 1, 10)
+
+	// Then
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, domainErrors.ErrFailedToListOrders)
+}
@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type heldLocksKey struct{}
+
+// heldLocks collects the row locks taken during a unit of work
+type heldLocks struct {
+	unlock []func()
+}
+
+// lockingRepository is an in-memory store whose GetByIDForUpdate holds the row until the
+// unit of work ends, like SELECT ... FOR UPDATE inside a transaction
+type lockingRepository struct {
+	ports.OrderRepository
+
+	mu     sync.Mutex
+	orders map[uint]*entities.Order
+	rows   map[uint]*sync.Mutex
+}
+
+func newLockingRepository(orders ...*entities.Order) *lockingRepository {
+	repo := &lockingRepository{orders: map[uint]*entities.Order{}, rows: map[uint]*sync.Mutex{}}
+	for _, order := range orders {
+		repo.orders[order.ID] = order.Clone()
+		repo.rows[order.ID] = &sync.Mutex{}
+	}
+	return repo
+}
+
+func (r *lockingRepository) GetByID(ctx context.Context, id uint) (*entities.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	order, ok := r.orders[id]
+	if !ok {
+		return nil, domainErrors.ErrOrderNotFound
+	}
+	return order.Clone(), nil
+}
+
+func (r *lockingRepository) GetByIDForUpdate(ctx context.Context, id uint) (*entities.Order, error) {
+	r.mu.Lock()
+	row, ok := r.rows[id]
+	r.mu.Unlock()
+	if !ok {
+		return nil, domainErrors.ErrOrderNotFound
+	}
+
+	row.Lock()
+	locks := ctx.Value(heldLocksKey{}).(*heldLocks)
+	locks.unlock = append(locks.unlock, row.Unlock)
+
+	order, err := r.GetByID(ctx, id)
+	// Widen the window between read and write so an unlocked read would lose updates
+	time.Sleep(time.Millisecond)
+	return order, err
+}
+
+func (r *lockingRepository) Update(ctx context.Context, order *entities.Order) (*entities.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.orders[order.ID] = order.Clone()
+	return order.Clone(), nil
+}
+
+// lockingUnitOfWork releases the row locks taken by fn when it returns, like a commit would
+type lockingUnitOfWork struct {
+	repos ports.Repositories
+}
+
+func (u lockingUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context, repos ports.Repositories) error) error {
+	locks := &heldLocks{}
+	defer func() {
+		for _, unlock := range locks.unlock {
+			unlock()
+		}
+	}()
+	return fn(context.WithValue(ctx, heldLocksKey{}, locks), u.repos)
+}
+
+func TestOrderUseCases_ConcurrentIncrementsAreNotLost(t *testing.T) {
+	// Given
+	order, _ := entities.NewOrder(123)
+	order.ID = 1
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 1, 10))
+
+	repo := newLockingRepository(order)
+	useCases := NewOrderUseCasesWithConfig(repo, lockingUnitOfWork{ports.Repositories{Orders: repo}}, nil, nil, logger.New("test"), DefaultOrderUseCasesConfig())
+
+	const increments = 10
+	request := &dto.AddOrderItemRequestDTO{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 1, UnitPrice: 10}
+
+	// When
+	var wg sync.WaitGroup
+	for worker := 0; worker < 2; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < increments; i++ {
+				_, err := useCases.AddItemToOrder(context.Background(), 1, request)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Then
+	stored, err := repo.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, stored.Items, 1)
+	assert.Equal(t, 1+2*increments, stored.Items[0].Quantity)
+}
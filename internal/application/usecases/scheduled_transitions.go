@@ -0,0 +1,252 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+)
+
+// errNoScheduledTransitionRepository is returned when the unit of work was built without a scheduled transition repository
+var errNoScheduledTransitionRepository = errors.New("no scheduled transition repository configured")
+
+// ScheduleTransition schedules a status change of an order, executed by ExecuteScheduledTransitions once due
+func (uc *orderUseCasesImpl) ScheduleTransition(ctx context.Context, orderID uint, request *dto.ScheduleTransitionRequestDTO) (*dto.ScheduledTransitionResponseDTO, error) {
+	uc.log(ctx).Info("ScheduleTransition use case called", "order_id", orderID, "target_status", request.TargetStatus, "execute_at", request.ExecuteAt)
+
+	var created *entities.ScheduledTransition
+	err := uc.inScheduledTransitionsUnitOfWork(ctx, func(ctx context.Context, orders ports.OrderRepository, repo ports.ScheduledTransitionRepository) error {
+		order, err := orders.GetByID(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to get order", "order_id", orderID, "error", err)
+			return err
+		}
+		if err := uc.authorizeCustomer(ctx, order.CustomerID); err != nil {
+			return err
+		}
+
+		scheduled, err := order.ScheduleTransition(request.TargetStatus, request.ExecuteAt, principalName(ctx), time.Now())
+		if err != nil {
+			uc.log(ctx).Error("Failed to schedule transition", "order_id", orderID, "error", err)
+			return scheduledTransitionError(err)
+		}
+
+		created, err = repo.Create(ctx, scheduled)
+		if err != nil {
+			uc.log(ctx).Error("Failed to store scheduled transition", "order_id", orderID, "error", err)
+			return repositoryError(err, domainErrors.ErrFailedToUpdateOrder)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log(ctx).Info("ScheduleTransition success", "order_id", orderID, "scheduled_transition_id", created.ID)
+	return dto.ScheduledTransitionToResponseDTO(created), nil
+}
+
+// ListScheduledTransitions retrieves the scheduled transitions of an order in every state, oldest first
+func (uc *orderUseCasesImpl) ListScheduledTransitions(ctx context.Context, orderID uint) (*dto.ScheduledTransitionListResponseDTO, error) {
+	uc.log(ctx).Info("ListScheduledTransitions use case called", "order_id", orderID)
+
+	var response *dto.ScheduledTransitionListResponseDTO
+	err := uc.inScheduledTransitionsUnitOfWork(ctx, func(ctx context.Context, orders ports.OrderRepository, repo ports.ScheduledTransitionRepository) error {
+		order, err := orders.GetByID(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to get order", "order_id", orderID, "error", err)
+			return err
+		}
+		if err := uc.authorizeCustomer(ctx, order.CustomerID); err != nil {
+			return err
+		}
+
+		transitions, err := repo.ListByOrderID(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to list scheduled transitions", "order_id", orderID, "error", err)
+			return repositoryError(err, domainErrors.ErrFailedToGetScheduledTransitions)
+		}
+
+		response = &dto.ScheduledTransitionListResponseDTO{
+			OrderID:              order.ID,
+			OrderPublicID:        order.PublicID,
+			ScheduledTransitions: make([]*dto.ScheduledTransitionResponseDTO, 0, len(transitions)),
+		}
+		for _, transition := range transitions {
+			response.ScheduledTransitions = append(response.ScheduledTransitions, dto.ScheduledTransitionToResponseDTO(transition))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log(ctx).Info("ListScheduledTransitions success", "order_id", orderID, "count", len(response.ScheduledTransitions))
+	return response, nil
+}
+
+// CancelScheduledTransition stops a pending scheduled transition of an order from executing
+func (uc *orderUseCasesImpl) CancelScheduledTransition(ctx context.Context, orderID, transitionID uint) (*dto.ScheduledTransitionResponseDTO, error) {
+	uc.log(ctx).Info("CancelScheduledTransition use case called", "order_id", orderID, "scheduled_transition_id", transitionID)
+
+	var cancelled *entities.ScheduledTransition
+	err := uc.inScheduledTransitionsUnitOfWork(ctx, func(ctx context.Context, orders ports.OrderRepository, repo ports.ScheduledTransitionRepository) error {
+		order, err := orders.GetByID(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to get order", "order_id", orderID, "error", err)
+			return err
+		}
+		if err := uc.authorizeCustomer(ctx, order.CustomerID); err != nil {
+			return err
+		}
+
+		transitions, err := repo.ListByOrderID(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to list scheduled transitions", "order_id", orderID, "error", err)
+			return repositoryError(err, domainErrors.ErrFailedToGetScheduledTransitions)
+		}
+
+		for _, transition := range transitions {
+			if transition.ID != transitionID {
+				continue
+			}
+
+			if err := transition.Cancel(time.Now()); err != nil {
+				return scheduledTransitionError(err)
+			}
+
+			cancelled, err = repo.Update(ctx, transition)
+			if err != nil {
+				uc.log(ctx).Error("Failed to store scheduled transition cancellation", "order_id", orderID, "scheduled_transition_id", transitionID, "error", err)
+				return repositoryError(err, domainErrors.ErrFailedToUpdateOrder)
+			}
+			return nil
+		}
+		return domainErrors.ErrScheduledTransitionNotFound
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log(ctx).Info("CancelScheduledTransition success", "order_id", orderID, "scheduled_transition_id", transitionID)
+	return dto.ScheduledTransitionToResponseDTO(cancelled), nil
+}
+
+// ExecuteScheduledTransitions executes up to batchSize scheduled transitions due at now through
+// TransitionOrderStatus, so every guard of a manual status change applies. A transition the order
+// refuses is marked failed with the domain error, one finding the order in its target status already
+// counts as executed and one hitting a transient error such as an unavailable database stays pending
+// for the next run. It returns the number of transitions executed or failed.
+func (uc *orderUseCasesImpl) ExecuteScheduledTransitions(ctx context.Context, now time.Time, batchSize int) (int, error) {
+	uc.log(ctx).Debug("ExecuteScheduledTransitions use case called", "before", now, "batch_size", batchSize)
+
+	var due []*entities.ScheduledTransition
+	err := uc.inScheduledTransitionsUnitOfWork(ctx, func(ctx context.Context, _ ports.OrderRepository, repo ports.ScheduledTransitionRepository) error {
+		var err error
+		due, err = repo.FindDue(ctx, now, batchSize)
+		return err
+	})
+	if err != nil {
+		uc.log(ctx).Error("Failed to find due scheduled transitions", "error", err)
+		return 0, repositoryError(err, domainErrors.ErrFailedToExecuteScheduledTransitions)
+	}
+
+	completed := 0
+	for _, scheduled := range due {
+		if err := uc.executeScheduledTransition(ctx, scheduled, now); err != nil {
+			uc.log(ctx).Warn("Scheduled transition left pending", "scheduled_transition_id", scheduled.ID, "order_id", scheduled.OrderID, "error", err)
+			continue
+		}
+		completed++
+	}
+
+	if completed > 0 {
+		uc.log(ctx).Info("ExecuteScheduledTransitions success", "completed", completed)
+	}
+	return completed, nil
+}
+
+// executeScheduledTransition moves the order of scheduled to its target status and records the outcome,
+// both committing together. It returns an error only when the transition stays pending.
+func (uc *orderUseCasesImpl) executeScheduledTransition(ctx context.Context, scheduled *entities.ScheduledTransition, now time.Time) error {
+	return uc.inScheduledTransitionsUnitOfWork(ctx, func(ctx context.Context, _ ports.OrderRepository, repo ports.ScheduledTransitionRepository) error {
+		_, err := uc.TransitionOrderStatus(ctx, scheduled.OrderID, &dto.UpdateOrderStatusRequestDTO{Status: scheduled.TargetStatus})
+		if err != nil {
+			code, message, retry := scheduledTransitionFailure(err)
+			if retry {
+				return err
+			}
+			uc.log(ctx).Warn("Scheduled transition failed", "scheduled_transition_id", scheduled.ID, "order_id", scheduled.OrderID, "code", code, "error", err)
+			err = scheduled.MarkFailed(now, code, message)
+		} else {
+			err = scheduled.MarkExecuted(now)
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, err := repo.Update(ctx, scheduled); err != nil {
+			uc.log(ctx).Error("Failed to store scheduled transition outcome", "scheduled_transition_id", scheduled.ID, "order_id", scheduled.OrderID, "error", err)
+			return err
+		}
+		return nil
+	})
+}
+
+// inScheduledTransitionsUnitOfWork runs fn in a unit of work with its order and scheduled transition repositories
+func (uc *orderUseCasesImpl) inScheduledTransitionsUnitOfWork(ctx context.Context, fn func(ctx context.Context, orders ports.OrderRepository, transitions ports.ScheduledTransitionRepository) error) error {
+	return uc.unitOfWork.Do(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		if repos.ScheduledTransitions == nil {
+			uc.log(ctx).Error("Scheduled transitions are unavailable", "error", errNoScheduledTransitionRepository)
+			return domainErrors.WrapDomainError(domainErrors.ErrFailedToGetScheduledTransitions, errNoScheduledTransitionRepository)
+		}
+		return fn(ctx, withRepositoryTimeout(repos.Orders, uc.config.RepositoryTimeout), repos.ScheduledTransitions)
+	})
+}
+
+// scheduledTransitionFailure describes the error that kept a scheduled transition from executing.
+// Errors of the order refusing the change fail the transition, retry reports the others such as an
+// unavailable database or a cancelled run, which leave it pending.
+func scheduledTransitionFailure(err error) (code, message string, retry bool) {
+	var transitionErr *entities.TransitionError
+	if errors.As(err, &transitionErr) {
+		code = domainErrors.ErrInvalidStatusTransition.Code
+		if errors.Is(err, entities.ErrOrderExpired) {
+			code = domainErrors.ErrOrderExpired.Code
+		}
+		return code, transitionErr.Reason, false
+	}
+	return domainErrorFailure(err)
+}
+
+// domainErrorFailure describes a domain error the caller can act on, such as invalid input or a
+// conflict, by its code and message. retry reports the others such as an unavailable database or a
+// cancelled run, which may succeed when tried again.
+func domainErrorFailure(err error) (code, message string, retry bool) {
+	var domainErr *domainErrors.DomainError
+	if errors.As(err, &domainErr) && !errors.Is(err, domainErrors.ErrRequestCancelled) &&
+		domainErrors.HTTPStatus(domainErr.Code) < http.StatusInternalServerError {
+		return domainErr.Code, domainErr.Message, false
+	}
+	return "", "", true
+}
+
+// scheduledTransitionError converts a rejected scheduled transition into the matching domain error,
+// other errors are returned unchanged
+func scheduledTransitionError(err error) error {
+	switch {
+	case errors.Is(err, entities.ErrUnknownOrderStatus):
+		return domainErrors.ErrInvalidOrderStatus
+	case errors.Is(err, entities.ErrInvalidScheduledTransition):
+		return domainErrors.ErrInvalidScheduledTransition.WithDetails(map[string]interface{}{"reason": err.Error()})
+	case errors.Is(err, entities.ErrScheduledTransitionNotPending):
+		return domainErrors.ErrScheduledTransitionNotPending
+	default:
+		return err
+	}
+}
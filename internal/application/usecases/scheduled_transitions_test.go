@@ -0,0 +1,266 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/auth"
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeScheduledTransitionRepository keeps scheduled transitions in memory, oldest first
+type fakeScheduledTransitionRepository struct {
+	transitions []*entities.ScheduledTransition
+}
+
+func (r *fakeScheduledTransitionRepository) Create(_ context.Context, transition *entities.ScheduledTransition) (*entities.ScheduledTransition, error) {
+	stored := transition.Clone()
+	stored.ID = uint(len(r.transitions) + 1)
+	r.transitions = append(r.transitions, stored)
+	return stored.Clone(), nil
+}
+
+func (r *fakeScheduledTransitionRepository) Update(_ context.Context, transition *entities.ScheduledTransition) (*entities.ScheduledTransition, error) {
+	for i, stored := range r.transitions {
+		if stored.ID == transition.ID {
+			if !stored.IsPending() {
+				return nil, domainErrors.ErrScheduledTransitionNotPending
+			}
+			r.transitions[i] = transition.Clone()
+			return transition.Clone(), nil
+		}
+	}
+	return nil, domainErrors.ErrScheduledTransitionNotFound
+}
+
+func (r *fakeScheduledTransitionRepository) ListByOrderID(_ context.Context, orderID uint) ([]*entities.ScheduledTransition, error) {
+	transitions := make([]*entities.ScheduledTransition, 0, len(r.transitions))
+	for _, transition := range r.transitions {
+		if transition.OrderID == orderID {
+			transitions = append(transitions, transition.Clone())
+		}
+	}
+	return transitions, nil
+}
+
+func (r *fakeScheduledTransitionRepository) FindDue(_ context.Context, now time.Time, limit int) ([]*entities.ScheduledTransition, error) {
+	due := make([]*entities.ScheduledTransition, 0, len(r.transitions))
+	for _, transition := range r.transitions {
+		if transition.IsDue(now) && len(due) < limit {
+			due = append(due, transition.Clone())
+		}
+	}
+	return due, nil
+}
+
+func setupScheduledTransitionUseCases() (OrderUseCases, *MockOrderRepository, *fakeScheduledTransitionRepository, *recordingPublisher) {
+	mockRepo := new(MockOrderRepository)
+	transitionRepo := &fakeScheduledTransitionRepository{}
+	publisher := &recordingPublisher{}
+	unitOfWork := NewInMemoryUnitOfWork(ports.Repositories{Orders: mockRepo, ScheduledTransitions: transitionRepo})
+	useCases := NewOrderUseCasesWithConfig(mockRepo, unitOfWork, publisher, nil, logger.New("test"), DefaultOrderUseCasesConfig())
+	return useCases, mockRepo, transitionRepo, publisher
+}
+
+// pendingOrder returns a pending order of customer 123 with one item
+func pendingOrder(id uint) *entities.Order {
+	order, _ := entities.NewOrder(123)
+	order.ID = id
+	order.AddItem(1, "SKU-001", "Product 1", 1, 10.0)
+	return order
+}
+
+func TestOrderUseCases_ScheduleTransition_Success(t *testing.T) {
+	// Given
+	useCases, mockRepo, transitionRepo, _ := setupScheduledTransitionUseCases()
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "support", Scopes: []auth.Scope{auth.ScopeOrdersAdmin}})
+	executeAt := time.Now().Add(24 * time.Hour)
+
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(pendingOrder(1), nil)
+
+	// When
+	result, err := useCases.ScheduleTransition(ctx, 1, &dto.ScheduleTransitionRequestDTO{
+		TargetStatus: entities.OrderStatusCancelled,
+		ExecuteAt:    executeAt,
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+	assert.Equal(t, entities.OrderStatusCancelled, result.TargetStatus)
+	assert.Equal(t, entities.ScheduledTransitionPending, result.Status)
+	assert.Equal(t, "support", result.CreatedBy)
+	assert.True(t, executeAt.Equal(result.ExecuteAt))
+	assert.Len(t, transitionRepo.transitions, 1)
+}
+
+func TestOrderUseCases_ScheduleTransition_Rejected(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    entities.OrderStatus
+		executeAt time.Time
+		expected  error
+	}{
+		{"unknown status", entities.OrderStatus("archived"), time.Now().Add(time.Hour), domainErrors.ErrInvalidOrderStatus},
+		{"execution time passed", entities.OrderStatusCancelled, time.Now().Add(-time.Hour), domainErrors.ErrInvalidScheduledTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo, transitionRepo, _ := setupScheduledTransitionUseCases()
+			mockRepo.On("GetByID", mock.Anything, uint(1)).Return(pendingOrder(1), nil)
+
+			// When
+			_, err := useCases.ScheduleTransition(context.Background(), 1, &dto.ScheduleTransitionRequestDTO{
+				TargetStatus: tt.target,
+				ExecuteAt:    tt.executeAt,
+			})
+
+			// Then
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Empty(t, transitionRepo.transitions)
+		})
+	}
+}
+
+func TestOrderUseCases_ScheduleTransition_UnreachableStatus(t *testing.T) {
+	// Given
+	useCases, mockRepo, _, _ := setupScheduledTransitionUseCases()
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(pendingOrder(1), nil)
+
+	// When
+	_, err := useCases.ScheduleTransition(context.Background(), 1, &dto.ScheduleTransitionRequestDTO{
+		TargetStatus: entities.OrderStatusDelivered,
+		ExecuteAt:    time.Now().Add(time.Hour),
+	})
+
+	// Then
+	var transitionErr *entities.TransitionError
+	assert.ErrorAs(t, err, &transitionErr)
+}
+
+func TestOrderUseCases_ScheduleTransition_OtherCustomerIsNotFound(t *testing.T) {
+	// Given
+	useCases, mockRepo, transitionRepo, _ := setupScheduledTransitionUseCases()
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(pendingOrder(1), nil)
+
+	// When
+	_, err := useCases.ScheduleTransition(customerContext(5, auth.ScopeOrdersWrite), 1, &dto.ScheduleTransitionRequestDTO{
+		TargetStatus: entities.OrderStatusCancelled,
+		ExecuteAt:    time.Now().Add(time.Hour),
+	})
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrOrderNotFound)
+	assert.Empty(t, transitionRepo.transitions)
+}
+
+func TestOrderUseCases_CancelScheduledTransition(t *testing.T) {
+	// Given
+	useCases, mockRepo, transitionRepo, _ := setupScheduledTransitionUseCases()
+	ctx := context.Background()
+	transitionRepo.transitions = []*entities.ScheduledTransition{
+		{ID: 1, OrderID: 1, TargetStatus: entities.OrderStatusCancelled, Status: entities.ScheduledTransitionPending},
+	}
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(pendingOrder(1), nil)
+
+	// When
+	result, err := useCases.CancelScheduledTransition(ctx, 1, 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.ScheduledTransitionCancelled, result.Status)
+	assert.NotNil(t, result.CompletedAt)
+
+	_, err = useCases.CancelScheduledTransition(ctx, 1, 1)
+	assert.ErrorIs(t, err, domainErrors.ErrScheduledTransitionNotPending)
+
+	_, err = useCases.CancelScheduledTransition(ctx, 1, 99)
+	assert.ErrorIs(t, err, domainErrors.ErrScheduledTransitionNotFound)
+}
+
+func TestOrderUseCases_ExecuteScheduledTransitions(t *testing.T) {
+	// Given
+	useCases, mockRepo, transitionRepo, publisher := setupScheduledTransitionUseCases()
+	ctx := context.Background()
+	now := time.Now()
+
+	shipped := pendingOrder(2)
+	shipped.Status = entities.OrderStatusShipped
+	transitionRepo.transitions = []*entities.ScheduledTransition{
+		{ID: 1, OrderID: 1, TargetStatus: entities.OrderStatusCancelled, ExecuteAt: now.Add(-time.Minute), Status: entities.ScheduledTransitionPending},
+		{ID: 2, OrderID: 2, TargetStatus: entities.OrderStatusCancelled, ExecuteAt: now.Add(-time.Minute), Status: entities.ScheduledTransitionPending},
+		{ID: 3, OrderID: 1, TargetStatus: entities.OrderStatusConfirmed, ExecuteAt: now.Add(time.Hour), Status: entities.ScheduledTransitionPending},
+	}
+
+	order := pendingOrder(1)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(order), nil)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(2)).Return(shipped, nil)
+
+	// When
+	completed, err := useCases.ExecuteScheduledTransitions(ctx, now, 10)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 2, completed)
+	assert.Equal(t, entities.OrderStatusCancelled, order.Status)
+	assert.Len(t, publisher.events, 1)
+
+	assert.Equal(t, entities.ScheduledTransitionExecuted, transitionRepo.transitions[0].Status)
+	assert.Equal(t, entities.ScheduledTransitionFailed, transitionRepo.transitions[1].Status)
+	assert.Equal(t, domainErrors.ErrInvalidStatusTransition.Code, transitionRepo.transitions[1].FailureCode)
+	assert.Equal(t, "order cannot be cancelled in current status", transitionRepo.transitions[1].FailureMessage)
+	assert.Equal(t, entities.ScheduledTransitionPending, transitionRepo.transitions[2].Status)
+	assert.Equal(t, entities.OrderStatusShipped, shipped.Status)
+}
+
+func TestOrderUseCases_ExecuteScheduledTransitions_DeletedOrderFails(t *testing.T) {
+	// Given
+	useCases, mockRepo, transitionRepo, _ := setupScheduledTransitionUseCases()
+	now := time.Now()
+	transitionRepo.transitions = []*entities.ScheduledTransition{
+		{ID: 1, OrderID: 1, TargetStatus: entities.OrderStatusCancelled, ExecuteAt: now, Status: entities.ScheduledTransitionPending},
+	}
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(nil, domainErrors.ErrOrderNotFound)
+
+	// When
+	completed, err := useCases.ExecuteScheduledTransitions(context.Background(), now, 10)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 1, completed)
+	assert.Equal(t, entities.ScheduledTransitionFailed, transitionRepo.transitions[0].Status)
+	assert.Equal(t, domainErrors.ErrOrderNotFound.Code, transitionRepo.transitions[0].FailureCode)
+}
+
+func TestOrderUseCases_ExecuteScheduledTransitions_TransientErrorStaysPending(t *testing.T) {
+	// Given
+	useCases, mockRepo, transitionRepo, _ := setupScheduledTransitionUseCases()
+	now := time.Now()
+	transitionRepo.transitions = []*entities.ScheduledTransition{
+		{ID: 1, OrderID: 1, TargetStatus: entities.OrderStatusCancelled, ExecuteAt: now, Status: entities.ScheduledTransitionPending},
+	}
+	order := pendingOrder(1)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	// When
+	completed, err := useCases.ExecuteScheduledTransitions(context.Background(), now, 10)
+
+	// Then
+	require.NoError(t, err)
+	assert.Zero(t, completed)
+	assert.Equal(t, entities.ScheduledTransitionPending, transitionRepo.transitions[0].Status)
+}
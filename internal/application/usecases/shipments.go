@@ -0,0 +1,222 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
+)
+
+// errNoShipmentRepository is returned when the unit of work was built without a shipment repository
+var errNoShipmentRepository = errors.New("no shipment repository configured")
+
+// CreateShipment records that part or all of an order left in a shipment and derives the order status from it
+func (uc *orderUseCasesImpl) CreateShipment(ctx context.Context, orderID uint, request *dto.CreateShipmentRequestDTO) (*dto.ShipmentResponseDTO, error) {
+	uc.log(ctx).Info("CreateShipment use case called", "order_id", orderID, "item_count", len(request.Items))
+
+	shippedAt := time.Now()
+	if request.ShippedAt != nil {
+		shippedAt = *request.ShippedAt
+	}
+
+	// Add the shipment to the locked order and store both
+	before, updatedOrder, shipment, err := uc.modifyShipments(ctx, orderID, func(ctx context.Context, order *entities.Order, shipments []*entities.Shipment, repo ports.ShipmentRepository) (*entities.Shipment, []*entities.Shipment, error) {
+		shipment, err := order.NewShipment(shipments, request.ToEntities(), request.Carrier, request.TrackingNumber, shippedAt)
+		if err != nil {
+			uc.log(ctx).Error("Failed to create shipment", "order_id", orderID, "error", err)
+			return nil, nil, shipmentError(err)
+		}
+
+		created, err := repo.Create(ctx, shipment)
+		if err != nil {
+			uc.log(ctx).Error("Failed to store shipment", "order_id", orderID, "error", err)
+			return nil, nil, repositoryError(err, domainErrors.ErrFailedToUpdateOrder)
+		}
+		return created, append(shipments, created), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.audit(ctx, entities.AuditActionShipmentCreated, orderID, before, updatedOrder)
+	uc.publish(ctx, events.NewShipmentEvent(events.OrderShipmentCreated, updatedOrder, shipment, time.Now()))
+	uc.publishStatusChange(ctx, before, updatedOrder)
+
+	uc.log(ctx).Info("CreateShipment success", "order_id", orderID, "shipment_id", shipment.ID, "status", updatedOrder.Status)
+	response := dto.ShipmentToResponseDTO(shipment)
+	response.OrderPublicID = updatedOrder.PublicID
+	response.OrderStatus = updatedOrder.Status
+	return response, nil
+}
+
+// DeliverShipment records that a shipment reached the customer, the order is delivered with its last shipment
+func (uc *orderUseCasesImpl) DeliverShipment(ctx context.Context, orderID, shipmentID uint) (*dto.ShipmentResponseDTO, error) {
+	uc.log(ctx).Info("DeliverShipment use case called", "order_id", orderID, "shipment_id", shipmentID)
+
+	// Mark the shipment of the locked order delivered and store both
+	before, updatedOrder, shipment, err := uc.modifyShipments(ctx, orderID, func(ctx context.Context, order *entities.Order, shipments []*entities.Shipment, repo ports.ShipmentRepository) (*entities.Shipment, []*entities.Shipment, error) {
+		for i, shipment := range shipments {
+			if shipment.ID != shipmentID {
+				continue
+			}
+
+			if err := shipment.MarkDelivered(time.Now()); err != nil {
+				return nil, nil, shipmentError(err)
+			}
+
+			updated, err := repo.Update(ctx, shipment)
+			if err != nil {
+				uc.log(ctx).Error("Failed to store shipment delivery", "order_id", orderID, "shipment_id", shipmentID, "error", err)
+				return nil, nil, repositoryError(err, domainErrors.ErrFailedToUpdateOrder)
+			}
+			shipments[i] = updated
+			return updated, shipments, nil
+		}
+		return nil, nil, domainErrors.ErrShipmentNotFound
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.audit(ctx, entities.AuditActionShipmentDelivered, orderID, before, updatedOrder)
+	uc.publish(ctx, events.NewShipmentEvent(events.OrderShipmentDelivered, updatedOrder, shipment, time.Now()))
+	uc.publishStatusChange(ctx, before, updatedOrder)
+
+	uc.log(ctx).Info("DeliverShipment success", "order_id", orderID, "shipment_id", shipmentID, "status", updatedOrder.Status)
+	response := dto.ShipmentToResponseDTO(shipment)
+	response.OrderPublicID = updatedOrder.PublicID
+	response.OrderStatus = updatedOrder.Status
+	return response, nil
+}
+
+// ListShipments retrieves the shipments of an order, oldest first
+func (uc *orderUseCasesImpl) ListShipments(ctx context.Context, orderID uint) (*dto.ShipmentListResponseDTO, error) {
+	uc.log(ctx).Info("ListShipments use case called", "order_id", orderID)
+
+	var response *dto.ShipmentListResponseDTO
+	err := uc.inShipmentsUnitOfWork(ctx, func(ctx context.Context, orders ports.OrderRepository, repo ports.ShipmentRepository) error {
+		order, err := orders.GetByID(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to get order", "order_id", orderID, "error", err)
+			return err
+		}
+		if err := uc.authorizeCustomer(ctx, order.CustomerID); err != nil {
+			return err
+		}
+
+		shipments, err := repo.ListByOrderID(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to list shipments", "order_id", orderID, "error", err)
+			return repositoryError(err, domainErrors.ErrFailedToGetShipments)
+		}
+
+		response = &dto.ShipmentListResponseDTO{
+			OrderID:       order.ID,
+			OrderPublicID: order.PublicID,
+			OrderStatus:   order.Status,
+			Shipments:     make([]*dto.ShipmentResponseDTO, 0, len(shipments)),
+		}
+		for _, shipment := range shipments {
+			response.Shipments = append(response.Shipments, dto.ShipmentToResponseDTO(shipment))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log(ctx).Info("ListShipments success", "order_id", orderID, "count", len(response.Shipments))
+	return response, nil
+}
+
+// modifyShipments runs change on the locked order and its shipments, then derives the order status
+// from the shipments change returns, capturing the payment of an order that ships, and stores the
+// order. It returns the order before and after the change along with the shipment change created or
+// updated.
+func (uc *orderUseCasesImpl) modifyShipments(
+	ctx context.Context,
+	orderID uint,
+	change func(ctx context.Context, order *entities.Order, shipments []*entities.Shipment, repo ports.ShipmentRepository) (*entities.Shipment, []*entities.Shipment, error),
+) (before, after *entities.Order, shipment *entities.Shipment, err error) {
+	err = uc.inShipmentsUnitOfWork(ctx, func(ctx context.Context, orders ports.OrderRepository, repo ports.ShipmentRepository) error {
+		order, err := orders.GetByIDForUpdate(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to get order", "order_id", orderID, "error", err)
+			return err
+		}
+		if err := uc.authorizeCustomer(ctx, order.CustomerID); err != nil {
+			return err
+		}
+		working := order.Clone()
+
+		shipments, err := repo.ListByOrderID(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to list shipments", "order_id", orderID, "error", err)
+			return repositoryError(err, domainErrors.ErrFailedToGetShipments)
+		}
+
+		shipment, shipments, err = change(ctx, working, shipments, repo)
+		if err != nil {
+			return err
+		}
+
+		if err := working.ApplyShipments(shipments); err != nil {
+			uc.log(ctx).Error("Failed to derive order status from shipments", "order_id", orderID, "error", err)
+			return err
+		}
+		if _, err := uc.settlePayment(ctx, order.Status, working); err != nil {
+			return err
+		}
+
+		after, err = orders.Update(ctx, working)
+		if err != nil {
+			uc.log(ctx).Error("Failed to update order", "order_id", orderID, "error", err)
+			return repositoryError(err, domainErrors.ErrFailedToUpdateOrder)
+		}
+		before = order
+		return nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return before, after, shipment, nil
+}
+
+// inShipmentsUnitOfWork runs fn in a unit of work with its order and shipment repositories
+func (uc *orderUseCasesImpl) inShipmentsUnitOfWork(ctx context.Context, fn func(ctx context.Context, orders ports.OrderRepository, shipments ports.ShipmentRepository) error) error {
+	return uc.unitOfWork.Do(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		if repos.Shipments == nil {
+			uc.log(ctx).Error("Shipments are unavailable", "error", errNoShipmentRepository)
+			return domainErrors.WrapDomainError(domainErrors.ErrFailedToGetShipments, errNoShipmentRepository)
+		}
+		return fn(ctx, withRepositoryTimeout(repos.Orders, uc.config.RepositoryTimeout), repos.Shipments)
+	})
+}
+
+// publishStatusChange publishes OrderStatusChanged when a shipment moved the order to another status
+func (uc *orderUseCasesImpl) publishStatusChange(ctx context.Context, before, after *entities.Order) {
+	if before.Status != after.Status {
+		uc.publish(ctx, events.NewOrderEvent(events.OrderStatusChanged, after, time.Now()))
+	}
+}
+
+// shipmentError converts a rejected shipment into the matching domain error, other errors are returned unchanged
+func shipmentError(err error) error {
+	switch {
+	case errors.Is(err, entities.ErrShipmentNotAllowed):
+		return domainErrors.ErrShipmentNotAllowed
+	case errors.Is(err, entities.ErrInvalidShipment):
+		return domainErrors.ErrInvalidShipment.WithDetails(map[string]interface{}{"reason": err.Error()})
+	case errors.Is(err, entities.ErrShipmentAlreadyDelivered):
+		return domainErrors.ErrShipmentAlreadyDelivered
+	case errors.Is(err, entities.ErrTrackingNumberTooLong):
+		return domainErrors.ErrInvalidTrackingNumber.WithDetails(map[string]interface{}{"reason": err.Error()})
+	default:
+		return err
+	}
+}
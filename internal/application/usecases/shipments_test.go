@@ -0,0 +1,228 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeShipmentRepository keeps shipments in memory, oldest first
+type fakeShipmentRepository struct {
+	shipments []*entities.Shipment
+}
+
+func (r *fakeShipmentRepository) Create(_ context.Context, shipment *entities.Shipment) (*entities.Shipment, error) {
+	stored := shipment.Clone()
+	stored.ID = uint(len(r.shipments) + 1)
+	r.shipments = append(r.shipments, stored)
+	return stored.Clone(), nil
+}
+
+func (r *fakeShipmentRepository) Update(_ context.Context, shipment *entities.Shipment) (*entities.Shipment, error) {
+	for i, stored := range r.shipments {
+		if stored.ID == shipment.ID {
+			r.shipments[i] = shipment.Clone()
+			return shipment.Clone(), nil
+		}
+	}
+	return nil, domainErrors.ErrShipmentNotFound
+}
+
+func (r *fakeShipmentRepository) ListByOrderID(_ context.Context, orderID uint) ([]*entities.Shipment, error) {
+	shipments := make([]*entities.Shipment, 0, len(r.shipments))
+	for _, shipment := range r.shipments {
+		if shipment.OrderID == orderID {
+			shipments = append(shipments, shipment.Clone())
+		}
+	}
+	return shipments, nil
+}
+
+func setupShipmentUseCases() (OrderUseCases, *MockOrderRepository, *fakeShipmentRepository, *recordingPublisher) {
+	mockRepo := new(MockOrderRepository)
+	shipmentRepo := &fakeShipmentRepository{}
+	publisher := &recordingPublisher{}
+	unitOfWork := NewInMemoryUnitOfWork(ports.Repositories{Orders: mockRepo, Shipments: shipmentRepo})
+	useCases := NewOrderUseCasesWithConfig(mockRepo, unitOfWork, publisher, nil, logger.New("test"), DefaultOrderUseCasesConfig())
+	return useCases, mockRepo, shipmentRepo, publisher
+}
+
+// processingOrder returns a processing order of 2 units of product 1 and 1 unit of product 2
+func processingOrder() *entities.Order {
+	order, _ := entities.NewOrder(123)
+	order.ID = 1
+	order.AddItem(1, "SKU-001", "Product 1", 2, 10.0)
+	order.AddItem(2, "SKU-002", "Product 2", 1, 5.0)
+	order.Status = entities.OrderStatusProcessing
+	return order
+}
+
+func TestOrderUseCases_CreateShipment_PartiallyShipsOrder(t *testing.T) {
+	// Given
+	useCases, mockRepo, shipmentRepo, publisher := setupShipmentUseCases()
+	ctx := context.Background()
+
+	order := processingOrder()
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *entities.Order) bool {
+		return order.Status == entities.OrderStatusPartiallyShipped
+	})).Return(storeInto(order), nil)
+
+	// When
+	result, err := useCases.CreateShipment(ctx, 1, &dto.CreateShipmentRequestDTO{
+		Items:          []dto.ShipmentItemDTO{{ProductID: 1, Quantity: 1}},
+		Carrier:        "DHL",
+		TrackingNumber: "JD01",
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+	assert.Equal(t, entities.OrderStatusPartiallyShipped, result.OrderStatus)
+	assert.Len(t, shipmentRepo.shipments, 1)
+
+	require.Len(t, publisher.events, 2)
+	assert.Equal(t, events.OrderShipmentCreated, publisher.events[0].Type)
+	assert.Equal(t, uint(1), publisher.events[0].ShipmentID)
+	assert.Equal(t, "JD01", publisher.events[0].TrackingNumber)
+	assert.Equal(t, events.OrderStatusChanged, publisher.events[1].Type)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_CreateShipment_ExceedsUnshippedQuantity(t *testing.T) {
+	// Given
+	useCases, mockRepo, shipmentRepo, publisher := setupShipmentUseCases()
+	ctx := context.Background()
+	shipmentRepo.shipments = []*entities.Shipment{{ID: 1, OrderID: 1, Items: []entities.ShipmentItem{{ProductID: 1, Quantity: 2}}}}
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(processingOrder(), nil)
+
+	// When
+	result, err := useCases.CreateShipment(ctx, 1, &dto.CreateShipmentRequestDTO{
+		Items: []dto.ShipmentItemDTO{{ProductID: 1, Quantity: 1}},
+	})
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidShipment)
+	assert.Len(t, shipmentRepo.shipments, 1)
+	assert.Empty(t, publisher.events)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_CreateShipment_RequiresProcessingOrder(t *testing.T) {
+	// Given
+	useCases, mockRepo, _, _ := setupShipmentUseCases()
+	ctx := context.Background()
+	order := processingOrder()
+	order.Status = entities.OrderStatusConfirmed
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+
+	// When
+	_, err := useCases.CreateShipment(ctx, 1, &dto.CreateShipmentRequestDTO{
+		Items: []dto.ShipmentItemDTO{{ProductID: 1, Quantity: 1}},
+	})
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrShipmentNotAllowed)
+}
+
+func TestOrderUseCases_DeliverShipment_DeliversOrderWithLastShipment(t *testing.T) {
+	// Given
+	useCases, mockRepo, shipmentRepo, publisher := setupShipmentUseCases()
+	ctx := context.Background()
+	order := processingOrder()
+	order.Status = entities.OrderStatusShipped
+	shipmentRepo.shipments = []*entities.Shipment{
+		{ID: 1, OrderID: 1, Items: []entities.ShipmentItem{{ProductID: 1, Quantity: 2}}},
+		{ID: 2, OrderID: 1, Items: []entities.ShipmentItem{{ProductID: 2, Quantity: 1}}},
+	}
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(order), nil)
+
+	// When
+	first, err := useCases.DeliverShipment(ctx, 1, 1)
+	require.NoError(t, err)
+	second, err := useCases.DeliverShipment(ctx, 1, 2)
+	require.NoError(t, err)
+
+	// Then
+	assert.Equal(t, entities.OrderStatusShipped, first.OrderStatus)
+	assert.NotNil(t, first.DeliveredAt)
+	assert.Equal(t, entities.OrderStatusDelivered, second.OrderStatus)
+
+	require.Len(t, publisher.events, 3)
+	assert.Equal(t, events.OrderShipmentDelivered, publisher.events[0].Type)
+	assert.Equal(t, events.OrderShipmentDelivered, publisher.events[1].Type)
+	assert.Equal(t, events.OrderStatusChanged, publisher.events[2].Type)
+	assert.Equal(t, entities.OrderStatusDelivered, publisher.events[2].Status)
+}
+
+func TestOrderUseCases_DeliverShipment_Errors(t *testing.T) {
+	// Given
+	useCases, mockRepo, shipmentRepo, _ := setupShipmentUseCases()
+	ctx := context.Background()
+	order := processingOrder()
+	order.Status = entities.OrderStatusPartiallyShipped
+	deliveredAt := order.CreatedAt
+	shipmentRepo.shipments = []*entities.Shipment{
+		{ID: 1, OrderID: 1, Items: []entities.ShipmentItem{{ProductID: 1, Quantity: 1}}, DeliveredAt: &deliveredAt},
+	}
+
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+
+	// When
+	_, unknownErr := useCases.DeliverShipment(ctx, 1, 9)
+	_, deliveredErr := useCases.DeliverShipment(ctx, 1, 1)
+
+	// Then
+	assert.ErrorIs(t, unknownErr, domainErrors.ErrShipmentNotFound)
+	assert.ErrorIs(t, deliveredErr, domainErrors.ErrShipmentAlreadyDelivered)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestOrderUseCases_ListShipments(t *testing.T) {
+	// Given
+	useCases, mockRepo, shipmentRepo, _ := setupShipmentUseCases()
+	ctx := context.Background()
+	order := processingOrder()
+	order.Status = entities.OrderStatusPartiallyShipped
+	shipmentRepo.shipments = []*entities.Shipment{
+		{ID: 1, OrderID: 1, Items: []entities.ShipmentItem{{ProductID: 1, Quantity: 1}}},
+		{ID: 2, OrderID: 2, Items: []entities.ShipmentItem{{ProductID: 1, Quantity: 1}}},
+	}
+
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(order, nil)
+
+	// When
+	result, err := useCases.ListShipments(ctx, 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusPartiallyShipped, result.OrderStatus)
+	require.Len(t, result.Shipments, 1)
+	assert.Equal(t, []dto.ShipmentItemDTO{{ProductID: 1, Quantity: 1}}, result.Shipments[0].Items)
+}
+
+func TestOrderUseCases_ListShipments_WithoutShipmentRepository(t *testing.T) {
+	// Given
+	useCases, _ := setupTestOrderUseCases()
+
+	// When
+	_, err := useCases.ListShipments(context.Background(), 1)
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToGetShipments)
+}
@@ -0,0 +1,163 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"orders-service/internal/application/dto"
+	appEvents "orders-service/internal/application/events"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/internal/domain/events"
+)
+
+// snapshotSchemaVersion is the event schema version new snapshots are encoded in. Stored snapshots keep
+// the version they were taken in, so raising it only changes the snapshots taken afterwards.
+const snapshotSchemaVersion = appEvents.SchemaV4
+
+// errNoSnapshotRepository is returned when the unit of work was built without a snapshot repository
+var errNoSnapshotRepository = errors.New("no snapshot repository configured")
+
+// ListOrderSnapshots retrieves the snapshots of an order without their payloads, oldest version first
+func (uc *orderUseCasesImpl) ListOrderSnapshots(ctx context.Context, orderID uint) (*dto.OrderSnapshotListResponseDTO, error) {
+	uc.log(ctx).Info("ListOrderSnapshots use case called", "order_id", orderID)
+
+	var response *dto.OrderSnapshotListResponseDTO
+	err := uc.inSnapshotsUnitOfWork(ctx, func(ctx context.Context, orders ports.OrderRepository, repo ports.SnapshotRepository) error {
+		order, err := orders.GetByID(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to get order", "order_id", orderID, "error", err)
+			return err
+		}
+		if err := uc.authorizeCustomer(ctx, order.CustomerID); err != nil {
+			return err
+		}
+
+		snapshots, err := repo.ListByOrderID(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to list snapshots", "order_id", orderID, "error", err)
+			return repositoryError(err, domainErrors.ErrFailedToGetSnapshots)
+		}
+
+		response = &dto.OrderSnapshotListResponseDTO{
+			OrderID:       order.ID,
+			OrderPublicID: order.PublicID,
+			Snapshots:     make([]*dto.OrderSnapshotResponseDTO, 0, len(snapshots)),
+		}
+		for _, snapshot := range snapshots {
+			response.Snapshots = append(response.Snapshots, dto.OrderSnapshotToResponseDTO(snapshot, order, false))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log(ctx).Info("ListOrderSnapshots success", "order_id", orderID, "count", len(response.Snapshots))
+	return response, nil
+}
+
+// GetOrderSnapshot retrieves a snapshot of an order with its payload
+func (uc *orderUseCasesImpl) GetOrderSnapshot(ctx context.Context, orderID uint, version int) (*dto.OrderSnapshotResponseDTO, error) {
+	uc.log(ctx).Info("GetOrderSnapshot use case called", "order_id", orderID, "version", version)
+
+	if version < 1 {
+		return nil, domainErrors.ErrInvalidSnapshotVersion
+	}
+
+	var response *dto.OrderSnapshotResponseDTO
+	err := uc.inSnapshotsUnitOfWork(ctx, func(ctx context.Context, orders ports.OrderRepository, repo ports.SnapshotRepository) error {
+		order, err := orders.GetByID(ctx, orderID)
+		if err != nil {
+			uc.log(ctx).Error("Failed to get order", "order_id", orderID, "error", err)
+			return err
+		}
+		if err := uc.authorizeCustomer(ctx, order.CustomerID); err != nil {
+			return err
+		}
+
+		snapshot, err := repo.GetByVersion(ctx, orderID, version)
+		if errors.Is(err, domainErrors.ErrSnapshotNotFound) {
+			return err
+		}
+		if err != nil {
+			uc.log(ctx).Error("Failed to get snapshot", "order_id", orderID, "version", version, "error", err)
+			return repositoryError(err, domainErrors.ErrFailedToGetSnapshots)
+		}
+
+		response = dto.OrderSnapshotToResponseDTO(snapshot, order, true)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log(ctx).Info("GetOrderSnapshot success", "order_id", orderID, "version", version)
+	return response, nil
+}
+
+// modifyOrderWithSnapshot runs modifyOrder and takes a snapshot of the stored order in the same unit of
+// work, so the change is not stored without its snapshot. The payload is encoded as an event of eventType.
+// A unit of work without a snapshot repository takes no snapshots.
+func (uc *orderUseCasesImpl) modifyOrderWithSnapshot(
+	ctx context.Context,
+	orderID uint,
+	reason entities.SnapshotReason,
+	eventType events.OrderEventType,
+	change func(order *entities.Order) error,
+) (before, after *entities.Order, err error) {
+	return uc.modifyOrderAndThen(ctx, orderID, change, func(ctx context.Context, repos ports.Repositories, order *entities.Order) error {
+		if repos.Snapshots == nil {
+			return nil
+		}
+		return uc.takeSnapshot(ctx, repos.Snapshots, order, reason, eventType)
+	})
+}
+
+// takeSnapshot stores the next snapshot version of order
+func (uc *orderUseCasesImpl) takeSnapshot(ctx context.Context, repo ports.SnapshotRepository, order *entities.Order, reason entities.SnapshotReason, eventType events.OrderEventType) error {
+	snapshots, err := repo.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		uc.log(ctx).Error("Failed to list snapshots", "order_id", order.ID, "error", err)
+		return repositoryError(err, domainErrors.ErrFailedToTakeSnapshot)
+	}
+	version := 1
+	if n := len(snapshots); n > 0 {
+		version = snapshots[n-1].Version + 1
+	}
+
+	takenAt := time.Now().UTC()
+	payload, err := appEvents.Encode(events.NewOrderEvent(eventType, order, takenAt), snapshotSchemaVersion)
+	if err != nil {
+		uc.log(ctx).Error("Failed to encode snapshot", "order_id", order.ID, "error", err)
+		return domainErrors.WrapDomainError(domainErrors.ErrFailedToTakeSnapshot, err)
+	}
+
+	if _, err := repo.Create(ctx, &entities.OrderSnapshot{
+		OrderID:       order.ID,
+		Version:       version,
+		Reason:        reason,
+		SchemaVersion: int(snapshotSchemaVersion),
+		TakenAt:       takenAt,
+		Payload:       payload,
+	}); err != nil {
+		uc.log(ctx).Error("Failed to store snapshot", "order_id", order.ID, "version", version, "error", err)
+		return repositoryError(err, domainErrors.ErrFailedToTakeSnapshot)
+	}
+
+	uc.log(ctx).Info("Order snapshot taken", "order_id", order.ID, "version", version, "reason", reason)
+	return nil
+}
+
+// inSnapshotsUnitOfWork runs fn in a unit of work with its order and snapshot repositories
+func (uc *orderUseCasesImpl) inSnapshotsUnitOfWork(ctx context.Context, fn func(ctx context.Context, orders ports.OrderRepository, snapshots ports.SnapshotRepository) error) error {
+	return uc.unitOfWork.Do(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		if repos.Snapshots == nil {
+			uc.log(ctx).Error("Snapshots are unavailable", "error", errNoSnapshotRepository)
+			return domainErrors.WrapDomainError(domainErrors.ErrFailedToGetSnapshots, errNoSnapshotRepository)
+		}
+		return fn(ctx, withRepositoryTimeout(repos.Orders, uc.config.RepositoryTimeout), repos.Snapshots)
+	})
+}
@@ -0,0 +1,230 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSnapshotRepository keeps snapshots in memory, oldest version first
+type fakeSnapshotRepository struct {
+	snapshots []*entities.OrderSnapshot
+	createErr error
+}
+
+func (r *fakeSnapshotRepository) Create(_ context.Context, snapshot *entities.OrderSnapshot) (*entities.OrderSnapshot, error) {
+	if r.createErr != nil {
+		return nil, r.createErr
+	}
+	stored := snapshot.Clone()
+	stored.ID = uint(len(r.snapshots) + 1)
+	r.snapshots = append(r.snapshots, stored)
+	return stored.Clone(), nil
+}
+
+func (r *fakeSnapshotRepository) ListByOrderID(_ context.Context, orderID uint) ([]*entities.OrderSnapshot, error) {
+	snapshots := make([]*entities.OrderSnapshot, 0, len(r.snapshots))
+	for _, snapshot := range r.snapshots {
+		if snapshot.OrderID == orderID {
+			snapshots = append(snapshots, snapshot.Clone())
+		}
+	}
+	return snapshots, nil
+}
+
+func (r *fakeSnapshotRepository) GetByVersion(_ context.Context, orderID uint, version int) (*entities.OrderSnapshot, error) {
+	for _, snapshot := range r.snapshots {
+		if snapshot.OrderID == orderID && snapshot.Version == version {
+			return snapshot.Clone(), nil
+		}
+	}
+	return nil, domainErrors.ErrSnapshotNotFound
+}
+
+func setupSnapshotUseCases() (OrderUseCases, *MockOrderRepository, *fakeSnapshotRepository, *InMemoryUnitOfWork) {
+	mockRepo := new(MockOrderRepository)
+	snapshotRepo := &fakeSnapshotRepository{}
+	unitOfWork := NewInMemoryUnitOfWork(ports.Repositories{Orders: mockRepo, Snapshots: snapshotRepo})
+	useCases := NewOrderUseCasesWithConfig(mockRepo, unitOfWork, nil, nil, logger.New("test"), DefaultOrderUseCasesConfig())
+	return useCases, mockRepo, snapshotRepo, unitOfWork
+}
+
+// snapshotPayload decodes the payload of a stored snapshot
+func snapshotPayload(t *testing.T, snapshot *entities.OrderSnapshot) map[string]interface{} {
+	t.Helper()
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(snapshot.Payload, &payload))
+	return payload
+}
+
+func TestOrderUseCases_ConfirmOrder_TakesSnapshot(t *testing.T) {
+	// Given
+	useCases, mockRepo, snapshotRepo, unitOfWork := setupSnapshotUseCases()
+	ctx := context.Background()
+
+	order := pendingTestOrder(t)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(order), nil)
+
+	// When
+	_, err := useCases.ConfirmOrder(ctx, 1, nil)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, snapshotRepo.snapshots, 1)
+	snapshot := snapshotRepo.snapshots[0]
+	assert.Equal(t, 1, snapshot.Version)
+	assert.Equal(t, entities.SnapshotReasonConfirmed, snapshot.Reason)
+	assert.Equal(t, 4, snapshot.SchemaVersion)
+
+	payload := snapshotPayload(t, snapshot)
+	assert.Equal(t, "order.status_changed.v4", payload["schema"])
+	assert.Equal(t, "confirmed", payload["status"])
+	assert.Equal(t, 25.0, payload["total_amount"])
+	assert.Len(t, payload["items"], 2)
+	assert.Equal(t, 1, unitOfWork.Commits())
+}
+
+func TestOrderUseCases_AmendOrder_TakesNextSnapshot(t *testing.T) {
+	// Given
+	useCases, mockRepo, snapshotRepo, _ := setupSnapshotUseCases()
+	ctx := context.Background()
+
+	snapshotRepo.snapshots = []*entities.OrderSnapshot{{ID: 1, OrderID: 1, Version: 1, Reason: entities.SnapshotReasonConfirmed}}
+	order := confirmedTestOrder(t)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(order), nil)
+
+	// When
+	_, err := useCases.AmendOrder(ctx, 1, &dto.AmendOrderRequestDTO{
+		Reason: "customer called",
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 3, UnitPrice: 10.0},
+		},
+	})
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, snapshotRepo.snapshots, 2)
+	snapshot := snapshotRepo.snapshots[1]
+	assert.Equal(t, 2, snapshot.Version)
+	assert.Equal(t, entities.SnapshotReasonAmended, snapshot.Reason)
+	assert.Equal(t, "order.amended.v4", snapshotPayload(t, snapshot)["schema"])
+}
+
+func TestOrderUseCases_TransitionOrderStatus_ConfirmationTakesSnapshot(t *testing.T) {
+	// Given
+	useCases, mockRepo, snapshotRepo, _ := setupSnapshotUseCases()
+	ctx := context.Background()
+
+	order := pendingTestOrder(t)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(storeInto(order), nil)
+
+	// When
+	_, err := useCases.TransitionOrderStatus(ctx, 1, &dto.UpdateOrderStatusRequestDTO{Status: entities.OrderStatusConfirmed})
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, snapshotRepo.snapshots, 1)
+	assert.Equal(t, entities.SnapshotReasonConfirmed, snapshotRepo.snapshots[0].Reason)
+}
+
+func TestOrderUseCases_ConfirmOrder_FailedSnapshotRollsBack(t *testing.T) {
+	// Given
+	useCases, mockRepo, snapshotRepo, unitOfWork := setupSnapshotUseCases()
+	ctx := context.Background()
+
+	snapshotRepo.createErr = errors.New("connection reset")
+	order := pendingTestOrder(t)
+	mockRepo.On("GetByIDForUpdate", mock.Anything, uint(1)).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(order, nil)
+
+	// When
+	result, err := useCases.ConfirmOrder(ctx, 1, nil)
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToTakeSnapshot)
+	assert.Equal(t, 1, unitOfWork.Rollbacks())
+}
+
+func TestOrderUseCases_ListOrderSnapshots(t *testing.T) {
+	// Given
+	useCases, mockRepo, snapshotRepo, _ := setupSnapshotUseCases()
+	ctx := context.Background()
+
+	snapshotRepo.snapshots = []*entities.OrderSnapshot{
+		{ID: 1, OrderID: 1, Version: 1, Reason: entities.SnapshotReasonConfirmed, Payload: []byte(`{}`)},
+		{ID: 2, OrderID: 2, Version: 1, Reason: entities.SnapshotReasonConfirmed, Payload: []byte(`{}`)},
+		{ID: 3, OrderID: 1, Version: 2, Reason: entities.SnapshotReasonAmended, Payload: []byte(`{}`)},
+	}
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(confirmedTestOrder(t), nil)
+
+	// When
+	result, err := useCases.ListOrderSnapshots(ctx, 1)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, result.Snapshots, 2)
+	assert.Equal(t, 1, result.Snapshots[0].Version)
+	assert.Equal(t, entities.SnapshotReasonAmended, result.Snapshots[1].Reason)
+	assert.Nil(t, result.Snapshots[0].Payload, "lists leave the payloads out")
+}
+
+func TestOrderUseCases_GetOrderSnapshot(t *testing.T) {
+	// Given
+	useCases, mockRepo, snapshotRepo, _ := setupSnapshotUseCases()
+	ctx := context.Background()
+
+	snapshotRepo.snapshots = []*entities.OrderSnapshot{
+		{ID: 1, OrderID: 1, Version: 1, Reason: entities.SnapshotReasonConfirmed, SchemaVersion: 4, Payload: []byte(`{"total_amount":25}`)},
+	}
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(confirmedTestOrder(t), nil)
+
+	// When
+	result, err := useCases.GetOrderSnapshot(ctx, 1, 1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.SchemaVersion)
+	assert.JSONEq(t, `{"total_amount":25}`, string(result.Payload))
+}
+
+func TestOrderUseCases_GetOrderSnapshot_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  int
+		expected error
+	}{
+		{"unknown version", 2, domainErrors.ErrSnapshotNotFound},
+		{"zero version", 0, domainErrors.ErrInvalidSnapshotVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			useCases, mockRepo, _, _ := setupSnapshotUseCases()
+			ctx := context.Background()
+			mockRepo.On("GetByID", mock.Anything, uint(1)).Return(confirmedTestOrder(t), nil)
+
+			// When
+			result, err := useCases.GetOrderSnapshot(ctx, 1, tt.version)
+
+			// Then
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+
+	"orders-service/internal/application/ports"
+)
+
+// InMemoryUnitOfWork implements ports.UnitOfWork without a transaction, fn runs directly against repos.
+// It suits tests and stores that cannot roll back, and counts the outcomes for assertions.
+type InMemoryUnitOfWork struct {
+	repos ports.Repositories
+
+	mu        sync.Mutex
+	commits   int
+	rollbacks int
+}
+
+// NewInMemoryUnitOfWork creates a unit of work running fn against repos
+func NewInMemoryUnitOfWork(repos ports.Repositories) *InMemoryUnitOfWork {
+	return &InMemoryUnitOfWork{repos: repos}
+}
+
+// Do implements ports.UnitOfWork
+func (u *InMemoryUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context, repos ports.Repositories) error) error {
+	ctx, hooks := ports.WithCommitHooks(ctx)
+	err := fn(ctx, u.repos)
+
+	u.mu.Lock()
+	if err != nil {
+		u.rollbacks++
+	} else {
+		u.commits++
+	}
+	u.mu.Unlock()
+
+	if err == nil {
+		hooks.Run()
+	}
+	return err
+}
+
+// Commits returns the number of units of work that completed without error
+func (u *InMemoryUnitOfWork) Commits() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.commits
+}
+
+// Rollbacks returns the number of units of work that failed
+func (u *InMemoryUnitOfWork) Rollbacks() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.rollbacks
+}
+
+// inUnitOfWork runs fn in a unit of work with the order repository bounded by the repository timeout
+func (uc *orderUseCasesImpl) inUnitOfWork(ctx context.Context, fn func(ctx context.Context, orders ports.OrderRepository) error) error {
+	return uc.unitOfWork.Do(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		return fn(ctx, withRepositoryTimeout(repos.Orders, uc.config.RepositoryTimeout))
+	})
+}
@@ -0,0 +1,155 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type unitOfWorkMarker struct{}
+
+// markingUnitOfWork runs fn with a ctx marked as transactional, like a database unit of work would
+type markingUnitOfWork struct {
+	*InMemoryUnitOfWork
+}
+
+func (u markingUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context, repos ports.Repositories) error) error {
+	return u.InMemoryUnitOfWork.Do(context.WithValue(ctx, unitOfWorkMarker{}, true), fn)
+}
+
+func inUnitOfWorkContext(ctx context.Context) bool {
+	return ctx.Value(unitOfWorkMarker{}) != nil
+}
+
+func setupUnitOfWorkOrderUseCases(config OrderUseCasesConfig) (OrderUseCases, *MockOrderRepository, *InMemoryUnitOfWork) {
+	mockRepo := new(MockOrderRepository)
+	unitOfWork := NewInMemoryUnitOfWork(ports.Repositories{Orders: mockRepo})
+	useCases := NewOrderUseCasesWithConfig(mockRepo, markingUnitOfWork{unitOfWork}, nil, nil, logger.New("test"), config)
+	return useCases, mockRepo, unitOfWork
+}
+
+func TestInMemoryUnitOfWork_CountsOutcomes(t *testing.T) {
+	unitOfWork := NewInMemoryUnitOfWork(ports.Repositories{})
+	ctx := context.Background()
+
+	require.NoError(t, unitOfWork.Do(ctx, func(ctx context.Context, repos ports.Repositories) error { return nil }))
+	failure := errors.New("boom")
+	assert.ErrorIs(t, unitOfWork.Do(ctx, func(ctx context.Context, repos ports.Repositories) error { return failure }), failure)
+
+	assert.Equal(t, 1, unitOfWork.Commits())
+	assert.Equal(t, 1, unitOfWork.Rollbacks())
+}
+
+func TestInMemoryUnitOfWork_RunsHooksAfterCommit(t *testing.T) {
+	unitOfWork := NewInMemoryUnitOfWork(ports.Repositories{})
+	ctx := context.Background()
+	var ran []string
+
+	err := unitOfWork.Do(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		ports.AfterCommit(ctx, func() { ran = append(ran, "outer") })
+		// A nested unit of work joins the outer one, its hooks wait for the outer commit
+		require.NoError(t, unitOfWork.Do(ctx, func(ctx context.Context, repos ports.Repositories) error {
+			ports.AfterCommit(ctx, func() { ran = append(ran, "nested") })
+			return nil
+		}))
+		assert.Empty(t, ran, "no hook runs before the commit")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"outer", "nested"}, ran)
+
+	ran = nil
+	_ = unitOfWork.Do(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		ports.AfterCommit(ctx, func() { ran = append(ran, "rolled back") })
+		return errors.New("boom")
+	})
+	assert.Empty(t, ran)
+
+	ports.AfterCommit(ctx, func() { ran = append(ran, "outside") })
+	assert.Equal(t, []string{"outside"}, ran)
+}
+
+func TestOrderUseCases_ConfirmOrder_RunsInUnitOfWork(t *testing.T) {
+	// Given
+	useCases, mockRepo, unitOfWork := setupUnitOfWorkOrderUseCases(DefaultOrderUseCasesConfig())
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+	existingOrder.AddItem(1, "SKU-001", "Product 1", 2, 10.50)
+
+	inTx := mock.MatchedBy(inUnitOfWorkContext)
+	mockRepo.On("GetByIDForUpdate", inTx, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", inTx, mock.AnythingOfType("*entities.Order")).Return(storeInto(existingOrder), nil)
+
+	// When
+	result, err := useCases.ConfirmOrder(context.Background(), 1, nil)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderStatusConfirmed, result.Status)
+	assert.Equal(t, 1, unitOfWork.Commits())
+	assert.Equal(t, 0, unitOfWork.Rollbacks())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_ConfirmOrder_FailedUpdateRollsBack(t *testing.T) {
+	// Given
+	useCases, mockRepo, unitOfWork := setupUnitOfWorkOrderUseCases(DefaultOrderUseCasesConfig())
+
+	existingOrder, _ := entities.NewOrder(123)
+	existingOrder.ID = 1
+	existingOrder.AddItem(1, "SKU-001", "Product 1", 2, 10.50)
+
+	inTx := mock.MatchedBy(inUnitOfWorkContext)
+	mockRepo.On("GetByIDForUpdate", inTx, uint(1)).Return(existingOrder, nil)
+	mockRepo.On("Update", inTx, mock.AnythingOfType("*entities.Order")).Return(nil, errors.New("connection reset"))
+
+	// When
+	result, err := useCases.ConfirmOrder(context.Background(), 1, nil)
+
+	// Then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToUpdateOrder)
+	assert.Equal(t, 0, unitOfWork.Commits())
+	assert.Equal(t, 1, unitOfWork.Rollbacks())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderUseCases_CreateOrder_CountsAndCreatesInOneUnitOfWork(t *testing.T) {
+	// Given
+	useCases, mockRepo, unitOfWork := setupUnitOfWorkOrderUseCases(DefaultOrderUseCasesConfig())
+
+	request := &dto.CreateOrderRequestDTO{
+		CustomerID: 123,
+		Items: []dto.CreateOrderItemDTO{
+			{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 1, UnitPrice: 10},
+		},
+	}
+	createdOrder, _ := request.ToEntity()
+	createdOrder.ID = 1
+
+	inTx := mock.MatchedBy(inUnitOfWorkContext)
+	mockRepo.On("WithCustomerLock", inTx, uint(123), mock.Anything).Return(nil)
+	mockRepo.On("CountByCustomerIDAndStatus", inTx, uint(123), entities.OrderStatusPending).Return(int64(0), nil)
+	mockRepo.On("NextOrderNumberSequence", mock.Anything, mock.Anything).Return(uint64(1), nil)
+	mockRepo.On("Create", inTx, mock.AnythingOfType("*entities.Order")).Return(createdOrder, nil)
+
+	// When
+	result, err := useCases.CreateOrder(context.Background(), request)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+	assert.Equal(t, 1, unitOfWork.Commits())
+	mockRepo.AssertExpectations(t)
+}
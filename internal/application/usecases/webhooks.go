@@ -0,0 +1,223 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"orders-service/internal/application/dto"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+)
+
+// DefaultWebhookReplayBatchSize is the number of dead letters a bulk replay delivers when not configured
+const DefaultWebhookReplayBatchSize = 100
+
+// WebhookUseCases defines the interface for inspecting and replaying the webhook deliveries that failed
+type WebhookUseCases interface {
+	ListDeadLetters(ctx context.Context, webhookID string, from, to *time.Time, page, pageSize int) (*dto.WebhookDeadLetterListResponseDTO, error)
+	ReplayDeadLetter(ctx context.Context, webhookID string, id uint) (*dto.WebhookDeadLetterResponseDTO, error)
+	ReplayDeadLetters(ctx context.Context, webhookID string, from, to *time.Time) (*dto.ReplayWebhookDeadLettersResponseDTO, error)
+}
+
+// WebhookUseCasesConfig tunes the webhook use cases
+type WebhookUseCasesConfig struct {
+	// ReplayBatchSize caps the dead letters delivered by a bulk replay, values below 1 use
+	// DefaultWebhookReplayBatchSize
+	ReplayBatchSize int
+}
+
+// webhookUseCasesImpl implements WebhookUseCases interface
+type webhookUseCasesImpl struct {
+	deadLetterRepo ports.WebhookDeadLetterRepository
+	sender         ports.WebhookSender
+	config         WebhookUseCasesConfig
+	now            func() time.Time
+	logger         logger.Logger
+}
+
+// NewWebhookUseCases creates a new instance of webhook use cases. Replays are posted with sender, the
+// one the publisher delivers with, so they are signed and retried like the original delivery.
+func NewWebhookUseCases(deadLetterRepo ports.WebhookDeadLetterRepository, sender ports.WebhookSender, log logger.Logger, config WebhookUseCasesConfig) WebhookUseCases {
+	if config.ReplayBatchSize < 1 {
+		config.ReplayBatchSize = DefaultWebhookReplayBatchSize
+	}
+
+	return &webhookUseCasesImpl{
+		deadLetterRepo: deadLetterRepo,
+		sender:         sender,
+		config:         config,
+		now:            time.Now,
+		logger:         log.With("component", "webhook_usecases"),
+	}
+}
+
+// ListDeadLetters retrieves the dead letters of a webhook given up between from, inclusive, and to,
+// exclusive, oldest first. Replayed dead letters are listed too.
+func (uc *webhookUseCasesImpl) ListDeadLetters(ctx context.Context, webhookID string, from, to *time.Time, page, pageSize int) (*dto.WebhookDeadLetterListResponseDTO, error) {
+	uc.log(ctx).Info("ListDeadLetters use case called", "webhook_id", webhookID, "from", from, "to", to, "page", page, "page_size", pageSize)
+
+	if !uc.sender.HasWebhook(webhookID) {
+		return nil, domainErrors.ErrWebhookNotFound
+	}
+	page, pageSize, err := validatePagination(page, pageSize, dto.MaxPageSize)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := deadLetterFilter(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetters, total, err := uc.deadLetterRepo.List(ctx, webhookID, filter, pageSize, page*pageSize)
+	if err != nil {
+		uc.log(ctx).Error("Failed to list webhook dead letters", "webhook_id", webhookID, "error", err)
+		return nil, repositoryError(err, domainErrors.ErrFailedToGetWebhookDeadLetters)
+	}
+
+	totalPages := dto.TotalPages(total, pageSize)
+	response := &dto.WebhookDeadLetterListResponseDTO{
+		DeadLetters: make([]*dto.WebhookDeadLetterResponseDTO, 0, len(deadLetters)),
+		Total:       total,
+		Page:        page,
+		PageSize:    pageSize,
+		TotalPages:  totalPages,
+		HasNext:     page+1 < totalPages,
+		HasPrevious: page > 0,
+	}
+	for _, deadLetter := range deadLetters {
+		response.DeadLetters = append(response.DeadLetters, dto.WebhookDeadLetterToResponseDTO(deadLetter))
+	}
+
+	uc.log(ctx).Info("ListDeadLetters success", "webhook_id", webhookID, "count", len(deadLetters), "total", total)
+	return response, nil
+}
+
+// ReplayDeadLetter delivers a dead letter again with a fresh retry budget. The dead letter is claimed
+// before the delivery, so of concurrent replays a single one delivers it and the others get
+// ErrWebhookDeadLetterReplayed. A failed delivery makes the dead letter pending again and is reported as
+// ErrWebhookDeliveryFailed.
+func (uc *webhookUseCasesImpl) ReplayDeadLetter(ctx context.Context, webhookID string, id uint) (*dto.WebhookDeadLetterResponseDTO, error) {
+	uc.log(ctx).Info("ReplayDeadLetter use case called", "webhook_id", webhookID, "dead_letter_id", id)
+
+	if !uc.sender.HasWebhook(webhookID) {
+		return nil, domainErrors.ErrWebhookNotFound
+	}
+
+	deadLetter, err := uc.deadLetterRepo.ClaimReplay(ctx, webhookID, id, uc.now())
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrWebhookDeadLetterNotFound) || errors.Is(err, domainErrors.ErrWebhookDeadLetterReplayed) {
+			return nil, err
+		}
+		uc.log(ctx).Error("Failed to claim webhook dead letter", "webhook_id", webhookID, "dead_letter_id", id, "error", err)
+		return nil, repositoryError(err, domainErrors.ErrFailedToReplayWebhookDeadLetters)
+	}
+
+	delivered, err := uc.replay(ctx, deadLetter)
+	if err != nil {
+		return nil, err
+	}
+	if !delivered {
+		return nil, domainErrors.WrapDomainError(domainErrors.ErrWebhookDeliveryFailed, errors.New(deadLetter.LastError))
+	}
+
+	uc.log(ctx).Info("ReplayDeadLetter success", "webhook_id", webhookID, "dead_letter_id", id)
+	return dto.WebhookDeadLetterToResponseDTO(deadLetter), nil
+}
+
+// ReplayDeadLetters delivers again the oldest pending dead letters of a webhook given up between from and
+// to, at most ReplayBatchSize of them. Dead letters claimed by a concurrent replay are skipped, those whose
+// delivery failed stay pending and are counted as failed and remaining.
+func (uc *webhookUseCasesImpl) ReplayDeadLetters(ctx context.Context, webhookID string, from, to *time.Time) (*dto.ReplayWebhookDeadLettersResponseDTO, error) {
+	uc.log(ctx).Info("ReplayDeadLetters use case called", "webhook_id", webhookID, "from", from, "to", to, "batch_size", uc.config.ReplayBatchSize)
+
+	if !uc.sender.HasWebhook(webhookID) {
+		return nil, domainErrors.ErrWebhookNotFound
+	}
+	filter, err := deadLetterFilter(from, to)
+	if err != nil {
+		return nil, err
+	}
+	filter.Pending = true
+
+	deadLetters, pending, err := uc.deadLetterRepo.List(ctx, webhookID, filter, uc.config.ReplayBatchSize, 0)
+	if err != nil {
+		uc.log(ctx).Error("Failed to list pending webhook dead letters", "webhook_id", webhookID, "error", err)
+		return nil, repositoryError(err, domainErrors.ErrFailedToReplayWebhookDeadLetters)
+	}
+
+	response := &dto.ReplayWebhookDeadLettersResponseDTO{}
+	for _, listed := range deadLetters {
+		deadLetter, err := uc.deadLetterRepo.ClaimReplay(ctx, webhookID, listed.ID, uc.now())
+		if errors.Is(err, domainErrors.ErrWebhookDeadLetterReplayed) {
+			pending--
+			continue
+		}
+		if err != nil {
+			uc.log(ctx).Error("Failed to claim webhook dead letter", "webhook_id", webhookID, "dead_letter_id", listed.ID, "error", err)
+			return nil, repositoryError(err, domainErrors.ErrFailedToReplayWebhookDeadLetters)
+		}
+
+		delivered, err := uc.replay(ctx, deadLetter)
+		if err != nil {
+			return nil, err
+		}
+		if delivered {
+			response.Replayed++
+		} else {
+			response.Failed++
+		}
+	}
+	response.Remaining = pending - int64(response.Replayed)
+
+	uc.log(ctx).Info("ReplayDeadLetters success", "webhook_id", webhookID, "replayed", response.Replayed, "failed", response.Failed, "remaining", response.Remaining)
+	return response, nil
+}
+
+// replay delivers a claimed dead letter and reports whether it was delivered. A failed delivery is
+// recorded on the dead letter, which is released for a later replay; the error reports a release that
+// could not be stored.
+func (uc *webhookUseCasesImpl) replay(ctx context.Context, deadLetter *entities.WebhookDeadLetter) (bool, error) {
+	attempts, deliveryErr := uc.sender.Send(ctx, deadLetter.WebhookID, deadLetter.EventType, deadLetter.Payload)
+	if deliveryErr == nil {
+		return true, nil
+	}
+
+	uc.log(ctx).Warn("Failed to replay webhook dead letter",
+		"webhook_id", deadLetter.WebhookID,
+		"dead_letter_id", deadLetter.ID,
+		"attempts", attempts,
+		"error", deliveryErr)
+
+	deadLetter.RecordFailure(attempts, deliveryErr)
+	if _, err := uc.deadLetterRepo.ReleaseReplay(ctx, deadLetter); err != nil {
+		uc.log(ctx).Error("Failed to release webhook dead letter", "webhook_id", deadLetter.WebhookID, "dead_letter_id", deadLetter.ID, "error", err)
+		return false, repositoryError(err, domainErrors.ErrFailedToReplayWebhookDeadLetters)
+	}
+	return false, nil
+}
+
+// deadLetterFilter builds the filter of the dead letters given up between from and to
+func deadLetterFilter(from, to *time.Time) (ports.WebhookDeadLetterFilter, error) {
+	var filter ports.WebhookDeadLetterFilter
+	if from != nil {
+		utc := from.UTC()
+		filter.From = &utc
+	}
+	if to != nil {
+		utc := to.UTC()
+		filter.To = &utc
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return ports.WebhookDeadLetterFilter{}, domainErrors.ErrInvalidDateRange
+	}
+	return filter, nil
+}
+
+// log returns the request logger carried by ctx, falling back to the logger of the use cases
+func (uc *webhookUseCasesImpl) log(ctx context.Context) logger.Logger {
+	return logger.FromContext(ctx, uc.logger)
+}
@@ -0,0 +1,248 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"orders-service/internal/application/ports"
+	"orders-service/internal/domain/entities"
+	domainErrors "orders-service/internal/domain/errors"
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockWebhookDeadLetterRepository implements the WebhookDeadLetterRepository interface for testing
+type MockWebhookDeadLetterRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookDeadLetterRepository) Create(ctx context.Context, deadLetter *entities.WebhookDeadLetter) (*entities.WebhookDeadLetter, error) {
+	args := m.Called(ctx, deadLetter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.WebhookDeadLetter), args.Error(1)
+}
+
+func (m *MockWebhookDeadLetterRepository) GetByID(ctx context.Context, webhookID string, id uint) (*entities.WebhookDeadLetter, error) {
+	args := m.Called(ctx, webhookID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.WebhookDeadLetter), args.Error(1)
+}
+
+func (m *MockWebhookDeadLetterRepository) List(ctx context.Context, webhookID string, filter ports.WebhookDeadLetterFilter, limit, offset int) ([]*entities.WebhookDeadLetter, int64, error) {
+	args := m.Called(ctx, webhookID, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*entities.WebhookDeadLetter), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWebhookDeadLetterRepository) ClaimReplay(ctx context.Context, webhookID string, id uint, replayedAt time.Time) (*entities.WebhookDeadLetter, error) {
+	args := m.Called(ctx, webhookID, id, replayedAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.WebhookDeadLetter), args.Error(1)
+}
+
+func (m *MockWebhookDeadLetterRepository) ReleaseReplay(ctx context.Context, deadLetter *entities.WebhookDeadLetter) (*entities.WebhookDeadLetter, error) {
+	args := m.Called(ctx, deadLetter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.WebhookDeadLetter), args.Error(1)
+}
+
+// stubWebhookSender knows the "partner" webhook and fails the deliveries of the dead letters in failing
+type stubWebhookSender struct {
+	sent    [][]byte
+	failing map[string]bool
+}
+
+func (s *stubWebhookSender) HasWebhook(webhookID string) bool {
+	return webhookID == "partner"
+}
+
+func (s *stubWebhookSender) Send(_ context.Context, _, _ string, payload []byte) (int, error) {
+	if s.failing[string(payload)] {
+		return 5, errors.New("unexpected status 503")
+	}
+	s.sent = append(s.sent, payload)
+	return 1, nil
+}
+
+var webhookNow = time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+
+func newTestWebhookUseCases(repo *MockWebhookDeadLetterRepository, sender *stubWebhookSender, batchSize int) WebhookUseCases {
+	useCases := NewWebhookUseCases(repo, sender, logger.New("test"), WebhookUseCasesConfig{ReplayBatchSize: batchSize})
+	useCases.(*webhookUseCasesImpl).now = func() time.Time { return webhookNow }
+	return useCases
+}
+
+func newTestWebhookDeadLetter(id uint, payload string) *entities.WebhookDeadLetter {
+	deadLetter := entities.NewWebhookDeadLetter("partner", "order.created", []byte(payload), 5, errors.New("timeout"), webhookNow.Add(-time.Hour))
+	deadLetter.ID = id
+	return deadLetter
+}
+
+func claimed(deadLetter *entities.WebhookDeadLetter) *entities.WebhookDeadLetter {
+	claimed := deadLetter.Clone()
+	claimed.MarkReplayed(webhookNow)
+	return claimed
+}
+
+func TestWebhookUseCases_ListDeadLetters_Success(t *testing.T) {
+	// Given
+	mockRepo := new(MockWebhookDeadLetterRepository)
+	useCases := newTestWebhookUseCases(mockRepo, &stubWebhookSender{}, 0)
+	ctx := context.Background()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mockRepo.On("List", ctx, "partner", ports.WebhookDeadLetterFilter{From: &from}, 10, 10).
+		Return([]*entities.WebhookDeadLetter{newTestWebhookDeadLetter(3, `{"id":3}`)}, int64(11), nil)
+
+	// When
+	response, err := useCases.ListDeadLetters(ctx, "partner", &from, nil, 1, 10)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), response.Total)
+	assert.Equal(t, 2, response.TotalPages)
+	assert.True(t, response.HasPrevious)
+	require.Len(t, response.DeadLetters, 1)
+	assert.JSONEq(t, `{"id":3}`, string(response.DeadLetters[0].Payload))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWebhookUseCases_UnknownWebhook(t *testing.T) {
+	mockRepo := new(MockWebhookDeadLetterRepository)
+	useCases := newTestWebhookUseCases(mockRepo, &stubWebhookSender{}, 0)
+	ctx := context.Background()
+
+	_, err := useCases.ListDeadLetters(ctx, "other", nil, nil, 0, 10)
+	assert.ErrorIs(t, err, domainErrors.ErrWebhookNotFound)
+
+	_, err = useCases.ReplayDeadLetter(ctx, "other", 1)
+	assert.ErrorIs(t, err, domainErrors.ErrWebhookNotFound)
+
+	_, err = useCases.ReplayDeadLetters(ctx, "other", nil, nil)
+	assert.ErrorIs(t, err, domainErrors.ErrWebhookNotFound)
+
+	mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWebhookUseCases_ListDeadLetters_InvalidDateRange(t *testing.T) {
+	mockRepo := new(MockWebhookDeadLetterRepository)
+	useCases := newTestWebhookUseCases(mockRepo, &stubWebhookSender{}, 0)
+	from := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+
+	_, err := useCases.ListDeadLetters(context.Background(), "partner", &from, &to, 0, 10)
+
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidDateRange)
+}
+
+func TestWebhookUseCases_ReplayDeadLetter_Success(t *testing.T) {
+	// Given
+	mockRepo := new(MockWebhookDeadLetterRepository)
+	sender := &stubWebhookSender{}
+	useCases := newTestWebhookUseCases(mockRepo, sender, 0)
+	ctx := context.Background()
+	deadLetter := newTestWebhookDeadLetter(3, `{"id":3}`)
+
+	mockRepo.On("ClaimReplay", ctx, "partner", uint(3), webhookNow).Return(claimed(deadLetter), nil)
+
+	// When
+	response, err := useCases.ReplayDeadLetter(ctx, "partner", 3)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, response.ReplayedAt)
+	assert.Equal(t, webhookNow, *response.ReplayedAt)
+	assert.Equal(t, [][]byte{[]byte(`{"id":3}`)}, sender.sent)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWebhookUseCases_ReplayDeadLetter_DeliveryFails(t *testing.T) {
+	// Given
+	mockRepo := new(MockWebhookDeadLetterRepository)
+	sender := &stubWebhookSender{failing: map[string]bool{`{"id":3}`: true}}
+	useCases := newTestWebhookUseCases(mockRepo, sender, 0)
+	ctx := context.Background()
+	deadLetter := newTestWebhookDeadLetter(3, `{"id":3}`)
+
+	mockRepo.On("ClaimReplay", ctx, "partner", uint(3), webhookNow).Return(claimed(deadLetter), nil)
+	mockRepo.On("ReleaseReplay", ctx, mock.MatchedBy(func(released *entities.WebhookDeadLetter) bool {
+		return released.ReplayedAt == nil && released.Attempts == 10 && released.LastError == "unexpected status 503"
+	})).Return(deadLetter, nil)
+
+	// When
+	response, err := useCases.ReplayDeadLetter(ctx, "partner", 3)
+
+	// Then
+	assert.ErrorIs(t, err, domainErrors.ErrWebhookDeliveryFailed)
+	assert.Contains(t, err.Error(), "unexpected status 503")
+	assert.Nil(t, response)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWebhookUseCases_ReplayDeadLetter_AlreadyReplayed(t *testing.T) {
+	mockRepo := new(MockWebhookDeadLetterRepository)
+	sender := &stubWebhookSender{}
+	useCases := newTestWebhookUseCases(mockRepo, sender, 0)
+	ctx := context.Background()
+
+	mockRepo.On("ClaimReplay", ctx, "partner", uint(3), webhookNow).Return(nil, domainErrors.ErrWebhookDeadLetterReplayed)
+	mockRepo.On("ClaimReplay", ctx, "partner", uint(4), webhookNow).Return(nil, domainErrors.ErrWebhookDeadLetterNotFound)
+	mockRepo.On("ClaimReplay", ctx, "partner", uint(5), webhookNow).Return(nil, errors.New("connection reset"))
+
+	_, err := useCases.ReplayDeadLetter(ctx, "partner", 3)
+	assert.ErrorIs(t, err, domainErrors.ErrWebhookDeadLetterReplayed)
+
+	_, err = useCases.ReplayDeadLetter(ctx, "partner", 4)
+	assert.ErrorIs(t, err, domainErrors.ErrWebhookDeadLetterNotFound)
+
+	_, err = useCases.ReplayDeadLetter(ctx, "partner", 5)
+	assert.ErrorIs(t, err, domainErrors.ErrFailedToReplayWebhookDeadLetters)
+
+	assert.Empty(t, sender.sent)
+}
+
+func TestWebhookUseCases_ReplayDeadLetters(t *testing.T) {
+	// Given
+	mockRepo := new(MockWebhookDeadLetterRepository)
+	sender := &stubWebhookSender{failing: map[string]bool{`{"id":2}`: true}}
+	useCases := newTestWebhookUseCases(mockRepo, sender, 3)
+	ctx := context.Background()
+	to := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	first := newTestWebhookDeadLetter(1, `{"id":1}`)
+	second := newTestWebhookDeadLetter(2, `{"id":2}`)
+	raced := newTestWebhookDeadLetter(3, `{"id":3}`)
+	mockRepo.On("List", ctx, "partner", ports.WebhookDeadLetterFilter{To: &to, Pending: true}, 3, 0).
+		Return([]*entities.WebhookDeadLetter{first, second, raced}, int64(5), nil)
+	mockRepo.On("ClaimReplay", ctx, "partner", uint(1), webhookNow).Return(claimed(first), nil)
+	mockRepo.On("ClaimReplay", ctx, "partner", uint(2), webhookNow).Return(claimed(second), nil)
+	mockRepo.On("ClaimReplay", ctx, "partner", uint(3), webhookNow).Return(nil, domainErrors.ErrWebhookDeadLetterReplayed)
+	mockRepo.On("ReleaseReplay", ctx, mock.Anything).Return(second, nil)
+
+	// When
+	response, err := useCases.ReplayDeadLetters(ctx, "partner", nil, &to)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.Replayed)
+	assert.Equal(t, 1, response.Failed)
+	// 5 pending, one replayed here and one by a concurrent replay
+	assert.Equal(t, int64(3), response.Remaining)
+	assert.Equal(t, [][]byte{[]byte(`{"id":1}`)}, sender.sent)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// CacheConfig configures the Redis read cache, the service runs without it when disabled
+type CacheConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	Addr        string        `mapstructure:"addr"`
+	Password    string        `mapstructure:"password"`
+	DB          int           `mapstructure:"db"`
+	PoolSize    int           `mapstructure:"pool_size"`
+	DialTimeout time.Duration `mapstructure:"dial_timeout"`
+	IOTimeout   time.Duration `mapstructure:"io_timeout"`
+
+	// OrderTTL is how long an order stays cached after it was read
+	OrderTTL time.Duration `mapstructure:"order_ttl"`
+}
+
+func CacheDefaults(v *viper.Viper) {
+	v.SetDefault("cache.enabled", false)
+	v.SetDefault("cache.addr", "localhost:6379")
+	v.SetDefault("cache.db", 0)
+	v.SetDefault("cache.pool_size", 10)
+	v.SetDefault("cache.dial_timeout", 500*time.Millisecond)
+	v.SetDefault("cache.io_timeout", 200*time.Millisecond)
+	v.SetDefault("cache.order_ttl", 5*time.Minute)
+}
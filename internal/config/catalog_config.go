@@ -0,0 +1,33 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// CatalogConfig configures the product catalog used to reprice pending orders, repricing is unavailable without a base URL
+type CatalogConfig struct {
+	BaseURL string `mapstructure:"base_url"`
+	// Timeout bounds every attempt of a request
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Failed reads are retried with jittered exponential backoff, 1 attempt disables retries
+	RetryMaxAttempts int           `mapstructure:"retry_max_attempts"`
+	RetryBaseDelay   time.Duration `mapstructure:"retry_base_delay"`
+	RetryMaxDelay    time.Duration `mapstructure:"retry_max_delay"`
+
+	// BreakerThreshold consecutive failures stop calls to the catalog for BreakerCooldown, 0 disables the breaker
+	BreakerThreshold int           `mapstructure:"breaker_threshold"`
+	BreakerCooldown  time.Duration `mapstructure:"breaker_cooldown"`
+}
+
+func CatalogDefaults(v *viper.Viper) {
+	v.SetDefault("catalog.base_url", "")
+	v.SetDefault("catalog.timeout", 2*time.Second)
+	v.SetDefault("catalog.retry_max_attempts", 3)
+	v.SetDefault("catalog.retry_base_delay", 100*time.Millisecond)
+	v.SetDefault("catalog.retry_max_delay", time.Second)
+	v.SetDefault("catalog.breaker_threshold", 5)
+	v.SetDefault("catalog.breaker_cooldown", 30*time.Second)
+}
@@ -10,33 +10,135 @@ import (
 )
 
 type Config struct {
-	Environment string         `mapstructure:"environment"`
-	Version     string         `mapstructure:"version"`
-	LogLevel    string         `mapstructure:"log_level"`
-	Server      ServerConfig   `mapstructure:"server"`
-	Database    DatabaseConfig `mapstructure:"database"`
-	Security    SecurityConfig `mapstructure:"security"`
-	Logging     LoggingConfig  `mapstructure:"logging"`
+	Environment string          `mapstructure:"environment"`
+	Version     string          `mapstructure:"version"`
+	LogLevel    string          `mapstructure:"log_level"`
+	Server      ServerConfig    `mapstructure:"server"`
+	Database    DatabaseConfig  `mapstructure:"database"`
+	Mongo       MongoConfig     `mapstructure:"mongo"`
+	Security    SecurityConfig  `mapstructure:"security"`
+	Logging     LoggingConfig   `mapstructure:"logging"`
+	Orders      OrdersConfig    `mapstructure:"orders"`
+	Cache       CacheConfig     `mapstructure:"cache"`
+	Workers     WorkersConfig   `mapstructure:"workers"`
+	Kafka       KafkaConfig     `mapstructure:"kafka"`
+	Catalog     CatalogConfig   `mapstructure:"catalog"`
+	Inventory   InventoryConfig `mapstructure:"inventory"`
+	Payments    PaymentsConfig  `mapstructure:"payments"`
+	Webhooks    WebhooksConfig  `mapstructure:"webhooks"`
+	Documents   DocumentsConfig `mapstructure:"documents"`
+
+	// dynamic holds the settings a Reloader may change, set by Load
+	dynamic *Dynamic
 }
 
+// ServerConfig configures the HTTP server. ReadTimeout bounds reading a whole request and
+// ReadHeaderTimeout its headers, WriteTimeout bounds writing the response and IdleTimeout how long a
+// keep-alive connection waits for the next request, 0 leaves a timeout unlimited. Streaming routes are
+// exempt from the read and write timeouts. MaxHeaderBytes caps the request headers, 0 keeps the
+// net/http default of 1 MiB.
 type ServerConfig struct {
-	Port            string        `mapstructure:"port"`
-	Host            string        `mapstructure:"host"`
-	ReadTimeout     time.Duration `mapstructure:"read_timeout"`
-	WriteTimeout    time.Duration `mapstructure:"write_timeout"`
-	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
-	CORS            CORSConfig    `mapstructure:"cors"`
+	Port              string            `mapstructure:"port"`
+	Host              string            `mapstructure:"host"`
+	ReadTimeout       time.Duration     `mapstructure:"read_timeout"`
+	ReadHeaderTimeout time.Duration     `mapstructure:"read_header_timeout"`
+	WriteTimeout      time.Duration     `mapstructure:"write_timeout"`
+	IdleTimeout       time.Duration     `mapstructure:"idle_timeout"`
+	MaxHeaderBytes    int               `mapstructure:"max_header_bytes"`
+	ShutdownTimeout   time.Duration     `mapstructure:"shutdown_timeout"`
+	RequestTimeout    time.Duration     `mapstructure:"request_timeout"`
+	BodyLimit         BodyLimitConfig   `mapstructure:"body_limit"`
+	StrictJSON        bool              `mapstructure:"strict_json"`
+	StrictIncludes    bool              `mapstructure:"strict_includes"`
+	ProblemDetails    bool              `mapstructure:"problem_details"`
+	CORS              CORSConfig        `mapstructure:"cors"`
+	Events            EventsConfig      `mapstructure:"events"`
+	TLS               TLSConfig         `mapstructure:"tls"`
+	Compression       CompressionConfig `mapstructure:"compression"`
+	ListCache         ListCacheConfig   `mapstructure:"list_cache"`
 }
 
+// CompressionConfig gzips responses for clients that accept it
+type CompressionConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// MinSize is the smallest body in bytes worth compressing
+	MinSize int `mapstructure:"min_size"`
+	// Level is the gzip level, from 1 (fastest) to 9 (smallest), -1 picks the default
+	Level int `mapstructure:"level"`
+	// ContentTypes lists the media types compressed, already compressed formats gain nothing
+	ContentTypes []string `mapstructure:"content_types"`
+}
+
+// ListCacheConfig lets clients cache the order lists and revalidate them with If-Modified-Since.
+// MaxAge overrides DefaultMaxAge for a list such as "orders", "customer_orders" or "orders_by_status".
+type ListCacheConfig struct {
+	Enabled       bool                     `mapstructure:"enabled"`
+	DefaultMaxAge time.Duration            `mapstructure:"default_max_age"`
+	MaxAge        map[string]time.Duration `mapstructure:"max_age"`
+}
+
+// EventsConfig tunes the server-sent event streams of order changes
+type EventsConfig struct {
+	// MaxStreams caps the open streams of the server, 0 leaves them unlimited
+	MaxStreams        int           `mapstructure:"max_streams"`
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"`
+	// BufferSize is how many events a stream may fall behind before events for it are dropped
+	BufferSize int `mapstructure:"buffer_size"`
+}
+
+// BodyLimitConfig caps request body sizes in bytes, Groups overrides Default for a route group such as "orders"
+type BodyLimitConfig struct {
+	Default int64            `mapstructure:"default"`
+	Groups  map[string]int64 `mapstructure:"groups"`
+}
+
+// For returns the limit that applies to group
+func (c BodyLimitConfig) For(group string) int64 {
+	if limit, ok := c.Groups[group]; ok {
+		return limit
+	}
+	return c.Default
+}
+
+// CORSConfig lets browsers call the API routes from other origins, health checks and metrics never send CORS headers
 type CORSConfig struct {
+	// AllowOrigins lists origins such as https://app.example.com, https://*.example.com allows every
+	// subdomain of example.com and * allows any origin
 	AllowOrigins []string `mapstructure:"allow_origins"`
 	AllowMethods []string `mapstructure:"allow_methods"`
 	AllowHeaders []string `mapstructure:"allow_headers"`
+	// ExposeHeaders lists the response headers browser scripts may read
+	ExposeHeaders []string `mapstructure:"expose_headers"`
+	// MaxAge is how long browsers may cache a preflight response, 0 leaves it to the browser
+	MaxAge time.Duration `mapstructure:"max_age"`
+	// AllowCredentials lets browsers send cookies and authorization headers, it cannot be used with the * origin
+	AllowCredentials bool `mapstructure:"allow_credentials"`
 }
 
 type SecurityConfig struct {
-	RateLimitRPS   int `mapstructure:"rate_limit_rps"`
-	RateLimitBurst int `mapstructure:"rate_limit_burst"`
+	RateLimitRPS    int             `mapstructure:"rate_limit_rps"`
+	RateLimitBurst  int             `mapstructure:"rate_limit_burst"`
+	RateLimitReads  RateLimitConfig `mapstructure:"rate_limit_reads"`
+	RateLimitWrites RateLimitConfig `mapstructure:"rate_limit_writes"`
+	APIKeys         []APIKeyConfig  `mapstructure:"api_keys"`
+}
+
+// APIKeyConfig describes an API client. Hash is the hex encoded SHA-256 of the key,
+// the plain key is never stored in configuration.
+// CustomerScoped keys act for the customer named in the X-Customer-ID header set by the gateway
+// and only reach that customer's orders.
+type APIKeyConfig struct {
+	Name           string   `mapstructure:"name"`
+	Hash           string   `mapstructure:"hash"`
+	Scopes         []string `mapstructure:"scopes"`
+	CustomerScoped bool     `mapstructure:"customer_scoped"`
+}
+
+// RateLimitConfig configures a token bucket for a class of routes.
+// Zero values fall back to security.rate_limit_rps and security.rate_limit_burst.
+type RateLimitConfig struct {
+	RPS   int `mapstructure:"rps"`
+	Burst int `mapstructure:"burst"`
 }
 
 func Load(configFile, env string) (*Config, error) {
@@ -80,6 +182,7 @@ func Load(configFile, env string) (*Config, error) {
 	if err := v.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+	config.dynamic = newDynamic(&config)
 
 	return &config, nil
 }
@@ -91,16 +194,62 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("log_level", "info")
 	v.SetDefault("server.host", "0.0.0.0")
 	v.SetDefault("server.read_timeout", 15*time.Second)
+	v.SetDefault("server.read_header_timeout", 5*time.Second)
 	v.SetDefault("server.write_timeout", 30*time.Second)
+	v.SetDefault("server.idle_timeout", 120*time.Second)
+	v.SetDefault("server.max_header_bytes", 64<<10)
 	v.SetDefault("server.shutdown_timeout", 30*time.Second)
+	v.SetDefault("server.request_timeout", 30*time.Second)
+	v.SetDefault("server.body_limit.default", 1<<20)
+	v.SetDefault("server.body_limit.groups.import", 4<<30)
+	v.SetDefault("server.strict_json", true)
+	v.SetDefault("server.strict_includes", false)
+	v.SetDefault("server.problem_details", false)
 	v.SetDefault("server.cors.allow_origins", []string{"*"})
-	v.SetDefault("server.cors.allow_methods", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"})
+	v.SetDefault("server.cors.allow_methods", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"})
 	v.SetDefault("server.cors.allow_headers", []string{"*"})
+	v.SetDefault("server.cors.expose_headers", []string{})
+	v.SetDefault("server.cors.max_age", 10*time.Minute)
+	v.SetDefault("server.cors.allow_credentials", false)
+	v.SetDefault("server.events.max_streams", 100)
+	v.SetDefault("server.events.heartbeat_interval", 15*time.Second)
+	v.SetDefault("server.events.buffer_size", 16)
+	TLSDefaults(v)
+	v.SetDefault("server.compression.enabled", true)
+	v.SetDefault("server.compression.min_size", 1024)
+	v.SetDefault("server.compression.level", -1)
+	v.SetDefault("server.compression.content_types", []string{"application/json", "application/problem+json", "text/csv"})
+	v.SetDefault("server.list_cache.enabled", true)
+	v.SetDefault("server.list_cache.default_max_age", 0)
 
 	DatabaseDefaults(v)
 
+	MongoDefaults(v)
+
 	v.SetDefault("security.rate_limit_rps", 100)
 	v.SetDefault("security.rate_limit_burst", 200)
+	v.SetDefault("security.rate_limit_reads.rps", 200)
+	v.SetDefault("security.rate_limit_reads.burst", 400)
+	v.SetDefault("security.rate_limit_writes.rps", 50)
+	v.SetDefault("security.rate_limit_writes.burst", 100)
 
 	DefaultLogger(v)
+
+	OrdersDefaults(v)
+
+	CacheDefaults(v)
+
+	WorkersDefaults(v)
+
+	KafkaDefaults(v)
+
+	CatalogDefaults(v)
+
+	InventoryDefaults(v)
+
+	PaymentsDefaults(v)
+
+	WebhooksDefaults(v)
+
+	DocumentsDefaults(v)
 }
@@ -1,25 +1,78 @@
 package config
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
+// The database drivers the persistence layer can open
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// The stores the orders can be kept in
+const (
+	OrderStoreGorm  = "gorm"
+	OrderStoreMongo = "mongo"
+)
+
 type DatabaseConfig struct {
-	Host         string        `mapstructure:"host"`
-	Port         string        `mapstructure:"port"`
-	Username     string        `mapstructure:"username"`
-	Password     string        `mapstructure:"password"`
-	Database     string        `mapstructure:"database"`
-	SSLMode      string        `mapstructure:"ssl_mode"`
-	MaxOpenConns int           `mapstructure:"max_open_conns"`
-	MaxIdleConns int           `mapstructure:"max_idle_conns"`
-	MaxLifetime  time.Duration `mapstructure:"max_lifetime"`
+	// Driver is postgres or sqlite. SQLite opens DSN, a file path or :memory:, and migrates its schema
+	// on boot, the connection settings below apply to Postgres only.
+	Driver string `mapstructure:"driver"`
+	DSN    string `mapstructure:"dsn"`
+
+	// OrderStore is gorm to keep the orders in the database above or mongo to keep them in MongoDB, see
+	// MongoConfig. The other records stay in the database above, order writes made in MongoDB do not
+	// join its transactions.
+	OrderStore string `mapstructure:"order_store"`
+
+	Host     string `mapstructure:"host"`
+	Port     string `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	Database string `mapstructure:"database"`
+	SSLMode  string `mapstructure:"ssl_mode"`
+
+	// Connection pool, zero leaves the database/sql default in place
+	MaxOpenConns    int           `mapstructure:"max_open_conns"`
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"`
+
+	// Queries slower than SlowQueryThreshold are logged as warnings, zero disables the check.
+	// LogLevel is one of silent, error, warn or info and falls back to the top level log_level when empty.
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"`
+	LogLevel           string        `mapstructure:"log_level"`
+
+	// ItemBatchSize caps the order items written per INSERT statement
+	ItemBatchSize int `mapstructure:"item_batch_size"`
+
+	// Transient read failures are retried with jittered exponential backoff, 1 attempt disables retries
+	RetryMaxAttempts int           `mapstructure:"retry_max_attempts"`
+	RetryBaseDelay   time.Duration `mapstructure:"retry_base_delay"`
+	RetryMaxDelay    time.Duration `mapstructure:"retry_max_delay"`
+}
+
+// SetURL selects the database from a driver:dsn string such as sqlite::memory: or sqlite:orders.db
+func (c *DatabaseConfig) SetURL(url string) error {
+	driver, dsn, ok := strings.Cut(url, ":")
+	if !ok || dsn == "" {
+		return fmt.Errorf("database %q is not of the form driver:dsn", url)
+	}
+	c.Driver = driver
+	c.DSN = dsn
+	return nil
 }
 
 func DatabaseDefaults(v *viper.Viper) {
 	// Database defaults
+	v.SetDefault("database.driver", DriverPostgres)
+	v.SetDefault("database.order_store", OrderStoreGorm)
 	v.SetDefault("database.host", "localhost")
 	v.SetDefault("database.port", "5432")
 	v.SetDefault("database.username", "orders-service")
@@ -28,5 +81,12 @@ func DatabaseDefaults(v *viper.Viper) {
 	v.SetDefault("database.ssl_mode", "disable")
 	v.SetDefault("database.max_open_conns", 25)
 	v.SetDefault("database.max_idle_conns", 25)
-	v.SetDefault("database.max_lifetime", 5*time.Minute)
+	v.SetDefault("database.conn_max_lifetime", 5*time.Minute)
+	v.SetDefault("database.conn_max_idle_time", time.Minute)
+	v.SetDefault("database.slow_query_threshold", 200*time.Millisecond)
+	v.SetDefault("database.log_level", "warn")
+	v.SetDefault("database.item_batch_size", 100)
+	v.SetDefault("database.retry_max_attempts", 3)
+	v.SetDefault("database.retry_base_delay", 50*time.Millisecond)
+	v.SetDefault("database.retry_max_delay", time.Second)
 }
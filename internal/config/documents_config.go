@@ -0,0 +1,36 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+)
+
+// DocumentsConfig shapes the printable order documents of GET /orders/:id/document
+type DocumentsConfig struct {
+	// Template names one of the layouts built into the binary, standard or compact
+	Template string        `mapstructure:"template"`
+	Company  CompanyConfig `mapstructure:"company"`
+}
+
+// CompanyConfig brands the documents with the details of the seller, empty fields are left out
+type CompanyConfig struct {
+	Name string `mapstructure:"name"`
+	// Address is printed as is, line breaks included
+	Address string `mapstructure:"address"`
+	Email   string `mapstructure:"email"`
+	Phone   string `mapstructure:"phone"`
+	Website string `mapstructure:"website"`
+	// LogoURL is loaded by the browser or the PDF renderer opening the document
+	LogoURL string `mapstructure:"logo_url"`
+	Footer  string `mapstructure:"footer"`
+}
+
+func DocumentsDefaults(v *viper.Viper) {
+	v.SetDefault("documents.template", "standard")
+	v.SetDefault("documents.company.name", "")
+	v.SetDefault("documents.company.address", "")
+	v.SetDefault("documents.company.email", "")
+	v.SetDefault("documents.company.phone", "")
+	v.SetDefault("documents.company.website", "")
+	v.SetDefault("documents.company.logo_url", "")
+	v.SetDefault("documents.company.footer", "")
+}
@@ -0,0 +1,226 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"orders-service/pkg/logger"
+)
+
+// dynamicKeys are the settings a reload applies to the running service, a key also covers the keys below it.
+// Every other setting is read once on startup and needs a restart to change.
+var dynamicKeys = []string{
+	"logging.level",
+	"logging.components",
+	"security.rate_limit_rps",
+	"security.rate_limit_burst",
+	"security.rate_limit_reads",
+	"security.rate_limit_writes",
+	"orders.max_page_size",
+}
+
+// DynamicSettings are the settings that can change while the service runs, see Reloader
+type DynamicSettings struct {
+	LogLevel string
+	// ComponentLogLevels overrides LogLevel per component
+	ComponentLogLevels map[string]string
+
+	// RateLimitReads and RateLimitWrites have the security.rate_limit_rps and burst fallbacks applied
+	RateLimitReads  RateLimitConfig
+	RateLimitWrites RateLimitConfig
+
+	MaxPageSize int
+}
+
+// Dynamic holds the current dynamic settings. Components read them through it on every use instead
+// of copying them on construction, so a reload takes effect right away. It is safe for concurrent use.
+type Dynamic struct {
+	current atomic.Pointer[DynamicSettings]
+}
+
+func newDynamic(c *Config) *Dynamic {
+	d := &Dynamic{}
+	settings := c.dynamicSettings()
+	d.current.Store(&settings)
+	return d
+}
+
+// Settings returns the current dynamic settings
+func (d *Dynamic) Settings() DynamicSettings {
+	return *d.current.Load()
+}
+
+// RateLimitReads returns the current requests per second and burst of safe requests
+func (d *Dynamic) RateLimitReads() (rps, burst int) {
+	limit := d.current.Load().RateLimitReads
+	return limit.RPS, limit.Burst
+}
+
+// RateLimitWrites returns the current requests per second and burst of the other requests
+func (d *Dynamic) RateLimitWrites() (rps, burst int) {
+	limit := d.current.Load().RateLimitWrites
+	return limit.RPS, limit.Burst
+}
+
+// MaxPageSize returns the current largest page size of the list endpoints
+func (d *Dynamic) MaxPageSize() int {
+	return d.current.Load().MaxPageSize
+}
+
+// Dynamic returns the dynamic settings of a loaded configuration, which a Reloader updates. A
+// configuration built in code gets a snapshot of its own settings that never changes.
+func (c *Config) Dynamic() *Dynamic {
+	if c.dynamic == nil {
+		return newDynamic(c)
+	}
+	return c.dynamic
+}
+
+func (c *Config) dynamicSettings() DynamicSettings {
+	return DynamicSettings{
+		LogLevel:           c.Logging.Level,
+		ComponentLogLevels: maps.Clone(c.Logging.Components),
+		RateLimitReads:     c.Security.rateLimit(c.Security.RateLimitReads),
+		RateLimitWrites:    c.Security.rateLimit(c.Security.RateLimitWrites),
+		MaxPageSize:        c.Orders.MaxPageSize,
+	}
+}
+
+// rateLimit fills the zero values of limit with the global rate limit
+func (c SecurityConfig) rateLimit(limit RateLimitConfig) RateLimitConfig {
+	if limit.RPS <= 0 {
+		limit.RPS = c.RateLimitRPS
+	}
+	if limit.Burst <= 0 {
+		limit.Burst = c.RateLimitBurst
+	}
+	return limit
+}
+
+// Reloader re-reads the configuration file and applies its dynamic settings to a running service
+type Reloader struct {
+	mu         sync.Mutex
+	configFile string
+	env        string
+	current    Config
+	dynamic    *Dynamic
+	onReload   []func(DynamicSettings)
+	logger     logger.Logger
+}
+
+// NewReloader creates a reloader of cfg, which must come from Load with the same file and environment
+func NewReloader(cfg *Config, configFile, env string, log logger.Logger) *Reloader {
+	if cfg.dynamic == nil {
+		cfg.dynamic = newDynamic(cfg)
+	}
+
+	current := *cfg
+	current.dynamic = nil
+	return &Reloader{
+		configFile: configFile,
+		env:        env,
+		current:    current,
+		dynamic:    cfg.dynamic,
+		logger:     log.With("component", "config_reloader"),
+	}
+}
+
+// OnReload registers fn to run with the new settings after a reload changed any of them, for components
+// such as the logger that cannot read them on every use
+func (r *Reloader) OnReload(fn func(DynamicSettings)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onReload = append(r.onReload, fn)
+}
+
+// Reload reads the configuration again and applies the changed dynamic settings. An invalid file is
+// rejected as a whole, changes of other settings are logged and ignored until the next restart.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next, err := Load(r.configFile, r.env)
+	if err != nil {
+		r.logger.Error("Configuration reload failed", "error", err)
+		return err
+	}
+	if err := next.Validate(); err != nil {
+		r.logger.Error("Reloaded configuration is invalid, keeping the current one", "error", err)
+		return err
+	}
+
+	applied := 0
+	for _, change := range diffSettings("", reflect.ValueOf(r.current), reflect.ValueOf(*next)) {
+		if !isDynamicKey(change.key) {
+			r.logger.Warn("Setting changed but requires a restart, ignored", "key", change.key)
+			continue
+		}
+		r.logger.Info("Setting reloaded", "key", change.key, "old", change.old, "new", change.new)
+		applied++
+	}
+	if applied == 0 {
+		r.logger.Info("Configuration reloaded, no dynamic setting changed")
+		return nil
+	}
+
+	r.current.Logging.Level = next.Logging.Level
+	r.current.Logging.Components = next.Logging.Components
+	r.current.Security.RateLimitRPS = next.Security.RateLimitRPS
+	r.current.Security.RateLimitBurst = next.Security.RateLimitBurst
+	r.current.Security.RateLimitReads = next.Security.RateLimitReads
+	r.current.Security.RateLimitWrites = next.Security.RateLimitWrites
+	r.current.Orders.MaxPageSize = next.Orders.MaxPageSize
+
+	settings := r.current.dynamicSettings()
+	r.dynamic.current.Store(&settings)
+	for _, fn := range r.onReload {
+		fn(settings)
+	}
+	return nil
+}
+
+func isDynamicKey(key string) bool {
+	for _, dynamic := range dynamicKeys {
+		if key == dynamic || strings.HasPrefix(key, dynamic+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// settingChange is a setting whose value differs between two configurations
+type settingChange struct {
+	key      string
+	old, new interface{}
+}
+
+// diffSettings lists the settings of the config structs a and b that differ, named by their mapstructure keys
+func diffSettings(prefix string, a, b reflect.Value) []settingChange {
+	var changes []settingChange
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+		tag := field.Tag.Get("mapstructure")
+		if tag == "" || !field.IsExported() {
+			continue
+		}
+
+		key := tag
+		if prefix != "" {
+			key = fmt.Sprintf("%s.%s", prefix, tag)
+		}
+
+		av, bv := a.Field(i), b.Field(i)
+		if av.Kind() == reflect.Struct && field.Type.PkgPath() == a.Type().PkgPath() {
+			changes = append(changes, diffSettings(key, av, bv)...)
+			continue
+		}
+		if !reflect.DeepEqual(av.Interface(), bv.Interface()) {
+			changes = append(changes, settingChange{key: key, old: av.Interface(), new: bv.Interface()})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"orders-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reloadConfig = `
+server:
+  port: "8100"
+security:
+  rate_limit_rps: 100
+  rate_limit_burst: 200
+  rate_limit_writes:
+    rps: 50
+logging:
+  level: info
+`
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestReloader_AppliesDynamicSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, reloadConfig)
+	cfg, err := Load(path, "test")
+	require.NoError(t, err)
+
+	dynamic := cfg.Dynamic()
+	rps, burst := dynamic.RateLimitWrites()
+	assert.Equal(t, 50, rps)
+	assert.Equal(t, 100, burst)
+
+	reloader := NewReloader(cfg, path, "test", logger.New("test"))
+	var reloaded []DynamicSettings
+	reloader.OnReload(func(settings DynamicSettings) { reloaded = append(reloaded, settings) })
+
+	writeConfig(t, path, `
+server:
+  port: "9999"
+security:
+  rate_limit_rps: 100
+  rate_limit_burst: 200
+  rate_limit_writes:
+    rps: 5
+    burst: 10
+logging:
+  level: debug
+  components:
+    gorm: warn
+orders:
+  max_page_size: 25
+`)
+	require.NoError(t, reloader.Reload())
+
+	rps, burst = dynamic.RateLimitWrites()
+	assert.Equal(t, 5, rps)
+	assert.Equal(t, 10, burst)
+	assert.Equal(t, 25, dynamic.MaxPageSize())
+	assert.Equal(t, "debug", dynamic.Settings().LogLevel)
+	assert.Equal(t, map[string]string{"gorm": "warn"}, dynamic.Settings().ComponentLogLevels)
+	require.Len(t, reloaded, 1)
+	assert.Equal(t, "debug", reloaded[0].LogLevel)
+
+	// Settings read on startup keep their value
+	assert.Equal(t, "8100", cfg.Server.Port)
+}
+
+func TestReloader_KeepsSettingsOfInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, reloadConfig)
+	cfg, err := Load(path, "test")
+	require.NoError(t, err)
+	reloader := NewReloader(cfg, path, "test", logger.New("test"))
+
+	writeConfig(t, path, reloadConfig+"\norders:\n  max_page_size: 5000\n")
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, reloader.Reload(), &validationErr)
+	assert.Equal(t, 100, cfg.Dynamic().MaxPageSize())
+}
+
+func TestReloader_IgnoresRestartOnlyChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, reloadConfig)
+	cfg, err := Load(path, "test")
+	require.NoError(t, err)
+	reloader := NewReloader(cfg, path, "test", logger.New("test"))
+	called := false
+	reloader.OnReload(func(DynamicSettings) { called = true })
+
+	writeConfig(t, path, reloadConfig+"\ndatabase:\n  host: other-db\n")
+
+	require.NoError(t, reloader.Reload())
+	assert.False(t, called)
+}
+
+func TestDiffSettings(t *testing.T) {
+	a, b := loadDefaults(t), loadDefaults(t)
+	b.Database.Host = "other"
+	b.Security.RateLimitReads.RPS = 1
+
+	var keys []string
+	for _, change := range diffSettings("", reflect.ValueOf(*a), reflect.ValueOf(*b)) {
+		keys = append(keys, change.key)
+	}
+
+	assert.Equal(t, []string{"database.host", "security.rate_limit_reads.rps"}, keys)
+	assert.False(t, isDynamicKey("database.host"))
+	assert.True(t, isDynamicKey("security.rate_limit_reads.rps"))
+}
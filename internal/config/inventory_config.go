@@ -0,0 +1,37 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// The ways orders are confirmed while the circuit breaker of the inventory is open
+const (
+	InventoryOpenCircuitFailFast = "fail_fast"
+	InventoryOpenCircuitSkip     = "skip"
+)
+
+// InventoryConfig configures the inventory reserving stock for confirmed orders, every line is reserved in
+// full without a base URL. Reservations are not idempotent and are never retried.
+type InventoryConfig struct {
+	BaseURL string `mapstructure:"base_url"`
+	// Timeout bounds a reservation
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// BreakerThreshold consecutive failures stop calls to the inventory for BreakerCooldown, 0 disables the breaker
+	BreakerThreshold int           `mapstructure:"breaker_threshold"`
+	BreakerCooldown  time.Duration `mapstructure:"breaker_cooldown"`
+
+	// OpenCircuitPolicy is fail_fast to reject confirmations while the breaker is open, or skip to confirm
+	// the orders without a reservation, every line backordered and the order flagged reservation_skipped
+	OpenCircuitPolicy string `mapstructure:"open_circuit_policy"`
+}
+
+func InventoryDefaults(v *viper.Viper) {
+	v.SetDefault("inventory.base_url", "")
+	v.SetDefault("inventory.timeout", 2*time.Second)
+	v.SetDefault("inventory.breaker_threshold", 5)
+	v.SetDefault("inventory.breaker_cooldown", 30*time.Second)
+	v.SetDefault("inventory.open_circuit_policy", InventoryOpenCircuitFailFast)
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// KafkaConfig configures the consumer ingesting orders published by upstream systems, started by the worker command
+type KafkaConfig struct {
+	Enabled bool     `mapstructure:"enabled"`
+	Brokers []string `mapstructure:"brokers"`
+	GroupID string   `mapstructure:"group_id"`
+	// Topic carries create order requests as JSON, keyed by the upstream order ID
+	Topic string `mapstructure:"topic"`
+	// DeadLetterTopic receives messages that could not be ingested
+	DeadLetterTopic string `mapstructure:"dead_letter_topic"`
+
+	// MaxAttempts is how often a message is tried before it is dead-lettered, invalid messages are dead-lettered right away
+	MaxAttempts  int           `mapstructure:"max_attempts"`
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+}
+
+func KafkaDefaults(v *viper.Viper) {
+	v.SetDefault("kafka.enabled", false)
+	v.SetDefault("kafka.brokers", []string{"localhost:9092"})
+	v.SetDefault("kafka.group_id", "orders-service")
+	v.SetDefault("kafka.topic", "marketplace.orders")
+	v.SetDefault("kafka.dead_letter_topic", "marketplace.orders.dlq")
+	v.SetDefault("kafka.max_attempts", 5)
+	v.SetDefault("kafka.retry_backoff", time.Second)
+}
@@ -5,6 +5,10 @@ import "github.com/spf13/viper"
 type LoggingConfig struct {
 	Level  string `mapstructure:"level"`
 	Format string `mapstructure:"format"`
+
+	// Components overrides Level for the loggers of a component, keyed on the component field of
+	// their entries such as order_repository
+	Components map[string]string `mapstructure:"components"`
 }
 
 func DefaultLogger(v *viper.Viper) {
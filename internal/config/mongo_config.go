@@ -0,0 +1,25 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// MongoConfig configures the MongoDB database holding the orders when database.order_store is mongo
+type MongoConfig struct {
+	URI      string `mapstructure:"uri"`
+	Database string `mapstructure:"database"`
+	// ConnectTimeout bounds connecting and the ping made on boot
+	ConnectTimeout time.Duration `mapstructure:"connect_timeout"`
+	// CustomerLockLease is how long a customer lock is held before another caller may take it over,
+	// it must outlast the longest write made under the lock
+	CustomerLockLease time.Duration `mapstructure:"customer_lock_lease"`
+}
+
+func MongoDefaults(v *viper.Viper) {
+	v.SetDefault("mongo.uri", "mongodb://localhost:27017")
+	v.SetDefault("mongo.database", "orders-service")
+	v.SetDefault("mongo.connect_timeout", 10*time.Second)
+	v.SetDefault("mongo.customer_lock_lease", 30*time.Second)
+}
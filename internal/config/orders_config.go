@@ -0,0 +1,84 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type OrdersConfig struct {
+	ExportMaxRows   int `mapstructure:"export_max_rows"`
+	ExportBatchSize int `mapstructure:"export_batch_size"`
+
+	// Order imports read at most ImportMaxLines lines, ImportBatchSize per transaction, and log their
+	// progress every ImportProgressEvery lines
+	ImportMaxLines      int `mapstructure:"import_max_lines"`
+	ImportBatchSize     int `mapstructure:"import_batch_size"`
+	ImportProgressEvery int `mapstructure:"import_progress_every"`
+
+	// MaxPageSize caps the page size of the list endpoints, it can be changed by a reload
+	MaxPageSize int `mapstructure:"max_page_size"`
+
+	// MaxPendingPerCustomer caps the open pending orders of a customer, 0 disables the limit
+	MaxPendingPerCustomer int `mapstructure:"max_pending_per_customer"`
+
+	// Size caps of a single order, 0 disables a cap
+	MaxItemsPerOrder   int     `mapstructure:"max_items_per_order"`
+	MaxQuantityPerItem int     `mapstructure:"max_quantity_per_item"`
+	MaxOrderTotal      float64 `mapstructure:"max_order_total"`
+	MaxUnitWeightGrams int     `mapstructure:"max_unit_weight_grams"`
+	MaxUnitPrice       float64 `mapstructure:"max_unit_price"`
+
+	// MinOrderAmount is the smallest total an order can be confirmed with, 0 disables the minimum.
+	// Orders tagged sample are exempt and admins may override it.
+	MinOrderAmount float64 `mapstructure:"min_order_amount"`
+
+	// PendingTTL is how long an order may stay pending before it expires, 0 disables expiry
+	PendingTTL time.Duration `mapstructure:"pending_ttl"`
+
+	// DuplicateWindow is how long after creating an order a customer is asked to confirm another order
+	// with the same items, 0 disables the check
+	DuplicateWindow time.Duration `mapstructure:"duplicate_window"`
+
+	// DBTimeout bounds every repository call of the use cases, 0 leaves calls bounded by the request timeout only
+	DBTimeout time.Duration `mapstructure:"db_timeout"`
+
+	// AuditBufferSize is how many audit entries may wait for the writer before new ones are dropped
+	AuditBufferSize int `mapstructure:"audit_buffer_size"`
+
+	// Number shapes the human friendly numbers given to new orders
+	Number OrderNumberConfig `mapstructure:"number"`
+}
+
+// OrderNumberConfig describes order numbers such as ORD-2025-000123
+type OrderNumberConfig struct {
+	Prefix      string `mapstructure:"prefix"`
+	IncludeYear bool   `mapstructure:"include_year"`
+	// Suffix is "sequence" for consecutive numbers or "random" for numbers that hide the order volume
+	Suffix string `mapstructure:"suffix"`
+	Digits int    `mapstructure:"digits"`
+}
+
+func OrdersDefaults(v *viper.Viper) {
+	v.SetDefault("orders.export_max_rows", 100000)
+	v.SetDefault("orders.export_batch_size", 500)
+	v.SetDefault("orders.import_max_lines", 5000000)
+	v.SetDefault("orders.import_batch_size", 500)
+	v.SetDefault("orders.import_progress_every", 10000)
+	v.SetDefault("orders.max_page_size", 100)
+	v.SetDefault("orders.max_pending_per_customer", 10)
+	v.SetDefault("orders.max_items_per_order", 100)
+	v.SetDefault("orders.max_quantity_per_item", 10000)
+	v.SetDefault("orders.max_order_total", 1000000)
+	v.SetDefault("orders.max_unit_weight_grams", 100000)
+	v.SetDefault("orders.max_unit_price", 1000000)
+	v.SetDefault("orders.min_order_amount", 0)
+	v.SetDefault("orders.pending_ttl", 72*time.Hour)
+	v.SetDefault("orders.duplicate_window", 2*time.Minute)
+	v.SetDefault("orders.audit_buffer_size", 1000)
+	v.SetDefault("orders.db_timeout", 5*time.Second)
+	v.SetDefault("orders.number.prefix", "ORD")
+	v.SetDefault("orders.number.include_year", true)
+	v.SetDefault("orders.number.suffix", "sequence")
+	v.SetDefault("orders.number.digits", 6)
+}
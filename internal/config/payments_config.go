@@ -0,0 +1,38 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// PaymentsConfig configures the payment gateway authorizing orders on confirmation and settling them
+// when they ship or are cancelled, orders are confirmed without a payment without a base URL
+type PaymentsConfig struct {
+	BaseURL string `mapstructure:"base_url"`
+	// Currency is the ISO 4217 code of the order amounts
+	Currency string `mapstructure:"currency"`
+	// Timeout bounds every attempt of a request
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Failed captures and voids are retried with jittered exponential backoff, authorizations never are.
+	// 1 attempt disables retries.
+	RetryMaxAttempts int           `mapstructure:"retry_max_attempts"`
+	RetryBaseDelay   time.Duration `mapstructure:"retry_base_delay"`
+	RetryMaxDelay    time.Duration `mapstructure:"retry_max_delay"`
+
+	// BreakerThreshold consecutive failures stop calls to the gateway for BreakerCooldown, 0 disables the breaker
+	BreakerThreshold int           `mapstructure:"breaker_threshold"`
+	BreakerCooldown  time.Duration `mapstructure:"breaker_cooldown"`
+}
+
+func PaymentsDefaults(v *viper.Viper) {
+	v.SetDefault("payments.base_url", "")
+	v.SetDefault("payments.currency", "USD")
+	v.SetDefault("payments.timeout", 5*time.Second)
+	v.SetDefault("payments.retry_max_attempts", 3)
+	v.SetDefault("payments.retry_base_delay", 200*time.Millisecond)
+	v.SetDefault("payments.retry_max_delay", 2*time.Second)
+	v.SetDefault("payments.breaker_threshold", 5)
+	v.SetDefault("payments.breaker_cooldown", 30*time.Second)
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+)
+
+// TLSConfig serves the API over TLS and HTTP/2 once CertFile is set, the files are read again on SIGHUP
+type TLSConfig struct {
+	CertFile string `mapstructure:"cert_file"`
+	KeyFile  string `mapstructure:"key_file"`
+	// ClientCAFile requires clients to present a certificate signed by one of its CAs (mTLS)
+	ClientCAFile string `mapstructure:"client_ca_file"`
+	// MinVersion is the oldest TLS version accepted, 1.2 or 1.3
+	MinVersion string `mapstructure:"min_version"`
+	// HealthPort also serves the health endpoints over plain HTTP for probes that cannot speak TLS, empty disables it
+	HealthPort string `mapstructure:"health_port"`
+}
+
+// Enabled reports whether the API is served over TLS
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != ""
+}
+
+func TLSDefaults(v *viper.Viper) {
+	v.SetDefault("server.tls.cert_file", "")
+	v.SetDefault("server.tls.key_file", "")
+	v.SetDefault("server.tls.client_ca_file", "")
+	v.SetDefault("server.tls.min_version", "1.2")
+	v.SetDefault("server.tls.health_port", "")
+}
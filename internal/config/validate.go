@@ -0,0 +1,446 @@
+package config
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Problem is a setting rejected by Validate, Key is its dotted path such as database.host
+type Problem struct {
+	Key     string
+	Message string
+}
+
+// ValidationError lists every problem Validate found
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid configuration, %d problem(s):", len(e.Problems))
+	for _, p := range e.Problems {
+		fmt.Fprintf(&b, "\n  - %s: %s", p.Key, p.Message)
+	}
+	return b.String()
+}
+
+// validator collects the problems of a configuration
+type validator struct {
+	problems []Problem
+}
+
+func (v *validator) add(key, format string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) required(key, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(key, "is required")
+	}
+}
+
+func (v *validator) port(key, value string) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		v.add(key, "must be a port between 1 and 65535, got %q", value)
+	}
+}
+
+func (v *validator) nonNegative(key string, value int) {
+	if value < 0 {
+		v.add(key, "must not be negative, got %d", value)
+	}
+}
+
+func (v *validator) positive(key string, value int) {
+	if value <= 0 {
+		v.add(key, "must be greater than 0, got %d", value)
+	}
+}
+
+func (v *validator) nonNegativeDuration(key string, value time.Duration) {
+	if value < 0 {
+		v.add(key, "must not be negative, got %s", value)
+	}
+}
+
+func (v *validator) positiveDuration(key string, value time.Duration) {
+	if value <= 0 {
+		v.add(key, "must be greater than 0, got %s", value)
+	}
+}
+
+func (v *validator) oneOf(key, value string, allowed ...string) {
+	if !slices.Contains(allowed, value) {
+		v.add(key, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+	}
+}
+
+// Validate checks the settings the enabled features need, such as the database connection, the
+// brokers once Kafka is enabled and the ranges of limits. It returns a *ValidationError listing
+// every problem, so a deployment can fix them all at once.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	c.Server.validate(v)
+	c.Database.validate(v)
+	if c.Database.OrderStore == OrderStoreMongo {
+		c.Mongo.validate(v)
+	}
+	c.Security.validate(v)
+	c.Logging.validate(v)
+	c.Orders.validate(v)
+	c.Cache.validate(v)
+	c.Workers.validate(v)
+	c.Kafka.validate(v)
+	c.Catalog.validate(v)
+	c.Inventory.validate(v)
+	c.Payments.validate(v)
+	c.Webhooks.validate(v)
+	c.Documents.validate(v)
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+func (c ServerConfig) validate(v *validator) {
+	v.port("server.port", c.Port)
+	v.nonNegativeDuration("server.read_timeout", c.ReadTimeout)
+	v.nonNegativeDuration("server.read_header_timeout", c.ReadHeaderTimeout)
+	v.nonNegativeDuration("server.write_timeout", c.WriteTimeout)
+	v.nonNegativeDuration("server.idle_timeout", c.IdleTimeout)
+	v.nonNegative("server.max_header_bytes", c.MaxHeaderBytes)
+	v.nonNegativeDuration("server.shutdown_timeout", c.ShutdownTimeout)
+	v.nonNegativeDuration("server.request_timeout", c.RequestTimeout)
+
+	if c.BodyLimit.Default <= 0 {
+		v.add("server.body_limit.default", "must be greater than 0, got %d", c.BodyLimit.Default)
+	}
+	for group, limit := range c.BodyLimit.Groups {
+		if limit <= 0 {
+			v.add("server.body_limit.groups."+group, "must be greater than 0, got %d", limit)
+		}
+	}
+
+	v.nonNegative("server.events.max_streams", c.Events.MaxStreams)
+	v.nonNegative("server.events.buffer_size", c.Events.BufferSize)
+	v.nonNegativeDuration("server.events.heartbeat_interval", c.Events.HeartbeatInterval)
+
+	v.nonNegativeDuration("server.list_cache.default_max_age", c.ListCache.DefaultMaxAge)
+	for list, maxAge := range c.ListCache.MaxAge {
+		v.nonNegativeDuration("server.list_cache.max_age."+list, maxAge)
+	}
+
+	c.CORS.validate(v)
+	c.Compression.validate(v)
+	c.TLS.validate(v, c.Port)
+}
+
+func (c CORSConfig) validate(v *validator) {
+	for i, origin := range c.AllowOrigins {
+		key := fmt.Sprintf("server.cors.allow_origins[%d]", i)
+		if origin == "*" {
+			if c.AllowCredentials {
+				v.add(key, "cannot be * when server.cors.allow_credentials is enabled")
+			}
+			continue
+		}
+		// The host may start with *. to allow its subdomains
+		u, err := url.Parse(strings.Replace(origin, "://*.", "://wildcard.", 1))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" || u.RawQuery != "" {
+			v.add(key, "must be *, an origin such as https://app.example.com or a wildcard such as https://*.example.com, got %q", origin)
+		}
+	}
+	v.nonNegativeDuration("server.cors.max_age", c.MaxAge)
+}
+
+func (c CompressionConfig) validate(v *validator) {
+	if !c.Enabled {
+		return
+	}
+	v.nonNegative("server.compression.min_size", c.MinSize)
+	if c.Level < -1 || c.Level > 9 {
+		v.add("server.compression.level", "must be between 0 and 9, or -1 for the default, got %d", c.Level)
+	}
+	if len(c.ContentTypes) == 0 {
+		v.add("server.compression.content_types", "must list at least one content type when compression is enabled")
+	}
+}
+
+// validate checks the TLS settings, the certificate files themselves are loaded and checked when the server starts
+func (c TLSConfig) validate(v *validator, serverPort string) {
+	if !c.Enabled() {
+		if c.KeyFile != "" || c.ClientCAFile != "" {
+			v.add("server.tls.cert_file", "is required when server.tls.key_file or server.tls.client_ca_file is set")
+		}
+		return
+	}
+	v.required("server.tls.key_file", c.KeyFile)
+	v.oneOf("server.tls.min_version", c.MinVersion, "1.2", "1.3")
+	if c.HealthPort != "" {
+		v.port("server.tls.health_port", c.HealthPort)
+		if c.HealthPort == serverPort {
+			v.add("server.tls.health_port", "must differ from server.port")
+		}
+	}
+}
+
+func (c DatabaseConfig) validate(v *validator) {
+	v.oneOf("database.driver", c.Driver, DriverPostgres, DriverSQLite)
+	v.oneOf("database.order_store", c.OrderStore, OrderStoreGorm, OrderStoreMongo)
+	if c.Driver == DriverSQLite {
+		v.required("database.dsn", c.DSN)
+	} else {
+		v.required("database.host", c.Host)
+		v.port("database.port", c.Port)
+		v.required("database.username", c.Username)
+		v.required("database.database", c.Database)
+		v.oneOf("database.ssl_mode", c.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+	}
+	if c.LogLevel != "" {
+		v.oneOf("database.log_level", c.LogLevel, "silent", "error", "warn", "info")
+	}
+
+	v.nonNegative("database.max_open_conns", c.MaxOpenConns)
+	v.nonNegative("database.max_idle_conns", c.MaxIdleConns)
+	v.nonNegativeDuration("database.conn_max_lifetime", c.ConnMaxLifetime)
+	v.nonNegativeDuration("database.conn_max_idle_time", c.ConnMaxIdleTime)
+	v.nonNegativeDuration("database.slow_query_threshold", c.SlowQueryThreshold)
+	v.nonNegative("database.item_batch_size", c.ItemBatchSize)
+	v.nonNegative("database.retry_max_attempts", c.RetryMaxAttempts)
+	v.nonNegativeDuration("database.retry_base_delay", c.RetryBaseDelay)
+	v.nonNegativeDuration("database.retry_max_delay", c.RetryMaxDelay)
+}
+
+func (c MongoConfig) validate(v *validator) {
+	if u, err := url.Parse(c.URI); err != nil || (u.Scheme != "mongodb" && u.Scheme != "mongodb+srv") || u.Host == "" {
+		v.add("mongo.uri", "must be a mongodb:// or mongodb+srv:// URI, got %q", c.URI)
+	}
+	v.required("mongo.database", c.Database)
+	v.positiveDuration("mongo.connect_timeout", c.ConnectTimeout)
+	v.positiveDuration("mongo.customer_lock_lease", c.CustomerLockLease)
+}
+
+func (c SecurityConfig) validate(v *validator) {
+	v.nonNegative("security.rate_limit_rps", c.RateLimitRPS)
+	v.nonNegative("security.rate_limit_burst", c.RateLimitBurst)
+	v.nonNegative("security.rate_limit_reads.rps", c.RateLimitReads.RPS)
+	v.nonNegative("security.rate_limit_reads.burst", c.RateLimitReads.Burst)
+	v.nonNegative("security.rate_limit_writes.rps", c.RateLimitWrites.RPS)
+	v.nonNegative("security.rate_limit_writes.burst", c.RateLimitWrites.Burst)
+
+	for i, key := range c.APIKeys {
+		prefix := fmt.Sprintf("security.api_keys[%d]", i)
+		v.required(prefix+".name", key.Name)
+		if hash, err := hex.DecodeString(key.Hash); err != nil || len(hash) != 32 {
+			v.add(prefix+".hash", "must be the hex encoded SHA-256 of the key")
+		}
+		if len(key.Scopes) == 0 {
+			v.add(prefix+".scopes", "must list at least one scope")
+		}
+	}
+}
+
+func (c LoggingConfig) validate(v *validator) {
+	v.oneOf("logging.level", c.Level, "debug", "info", "warn", "error")
+	for component, level := range c.Components {
+		v.oneOf("logging.components."+component, level, "debug", "info", "warn", "error")
+	}
+	v.oneOf("logging.format", c.Format, "json", "text")
+}
+
+func (c OrdersConfig) validate(v *validator) {
+	v.nonNegative("orders.export_max_rows", c.ExportMaxRows)
+	v.positive("orders.export_batch_size", c.ExportBatchSize)
+	v.nonNegative("orders.import_max_lines", c.ImportMaxLines)
+	v.positive("orders.import_batch_size", c.ImportBatchSize)
+	v.nonNegative("orders.import_progress_every", c.ImportProgressEvery)
+	// From the default page size to the largest page size the API supports
+	if c.MaxPageSize < 10 || c.MaxPageSize > 100 {
+		v.add("orders.max_page_size", "must be between 10 and 100, got %d", c.MaxPageSize)
+	}
+	v.nonNegative("orders.max_pending_per_customer", c.MaxPendingPerCustomer)
+	v.nonNegative("orders.max_items_per_order", c.MaxItemsPerOrder)
+	v.nonNegative("orders.max_quantity_per_item", c.MaxQuantityPerItem)
+	// Up to the quantity an order line holds at most whatever the limits
+	if c.MaxQuantityPerItem > 1000000 {
+		v.add("orders.max_quantity_per_item", "must be at most 1000000, got %d", c.MaxQuantityPerItem)
+	}
+	v.nonNegative("orders.max_unit_weight_grams", c.MaxUnitWeightGrams)
+	if c.MaxOrderTotal < 0 {
+		v.add("orders.max_order_total", "must not be negative, got %g", c.MaxOrderTotal)
+	}
+	if c.MaxUnitPrice < 0 {
+		v.add("orders.max_unit_price", "must not be negative, got %g", c.MaxUnitPrice)
+	}
+	if c.MinOrderAmount < 0 {
+		v.add("orders.min_order_amount", "must not be negative, got %g", c.MinOrderAmount)
+	}
+	if c.MaxOrderTotal > 0 && c.MinOrderAmount > c.MaxOrderTotal {
+		v.add("orders.min_order_amount", "must not exceed orders.max_order_total")
+	}
+	v.nonNegativeDuration("orders.pending_ttl", c.PendingTTL)
+	v.nonNegativeDuration("orders.duplicate_window", c.DuplicateWindow)
+	v.nonNegativeDuration("orders.db_timeout", c.DBTimeout)
+	v.nonNegative("orders.audit_buffer_size", c.AuditBufferSize)
+
+	v.oneOf("orders.number.suffix", c.Number.Suffix, "sequence", "random")
+	if c.Number.Digits < 0 || c.Number.Digits > 18 {
+		v.add("orders.number.digits", "must be between 0 and 18, got %d", c.Number.Digits)
+	}
+}
+
+func (c CacheConfig) validate(v *validator) {
+	if !c.Enabled {
+		return
+	}
+	v.required("cache.addr", c.Addr)
+	v.nonNegative("cache.db", c.DB)
+	v.positive("cache.pool_size", c.PoolSize)
+	v.positiveDuration("cache.dial_timeout", c.DialTimeout)
+	v.positiveDuration("cache.io_timeout", c.IOTimeout)
+	v.positiveDuration("cache.order_ttl", c.OrderTTL)
+}
+
+func (c WorkersConfig) validate(v *validator) {
+	v.port("workers.health_port", c.HealthPort)
+	v.nonNegativeDuration("workers.shutdown_timeout", c.ShutdownTimeout)
+
+	v.nonNegativeDuration("workers.expiration.interval", c.Expiration.Interval)
+	if c.Expiration.Interval > 0 {
+		v.positive("workers.expiration.batch_size", c.Expiration.BatchSize)
+	}
+	v.nonNegativeDuration("workers.scheduled_transitions.interval", c.ScheduledTransitions.Interval)
+	if c.ScheduledTransitions.Interval > 0 {
+		v.positive("workers.scheduled_transitions.batch_size", c.ScheduledTransitions.BatchSize)
+	}
+	v.positive("workers.order_jobs.queue_size", c.OrderJobs.QueueSize)
+	v.nonNegativeDuration("workers.order_jobs.interval", c.OrderJobs.Interval)
+	if c.OrderJobs.Interval > 0 {
+		v.positive("workers.order_jobs.batch_size", c.OrderJobs.BatchSize)
+	}
+	if c.Lock.Enabled {
+		v.oneOf("workers.lock.backend", c.Lock.Backend, "postgres", "memory")
+		v.positiveDuration("workers.lock.ttl", c.Lock.TTL)
+	}
+}
+
+func (c KafkaConfig) validate(v *validator) {
+	if !c.Enabled {
+		return
+	}
+	if len(c.Brokers) == 0 {
+		v.add("kafka.brokers", "must list at least one broker when kafka is enabled")
+	}
+	for i, broker := range c.Brokers {
+		if strings.TrimSpace(broker) == "" {
+			v.add(fmt.Sprintf("kafka.brokers[%d]", i), "is empty")
+		}
+	}
+	v.required("kafka.group_id", c.GroupID)
+	v.required("kafka.topic", c.Topic)
+	v.required("kafka.dead_letter_topic", c.DeadLetterTopic)
+	if c.Topic != "" && c.Topic == c.DeadLetterTopic {
+		v.add("kafka.dead_letter_topic", "must differ from kafka.topic")
+	}
+	v.positive("kafka.max_attempts", c.MaxAttempts)
+	v.nonNegativeDuration("kafka.retry_backoff", c.RetryBackoff)
+}
+
+func (c CatalogConfig) validate(v *validator) {
+	if c.BaseURL == "" {
+		return
+	}
+	if u, err := url.Parse(c.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add("catalog.base_url", "must be an absolute http or https URL, got %q", c.BaseURL)
+	}
+	v.positiveDuration("catalog.timeout", c.Timeout)
+	v.nonNegative("catalog.retry_max_attempts", c.RetryMaxAttempts)
+	v.nonNegativeDuration("catalog.retry_base_delay", c.RetryBaseDelay)
+	v.nonNegativeDuration("catalog.retry_max_delay", c.RetryMaxDelay)
+	v.nonNegative("catalog.breaker_threshold", c.BreakerThreshold)
+	if c.BreakerThreshold > 0 {
+		v.positiveDuration("catalog.breaker_cooldown", c.BreakerCooldown)
+	}
+}
+
+func (c InventoryConfig) validate(v *validator) {
+	if c.BaseURL == "" {
+		return
+	}
+	if u, err := url.Parse(c.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add("inventory.base_url", "must be an absolute http or https URL, got %q", c.BaseURL)
+	}
+	v.positiveDuration("inventory.timeout", c.Timeout)
+	v.nonNegative("inventory.breaker_threshold", c.BreakerThreshold)
+	if c.BreakerThreshold > 0 {
+		v.positiveDuration("inventory.breaker_cooldown", c.BreakerCooldown)
+	}
+	v.oneOf("inventory.open_circuit_policy", c.OpenCircuitPolicy, InventoryOpenCircuitFailFast, InventoryOpenCircuitSkip)
+}
+
+func (c PaymentsConfig) validate(v *validator) {
+	if c.BaseURL == "" {
+		return
+	}
+	if u, err := url.Parse(c.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add("payments.base_url", "must be an absolute http or https URL, got %q", c.BaseURL)
+	}
+	if len(c.Currency) != 3 {
+		v.add("payments.currency", "must be a 3 letter ISO 4217 code, got %q", c.Currency)
+	}
+	v.positiveDuration("payments.timeout", c.Timeout)
+	v.nonNegative("payments.retry_max_attempts", c.RetryMaxAttempts)
+	v.nonNegativeDuration("payments.retry_base_delay", c.RetryBaseDelay)
+	v.nonNegativeDuration("payments.retry_max_delay", c.RetryMaxDelay)
+	v.nonNegative("payments.breaker_threshold", c.BreakerThreshold)
+	if c.BreakerThreshold > 0 {
+		v.positiveDuration("payments.breaker_cooldown", c.BreakerCooldown)
+	}
+}
+
+func (c WebhooksConfig) validate(v *validator) {
+	seen := make(map[string]bool, len(c.Endpoints))
+	for i, endpoint := range c.Endpoints {
+		prefix := fmt.Sprintf("webhooks.endpoints[%d]", i)
+		v.required(prefix+".id", endpoint.ID)
+		if seen[endpoint.ID] {
+			v.add(prefix+".id", "duplicates the id %q", endpoint.ID)
+		}
+		seen[endpoint.ID] = true
+		if u, err := url.Parse(endpoint.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			v.add(prefix+".url", "must be an absolute http or https URL, got %q", endpoint.URL)
+		}
+		v.required(prefix+".secret", endpoint.Secret)
+	}
+	if len(c.Endpoints) == 0 {
+		return
+	}
+	v.positiveDuration("webhooks.timeout", c.Timeout)
+	v.positive("webhooks.max_attempts", c.MaxAttempts)
+	v.nonNegativeDuration("webhooks.retry_backoff", c.RetryBackoff)
+	v.nonNegative("webhooks.breaker_threshold", c.BreakerThreshold)
+	if c.BreakerThreshold > 0 {
+		v.positiveDuration("webhooks.breaker_cooldown", c.BreakerCooldown)
+	}
+	v.positive("webhooks.buffer_size", c.BufferSize)
+	v.positive("webhooks.replay_batch_size", c.ReplayBatchSize)
+}
+
+func (c DocumentsConfig) validate(v *validator) {
+	v.oneOf("documents.template", c.Template, "standard", "compact")
+	if c.Company.LogoURL == "" {
+		return
+	}
+	if u, err := url.Parse(c.Company.LogoURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add("documents.company.logo_url", "must be an absolute http or https URL, got %q", c.Company.LogoURL)
+	}
+}
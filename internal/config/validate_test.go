@@ -0,0 +1,225 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadDefaults loads the default configuration, no config file exists next to the tests
+func loadDefaults(t *testing.T) *Config {
+	t.Helper()
+	cfg, err := Load("", "test")
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestValidate_ShippedConfigs(t *testing.T) {
+	for _, file := range []string{"../../configs/config.yaml", "../../configs/config-docker.yaml"} {
+		t.Run(file, func(t *testing.T) {
+			cfg, err := Load(file, "test")
+			require.NoError(t, err)
+
+			assert.NoError(t, cfg.Validate())
+		})
+	}
+}
+
+func TestValidate_ListsEveryProblem(t *testing.T) {
+	cfg := loadDefaults(t)
+	cfg.Server.Port = "99999"
+	cfg.Database.Host = ""
+	cfg.Database.SSLMode = "sometimes"
+	cfg.Orders.MaxItemsPerOrder = -1
+	cfg.Workers.Expiration.BatchSize = 0
+	cfg.Catalog.BaseURL = "catalog.internal"
+
+	err := cfg.Validate()
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	var keys []string
+	for _, problem := range validationErr.Problems {
+		keys = append(keys, problem.Key)
+	}
+	assert.ElementsMatch(t, []string{
+		"server.port",
+		"database.host",
+		"database.ssl_mode",
+		"orders.max_items_per_order",
+		"workers.expiration.batch_size",
+		"catalog.base_url",
+	}, keys)
+	assert.Contains(t, err.Error(), "6 problem(s)")
+	assert.Contains(t, err.Error(), "  - database.host: is required")
+}
+
+func TestValidate_SQLiteNeedsOnlyADSN(t *testing.T) {
+	cfg := loadDefaults(t)
+	require.NoError(t, cfg.Database.SetURL("sqlite::memory:"))
+	cfg.Database.Host = ""
+	cfg.Database.SSLMode = "sometimes"
+
+	assert.Equal(t, DriverSQLite, cfg.Database.Driver)
+	assert.Equal(t, ":memory:", cfg.Database.DSN)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Database.DSN = ""
+	assert.ErrorContains(t, cfg.Validate(), "database.dsn: is required")
+	assert.Error(t, cfg.Database.SetURL("sqlite"))
+}
+
+func TestValidate_MongoOrderStore(t *testing.T) {
+	cfg := loadDefaults(t)
+	cfg.Mongo.URI = "localhost:27017"
+	require.NoError(t, cfg.Validate(), "the mongo settings are ignored while orders are kept by GORM")
+
+	cfg.Database.OrderStore = OrderStoreMongo
+	cfg.Mongo.Database = ""
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, cfg.Validate(), &validationErr)
+	assert.Equal(t, []Problem{
+		{Key: "mongo.uri", Message: `must be a mongodb:// or mongodb+srv:// URI, got "localhost:27017"`},
+		{Key: "mongo.database", Message: "is required"},
+	}, validationErr.Problems)
+
+	cfg.Database.OrderStore = "cassandra"
+	assert.ErrorContains(t, cfg.Validate(), "database.order_store")
+}
+
+func TestValidate_ServerTimeouts(t *testing.T) {
+	cfg := loadDefaults(t)
+	assert.Equal(t, 5*time.Second, cfg.Server.ReadHeaderTimeout)
+	assert.Equal(t, 120*time.Second, cfg.Server.IdleTimeout)
+	assert.Equal(t, 64<<10, cfg.Server.MaxHeaderBytes)
+
+	cfg.Server.ReadHeaderTimeout = -time.Second
+	cfg.Server.IdleTimeout = -time.Second
+	cfg.Server.MaxHeaderBytes = -1
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, cfg.Validate(), &validationErr)
+	var keys []string
+	for _, problem := range validationErr.Problems {
+		keys = append(keys, problem.Key)
+	}
+	assert.ElementsMatch(t, []string{"server.read_header_timeout", "server.idle_timeout", "server.max_header_bytes"}, keys)
+}
+
+func TestValidate_ChecksEnabledFeaturesOnly(t *testing.T) {
+	cfg := loadDefaults(t)
+	cfg.Kafka.Brokers = nil
+	cfg.Cache.Addr = ""
+
+	require.NoError(t, cfg.Validate())
+
+	cfg.Kafka.Enabled = true
+	cfg.Cache.Enabled = true
+	cfg.Cache.IOTimeout = -time.Second
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, cfg.Validate(), &validationErr)
+	assert.Equal(t, []Problem{
+		{Key: "cache.addr", Message: "is required"},
+		{Key: "cache.io_timeout", Message: "must be greater than 0, got -1s"},
+		{Key: "kafka.brokers", Message: "must list at least one broker when kafka is enabled"},
+	}, validationErr.Problems)
+}
+
+func TestValidate_APIKeys(t *testing.T) {
+	cfg := loadDefaults(t)
+	cfg.Security.APIKeys = []APIKeyConfig{
+		{Name: "ok", Hash: "df76ff796f70d2c9cb055ea6280553caa27eda26b70e01082c160de75a05a4a9", Scopes: []string{"orders:read"}},
+		{Name: "", Hash: "not-hex", Scopes: nil},
+	}
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, cfg.Validate(), &validationErr)
+	assert.Len(t, validationErr.Problems, 3)
+	assert.Equal(t, "security.api_keys[1].name", validationErr.Problems[0].Key)
+}
+
+func TestValidate_WebhookEndpoints(t *testing.T) {
+	cfg := loadDefaults(t)
+	cfg.Webhooks.Endpoints = []WebhookEndpointConfig{
+		{ID: "partner", URL: "https://partner.example.com/hooks", Secret: "s3cret"},
+		{ID: "partner", URL: "partner.example.com", Secret: ""},
+	}
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, cfg.Validate(), &validationErr)
+	assert.Equal(t, []Problem{
+		{Key: "webhooks.endpoints[1].id", Message: `duplicates the id "partner"`},
+		{Key: "webhooks.endpoints[1].url", Message: `must be an absolute http or https URL, got "partner.example.com"`},
+		{Key: "webhooks.endpoints[1].secret", Message: "is required"},
+	}, validationErr.Problems)
+}
+
+func TestValidate_PaymentGateway(t *testing.T) {
+	cfg := loadDefaults(t)
+	cfg.Payments.BaseURL = "https://payments.internal"
+	require.NoError(t, cfg.Validate())
+
+	cfg.Payments.Currency = "dollars"
+	cfg.Payments.Timeout = 0
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, cfg.Validate(), &validationErr)
+	assert.Equal(t, []Problem{
+		{Key: "payments.currency", Message: `must be a 3 letter ISO 4217 code, got "dollars"`},
+		{Key: "payments.timeout", Message: "must be greater than 0, got 0s"},
+	}, validationErr.Problems)
+}
+
+func TestValidate_InventoryOpenCircuitPolicy(t *testing.T) {
+	cfg := loadDefaults(t)
+	cfg.Inventory.BaseURL = "https://inventory.internal"
+	assert.Equal(t, InventoryOpenCircuitFailFast, cfg.Inventory.OpenCircuitPolicy)
+	require.NoError(t, cfg.Validate())
+
+	cfg.Inventory.OpenCircuitPolicy = InventoryOpenCircuitSkip
+	require.NoError(t, cfg.Validate())
+
+	cfg.Inventory.OpenCircuitPolicy = "retry"
+	assert.ErrorContains(t, cfg.Validate(), "inventory.open_circuit_policy")
+}
+
+func TestValidate_TLS(t *testing.T) {
+	cfg := loadDefaults(t)
+	cfg.Server.TLS.ClientCAFile = "ca.pem"
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, cfg.Validate(), &validationErr)
+	assert.Equal(t, "server.tls.cert_file", validationErr.Problems[0].Key)
+
+	cfg.Server.TLS.CertFile = "server.pem"
+	cfg.Server.TLS.MinVersion = "1.0"
+	cfg.Server.TLS.HealthPort = cfg.Server.Port
+
+	require.ErrorAs(t, cfg.Validate(), &validationErr)
+	assert.Equal(t, []Problem{
+		{Key: "server.tls.key_file", Message: "is required"},
+		{Key: "server.tls.min_version", Message: `must be one of 1.2, 1.3, got "1.0"`},
+		{Key: "server.tls.health_port", Message: "must differ from server.port"},
+	}, validationErr.Problems)
+}
+
+func TestValidate_CORS(t *testing.T) {
+	cfg := loadDefaults(t)
+	cfg.Server.CORS.AllowOrigins = []string{"https://app.example.com", "https://*.example.com", "http://localhost:3000"}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Server.CORS.AllowOrigins = []string{"*", "app.example.com", "https://example.com/path"}
+	cfg.Server.CORS.AllowCredentials = true
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, cfg.Validate(), &validationErr)
+	keys := make([]string, 0, len(validationErr.Problems))
+	for _, problem := range validationErr.Problems {
+		keys = append(keys, problem.Key)
+	}
+	assert.Equal(t, []string{"server.cors.allow_origins[0]", "server.cors.allow_origins[1]", "server.cors.allow_origins[2]"}, keys)
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// WebhooksConfig configures the delivery of order events to partner endpoints. Deliveries failing after
+// MaxAttempts are kept as dead letters and can be replayed by admins.
+type WebhooksConfig struct {
+	Endpoints []WebhookEndpointConfig `mapstructure:"endpoints"`
+	// Timeout bounds a single delivery attempt
+	Timeout      time.Duration `mapstructure:"timeout"`
+	MaxAttempts  int           `mapstructure:"max_attempts"`
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+	// BreakerThreshold consecutive failures stop deliveries to an endpoint host for BreakerCooldown,
+	// 0 disables the breaker. Deliveries rejected by an open breaker go to the dead letters.
+	BreakerThreshold int           `mapstructure:"breaker_threshold"`
+	BreakerCooldown  time.Duration `mapstructure:"breaker_cooldown"`
+	// BufferSize is how many events an endpoint may fall behind before further ones go to its dead letters
+	BufferSize int `mapstructure:"buffer_size"`
+	// ReplayBatchSize caps the dead letters delivered by one bulk replay
+	ReplayBatchSize int `mapstructure:"replay_batch_size"`
+}
+
+// WebhookEndpointConfig describes a partner endpoint. Secret signs the deliveries, Events lists the
+// event types sent to it, all of them when empty.
+type WebhookEndpointConfig struct {
+	ID     string   `mapstructure:"id"`
+	URL    string   `mapstructure:"url"`
+	Secret string   `mapstructure:"secret"`
+	Events []string `mapstructure:"events"`
+}
+
+func WebhooksDefaults(v *viper.Viper) {
+	v.SetDefault("webhooks.timeout", 5*time.Second)
+	v.SetDefault("webhooks.max_attempts", 5)
+	v.SetDefault("webhooks.retry_backoff", time.Second)
+	v.SetDefault("webhooks.breaker_threshold", 5)
+	v.SetDefault("webhooks.breaker_cooldown", 30*time.Second)
+	v.SetDefault("webhooks.buffer_size", 1000)
+	v.SetDefault("webhooks.replay_batch_size", 100)
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// WorkersConfig configures the background jobs run by the worker command
+type WorkersConfig struct {
+	// Embedded also runs the jobs inside the API server, disable it once a separate worker is deployed
+	Embedded bool `mapstructure:"embedded"`
+	// HealthPort serves the liveness endpoint of the worker command
+	HealthPort      string        `mapstructure:"health_port"`
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+
+	Expiration           ExpirationWorkerConfig          `mapstructure:"expiration"`
+	ScheduledTransitions ScheduledTransitionWorkerConfig `mapstructure:"scheduled_transitions"`
+	OrderJobs            OrderJobWorkerConfig            `mapstructure:"order_jobs"`
+	Lock                 WorkerLockConfig                `mapstructure:"lock"`
+}
+
+// WorkerLockConfig keeps the replicas running the jobs from making the same pass at the same time
+type WorkerLockConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Backend is postgres, shared by every replica, or memory for a single replica
+	Backend string `mapstructure:"backend"`
+	// TTL is how long a lock outlives a replica that stopped renewing it, postgres releases the locks
+	// of a replica as soon as its connection closes
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+// ExpirationWorkerConfig configures the job expiring pending orders, orders.pending_ttl sets when they expire
+type ExpirationWorkerConfig struct {
+	// Interval is how often pending orders are checked for expiry, 0 disables the job
+	Interval  time.Duration `mapstructure:"interval"`
+	BatchSize int           `mapstructure:"batch_size"`
+}
+
+// ScheduledTransitionWorkerConfig configures the job executing the order status transitions that became due
+type ScheduledTransitionWorkerConfig struct {
+	// Interval is how often due transitions are executed, 0 disables the job
+	Interval  time.Duration `mapstructure:"interval"`
+	BatchSize int           `mapstructure:"batch_size"`
+}
+
+// OrderJobWorkerConfig configures the job creating the orders submitted to POST /orders/async
+type OrderJobWorkerConfig struct {
+	// QueueSize is how many submitted jobs the in-process queue buffers, the others wait for the sweep
+	QueueSize int `mapstructure:"queue_size"`
+	// Interval is how often the stored jobs are swept for the ones the queue did not deliver, 0 disables
+	// the job. A job is swept once it waited an interval, so the queue gets it first.
+	Interval  time.Duration `mapstructure:"interval"`
+	BatchSize int           `mapstructure:"batch_size"`
+}
+
+func WorkersDefaults(v *viper.Viper) {
+	v.SetDefault("workers.embedded", true)
+	v.SetDefault("workers.health_port", "8081")
+	v.SetDefault("workers.shutdown_timeout", 30*time.Second)
+	v.SetDefault("workers.expiration.interval", time.Minute)
+	v.SetDefault("workers.expiration.batch_size", 100)
+	v.SetDefault("workers.scheduled_transitions.interval", time.Minute)
+	v.SetDefault("workers.scheduled_transitions.batch_size", 100)
+	v.SetDefault("workers.order_jobs.queue_size", 1024)
+	v.SetDefault("workers.order_jobs.interval", 30*time.Second)
+	v.SetDefault("workers.order_jobs.batch_size", 100)
+	v.SetDefault("workers.lock.enabled", true)
+	v.SetDefault("workers.lock.backend", "postgres")
+	v.SetDefault("workers.lock.ttl", 30*time.Second)
+}
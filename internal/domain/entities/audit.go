@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditAction names a mutating operation recorded in the audit log
+type AuditAction string
+
+const (
+	AuditActionOrderCreated        AuditAction = "order.created"
+	AuditActionItemAdded           AuditAction = "order.item_added"
+	AuditActionItemRemoved         AuditAction = "order.item_removed"
+	AuditActionItemQuantityUpdated AuditAction = "order.item_quantity_updated"
+	AuditActionItemsReplaced       AuditAction = "order.items_replaced"
+	AuditActionItemsRepriced       AuditAction = "order.items_repriced"
+	AuditActionItemCancelled       AuditAction = "order.item_cancelled"
+	AuditActionOrderAmended        AuditAction = "order.amended"
+	AuditActionItemFulfilled       AuditAction = "order.item_fulfilled"
+	AuditActionStatusChanged       AuditAction = "order.status_changed"
+	AuditActionPriorityChanged     AuditAction = "order.priority_changed"
+	AuditActionContactChanged      AuditAction = "order.contact_changed"
+	AuditActionOrderDeleted        AuditAction = "order.deleted"
+	AuditActionOrderRestored       AuditAction = "order.restored"
+	AuditActionShipmentCreated     AuditAction = "order.shipment_created"
+	AuditActionShipmentDelivered   AuditAction = "order.shipment_delivered"
+	AuditActionRefunded            AuditAction = "order.refunded"
+)
+
+// AuditEntry records who changed an order, how, and what it looked like before and after.
+// Before is empty for created and restored orders and After is empty for deleted orders.
+type AuditEntry struct {
+	ID        uint            `json:"id"`
+	OrderID   uint            `json:"order_id"`
+	Actor     string          `json:"actor"`
+	Action    AuditAction     `json:"action"`
+	Before    json.RawMessage `json:"before,omitempty"`
+	After     json.RawMessage `json:"after,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
@@ -0,0 +1,196 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxAmendmentReasonLength is the longest reason an amendment can be given
+const MaxAmendmentReasonLength = 500
+
+// Errors returned when the items of a confirmed order are changed
+var (
+	// ErrAmendmentRequired is returned by AddItem, RemoveItem, UpdateItemQuantity and ReplaceItems on a
+	// confirmed order, whose items only change through Amend
+	ErrAmendmentRequired   = errors.New("confirmed orders can only be changed through an amendment")
+	ErrAmendmentNotAllowed = errors.New("only confirmed orders can be amended")
+	ErrInvalidAmendment    = errors.New("invalid amendment")
+)
+
+// ItemChange records how an amendment changed a line, a quantity of 0 before or after marks a line
+// that was added or removed
+type ItemChange struct {
+	ProductID       uint              `json:"product_id"`
+	ProductSKU      string            `json:"product_sku"`
+	ProductName     string            `json:"product_name"`
+	Attributes      map[string]string `json:"attributes,omitempty"`
+	QuantityBefore  int               `json:"quantity_before"`
+	QuantityAfter   int               `json:"quantity_after"`
+	UnitPriceBefore float64           `json:"unit_price_before"`
+	UnitPriceAfter  float64           `json:"unit_price_after"`
+}
+
+// AddedQuantity returns how many units the change added to the line, 0 when it added none
+func (c ItemChange) AddedQuantity() int {
+	return max(c.QuantityAfter-c.QuantityBefore, 0)
+}
+
+// OrderAmendment records a change of the items of a confirmed order and why it was made
+type OrderAmendment struct {
+	// Number counts the amendments of the order from 1
+	Number    int          `json:"number"`
+	Reason    string       `json:"reason"`
+	Changes   []ItemChange `json:"changes"`
+	AmendedAt time.Time    `json:"amended_at"`
+}
+
+// Amend replaces the items of a confirmed order by inputs, validated as by ReplaceItems, and records the
+// lines that changed along with reason in Amendments. Lines are matched by product and attributes. An
+// amendment must change at least one line.
+func (o *Order) Amend(reason string, inputs []OrderItemInput) (*OrderAmendment, error) {
+	if o.Status != OrderStatusConfirmed {
+		return nil, ErrAmendmentNotAllowed
+	}
+	reason = strings.TrimSpace(reason)
+	switch {
+	case reason == "":
+		return nil, fmt.Errorf("%w: a reason is required", ErrInvalidAmendment)
+	case len(reason) > MaxAmendmentReasonLength:
+		return nil, fmt.Errorf("%w: reason must be at most %d characters", ErrInvalidAmendment, MaxAmendmentReasonLength)
+	}
+
+	items, err := buildItems(inputs)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: the order keeps at least one item, cancel the order instead", ErrInvalidAmendment)
+	}
+	changes := itemChanges(o.Items, items)
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("%w: the items are unchanged", ErrInvalidAmendment)
+	}
+
+	// Lines the amendment keeps keep their reservation, up to their new quantity. The units it adds,
+	// new lines included, are backordered until reserved, see ReserveAddedItems.
+	for i := range items {
+		line := duplicateLine(o.Items, items[i].ProductID, items[i].Attributes)
+		if line < 0 {
+			items[i].Backordered = true
+			continue
+		}
+		items[i].ReservedQuantity = o.Items[line].ReservedQuantity
+		items[i].Backordered = o.Items[line].Backordered || items[i].Quantity > o.Items[line].Quantity
+		items[i].resize(items[i].Quantity)
+	}
+	if err := o.setItems(items); err != nil {
+		return nil, err
+	}
+
+	o.AmendmentCount++
+	amendment := OrderAmendment{
+		Number:    o.AmendmentCount,
+		Reason:    reason,
+		Changes:   changes,
+		AmendedAt: o.UpdatedAt.UTC(),
+	}
+	o.Amendments = append(o.Amendments, amendment)
+	return &amendment, nil
+}
+
+// AddedItems returns the units amendment added to the order as an order to reserve them for, holding one
+// line of the added quantity per change that added some. ReserveAddedItems records the reservation.
+func (o *Order) AddedItems(amendment *OrderAmendment) *Order {
+	added := &Order{ID: o.ID, CustomerID: o.CustomerID, Status: o.Status}
+	for _, change := range amendment.Changes {
+		line := duplicateLine(o.Items, change.ProductID, change.Attributes)
+		if change.AddedQuantity() == 0 || line < 0 {
+			continue
+		}
+		item := o.Items[line]
+		item.Attributes = copyAttributes(item.Attributes)
+		item.ReservedQuantity = 0
+		item.Backordered = false
+		item.resize(change.AddedQuantity())
+		added.Items = append(added.Items, item)
+	}
+	return added
+}
+
+// ReserveAddedItems adds the units reserved for the lines of added, as returned by AddedItems, to the
+// lines of the order. Lines reserved short of their quantity are flagged backordered.
+func (o *Order) ReserveAddedItems(added *Order) error {
+	items := o.copyItems()
+	for _, item := range added.Items {
+		line := duplicateLine(items, item.ProductID, item.Attributes)
+		if line < 0 {
+			return fmt.Errorf("%w: product %d is not in the order", ErrInvalidReservation, item.ProductID)
+		}
+		items[line].ReservedQuantity += item.ReservedQuantity
+		items[line].Backordered = items[line].ReservedQuantity < items[line].Quantity
+	}
+	if err := o.setItems(items); err != nil {
+		return err
+	}
+	if added.ReservationSkipped {
+		o.ReservationSkipped = true
+	}
+	return nil
+}
+
+// itemChanges lists the lines whose quantity or unit price differ between before and after, in the
+// order of before followed by the lines only after has
+func itemChanges(before, after []OrderItem) []ItemChange {
+	var changes []ItemChange
+	for _, item := range before {
+		change := ItemChange{
+			ProductID:       item.ProductID,
+			ProductSKU:      item.ProductSKU,
+			ProductName:     item.ProductName,
+			Attributes:      copyAttributes(item.Attributes),
+			QuantityBefore:  item.Quantity,
+			UnitPriceBefore: item.UnitPrice,
+		}
+		if line := duplicateLine(after, item.ProductID, item.Attributes); line >= 0 {
+			change.QuantityAfter = after[line].Quantity
+			change.UnitPriceAfter = after[line].UnitPrice
+			if change.QuantityAfter == change.QuantityBefore && toCents(change.UnitPriceAfter) == toCents(change.UnitPriceBefore) {
+				continue
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	for _, item := range after {
+		if duplicateLine(before, item.ProductID, item.Attributes) >= 0 {
+			continue
+		}
+		changes = append(changes, ItemChange{
+			ProductID:      item.ProductID,
+			ProductSKU:     item.ProductSKU,
+			ProductName:    item.ProductName,
+			Attributes:     copyAttributes(item.Attributes),
+			QuantityAfter:  item.Quantity,
+			UnitPriceAfter: item.UnitPrice,
+		})
+	}
+	return changes
+}
+
+func copyAmendments(amendments []OrderAmendment) []OrderAmendment {
+	if amendments == nil {
+		return nil
+	}
+	copied := make([]OrderAmendment, len(amendments))
+	for i, amendment := range amendments {
+		copied[i] = amendment
+		copied[i].Changes = make([]ItemChange, len(amendment.Changes))
+		for j, change := range amendment.Changes {
+			copied[i].Changes[j] = change
+			copied[i].Changes[j].Attributes = copyAttributes(change.Attributes)
+		}
+	}
+	return copied
+}
@@ -0,0 +1,178 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrder_Amend_RecordsChanges(t *testing.T) {
+	order := confirmedOrder(t)
+
+	amendment, err := order.Amend("  customer called  ", []OrderItemInput{
+		{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 3, UnitPrice: 10.0},
+		{ProductID: 3, ProductSKU: "SKU-003", ProductName: "Product 3", Quantity: 1, UnitPrice: 7.5},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, amendment.Number)
+	assert.Equal(t, "customer called", amendment.Reason)
+	assert.Equal(t, order.UpdatedAt.UTC(), amendment.AmendedAt)
+	require.Len(t, amendment.Changes, 3)
+
+	raised := amendment.Changes[0]
+	assert.Equal(t, uint(1), raised.ProductID)
+	assert.Equal(t, 2, raised.QuantityBefore)
+	assert.Equal(t, 3, raised.QuantityAfter)
+	assert.Equal(t, 1, raised.AddedQuantity())
+
+	removed := amendment.Changes[1]
+	assert.Equal(t, uint(2), removed.ProductID)
+	assert.Equal(t, 1, removed.QuantityBefore)
+	assert.Zero(t, removed.QuantityAfter)
+	assert.Zero(t, removed.AddedQuantity())
+
+	added := amendment.Changes[2]
+	assert.Equal(t, uint(3), added.ProductID)
+	assert.Zero(t, added.QuantityBefore)
+	assert.Equal(t, 1, added.QuantityAfter)
+	assert.Equal(t, 7.5, added.UnitPriceAfter)
+
+	assert.Equal(t, 37.5, order.TotalAmount)
+	assert.Equal(t, OrderStatusConfirmed, order.Status)
+	assert.Equal(t, 1, order.AmendmentCount)
+	assert.Equal(t, []OrderAmendment{*amendment}, order.Amendments)
+}
+
+func TestOrder_Amend_RecordsPriceChange(t *testing.T) {
+	order := confirmedOrder(t)
+	_, err := order.Amend("first", []OrderItemInput{
+		{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 10.0},
+	})
+	require.NoError(t, err)
+
+	amendment, err := order.Amend("price match", []OrderItemInput{
+		{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 8.0},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, amendment.Number)
+	require.Len(t, amendment.Changes, 1)
+	assert.Equal(t, 10.0, amendment.Changes[0].UnitPriceBefore)
+	assert.Equal(t, 8.0, amendment.Changes[0].UnitPriceAfter)
+	assert.Equal(t, 16.0, order.TotalAmount)
+	assert.Equal(t, 2, order.AmendmentCount)
+	assert.Len(t, order.Amendments, 2)
+}
+
+func TestOrder_Amend_Rejected(t *testing.T) {
+	unchanged := []OrderItemInput{
+		{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 2, UnitPrice: 10.0},
+		{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 5.0},
+	}
+	changed := []OrderItemInput{
+		{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 1, UnitPrice: 10.0},
+	}
+
+	tests := []struct {
+		name     string
+		prepare  func(order *Order)
+		reason   string
+		inputs   []OrderItemInput
+		expected error
+	}{
+		{
+			name:     "pending order",
+			prepare:  func(order *Order) { order.Status = OrderStatusPending },
+			reason:   "change",
+			inputs:   changed,
+			expected: ErrAmendmentNotAllowed,
+		},
+		{
+			name:     "shipped order",
+			prepare:  func(order *Order) { order.Status = OrderStatusShipped },
+			reason:   "change",
+			inputs:   changed,
+			expected: ErrAmendmentNotAllowed,
+		},
+		{name: "missing reason", reason: "   ", inputs: changed, expected: ErrInvalidAmendment},
+		{name: "reason too long", reason: strings.Repeat("a", MaxAmendmentReasonLength+1), inputs: changed, expected: ErrInvalidAmendment},
+		{name: "no items", reason: "change", inputs: nil, expected: ErrInvalidAmendment},
+		{name: "unchanged items", reason: "change", inputs: unchanged, expected: ErrInvalidAmendment},
+		{
+			name:   "invalid quantity",
+			reason: "change",
+			inputs: []OrderItemInput{{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 0, UnitPrice: 10.0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := confirmedOrder(t)
+			if tt.prepare != nil {
+				tt.prepare(order)
+			}
+
+			amendment, err := order.Amend(tt.reason, tt.inputs)
+
+			if tt.expected != nil {
+				assert.ErrorIs(t, err, tt.expected)
+			} else {
+				assert.Error(t, err)
+			}
+			assert.Nil(t, amendment)
+			assert.Len(t, order.Items, 2)
+			assert.Equal(t, 25.0, order.TotalAmount)
+			assert.Zero(t, order.AmendmentCount)
+			assert.Empty(t, order.Amendments)
+		})
+	}
+}
+
+func TestOrder_ConfirmedItemChangesRequireAmendment(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(order *Order) error
+	}{
+		{name: "add item", change: func(order *Order) error { return order.AddItem(3, "SKU-003", "Product 3", 1, 1.0) }},
+		{name: "remove item", change: func(order *Order) error { return order.RemoveItem(2) }},
+		{name: "update quantity", change: func(order *Order) error { return order.UpdateItemQuantity(1, 5) }},
+		{
+			name: "replace items",
+			change: func(order *Order) error {
+				return order.ReplaceItems([]OrderItemInput{{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 1, UnitPrice: 10.0}})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := confirmedOrder(t)
+
+			err := tt.change(order)
+
+			assert.ErrorIs(t, err, ErrAmendmentRequired)
+			assert.Len(t, order.Items, 2)
+			assert.Equal(t, 25.0, order.TotalAmount)
+		})
+	}
+}
+
+func TestOrder_Clone_CopiesAmendments(t *testing.T) {
+	order := confirmedOrder(t)
+	_, err := order.Amend("change", []OrderItemInput{
+		{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 1, UnitPrice: 10.0, Attributes: map[string]string{"size": "M"}},
+	})
+	require.NoError(t, err)
+
+	clone := order.Clone()
+	clone.Amendments[0].Reason = "changed"
+	clone.Amendments[0].Changes[0].QuantityAfter = 9
+	clone.Amendments[0].Changes[2].Attributes["size"] = "L"
+
+	assert.Equal(t, "change", order.Amendments[0].Reason)
+	assert.Zero(t, order.Amendments[0].Changes[0].QuantityAfter)
+	assert.Equal(t, "M", order.Amendments[0].Changes[2].Attributes["size"])
+}
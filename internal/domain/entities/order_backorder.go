@@ -0,0 +1,115 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned when reserving or fulfilling the items of an order
+var (
+	ErrInvalidReservation    = errors.New("invalid reservation")
+	ErrFulfillmentNotAllowed = errors.New("only confirmed or processing orders can have backordered items fulfilled")
+	ErrItemNotBackordered    = errors.New("item is not backordered")
+)
+
+// ReserveItems records how many units of each line the inventory reserved, reserved holds one count per
+// line in the order of Items. Lines reserved short of their quantity are flagged backordered.
+func (o *Order) ReserveItems(reserved []int) error {
+	if len(reserved) != len(o.Items) {
+		return fmt.Errorf("%w: %d counts for %d items", ErrInvalidReservation, len(reserved), len(o.Items))
+	}
+
+	items := o.copyItems()
+	for i := range items {
+		items[i].ReservedQuantity = reserved[i]
+		items[i].Backordered = reserved[i] < items[i].Quantity
+	}
+	return o.setItems(items)
+}
+
+// ReserveAllItems reserves every line in full, used when no inventory is consulted
+func (o *Order) ReserveAllItems() error {
+	reserved := make([]int, len(o.Items))
+	for i, item := range o.Items {
+		reserved[i] = item.Quantity
+	}
+	return o.ReserveItems(reserved)
+}
+
+// SkipReservation confirms the lines without a reservation when the inventory could not be asked, every
+// line is backordered and the order flagged ReservationSkipped until its lines are fulfilled
+func (o *Order) SkipReservation() error {
+	if err := o.ReserveItems(make([]int, len(o.Items))); err != nil {
+		return err
+	}
+	o.ReservationSkipped = true
+	return nil
+}
+
+// HasBackorderedItems reports whether any line waits for stock
+func (o *Order) HasBackorderedItems() bool {
+	for _, item := range o.Items {
+		if item.Backordered {
+			return true
+		}
+	}
+	return false
+}
+
+// FulfillItem marks the backordered line of productID fully reserved once its stock arrived, clearing
+// the flag. It returns the line as it was before, whose reserved quantity tells how many units arrived.
+func (o *Order) FulfillItem(productID uint) (*OrderItem, error) {
+	if o.Status != OrderStatusConfirmed && o.Status != OrderStatusProcessing {
+		return nil, ErrFulfillmentNotAllowed
+	}
+
+	items := o.copyItems()
+	line := -1
+	for i := range items {
+		if items[i].ProductID != productID {
+			continue
+		}
+		if line >= 0 {
+			return nil, fmt.Errorf("%w: product has several lines with different attributes", ErrFulfillmentNotAllowed)
+		}
+		line = i
+	}
+	if line < 0 {
+		return nil, ErrItemNotInOrder
+	}
+	if !items[line].Backordered {
+		return nil, ErrItemNotBackordered
+	}
+
+	fulfilled := items[line]
+	items[line].ReservedQuantity = items[line].Quantity
+	items[line].Backordered = false
+	if err := o.setItems(items); err != nil {
+		return nil, err
+	}
+	if !o.HasBackorderedItems() {
+		o.ReservationSkipped = false
+	}
+	return &fulfilled, nil
+}
+
+// resize sets the quantity of the line and its total, keeping the reservation within the quantity.
+// A backordered line the reservation now covers is no longer backordered.
+func (i *OrderItem) resize(quantity int) {
+	i.Quantity = quantity
+	i.TotalPrice = float64(quantity) * i.UnitPrice
+	i.ReservedQuantity = min(i.ReservedQuantity, quantity)
+	if i.ReservedQuantity == quantity {
+		i.Backordered = false
+	}
+}
+
+// checkReservations enforces that no line reserves more units than it orders
+func checkReservations(items []OrderItem) error {
+	for _, item := range items {
+		if item.ReservedQuantity < 0 || item.ReservedQuantity > item.Quantity {
+			return fmt.Errorf("%w: product %d reserves %d of %d units", ErrInvalidReservation, item.ProductID, item.ReservedQuantity, item.Quantity)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrder_ReserveItems_FlagsShortLines(t *testing.T) {
+	order := confirmedOrder(t)
+
+	require.NoError(t, order.ReserveItems([]int{1, 1}))
+
+	assert.Equal(t, 1, order.Items[0].ReservedQuantity)
+	assert.True(t, order.Items[0].Backordered)
+	assert.Equal(t, 1, order.Items[1].ReservedQuantity)
+	assert.False(t, order.Items[1].Backordered)
+	assert.True(t, order.HasBackorderedItems())
+}
+
+func TestOrder_ReserveAllItems(t *testing.T) {
+	order := confirmedOrder(t)
+
+	require.NoError(t, order.ReserveAllItems())
+
+	assert.Equal(t, 2, order.Items[0].ReservedQuantity)
+	assert.Equal(t, 1, order.Items[1].ReservedQuantity)
+	assert.False(t, order.HasBackorderedItems())
+}
+
+func TestOrder_SkipReservation(t *testing.T) {
+	order := confirmedOrder(t)
+
+	require.NoError(t, order.SkipReservation())
+
+	assert.True(t, order.ReservationSkipped)
+	for _, item := range order.Items {
+		assert.Zero(t, item.ReservedQuantity)
+		assert.True(t, item.Backordered)
+	}
+
+	_, err := order.FulfillItem(1)
+	require.NoError(t, err)
+	assert.True(t, order.ReservationSkipped, "product 2 still waits for stock")
+
+	_, err = order.FulfillItem(2)
+	require.NoError(t, err)
+	assert.False(t, order.ReservationSkipped)
+}
+
+func TestOrder_ReserveItems_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		reserved []int
+	}{
+		{name: "missing counts", reserved: []int{2}},
+		{name: "more than ordered", reserved: []int{3, 1}},
+		{name: "negative", reserved: []int{-1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := confirmedOrder(t)
+
+			err := order.ReserveItems(tt.reserved)
+
+			assert.ErrorIs(t, err, ErrInvalidReservation)
+			assert.Zero(t, order.Items[0].ReservedQuantity)
+			assert.False(t, order.HasBackorderedItems())
+		})
+	}
+}
+
+func TestOrder_FulfillItem(t *testing.T) {
+	order := confirmedOrder(t)
+	require.NoError(t, order.ReserveItems([]int{0, 1}))
+
+	before, err := order.FulfillItem(1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, before.ReservedQuantity)
+	assert.True(t, before.Backordered)
+	assert.Equal(t, 2, order.Items[0].ReservedQuantity)
+	assert.False(t, order.Items[0].Backordered)
+	assert.False(t, order.HasBackorderedItems())
+}
+
+func TestOrder_FulfillItem_Rejected(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    OrderStatus
+		productID uint
+		expected  error
+	}{
+		{name: "pending order", status: OrderStatusPending, productID: 1, expected: ErrFulfillmentNotAllowed},
+		{name: "shipped order", status: OrderStatusShipped, productID: 1, expected: ErrFulfillmentNotAllowed},
+		{name: "unknown product", status: OrderStatusConfirmed, productID: 9, expected: ErrItemNotInOrder},
+		{name: "line not backordered", status: OrderStatusConfirmed, productID: 2, expected: ErrItemNotBackordered},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := confirmedOrder(t)
+			require.NoError(t, order.ReserveItems([]int{0, 1}))
+			order.Status = tt.status
+
+			item, err := order.FulfillItem(tt.productID)
+
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Nil(t, item)
+			assert.True(t, order.Items[0].Backordered)
+		})
+	}
+}
+
+func TestOrder_CancelItem_KeepsReservationWithinQuantity(t *testing.T) {
+	order := confirmedOrder(t)
+	require.NoError(t, order.ReserveItems([]int{1, 1}))
+	order.Items[0].Quantity = 3
+	order.Items[0].ReservedQuantity = 2
+
+	_, err := order.CancelItem(1, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, order.Items[0].Quantity)
+	assert.Equal(t, 1, order.Items[0].ReservedQuantity)
+	assert.False(t, order.Items[0].Backordered, "the remaining unit is reserved")
+}
+
+func TestOrder_Amend_KeepsReservations(t *testing.T) {
+	order := confirmedOrder(t)
+	require.NoError(t, order.ReserveItems([]int{1, 1}))
+
+	_, err := order.Amend("change", []OrderItemInput{
+		{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 4, UnitPrice: 10.0},
+		{ProductID: 3, ProductSKU: "SKU-003", ProductName: "Product 3", Quantity: 1, UnitPrice: 5.0},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, order.Items[0].ReservedQuantity)
+	assert.True(t, order.Items[0].Backordered)
+	assert.Zero(t, order.Items[1].ReservedQuantity)
+	assert.True(t, order.Items[1].Backordered, "the new line is not reserved yet")
+}
+
+func TestOrder_Amend_BackordersAddedUnits(t *testing.T) {
+	order := confirmedOrder(t)
+	require.NoError(t, order.ReserveAllItems())
+
+	_, err := order.Amend("change", []OrderItemInput{
+		{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 3, UnitPrice: 10.0},
+		{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 6.0},
+		{ProductID: 3, ProductSKU: "SKU-003", ProductName: "Product 3", Quantity: 2, UnitPrice: 5.0},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, order.Items, 3)
+	assert.Equal(t, 2, order.Items[0].ReservedQuantity)
+	assert.True(t, order.Items[0].Backordered, "the added unit is not reserved yet")
+	assert.Equal(t, 1, order.Items[1].ReservedQuantity)
+	assert.False(t, order.Items[1].Backordered, "a price change adds no units")
+	assert.Zero(t, order.Items[2].ReservedQuantity)
+	assert.True(t, order.Items[2].Backordered)
+	assert.True(t, order.HasBackorderedItems())
+}
+
+func TestOrder_ReserveAddedItems(t *testing.T) {
+	order := confirmedOrder(t)
+	require.NoError(t, order.ReserveAllItems())
+	amendment, err := order.Amend("change", []OrderItemInput{
+		{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 4, UnitPrice: 10.0},
+		{ProductID: 3, ProductSKU: "SKU-003", ProductName: "Product 3", Quantity: 2, UnitPrice: 5.0},
+	})
+	require.NoError(t, err)
+
+	added := order.AddedItems(amendment)
+	require.Len(t, added.Items, 2, "the removed line adds nothing")
+	assert.Equal(t, order.ID, added.ID)
+	assert.Equal(t, 2, added.Items[0].Quantity)
+	assert.Equal(t, 2, added.Items[1].Quantity)
+
+	require.NoError(t, added.ReserveItems([]int{2, 1}))
+	require.NoError(t, order.ReserveAddedItems(added))
+
+	assert.Equal(t, 4, order.Items[0].ReservedQuantity)
+	assert.False(t, order.Items[0].Backordered)
+	assert.Equal(t, 1, order.Items[1].ReservedQuantity)
+	assert.True(t, order.Items[1].Backordered)
+	assert.False(t, order.ReservationSkipped)
+}
@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// Limits of the contact details of an order
+const (
+	MaxCustomerEmailLength = 254
+	MaxCustomerNameLength  = 200
+)
+
+// Errors returned when the contact details of an order are rejected
+var (
+	ErrInvalidCustomerEmail    = errors.New("customer email must be a valid email address")
+	ErrInvalidCustomerName     = fmt.Errorf("customer name must be at most %d characters", MaxCustomerNameLength)
+	ErrContactChangeNotAllowed = errors.New("only the contact details of pending orders can be changed")
+)
+
+// ValidateCustomerEmail checks that email is a bare address such as jane@example.com. An empty email is valid,
+// the contact details are optional. The error does not repeat the email so it can be logged.
+func ValidateCustomerEmail(email string) error {
+	if email == "" {
+		return nil
+	}
+	if len(email) > MaxCustomerEmailLength {
+		return fmt.Errorf("%w, longer than %d characters", ErrInvalidCustomerEmail, MaxCustomerEmailLength)
+	}
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return ErrInvalidCustomerEmail
+	}
+	return nil
+}
+
+// SetContact replaces the contact details of a pending order, surrounding whitespace is removed and
+// empty values clear them. Setting the details an order already has is allowed in any status. The order
+// keeps them as a snapshot, they do not follow the customer's account.
+func (o *Order) SetContact(email, name string) error {
+	email = strings.TrimSpace(email)
+	name = strings.TrimSpace(name)
+
+	if err := ValidateCustomerEmail(email); err != nil {
+		return err
+	}
+	if len([]rune(name)) > MaxCustomerNameLength {
+		return ErrInvalidCustomerName
+	}
+	if email == o.CustomerEmail && name == o.CustomerName {
+		return nil
+	}
+	if o.Status != OrderStatusPending {
+		return ErrContactChangeNotAllowed
+	}
+
+	o.CustomerEmail = email
+	o.CustomerName = name
+	o.UpdatedAt = time.Now()
+	return nil
+}
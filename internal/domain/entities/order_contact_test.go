@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCustomerEmail(t *testing.T) {
+	tests := []struct {
+		email   string
+		wantErr bool
+	}{
+		{email: "jane@example.com"},
+		{email: ""},
+		{email: "jane", wantErr: true},
+		{email: "Jane <jane@example.com>", wantErr: true},
+		{email: strings.Repeat("a", 250) + "@example.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			err := ValidateCustomerEmail(tt.email)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidCustomerEmail)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestOrder_SetContact(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   OrderStatus
+		email    string
+		customer string
+		expected error
+	}{
+		{name: "pending order", status: OrderStatusPending, email: " jane@example.com ", customer: " Jane Doe "},
+		{name: "clears contact", status: OrderStatusPending},
+		{name: "confirmed order", status: OrderStatusConfirmed, email: "jane@example.com", expected: ErrContactChangeNotAllowed},
+		{name: "confirmed order unchanged", status: OrderStatusConfirmed, email: "old@example.com"},
+		{name: "invalid email", status: OrderStatusPending, email: "jane", expected: ErrInvalidCustomerEmail},
+		{name: "long name", status: OrderStatusPending, customer: strings.Repeat("a", MaxCustomerNameLength+1), expected: ErrInvalidCustomerName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, _ := NewOrder(1)
+			order.CustomerEmail = "old@example.com"
+			order.Status = tt.status
+
+			err := order.SetContact(tt.email, tt.customer)
+
+			if tt.expected != nil {
+				assert.ErrorIs(t, err, tt.expected)
+				assert.Equal(t, "old@example.com", order.CustomerEmail)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, strings.TrimSpace(tt.email), order.CustomerEmail)
+			assert.Equal(t, strings.TrimSpace(tt.customer), order.CustomerName)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidOrderHistory is returned when an imported order cannot be given the status or timestamps it
+// had in the system it comes from
+var ErrInvalidOrderHistory = errors.New("invalid order history")
+
+// RestoreHistory gives a new order the status and timestamps it had before it was imported, bypassing
+// the rule that new orders start pending. Items must be added first: past pending only cancelled and
+// expired orders may be empty, and a refunded order is refunded in full. On hold and partially shipped
+// orders are rejected, their status depends on a hold or shipments an import does not carry.
+//
+// A zero createdAt keeps the creation time of the order and a zero updatedAt falls back to createdAt.
+// Neither may be after now, and the order cannot be updated before it was created.
+func (o *Order) RestoreHistory(status OrderStatus, createdAt, updatedAt, now time.Time) error {
+	if err := ValidateOrderStatus(status); err != nil {
+		return err
+	}
+	switch status {
+	case OrderStatusOnHold, OrderStatusPartiallyShipped:
+		return fmt.Errorf("%w: orders cannot be imported %s", ErrInvalidOrderHistory, status)
+	case OrderStatusPending, OrderStatusCancelled, OrderStatusExpired:
+	default:
+		if o.IsEmpty() {
+			return fmt.Errorf("%w: %s orders must have items", ErrInvalidOrderHistory, status)
+		}
+	}
+
+	if createdAt.IsZero() {
+		createdAt = o.CreatedAt
+	}
+	if updatedAt.IsZero() {
+		updatedAt = createdAt
+	}
+	if createdAt.After(now) || updatedAt.After(now) {
+		return fmt.Errorf("%w: timestamps cannot be in the future", ErrInvalidOrderHistory)
+	}
+	if updatedAt.Before(createdAt) {
+		return fmt.Errorf("%w: updated_at cannot be before created_at", ErrInvalidOrderHistory)
+	}
+
+	o.Status = status
+	o.CreatedAt = createdAt
+	o.UpdatedAt = updatedAt
+	if status != OrderStatusPending {
+		o.ExpiresAt = nil
+	}
+	if status == OrderStatusRefunded {
+		o.RefundedAmount = o.TotalAmount
+	}
+	return nil
+}
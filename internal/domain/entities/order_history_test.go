@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHistoricalOrder(t *testing.T, withItems bool) *Order {
+	t.Helper()
+	order, err := NewOrder(123)
+	require.NoError(t, err)
+	if withItems {
+		require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 2, 10))
+	}
+	order.SetExpiry(time.Hour)
+	return order
+}
+
+func TestOrder_RestoreHistory(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	createdAt := now.AddDate(-1, 0, 0)
+	updatedAt := createdAt.Add(48 * time.Hour)
+
+	order := newHistoricalOrder(t, true)
+	require.NoError(t, order.RestoreHistory(OrderStatusDelivered, createdAt, updatedAt, now))
+	assert.Equal(t, OrderStatusDelivered, order.Status)
+	assert.Equal(t, createdAt, order.CreatedAt)
+	assert.Equal(t, updatedAt, order.UpdatedAt)
+	assert.Nil(t, order.ExpiresAt)
+
+	refunded := newHistoricalOrder(t, true)
+	require.NoError(t, refunded.RestoreHistory(OrderStatusRefunded, createdAt, time.Time{}, now))
+	assert.Equal(t, 20.0, refunded.RefundedAmount)
+	assert.Equal(t, createdAt, refunded.UpdatedAt, "a zero updatedAt falls back to createdAt")
+
+	cancelled := newHistoricalOrder(t, false)
+	assert.NoError(t, cancelled.RestoreHistory(OrderStatusCancelled, createdAt, updatedAt, now))
+
+	pending := newHistoricalOrder(t, true)
+	created := pending.CreatedAt
+	require.NoError(t, pending.RestoreHistory(OrderStatusPending, time.Time{}, time.Time{}, created.Add(time.Second)))
+	assert.Equal(t, created, pending.CreatedAt)
+	assert.NotNil(t, pending.ExpiresAt)
+}
+
+func TestOrder_RestoreHistory_Rejects(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	createdAt := now.AddDate(-1, 0, 0)
+
+	tests := []struct {
+		name      string
+		status    OrderStatus
+		withItems bool
+		createdAt time.Time
+		updatedAt time.Time
+	}{
+		{"on hold", OrderStatusOnHold, true, createdAt, time.Time{}},
+		{"partially shipped", OrderStatusPartiallyShipped, true, createdAt, time.Time{}},
+		{"confirmed without items", OrderStatusConfirmed, false, createdAt, time.Time{}},
+		{"created in the future", OrderStatusDelivered, true, now.Add(time.Minute), time.Time{}},
+		{"updated in the future", OrderStatusDelivered, true, createdAt, now.Add(time.Minute)},
+		{"updated before created", OrderStatusDelivered, true, createdAt, createdAt.Add(-time.Minute)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := newHistoricalOrder(t, tt.withItems)
+			err := order.RestoreHistory(tt.status, tt.createdAt, tt.updatedAt, now)
+			assert.ErrorIs(t, err, ErrInvalidOrderHistory)
+			assert.Equal(t, OrderStatusPending, order.Status)
+		})
+	}
+
+	order := newHistoricalOrder(t, true)
+	assert.ErrorIs(t, order.RestoreHistory("archived", createdAt, time.Time{}, now), ErrUnknownOrderStatus)
+}
@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits of the custom attributes an order item can carry
+const (
+	MaxItemAttributes           = 20
+	MaxItemAttributeKeyLength   = 255
+	MaxItemAttributeValueLength = 255
+)
+
+// attributeErrors returns the reasons attributes are rejected, checking keys in sorted order
+// so the result does not depend on map iteration
+func attributeErrors(attributes map[string]string) []string {
+	var reasons []string
+	if len(attributes) > MaxItemAttributes {
+		reasons = append(reasons, fmt.Sprintf("at most %d attributes allowed", MaxItemAttributes))
+	}
+
+	for _, key := range sortedKeys(attributes) {
+		switch {
+		case strings.TrimSpace(key) == "":
+			reasons = append(reasons, "attribute keys must not be blank")
+		case utf8.RuneCountInString(key) > MaxItemAttributeKeyLength:
+			reasons = append(reasons, fmt.Sprintf("attribute keys must be at most %d characters", MaxItemAttributeKeyLength))
+		case utf8.RuneCountInString(attributes[key]) > MaxItemAttributeValueLength:
+			reasons = append(reasons, fmt.Sprintf("attribute %q exceeds %d characters", key, MaxItemAttributeValueLength))
+		}
+	}
+	return reasons
+}
+
+// sameAttributes reports whether a and b hold the same pairs, nil and empty are the same
+func sameAttributes(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+// copyAttributes returns an independent copy of attributes, nil when there are none
+func copyAttributes(attributes map[string]string) map[string]string {
+	if len(attributes) == 0 {
+		return nil
+	}
+	copied := make(map[string]string, len(attributes))
+	for key, value := range attributes {
+		copied[key] = value
+	}
+	return copied
+}
+
+func sortedKeys(attributes map[string]string) []string {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,104 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrder_AddItem_MergesOnlyMatchingAttributes(t *testing.T) {
+	order, _ := NewOrder(123)
+	require.NoError(t, order.AddItem(1, "SKU-001", "T-Shirt", 1, 10.0, WithAttributes(map[string]string{"size": "M"})))
+
+	// The same product and attributes merge into the existing line
+	require.NoError(t, order.AddItem(1, "SKU-001", "T-Shirt", 2, 10.0, WithAttributes(map[string]string{"size": "M"})))
+	require.Len(t, order.Items, 1)
+	assert.Equal(t, 3, order.Items[0].Quantity)
+
+	// Different attributes or none at all become separate lines
+	require.NoError(t, order.AddItem(1, "SKU-001", "T-Shirt", 1, 10.0, WithAttributes(map[string]string{"size": "L"})))
+	require.NoError(t, order.AddItem(1, "SKU-001", "T-Shirt", 1, 10.0))
+	require.Len(t, order.Items, 3)
+	assert.Equal(t, map[string]string{"size": "L"}, order.Items[1].Attributes)
+	assert.Nil(t, order.Items[2].Attributes)
+	assert.Equal(t, 50.0, order.TotalAmount)
+
+	// Reject only applies to a line with the same attributes
+	err := order.AddItem(1, "SKU-001", "T-Shirt", 1, 10.0, OnDuplicate(DuplicateItemReject), WithAttributes(map[string]string{"size": "L"}))
+	assert.ErrorIs(t, err, ErrDuplicateItem)
+	err = order.AddItem(1, "SKU-001", "T-Shirt", 1, 10.0, OnDuplicate(DuplicateItemReject), WithAttributes(map[string]string{"size": "S"}))
+	assert.NoError(t, err)
+}
+
+func TestOrder_AddItem_CopiesAttributes(t *testing.T) {
+	order, _ := NewOrder(123)
+	attributes := map[string]string{"color": "red"}
+	require.NoError(t, order.AddItem(1, "SKU-001", "T-Shirt", 1, 10.0, WithAttributes(attributes)))
+
+	attributes["color"] = "blue"
+	clone := order.Clone()
+	clone.Items[0].Attributes["color"] = "green"
+
+	assert.Equal(t, "red", order.Items[0].Attributes["color"])
+}
+
+func TestOrder_AddItem_RejectsInvalidAttributes(t *testing.T) {
+	tooMany := make(map[string]string, MaxItemAttributes+1)
+	for i := 0; i <= MaxItemAttributes; i++ {
+		tooMany[strings.Repeat("k", i+1)] = "v"
+	}
+
+	tests := []struct {
+		name       string
+		attributes map[string]string
+		reason     string
+	}{
+		{"too many", tooMany, "at most 20 attributes allowed"},
+		{"blank key", map[string]string{" ": "v"}, "attribute keys must not be blank"},
+		{"long key", map[string]string{strings.Repeat("k", 256): "v"}, "attribute keys must be at most 255 characters"},
+		{"long value", map[string]string{"note": strings.Repeat("v", 256)}, `attribute "note" exceeds 255 characters`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, _ := NewOrder(123)
+
+			err := order.AddItem(1, "SKU-001", "T-Shirt", 1, 10.0, WithAttributes(tt.attributes))
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.reason)
+			assert.Empty(t, order.Items)
+		})
+	}
+}
+
+func TestValidateItems_AllowsProductWithDifferentAttributes(t *testing.T) {
+	inputs := []OrderItemInput{
+		{ProductID: 1, ProductSKU: "SKU-1", ProductName: "Product 1", Quantity: 1, UnitPrice: 10, Attributes: map[string]string{"size": "M"}},
+		{ProductID: 1, ProductSKU: "SKU-1", ProductName: "Product 1", Quantity: 1, UnitPrice: 10, Attributes: map[string]string{"size": "L"}},
+		{ProductID: 1, ProductSKU: "SKU-1", ProductName: "Product 1", Quantity: 1, UnitPrice: 10, Attributes: map[string]string{"size": "M"}},
+		{ProductID: 2, ProductSKU: "SKU-2", ProductName: "Product 2", Quantity: 1, UnitPrice: 10, Attributes: map[string]string{"": "x"}},
+	}
+
+	var itemErrors ItemErrors
+	require.ErrorAs(t, ValidateItems(inputs), &itemErrors)
+	assert.Equal(t, ItemErrors{
+		{Index: 2, Field: "product_id", Reason: "duplicate product ID 1, already listed at item 0"},
+		{Index: 3, Field: "attributes", Reason: "attribute keys must not be blank"},
+	}, itemErrors)
+}
+
+func TestOrder_UpdateItemQuantity_AmbiguousProduct(t *testing.T) {
+	order, _ := NewOrder(123)
+	require.NoError(t, order.AddItem(1, "SKU-001", "T-Shirt", 1, 10.0, WithAttributes(map[string]string{"size": "M"})))
+	require.NoError(t, order.AddItem(1, "SKU-001", "T-Shirt", 1, 10.0, WithAttributes(map[string]string{"size": "L"})))
+
+	assert.Error(t, order.UpdateItemQuantity(1, 5))
+
+	// Removing the product removes every line of it
+	require.NoError(t, order.RemoveItem(1))
+	assert.Empty(t, order.Items)
+	assert.Zero(t, order.TotalAmount)
+}
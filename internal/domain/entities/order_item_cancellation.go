@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Errors returned when an item of a confirmed order cannot be cancelled
+var (
+	ErrItemCancellationNotAllowed = errors.New("only confirmed orders can have items cancelled")
+	ErrItemNotInOrder             = errors.New("item not found in order")
+	ErrInvalidCancelQuantity      = errors.New("invalid cancel quantity")
+	ErrLastItemCancellation       = errors.New("cancelling the remaining quantity would empty the order, cancel the order instead")
+)
+
+// CancelledItem records a quantity of a line dropped from a confirmed order
+type CancelledItem struct {
+	ProductID   uint      `json:"product_id"`
+	ProductSKU  string    `json:"product_sku"`
+	ProductName string    `json:"product_name"`
+	Quantity    int       `json:"quantity"`
+	UnitPrice   float64   `json:"unit_price"`
+	CancelledAt time.Time `json:"cancelled_at"`
+}
+
+// CancelItem drops quantity units of the line of productID from a confirmed order, removing the line
+// once none are left, and records the cancellation in CancelledItems. The order keeps at least one
+// unit, emptying it requires cancelling the whole order.
+func (o *Order) CancelItem(productID uint, quantity int) (*CancelledItem, error) {
+	if o.Status != OrderStatusConfirmed {
+		return nil, ErrItemCancellationNotAllowed
+	}
+	if quantity <= 0 {
+		return nil, fmt.Errorf("%w: quantity must be positive", ErrInvalidCancelQuantity)
+	}
+
+	items := o.copyItems()
+	line := -1
+	for i := range items {
+		if items[i].ProductID != productID {
+			continue
+		}
+		if line >= 0 {
+			return nil, fmt.Errorf("%w: product has several lines with different attributes", ErrItemCancellationNotAllowed)
+		}
+		line = i
+	}
+	if line < 0 {
+		return nil, ErrItemNotInOrder
+	}
+
+	item := items[line]
+	switch {
+	case quantity > item.Quantity:
+		return nil, fmt.Errorf("%w: only %d ordered", ErrInvalidCancelQuantity, item.Quantity)
+	case quantity == item.Quantity && len(items) == 1:
+		return nil, ErrLastItemCancellation
+	case quantity == item.Quantity:
+		items = append(items[:line], items[line+1:]...)
+	default:
+		items[line].resize(items[line].Quantity - quantity)
+	}
+
+	if err := o.setItems(items); err != nil {
+		return nil, err
+	}
+
+	cancelled := CancelledItem{
+		ProductID:   item.ProductID,
+		ProductSKU:  item.ProductSKU,
+		ProductName: item.ProductName,
+		Quantity:    quantity,
+		UnitPrice:   item.UnitPrice,
+		CancelledAt: o.UpdatedAt.UTC(),
+	}
+	o.CancelledItems = append(o.CancelledItems, cancelled)
+	return &cancelled, nil
+}
@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// confirmedOrder returns a confirmed order of 2 units of product 1 at 10 and 1 unit of product 2 at 5
+func confirmedOrder(t *testing.T) *Order {
+	t.Helper()
+	order, _ := NewOrder(123)
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 2, 10.0))
+	require.NoError(t, order.AddItem(2, "SKU-002", "Product 2", 1, 5.0))
+	require.NoError(t, order.ConfirmOrder())
+	return order
+}
+
+func TestOrder_CancelItem_ReducesLine(t *testing.T) {
+	order := confirmedOrder(t)
+
+	cancelled, err := order.CancelItem(1, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, order.Items[0].Quantity)
+	assert.Equal(t, 10.0, order.Items[0].TotalPrice)
+	assert.Equal(t, 15.0, order.TotalAmount)
+	assert.Equal(t, uint(1), cancelled.ProductID)
+	assert.Equal(t, 1, cancelled.Quantity)
+	assert.Equal(t, 10.0, cancelled.UnitPrice)
+	assert.Equal(t, []CancelledItem{*cancelled}, order.CancelledItems)
+}
+
+func TestOrder_CancelItem_RemovesLine(t *testing.T) {
+	order := confirmedOrder(t)
+
+	_, err := order.CancelItem(1, 2)
+
+	require.NoError(t, err)
+	require.Len(t, order.Items, 1)
+	assert.Equal(t, uint(2), order.Items[0].ProductID)
+	assert.Equal(t, 5.0, order.TotalAmount)
+	assert.Len(t, order.CancelledItems, 1)
+}
+
+func TestOrder_CancelItem_Rejected(t *testing.T) {
+	tests := []struct {
+		name      string
+		prepare   func(t *testing.T, order *Order)
+		productID uint
+		quantity  int
+		expected  error
+	}{
+		{
+			name:      "pending order",
+			prepare:   func(t *testing.T, order *Order) { order.Status = OrderStatusPending },
+			productID: 1, quantity: 1, expected: ErrItemCancellationNotAllowed,
+		},
+		{name: "unknown product", productID: 9, quantity: 1, expected: ErrItemNotInOrder},
+		{name: "non positive quantity", productID: 1, quantity: 0, expected: ErrInvalidCancelQuantity},
+		{name: "more than ordered", productID: 1, quantity: 3, expected: ErrInvalidCancelQuantity},
+		{
+			name: "last remaining quantity",
+			prepare: func(t *testing.T, order *Order) {
+				_, err := order.CancelItem(2, 1)
+				require.NoError(t, err)
+			},
+			productID: 1, quantity: 2, expected: ErrLastItemCancellation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := confirmedOrder(t)
+			if tt.prepare != nil {
+				tt.prepare(t, order)
+			}
+			total := order.TotalAmount
+			history := len(order.CancelledItems)
+
+			cancelled, err := order.CancelItem(tt.productID, tt.quantity)
+
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Nil(t, cancelled)
+			assert.Equal(t, total, order.TotalAmount)
+			assert.Len(t, order.CancelledItems, history)
+		})
+	}
+}
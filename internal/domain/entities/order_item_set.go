@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ItemSetHash identifies the products and quantities of items regardless of their order, prices or
+// attributes, so two carts holding the same goods hash alike. It is the hex SHA-256 of the sorted
+// product:quantity pairs, empty for no items.
+func ItemSetHash(items []OrderItem) string {
+	if len(items) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(items))
+	for _, item := range items {
+		pairs = append(pairs, fmt.Sprintf("%d:%d", item.ProductID, item.Quantity))
+	}
+	slices.Sort(pairs)
+
+	sum := sha256.Sum256([]byte(strings.Join(pairs, ",")))
+	return hex.EncodeToString(sum[:])
+}
+
+// ItemSetHash is the ItemSetHash of the current items of the order
+func (o *Order) ItemSetHash() string {
+	return ItemSetHash(o.Items)
+}
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItemSetHash(t *testing.T) {
+	cart := []OrderItem{{ProductID: 1, Quantity: 2, UnitPrice: 10}, {ProductID: 2, Quantity: 1, UnitPrice: 5}}
+
+	tests := []struct {
+		name  string
+		items []OrderItem
+		same  bool
+	}{
+		{"same items", []OrderItem{{ProductID: 1, Quantity: 2}, {ProductID: 2, Quantity: 1}}, true},
+		{"other order and prices", []OrderItem{{ProductID: 2, Quantity: 1, UnitPrice: 7}, {ProductID: 1, Quantity: 2, UnitPrice: 9}}, true},
+		{"other quantity", []OrderItem{{ProductID: 1, Quantity: 3}, {ProductID: 2, Quantity: 1}}, false},
+		{"missing item", []OrderItem{{ProductID: 1, Quantity: 2}}, false},
+		{"swapped quantities", []OrderItem{{ProductID: 1, Quantity: 1}, {ProductID: 2, Quantity: 2}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.same, ItemSetHash(cart) == ItemSetHash(tt.items))
+		})
+	}
+}
+
+func TestItemSetHash_NoItems(t *testing.T) {
+	assert.Empty(t, ItemSetHash(nil))
+	assert.Len(t, ItemSetHash([]OrderItem{{ProductID: 1, Quantity: 1}}), 64)
+}
@@ -0,0 +1,103 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// OrderJobStatus is the state of an order accepted for asynchronous creation
+type OrderJobStatus string
+
+const (
+	OrderJobPending   OrderJobStatus = "pending"
+	OrderJobSucceeded OrderJobStatus = "succeeded"
+	OrderJobFailed    OrderJobStatus = "failed"
+)
+
+// ErrOrderJobNotPending is returned when the outcome of a job that already completed is recorded
+var ErrOrderJobNotPending = errors.New("order job is no longer pending")
+
+// OrderJob is a create order request accepted to run in the background. It ends succeeded with the
+// order it created, or failed with the error that rejected the request.
+type OrderJob struct {
+	// ID is the UUID the client polls the job with
+	ID         string `json:"id"`
+	CustomerID uint   `json:"customer_id"`
+	// Request is the create order request as submitted, in JSON
+	Request   json.RawMessage `json:"request"`
+	CreatedBy string          `json:"created_by"`
+	Status    OrderJobStatus  `json:"status"`
+	// OrderID and OrderPublicID identify the order created by a succeeded job
+	OrderID       uint   `json:"order_id,omitempty"`
+	OrderPublicID string `json:"order_public_id,omitempty"`
+	// ErrorCode and ErrorMessage describe the error of a failed job
+	ErrorCode    string     `json:"error_code,omitempty"`
+	ErrorMessage string     `json:"error_message,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+}
+
+// NewOrderJob builds a pending job creating an order for customerID from request
+func NewOrderJob(customerID uint, request json.RawMessage, createdBy string, now time.Time) *OrderJob {
+	return &OrderJob{
+		ID:         NewPublicID(),
+		CustomerID: customerID,
+		Request:    request,
+		CreatedBy:  createdBy,
+		Status:     OrderJobPending,
+		CreatedAt:  now.UTC(),
+	}
+}
+
+// IsPending checks if the job still waits to run
+func (j *OrderJob) IsPending() bool {
+	return j.Status == OrderJobPending
+}
+
+// MarkSucceeded records the order created by the job at the given instant
+func (j *OrderJob) MarkSucceeded(at time.Time, orderID uint, orderPublicID string) error {
+	if err := j.complete(OrderJobSucceeded, at); err != nil {
+		return err
+	}
+	j.OrderID = orderID
+	j.OrderPublicID = orderPublicID
+	return nil
+}
+
+// MarkFailed records that the request was rejected with the given error code and message,
+// the message is cut to MaxFailureMessageLength characters
+func (j *OrderJob) MarkFailed(at time.Time, code, message string) error {
+	if err := j.complete(OrderJobFailed, at); err != nil {
+		return err
+	}
+	if runes := []rune(message); len(runes) > MaxFailureMessageLength {
+		message = string(runes[:MaxFailureMessageLength])
+	}
+	j.ErrorCode = code
+	j.ErrorMessage = message
+	return nil
+}
+
+func (j *OrderJob) complete(status OrderJobStatus, at time.Time) error {
+	if !j.IsPending() {
+		return ErrOrderJobNotPending
+	}
+	completedAt := at.UTC()
+	j.Status = status
+	j.CompletedAt = &completedAt
+	return nil
+}
+
+// Clone returns a copy of the job that shares no memory with it
+func (j *OrderJob) Clone() *OrderJob {
+	clone := *j
+	if j.Request != nil {
+		clone.Request = append(json.RawMessage(nil), j.Request...)
+	}
+	if j.CompletedAt != nil {
+		completedAt := *j.CompletedAt
+		clone.CompletedAt = &completedAt
+	}
+	return &clone
+}
@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOrderJob(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	job := NewOrderJob(123, json.RawMessage(`{"customer_id":123}`), "importer", now)
+
+	assert.True(t, IsPublicID(job.ID))
+	assert.Equal(t, uint(123), job.CustomerID)
+	assert.Equal(t, "importer", job.CreatedBy)
+	assert.True(t, job.IsPending())
+	assert.Equal(t, time.UTC, job.CreatedAt.Location())
+	assert.NotEqual(t, job.ID, NewOrderJob(123, nil, "importer", now).ID)
+}
+
+func TestOrderJob_Complete(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	succeeded := NewOrderJob(123, nil, "importer", now)
+	require.NoError(t, succeeded.MarkSucceeded(now.Add(time.Second), 7, "order-uuid"))
+	assert.Equal(t, OrderJobSucceeded, succeeded.Status)
+	assert.Equal(t, uint(7), succeeded.OrderID)
+	assert.Equal(t, "order-uuid", succeeded.OrderPublicID)
+	require.NotNil(t, succeeded.CompletedAt)
+	assert.ErrorIs(t, succeeded.MarkFailed(now, "ORDER_NOT_FOUND", "late"), ErrOrderJobNotPending)
+
+	failed := NewOrderJob(123, nil, "importer", now)
+	require.NoError(t, failed.MarkFailed(now, "INVALID_ORDER_ITEMS", strings.Repeat("x", MaxFailureMessageLength+10)))
+	assert.Equal(t, OrderJobFailed, failed.Status)
+	assert.Equal(t, "INVALID_ORDER_ITEMS", failed.ErrorCode)
+	assert.Len(t, failed.ErrorMessage, MaxFailureMessageLength)
+	assert.ErrorIs(t, failed.MarkSucceeded(now, 7, ""), ErrOrderJobNotPending)
+}
+
+func TestOrderJob_Clone(t *testing.T) {
+	job := NewOrderJob(123, json.RawMessage(`{"customer_id":123}`), "importer", time.Now())
+	require.NoError(t, job.MarkSucceeded(time.Now(), 7, ""))
+
+	clone := job.Clone()
+	clone.Request[0] = '['
+	*clone.CompletedAt = time.Time{}
+
+	assert.Equal(t, `{"customer_id":123}`, string(job.Request))
+	assert.False(t, job.CompletedAt.IsZero())
+}
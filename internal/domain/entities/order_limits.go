@@ -0,0 +1,143 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned when an order change would exceed its limits
+var (
+	ErrItemLimitExceeded     = errors.New("order has too many distinct items")
+	ErrQuantityLimitExceeded = errors.New("item quantity exceeds the limit")
+	ErrTotalLimitExceeded    = errors.New("order total exceeds the limit")
+	ErrWeightLimitExceeded   = errors.New("item weight exceeds the limit")
+	ErrBelowMinimumAmount    = errors.New("order total is below the minimum order amount")
+)
+
+// MaxLineQuantity caps the quantity of an order line whatever the configured limits, so quantities and
+// their sums stay far from overflowing an int
+const MaxLineQuantity = 1_000_000
+
+// OrderLimits caps the size of an order so downstream fulfillment can handle it. Zero values disable a cap.
+type OrderLimits struct {
+	MaxItems           int
+	MaxQuantityPerItem int
+	MaxTotalAmount     float64
+	MaxUnitWeightGrams int
+	MaxUnitPrice       float64
+
+	// MinOrderAmount is the smallest total an order can be confirmed with, orders tagged sample are exempt
+	MinOrderAmount float64
+}
+
+// DefaultOrderLimits returns the limits used when none are configured
+func DefaultOrderLimits() OrderLimits {
+	return OrderLimits{
+		MaxItems:           100,
+		MaxQuantityPerItem: 10000,
+		MaxTotalAmount:     1000000,
+		MaxUnitWeightGrams: 100000,
+		MaxUnitPrice:       1000000,
+	}
+}
+
+// Check validates a candidate item list against the limits
+func (l OrderLimits) Check(items []OrderItem) error {
+	if l.MaxItems > 0 && len(items) > l.MaxItems {
+		return fmt.Errorf("%w: at most %d allowed", ErrItemLimitExceeded, l.MaxItems)
+	}
+
+	total := 0.0
+	for _, item := range items {
+		if l.MaxQuantityPerItem > 0 && item.Quantity > l.MaxQuantityPerItem {
+			return fmt.Errorf("%w: product %d has quantity %d, at most %d allowed",
+				ErrQuantityLimitExceeded, item.ProductID, item.Quantity, l.MaxQuantityPerItem)
+		}
+		if l.MaxUnitPrice > 0 && toCents(item.UnitPrice) > toCents(l.MaxUnitPrice) {
+			return &UnitPriceError{Price: item.UnitPrice, Reason: fmt.Sprintf("must be at most %.2f", l.MaxUnitPrice)}
+		}
+		if l.MaxUnitWeightGrams > 0 && item.UnitWeightGrams != nil && *item.UnitWeightGrams > l.MaxUnitWeightGrams {
+			return fmt.Errorf("%w: product %d weighs %d grams, at most %d allowed",
+				ErrWeightLimitExceeded, item.ProductID, *item.UnitWeightGrams, l.MaxUnitWeightGrams)
+		}
+		total += item.TotalPrice
+	}
+
+	if l.MaxTotalAmount > 0 && toCents(total) > toCents(l.MaxTotalAmount) {
+		return fmt.Errorf("%w: at most %.2f allowed", ErrTotalLimitExceeded, l.MaxTotalAmount)
+	}
+
+	return nil
+}
+
+// checkHardLimits rejects item lists past the bounds that hold whatever the configured limits: lines of at
+// most MaxLineQuantity, and line totals and an order total of at most MaxExactAmount
+func checkHardLimits(items []OrderItem) error {
+	total := 0.0
+	for _, item := range items {
+		if item.Quantity > MaxLineQuantity {
+			return fmt.Errorf("%w: product %d has quantity %d, at most %d allowed",
+				ErrQuantityLimitExceeded, item.ProductID, item.Quantity, MaxLineQuantity)
+		}
+		if err := validateLinePrice(item.Quantity, item.UnitPrice); err != nil {
+			return err
+		}
+		total += item.TotalPrice
+	}
+	if total > MaxExactAmount {
+		return fmt.Errorf("%w: at most %.2f allowed", ErrTotalLimitExceeded, MaxExactAmount)
+	}
+	return nil
+}
+
+// addLineQuantity adds quantity to the current quantity of a line without overflowing, sums over
+// MaxLineQuantity fail with ErrQuantityLimitExceeded
+func addLineQuantity(productID uint, current, quantity int) (int, error) {
+	if quantity > MaxLineQuantity-current {
+		return 0, fmt.Errorf("%w: product %d has quantity %d, adding %d exceeds %d",
+			ErrQuantityLimitExceeded, productID, current, quantity, MaxLineQuantity)
+	}
+	return current + quantity, nil
+}
+
+// BelowMinimumError is returned when an order is confirmed with a total under the minimum order amount
+type BelowMinimumError struct {
+	Minimum float64
+	Total   float64
+}
+
+func (e *BelowMinimumError) Error() string {
+	return fmt.Sprintf("%v: total %.2f, at least %.2f required", ErrBelowMinimumAmount, e.Total, e.Minimum)
+}
+
+func (e *BelowMinimumError) Unwrap() error {
+	return ErrBelowMinimumAmount
+}
+
+// Shortfall is the amount missing to reach the minimum
+func (e *BelowMinimumError) Shortfall() float64 {
+	return float64(toCents(e.Minimum)-toCents(e.Total)) / 100
+}
+
+// WithMinimumOverride confirms an order even if its total is below the minimum order amount
+func WithMinimumOverride() TransitionOption {
+	return func(t *Transition) {
+		t.overrideMinimum = true
+	}
+}
+
+// CheckMinimumAmount returns a BelowMinimumError if confirming the order would be rejected for its total
+func (o *Order) CheckMinimumAmount() error {
+	return requireMinimumAmount(o, &Transition{From: o.Status, To: OrderStatusConfirmed})
+}
+
+func requireMinimumAmount(o *Order, t *Transition) error {
+	minimum := o.Limits.MinOrderAmount
+	if minimum <= 0 || t.overrideMinimum || o.HasTag(TagSample) {
+		return nil
+	}
+	if toCents(o.TotalAmount) < toCents(minimum) {
+		return &BelowMinimumError{Minimum: minimum, Total: o.TotalAmount}
+	}
+	return nil
+}
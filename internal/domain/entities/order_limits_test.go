@@ -0,0 +1,179 @@
+package entities
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderLimits_Check(t *testing.T) {
+	limits := OrderLimits{MaxItems: 2, MaxQuantityPerItem: 10, MaxTotalAmount: 100, MaxUnitWeightGrams: 500, MaxUnitPrice: 80}
+	grams := func(g int) *int { return &g }
+
+	tests := []struct {
+		name  string
+		items []OrderItem
+		err   error
+	}{
+		{
+			name:  "within limits",
+			items: []OrderItem{{ProductID: 1, Quantity: 10, TotalPrice: 50}, {ProductID: 2, Quantity: 1, TotalPrice: 50}},
+		},
+		{
+			name:  "too many items",
+			items: []OrderItem{{ProductID: 1, Quantity: 1}, {ProductID: 2, Quantity: 1}, {ProductID: 3, Quantity: 1}},
+			err:   ErrItemLimitExceeded,
+		},
+		{
+			name:  "quantity too large",
+			items: []OrderItem{{ProductID: 1, Quantity: 11, TotalPrice: 11}},
+			err:   ErrQuantityLimitExceeded,
+		},
+		{
+			name:  "total too large",
+			items: []OrderItem{{ProductID: 1, Quantity: 1, TotalPrice: 100.01}},
+			err:   ErrTotalLimitExceeded,
+		},
+		{
+			name:  "unit price too large",
+			items: []OrderItem{{ProductID: 1, Quantity: 1, UnitPrice: 80.01, TotalPrice: 80.01}},
+			err:   ErrInvalidUnitPrice,
+		},
+		{
+			name:  "weight at the limit",
+			items: []OrderItem{{ProductID: 1, Quantity: 1, UnitWeightGrams: grams(500)}},
+		},
+		{
+			name:  "weight too large",
+			items: []OrderItem{{ProductID: 1, Quantity: 1, UnitWeightGrams: grams(501)}},
+			err:   ErrWeightLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := limits.Check(tt.items)
+
+			if tt.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+
+	t.Run("zero limits allow anything", func(t *testing.T) {
+		assert.NoError(t, OrderLimits{}.Check([]OrderItem{{ProductID: 1, Quantity: 2000000000, TotalPrice: 1e12}}))
+	})
+}
+
+func TestOrder_LimitsApplyToItemChanges(t *testing.T) {
+	order, _ := NewOrder(123)
+	order.Limits = OrderLimits{MaxItems: 1, MaxQuantityPerItem: 5}
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 3, 10.0))
+
+	// Adding to an existing line counts the combined quantity
+	err := order.AddItem(1, "SKU-001", "Product 1", 3, 10.0)
+	assert.ErrorIs(t, err, ErrQuantityLimitExceeded)
+
+	err = order.AddItem(2, "SKU-002", "Product 2", 1, 10.0)
+	assert.ErrorIs(t, err, ErrItemLimitExceeded)
+
+	err = order.UpdateItemQuantity(1, 6)
+	assert.ErrorIs(t, err, ErrQuantityLimitExceeded)
+
+	err = order.ReplaceItems([]OrderItemInput{
+		{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 1.0},
+		{ProductID: 3, ProductSKU: "SKU-003", ProductName: "Product 3", Quantity: 1, UnitPrice: 1.0},
+	})
+	assert.ErrorIs(t, err, ErrItemLimitExceeded)
+
+	// Rejected changes leave the order untouched
+	assert.Len(t, order.Items, 1)
+	assert.Equal(t, 3, order.Items[0].Quantity)
+	assert.Equal(t, 30.0, order.TotalAmount)
+}
+
+func TestOrder_ConfirmRequiresMinimumAmount(t *testing.T) {
+	tests := []struct {
+		name      string
+		unitPrice float64
+		tags      []string
+		opts      []TransitionOption
+		shortfall float64
+	}{
+		{name: "exactly at the minimum", unitPrice: 5.0},
+		{name: "below the minimum", unitPrice: 4.01, shortfall: 0.99},
+		{name: "below the minimum with override", unitPrice: 1.0, opts: []TransitionOption{WithMinimumOverride()}},
+		{name: "below the minimum tagged sample", unitPrice: 1.0, tags: []string{"Sample"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, _ := NewOrder(123)
+			require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 1, tt.unitPrice))
+			require.NoError(t, order.SetTags(tt.tags))
+			order.Limits = OrderLimits{MinOrderAmount: 5.0}
+
+			err := order.ConfirmOrder(tt.opts...)
+
+			if tt.shortfall == 0 {
+				require.NoError(t, err)
+				assert.Equal(t, OrderStatusConfirmed, order.Status)
+				return
+			}
+			var belowMinimum *BelowMinimumError
+			require.ErrorAs(t, err, &belowMinimum)
+			assert.ErrorIs(t, err, ErrBelowMinimumAmount)
+			assert.Equal(t, 5.0, belowMinimum.Minimum)
+			assert.Equal(t, tt.shortfall, belowMinimum.Shortfall())
+			assert.Equal(t, OrderStatusPending, order.Status)
+		})
+	}
+}
+
+func TestOrder_AddItem_MergeCannotOverflowQuantity(t *testing.T) {
+	order, _ := NewOrder(123)
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", MaxLineQuantity-1, 0.01))
+
+	// Both quantities are valid on their own, their sum is not
+	err := order.AddItem(1, "SKU-001", "Product 1", MaxLineQuantity, 0.01)
+
+	assert.ErrorIs(t, err, ErrQuantityLimitExceeded)
+	assert.Equal(t, MaxLineQuantity-1, order.Items[0].Quantity)
+	assert.Equal(t, MaxLineQuantity-1, order.GetTotalQuantity())
+	assert.Positive(t, order.TotalAmount)
+}
+
+func TestOrder_AddItem_MergeUpToTheLineQuantityCap(t *testing.T) {
+	order, _ := NewOrder(123)
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", MaxLineQuantity-1, 0.01))
+
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 1, 0.01))
+	assert.Equal(t, MaxLineQuantity, order.Items[0].Quantity)
+
+	assert.ErrorIs(t, order.AddItem(1, "SKU-001", "Product 1", 1, 0.01), ErrQuantityLimitExceeded)
+	assert.Equal(t, MaxLineQuantity, order.Items[0].Quantity)
+}
+
+func TestOrder_AddItem_NewLineOverTheLineQuantityCap(t *testing.T) {
+	order, _ := NewOrder(123)
+
+	err := order.AddItem(1, "SKU-001", "Product 1", math.MaxInt, 0.01)
+
+	assert.ErrorIs(t, err, ErrQuantityLimitExceeded)
+	assert.Empty(t, order.Items)
+}
+
+func TestOrder_UpdateItemQuantity_OverTheLineQuantityCap(t *testing.T) {
+	order, _ := NewOrder(123)
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 2, 10.0))
+
+	err := order.UpdateItemQuantity(1, math.MaxInt)
+
+	assert.ErrorIs(t, err, ErrQuantityLimitExceeded)
+	assert.Equal(t, 2, order.Items[0].Quantity)
+	assert.Equal(t, 20.0, order.TotalAmount)
+}
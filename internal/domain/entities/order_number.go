@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OrderNumberSuffix decides how the last part of an order number is chosen
+type OrderNumberSuffix string
+
+const (
+	// OrderNumberSuffixSequence numbers orders consecutively, per year when the year is part of the number
+	OrderNumberSuffixSequence OrderNumberSuffix = "sequence"
+	// OrderNumberSuffixRandom picks a random suffix, so numbers reveal nothing about order volume
+	OrderNumberSuffixRandom OrderNumberSuffix = "random"
+)
+
+// MaxOrderNumberLength is the longest order number that is stored
+const MaxOrderNumberLength = 64
+
+// OrderNumberFormat describes the human friendly numbers given to new orders, such as ORD-2025-000123
+type OrderNumberFormat struct {
+	// Prefix starts every number, it is left out when empty
+	Prefix string
+
+	// IncludeYear adds the UTC year of creation after the prefix
+	IncludeYear bool
+
+	// Suffix is sequence or random, sequence when empty or unknown
+	Suffix OrderNumberSuffix
+
+	// Digits is the zero padded width of the suffix, 6 when not positive. Sequences outgrowing it get longer.
+	Digits int
+}
+
+// DefaultOrderNumberFormat returns the format used when none is configured
+func DefaultOrderNumberFormat() OrderNumberFormat {
+	return OrderNumberFormat{
+		Prefix:      "ORD",
+		IncludeYear: true,
+		Suffix:      OrderNumberSuffixSequence,
+		Digits:      6,
+	}
+}
+
+// UsesSequence reports whether numbers take their suffix from a sequence
+func (f OrderNumberFormat) UsesSequence() bool {
+	return f.Suffix != OrderNumberSuffixRandom
+}
+
+// SequenceScope names the sequence numbering orders created at the given time. Sequences restart
+// every year when the year is part of the number, so the scope holds the prefix and the year.
+func (f OrderNumberFormat) SequenceScope(at time.Time) string {
+	return strings.Join(f.head(at), "-")
+}
+
+// Format builds the number of an order created at the given time from its sequence value
+func (f OrderNumberFormat) Format(at time.Time, value uint64) string {
+	return strings.Join(append(f.head(at), fmt.Sprintf("%0*d", f.digits(), value)), "-")
+}
+
+// Random builds a number with a random suffix for an order created at the given time
+func (f OrderNumberFormat) Random(at time.Time) (string, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(f.digits())), nil)
+	value, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate order number: %w", err)
+	}
+	return f.Format(at, value.Uint64()), nil
+}
+
+func (f OrderNumberFormat) head(at time.Time) []string {
+	var parts []string
+	if prefix := strings.TrimSpace(f.Prefix); prefix != "" {
+		parts = append(parts, prefix)
+	}
+	if f.IncludeYear {
+		parts = append(parts, strconv.Itoa(at.UTC().Year()))
+	}
+	return parts
+}
+
+func (f OrderNumberFormat) digits() int {
+	if f.Digits <= 0 {
+		return 6
+	}
+	return f.Digits
+}
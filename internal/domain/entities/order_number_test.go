@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderNumberFormat_Format(t *testing.T) {
+	at := time.Date(2025, 12, 31, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*3600))
+
+	tests := []struct {
+		name   string
+		format OrderNumberFormat
+		value  uint64
+		want   string
+		scope  string
+	}{
+		{"default", DefaultOrderNumberFormat(), 123, "ORD-2026-000123", "ORD-2026"},
+		{"without year", OrderNumberFormat{Prefix: "SO", Digits: 4}, 7, "SO-0007", "SO"},
+		{"without prefix", OrderNumberFormat{IncludeYear: true, Digits: 3}, 42, "2026-042", "2026"},
+		{"default width", OrderNumberFormat{Prefix: "ORD"}, 1, "ORD-000001", "ORD"},
+		{"outgrows width", OrderNumberFormat{Prefix: "ORD", Digits: 2}, 1234, "ORD-1234", "ORD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.format.Format(at, tt.value))
+			assert.Equal(t, tt.scope, tt.format.SequenceScope(at))
+		})
+	}
+}
+
+func TestOrderNumberFormat_Random(t *testing.T) {
+	format := OrderNumberFormat{Prefix: "ORD", IncludeYear: true, Suffix: OrderNumberSuffixRandom, Digits: 8}
+	at := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	number, err := format.Random(at)
+
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^ORD-2025-\d{8}$`), number)
+	assert.False(t, format.UsesSequence())
+	assert.True(t, DefaultOrderNumberFormat().UsesSequence())
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PaymentStatus tracks the payment authorization taken when an order is confirmed
+type PaymentStatus string
+
+const (
+	// PaymentStatusAuthorized holds the order amount on the customer's payment method
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	// PaymentStatusCaptured charged the authorized amount, once the order shipped
+	PaymentStatusCaptured PaymentStatus = "captured"
+	// PaymentStatusVoided released the authorization, once the order was cancelled
+	PaymentStatusVoided PaymentStatus = "voided"
+)
+
+// ErrInvalidPaymentTransition is returned for a payment change the authorization of the order does not allow
+var ErrInvalidPaymentTransition = errors.New("invalid payment transition")
+
+// RecordPaymentAuthorization stores the authorization the payment gateway granted for the order
+func (o *Order) RecordPaymentAuthorization(authorizationID string) error {
+	if authorizationID == "" {
+		return fmt.Errorf("%w: empty authorization ID", ErrInvalidPaymentTransition)
+	}
+	if o.PaymentStatus != "" {
+		return fmt.Errorf("%w: order already has a %s payment", ErrInvalidPaymentTransition, o.PaymentStatus)
+	}
+	o.PaymentAuthorizationID = authorizationID
+	o.PaymentStatus = PaymentStatusAuthorized
+	return nil
+}
+
+// RecordPaymentCapture marks the authorization of the order captured
+func (o *Order) RecordPaymentCapture() error {
+	return o.settlePayment(PaymentStatusCaptured)
+}
+
+// RecordPaymentVoid marks the authorization of the order voided
+func (o *Order) RecordPaymentVoid() error {
+	return o.settlePayment(PaymentStatusVoided)
+}
+
+// HasOutstandingAuthorization reports whether the order holds an authorization neither captured nor voided
+func (o *Order) HasOutstandingAuthorization() bool {
+	return o.PaymentStatus == PaymentStatusAuthorized
+}
+
+func (o *Order) settlePayment(status PaymentStatus) error {
+	if !o.HasOutstandingAuthorization() {
+		return fmt.Errorf("%w: no outstanding authorization to mark %s", ErrInvalidPaymentTransition, status)
+	}
+	o.PaymentStatus = status
+	return nil
+}
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrder_RecordPaymentAuthorization(t *testing.T) {
+	order, _ := NewOrder(1)
+
+	require.NoError(t, order.RecordPaymentAuthorization("auth-1"))
+
+	assert.Equal(t, "auth-1", order.PaymentAuthorizationID)
+	assert.Equal(t, PaymentStatusAuthorized, order.PaymentStatus)
+	assert.True(t, order.HasOutstandingAuthorization())
+	assert.ErrorIs(t, order.RecordPaymentAuthorization("auth-2"), ErrInvalidPaymentTransition)
+	assert.Equal(t, "auth-1", order.PaymentAuthorizationID)
+}
+
+func TestOrder_RecordPaymentAuthorization_EmptyID(t *testing.T) {
+	order, _ := NewOrder(1)
+
+	assert.ErrorIs(t, order.RecordPaymentAuthorization(""), ErrInvalidPaymentTransition)
+	assert.Empty(t, order.PaymentStatus)
+}
+
+func TestOrder_SettlePayment(t *testing.T) {
+	tests := []struct {
+		name     string
+		settle   func(order *Order) error
+		expected PaymentStatus
+	}{
+		{name: "capture", settle: (*Order).RecordPaymentCapture, expected: PaymentStatusCaptured},
+		{name: "void", settle: (*Order).RecordPaymentVoid, expected: PaymentStatusVoided},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, _ := NewOrder(1)
+			assert.ErrorIs(t, tt.settle(order), ErrInvalidPaymentTransition, "nothing to settle yet")
+
+			require.NoError(t, order.RecordPaymentAuthorization("auth-1"))
+			require.NoError(t, tt.settle(order))
+
+			assert.Equal(t, tt.expected, order.PaymentStatus)
+			assert.False(t, order.HasOutstandingAuthorization())
+			assert.ErrorIs(t, order.RecordPaymentCapture(), ErrInvalidPaymentTransition)
+			assert.ErrorIs(t, order.RecordPaymentVoid(), ErrInvalidPaymentTransition)
+		})
+	}
+}
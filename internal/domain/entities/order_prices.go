@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidUnitPrice is returned for a unit price that is not a positive, finite amount of whole cents
+var ErrInvalidUnitPrice = errors.New("invalid unit price")
+
+// MaxExactAmount is the largest amount whose cents a float64 still holds exactly, 2^53 cents. Line
+// totals and order totals above it are rejected whatever the configured limits.
+const MaxExactAmount = float64(1<<53) / 100
+
+// UnitPriceError is returned for a rejected unit price, Price is the offending value
+type UnitPriceError struct {
+	Price  float64
+	Reason string
+}
+
+func (e *UnitPriceError) Error() string {
+	return "unit price " + e.Reason
+}
+
+func (e *UnitPriceError) Unwrap() error {
+	return ErrInvalidUnitPrice
+}
+
+// ValidateUnitPrice checks that price is a positive, finite amount with at most 2 decimal places
+func ValidateUnitPrice(price float64) error {
+	switch {
+	case math.IsNaN(price) || math.IsInf(price, 0):
+		return &UnitPriceError{Price: price, Reason: "must be a finite number"}
+	case price <= 0:
+		return &UnitPriceError{Price: price, Reason: "must be positive"}
+	case price > MaxExactAmount:
+		return &UnitPriceError{Price: price, Reason: fmt.Sprintf("must be at most %.2f", MaxExactAmount)}
+	case decimalPlaces(price) > 2:
+		return &UnitPriceError{Price: price, Reason: "must have at most 2 decimal places"}
+	}
+	return nil
+}
+
+// validateLinePrice checks the unit price of a line and that its total stays an exact amount
+func validateLinePrice(quantity int, price float64) error {
+	if err := ValidateUnitPrice(price); err != nil {
+		return err
+	}
+	if float64(quantity)*price > MaxExactAmount {
+		return &UnitPriceError{Price: price, Reason: fmt.Sprintf("times quantity %d must be at most %.2f", quantity, MaxExactAmount)}
+	}
+	return nil
+}
+
+// decimalPlaces counts the decimals of the shortest representation of amount, which is the amount as
+// written in a request
+func decimalPlaces(amount float64) int {
+	_, decimals, found := strings.Cut(strconv.FormatFloat(amount, 'f', -1, 64), ".")
+	if !found {
+		return 0
+	}
+	return len(decimals)
+}
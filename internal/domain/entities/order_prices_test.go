@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateUnitPrice(t *testing.T) {
+	tests := []struct {
+		price  float64
+		reason string
+	}{
+		{9.99, ""},
+		{0.01, ""},
+		{1e6, ""},
+		{19.9, ""},
+		{0, "must be positive"},
+		{math.Copysign(0, -1), "must be positive"},
+		{-5, "must be positive"},
+		{math.NaN(), "must be a finite number"},
+		{math.Inf(1), "must be a finite number"},
+		{math.Inf(-1), "must be a finite number"},
+		{1e308, "must be at most 90071992547409.92"},
+		{9.999, "must have at most 2 decimal places"},
+		{0.001, "must have at most 2 decimal places"},
+		{1e-7, "must have at most 2 decimal places"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.FormatFloat(tt.price, 'g', -1, 64), func(t *testing.T) {
+			err := ValidateUnitPrice(tt.price)
+
+			if tt.reason == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidUnitPrice)
+			var priceErr *UnitPriceError
+			require.ErrorAs(t, err, &priceErr)
+			assert.Equal(t, tt.reason, priceErr.Reason)
+			if !math.IsNaN(tt.price) {
+				assert.Equal(t, tt.price, priceErr.Price)
+			}
+		})
+	}
+}
+
+func TestOrder_AddItem_RejectsInvalidUnitPrice(t *testing.T) {
+	order, _ := NewOrder(123)
+
+	err := order.AddItem(1, "SKU-001", "Product 1", 1, math.NaN())
+
+	assert.ErrorIs(t, err, ErrInvalidUnitPrice)
+	assert.Empty(t, order.Items)
+	assert.Equal(t, 0.0, order.TotalAmount)
+}
+
+func TestOrder_AddItem_RejectsLineTotalPastExactAmounts(t *testing.T) {
+	order, _ := NewOrder(123)
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 9, 1e13))
+
+	// Merging into the line pushes its total past the amounts a float64 holds to the cent
+	err := order.AddItem(1, "SKU-001", "Product 1", 1, 1e13)
+
+	assert.ErrorIs(t, err, ErrInvalidUnitPrice)
+	assert.Equal(t, 9, order.Items[0].Quantity)
+	assert.Equal(t, 9e13, order.TotalAmount)
+}
+
+func TestOrder_AddItem_RejectsOrderTotalPastExactAmounts(t *testing.T) {
+	order, _ := NewOrder(123)
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 5, 1e13))
+
+	err := order.AddItem(2, "SKU-002", "Product 2", 5, 1e13)
+
+	assert.ErrorIs(t, err, ErrTotalLimitExceeded)
+	assert.Len(t, order.Items, 1)
+}
+
+func TestValidateItems_ReportsInvalidUnitPrices(t *testing.T) {
+	err := ValidateItems([]OrderItemInput{
+		{ProductID: 1, ProductSKU: "SKU-001", ProductName: "Product 1", Quantity: 1, UnitPrice: math.Inf(1)},
+		{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 0.005},
+		{ProductID: 3, ProductSKU: "SKU-003", ProductName: "Product 3", Quantity: MaxLineQuantity, UnitPrice: 1e8},
+	})
+
+	var itemErrors ItemErrors
+	require.ErrorAs(t, err, &itemErrors)
+	assert.Equal(t, ItemErrors{
+		{Index: 0, Field: "unit_price", Reason: "unit price must be a finite number"},
+		{Index: 1, Field: "unit_price", Reason: "unit price must have at most 2 decimal places"},
+		{Index: 2, Field: "unit_price", Reason: "unit price times quantity 1000000 must be at most 90071992547409.92"},
+	}, itemErrors)
+}
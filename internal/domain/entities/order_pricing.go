@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned when an order cannot be repriced
+var (
+	ErrRepriceNotAllowed = errors.New("only pending orders can be repriced")
+	ErrProductNotPriced  = errors.New("product has no catalog price")
+)
+
+// PriceChange records an order line whose unit price changed when the order was repriced
+type PriceChange struct {
+	ItemID    uint
+	ProductID uint
+	OldPrice  float64
+	NewPrice  float64
+}
+
+// Reprice sets the unit price of every line to the price of its product in prices and recalculates
+// the totals, returning the lines whose price changed in item order. Every product of the order must
+// have a positive price, otherwise the order is left unchanged.
+func (o *Order) Reprice(prices map[uint]float64) ([]PriceChange, error) {
+	if !o.IsPending() {
+		return nil, ErrRepriceNotAllowed
+	}
+
+	items := o.copyItems()
+	changes := make([]PriceChange, 0)
+	for i := range items {
+		price, ok := prices[items[i].ProductID]
+		if !ok || ValidateUnitPrice(price) != nil {
+			return nil, fmt.Errorf("%w: product ID %d", ErrProductNotPriced, items[i].ProductID)
+		}
+		if toCents(price) == toCents(items[i].UnitPrice) {
+			continue
+		}
+
+		changes = append(changes, PriceChange{
+			ItemID:    items[i].ID,
+			ProductID: items[i].ProductID,
+			OldPrice:  items[i].UnitPrice,
+			NewPrice:  price,
+		})
+		items[i].UnitPrice = price
+		items[i].TotalPrice = float64(items[i].Quantity) * price
+	}
+
+	if len(changes) == 0 {
+		return changes, nil
+	}
+	if err := o.setItems(items); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// ProductIDs returns the distinct products of the order in item order
+func (o *Order) ProductIDs() []uint {
+	seen := make(map[uint]bool, len(o.Items))
+	ids := make([]uint, 0, len(o.Items))
+	for _, item := range o.Items {
+		if !seen[item.ProductID] {
+			seen[item.ProductID] = true
+			ids = append(ids, item.ProductID)
+		}
+	}
+	return ids
+}
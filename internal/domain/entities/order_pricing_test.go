@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPricedOrder(t *testing.T) *Order {
+	t.Helper()
+	order, _ := NewOrder(123)
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 2, 10.0))
+	require.NoError(t, order.AddItem(2, "SKU-002", "Product 2", 1, 5.0))
+	return order
+}
+
+func TestOrder_Reprice(t *testing.T) {
+	order := newPricedOrder(t)
+
+	changes, err := order.Reprice(map[uint]float64{1: 12.5, 2: 5.0})
+
+	require.NoError(t, err)
+	assert.Equal(t, []PriceChange{{ProductID: 1, OldPrice: 10.0, NewPrice: 12.5}}, changes)
+	assert.Equal(t, 12.5, order.Items[0].UnitPrice)
+	assert.Equal(t, 25.0, order.Items[0].TotalPrice)
+	assert.Equal(t, 30.0, order.TotalAmount)
+}
+
+func TestOrder_Reprice_UnchangedPrices(t *testing.T) {
+	order := newPricedOrder(t)
+
+	changes, err := order.Reprice(map[uint]float64{1: 10.0, 2: 5.0, 3: 99.0})
+
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, 25.0, order.TotalAmount)
+}
+
+func TestOrder_Reprice_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   OrderStatus
+		prices   map[uint]float64
+		expected error
+	}{
+		{"confirmed order", OrderStatusConfirmed, map[uint]float64{1: 12.0, 2: 5.0}, ErrRepriceNotAllowed},
+		{"product missing from catalog", OrderStatusPending, map[uint]float64{1: 12.0}, ErrProductNotPriced},
+		{"non positive price", OrderStatusPending, map[uint]float64{1: 12.0, 2: 0}, ErrProductNotPriced},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := newPricedOrder(t)
+			order.Status = tt.status
+
+			changes, err := order.Reprice(tt.prices)
+
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Nil(t, changes)
+			assert.Equal(t, 10.0, order.Items[0].UnitPrice)
+			assert.Equal(t, 25.0, order.TotalAmount)
+		})
+	}
+}
+
+func TestOrder_Reprice_TotalLimit(t *testing.T) {
+	order := newPricedOrder(t)
+	order.Limits = OrderLimits{MaxTotalAmount: 100}
+
+	_, err := order.Reprice(map[uint]float64{1: 60.0, 2: 5.0})
+
+	assert.ErrorIs(t, err, ErrTotalLimitExceeded)
+	assert.Equal(t, 25.0, order.TotalAmount)
+}
+
+func TestOrder_ProductIDs(t *testing.T) {
+	order := newPricedOrder(t)
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 1, 10.0, WithAttributes(map[string]string{"size": "L"})))
+
+	assert.Equal(t, []uint{1, 2}, order.ProductIDs())
+}
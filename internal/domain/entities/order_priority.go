@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OrderPriority decides how soon fulfillment picks an order up, see ListByFilterOrderedByPriority
+type OrderPriority string
+
+const (
+	OrderPriorityLow    OrderPriority = "low"
+	OrderPriorityNormal OrderPriority = "normal"
+	OrderPriorityHigh   OrderPriority = "high"
+	OrderPriorityUrgent OrderPriority = "urgent"
+)
+
+// Errors returned when the priority of an order is rejected
+var (
+	ErrInvalidPriority          = errors.New("priority must be low, normal, high or urgent")
+	ErrPriorityChangeNotAllowed = errors.New("only the priority of pending or confirmed orders can be changed")
+)
+
+// orderPriorities lists the priorities from the least to the most urgent
+var orderPriorities = []OrderPriority{OrderPriorityLow, OrderPriorityNormal, OrderPriorityHigh, OrderPriorityUrgent}
+
+// Rank orders priorities, a more urgent priority has a higher rank. Unknown priorities rank as normal,
+// which is what orders stored before priorities existed have.
+func (p OrderPriority) Rank() int {
+	for rank, priority := range orderPriorities {
+		if priority == p {
+			return rank
+		}
+	}
+	return OrderPriorityNormal.Rank()
+}
+
+// ParseOrderPriority converts s to an order priority, ignoring case and surrounding whitespace.
+// An empty s is the normal priority.
+func ParseOrderPriority(s string) (OrderPriority, error) {
+	priority := OrderPriority(strings.ToLower(strings.TrimSpace(s)))
+	if priority == "" {
+		return OrderPriorityNormal, nil
+	}
+	for _, known := range orderPriorities {
+		if priority == known {
+			return priority, nil
+		}
+	}
+	return "", fmt.Errorf("%w, got %q", ErrInvalidPriority, s)
+}
+
+// SetPriority changes the priority of a pending or confirmed order, an empty priority is normal
+func (o *Order) SetPriority(priority OrderPriority) error {
+	parsed, err := ParseOrderPriority(string(priority))
+	if err != nil {
+		return err
+	}
+	if o.Status != OrderStatusPending && o.Status != OrderStatusConfirmed {
+		return ErrPriorityChangeNotAllowed
+	}
+
+	o.Priority = parsed
+	o.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOrder_DefaultsToNormalPriority(t *testing.T) {
+	order, err := NewOrder(1)
+
+	require.NoError(t, err)
+	assert.Equal(t, OrderPriorityNormal, order.Priority)
+}
+
+func TestParseOrderPriority(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected OrderPriority
+		wantErr  bool
+	}{
+		{input: "urgent", expected: OrderPriorityUrgent},
+		{input: " High ", expected: OrderPriorityHigh},
+		{input: "", expected: OrderPriorityNormal},
+		{input: "asap", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			priority, err := ParseOrderPriority(tt.input)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPriority)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, priority)
+		})
+	}
+}
+
+func TestOrderPriority_Rank(t *testing.T) {
+	assert.Less(t, OrderPriorityLow.Rank(), OrderPriorityNormal.Rank())
+	assert.Less(t, OrderPriorityNormal.Rank(), OrderPriorityHigh.Rank())
+	assert.Less(t, OrderPriorityHigh.Rank(), OrderPriorityUrgent.Rank())
+	assert.Equal(t, OrderPriorityNormal.Rank(), OrderPriority("").Rank())
+}
+
+func TestOrder_SetPriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   OrderStatus
+		priority OrderPriority
+		expected error
+	}{
+		{name: "pending order", status: OrderStatusPending, priority: OrderPriorityUrgent},
+		{name: "confirmed order", status: OrderStatusConfirmed, priority: OrderPriorityLow},
+		{name: "processing order", status: OrderStatusProcessing, priority: OrderPriorityUrgent, expected: ErrPriorityChangeNotAllowed},
+		{name: "unknown priority", status: OrderStatusPending, priority: "asap", expected: ErrInvalidPriority},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, _ := NewOrder(1)
+			order.Status = tt.status
+
+			err := order.SetPriority(tt.priority)
+
+			if tt.expected != nil {
+				assert.ErrorIs(t, err, tt.expected)
+				assert.Equal(t, OrderPriorityNormal, order.Priority)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.priority, order.Priority)
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package entities
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// propertyConfig runs each property on enough random inputs to walk every edge of the state machine
+var propertyConfig = &quick.Config{MaxCount: 2000}
+
+// propertyNow is the instant the generated transitions are evaluated at
+var propertyNow = time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+
+// lineInput is a random valid order line, prices in whole cents as the API accepts them
+type lineInput struct {
+	ProductID uint
+	Quantity  int
+	UnitPrice float64
+}
+
+func (lineInput) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(lineInput{
+		ProductID: uint(r.Intn(20) + 1),
+		Quantity:  r.Intn(50) + 1,
+		UnitPrice: float64(r.Intn(100_000)+1) / 100,
+	})
+}
+
+// lineInputs is a random list of 1 to 10 order lines
+type lineInputs []lineInput
+
+func (lineInputs) Generate(r *rand.Rand, size int) reflect.Value {
+	lines := make(lineInputs, r.Intn(10)+1)
+	for i := range lines {
+		lines[i] = lineInput{}.Generate(r, size).Interface().(lineInput)
+	}
+	return reflect.ValueOf(lines)
+}
+
+// pendingOrder builds a pending order from lines, merging the lines of the same product
+func pendingOrder(t *testing.T, lines lineInputs) *Order {
+	t.Helper()
+	order, err := NewOrder(1)
+	require.NoError(t, err)
+	for _, line := range lines {
+		require.NoError(t, order.AddItem(line.ProductID, "SKU", "Product", line.Quantity, line.UnitPrice))
+	}
+	return order
+}
+
+// lineTotals sums the line totals of order in cents
+func lineTotals(order *Order) int64 {
+	var total int64
+	for _, item := range order.Items {
+		total += toCents(item.TotalPrice)
+	}
+	return total
+}
+
+// orderStep is a random request to change an order, a status transition or a partial refund. A step
+// that follows the state machine picks its target among the valid transitions of the order, so walks
+// reach the statuses deep in the lifecycle.
+type orderStep struct {
+	To       OrderStatus
+	Follow   bool
+	Pick     int
+	Reason   string
+	Release  bool
+	Carrier  string
+	Tracking string
+	Refund   float64
+}
+
+// orderWalk is a random sequence of steps applied to an order
+type orderWalk struct {
+	Lines   lineInputs
+	Expired bool
+	Steps   []orderStep
+}
+
+func (orderWalk) Generate(r *rand.Rand, size int) reflect.Value {
+	// An unknown status checks that requests for it are rejected like any other invalid target
+	targets := append(OrderStatuses(), "archived")
+	walk := orderWalk{
+		Lines:   lineInputs{}.Generate(r, size).Interface().(lineInputs),
+		Expired: r.Intn(4) == 0,
+		Steps:   make([]orderStep, r.Intn(20)+1),
+	}
+	for i := range walk.Steps {
+		step := orderStep{To: targets[r.Intn(len(targets))], Follow: r.Intn(4) != 0, Pick: r.Intn(len(targets))}
+		if r.Intn(2) == 0 {
+			step.Reason = "customer asked"
+		}
+		step.Release = r.Intn(2) == 0
+		if r.Intn(2) == 0 {
+			step.Carrier, step.Tracking = "UPS", "1Z999"
+		}
+		if r.Intn(4) == 0 {
+			step.Refund = float64(r.Intn(50_000)+1) / 100
+		}
+		walk.Steps[i] = step
+	}
+	return reflect.ValueOf(walk)
+}
+
+func (s orderStep) apply(order *Order) error {
+	if s.Refund > 0 {
+		return order.RefundAmount(s.Refund)
+	}
+	to := s.To
+	if valid := ValidTransitions(order.Status); s.Follow && len(valid) > 0 {
+		to = valid[s.Pick%len(valid)]
+	}
+	opts := []TransitionOption{AtTime(propertyNow), WithReason(s.Reason), WithTracking(s.Carrier, s.Tracking)}
+	if s.Release {
+		opts = append(opts, WithHoldRelease())
+	}
+	return order.TransitionTo(to, opts...)
+}
+
+// checkOrderInvariants fails t when order is in a state no sequence of valid changes can reach
+func checkOrderInvariants(t *testing.T, order *Order) bool {
+	t.Helper()
+	ok := assert.Contains(t, orderStatuses, order.Status)
+	onHold := order.Status == OrderStatusOnHold
+	ok = assert.Equal(t, onHold, order.HeldFromStatus != "", "held from %q in %s", order.HeldFromStatus, order.Status) && ok
+	ok = assert.Equal(t, onHold, order.HoldReason != "", "hold reason %q in %s", order.HoldReason, order.Status) && ok
+	if onHold {
+		ok = assert.Contains(t, ValidTransitions(OrderStatusOnHold), order.HeldFromStatus) && ok
+	}
+
+	ok = assert.Equal(t, lineTotals(order), toCents(order.TotalAmount), "total of %s", order.Status) && ok
+	ok = assert.LessOrEqual(t, toCents(order.RefundedAmount), toCents(order.TotalAmount)) && ok
+	if order.Status == OrderStatusRefunded {
+		ok = assert.Equal(t, toCents(order.TotalAmount), toCents(order.RefundedAmount), "a refunded order is refunded in full") && ok
+	}
+	if order.Status != OrderStatusPending && order.Status != OrderStatusExpired && order.HeldFromStatus != OrderStatusPending &&
+		order.Status != OrderStatusCancelled {
+		ok = assert.Nil(t, order.ExpiresAt, "a confirmed order cannot expire, status %s", order.Status) && ok
+	}
+	return ok
+}
+
+func TestOrder_Property_TransitionsNeverReachAnImpossibleState(t *testing.T) {
+	property := func(walk orderWalk) bool {
+		order := pendingOrder(t, walk.Lines)
+		if walk.Expired {
+			order.CreatedAt = propertyNow.Add(-2 * time.Hour)
+			order.SetExpiry(time.Hour)
+		} else {
+			order.SetExpiry(72 * time.Hour)
+		}
+		if !checkOrderInvariants(t, order) {
+			return false
+		}
+
+		for _, step := range walk.Steps {
+			before := order.Clone()
+			err := step.apply(order)
+
+			if err != nil {
+				if !assert.Equal(t, before, order, "a rejected %+v changed the order", step) {
+					return false
+				}
+				continue
+			}
+			if step.Refund == 0 && !assert.Contains(t, ValidTransitions(before.Status), order.Status, "%s to %s", before.Status, order.Status) {
+				return false
+			}
+			if len(ValidTransitions(before.Status)) == 0 && !assert.Equal(t, before.Status, order.Status, "%s is terminal", before.Status) {
+				return false
+			}
+			if !checkOrderInvariants(t, order) {
+				return false
+			}
+		}
+		return true
+	}
+
+	require.NoError(t, quick.Check(property, propertyConfig))
+}
+
+func TestOrder_Property_TotalIsTheSumOfLineTotals(t *testing.T) {
+	property := func(lines lineInputs) bool {
+		order := pendingOrder(t, lines)
+
+		total := order.CalculateTotal()
+
+		return assert.Equal(t, lineTotals(order), toCents(total)) &&
+			assert.Equal(t, total, order.TotalAmount)
+	}
+
+	require.NoError(t, quick.Check(property, propertyConfig))
+}
+
+func TestOrder_Property_AddThenRemoveRestoresTheTotal(t *testing.T) {
+	property := func(lines lineInputs, added lineInput) bool {
+		order := pendingOrder(t, lines)
+		totalBefore := order.TotalAmount
+		itemsBefore := order.copyItems()
+		// A product new to the order, adding a product already in it grows its line instead
+		added.ProductID += 100
+
+		require.NoError(t, order.AddItem(added.ProductID, "SKU-NEW", "New product", added.Quantity, added.UnitPrice))
+		require.NoError(t, order.RemoveItem(added.ProductID))
+
+		return assert.Equal(t, toCents(totalBefore), toCents(order.TotalAmount)) &&
+			assert.Equal(t, itemsBefore, order.Items)
+	}
+
+	require.NoError(t, quick.Check(property, propertyConfig))
+}
+
+func TestOrder_Property_UpdateItemQuantityIsIdempotent(t *testing.T) {
+	property := func(lines lineInputs, pick uint8, quantity uint8) bool {
+		order := pendingOrder(t, lines)
+		productID := order.Items[int(pick)%len(order.Items)].ProductID
+		newQuantity := int(quantity)%100 + 1
+
+		require.NoError(t, order.UpdateItemQuantity(productID, newQuantity))
+		once := order.Clone()
+		require.NoError(t, order.UpdateItemQuantity(productID, newQuantity))
+
+		once.UpdatedAt = order.UpdatedAt
+		return assert.Equal(t, once, order)
+	}
+
+	require.NoError(t, quick.Check(property, propertyConfig))
+}
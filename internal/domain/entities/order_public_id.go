@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidPublicID is returned for a string that is not a UUID
+var ErrInvalidPublicID = errors.New("invalid public ID")
+
+// publicIDLength is the length of a UUID in its 8-4-4-4-12 text form
+const publicIDLength = 36
+
+// NewPublicID returns a random (version 4) UUID in lowercase text form
+func NewPublicID() string {
+	return uuid.NewString()
+}
+
+// ParsePublicID checks s is a UUID in 8-4-4-4-12 text form and returns it in lowercase,
+// ignoring surrounding whitespace. Any UUID version is accepted.
+func ParsePublicID(s string) (string, error) {
+	id := strings.TrimSpace(s)
+	// uuid.Parse also accepts the URN, braced and unhyphenated forms, which are not public IDs
+	if len(id) != publicIDLength {
+		return "", ErrInvalidPublicID
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return "", ErrInvalidPublicID
+	}
+	return parsed.String(), nil
+}
+
+// IsPublicID reports whether s looks like a UUID rather than a numeric ID
+func IsPublicID(s string) bool {
+	_, err := ParsePublicID(s)
+	return err == nil
+}
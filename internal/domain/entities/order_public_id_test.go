@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPublicID(t *testing.T) {
+	id := NewPublicID()
+
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), id)
+	assert.NotEqual(t, id, NewPublicID())
+}
+
+func TestParsePublicID(t *testing.T) {
+	id, err := ParsePublicID(" 3F2504E0-4F89-41D3-9A0C-0305E82C3301 ")
+	require.NoError(t, err)
+	assert.Equal(t, "3f2504e0-4f89-41d3-9a0c-0305e82c3301", id)
+
+	for _, invalid := range []string{
+		"",
+		"123",
+		"3f2504e04f8941d39a0c0305e82c3301",
+		"3f2504e0-4f89-41d3-9a0c-0305e82c330",
+		"3f2504e0_4f89-41d3-9a0c-0305e82c3301",
+		"3f2504e0-4f89-41d3-9a0c-0305e82c330g",
+	} {
+		_, err := ParsePublicID(invalid)
+		assert.ErrorIs(t, err, ErrInvalidPublicID, invalid)
+		assert.False(t, IsPublicID(invalid), invalid)
+	}
+}
+
+func TestNewOrder_AssignsPublicID(t *testing.T) {
+	order, err := NewOrder(1)
+
+	require.NoError(t, err)
+	assert.True(t, IsPublicID(order.PublicID))
+}
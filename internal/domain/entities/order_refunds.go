@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Longest reason and reference a refund accepts
+const (
+	MaxRefundReasonLength    = 500
+	MaxRefundReferenceLength = 100
+)
+
+// Errors returned when a refund is rejected
+var (
+	ErrRefundNotAllowed   = errors.New("order cannot be refunded in current status")
+	ErrInvalidRefund      = errors.New("invalid refund")
+	ErrRefundExceedsTotal = errors.New("refund amount exceeds the order total")
+)
+
+// Refund is money paid back to the customer for an order. The refunds of an order never add up to more
+// than its total, RefundedAmount on the order holds their sum.
+type Refund struct {
+	ID      uint    `json:"id"`
+	OrderID uint    `json:"order_id"`
+	Amount  float64 `json:"amount"`
+	Reason  string  `json:"reason,omitempty"`
+	// Reference identifies the refund with the payment provider or in the caller's books
+	Reference string    `json:"reference,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Clone returns a copy of the refund
+func (r *Refund) Clone() *Refund {
+	clone := *r
+	return &clone
+}
+
+// Refund pays amount of the order back, see RefundAmount, and returns the refund to store with the order
+func (o *Order) Refund(amount float64, reason, reference string, at time.Time) (*Refund, error) {
+	reason = strings.TrimSpace(reason)
+	reference = strings.TrimSpace(reference)
+	if utf8.RuneCountInString(reason) > MaxRefundReasonLength {
+		return nil, fmt.Errorf("%w: reason exceeds %d characters", ErrInvalidRefund, MaxRefundReasonLength)
+	}
+	if utf8.RuneCountInString(reference) > MaxRefundReferenceLength {
+		return nil, fmt.Errorf("%w: reference exceeds %d characters", ErrInvalidRefund, MaxRefundReferenceLength)
+	}
+
+	if err := o.RefundAmount(amount); err != nil {
+		return nil, err
+	}
+	return &Refund{
+		OrderID:   o.ID,
+		Amount:    amount,
+		Reason:    reason,
+		Reference: reference,
+		CreatedAt: at.UTC(),
+	}, nil
+}
+
+// RefundRemaining moves the order to refunded through the order state machine, paying back what earlier
+// refunds left. It returns the refund of that remainder, nil when nothing was left to pay back.
+func (o *Order) RefundRemaining(reason string, at time.Time) (*Refund, error) {
+	remaining := o.RemainingRefundableAmount()
+	if err := o.TransitionTo(OrderStatusRefunded, AtTime(at)); err != nil {
+		return nil, err
+	}
+	if remaining == 0 {
+		return nil, nil
+	}
+	return &Refund{
+		OrderID:   o.ID,
+		Amount:    remaining,
+		Reason:    strings.TrimSpace(reason),
+		CreatedAt: at.UTC(),
+	}, nil
+}
+
+// RemainingRefundableAmount returns the part of the order total not refunded yet
+func (o *Order) RemainingRefundableAmount() float64 {
+	return float64(toCents(o.TotalAmount)-toCents(o.RefundedAmount)) / 100
+}
@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func deliveredOrder(t *testing.T) *Order {
+	t.Helper()
+	order, _ := NewOrder(123)
+	order.ID = 1
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 3, 10.10))
+	order.Status = OrderStatusDelivered
+	return order
+}
+
+func TestOrder_Refund(t *testing.T) {
+	order := deliveredOrder(t)
+	at := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	refund, err := order.Refund(10.10, " Damaged mug ", " RF-1 ", at)
+
+	require.NoError(t, err)
+	assert.Equal(t, &Refund{OrderID: 1, Amount: 10.10, Reason: "Damaged mug", Reference: "RF-1", CreatedAt: at}, refund)
+	assert.Equal(t, 10.10, order.RefundedAmount)
+	assert.Equal(t, OrderStatusDelivered, order.Status)
+	assert.InDelta(t, 20.20, order.RemainingRefundableAmount(), 0.001)
+}
+
+func TestOrder_Refund_Rejected(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    OrderStatus
+		amount    float64
+		reason    string
+		reference string
+		expected  error
+	}{
+		{name: "shipped order", status: OrderStatusShipped, amount: 5, expected: ErrRefundNotAllowed},
+		{name: "above the total", status: OrderStatusDelivered, amount: 30.31, expected: ErrRefundExceedsTotal},
+		{name: "zero amount", status: OrderStatusDelivered, amount: 0, expected: ErrInvalidRefund},
+		{name: "fraction of a cent", status: OrderStatusDelivered, amount: 0.001, expected: ErrInvalidRefund},
+		{name: "long reason", status: OrderStatusDelivered, amount: 5, reason: strings.Repeat("x", MaxRefundReasonLength+1), expected: ErrInvalidRefund},
+		{name: "long reference", status: OrderStatusDelivered, amount: 5, reference: strings.Repeat("x", MaxRefundReferenceLength+1), expected: ErrInvalidRefund},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := deliveredOrder(t)
+			order.Status = tt.status
+
+			refund, err := order.Refund(tt.amount, tt.reason, tt.reference, time.Now())
+
+			assert.Nil(t, refund)
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Zero(t, order.RefundedAmount)
+		})
+	}
+}
+
+func TestOrder_RefundRemaining(t *testing.T) {
+	t.Run("refunds what partial refunds left", func(t *testing.T) {
+		order := deliveredOrder(t)
+		_, err := order.Refund(10.10, "", "", time.Now())
+		require.NoError(t, err)
+
+		refund, err := order.RefundRemaining("Refunded in full", time.Now())
+
+		require.NoError(t, err)
+		assert.InDelta(t, 20.20, refund.Amount, 0.001)
+		assert.Equal(t, "Refunded in full", refund.Reason)
+		assert.Equal(t, OrderStatusRefunded, order.Status)
+		assert.InDelta(t, 30.30, order.RefundedAmount, 0.001)
+	})
+
+	t.Run("nothing left to refund", func(t *testing.T) {
+		order := deliveredOrder(t)
+		order.TotalAmount = 0
+
+		refund, err := order.RefundRemaining("", time.Now())
+
+		require.NoError(t, err)
+		assert.Nil(t, refund)
+		assert.Equal(t, OrderStatusRefunded, order.Status)
+	})
+
+	t.Run("passes the state machine", func(t *testing.T) {
+		order := deliveredOrder(t)
+		order.Status = OrderStatusShipped
+
+		refund, err := order.RefundRemaining("", time.Now())
+
+		assert.Nil(t, refund)
+		var transitionErr *TransitionError
+		assert.ErrorAs(t, err, &transitionErr)
+		assert.Zero(t, order.RefundedAmount)
+	})
+}
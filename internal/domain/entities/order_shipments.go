@@ -0,0 +1,169 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Errors returned when a shipment is rejected
+var (
+	ErrShipmentNotAllowed       = errors.New("only processing or partially shipped orders can be shipped in parts")
+	ErrInvalidShipment          = errors.New("invalid shipment")
+	ErrShipmentAlreadyDelivered = errors.New("shipment is already delivered")
+)
+
+// ShipmentItem is the quantity of a product sent in a shipment
+type ShipmentItem struct {
+	ProductID uint `json:"product_id"`
+	Quantity  int  `json:"quantity"`
+}
+
+// Shipment is a parcel carrying part or all of an order
+type Shipment struct {
+	ID             uint           `json:"id"`
+	OrderID        uint           `json:"order_id"`
+	Items          []ShipmentItem `json:"items"`
+	Carrier        string         `json:"carrier,omitempty"`
+	TrackingNumber string         `json:"tracking_number,omitempty"`
+	ShippedAt      time.Time      `json:"shipped_at"`
+	DeliveredAt    *time.Time     `json:"delivered_at,omitempty"`
+}
+
+// IsDelivered checks if the shipment reached the customer
+func (s *Shipment) IsDelivered() bool {
+	return s.DeliveredAt != nil
+}
+
+// MarkDelivered records that the shipment reached the customer at the given instant
+func (s *Shipment) MarkDelivered(at time.Time) error {
+	if s.IsDelivered() {
+		return ErrShipmentAlreadyDelivered
+	}
+	deliveredAt := at.UTC()
+	s.DeliveredAt = &deliveredAt
+	return nil
+}
+
+// Clone returns a copy of the shipment that shares no memory with it
+func (s *Shipment) Clone() *Shipment {
+	clone := *s
+	clone.Items = append([]ShipmentItem(nil), s.Items...)
+	if s.DeliveredAt != nil {
+		deliveredAt := *s.DeliveredAt
+		clone.DeliveredAt = &deliveredAt
+	}
+	return &clone
+}
+
+// UnshippedQuantities returns, per product of the order, the quantity not covered by shipments yet
+func (o *Order) UnshippedQuantities(shipments []*Shipment) map[uint]int {
+	remaining := make(map[uint]int, len(o.Items))
+	for _, item := range o.Items {
+		remaining[item.ProductID] += item.Quantity
+	}
+	for _, shipment := range shipments {
+		for _, item := range shipment.Items {
+			remaining[item.ProductID] -= item.Quantity
+		}
+	}
+	return remaining
+}
+
+// NewShipment builds a shipment of items for the order given the shipments it already has.
+// A product may appear once per shipment and never with more than its unshipped quantity.
+func (o *Order) NewShipment(shipments []*Shipment, items []ShipmentItem, carrier, trackingNumber string, shippedAt time.Time) (*Shipment, error) {
+	if o.Status != OrderStatusProcessing && o.Status != OrderStatusPartiallyShipped {
+		return nil, ErrShipmentNotAllowed
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: at least one item is required", ErrInvalidShipment)
+	}
+
+	trackingNumber = strings.TrimSpace(trackingNumber)
+	if utf8.RuneCountInString(trackingNumber) > MaxTrackingNumberLength {
+		return nil, ErrTrackingNumberTooLong
+	}
+
+	remaining := o.UnshippedQuantities(shipments)
+	seen := make(map[uint]bool, len(items))
+	for i, item := range items {
+		unshipped, inOrder := remaining[item.ProductID]
+		switch {
+		case !inOrder:
+			return nil, fmt.Errorf("%w: item %d: product ID %d is not in the order", ErrInvalidShipment, i, item.ProductID)
+		case seen[item.ProductID]:
+			return nil, fmt.Errorf("%w: item %d: duplicate product ID %d", ErrInvalidShipment, i, item.ProductID)
+		case item.Quantity <= 0:
+			return nil, fmt.Errorf("%w: item %d: quantity must be positive", ErrInvalidShipment, i)
+		case item.Quantity > unshipped:
+			return nil, fmt.Errorf("%w: item %d: quantity %d exceeds the %d unshipped units of product ID %d",
+				ErrInvalidShipment, i, item.Quantity, unshipped, item.ProductID)
+		}
+		seen[item.ProductID] = true
+	}
+
+	return &Shipment{
+		OrderID:        o.ID,
+		Items:          append([]ShipmentItem(nil), items...),
+		Carrier:        strings.TrimSpace(carrier),
+		TrackingNumber: trackingNumber,
+		ShippedAt:      shippedAt.UTC(),
+	}, nil
+}
+
+// ApplyShipments derives the order status from its shipments: delivered when every unit was
+// shipped and every shipment delivered, shipped when every unit was shipped, partially shipped
+// otherwise. An order without shipments, or one that left the shipping flow such as a refunded
+// order, keeps its status.
+func (o *Order) ApplyShipments(shipments []*Shipment) error {
+	if len(shipments) == 0 {
+		return nil
+	}
+	switch o.Status {
+	case OrderStatusProcessing, OrderStatusPartiallyShipped, OrderStatusShipped:
+	default:
+		return nil
+	}
+
+	target := OrderStatusShipped
+	for _, unshipped := range o.UnshippedQuantities(shipments) {
+		if unshipped > 0 {
+			target = OrderStatusPartiallyShipped
+			break
+		}
+	}
+	if target == OrderStatusShipped && allDelivered(shipments) {
+		target = OrderStatusDelivered
+	}
+
+	// Delivered is only reachable through shipped, every step passes the state machine
+	for o.Status != target {
+		next := target
+		if target == OrderStatusDelivered && o.Status != OrderStatusShipped {
+			next = OrderStatusShipped
+		}
+		if err := o.TransitionTo(next, withShipments()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func allDelivered(shipments []*Shipment) bool {
+	for _, shipment := range shipments {
+		if !shipment.IsDelivered() {
+			return false
+		}
+	}
+	return true
+}
+
+// withShipments marks a transition as derived from the shipments of the order
+func withShipments() TransitionOption {
+	return func(t *Transition) {
+		t.fromShipments = true
+	}
+}
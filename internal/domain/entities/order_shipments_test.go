@@ -0,0 +1,133 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newProcessingOrder builds a processing order of 3 units of product 1 and 1 unit of product 2
+func newProcessingOrder(t *testing.T) *Order {
+	t.Helper()
+	order, _ := NewOrder(123)
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 3, 10.0))
+	require.NoError(t, order.AddItem(2, "SKU-002", "Product 2", 1, 5.0))
+	order.Status = OrderStatusProcessing
+	return order
+}
+
+func TestOrder_NewShipment_Validation(t *testing.T) {
+	order := newProcessingOrder(t)
+	shipped := []*Shipment{{Items: []ShipmentItem{{ProductID: 1, Quantity: 2}}}}
+
+	tests := []struct {
+		name   string
+		items  []ShipmentItem
+		reason string
+	}{
+		{"no items", nil, "at least one item is required"},
+		{"unknown product", []ShipmentItem{{ProductID: 9, Quantity: 1}}, "product ID 9 is not in the order"},
+		{"duplicate product", []ShipmentItem{{ProductID: 2, Quantity: 1}, {ProductID: 2, Quantity: 1}}, "duplicate product ID 2"},
+		{"zero quantity", []ShipmentItem{{ProductID: 2, Quantity: 0}}, "quantity must be positive"},
+		{"over the unshipped quantity", []ShipmentItem{{ProductID: 1, Quantity: 2}}, "quantity 2 exceeds the 1 unshipped units of product ID 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shipment, err := order.NewShipment(shipped, tt.items, "DHL", "JD01", time.Now())
+
+			assert.ErrorIs(t, err, ErrInvalidShipment)
+			assert.Contains(t, err.Error(), tt.reason)
+			assert.Nil(t, shipment)
+		})
+	}
+}
+
+func TestOrder_NewShipment_RequiresProcessingOrder(t *testing.T) {
+	order := newProcessingOrder(t)
+	order.Status = OrderStatusConfirmed
+
+	_, err := order.NewShipment(nil, []ShipmentItem{{ProductID: 1, Quantity: 1}}, "", "", time.Now())
+
+	assert.ErrorIs(t, err, ErrShipmentNotAllowed)
+}
+
+func TestOrder_NewShipment_RejectsLongTrackingNumber(t *testing.T) {
+	order := newProcessingOrder(t)
+
+	_, err := order.NewShipment(nil, []ShipmentItem{{ProductID: 1, Quantity: 1}}, "DHL", strings.Repeat("1", MaxTrackingNumberLength+1), time.Now())
+
+	assert.ErrorIs(t, err, ErrTrackingNumberTooLong)
+}
+
+func TestOrder_ApplyShipments_DerivesStatus(t *testing.T) {
+	order := newProcessingOrder(t)
+	var shipments []*Shipment
+	ship := func(items ...ShipmentItem) *Shipment {
+		shipment, err := order.NewShipment(shipments, items, " DHL ", "JD01", time.Now())
+		require.NoError(t, err)
+		shipments = append(shipments, shipment)
+		require.NoError(t, order.ApplyShipments(shipments))
+		return shipment
+	}
+
+	// Part of the units shipped
+	first := ship(ShipmentItem{ProductID: 1, Quantity: 2})
+	assert.Equal(t, "DHL", first.Carrier)
+	assert.Equal(t, OrderStatusPartiallyShipped, order.Status)
+	assert.Equal(t, map[uint]int{1: 1, 2: 1}, order.UnshippedQuantities(shipments))
+
+	// Every unit shipped
+	second := ship(ShipmentItem{ProductID: 1, Quantity: 1}, ShipmentItem{ProductID: 2, Quantity: 1})
+	assert.Equal(t, OrderStatusShipped, order.Status)
+
+	// Delivered once every shipment is
+	require.NoError(t, first.MarkDelivered(time.Now()))
+	require.NoError(t, order.ApplyShipments(shipments))
+	assert.Equal(t, OrderStatusShipped, order.Status)
+
+	require.NoError(t, second.MarkDelivered(time.Now()))
+	require.NoError(t, order.ApplyShipments(shipments))
+	assert.Equal(t, OrderStatusDelivered, order.Status)
+
+	assert.ErrorIs(t, second.MarkDelivered(time.Now()), ErrShipmentAlreadyDelivered)
+}
+
+func TestOrder_ApplyShipments_DeliversFromPartiallyShipped(t *testing.T) {
+	order := newProcessingOrder(t)
+	order.Status = OrderStatusPartiallyShipped
+	deliveredAt := time.Now()
+	shipments := []*Shipment{{
+		Items:       []ShipmentItem{{ProductID: 1, Quantity: 3}, {ProductID: 2, Quantity: 1}},
+		DeliveredAt: &deliveredAt,
+	}}
+
+	require.NoError(t, order.ApplyShipments(shipments))
+
+	assert.Equal(t, OrderStatusDelivered, order.Status)
+}
+
+func TestOrder_ApplyShipments_KeepsStatusOutsideShipping(t *testing.T) {
+	order := newProcessingOrder(t)
+	order.Status = OrderStatusRefunded
+	deliveredAt := time.Now()
+	shipments := []*Shipment{{Items: []ShipmentItem{{ProductID: 1, Quantity: 3}, {ProductID: 2, Quantity: 1}}, DeliveredAt: &deliveredAt}}
+
+	require.NoError(t, order.ApplyShipments(shipments))
+
+	assert.Equal(t, OrderStatusRefunded, order.Status)
+}
+
+func TestOrder_TransitionTo_PartiallyShippedFollowsShipments(t *testing.T) {
+	order := newProcessingOrder(t)
+
+	err := order.TransitionTo(OrderStatusPartiallyShipped)
+
+	var transitionErr *TransitionError
+	require.ErrorAs(t, err, &transitionErr)
+	assert.Equal(t, "order status follows its shipments, record a shipment instead", transitionErr.Reason)
+	assert.NotContains(t, order.AllowedTransitions(), OrderStatusPartiallyShipped)
+}
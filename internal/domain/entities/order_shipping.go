@@ -0,0 +1,89 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ShippingMethod is how an order reaches the customer
+type ShippingMethod string
+
+const (
+	ShippingMethodStandard ShippingMethod = "standard"
+	ShippingMethodExpress  ShippingMethod = "express"
+	ShippingMethodPickup   ShippingMethod = "pickup"
+)
+
+// MaxTrackingNumberLength is the longest tracking number a shipped order accepts
+const MaxTrackingNumberLength = 100
+
+// Errors returned when the shipping details of a transition are rejected
+var (
+	ErrInvalidShippingMethod   = errors.New("invalid shipping method")
+	ErrEstimatedDeliveryInPast = errors.New("estimated delivery cannot be in the past")
+	ErrTrackingNumberTooLong   = fmt.Errorf("tracking number must be at most %d characters", MaxTrackingNumberLength)
+)
+
+// ValidShippingMethod reports whether method is a known shipping method
+func ValidShippingMethod(method ShippingMethod) bool {
+	switch method {
+	case ShippingMethodStandard, ShippingMethodExpress, ShippingMethodPickup:
+		return true
+	default:
+		return false
+	}
+}
+
+// WithShipping sets the shipping method and estimated delivery when confirming an order.
+// An empty method keeps the standard default, a nil estimate leaves the delivery date open.
+func WithShipping(method ShippingMethod, estimatedDeliveryAt *time.Time) TransitionOption {
+	return func(t *Transition) {
+		t.ShippingMethod = method
+		t.EstimatedDeliveryAt = estimatedDeliveryAt
+	}
+}
+
+// WithTracking records the carrier and tracking number when shipping an order
+func WithTracking(carrier, trackingNumber string) TransitionOption {
+	return func(t *Transition) {
+		t.Carrier = strings.TrimSpace(carrier)
+		t.TrackingNumber = strings.TrimSpace(trackingNumber)
+	}
+}
+
+func requireValidShipping(_ *Order, t *Transition) error {
+	if t.ShippingMethod != "" && !ValidShippingMethod(t.ShippingMethod) {
+		return fmt.Errorf("%w %q", ErrInvalidShippingMethod, t.ShippingMethod)
+	}
+	if t.EstimatedDeliveryAt != nil && t.EstimatedDeliveryAt.Before(t.At) {
+		return ErrEstimatedDeliveryInPast
+	}
+	return nil
+}
+
+func requireValidTracking(_ *Order, t *Transition) error {
+	if utf8.RuneCountInString(t.TrackingNumber) > MaxTrackingNumberLength {
+		return ErrTrackingNumberTooLong
+	}
+	return nil
+}
+
+func recordShipping(o *Order, t *Transition) {
+	o.ShippingMethod = t.ShippingMethod
+	if o.ShippingMethod == "" {
+		o.ShippingMethod = ShippingMethodStandard
+	}
+	o.EstimatedDeliveryAt = nil
+	if t.EstimatedDeliveryAt != nil {
+		estimatedDeliveryAt := t.EstimatedDeliveryAt.UTC()
+		o.EstimatedDeliveryAt = &estimatedDeliveryAt
+	}
+}
+
+func recordTracking(o *Order, t *Transition) {
+	o.Carrier = t.Carrier
+	o.TrackingNumber = t.TrackingNumber
+}
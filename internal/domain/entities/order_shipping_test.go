@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrder_ConfirmOrder_Shipping(t *testing.T) {
+	newPending := func() *Order {
+		order, _ := NewOrder(123)
+		require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 1, 10.0))
+		return order
+	}
+
+	t.Run("defaults to standard without an estimate", func(t *testing.T) {
+		order := newPending()
+
+		require.NoError(t, order.ConfirmOrder())
+
+		assert.Equal(t, ShippingMethodStandard, order.ShippingMethod)
+		assert.Nil(t, order.EstimatedDeliveryAt)
+	})
+
+	t.Run("records method and estimate", func(t *testing.T) {
+		order := newPending()
+		estimate := time.Now().Add(48 * time.Hour)
+
+		require.NoError(t, order.ConfirmOrder(WithShipping(ShippingMethodExpress, &estimate)))
+
+		assert.Equal(t, ShippingMethodExpress, order.ShippingMethod)
+		require.NotNil(t, order.EstimatedDeliveryAt)
+		assert.True(t, estimate.Equal(*order.EstimatedDeliveryAt))
+	})
+
+	t.Run("rejects an estimate in the past", func(t *testing.T) {
+		order := newPending()
+		estimate := time.Now().Add(-time.Hour)
+
+		err := order.ConfirmOrder(WithShipping(ShippingMethodPickup, &estimate))
+
+		assert.ErrorIs(t, err, ErrEstimatedDeliveryInPast)
+		assert.Equal(t, OrderStatusPending, order.Status)
+		assert.Empty(t, order.ShippingMethod)
+	})
+
+	t.Run("rejects an unknown method", func(t *testing.T) {
+		order := newPending()
+
+		err := order.ConfirmOrder(WithShipping("teleport", nil))
+
+		assert.ErrorIs(t, err, ErrInvalidShippingMethod)
+		assert.Equal(t, OrderStatusPending, order.Status)
+	})
+}
+
+func TestOrder_TransitionToShipped_Tracking(t *testing.T) {
+	t.Run("records carrier and tracking number", func(t *testing.T) {
+		order, _ := NewOrder(123)
+		order.Status = OrderStatusProcessing
+
+		require.NoError(t, order.TransitionToShipped(WithTracking(" DHL ", "JD0123456789")))
+
+		assert.Equal(t, "DHL", order.Carrier)
+		assert.Equal(t, "JD0123456789", order.TrackingNumber)
+	})
+
+	t.Run("rejects a long tracking number", func(t *testing.T) {
+		order, _ := NewOrder(123)
+		order.Status = OrderStatusProcessing
+
+		err := order.TransitionToShipped(WithTracking("DHL", strings.Repeat("1", MaxTrackingNumberLength+1)))
+
+		assert.ErrorIs(t, err, ErrTrackingNumberTooLong)
+		assert.Equal(t, OrderStatusProcessing, order.Status)
+		assert.Empty(t, order.TrackingNumber)
+	})
+}
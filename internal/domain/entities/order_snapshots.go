@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"slices"
+	"time"
+)
+
+// SnapshotReason tells which change of an order a snapshot was taken for
+type SnapshotReason string
+
+// Changes of an order that take a snapshot
+const (
+	SnapshotReasonConfirmed SnapshotReason = "confirmed"
+	SnapshotReasonAmended   SnapshotReason = "amended"
+)
+
+// OrderSnapshot is an immutable copy of an order as it was right after a confirmation or an amendment,
+// kept for accounting. Versions count the snapshots of an order from 1. Payload holds the order encoded in
+// the event schema version SchemaVersion, so a stored snapshot reads the same however the order changes
+// later.
+type OrderSnapshot struct {
+	ID            uint           `json:"id"`
+	OrderID       uint           `json:"order_id"`
+	Version       int            `json:"version"`
+	Reason        SnapshotReason `json:"reason"`
+	SchemaVersion int            `json:"schema_version"`
+	TakenAt       time.Time      `json:"taken_at"`
+	Payload       []byte         `json:"payload"`
+}
+
+// Clone returns a copy of the snapshot that shares no payload with it
+func (s *OrderSnapshot) Clone() *OrderSnapshot {
+	clone := *s
+	clone.Payload = slices.Clone(s.Payload)
+	return &clone
+}
@@ -0,0 +1,349 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Transition describes a single status change being applied to an order
+type Transition struct {
+	From   OrderStatus
+	To     OrderStatus
+	Reason string
+	// At is the instant the transition is evaluated at, guards use it for time based checks
+	At time.Time
+
+	// Shipping details, see WithShipping and WithTracking
+	ShippingMethod      ShippingMethod
+	EstimatedDeliveryAt *time.Time
+	Carrier             string
+	TrackingNumber      string
+
+	releaseHold     bool
+	fromShipments   bool
+	overrideMinimum bool
+}
+
+// TransitionGuard rejects a transition by returning an error
+type TransitionGuard func(o *Order, t *Transition) error
+
+// TransitionHook runs after the order status changed
+type TransitionHook func(o *Order, t *Transition)
+
+// TransitionOption customizes a call to TransitionTo
+type TransitionOption func(t *Transition)
+
+// WithReason attaches a reason to the transition, required when placing an order on hold
+func WithReason(reason string) TransitionOption {
+	return func(t *Transition) {
+		t.Reason = strings.TrimSpace(reason)
+	}
+}
+
+// WithHoldRelease allows an on-hold order to return to the status it was held from
+func WithHoldRelease() TransitionOption {
+	return func(t *Transition) {
+		t.releaseHold = true
+	}
+}
+
+// AtTime evaluates the transition at the given instant instead of the current time
+func AtTime(at time.Time) TransitionOption {
+	return func(t *Transition) {
+		t.At = at
+	}
+}
+
+// TransitionError is returned when an order cannot move to the requested status
+type TransitionError struct {
+	From    OrderStatus
+	To      OrderStatus
+	Allowed []OrderStatus
+	Reason  string
+
+	// Err is the underlying cause, for example ErrOrderExpired
+	Err error
+}
+
+func (e *TransitionError) Error() string {
+	return e.Reason
+}
+
+func (e *TransitionError) Unwrap() error {
+	return e.Err
+}
+
+type transitionRule struct {
+	guard TransitionGuard
+	hook  TransitionHook
+
+	// needsReason and needsRelease are satisfied by transition options rather than by the order state
+	needsReason  bool
+	needsRelease bool
+	// needsShipments marks statuses derived from shipments, only ApplyShipments reaches them
+	needsShipments bool
+}
+
+// orderStatuses lists every known status in lifecycle order
+var orderStatuses = []OrderStatus{
+	OrderStatusPending,
+	OrderStatusConfirmed,
+	OrderStatusProcessing,
+	OrderStatusOnHold,
+	OrderStatusPartiallyShipped,
+	OrderStatusShipped,
+	OrderStatusDelivered,
+	OrderStatusReturnRequested,
+	OrderStatusReturned,
+	OrderStatusRefunded,
+	OrderStatusCancelled,
+	OrderStatusExpired,
+}
+
+// orderTransitions is the order state machine, keyed by current status and then target status.
+// Every status in orderStatuses must have an entry, terminal statuses map to no targets.
+var orderTransitions = map[OrderStatus]map[OrderStatus]transitionRule{
+	OrderStatusPending: {
+		OrderStatusConfirmed: {guard: allGuards(requireNotExpired, requireItems, requireMinimumAmount, requireValidShipping), hook: allHooks(clearExpiry, recordShipping)},
+		OrderStatusOnHold:    {needsReason: true, hook: recordHold},
+		OrderStatusCancelled: {hook: clearHold},
+		OrderStatusExpired:   {guard: requireExpiryPassed},
+	},
+	OrderStatusConfirmed: {
+		OrderStatusProcessing: {},
+		OrderStatusOnHold:     {needsReason: true, hook: recordHold},
+		OrderStatusCancelled:  {hook: clearHold},
+	},
+	OrderStatusProcessing: {
+		OrderStatusShipped:          {guard: requireValidTracking, hook: recordTracking},
+		OrderStatusPartiallyShipped: {needsShipments: true},
+		OrderStatusOnHold:           {needsReason: true, hook: recordHold},
+		OrderStatusCancelled:        {hook: clearHold},
+	},
+	OrderStatusPartiallyShipped: {
+		OrderStatusShipped: {needsShipments: true},
+	},
+	OrderStatusOnHold: {
+		OrderStatusPending:    {needsRelease: true, guard: requireHeldFrom, hook: clearHold},
+		OrderStatusConfirmed:  {needsRelease: true, guard: requireHeldFrom, hook: clearHold},
+		OrderStatusProcessing: {needsRelease: true, guard: requireHeldFrom, hook: clearHold},
+		OrderStatusCancelled:  {hook: clearHold},
+	},
+	OrderStatusShipped: {
+		OrderStatusDelivered: {},
+	},
+	OrderStatusDelivered: {
+		OrderStatusReturnRequested: {},
+		OrderStatusRefunded:        {hook: refundRemaining},
+	},
+	OrderStatusReturnRequested: {
+		OrderStatusReturned: {},
+	},
+	OrderStatusReturned: {
+		OrderStatusRefunded: {hook: refundRemaining},
+	},
+	OrderStatusRefunded:  {},
+	OrderStatusCancelled: {},
+	OrderStatusExpired:   {},
+}
+
+// invalidTransitionMessages explains, per target status, which statuses the target can be reached from
+var invalidTransitionMessages = map[OrderStatus]string{
+	OrderStatusPending:          "orders cannot return to pending",
+	OrderStatusConfirmed:        "only pending orders can be confirmed",
+	OrderStatusProcessing:       "only confirmed orders can be moved to processing",
+	OrderStatusShipped:          "only processing or partially shipped orders can be shipped",
+	OrderStatusPartiallyShipped: "only processing orders can be partially shipped",
+	OrderStatusDelivered:        "only shipped orders can be delivered",
+	OrderStatusCancelled:        "order cannot be cancelled in current status",
+	OrderStatusOnHold:           "only pending, confirmed or processing orders can be placed on hold",
+	OrderStatusReturnRequested:  "only delivered orders can have a return requested",
+	OrderStatusReturned:         "only orders with a requested return can be returned",
+	OrderStatusRefunded:         "only delivered or returned orders can be refunded",
+	OrderStatusExpired:          "only pending orders can expire",
+}
+
+// TransitionTo moves the order to the given status if the state machine allows it
+func (o *Order) TransitionTo(status OrderStatus, opts ...TransitionOption) error {
+	t := &Transition{From: o.Status, To: status, At: time.Now()}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	if _, known := orderTransitions[status]; !known {
+		return o.transitionError(t, errors.New("invalid order status"))
+	}
+
+	rule, ok := orderTransitions[o.Status][status]
+	if !ok {
+		cause := errors.New(invalidTransitionMessages[status])
+		if o.IsOnHold() {
+			cause = errors.New("order is on hold")
+		} else if o.Status == OrderStatusExpired {
+			cause = ErrOrderExpired
+		}
+		if o.repeats(t) {
+			cause = &alreadyInStatusError{cause: cause}
+		}
+		return o.transitionError(t, cause)
+	}
+
+	if rule.needsReason && t.Reason == "" {
+		return o.transitionError(t, errors.New("hold reason is required"))
+	}
+
+	if rule.needsRelease && !t.releaseHold {
+		return o.transitionError(t, errors.New("order is on hold"))
+	}
+
+	if rule.needsShipments && !t.fromShipments {
+		return o.transitionError(t, errors.New("order status follows its shipments, record a shipment instead"))
+	}
+
+	if rule.guard != nil {
+		if err := rule.guard(o, t); err != nil {
+			return o.transitionError(t, err)
+		}
+	}
+
+	o.Status = status
+	if rule.hook != nil {
+		rule.hook(o, t)
+	}
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// ValidTransitions returns the statuses reachable from the given status, ignoring guards
+func ValidTransitions(from OrderStatus) []OrderStatus {
+	targets := orderTransitions[from]
+	valid := make([]OrderStatus, 0, len(targets))
+	for _, status := range orderStatuses {
+		if _, ok := targets[status]; ok {
+			valid = append(valid, status)
+		}
+	}
+	return valid
+}
+
+// AllowedTransitions returns the statuses the order can move to from its current state.
+// Guards are evaluated against the order, options such as a hold reason are assumed to be supplied.
+// Statuses derived from shipments are left out since callers cannot request them.
+func (o *Order) AllowedTransitions() []OrderStatus {
+	allowed := make([]OrderStatus, 0)
+	for _, status := range ValidTransitions(o.Status) {
+		rule := orderTransitions[o.Status][status]
+		if rule.needsShipments {
+			continue
+		}
+		if rule.guard != nil && rule.guard(o, &Transition{From: o.Status, To: status, At: time.Now()}) != nil {
+			continue
+		}
+		allowed = append(allowed, status)
+	}
+	return allowed
+}
+
+// repeats reports whether t asks for the status the order already has. Tracking other than the
+// recorded one describes a different shipment, so it is not a repeat.
+func (o *Order) repeats(t *Transition) bool {
+	if t.To != o.Status {
+		return false
+	}
+	return t.TrackingNumber == "" || (t.Carrier == o.Carrier && t.TrackingNumber == o.TrackingNumber)
+}
+
+// alreadyInStatusError keeps the reason a repeated transition is invalid while matching ErrAlreadyInStatus
+type alreadyInStatusError struct {
+	cause error
+}
+
+func (e *alreadyInStatusError) Error() string {
+	return e.cause.Error()
+}
+
+func (e *alreadyInStatusError) Unwrap() []error {
+	return []error{e.cause, ErrAlreadyInStatus}
+}
+
+func (o *Order) transitionError(t *Transition, cause error) error {
+	return &TransitionError{
+		From:    t.From,
+		To:      t.To,
+		Allowed: o.AllowedTransitions(),
+		Reason:  cause.Error(),
+		Err:     cause,
+	}
+}
+
+// Transition guards
+
+// allGuards combines guards, the first failing guard rejects the transition
+func allGuards(guards ...TransitionGuard) TransitionGuard {
+	return func(o *Order, t *Transition) error {
+		for _, guard := range guards {
+			if err := guard(o, t); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func requireItems(o *Order, _ *Transition) error {
+	if o.IsEmpty() {
+		return errors.New("cannot confirm empty order")
+	}
+	return nil
+}
+
+func requireNotExpired(o *Order, t *Transition) error {
+	if o.IsExpiredAt(t.At) {
+		return ErrOrderExpired
+	}
+	return nil
+}
+
+func requireExpiryPassed(o *Order, t *Transition) error {
+	if !o.IsExpiredAt(t.At) {
+		return errors.New("order has not reached its expiry time")
+	}
+	return nil
+}
+
+func requireHeldFrom(o *Order, t *Transition) error {
+	if o.HeldFromStatus != t.To {
+		return errors.New("order is on hold")
+	}
+	return nil
+}
+
+// Transition hooks
+
+// allHooks combines hooks, running them in order
+func allHooks(hooks ...TransitionHook) TransitionHook {
+	return func(o *Order, t *Transition) {
+		for _, hook := range hooks {
+			hook(o, t)
+		}
+	}
+}
+
+func recordHold(o *Order, t *Transition) {
+	o.HeldFromStatus = t.From
+	o.HoldReason = t.Reason
+}
+
+func clearHold(o *Order, _ *Transition) {
+	o.HeldFromStatus = ""
+	o.HoldReason = ""
+}
+
+func clearExpiry(o *Order, _ *Transition) {
+	o.ExpiresAt = nil
+}
+
+func refundRemaining(o *Order, _ *Transition) {
+	o.RefundedAmount = o.TotalAmount
+}
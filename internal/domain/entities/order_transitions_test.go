@@ -0,0 +1,337 @@
+package entities
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderTransitions_CoverEveryStatus(t *testing.T) {
+	for _, status := range orderStatuses {
+		_, ok := orderTransitions[status]
+		assert.True(t, ok, "status %s has no entry in the state machine", status)
+	}
+	assert.Len(t, orderTransitions, len(orderStatuses))
+
+	for from, targets := range orderTransitions {
+		for to := range targets {
+			assert.NoError(t, ValidateOrderStatus(to), "transition %s -> %s targets an unknown status", from, to)
+		}
+	}
+}
+
+func TestOrder_TransitionTo(t *testing.T) {
+	t.Run("follows the happy path", func(t *testing.T) {
+		order, _ := NewOrder(123)
+		require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 1, 10.0))
+
+		for _, status := range []OrderStatus{
+			OrderStatusConfirmed,
+			OrderStatusProcessing,
+			OrderStatusShipped,
+			OrderStatusDelivered,
+			OrderStatusReturnRequested,
+			OrderStatusReturned,
+			OrderStatusRefunded,
+		} {
+			require.NoError(t, order.TransitionTo(status))
+			assert.Equal(t, status, order.Status)
+		}
+		assert.Equal(t, order.TotalAmount, order.RefundedAmount)
+	})
+
+	t.Run("invalid transition reports allowed statuses", func(t *testing.T) {
+		order, _ := NewOrder(123)
+
+		err := order.TransitionTo(OrderStatusShipped)
+
+		var transitionErr *TransitionError
+		require.ErrorAs(t, err, &transitionErr)
+		assert.Equal(t, OrderStatusPending, transitionErr.From)
+		assert.Equal(t, OrderStatusShipped, transitionErr.To)
+		assert.Equal(t, []OrderStatus{OrderStatusOnHold, OrderStatusCancelled}, transitionErr.Allowed)
+		assert.Equal(t, "only processing or partially shipped orders can be shipped", err.Error())
+		assert.Equal(t, OrderStatusPending, order.Status)
+	})
+
+	t.Run("guard failure keeps the status", func(t *testing.T) {
+		order, _ := NewOrder(123)
+
+		err := order.TransitionTo(OrderStatusConfirmed)
+
+		var transitionErr *TransitionError
+		require.ErrorAs(t, err, &transitionErr)
+		assert.Equal(t, "cannot confirm empty order", transitionErr.Reason)
+		assert.Equal(t, OrderStatusPending, order.Status)
+	})
+
+	t.Run("unknown status is rejected", func(t *testing.T) {
+		order, _ := NewOrder(123)
+
+		err := order.TransitionTo("invalid_status")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid order status")
+	})
+
+	t.Run("hold needs a reason option", func(t *testing.T) {
+		order, _ := NewOrder(123)
+
+		assert.Error(t, order.TransitionTo(OrderStatusOnHold))
+		require.NoError(t, order.TransitionTo(OrderStatusOnHold, WithReason("fraud review")))
+		assert.Equal(t, OrderStatusPending, order.HeldFromStatus)
+		assert.Equal(t, "fraud review", order.HoldReason)
+	})
+
+	t.Run("held order only leaves hold through a release", func(t *testing.T) {
+		order, _ := NewOrder(123)
+		order.Status = OrderStatusConfirmed
+		require.NoError(t, order.PlaceOnHold("fraud review"))
+
+		err := order.TransitionTo(OrderStatusConfirmed)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "order is on hold")
+
+		err = order.TransitionTo(OrderStatusPending, WithHoldRelease())
+		assert.Error(t, err)
+
+		require.NoError(t, order.TransitionTo(OrderStatusConfirmed, WithHoldRelease()))
+		assert.Equal(t, OrderStatusConfirmed, order.Status)
+		assert.Empty(t, order.HeldFromStatus)
+	})
+}
+
+func TestOrder_TransitionTo_RepeatedStatus(t *testing.T) {
+	for _, status := range orderStatuses {
+		t.Run(string(status), func(t *testing.T) {
+			order, _ := NewOrder(123)
+			require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 1, 10.0))
+			order.Status = status
+			updatedAt := order.UpdatedAt
+
+			err := order.TransitionTo(status)
+
+			assert.ErrorIs(t, err, ErrAlreadyInStatus)
+			var transitionErr *TransitionError
+			require.ErrorAs(t, err, &transitionErr)
+			assert.Equal(t, status, transitionErr.From)
+			assert.Equal(t, status, transitionErr.To)
+			assert.Equal(t, status, order.Status)
+			assert.Equal(t, updatedAt, order.UpdatedAt)
+		})
+	}
+
+	t.Run("same tracking repeats the shipment", func(t *testing.T) {
+		order, _ := NewOrder(123)
+		order.Status = OrderStatusShipped
+		order.Carrier = "UPS"
+		order.TrackingNumber = "1Z999"
+
+		err := order.TransitionTo(OrderStatusShipped, WithTracking(" UPS ", "1Z999"))
+
+		assert.ErrorIs(t, err, ErrAlreadyInStatus)
+	})
+
+	t.Run("other tracking is not a repeat", func(t *testing.T) {
+		order, _ := NewOrder(123)
+		order.Status = OrderStatusShipped
+		order.Carrier = "UPS"
+		order.TrackingNumber = "1Z999"
+
+		err := order.TransitionTo(OrderStatusShipped, WithTracking("UPS", "1Z000"))
+
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrAlreadyInStatus)
+		assert.Equal(t, "1Z999", order.TrackingNumber)
+	})
+
+	t.Run("keeps the reason of the rejection", func(t *testing.T) {
+		order, _ := NewOrder(123)
+		order.Status = OrderStatusExpired
+
+		err := order.TransitionTo(OrderStatusExpired)
+
+		assert.ErrorIs(t, err, ErrAlreadyInStatus)
+		assert.ErrorIs(t, err, ErrOrderExpired)
+		assert.Equal(t, ErrOrderExpired.Error(), err.Error())
+	})
+
+	t.Run("other statuses stay invalid", func(t *testing.T) {
+		order, _ := NewOrder(123)
+		order.Status = OrderStatusCancelled
+
+		err := order.TransitionTo(OrderStatusShipped)
+
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrAlreadyInStatus)
+	})
+}
+
+func TestValidTransitions(t *testing.T) {
+	assert.Equal(t, []OrderStatus{OrderStatusOnHold, OrderStatusPartiallyShipped, OrderStatusShipped, OrderStatusCancelled}, ValidTransitions(OrderStatusProcessing))
+	assert.Equal(t, []OrderStatus{OrderStatusReturnRequested, OrderStatusRefunded}, ValidTransitions(OrderStatusDelivered))
+	assert.Empty(t, ValidTransitions(OrderStatusCancelled))
+	assert.Empty(t, ValidTransitions(OrderStatusRefunded))
+	assert.Empty(t, ValidTransitions("invalid_status"))
+}
+
+func TestOrder_AllowedTransitions(t *testing.T) {
+	t.Run("empty pending order cannot be confirmed", func(t *testing.T) {
+		order, _ := NewOrder(123)
+
+		assert.Equal(t, []OrderStatus{OrderStatusOnHold, OrderStatusCancelled}, order.AllowedTransitions())
+	})
+
+	t.Run("held order can only return to its previous status", func(t *testing.T) {
+		order, _ := NewOrder(123)
+		order.Status = OrderStatusProcessing
+		require.NoError(t, order.PlaceOnHold("fraud review"))
+
+		assert.Equal(t, []OrderStatus{OrderStatusProcessing, OrderStatusCancelled}, order.AllowedTransitions())
+	})
+
+	t.Run("terminal status allows nothing", func(t *testing.T) {
+		order, _ := NewOrder(123)
+		order.Status = OrderStatusCancelled
+
+		assert.NotNil(t, order.AllowedTransitions())
+		assert.Empty(t, order.AllowedTransitions())
+	})
+}
+
+// TestOrder_AllowedTransitionsMatchTransitionMethods asserts, for every status and every target,
+// that the transition methods succeed exactly when AllowedTransitions lists the target
+func TestOrder_AllowedTransitionsMatchTransitionMethods(t *testing.T) {
+	methods := map[OrderStatus]func(o *Order) error{
+		OrderStatusConfirmed:       func(o *Order) error { return o.ConfirmOrder() },
+		OrderStatusProcessing:      (*Order).TransitionToProcessing,
+		OrderStatusShipped:         func(o *Order) error { return o.TransitionToShipped() },
+		OrderStatusDelivered:       (*Order).TransitionToDelivered,
+		OrderStatusCancelled:       (*Order).CancelOrder,
+		OrderStatusReturnRequested: (*Order).TransitionToReturnRequested,
+		OrderStatusReturned:        (*Order).TransitionToReturned,
+		OrderStatusRefunded:        (*Order).TransitionToRefunded,
+		OrderStatusOnHold:          func(o *Order) error { return o.PlaceOnHold("fraud review") },
+	}
+
+	newOrder := func(status OrderStatus, withItems bool, heldFrom OrderStatus) *Order {
+		order, _ := NewOrder(123)
+		if withItems {
+			require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 1, 10.0))
+		}
+		order.Status = status
+		order.HeldFromStatus = heldFrom
+		return order
+	}
+
+	type fixture struct {
+		name      string
+		status    OrderStatus
+		withItems bool
+		heldFrom  OrderStatus
+	}
+
+	fixtures := make([]fixture, 0)
+	for _, status := range orderStatuses {
+		if status == OrderStatusOnHold {
+			for _, heldFrom := range []OrderStatus{OrderStatusPending, OrderStatusConfirmed, OrderStatusProcessing} {
+				fixtures = append(fixtures, fixture{name: "on_hold from " + string(heldFrom), status: status, withItems: true, heldFrom: heldFrom})
+			}
+			continue
+		}
+		fixtures = append(fixtures, fixture{name: string(status), status: status, withItems: true})
+	}
+	fixtures = append(fixtures, fixture{name: "empty pending", status: OrderStatusPending})
+
+	for _, f := range fixtures {
+		t.Run(f.name, func(t *testing.T) {
+			allowed := newOrder(f.status, f.withItems, f.heldFrom).AllowedTransitions()
+
+			for _, target := range orderStatuses {
+				expected := slices.Contains(allowed, target)
+
+				order := newOrder(f.status, f.withItems, f.heldFrom)
+				var err error
+				if f.status == OrderStatusOnHold && target != OrderStatusCancelled && target != OrderStatusOnHold {
+					// Leaving a hold for anything but cancellation goes through ReleaseHold
+					if target == f.heldFrom {
+						err = order.ReleaseHold()
+					} else {
+						err = order.TransitionTo(target, WithHoldRelease())
+					}
+				} else if method, ok := methods[target]; ok {
+					err = method(order)
+				} else {
+					err = order.TransitionTo(target, WithReason("fraud review"), WithHoldRelease())
+				}
+
+				assert.Equal(t, expected, err == nil, "%s -> %s: allowed=%v err=%v", f.name, target, allowed, err)
+				if err == nil {
+					assert.Equal(t, target, order.Status)
+				} else {
+					assert.Equal(t, f.status, order.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestOrder_Expiry(t *testing.T) {
+	newPendingOrder := func(ttl time.Duration) *Order {
+		order, _ := NewOrder(123)
+		require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 1, 10.0))
+		order.SetExpiry(ttl)
+		return order
+	}
+
+	t.Run("confirming clears the expiry", func(t *testing.T) {
+		order := newPendingOrder(72 * time.Hour)
+		require.NotNil(t, order.ExpiresAt)
+
+		require.NoError(t, order.ConfirmOrder())
+		assert.Nil(t, order.ExpiresAt)
+	})
+
+	t.Run("order past its expiry cannot be confirmed", func(t *testing.T) {
+		order := newPendingOrder(72 * time.Hour)
+		order.ExpiresAt = ptrTime(time.Now().Add(-time.Minute))
+
+		err := order.ConfirmOrder()
+
+		assert.ErrorIs(t, err, ErrOrderExpired)
+		assert.Equal(t, OrderStatusPending, order.Status)
+		assert.Equal(t, []OrderStatus{OrderStatusOnHold, OrderStatusCancelled, OrderStatusExpired}, order.AllowedTransitions())
+	})
+
+	t.Run("expire requires the expiry time to have passed", func(t *testing.T) {
+		order := newPendingOrder(time.Hour)
+
+		assert.Error(t, order.Expire(time.Now()))
+		require.NoError(t, order.Expire(order.CreatedAt.Add(time.Hour)))
+		assert.Equal(t, OrderStatusExpired, order.Status)
+	})
+
+	t.Run("expired order reports ErrOrderExpired on confirm", func(t *testing.T) {
+		order := newPendingOrder(time.Hour)
+		order.Status = OrderStatusExpired
+
+		err := order.ConfirmOrder()
+
+		assert.ErrorIs(t, err, ErrOrderExpired)
+		assert.Error(t, order.AddItem(2, "SKU-002", "Product 2", 1, 5.0))
+	})
+
+	t.Run("zero ttl never expires", func(t *testing.T) {
+		order := newPendingOrder(0)
+
+		assert.Nil(t, order.ExpiresAt)
+		assert.False(t, order.IsExpiredAt(time.Now().Add(24*365*time.Hour)))
+	})
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -2,8 +2,12 @@ package entities
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 type OrderStatus string
@@ -13,11 +17,93 @@ const (
 	OrderStatusConfirmed  OrderStatus = "confirmed"
 	OrderStatusProcessing OrderStatus = "processing"
 	OrderStatusShipped    OrderStatus = "shipped"
-	OrderStatusDelivered  OrderStatus = "delivered"
-	OrderStatusCancelled  OrderStatus = "cancelled"
-	OrderStatusRefunded   OrderStatus = "refunded"
+	// OrderStatusPartiallyShipped is derived from shipments covering only part of the order, see ApplyShipments
+	OrderStatusPartiallyShipped OrderStatus = "partially_shipped"
+	OrderStatusDelivered        OrderStatus = "delivered"
+	OrderStatusCancelled        OrderStatus = "cancelled"
+	OrderStatusRefunded         OrderStatus = "refunded"
+
+	OrderStatusReturnRequested OrderStatus = "return_requested"
+	OrderStatusReturned        OrderStatus = "returned"
+	OrderStatusOnHold          OrderStatus = "on_hold"
+	OrderStatusExpired         OrderStatus = "expired"
 )
 
+// ErrUnknownOrderStatus is returned for a string that names no order status
+var ErrUnknownOrderStatus = errors.New("invalid order status")
+
+// ErrOrderExpired is returned when a pending order passed its expiry time before being confirmed
+var ErrOrderExpired = errors.New("order has expired")
+
+// ErrAlreadyInStatus is returned when an order is asked to move to the status it already has, callers
+// repeating a transition can treat it as success
+var ErrAlreadyInStatus = errors.New("order is already in the requested status")
+
+// ErrDuplicateItem is returned when AddItem rejects a product that is already in the order
+var ErrDuplicateItem = errors.New("product is already in the order")
+
+// Errors returned when the details of a new order are rejected
+var (
+	ErrCustomerIDRequired       = errors.New("customer ID is required")
+	ErrInvalidExternalReference = fmt.Errorf("external reference must be at most %d characters", MaxExternalReferenceLength)
+	ErrInvalidTags              = errors.New("invalid order tags")
+)
+
+// DuplicateItemPolicy decides what AddItem does with a product that is already in the order
+type DuplicateItemPolicy string
+
+const (
+	// DuplicateItemMerge adds the quantity to the existing line, the default
+	DuplicateItemMerge DuplicateItemPolicy = "merge"
+	// DuplicateItemReject fails with ErrDuplicateItem
+	DuplicateItemReject DuplicateItemPolicy = "reject"
+)
+
+// AddItemOption customizes a call to AddItem
+type AddItemOption func(o *addItemOptions)
+
+type addItemOptions struct {
+	onDuplicate     DuplicateItemPolicy
+	attributes      map[string]string
+	unitWeightGrams *int
+}
+
+// OnDuplicate sets how AddItem handles a product already in the order, an empty policy merges
+func OnDuplicate(policy DuplicateItemPolicy) AddItemOption {
+	return func(o *addItemOptions) {
+		o.onDuplicate = policy
+	}
+}
+
+// WithAttributes attaches custom attributes to the added item
+func WithAttributes(attributes map[string]string) AddItemOption {
+	return func(o *addItemOptions) {
+		o.attributes = attributes
+	}
+}
+
+// WithUnitWeightGrams sets the weight of one unit of the added item, nil leaves it unknown
+func WithUnitWeightGrams(grams *int) AddItemOption {
+	return func(o *addItemOptions) {
+		o.unitWeightGrams = grams
+	}
+}
+
+// MaxExternalReferenceLength is the longest external reference an order accepts
+const MaxExternalReferenceLength = 100
+
+// Limits of the tags of an order
+const (
+	MaxOrderTags      = 10
+	MaxOrderTagLength = 32
+)
+
+// TagSample marks orders of free samples, they are exempt from the minimum order amount
+const TagSample = "sample"
+
+// TagGift marks orders sent as gifts, their printed documents may leave the prices out until delivery
+const TagGift = "gift"
+
 type OrderItem struct {
 	ID          uint    `json:"id"`
 	ProductID   uint    `json:"product_id"`
@@ -26,75 +112,173 @@ type OrderItem struct {
 	Quantity    int     `json:"quantity"`
 	UnitPrice   float64 `json:"unit_price"`
 	TotalPrice  float64 `json:"total_price"`
+
+	// Attributes are custom details such as size or color. Lines of the same product
+	// with different attributes are kept apart.
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	// UnitWeightGrams is the weight of one unit, nil when unknown
+	UnitWeightGrams *int `json:"unit_weight_grams,omitempty"`
+
+	// ReservedQuantity is how many units the inventory reserved when the order was confirmed, never
+	// more than Quantity. Backordered marks a line reserved short of its quantity until stock arrives.
+	ReservedQuantity int  `json:"reserved_quantity,omitempty"`
+	Backordered      bool `json:"backordered,omitempty"`
+}
+
+// OrderItemInput describes a desired order line, used by ReplaceItems
+type OrderItemInput struct {
+	ProductID       uint
+	ProductSKU      string
+	ProductName     string
+	Quantity        int
+	UnitPrice       float64
+	Attributes      map[string]string
+	UnitWeightGrams *int
 }
 
+// Order is an order of a customer with its lines. An Order is not safe for concurrent use: its
+// methods change it in place without locking. Whoever holds an order owns it, code handing an
+// order to another goroutine hands over a Clone and only the receiver may change it afterwards.
 type Order struct {
-	ID          uint        `json:"id"`
-	CustomerID  uint        `json:"customer_id"`
-	Items       []OrderItem `json:"items"`
-	TotalAmount float64     `json:"total_amount"`
-	Status      OrderStatus `json:"status"`
-	CreatedAt   time.Time   `json:"created_at"`
-	UpdatedAt   time.Time   `json:"updated_at"`
+	ID                uint        `json:"id"`
+	PublicID          string      `json:"public_id,omitempty"` // UUID assigned on creation, see NewPublicID
+	CustomerID        uint        `json:"customer_id"`
+	ExternalReference string      `json:"external_reference,omitempty"`
+	OrderNumber       string      `json:"order_number,omitempty"` // assigned on creation, see OrderNumberFormat
+	Tags              []string    `json:"tags,omitempty"`         // set on creation, see SetTags
+	Items             []OrderItem `json:"items"`
+	// CancelledItems lists the quantities dropped after confirmation, see CancelItem
+	CancelledItems []CancelledItem `json:"cancelled_items,omitempty"`
+	// AmendmentCount counts the item changes made after confirmation, listed in Amendments, see Amend
+	AmendmentCount   int              `json:"amendment_count,omitempty"`
+	Amendments       []OrderAmendment `json:"amendments,omitempty"`
+	TotalAmount      float64          `json:"total_amount"`
+	TotalWeightGrams *int             `json:"total_weight_grams,omitempty"` // nil unless every item has a weight, see CalculateWeight
+	RefundedAmount   float64          `json:"refunded_amount"`
+	Status           OrderStatus      `json:"status"`
+	HeldFromStatus   OrderStatus      `json:"held_from_status,omitempty"`
+	HoldReason       string           `json:"hold_reason,omitempty"`
+	ExpiresAt        *time.Time       `json:"expires_at,omitempty"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+	DeletedAt        *time.Time       `json:"deleted_at,omitempty"`
+
+	// Set when the order is confirmed, see WithShipping
+	ShippingMethod      ShippingMethod `json:"shipping_method,omitempty"`
+	EstimatedDeliveryAt *time.Time     `json:"estimated_delivery_at,omitempty"`
+	// Set when the order is shipped, see WithTracking
+	Carrier        string `json:"carrier,omitempty"`
+	TrackingNumber string `json:"tracking_number,omitempty"`
+
+	// Priority decides how soon fulfillment picks the order up, normal unless set, see SetPriority
+	Priority OrderPriority `json:"priority"`
+
+	// Contact details of the customer as given when ordering, see SetContact. They are personal data:
+	// API responses and logs leave them out unless the caller may see them.
+	CustomerEmail string `json:"customer_email,omitempty"`
+	CustomerName  string `json:"customer_name,omitempty"`
+
+	// Payment authorization taken when the order is confirmed, captured when it ships and voided when it
+	// is cancelled. Empty for orders confirmed without a payment gateway, see RecordPaymentAuthorization.
+	PaymentAuthorizationID string        `json:"payment_authorization_id,omitempty"`
+	PaymentStatus          PaymentStatus `json:"payment_status,omitempty"`
+
+	// ReservationSkipped flags an order confirmed without asking the inventory, its lines stay backordered
+	// until fulfilled, see SkipReservation
+	ReservationSkipped bool `json:"reservation_skipped,omitempty"`
+
+	// Limits applies to item changes, the zero value allows any size
+	Limits OrderLimits `json:"-"`
 }
 
 // Domain methods for Order
 
-// AddItem adds a new item to the order or updates quantity if product already exists
-func (o *Order) AddItem(productID uint, productSKU, productName string, quantity int, unitPrice float64) error {
+// AddItem adds a new item to the order or updates quantity if a line of the product with the same
+// attributes already exists. With OnDuplicate(DuplicateItemReject) such a line fails with ErrDuplicateItem instead.
+func (o *Order) AddItem(productID uint, productSKU, productName string, quantity int, unitPrice float64, opts ...AddItemOption) error {
 	if o.isImmutable() {
 		return errors.New("order cannot be modified in current status")
 	}
+	if o.Status == OrderStatusConfirmed {
+		return ErrAmendmentRequired
+	}
+
+	options := addItemOptions{onDuplicate: DuplicateItemMerge}
+	for _, opt := range opts {
+		opt(&options)
+	}
 
-	if err := validateOrderItem(productID, productSKU, productName, quantity, unitPrice); err != nil {
+	item, err := newOrderItem(OrderItemInput{
+		ProductID:       productID,
+		ProductSKU:      productSKU,
+		ProductName:     productName,
+		Quantity:        quantity,
+		UnitPrice:       unitPrice,
+		Attributes:      options.attributes,
+		UnitWeightGrams: options.unitWeightGrams,
+	})
+	if err != nil {
 		return err
 	}
 
+	items := o.copyItems()
+
 	// Check if item already exists
-	for i := range o.Items {
-		if o.Items[i].ProductID == productID {
+	found := false
+	for i := range items {
+		if items[i].ProductID == productID && sameAttributes(items[i].Attributes, item.Attributes) {
+			if options.onDuplicate == DuplicateItemReject {
+				return fmt.Errorf("%w: product ID %d", ErrDuplicateItem, productID)
+			}
+
 			// Update existing item quantity
-			o.Items[i].Quantity += quantity
-			o.Items[i].TotalPrice = float64(o.Items[i].Quantity) * o.Items[i].UnitPrice
-			o.CalculateTotal()
-			o.UpdatedAt = time.Now()
-			return nil
+			merged, err := addLineQuantity(productID, items[i].Quantity, quantity)
+			if err != nil {
+				return err
+			}
+			items[i].Quantity = merged
+			items[i].TotalPrice = float64(items[i].Quantity) * items[i].UnitPrice
+			if item.UnitWeightGrams != nil {
+				items[i].UnitWeightGrams = item.UnitWeightGrams
+			}
+			found = true
+			break
 		}
 	}
 
 	// Add new item
-	newItem := OrderItem{
-		ProductID:   productID,
-		ProductSKU:  strings.TrimSpace(productSKU),
-		ProductName: strings.TrimSpace(productName),
-		Quantity:    quantity,
-		UnitPrice:   unitPrice,
-		TotalPrice:  float64(quantity) * unitPrice,
+	if !found {
+		items = append(items, *item)
 	}
 
-	o.Items = append(o.Items, newItem)
-	o.CalculateTotal()
-	o.UpdatedAt = time.Now()
-	return nil
+	return o.setItems(items)
 }
 
-// RemoveItem removes an item from the order
+// RemoveItem removes the product from the order, every line of it when it was added with different attributes
 func (o *Order) RemoveItem(productID uint) error {
 	if o.isImmutable() {
 		return errors.New("order cannot be modified in current status")
 	}
+	if o.Status == OrderStatusConfirmed {
+		return ErrAmendmentRequired
+	}
 
-	for i, item := range o.Items {
-		if item.ProductID == productID {
-			// Remove item by slicing
-			o.Items = append(o.Items[:i], o.Items[i+1:]...)
-			o.CalculateTotal()
-			o.UpdatedAt = time.Now()
-			return nil
+	items := make([]OrderItem, 0, len(o.Items))
+	for _, item := range o.Items {
+		if item.ProductID != productID {
+			items = append(items, item)
 		}
 	}
+	if len(items) == len(o.Items) {
+		return errors.New("item not found in order")
+	}
 
-	return errors.New("item not found in order")
+	o.Items = items
+	o.CalculateTotal()
+	o.CalculateWeight()
+	o.UpdatedAt = time.Now()
+	return nil
 }
 
 // UpdateItemQuantity updates the quantity of an existing item
@@ -102,22 +286,131 @@ func (o *Order) UpdateItemQuantity(productID uint, quantity int) error {
 	if o.isImmutable() {
 		return errors.New("order cannot be modified in current status")
 	}
+	if o.Status == OrderStatusConfirmed {
+		return ErrAmendmentRequired
+	}
 
 	if quantity <= 0 {
 		return errors.New("quantity must be positive")
 	}
 
-	for i := range o.Items {
-		if o.Items[i].ProductID == productID {
-			o.Items[i].Quantity = quantity
-			o.Items[i].TotalPrice = float64(quantity) * o.Items[i].UnitPrice
-			o.CalculateTotal()
-			o.UpdatedAt = time.Now()
-			return nil
+	items := o.copyItems()
+	line := -1
+	for i := range items {
+		if items[i].ProductID != productID {
+			continue
+		}
+		if line >= 0 {
+			return errors.New("product has several lines with different attributes, replace the items instead")
+		}
+		line = i
+	}
+	if line < 0 {
+		return errors.New("item not found in order")
+	}
+
+	items[line].resize(quantity)
+	return o.setItems(items)
+}
+
+// SetExternalReference records the caller's own order number, an empty reference clears it
+func (o *Order) SetExternalReference(reference string) error {
+	reference = strings.TrimSpace(reference)
+	if utf8.RuneCountInString(reference) > MaxExternalReferenceLength {
+		return ErrInvalidExternalReference
+	}
+
+	o.ExternalReference = reference
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetTags labels the order. Tags are trimmed and lowercased, empty and repeated tags are dropped.
+func (o *Order) SetTags(tags []string) error {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || slices.Contains(normalized, tag) {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > MaxOrderTagLength {
+			return fmt.Errorf("%w, tags must be at most %d characters", ErrInvalidTags, MaxOrderTagLength)
+		}
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > MaxOrderTags {
+		return fmt.Errorf("%w, an order has at most %d tags", ErrInvalidTags, MaxOrderTags)
+	}
+	if len(normalized) == 0 {
+		normalized = nil
+	}
+
+	o.Tags = normalized
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// HasTag checks if the order carries tag
+func (o *Order) HasTag(tag string) bool {
+	return slices.Contains(o.Tags, tag)
+}
+
+// ReplaceItems swaps the whole item list. Every line is validated before the order is changed.
+func (o *Order) ReplaceItems(inputs []OrderItemInput) error {
+	if o.isImmutable() {
+		return errors.New("order cannot be modified in current status")
+	}
+	if o.Status == OrderStatusConfirmed {
+		return ErrAmendmentRequired
+	}
+
+	items, err := buildItems(inputs)
+	if err != nil {
+		return err
+	}
+	return o.setItems(items)
+}
+
+// buildItems validates inputs as the complete item list of an order
+func buildItems(inputs []OrderItemInput) ([]OrderItem, error) {
+	items := make([]OrderItem, 0, len(inputs))
+	for i, input := range inputs {
+		item, err := newOrderItem(input)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
 		}
+
+		if duplicateLine(items, item.ProductID, item.Attributes) >= 0 {
+			return nil, fmt.Errorf("item %d: duplicate product ID %d", i, item.ProductID)
+		}
+
+		items = append(items, *item)
 	}
+	return items, nil
+}
 
-	return errors.New("item not found in order")
+// Clone returns a deep copy of the order sharing no slice, map or pointer with it. Use cases change
+// a clone and keep the original as the state before the change, untouched if storing the clone fails.
+func (o *Order) Clone() *Order {
+	clone := *o
+	clone.Items = o.copyItems()
+	clone.Tags = slices.Clone(o.Tags)
+	clone.CancelledItems = slices.Clone(o.CancelledItems)
+	clone.Amendments = copyAmendments(o.Amendments)
+	if o.ExpiresAt != nil {
+		expiresAt := *o.ExpiresAt
+		clone.ExpiresAt = &expiresAt
+	}
+	if o.DeletedAt != nil {
+		deletedAt := *o.DeletedAt
+		clone.DeletedAt = &deletedAt
+	}
+	if o.EstimatedDeliveryAt != nil {
+		estimatedDeliveryAt := *o.EstimatedDeliveryAt
+		clone.EstimatedDeliveryAt = &estimatedDeliveryAt
+	}
+	clone.TotalWeightGrams = copyInt(o.TotalWeightGrams)
+	return &clone
 }
 
 // CalculateTotal recalculates and updates the total amount
@@ -130,72 +423,118 @@ func (o *Order) CalculateTotal() float64 {
 	return total
 }
 
-// ConfirmOrder transitions the order from pending to confirmed
-func (o *Order) ConfirmOrder() error {
-	if o.Status != OrderStatusPending {
-		return errors.New("only pending orders can be confirmed")
+// CalculateWeight recalculates and updates the total weight. It is nil when the order has no
+// items or any item lacks a weight, so an unknown weight is never reported as zero grams.
+func (o *Order) CalculateWeight() *int {
+	o.TotalWeightGrams = nil
+	if len(o.Items) == 0 {
+		return nil
 	}
 
-	if len(o.Items) == 0 {
-		return errors.New("cannot confirm empty order")
+	total := 0
+	for _, item := range o.Items {
+		if item.UnitWeightGrams == nil {
+			return nil
+		}
+		total += item.Quantity * *item.UnitWeightGrams
 	}
+	o.TotalWeightGrams = &total
+	return o.TotalWeightGrams
+}
 
-	o.Status = OrderStatusConfirmed
-	o.UpdatedAt = time.Now()
-	return nil
+// ConfirmOrder transitions the order from pending to confirmed, opts such as WithShipping customize the transition
+func (o *Order) ConfirmOrder(opts ...TransitionOption) error {
+	return o.TransitionTo(OrderStatusConfirmed, opts...)
 }
 
 // CancelOrder cancels the order if cancellation is allowed
 func (o *Order) CancelOrder() error {
-	if !o.CanBeCancelled() {
-		return errors.New("order cannot be cancelled in current status")
-	}
-
-	o.Status = OrderStatusCancelled
-	o.UpdatedAt = time.Now()
-	return nil
+	return o.TransitionTo(OrderStatusCancelled)
 }
 
 // TransitionToProcessing moves order from confirmed to processing
 func (o *Order) TransitionToProcessing() error {
-	if o.Status != OrderStatusConfirmed {
-		return errors.New("only confirmed orders can be moved to processing")
-	}
-
-	o.Status = OrderStatusProcessing
-	o.UpdatedAt = time.Now()
-	return nil
+	return o.TransitionTo(OrderStatusProcessing)
 }
 
-// TransitionToShipped moves order from processing to shipped
-func (o *Order) TransitionToShipped() error {
-	if o.Status != OrderStatusProcessing {
-		return errors.New("only processing orders can be shipped")
-	}
-
-	o.Status = OrderStatusShipped
-	o.UpdatedAt = time.Now()
-	return nil
+// TransitionToShipped moves order from processing to shipped, opts such as WithTracking customize the transition
+func (o *Order) TransitionToShipped(opts ...TransitionOption) error {
+	return o.TransitionTo(OrderStatusShipped, opts...)
 }
 
 // TransitionToDelivered moves order from shipped to delivered
 func (o *Order) TransitionToDelivered() error {
-	if o.Status != OrderStatusShipped {
-		return errors.New("only shipped orders can be delivered")
+	return o.TransitionTo(OrderStatusDelivered)
+}
+
+// PlaceOnHold freezes a pending, confirmed or processing order, remembering its status for ReleaseHold
+func (o *Order) PlaceOnHold(reason string) error {
+	return o.TransitionTo(OrderStatusOnHold, WithReason(reason))
+}
+
+// ReleaseHold returns an on-hold order to the status it had before being held. Releasing a pending,
+// confirmed or processing order fails with ErrAlreadyInStatus.
+func (o *Order) ReleaseHold() error {
+	if !o.IsOnHold() {
+		cause := errors.New("only orders on hold can be released")
+		// An order that could be held is where a release leaves it, as a repeated release finds it
+		if slices.Contains(ValidTransitions(o.Status), OrderStatusOnHold) {
+			return &alreadyInStatusError{cause: cause}
+		}
+		return cause
 	}
 
-	o.Status = OrderStatusDelivered
-	o.UpdatedAt = time.Now()
-	return nil
+	return o.TransitionTo(o.HeldFromStatus, WithHoldRelease())
 }
 
-// TransitionToRefunded moves order from delivered to refunded
+// TransitionToReturnRequested moves order from delivered to return requested
+func (o *Order) TransitionToReturnRequested() error {
+	return o.TransitionTo(OrderStatusReturnRequested)
+}
+
+// TransitionToReturned moves order from return requested to returned
+func (o *Order) TransitionToReturned() error {
+	return o.TransitionTo(OrderStatusReturned)
+}
+
+// TransitionToRefunded moves order from delivered or returned to refunded, refunding the remaining amount
 func (o *Order) TransitionToRefunded() error {
-	if o.Status != OrderStatusDelivered {
-		return errors.New("only delivered orders can be refunded")
+	return o.TransitionTo(OrderStatusRefunded)
+}
+
+// Expire moves a pending order whose expiry time has passed to expired
+func (o *Order) Expire(now time.Time) error {
+	return o.TransitionTo(OrderStatusExpired, AtTime(now))
+}
+
+// SetExpiry makes a pending order expire ttl after it was created, a zero ttl never expires
+func (o *Order) SetExpiry(ttl time.Duration) {
+	if ttl <= 0 {
+		o.ExpiresAt = nil
+		return
+	}
+	expiresAt := o.CreatedAt.Add(ttl)
+	o.ExpiresAt = &expiresAt
+}
+
+// RefundAmount records a partial refund. Refunding the remaining amount moves the order to refunded.
+func (o *Order) RefundAmount(amount float64) error {
+	if !o.CanBeRefunded() {
+		return ErrRefundNotAllowed
 	}
 
-	o.Status = OrderStatusRefunded
+	if amount <= 0 || toCents(amount) == 0 {
+		return fmt.Errorf("%w: refund amount must be positive", ErrInvalidRefund)
+	}
+
+	if toCents(o.RefundedAmount+amount) > toCents(o.TotalAmount) {
+		return ErrRefundExceedsTotal
+	}
+
+	o.RefundedAmount += amount
+	if toCents(o.RefundedAmount) == toCents(o.TotalAmount) {
+		o.Status = OrderStatusRefunded
+	}
 	o.UpdatedAt = time.Now()
 	return nil
 }
@@ -204,9 +543,30 @@ func (o *Order) TransitionToRefunded() error {
 
 // CanBeCancelled checks if the order can be cancelled
 func (o *Order) CanBeCancelled() bool {
-	return o.Status == OrderStatusPending ||
-		o.Status == OrderStatusConfirmed ||
-		o.Status == OrderStatusProcessing
+	_, ok := orderTransitions[o.Status][OrderStatusCancelled]
+	return ok
+}
+
+// CanBeDeleted checks if the order can be deleted. Orders being fulfilled, including
+// those put on hold while processing or shipped, must be kept.
+func (o *Order) CanBeDeleted() bool {
+	status := o.Status
+	if status == OrderStatusOnHold {
+		status = o.HeldFromStatus
+	}
+	return status != OrderStatusProcessing && status != OrderStatusPartiallyShipped && status != OrderStatusShipped
+}
+
+// CanBeRefunded checks if money can be refunded for the order
+func (o *Order) CanBeRefunded() bool {
+	return o.Status == OrderStatusDelivered ||
+		o.Status == OrderStatusReturnRequested ||
+		o.Status == OrderStatusReturned
+}
+
+// IsPartiallyRefunded checks if part, but not all, of the order total was refunded
+func (o *Order) IsPartiallyRefunded() bool {
+	return o.RefundedAmount > 0 && toCents(o.RefundedAmount) < toCents(o.TotalAmount)
 }
 
 // IsEmpty checks if the order has no items
@@ -229,12 +589,27 @@ func (o *Order) IsCancelled() bool {
 	return o.Status == OrderStatusCancelled
 }
 
+// IsOnHold checks if order is on hold
+func (o *Order) IsOnHold() bool {
+	return o.Status == OrderStatusOnHold
+}
+
+// IsExpired checks if order has been expired
+func (o *Order) IsExpired() bool {
+	return o.Status == OrderStatusExpired
+}
+
+// IsExpiredAt checks if a pending order has passed its expiry time at the given instant
+func (o *Order) IsExpiredAt(now time.Time) bool {
+	return o.Status == OrderStatusPending && o.ExpiresAt != nil && !now.Before(*o.ExpiresAt)
+}
+
 // IsDelivered checks if order is delivered
 func (o *Order) IsDelivered() bool {
 	return o.Status == OrderStatusDelivered
 }
 
-// GetItem returns an item by product ID
+// GetItem returns the first line of the product
 func (o *Order) GetItem(productID uint) (*OrderItem, error) {
 	for i := range o.Items {
 		if o.Items[i].ProductID == productID {
@@ -249,7 +624,8 @@ func (o *Order) GetItemCount() int {
 	return len(o.Items)
 }
 
-// GetTotalQuantity returns the total quantity of all items
+// GetTotalQuantity returns the total quantity of all items. Lines hold at most MaxLineQuantity each,
+// so the sum cannot overflow.
 func (o *Order) GetTotalQuantity() int {
 	total := 0
 	for _, item := range o.Items {
@@ -258,26 +634,85 @@ func (o *Order) GetTotalQuantity() int {
 	return total
 }
 
+// copyItems returns a copy of the items that can be changed without touching the order
+func (o *Order) copyItems() []OrderItem {
+	items := append(make([]OrderItem, 0, len(o.Items)+1), o.Items...)
+	for i := range items {
+		items[i].Attributes = copyAttributes(items[i].Attributes)
+		items[i].UnitWeightGrams = copyInt(items[i].UnitWeightGrams)
+	}
+	return items
+}
+
+func copyInt(value *int) *int {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
+
+// duplicateLine returns the index of the line of productID with the same attributes, -1 when there is none
+func duplicateLine(items []OrderItem, productID uint, attributes map[string]string) int {
+	for i := range items {
+		if items[i].ProductID == productID && sameAttributes(items[i].Attributes, attributes) {
+			return i
+		}
+	}
+	return -1
+}
+
+// setItems checks the new item list against the order limits and stores it
+func (o *Order) setItems(items []OrderItem) error {
+	if err := checkHardLimits(items); err != nil {
+		return err
+	}
+	if err := o.Limits.Check(items); err != nil {
+		return err
+	}
+	if err := checkReservations(items); err != nil {
+		return err
+	}
+
+	o.Items = items
+	o.CalculateTotal()
+	o.CalculateWeight()
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
 // isImmutable checks if the order can be modified
 func (o *Order) isImmutable() bool {
 	return o.Status == OrderStatusCancelled ||
+		o.Status == OrderStatusOnHold ||
+		o.Status == OrderStatusPartiallyShipped ||
 		o.Status == OrderStatusDelivered ||
-		o.Status == OrderStatusRefunded
+		o.Status == OrderStatusReturnRequested ||
+		o.Status == OrderStatusReturned ||
+		o.Status == OrderStatusRefunded ||
+		o.Status == OrderStatusExpired
+}
+
+// toCents converts a monetary amount to whole cents so comparisons are not affected by float rounding
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
 }
 
 // Factory function for creating new orders
 func NewOrder(customerID uint) (*Order, error) {
 	if customerID == 0 {
-		return nil, errors.New("customer ID is required")
+		return nil, ErrCustomerIDRequired
 	}
 
 	now := time.Now()
 
 	return &Order{
+		PublicID:    NewPublicID(),
 		CustomerID:  customerID,
 		Items:       make([]OrderItem, 0),
 		TotalAmount: 0.0,
 		Status:      OrderStatusPending,
+		Priority:    OrderPriorityNormal,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}, nil
@@ -285,51 +720,160 @@ func NewOrder(customerID uint) (*Order, error) {
 
 // Factory function for creating new order items
 func NewOrderItem(productID uint, productSKU, productName string, quantity int, unitPrice float64) (*OrderItem, error) {
-	if err := validateOrderItem(productID, productSKU, productName, quantity, unitPrice); err != nil {
+	return newOrderItem(OrderItemInput{
+		ProductID:   productID,
+		ProductSKU:  productSKU,
+		ProductName: productName,
+		Quantity:    quantity,
+		UnitPrice:   unitPrice,
+	})
+}
+
+// newOrderItem validates input and builds the order line it describes
+func newOrderItem(input OrderItemInput) (*OrderItem, error) {
+	if err := validateOrderItem(input); err != nil {
 		return nil, err
 	}
 
 	return &OrderItem{
-		ProductID:   productID,
-		ProductSKU:  strings.TrimSpace(productSKU),
-		ProductName: strings.TrimSpace(productName),
-		Quantity:    quantity,
-		UnitPrice:   unitPrice,
-		TotalPrice:  float64(quantity) * unitPrice,
+		ProductID:       input.ProductID,
+		ProductSKU:      strings.TrimSpace(input.ProductSKU),
+		ProductName:     strings.TrimSpace(input.ProductName),
+		Quantity:        input.Quantity,
+		UnitPrice:       input.UnitPrice,
+		TotalPrice:      float64(input.Quantity) * input.UnitPrice,
+		Attributes:      copyAttributes(input.Attributes),
+		UnitWeightGrams: copyInt(input.UnitWeightGrams),
 	}, nil
 }
 
 // Domain validation functions
-func validateOrderItem(productID uint, productSKU, productName string, quantity int, unitPrice float64) error {
-	if productID == 0 {
-		return errors.New("product ID is required")
+func validateOrderItem(input OrderItemInput) error {
+	fieldErrors := orderItemFieldErrors(input)
+	if len(fieldErrors) == 0 {
+		return nil
 	}
+	if fieldErrors[0].Field == "quantity" && input.Quantity > MaxLineQuantity {
+		return fmt.Errorf("%w: %s", ErrQuantityLimitExceeded, fieldErrors[0].Reason)
+	}
+	if fieldErrors[0].Field == "unit_price" {
+		// A rejected price keeps its UnitPriceError, so callers can report the offending value
+		return validateLinePrice(input.Quantity, input.UnitPrice)
+	}
+	return errors.New(fieldErrors[0].Reason)
+}
+
+// orderItemFieldErrors returns every rejected field of input, Index is left at 0
+func orderItemFieldErrors(input OrderItemInput) []ItemError {
+	var fieldErrors []ItemError
 
-	if strings.TrimSpace(productSKU) == "" {
-		return errors.New("product SKU is required")
+	if input.ProductID == 0 {
+		fieldErrors = append(fieldErrors, ItemError{Field: "product_id", Reason: "product ID is required"})
 	}
 
-	if strings.TrimSpace(productName) == "" {
-		return errors.New("product name is required")
+	if strings.TrimSpace(input.ProductSKU) == "" {
+		fieldErrors = append(fieldErrors, ItemError{Field: "product_sku", Reason: "product SKU is required"})
 	}
 
-	if quantity <= 0 {
-		return errors.New("quantity must be positive")
+	if strings.TrimSpace(input.ProductName) == "" {
+		fieldErrors = append(fieldErrors, ItemError{Field: "product_name", Reason: "product name is required"})
+	}
+
+	// The line total is checked for the quantity only once the quantity itself is valid
+	lineQuantity := 1
+	switch {
+	case input.Quantity <= 0:
+		fieldErrors = append(fieldErrors, ItemError{Field: "quantity", Reason: "quantity must be positive"})
+	case input.Quantity > MaxLineQuantity:
+		fieldErrors = append(fieldErrors, ItemError{Field: "quantity", Reason: fmt.Sprintf("quantity must be at most %d", MaxLineQuantity)})
+	default:
+		lineQuantity = input.Quantity
+	}
+
+	if err := validateLinePrice(lineQuantity, input.UnitPrice); err != nil {
+		fieldErrors = append(fieldErrors, ItemError{Field: "unit_price", Reason: err.Error()})
 	}
+	if input.UnitWeightGrams != nil && *input.UnitWeightGrams <= 0 {
+		fieldErrors = append(fieldErrors, ItemError{Field: "unit_weight_grams", Reason: "unit weight must be positive"})
+	}
+	for _, reason := range attributeErrors(input.Attributes) {
+		fieldErrors = append(fieldErrors, ItemError{Field: "attributes", Reason: reason})
+	}
+
+	return fieldErrors
+}
+
+// ItemError is a rejected field of the item at Index in an item list
+type ItemError struct {
+	Index  int
+	Field  string
+	Reason string
+}
+
+// ItemErrors lists every rejected field of an item list
+type ItemErrors []ItemError
 
-	if unitPrice <= 0 {
-		return errors.New("unit price must be positive")
+func (e ItemErrors) Error() string {
+	reasons := make([]string, len(e))
+	for i, itemErr := range e {
+		reasons[i] = fmt.Sprintf("item %d: %s", itemErr.Index, itemErr.Reason)
 	}
+	return strings.Join(reasons, "; ")
+}
+
+// ValidateItems checks every input and reports all rejected fields at once as ItemErrors.
+// A product listed more than once with the same attributes is rejected at every repeat.
+func ValidateItems(inputs []OrderItemInput) error {
+	var itemErrors ItemErrors
+	for i, input := range inputs {
+		for _, fieldError := range orderItemFieldErrors(input) {
+			fieldError.Index = i
+			itemErrors = append(itemErrors, fieldError)
+		}
 
+		if input.ProductID == 0 {
+			continue
+		}
+		for first := 0; first < i; first++ {
+			if inputs[first].ProductID == input.ProductID && sameAttributes(inputs[first].Attributes, input.Attributes) {
+				itemErrors = append(itemErrors, ItemError{
+					Index:  i,
+					Field:  "product_id",
+					Reason: fmt.Sprintf("duplicate product ID %d, already listed at item %d", input.ProductID, first),
+				})
+				break
+			}
+		}
+	}
+	if len(itemErrors) > 0 {
+		return itemErrors
+	}
 	return nil
 }
 
 func ValidateOrderStatus(status OrderStatus) error {
-	switch status {
-	case OrderStatusPending, OrderStatusConfirmed, OrderStatusProcessing,
-		OrderStatusShipped, OrderStatusDelivered, OrderStatusCancelled, OrderStatusRefunded:
-		return nil
-	default:
-		return errors.New("invalid order status")
+	if _, ok := orderTransitions[status]; !ok {
+		return ErrUnknownOrderStatus
+	}
+	return nil
+}
+
+// ParseOrderStatus converts s to an order status, ignoring case and surrounding whitespace
+func ParseOrderStatus(s string) (OrderStatus, error) {
+	status := OrderStatus(strings.ToLower(strings.TrimSpace(s)))
+	if err := ValidateOrderStatus(status); err != nil {
+		return "", err
 	}
+	return status, nil
+}
+
+// CountsTowardsSpend reports whether orders in status count as money the customer spent.
+// Cancelled and refunded orders were never paid or were paid back.
+func (s OrderStatus) CountsTowardsSpend() bool {
+	return s != OrderStatusCancelled && s != OrderStatusRefunded
+}
+
+// OrderStatuses returns every order status in lifecycle order
+func OrderStatuses() []OrderStatus {
+	return append([]OrderStatus(nil), orderStatuses...)
 }
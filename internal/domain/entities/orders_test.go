@@ -1,10 +1,13 @@
 package entities
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewOrder(t *testing.T) {
@@ -248,6 +251,22 @@ func TestOrder_AddItem_UpdateExisting(t *testing.T) {
 	assert.Equal(t, 50.0, order.TotalAmount)
 }
 
+func TestOrder_AddItem_RejectDuplicate(t *testing.T) {
+	order, _ := NewOrder(123)
+	require.NoError(t, order.AddItem(1, "SKU-001", "Test Product", 2, 10.0))
+
+	// A new product is added as usual
+	err := order.AddItem(2, "SKU-002", "Other Product", 1, 5.0, OnDuplicate(DuplicateItemReject))
+	require.NoError(t, err)
+
+	// The same product again is rejected and leaves the order unchanged
+	err = order.AddItem(1, "SKU-001", "Test Product", 3, 10.0, OnDuplicate(DuplicateItemReject))
+	assert.ErrorIs(t, err, ErrDuplicateItem)
+	assert.Len(t, order.Items, 2)
+	assert.Equal(t, 2, order.Items[0].Quantity)
+	assert.Equal(t, 25.0, order.TotalAmount)
+}
+
 func TestOrder_RemoveItem(t *testing.T) {
 	order, _ := NewOrder(123)
 	order.AddItem(1, "SKU-001", "Product 1", 2, 10.0)
@@ -353,6 +372,29 @@ func TestOrder_CalculateTotal(t *testing.T) {
 	assert.Equal(t, 65.0, order.TotalAmount)
 }
 
+func TestOrder_CalculateWeight(t *testing.T) {
+	grams := func(g int) *int { return &g }
+	order, _ := NewOrder(123)
+	assert.Nil(t, order.CalculateWeight(), "an empty order has no weight")
+
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 2, 10.0, WithUnitWeightGrams(grams(250))))
+	require.NoError(t, order.AddItem(2, "SKU-002", "Product 2", 3, 15.0, WithUnitWeightGrams(grams(100))))
+	require.NotNil(t, order.TotalWeightGrams)
+	assert.Equal(t, 800, *order.TotalWeightGrams)
+
+	// An item without weight makes the total unknown rather than lighter
+	require.NoError(t, order.AddItem(3, "SKU-003", "Product 3", 1, 5.0))
+	assert.Nil(t, order.TotalWeightGrams)
+
+	require.NoError(t, order.RemoveItem(3))
+	require.NotNil(t, order.CalculateWeight())
+	assert.Equal(t, 800, *order.TotalWeightGrams)
+
+	// The weight cannot be zero or negative
+	err := order.AddItem(4, "SKU-004", "Product 4", 1, 5.0, WithUnitWeightGrams(grams(0)))
+	assert.EqualError(t, err, "unit weight must be positive")
+}
+
 func TestOrder_ConfirmOrder(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -405,6 +447,97 @@ func TestOrder_ConfirmOrder(t *testing.T) {
 	}
 }
 
+func TestParseOrderStatus(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected OrderStatus
+		valid    bool
+	}{
+		{"pending", OrderStatusPending, true},
+		{"Pending", OrderStatusPending, true},
+		{" SHIPPED ", OrderStatusShipped, true},
+		{"Return_Requested", OrderStatusReturnRequested, true},
+		{"shiped", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			status, err := ParseOrderStatus(tt.input)
+
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrUnknownOrderStatus)
+			}
+			assert.Equal(t, tt.expected, status)
+		})
+	}
+}
+
+// FuzzParseOrderStatus checks that any string parses to a known status or fails with ErrUnknownOrderStatus,
+// and that a parsed status parses to itself again
+func FuzzParseOrderStatus(f *testing.F) {
+	for _, seed := range []string{"pending", " SHIPPED ", "Return_Requested", "shiped", "", "\x00", "\u212a", "ON_HOLD\t"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		status, err := ParseOrderStatus(s)
+		if err != nil {
+			assert.ErrorIs(t, err, ErrUnknownOrderStatus)
+			assert.Empty(t, status)
+			return
+		}
+		assert.NoError(t, ValidateOrderStatus(status))
+		reparsed, err := ParseOrderStatus(string(status))
+		assert.NoError(t, err)
+		assert.Equal(t, status, reparsed)
+	})
+}
+
+func TestOrderStatuses(t *testing.T) {
+	statuses := OrderStatuses()
+
+	assert.Equal(t, OrderStatusPending, statuses[0])
+	for _, status := range statuses {
+		assert.NoError(t, ValidateOrderStatus(status))
+	}
+
+	// The returned slice is a copy
+	statuses[0] = "mutated"
+	assert.Equal(t, OrderStatusPending, OrderStatuses()[0])
+}
+
+func TestOrder_CanBeDeleted(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         OrderStatus
+		heldFromStatus OrderStatus
+		deletable      bool
+	}{
+		{name: "pending", status: OrderStatusPending, deletable: true},
+		{name: "confirmed", status: OrderStatusConfirmed, deletable: true},
+		{name: "processing", status: OrderStatusProcessing, deletable: false},
+		{name: "shipped", status: OrderStatusShipped, deletable: false},
+		{name: "delivered", status: OrderStatusDelivered, deletable: true},
+		{name: "cancelled", status: OrderStatusCancelled, deletable: true},
+		{name: "on hold from confirmed", status: OrderStatusOnHold, heldFromStatus: OrderStatusConfirmed, deletable: true},
+		{name: "on hold from processing", status: OrderStatusOnHold, heldFromStatus: OrderStatusProcessing, deletable: false},
+		{name: "on hold from shipped", status: OrderStatusOnHold, heldFromStatus: OrderStatusShipped, deletable: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, _ := NewOrder(123)
+			order.Status = tt.status
+			order.HeldFromStatus = tt.heldFromStatus
+
+			assert.Equal(t, tt.deletable, order.CanBeDeleted())
+		})
+	}
+}
+
 func TestOrder_CancelOrder(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -485,7 +618,7 @@ func TestOrder_StatusTransitions(t *testing.T) {
 		},
 		{
 			name:        "transition to shipped",
-			method:      (*Order).TransitionToShipped,
+			method:      func(o *Order) error { return o.TransitionToShipped() },
 			fromStatus:  OrderStatusProcessing,
 			toStatus:    OrderStatusShipped,
 			expectError: false,
@@ -504,6 +637,48 @@ func TestOrder_StatusTransitions(t *testing.T) {
 			toStatus:    OrderStatusRefunded,
 			expectError: false,
 		},
+		{
+			name:        "transition to return requested",
+			method:      (*Order).TransitionToReturnRequested,
+			fromStatus:  OrderStatusDelivered,
+			toStatus:    OrderStatusReturnRequested,
+			expectError: false,
+		},
+		{
+			name:          "invalid transition to return requested",
+			method:        (*Order).TransitionToReturnRequested,
+			fromStatus:    OrderStatusShipped,
+			expectError:   true,
+			errorContains: "only delivered orders can have a return requested",
+		},
+		{
+			name:        "transition to returned",
+			method:      (*Order).TransitionToReturned,
+			fromStatus:  OrderStatusReturnRequested,
+			toStatus:    OrderStatusReturned,
+			expectError: false,
+		},
+		{
+			name:          "invalid transition to returned",
+			method:        (*Order).TransitionToReturned,
+			fromStatus:    OrderStatusDelivered,
+			expectError:   true,
+			errorContains: "only orders with a requested return can be returned",
+		},
+		{
+			name:        "transition from returned to refunded",
+			method:      (*Order).TransitionToRefunded,
+			fromStatus:  OrderStatusReturned,
+			toStatus:    OrderStatusRefunded,
+			expectError: false,
+		},
+		{
+			name:          "invalid transition from return requested to refunded",
+			method:        (*Order).TransitionToRefunded,
+			fromStatus:    OrderStatusReturnRequested,
+			expectError:   true,
+			errorContains: "only delivered or returned orders can be refunded",
+		},
 	}
 
 	for _, tt := range tests {
@@ -524,6 +699,285 @@ func TestOrder_StatusTransitions(t *testing.T) {
 	}
 }
 
+func TestOrder_SetExternalReference(t *testing.T) {
+	order, _ := NewOrder(123)
+
+	assert.NoError(t, order.SetExternalReference("  PO-1001 "))
+	assert.Equal(t, "PO-1001", order.ExternalReference)
+
+	err := order.SetExternalReference(strings.Repeat("x", MaxExternalReferenceLength+1))
+	assert.ErrorIs(t, err, ErrInvalidExternalReference)
+	assert.Contains(t, err.Error(), "external reference must be at most 100 characters")
+	assert.Equal(t, "PO-1001", order.ExternalReference)
+
+	// The limit counts characters, not bytes
+	assert.NoError(t, order.SetExternalReference(strings.Repeat("é", MaxExternalReferenceLength)))
+
+	assert.NoError(t, order.SetExternalReference(""))
+	assert.Empty(t, order.ExternalReference)
+}
+
+func TestOrder_SetTags(t *testing.T) {
+	order, _ := NewOrder(123)
+
+	assert.NoError(t, order.SetTags([]string{" Sample ", "sample", "", "VIP"}))
+	assert.Equal(t, []string{"sample", "vip"}, order.Tags)
+	assert.True(t, order.HasTag(TagSample))
+
+	err := order.SetTags([]string{strings.Repeat("x", MaxOrderTagLength+1)})
+	assert.ErrorIs(t, err, ErrInvalidTags)
+	assert.Equal(t, []string{"sample", "vip"}, order.Tags)
+
+	assert.NoError(t, order.SetTags([]string{strings.Repeat("é", MaxOrderTagLength)}))
+
+	assert.NoError(t, order.SetTags(nil))
+	assert.Nil(t, order.Tags)
+	assert.False(t, order.HasTag(TagSample))
+}
+
+func TestOrder_ReplaceItems(t *testing.T) {
+	t.Run("replaces items and recalculates total", func(t *testing.T) {
+		order, _ := NewOrder(123)
+		order.AddItem(1, "SKU-001", "Product 1", 2, 10.0)
+
+		err := order.ReplaceItems([]OrderItemInput{
+			{ProductID: 2, ProductSKU: " SKU-002 ", ProductName: "Product 2", Quantity: 3, UnitPrice: 5.0},
+			{ProductID: 3, ProductSKU: "SKU-003", ProductName: "Product 3", Quantity: 1, UnitPrice: 2.5},
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, order.Items, 2)
+		assert.Equal(t, "SKU-002", order.Items[0].ProductSKU)
+		assert.Equal(t, 17.5, order.TotalAmount)
+	})
+
+	t.Run("empty list clears the order", func(t *testing.T) {
+		order, _ := NewOrder(123)
+		order.AddItem(1, "SKU-001", "Product 1", 2, 10.0)
+
+		err := order.ReplaceItems(nil)
+
+		assert.NoError(t, err)
+		assert.True(t, order.IsEmpty())
+		assert.Equal(t, 0.0, order.TotalAmount)
+	})
+
+	tests := []struct {
+		name          string
+		status        OrderStatus
+		inputs        []OrderItemInput
+		errorContains string
+	}{
+		{
+			name:   "duplicate product",
+			status: OrderStatusPending,
+			inputs: []OrderItemInput{
+				{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 5.0},
+				{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 2, UnitPrice: 5.0},
+			},
+			errorContains: "item 1: duplicate product ID 2",
+		},
+		{
+			name:   "invalid line",
+			status: OrderStatusPending,
+			inputs: []OrderItemInput{
+				{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 0, UnitPrice: 5.0},
+			},
+			errorContains: "item 0: quantity must be positive",
+		},
+		{
+			name:   "immutable order",
+			status: OrderStatusCancelled,
+			inputs: []OrderItemInput{
+				{ProductID: 2, ProductSKU: "SKU-002", ProductName: "Product 2", Quantity: 1, UnitPrice: 5.0},
+			},
+			errorContains: "order cannot be modified in current status",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, _ := NewOrder(123)
+			order.AddItem(1, "SKU-001", "Product 1", 2, 10.0)
+			order.Status = tt.status
+
+			err := order.ReplaceItems(tt.inputs)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+			assert.Len(t, order.Items, 1)
+			assert.Equal(t, 20.0, order.TotalAmount)
+		})
+	}
+}
+
+func TestOrder_PlaceOnHold(t *testing.T) {
+	for _, status := range []OrderStatus{OrderStatusPending, OrderStatusConfirmed, OrderStatusProcessing} {
+		t.Run("hold and release from "+string(status), func(t *testing.T) {
+			order, _ := NewOrder(123)
+			order.Status = status
+
+			err := order.PlaceOnHold("  fraud review  ")
+
+			assert.NoError(t, err)
+			assert.Equal(t, OrderStatusOnHold, order.Status)
+			assert.Equal(t, status, order.HeldFromStatus)
+			assert.Equal(t, "fraud review", order.HoldReason)
+			assert.True(t, order.IsOnHold())
+			assert.True(t, order.CanBeCancelled())
+
+			err = order.ReleaseHold()
+
+			assert.NoError(t, err)
+			assert.Equal(t, status, order.Status)
+			assert.Empty(t, order.HeldFromStatus)
+			assert.Empty(t, order.HoldReason)
+		})
+	}
+
+	t.Run("shipped order cannot be held", func(t *testing.T) {
+		order, _ := NewOrder(123)
+		order.Status = OrderStatusShipped
+
+		err := order.PlaceOnHold("fraud review")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "only pending, confirmed or processing orders can be placed on hold")
+	})
+
+	t.Run("reason is required", func(t *testing.T) {
+		order, _ := NewOrder(123)
+
+		err := order.PlaceOnHold("   ")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "hold reason is required")
+		assert.Equal(t, OrderStatusPending, order.Status)
+	})
+
+	t.Run("release requires hold", func(t *testing.T) {
+		order, _ := NewOrder(123)
+
+		err := order.ReleaseHold()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "only orders on hold can be released")
+	})
+
+	t.Run("repeated release", func(t *testing.T) {
+		order, _ := NewOrder(123)
+		require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 1, 10.0))
+		require.NoError(t, order.PlaceOnHold("fraud review"))
+		require.NoError(t, order.ReleaseHold())
+
+		err := order.ReleaseHold()
+
+		assert.ErrorIs(t, err, ErrAlreadyInStatus)
+		assert.Equal(t, OrderStatusPending, order.Status)
+	})
+
+	t.Run("release of an order that cannot be held", func(t *testing.T) {
+		order, _ := NewOrder(123)
+		order.Status = OrderStatusDelivered
+
+		err := order.ReleaseHold()
+
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrAlreadyInStatus)
+	})
+}
+
+func TestOrder_OnHoldBlocksTransitions(t *testing.T) {
+	order, _ := NewOrder(123)
+	order.AddItem(1, "SKU-001", "Product 1", 1, 10.0)
+	assert.NoError(t, order.PlaceOnHold("fraud review"))
+
+	err := order.ConfirmOrder()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "order is on hold")
+
+	err = order.TransitionToShipped()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "order is on hold")
+
+	err = order.AddItem(2, "SKU-002", "Product 2", 1, 5.0)
+	assert.Error(t, err)
+
+	assert.NoError(t, order.CancelOrder())
+	assert.Equal(t, OrderStatusCancelled, order.Status)
+	assert.Empty(t, order.HeldFromStatus)
+}
+
+func TestOrder_RefundAmount(t *testing.T) {
+	newDeliveredOrder := func() *Order {
+		order, _ := NewOrder(123)
+		order.AddItem(1, "SKU-001", "Product 1", 3, 10.10)
+		order.Status = OrderStatusDelivered
+		return order
+	}
+
+	t.Run("partial refund keeps status", func(t *testing.T) {
+		order := newDeliveredOrder()
+
+		err := order.RefundAmount(10.10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 10.10, order.RefundedAmount)
+		assert.Equal(t, OrderStatusDelivered, order.Status)
+		assert.True(t, order.IsPartiallyRefunded())
+	})
+
+	t.Run("refunding the remainder moves to refunded", func(t *testing.T) {
+		order := newDeliveredOrder()
+		order.Status = OrderStatusReturned
+
+		assert.NoError(t, order.RefundAmount(10.10))
+		assert.NoError(t, order.RefundAmount(20.20))
+
+		assert.Equal(t, OrderStatusRefunded, order.Status)
+		assert.False(t, order.IsPartiallyRefunded())
+	})
+
+	t.Run("amount exceeding the total is rejected", func(t *testing.T) {
+		order := newDeliveredOrder()
+		assert.NoError(t, order.RefundAmount(30))
+
+		err := order.RefundAmount(0.31)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "refund amount exceeds the order total")
+		assert.Equal(t, 30.0, order.RefundedAmount)
+	})
+
+	t.Run("non positive amount is rejected", func(t *testing.T) {
+		order := newDeliveredOrder()
+
+		err := order.RefundAmount(0)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "refund amount must be positive")
+	})
+
+	t.Run("undelivered order cannot be refunded", func(t *testing.T) {
+		order := newDeliveredOrder()
+		order.Status = OrderStatusShipped
+
+		err := order.RefundAmount(5)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "order cannot be refunded in current status")
+	})
+
+	t.Run("full refund transition records the whole total", func(t *testing.T) {
+		order := newDeliveredOrder()
+		assert.NoError(t, order.RefundAmount(5))
+
+		assert.NoError(t, order.TransitionToRefunded())
+
+		assert.InDelta(t, 30.30, order.RefundedAmount, 0.001)
+	})
+}
+
 func TestOrder_BusinessRules(t *testing.T) {
 	order, _ := NewOrder(123)
 
@@ -583,10 +1037,63 @@ func TestOrder_GetCounts(t *testing.T) {
 	assert.Equal(t, 5, order.GetTotalQuantity()) // 2 + 3
 }
 
+func TestValidateItems(t *testing.T) {
+	// Given five items, three of them invalid
+	inputs := []OrderItemInput{
+		{ProductID: 1, ProductSKU: "SKU-1", ProductName: "Product 1", Quantity: 1, UnitPrice: 10},
+		{ProductID: 2, ProductSKU: "SKU-2", ProductName: "Product 2", Quantity: 1, UnitPrice: 0},
+		{ProductID: 3, ProductSKU: "SKU-3", ProductName: "Product 3", Quantity: 1, UnitPrice: 10},
+		{ProductID: 0, ProductSKU: "  ", ProductName: "Product 4", Quantity: -2, UnitPrice: 10},
+		{ProductID: 5, ProductSKU: "SKU-5", ProductName: "", Quantity: 1, UnitPrice: 10},
+	}
+
+	// When
+	err := ValidateItems(inputs)
+
+	// Then
+	var itemErrors ItemErrors
+	require.ErrorAs(t, err, &itemErrors)
+	assert.Equal(t, ItemErrors{
+		{Index: 1, Field: "unit_price", Reason: "unit price must be positive"},
+		{Index: 3, Field: "product_id", Reason: "product ID is required"},
+		{Index: 3, Field: "product_sku", Reason: "product SKU is required"},
+		{Index: 3, Field: "quantity", Reason: "quantity must be positive"},
+		{Index: 4, Field: "product_name", Reason: "product name is required"},
+	}, itemErrors)
+	assert.Contains(t, err.Error(), "item 3: quantity must be positive")
+
+	assert.NoError(t, ValidateItems(inputs[:1]))
+	assert.NoError(t, ValidateItems(nil))
+}
+
+func TestValidateItems_RejectsRepeatedProducts(t *testing.T) {
+	inputs := []OrderItemInput{
+		{ProductID: 1, ProductSKU: "SKU-1", ProductName: "Product 1", Quantity: 1, UnitPrice: 10},
+		{ProductID: 2, ProductSKU: "SKU-2", ProductName: "Product 2", Quantity: 1, UnitPrice: 10},
+		{ProductID: 1, ProductSKU: "SKU-1", ProductName: "Product 1", Quantity: 2, UnitPrice: 10},
+		{ProductID: 1, ProductSKU: "SKU-1", ProductName: "Product 1", Quantity: 3, UnitPrice: 10},
+	}
+
+	var itemErrors ItemErrors
+	require.ErrorAs(t, ValidateItems(inputs), &itemErrors)
+	assert.Equal(t, ItemErrors{
+		{Index: 2, Field: "product_id", Reason: "duplicate product ID 1, already listed at item 0"},
+		{Index: 3, Field: "product_id", Reason: "duplicate product ID 1, already listed at item 0"},
+	}, itemErrors)
+}
+
+func TestOrderStatus_CountsTowardsSpend(t *testing.T) {
+	for _, status := range OrderStatuses() {
+		excluded := status == OrderStatusCancelled || status == OrderStatusRefunded
+		assert.Equal(t, !excluded, status.CountsTowardsSpend(), status)
+	}
+}
+
 func TestValidateOrderStatus(t *testing.T) {
 	validStatuses := []OrderStatus{
 		OrderStatusPending, OrderStatusConfirmed, OrderStatusProcessing,
 		OrderStatusShipped, OrderStatusDelivered, OrderStatusCancelled, OrderStatusRefunded,
+		OrderStatusReturnRequested, OrderStatusReturned, OrderStatusOnHold,
 	}
 
 	for _, status := range validStatuses {
@@ -595,3 +1102,56 @@ func TestValidateOrderStatus(t *testing.T) {
 
 	assert.Error(t, ValidateOrderStatus("invalid_status"))
 }
+
+func TestOrder_Clone(t *testing.T) {
+	order, _ := NewOrder(123)
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 1, 10.0))
+	order.SetExpiry(time.Hour)
+
+	clone := order.Clone()
+	require.NoError(t, order.UpdateItemQuantity(1, 5))
+	*order.ExpiresAt = order.ExpiresAt.Add(time.Hour)
+
+	assert.Equal(t, 1, clone.Items[0].Quantity)
+	assert.Equal(t, 10.0, clone.TotalAmount)
+	assert.Equal(t, order.CreatedAt.Add(time.Hour), *clone.ExpiresAt)
+}
+
+func TestOrder_Clone_NoAliasing(t *testing.T) {
+	order, _ := NewOrder(123)
+	weight := 250
+	require.NoError(t, order.AddItem(1, "SKU-001", "Product 1", 2, 10.0,
+		WithAttributes(map[string]string{"size": "M"}), WithUnitWeightGrams(&weight)))
+	require.NoError(t, order.SetTags([]string{"gift"}))
+	order.CancelledItems = []CancelledItem{{ProductID: 2, Quantity: 1}}
+	order.CalculateWeight()
+	original := order.Clone()
+
+	clone := order.Clone()
+	clone.Items[0].Quantity = 9
+	clone.Items[0].Attributes["size"] = "XL"
+	*clone.Items[0].UnitWeightGrams = 1
+	clone.Items = append(clone.Items, OrderItem{ProductID: 3})
+	clone.Tags[0] = "vip"
+	clone.CancelledItems[0].Quantity = 5
+	*clone.TotalWeightGrams = 1
+
+	assert.Equal(t, original, order)
+}
+
+func BenchmarkOrder_CalculateTotal(b *testing.B) {
+	for _, lines := range []int{10, 1000, 100000} {
+		b.Run(fmt.Sprintf("items=%d", lines), func(b *testing.B) {
+			order := &Order{Items: make([]OrderItem, lines)}
+			for i := range order.Items {
+				order.Items[i] = OrderItem{ProductID: uint(i + 1), Quantity: 2, UnitPrice: 9.99, TotalPrice: 19.98}
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				order.CalculateTotal()
+			}
+		})
+	}
+}
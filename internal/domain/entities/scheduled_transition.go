@@ -0,0 +1,131 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ScheduledTransitionStatus is the state of a status change scheduled for later
+type ScheduledTransitionStatus string
+
+const (
+	ScheduledTransitionPending   ScheduledTransitionStatus = "pending"
+	ScheduledTransitionExecuted  ScheduledTransitionStatus = "executed"
+	ScheduledTransitionFailed    ScheduledTransitionStatus = "failed"
+	ScheduledTransitionCancelled ScheduledTransitionStatus = "cancelled"
+)
+
+// MaxFailureMessageLength is the maximum number of characters of the failure message kept
+const MaxFailureMessageLength = 500
+
+// Errors returned when a scheduled transition is rejected
+var (
+	ErrInvalidScheduledTransition    = errors.New("invalid scheduled transition")
+	ErrScheduledTransitionNotPending = errors.New("scheduled transition is no longer pending")
+)
+
+// ScheduledTransition moves an order to TargetStatus once ExecuteAt passed, unless it is cancelled first.
+// It ends executed, failed with the error that rejected the transition, or cancelled.
+type ScheduledTransition struct {
+	ID           uint                      `json:"id"`
+	OrderID      uint                      `json:"order_id"`
+	TargetStatus OrderStatus               `json:"target_status"`
+	ExecuteAt    time.Time                 `json:"execute_at"`
+	CreatedBy    string                    `json:"created_by"`
+	Status       ScheduledTransitionStatus `json:"status"`
+	// FailureCode and FailureMessage describe the error of a failed transition
+	FailureCode    string     `json:"failure_code,omitempty"`
+	FailureMessage string     `json:"failure_message,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+}
+
+// IsPending checks if the transition still waits for its execution time
+func (s *ScheduledTransition) IsPending() bool {
+	return s.Status == ScheduledTransitionPending
+}
+
+// IsDue checks if a pending transition should execute at now
+func (s *ScheduledTransition) IsDue(now time.Time) bool {
+	return s.IsPending() && !s.ExecuteAt.After(now)
+}
+
+// MarkExecuted records that the order moved to the target status at the given instant
+func (s *ScheduledTransition) MarkExecuted(at time.Time) error {
+	return s.complete(ScheduledTransitionExecuted, at)
+}
+
+// MarkFailed records that the order refused the transition with the given error code and message,
+// the message is cut to MaxFailureMessageLength characters
+func (s *ScheduledTransition) MarkFailed(at time.Time, code, message string) error {
+	if err := s.complete(ScheduledTransitionFailed, at); err != nil {
+		return err
+	}
+	if runes := []rune(message); len(runes) > MaxFailureMessageLength {
+		message = string(runes[:MaxFailureMessageLength])
+	}
+	s.FailureCode = code
+	s.FailureMessage = message
+	return nil
+}
+
+// Cancel stops a pending transition from executing
+func (s *ScheduledTransition) Cancel(at time.Time) error {
+	return s.complete(ScheduledTransitionCancelled, at)
+}
+
+func (s *ScheduledTransition) complete(status ScheduledTransitionStatus, at time.Time) error {
+	if !s.IsPending() {
+		return ErrScheduledTransitionNotPending
+	}
+	completedAt := at.UTC()
+	s.Status = status
+	s.CompletedAt = &completedAt
+	return nil
+}
+
+// Clone returns a copy of the scheduled transition that shares no memory with it
+func (s *ScheduledTransition) Clone() *ScheduledTransition {
+	clone := *s
+	if s.CompletedAt != nil {
+		completedAt := *s.CompletedAt
+		clone.CompletedAt = &completedAt
+	}
+	return &clone
+}
+
+// ScheduleTransition builds a transition moving the order to target at executeAt. The state machine
+// must allow the move from the current status, guards are only evaluated on execution since the order
+// may still change until then. Statuses needing a reason, a hold release or shipments cannot be scheduled.
+func (o *Order) ScheduleTransition(target OrderStatus, executeAt time.Time, createdBy string, now time.Time) (*ScheduledTransition, error) {
+	if err := ValidateOrderStatus(target); err != nil {
+		return nil, err
+	}
+
+	t := &Transition{From: o.Status, To: target, At: now}
+	rule, ok := orderTransitions[o.Status][target]
+	switch {
+	case !ok && o.IsOnHold():
+		return nil, o.transitionError(t, errors.New("order is on hold"))
+	case !ok && o.Status == OrderStatusExpired:
+		return nil, o.transitionError(t, ErrOrderExpired)
+	case !ok:
+		return nil, o.transitionError(t, errors.New(invalidTransitionMessages[target]))
+	case rule.needsReason || rule.needsRelease || rule.needsShipments:
+		return nil, o.transitionError(t, fmt.Errorf("transitions to %s cannot be scheduled", target))
+	}
+
+	if !executeAt.After(now) {
+		return nil, fmt.Errorf("%w: execute_at must be in the future", ErrInvalidScheduledTransition)
+	}
+
+	return &ScheduledTransition{
+		OrderID:      o.ID,
+		TargetStatus: target,
+		ExecuteAt:    executeAt.UTC(),
+		CreatedBy:    createdBy,
+		Status:       ScheduledTransitionPending,
+		CreatedAt:    now.UTC(),
+	}, nil
+}
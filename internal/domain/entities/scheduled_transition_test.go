@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrder_ScheduleTransition(t *testing.T) {
+	order, _ := NewOrder(123)
+	order.ID = 7
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	scheduled, err := order.ScheduleTransition(OrderStatusCancelled, now.Add(24*time.Hour), "support", now)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), scheduled.OrderID)
+	assert.Equal(t, OrderStatusCancelled, scheduled.TargetStatus)
+	assert.Equal(t, ScheduledTransitionPending, scheduled.Status)
+	assert.Equal(t, "support", scheduled.CreatedBy)
+	assert.False(t, scheduled.IsDue(now))
+	assert.True(t, scheduled.IsDue(now.Add(24*time.Hour)))
+}
+
+func TestOrder_ScheduleTransition_Rejected(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		status OrderStatus
+		target OrderStatus
+		reason string
+	}{
+		{"not reachable", OrderStatusPending, OrderStatusShipped, "only processing or partially shipped orders can be shipped"},
+		{"needs a reason", OrderStatusPending, OrderStatusOnHold, "transitions to on_hold cannot be scheduled"},
+		{"derived from shipments", OrderStatusProcessing, OrderStatusPartiallyShipped, "transitions to partially_shipped cannot be scheduled"},
+		{"terminal status", OrderStatusExpired, OrderStatusConfirmed, "order has expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, _ := NewOrder(123)
+			order.Status = tt.status
+
+			scheduled, err := order.ScheduleTransition(tt.target, now.Add(time.Hour), "support", now)
+
+			var transitionErr *TransitionError
+			require.ErrorAs(t, err, &transitionErr)
+			assert.Contains(t, transitionErr.Reason, tt.reason)
+			assert.Nil(t, scheduled)
+		})
+	}
+}
+
+func TestOrder_ScheduleTransition_RequiresKnownStatusAndFutureTime(t *testing.T) {
+	order, _ := NewOrder(123)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	_, err := order.ScheduleTransition(OrderStatus("archived"), now.Add(time.Hour), "support", now)
+	assert.ErrorIs(t, err, ErrUnknownOrderStatus)
+
+	_, err = order.ScheduleTransition(OrderStatusCancelled, now, "support", now)
+	assert.ErrorIs(t, err, ErrInvalidScheduledTransition)
+}
+
+func TestScheduledTransition_CompletesOnce(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	scheduled := &ScheduledTransition{Status: ScheduledTransitionPending, ExecuteAt: now}
+
+	require.NoError(t, scheduled.MarkFailed(now, "INVALID_STATUS_TRANSITION", "order cannot be cancelled in current status"))
+
+	assert.Equal(t, ScheduledTransitionFailed, scheduled.Status)
+	assert.Equal(t, "INVALID_STATUS_TRANSITION", scheduled.FailureCode)
+	require.NotNil(t, scheduled.CompletedAt)
+	assert.False(t, scheduled.IsDue(now))
+	assert.ErrorIs(t, scheduled.Cancel(now), ErrScheduledTransitionNotPending)
+	assert.ErrorIs(t, scheduled.MarkExecuted(now), ErrScheduledTransitionNotPending)
+}
@@ -0,0 +1,74 @@
+package entities
+
+import "time"
+
+// MaxWebhookErrorLength is the maximum number of characters of the last delivery error kept
+const MaxWebhookErrorLength = 500
+
+// WebhookDeadLetter keeps a webhook delivery that failed after its retries were exhausted, so it can be
+// replayed once the partner endpoint is reachable again. Payload is the signed body as it was delivered.
+type WebhookDeadLetter struct {
+	ID        uint   `json:"id"`
+	WebhookID string `json:"webhook_id"`
+	EventType string `json:"event_type"`
+	Payload   []byte `json:"payload"`
+	// LastError describes why the last delivery attempt failed, Attempts counts the attempts of the
+	// delivery and of the replays that failed since
+	LastError  string     `json:"last_error"`
+	Attempts   int        `json:"attempts"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ReplayedAt *time.Time `json:"replayed_at,omitempty"`
+}
+
+// NewWebhookDeadLetter records a delivery of payload to webhookID that failed after attempts with cause
+func NewWebhookDeadLetter(webhookID, eventType string, payload []byte, attempts int, cause error, now time.Time) *WebhookDeadLetter {
+	return &WebhookDeadLetter{
+		WebhookID: webhookID,
+		EventType: eventType,
+		Payload:   append([]byte(nil), payload...),
+		LastError: truncateWebhookError(cause),
+		Attempts:  attempts,
+		CreatedAt: now.UTC(),
+	}
+}
+
+// IsReplayed checks if a replay delivered the payload or is delivering it
+func (d *WebhookDeadLetter) IsReplayed() bool {
+	return d.ReplayedAt != nil
+}
+
+// MarkReplayed records the replay started at the given instant
+func (d *WebhookDeadLetter) MarkReplayed(at time.Time) {
+	replayedAt := at.UTC()
+	d.ReplayedAt = &replayedAt
+}
+
+// RecordFailure adds the attempts of a failed replay and makes the dead letter pending again,
+// the error is cut to MaxWebhookErrorLength characters
+func (d *WebhookDeadLetter) RecordFailure(attempts int, cause error) {
+	d.Attempts += attempts
+	d.LastError = truncateWebhookError(cause)
+	d.ReplayedAt = nil
+}
+
+// Clone returns a copy of the dead letter that shares no memory with it
+func (d *WebhookDeadLetter) Clone() *WebhookDeadLetter {
+	clone := *d
+	clone.Payload = append([]byte(nil), d.Payload...)
+	if d.ReplayedAt != nil {
+		replayedAt := *d.ReplayedAt
+		clone.ReplayedAt = &replayedAt
+	}
+	return &clone
+}
+
+func truncateWebhookError(cause error) string {
+	if cause == nil {
+		return ""
+	}
+	message := cause.Error()
+	if runes := []rune(message); len(runes) > MaxWebhookErrorLength {
+		message = string(runes[:MaxWebhookErrorLength])
+	}
+	return message
+}
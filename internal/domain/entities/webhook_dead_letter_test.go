@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWebhookDeadLetter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	payload := []byte(`{"type":"order.created"}`)
+
+	deadLetter := NewWebhookDeadLetter("partner", "order.created", payload, 5, errors.New("unexpected status 503"), now)
+	payload[0] = '['
+
+	assert.Equal(t, "partner", deadLetter.WebhookID)
+	assert.Equal(t, "order.created", deadLetter.EventType)
+	assert.Equal(t, `{"type":"order.created"}`, string(deadLetter.Payload))
+	assert.Equal(t, "unexpected status 503", deadLetter.LastError)
+	assert.Equal(t, 5, deadLetter.Attempts)
+	assert.Equal(t, now.UTC(), deadLetter.CreatedAt)
+	assert.False(t, deadLetter.IsReplayed())
+}
+
+func TestWebhookDeadLetter_ReplayLifecycle(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	deadLetter := NewWebhookDeadLetter("partner", "order.created", []byte(`{}`), 5, errors.New("timeout"), now)
+
+	deadLetter.MarkReplayed(now.Add(time.Hour))
+	require.True(t, deadLetter.IsReplayed())
+	assert.Equal(t, now.Add(time.Hour), *deadLetter.ReplayedAt)
+
+	deadLetter.RecordFailure(3, errors.New(strings.Repeat("é", MaxWebhookErrorLength+10)))
+	assert.False(t, deadLetter.IsReplayed())
+	assert.Equal(t, 8, deadLetter.Attempts)
+	assert.Equal(t, strings.Repeat("é", MaxWebhookErrorLength), deadLetter.LastError)
+}
+
+func TestWebhookDeadLetter_Clone(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	deadLetter := NewWebhookDeadLetter("partner", "order.created", []byte(`{}`), 1, errors.New("timeout"), now)
+	deadLetter.MarkReplayed(now)
+
+	clone := deadLetter.Clone()
+	clone.Payload[0] = '['
+	*clone.ReplayedAt = now.Add(time.Hour)
+
+	assert.Equal(t, `{}`, string(deadLetter.Payload))
+	assert.Equal(t, now, *deadLetter.ReplayedAt)
+}
@@ -6,13 +6,45 @@ type DomainError struct {
 	Code    string
 	Message string
 	Field   string
+	Details map[string]interface{}
+	// Cause is the underlying error, it is logged but never shown to clients
+	Cause error
 }
 
 func (e *DomainError) Error() string {
+	message := fmt.Sprintf("%s: %s", e.Code, e.Message)
 	if e.Field != "" {
-		return fmt.Sprintf("%s: %s (field: %s)", e.Code, e.Message, e.Field)
+		message = fmt.Sprintf("%s (field: %s)", message, e.Field)
 	}
-	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+	if e.Cause != nil {
+		message = fmt.Sprintf("%s: %v", message, e.Cause)
+	}
+	return message
+}
+
+// Unwrap returns the cause so errors.Is and errors.As see through the domain error
+func (e *DomainError) Unwrap() error {
+	return e.Cause
+}
+
+// Is matches domain errors by code, so copies made by WithDetails still match their sentinel
+func (e *DomainError) Is(target error) bool {
+	t, ok := target.(*DomainError)
+	return ok && t.Code == e.Code
+}
+
+// WrapDomainError returns a copy of base caused by cause, matching base with errors.Is
+func WrapDomainError(base *DomainError, cause error) *DomainError {
+	wrapped := *base
+	wrapped.Cause = cause
+	return &wrapped
+}
+
+// WithDetails returns a copy of the error carrying extra context for the client
+func (e *DomainError) WithDetails(details map[string]interface{}) *DomainError {
+	withDetails := *e
+	withDetails.Details = details
+	return &withDetails
 }
 
 // Order-specific domain errors
@@ -22,30 +54,341 @@ var (
 		Message: "Order not found",
 	}
 
+	ErrOrderDeleted = &DomainError{
+		Code:    "ORDER_DELETED",
+		Message: "Order has been deleted",
+	}
+
 	ErrOrderAlreadyExists = &DomainError{
 		Code:    "ORDER_ALREADY_EXISTS",
 		Message: "Order with this ID already exists",
 		Field:   "id",
 	}
 
+	ErrDuplicateExternalReference = &DomainError{
+		Code:    "DUPLICATE_EXTERNAL_REFERENCE",
+		Message: "Customer already has an order with this external reference",
+		Field:   "external_reference",
+	}
+
+	ErrDuplicateOrderNumber = &DomainError{
+		Code:    "DUPLICATE_ORDER_NUMBER",
+		Message: "Another order already has this order number",
+		Field:   "order_number",
+	}
+
 	ErrInvalidCustomerID = &DomainError{
 		Code:    "INVALID_CUSTOMER_ID",
 		Message: "Customer ID is required",
 		Field:   "customer_id",
 	}
 
+	ErrInvalidExternalReference = &DomainError{
+		Code:    "INVALID_EXTERNAL_REFERENCE",
+		Message: "External reference must be at most 100 characters",
+		Field:   "external_reference",
+	}
+
+	ErrInvalidOrderTags = &DomainError{
+		Code:    "INVALID_ORDER_TAGS",
+		Message: "An order has at most 10 tags of at most 32 characters",
+		Field:   "tags",
+	}
+
 	ErrInvalidOrderStatus = &DomainError{
 		Code:    "INVALID_ORDER_STATUS",
 		Message: "Invalid order status",
 		Field:   "status",
 	}
 
+	ErrInvalidOrderHistory = &DomainError{
+		Code:    "INVALID_ORDER_HISTORY",
+		Message: "Invalid status or timestamps for an imported order",
+		Field:   "status",
+	}
+
 	ErrInvalidStatusTransition = &DomainError{
 		Code:    "INVALID_STATUS_TRANSITION",
 		Message: "Invalid status transition",
 		Field:   "status",
 	}
 
+	// Shipping details given with a status change
+	ErrInvalidShippingMethod = &DomainError{
+		Code:    "INVALID_SHIPPING_METHOD",
+		Message: "Shipping method must be standard, express or pickup",
+		Field:   "shipping_method",
+	}
+
+	ErrInvalidEstimatedDelivery = &DomainError{
+		Code:    "INVALID_ESTIMATED_DELIVERY",
+		Message: "Estimated delivery cannot be in the past",
+		Field:   "estimated_delivery_at",
+	}
+
+	ErrInvalidTrackingNumber = &DomainError{
+		Code:    "INVALID_TRACKING_NUMBER",
+		Message: "Tracking number is too long",
+		Field:   "tracking.tracking_number",
+	}
+
+	// Fulfillment priority
+	ErrInvalidOrderPriority = &DomainError{
+		Code:    "INVALID_ORDER_PRIORITY",
+		Message: "Priority must be low, normal, high or urgent",
+		Field:   "priority",
+	}
+
+	ErrPriorityChangeNotAllowed = &DomainError{
+		Code:    "PRIORITY_CHANGE_NOT_ALLOWED",
+		Message: "Only the priority of pending or confirmed orders can be changed",
+		Field:   "status",
+	}
+
+	// Contact details of the customer
+	ErrInvalidCustomerEmail = &DomainError{
+		Code:    "INVALID_CUSTOMER_EMAIL",
+		Message: "Customer email must be a valid email address",
+		Field:   "customer_email",
+	}
+
+	ErrInvalidCustomerName = &DomainError{
+		Code:    "INVALID_CUSTOMER_NAME",
+		Message: "Customer name must be at most 200 characters",
+		Field:   "customer_name",
+	}
+
+	ErrContactChangeNotAllowed = &DomainError{
+		Code:    "CONTACT_CHANGE_NOT_ALLOWED",
+		Message: "Only the contact details of pending orders can be changed",
+		Field:   "status",
+	}
+
+	// Shipments of an order
+	ErrShipmentNotFound = &DomainError{
+		Code:    "SHIPMENT_NOT_FOUND",
+		Message: "Shipment not found",
+	}
+
+	ErrInvalidShipment = &DomainError{
+		Code:    "INVALID_SHIPMENT",
+		Message: "Shipment items must belong to the order and not exceed its unshipped quantities",
+		Field:   "items",
+	}
+
+	ErrShipmentNotAllowed = &DomainError{
+		Code:    "SHIPMENT_NOT_ALLOWED",
+		Message: "Only processing or partially shipped orders can be shipped in parts",
+		Field:   "status",
+	}
+
+	ErrShipmentAlreadyDelivered = &DomainError{
+		Code:    "SHIPMENT_ALREADY_DELIVERED",
+		Message: "Shipment is already delivered",
+	}
+
+	// Refunds of an order
+	ErrInvalidRefund = &DomainError{
+		Code:    "INVALID_REFUND",
+		Message: "Refund amount must be positive, its reason and reference within their length limits",
+		Field:   "amount",
+	}
+
+	ErrRefundNotAllowed = &DomainError{
+		Code:    "REFUND_NOT_ALLOWED",
+		Message: "Only delivered, return requested or returned orders can be refunded",
+		Field:   "status",
+	}
+
+	ErrRefundExceedsTotal = &DomainError{
+		Code:    "REFUND_EXCEEDS_TOTAL",
+		Message: "Refunds cannot exceed the order total",
+		Field:   "amount",
+	}
+
+	// Snapshots of orders taken for accounting
+	ErrSnapshotNotFound = &DomainError{
+		Code:    "SNAPSHOT_NOT_FOUND",
+		Message: "Order snapshot not found",
+	}
+
+	ErrInvalidSnapshotVersion = &DomainError{
+		Code:    "INVALID_SNAPSHOT_VERSION",
+		Message: "Snapshot version must be a positive integer",
+		Field:   "version",
+	}
+
+	// Printable order documents
+	ErrInvalidDocumentFormat = &DomainError{
+		Code:    "INVALID_DOCUMENT_FORMAT",
+		Message: "Document format must be html or pdf",
+		Field:   "format",
+	}
+
+	ErrDocumentFormatUnavailable = &DomainError{
+		Code:    "DOCUMENT_FORMAT_UNAVAILABLE",
+		Message: "Documents cannot be rendered in this format",
+		Field:   "format",
+	}
+
+	// Status changes scheduled for later
+	ErrScheduledTransitionNotFound = &DomainError{
+		Code:    "SCHEDULED_TRANSITION_NOT_FOUND",
+		Message: "Scheduled transition not found",
+	}
+
+	ErrInvalidScheduledTransition = &DomainError{
+		Code:    "INVALID_SCHEDULED_TRANSITION",
+		Message: "Scheduled transitions must execute in the future",
+		Field:   "execute_at",
+	}
+
+	ErrScheduledTransitionNotPending = &DomainError{
+		Code:    "SCHEDULED_TRANSITION_NOT_PENDING",
+		Message: "Scheduled transition already executed, failed or was cancelled",
+	}
+
+	// Webhook deliveries that failed after their retries
+	ErrWebhookNotFound = &DomainError{
+		Code:    "WEBHOOK_NOT_FOUND",
+		Message: "Webhook not found",
+	}
+
+	ErrWebhookDeadLetterNotFound = &DomainError{
+		Code:    "WEBHOOK_DEAD_LETTER_NOT_FOUND",
+		Message: "Webhook dead letter not found",
+	}
+
+	ErrWebhookDeadLetterReplayed = &DomainError{
+		Code:    "WEBHOOK_DEAD_LETTER_REPLAYED",
+		Message: "Webhook dead letter was already replayed",
+	}
+
+	ErrWebhookDeliveryFailed = &DomainError{
+		Code:    "WEBHOOK_DELIVERY_FAILED",
+		Message: "Webhook endpoint did not accept the delivery, retry later",
+	}
+
+	// Orders created asynchronously
+	ErrOrderJobNotFound = &DomainError{
+		Code:    "ORDER_JOB_NOT_FOUND",
+		Message: "Order job not found",
+	}
+
+	ErrOrderJobNotPending = &DomainError{
+		Code:    "ORDER_JOB_NOT_PENDING",
+		Message: "Order job already succeeded or failed",
+	}
+
+	// Repricing against the product catalog
+	ErrOrderNotRepriceable = &DomainError{
+		Code:    "ORDER_NOT_REPRICEABLE",
+		Message: "Only pending orders can be repriced",
+		Field:   "status",
+	}
+
+	ErrProductNotInCatalog = &DomainError{
+		Code:    "PRODUCT_NOT_IN_CATALOG",
+		Message: "Product has no current catalog price",
+		Field:   "items",
+	}
+
+	ErrPriceMismatch = &DomainError{
+		Code:    "PRICE_MISMATCH",
+		Message: "Unit price differs from the current catalog price",
+		Field:   "items",
+	}
+
+	// Amendments of confirmed orders
+	ErrOrderRequiresAmendment = &DomainError{
+		Code:    "ORDER_REQUIRES_AMENDMENT",
+		Message: "Items of a confirmed order can only be changed through an amendment",
+		Field:   "status",
+	}
+
+	ErrAmendmentNotAllowed = &DomainError{
+		Code:    "AMENDMENT_NOT_ALLOWED",
+		Message: "Only confirmed orders can be amended",
+		Field:   "status",
+	}
+
+	ErrInvalidAmendment = &DomainError{
+		Code:    "INVALID_AMENDMENT",
+		Message: "Invalid order amendment",
+	}
+
+	// Item cancellation after confirmation
+	ErrItemCancellationNotAllowed = &DomainError{
+		Code:    "ITEM_CANCELLATION_NOT_ALLOWED",
+		Message: "Only single line items of confirmed orders can be cancelled",
+		Field:   "status",
+	}
+
+	ErrLastItemCancellation = &DomainError{
+		Code:    "LAST_ITEM_CANCELLATION",
+		Message: "Cancelling the remaining quantity would empty the order, cancel the order instead",
+		Field:   "quantity",
+	}
+
+	// Backordered items
+	ErrFulfillmentNotAllowed = &DomainError{
+		Code:    "FULFILLMENT_NOT_ALLOWED",
+		Message: "Only single line items of confirmed or processing orders can be fulfilled",
+		Field:   "status",
+	}
+
+	ErrItemNotBackordered = &DomainError{
+		Code:    "ITEM_NOT_BACKORDERED",
+		Message: "Order item is not backordered",
+		Field:   "product_id",
+	}
+
+	ErrInventoryUnavailable = &DomainError{
+		Code:    "INVENTORY_UNAVAILABLE",
+		Message: "Inventory is unavailable, retry later",
+	}
+
+	// Dependencies the service stopped calling while their circuit breaker is open
+	ErrDependencyUnavailable = &DomainError{
+		Code:    "DEPENDENCY_UNAVAILABLE",
+		Message: "A dependency is unavailable, retry later",
+	}
+
+	// Payment authorization of confirmed orders
+	ErrPaymentDeclined = &DomainError{
+		Code:    "PAYMENT_DECLINED",
+		Message: "Payment was declined",
+	}
+
+	ErrPaymentUnavailable = &DomainError{
+		Code:    "PAYMENT_UNAVAILABLE",
+		Message: "Payment gateway is unavailable, retry later",
+	}
+
+	// Minimum order amount
+	ErrOrderBelowMinimum = &DomainError{
+		Code:    "ORDER_BELOW_MINIMUM",
+		Message: "Order total is below the minimum order amount",
+		Field:   "total_amount",
+	}
+
+	ErrMinimumOverrideForbidden = &DomainError{
+		Code:    "MINIMUM_OVERRIDE_FORBIDDEN",
+		Message: "Only admins can confirm orders below the minimum order amount",
+		Field:   "override_minimum",
+	}
+
+	ErrCustomerNotAllowed = &DomainError{
+		Code:    "CUSTOMER_NOT_ALLOWED",
+		Message: "The API key cannot create orders for another customer",
+		Field:   "customer_id",
+	}
+
+	ErrCatalogUnavailable = &DomainError{
+		Code:    "CATALOG_UNAVAILABLE",
+		Message: "Product catalog is unavailable, retry later",
+	}
+
 	ErrOrderAlreadyConfirmed = &DomainError{
 		Code:    "ORDER_ALREADY_CONFIRMED",
 		Message: "Order is already confirmed and cannot be modified",
@@ -61,6 +404,12 @@ var (
 		Message: "Order cannot be cancelled in current status",
 	}
 
+	ErrOrderNotDeletable = &DomainError{
+		Code:    "ORDER_NOT_DELETABLE",
+		Message: "Orders that are processing or shipped cannot be deleted",
+		Field:   "status",
+	}
+
 	ErrEmptyOrder = &DomainError{
 		Code:    "EMPTY_ORDER",
 		Message: "Order must have at least one item",
@@ -104,16 +453,59 @@ var (
 
 	ErrInvalidUnitPrice = &DomainError{
 		Code:    "INVALID_UNIT_PRICE",
-		Message: "Unit price must be positive",
+		Message: "Unit price must be a positive amount with at most 2 decimal places",
 		Field:   "unit_price",
 	}
 
+	ErrInvalidOrderItems = &DomainError{
+		Code:    "INVALID_ORDER_ITEMS",
+		Message: "One or more order items are invalid",
+		Field:   "items",
+	}
+
 	ErrDuplicateOrderItem = &DomainError{
 		Code:    "DUPLICATE_ORDER_ITEM",
 		Message: "Product already exists in order",
 		Field:   "product_id",
 	}
 
+	ErrTooManyPendingOrders = &DomainError{
+		Code:    "TOO_MANY_PENDING_ORDERS",
+		Message: "Customer has too many pending orders, confirm or cancel some first",
+		Field:   "customer_id",
+	}
+
+	ErrPossibleDuplicateOrder = &DomainError{
+		Code:    "POSSIBLE_DUPLICATE_ORDER",
+		Message: "Customer just created an order with the same items, set allow_duplicate to create another one",
+		Field:   "items",
+	}
+
+	// Order size limits
+	ErrOrderItemLimitExceeded = &DomainError{
+		Code:    "ORDER_ITEM_LIMIT_EXCEEDED",
+		Message: "Order has too many distinct items",
+		Field:   "items",
+	}
+
+	ErrQuantityLimitExceeded = &DomainError{
+		Code:    "QUANTITY_LIMIT_EXCEEDED",
+		Message: "Item quantity exceeds the allowed maximum",
+		Field:   "quantity",
+	}
+
+	ErrWeightLimitExceeded = &DomainError{
+		Code:    "WEIGHT_LIMIT_EXCEEDED",
+		Message: "Item weight exceeds the allowed maximum",
+		Field:   "unit_weight_grams",
+	}
+
+	ErrOrderTotalLimitExceeded = &DomainError{
+		Code:    "ORDER_TOTAL_LIMIT_EXCEEDED",
+		Message: "Order total exceeds the allowed maximum",
+		Field:   "total_amount",
+	}
+
 	// Repository errors
 	ErrFailedToCreateOrder = &DomainError{
 		Code:    "FAILED_TO_CREATE_ORDER",
@@ -134,12 +526,135 @@ var (
 		Code:    "FAILED_TO_LIST_ORDERS",
 		Message: "Failed to list orders",
 	}
+
+	ErrFailedToExportOrders = &DomainError{
+		Code:    "FAILED_TO_EXPORT_ORDERS",
+		Message: "Failed to export orders",
+	}
+
+	ErrFailedToGetOrderStats = &DomainError{
+		Code:    "FAILED_TO_GET_ORDER_STATS",
+		Message: "Failed to get order statistics",
+	}
+
+	ErrInvalidDateRange = &DomainError{
+		Code:    "INVALID_DATE_RANGE",
+		Message: "Date range must start before it ends and span at most 366 days",
+		Field:   "from",
+	}
+
+	ErrInvalidPagination = &DomainError{
+		Code:    "INVALID_PAGINATION",
+		Message: "Invalid pagination parameters",
+	}
+
+	// Export errors
+	ErrFailedToExpireOrders = &DomainError{
+		Code:    "FAILED_TO_EXPIRE_ORDERS",
+		Message: "Failed to expire pending orders",
+	}
+
+	ErrFailedToGetAuditLog = &DomainError{
+		Code:    "FAILED_TO_GET_AUDIT_LOG",
+		Message: "Failed to retrieve the order audit log",
+	}
+
+	ErrFailedToGetShipments = &DomainError{
+		Code:    "FAILED_TO_GET_SHIPMENTS",
+		Message: "Failed to retrieve the order shipments",
+	}
+
+	ErrFailedToGetRefunds = &DomainError{
+		Code:    "FAILED_TO_GET_REFUNDS",
+		Message: "Failed to retrieve the order refunds",
+	}
+
+	ErrFailedToGetSnapshots = &DomainError{
+		Code:    "FAILED_TO_GET_SNAPSHOTS",
+		Message: "Failed to retrieve the order snapshots",
+	}
+
+	ErrFailedToTakeSnapshot = &DomainError{
+		Code:    "FAILED_TO_TAKE_SNAPSHOT",
+		Message: "Failed to take a snapshot of the order",
+	}
+
+	ErrFailedToRenderDocument = &DomainError{
+		Code:    "FAILED_TO_RENDER_DOCUMENT",
+		Message: "Failed to render the order document",
+	}
+
+	ErrFailedToGetScheduledTransitions = &DomainError{
+		Code:    "FAILED_TO_GET_SCHEDULED_TRANSITIONS",
+		Message: "Failed to retrieve the scheduled transitions of the order",
+	}
+
+	ErrFailedToExecuteScheduledTransitions = &DomainError{
+		Code:    "FAILED_TO_EXECUTE_SCHEDULED_TRANSITIONS",
+		Message: "Failed to execute due scheduled transitions",
+	}
+
+	ErrFailedToGetWebhookDeadLetters = &DomainError{
+		Code:    "FAILED_TO_GET_WEBHOOK_DEAD_LETTERS",
+		Message: "Failed to retrieve the webhook dead letters",
+	}
+
+	ErrFailedToReplayWebhookDeadLetters = &DomainError{
+		Code:    "FAILED_TO_REPLAY_WEBHOOK_DEAD_LETTERS",
+		Message: "Failed to replay the webhook dead letters",
+	}
+
+	ErrFailedToSubmitOrderJob = &DomainError{
+		Code:    "FAILED_TO_SUBMIT_ORDER_JOB",
+		Message: "Failed to accept the order for asynchronous creation",
+	}
+
+	ErrFailedToGetOrderJob = &DomainError{
+		Code:    "FAILED_TO_GET_ORDER_JOB",
+		Message: "Failed to retrieve the order job",
+	}
+
+	ErrFailedToProcessOrderJobs = &DomainError{
+		Code:    "FAILED_TO_PROCESS_ORDER_JOBS",
+		Message: "Failed to process pending order jobs",
+	}
+
+	ErrFailedToImportOrders = &DomainError{
+		Code:    "FAILED_TO_IMPORT_ORDERS",
+		Message: "Failed to import orders",
+	}
+
+	ErrRequestCancelled = &DomainError{
+		Code:    "REQUEST_CANCELLED",
+		Message: "The request was cancelled before it completed",
+	}
+
+	ErrOrderExpired = &DomainError{
+		Code:    "ORDER_EXPIRED",
+		Message: "Order has expired and can no longer be confirmed",
+	}
+
+	ErrExportTooLarge = &DomainError{
+		Code:    "EXPORT_TOO_LARGE",
+		Message: "Export exceeds the maximum number of rows, narrow the filters",
+	}
+
+	ErrTooManyEventStreams = &DomainError{
+		Code:    "TOO_MANY_EVENT_STREAMS",
+		Message: "Too many open event streams, retry later",
+	}
+)
+
+// Codes of the errors built by the helpers below
+const (
+	orderValidationErrorCode     = "ORDER_VALIDATION_ERROR"
+	orderItemValidationErrorCode = "ORDER_ITEM_VALIDATION_ERROR"
 )
 
 // Helper functions to create specific errors
 func NewOrderValidationError(field, message string) *DomainError {
 	return &DomainError{
-		Code:    "ORDER_VALIDATION_ERROR",
+		Code:    orderValidationErrorCode,
 		Message: message,
 		Field:   field,
 	}
@@ -147,7 +662,7 @@ func NewOrderValidationError(field, message string) *DomainError {
 
 func NewOrderItemValidationError(field, message string) *DomainError {
 	return &DomainError{
-		Code:    "ORDER_ITEM_VALIDATION_ERROR",
+		Code:    orderItemValidationErrorCode,
 		Message: message,
 		Field:   field,
 	}
@@ -155,7 +670,7 @@ func NewOrderItemValidationError(field, message string) *DomainError {
 
 func NewInvalidStatusTransitionError(from, to string) *DomainError {
 	return &DomainError{
-		Code:    "INVALID_STATUS_TRANSITION",
+		Code:    ErrInvalidStatusTransition.Code,
 		Message: fmt.Sprintf("Cannot transition from %s to %s", from, to),
 		Field:   "status",
 	}
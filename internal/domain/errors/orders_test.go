@@ -0,0 +1,52 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapDomainError(t *testing.T) {
+	// Given
+	cause := errors.New("connection refused")
+
+	// When
+	err := WrapDomainError(ErrFailedToCreateOrder, cause)
+
+	// Then
+	assert.ErrorIs(t, err, ErrFailedToCreateOrder)
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, ErrFailedToUpdateOrder)
+	assert.Equal(t, "FAILED_TO_CREATE_ORDER: Failed to create order: connection refused", err.Error())
+	assert.Nil(t, ErrFailedToCreateOrder.Cause, "the sentinel is left untouched")
+}
+
+func TestDomainError_Unwrap(t *testing.T) {
+	// Given a domain error further down a wrapped chain
+	err := fmt.Errorf("update: %w", WrapDomainError(ErrOrderNotFound, errors.New("record not found")))
+
+	// When
+	var domainErr *DomainError
+	found := errors.As(err, &domainErr)
+
+	// Then
+	assert.True(t, found)
+	assert.Equal(t, ErrOrderNotFound.Code, domainErr.Code)
+	assert.EqualError(t, errors.Unwrap(domainErr), "record not found")
+	assert.Nil(t, ErrOrderNotFound.Unwrap())
+}
+
+func TestDomainError_WithDetailsKeepsCause(t *testing.T) {
+	// Given
+	cause := errors.New("boom")
+
+	// When
+	err := WrapDomainError(ErrFailedToListOrders, cause).WithDetails(map[string]interface{}{"page": 1})
+
+	// Then
+	assert.ErrorIs(t, err, ErrFailedToListOrders)
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, 1, err.Details["page"])
+}
@@ -0,0 +1,155 @@
+package errors
+
+import "net/http"
+
+// StatusClientClosedRequest is the non-standard status for a request the client abandoned
+const StatusClientClosedRequest = 499
+
+// ErrorMapping describes how a domain error code is reported by the transport adapters
+type ErrorMapping struct {
+	HTTPStatus int
+}
+
+// registry holds the mapping of every domain error code. A code missing here is reported as an
+// internal error, TestRegistry_EveryErrorIsRegistered fails when a new code is added without an entry.
+var registry = map[string]ErrorMapping{
+	// Lookups
+	ErrOrderNotFound.Code:               {HTTPStatus: http.StatusNotFound},
+	ErrOrderItemNotFound.Code:           {HTTPStatus: http.StatusNotFound},
+	ErrOrderDeleted.Code:                {HTTPStatus: http.StatusGone},
+	ErrShipmentNotFound.Code:            {HTTPStatus: http.StatusNotFound},
+	ErrScheduledTransitionNotFound.Code: {HTTPStatus: http.StatusNotFound},
+	ErrSnapshotNotFound.Code:            {HTTPStatus: http.StatusNotFound},
+	ErrWebhookNotFound.Code:             {HTTPStatus: http.StatusNotFound},
+	ErrWebhookDeadLetterNotFound.Code:   {HTTPStatus: http.StatusNotFound},
+	ErrOrderJobNotFound.Code:            {HTTPStatus: http.StatusNotFound},
+
+	// Invalid input
+	ErrInvalidCustomerID.Code:          {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidExternalReference.Code:   {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidOrderTags.Code:           {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidOrderStatus.Code:         {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidOrderHistory.Code:        {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidStatusTransition.Code:    {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidShippingMethod.Code:      {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidEstimatedDelivery.Code:   {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidTrackingNumber.Code:      {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidOrderPriority.Code:       {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidCustomerEmail.Code:       {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidCustomerName.Code:        {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidTotalAmount.Code:         {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidProductID.Code:           {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidProductSKU.Code:          {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidProductName.Code:         {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidQuantity.Code:            {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidUnitPrice.Code:           {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidDateRange.Code:           {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidPagination.Code:          {HTTPStatus: http.StatusBadRequest},
+	ErrEmptyOrder.Code:                 {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidOrderItems.Code:          {HTTPStatus: http.StatusBadRequest},
+	ErrOrderItemLimitExceeded.Code:     {HTTPStatus: http.StatusBadRequest},
+	ErrQuantityLimitExceeded.Code:      {HTTPStatus: http.StatusBadRequest},
+	ErrOrderTotalLimitExceeded.Code:    {HTTPStatus: http.StatusBadRequest},
+	ErrWeightLimitExceeded.Code:        {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidShipment.Code:            {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidRefund.Code:              {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidSnapshotVersion.Code:     {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidDocumentFormat.Code:      {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidScheduledTransition.Code: {HTTPStatus: http.StatusBadRequest},
+	ErrInvalidAmendment.Code:           {HTTPStatus: http.StatusBadRequest},
+	orderValidationErrorCode:           {HTTPStatus: http.StatusBadRequest},
+	orderItemValidationErrorCode:       {HTTPStatus: http.StatusBadRequest},
+	ErrOrderAlreadyConfirmed.Code:      {HTTPStatus: http.StatusBadRequest},
+	ErrOrderAlreadyCancelled.Code:      {HTTPStatus: http.StatusBadRequest},
+	ErrOrderCannotBeCancelled.Code:     {HTTPStatus: http.StatusBadRequest},
+	ErrExportTooLarge.Code:             {HTTPStatus: http.StatusRequestEntityTooLarge},
+
+	// Conflicts with the current state
+	ErrOrderAlreadyExists.Code:            {HTTPStatus: http.StatusConflict},
+	ErrDuplicateExternalReference.Code:    {HTTPStatus: http.StatusConflict},
+	ErrDuplicateOrderNumber.Code:          {HTTPStatus: http.StatusConflict},
+	ErrDuplicateOrderItem.Code:            {HTTPStatus: http.StatusConflict},
+	ErrTooManyPendingOrders.Code:          {HTTPStatus: http.StatusConflict},
+	ErrPossibleDuplicateOrder.Code:        {HTTPStatus: http.StatusConflict},
+	ErrOrderExpired.Code:                  {HTTPStatus: http.StatusConflict},
+	ErrOrderNotDeletable.Code:             {HTTPStatus: http.StatusConflict},
+	ErrShipmentNotAllowed.Code:            {HTTPStatus: http.StatusConflict},
+	ErrShipmentAlreadyDelivered.Code:      {HTTPStatus: http.StatusConflict},
+	ErrRefundNotAllowed.Code:              {HTTPStatus: http.StatusConflict},
+	ErrRefundExceedsTotal.Code:            {HTTPStatus: http.StatusConflict},
+	ErrScheduledTransitionNotPending.Code: {HTTPStatus: http.StatusConflict},
+	ErrOrderJobNotPending.Code:            {HTTPStatus: http.StatusConflict},
+	ErrOrderNotRepriceable.Code:           {HTTPStatus: http.StatusConflict},
+	ErrProductNotInCatalog.Code:           {HTTPStatus: http.StatusConflict},
+	ErrPriceMismatch.Code:                 {HTTPStatus: http.StatusConflict},
+	ErrItemCancellationNotAllowed.Code:    {HTTPStatus: http.StatusConflict},
+	ErrLastItemCancellation.Code:          {HTTPStatus: http.StatusConflict},
+	ErrWebhookDeadLetterReplayed.Code:     {HTTPStatus: http.StatusConflict},
+	ErrOrderRequiresAmendment.Code:        {HTTPStatus: http.StatusConflict},
+	ErrAmendmentNotAllowed.Code:           {HTTPStatus: http.StatusConflict},
+	ErrFulfillmentNotAllowed.Code:         {HTTPStatus: http.StatusConflict},
+	ErrItemNotBackordered.Code:            {HTTPStatus: http.StatusConflict},
+	ErrPriorityChangeNotAllowed.Code:      {HTTPStatus: http.StatusConflict},
+	ErrContactChangeNotAllowed.Code:       {HTTPStatus: http.StatusConflict},
+
+	// Business rules
+	ErrOrderBelowMinimum.Code: {HTTPStatus: http.StatusUnprocessableEntity},
+
+	// Payments
+	ErrPaymentDeclined.Code: {HTTPStatus: http.StatusPaymentRequired},
+
+	// Permissions
+	ErrMinimumOverrideForbidden.Code: {HTTPStatus: http.StatusForbidden},
+	ErrCustomerNotAllowed.Code:       {HTTPStatus: http.StatusForbidden},
+
+	// Repository failures
+	ErrFailedToCreateOrder.Code:                 {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToUpdateOrder.Code:                 {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToDeleteOrder.Code:                 {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToListOrders.Code:                  {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToExportOrders.Code:                {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToGetOrderStats.Code:               {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToExpireOrders.Code:                {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToGetAuditLog.Code:                 {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToGetShipments.Code:                {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToGetRefunds.Code:                  {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToGetSnapshots.Code:                {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToTakeSnapshot.Code:                {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToRenderDocument.Code:              {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToGetScheduledTransitions.Code:     {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToExecuteScheduledTransitions.Code: {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToGetWebhookDeadLetters.Code:       {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToReplayWebhookDeadLetters.Code:    {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToSubmitOrderJob.Code:              {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToGetOrderJob.Code:                 {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToProcessOrderJobs.Code:            {HTTPStatus: http.StatusInternalServerError},
+	ErrFailedToImportOrders.Code:                {HTTPStatus: http.StatusInternalServerError},
+
+	// Capacity limits
+	ErrTooManyEventStreams.Code:   {HTTPStatus: http.StatusServiceUnavailable},
+	ErrCatalogUnavailable.Code:    {HTTPStatus: http.StatusServiceUnavailable},
+	ErrInventoryUnavailable.Code:  {HTTPStatus: http.StatusServiceUnavailable},
+	ErrDependencyUnavailable.Code: {HTTPStatus: http.StatusServiceUnavailable},
+	ErrPaymentUnavailable.Code:    {HTTPStatus: http.StatusServiceUnavailable},
+	ErrWebhookDeliveryFailed.Code: {HTTPStatus: http.StatusServiceUnavailable},
+
+	// Features this deployment does not offer
+	ErrDocumentFormatUnavailable.Code: {HTTPStatus: http.StatusNotImplemented},
+
+	// Requests abandoned by the client
+	ErrRequestCancelled.Code: {HTTPStatus: StatusClientClosedRequest},
+}
+
+// Lookup returns the mapping registered for code
+func Lookup(code string) (ErrorMapping, bool) {
+	mapping, ok := registry[code]
+	return mapping, ok
+}
+
+// HTTPStatus returns the HTTP status of code, 500 for codes without a mapping
+func HTTPStatus(code string) int {
+	if mapping, ok := registry[code]; ok {
+		return mapping.HTTPStatus
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,100 @@
+package errors
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// declaredCodes parses the package sources and returns the code of every DomainError literal,
+// so errors added in any file are checked without having to list them here as well
+func declaredCodes(t *testing.T) map[string]string {
+	t.Helper()
+
+	paths, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		require.NoError(t, err)
+		files = append(files, file)
+	}
+
+	constants := make(map[string]string)
+	codes := make(map[string]string)
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			if spec, ok := node.(*ast.ValueSpec); ok {
+				for i, name := range spec.Names {
+					if i < len(spec.Values) {
+						if lit, ok := spec.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+							constants[name.Name], _ = strconv.Unquote(lit.Value)
+						}
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			composite, ok := node.(*ast.CompositeLit)
+			if !ok || !isDomainErrorType(composite.Type) {
+				return true
+			}
+			for _, elt := range composite.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok || kv.Key.(*ast.Ident).Name != "Code" {
+					continue
+				}
+				switch value := kv.Value.(type) {
+				case *ast.BasicLit:
+					code, _ := strconv.Unquote(value.Value)
+					codes[code] = fset.Position(composite.Pos()).String()
+				case *ast.Ident:
+					codes[constants[value.Name]] = fset.Position(composite.Pos()).String()
+				}
+			}
+			return true
+		})
+	}
+	return codes
+}
+
+func isDomainErrorType(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "DomainError"
+}
+
+func TestRegistry_EveryErrorIsRegistered(t *testing.T) {
+	codes := declaredCodes(t)
+	require.NotEmpty(t, codes)
+
+	for code, position := range codes {
+		_, ok := Lookup(code)
+		assert.True(t, ok, "%s declared at %s has no entry in the registry", code, position)
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, HTTPStatus(ErrOrderNotFound.Code))
+	assert.Equal(t, http.StatusGone, HTTPStatus(ErrOrderDeleted.Code))
+	assert.Equal(t, http.StatusConflict, HTTPStatus(ErrOrderNotDeletable.Code))
+	assert.Equal(t, http.StatusBadRequest, HTTPStatus(NewOrderValidationError("customer_id", "invalid").Code))
+	assert.Equal(t, http.StatusInternalServerError, HTTPStatus(ErrFailedToCreateOrder.Code))
+	assert.Equal(t, http.StatusInternalServerError, HTTPStatus("NOT_A_REGISTERED_CODE"), "unknown codes are internal errors")
+}
@@ -0,0 +1,142 @@
+package events
+
+import (
+	"time"
+
+	"orders-service/internal/domain/entities"
+)
+
+// OrderEventType identifies what happened to an order
+type OrderEventType string
+
+const (
+	// OrderCreated is emitted when a new order was stored
+	OrderCreated OrderEventType = "order.created"
+	// OrderItemsChanged is emitted when items were added, removed, replaced or their quantity changed
+	OrderItemsChanged OrderEventType = "order.items_changed"
+	// OrderItemCancelled is emitted when part or all of a line was dropped from a confirmed order
+	OrderItemCancelled OrderEventType = "order.item_cancelled"
+	// OrderAmended is emitted when the items of a confirmed order were changed through an amendment
+	OrderAmended OrderEventType = "order.amended"
+	// OrderItemFulfilled is emitted when stock arrived for a backordered line
+	OrderItemFulfilled OrderEventType = "order.item_fulfilled"
+	// OrderStatusChanged is emitted when an order moved to another status or was placed on or released from hold
+	OrderStatusChanged OrderEventType = "order.status_changed"
+	// OrderPriorityChanged is emitted when the fulfillment priority of an order changed
+	OrderPriorityChanged OrderEventType = "order.priority_changed"
+	// OrderContactChanged is emitted when the contact details of a pending order changed
+	OrderContactChanged OrderEventType = "order.contact_changed"
+	// OrderDeleted is emitted when an order was deleted, the event carries its last state
+	OrderDeleted OrderEventType = "order.deleted"
+	// OrderExpired is emitted when a pending order passed its expiry time without being confirmed
+	OrderExpired OrderEventType = "order.expired"
+	// OrderShipmentCreated is emitted when part or all of an order left in a shipment
+	OrderShipmentCreated OrderEventType = "order.shipment_created"
+	// OrderShipmentDelivered is emitted when a shipment of an order reached the customer
+	OrderShipmentDelivered OrderEventType = "order.shipment_delivered"
+	// OrderRefunded is emitted when part or all of an order was paid back to the customer
+	OrderRefunded OrderEventType = "order.refunded"
+)
+
+// OrderEvent records a change of an order for other services to react to.
+// OrderPublicID is the identifier consumers should key on, OrderID is only meaningful to this service.
+type OrderEvent struct {
+	Type          OrderEventType       `json:"type"`
+	OrderPublicID string               `json:"order_public_id"`
+	OrderID       uint                 `json:"order_id"`
+	CustomerID    uint                 `json:"customer_id"`
+	Status        entities.OrderStatus `json:"status"`
+	OccurredAt    time.Time            `json:"occurred_at"`
+
+	// Priority lets pickers route urgent orders first
+	Priority entities.OrderPriority `json:"priority"`
+
+	// CustomerEmail and CustomerName are the contact details the order was placed with, personal data
+	// that is left out for consumers not allowed to see it
+	CustomerEmail string `json:"customer_email,omitempty"`
+	CustomerName  string `json:"customer_name,omitempty"`
+
+	// Items and TotalAmount are a snapshot of the order lines when the event occurred
+	Items       []entities.OrderItem `json:"items"`
+	TotalAmount float64              `json:"total_amount"`
+
+	// RefundedAmount is the part of TotalAmount paid back to the customer
+	RefundedAmount float64 `json:"refunded_amount"`
+
+	// Carrier and TrackingNumber are set once the order shipped with tracking details
+	Carrier        string `json:"carrier,omitempty"`
+	TrackingNumber string `json:"tracking_number,omitempty"`
+
+	// ShipmentID is set on shipment events, which carry the tracking details of that shipment
+	ShipmentID uint `json:"shipment_id,omitempty"`
+
+	// RefundID and RefundAmount are set on refund events, naming the refund and the amount it paid back
+	RefundID     uint    `json:"refund_id,omitempty"`
+	RefundAmount float64 `json:"refund_amount,omitempty"`
+
+	// ProductID and Quantity are set on item cancelled and item fulfilled events, naming the line and the
+	// quantity cancelled or the quantity that arrived
+	ProductID uint `json:"product_id,omitempty"`
+	Quantity  int  `json:"quantity,omitempty"`
+}
+
+// NewOrderEvent builds an event of the given type from the current state of the order
+func NewOrderEvent(eventType OrderEventType, order *entities.Order, occurredAt time.Time) OrderEvent {
+	return OrderEvent{
+		Type:           eventType,
+		OrderPublicID:  order.PublicID,
+		OrderID:        order.ID,
+		CustomerID:     order.CustomerID,
+		Status:         order.Status,
+		OccurredAt:     occurredAt,
+		Priority:       order.Priority,
+		CustomerEmail:  order.CustomerEmail,
+		CustomerName:   order.CustomerName,
+		Items:          order.Clone().Items,
+		TotalAmount:    order.TotalAmount,
+		RefundedAmount: order.RefundedAmount,
+		Carrier:        order.Carrier,
+		TrackingNumber: order.TrackingNumber,
+	}
+}
+
+// WithoutContact returns the event with the contact details of the customer removed
+func (e OrderEvent) WithoutContact() OrderEvent {
+	e.CustomerEmail = ""
+	e.CustomerName = ""
+	return e
+}
+
+// NewShipmentEvent builds an event of the given type about a shipment of the order
+func NewShipmentEvent(eventType OrderEventType, order *entities.Order, shipment *entities.Shipment, occurredAt time.Time) OrderEvent {
+	event := NewOrderEvent(eventType, order, occurredAt)
+	event.ShipmentID = shipment.ID
+	event.Carrier = shipment.Carrier
+	event.TrackingNumber = shipment.TrackingNumber
+	return event
+}
+
+// NewItemCancelledEvent builds the event of a quantity of a line cancelled from the order
+func NewItemCancelledEvent(order *entities.Order, cancelled *entities.CancelledItem, occurredAt time.Time) OrderEvent {
+	event := NewOrderEvent(OrderItemCancelled, order, occurredAt)
+	event.ProductID = cancelled.ProductID
+	event.Quantity = cancelled.Quantity
+	return event
+}
+
+// NewItemFulfilledEvent builds the event of the stock of a backordered line arriving, before is the line
+// while it was still backordered
+func NewItemFulfilledEvent(order *entities.Order, before *entities.OrderItem, occurredAt time.Time) OrderEvent {
+	event := NewOrderEvent(OrderItemFulfilled, order, occurredAt)
+	event.ProductID = before.ProductID
+	event.Quantity = before.Quantity - before.ReservedQuantity
+	return event
+}
+
+// NewRefundEvent builds the event of a refund of the order
+func NewRefundEvent(order *entities.Order, refund *entities.Refund, occurredAt time.Time) OrderEvent {
+	event := NewOrderEvent(OrderRefunded, order, occurredAt)
+	event.RefundID = refund.ID
+	event.RefundAmount = refund.Amount
+	return event
+}
@@ -4,32 +4,85 @@ import (
 	"context"
 	"fmt"
 
+	"orders-service/internal/adapters/persistence/audit_repository"
+	mongoConn "orders-service/internal/adapters/persistence/mongodb"
+	"orders-service/internal/adapters/persistence/order_jobs_repository"
+	"orders-service/internal/adapters/persistence/orders_repository"
 	gormConn "orders-service/internal/adapters/persistence/postgres"
+	redisConn "orders-service/internal/adapters/persistence/redis"
+	"orders-service/internal/adapters/persistence/refunds_repository"
+	"orders-service/internal/adapters/persistence/scheduled_transitions_repository"
+	"orders-service/internal/adapters/persistence/shipments_repository"
+	"orders-service/internal/adapters/persistence/snapshots_repository"
+	"orders-service/internal/adapters/persistence/webhook_dead_letters_repository"
+	"orders-service/internal/application/ports"
 	"orders-service/internal/config"
 	"orders-service/pkg/logger"
 
+	"go.mongodb.org/mongo-driver/mongo"
 	"gorm.io/gorm"
 )
 
 type DatabaseConnections struct {
+	driver string
 	conn   *gormConn.GormDB
+	mongo  *mongoConn.Client // nil unless the orders are stored in MongoDB
+	redis  *redisConn.Client // nil when the cache is disabled
 	logger logger.Logger
 }
 
 func NewDatabaseConnections(cfg *config.Config, logger logger.Logger) (*DatabaseConnections, error) {
 	log := logger.With("component", "database_connections")
 
-	// PostgreSQL connection
-	log.Info("Connecting to PostgreSQL...")
-	pg, err := gormConn.NewGormConnection(cfg, logger)
+	// Database connection
+	log.Info("Connecting to the database...", "driver", cfg.Database.Driver)
+	db, err := gormConn.NewGormConnection(cfg, logger)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+		return nil, fmt.Errorf("failed to connect to %s: %w", cfg.Database.Driver, err)
+	}
+
+	// SQLite databases are for demos and CI, often in memory, their schema is created on boot
+	if cfg.Database.Driver == config.DriverSQLite {
+		if err := db.AutoMigrate(Models()...); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+	}
+
+	// The orders live in MongoDB when configured, every other table stays in the SQL database
+	var mongo *mongoConn.Client
+	if cfg.Database.OrderStore == config.OrderStoreMongo {
+		log.Info("Connecting to MongoDB...", "database", cfg.Mongo.Database)
+		mongo, err = mongoConn.NewClient(cfg, logger)
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Mongo.ConnectTimeout)
+		err = order_repository.EnsureMongoIndexes(ctx, mongo.Database())
+		cancel()
+		if err != nil {
+			_ = mongo.Close()
+			_ = db.Close()
+			return nil, fmt.Errorf("failed to create mongo indexes: %w", err)
+		}
+	}
+
+	// Redis is optional, an unreachable server only disables caching until it comes back
+	var redis *redisConn.Client
+	if cfg.Cache.Enabled {
+		log.Info("Connecting to Redis...")
+		redis = redisConn.NewClient(cfg, logger)
 	}
 
 	log.Info("All database connections established successfully")
 
 	return &DatabaseConnections{
-		conn:   pg,
+		driver: cfg.Database.Driver,
+		conn:   db,
+		mongo:  mongo,
+		redis:  redis,
 		logger: log,
 	}, nil
 }
@@ -40,7 +93,19 @@ func (d *DatabaseConnections) Close() error {
 	var errs []error
 
 	if err := d.conn.Close(); err != nil {
-		errs = append(errs, fmt.Errorf("postgres close error: %w", err))
+		errs = append(errs, fmt.Errorf("%s close error: %w", d.driver, err))
+	}
+
+	if d.mongo != nil {
+		if err := d.mongo.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("mongo close error: %w", err))
+		}
+	}
+
+	if d.redis != nil {
+		if err := d.redis.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("redis close error: %w", err))
+		}
 	}
 
 	if len(errs) > 0 {
@@ -54,11 +119,52 @@ func (d *DatabaseConnections) Close() error {
 func (d *DatabaseConnections) HealthCheck(ctx context.Context) map[string]error {
 	checks := make(map[string]error)
 
-	checks["postgres"] = d.conn.HealthCheck(ctx)
+	checks[d.driver] = d.conn.HealthCheck(ctx)
+	if d.mongo != nil {
+		checks["mongo"] = d.mongo.HealthCheck(ctx)
+	}
 
 	return checks
 }
 
+// Driver returns the database driver the connections were opened with
+func (d *DatabaseConnections) Driver() string {
+	return d.driver
+}
+
 func (d *DatabaseConnections) GetGormDB() *gorm.DB {
 	return d.conn.DB()
 }
+
+// GetMongoDB returns the MongoDB database holding the orders, or nil when they are stored in the SQL database
+func (d *DatabaseConnections) GetMongoDB() *mongo.Database {
+	if d.mongo == nil {
+		return nil
+	}
+	return d.mongo.Database()
+}
+
+// GetCache returns the Redis cache, or nil when caching is disabled
+func (d *DatabaseConnections) GetCache() ports.Cache {
+	if d.redis == nil {
+		return nil
+	}
+	return d.redis
+}
+
+// Models returns every GORM model of the schema
+func Models() []interface{} {
+	return []interface{}{
+		&order_repository.OrderModel{},
+		&order_repository.OrderItemModel{},
+		&order_repository.OrderNumberSequenceModel{},
+		&audit_repository.AuditEntryModel{},
+		&shipment_repository.ShipmentModel{},
+		&shipment_repository.ShipmentItemModel{},
+		&refund_repository.RefundModel{},
+		&snapshot_repository.SnapshotModel{},
+		&scheduled_transition_repository.ScheduledTransitionModel{},
+		&webhook_dead_letter_repository.WebhookDeadLetterModel{},
+		&order_job_repository.OrderJobModel{},
+	}
+}
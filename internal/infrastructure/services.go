@@ -0,0 +1,246 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	"orders-service/internal/adapters/catalog"
+	"orders-service/internal/adapters/documents"
+	eventsAdapter "orders-service/internal/adapters/events"
+	"orders-service/internal/adapters/inventory"
+	"orders-service/internal/adapters/jobs"
+	"orders-service/internal/adapters/locks"
+	"orders-service/internal/adapters/payments"
+	"orders-service/internal/adapters/persistence/audit_repository"
+	"orders-service/internal/adapters/persistence/order_jobs_repository"
+	"orders-service/internal/adapters/persistence/orders_repository"
+	"orders-service/internal/adapters/persistence/refunds_repository"
+	"orders-service/internal/adapters/persistence/scheduled_transitions_repository"
+	"orders-service/internal/adapters/persistence/shipments_repository"
+	"orders-service/internal/adapters/persistence/snapshots_repository"
+	"orders-service/internal/adapters/persistence/transaction"
+	"orders-service/internal/adapters/persistence/webhook_dead_letters_repository"
+	"orders-service/internal/adapters/webhooks"
+	"orders-service/internal/application/audit"
+	"orders-service/internal/application/ports"
+	"orders-service/internal/application/usecases"
+	"orders-service/internal/config"
+	"orders-service/internal/domain/entities"
+	"orders-service/pkg/httpclient"
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+)
+
+// Services holds the use cases wired over the database connections, shared by the server and worker commands
+type Services struct {
+	Orders      usecases.OrderUseCases
+	OrderEvents usecases.OrderEventUseCases
+	Audit       usecases.AuditUseCases
+	Webhooks    usecases.WebhookUseCases
+	// OrderJobQueue delivers the jobs of POST /orders/async to the order job worker of this process
+	OrderJobQueue ports.JobQueue
+	// JobLock keeps the replicas running the background jobs from making the same pass, nil when disabled
+	JobLock ports.DistributedLock
+	// Dependencies are the clients of the services called over HTTP, reported by the health endpoints
+	Dependencies []*httpclient.Client
+
+	auditRecorder    *audit.AsyncRecorder
+	webhookPublisher *webhooks.Publisher
+}
+
+func NewServices(cfg *config.Config, connections *DatabaseConnections, log logger.Logger) *Services {
+	// Initialize repositories
+	var orderRepo ports.OrderRepository
+	if mongoDB := connections.GetMongoDB(); mongoDB != nil {
+		orderRepo = order_repository.NewMongoOrderRepositoryWithConfig(mongoDB, order_repository.MongoOrderRepositoryConfig{
+			CustomerLockLease: cfg.Mongo.CustomerLockLease,
+		})
+	} else {
+		orderRepo = order_repository.NewGormOrderRepositoryWithConfig(connections.GetGormDB(), order_repository.GormOrderRepositoryConfig{
+			ItemBatchSize: cfg.Database.ItemBatchSize,
+		})
+	}
+	orderRepo = order_repository.NewResilientOrderRepository(orderRepo, order_repository.RetryPolicy{
+		MaxAttempts: cfg.Database.RetryMaxAttempts,
+		BaseDelay:   cfg.Database.RetryBaseDelay,
+		MaxDelay:    cfg.Database.RetryMaxDelay,
+	}, metrics.Default, log)
+	if cache := connections.GetCache(); cache != nil {
+		orderRepo = order_repository.NewCachedOrderRepository(orderRepo, cache, cfg.Cache.OrderTTL, log)
+	}
+
+	auditRepo := audit_repository.NewGormAuditRepository(connections.GetGormDB())
+	shipmentRepo := shipment_repository.NewGormShipmentRepository(connections.GetGormDB())
+	refundRepo := refund_repository.NewGormRefundRepository(connections.GetGormDB())
+	snapshotRepo := snapshot_repository.NewGormSnapshotRepository(connections.GetGormDB())
+	scheduledTransitionRepo := scheduled_transition_repository.NewGormScheduledTransitionRepository(connections.GetGormDB())
+	orderJobRepo := order_job_repository.NewGormOrderJobRepository(connections.GetGormDB())
+	unitOfWork := transaction.NewGormUnitOfWork(connections.GetGormDB(), ports.Repositories{
+		Orders:               orderRepo,
+		Audit:                auditRepo,
+		Shipments:            shipmentRepo,
+		Refunds:              refundRepo,
+		Snapshots:            snapshotRepo,
+		ScheduledTransitions: scheduledTransitionRepo,
+		OrderJobs:            orderJobRepo,
+	})
+
+	webhookDeadLetterRepo := webhook_dead_letter_repository.NewGormWebhookDeadLetterRepository(connections.GetGormDB())
+
+	// Initialize use cases
+	// Events reach the streams of this process and the webhook endpoints of partners
+	eventBus := eventsAdapter.NewBus(cfg.Server.Events.MaxStreams, cfg.Server.Events.BufferSize, log)
+	webhookEndpoints := make([]webhooks.Endpoint, 0, len(cfg.Webhooks.Endpoints))
+	for _, endpoint := range cfg.Webhooks.Endpoints {
+		webhookEndpoints = append(webhookEndpoints, webhooks.Endpoint{
+			ID:     endpoint.ID,
+			URL:    endpoint.URL,
+			Secret: endpoint.Secret,
+			Events: endpoint.Events,
+		})
+	}
+	// The sender retries deliveries itself, signing every attempt anew
+	webhookClient := httpclient.DefaultConfig()
+	webhookClient.Timeout = cfg.Webhooks.Timeout
+	webhookClient.MaxAttempts = 1
+	webhookClient.BreakerThreshold = cfg.Webhooks.BreakerThreshold
+	webhookClient.BreakerCooldown = cfg.Webhooks.BreakerCooldown
+	webhookSender := webhooks.NewHTTPSender(webhookEndpoints, httpclient.New("webhooks", webhookClient, metrics.Default, log), webhooks.SenderConfig{
+		MaxAttempts:  cfg.Webhooks.MaxAttempts,
+		RetryBackoff: cfg.Webhooks.RetryBackoff,
+	})
+	webhookPublisher := webhooks.NewPublisher(webhookSender, webhookDeadLetterRepo, webhookEndpoints, cfg.Webhooks.BufferSize, metrics.Default, log)
+	eventPublisher := eventsAdapter.NewFanoutPublisher(eventsAdapter.NewLogPublisher(log), eventBus, webhookPublisher)
+	auditRecorder := audit.NewAsyncRecorder(auditRepo, cfg.Orders.AuditBufferSize, log)
+	// Jobs reach the order job worker of this process only, the stored jobs are swept by every worker
+	orderJobQueue := jobs.NewChannelQueue(cfg.Workers.OrderJobs.QueueSize)
+	var productCatalog ports.ProductCatalog
+	var dependencies []*httpclient.Client
+	if cfg.Catalog.BaseURL != "" {
+		catalogClient := httpclient.DefaultConfig()
+		catalogClient.Timeout = cfg.Catalog.Timeout
+		catalogClient.MaxAttempts = cfg.Catalog.RetryMaxAttempts
+		catalogClient.BaseDelay = cfg.Catalog.RetryBaseDelay
+		catalogClient.MaxDelay = cfg.Catalog.RetryMaxDelay
+		catalogClient.BreakerThreshold = cfg.Catalog.BreakerThreshold
+		catalogClient.BreakerCooldown = cfg.Catalog.BreakerCooldown
+		client := httpclient.New("catalog", catalogClient, metrics.Default, log)
+		productCatalog = catalog.NewHTTPProductCatalog(cfg.Catalog.BaseURL, client)
+		dependencies = append(dependencies, client)
+	}
+	// Without an inventory every line is reserved in full, without a gateway orders are confirmed unpaid
+	var stockInventory ports.Inventory
+	if cfg.Inventory.BaseURL != "" {
+		inventoryClient := httpclient.DefaultConfig()
+		inventoryClient.Timeout = cfg.Inventory.Timeout
+		inventoryClient.MaxAttempts = 1
+		inventoryClient.BreakerThreshold = cfg.Inventory.BreakerThreshold
+		inventoryClient.BreakerCooldown = cfg.Inventory.BreakerCooldown
+		client := httpclient.New("inventory", inventoryClient, metrics.Default, log)
+		stockInventory = inventory.NewHTTPInventory(cfg.Inventory.BaseURL, client)
+		dependencies = append(dependencies, client)
+	}
+	var paymentGateway ports.PaymentGateway
+	if cfg.Payments.BaseURL != "" {
+		paymentsClient := httpclient.DefaultConfig()
+		paymentsClient.Timeout = cfg.Payments.Timeout
+		paymentsClient.MaxAttempts = cfg.Payments.RetryMaxAttempts
+		paymentsClient.BaseDelay = cfg.Payments.RetryBaseDelay
+		paymentsClient.MaxDelay = cfg.Payments.RetryMaxDelay
+		paymentsClient.BreakerThreshold = cfg.Payments.BreakerThreshold
+		paymentsClient.BreakerCooldown = cfg.Payments.BreakerCooldown
+		client := httpclient.New("payments", paymentsClient, metrics.Default, log)
+		paymentGateway = payments.NewHTTPPaymentGateway(cfg.Payments.BaseURL, client)
+		dependencies = append(dependencies, client)
+	}
+	// Documents are rendered as HTML, a PDF renderer can wrap documentRenderer once one is chosen
+	var documentRenderer ports.DocumentRenderer
+	if renderer, err := documents.NewHTMLRenderer(cfg.Documents.Template, documents.Branding{
+		Name:    cfg.Documents.Company.Name,
+		Address: cfg.Documents.Company.Address,
+		Email:   cfg.Documents.Company.Email,
+		Phone:   cfg.Documents.Company.Phone,
+		Website: cfg.Documents.Company.Website,
+		LogoURL: cfg.Documents.Company.LogoURL,
+		Footer:  cfg.Documents.Company.Footer,
+	}); err != nil {
+		log.Error("Order documents are unavailable", "error", err)
+	} else {
+		documentRenderer = renderer
+	}
+	orderUseCases := usecases.NewOrderUseCasesWithConfig(orderRepo, unitOfWork, eventPublisher, auditRecorder, log, usecases.OrderUseCasesConfig{
+		ExportMaxRows:               cfg.Orders.ExportMaxRows,
+		ExportBatchSize:             cfg.Orders.ExportBatchSize,
+		ImportMaxLines:              cfg.Orders.ImportMaxLines,
+		ImportBatchSize:             cfg.Orders.ImportBatchSize,
+		ImportProgressEvery:         cfg.Orders.ImportProgressEvery,
+		MaxPendingOrdersPerCustomer: cfg.Orders.MaxPendingPerCustomer,
+		OrderLimits: entities.OrderLimits{
+			MaxItems:           cfg.Orders.MaxItemsPerOrder,
+			MaxQuantityPerItem: cfg.Orders.MaxQuantityPerItem,
+			MaxTotalAmount:     cfg.Orders.MaxOrderTotal,
+			MaxUnitWeightGrams: cfg.Orders.MaxUnitWeightGrams,
+			MaxUnitPrice:       cfg.Orders.MaxUnitPrice,
+			MinOrderAmount:     cfg.Orders.MinOrderAmount,
+		},
+		PendingOrderTTL:      cfg.Orders.PendingTTL,
+		DuplicateOrderWindow: cfg.Orders.DuplicateWindow,
+		RepositoryTimeout:    cfg.Orders.DBTimeout,
+		OrderNumberFormat: entities.OrderNumberFormat{
+			Prefix:      cfg.Orders.Number.Prefix,
+			IncludeYear: cfg.Orders.Number.IncludeYear,
+			Suffix:      entities.OrderNumberSuffix(cfg.Orders.Number.Suffix),
+			Digits:      cfg.Orders.Number.Digits,
+		},
+		ProductCatalog:       productCatalog,
+		Inventory:            stockInventory,
+		InventoryOpenCircuit: usecases.InventoryOpenCircuitPolicy(cfg.Inventory.OpenCircuitPolicy),
+		PaymentGateway:       paymentGateway,
+		Currency:             cfg.Payments.Currency,
+		DocumentRenderer:     documentRenderer,
+		JobQueue:             orderJobQueue,
+		MaxPageSize:          cfg.Dynamic().MaxPageSize,
+	})
+
+	return &Services{
+		Orders:      orderUseCases,
+		OrderEvents: usecases.NewOrderEventUseCases(orderRepo, eventBus, log),
+		Audit:       usecases.NewAuditUseCases(auditRepo, log),
+		Webhooks: usecases.NewWebhookUseCases(webhookDeadLetterRepo, webhookSender, log, usecases.WebhookUseCasesConfig{
+			ReplayBatchSize: cfg.Webhooks.ReplayBatchSize,
+		}),
+		OrderJobQueue:    orderJobQueue,
+		JobLock:          newJobLock(cfg.Workers.Lock, connections, log),
+		Dependencies:     dependencies,
+		auditRecorder:    auditRecorder,
+		webhookPublisher: webhookPublisher,
+	}
+}
+
+// newJobLock builds the lock of the background jobs selected by cfg
+func newJobLock(cfg config.WorkerLockConfig, connections *DatabaseConnections, log logger.Logger) ports.DistributedLock {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Backend == "memory" {
+		return locks.NewMemoryLock()
+	}
+	// Advisory locks are a Postgres feature, a SQLite database serves a single process
+	if connections.Driver() != config.DriverPostgres {
+		log.Warn("Background jobs lock within this process only", "driver", connections.Driver())
+		return locks.NewMemoryLock()
+	}
+
+	db, err := connections.GetGormDB().DB()
+	if err != nil {
+		log.Error("Background jobs run without a lock", "error", err)
+		return nil
+	}
+	return locks.NewAdvisoryLock(db)
+}
+
+// Close flushes the queued webhook deliveries and the audit log, call it once no caller can publish
+// events or record new entries
+func (s *Services) Close(ctx context.Context) error {
+	return errors.Join(s.webhookPublisher.Close(ctx), s.auditRecorder.Close(ctx))
+}
@@ -0,0 +1,165 @@
+package httpclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending the request while the circuit breaker of its host is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of the circuit breaker of a host
+type BreakerState string
+
+const (
+	// BreakerClosed lets every request through
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen rejects requests until the cooldown passed
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen lets a single probe through, its outcome closes or reopens the breaker
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+// Breaker opens after threshold consecutive failures of a host. Once cooldown passed it lets one probe
+// through, closing again when the probe succeeds and reopening when it fails. A nil Breaker never opens.
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu            sync.Mutex
+	state         BreakerState
+	failures      int
+	openedAt      time.Time
+	probing       bool
+	lastError     string
+	lastFailureAt time.Time
+}
+
+// BreakerSnapshot describes a breaker at one instant, for health checks and metrics
+type BreakerSnapshot struct {
+	State BreakerState `json:"state"`
+	// Failures counts the consecutive failures, a success resets it
+	Failures      int        `json:"failures"`
+	LastError     string     `json:"last_error,omitempty"`
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+}
+
+func newBreaker(threshold int, cooldown time.Duration, now func() time.Time) *Breaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &Breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       now,
+		state:     BreakerClosed,
+	}
+}
+
+// State returns the current state, an open breaker whose cooldown passed reports half open
+func (b *Breaker) State() BreakerState {
+	if b == nil {
+		return BreakerClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.currentState()
+}
+
+// Snapshot returns the state, failure count and last error of the breaker
+func (b *Breaker) Snapshot() BreakerSnapshot {
+	if b == nil {
+		return BreakerSnapshot{State: BreakerClosed}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	snapshot := BreakerSnapshot{State: b.currentState(), Failures: b.failures, LastError: b.lastError}
+	if !b.lastFailureAt.IsZero() {
+		lastFailureAt := b.lastFailureAt
+		snapshot.LastFailureAt = &lastFailureAt
+	}
+	return snapshot
+}
+
+// allow reserves an attempt, failing with ErrCircuitOpen while the breaker is open or its probe is in flight
+func (b *Breaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && b.cooledDown() {
+		b.state = BreakerHalfOpen
+	}
+	switch b.state {
+	case BreakerOpen:
+		return ErrCircuitOpen
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// success records an attempt the host answered, closing the breaker
+func (b *Breaker) success() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = BreakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// failure records an attempt that failed with reason and reports whether it opened the breaker
+func (b *Breaker) failure(reason string) bool {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	b.lastError = reason
+	b.lastFailureAt = b.now()
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		opened := b.state != BreakerOpen
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+		return opened
+	}
+	return false
+}
+
+// cancel releases an attempt that neither succeeded nor failed, such as one the caller cancelled
+func (b *Breaker) cancel() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func (b *Breaker) currentState() BreakerState {
+	if b.state == BreakerOpen && b.cooledDown() {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+func (b *Breaker) cooledDown() bool {
+	return !b.now().Before(b.openedAt.Add(b.cooldown))
+}
@@ -0,0 +1,91 @@
+package httpclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := newBreaker(3, time.Minute, func() time.Time { return now })
+
+	b.failure("boom")
+	b.failure("boom")
+	b.success()
+	assert.False(t, b.failure("boom"), "a success resets the count")
+	b.failure("boom")
+	assert.Equal(t, BreakerClosed, b.State())
+
+	assert.True(t, b.failure("boom"))
+	assert.Equal(t, BreakerOpen, b.State())
+	assert.ErrorIs(t, b.allow(), ErrCircuitOpen)
+}
+
+func TestBreaker_HalfOpenProbe(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := newBreaker(1, time.Minute, func() time.Time { return now })
+	b.failure("boom")
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, BreakerHalfOpen, b.State())
+	require.NoError(t, b.allow())
+	assert.ErrorIs(t, b.allow(), ErrCircuitOpen, "one probe at a time")
+
+	// A cancelled probe frees the slot
+	b.cancel()
+	require.NoError(t, b.allow())
+
+	b.success()
+	assert.Equal(t, BreakerClosed, b.State())
+	assert.NoError(t, b.allow())
+}
+
+func TestBreaker_FailedProbeReopens(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := newBreaker(2, time.Minute, func() time.Time { return now })
+	b.failure("boom")
+	b.failure("boom")
+
+	now = now.Add(time.Minute)
+	require.NoError(t, b.allow())
+	assert.True(t, b.failure("boom"))
+	assert.Equal(t, BreakerOpen, b.State())
+
+	now = now.Add(30 * time.Second)
+	assert.ErrorIs(t, b.allow(), ErrCircuitOpen, "the cooldown restarts")
+}
+
+func TestBreaker_Disabled(t *testing.T) {
+	b := newBreaker(0, time.Minute, time.Now)
+
+	for i := 0; i < 10; i++ {
+		b.failure("boom")
+	}
+	assert.NoError(t, b.allow())
+	assert.Equal(t, BreakerClosed, b.State())
+}
+
+func TestBreaker_Snapshot(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := newBreaker(2, time.Minute, func() time.Time { return now })
+	assert.Equal(t, BreakerSnapshot{State: BreakerClosed}, b.Snapshot())
+
+	b.failure("unexpected status 503")
+	b.failure("connection refused")
+
+	snapshot := b.Snapshot()
+	assert.Equal(t, BreakerOpen, snapshot.State)
+	assert.Equal(t, 2, snapshot.Failures)
+	assert.Equal(t, "connection refused", snapshot.LastError)
+	require.NotNil(t, snapshot.LastFailureAt)
+	assert.Equal(t, now, *snapshot.LastFailureAt)
+
+	b.success()
+	snapshot = b.Snapshot()
+	assert.Equal(t, BreakerClosed, snapshot.State)
+	assert.Zero(t, snapshot.Failures)
+	assert.Equal(t, "connection refused", snapshot.LastError, "the last error stays visible after recovering")
+}
@@ -0,0 +1,328 @@
+// Package httpclient builds the HTTP clients calling other services, with timeouts, retries of
+// idempotent requests and a circuit breaker per host.
+package httpclient
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+)
+
+// Metric names reported by a Client, formatted with the name of the client
+const (
+	RequestsMetric = "http_client_%s_requests_total"
+	RetriesMetric  = "http_client_%s_retries_total"
+	FailuresMetric = "http_client_%s_failures_total"
+	RejectedMetric = "http_client_%s_circuit_rejected_total"
+)
+
+// HeaderRequestID carries the ID of the request an outbound request is made for, so the logs of both
+// services can be joined
+const HeaderRequestID = "X-Request-ID"
+
+// maxDrainBytes is how much of a discarded response body is read so its connection can be reused
+const maxDrainBytes = 64 << 10
+
+// Config tunes a Client
+type Config struct {
+	// Timeout bounds every attempt including reading the response body, 0 leaves attempts unbounded
+	Timeout time.Duration
+	// DialTimeout bounds opening a connection
+	DialTimeout time.Duration
+	// KeepAlive is the TCP keep-alive period of open connections
+	KeepAlive time.Duration
+	// IdleConnTimeout is how long an idle connection is kept for reuse
+	IdleConnTimeout time.Duration
+	// MaxIdleConnsPerHost caps the idle connections kept per host
+	MaxIdleConnsPerHost int
+
+	// MaxAttempts includes the first attempt, values below 2 disable retries
+	MaxAttempts int
+	// BaseDelay is the backoff before the second attempt, it doubles for every further attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff, 0 leaves it uncapped
+	MaxDelay time.Duration
+
+	// BreakerThreshold is the consecutive failures opening the breaker of a host, 0 disables the breakers
+	BreakerThreshold int
+	// BreakerCooldown is how long an open breaker rejects requests before letting a probe through
+	BreakerCooldown time.Duration
+
+	Hooks Hooks
+}
+
+// DefaultConfig returns the settings of a client calling a service on the internal network
+func DefaultConfig() Config {
+	return Config{
+		Timeout:             5 * time.Second,
+		DialTimeout:         2 * time.Second,
+		KeepAlive:           30 * time.Second,
+		IdleConnTimeout:     90 * time.Second,
+		MaxIdleConnsPerHost: 16,
+		MaxAttempts:         3,
+		BaseDelay:           100 * time.Millisecond,
+		MaxDelay:            2 * time.Second,
+		BreakerThreshold:    5,
+		BreakerCooldown:     30 * time.Second,
+	}
+}
+
+// Hooks observe the attempts of a Client, nil hooks are skipped
+type Hooks struct {
+	// BeforeAttempt is called before every attempt, attempt counts from 1
+	BeforeAttempt func(req *http.Request, attempt int)
+	// AfterAttempt is called with the outcome of every attempt, resp is nil when err is set
+	AfterAttempt func(req *http.Request, attempt int, resp *http.Response, err error, elapsed time.Duration)
+}
+
+// Client sends requests to other services. It is safe for concurrent use, build one per dependency
+// and share it so connections and breakers are reused.
+type Client struct {
+	name   string
+	config Config
+	client *http.Client
+	now    func() time.Time
+
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+
+	requests *metrics.Counter
+	retries  *metrics.Counter
+	failures *metrics.Counter
+	rejected *metrics.Counter
+	logger   logger.Logger
+}
+
+// New builds the client of the dependency called name, counting its requests in registry
+func New(name string, config Config, registry *metrics.Registry, log logger.Logger) *Client {
+	dialer := &net.Dialer{Timeout: config.DialTimeout, KeepAlive: config.KeepAlive}
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+		IdleConnTimeout:       config.IdleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	return &Client{
+		name:     name,
+		config:   config,
+		client:   &http.Client{Timeout: config.Timeout, Transport: transport},
+		now:      time.Now,
+		breakers: make(map[string]*Breaker),
+		requests: registry.Counter(fmt.Sprintf(RequestsMetric, name)),
+		retries:  registry.Counter(fmt.Sprintf(RetriesMetric, name)),
+		failures: registry.Counter(fmt.Sprintf(FailuresMetric, name)),
+		rejected: registry.Counter(fmt.Sprintf(RejectedMetric, name)),
+		logger:   log.With("component", "http_client", "client", name),
+	}
+}
+
+// Do sends req through the circuit breaker of its host, with the X-Request-ID found in its context. Transport errors and 5xx responses count as
+// failures, an open breaker fails the request with ErrCircuitOpen without sending it.
+//
+// Idempotent requests, by method or by an Idempotency-Key header, are retried with backoff after a
+// transport error or a 429, 502, 503 or 504 response, provided their body can be replayed. A backoff
+// that would end after the context deadline is not started. The response of the last attempt is
+// returned, the caller closes its body.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	req = withRequestID(req)
+	breaker := c.breaker(req.URL.Host)
+	retryable := c.config.MaxAttempts > 1 && isIdempotent(req) && (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil)
+
+	for attempt := 1; ; attempt++ {
+		if err := breaker.allow(); err != nil {
+			c.rejected.Inc()
+			return nil, fmt.Errorf("%s %s: %w", req.Method, req.URL.Host, err)
+		}
+
+		resp, err := c.send(req, attempt)
+		switch {
+		case ctx.Err() != nil:
+			breaker.cancel()
+		case err != nil || resp.StatusCode >= http.StatusInternalServerError:
+			c.failures.Inc()
+			if breaker.failure(failureReason(resp, err)) {
+				c.logger.Warn("Circuit breaker opened", "host", req.URL.Host, "cooldown", c.config.BreakerCooldown)
+			}
+		default:
+			breaker.success()
+		}
+
+		if !retryable || attempt >= c.config.MaxAttempts || ctx.Err() != nil || !isRetryable(resp, err) {
+			return resp, err
+		}
+
+		delay := c.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			return resp, err
+		}
+		c.logger.Warn("Outbound request failed, retrying",
+			"method", req.Method,
+			"host", req.URL.Host,
+			"attempt", attempt,
+			"delay", delay,
+			"status", statusCode(resp),
+			"error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, err
+		case <-timer.C:
+		}
+
+		discard(resp)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("replay request body: %w", err)
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+		c.retries.Inc()
+	}
+}
+
+// send makes a single attempt, calling the hooks around it
+func (c *Client) send(req *http.Request, attempt int) (*http.Response, error) {
+	if hook := c.config.Hooks.BeforeAttempt; hook != nil {
+		hook(req, attempt)
+	}
+	c.requests.Inc()
+
+	start := time.Now()
+	resp, err := c.client.Do(req)
+	elapsed := time.Since(start)
+
+	c.logger.Debug("Outbound request",
+		"method", req.Method,
+		"host", req.URL.Host,
+		"path", req.URL.Path,
+		"attempt", attempt,
+		"status", statusCode(resp),
+		"elapsed", elapsed,
+		"error", err)
+	if hook := c.config.Hooks.AfterAttempt; hook != nil {
+		hook(req, attempt, resp, err, elapsed)
+	}
+	return resp, err
+}
+
+// withRequestID returns req with the X-Request-ID of the request it is made for, found in its context,
+// unless the caller set one. The request of the caller is left unchanged.
+func withRequestID(req *http.Request) *http.Request {
+	requestID := logger.RequestIDFromContext(req.Context())
+	if requestID == "" || req.Header.Get(HeaderRequestID) != "" {
+		return req
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set(HeaderRequestID, requestID)
+	return req
+}
+
+// Name returns the name of the dependency the client calls
+func (c *Client) Name() string {
+	return c.name
+}
+
+// Circuits returns the circuit breaker of every host called so far, empty when breakers are disabled
+func (c *Client) Circuits() map[string]BreakerSnapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	circuits := make(map[string]BreakerSnapshot, len(c.breakers))
+	for host, b := range c.breakers {
+		if b != nil {
+			circuits[host] = b.Snapshot()
+		}
+	}
+	return circuits
+}
+
+// breaker returns the circuit breaker of host, creating it on first use
+func (c *Client) breaker(host string) *Breaker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.breakers[host]
+	if !ok {
+		b = newBreaker(c.config.BreakerThreshold, c.config.BreakerCooldown, c.now)
+		c.breakers[host] = b
+	}
+	return b
+}
+
+// backoff returns the jittered delay before the retry following attempt, between half and all of the exponential delay
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.config.BaseDelay
+	for i := 1; i < attempt && delay < math.MaxInt64/2; i++ {
+		delay *= 2
+	}
+	if c.config.MaxDelay > 0 && delay > c.config.MaxDelay {
+		delay = c.config.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// isIdempotent reports whether sending req twice has the effect of sending it once
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// isRetryable reports whether an attempt failed in a way another attempt may not
+func isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// discard reads what is left of a response that will not be returned and closes it
+func discard(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBytes))
+	_ = resp.Body.Close()
+}
+
+// failureReason describes a failed attempt for the breaker of its host
+func failureReason(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("unexpected status %d", resp.StatusCode)
+}
+
+func statusCode(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
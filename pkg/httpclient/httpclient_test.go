@@ -0,0 +1,308 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"orders-service/pkg/logger"
+	"orders-service/pkg/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testConfig retries quickly and opens breakers after three failures
+func testConfig() Config {
+	config := DefaultConfig()
+	config.Timeout = time.Second
+	config.BaseDelay = time.Millisecond
+	config.MaxDelay = 5 * time.Millisecond
+	config.BreakerThreshold = 3
+	config.BreakerCooldown = time.Minute
+	return config
+}
+
+// burstServer answers the first failures requests with status and the rest with 200
+func burstServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func get(t *testing.T, client *Client, url string) (*http.Response, error) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	if resp != nil {
+		t.Cleanup(func() { _ = resp.Body.Close() })
+	}
+	return resp, err
+}
+
+func TestClient_RetriesServerErrorBurst(t *testing.T) {
+	server, calls := burstServer(t, 2, http.StatusServiceUnavailable)
+	registry := metrics.NewRegistry()
+	client := New("catalog", testConfig(), registry, logger.New("test"))
+
+	resp, err := get(t, client, server.URL)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "ok", string(body))
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, int64(3), registry.Counter(fmt.Sprintf(RequestsMetric, "catalog")).Value())
+	assert.Equal(t, int64(2), registry.Counter(fmt.Sprintf(RetriesMetric, "catalog")).Value())
+	assert.Equal(t, int64(2), registry.Counter(fmt.Sprintf(FailuresMetric, "catalog")).Value())
+}
+
+func TestClient_ReturnsLastResponseWhenRetriesRunOut(t *testing.T) {
+	server, calls := burstServer(t, 10, http.StatusBadGateway)
+	client := New("catalog", testConfig(), metrics.NewRegistry(), logger.New("test"))
+
+	resp, err := get(t, client, server.URL)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestClient_DoesNotRetryClientErrors(t *testing.T) {
+	server, calls := burstServer(t, 1, http.StatusNotFound)
+	registry := metrics.NewRegistry()
+	client := New("catalog", testConfig(), registry, logger.New("test"))
+
+	resp, err := get(t, client, server.URL)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Zero(t, registry.Counter(fmt.Sprintf(FailuresMetric, "catalog")).Value())
+}
+
+func TestClient_RetriesOnlyIdempotentRequests(t *testing.T) {
+	var bodies []string
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	client := New("webhooks", testConfig(), metrics.NewRegistry(), logger.New("test"))
+
+	post := func(idempotencyKey string) int {
+		req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"event":"order.created"}`))
+		require.NoError(t, err)
+		if idempotencyKey != "" {
+			req.Header.Set("Idempotency-Key", idempotencyKey)
+		}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, post(""))
+	assert.Equal(t, int32(1), calls.Load())
+
+	calls.Store(0)
+	bodies = nil
+	assert.Equal(t, http.StatusOK, post("event-1"))
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, []string{`{"event":"order.created"}`, `{"event":"order.created"}`}, bodies, "the body is replayed")
+}
+
+func TestClient_RetriesTimeouts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+	config := testConfig()
+	config.Timeout = 50 * time.Millisecond
+	client := New("catalog", config, metrics.NewRegistry(), logger.New("test"))
+
+	resp, err := get(t, client, server.URL)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_TimeoutsExhaustRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+	config := testConfig()
+	config.Timeout = 20 * time.Millisecond
+	config.MaxAttempts = 2
+	client := New("catalog", config, metrics.NewRegistry(), logger.New("test"))
+
+	resp, err := get(t, client, server.URL)
+
+	assert.Nil(t, resp)
+	var netErr interface{ Timeout() bool }
+	require.ErrorAs(t, err, &netErr)
+	assert.True(t, netErr.Timeout())
+}
+
+func TestClient_DoesNotBackOffPastDeadline(t *testing.T) {
+	server, calls := burstServer(t, 10, http.StatusServiceUnavailable)
+	config := testConfig()
+	config.BaseDelay = time.Hour
+	config.MaxDelay = 0
+	client := New("catalog", config, metrics.NewRegistry(), logger.New("test"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestClient_CircuitBreakerOpensPerHost(t *testing.T) {
+	failing, failingCalls := burstServer(t, 100, http.StatusInternalServerError)
+	healthy, healthyCalls := burstServer(t, 0, http.StatusOK)
+	config := testConfig()
+	config.MaxAttempts = 1
+	registry := metrics.NewRegistry()
+	client := New("catalog", config, registry, logger.New("test"))
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		resp, err := get(t, client, failing.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	}
+
+	_, err := get(t, client, failing.URL)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(3), failingCalls.Load(), "an open breaker does not send the request")
+	assert.Equal(t, int64(1), registry.Counter(fmt.Sprintf(RejectedMetric, "catalog")).Value())
+
+	_, err = get(t, client, healthy.URL)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), healthyCalls.Load(), "other hosts are unaffected")
+
+	// After the cooldown a failed probe reopens the breaker
+	now = now.Add(time.Minute)
+	_, err = get(t, client, failing.URL)
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), failingCalls.Load())
+	_, err = get(t, client, failing.URL)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	circuits := client.Circuits()
+	require.Len(t, circuits, 2)
+	failingHost := strings.TrimPrefix(failing.URL, "http://")
+	assert.Equal(t, BreakerOpen, circuits[failingHost].State)
+	assert.Equal(t, "unexpected status 500", circuits[failingHost].LastError)
+	assert.Equal(t, BreakerClosed, circuits[strings.TrimPrefix(healthy.URL, "http://")].State)
+}
+
+func TestClient_CircuitBreakerStopsRetries(t *testing.T) {
+	server, calls := burstServer(t, 100, http.StatusServiceUnavailable)
+	config := testConfig()
+	config.MaxAttempts = 5
+	config.BreakerThreshold = 2
+	client := New("catalog", config, metrics.NewRegistry(), logger.New("test"))
+
+	_, err := get(t, client, server.URL)
+
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_Hooks(t *testing.T) {
+	server, _ := burstServer(t, 1, http.StatusServiceUnavailable)
+	var before []int
+	var statuses []int
+	config := testConfig()
+	config.Hooks = Hooks{
+		BeforeAttempt: func(req *http.Request, attempt int) { before = append(before, attempt) },
+		AfterAttempt: func(req *http.Request, attempt int, resp *http.Response, err error, elapsed time.Duration) {
+			require.NoError(t, err)
+			statuses = append(statuses, resp.StatusCode)
+		},
+	}
+	client := New("catalog", config, metrics.NewRegistry(), logger.New("test"))
+
+	_, err := get(t, client, server.URL)
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, before)
+	assert.Equal(t, []int{http.StatusServiceUnavailable, http.StatusOK}, statuses)
+}
+
+func TestClient_PropagatesRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctxID    string
+		headerID string
+		expected string
+	}{
+		{"from the context", "req-1", "", "req-1"},
+		{"set by the caller", "req-1", "caller-id", "caller-id"},
+		{"none", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			var received string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r.Header.Get(HeaderRequestID)
+			}))
+			defer server.Close()
+			client := New("catalog", testConfig(), metrics.NewRegistry(), logger.New("test"))
+
+			ctx := context.Background()
+			if tt.ctxID != "" {
+				ctx = logger.WithRequestID(ctx, tt.ctxID)
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+			require.NoError(t, err)
+			if tt.headerID != "" {
+				req.Header.Set(HeaderRequestID, tt.headerID)
+			}
+
+			// When
+			resp, err := client.Do(req)
+
+			// Then
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, tt.expected, received)
+			assert.Equal(t, tt.headerID, req.Header.Get(HeaderRequestID), "the request of the caller is unchanged")
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scenarios fired by Run
+const (
+	// ScenarioCreate creates an order per request, each for another customer
+	ScenarioCreate = "create"
+	// ScenarioGet reads orders created before the timer starts
+	ScenarioGet = "get"
+)
+
+// Config describes a load test against a running instance
+type Config struct {
+	// BaseURL is the address of the instance, such as http://localhost:8080
+	BaseURL string
+	// Header is sent with every request, such as the API key
+	Header   http.Header
+	Scenario string
+	// Concurrency is the number of requests in flight, Requests the number sent in total
+	Concurrency int
+	Requests    int
+	// Items is the number of items of the orders created
+	Items int
+	// FirstCustomerID is the customer of the first order created, the next orders count up from it so the
+	// pending order limit of a customer is not reached
+	FirstCustomerID uint
+	// Seed is the number of orders ScenarioGet creates to read
+	Seed int
+}
+
+// Report holds the outcome of a load test
+type Report struct {
+	Scenario string
+	Requests int
+	// Statuses counts the responses by status code, Errors the requests that got no response
+	Statuses map[int]int
+	Errors   int
+	Elapsed  time.Duration
+
+	latencies []time.Duration
+}
+
+// Failures returns the number of requests that failed or were not answered with a 2xx status
+func (r *Report) Failures() int {
+	failures := r.Errors
+	for status, count := range r.Statuses {
+		if status < 200 || status > 299 {
+			failures += count
+		}
+	}
+	return failures
+}
+
+// Percentile returns the latency p percent of the answered requests stayed within, 0 without any
+func (r *Report) Percentile(p float64) time.Duration {
+	if len(r.latencies) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(r.latencies))))
+	return r.latencies[min(max(rank, 1), len(r.latencies))-1]
+}
+
+// Throughput returns the requests completed per second
+func (r *Report) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Requests) / r.Elapsed.Seconds()
+}
+
+// Write prints the report for a person comparing runs
+func (r *Report) Write(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "scenario:    %s\n", r.Scenario)
+	fmt.Fprintf(&b, "requests:    %d in %s (%.1f/s)\n", r.Requests, r.Elapsed.Round(time.Millisecond), r.Throughput())
+	fmt.Fprintf(&b, "failures:    %d\n", r.Failures())
+
+	statuses := make([]int, 0, len(r.Statuses))
+	for status := range r.Statuses {
+		statuses = append(statuses, status)
+	}
+	slices.Sort(statuses)
+	for _, status := range statuses {
+		fmt.Fprintf(&b, "  status %d: %d\n", status, r.Statuses[status])
+	}
+	if r.Errors > 0 {
+		fmt.Fprintf(&b, "  no response: %d\n", r.Errors)
+	}
+
+	fmt.Fprintln(&b, "latency:")
+	for _, p := range []float64{50, 90, 95, 99, 100} {
+		fmt.Fprintf(&b, "  p%-3g %s\n", p, r.Percentile(p))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Run fires cfg.Requests requests with cfg.Concurrency in flight, it stops early once ctx is cancelled
+func Run(ctx context.Context, client *http.Client, cfg Config) (*Report, error) {
+	if cfg.Concurrency <= 0 || cfg.Requests <= 0 {
+		return nil, errors.New("concurrency and requests must be greater than 0")
+	}
+	baseURL := strings.TrimRight(cfg.BaseURL, "/")
+
+	var next func(i int) (*http.Request, error)
+	switch cfg.Scenario {
+	case ScenarioCreate:
+		next = func(i int) (*http.Request, error) {
+			return newCreateRequest(ctx, baseURL, cfg, cfg.FirstCustomerID+uint(i))
+		}
+	case ScenarioGet:
+		paths, err := seed(ctx, client, baseURL, cfg)
+		if err != nil {
+			return nil, err
+		}
+		next = func(i int) (*http.Request, error) {
+			return newRequest(ctx, http.MethodGet, baseURL+paths[i%len(paths)], nil, cfg.Header)
+		}
+	default:
+		return nil, fmt.Errorf("unknown scenario %q, use %s or %s", cfg.Scenario, ScenarioCreate, ScenarioGet)
+	}
+
+	report := &Report{Scenario: cfg.Scenario, Statuses: make(map[int]int)}
+	var mu sync.Mutex
+	record := func(latency time.Duration, status int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		report.Requests++
+		if err != nil {
+			report.Errors++
+			return
+		}
+		report.Statuses[status]++
+		report.latencies = append(report.latencies, latency)
+	}
+
+	requests := make(chan int)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for range cfg.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range requests {
+				req, err := next(i)
+				if err != nil {
+					record(0, 0, err)
+					continue
+				}
+				sent := time.Now()
+				status, err := do(client, req)
+				record(time.Since(sent), status, err)
+			}
+		}()
+	}
+
+feed:
+	for i := range cfg.Requests {
+		select {
+		case requests <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(requests)
+	wg.Wait()
+
+	report.Elapsed = time.Since(start)
+	slices.Sort(report.latencies)
+	return report, nil
+}
+
+// seed creates the orders ScenarioGet reads and returns their paths
+func seed(ctx context.Context, client *http.Client, baseURL string, cfg Config) ([]string, error) {
+	paths := make([]string, 0, max(cfg.Seed, 1))
+	for i := range max(cfg.Seed, 1) {
+		req, err := newCreateRequest(ctx, baseURL, cfg, cfg.FirstCustomerID+uint(i))
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("seed order: %w", err)
+		}
+		var created struct {
+			ID uint `json:"id"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&created)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusCreated {
+			return nil, fmt.Errorf("seed order: status %d", resp.StatusCode)
+		}
+		if err != nil || created.ID == 0 {
+			return nil, fmt.Errorf("seed order: response without an order ID: %v", err)
+		}
+		paths = append(paths, fmt.Sprintf("/api/v1/orders/%d", created.ID))
+	}
+	return paths, nil
+}
+
+// orderRequest is the body of POST /api/v1/orders
+type orderRequest struct {
+	CustomerID uint        `json:"customer_id"`
+	Items      []orderItem `json:"items"`
+}
+
+type orderItem struct {
+	ProductID   uint    `json:"product_id"`
+	ProductSKU  string  `json:"product_sku"`
+	ProductName string  `json:"product_name"`
+	Quantity    int     `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+}
+
+func newCreateRequest(ctx context.Context, baseURL string, cfg Config, customerID uint) (*http.Request, error) {
+	body := orderRequest{CustomerID: customerID}
+	for i := 1; i <= max(cfg.Items, 1); i++ {
+		body.Items = append(body.Items, orderItem{
+			ProductID:   uint(i),
+			ProductSKU:  fmt.Sprintf("LOADGEN-%03d", i),
+			ProductName: fmt.Sprintf("Load test product %d", i),
+			Quantity:    1,
+			UnitPrice:   9.99,
+		})
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return newRequest(ctx, http.MethodPost, baseURL+"/api/v1/orders", payload, cfg.Header)
+}
+
+func newRequest(ctx context.Context, method, url string, body []byte, header http.Header) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// do sends req and reads the whole response, so the latency covers the body
+func do(client *http.Client, req *http.Request) (int, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return 0, err
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,137 @@
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOrders answers POST /api/v1/orders and GET /api/v1/orders/{id} and remembers the customers
+type fakeOrders struct {
+	mu        sync.Mutex
+	customers []uint
+	gets      atomic.Int32
+}
+
+func (f *fakeOrders) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-API-Key") != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	switch r.Method {
+	case http.MethodPost:
+		var body orderRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Items) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.mu.Lock()
+		f.customers = append(f.customers, body.CustomerID)
+		id := len(f.customers)
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id":%d}`, id)
+	case http.MethodGet:
+		if f.gets.Add(1)%10 == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"id":1}`)
+	}
+}
+
+func TestRun_Create(t *testing.T) {
+	// Given
+	orders := &fakeOrders{}
+	server := httptest.NewServer(orders)
+	defer server.Close()
+
+	// When
+	report, err := Run(context.Background(), server.Client(), Config{
+		BaseURL:         server.URL,
+		Header:          http.Header{"X-Api-Key": {"secret"}},
+		Scenario:        ScenarioCreate,
+		Concurrency:     4,
+		Requests:        20,
+		Items:           3,
+		FirstCustomerID: 1000,
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 20, report.Requests)
+	assert.Equal(t, map[int]int{http.StatusCreated: 20}, report.Statuses)
+	assert.Zero(t, report.Failures())
+	assert.ElementsMatch(t, []uint{1000, 1001, 1002, 1003, 1004, 1005, 1006, 1007, 1008, 1009,
+		1010, 1011, 1012, 1013, 1014, 1015, 1016, 1017, 1018, 1019}, orders.customers)
+}
+
+func TestRun_GetSeedsOrdersFirst(t *testing.T) {
+	// Given
+	orders := &fakeOrders{}
+	server := httptest.NewServer(orders)
+	defer server.Close()
+
+	// When
+	report, err := Run(context.Background(), server.Client(), Config{
+		BaseURL:         server.URL,
+		Header:          http.Header{"X-Api-Key": {"secret"}},
+		Scenario:        ScenarioGet,
+		Concurrency:     2,
+		Requests:        30,
+		FirstCustomerID: 1,
+		Seed:            5,
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Len(t, orders.customers, 5)
+	assert.Equal(t, 30, report.Requests)
+	assert.Equal(t, 3, report.Failures(), "every tenth read is answered 404")
+}
+
+func TestRun_RejectsUnknownScenario(t *testing.T) {
+	_, err := Run(context.Background(), http.DefaultClient, Config{Scenario: "delete", Concurrency: 1, Requests: 1})
+
+	assert.ErrorContains(t, err, "unknown scenario")
+}
+
+func TestReport_Percentile(t *testing.T) {
+	report := &Report{}
+	for i := 1; i <= 100; i++ {
+		report.latencies = append(report.latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	assert.Equal(t, 50*time.Millisecond, report.Percentile(50))
+	assert.Equal(t, 99*time.Millisecond, report.Percentile(99))
+	assert.Equal(t, 100*time.Millisecond, report.Percentile(100))
+	assert.Zero(t, (&Report{}).Percentile(50))
+}
+
+func TestReport_Write(t *testing.T) {
+	report := &Report{
+		Scenario:  ScenarioGet,
+		Requests:  4,
+		Statuses:  map[int]int{200: 3, 404: 1},
+		Elapsed:   2 * time.Second,
+		latencies: []time.Duration{time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond},
+	}
+	var out bytes.Buffer
+
+	require.NoError(t, report.Write(&out))
+
+	assert.Contains(t, out.String(), "requests:    4 in 2s (2.0/s)")
+	assert.Contains(t, out.String(), "failures:    1")
+	assert.Contains(t, out.String(), "status 404: 1")
+	assert.Contains(t, out.String(), "p50  2ms")
+}
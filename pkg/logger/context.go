@@ -0,0 +1,43 @@
+package logger
+
+import "context"
+
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the request ID for loggers further down the call chain
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or an empty string
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+type loggerKey struct{}
+
+// WithContext returns a copy of ctx carrying log, pre-populated with request scoped fields such as
+// the request ID. Give it a logger without a component field, FromContext adds the caller's.
+func WithContext(ctx context.Context, log Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, log)
+}
+
+// FromContext returns the logger carried by ctx tagged with the component of fallback, so entries keep
+// the request fields and per component levels still apply. It returns fallback when ctx carries none.
+func FromContext(ctx context.Context, fallback Logger) Logger {
+	if ctx == nil {
+		return fallback
+	}
+	log, ok := ctx.Value(loggerKey{}).(Logger)
+	if !ok {
+		return fallback
+	}
+	if l, ok := fallback.(*zapLogger); ok && l.component != "" {
+		return log.With("component", l.component)
+	}
+	return log
+}
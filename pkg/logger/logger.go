@@ -2,7 +2,9 @@
 package logger
 
 import (
+	"fmt"
 	"strings"
+	"sync/atomic"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -22,10 +24,36 @@ type Logger interface {
 type zapLogger struct {
 	sugar *zap.SugaredLogger
 	base  *zap.Logger
+	// levels is shared by every logger derived with With, component is the last "component" field given to With
+	levels    *levels
+	component string
 }
 
+// levels decides which entries are written. The zap core accepts every level, filtering happens per
+// logger so a component can log below the global level.
+type levels struct {
+	global     zap.AtomicLevel
+	components atomic.Pointer[map[string]zapcore.Level]
+}
+
+// of returns the minimum level of component, the global level unless it has an override
+func (lv *levels) of(component string) zapcore.Level {
+	if component != "" {
+		if overrides := lv.components.Load(); overrides != nil {
+			if level, ok := (*overrides)[component]; ok {
+				return level
+			}
+		}
+	}
+	return lv.global.Level()
+}
+
+// New creates a logger for env, development environments write colored console lines at debug level,
+// other environments JSON at info level. SetLevel and SetComponentLevels change the levels later on.
 func New(env string) Logger {
 	config := getZapConfig(env)
+	global := zap.NewAtomicLevelAt(config.Level.Level())
+	config.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
 
 	base, err := config.Build(
 		zap.AddCallerSkip(1), // Skip one level to show the actual caller
@@ -35,10 +63,57 @@ func New(env string) Logger {
 		panic("Failed to initialize logging: " + err.Error())
 	}
 
+	return newZapLogger(base, global)
+}
+
+func newZapLogger(base *zap.Logger, global zap.AtomicLevel) *zapLogger {
 	return &zapLogger{
-		sugar: base.Sugar(),
-		base:  base,
+		sugar:  base.Sugar(),
+		base:   base,
+		levels: &levels{global: global},
+	}
+}
+
+// SetLevel changes the minimum level of log, and of every logger derived from the same New call,
+// to one of debug, info, warn or error. Components with their own level keep it.
+// Loggers not created by New are left unchanged.
+func SetLevel(log Logger, level string) error {
+	parsed, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	if l, ok := log.(*zapLogger); ok {
+		l.levels.global.SetLevel(parsed)
 	}
+	return nil
+}
+
+// SetComponentLevels replaces the per component levels of log and of every logger derived from the same
+// New call. Keys are the values of the "component" field given to With, such as order_repository.
+// Components not listed follow the level set by SetLevel.
+func SetComponentLevels(log Logger, components map[string]string) error {
+	overrides := make(map[string]zapcore.Level, len(components))
+	for component, level := range components {
+		parsed, err := parseLevel(level)
+		if err != nil {
+			return fmt.Errorf("component %s: %w", component, err)
+		}
+		overrides[component] = parsed
+	}
+
+	if l, ok := log.(*zapLogger); ok {
+		l.levels.components.Store(&overrides)
+	}
+	return nil
+}
+
+func parseLevel(level string) (zapcore.Level, error) {
+	var parsed zapcore.Level
+	if err := parsed.UnmarshalText([]byte(level)); err != nil {
+		return parsed, fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+	return parsed, nil
 }
 
 func getZapConfig(env string) zap.Config {
@@ -75,31 +150,71 @@ func getZapConfig(env string) zap.Config {
 	}
 }
 
+func (l *zapLogger) enabled(level zapcore.Level) bool {
+	return level >= l.levels.of(l.component)
+}
+
 func (l *zapLogger) Debug(msg string, args ...interface{}) {
-	l.sugar.Debugw(msg, args...)
+	if l.enabled(zapcore.DebugLevel) {
+		l.sugar.Debugw(msg, args...)
+	}
 }
 
 func (l *zapLogger) Info(msg string, args ...interface{}) {
-	l.sugar.Infow(msg, args...)
+	if l.enabled(zapcore.InfoLevel) {
+		l.sugar.Infow(msg, args...)
+	}
 }
 
 func (l *zapLogger) Warn(msg string, args ...interface{}) {
-	l.sugar.Warnw(msg, args...)
+	if l.enabled(zapcore.WarnLevel) {
+		l.sugar.Warnw(msg, args...)
+	}
 }
 
 func (l *zapLogger) Error(msg string, args ...interface{}) {
-	l.sugar.Errorw(msg, args...)
+	if l.enabled(zapcore.ErrorLevel) {
+		l.sugar.Errorw(msg, args...)
+	}
 }
 
+// Fatal is always written, it exits the process
 func (l *zapLogger) Fatal(msg string, args ...interface{}) {
 	l.sugar.Fatalw(msg, args...)
 }
 
 func (l *zapLogger) With(fields ...interface{}) Logger {
+	component := l.component
+	if name, ok := componentField(fields); ok {
+		component = name
+	}
+
 	return &zapLogger{
-		sugar: l.sugar.With(fields...),
-		base:  l.base,
+		sugar:     l.sugar.With(fields...),
+		base:      l.base,
+		levels:    l.levels,
+		component: component,
+	}
+}
+
+// componentField finds the value of the "component" field among key value pairs and zap fields
+func componentField(fields []interface{}) (string, bool) {
+	for i := 0; i < len(fields); i++ {
+		switch field := fields[i].(type) {
+		case zap.Field:
+			if field.Key == "component" && field.Type == zapcore.StringType {
+				return field.String, true
+			}
+		case string:
+			if i+1 < len(fields) {
+				if value, ok := fields[i+1].(string); ok && field == "component" {
+					return value, true
+				}
+			}
+			i++
+		}
 	}
+	return "", false
 }
 
 func (l *zapLogger) Sync() error {
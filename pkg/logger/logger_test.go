@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newObservedLogger(level zapcore.Level) (Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	return newZapLogger(zap.New(core), zap.NewAtomicLevelAt(level)), logs
+}
+
+func messages(logs *observer.ObservedLogs) []string {
+	var out []string
+	for _, entry := range logs.TakeAll() {
+		out = append(out, entry.Message)
+	}
+	return out
+}
+
+func TestLogger_FiltersByGlobalLevel(t *testing.T) {
+	log, logs := newObservedLogger(zapcore.InfoLevel)
+
+	log.Debug("debug")
+	log.Info("info")
+	require.NoError(t, SetLevel(log, "error"))
+	log.Warn("warn")
+	log.Error("error")
+
+	assert.Equal(t, []string{"info", "error"}, messages(logs))
+}
+
+func TestLogger_ComponentLevels(t *testing.T) {
+	log, logs := newObservedLogger(zapcore.InfoLevel)
+	repository := log.With("component", "gorm")
+	handler := log.With("component", "order_handler", "request_id", "abc")
+
+	require.NoError(t, SetComponentLevels(log, map[string]string{"gorm": "warn", "order_handler": "debug"}))
+
+	repository.Info("repository info")
+	repository.Warn("repository warn")
+	handler.Debug("handler debug")
+	handler.With("order_id", 1).Debug("derived handler debug")
+	log.Debug("root debug")
+
+	assert.Equal(t, []string{"repository warn", "handler debug", "derived handler debug"}, messages(logs))
+
+	// Replacing the overrides puts unlisted components back on the global level
+	require.NoError(t, SetComponentLevels(log, nil))
+	handler.Debug("handler debug")
+	repository.Info("repository info")
+	assert.Equal(t, []string{"repository info"}, messages(logs))
+}
+
+func TestLogger_ComponentFromZapField(t *testing.T) {
+	log, logs := newObservedLogger(zapcore.InfoLevel)
+	require.NoError(t, SetComponentLevels(log, map[string]string{"worker": "debug"}))
+
+	log.With(zap.String("component", "worker"), "job", "expiration").Debug("worker debug")
+
+	assert.Equal(t, []string{"worker debug"}, messages(logs))
+}
+
+func TestSetLevel_RejectsUnknownLevels(t *testing.T) {
+	log, _ := newObservedLogger(zapcore.InfoLevel)
+
+	assert.Error(t, SetLevel(log, "verbose"))
+	assert.Error(t, SetComponentLevels(log, map[string]string{"gorm": "loud"}))
+}
+
+func TestFromContext(t *testing.T) {
+	root, logs := newObservedLogger(zapcore.InfoLevel)
+	fallback := root.With("component", "order_usecases")
+	require.NoError(t, SetComponentLevels(root, map[string]string{"order_usecases": "warn"}))
+
+	ctx := WithContext(context.Background(), root.With("request_id", "req-1"))
+	FromContext(ctx, fallback).Info("filtered by the component level")
+	FromContext(ctx, fallback).Warn("from context")
+	FromContext(context.Background(), fallback).Warn("fallback")
+
+	entries := logs.TakeAll()
+	require.Len(t, entries, 2)
+	assert.Equal(t, map[string]interface{}{"request_id": "req-1", "component": "order_usecases"}, entries[0].ContextMap())
+	assert.Equal(t, map[string]interface{}{"component": "order_usecases"}, entries[1].ContextMap())
+}
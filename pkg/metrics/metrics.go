@@ -0,0 +1,65 @@
+// pkg/metrics/metrics.go
+package metrics
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Counter is a monotonically increasing value safe for concurrent use
+type Counter struct {
+	value atomic.Int64
+}
+
+// Inc adds one to the counter
+func (c *Counter) Inc() {
+	c.value.Add(1)
+}
+
+// Add adds delta to the counter
+func (c *Counter) Add(delta int64) {
+	c.value.Add(delta)
+}
+
+// Value returns the current count
+func (c *Counter) Value() int64 {
+	return c.value.Load()
+}
+
+// Registry holds named counters
+type Registry struct {
+	mu       sync.Mutex
+	counters map[string]*Counter
+}
+
+// Default is the process wide registry reported by the metrics endpoint
+var Default = NewRegistry()
+
+func NewRegistry() *Registry {
+	return &Registry{counters: make(map[string]*Counter)}
+}
+
+// Counter returns the counter registered under name, creating it on first use
+func (r *Registry) Counter(name string) *Counter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	counter, ok := r.counters[name]
+	if !ok {
+		counter = &Counter{}
+		r.counters[name] = counter
+	}
+	return counter
+}
+
+// Snapshot returns the current value of every counter
+func (r *Registry) Snapshot() map[string]int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snapshot := make(map[string]int64, len(r.counters))
+	for name, counter := range r.counters {
+		snapshot[name] = counter.Value()
+	}
+	return snapshot
+}